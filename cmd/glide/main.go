@@ -5,16 +5,29 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/glide-cli/glide/v3/internal/chaos"
 	cliPkg "github.com/glide-cli/glide/v3/internal/cli"
 	"github.com/glide-cli/glide/v3/internal/config"
 	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/policy"
+	"github.com/glide-cli/glide/v3/internal/runreport"
+	"github.com/glide-cli/glide/v3/internal/selftest"
+	"github.com/glide-cli/glide/v3/internal/timings"
+	"github.com/glide-cli/glide/v3/pkg/audit"
 	"github.com/glide-cli/glide/v3/pkg/branding"
 	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/featureflags"
+	"github.com/glide-cli/glide/v3/pkg/invocation"
 	"github.com/glide-cli/glide/v3/pkg/logging"
 	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/performance"
 	"github.com/glide-cli/glide/v3/pkg/plugin"
+	"github.com/glide-cli/glide/v3/pkg/progress"
+	"github.com/glide-cli/glide/v3/pkg/prompt"
 	"github.com/glide-cli/glide/v3/pkg/update"
 	"github.com/glide-cli/glide/v3/pkg/version"
 	"github.com/spf13/cobra"
@@ -26,9 +39,24 @@ var (
 	debugMode bool
 
 	// Global output flags
-	outputFormat string
-	quietMode    bool
-	noColor      bool
+	outputFormat  string
+	quietMode     bool
+	noColor       bool
+	noTrunc       bool
+	accessible    bool
+	dryRun        bool
+	strictConfig  bool
+	noConfigFlags bool
+	exitCodeSpec  string
+	reportFile    string
+
+	// exitCodes overrides the exit codes of error types
+	exitCodes glideErrors.ExitCodeMap
+
+	// runReport records the run for --report-file, and reportCommand is the
+	// path of the command that ran
+	runReport     *runreport.Recorder
+	reportCommand string
 
 	// Update notification
 	updateNotificationManager *update.NotificationManager
@@ -36,27 +64,85 @@ var (
 )
 
 func main() {
-	if err := Execute(); err != nil {
+	// glide starts itself as a trivial plugin to test the plugin protocol
+	if len(os.Args) == 2 && os.Args[1] == selftest.PluginArg {
+		os.Exit(selftest.ServePlugin())
+	}
+
+	err := Execute()
+	exitCode := 0
+	if err != nil {
 		// Use the new error handler for consistent error display
-		os.Exit(glideErrors.Print(err))
+		handler := glideErrors.DefaultHandler()
+		handler.Debug = debugMode || os.Getenv("GLIDE_DEBUG") != ""
+		handler.ExitCodes = exitCodes
+		exitCode = handler.Handle(err)
+	}
+
+	writeRunReport(exitCode, err)
+
+	if err != nil {
+		os.Exit(exitCode)
 	}
 }
 
 func Execute() error {
 	// Initialize logging from environment variables
 	logging.SetDefault(logging.New(logging.FromEnv()))
+	// Print log lines above active spinners and bars instead of through them
+	logging.SetInterceptor(progress.Interrupt)
+
+	// Tag everything this run logs or starts with its invocation ID
+	inv := invocation.Start("")
 
-	logging.Debug("Starting glide", "version", version.GetVersionString())
+	// Time each phase of the run for --report-file
+	runReport = runreport.NewRecorder(inv.ID, os.Args[1:])
+
+	logging.Debug("Starting glide", "version", version.GetVersionString(), "parent", inv.Parent)
 
 	// Version information is set via ldflags at build time directly in the version package
 
+	// Prompts may be answered by a script or an external UI
+	backend, err := prompt.BackendFromEnv()
+	if err != nil {
+		return glideErrors.Wrap(err, "invalid prompt backend",
+			glideErrors.WithSuggestions(fmt.Sprintf("Check %s and %s", prompt.AnswersEnv, prompt.CommandEnv)))
+	}
+	if backend != nil {
+		prompt.SetBackend(backend)
+	}
+
+	// Failures injected by the end-to-end tests
+	if err := chaos.Apply(); err != nil {
+		return glideErrors.NewConfigError(err.Error(),
+			glideErrors.WithSuggestions(fmt.Sprintf("Unset %s unless you are testing glide's error handling", chaos.Env)))
+	}
+
+	// Warn when instrumented operations exceed their performance budgets
+	performance.SetWarnings(os.Getenv("GLIDE_PERF_WARN") != "")
+	cliPkg.ApplyBudgetOverrides()
+
 	// Load configuration
-	cfg, err := config.Load()
+	var cfg *config.Config
+	err = runReport.Track("config_load", func() (err error) {
+		cfg, err = config.Load()
+		return err
+	})
 	if err != nil && !os.IsNotExist(err) {
 		logging.Error("Failed to load configuration", "error", err)
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// Exit codes configured for wrapper scripts
+	if exitCodes, err = configuredExitCodes(cfg); err != nil {
+		return err
+	}
+
+	// Experimental features users turned on
+	if cfg != nil {
+		featureflags.Configure(cfg.Features)
+	}
+
 	// Start background update check if enabled
 	startUpdateCheck(cfg)
 
@@ -69,7 +155,29 @@ func Execute() error {
 	}
 
 	// Detect project context with plugin extensions
-	ctx := context.DetectWithExtensions(extensionProviders)
+	var ctx *context.ProjectContext
+	_ = runReport.Track("context_detection", func() error {
+		ctx = context.DetectWithExtensions(extensionProviders)
+		return nil
+	})
+
+	if ctx != nil {
+		inv.SetProject(ctx.ProjectRoot)
+		runReport.SetProject(ctx.ProjectRoot)
+	}
+
+	// Let the error handler suggest next steps based on project state
+	cliPkg.RegisterContextSuggestions(ctx)
+
+	// Name and label the compose resources of this worktree, and point it at
+	// the shared services (before plugins start, so they inherit
+	// COMPOSE_PROJECT_NAME and COMPOSE_FILE). Commands that never run
+	// compose skip the generated overrides.
+	cliPkg.ApplyNamespace(ctx)
+	if cliPkg.MayRunCompose(os.Args[1:]) {
+		cliPkg.ApplyOwnershipLabels(ctx)
+		cliPkg.ApplySharedServices(ctx)
+	}
 
 	// Create output manager directly
 	outputManager := output.NewManager(
@@ -92,6 +200,9 @@ func Execute() error {
 		SilenceUsage:          true, // Don't show usage on error
 		DisableAutoGenTag:     true, // Disable "Auto generated by spf13/cobra" in docs
 		DisableFlagsInUseLine: false,
+		// Parse global flags given before the subcommand name so that
+		// pass-through YAML commands still see --dry-run and friends
+		TraverseChildren: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Handle debug mode
 			if debugMode || os.Getenv("GLIDE_DEBUG") != "" {
@@ -99,23 +210,70 @@ func Execute() error {
 				logging.Debug("Debug mode enabled")
 			}
 
+			// Fill in the flag defaults teams configure per command
+			if !noConfigFlags {
+				if err := cliPkg.ApplyConfigFlags(cmd, cfg); err != nil {
+					return err
+				}
+			}
+
 			// Parse output format
 			format, err := output.ParseFormat(outputFormat)
 			if err != nil {
 				return fmt.Errorf("invalid output format: %w", err)
 			}
 
+			// Piped stdout gets plain, unanimated output unless a format was
+			// asked for; messages already go to stderr
+			if outputManager.IsPiped() {
+				if !cmd.Flags().Changed("format") {
+					format = output.FormatPlain
+				}
+				progress.SetAnimated(false)
+			}
+
 			// Check environment variables if flags not set
 			if !cmd.Flags().Changed("no-color") && os.Getenv("NO_COLOR") != "" {
 				noColor = true
 			}
+			if !cmd.Flags().Changed("no-color") && cfg != nil && cfg.Defaults.Colors.Enabled == "never" {
+				noColor = true
+			}
+
+			// Screen readers get labels instead of icons and periodic text
+			// instead of animation
+			if !cmd.Flags().Changed("accessible") {
+				if on, err := strconv.ParseBool(os.Getenv(output.AccessibleEnv)); err == nil {
+					accessible = on
+				} else {
+					accessible = cfg != nil && cfg.Defaults.Accessible
+				}
+			}
+			output.SetAccessible(accessible)
+			progress.SetAccessible(accessible)
 
 			// Update the output manager with the command-line flags
 			outputManager.SetFormat(format)
 			outputManager.SetQuiet(quietMode)
 			outputManager.SetNoColor(noColor)
+			outputManager.SetNoTrunc(noTrunc)
+
+			if exitCodeSpec != "" {
+				flagCodes, err := glideErrors.ParseExitCodeMap(exitCodeSpec)
+				if err != nil {
+					return err
+				}
+				exitCodes = exitCodes.Merge(flagCodes)
+			}
 
-			return nil
+			// Ask the first-run questions before the first command run in a
+			// terminal
+			if err := cliPkg.MaybeOnboard(cmd, cfg); err != nil {
+				return err
+			}
+
+			// Reject unknown config keys when asked to
+			return cliPkg.CheckStrictConfig(strictConfig, cfg)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
@@ -130,9 +288,22 @@ func Execute() error {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", fmt.Sprintf("config file (default is $HOME/%s)", branding.ConfigFileName))
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Enable debug logging (equivalent to GLIDE_LOG_LEVEL=debug)")
-	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "table", "Output format (table, json, yaml, plain)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "table", fmt.Sprintf("Output format (%s)", strings.Join(output.FormatNames(), ", ")))
 	rootCmd.PersistentFlags().BoolVarP(&quietMode, "quiet", "q", false, "Suppress non-error output")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	rootCmd.PersistentFlags().BoolVar(&accessible, "accessible", false, "Screen-reader-friendly output: text labels instead of icons, no emoji, box drawing, or spinners")
+	rootCmd.PersistentFlags().BoolVar(&noTrunc, "no-trunc", false, "Print table cells in full instead of truncating them to the terminal width")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Show what a command would run without executing it")
+	rootCmd.PersistentFlags().BoolVar(&strictConfig, "strict-config", false, "Fail on unknown configuration keys instead of ignoring them")
+	rootCmd.PersistentFlags().BoolVar(&noConfigFlags, "no-config-flags", false, "Ignore the flag defaults set under flags: in configuration")
+	rootCmd.PersistentFlags().Bool("notify", false, "Send a notification when the command finishes, however long it ran")
+	rootCmd.PersistentFlags().String("wait", "", "Queue behind another glide process holding a lock the command needs (optionally at most a duration, e.g. --wait=10m)")
+	rootCmd.PersistentFlags().Lookup("wait").NoOptDefVal = "true"
+	rootCmd.PersistentFlags().StringVar(&exitCodeSpec, "exit-code-map", "", "Exit with custom codes for error types, e.g. docker=2,validation=3")
+	rootCmd.PersistentFlags().StringVar(&reportFile, "report-file", "", "Write a JSON report of the run (phases, warnings, metrics, exit code) to this file, e.g. for CI artifacts")
+	rootCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return output.FormatNames(), cobra.ShellCompDirectiveNoFileComp
+	})
 
 	// Initialize CLI with dependencies
 	cli := cliPkg.New(outputManager, ctx, cfg)
@@ -163,8 +334,9 @@ func Execute() error {
 	// and synced to the typed config registry (pkg/config).
 	// Plugins access their typed configs using config.Get[T](pluginName).
 
-	// Set standard context for cancellation/deadline support
-	rootCmd.SetContext(stdcontext.Background())
+	// Set standard context for cancellation/deadline support, carrying the
+	// invocation to every command
+	rootCmd.SetContext(invocation.WithContext(stdcontext.Background(), inv))
 
 	// Load all registered build-time plugins
 	result, err := plugin.LoadAll(rootCmd)
@@ -178,8 +350,25 @@ func Execute() error {
 		fmt.Fprintf(os.Stderr, "%s\n", result.ErrorMessage())
 	}
 
+	// Keep every plugin command run for `glide plugins stats`
+	cliPkg.RecordPluginStats()
+
 	// Load runtime plugins
-	runtimeResult, err := plugin.LoadAllRuntimePlugins(rootCmd)
+	var runtimeResult *plugin.PluginLoadResult
+	pluginLoadStart := time.Now()
+	projectRoot := ""
+	if ctx != nil {
+		projectRoot = ctx.ProjectRoot
+	}
+	// The project may restrict which installed plugins activate in it
+	projectPlugins := cliPkg.ProjectPlugins()
+	err = runReport.Track("plugin_discovery", func() (err error) {
+		runtimeResult, err = plugin.LoadAllRuntimePlugins(rootCmd, projectRoot, projectPlugins.Enabled, projectPlugins.Disabled)
+		return err
+	})
+	if err == nil {
+		cliPkg.RecordTiming(ctx, timings.OperationPluginLoad, time.Since(pluginLoadStart))
+	}
 	if err != nil {
 		// Fatal error during runtime plugin loading
 		return fmt.Errorf("failed to load runtime plugins: %w", err)
@@ -190,6 +379,21 @@ func Execute() error {
 		fmt.Fprintf(os.Stderr, "%s\n", runtimeResult.ErrorMessage())
 	}
 
+	// Load the project policy now that every command is registered
+	var projectPolicy *policy.Policy
+	if ctx != nil {
+		projectPolicy, err = policy.Load(ctx.ProjectRoot)
+		if err != nil {
+			return glideErrors.New(glideErrors.TypeConfig, err.Error(),
+				glideErrors.WithExitCode(78),
+				glideErrors.WithSuggestions("Fix the syntax of the project policy file, or ask the repository's platform team"))
+		}
+	}
+
+	// Run every builtin, YAML, and plugin command through the policy,
+	// audit, locking, timing, and telemetry middleware
+	cliPkg.DefaultChain(ctx, audit.NewLogger(audit.DefaultPath()), projectPolicy).Apply(rootCmd)
+
 	// Register completions for all commands
 	cli.RegisterCompletions(rootCmd)
 
@@ -197,7 +401,18 @@ func Execute() error {
 	rootCmd.SuggestionsMinimumDistance = 1
 
 	// Execute root command
-	cmdErr := rootCmd.Execute()
+	started := time.Now()
+	executedCmd, cmdErr := rootCmd.ExecuteC()
+	runReport.AddPhase("command", time.Since(started))
+	if executedCmd != nil {
+		reportCommand = executedCmd.CommandPath()
+	}
+
+	// Tell the user a long-running command finished
+	cliPkg.NotifyCompletion(cfg, ctx, executedCmd, started, cmdErr)
+
+	// Record project activity and apply the cleanup policy when it is due
+	cliPkg.RunOpportunisticCleanup(cfg, ctx, executedCmd)
 
 	// Show update notification after command completes (if not in quiet mode)
 	if !quietMode {
@@ -207,6 +422,38 @@ func Execute() error {
 	return cmdErr
 }
 
+// writeRunReport writes the report of the run to the file named by
+// --report-file or GLIDE_REPORT_FILE, if any
+func writeRunReport(exitCode int, err error) {
+	path := reportFile
+	if path == "" {
+		path = os.Getenv(runreport.FileEnv)
+	}
+	if path == "" || runReport == nil {
+		return
+	}
+
+	runReport.Finish(reportCommand, exitCode, err, output.Warnings())
+	if err := runReport.Write(path); err != nil {
+		// Safe to ignore: The run report is best effort and must not change the exit code
+		_ = output.Warning("Could not write run report to %s: %v", path, err)
+	}
+}
+
+// configuredExitCodes returns the exit codes mapped in the configuration
+func configuredExitCodes(cfg *config.Config) (glideErrors.ExitCodeMap, error) {
+	codes := glideErrors.ExitCodeMap{}
+	if cfg == nil {
+		return codes, nil
+	}
+	for name, code := range cfg.ExitCodes {
+		if err := codes.Set(name, code); err != nil {
+			return nil, glideErrors.WrapWithOp(err, "reading exit_codes configuration")
+		}
+	}
+	return codes, nil
+}
+
 // startUpdateCheck initializes the update notification manager and starts background check
 func startUpdateCheck(cfg *config.Config) {
 	// Check if updates are disabled via config
@@ -232,6 +479,9 @@ func startUpdateCheck(cfg *config.Config) {
 		Enabled:       true,
 		CheckInterval: checkInterval,
 	}
+	if cfg != nil {
+		notifyConfig.Channel = cfg.Defaults.Update.Channel
+	}
 
 	// Initialize notification manager
 	updateNotificationManager = update.NewNotificationManager(version.Get(), notifyConfig)
@@ -0,0 +1,248 @@
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FormatVersion is the version of the bundle layout this package writes
+const FormatVersion = 1
+
+// DefaultFileName is where export-setup writes a bundle unless told otherwise
+const DefaultFileName = "glide-setup.yml"
+
+// machineKeys are global configuration keys that only make sense on the
+// machine they were written on, such as absolute project paths
+var machineKeys = []string{"projects", "default_project", "root"}
+
+// secretKeys are nested global configuration keys that hold credentials,
+// which a bundle meant for teammates must not carry
+var secretKeys = [][]string{{"notifications", "webhook", "url"}}
+
+// Actions taken on a configuration key during import
+const (
+	ActionAdd     = "add"
+	ActionReplace = "replace"
+	ActionKeep    = "keep"
+)
+
+// Bundle is a portable snapshot of a working environment
+type Bundle struct {
+	Version      int       `yaml:"version" json:"version"`
+	CreatedAt    time.Time `yaml:"created_at" json:"created_at"`
+	GlideVersion string    `yaml:"glide_version,omitempty" json:"glide_version,omitempty"`
+	// Config is the portable part of the global configuration
+	Config map[string]any `yaml:"config,omitempty" json:"config,omitempty"`
+	// Plugins pins the installed plugins
+	Plugins []Plugin `yaml:"plugins,omitempty" json:"plugins,omitempty"`
+	// Trusted lists the directories whose commands may run, relative to the
+	// home directory ("~/src/acme") where possible
+	Trusted []string `yaml:"trusted,omitempty" json:"trusted,omitempty"`
+}
+
+// Plugin is a plugin lockfile entry
+type Plugin struct {
+	// Name is the plugin's file name in the plugin directory
+	Name    string `yaml:"name" json:"name"`
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
+	// Source is the GitHub repository releases are installed from, e.g.
+	// github.com/glide-cli/glide-plugin-go
+	Source string `yaml:"source,omitempty" json:"source,omitempty"`
+	// SHA256 is the checksum of the binary built for Platform
+	SHA256   string `yaml:"sha256,omitempty" json:"sha256,omitempty"`
+	Platform string `yaml:"platform,omitempty" json:"platform,omitempty"`
+}
+
+// ConfigChange is what importing does to one configuration key
+type ConfigChange struct {
+	Key    string `yaml:"key" json:"key"`
+	Action string `yaml:"action" json:"action"`
+}
+
+// Load reads a bundle from a file, or from stdin when path is "-"
+func Load(path string) (*Bundle, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes a bundle, rejecting layouts newer than this build knows
+func Parse(data []byte) (*Bundle, error) {
+	var b Bundle
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %w", err)
+	}
+	if b.Version == 0 {
+		return nil, fmt.Errorf("not a setup bundle: no version")
+	}
+	if b.Version > FormatVersion {
+		return nil, fmt.Errorf("bundle version %d is newer than this build supports (%d)", b.Version, FormatVersion)
+	}
+	// Bundles written before a key was known to be machine-specific or
+	// secret may still carry it
+	b.Config = ConfigSubset(b.Config)
+	return &b, nil
+}
+
+// Marshal encodes the bundle as YAML
+func (b *Bundle) Marshal() ([]byte, error) {
+	return yaml.Marshal(b)
+}
+
+// ConfigSubset returns the global configuration without its
+// machine-specific keys, its secrets, and the jobs that run in a directory
+// of this machine. raw is left alone.
+func ConfigSubset(raw map[string]any) map[string]any {
+	subset := make(map[string]any, len(raw))
+	for key, value := range raw {
+		subset[key] = value
+	}
+	for _, key := range machineKeys {
+		delete(subset, key)
+	}
+	for _, path := range secretKeys {
+		deletePath(subset, path)
+	}
+	if jobs, ok := subset["jobs"].(map[string]any); ok {
+		portable := make(map[string]any, len(jobs))
+		for name, job := range jobs {
+			if settings, ok := job.(map[string]any); ok && settings["dir"] != nil && settings["dir"] != "" {
+				continue
+			}
+			portable[name] = job
+		}
+		if len(portable) == 0 {
+			delete(subset, "jobs")
+		} else {
+			subset["jobs"] = portable
+		}
+	}
+	if len(subset) == 0 {
+		return nil
+	}
+	return subset
+}
+
+// deletePath removes the key at path from the nested maps of m, copying
+// the maps it changes and dropping those it leaves empty
+func deletePath(m map[string]any, path []string) {
+	if len(path) == 1 {
+		delete(m, path[0])
+		return
+	}
+	child, ok := m[path[0]].(map[string]any)
+	if !ok {
+		return
+	}
+	copied := make(map[string]any, len(child))
+	for key, value := range child {
+		copied[key] = value
+	}
+	deletePath(copied, path[1:])
+	if len(copied) == 0 {
+		delete(m, path[0])
+	} else {
+		m[path[0]] = copied
+	}
+}
+
+// MergeConfig applies the bundle's top-level keys to an existing
+// configuration. Keys the configuration already sets are kept unless
+// overwrite is given, or identical. The changes are returned sorted by key.
+func MergeConfig(existing, incoming map[string]any, overwrite bool) (map[string]any, []ConfigChange) {
+	merged := make(map[string]any, len(existing)+len(incoming))
+	for key, value := range existing {
+		merged[key] = value
+	}
+
+	keys := make([]string, 0, len(incoming))
+	for key := range incoming {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var changes []ConfigChange
+	for _, key := range keys {
+		current, exists := existing[key]
+		switch {
+		case !exists:
+			merged[key] = incoming[key]
+			changes = append(changes, ConfigChange{Key: key, Action: ActionAdd})
+		case equalYAML(current, incoming[key]):
+			// Already set up this way
+		case overwrite:
+			merged[key] = incoming[key]
+			changes = append(changes, ConfigChange{Key: key, Action: ActionReplace})
+		default:
+			changes = append(changes, ConfigChange{Key: key, Action: ActionKeep})
+		}
+	}
+	return merged, changes
+}
+
+// equalYAML reports whether two decoded values encode to the same YAML
+func equalYAML(a, b any) bool {
+	ea, errA := yaml.Marshal(a)
+	eb, errB := yaml.Marshal(b)
+	return errA == nil && errB == nil && string(ea) == string(eb)
+}
+
+// PortablePath writes a directory under home as "~/..." so it resolves on
+// another machine; other directories are kept as they are
+func PortablePath(dir, home string) string {
+	if home == "" {
+		return dir
+	}
+	rel, err := filepath.Rel(home, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return dir
+	}
+	if rel == "." {
+		return "~"
+	}
+	return "~/" + filepath.ToSlash(rel)
+}
+
+// LocalPath resolves a path written by PortablePath against home
+func LocalPath(portable, home string) string {
+	switch {
+	case portable == "~":
+		return home
+	case strings.HasPrefix(portable, "~/"):
+		return filepath.Join(home, filepath.FromSlash(portable[2:]))
+	default:
+		return portable
+	}
+}
+
+// HashFile returns the SHA256 of a file
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,140 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	b, err := Parse([]byte("version: 1\nplugins:\n  - name: glide-plugin-go\n    version: v1.4.0\ntrusted:\n  - ~/src/acme\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "glide-plugin-go", b.Plugins[0].Name)
+	assert.Equal(t, []string{"~/src/acme"}, b.Trusted)
+
+	_, err = Parse([]byte("defaults: {}\n"))
+	assert.ErrorContains(t, err, "no version")
+
+	_, err = Parse([]byte("version: 99\n"))
+	assert.ErrorContains(t, err, "newer")
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	b := &Bundle{
+		Version: FormatVersion,
+		Config:  map[string]any{"defaults": map[string]any{"test": map[string]any{"processes": 4}}},
+		Plugins: []Plugin{{Name: "glide-plugin-go", Version: "v1.4.0", Source: "github.com/glide-cli/glide-plugin-go"}},
+	}
+	data, err := b.Marshal()
+	require.NoError(t, err)
+
+	parsed, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, b.Plugins, parsed.Plugins)
+	assert.True(t, equalYAML(b.Config, parsed.Config))
+}
+
+func TestConfigSubset(t *testing.T) {
+	raw := map[string]any{
+		"projects":        map[string]any{"acme": map[string]any{"path": "/Users/me/acme"}},
+		"default_project": "acme",
+		"defaults":        map[string]any{"docker": map[string]any{"auto_start": true}},
+	}
+
+	subset := ConfigSubset(raw)
+	assert.Equal(t, map[string]any{"defaults": raw["defaults"]}, subset)
+	// The original is left alone
+	assert.Contains(t, raw, "projects")
+
+	assert.Nil(t, ConfigSubset(map[string]any{"projects": map[string]any{}}))
+}
+
+func TestConfigSubset_LeavesOutSecretsAndMachineJobs(t *testing.T) {
+	raw := map[string]any{
+		"notifications": map[string]any{
+			"channels": []any{"webhook"},
+			"webhook":  map[string]any{"url": "https://hooks.slack.com/services/T0/B0/xyz", "format": "slack"},
+		},
+		"jobs": map[string]any{
+			"prune":    map[string]any{"schedule": "@daily", "run": "cache prune"},
+			"prefetch": map[string]any{"schedule": "@hourly", "run": "prefetch", "dir": "/Users/me/acme"},
+		},
+	}
+
+	subset := ConfigSubset(raw)
+	assert.Equal(t, map[string]any{
+		"notifications": map[string]any{
+			"channels": []any{"webhook"},
+			"webhook":  map[string]any{"format": "slack"},
+		},
+		"jobs": map[string]any{
+			"prune": map[string]any{"schedule": "@daily", "run": "cache prune"},
+		},
+	}, subset)
+
+	// The original is left alone
+	webhook := raw["notifications"].(map[string]any)["webhook"].(map[string]any)
+	assert.Contains(t, webhook, "url")
+	assert.Contains(t, raw["jobs"], "prefetch")
+
+	// Nor does importing take them from an older bundle
+	b, err := Parse([]byte(`version: 1
+config:
+  notifications:
+    webhook:
+      url: https://hooks.slack.com/services/T0/B0/xyz
+  jobs:
+    prefetch: {schedule: "@hourly", run: prefetch, dir: /Users/me/acme}
+`))
+	require.NoError(t, err)
+	assert.Nil(t, b.Config)
+}
+
+func TestMergeConfig(t *testing.T) {
+	existing := map[string]any{
+		"defaults": map[string]any{"test": map[string]any{"processes": 2}},
+		"cleanup":  map[string]any{"interval": "24h"},
+	}
+	incoming := map[string]any{
+		"defaults":   map[string]any{"test": map[string]any{"processes": 8}},
+		"cleanup":    map[string]any{"interval": "24h"},
+		"git_policy": map[string]any{"branch_pattern": "^feat/"},
+	}
+
+	merged, changes := MergeConfig(existing, incoming, false)
+	assert.Equal(t, []ConfigChange{
+		{Key: "defaults", Action: ActionKeep},
+		{Key: "git_policy", Action: ActionAdd},
+	}, changes)
+	assert.Equal(t, existing["defaults"], merged["defaults"])
+	assert.Equal(t, incoming["git_policy"], merged["git_policy"])
+
+	merged, changes = MergeConfig(existing, incoming, true)
+	assert.Contains(t, changes, ConfigChange{Key: "defaults", Action: ActionReplace})
+	assert.Equal(t, incoming["defaults"], merged["defaults"])
+}
+
+func TestPortablePath(t *testing.T) {
+	home := filepath.Join(string(filepath.Separator), "home", "me")
+
+	assert.Equal(t, "~/src/acme", PortablePath(filepath.Join(home, "src", "acme"), home))
+	assert.Equal(t, "~", PortablePath(home, home))
+	assert.Equal(t, "/opt/acme", PortablePath("/opt/acme", home))
+	assert.Equal(t, "/home/meadow", PortablePath("/home/meadow", home))
+
+	assert.Equal(t, filepath.Join(home, "src", "acme"), LocalPath("~/src/acme", home))
+	assert.Equal(t, home, LocalPath("~", home))
+	assert.Equal(t, "/opt/acme", LocalPath("/opt/acme", home))
+}
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plugin")
+	require.NoError(t, os.WriteFile(path, []byte("abc"), 0755))
+
+	sum, err := HashFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", sum)
+}
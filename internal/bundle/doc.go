@@ -0,0 +1,29 @@
+// Package bundle describes setup bundles: portable snapshots of a
+// working glide environment that new team members import in one step.
+//
+// A bundle holds the portable part of the global configuration (project
+// paths and other machine-specific keys are left out), a lockfile pinning
+// the installed plugins, and the directories the user trusts to run their
+// .glide.yml commands, written relative to the home directory:
+//
+//	version: 1
+//	created_at: 2026-10-15T09:00:00Z
+//	config:
+//	  defaults:
+//	    docker:
+//	      auto_start: true
+//	plugins:
+//	  - name: glide-plugin-go
+//	    version: v1.4.0
+//	    source: github.com/glide-cli/glide-plugin-go
+//	    sha256: 3f1c...
+//	    platform: darwin/arm64
+//	trusted:
+//	  - ~/src/acme
+//
+// Usage:
+//
+//	b, err := bundle.Load("glide-setup.yml")
+//	merged, changes := bundle.MergeConfig(existing, b.Config, false)
+//	dir := bundle.LocalPath(b.Trusted[0], home)
+package bundle
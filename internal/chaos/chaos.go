@@ -0,0 +1,180 @@
+package chaos
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/logging"
+)
+
+// Env names the faults to inject
+const Env = "GLIDE_CHAOS"
+
+// Fault is a failure glide can be made to suffer
+type Fault string
+
+const (
+	// DockerDown makes the Docker daemon unreachable
+	DockerDown Fault = "docker-down"
+	// PluginTimeout makes plugin commands time out, optionally only those
+	// of the plugin given as its value
+	PluginTimeout Fault = "plugin-timeout"
+	// SlowDisk delays config reads by its value, DefaultSlowDiskDelay
+	// without one
+	SlowDisk Fault = "slow-disk"
+	// ConfigCorrupt makes config files read back as invalid YAML
+	ConfigCorrupt Fault = "config-corrupt"
+)
+
+// knownFaults are the faults Parse accepts
+var knownFaults = []Fault{DockerDown, PluginTimeout, SlowDisk, ConfigCorrupt}
+
+// DefaultSlowDiskDelay is how much longer config reads take with slow-disk
+const DefaultSlowDiskDelay = 2 * time.Second
+
+// UnreachableDockerHost is the DOCKER_HOST docker-down sets
+const UnreachableDockerHost = "unix:///nonexistent/glide-chaos/docker.sock"
+
+// corruptConfig is what config files read back as with config-corrupt
+const corruptConfig = "commands: {corrupted: [\n\t- by " + Env + "\n"
+
+// Faults maps the injected faults to their values, "" for none
+type Faults map[Fault]string
+
+// Parse parses a GLIDE_CHAOS value, e.g. "docker-down,slow-disk=500ms"
+func Parse(spec string) (Faults, error) {
+	faults := Faults{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(item, "=")
+		fault := Fault(strings.TrimSpace(name))
+		if !known(fault) {
+			return nil, fmt.Errorf("unknown fault %q in %s (known faults: %s)", name, Env, faultNames())
+		}
+		value = strings.TrimSpace(value)
+		if fault == SlowDisk && value != "" {
+			if _, err := time.ParseDuration(value); err != nil {
+				return nil, fmt.Errorf("invalid slow-disk delay %q in %s: %w", value, Env, err)
+			}
+		}
+		faults[fault] = value
+	}
+	return faults, nil
+}
+
+func known(fault Fault) bool {
+	for _, f := range knownFaults {
+		if f == fault {
+			return true
+		}
+	}
+	return false
+}
+
+func faultNames() string {
+	names := make([]string, len(knownFaults))
+	for i, f := range knownFaults {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Active reports whether a fault is injected
+func (f Faults) Active(fault Fault) bool {
+	_, ok := f[fault]
+	return ok
+}
+
+var (
+	mu      sync.RWMutex
+	current Faults
+)
+
+// Set injects faults in place of those GLIDE_CHAOS names, for tests; nil
+// injects none
+func Set(faults Faults) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = faults
+}
+
+// Current returns the injected faults
+func Current() Faults {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Active reports whether a fault is injected
+func Active(fault Fault) bool {
+	return Current().Active(fault)
+}
+
+// Apply injects the faults GLIDE_CHAOS names. docker-down takes effect
+// here, for glide and every process it starts.
+func Apply() error {
+	spec := os.Getenv(Env)
+	if spec == "" {
+		return nil
+	}
+	faults, err := Parse(spec)
+	if err != nil {
+		return err
+	}
+	Set(faults)
+
+	if faults.Active(DockerDown) {
+		if err := os.Setenv("DOCKER_HOST", UnreachableDockerHost); err != nil {
+			return err
+		}
+		// A docker context would win over DOCKER_HOST
+		_ = os.Unsetenv("DOCKER_CONTEXT")
+	}
+
+	logging.Warn("Injecting failures for testing", "faults", spec, "variable", Env)
+	return nil
+}
+
+// PluginTimesOut reports whether a plugin's commands are made to time out
+func PluginTimesOut(plugin string) bool {
+	faults := Current()
+	target, ok := faults[PluginTimeout]
+	return ok && (target == "" || target == plugin)
+}
+
+// SlowDiskDelay returns how much longer config reads take, 0 unless
+// slow-disk is injected
+func SlowDiskDelay() time.Duration {
+	value, ok := Current()[SlowDisk]
+	if !ok {
+		return 0
+	}
+	if delay, err := time.ParseDuration(value); err == nil {
+		return delay
+	}
+	return DefaultSlowDiskDelay
+}
+
+// ReadFile reads a file like os.ReadFile, slowly with slow-disk
+func ReadFile(path string) ([]byte, error) {
+	if delay := SlowDiskDelay(); delay > 0 {
+		time.Sleep(delay)
+	}
+	return os.ReadFile(path)
+}
+
+// ReadConfig reads a config file like ReadFile, as invalid YAML with
+// config-corrupt
+func ReadConfig(path string) ([]byte, error) {
+	data, err := ReadFile(path)
+	if err == nil && Active(ConfigCorrupt) {
+		return []byte(corruptConfig), nil
+	}
+	return data, err
+}
@@ -0,0 +1,88 @@
+package chaos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParse(t *testing.T) {
+	faults, err := Parse("docker-down, slow-disk=500ms,plugin-timeout=acme,")
+	require.NoError(t, err)
+	assert.Equal(t, Faults{DockerDown: "", SlowDisk: "500ms", PluginTimeout: "acme"}, faults)
+	assert.True(t, faults.Active(DockerDown))
+	assert.False(t, faults.Active(ConfigCorrupt))
+
+	_, err = Parse("docker-dwon")
+	assert.ErrorContains(t, err, `unknown fault "docker-dwon"`)
+
+	_, err = Parse("slow-disk=fast")
+	assert.ErrorContains(t, err, "invalid slow-disk delay")
+}
+
+func TestApply(t *testing.T) {
+	t.Cleanup(func() { Set(nil) })
+
+	t.Setenv(Env, "")
+	require.NoError(t, Apply())
+	assert.False(t, Active(DockerDown))
+
+	t.Setenv("DOCKER_HOST", "unix:///var/run/docker.sock")
+	t.Setenv("DOCKER_CONTEXT", "desktop-linux")
+	t.Setenv(Env, "docker-down")
+	require.NoError(t, Apply())
+	assert.True(t, Active(DockerDown))
+	assert.Equal(t, UnreachableDockerHost, os.Getenv("DOCKER_HOST"))
+	_, set := os.LookupEnv("DOCKER_CONTEXT")
+	assert.False(t, set)
+
+	t.Setenv(Env, "disk-full")
+	assert.Error(t, Apply())
+}
+
+func TestPluginTimesOut(t *testing.T) {
+	t.Cleanup(func() { Set(nil) })
+
+	assert.False(t, PluginTimesOut("acme"))
+
+	Set(Faults{PluginTimeout: ""})
+	assert.True(t, PluginTimesOut("acme"))
+
+	Set(Faults{PluginTimeout: "docker"})
+	assert.True(t, PluginTimesOut("docker"))
+	assert.False(t, PluginTimesOut("acme"))
+}
+
+func TestReadConfig(t *testing.T) {
+	t.Cleanup(func() { Set(nil) })
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("commands:\n  up: docker compose up\n"), 0o644))
+
+	data, err := ReadConfig(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "docker compose up")
+
+	Set(Faults{ConfigCorrupt: ""})
+	data, err = ReadConfig(path)
+	require.NoError(t, err)
+	var parsed map[string]interface{}
+	assert.Error(t, yaml.Unmarshal(data, &parsed), "corrupted configs do not parse")
+
+	_, err = ReadConfig(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.True(t, os.IsNotExist(err), "missing files stay missing")
+
+	Set(Faults{SlowDisk: "30ms"})
+	start := time.Now()
+	_, err = ReadFile(path)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+
+	Set(Faults{SlowDisk: ""})
+	assert.Equal(t, DefaultSlowDiskDelay, SlowDiskDelay())
+}
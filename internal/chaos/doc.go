@@ -0,0 +1,25 @@
+// Package chaos injects failures into glide so end-to-end tests can
+// exercise its error handling deterministically. It is off unless the
+// GLIDE_CHAOS variable names faults, comma-separated:
+//
+//	GLIDE_CHAOS=docker-down                 # the Docker daemon is unreachable
+//	GLIDE_CHAOS=plugin-timeout              # plugin commands time out
+//	GLIDE_CHAOS=plugin-timeout=acme         # only the acme plugin's commands do
+//	GLIDE_CHAOS=slow-disk=500ms             # config reads take 500ms longer (2s by default)
+//	GLIDE_CHAOS=config-corrupt              # config files read back as invalid YAML
+//	GLIDE_CHAOS=docker-down,slow-disk       # several at once
+//
+// docker-down points DOCKER_HOST at a socket that does not exist, for glide
+// and every process it starts, so docker fails the way it does when the
+// daemon is stopped. The other faults are injected where glide reads its
+// configuration and calls plugins:
+//
+//	data, err := chaos.ReadConfig(path) // slow-disk and config-corrupt
+//	if chaos.PluginTimesOut(plugin) {
+//	    ...
+//	}
+//
+// Apply is called once at startup; it rejects unknown faults and warns
+// that failures are being injected, so the variable is not left set by
+// accident.
+package chaos
@@ -0,0 +1,155 @@
+package cleanup
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestParseAge(t *testing.T) {
+	tests := map[string]time.Duration{
+		"7d":    7 * 24 * time.Hour,
+		"24h":   24 * time.Hour,
+		"1d12h": 36 * time.Hour,
+		"90m":   90 * time.Minute,
+		" 2d ":  48 * time.Hour,
+	}
+	for input, want := range tests {
+		got, err := ParseAge(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "d", "xd", "7 days", "-1h"} {
+		_, err := ParseAge(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestPolicyFromConfig(t *testing.T) {
+	policy, err := PolicyFromConfig(config.CleanupConfig{
+		DanglingImages: "7d",
+		IdleContainers: "24h",
+		Opportunistic:  true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Policy{
+		DanglingImages: 7 * 24 * time.Hour,
+		IdleContainers: 24 * time.Hour,
+		Opportunistic:  true,
+		Interval:       DefaultInterval,
+	}, policy)
+	assert.False(t, policy.Empty())
+
+	empty, err := PolicyFromConfig(config.CleanupConfig{})
+	require.NoError(t, err)
+	assert.True(t, empty.Empty())
+
+	_, err = PolicyFromConfig(config.CleanupConfig{IdleContainers: "a while"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cleanup.idle_containers")
+}
+
+func TestState_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "glide", "cleanup.json")
+
+	state := LoadState(path)
+	assert.True(t, state.Due(testNow, DefaultInterval))
+
+	state.Touch("/src/myapp/", testNow)
+	state.LastRun = testNow
+	require.NoError(t, state.Save())
+
+	loaded := LoadState(path)
+	assert.True(t, loaded.LastActivity("/src/myapp").Equal(testNow))
+	assert.Equal(t, []string{"/src/myapp"}, loaded.Projects())
+	assert.False(t, loaded.Due(testNow.Add(time.Hour), DefaultInterval))
+	assert.True(t, loaded.Due(testNow.Add(DefaultInterval), DefaultInterval))
+}
+
+func newTestEngine(policy Policy, projects []string, state *State) *Engine {
+	engine := NewEngine(policy, projects, state)
+	engine.now = func() time.Time { return testNow }
+	engine.danglingImages = func() ([]docker.Image, error) {
+		return []docker.Image{
+			{ID: "sha256:0123456789abcdef", Size: "1.2GB", Created: testNow.Add(-10 * 24 * time.Hour)},
+			{ID: "sha256:fedcba9876543210", Size: "12MB", Created: testNow.Add(-2 * 24 * time.Hour)},
+		}, nil
+	}
+	engine.containers = func() ([]docker.ProjectContainer, error) {
+		return []docker.ProjectContainer{
+			{ID: "c1", Name: "myapp-php-1", WorkingDir: "/src/myapp", State: "running", StartedAt: testNow.Add(-72 * time.Hour)},
+			{ID: "c2", Name: "myapp-db-1", WorkingDir: "/src/myapp", State: "exited", StartedAt: testNow.Add(-72 * time.Hour)},
+			{ID: "c3", Name: "fresh-php-1", WorkingDir: "/src/fresh", State: "running", StartedAt: testNow.Add(-time.Hour)},
+			{ID: "c4", Name: "foreign-1", WorkingDir: "/elsewhere", State: "running", StartedAt: testNow.Add(-72 * time.Hour)},
+			{ID: "c5", Name: "used-php-1", WorkingDir: "/src/used/worktrees/x", State: "running", StartedAt: testNow.Add(-72 * time.Hour)},
+			{ID: "c6", Name: "labeled-php-1", WorkingDir: "/moved", State: "running", StartedAt: testNow.Add(-48 * time.Hour),
+				Labels: map[string]string{docker.LabelProject: "/src/labeled", docker.LabelWorktree: "main"}},
+		}, nil
+	}
+	return engine
+}
+
+func TestEngine_Plan(t *testing.T) {
+	state := LoadState(filepath.Join(t.TempDir(), "cleanup.json"))
+	state.Touch("/src/used", testNow.Add(-2*time.Hour))
+
+	engine := newTestEngine(Policy{
+		DanglingImages: 7 * 24 * time.Hour,
+		IdleContainers: 24 * time.Hour,
+	}, []string{"/src/myapp", "/src/fresh"}, state)
+
+	actions, err := engine.Plan()
+	require.NoError(t, err)
+	assert.Equal(t, []Action{
+		{Kind: KindRemoveImage, ID: "sha256:0123456789abcdef", Name: "0123456789ab", Reason: "dangling for more than 7d, 1.2GB"},
+		{Kind: KindStopContainer, ID: "c6", Name: "labeled-php-1", Reason: "idle for 2d in /src/labeled"},
+		{Kind: KindStopContainer, ID: "c1", Name: "myapp-php-1", Reason: "idle for 3d in /src/myapp"},
+	}, actions)
+}
+
+func TestEngine_PlanDisabledRules(t *testing.T) {
+	state := LoadState(filepath.Join(t.TempDir(), "cleanup.json"))
+	engine := newTestEngine(Policy{}, []string{"/src/myapp"}, state)
+	engine.danglingImages = func() ([]docker.Image, error) { return nil, errors.New("should not be called") }
+	engine.containers = func() ([]docker.ProjectContainer, error) { return nil, errors.New("should not be called") }
+
+	actions, err := engine.Plan()
+	require.NoError(t, err)
+	assert.Empty(t, actions)
+}
+
+func TestEngine_Apply(t *testing.T) {
+	state := LoadState(filepath.Join(t.TempDir(), "cleanup.json"))
+	engine := newTestEngine(Policy{}, nil, state)
+
+	var removed, stopped []string
+	engine.removeImage = func(id string) error {
+		removed = append(removed, id)
+		return errors.New("image is in use")
+	}
+	engine.stopContainer = func(id string) error {
+		stopped = append(stopped, id)
+		return nil
+	}
+
+	results := engine.Apply([]Action{
+		{Kind: KindRemoveImage, ID: "sha256:aaa"},
+		{Kind: KindStopContainer, ID: "c1"},
+	})
+
+	assert.Equal(t, []string{"sha256:aaa"}, removed)
+	assert.Equal(t, []string{"c1"}, stopped)
+	require.Len(t, results, 2)
+	assert.Equal(t, "image is in use", results[0].Error)
+	assert.Empty(t, results[1].Error)
+	assert.True(t, state.LastRun.Equal(testNow))
+}
@@ -0,0 +1,26 @@
+// Package cleanup applies a retention policy to the Docker resources glide
+// projects leave behind.
+//
+// A policy is built from the global configuration:
+//
+//	cleanup:
+//	  dangling_images: 7d     # remove dangling images older than a week
+//	  idle_containers: 24h    # stop containers of projects unused for a day
+//	  opportunistic: true     # apply after commands, at most once per interval
+//	  interval: 24h
+//
+// Cleanup is split into planning and applying, so every run can be previewed:
+//
+//	engine := cleanup.NewEngine(policy, projectRoots, state)
+//	actions, err := engine.Plan()
+//	if err != nil {
+//	    return err
+//	}
+//	results := engine.Apply(actions)
+//
+// A container belongs to a glide project when it carries glide's ownership
+// labels, or, for containers started before glide labeled them, when its
+// compose working directory is inside a project root. It counts as idle
+// from the later of its start time and the last glide command run in that
+// project, which the State records.
+package cleanup
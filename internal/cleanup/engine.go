@@ -0,0 +1,201 @@
+package cleanup
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/pkg/output"
+)
+
+// Action kinds
+const (
+	KindRemoveImage   = "remove-image"
+	KindStopContainer = "stop-container"
+)
+
+// Action is a single change the policy makes
+type Action struct {
+	Kind   string `json:"kind" yaml:"kind"`
+	ID     string `json:"id" yaml:"id"`
+	Name   string `json:"name" yaml:"name"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// Result is the outcome of applying an action
+type Result struct {
+	Action
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Engine plans and applies a retention policy
+type Engine struct {
+	policy   Policy
+	projects []string
+	state    *State
+
+	// The following are replaced in tests
+	now            func() time.Time
+	danglingImages func() ([]docker.Image, error)
+	containers     func() ([]docker.ProjectContainer, error)
+	removeImage    func(id string) error
+	stopContainer  func(id string) error
+}
+
+// NewEngine creates an engine for the given policy. projects are the roots
+// of glide projects, in addition to those with activity in state.
+func NewEngine(policy Policy, projects []string, state *State) *Engine {
+	roots := make(map[string]bool)
+	for _, list := range [][]string{projects, state.Projects()} {
+		for _, root := range list {
+			if root != "" {
+				roots[filepath.Clean(root)] = true
+			}
+		}
+	}
+	unique := make([]string, 0, len(roots))
+	for root := range roots {
+		unique = append(unique, root)
+	}
+	sort.Strings(unique)
+
+	return &Engine{
+		policy:         policy,
+		projects:       unique,
+		state:          state,
+		now:            time.Now,
+		danglingImages: docker.DanglingImages,
+		containers:     docker.ComposeContainers,
+		removeImage:    docker.RemoveImage,
+		stopContainer:  docker.StopContainer,
+	}
+}
+
+// Plan returns the actions the policy would take now, images first
+func (e *Engine) Plan() ([]Action, error) {
+	now := e.now()
+	var actions []Action
+
+	if e.policy.DanglingImages > 0 {
+		images, err := e.danglingImages()
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range images {
+			age := now.Sub(image.Created)
+			if age < e.policy.DanglingImages {
+				continue
+			}
+			reason := fmt.Sprintf("dangling for more than %s", output.Duration(e.policy.DanglingImages))
+			if image.Size != "" {
+				reason += ", " + image.Size
+			}
+			actions = append(actions, Action{
+				Kind:   KindRemoveImage,
+				ID:     image.ID,
+				Name:   shortID(image.ID),
+				Reason: reason,
+			})
+		}
+	}
+
+	if e.policy.IdleContainers > 0 {
+		containers, err := e.containers()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range containers {
+			if c.State != "running" {
+				continue
+			}
+			root := e.projectRoot(c.WorkingDir)
+			if owner, ok := c.Owner(); ok {
+				root = filepath.Clean(owner.Project)
+			}
+			if root == "" {
+				continue
+			}
+
+			idleSince := c.StartedAt
+			if last := e.state.LastActivity(root); last.After(idleSince) {
+				idleSince = last
+			}
+			idle := now.Sub(idleSince)
+			if idle < e.policy.IdleContainers {
+				continue
+			}
+			actions = append(actions, Action{
+				Kind:   KindStopContainer,
+				ID:     c.ID,
+				Name:   c.Name,
+				Reason: fmt.Sprintf("idle for %s in %s", output.Duration(idle), root),
+			})
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		if actions[i].Kind != actions[j].Kind {
+			return actions[i].Kind == KindRemoveImage
+		}
+		return actions[i].Name < actions[j].Name
+	})
+	return actions, nil
+}
+
+// Apply carries out the actions, continuing past failures, and records the
+// run in the state
+func (e *Engine) Apply(actions []Action) []Result {
+	results := make([]Result, 0, len(actions))
+	for _, action := range actions {
+		var err error
+		switch action.Kind {
+		case KindRemoveImage:
+			err = e.removeImage(action.ID)
+		case KindStopContainer:
+			err = e.stopContainer(action.ID)
+		default:
+			err = fmt.Errorf("unknown action %q", action.Kind)
+		}
+
+		result := Result{Action: action}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	e.state.LastRun = e.now().UTC()
+	return results
+}
+
+// projectRoot returns the innermost glide project containing dir, or ""
+// when dir is outside every project
+func (e *Engine) projectRoot(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	dir = filepath.Clean(dir)
+	match := ""
+	for _, root := range e.projects {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if len(root) > len(match) {
+			match = root
+		}
+	}
+	return match
+}
+
+// shortID abbreviates an image ID the way docker does
+func shortID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
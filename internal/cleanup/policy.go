@@ -0,0 +1,93 @@
+package cleanup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// DefaultInterval is the minimum time between opportunistic runs
+const DefaultInterval = 24 * time.Hour
+
+// Policy is a parsed retention policy. Zero ages disable a rule.
+type Policy struct {
+	// DanglingImages is the age after which dangling images are removed
+	DanglingImages time.Duration
+	// IdleContainers is the idle time after which project containers are stopped
+	IdleContainers time.Duration
+	// Opportunistic applies the policy after commands
+	Opportunistic bool
+	// Interval is the minimum time between opportunistic runs
+	Interval time.Duration
+}
+
+// Empty reports whether the policy has no rules
+func (p Policy) Empty() bool {
+	return p.DanglingImages == 0 && p.IdleContainers == 0
+}
+
+// PolicyFromConfig parses the cleanup section of the configuration
+func PolicyFromConfig(cfg config.CleanupConfig) (Policy, error) {
+	policy := Policy{Opportunistic: cfg.Opportunistic, Interval: DefaultInterval}
+
+	fields := []struct {
+		key   string
+		value string
+		dest  *time.Duration
+	}{
+		{"cleanup.dangling_images", cfg.DanglingImages, &policy.DanglingImages},
+		{"cleanup.idle_containers", cfg.IdleContainers, &policy.IdleContainers},
+		{"cleanup.interval", cfg.Interval, &policy.Interval},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		age, err := ParseAge(field.value)
+		if err != nil {
+			return Policy{}, glideErrors.NewConfigError(fmt.Sprintf("invalid %s: %q", field.key, field.value),
+				glideErrors.WithError(err),
+				glideErrors.WithSuggestions(`Use a duration such as "7d", "24h", or "90m"`),
+			)
+		}
+		*field.dest = age
+	}
+	return policy, nil
+}
+
+// ParseAge parses a Go duration that may also use a "d" (24h) unit, either
+// alone ("7d") or as a prefix ("1d12h")
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	var days time.Duration
+	if i := strings.Index(s, "d"); i >= 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid day count in %q", s)
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		s = s[i+1:]
+		if s == "" {
+			return checkAge(days)
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return checkAge(days + d)
+}
+
+// checkAge rejects negative ages
+func checkAge(d time.Duration) (time.Duration, error) {
+	if d < 0 {
+		return 0, fmt.Errorf("age must not be negative")
+	}
+	return d, nil
+}
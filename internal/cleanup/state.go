@@ -0,0 +1,79 @@
+package cleanup
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// State records when the policy last ran and when each project was last
+// used. It is shared by every glide process on the machine.
+type State struct {
+	LastRun  time.Time            `json:"last_run,omitempty"`
+	Activity map[string]time.Time `json:"activity,omitempty"`
+
+	path string
+}
+
+// DefaultStatePath returns the state file in the user's glide directory
+func DefaultStatePath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "cleanup.json")
+}
+
+// LoadState reads the state file. A missing or unreadable file yields an
+// empty state, since the state only makes cleanup more conservative.
+func LoadState(path string) *State {
+	state := &State{path: path}
+	if data, err := os.ReadFile(path); err == nil {
+		// Safe to ignore: a corrupt state file is replaced on the next save
+		_ = json.Unmarshal(data, state)
+	}
+	if state.Activity == nil {
+		state.Activity = make(map[string]time.Time)
+	}
+	return state
+}
+
+// Touch records that a command ran in the project at root
+func (s *State) Touch(root string, at time.Time) {
+	s.Activity[filepath.Clean(root)] = at.UTC()
+}
+
+// LastActivity returns when a command last ran in the project at root
+func (s *State) LastActivity(root string) time.Time {
+	return s.Activity[filepath.Clean(root)]
+}
+
+// Projects returns the roots of every project with recorded activity
+func (s *State) Projects() []string {
+	roots := make([]string, 0, len(s.Activity))
+	for root := range s.Activity {
+		roots = append(roots, root)
+	}
+	return roots
+}
+
+// Due reports whether an opportunistic run is due
+func (s *State) Due(now time.Time, interval time.Duration) bool {
+	return now.Sub(s.LastRun) >= interval
+}
+
+// Save writes the state file atomically
+func (s *State) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
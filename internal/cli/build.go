@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// defaultBuildParallelism is the number of images built at once
+const defaultBuildParallelism = 4
+
+// bakeImages is replaced in tests
+var bakeImages = docker.Bake
+
+// BuildResult is the structured result of building one service's image
+type BuildResult struct {
+	Service string  `json:"service" yaml:"service"`
+	Image   string  `json:"image" yaml:"image"`
+	Digest  string  `json:"digest,omitempty" yaml:"digest,omitempty"`
+	Seconds float64 `json:"seconds" yaml:"seconds"`
+	Error   string  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// BuildCommand builds a project's images with buildx bake
+type BuildCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+
+	profiles    []string
+	parallelism int
+	noCache     bool
+	pull        bool
+}
+
+// NewBuildCommand creates the build command
+func NewBuildCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	bc := &BuildCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "build [service...]",
+		Short: "Build the project's images with buildx bake",
+		Long: `Build every service with a build section in the compose files through a
+single 'docker buildx bake' run per batch, instead of one 'docker build' per
+service. Images are loaded into the local image store under the names
+compose uses, so 'glide up' picks them up.
+
+A 'build' command defined in .glide.yml takes precedence over this one.
+
+Configuration (.glide.yml):
+  build:
+    parallelism: 4       # images built at once
+    cache_from: ["type=registry,ref=ghcr.io/acme/app:buildcache"]
+    cache_to: ["type=registry,ref=ghcr.io/acme/app:buildcache,mode=max"]
+
+Examples:
+  glide build                   # Build every service
+  glide build php worker        # Build selected services
+  glide build --no-cache        # Ignore cached layers
+  glide build --format json     # Per-image results as JSON`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			dir, err := composeProjectDir(bc.ctx)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			project, err := docker.LoadComposeProject(dir, bc.profiles...)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return project.BuildServices(), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return bc.Execute(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&bc.profiles, "profile", nil, "Compose profiles to include ('*' for all)")
+	cmd.Flags().IntVarP(&bc.parallelism, "parallel", "j", 0, "Number of images to build at once (default from build.parallelism, or 4)")
+	cmd.Flags().BoolVar(&bc.noCache, "no-cache", false, "Do not use cached layers")
+	cmd.Flags().BoolVar(&bc.pull, "pull", false, "Always pull newer base images")
+
+	return cmd
+}
+
+// Execute builds the requested services in batches
+func (bc *BuildCommand) Execute(cmd *cobra.Command, args []string) error {
+	dir, err := composeProjectDir(bc.ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := docker.LoadComposeProject(dir, bc.profiles...)
+	if err != nil {
+		return err
+	}
+
+	services, err := bc.selectServices(project, args)
+	if err != nil {
+		return err
+	}
+	if len(services) == 0 {
+		output.Info("Nothing to build: no services have a build section")
+		return nil
+	}
+
+	buildCfg := localProjectConfig().Build
+	opts := docker.BakeOptions{
+		CacheFrom: buildCfg.CacheFrom,
+		CacheTo:   buildCfg.CacheTo,
+		NoCache:   bc.noCache,
+		Pull:      bc.pull,
+	}
+	parallelism := bc.parallelism
+	if parallelism <= 0 {
+		parallelism = buildCfg.Parallelism
+	}
+	if parallelism <= 0 {
+		parallelism = defaultBuildParallelism
+	}
+
+	file := project.BakeFile(services, opts)
+	results := make([]BuildResult, 0, len(services))
+	failed := 0
+
+	for start := 0; start < len(services); start += parallelism {
+		end := start + parallelism
+		if end > len(services) {
+			end = len(services)
+		}
+		batch := services[start:end]
+
+		output.Info("🔨 Building %s", strings.Join(batch, ", "))
+		began := time.Now()
+		metadata, err := bakeImages(dir, file, batch, opts)
+		elapsed := time.Since(began).Round(time.Millisecond).Seconds()
+
+		for _, service := range batch {
+			result := BuildResult{
+				Service: service,
+				Image:   project.ImageName(service),
+				Seconds: elapsed,
+				Digest:  metadata[service].Digest,
+			}
+			if err != nil {
+				result.Error = err.Error()
+				failed++
+			}
+			results = append(results, result)
+		}
+	}
+
+	if output.GetFormat().IsStructured() {
+		if err := output.Display(results); err != nil {
+			return err
+		}
+	} else {
+		showBuildResults(results)
+	}
+
+	if failed > 0 {
+		return glideErrors.NewDockerError(fmt.Sprintf("%d of %d image(s) failed to build", failed, len(results)),
+			glideErrors.WithSuggestions("Scroll up for the build log of the failed batch"),
+		)
+	}
+	return nil
+}
+
+// selectServices returns the services to build, validating any requested
+// on the command line
+func (bc *BuildCommand) selectServices(project *docker.ComposeProject, args []string) ([]string, error) {
+	buildable := project.BuildServices()
+	if len(args) == 0 {
+		return buildable, nil
+	}
+
+	var services []string
+	for _, name := range args {
+		service, ok := project.Services[name]
+		if !ok {
+			return nil, glideErrors.NewConfigError(fmt.Sprintf("unknown service %q", name),
+				glideErrors.WithSuggestions("Buildable services: "+strings.Join(buildable, ", ")),
+			)
+		}
+		if service.Build == nil {
+			return nil, glideErrors.NewConfigError(fmt.Sprintf("service %q has no build section", name),
+				glideErrors.WithSuggestions("Buildable services: "+strings.Join(buildable, ", ")),
+			)
+		}
+		services = append(services, name)
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// showBuildResults prints a table of build results
+func showBuildResults(results []BuildResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, "SERVICE\tIMAGE\tRESULT\tTIME")
+	for _, r := range results {
+		status := "✓ built"
+		if r.Error != "" {
+			status = "✗ failed"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%.1fs\n", r.Service, r.Image, status, r.Seconds)
+	}
+	_ = w.Flush()
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/glide-cli/glide/v3/pkg/output"
 	"github.com/glide-cli/glide/v3/pkg/plugin"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 // Builder handles the construction of CLI commands
@@ -32,6 +31,7 @@ func NewBuilder(
 		outputManager:  outputManager,
 		registry:       NewRegistry(),
 	}
+	builder.registry.SetPlanner(NewPlanner(projectContext, cfg))
 
 	// Register all commands
 	builder.registerCommands()
@@ -53,6 +53,38 @@ func (b *Builder) registerCommands() {
 		Description: "Initial setup and configuration",
 	})
 
+	b.registry.Register("export-setup", func() *cobra.Command {
+		return NewExportSetupCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "export-setup",
+		Category:    CategorySetup,
+		Description: "Write a bundle of your setup for teammates to import",
+	})
+
+	b.registry.Register("import-setup", func() *cobra.Command {
+		return NewImportSetupCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "import-setup",
+		Category:    CategorySetup,
+		Description: "Apply a setup bundle exported by a teammate",
+	})
+
+	b.registry.Register("upgrade-config", func() *cobra.Command {
+		return NewUpgradeConfigCommand(b.projectContext)
+	}, Metadata{
+		Name:        "upgrade-config",
+		Category:    CategorySetup,
+		Description: "Rewrite legacy configuration to the current format",
+	})
+
+	b.registry.Register("onboard", func() *cobra.Command {
+		return NewOnboardCommand(b.config)
+	}, Metadata{
+		Name:        "onboard",
+		Category:    CategorySetup,
+		Description: "Answer the first-run questions again",
+	})
+
 	// Plugin management commands
 	b.registry.Register("plugins", func() *cobra.Command {
 		return NewPluginsCommand()
@@ -105,6 +137,190 @@ func (b *Builder) registerCommands() {
 		Description: "Context-aware help and guidance",
 	})
 
+	b.registry.Register("snapshot", func() *cobra.Command {
+		return NewSnapshotCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "snapshot",
+		Category:    CategoryDeveloper,
+		Description: "Capture and restore workspace state",
+	})
+
+	b.registry.Register("sync", func() *cobra.Command {
+		return NewSyncCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "sync",
+		Category:    CategoryDeveloper,
+		Description: "Sync project files into containers",
+	})
+
+	b.registry.Register("prefetch", func() *cobra.Command {
+		return NewPrefetchCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "prefetch",
+		Category:    CategoryDocker,
+		Description: "Pull the project's container images ahead of time",
+	})
+
+	b.registry.Register("build", func() *cobra.Command {
+		return NewBuildCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "build",
+		Category:    CategoryDocker,
+		Description: "Build the project's images with buildx bake",
+	})
+
+	b.registry.Register("ps", func() *cobra.Command {
+		return NewPsCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "ps",
+		Category:    CategoryDocker,
+		Description: "List the project's services with their state, health, and ports",
+	})
+
+	b.registry.Register("exec", func() *cobra.Command {
+		return NewExecCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "exec",
+		Category:    CategoryDocker,
+		Description: "Run a command in a service's running container",
+	})
+
+	b.registry.Register("cp", func() *cobra.Command {
+		return NewCpCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "cp",
+		Category:    CategoryDocker,
+		Description: "Copy files between the host and a service's container",
+	})
+
+	b.registry.Register("port", func() *cobra.Command {
+		return NewPortCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "port",
+		Category:    CategoryDocker,
+		Description: "Show published ports and check connectivity",
+	})
+
+	b.registry.Register("top", func() *cobra.Command {
+		return NewTopCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "top",
+		Category:    CategoryDocker,
+		Description: "Show live resource usage per service",
+	})
+
+	b.registry.Register("clean", func() *cobra.Command {
+		return NewCleanCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "clean",
+		Category:    CategoryDocker,
+		Description: "Remove stale Docker resources according to the cleanup policy",
+	})
+
+	b.registry.Register("du", func() *cobra.Command {
+		return NewDuCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "du",
+		Category:    CategoryProject,
+		Description: "Show the disk space the project's worktrees, volumes, images, and caches take",
+	})
+
+	b.registry.Register("meta", func() *cobra.Command {
+		return NewMetaCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "meta",
+		Category:    CategoryProject,
+		Description: "Run commands across the projects of a meta-project",
+	})
+
+	b.registry.Register("policy", func() *cobra.Command {
+		return NewPolicyCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "policy",
+		Category:    CategoryDeveloper,
+		Description: "Check branch and commit naming rules",
+	})
+
+	b.registry.Register("selftest", func() *cobra.Command {
+		return NewSelfTestCommand()
+	}, Metadata{
+		Name:        "selftest",
+		Category:    CategoryDebug,
+		Description: "Check that glide works on this machine, end to end",
+	})
+
+	b.registry.Register("support", func() *cobra.Command {
+		return NewSupportCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "support",
+		Category:    CategoryDebug,
+		Description: "Collect diagnostics for bug reports",
+	})
+
+	b.registry.Register("perf", func() *cobra.Command {
+		return NewPerfCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "perf",
+		Category:    CategoryDeveloper,
+		Description: "Show performance budgets",
+	})
+
+	b.registry.Register("time", func() *cobra.Command {
+		return NewTimeCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "time",
+		Category:    CategoryProject,
+		Description: "Report development time per project and branch",
+	})
+
+	b.registry.Register("trust", func() *cobra.Command {
+		return NewTrustCommand(b.projectContext)
+	}, Metadata{
+		Name:        "trust",
+		Category:    CategoryProject,
+		Description: "Allow a project's .glide.yml commands to run",
+	})
+
+	b.registry.Register("explain", func() *cobra.Command {
+		return NewExplainCommand(b.registry.Planner())
+	}, Metadata{
+		Name:        "explain",
+		Category:    CategoryHelp,
+		Description: "Show what a command would run without executing it",
+	})
+
+	b.registry.Register("env", func() *cobra.Command {
+		return NewEnvCommand()
+	}, Metadata{
+		Name:        "env",
+		Category:    CategoryHelp,
+		Description: "Show the environment variables glide reads",
+	})
+
+	b.registry.Register("cache", func() *cobra.Command {
+		return NewCacheCommand()
+	}, Metadata{
+		Name:        "cache",
+		Category:    CategoryCore,
+		Description: "Manage the cache of downloaded plugins and updates",
+	})
+
+	b.registry.Register("jobs", func() *cobra.Command {
+		return NewJobsCommand(b.config)
+	}, Metadata{
+		Name:        "jobs",
+		Category:    CategoryCore,
+		Description: "List and run scheduled maintenance jobs",
+	})
+
+	b.registry.Register("features", func() *cobra.Command {
+		return NewFeaturesCommand()
+	}, Metadata{
+		Name:        "features",
+		Category:    CategoryHelp,
+		Description: "List and turn on experimental features",
+	})
+
 	// Project-specific commands have been moved to glide-plugin-chirocat
 	// Docker commands: up, down, status, logs, shell
 	// Developer commands: test, artisan, composer, lint
@@ -118,6 +334,14 @@ func (b *Builder) registerCommands() {
 		Description: "Update Glide CLI to the latest version",
 		Aliases:     []string{"update", "upgrade"},
 	})
+
+	b.registry.Register("uninstall", func() *cobra.Command {
+		return NewUninstallCommand(b.projectContext, b.config)
+	}, Metadata{
+		Name:        "uninstall",
+		Category:    CategoryCore,
+		Description: "Remove Glide and the files it created",
+	})
 }
 
 // Build creates the root command with all subcommands
@@ -149,20 +373,23 @@ func (b *Builder) Build() *cobra.Command {
 // addDebugCommands adds debug commands to the root command
 func (b *Builder) addDebugCommands(rootCmd *cobra.Command) {
 	// Context debug command
-	rootCmd.AddCommand(&cobra.Command{
+	contextCmd := &cobra.Command{
 		Use:          "context",
 		Short:        "Show detected project context (debug)",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return showContext(cmd, b.outputManager, b.projectContext)
 		},
-	})
+	}
+	contextCmd.AddCommand(NewContextWatchCommand(b.projectContext))
+	rootCmd.AddCommand(contextCmd)
 
 	// Shell test command
 	rootCmd.AddCommand(&cobra.Command{
 		Use:          "shell-test",
 		Short:        "Test shell execution (debug)",
 		SilenceUsage: true,
+		Annotations:  map[string]string{ShellEscapeAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return testShell(cmd, args, b.outputManager)
 		},
@@ -202,6 +429,9 @@ func (b *Builder) GetRegistry() *Registry {
 
 // loadYAMLCommands discovers and loads YAML-defined commands with proper priority ordering
 func (b *Builder) loadYAMLCommands() {
+	// Untrusted project commands may run in the sandbox the config sets up
+	ConfigureSandbox(b.config)
+
 	// 1. Core commands are already registered (highest priority)
 
 	// 2. Discover and load all .glide.yml files up the tree
@@ -214,8 +444,14 @@ func (b *Builder) loadYAMLCommands() {
 		if err == nil && localConfigs.Commands != nil {
 			commands, err := config.ParseCommands(localConfigs.Commands)
 			if err == nil {
+				// Project commands run once the project is trusted
+				trustDir := projectTrustDir(cwd)
 				for name, cmd := range commands {
+					cmd.Dir = trustDir
 					// Check for conflicts with core commands
+					if isOverridableCommand(name) {
+						b.registry.Remove(name)
+					}
 					if !isProtectedCommand(name) {
 						// Safe to ignore: YAML command registration errors are logged by registry
 						// Duplicate commands or invalid configs are non-fatal
@@ -239,7 +475,7 @@ func (b *Builder) loadYAMLCommands() {
 		data, err := os.ReadFile(globalConfigPath)
 		if err == nil {
 			var globalConfig config.Config
-			if err := yaml.Unmarshal(data, &globalConfig); err == nil {
+			if err := config.UnmarshalConfig(data, globalConfigPath, &globalConfig); err == nil {
 				if globalConfig.Commands != nil {
 					commands, err := config.ParseCommands(globalConfig.Commands)
 					if err == nil {
@@ -258,12 +494,19 @@ func (b *Builder) loadYAMLCommands() {
 	}
 }
 
+// isOverridableCommand checks if a core command yields to a project's own
+// YAML command of the same name. These are names projects commonly define
+// themselves.
+func isOverridableCommand(name string) bool {
+	return name == "build" || name == "clean" || name == "ps" || name == "exec" || name == "cp"
+}
+
 // isProtectedCommand checks if a command name is protected (core command)
 func isProtectedCommand(name string) bool {
 	protected := []string{
-		"help", "setup", "plugins", "plugin", "self-update",
-		"update", "upgrade", "version", "completion", "global",
-		"config", "context", "shell-test", "docker-test", "container-test",
+		"help", "setup", "onboard", "upgrade-config", "export-setup", "import-setup", "plugins", "plugin", "self-update",
+		"update", "upgrade", "version", "completion", "global", "explain", "snapshot", "sync", "prefetch", "top", "meta", "policy", "perf", "time",
+		"trust", "env", "uninstall", "config", "context", "selftest", "support", "shell-test", "docker-test", "container-test",
 	}
 	for _, p := range protected {
 		if name == p {
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"math"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/artifacts"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// CachePruneReport is the result of `glide cache prune`
+type CachePruneReport struct {
+	artifacts.PruneResult `yaml:",inline"`
+	Dir                   string `json:"dir" yaml:"dir"`
+}
+
+// NewCacheCommand creates the cache command group
+func NewCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the cache of downloaded plugins and updates",
+		Long: `Downloaded plugin binaries and update archives are kept in a
+content-addressed cache, ~/.glide/cache/artifacts, so installing the same
+release again does not download it again. Point GLIDE_ARTIFACT_CACHE at a
+shared filesystem to share the cache between machines.
+
+The cache is pruned to GLIDE_ARTIFACT_CACHE_MAX (default 1GB; 0 turns
+caching off), least recently used first, whenever it grows.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.AddCommand(newCachePruneCommand())
+	return cmd
+}
+
+// newCachePruneCommand removes cached artifacts
+func newCachePruneCommand() *cobra.Command {
+	var (
+		maxSize   string
+		olderThan string
+		all       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove cached plugin binaries and update archives",
+		Long: `Remove cached files that were not used for a while, or the least
+recently used ones until the cache fits a size.
+
+Examples:
+  glide cache prune                    # Shrink the cache to its limit
+  glide cache prune --max-size 200MB   # Shrink it further
+  glide cache prune --older-than 30d   # Remove files unused for 30 days
+  glide cache prune --all              # Empty the cache`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cache := artifactCache()
+
+			limit := cache.MaxSize
+			if !cache.Enabled() {
+				limit = math.MaxInt64
+			}
+			if maxSize != "" {
+				size, err := artifacts.ParseSize(maxSize)
+				if err != nil {
+					return glideErrors.New(glideErrors.TypeInvalid, err.Error())
+				}
+				limit = size
+			}
+			if all {
+				limit = 0
+			}
+
+			var unusedSince time.Time
+			if olderThan != "" {
+				cutoff, err := parseReportTime("--older-than", olderThan, time.Now())
+				if err != nil {
+					return err
+				}
+				unusedSince = cutoff
+			}
+
+			result, err := cache.Prune(limit, unusedSince)
+			if err != nil {
+				return glideErrors.WrapWithOp(err, "pruning the artifact cache", glideErrors.WithPath(cache.Dir))
+			}
+
+			report := CachePruneReport{Dir: cache.Dir, PruneResult: result}
+			if output.GetFormat().IsStructured() {
+				return output.Display(report)
+			}
+			if result.Removed == 0 {
+				output.Info("Nothing to prune: %d file(s), %s in %s", result.Kept, output.Bytes(uint64(result.Size)), output.Path(cache.Dir))
+				return nil
+			}
+			output.Success("✓ Removed %d file(s), freeing %s; %d file(s), %s left", result.Removed,
+				output.Bytes(uint64(result.Freed)), result.Kept, output.Bytes(uint64(result.Size)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&maxSize, "max-size", "", "Shrink the cache to this size, e.g. 500MB (default: GLIDE_ARTIFACT_CACHE_MAX)")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Remove files unused since this age or date, e.g. 30d or 2026-10-01")
+	cmd.Flags().BoolVar(&all, "all", false, "Remove every cached file")
+	return cmd
+}
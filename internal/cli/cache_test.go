@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/pluginregistry"
+	"github.com/glide-cli/glide/v3/pkg/artifacts"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubArtifactCache points the artifact cache at a temporary directory
+func stubArtifactCache(t *testing.T) *artifacts.Cache {
+	t.Helper()
+	cache := artifacts.New(t.TempDir(), artifacts.DefaultMaxSize)
+	original := artifactCache
+	artifactCache = func() *artifacts.Cache { return cache }
+	t.Cleanup(func() { artifactCache = original })
+	return cache
+}
+
+func TestCachePrune(t *testing.T) {
+	cache := stubArtifactCache(t)
+	for _, key := range []string{"a", "b"} {
+		path := filepath.Join(t.TempDir(), key)
+		require.NoError(t, os.WriteFile(path, []byte(key+" binary"), 0644))
+		require.NoError(t, cache.Store(key, path))
+	}
+
+	run := func(args ...string) {
+		t.Helper()
+		root := &cobra.Command{Use: "glide"}
+		root.AddCommand(NewCacheCommand())
+		root.SetArgs(append([]string{"cache", "prune"}, args...))
+		require.NoError(t, root.Execute())
+	}
+
+	run()
+	files, _, err := cache.Usage()
+	require.NoError(t, err)
+	assert.Equal(t, 2, files, "the cache is within its limit")
+
+	run("--max-size", "8")
+	files, _, err = cache.Usage()
+	require.NoError(t, err)
+	assert.Equal(t, 1, files)
+
+	run("--all")
+	files, _, err = cache.Usage()
+	require.NoError(t, err)
+	assert.Zero(t, files)
+}
+
+func TestDownloadAsset_Cached(t *testing.T) {
+	cache := stubArtifactCache(t)
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		_, _ = w.Write([]byte("plugin binary"))
+	}))
+	defer server.Close()
+	reg := &pluginregistry.Registry{Name: "acme", Host: "github.acme.com", API: server.URL}
+
+	for range 2 {
+		path, err := downloadAsset(reg, server.URL+"/repos/a/b/releases/assets/7")
+		require.NoError(t, err)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "plugin binary", string(data))
+		require.NoError(t, os.Remove(path))
+	}
+	assert.Equal(t, 1, downloads, "the second install comes from the cache")
+
+	// Local mirrors are not cached
+	mirrored, err := pluginregistry.WithMirror(nil, t.TempDir())
+	require.NoError(t, err)
+	binary := filepath.Join(strings.TrimPrefix(mirrored[0].API, "file://"), "b-linux-amd64")
+	require.NoError(t, os.MkdirAll(filepath.Dir(binary), 0755))
+	require.NoError(t, os.WriteFile(binary, []byte("mirrored"), 0755))
+	path, err := downloadAsset(mirrored[0], mirrored[0].API+"/b-linux-amd64")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(path))
+	files, _, err := cache.Usage()
+	require.NoError(t, err)
+	assert.Equal(t, 1, files)
+}
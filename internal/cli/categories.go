@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/glide-cli/glide/v3/pkg/registry"
+)
+
+// CategorySourceCore identifies categories registered by Glide itself
+const CategorySourceCore = "core"
+
+// CategoryInfo holds information about a command category
+type CategoryInfo struct {
+	ID          string
+	Name        string
+	Description string
+	Priority    int // Lower numbers appear first
+	Color       *color.Color
+	Source      string // CategorySourceCore or the name of the registering plugin
+}
+
+// CategoryRegistry manages help categories registered by core and plugins.
+//
+// Conflicts are resolved in favour of core: plugins cannot redefine a core
+// category, and the first plugin to register a category ID keeps it.
+// Priorities can be overridden from configuration without re-registering.
+type CategoryRegistry struct {
+	categories *registry.Registry[CategoryInfo]
+
+	mu                sync.RWMutex // guards registration sequences and overrides
+	priorityOverrides map[string]int
+}
+
+// NewCategoryRegistry creates an empty category registry
+func NewCategoryRegistry() *CategoryRegistry {
+	return &CategoryRegistry{
+		categories:        registry.New[CategoryInfo](),
+		priorityOverrides: make(map[string]int),
+	}
+}
+
+// Register adds a category. Re-registering a category from the same source
+// replaces it; registering an ID owned by another source returns an error,
+// except that core registrations always take precedence over plugins.
+func (r *CategoryRegistry) Register(info CategoryInfo) error {
+	if info.ID == "" {
+		return fmt.Errorf("category ID cannot be empty")
+	}
+	if info.Source == "" {
+		info.Source = CategorySourceCore
+	}
+	if info.Color == nil {
+		info.Color = color.New(color.FgYellow, color.Bold)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.categories.Get(info.ID); ok {
+		switch {
+		case existing.Source == info.Source:
+			// Same owner refreshing its definition
+		case info.Source == CategorySourceCore:
+			// Core reclaims the ID from a plugin
+		case existing.Source == CategorySourceCore:
+			return fmt.Errorf("category %s is reserved by core and cannot be redefined by plugin %s", info.ID, info.Source)
+		default:
+			return fmt.Errorf("category %s from plugin %s conflicts with plugin %s", info.ID, info.Source, existing.Source)
+		}
+		r.categories.Remove(info.ID)
+	}
+
+	return r.categories.Register(info.ID, info)
+}
+
+// Get returns a category with any configured priority override applied
+func (r *CategoryRegistry) Get(id string) (CategoryInfo, bool) {
+	info, ok := r.categories.Get(id)
+	if !ok {
+		return CategoryInfo{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if priority, overridden := r.priorityOverrides[id]; overridden {
+		info.Priority = priority
+	}
+	return info, true
+}
+
+// List returns all categories ordered by priority
+func (r *CategoryRegistry) List() []CategoryInfo {
+	ids := r.categories.ListNames()
+	r.SortIDs(ids)
+
+	categories := make([]CategoryInfo, 0, len(ids))
+	for _, id := range ids {
+		info, _ := r.Get(id)
+		categories = append(categories, info)
+	}
+	return categories
+}
+
+// SortIDs orders category IDs by priority. Unknown categories sort last and
+// ties are broken alphabetically so help output is stable.
+func (r *CategoryRegistry) SortIDs(ids []string) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		catI, okI := r.Get(ids[i])
+		catJ, okJ := r.Get(ids[j])
+		switch {
+		case okI != okJ:
+			return okI
+		case okI && catI.Priority != catJ.Priority:
+			return catI.Priority < catJ.Priority
+		default:
+			return ids[i] < ids[j]
+		}
+	})
+}
+
+// SetPriorityOverrides replaces the configured priority overrides
+func (r *CategoryRegistry) SetPriorityOverrides(overrides map[string]int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.priorityOverrides = make(map[string]int, len(overrides))
+	for id, priority := range overrides {
+		r.priorityOverrides[id] = priority
+	}
+}
+
+// categoryColor converts a color name from plugin metadata into a header color
+func categoryColor(name string) *color.Color {
+	attrs := map[string]color.Attribute{
+		"black":   color.FgBlack,
+		"red":     color.FgRed,
+		"green":   color.FgGreen,
+		"yellow":  color.FgYellow,
+		"blue":    color.FgBlue,
+		"magenta": color.FgMagenta,
+		"cyan":    color.FgCyan,
+		"white":   color.FgWhite,
+	}
+
+	attr, ok := attrs[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		attr = color.FgYellow
+	}
+	return color.New(attr, color.Bold)
+}
+
+// helpCategories is the category registry used by the help system
+var helpCategories = newCoreCategoryRegistry()
+
+// Categories returns the category registry used by the help system
+func Categories() *CategoryRegistry {
+	return helpCategories
+}
+
+// newCoreCategoryRegistry creates a registry pre-populated with core categories
+func newCoreCategoryRegistry() *CategoryRegistry {
+	r := NewCategoryRegistry()
+
+	core := []CategoryInfo{
+		{ID: "core", Name: "Core Commands", Description: "Essential development commands", Priority: 10},
+		{ID: "global", Name: "Global Commands", Description: "Multi-worktree management", Priority: 20},
+		{ID: "setup", Name: "Setup & Configuration", Description: "Project setup and configuration", Priority: 30},
+		// Project-specific categories (40-70) - will be moved to plugins
+		{ID: "docker", Name: "Docker Management", Description: "Container and service control", Priority: 40},
+		{ID: "testing", Name: "Testing", Description: "Test execution and coverage", Priority: 50},
+		{ID: "developer", Name: "Development Tools", Description: "Code quality and utilities", Priority: 60},
+		{ID: "database", Name: "Database", Description: "Database management and access", Priority: 70},
+		// Plugin commands get their own section
+		{ID: "plugin", Name: "Plugin Commands", Description: "Commands from installed plugins", Priority: 80},
+		// Help is always last
+		{ID: "help", Name: "Help & Documentation", Description: "Help topics and guides", Priority: 90},
+	}
+
+	for _, info := range core {
+		if err := r.Register(info); err != nil {
+			panic(fmt.Sprintf("failed to register core category %s: %v", info.ID, err))
+		}
+	}
+
+	return r
+}
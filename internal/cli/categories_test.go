@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryRegistry_Register(t *testing.T) {
+	t.Run("plugin cannot redefine core category", func(t *testing.T) {
+		r := newCoreCategoryRegistry()
+
+		err := r.Register(CategoryInfo{ID: "core", Name: "Hijacked", Priority: 1, Source: "evil"})
+		assert.Error(t, err)
+
+		info, ok := r.Get("core")
+		require.True(t, ok)
+		assert.Equal(t, "Core Commands", info.Name)
+	})
+
+	t.Run("first plugin keeps a contested category", func(t *testing.T) {
+		r := NewCategoryRegistry()
+		require.NoError(t, r.Register(CategoryInfo{ID: "cloud", Name: "Cloud", Priority: 110, Source: "aws"}))
+
+		err := r.Register(CategoryInfo{ID: "cloud", Name: "Cloud Tools", Priority: 120, Source: "gcp"})
+		assert.Error(t, err)
+
+		info, _ := r.Get("cloud")
+		assert.Equal(t, "aws", info.Source)
+	})
+
+	t.Run("same source can refresh its category", func(t *testing.T) {
+		r := NewCategoryRegistry()
+		require.NoError(t, r.Register(CategoryInfo{ID: "cloud", Name: "Cloud", Priority: 110, Source: "aws"}))
+		require.NoError(t, r.Register(CategoryInfo{ID: "cloud", Name: "Cloud", Priority: 105, Source: "aws"}))
+
+		info, _ := r.Get("cloud")
+		assert.Equal(t, 105, info.Priority)
+	})
+
+	t.Run("core reclaims category from plugin", func(t *testing.T) {
+		r := NewCategoryRegistry()
+		require.NoError(t, r.Register(CategoryInfo{ID: "deploy", Name: "Plugin Deploy", Source: "deployer"}))
+		require.NoError(t, r.Register(CategoryInfo{ID: "deploy", Name: "Deployment"}))
+
+		info, _ := r.Get("deploy")
+		assert.Equal(t, CategorySourceCore, info.Source)
+		assert.NotNil(t, info.Color, "default color should be applied")
+	})
+
+	t.Run("empty ID is rejected", func(t *testing.T) {
+		assert.Error(t, NewCategoryRegistry().Register(CategoryInfo{Name: "Nameless"}))
+	})
+}
+
+func TestCategoryRegistry_PriorityOverrides(t *testing.T) {
+	r := newCoreCategoryRegistry()
+	r.SetPriorityOverrides(map[string]int{"database": 5})
+
+	info, _ := r.Get("database")
+	assert.Equal(t, 5, info.Priority)
+
+	ids := []string{"help", "unknown", "core", "database"}
+	r.SortIDs(ids)
+	assert.Equal(t, []string{"database", "core", "help", "unknown"}, ids)
+
+	r.SetPriorityOverrides(nil)
+	info, _ = r.Get("database")
+	assert.Equal(t, 70, info.Priority)
+}
+
+func TestCategoryColor(t *testing.T) {
+	assert.Equal(t, categoryColor("cyan").Sprint("x"), categoryColor(" Cyan ").Sprint("x"))
+	assert.NotNil(t, categoryColor("not-a-color"))
+}
@@ -0,0 +1,312 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/cleanup"
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/pkg/audit"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// cleanupStatePath, newCleanupEngine, and spawnCleanup are replaced in tests
+	cleanupStatePath = cleanup.DefaultStatePath
+	newCleanupEngine = func(policy cleanup.Policy, projects []string, state *cleanup.State) cleanupEngine {
+		return cleanup.NewEngine(policy, projects, state)
+	}
+	spawnCleanup = func() error {
+		exe, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		cmd := exec.Command(exe, "clean", "--auto", "--quiet")
+		// Run outside the project so a project's own YAML `clean` command
+		// cannot take the place of the built-in one
+		cmd.Dir = os.TempDir()
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		return cmd.Process.Release()
+	}
+)
+
+// cleanupEngine plans and applies a retention policy
+type cleanupEngine interface {
+	Plan() ([]cleanup.Action, error)
+	Apply(actions []cleanup.Action) []cleanup.Result
+}
+
+// CleanCommand applies the cleanup retention policy
+type CleanCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+
+	auto  bool
+	force bool
+}
+
+// NewCleanCommand creates the clean command
+func NewCleanCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	cc := &CleanCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove stale Docker resources according to the cleanup policy",
+		Long: fmt.Sprintf(`Apply the Docker retention policy from the global configuration
+(~/%s): remove old dangling images and stop the containers of glide projects
+nobody has used for a while.
+
+The planned changes are shown and confirmed before anything happens. Use
+--dry-run to only preview them, and --auto to apply the policy without asking,
+e.g. from a scheduled job. With cleanup.opportunistic set, glide runs
+'clean --auto' in the background after commands, at most once per interval.
+
+A 'clean' command defined in .glide.yml takes precedence over this one.
+
+Configuration (~/%s):
+  cleanup:
+    dangling_images: 7d     # remove dangling images older than a week
+    idle_containers: 24h    # stop project containers unused for a day
+    opportunistic: true     # apply after commands
+    interval: 24h           # at most this often
+
+Examples:
+  glide clean               # Preview, confirm, and apply the policy
+  glide clean --dry-run     # Preview only
+  glide clean --auto        # Apply without confirmation`, branding.ConfigFileName, branding.ConfigFileName),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Annotations:   map[string]string{DryRunAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.Execute(cmd)
+		},
+	}
+
+	cmd.Flags().BoolVar(&cc.auto, "auto", false, "Apply the policy without confirmation")
+	cmd.Flags().BoolVar(&cc.force, "force", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+// Execute plans the policy, then previews or applies it
+func (cc *CleanCommand) Execute(cmd *cobra.Command) error {
+	policy, err := cleanup.PolicyFromConfig(cc.cleanupConfig())
+	if err != nil {
+		return err
+	}
+	if policy.Empty() {
+		output.Info("No cleanup policy configured. Add one to ~/%s:", branding.ConfigFileName)
+		output.Println("  cleanup:")
+		output.Println("    dangling_images: 7d")
+		output.Println("    idle_containers: 24h")
+		return nil
+	}
+
+	state := cleanup.LoadState(cleanupStatePath())
+	engine := newCleanupEngine(policy, cc.projectRoots(), state)
+
+	actions, err := engine.Plan()
+	if err != nil {
+		return err
+	}
+
+	if IsDryRun(cmd) {
+		if output.GetFormat().IsStructured() {
+			return output.Display(actions)
+		}
+		if len(actions) == 0 {
+			output.Info("Nothing to clean")
+			return nil
+		}
+		showCleanupActions(actions)
+		output.Info("Dry run: nothing was changed")
+		return nil
+	}
+
+	if len(actions) > 0 && !cc.auto {
+		if confirmed, err := cc.confirm(cmd, actions); err != nil || !confirmed {
+			return err
+		}
+	}
+
+	results := engine.Apply(actions)
+	if err := state.Save(); err != nil {
+		output.Warning("Could not save cleanup state %s: %v", cleanupStatePath(), err)
+	}
+
+	if output.GetFormat().IsStructured() {
+		if err := output.Display(results); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		output.Info("Nothing to clean")
+	} else {
+		showCleanupResults(results)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return glideErrors.NewDockerError(fmt.Sprintf("%d of %d cleanup action(s) failed", failed, len(results)))
+	}
+	return nil
+}
+
+// confirm shows the plan and asks before applying it, recording the outcome
+// in the audit log like other destructive commands
+func (cc *CleanCommand) confirm(cmd *cobra.Command, actions []cleanup.Action) (bool, error) {
+	entry := audit.Entry{
+		Command: cmd.CommandPath(),
+		Targets: describeCleanupActions(actions),
+	}
+	logger := audit.NewLogger(audit.DefaultPath())
+
+	switch {
+	case cc.force:
+		entry.Outcome = audit.OutcomeForced
+
+	case !stdinIsTerminal():
+		entry.Outcome = audit.OutcomeRefused
+		recordAudit(logger, entry)
+		return false, glideErrors.New(glideErrors.TypePermission,
+			fmt.Sprintf("%s needs confirmation, but no terminal is attached", cmd.CommandPath()),
+			glideErrors.WithExitCode(1),
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Apply the policy without confirmation: %s clean --auto", branding.CommandName),
+				fmt.Sprintf("Preview first with: %s clean --dry-run", branding.CommandName),
+			),
+		)
+
+	default:
+		showCleanupActions(actions)
+		confirmed, err := confirmDestructive("clean")
+		if err != nil || !confirmed {
+			entry.Outcome = audit.OutcomeCancelled
+			recordAudit(logger, entry)
+			return false, err
+		}
+		entry.Outcome = audit.OutcomeConfirmed
+	}
+
+	recordAudit(logger, entry)
+	return true, nil
+}
+
+// cleanupConfig returns the cleanup section of the global configuration
+func (cc *CleanCommand) cleanupConfig() config.CleanupConfig {
+	if cc.cfg == nil {
+		return config.CleanupConfig{}
+	}
+	return cc.cfg.Cleanup
+}
+
+// projectRoots returns the roots of the registered and current projects
+func (cc *CleanCommand) projectRoots() []string {
+	var roots []string
+	if cc.cfg != nil {
+		for _, project := range cc.cfg.Projects {
+			roots = append(roots, project.Path)
+		}
+	}
+	if cc.ctx != nil && cc.ctx.ProjectRoot != "" {
+		roots = append(roots, cc.ctx.ProjectRoot)
+	}
+	return roots
+}
+
+// RunOpportunisticCleanup records that a command ran in the current project
+// and, when cleanup.opportunistic is set and a run is due, starts
+// `glide clean --auto` in the background. It never fails the command.
+func RunOpportunisticCleanup(cfg *config.Config, ctx *context.ProjectContext, executed *cobra.Command) {
+	if cfg == nil || executed == nil || executed.Name() == "clean" || strings.HasPrefix(executed.Name(), "__") {
+		return
+	}
+	policy, err := cleanup.PolicyFromConfig(cfg.Cleanup)
+	if err != nil || policy.Empty() {
+		return
+	}
+
+	now := time.Now()
+	state := cleanup.LoadState(cleanupStatePath())
+	if ctx != nil && ctx.ProjectRoot != "" {
+		state.Touch(ctx.ProjectRoot, now)
+	}
+
+	if policy.Opportunistic && state.Due(now, policy.Interval) {
+		if err := spawnCleanup(); err != nil {
+			logging.Debug("Could not start background cleanup", "error", err)
+		} else {
+			state.LastRun = now.UTC()
+		}
+	}
+
+	if err := state.Save(); err != nil {
+		logging.Debug("Could not save cleanup state", "error", err)
+	}
+}
+
+// describeCleanupActions describes each action on one line
+func describeCleanupActions(actions []cleanup.Action) []string {
+	descriptions := make([]string, 0, len(actions))
+	for _, a := range actions {
+		descriptions = append(descriptions, fmt.Sprintf("%s %s (%s)", cleanupVerb(a.Kind), a.Name, a.Reason))
+	}
+	return descriptions
+}
+
+// cleanupVerb returns a readable name for an action kind
+func cleanupVerb(kind string) string {
+	switch kind {
+	case cleanup.KindRemoveImage:
+		return "Remove image"
+	case cleanup.KindStopContainer:
+		return "Stop container"
+	default:
+		return kind
+	}
+}
+
+// showCleanupActions prints a table of planned actions
+func showCleanupActions(actions []cleanup.Action) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, "ACTION\tTARGET\tREASON")
+	for _, a := range actions {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", cleanupVerb(a.Kind), a.Name, a.Reason)
+	}
+	_ = w.Flush()
+}
+
+// showCleanupResults prints a table of applied actions
+func showCleanupResults(results []cleanup.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, "ACTION\tTARGET\tRESULT")
+	for _, r := range results {
+		status := "✓ done"
+		if r.Error != "" {
+			status = "✗ " + r.Error
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", cleanupVerb(r.Kind), r.Name, status)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/cleanup"
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCleanupEngine struct {
+	actions  []cleanup.Action
+	applied  [][]cleanup.Action
+	projects []string
+}
+
+func (f *fakeCleanupEngine) Plan() ([]cleanup.Action, error) {
+	return f.actions, nil
+}
+
+func (f *fakeCleanupEngine) Apply(actions []cleanup.Action) []cleanup.Result {
+	f.applied = append(f.applied, actions)
+	results := make([]cleanup.Result, 0, len(actions))
+	for _, a := range actions {
+		results = append(results, cleanup.Result{Action: a})
+	}
+	return results
+}
+
+// stubCleanup replaces the engine and state file used by the clean command
+func stubCleanup(t *testing.T, actions ...cleanup.Action) *fakeCleanupEngine {
+	t.Helper()
+	t.Setenv("GLIDE_AUDIT_LOG", filepath.Join(t.TempDir(), "audit.log"))
+
+	engine := &fakeCleanupEngine{actions: actions}
+	statePath := filepath.Join(t.TempDir(), "cleanup.json")
+
+	originalEngine, originalPath := newCleanupEngine, cleanupStatePath
+	newCleanupEngine = func(policy cleanup.Policy, projects []string, state *cleanup.State) cleanupEngine {
+		engine.projects = projects
+		return engine
+	}
+	cleanupStatePath = func() string { return statePath }
+	t.Cleanup(func() {
+		newCleanupEngine, cleanupStatePath = originalEngine, originalPath
+	})
+	return engine
+}
+
+// runClean runs the clean command under a root that has the global
+// --dry-run flag
+func runClean(t *testing.T, cfg *config.Config, args ...string) error {
+	t.Helper()
+	root := &cobra.Command{Use: "glide"}
+	root.PersistentFlags().Bool("dry-run", false, "")
+	root.AddCommand(NewCleanCommand(&context.ProjectContext{ProjectRoot: "/src/myapp"}, cfg))
+	root.SetArgs(append([]string{"clean"}, args...))
+	return root.Execute()
+}
+
+var cleanPolicyConfig = &config.Config{
+	Projects: map[string]config.ProjectConfig{"other": {Path: "/src/other"}},
+	Cleanup:  config.CleanupConfig{DanglingImages: "7d", IdleContainers: "24h"},
+}
+
+func TestCleanCommand_Auto(t *testing.T) {
+	engine := stubCleanup(t, cleanup.Action{Kind: cleanup.KindStopContainer, ID: "c1", Name: "myapp-php-1"})
+	prompts := stubDestructivePrompt(t, false, false)
+
+	require.NoError(t, runClean(t, cleanPolicyConfig, "--auto"))
+	require.Len(t, engine.applied, 1, "--auto applies without confirmation")
+	assert.Equal(t, 0, *prompts)
+	assert.ElementsMatch(t, []string{"/src/other", "/src/myapp"}, engine.projects)
+	assert.FileExists(t, cleanupStatePath(), "the state is saved after a run")
+}
+
+func TestCleanCommand_DryRun(t *testing.T) {
+	engine := stubCleanup(t, cleanup.Action{Kind: cleanup.KindRemoveImage, ID: "sha256:aaa", Name: "aaa"})
+
+	require.NoError(t, runClean(t, cleanPolicyConfig, "--dry-run"))
+	assert.Empty(t, engine.applied)
+}
+
+func TestCleanCommand_Confirmation(t *testing.T) {
+	action := cleanup.Action{Kind: cleanup.KindRemoveImage, ID: "sha256:aaa", Name: "aaa"}
+
+	t.Run("refused without a terminal", func(t *testing.T) {
+		engine := stubCleanup(t, action)
+		stubDestructivePrompt(t, false, true)
+
+		err := runClean(t, cleanPolicyConfig)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no terminal")
+		assert.Empty(t, engine.applied)
+	})
+
+	t.Run("cancelled at the prompt", func(t *testing.T) {
+		engine := stubCleanup(t, action)
+		stubDestructivePrompt(t, true, false)
+
+		require.NoError(t, runClean(t, cleanPolicyConfig))
+		assert.Empty(t, engine.applied)
+	})
+
+	t.Run("confirmed at the prompt", func(t *testing.T) {
+		engine := stubCleanup(t, action)
+		stubDestructivePrompt(t, true, true)
+
+		require.NoError(t, runClean(t, cleanPolicyConfig))
+		assert.Len(t, engine.applied, 1)
+	})
+
+	t.Run("nothing to confirm", func(t *testing.T) {
+		engine := stubCleanup(t)
+		stubDestructivePrompt(t, false, false)
+
+		require.NoError(t, runClean(t, cleanPolicyConfig))
+		assert.Len(t, engine.applied, 1)
+	})
+}
+
+func TestCleanCommand_NoPolicy(t *testing.T) {
+	engine := stubCleanup(t, cleanup.Action{Kind: cleanup.KindRemoveImage, ID: "sha256:aaa"})
+
+	require.NoError(t, runClean(t, &config.Config{}, "--auto"))
+	assert.Empty(t, engine.applied)
+}
+
+func TestRunOpportunisticCleanup(t *testing.T) {
+	stubCleanup(t)
+	spawned := 0
+	original := spawnCleanup
+	spawnCleanup = func() error {
+		spawned++
+		return nil
+	}
+	t.Cleanup(func() { spawnCleanup = original })
+
+	ctx := &context.ProjectContext{ProjectRoot: "/src/myapp"}
+	executed := &cobra.Command{Use: "status"}
+
+	cfg := &config.Config{Cleanup: config.CleanupConfig{IdleContainers: "24h"}}
+	RunOpportunisticCleanup(cfg, ctx, executed)
+	assert.Equal(t, 0, spawned, "opportunistic runs are opt-in")
+	assert.False(t, cleanup.LoadState(cleanupStatePath()).LastActivity("/src/myapp").IsZero(), "activity is recorded")
+
+	cfg.Cleanup.Opportunistic = true
+	RunOpportunisticCleanup(cfg, ctx, executed)
+	RunOpportunisticCleanup(cfg, ctx, executed)
+	assert.Equal(t, 1, spawned, "at most one run per interval")
+
+	RunOpportunisticCleanup(cfg, ctx, &cobra.Command{Use: "clean"})
+	RunOpportunisticCleanup(nil, ctx, executed)
+	assert.Equal(t, 1, spawned)
+}
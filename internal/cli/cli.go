@@ -97,7 +97,7 @@ func (c *CLI) AddLocalCommands(cmd *cobra.Command) {
 // addDebugCommands adds debug-only commands
 func (c *CLI) addDebugCommands(cmd *cobra.Command) {
 	// Add context debug command
-	cmd.AddCommand(&cobra.Command{
+	contextCmd := &cobra.Command{
 		Use:          "context",
 		Short:        "Show detected project context (debug)",
 		SilenceUsage: true,
@@ -105,13 +105,16 @@ func (c *CLI) addDebugCommands(cmd *cobra.Command) {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return c.showContext(cmd)
 		},
-	})
+	}
+	contextCmd.AddCommand(NewContextWatchCommand(c.projectContext))
+	cmd.AddCommand(contextCmd)
 
 	// Add shell test command (debug)
 	cmd.AddCommand(&cobra.Command{
 		Use:          "shell-test",
 		Short:        "Test shell execution (debug)",
 		SilenceUsage: true,
+		Annotations:  map[string]string{ShellEscapeAnnotation: "true"},
 		Hidden:       true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return c.testShell(cmd, args)
@@ -152,6 +155,9 @@ func (c *CLI) showContext(cmd *cobra.Command) error {
 	cmd.Println("=== Project Context ===")
 	cmd.Printf("Working Directory: %s\n", ctx.WorkingDir)
 	cmd.Printf("Project Root: %s\n", ctx.ProjectRoot)
+	if ctx.RootSource != "" {
+		cmd.Printf("Root Source: %s\n", ctx.RootSource)
+	}
 	cmd.Printf("Development Mode: %s\n", ctx.DevelopmentMode)
 	cmd.Printf("Location: %s\n", ctx.Location)
 
@@ -2,8 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/glide-cli/glide/v3/internal/config"
@@ -19,15 +19,17 @@ import (
 type CompletionType string
 
 const (
-	CompletionBash CompletionType = "bash"
-	CompletionZsh  CompletionType = "zsh"
-	CompletionFish CompletionType = "fish"
+	CompletionBash       CompletionType = "bash"
+	CompletionZsh        CompletionType = "zsh"
+	CompletionFish       CompletionType = "fish"
+	CompletionPowerShell CompletionType = "powershell"
 )
 
 // CompletionManager handles shell completion generation and installation
 type CompletionManager struct {
-	ctx *context.ProjectContext
-	cfg *config.Config
+	ctx     *context.ProjectContext
+	cfg     *config.Config
+	homeDir string // Overrides the user's home directory (used in tests)
 }
 
 // NewCompletionManager creates a new completion manager
@@ -45,9 +47,12 @@ func NewCompletionCommand(ctx *context.ProjectContext, cfg *config.Config) *cobr
 	cmd := &cobra.Command{
 		Use:   "completion [shell]",
 		Short: "Generate shell completion scripts",
-		Long: fmt.Sprintf(`Generate shell completion scripts for bash, zsh, or fish.
+		Long: fmt.Sprintf(`Generate shell completion scripts for bash, zsh, fish, or powershell.
 
-To install completions:
+To install completions automatically for your current shell:
+  %s completion install
+
+To install completions manually:
 
 Bash:
   %s completion bash > /etc/bash_completion.d/%s
@@ -60,13 +65,18 @@ Zsh:
   source <(%s completion zsh)
 
 Fish:
-  %s completion fish > ~/.config/fish/completions/%s.fish`,
+  %s completion fish > ~/.config/fish/completions/%s.fish
+
+PowerShell:
+  %s completion powershell | Out-String | Invoke-Expression`,
+			branding.CommandName,
 			branding.CommandName, branding.CommandName,
 			branding.CommandName, branding.CommandName,
 			branding.CommandName, branding.CommandName,
 			branding.CommandName,
-			branding.CommandName, branding.CommandName),
-		ValidArgs:    []string{"bash", "zsh", "fish"},
+			branding.CommandName, branding.CommandName,
+			branding.CommandName),
+		ValidArgs:    supportedShells(),
 		Args:         cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -74,29 +84,38 @@ Fish:
 		},
 	}
 
+	cmd.AddCommand(NewCompletionInstallCommand(manager))
+
 	return cmd
 }
 
 // GenerateCompletion generates completion script for the specified shell
 func (cm *CompletionManager) GenerateCompletion(cmd *cobra.Command, shell CompletionType) error {
+	return generateCompletion(cmd.Root(), shell, os.Stdout)
+}
+
+// generateCompletion writes the completion script for rootCmd to w
+func generateCompletion(rootCmd *cobra.Command, shell CompletionType, w io.Writer) error {
 	switch shell {
 	case CompletionBash:
-		return cmd.Root().GenBashCompletion(os.Stdout)
+		return rootCmd.GenBashCompletion(w)
 	case CompletionZsh:
-		return cmd.Root().GenZshCompletion(os.Stdout)
+		return rootCmd.GenZshCompletion(w)
 	case CompletionFish:
-		return cmd.Root().GenFishCompletion(os.Stdout, true)
+		return rootCmd.GenFishCompletion(w, true)
+	case CompletionPowerShell:
+		return rootCmd.GenPowerShellCompletionWithDesc(w)
 	default:
 		return glideErrors.NewConfigError(
 			fmt.Sprintf("unsupported shell: %s", shell),
-			glideErrors.WithSuggestions("Use 'bash', 'zsh', or 'fish'"),
+			glideErrors.WithSuggestions("Use 'bash', 'zsh', 'fish', or 'powershell'"),
 		)
 	}
 }
 
 // InstallCompletion installs completion scripts automatically during setup
 func (cm *CompletionManager) InstallCompletion() error {
-	shell := cm.detectShell()
+	shell := DetectShell()
 	if shell == "" {
 		output.Warning("Could not detect shell, skipping completion installation")
 		return nil
@@ -104,188 +123,15 @@ func (cm *CompletionManager) InstallCompletion() error {
 
 	output.Info("Installing %s completion...", shell)
 
-	switch CompletionType(shell) {
-	case CompletionBash:
-		return cm.installBashCompletion()
-	case CompletionZsh:
-		return cm.installZshCompletion()
-	case CompletionFish:
-		return cm.installFishCompletion()
-	default:
-		output.Warning("Unsupported shell %s, skipping completion installation", shell)
-		return nil
-	}
-}
-
-// detectShell attempts to detect the user's current shell
-func (cm *CompletionManager) detectShell() string {
-	// Check SHELL environment variable
-	if shell := os.Getenv("SHELL"); shell != "" {
-		return filepath.Base(shell)
-	}
-
-	// Fallback detection methods could be added here
-	return ""
-}
-
-// installBashCompletion installs bash completion
-func (cm *CompletionManager) installBashCompletion() error {
-	// Try common bash completion directories
-	completionDirs := []string{
-		"/usr/local/etc/bash_completion.d",                     // macOS with Homebrew
-		"/etc/bash_completion.d",                               // Linux
-		filepath.Join(os.Getenv("HOME"), ".bash_completion.d"), // User directory
-	}
-
-	for _, dir := range completionDirs {
-		if cm.dirExists(dir) || cm.canCreateDir(dir) {
-			completionFile := filepath.Join(dir, branding.CommandName)
-			return cm.writeCompletionFile(completionFile, CompletionBash)
-		}
-	}
-
-	// Fallback: Add to ~/.bash_completion
-	bashCompletion := filepath.Join(os.Getenv("HOME"), ".bash_completion")
-	return cm.appendCompletionToFile(bashCompletion, CompletionBash)
-}
-
-// installZshCompletion installs zsh completion
-func (cm *CompletionManager) installZshCompletion() error {
-	// Get zsh fpath
-	fpath := os.Getenv("FPATH")
-	if fpath == "" {
-		// Default zsh completion directory
-		fpath = "/usr/local/share/zsh/site-functions"
-	}
-
-	// Use first directory in fpath
-	fpathDirs := strings.Split(fpath, ":")
-	if len(fpathDirs) > 0 {
-		completionFile := filepath.Join(fpathDirs[0], fmt.Sprintf("_%s", branding.CommandName))
-		if cm.dirExists(fpathDirs[0]) || cm.canCreateDir(fpathDirs[0]) {
-			return cm.writeCompletionFile(completionFile, CompletionZsh)
-		}
-	}
-
-	// Fallback: User zsh directory
-	userZshDir := filepath.Join(os.Getenv("HOME"), ".zsh", "completions")
-	if err := os.MkdirAll(userZshDir, 0755); err == nil {
-		completionFile := filepath.Join(userZshDir, fmt.Sprintf("_%s", branding.CommandName))
-		if err := cm.writeCompletionFile(completionFile, CompletionZsh); err == nil {
-			output.Info("Add to your ~/.zshrc: fpath=(~/.zsh/completions $fpath)")
-			return nil
-		}
-	}
-
-	return glideErrors.NewPermissionError(
-		"~/.zsh/completions",
-		"could not install zsh completion",
-		glideErrors.WithSuggestions(
-			fmt.Sprintf("Run: %s completion zsh > ~/.zsh/completions/_%s", branding.CommandName, branding.CommandName),
-			"Add to ~/.zshrc: fpath=(~/.zsh/completions $fpath)",
-		),
-	)
-}
-
-// installFishCompletion installs fish completion
-func (cm *CompletionManager) installFishCompletion() error {
-	fishDir := filepath.Join(os.Getenv("HOME"), ".config", "fish", "completions")
-	if err := os.MkdirAll(fishDir, 0755); err != nil {
-		return glideErrors.NewPermissionError(
-			fishDir,
-			"could not create fish completions directory",
-			glideErrors.WithSuggestions("Run: mkdir -p ~/.config/fish/completions"),
-		)
-	}
-
-	completionFile := filepath.Join(fishDir, fmt.Sprintf("%s.fish", branding.CommandName))
-	return cm.writeCompletionFile(completionFile, CompletionFish)
-}
-
-// writeCompletionFile writes completion script to a file
-func (cm *CompletionManager) writeCompletionFile(filename string, shell CompletionType) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return glideErrors.NewPermissionError(
-			filename,
-			fmt.Sprintf("could not create completion file: %s", filename),
-			glideErrors.WithSuggestions(
-				fmt.Sprintf("Try: sudo %s completion %s > %s", branding.CommandName, shell, filename),
-				fmt.Sprintf("Or install manually with: %s completion %s", branding.CommandName, string(shell)),
-			),
-		)
-	}
-	defer file.Close()
-
-	// Create a temporary cobra command that matches the real structure
-	rootCmd := cm.createMockRootCommand()
-
-	switch shell {
-	case CompletionBash:
-		err = rootCmd.GenBashCompletion(file)
-	case CompletionZsh:
-		err = rootCmd.GenZshCompletion(file)
-	case CompletionFish:
-		err = rootCmd.GenFishCompletion(file, true)
-	}
-
+	result, err := cm.Install(cm.createMockRootCommand(), shell)
 	if err != nil {
-		os.Remove(filename) // Clean up on error
-		return fmt.Errorf("failed to generate completion: %w", err)
+		return err
 	}
 
-	output.Success("Installed %s completion: %s", shell, filename)
+	cm.printInstallResult(result)
 	return nil
 }
 
-// appendCompletionToFile appends completion source to existing file
-func (cm *CompletionManager) appendCompletionToFile(filename string, shell CompletionType) error {
-	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return glideErrors.NewPermissionError(
-			filename,
-			fmt.Sprintf("could not open completion file: %s", filename),
-			glideErrors.WithSuggestions("Check file permissions"),
-		)
-	}
-	defer file.Close()
-
-	// Add source line for completion
-	sourceLine := fmt.Sprintf("\n# %s completion\nsource <(%s completion %s)\n", branding.ProjectName, branding.CommandName, shell)
-	if _, err := file.WriteString(sourceLine); err != nil {
-		return fmt.Errorf("failed to write completion source: %w", err)
-	}
-
-	output.Success("Added %s completion source to: %s", shell, filename)
-	output.Info("Restart your shell or run: source %s", filename)
-	return nil
-}
-
-// dirExists checks if a directory exists
-func (cm *CompletionManager) dirExists(dir string) bool {
-	info, err := os.Stat(dir)
-	return err == nil && info.IsDir()
-}
-
-// canCreateDir checks if we can create a directory
-func (cm *CompletionManager) canCreateDir(dir string) bool {
-	parent := filepath.Dir(dir)
-	info, err := os.Stat(parent)
-	if err != nil {
-		return false
-	}
-
-	// Check if parent is writable (simplified check)
-	testFile := filepath.Join(parent, fmt.Sprintf(".%s_test", branding.CommandName))
-	if file, err := os.Create(testFile); err == nil {
-		file.Close()
-		os.Remove(testFile)
-		return true
-	}
-
-	return info.Mode().Perm()&0200 != 0 // Check write permission
-}
-
 // setupCompletions configures completion functions for cobra commands
 // func (cm *CompletionManager) setupCompletions(rootCmd *cobra.Command) {
 // 	// Add completion for format flag
@@ -372,6 +218,7 @@ func (cm *CompletionManager) getConfigKeyCompletions() []string {
 		"defaults.worktree.auto_setup",
 		"defaults.worktree.copy_env",
 		"defaults.worktree.run_migrations",
+		"defaults.worktree.sync_strategy",
 		"default_project",
 	}
 }
@@ -433,13 +280,13 @@ func (cm *CompletionManager) createMockRootCommand() *cobra.Command {
 
 	// Add global flags with completion
 	rootCmd.PersistentFlags().String("config", "", fmt.Sprintf("config file (default is $HOME/%s)", branding.ConfigFileName))
-	rootCmd.PersistentFlags().String("format", "table", "Output format (table, json, yaml, plain)")
+	rootCmd.PersistentFlags().String("format", "table", fmt.Sprintf("Output format (%s)", strings.Join(output.FormatNames(), ", ")))
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress non-error output")
 	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
 
 	// Register format flag completion
 	rootCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"table", "json", "yaml", "plain"}, cobra.ShellCompDirectiveNoFileComp
+		return output.FormatNames(), cobra.ShellCompDirectiveNoFileComp
 	})
 
 	// Add mock commands for completion structure
@@ -0,0 +1,384 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// CompletionInstallResult describes what an installation changed
+type CompletionInstallResult struct {
+	Shell          CompletionType
+	CompletionFile string
+	RCFile         string // Empty when the shell loads completions without rc changes
+	RCUpdated      bool   // False when the marker block was already up to date
+}
+
+// completionTargets holds the per-shell install locations
+type completionTargets struct {
+	completionFile string
+	rcFile         string
+	rcBlock        string
+}
+
+// NewCompletionInstallCommand creates the `completion install` subcommand
+func NewCompletionInstallCommand(manager *CompletionManager) *cobra.Command {
+	var shellFlag string
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install shell completions for the current user",
+		Long: fmt.Sprintf(`Detect your shell, write the completion script to the standard per-user
+location and register it in your shell's rc file.
+
+The rc file is updated with a marked block, so running this command again
+refreshes the completion script without duplicating configuration.
+
+Examples:
+  %s completion install                 # Detect the shell from $SHELL
+  %s completion install --shell zsh     # Install for a specific shell`,
+			branding.CommandName, branding.CommandName),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shell := CompletionType(shellFlag)
+			if shell == "" {
+				shell = DetectShell()
+			}
+			if shell == "" {
+				return glideErrors.NewConfigError(
+					"could not detect your shell",
+					glideErrors.WithSuggestions(
+						fmt.Sprintf("Run: %s completion install --shell <bash|zsh|fish|powershell>", branding.CommandName),
+					),
+				)
+			}
+
+			result, err := manager.Install(cmd.Root(), shell)
+			if err != nil {
+				return err
+			}
+
+			manager.printInstallResult(result)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&shellFlag, "shell", "", "Shell to install completions for (bash, zsh, fish, powershell)")
+	_ = cmd.RegisterFlagCompletionFunc("shell", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return supportedShells(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+// DetectShell determines the user's interactive shell.
+// It returns an empty CompletionType when the shell is unknown or unsupported.
+func DetectShell() CompletionType {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		name := strings.TrimSuffix(filepath.Base(shell), ".exe")
+		switch name {
+		case "bash":
+			return CompletionBash
+		case "zsh":
+			return CompletionZsh
+		case "fish":
+			return CompletionFish
+		case "pwsh", "powershell":
+			return CompletionPowerShell
+		}
+	}
+
+	// PowerShell does not set $SHELL, but always exports PSModulePath
+	if runtime.GOOS == "windows" || os.Getenv("PSModulePath") != "" {
+		return CompletionPowerShell
+	}
+
+	return ""
+}
+
+// supportedShells returns the shells completions can be generated for
+func supportedShells() []string {
+	return []string{
+		string(CompletionBash),
+		string(CompletionZsh),
+		string(CompletionFish),
+		string(CompletionPowerShell),
+	}
+}
+
+// Install writes the completion script for the shell and registers it in the
+// shell's rc file. It is safe to run repeatedly.
+func (cm *CompletionManager) Install(rootCmd *cobra.Command, shell CompletionType) (*CompletionInstallResult, error) {
+	targets, err := cm.targets(shell)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(targets.completionFile), 0755); err != nil {
+		return nil, glideErrors.NewPermissionError(
+			filepath.Dir(targets.completionFile),
+			"could not create completion directory",
+		)
+	}
+
+	if err := cm.writeCompletionScript(rootCmd, shell, targets.completionFile); err != nil {
+		return nil, err
+	}
+
+	result := &CompletionInstallResult{
+		Shell:          shell,
+		CompletionFile: targets.completionFile,
+		RCFile:         targets.rcFile,
+	}
+
+	if targets.rcFile != "" {
+		updated, err := upsertMarkerBlock(targets.rcFile, targets.rcBlock)
+		if err != nil {
+			return nil, glideErrors.NewPermissionError(
+				targets.rcFile,
+				fmt.Sprintf("could not update %s", targets.rcFile),
+				glideErrors.WithError(err),
+			)
+		}
+		result.RCUpdated = updated
+	}
+
+	return result, nil
+}
+
+// IsInstalled reports whether completions installed by Install are present
+// for the given shell.
+func (cm *CompletionManager) IsInstalled(shell CompletionType) bool {
+	targets, err := cm.targets(shell)
+	if err != nil {
+		return false
+	}
+
+	if _, err := os.Stat(targets.completionFile); err != nil {
+		return false
+	}
+
+	if targets.rcFile == "" {
+		return true
+	}
+
+	data, err := os.ReadFile(targets.rcFile)
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(data, []byte(completionMarkerStart()))
+}
+
+// targets resolves where completion files and rc blocks go for a shell
+func (cm *CompletionManager) targets(shell CompletionType) (*completionTargets, error) {
+	home := cm.homeDir
+	if home == "" {
+		var err error
+		home, err = os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine home directory: %w", err)
+		}
+	}
+
+	name := branding.CommandName
+
+	switch shell {
+	case CompletionBash:
+		file := filepath.Join(xdgDataHome(home), "bash-completion", "completions", name)
+		return &completionTargets{
+			completionFile: file,
+			rcFile:         filepath.Join(home, ".bashrc"),
+			rcBlock:        fmt.Sprintf("[ -f %q ] && . %q", file, file),
+		}, nil
+
+	case CompletionZsh:
+		dir := filepath.Join(home, ".zsh", "completions")
+		return &completionTargets{
+			completionFile: filepath.Join(dir, "_"+name),
+			rcFile:         filepath.Join(home, ".zshrc"),
+			rcBlock:        fmt.Sprintf("fpath=(%q $fpath)\nautoload -Uz compinit && compinit", dir),
+		}, nil
+
+	case CompletionFish:
+		// Fish autoloads everything in its completions directory
+		return &completionTargets{
+			completionFile: filepath.Join(xdgConfigHome(home), "fish", "completions", name+".fish"),
+		}, nil
+
+	case CompletionPowerShell:
+		profileDir := filepath.Join(xdgConfigHome(home), "powershell")
+		if runtime.GOOS == "windows" {
+			profileDir = filepath.Join(home, "Documents", "PowerShell")
+		}
+		file := filepath.Join(profileDir, name+"-completion.ps1")
+		return &completionTargets{
+			completionFile: file,
+			rcFile:         filepath.Join(profileDir, "Microsoft.PowerShell_profile.ps1"),
+			rcBlock:        fmt.Sprintf("if (Test-Path '%s') { . '%s' }", file, file),
+		}, nil
+
+	default:
+		return nil, glideErrors.NewConfigError(
+			fmt.Sprintf("unsupported shell: %s", shell),
+			glideErrors.WithSuggestions(fmt.Sprintf("Use one of: %s", strings.Join(supportedShells(), ", "))),
+		)
+	}
+}
+
+// writeCompletionScript generates the completion script for rootCmd into filename
+func (cm *CompletionManager) writeCompletionScript(rootCmd *cobra.Command, shell CompletionType, filename string) error {
+	var buf bytes.Buffer
+	if err := generateCompletion(rootCmd, shell, &buf); err != nil {
+		return fmt.Errorf("failed to generate completion: %w", err)
+	}
+
+	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		return glideErrors.NewPermissionError(
+			filename,
+			fmt.Sprintf("could not write completion file: %s", filename),
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Install manually with: %s completion %s", branding.CommandName, shell),
+			),
+		)
+	}
+
+	return nil
+}
+
+// printInstallResult reports what Install changed
+func (cm *CompletionManager) printInstallResult(result *CompletionInstallResult) {
+	output.Success("Installed %s completion: %s", result.Shell, result.CompletionFile)
+
+	switch {
+	case result.RCFile == "":
+		output.Info("Completions load automatically in new %s sessions", result.Shell)
+	case result.RCUpdated:
+		output.Info("Updated %s", result.RCFile)
+		output.Info("Restart your shell or run: source %s", result.RCFile)
+	default:
+		output.Info("%s is already configured", result.RCFile)
+	}
+}
+
+// completionMarkerStart returns the opening line of the managed rc block
+func completionMarkerStart() string {
+	return fmt.Sprintf("# >>> %s completion >>>", branding.CommandName)
+}
+
+// completionMarkerEnd returns the closing line of the managed rc block
+func completionMarkerEnd() string {
+	return fmt.Sprintf("# <<< %s completion <<<", branding.CommandName)
+}
+
+// upsertMarkerBlock inserts or replaces the managed completion block in an rc
+// file. It returns false when the file already contained an identical block.
+func upsertMarkerBlock(path, content string) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	start, end := completionMarkerStart(), completionMarkerEnd()
+	block := start + "\n" + content + "\n" + end + "\n"
+	text := string(existing)
+
+	var updated string
+	startIdx := strings.Index(text, start)
+	endIdx := strings.Index(text, end)
+	if startIdx >= 0 && endIdx > startIdx {
+		tail := text[endIdx+len(end):]
+		tail = strings.TrimPrefix(tail, "\n")
+		updated = text[:startIdx] + block + tail
+	} else {
+		updated = text
+		if updated != "" && !strings.HasSuffix(updated, "\n") {
+			updated += "\n"
+		}
+		if updated != "" {
+			updated += "\n"
+		}
+		updated += block
+	}
+
+	if updated == text {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, []byte(updated), 0644)
+}
+
+// xdgDataHome returns $XDG_DATA_HOME or its default under home
+func xdgDataHome(home string) string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return dir
+	}
+	return filepath.Join(home, ".local", "share")
+}
+
+// xdgConfigHome returns $XDG_CONFIG_HOME or its default under home
+func xdgConfigHome(home string) string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	return filepath.Join(home, ".config")
+}
+
+// installedFiles returns the completion file and the rc file holding the
+// managed block that Install left for a shell; either is empty when absent
+func (cm *CompletionManager) installedFiles(shell CompletionType) (completionFile, rcFile string) {
+	targets, err := cm.targets(shell)
+	if err != nil {
+		return "", ""
+	}
+
+	if _, err := os.Stat(targets.completionFile); err == nil {
+		completionFile = targets.completionFile
+	}
+	if targets.rcFile != "" {
+		if data, err := os.ReadFile(targets.rcFile); err == nil && bytes.Contains(data, []byte(completionMarkerStart())) {
+			rcFile = targets.rcFile
+		}
+	}
+	return completionFile, rcFile
+}
+
+// removeMarkerBlock deletes the managed completion block from an rc file,
+// leaving the rest of the file untouched. It returns false when the file
+// has no block.
+func removeMarkerBlock(path string) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	start, end := completionMarkerStart(), completionMarkerEnd()
+	text := string(existing)
+	startIdx := strings.Index(text, start)
+	endIdx := strings.Index(text, end)
+	if startIdx < 0 || endIdx < startIdx {
+		return false, nil
+	}
+
+	// Install separates the block from earlier content with a blank line
+	head := text[:startIdx]
+	if strings.HasSuffix(head, "\n\n") {
+		head = head[:len(head)-1]
+	}
+	tail := strings.TrimPrefix(text[endIdx+len(end):], "\n")
+	return true, os.WriteFile(path, []byte(head+tail), 0644)
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	internalContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCompletionManager(t *testing.T) *CompletionManager {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	cm := NewCompletionManager(&internalContext.ProjectContext{}, &config.Config{})
+	cm.homeDir = t.TempDir()
+	return cm
+}
+
+func TestDetectShell(t *testing.T) {
+	tests := []struct {
+		shell    string
+		expected CompletionType
+	}{
+		{"/bin/bash", CompletionBash},
+		{"/usr/local/bin/zsh", CompletionZsh},
+		{"/opt/homebrew/bin/fish", CompletionFish},
+		{"/usr/bin/pwsh", CompletionPowerShell},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			t.Setenv("SHELL", tt.shell)
+			assert.Equal(t, tt.expected, DetectShell())
+		})
+	}
+}
+
+func TestCompletionManager_Install(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "glide"}
+	rootCmd.AddCommand(&cobra.Command{Use: "up", Run: func(*cobra.Command, []string) {}})
+
+	t.Run("bash writes script and rc block", func(t *testing.T) {
+		cm := newTestCompletionManager(t)
+
+		result, err := cm.Install(rootCmd, CompletionBash)
+		require.NoError(t, err)
+		assert.True(t, result.RCUpdated)
+		assert.FileExists(t, result.CompletionFile)
+
+		rc, err := os.ReadFile(filepath.Join(cm.homeDir, ".bashrc"))
+		require.NoError(t, err)
+		assert.Contains(t, string(rc), completionMarkerStart())
+		assert.Contains(t, string(rc), result.CompletionFile)
+		assert.True(t, cm.IsInstalled(CompletionBash))
+	})
+
+	t.Run("reinstall is idempotent", func(t *testing.T) {
+		cm := newTestCompletionManager(t)
+		rcPath := filepath.Join(cm.homeDir, ".zshrc")
+		require.NoError(t, os.WriteFile(rcPath, []byte("export EDITOR=vim"), 0644))
+
+		_, err := cm.Install(rootCmd, CompletionZsh)
+		require.NoError(t, err)
+		result, err := cm.Install(rootCmd, CompletionZsh)
+		require.NoError(t, err)
+		assert.False(t, result.RCUpdated)
+
+		rc, err := os.ReadFile(rcPath)
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(rc), completionMarkerStart()))
+		assert.True(t, strings.HasPrefix(string(rc), "export EDITOR=vim\n"))
+	})
+
+	t.Run("fish needs no rc changes", func(t *testing.T) {
+		cm := newTestCompletionManager(t)
+
+		result, err := cm.Install(rootCmd, CompletionFish)
+		require.NoError(t, err)
+		assert.Empty(t, result.RCFile)
+		assert.True(t, cm.IsInstalled(CompletionFish))
+	})
+
+	t.Run("unsupported shell", func(t *testing.T) {
+		cm := newTestCompletionManager(t)
+
+		_, err := cm.Install(rootCmd, CompletionType("tcsh"))
+		assert.Error(t, err)
+		assert.False(t, cm.IsInstalled(CompletionType("tcsh")))
+	})
+}
+
+func TestUpsertMarkerBlock_ReplacesExistingBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rc")
+	original := "before\n\n" + completionMarkerStart() + "\nold\n" + completionMarkerEnd() + "\nafter\n"
+	require.NoError(t, os.WriteFile(path, []byte(original), 0644))
+
+	changed, err := upsertMarkerBlock(path, "new")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "before\n\n"+completionMarkerStart()+"\nnew\n"+completionMarkerEnd()+"\nafter\n", string(data))
+}
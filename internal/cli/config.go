@@ -11,6 +11,7 @@ import (
 	"github.com/glide-cli/glide/v3/internal/config"
 	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
 	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/update"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -74,8 +75,10 @@ Examples:
   glide config set default_project myproject
   glide config set defaults.docker.auto_start true
   glide config set defaults.test.processes 10
-  glide config set projects.myproject.path /path/to/project`,
+  glide config set projects.myproject.path /path/to/project
+  glide --dry-run config set defaults.test.processes 4   # Preview the change`,
 		Args:          cobra.ExactArgs(2),
+		Annotations:   map[string]string{DryRunAnnotation: "true"},
 		RunE:          cc.runSet,
 		SilenceUsage:  true,
 		SilenceErrors: true,
@@ -136,6 +139,14 @@ func (cc *ConfigCommand) runSet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	value := args[1]
 
+	// Remember the current config for --dry-run previews
+	before := ""
+	if cc.cfg != nil {
+		// Safe to ignore: the preview only needs what can be marshaled
+		data, _ := yaml.Marshal(cc.cfg)
+		before = string(data)
+	}
+
 	// Load current config or create new one
 	if cc.cfg == nil {
 		cc.cfg = &config.Config{
@@ -167,6 +178,26 @@ func (cc *ConfigCommand) runSet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Preview the change without saving it
+	if IsDryRun(cmd) {
+		after, err := yaml.Marshal(cc.cfg)
+		if err != nil {
+			return glideErrors.Wrap(err, "failed to marshal config")
+		}
+		diff := output.DiffWithOptions(before, string(after), output.DiffOptions{
+			OldName: cc.cfgPath,
+			NewName: cc.cfgPath + " (after set)",
+			Context: output.DefaultDiffContext,
+		})
+		if diff == "" {
+			output.Info("%s is already %s; nothing would change", key, value)
+			return nil
+		}
+		output.Raw(diff)
+		output.Info("Dry run: %s was not changed", cc.cfgPath)
+		return nil
+	}
+
 	// Save the configuration
 	if err := cc.save(); err != nil {
 		return glideErrors.Wrap(err, "failed to save configuration",
@@ -227,6 +258,24 @@ func (cc *ConfigCommand) runList(cmd *cobra.Command, args []string) error {
 
 	output.Println("  Colors:")
 	output.Printf("    Enabled: %s\n", cc.cfg.Defaults.Colors.Enabled)
+	output.Printf("  Accessible: %v\n", cc.cfg.Defaults.Accessible)
+
+	output.Println("  Update:")
+	channel := cc.cfg.Defaults.Update.Channel
+	if channel == "" {
+		channel = update.ChannelStable
+	}
+	output.Printf("    Channel: %s\n", channel)
+	output.Printf("  Telemetry: %v\n", cc.cfg.Defaults.Telemetry.Enabled)
+
+	output.Println("  Sandbox:")
+	output.Printf("    Untrusted: %v\n", cc.cfg.Defaults.Sandbox.Untrusted)
+	if cc.cfg.Defaults.Sandbox.Image != "" {
+		output.Printf("    Image: %s\n", cc.cfg.Defaults.Sandbox.Image)
+	}
+	if len(cc.cfg.Defaults.Sandbox.Env) > 0 {
+		output.Printf("    Env: %s\n", strings.Join(cc.cfg.Defaults.Sandbox.Env, ", "))
+	}
 
 	output.Println("  Worktree:")
 	output.Printf("    Auto Setup: %v\n", cc.cfg.Defaults.Worktree.AutoSetup)
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+// guardFlags confirm destructive commands and are never set from a config
+var guardFlags = map[string]bool{"force": true, "yes": true}
+
+// ApplyConfigFlags gives the flags of the command being run the defaults
+// configured under flags: in ~/.glide.yml and the project's .glide.yml, the
+// project's winning. Flags given on the command line keep their values. The
+// project's defaults only apply once the project is trusted.
+func ApplyConfigFlags(cmd *cobra.Command, cfg *config.Config) error {
+	project := localProjectConfig()
+	if len(project.Flags[commandKey(cmd)]) > 0 {
+		cwd, _ := os.Getwd()
+		if dir := projectTrustDir(cwd); dir != "" {
+			if err := requireTrust(dir); err != nil {
+				logging.Warn("Ignoring flag defaults of an untrusted project", "command", commandKey(cmd), "dir", dir)
+				project = nil
+			}
+		}
+	}
+	defaults := configFlagDefaults(cfg, project)
+	return applyFlagDefaults(cmd, defaults[commandKey(cmd)])
+}
+
+// isGuardFlag reports whether a flag confirms or widens what a
+// destructive command deletes, so a config must not set it
+func isGuardFlag(cmd *cobra.Command, name string) bool {
+	if guardFlags[name] {
+		return true
+	}
+	for _, annotation := range []string{DestructiveAnnotation, TypedConfirmAnnotation} {
+		for _, flag := range strings.Split(cmd.Annotations[annotation], ",") {
+			if strings.TrimSpace(flag) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// configFlagDefaults merges the flag defaults of the global and project
+// configs
+func configFlagDefaults(global, project *config.Config) map[string]map[string]interface{} {
+	merged := make(map[string]map[string]interface{})
+	for _, cfg := range []*config.Config{global, project} {
+		if cfg == nil {
+			continue
+		}
+		for command, flags := range cfg.Flags {
+			if merged[command] == nil {
+				merged[command] = make(map[string]interface{})
+			}
+			for name, value := range flags {
+				merged[command][name] = value
+			}
+		}
+	}
+	return merged
+}
+
+// commandKey returns the path a command is configured under, e.g.
+// "worktree sync"
+func commandKey(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// applyFlagDefaults sets each flag the command line left alone
+func applyFlagDefaults(cmd *cobra.Command, defaults map[string]interface{}) error {
+	if len(defaults) == 0 || cmd.DisableFlagParsing {
+		return nil
+	}
+
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			logging.Warn("Ignoring configured default for unknown flag", "command", commandKey(cmd), "flag", name)
+			continue
+		}
+		if flag.Changed {
+			continue
+		}
+		if isGuardFlag(cmd, name) {
+			logging.Warn("Ignoring configured default for a flag of destructive operations", "command", commandKey(cmd), "flag", name)
+			continue
+		}
+
+		for _, value := range flagValues(defaults[name]) {
+			if err := cmd.Flags().Set(name, value); err != nil {
+				return glideErrors.NewConfigError(
+					fmt.Sprintf("invalid default for --%s of %s in flags configuration", name, commandKey(cmd)),
+					glideErrors.WithError(err),
+					glideErrors.WithSuggestions(
+						fmt.Sprintf("Fix flags.%s.%s in .glide.yml or ~/.glide.yml", commandKey(cmd), name),
+						"Run with --no-config-flags to ignore configured flag defaults",
+					),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// flagValues renders a configured value the way it would be typed, once
+// per element for lists
+func flagValues(value interface{}) []string {
+	if list, ok := value.([]interface{}); ok {
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	}
+	return []string{fmt.Sprint(value)}
+}
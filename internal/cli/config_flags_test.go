@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFlagDefaults(t *testing.T) {
+	newCommand := func() *cobra.Command {
+		root := &cobra.Command{Use: "glide"}
+		logs := &cobra.Command{Use: "logs", Run: func(*cobra.Command, []string) {}}
+		logs.Flags().BoolP("follow", "f", false, "")
+		logs.Flags().Int("tail", 0, "")
+		logs.Flags().StringSlice("service", nil, "")
+		root.AddCommand(logs)
+		return logs
+	}
+
+	t.Run("fills in flags left alone", func(t *testing.T) {
+		cmd := newCommand()
+		require.NoError(t, cmd.ParseFlags([]string{"--tail", "50"}))
+		require.NoError(t, applyFlagDefaults(cmd, map[string]interface{}{
+			"follow":  true,
+			"tail":    10,
+			"service": []interface{}{"app", "worker"},
+			"missing": "x",
+		}))
+
+		follow, _ := cmd.Flags().GetBool("follow")
+		tail, _ := cmd.Flags().GetInt("tail")
+		services, _ := cmd.Flags().GetStringSlice("service")
+		assert.True(t, follow)
+		assert.Equal(t, 50, tail, "the command line wins")
+		assert.Equal(t, []string{"app", "worker"}, services)
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		cmd := newCommand()
+		err := applyFlagDefaults(cmd, map[string]interface{}{"tail": "lots"})
+		assert.ErrorContains(t, err, "--tail of logs")
+	})
+
+	t.Run("never sets guard flags", func(t *testing.T) {
+		cmd := newCommand()
+		cmd.Flags().Bool("force", false, "")
+		cmd.Flags().Bool("volumes", false, "")
+		MarkDestructive(cmd, nil, "volumes")
+		require.NoError(t, applyFlagDefaults(cmd, map[string]interface{}{"force": true, "volumes": true, "tail": 5}))
+
+		assert.False(t, cmd.Flags().Changed("force"))
+		assert.False(t, cmd.Flags().Changed("volumes"))
+		tail, _ := cmd.Flags().GetInt("tail")
+		assert.Equal(t, 5, tail)
+	})
+
+	t.Run("keys commands by path", func(t *testing.T) {
+		cmd := newCommand()
+		assert.Equal(t, "logs", commandKey(cmd))
+	})
+}
+
+func TestConfigFlagDefaults(t *testing.T) {
+	global := &config.Config{Flags: map[string]map[string]interface{}{
+		"logs": {"follow": true, "tail": 10},
+	}}
+	project := &config.Config{Flags: map[string]map[string]interface{}{
+		"logs": {"tail": 100},
+		"test": {"parallel": true},
+	}}
+
+	merged := configFlagDefaults(global, project)
+	assert.Equal(t, map[string]interface{}{"follow": true, "tail": 100}, merged["logs"])
+	assert.Equal(t, map[string]interface{}{"parallel": true}, merged["test"])
+	assert.Empty(t, configFlagDefaults(nil, &config.Config{}))
+}
+
+func TestApplyConfigFlags_UntrustedProject(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".glide.yml"), []byte("flags:\n  logs:\n    tail: 100\n"), 0644))
+	t.Chdir(dir)
+
+	newLogs := func() *cobra.Command {
+		root := &cobra.Command{Use: "glide"}
+		logs := &cobra.Command{Use: "logs", Run: func(*cobra.Command, []string) {}}
+		logs.Flags().Int("tail", 0, "")
+		root.AddCommand(logs)
+		return logs
+	}
+
+	stubTrust(t, false, false)
+	cmd := newLogs()
+	require.NoError(t, ApplyConfigFlags(cmd, &config.Config{}))
+	tail, _ := cmd.Flags().GetInt("tail")
+	assert.Equal(t, 0, tail, "an untrusted project's defaults are ignored")
+
+	t.Setenv("GLIDE_TRUST_ALL", "1")
+	cmd = newLogs()
+	require.NoError(t, ApplyConfigFlags(cmd, &config.Config{}))
+	tail, _ = cmd.Flags().GetInt("tail")
+	assert.Equal(t, 100, tail)
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewContextWatchCommand creates the context watch command, which streams
+// context changes for editors and other tools
+func NewContextWatchCommand(ctx *glideContext.ProjectContext) *cobra.Command {
+	var interval, debounce time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Stream changes to the project context as events",
+		Long: `Watch the project and print an event whenever its context changes:
+the checked-out branch, the compose files or their contents, or whether
+Docker is running. The first event carries the current context.
+
+Changes are reported once they have lasted for --debounce, so a burst of
+edits or a rebase yields one event. With --format json every event is one
+JSON object per line, for editor integrations and dashboards.
+
+Examples:
+  glide context watch
+  glide context watch --format json
+  glide context watch --debounce 3s`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if ctx == nil || ctx.ProjectRoot == "" {
+				return glideErrors.NewConfigError("watching the context requires a project",
+					glideErrors.WithSuggestions("Run this command from inside your project directory"),
+				)
+			}
+
+			watcher := glideContext.NewWatcher(ctx)
+			watcher.Interval = interval
+			watcher.Debounce = debounce
+
+			runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			emit := watchEventWriter(os.Stdout, output.GetFormat())
+			return watcher.Watch(runCtx, func(event glideContext.WatchEvent) {
+				if err := emit(event); err != nil {
+					output.Warning("Could not write event: %v", err)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 500*time.Millisecond, "How often to check the branch and compose files")
+	cmd.Flags().DurationVar(&debounce, "debounce", time.Second, "How long a change must last before it is reported")
+
+	return cmd
+}
+
+// watchEventWriter returns a function writing events in a format: one JSON
+// object per line, YAML documents, or one line of text each
+func watchEventWriter(w io.Writer, format output.Format) func(glideContext.WatchEvent) error {
+	switch format {
+	case output.FormatJSON:
+		encoder := json.NewEncoder(w)
+		return func(event glideContext.WatchEvent) error { return encoder.Encode(event) }
+	case output.FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		return func(event glideContext.WatchEvent) error { return encoder.Encode(event) }
+	}
+
+	return func(event glideContext.WatchEvent) error {
+		detail := event.Detail
+		if event.Type == glideContext.WatchStarted {
+			detail = fmt.Sprintf("%s on %s, docker running: %v", event.Context.ProjectRoot, event.Context.Branch, event.Context.DockerRunning)
+		}
+		_, err := fmt.Fprintf(w, "%s  %-16s %s\n", event.Time.Local().Format("15:04:05"), event.Type, detail)
+		return err
+	}
+}
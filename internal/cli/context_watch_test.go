@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchEventWriter(t *testing.T) {
+	event := glideContext.WatchEvent{
+		Type:    glideContext.BranchChanged,
+		Time:    time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC),
+		Detail:  "main -> feature/login",
+		Context: glideContext.WatchState{ProjectRoot: "/work/shop", Branch: "feature/login", DockerRunning: true},
+	}
+
+	var buf bytes.Buffer
+	write := watchEventWriter(&buf, output.FormatJSON)
+	require.NoError(t, write(event))
+	require.NoError(t, write(event))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2, "one JSON object per line")
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(lines[0], &decoded))
+	assert.Equal(t, "branch_changed", decoded["type"])
+	assert.Equal(t, "feature/login", decoded["context"].(map[string]interface{})["branch"])
+
+	buf.Reset()
+	require.NoError(t, watchEventWriter(&buf, output.FormatTable)(event))
+	assert.Contains(t, buf.String(), "branch_changed")
+	assert.Contains(t, buf.String(), "main -> feature/login")
+}
+
+func TestContextWatchCommand_RequiresProject(t *testing.T) {
+	cmd := NewContextWatchCommand(nil)
+	cmd.SetArgs([]string{})
+	assert.Error(t, cmd.Execute())
+}
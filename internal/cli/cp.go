@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/progress"
+	"github.com/spf13/cobra"
+)
+
+// copyFromContainer and copyToContainer are replaced in tests
+var (
+	copyFromContainer = docker.CopyFromContainer
+	copyToContainer   = docker.CopyToContainer
+)
+
+// copyPath is one side of `glide cp`, a host path or a path in a service's
+// container
+type copyPath struct {
+	Service string
+	Path    string
+}
+
+// String formats the path as it is given on the command line
+func (p copyPath) String() string {
+	if p.Service == "" {
+		return p.Path
+	}
+	return p.Service + ":" + p.Path
+}
+
+// parseCopyPath parses service:/path or a host path. Host paths containing
+// a colon must start with / or ., e.g. ./a:b.
+func parseCopyPath(arg string) (copyPath, error) {
+	if strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, ".") || !strings.Contains(arg, ":") {
+		return copyPath{Path: arg}, nil
+	}
+	service, p, _ := strings.Cut(arg, ":")
+	if service == "" || p == "" {
+		return copyPath{}, glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("invalid container path %q", arg),
+			glideErrors.WithSuggestions("Name a service and a path in its container, e.g. php:/var/www/html/.env"),
+		)
+	}
+	return copyPath{Service: service, Path: p}, nil
+}
+
+// CpCommand copies files between the host and a service's container
+type CpCommand struct {
+	ctx *glideContext.ProjectContext
+	cfg *config.Config
+}
+
+// NewCpCommand creates the cp command
+func NewCpCommand(ctx *glideContext.ProjectContext, cfg *config.Config) *cobra.Command {
+	cc := &CpCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "cp <service>:<path> <host-path> | <host-path> <service>:<path>",
+		Short: "Copy files between the host and a service's container",
+		Long: `Copy a file or directory between the host and the running container of a
+compose service of the current project, or of the current worktree in
+multi-worktree mode. Progress is shown while large transfers run.
+
+A path copies into a destination that is an existing directory, or that ends
+in a slash; otherwise it is copied as the destination. Files from the
+container cannot be written outside the host destination, even through
+symlinks.
+
+Examples:
+  glide cp php:/var/www/html/storage/logs ./logs
+  glide cp mysql:/etc/mysql/my.cnf .
+  glide cp ./fixtures php:/tmp/
+  glide cp .env.testing php:/var/www/html/.env`,
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.Execute(args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+// Execute copies src to dest
+func (cc *CpCommand) Execute(srcArg, destArg string) error {
+	if cc.ctx == nil || cc.ctx.ProjectRoot == "" {
+		return glideErrors.New(glideErrors.TypeMissing, "not in a project",
+			glideErrors.WithSuggestions("Run this command from inside your project directory"),
+		)
+	}
+
+	src, err := parseCopyPath(srcArg)
+	if err != nil {
+		return err
+	}
+	dest, err := parseCopyPath(destArg)
+	if err != nil {
+		return err
+	}
+	if (src.Service == "") == (dest.Service == "") {
+		return glideErrors.New(glideErrors.TypeInvalid, "exactly one of the paths must be in a container",
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Copy from a container: %s cp php:/path ./local", branding.CommandName),
+				fmt.Sprintf("Copy to a container: %s cp ./local php:/path", branding.CommandName),
+			),
+		)
+	}
+
+	service := src.Service
+	if service == "" {
+		service = dest.Service
+	}
+	container, err := serviceContainer(cc.ctx, service)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	if src.Service == "" {
+		if total, err = hostPathSize(src.Path); err != nil {
+			return glideErrors.NewFileNotFoundError(src.Path, glideErrors.WithError(err))
+		}
+	}
+
+	message := fmt.Sprintf("Copying %s to %s", src, dest)
+	spinner := progress.NewSpinner(message)
+	spinner.Start()
+	report := func(copied int64) {
+		if total > 0 {
+			spinner.Update(fmt.Sprintf("%s (%s of %s)", message, output.Bytes(uint64(copied)), output.Bytes(uint64(total))))
+		} else {
+			spinner.Update(fmt.Sprintf("%s (%s)", message, output.Bytes(uint64(copied))))
+		}
+	}
+
+	if src.Service == "" {
+		err = copyToContainer(container, src.Path, dest.Path, report)
+	} else {
+		err = copyFromContainer(container, src.Path, dest.Path, report)
+	}
+	if err != nil {
+		spinner.Error(fmt.Sprintf("Failed to copy %s", src))
+		return glideErrors.NewDockerError(fmt.Sprintf("failed to copy %s to %s", src, dest),
+			glideErrors.WithError(err),
+			glideErrors.WithContext("container", container),
+		)
+	}
+	spinner.Success(fmt.Sprintf("Copied %s to %s", src, dest))
+	return nil
+}
+
+// hostPathSize returns the bytes of file content under p
+func hostPathSize(p string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(p, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCopyPath(t *testing.T) {
+	for arg, want := range map[string]copyPath{
+		"php:/var/log": {Service: "php", Path: "/var/log"},
+		"php:relative": {Service: "php", Path: "relative"},
+		"./logs":       {Path: "./logs"},
+		"logs":         {Path: "logs"},
+		"/tmp/a:b":     {Path: "/tmp/a:b"},
+		"./a:b":        {Path: "./a:b"},
+		".env.testing": {Path: ".env.testing"},
+	} {
+		got, err := parseCopyPath(arg)
+		require.NoError(t, err, arg)
+		assert.Equal(t, want, got, arg)
+	}
+
+	for _, arg := range []string{"php:", ":/var/log"} {
+		_, err := parseCopyPath(arg)
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid), arg)
+	}
+}
+
+// stubCopy records the copies instead of running them
+func stubCopy(t *testing.T, err error) *[]string {
+	t.Helper()
+	var calls []string
+	from, to := copyFromContainer, copyToContainer
+	copyFromContainer = func(container, src, dest string, progress func(int64)) error {
+		calls = append(calls, "from "+container+" "+src+" "+dest)
+		return err
+	}
+	copyToContainer = func(container, src, dest string, progress func(int64)) error {
+		calls = append(calls, "to "+container+" "+src+" "+dest)
+		return err
+	}
+	t.Cleanup(func() { copyFromContainer, copyToContainer = from, to })
+	return &calls
+}
+
+func TestCpCommand_Execute(t *testing.T) {
+	stubListContainers(t, docker.ProjectContainer{Name: "myapp-php-1", Service: "php", State: "running",
+		WorkingDir: "/src/myapp"})
+	cc := &CpCommand{ctx: &glideContext.ProjectContext{ProjectRoot: "/src/myapp"}}
+
+	local := filepath.Join(t.TempDir(), "fixtures.sql")
+	require.NoError(t, os.WriteFile(local, []byte("select 1;"), 0644))
+
+	calls := stubCopy(t, nil)
+	require.NoError(t, cc.Execute("php:/var/log", "./logs"))
+	require.NoError(t, cc.Execute(local, "php:/tmp/"))
+	assert.Equal(t, []string{
+		"from myapp-php-1 /var/log ./logs",
+		"to myapp-php-1 " + local + " /tmp/",
+	}, *calls)
+
+	err := cc.Execute(filepath.Join(t.TempDir(), "missing"), "php:/tmp/")
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeFileNotFound))
+
+	for _, args := range [][2]string{{"./a", "./b"}, {"php:/a", "php:/b"}} {
+		err := cc.Execute(args[0], args[1])
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid), "args %v", args)
+	}
+
+	stubCopy(t, errors.New("archive entry escapes"))
+	err = cc.Execute("php:/var/log", "./logs")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to copy php:/var/log to ./logs")
+}
+
+func TestCpCommand_OutsideProject(t *testing.T) {
+	cmd := NewCpCommand(&glideContext.ProjectContext{}, nil)
+	cmd.SetArgs([]string{"php:/var/log", "."})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeMissing))
+}
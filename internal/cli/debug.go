@@ -3,6 +3,9 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -26,6 +29,9 @@ func showContext(_ *cobra.Command, outputManager *output.Manager, projectContext
 	_ = outputManager.Info("=== Project Context ===")
 	_ = outputManager.Info("Working Directory: %s", ctx.WorkingDir)
 	_ = outputManager.Info("Project Root: %s", ctx.ProjectRoot)
+	if ctx.RootSource != "" {
+		_ = outputManager.Info("Root Source: %s", ctx.RootSource)
+	}
 	_ = outputManager.Info("Development Mode: %s", ctx.DevelopmentMode)
 	_ = outputManager.Info("Location: %s", ctx.Location)
 
@@ -38,6 +44,40 @@ func showContext(_ *cobra.Command, outputManager *output.Manager, projectContext
 		}
 	}
 
+	if ns, ok := worktreeNamespace(ctx); ok {
+		_ = outputManager.Info("")
+		_ = outputManager.Info("=== Namespace ===")
+		dir, _ := currentWorktree(ctx)
+		env := namespaceEnv(ns)
+		for _, key := range []string{composeProjectNameEnv, databaseNameEnv, volumePrefixEnv} {
+			line := fmt.Sprintf("%s: %s", key, env[key])
+			if value, set := os.LookupEnv(key); set && value != env[key] && !slices.Contains(namespaceApplied, key) {
+				line += fmt.Sprintf(" (overridden by the environment: %s)", value)
+			} else if !set && dotenvSets(filepath.Join(dir, ".env"), key) {
+				line += " (overridden by .env)"
+			}
+			_ = outputManager.Info("%s", line)
+		}
+		if len(ns.Collisions) > 0 {
+			_ = outputManager.Warning("Collides with worktree(s) %s; names are suffixed with a hash", strings.Join(ns.Collisions, ", "))
+		}
+		if shared := sharedServices(ctx); len(shared) > 0 {
+			_ = outputManager.Info("Shared Services: %s (compose project %s)", strings.Join(shared, ", "), sharedProjectName(ctx))
+		}
+	}
+
+	if len(ctx.Modules) > 0 {
+		_ = outputManager.Info("")
+		_ = outputManager.Info("=== Modules ===")
+		for _, m := range ctx.Modules {
+			current := ""
+			if m.Name == ctx.CurrentModule {
+				current = " (current)"
+			}
+			_ = outputManager.Info("%s: %s [%s]%s", m.Name, m.Path, m.Kind, current)
+		}
+	}
+
 	_ = outputManager.Info("")
 	_ = outputManager.Info("Docker Running: %v", ctx.DockerRunning)
 	if len(ctx.ComposeFiles) > 0 {
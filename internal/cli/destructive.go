@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/policy"
+	"github.com/glide-cli/glide/v3/pkg/audit"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/prompt"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// DestructiveAnnotation marks commands that delete data. The value is "true"
+// when every invocation is destructive, or a comma-separated list of flags
+// that make an invocation destructive (e.g. "volumes").
+const DestructiveAnnotation = "destructive"
+
+// TypedConfirmAnnotation marks highly destructive commands, which must be
+// confirmed by typing the project name. Its value works like
+// DestructiveAnnotation's.
+const TypedConfirmAnnotation = "typed-confirm"
+
+// DestructiveTargetsFunc describes exactly what an invocation would delete
+type DestructiveTargetsFunc func(cmd *cobra.Command, args []string) []string
+
+var (
+	destructiveTargetsMu sync.RWMutex
+	destructiveTargets   = make(map[*cobra.Command]DestructiveTargetsFunc)
+
+	// destructiveGuards holds the confirmation DestructiveGuard set up for
+	// each command, for commands that choose what to delete interactively
+	destructiveGuardsMu sync.RWMutex
+	destructiveGuards   = make(map[*cobra.Command]guardFunc)
+
+	// confirmDestructive, typedConfirm and stdinIsTerminal are replaced in
+	// tests
+	confirmDestructive = prompt.ConfirmDestructive
+	typedConfirm       = prompt.TypedConfirm
+	stdinIsTerminal    = func() bool { return term.IsTerminal(int(os.Stdin.Fd())) }
+)
+
+// MarkDestructive annotates cmd as destructive. With no flags every
+// invocation is destructive; otherwise only invocations that set one of the
+// flags are. targets may be nil.
+func MarkDestructive(cmd *cobra.Command, targets DestructiveTargetsFunc, flags ...string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	if len(flags) == 0 {
+		cmd.Annotations[DestructiveAnnotation] = "true"
+	} else {
+		cmd.Annotations[DestructiveAnnotation] = strings.Join(flags, ",")
+	}
+
+	if targets != nil {
+		destructiveTargetsMu.Lock()
+		destructiveTargets[cmd] = targets
+		destructiveTargetsMu.Unlock()
+	}
+}
+
+// MarkHighlyDestructive annotates cmd as destructive in a way that cannot
+// be undone, such as wiping volumes, so it is confirmed by typing the
+// project name rather than y/n. flags work as in MarkDestructive.
+func MarkHighlyDestructive(cmd *cobra.Command, targets DestructiveTargetsFunc, flags ...string) {
+	MarkDestructive(cmd, targets, flags...)
+	if len(flags) == 0 {
+		cmd.Annotations[TypedConfirmAnnotation] = "true"
+	} else {
+		cmd.Annotations[TypedConfirmAnnotation] = strings.Join(flags, ",")
+	}
+}
+
+// guardFunc confirms and audits one destructive invocation. It returns
+// false, with the error to return if any, when the command must not run.
+type guardFunc func(cmd *cobra.Command, args []string) (bool, error)
+
+// DestructiveGuard makes every destructive command ask for confirmation
+// before running, accept --force to skip the prompt, and record the outcome
+// in the audit log. Highly destructive commands, and those the policy
+// lists under confirm.typed, are confirmed by typing the project name.
+func DestructiveGuard(ctx *context.ProjectContext, logger *audit.Logger, pol *policy.Policy) Middleware {
+	guard := destructiveGuardFunc(ctx, logger, pol)
+	return Middleware{
+		Name: "audit",
+		Applies: func(cmd *cobra.Command) bool {
+			return cmd.Annotations[DestructiveAnnotation] != "" && runFunc(cmd) != nil
+		},
+		Setup: func(cmd *cobra.Command) {
+			if cmd.Flags().Lookup("force") == nil {
+				cmd.Flags().Bool("force", false, "Skip the confirmation prompt for destructive operations")
+			}
+			destructiveGuardsMu.Lock()
+			destructiveGuards[cmd] = guard
+			destructiveGuardsMu.Unlock()
+		},
+		Wrap: func(_ *cobra.Command, next RunFunc) RunFunc {
+			return func(c *cobra.Command, args []string) error {
+				if !isDestructiveInvocation(c) {
+					return next(c, args)
+				}
+				if proceed, err := guard(c, args); !proceed {
+					return err
+				}
+				return next(c, args)
+			}
+		},
+	}
+}
+
+// destructiveGuardFunc returns the confirmation DestructiveGuard runs
+func destructiveGuardFunc(ctx *context.ProjectContext, logger *audit.Logger, pol *policy.Policy) guardFunc {
+	return func(c *cobra.Command, args []string) (bool, error) {
+		entry := audit.Entry{
+			Command: strings.TrimSpace(c.CommandPath() + " " + strings.Join(args, " ")),
+			Targets: describeDestructiveTargets(c, args),
+		}
+
+		force, _ := c.Flags().GetBool("force")
+		switch {
+		case force:
+			entry.Outcome = audit.OutcomeForced
+
+		case !stdinIsTerminal():
+			entry.Outcome = audit.OutcomeRefused
+			recordAudit(logger, entry)
+			return false, glideErrors.New(glideErrors.TypePermission,
+				fmt.Sprintf("%s is destructive and needs confirmation, but no terminal is attached", c.CommandPath()),
+				glideErrors.WithExitCode(1),
+				glideErrors.WithSuggestions(
+					fmt.Sprintf("Re-run with --force to confirm: %s --force", entry.Command),
+					fmt.Sprintf("Preview first with: %s explain %s", branding.CommandName, strings.TrimPrefix(entry.Command, c.Root().Name()+" ")),
+				),
+			)
+
+		default:
+			showDestructiveTargets(entry.Targets)
+			operation := strings.TrimPrefix(entry.Command, c.Root().Name()+" ")
+			commandPath := strings.TrimPrefix(c.CommandPath(), c.Root().Name()+" ")
+			var confirmed bool
+			var err error
+			if pol.RequiresTypedConfirm(commandPath, annotatedInvocation(c, TypedConfirmAnnotation)) {
+				confirmed, err = typedConfirm(operation, typedConfirmPhrase(ctx, c.Name()))
+			} else {
+				confirmed, err = confirmDestructive(operation)
+			}
+			if err != nil || !confirmed {
+				entry.Outcome = audit.OutcomeCancelled
+				recordAudit(logger, entry)
+				return false, err
+			}
+			entry.Outcome = audit.OutcomeConfirmed
+		}
+
+		recordAudit(logger, entry)
+		return true, nil
+	}
+}
+
+// confirmSelectedDestructive confirms an invocation whose destructive flags
+// were chosen interactively, after the command set them, exactly as
+// DestructiveGuard confirms them on the command line: the same prompt and
+// the same audit entry. It returns false when nothing may be deleted.
+func confirmSelectedDestructive(cmd *cobra.Command, args []string) (bool, error) {
+	if !isDestructiveInvocation(cmd) {
+		return true, nil
+	}
+	destructiveGuardsMu.RLock()
+	guard := destructiveGuards[cmd]
+	destructiveGuardsMu.RUnlock()
+	if guard == nil {
+		// Not run through the middleware chain, so there is nothing to audit to
+		guard = destructiveGuardFunc(nil, nil, nil)
+	}
+	return guard(cmd, args)
+}
+
+// isDestructiveInvocation reports whether this invocation of a destructive
+// command will actually delete anything
+func isDestructiveInvocation(cmd *cobra.Command) bool {
+	if dryRun, err := cmd.Flags().GetBool("dry-run"); err == nil && dryRun {
+		return false
+	}
+	return annotatedInvocation(cmd, DestructiveAnnotation)
+}
+
+// annotatedInvocation reports whether an annotation holding "true" or a
+// list of flags applies to this invocation
+func annotatedInvocation(cmd *cobra.Command, annotation string) bool {
+	value := cmd.Annotations[annotation]
+	if value == "" {
+		return false
+	}
+	if value == "true" {
+		return true
+	}
+
+	for _, flag := range strings.Split(value, ",") {
+		if cmd.Flags().Changed(strings.TrimSpace(flag)) {
+			return true
+		}
+	}
+	return false
+}
+
+// typedConfirmPhrase returns what the user types to confirm a highly
+// destructive command: the project's name, or fallback outside a project
+func typedConfirmPhrase(ctx *context.ProjectContext, fallback string) string {
+	if name := projectName(ctx); name != "" {
+		return name
+	}
+	return fallback
+}
+
+// projectName returns the project's configured name, or its directory's
+// name, or "" outside a project
+func projectName(ctx *context.ProjectContext) string {
+	switch {
+	case ctx == nil:
+		return ""
+	case ctx.ProjectName != "":
+		return ctx.ProjectName
+	case ctx.ProjectRoot != "":
+		return filepath.Base(ctx.ProjectRoot)
+	}
+	return ""
+}
+
+// describeDestructiveTargets returns what the invocation would delete
+func describeDestructiveTargets(cmd *cobra.Command, args []string) []string {
+	destructiveTargetsMu.RLock()
+	targets := destructiveTargets[cmd]
+	destructiveTargetsMu.RUnlock()
+
+	if targets == nil {
+		if pluginName := cmd.Annotations["plugin"]; pluginName != "" {
+			return []string{fmt.Sprintf("Data managed by the %s plugin (see '%s --help')", pluginName, cmd.CommandPath())}
+		}
+		return nil
+	}
+	return targets(cmd, args)
+}
+
+// showDestructiveTargets lists what is about to be deleted
+func showDestructiveTargets(targets []string) {
+	if len(targets) == 0 {
+		return
+	}
+
+	output.Warning("The following will be permanently deleted:")
+	for _, target := range targets {
+		output.Printf("  • %s\n", target)
+	}
+}
+
+// recordAudit writes an audit entry, warning instead of failing the command
+// when the log cannot be written
+func recordAudit(logger *audit.Logger, entry audit.Entry) {
+	if logger == nil {
+		return
+	}
+	if err := logger.Record(entry); err != nil {
+		output.Warning("Could not write audit log %s: %v", logger.Path(), err)
+	}
+}
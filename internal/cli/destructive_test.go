@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/policy"
+	"github.com/glide-cli/glide/v3/pkg/audit"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDestructivePrompt replaces the confirmation prompt and terminal check
+func stubDestructivePrompt(t *testing.T, terminal, confirm bool) *int {
+	t.Helper()
+
+	prompts := 0
+	origConfirm, origTerminal := confirmDestructive, stdinIsTerminal
+	confirmDestructive = func(string) (bool, error) {
+		prompts++
+		return confirm, nil
+	}
+	stdinIsTerminal = func() bool { return terminal }
+	t.Cleanup(func() {
+		confirmDestructive, stdinIsTerminal = origConfirm, origTerminal
+	})
+
+	return &prompts
+}
+
+// newDestructiveTestRoot builds a tree with a destructive "wipe" command and
+// a "down" command that is destructive only with --volumes
+func newDestructiveTestRoot(ran *[]string) *cobra.Command {
+	root := &cobra.Command{Use: "glide"}
+
+	wipe := &cobra.Command{
+		Use: "wipe",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			*ran = append(*ran, "wipe")
+			return nil
+		},
+	}
+	MarkDestructive(wipe, func(*cobra.Command, []string) []string {
+		return []string{"everything"}
+	})
+
+	down := &cobra.Command{
+		Use: "down",
+		Run: func(cmd *cobra.Command, args []string) {
+			*ran = append(*ran, "down")
+		},
+	}
+	down.Flags().Bool("volumes", false, "")
+	down.Flags().Bool("dry-run", false, "")
+	MarkDestructive(down, nil, "volumes")
+
+	root.AddCommand(wipe, down)
+	return root
+}
+
+func runDestructiveTest(t *testing.T, root *cobra.Command, args ...string) error {
+	t.Helper()
+	root.SetArgs(args)
+	return root.Execute()
+}
+
+func TestDestructiveGuard(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		terminal    bool
+		confirm     bool
+		wantRan     []string
+		wantPrompts int
+		wantOutcome audit.Outcome
+		wantErrType glideErrors.ErrorType
+	}{
+		{
+			name:        "confirmed",
+			args:        []string{"wipe"},
+			terminal:    true,
+			confirm:     true,
+			wantRan:     []string{"wipe"},
+			wantPrompts: 1,
+			wantOutcome: audit.OutcomeConfirmed,
+		},
+		{
+			name:        "cancelled",
+			args:        []string{"wipe"},
+			terminal:    true,
+			confirm:     false,
+			wantPrompts: 1,
+			wantOutcome: audit.OutcomeCancelled,
+		},
+		{
+			name:        "forced",
+			args:        []string{"wipe", "--force"},
+			wantRan:     []string{"wipe"},
+			wantOutcome: audit.OutcomeForced,
+		},
+		{
+			name:        "refused without terminal",
+			args:        []string{"wipe"},
+			wantOutcome: audit.OutcomeRefused,
+			wantErrType: glideErrors.TypePermission,
+		},
+		{
+			name:    "flag-dependent command without flag",
+			args:    []string{"down"},
+			wantRan: []string{"down"},
+		},
+		{
+			name:        "flag-dependent command with flag",
+			args:        []string{"down", "--volumes"},
+			terminal:    true,
+			confirm:     true,
+			wantRan:     []string{"down"},
+			wantPrompts: 1,
+			wantOutcome: audit.OutcomeConfirmed,
+		},
+		{
+			name:    "dry run skips confirmation",
+			args:    []string{"down", "--volumes", "--dry-run"},
+			wantRan: []string{"down"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompts := stubDestructivePrompt(t, tt.terminal, tt.confirm)
+			logger := audit.NewLogger(filepath.Join(t.TempDir(), "audit.log"))
+
+			var ran []string
+			root := newDestructiveTestRoot(&ran)
+			Chain{DestructiveGuard(nil, logger, nil)}.Apply(root)
+
+			err := runDestructiveTest(t, root, tt.args...)
+			if tt.wantErrType != "" {
+				require.Error(t, err)
+				assert.True(t, glideErrors.Is(err, tt.wantErrType))
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantRan, ran)
+			assert.Equal(t, tt.wantPrompts, *prompts)
+
+			entries, err := logger.Entries()
+			require.NoError(t, err)
+			if tt.wantOutcome == "" {
+				assert.Empty(t, entries)
+				return
+			}
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.wantOutcome, entries[0].Outcome)
+		})
+	}
+}
+
+func TestDestructiveGuard_RecordsTargets(t *testing.T) {
+	stubDestructivePrompt(t, false, false)
+	logger := audit.NewLogger(filepath.Join(t.TempDir(), "audit.log"))
+
+	var ran []string
+	root := newDestructiveTestRoot(&ran)
+	Chain{DestructiveGuard(nil, logger, nil)}.Apply(root)
+
+	require.NoError(t, runDestructiveTest(t, root, "wipe", "--force"))
+
+	entries, err := logger.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "glide wipe", entries[0].Command)
+	assert.Equal(t, []string{"everything"}, entries[0].Targets)
+}
+
+func TestDestructiveGuard_TypedConfirm(t *testing.T) {
+	prompts := stubDestructivePrompt(t, true, true)
+	var phrases []string
+	origTyped := typedConfirm
+	typedConfirm = func(_, phrase string) (bool, error) {
+		phrases = append(phrases, phrase)
+		return true, nil
+	}
+	t.Cleanup(func() { typedConfirm = origTyped })
+
+	ctx := &context.ProjectContext{ProjectRoot: "/work/shop"}
+	run := func(pol *policy.Policy, args ...string) {
+		t.Helper()
+		var ran []string
+		root := newDestructiveTestRoot(&ran)
+		wipe, _, err := root.Find([]string{"wipe"})
+		require.NoError(t, err)
+		MarkHighlyDestructive(wipe, nil)
+		Chain{DestructiveGuard(ctx, nil, pol)}.Apply(root)
+		require.NoError(t, runDestructiveTest(t, root, args...))
+	}
+
+	run(nil, "wipe")
+	assert.Equal(t, []string{"shop"}, phrases, "highly destructive commands take the project name")
+	assert.Equal(t, 0, *prompts)
+
+	run(&policy.Policy{Confirm: policy.ConfirmRules{Simple: []string{"wipe"}}}, "wipe")
+	assert.Len(t, phrases, 1)
+	assert.Equal(t, 1, *prompts, "the policy can accept yes/no instead")
+
+	run(&policy.Policy{Confirm: policy.ConfirmRules{Typed: []string{"down"}}}, "down", "--volumes")
+	assert.Equal(t, []string{"shop", "shop"}, phrases, "the policy can require the project name")
+	assert.Equal(t, 1, *prompts)
+}
+
+func TestDestructiveGuard_PluginCommand(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:         "reset",
+		Annotations: map[string]string{"plugin": "db", DestructiveAnnotation: "true"},
+		RunE:        func(*cobra.Command, []string) error { return nil },
+	}
+
+	targets := describeDestructiveTargets(cmd, nil)
+	require.Len(t, targets, 1)
+	assert.Contains(t, targets[0], "db plugin")
+}
+
+func TestProjectCommands_DestructiveAnnotations(t *testing.T) {
+	project := NewProjectCommand(nil, nil)
+
+	down, _, err := project.Find([]string{"down"})
+	require.NoError(t, err)
+	assert.Equal(t, "volumes", down.Annotations[DestructiveAnnotation])
+	assert.Equal(t, "volumes", down.Annotations[TypedConfirmAnnotation])
+
+	clean, _, err := project.Find([]string{"clean"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, clean.Annotations[DestructiveAnnotation])
+	assert.Equal(t, "volumes,all", clean.Annotations[TypedConfirmAnnotation])
+
+	remove, _, err := project.Find([]string{"worktree", "remove"})
+	require.NoError(t, err)
+	assert.Equal(t, "true", remove.Annotations[DestructiveAnnotation])
+}
+
+func TestProjectClean_InteractiveSelectionIsGuarded(t *testing.T) {
+	prompts := stubDestructivePrompt(t, true, true)
+	var phrases []string
+	origTyped := typedConfirm
+	typedConfirm = func(_, phrase string) (bool, error) {
+		phrases = append(phrases, phrase)
+		return false, nil
+	}
+	t.Cleanup(func() { typedConfirm = origTyped })
+
+	ctx := &context.ProjectContext{ProjectRoot: "/work/shop"}
+	logger := audit.NewLogger(filepath.Join(t.TempDir(), "audit.log"))
+	project := NewProjectCommand(ctx, nil)
+	Chain{DestructiveGuard(ctx, logger, nil)}.Apply(project)
+	clean, _, err := project.Find([]string{"clean"})
+	require.NoError(t, err)
+
+	cc := &ProjectCleanCommand{ctx: ctx}
+	proceed, err := cc.confirmSelection(clean, nil, false, true, false)
+	require.NoError(t, err)
+	assert.False(t, proceed, "cancelling the typed confirmation cleans nothing")
+	assert.Equal(t, []string{"shop"}, phrases, "choosing volumes takes the project name")
+	assert.Equal(t, 0, *prompts)
+
+	entries, err := logger.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "project clean", entries[0].Command)
+	assert.Equal(t, audit.OutcomeCancelled, entries[0].Outcome)
+}
@@ -61,4 +61,27 @@
 //	        root.AddCommand(cmd)
 //	    }
 //	}
+//
+// # Middleware
+//
+// Cross-cutting concerns such as policy checks, audit logging, locking,
+// timing, and telemetry are middleware. Once every builtin, YAML, and plugin
+// command is registered, the chain wraps each command in the middleware that
+// applies to it:
+//
+//	cli.DefaultChain(ctx, auditLogger, projectPolicy).Apply(root)
+//
+// A middleware selects its commands and wraps their run function:
+//
+//	timing := cli.Middleware{
+//	    Name:    "timing",
+//	    Applies: func(cmd *cobra.Command) bool { return cmd.Name() == "up" },
+//	    Wrap: func(cmd *cobra.Command, next cli.RunFunc) cli.RunFunc {
+//	        return func(cmd *cobra.Command, args []string) error {
+//	            started := time.Now()
+//	            defer func() { log.Print(time.Since(started)) }()
+//	            return next(cmd, args)
+//	        }
+//	    },
+//	}
 package cli
@@ -0,0 +1,345 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/glide-cli/glide/v3/pkg/progress"
+	"github.com/spf13/cobra"
+)
+
+// Kinds of disk usage entries
+const (
+	UsageWorktree  = "worktree"
+	UsageVolume    = "volume"
+	UsageImage     = "image"
+	UsageSnapshots = "snapshots"
+	UsageCache     = "cache"
+	UsageLogs      = "logs"
+)
+
+var (
+	// volumeSizes and composeImages are replaced in tests
+	volumeSizes   = docker.VolumeSizes
+	composeImages = docker.ComposeImages
+)
+
+// DiskUsageEntry is a row of `glide du`
+type DiskUsageEntry struct {
+	Kind string `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
+	// Worktree is the worktree the entry belongs to, if any
+	Worktree string `json:"worktree,omitempty" yaml:"worktree,omitempty"`
+	// Path is the directory measured, for entries on the filesystem
+	Path  string `json:"path,omitempty" yaml:"path,omitempty"`
+	Bytes uint64 `json:"bytes" yaml:"bytes"`
+	// Hint is how to reclaim the space
+	Hint string `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+// DiskUsageReport is the result of `glide du`
+type DiskUsageReport struct {
+	Entries    []DiskUsageEntry `json:"entries" yaml:"entries"`
+	TotalBytes uint64           `json:"total_bytes" yaml:"total_bytes"`
+	// DockerError is set when Docker resources could not be measured
+	DockerError string `json:"docker_error,omitempty" yaml:"docker_error,omitempty"`
+}
+
+// DuCommand reports the disk space a project takes
+type DuCommand struct {
+	ctx *glideContext.ProjectContext
+	cfg *config.Config
+
+	noDocker bool
+}
+
+// NewDuCommand creates the du command
+func NewDuCommand(ctx *glideContext.ProjectContext, cfg *config.Config) *cobra.Command {
+	dc := &DuCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "du",
+		Short: "Show the disk space the project's worktrees, volumes, images, and caches take",
+		Long: fmt.Sprintf(`Measure the disk space of the current project: each worktree's checkout,
+the Docker volumes and built images that belong to it (found by their
+labels), its snapshots, and glide's own caches and logs. Entries are sorted
+largest first, with a hint on how to reclaim the space.
+
+Examples:
+  %[1]s du                   # Everything, largest first
+  %[1]s du --no-docker       # Skip Docker, which can take a while to measure
+  %[1]s du --format json     # For scripts`, branding.CommandName),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return dc.Execute()
+		},
+	}
+
+	cmd.Flags().BoolVar(&dc.noDocker, "no-docker", false, "Skip Docker volumes and images")
+
+	return cmd
+}
+
+// Execute measures and reports the project's disk usage
+func (dc *DuCommand) Execute() error {
+	if dc.ctx == nil || dc.ctx.ProjectRoot == "" {
+		return glideErrors.New(glideErrors.TypeMissing, "not in a project",
+			glideErrors.WithSuggestions("Run this command from inside your project directory"),
+		)
+	}
+
+	structured := output.GetFormat().IsStructured()
+	var spinner *progress.Spinner
+	if !structured {
+		spinner = progress.NewSpinner("Measuring disk usage")
+		spinner.Start()
+	}
+	report := dc.report()
+	if spinner != nil {
+		spinner.Success(fmt.Sprintf("Measured %d item(s)", len(report.Entries)))
+	}
+
+	if structured {
+		return output.Display(report)
+	}
+	renderDiskUsage(report)
+	return nil
+}
+
+// report measures every entry, largest first
+func (dc *DuCommand) report() DiskUsageReport {
+	var report DiskUsageReport
+	report.Entries = append(report.Entries, dc.worktreeEntries()...)
+	report.Entries = append(report.Entries, dc.snapshotEntries()...)
+	if !dc.noDocker {
+		entries, err := dc.dockerEntries()
+		if err != nil {
+			report.DockerError = err.Error()
+		}
+		report.Entries = append(report.Entries, entries...)
+	}
+	report.Entries = append(report.Entries, glideDataEntries()...)
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		a, b := report.Entries[i], report.Entries[j]
+		if a.Bytes != b.Bytes {
+			return a.Bytes > b.Bytes
+		}
+		return a.Kind+a.Name < b.Kind+b.Name
+	})
+	if report.Entries == nil {
+		report.Entries = []DiskUsageEntry{}
+	}
+	for _, e := range report.Entries {
+		report.TotalBytes += e.Bytes
+	}
+	return report
+}
+
+// worktreeEntries measures the checkouts: vcs/ and each worktree in
+// multi-worktree mode, the project root otherwise. The project's glide
+// data directory is measured separately.
+func (dc *DuCommand) worktreeEntries() []DiskUsageEntry {
+	glideDir := filepath.Join(dc.ctx.ProjectRoot, branding.GetPluginDirName())
+	if dc.ctx.DevelopmentMode != glideContext.ModeMultiWorktree {
+		dir, name := currentWorktree(dc.ctx)
+		return []DiskUsageEntry{{Kind: UsageWorktree, Name: name, Worktree: name, Path: dir, Bytes: dirSize(dir, glideDir)}}
+	}
+
+	var entries []DiskUsageEntry
+	vcsDir := filepath.Join(dc.ctx.ProjectRoot, "vcs")
+	if dirExists(vcsDir) {
+		entries = append(entries, DiskUsageEntry{Kind: UsageWorktree, Name: "vcs", Worktree: "vcs", Path: vcsDir, Bytes: dirSize(vcsDir)})
+	}
+	for _, name := range projectWorktrees(dc.ctx.ProjectRoot) {
+		if name == "" {
+			continue
+		}
+		dir := filepath.Join(dc.ctx.ProjectRoot, "worktrees", name)
+		entries = append(entries, DiskUsageEntry{
+			Kind:     UsageWorktree,
+			Name:     name,
+			Worktree: name,
+			Path:     dir,
+			Bytes:    dirSize(dir),
+			Hint:     fmt.Sprintf("%s project worktree remove %s", branding.CommandName, name),
+		})
+	}
+	return entries
+}
+
+// snapshotEntries measures the snapshots of each worktree
+func (dc *DuCommand) snapshotEntries() []DiskUsageEntry {
+	dir := filepath.Join(dc.ctx.ProjectRoot, branding.GetPluginDirName(), "snapshots")
+	items, _ := os.ReadDir(dir)
+
+	var entries []DiskUsageEntry
+	for _, item := range items {
+		if !item.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, item.Name())
+		entries = append(entries, DiskUsageEntry{
+			Kind:     UsageSnapshots,
+			Name:     item.Name(),
+			Worktree: item.Name(),
+			Path:     path,
+			Bytes:    dirSize(path),
+			Hint:     fmt.Sprintf("%s snapshot list, then %s snapshot delete <name>", branding.CommandName, branding.CommandName),
+		})
+	}
+	return entries
+}
+
+// dockerEntries lists the volumes glide labeled for the project and the
+// images compose built for its compose projects
+func (dc *DuCommand) dockerEntries() ([]DiskUsageEntry, error) {
+	projects := dc.composeProjects()
+
+	volumes, err := volumeSizes()
+	if err != nil {
+		return nil, err
+	}
+	var entries []DiskUsageEntry
+	for _, v := range volumes {
+		worktree, ok := volumeWorktree(v, dc.ctx.ProjectRoot, projects)
+		if !ok {
+			continue
+		}
+		entries = append(entries, DiskUsageEntry{
+			Kind:     UsageVolume,
+			Name:     v.Name,
+			Worktree: worktree,
+			Bytes:    v.Bytes,
+			Hint:     "docker volume rm " + v.Name,
+		})
+	}
+
+	names := make([]string, 0, len(projects))
+	for name := range projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		images, err := composeImages(name)
+		if err != nil {
+			return entries, err
+		}
+		for _, image := range images {
+			entries = append(entries, DiskUsageEntry{
+				Kind:     UsageImage,
+				Name:     image.Reference,
+				Worktree: projects[name],
+				Bytes:    image.Bytes,
+				Hint:     "docker image rm " + image.Reference,
+			})
+		}
+	}
+	return entries, nil
+}
+
+// composeProjects returns the compose project names of the project's
+// worktrees, mapped to the worktree
+func (dc *DuCommand) composeProjects() map[string]string {
+	projects := make(map[string]string)
+	if dc.ctx.DevelopmentMode != glideContext.ModeMultiWorktree {
+		dir, name := currentWorktree(dc.ctx)
+		if project, err := loadComposeProject(dir); err == nil && project.Name != "" {
+			projects[project.Name] = name
+		}
+		return projects
+	}
+
+	namespaces := docker.DeriveNamespaces(filepath.Base(dc.ctx.ProjectRoot), projectWorktrees(dc.ctx.ProjectRoot))
+	for worktree, ns := range namespaces {
+		if worktree == "" {
+			worktree = "vcs"
+		}
+		projects[ns.Project] = worktree
+	}
+	if len(sharedServices(dc.ctx)) > 0 {
+		projects[sharedProjectName(dc.ctx)] = sharedWorktree
+	}
+	return projects
+}
+
+// volumeWorktree returns the worktree a volume belongs to: the one glide
+// labeled it with, or the one whose compose project created it
+func volumeWorktree(v docker.VolumeUsage, projectRoot string, projects map[string]string) (string, bool) {
+	if owner, ok := docker.OwnershipFromLabels(v.Labels); ok {
+		return owner.Worktree, owner.Project == projectRoot
+	}
+	worktree, ok := projects[v.Labels[docker.ComposeProjectLabel]]
+	return worktree, ok
+}
+
+// glideDataEntries measures glide's caches and logs in the home directory,
+// which all projects share
+func glideDataEntries() []DiskUsageEntry {
+	cacheDir := artifactCache().Dir
+	logDir := pluginLogDir()
+	return []DiskUsageEntry{
+		{Kind: UsageCache, Name: "plugin and update downloads", Path: cacheDir, Bytes: dirSize(cacheDir),
+			Hint: branding.CommandName + " cache prune"},
+		{Kind: UsageLogs, Name: "plugin logs", Path: logDir, Bytes: dirSize(logDir),
+			Hint: fmt.Sprintf("Rotated at %s per plugin; delete %s to clear", output.Bytes(sdk.DefaultLogMaxSize), logDir)},
+	}
+}
+
+// dirSize returns the total size of the regular files under dir, without
+// following symlinks or descending into skip. Unreadable entries are left
+// out.
+func dirSize(dir string, skip ...string) uint64 {
+	var total uint64
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			for _, s := range skip {
+				if path == s {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += uint64(info.Size())
+			}
+		}
+		return nil
+	})
+	return total
+}
+
+// renderDiskUsage prints the report as a table
+func renderDiskUsage(report DiskUsageReport) {
+	table := output.NewTable("KIND", "NAME", "WORKTREE", "SIZE", "HINT")
+	table.AddRow("----", "----", "--------", "----", "----")
+	for _, e := range report.Entries {
+		table.AddRow(e.Kind, e.Name, e.Worktree, output.Bytes(e.Bytes), e.Hint)
+	}
+	_ = output.PrintTable(table)
+
+	output.Println()
+	output.Info("Total: %s", output.Bytes(report.TotalBytes))
+	if report.DockerError != "" {
+		output.Warning("Docker resources could not be measured: %s", report.DockerError)
+	}
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSized writes a file of size bytes, creating its directory
+func writeSized(t *testing.T, path string, size int) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+}
+
+// stubDockerUsage replaces the volumes and images docker reports
+func stubDockerUsage(t *testing.T, volumes []docker.VolumeUsage, images map[string][]docker.ImageUsage, err error) {
+	t.Helper()
+	originalVolumes, originalImages := volumeSizes, composeImages
+	volumeSizes = func() ([]docker.VolumeUsage, error) { return volumes, err }
+	composeImages = func(project string) ([]docker.ImageUsage, error) { return images[project], nil }
+	t.Cleanup(func() { volumeSizes, composeImages = originalVolumes, originalImages })
+}
+
+func TestDuCommand_Report(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "shop")
+	writeSized(t, filepath.Join(root, "vcs", "app.txt"), 300)
+	writeSized(t, filepath.Join(root, "worktrees", "feature-x", "app.txt"), 100)
+	writeSized(t, filepath.Join(root, ".glide", "snapshots", "feature-x", "db.sql"), 50)
+	require.NoError(t, os.Symlink(filepath.Join(root, "vcs", "app.txt"), filepath.Join(root, "worktrees", "feature-x", "link")))
+	cache := stubArtifactCache(t)
+	writeSized(t, filepath.Join(cache.Dir, "blob"), 20)
+	logDir := t.TempDir()
+	original := pluginLogDir
+	pluginLogDir = func() string { return logDir }
+	t.Cleanup(func() { pluginLogDir = original })
+	t.Chdir(t.TempDir())
+
+	stubDockerUsage(t, []docker.VolumeUsage{
+		{Name: "shop-feature-x_mysql", Bytes: 5000, Labels: map[string]string{
+			docker.LabelProject: root, docker.LabelWorktree: "feature-x", docker.ComposeProjectLabel: "shop-feature-x",
+		}},
+		{Name: "shop_cache", Bytes: 400, Labels: map[string]string{docker.ComposeProjectLabel: "shop"}},
+		{Name: "other_mysql", Bytes: 9000, Labels: map[string]string{docker.LabelProject: "/src/other", docker.LabelWorktree: "vcs"}},
+		{Name: "unrelated", Bytes: 9000},
+	}, map[string][]docker.ImageUsage{
+		"shop-feature-x": {{ID: "abc", Reference: "shop-feature-x-php:latest", Bytes: 2000}},
+	}, nil)
+
+	dc := &DuCommand{ctx: &glideContext.ProjectContext{ProjectRoot: root, DevelopmentMode: glideContext.ModeMultiWorktree}}
+	report := dc.report()
+
+	var rows [][3]string
+	for _, e := range report.Entries {
+		rows = append(rows, [3]string{e.Kind, e.Name, e.Worktree})
+	}
+	assert.Equal(t, [][3]string{
+		{UsageVolume, "shop-feature-x_mysql", "feature-x"},
+		{UsageImage, "shop-feature-x-php:latest", "feature-x"},
+		{UsageVolume, "shop_cache", "vcs"},
+		{UsageWorktree, "vcs", "vcs"},
+		{UsageWorktree, "feature-x", "feature-x"},
+		{UsageSnapshots, "feature-x", "feature-x"},
+		{UsageCache, "plugin and update downloads", ""},
+		{UsageLogs, "plugin logs", ""},
+	}, rows)
+	assert.Equal(t, uint64(100), report.Entries[4].Bytes, "symlinks are not followed")
+	assert.Equal(t, "glide project worktree remove feature-x", report.Entries[4].Hint)
+	assert.Equal(t, uint64(5000+2000+400+300+100+50+20), report.TotalBytes)
+}
+
+func TestDuCommand_SingleRepo(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "shop")
+	writeSized(t, filepath.Join(root, "app.txt"), 100)
+	writeSized(t, filepath.Join(root, ".glide", "snapshots", "shop", "db.sql"), 50)
+	stubArtifactCache(t)
+	stubComposeProject(t, &docker.ComposeProject{Name: "shop"})
+	stubDockerUsage(t, nil, nil, errors.New("Cannot connect to the Docker daemon"))
+
+	dc := &DuCommand{ctx: &glideContext.ProjectContext{ProjectRoot: root, DevelopmentMode: glideContext.ModeSingleRepo}}
+	report := dc.report()
+
+	assert.Equal(t, "Cannot connect to the Docker daemon", report.DockerError)
+	require.NotEmpty(t, report.Entries)
+	assert.Equal(t, DiskUsageEntry{Kind: UsageWorktree, Name: "shop", Worktree: "shop", Path: root, Bytes: 100}, report.Entries[0],
+		"the glide directory is not counted twice")
+
+	dc.noDocker = true
+	stubDockerUsage(t, nil, nil, errors.New("not called"))
+	assert.Empty(t, dc.report().DockerError)
+}
+
+func TestDuCommand_OutsideProject(t *testing.T) {
+	cmd := NewDuCommand(&glideContext.ProjectContext{}, nil)
+	cmd.SetArgs(nil)
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeMissing))
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/glide-cli/glide/v3/pkg/envvars"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// EnvVarRow is a variable listed by `glide env vars`
+type EnvVarRow struct {
+	envvars.Var `yaml:",inline"`
+	Value       string `json:"value,omitempty" yaml:"value,omitempty"`
+	Set         bool   `json:"set" yaml:"set"`
+}
+
+// EnvVarsReport is the result of `glide env vars`
+type EnvVarsReport struct {
+	Variables []EnvVarRow `json:"variables" yaml:"variables"`
+	// Unknown are set GLIDE_ variables that nothing declared
+	Unknown []string `json:"unknown" yaml:"unknown"`
+}
+
+// EnvCommand documents the environment variables glide reads
+type EnvCommand struct {
+	set bool
+}
+
+// NewEnvCommand creates the env command group
+func NewEnvCommand() *cobra.Command {
+	ec := &EnvCommand{}
+
+	cmd := &cobra.Command{
+		Use:           "env",
+		Short:         "Show the environment variables glide reads",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	vars := &cobra.Command{
+		Use:   "vars",
+		Short: "List GLIDE_* environment variables and their current values",
+		Long: `List every GLIDE_* environment variable glide and its build-time plugins
+read, with what it does, its default, and its current value. Secret values
+are masked.
+
+Set GLIDE_ variables that nothing reads are reported as unknown; they are
+usually typos, such as GLIDE_LOG_LEVLE, or left over from a removed plugin.
+
+Examples:
+  glide env vars                # Every variable
+  glide env vars --set          # Only the variables set now
+  glide env vars --format json  # For tooling`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ec.executeVars()
+		},
+	}
+	vars.Flags().BoolVar(&ec.set, "set", false, "Only list variables that are set")
+
+	cmd.AddCommand(vars)
+	return cmd
+}
+
+// executeVars lists the declared variables
+func (ec *EnvCommand) executeVars() error {
+	report := envVarsReport(os.Environ(), ec.set)
+
+	if output.GetFormat().IsStructured() {
+		return output.Display(report)
+	}
+	showEnvVars(report)
+	return nil
+}
+
+// envVarsReport collects the declared variables and the unknown ones set
+// in environ
+func envVarsReport(environ []string, onlySet bool) EnvVarsReport {
+	report := EnvVarsReport{Variables: []EnvVarRow{}, Unknown: envvars.Unknown(environ)}
+	if report.Unknown == nil {
+		report.Unknown = []string{}
+	}
+
+	for _, v := range envvars.List() {
+		value, set := envvars.Value(v)
+		if onlySet && !set {
+			continue
+		}
+		report.Variables = append(report.Variables, EnvVarRow{Var: v, Value: value, Set: set})
+	}
+	return report
+}
+
+// showEnvVars prints the report as a table
+func showEnvVars(report EnvVarsReport) {
+	if len(report.Variables) == 0 {
+		output.Info("No GLIDE_ variables are set")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		// Safe to ignore: Table formatting (informational display only)
+		_, _ = fmt.Fprintln(w, "NAME\tVALUE\tDEFAULT\tSOURCE\tDESCRIPTION")
+		for _, row := range report.Variables {
+			value := "-"
+			if row.Set {
+				value = row.Value
+			}
+			def := row.Default
+			if def == "" {
+				def = "-"
+			}
+			description := row.Description
+			if row.Internal {
+				description += " (set by glide)"
+			}
+			// Safe to ignore: Table formatting (informational display only)
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", row.Name, value, def, row.Source, description)
+		}
+		// Safe to ignore: Table formatting (informational display only)
+		_ = w.Flush()
+	}
+
+	if len(report.Unknown) > 0 {
+		output.Println()
+		output.Warning("%d GLIDE_ variable(s) are set but nothing reads them; check for typos:", len(report.Unknown))
+		for _, name := range report.Unknown {
+			output.Printf("  %s\n", name)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvVarsReport(t *testing.T) {
+	t.Setenv("GLIDE_LOG_LEVEL", "debug")
+	environ := []string{"GLIDE_LOG_LEVEL=debug", "GLIDE_LOG_LEVLE=info", "PATH=/usr/bin"}
+
+	report := envVarsReport(environ, true)
+	assert.Equal(t, []string{"GLIDE_LOG_LEVLE"}, report.Unknown)
+	var names []string
+	for _, row := range report.Variables {
+		names = append(names, row.Name)
+	}
+	assert.Contains(t, names, "GLIDE_LOG_LEVEL")
+	assert.NotContains(t, names, "GLIDE_PAGER")
+
+	report = envVarsReport(nil, false)
+	assert.Empty(t, report.Unknown)
+	assert.Greater(t, len(report.Variables), 1)
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	// runDockerAttached and stdoutIsTerminal are replaced in tests
+	runDockerAttached = func(args []string) error {
+		c := exec.Command("docker", args...)
+		c.Stdin = os.Stdin
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+		return c.Run()
+	}
+	stdoutIsTerminal = func() bool { return term.IsTerminal(int(os.Stdout.Fd())) }
+)
+
+// ExecCommand runs a command in a compose service's container
+type ExecCommand struct {
+	ctx *glideContext.ProjectContext
+	cfg *config.Config
+
+	user    string
+	workdir string
+	env     []string
+	noTTY   bool
+}
+
+// NewExecCommand creates the exec command
+func NewExecCommand(ctx *glideContext.ProjectContext, cfg *config.Config) *cobra.Command {
+	ec := &ExecCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "exec <service> -- <command> [args...]",
+		Short: "Run a command in a service's running container",
+		Long: `Run a command in the running container of a compose service of the current
+project, or of the current worktree in multi-worktree mode. A TTY is allocated
+when the terminal is interactive, and glide exits with the command's exit code.
+
+The user, working directory, and environment default to the service's
+exec: settings in .glide.yml; the flags override them.
+
+Examples:
+  glide exec php -- php artisan migrate
+  glide exec -u root php -- apt-get update
+  glide exec -e APP_DEBUG=1 node -- npm test
+  glide exec -T mysql -- mysqldump app > dump.sql`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if cmd.ArgsLenAtDash() != 1 || len(args) < 2 {
+				return glideErrors.New(glideErrors.TypeInvalid, "expected a service and a command after --",
+					glideErrors.WithSuggestions(fmt.Sprintf("Run: %s exec <service> -- <command>", branding.CommandName)),
+				)
+			}
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ec.Execute(args[0], args[1:])
+		},
+	}
+
+	cmd.Flags().StringVarP(&ec.user, "user", "u", "", "Run the command as this user")
+	cmd.Flags().StringVarP(&ec.workdir, "workdir", "w", "", "Run the command in this directory of the container")
+	cmd.Flags().StringArrayVarP(&ec.env, "env", "e", nil, "Set an environment variable (KEY=VALUE, repeatable)")
+	cmd.Flags().BoolVarP(&ec.noTTY, "no-tty", "T", false, "Do not allocate a TTY")
+
+	return cmd
+}
+
+// Execute runs command in the service's container
+func (ec *ExecCommand) Execute(service string, command []string) error {
+	if ec.ctx == nil || ec.ctx.ProjectRoot == "" {
+		return glideErrors.New(glideErrors.TypeMissing, "not in a project",
+			glideErrors.WithSuggestions("Run this command from inside your project directory"),
+		)
+	}
+	for _, kv := range ec.env {
+		if !strings.Contains(kv, "=") {
+			return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("invalid environment variable %q", kv),
+				glideErrors.WithSuggestions("Use KEY=VALUE, e.g. -e APP_DEBUG=1"),
+			)
+		}
+	}
+
+	container, err := serviceContainer(ec.ctx, service)
+	if err != nil {
+		return err
+	}
+
+	tty := !ec.noTTY && stdinIsTerminal() && stdoutIsTerminal()
+	args := ec.dockerArgs(localProjectConfig().Exec[service], container, command, tty)
+	if err := runDockerAttached(args); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return glideErrors.New(glideErrors.TypeCommand,
+				fmt.Sprintf("%s exited with code %d in %s", command[0], exitErr.ExitCode(), service),
+				glideErrors.WithExitCode(exitErr.ExitCode()),
+			)
+		}
+		return glideErrors.NewDockerError("failed to run docker exec",
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions("Make sure Docker is installed and running"),
+		)
+	}
+	return nil
+}
+
+// serviceContainer returns the name of the service's running container in
+// the current worktree, the first by name when the service has replicas
+func serviceContainer(ctx *glideContext.ProjectContext, service string) (string, error) {
+	containers, err := listContainers()
+	if err != nil {
+		return "", err
+	}
+	dir, owner := worktreeOwnership(ctx)
+
+	var names []string
+	for _, c := range worktreeContainers(containers, owner.Project, owner.Worktree, dir) {
+		if c.Service == service && c.State == "running" {
+			names = append(names, c.Name)
+		}
+	}
+	if len(names) == 0 {
+		return "", glideErrors.NewDockerError(fmt.Sprintf("service %s has no running container", service),
+			glideErrors.WithContext("service", service),
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Start the project with: %s up", branding.CommandName),
+				fmt.Sprintf("List the running services with: %s ps", branding.CommandName),
+			),
+		)
+	}
+	sort.Strings(names)
+	return names[0], nil
+}
+
+// dockerArgs builds the docker exec arguments, applying the service's
+// configured defaults under the flags
+func (ec *ExecCommand) dockerArgs(defaults config.ExecConfig, container string, command []string, tty bool) []string {
+	args := []string{"exec", "-i"}
+	if tty {
+		args = append(args, "-t")
+	}
+
+	user := defaults.User
+	if ec.user != "" {
+		user = ec.user
+	}
+	if user != "" {
+		args = append(args, "--user", user)
+	}
+
+	workdir := defaults.Workdir
+	if ec.workdir != "" {
+		workdir = ec.workdir
+	}
+	if workdir != "" {
+		args = append(args, "--workdir", workdir)
+	}
+
+	keys := make([]string, 0, len(defaults.Env))
+	for key := range defaults.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--env", key+"="+defaults.Env[key])
+	}
+	// Flags come last so docker lets them win
+	for _, kv := range ec.env {
+		args = append(args, "--env", kv)
+	}
+
+	args = append(args, container)
+	return append(args, command...)
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDockerAttached records the docker arguments instead of running them
+func stubDockerAttached(t *testing.T, err error) *[]string {
+	t.Helper()
+	var recorded []string
+	original := runDockerAttached
+	runDockerAttached = func(args []string) error {
+		recorded = args
+		return err
+	}
+	t.Cleanup(func() { runDockerAttached = original })
+	return &recorded
+}
+
+func TestExecCommand_DockerArgs(t *testing.T) {
+	defaults := config.ExecConfig{
+		User:    "www-data",
+		Workdir: "/var/www",
+		Env:     map[string]string{"XDEBUG_MODE": "off", "APP_ENV": "local"},
+	}
+
+	ec := &ExecCommand{}
+	assert.Equal(t, []string{
+		"exec", "-i", "-t", "--user", "www-data", "--workdir", "/var/www",
+		"--env", "APP_ENV=local", "--env", "XDEBUG_MODE=off",
+		"myapp-php-1", "php", "-v",
+	}, ec.dockerArgs(defaults, "myapp-php-1", []string{"php", "-v"}, true))
+
+	// Flags override the defaults
+	ec = &ExecCommand{user: "root", workdir: "/tmp", env: []string{"XDEBUG_MODE=debug"}}
+	assert.Equal(t, []string{
+		"exec", "-i", "--user", "root", "--workdir", "/tmp",
+		"--env", "APP_ENV=local", "--env", "XDEBUG_MODE=off", "--env", "XDEBUG_MODE=debug",
+		"myapp-php-1", "id",
+	}, ec.dockerArgs(defaults, "myapp-php-1", []string{"id"}, false))
+
+	assert.Equal(t, []string{"exec", "-i", "myapp-php-1", "id"},
+		(&ExecCommand{}).dockerArgs(config.ExecConfig{}, "myapp-php-1", []string{"id"}, false))
+}
+
+func TestServiceContainer(t *testing.T) {
+	ctx := &glideContext.ProjectContext{ProjectRoot: "/src/myapp", IsWorktree: true, WorktreeName: "feature-x"}
+	stubListContainers(t,
+		docker.ProjectContainer{Name: "main-php-1", Service: "php", State: "running",
+			Labels: docker.Ownership{Project: "/src/myapp", Worktree: "main"}.Labels()},
+		docker.ProjectContainer{Name: "feature-x-php-2", Service: "php", State: "running",
+			Labels: docker.Ownership{Project: "/src/myapp", Worktree: "feature-x"}.Labels()},
+		docker.ProjectContainer{Name: "feature-x-php-1", Service: "php", State: "running",
+			Labels: docker.Ownership{Project: "/src/myapp", Worktree: "feature-x"}.Labels()},
+		docker.ProjectContainer{Name: "feature-x-mysql-1", Service: "mysql", State: "exited",
+			Labels: docker.Ownership{Project: "/src/myapp", Worktree: "feature-x"}.Labels()},
+	)
+
+	name, err := serviceContainer(ctx, "php")
+	require.NoError(t, err)
+	assert.Equal(t, "feature-x-php-1", name, "the worktree's first replica")
+
+	_, err = serviceContainer(ctx, "mysql")
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeDocker), "stopped containers are not used")
+}
+
+func TestExecCommand_ExitCode(t *testing.T) {
+	stubListContainers(t, docker.ProjectContainer{Name: "myapp-php-1", Service: "php", State: "running",
+		WorkingDir: "/src/myapp"})
+	exitErr := exec.Command("sh", "-c", "exit 3").Run()
+	require.Error(t, exitErr)
+	recorded := stubDockerAttached(t, exitErr)
+
+	ec := &ExecCommand{ctx: &glideContext.ProjectContext{ProjectRoot: "/src/myapp"}, noTTY: true}
+	err := ec.Execute("php", []string{"false"})
+	require.Error(t, err)
+
+	var glideErr *glideErrors.GlideError
+	require.ErrorAs(t, err, &glideErr)
+	assert.Equal(t, 3, glideErr.Code, "the container's exit code becomes glide's")
+	assert.Equal(t, []string{"exec", "-i", "myapp-php-1", "false"}, *recorded)
+}
+
+func TestExecCommand_Args(t *testing.T) {
+	for _, args := range [][]string{{"php"}, {"php", "--"}, {"php", "ls"}} {
+		cmd := NewExecCommand(&glideContext.ProjectContext{ProjectRoot: "/src/myapp"}, nil)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		require.Error(t, err, "args %v", args)
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid), "args %v", args)
+	}
+
+	cmd := NewExecCommand(&glideContext.ProjectContext{ProjectRoot: "/src/myapp"}, nil)
+	cmd.SetArgs([]string{"-e", "NOVALUE", "php", "--", "env"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid))
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewExplainCommand creates the explain command. planner is the one the
+// global --dry-run flag uses.
+func NewExplainCommand(planner *Planner) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain <command> [args...]",
+		Short: "Show what a command would run without executing it",
+		Long: `Show the resolved execution plan for any glide command without running it.
+
+The plan lists the underlying shell or plugin invocations, the working
+directory, GLIDE_* environment variables, affected worktrees, and the
+configuration values that influence the command. The same planner backs the
+global --dry-run flag.
+
+Examples:
+  glide explain test --filter=Unit     # Show the expanded YAML command
+  glide explain up                     # Show which plugin handles 'up'
+  glide explain --format json build    # Machine-readable plan`,
+		Args: cobra.MinimumNArgs(1),
+		// Explaining never executes anything, so --dry-run is harmless here
+		Annotations:   map[string]string{DryRunAnnotation: "true"},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := planner.PlanArgs(cmd.Root(), args)
+			if err != nil {
+				return err
+			}
+			RenderPlan(plan)
+			return nil
+		},
+	}
+
+	// Everything after the target command name belongs to that command
+	cmd.Flags().SetInterspersed(false)
+
+	return cmd
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/featureflags"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// FeatureRow is a flag listed by `glide features list`
+type FeatureRow struct {
+	featureflags.Flag `yaml:",inline"`
+	Enabled           bool `json:"enabled" yaml:"enabled"`
+	// From is where the state comes from: default, config, or env
+	From string `json:"from" yaml:"from"`
+}
+
+// FeaturesReport is the result of `glide features list`
+type FeaturesReport struct {
+	Features []FeatureRow `json:"features" yaml:"features"`
+	// Unknown are flags set in the configuration or GLIDE_FEATURES that
+	// nothing declared
+	Unknown []string `json:"unknown" yaml:"unknown"`
+}
+
+// NewFeaturesCommand creates the features command group
+func NewFeaturesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "features",
+		Short: "List and turn on experimental features",
+		Long: `Experimental features ship turned off. Turn one on to try it; it is stored
+under features: in the global configuration.
+
+GLIDE_FEATURES turns features on or off for one run, overriding the
+configuration: a comma-separated list where a leading - turns a feature
+off, e.g. GLIDE_FEATURES=tui,-daemon.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List experimental features and whether they are on",
+		Long: `List every experimental feature glide and its build-time plugins
+declare, whether it is on, and whether that comes from the default, the
+configuration, or GLIDE_FEATURES.
+
+Examples:
+  glide features list                # Every feature
+  glide features list --format json  # For tooling`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report := featuresReport()
+			if output.GetFormat().IsStructured() {
+				return output.Display(report)
+			}
+			showFeatures(report)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(list, newSetFeatureCommand(true), newSetFeatureCommand(false))
+	return cmd
+}
+
+// newSetFeatureCommand creates the enable or disable subcommand
+func newSetFeatureCommand(enable bool) *cobra.Command {
+	use, short := "disable", "Turn an experimental feature off"
+	if enable {
+		use, short = "enable", "Turn an experimental feature on"
+	}
+
+	cmd := &cobra.Command{
+		Use:           use + " <feature>",
+		Short:         short,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var names []string
+			for _, f := range featureflags.List() {
+				names = append(names, f.Name+"\t"+f.Description)
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setFeature(config.NewLoader(), args[0], enable)
+		},
+	}
+	MarkExclusive(cmd, GroupConfigWrite)
+	return cmd
+}
+
+// setFeature stores a feature's state in the global configuration
+func setFeature(loader *config.Loader, name string, enable bool) error {
+	if _, ok := featureflags.Lookup(name); !ok {
+		return glideErrors.NewConfigError(fmt.Sprintf("unknown feature: %s", name),
+			glideErrors.WithSuggestions("Run 'glide features list' to see the available features"))
+	}
+
+	if err := loader.SetFeature(name, enable); err != nil {
+		return glideErrors.Wrap(err, "failed to save configuration",
+			glideErrors.WithSuggestions(fmt.Sprintf("Check that %s is writable", loader.GetConfigPath())))
+	}
+
+	state := "off"
+	if enable {
+		state = "on"
+	}
+	output.Success("✓ Turned %s %s", state, name)
+	if on, from := featureflags.State(name); from == featureflags.FromEnv && on != enable {
+		output.Warning("%s overrides this while it names %s", featureflags.EnvVar, name)
+	}
+	return nil
+}
+
+// featuresReport collects the declared flags and their state
+func featuresReport() FeaturesReport {
+	report := FeaturesReport{Features: []FeatureRow{}, Unknown: featureflags.Unknown()}
+	if report.Unknown == nil {
+		report.Unknown = []string{}
+	}
+
+	for _, f := range featureflags.List() {
+		on, from := featureflags.State(f.Name)
+		report.Features = append(report.Features, FeatureRow{Flag: f, Enabled: on, From: from})
+	}
+	return report
+}
+
+// showFeatures prints the report as a table
+func showFeatures(report FeaturesReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, "FEATURE\tSTATE\tFROM\tSOURCE\tDESCRIPTION")
+	for _, row := range report.Features {
+		state := "off"
+		if row.Enabled {
+			state = "on"
+		}
+		// Safe to ignore: Table formatting (informational display only)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", row.Name, state, row.From, row.Source, row.Description)
+	}
+	// Safe to ignore: Table formatting (informational display only)
+	_ = w.Flush()
+
+	if len(report.Unknown) > 0 {
+		output.Println()
+		output.Warning("%d feature(s) are set but nothing declares them; check for typos:", len(report.Unknown))
+		for _, name := range report.Unknown {
+			output.Printf("  %s\n", name)
+		}
+	}
+}
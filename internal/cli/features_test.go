@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/pkg/featureflags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesReport(t *testing.T) {
+	t.Cleanup(func() { featureflags.Configure(nil) })
+	t.Setenv(featureflags.EnvVar, "tui,telport")
+	featureflags.Configure(map[string]bool{"daemon": true})
+
+	report := featuresReport()
+	assert.Equal(t, []string{"telport"}, report.Unknown)
+	states := make(map[string]FeatureRow)
+	for _, row := range report.Features {
+		states[row.Name] = row
+	}
+	assert.Equal(t, FeatureRow{Flag: states["daemon"].Flag, Enabled: true, From: featureflags.FromConfig}, states["daemon"])
+	assert.Equal(t, featureflags.FromEnv, states["tui"].From)
+	assert.False(t, states["wasm-plugins"].Enabled)
+}
+
+func TestSetFeature(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	loader := config.NewLoader()
+
+	require.NoError(t, setFeature(loader, "daemon", true))
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.Features["daemon"])
+
+	assert.ErrorContains(t, setFeature(loader, "teleport", true), "unknown feature: teleport")
+}
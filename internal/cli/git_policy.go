@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/gitpolicy"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ciBranchVariables hold the branch being built in CI, where the checkout
+// is usually a detached HEAD
+var ciBranchVariables = []string{
+	"GITHUB_HEAD_REF",                     // GitHub Actions pull requests
+	"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", // GitLab merge request pipelines
+	"CI_COMMIT_BRANCH",                    // GitLab branch pipelines
+	"BRANCH_NAME",                         // Jenkins multibranch pipelines
+}
+
+// PolicyReport is the result of `glide policy check`
+type PolicyReport struct {
+	Branch     string                `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Commits    int                   `json:"commits_checked" yaml:"commits_checked"`
+	Violations []gitpolicy.Violation `json:"violations" yaml:"violations"`
+}
+
+// PolicyCommand checks branch and commit naming rules
+type PolicyCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+}
+
+// NewPolicyCommand creates the policy command group
+func NewPolicyCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	pc := &PolicyCommand{ctx: ctx, cfg: cfg}
+
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Check branch and commit naming rules",
+		Long: `Check branch names and commit messages against the rules in .glide.yml,
+and install git hooks that check them on every commit and push.
+
+  git_policy:
+    branch_pattern: '^(feature|fix|chore)/[a-z0-9-]+$'
+    commit_pattern: '^(feat|fix|docs|chore|refactor|test)(\(.+\))?: .+'
+    exempt_branches: [main, develop]   # default: main, master, develop
+
+Patterns are Go regular expressions. Only the subject line of a commit
+message is checked, and messages git writes itself (merges, reverts,
+fixup! commits) are ignored.
+
+Examples:
+  glide policy check                           # Check the branch and last commit
+  glide policy check --range origin/main..HEAD # Check every commit of a branch in CI
+  glide policy install-hooks                   # Check on every commit and push`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	check := &cobra.Command{
+		Use:   "check",
+		Short: "Check the branch name and commit messages",
+		Long: `Check the current branch name and commit messages against the naming rules.
+Exits with status 1 when a rule is broken, for use in CI.
+
+In CI the branch is read from GITHUB_HEAD_REF, CI_MERGE_REQUEST_SOURCE_BRANCH_NAME,
+CI_COMMIT_BRANCH, or BRANCH_NAME, since the checkout is usually detached.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          pc.executeCheck,
+	}
+	check.Flags().String("branch", "", "Branch name to check (default: the current branch)")
+	check.Flags().String("range", "", "Commits to check as git log arguments, e.g. origin/main..HEAD (default: the last commit)")
+	check.Flags().String("commit-msg-file", "", "Check only the commit message in this file, as the commit-msg hook does")
+
+	install := &cobra.Command{
+		Use:   "install-hooks",
+		Short: "Install git hooks that check the naming rules",
+		Long: `Install commit-msg and pre-push hooks into the repository. The commit-msg
+hook checks each new commit message, and the pre-push hook checks the names
+of pushed branches and the messages of pushed commits.
+
+Worktrees share the hooks of their repository. Hooks installed by other
+tools are left alone unless --force is given.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          pc.executeInstallHooks,
+	}
+	install.Flags().Bool("force", false, "Replace existing hooks installed by other tools")
+
+	uninstall := &cobra.Command{
+		Use:           "uninstall-hooks",
+		Short:         "Remove the git hooks installed by install-hooks",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          pc.executeUninstallHooks,
+	}
+
+	cmd.AddCommand(check, install, uninstall)
+	return cmd
+}
+
+// executeCheck checks the branch and commits
+func (pc *PolicyCommand) executeCheck(cmd *cobra.Command, _ []string) error {
+	rules, err := gitpolicy.RulesFromConfig(localProjectConfig().GitPolicy)
+	if err != nil {
+		return err
+	}
+	if rules.Empty() {
+		output.Info("No git_policy is configured in .glide.yml")
+		return nil
+	}
+
+	dir := pc.repoDir()
+	report := PolicyReport{Violations: []gitpolicy.Violation{}}
+
+	if msgFile, _ := cmd.Flags().GetString("commit-msg-file"); msgFile != "" {
+		data, err := os.ReadFile(msgFile)
+		if err != nil {
+			return glideErrors.NewFileNotFoundError(msgFile, glideErrors.WithError(err))
+		}
+		report.Commits = 1
+		if v := rules.CheckCommit(string(data)); v != nil {
+			report.Violations = append(report.Violations, *v)
+		}
+		return showPolicyReport(report)
+	}
+
+	report.Branch, _ = cmd.Flags().GetString("branch")
+	if report.Branch == "" {
+		report.Branch = currentPolicyBranch(dir)
+	}
+	if report.Branch != "" {
+		if v := rules.CheckBranch(report.Branch); v != nil {
+			report.Violations = append(report.Violations, *v)
+		}
+	}
+
+	revisions := []string{"-1", "HEAD"}
+	if rangeArg, _ := cmd.Flags().GetString("range"); rangeArg != "" {
+		revisions = strings.Fields(rangeArg)
+	}
+	commits, err := gitpolicy.Commits(dir, revisions...)
+	if err != nil {
+		return glideErrors.NewCommandError("git log", 1,
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions("Check the --range argument, and fetch the base branch in CI (e.g. fetch-depth: 0)"),
+		)
+	}
+	report.Commits = len(commits)
+	report.Violations = append(report.Violations, rules.CheckCommits(commits)...)
+
+	return showPolicyReport(report)
+}
+
+// executeInstallHooks installs the policy hooks
+func (pc *PolicyCommand) executeInstallHooks(cmd *cobra.Command, _ []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+
+	command, err := os.Executable()
+	if err != nil {
+		command = branding.CommandName
+	}
+	paths, err := gitpolicy.InstallHooks(pc.repoDir(), command, force)
+	if err != nil {
+		return glideErrors.New(glideErrors.TypeConfig, "failed to install git hooks",
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions(
+				"Run from inside the repository",
+				"Use --force to replace hooks installed by other tools",
+			),
+		)
+	}
+
+	for _, path := range paths {
+		output.Success("✓ Installed %s", path)
+	}
+	if rules, err := gitpolicy.RulesFromConfig(localProjectConfig().GitPolicy); err == nil && rules.Empty() {
+		output.Warning("No git_policy is configured in .glide.yml yet, so the hooks accept everything")
+	}
+	return nil
+}
+
+// executeUninstallHooks removes the policy hooks
+func (pc *PolicyCommand) executeUninstallHooks(_ *cobra.Command, _ []string) error {
+	paths, err := gitpolicy.UninstallHooks(pc.repoDir())
+	if err != nil {
+		return glideErrors.New(glideErrors.TypeConfig, "failed to remove git hooks", glideErrors.WithError(err))
+	}
+
+	if len(paths) == 0 {
+		output.Info("No policy hooks are installed")
+	}
+	for _, path := range paths {
+		output.Success("✓ Removed %s", path)
+	}
+	return nil
+}
+
+// repoDir returns the repository the policy applies to: the working
+// directory, or vcs/ at the root of a multi-worktree project
+func (pc *PolicyCommand) repoDir() string {
+	dir, _ := os.Getwd()
+	if _, err := gitOutput(dir, "rev-parse", "--git-dir"); err != nil && pc.ctx != nil && pc.ctx.ProjectRoot != "" {
+		return filepath.Join(pc.ctx.ProjectRoot, "vcs")
+	}
+	return dir
+}
+
+// currentPolicyBranch returns the branch being checked: the CI branch, or
+// the checked out branch, or "" on a detached HEAD
+func currentPolicyBranch(dir string) string {
+	for _, name := range ciBranchVariables {
+		if branch := os.Getenv(name); branch != "" {
+			return branch
+		}
+	}
+	branch, err := gitOutput(dir, "symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(branch)
+}
+
+// showPolicyReport prints the violations and returns an error when there
+// are any
+func showPolicyReport(report PolicyReport) error {
+	if output.GetFormat().IsStructured() {
+		if err := output.Display(report); err != nil {
+			return err
+		}
+	} else if len(report.Violations) == 0 {
+		output.Success("✓ Branch and commit names follow the policy")
+	} else {
+		for _, v := range report.Violations {
+			switch v.Kind {
+			case gitpolicy.KindBranch:
+				output.Error("✗ Branch %q does not match %s", v.Subject, v.Pattern)
+			default:
+				output.Error("✗ Commit %s %q does not match %s", orDash(v.Commit), v.Subject, v.Pattern)
+			}
+		}
+	}
+
+	if len(report.Violations) == 0 {
+		return nil
+	}
+	return glideErrors.New(glideErrors.TypeCommand,
+		fmt.Sprintf("%d naming policy violation(s)", len(report.Violations)),
+		glideErrors.WithExitCode(1),
+		glideErrors.WithSuggestions(
+			"Rename a branch: git branch -m <new-name>",
+			"Reword the last commit: git commit --amend",
+			"The rules are under git_policy in .glide.yml",
+		),
+	)
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPolicyRepo creates a repository with a git_policy on the given branch
+func newPolicyRepo(t *testing.T, branch string) func(args ...string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	for _, name := range ciBranchVariables {
+		t.Setenv(name, "")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q", "-b", branch)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".glide.yml"), []byte(`
+git_policy:
+  branch_pattern: '^feature/'
+  commit_pattern: '^(feat|fix): '
+`), 0644))
+	t.Chdir(dir)
+	return git
+}
+
+func runPolicy(args ...string) error {
+	root := &cobra.Command{Use: "glide"}
+	root.AddCommand(NewPolicyCommand(nil, nil))
+	root.SetArgs(append([]string{"policy"}, args...))
+	return root.Execute()
+}
+
+func TestPolicyCheck(t *testing.T) {
+	git := newPolicyRepo(t, "feature/login")
+	git("commit", "-q", "--allow-empty", "-m", "feat: add login")
+
+	require.NoError(t, runPolicy("check"))
+
+	git("commit", "-q", "--allow-empty", "-m", "wip")
+	err := runPolicy("check")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 naming policy violation(s)")
+
+	err = runPolicy("check", "--branch", "login", "--range", "HEAD~1..HEAD")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 naming policy violation(s)")
+}
+
+func TestPolicyCheck_CIBranch(t *testing.T) {
+	git := newPolicyRepo(t, "feature/login")
+	git("commit", "-q", "--allow-empty", "-m", "fix: typo")
+	t.Setenv("GITHUB_HEAD_REF", "hotfix")
+
+	err := runPolicy("check")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 naming policy violation(s)")
+}
+
+func TestPolicyCheck_CommitMsgFile(t *testing.T) {
+	newPolicyRepo(t, "not-checked")
+	msg := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+
+	require.NoError(t, os.WriteFile(msg, []byte("feat: ok\n# comment\n"), 0644))
+	require.NoError(t, runPolicy("check", "--commit-msg-file", msg), "only the message is checked")
+
+	require.NoError(t, os.WriteFile(msg, []byte("Update stuff\n"), 0644))
+	assert.Error(t, runPolicy("check", "--commit-msg-file", msg))
+}
+
+func TestPolicyInstallHooks(t *testing.T) {
+	newPolicyRepo(t, "main")
+
+	require.NoError(t, runPolicy("install-hooks"))
+	assert.FileExists(t, filepath.Join(".git", "hooks", "commit-msg"))
+	assert.FileExists(t, filepath.Join(".git", "hooks", "pre-push"))
+
+	require.NoError(t, runPolicy("uninstall-hooks"))
+	assert.NoFileExists(t, filepath.Join(".git", "hooks", "commit-msg"))
+}
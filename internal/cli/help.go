@@ -9,7 +9,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/glide-cli/glide/v3/internal/config"
 	"github.com/glide-cli/glide/v3/internal/context"
-	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/glide-cli/glide/v3/pkg/logging"
 	"github.com/glide-cli/glide/v3/pkg/output"
 	"github.com/glide-cli/glide/v3/pkg/plugin"
 	"github.com/spf13/cobra"
@@ -69,6 +69,10 @@ Examples:
 			case "troubleshooting", "troubleshoot", "issues":
 				return hc.showTroubleshooting()
 			default:
+				// Plugin-contributed topics come after the built-in ones
+				if pluginTopic, ok := plugin.FindPluginHelpTopic(topic); ok {
+					return hc.showPluginHelpTopic(pluginTopic)
+				}
 				// Check if it's a specific command help request
 				return hc.showCommandHelp(topic)
 			}
@@ -277,6 +281,23 @@ func (hc *HelpCommand) showTroubleshooting() error {
 	return nil
 }
 
+// showPluginHelpTopic shows a long-form help topic provided by a plugin
+func (hc *HelpCommand) showPluginHelpTopic(t plugin.PluginHelpTopic) error {
+	title := t.Topic.Summary
+	if title == "" {
+		title = t.Topic.Name
+	}
+	output.Success("📖 %s", title)
+	output.Raw("\n")
+
+	output.Raw(output.Linkify(strings.TrimRight(t.Topic.Content, "\n")) + "\n")
+
+	output.Raw("\n")
+	output.Info("Provided by the %s plugin", t.Plugin)
+
+	return nil
+}
+
 // showCommandHelp shows help for a specific command (fallback to cobra help)
 func (hc *HelpCommand) showCommandHelp(commandName string) error {
 	// This would ideally integrate with cobra's help system
@@ -287,79 +308,13 @@ func (hc *HelpCommand) showCommandHelp(commandName string) error {
 	output.Raw("Or try these help topics:\n")
 	output.Raw("  glide help workflows      # Common workflow examples\n")
 	output.Raw("  glide help getting-started # Complete setup guide\n")
+	for _, t := range plugin.GetGlobalPluginHelpTopics() {
+		output.Raw(fmt.Sprintf("  glide help %-14s # %s\n", t.Topic.Name, t.Topic.Summary))
+	}
 
 	return nil
 }
 
-// CategoryInfo holds information about a command category
-type CategoryInfo struct {
-	Name        string
-	Description string
-	Priority    int // Lower numbers appear first
-	Color       *color.Color
-}
-
-// Categories defines all command categories with their display properties
-var Categories = map[string]CategoryInfo{
-	"core": {
-		Name:        "Core Commands",
-		Description: "Essential development commands",
-		Priority:    10,
-		Color:       color.New(color.FgYellow, color.Bold),
-	},
-	"global": {
-		Name:        "Global Commands",
-		Description: "Multi-worktree management",
-		Priority:    20,
-		Color:       color.New(color.FgYellow, color.Bold),
-	},
-	"setup": {
-		Name:        "Setup & Configuration",
-		Description: "Project setup and configuration",
-		Priority:    30,
-		Color:       color.New(color.FgYellow, color.Bold),
-	},
-	// Project-specific categories (40-60) - will be moved to plugins
-	"docker": {
-		Name:        "Docker Management",
-		Description: "Container and service control",
-		Priority:    40,
-		Color:       color.New(color.FgYellow, color.Bold),
-	},
-	"testing": {
-		Name:        "Testing",
-		Description: "Test execution and coverage",
-		Priority:    50,
-		Color:       color.New(color.FgYellow, color.Bold),
-	},
-	"developer": {
-		Name:        "Development Tools",
-		Description: "Code quality and utilities",
-		Priority:    60,
-		Color:       color.New(color.FgYellow, color.Bold),
-	},
-	"database": {
-		Name:        "Database",
-		Description: "Database management and access",
-		Priority:    70,
-		Color:       color.New(color.FgYellow, color.Bold),
-	},
-	// Plugin commands get their own section
-	"plugin": {
-		Name:        "Plugin Commands",
-		Description: "Commands from installed plugins",
-		Priority:    80,
-		Color:       color.New(color.FgYellow, color.Bold),
-	},
-	// Help is always last
-	"help": {
-		Name:        "Help & Documentation",
-		Description: "Help topics and guides",
-		Priority:    90,
-		Color:       color.New(color.FgYellow, color.Bold),
-	},
-}
-
 // CommandEntry represents a command for display
 type CommandEntry struct {
 	Name        string
@@ -483,17 +438,7 @@ func (hc *HelpCommand) ShowHelp(rootCmd *cobra.Command) error {
 	for cat := range commandsByCategory {
 		sortedCategories = append(sortedCategories, cat)
 	}
-	sort.Slice(sortedCategories, func(i, j int) bool {
-		catI, okI := Categories[sortedCategories[i]]
-		catJ, okJ := Categories[sortedCategories[j]]
-		if !okI {
-			return false
-		}
-		if !okJ {
-			return true
-		}
-		return catI.Priority < catJ.Priority
-	})
+	Categories().SortIDs(sortedCategories)
 
 	// Display commands by category
 	for _, category := range sortedCategories {
@@ -519,7 +464,7 @@ func (hc *HelpCommand) ShowHelp(rootCmd *cobra.Command) error {
 			continue
 		}
 
-		catInfo, ok := Categories[category]
+		catInfo, ok := Categories().Get(category)
 		if !ok {
 			caser := cases.Title(language.English)
 			catInfo = CategoryInfo{
@@ -633,6 +578,9 @@ func (hc *HelpCommand) ShowHelp(rootCmd *cobra.Command) error {
 	fmt.Println("  glide [command] --help       Same as above")
 	fmt.Println("  glide help getting-started   New user guide")
 	fmt.Println("  glide help workflows         Common development patterns")
+	for _, t := range plugin.GetGlobalPluginHelpTopics() {
+		fmt.Printf("  glide help %-17s %s\n", t.Topic.Name, t.Topic.Summary)
+	}
 
 	// Context-aware tips
 	if hc.ProjectContext != nil {
@@ -723,53 +671,13 @@ func (hc *HelpCommand) getPluginSubcommands(rootCmd *cobra.Command, pluginName s
 	return subcommands
 }
 
-// areCompletionsInstalled checks if shell completions are already installed
+// areCompletionsInstalled checks if shell completions are installed for the user's shell
 func (hc *HelpCommand) areCompletionsInstalled() bool {
-	// Check common completion locations for various shells
-	homeDir := os.Getenv("HOME")
-
-	// Locations to check for installed completions
-	completionPaths := []string{
-		// Bash completions
-		fmt.Sprintf("/usr/local/etc/bash_completion.d/%s", branding.CommandName),
-		fmt.Sprintf("/etc/bash_completion.d/%s", branding.CommandName),
-		fmt.Sprintf("%s/.bash_completion.d/%s", homeDir, branding.CommandName),
-
-		// Zsh completions
-		fmt.Sprintf("/usr/local/share/zsh/site-functions/_%s", branding.CommandName),
-		fmt.Sprintf("/usr/share/zsh/site-functions/_%s", branding.CommandName),
-		fmt.Sprintf("%s/.zsh/completions/_%s", homeDir, branding.CommandName),
-
-		// Fish completions
-		fmt.Sprintf("%s/.config/fish/completions/%s.fish", homeDir, branding.CommandName),
-	}
-
-	// Also check if completion content exists in shell config files
-	shellConfigs := []string{
-		fmt.Sprintf("%s/.bashrc", homeDir),
-		fmt.Sprintf("%s/.bash_profile", homeDir),
-		fmt.Sprintf("%s/.bash_completion", homeDir),
-		fmt.Sprintf("%s/.zshrc", homeDir),
+	shell := DetectShell()
+	if shell == "" {
+		return false
 	}
-
-	// Check if any completion file exists
-	for _, path := range completionPaths {
-		if _, err := os.Stat(path); err == nil {
-			return true
-		}
-	}
-
-	// Check if completion is sourced in shell configs
-	for _, configPath := range shellConfigs {
-		if data, err := os.ReadFile(configPath); err == nil {
-			if strings.Contains(string(data), branding.CommandName) &&
-				strings.Contains(string(data), "complete") {
-				return true
-			}
-		}
-	}
-
-	return false
+	return NewCompletionManager(hc.ProjectContext, hc.Config).IsInstalled(shell)
 }
 
 // shouldShowCategory determines if a category should be shown based on context
@@ -904,19 +812,33 @@ func (hc *HelpCommand) shouldShowCommand(cmd *cobra.Command) bool {
 	}
 }
 
-// loadPluginCategories loads custom categories from plugins and adds them to the global Categories map
+// loadPluginCategories registers custom categories from plugins and applies
+// configured priority overrides
 func (hc *HelpCommand) loadPluginCategories() {
-	// Get custom categories from plugins
-	customCategories := plugin.GetGlobalPluginCategories()
-	for _, cat := range customCategories {
-		// Add to the global Categories map
-		Categories[cat.Id] = CategoryInfo{
+	for _, owned := range plugin.GetGlobalPluginCategories() {
+		cat := owned.Category
+		source := owned.Plugin
+		if source == "" {
+			source = "plugin"
+		}
+
+		err := Categories().Register(CategoryInfo{
+			ID:          cat.Id,
 			Name:        cat.Name,
 			Description: cat.Description,
 			Priority:    int(cat.Priority),
-			Color:       color.New(color.FgYellow, color.Bold), // Yellow bold for custom categories
+			Color:       categoryColor(cat.Color),
+			Source:      source,
+		})
+		if err != nil {
+			// The first plugin keeps a contested ID
+			logging.Warn("Ignoring plugin category", "error", err)
 		}
 	}
+
+	if hc.Config != nil {
+		Categories().SetPriorityOverrides(hc.Config.Defaults.Help.CategoryPriorities)
+	}
 }
 
 // showContextInfo displays context information at the top of help
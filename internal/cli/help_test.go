@@ -543,7 +543,7 @@ func TestCategories(t *testing.T) {
 		}
 
 		for _, cat := range expectedCategories {
-			info, exists := Categories[cat]
+			info, exists := Categories().Get(cat)
 			assert.True(t, exists, "category %s should be defined", cat)
 			assert.NotEmpty(t, info.Name)
 			assert.NotEmpty(t, info.Description)
@@ -553,18 +553,22 @@ func TestCategories(t *testing.T) {
 
 	t.Run("category priorities", func(t *testing.T) {
 		// Core should have lower priority (appears first)
-		assert.Less(t, Categories["core"].Priority, Categories["help"].Priority,
+		core, _ := Categories().Get("core")
+		help, _ := Categories().Get("help")
+		assert.Less(t, core.Priority, help.Priority,
 			"core should appear before help")
 
-		assert.Less(t, Categories["setup"].Priority, Categories["plugin"].Priority,
+		setup, _ := Categories().Get("setup")
+		pluginCat, _ := Categories().Get("plugin")
+		assert.Less(t, setup.Priority, pluginCat.Priority,
 			"setup should appear before plugin")
 	})
 
 	t.Run("category display order", func(t *testing.T) {
 		// Extract priorities
 		priorities := make(map[string]int)
-		for cat, info := range Categories {
-			priorities[cat] = info.Priority
+		for _, info := range Categories().List() {
+			priorities[info.ID] = info.Priority
 		}
 
 		// Core commands should be first (priority 10)
@@ -696,7 +700,7 @@ func TestHelpTopicAliases(t *testing.T) {
 // TestCategoryInfo tests category information structure
 func TestCategoryInfo(t *testing.T) {
 	t.Run("core category info", func(t *testing.T) {
-		info := Categories["core"]
+		info, _ := Categories().Get("core")
 		assert.Equal(t, "Core Commands", info.Name)
 		assert.Equal(t, "Essential development commands", info.Description)
 		assert.Equal(t, 10, info.Priority)
@@ -704,14 +708,14 @@ func TestCategoryInfo(t *testing.T) {
 	})
 
 	t.Run("plugin category info", func(t *testing.T) {
-		info := Categories["plugin"]
+		info, _ := Categories().Get("plugin")
 		assert.Equal(t, "Plugin Commands", info.Name)
 		assert.Equal(t, "Commands from installed plugins", info.Description)
 		assert.Equal(t, 80, info.Priority)
 	})
 
 	t.Run("help category info", func(t *testing.T) {
-		info := Categories["help"]
+		info, _ := Categories().Get("help")
 		assert.Equal(t, "Help & Documentation", info.Name)
 		assert.Equal(t, 90, info.Priority) // Should be last
 	})
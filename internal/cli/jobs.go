@@ -0,0 +1,319 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/jobs"
+	"github.com/glide-cli/glide/v3/internal/lock"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/featureflags"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// daemonFeature is the feature flag scheduled jobs run behind
+const daemonFeature = "daemon"
+
+var (
+	// newJobRunner and jobsNow are replaced in tests
+	newJobRunner = jobs.NewRunner
+	jobsNow      = time.Now
+)
+
+// JobRow is a job listed by `glide jobs list`
+type JobRow struct {
+	Name     string    `json:"name" yaml:"name"`
+	Schedule string    `json:"schedule" yaml:"schedule"`
+	Command  string    `json:"command" yaml:"command"`
+	Dir      string    `json:"dir,omitempty" yaml:"dir,omitempty"`
+	Jitter   string    `json:"jitter,omitempty" yaml:"jitter,omitempty"`
+	NextRun  time.Time `json:"next_run" yaml:"next_run"`
+	// LastRun is nil when the job never ran
+	LastRun *jobs.Run `json:"last_run,omitempty" yaml:"last_run,omitempty"`
+	Log     string    `json:"log" yaml:"log"`
+}
+
+// JobsReport is the result of `glide jobs list`
+type JobsReport struct {
+	Jobs []JobRow `json:"jobs" yaml:"jobs"`
+	// Scheduled is whether the daemon feature, which runs the jobs on
+	// their schedules, is on
+	Scheduled bool `json:"scheduled" yaml:"scheduled"`
+}
+
+// NewJobsCommand creates the jobs command group
+func NewJobsCommand(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "List and run scheduled maintenance jobs",
+		Long: fmt.Sprintf(`Scheduled jobs run maintenance commands, such as a nightly cache prune or
+an image prefetch before the working day, while the daemon feature is on.
+Configure them under jobs: in ~/.glide.yml:
+
+  jobs:
+    prune:
+      schedule: "0 3 * * *"      # minute hour day-of-month month day-of-week
+      run: cache prune
+      jitter: 30m                # start up to 30 minutes late
+    prefetch:
+      schedule: "0 8 * * 1-5"
+      run: prefetch
+      dir: ~/code/acme
+
+Each job's output is appended to a log under ~/.glide/logs/jobs.
+
+Examples:
+  %[1]s jobs list          # Jobs with their next and last runs
+  %[1]s jobs run prune     # Run a job now
+  %[1]s jobs serve         # Run jobs on their schedules`, branding.CommandName),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.AddCommand(newJobsListCommand(cfg), newJobsRunCommand(cfg), newJobsServeCommand(cfg))
+	return cmd
+}
+
+// newJobsListCommand lists the configured jobs
+func newJobsListCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List scheduled jobs with their next and last runs",
+		Long: fmt.Sprintf(`List the configured jobs, when each runs next, and how its last run went.
+Next runs do not include the job's jitter.
+
+Examples:
+  %[1]s jobs list
+  %[1]s jobs list --format json`, branding.CommandName),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := jobsReport(cfg)
+			if err != nil {
+				return err
+			}
+			if output.GetFormat().IsStructured() {
+				return output.Display(report)
+			}
+			showJobs(report)
+			return nil
+		},
+	}
+}
+
+// newJobsRunCommand runs a job now
+func newJobsRunCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "run <job>",
+		Short: "Run a scheduled job now",
+		Long: fmt.Sprintf(`Run a job now, whether or not the daemon feature is on. Its output is shown
+and appended to its log, and the run is recorded like a scheduled one.
+
+Examples:
+  %[1]s jobs run prune`, branding.CommandName),
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			list, _ := configuredJobs(cfg)
+			names := make([]string, 0, len(list))
+			for _, job := range list {
+				names = append(names, job.Name+"\t"+job.Command())
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJob(cmd.Context(), cfg, args[0])
+		},
+	}
+}
+
+// newJobsServeCommand runs the jobs on their schedules
+func newJobsServeCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Run scheduled jobs on their schedules until stopped",
+		Long: fmt.Sprintf(`Run the configured jobs on their schedules, one at a time, until
+interrupted. This is the daemon's scheduler and needs the daemon feature:
+
+  %[1]s features enable daemon
+
+A job whose time passed while nothing served it, e.g. overnight with the
+machine off, runs once right away. Run it in the background or from a
+launchd or systemd user service to keep jobs running.`, branding.CommandName),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return serveJobs(cmd.Context(), cfg)
+		},
+	}
+}
+
+// configuredJobs parses the jobs of the configuration
+func configuredJobs(cfg *config.Config) ([]jobs.Job, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	return jobs.FromConfig(cfg.Jobs)
+}
+
+// jobsReport collects the configured jobs and their runs
+func jobsReport(cfg *config.Config) (JobsReport, error) {
+	list, err := configuredJobs(cfg)
+	if err != nil {
+		return JobsReport{}, err
+	}
+	runner, err := newJobRunner()
+	if err != nil {
+		return JobsReport{}, err
+	}
+
+	report := JobsReport{Jobs: []JobRow{}, Scheduled: featureflags.Enabled(daemonFeature)}
+	state := jobs.LoadState(runner.StatePath)
+	now := jobsNow()
+	for _, job := range list {
+		last := state.Last(job.Name)
+		row := JobRow{
+			Name:     job.Name,
+			Schedule: job.Schedule.String(),
+			Command:  job.Command(),
+			Dir:      job.Dir,
+			NextRun:  job.NextRun(last, now),
+			Log:      jobs.LogPath(runner.LogDir, job.Name),
+		}
+		if job.Jitter > 0 {
+			row.Jitter = job.Jitter.String()
+		}
+		if !last.Started.IsZero() {
+			row.LastRun = &last
+		}
+		report.Jobs = append(report.Jobs, row)
+	}
+	return report, nil
+}
+
+// showJobs prints the jobs as a table
+func showJobs(report JobsReport) {
+	if len(report.Jobs) == 0 {
+		output.Info("No jobs are configured. Add them under jobs: in ~/.glide.yml")
+		return
+	}
+
+	table := output.NewTable("NAME", "SCHEDULE", "COMMAND", "NEXT RUN", "LAST RUN", "STATUS")
+	table.AddRow("----", "--------", "-------", "--------", "--------", "------")
+	for _, row := range report.Jobs {
+		next := "never"
+		if !row.NextRun.IsZero() {
+			next = row.NextRun.Local().Format("2006-01-02 15:04")
+		}
+		lastRun, status := "-", "never run"
+		if row.LastRun != nil {
+			lastRun = row.LastRun.Started.Local().Format("2006-01-02 15:04")
+			status = jobStatus(*row.LastRun)
+		}
+		table.AddRow(row.Name, row.Schedule, row.Command, next, lastRun, status)
+	}
+	_ = output.PrintTable(table)
+
+	if !report.Scheduled {
+		output.Println()
+		output.Info("Jobs only run on their schedules with the daemon feature on: %s features enable %s, then %s jobs serve",
+			branding.CommandName, daemonFeature, branding.CommandName)
+	}
+}
+
+// jobStatus describes how a run went
+func jobStatus(run jobs.Run) string {
+	switch {
+	case run.Error != "":
+		return "error: " + run.Error
+	case run.ExitCode != 0:
+		return fmt.Sprintf("failed (exit %d)", run.ExitCode)
+	default:
+		return fmt.Sprintf("ok (%s)", run.Duration.Round(time.Second))
+	}
+}
+
+// runJob runs the named job now, in the foreground
+func runJob(ctx context.Context, cfg *config.Config, name string) error {
+	list, err := configuredJobs(cfg)
+	if err != nil {
+		return err
+	}
+	job, ok := jobs.Find(list, name)
+	if !ok {
+		return glideErrors.New(glideErrors.TypeMissing, fmt.Sprintf("unknown job: %s", name),
+			glideErrors.WithSuggestions(fmt.Sprintf("Run '%s jobs list' to see the configured jobs", branding.CommandName)),
+		)
+	}
+	runner, err := newJobRunner()
+	if err != nil {
+		return err
+	}
+
+	run, err := runner.Run(ctx, job, os.Stdout, false)
+	var held *lock.HeldError
+	if errors.As(err, &held) {
+		return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("job %s is already running", name),
+			glideErrors.WithContext("holder", held.Holder.String()),
+		)
+	}
+	if err != nil {
+		return glideErrors.Wrap(err, fmt.Sprintf("job %s could not run", name))
+	}
+	if !run.Succeeded() {
+		return glideErrors.NewCommandError(job.Command(), run.ExitCode,
+			glideErrors.WithSuggestions("See its log: "+jobs.LogPath(runner.LogDir, name)),
+		)
+	}
+	output.Success("Job %s finished in %s", name, run.Duration.Round(time.Millisecond))
+	return nil
+}
+
+// serveJobs runs the jobs on their schedules until interrupted
+func serveJobs(ctx context.Context, cfg *config.Config) error {
+	if !featureflags.Enabled(daemonFeature) {
+		return glideErrors.NewConfigError("scheduled jobs run with the daemon feature, which is off",
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Turn it on: %s features enable %s", branding.CommandName, daemonFeature),
+				fmt.Sprintf("Or run a job once: %s jobs run <job>", branding.CommandName),
+			),
+		)
+	}
+	list, err := configuredJobs(cfg)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return glideErrors.New(glideErrors.TypeMissing, "no jobs are configured",
+			glideErrors.WithSuggestions("Add them under jobs: in ~/.glide.yml"),
+		)
+	}
+	runner, err := newJobRunner()
+	if err != nil {
+		return err
+	}
+	runner.Logf = func(format string, args ...any) {
+		output.Info("%s  %s", jobsNow().Format("15:04:05"), fmt.Sprintf(format, args...))
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return runner.Serve(ctx, list)
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/jobs"
+	"github.com/glide-cli/glide/v3/internal/lock"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubJobRunner makes jobs run with sh, in temporary directories
+func stubJobRunner(t *testing.T) *jobs.Runner {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("jobs are run with sh in tests")
+	}
+	dir := t.TempDir()
+	runner := &jobs.Runner{
+		Exe:       "/bin/sh",
+		LogDir:    filepath.Join(dir, "logs"),
+		StatePath: filepath.Join(dir, "jobs.json"),
+		Locks:     lock.NewManager(filepath.Join(dir, "locks")),
+	}
+	originalRunner, originalNow := newJobRunner, jobsNow
+	newJobRunner = func() (*jobs.Runner, error) { return runner, nil }
+	jobsNow = func() time.Time { return time.Date(2026, 3, 8, 12, 30, 0, 0, time.Local) }
+	t.Cleanup(func() { newJobRunner, jobsNow = originalRunner, originalNow })
+	return runner
+}
+
+func TestJobsReport(t *testing.T) {
+	runner := stubJobRunner(t)
+	t.Setenv("GLIDE_FEATURES", "daemon")
+
+	state := jobs.LoadState(runner.StatePath)
+	state.Runs["prune"] = jobs.Run{Started: time.Date(2026, 3, 8, 3, 0, 0, 0, time.Local), Duration: 2 * time.Second}
+	require.NoError(t, state.Save())
+
+	cfg := &config.Config{Jobs: map[string]config.JobConfig{
+		"prune":    {Schedule: "0 3 * * *", Run: "cache prune", Jitter: "30m"},
+		"prefetch": {Schedule: "0 8 * * 1-5", Run: "prefetch"},
+	}}
+	report, err := jobsReport(cfg)
+	require.NoError(t, err)
+	assert.True(t, report.Scheduled)
+	require.Len(t, report.Jobs, 2)
+
+	prefetch, prune := report.Jobs[0], report.Jobs[1]
+	assert.Equal(t, time.Date(2026, 3, 9, 8, 0, 0, 0, time.Local), prefetch.NextRun)
+	assert.Nil(t, prefetch.LastRun)
+
+	assert.Equal(t, "cache prune", prune.Command)
+	assert.Equal(t, "30m0s", prune.Jitter)
+	assert.Equal(t, time.Date(2026, 3, 9, 3, 0, 0, 0, time.Local), prune.NextRun)
+	require.NotNil(t, prune.LastRun)
+	assert.Equal(t, "ok (2s)", jobStatus(*prune.LastRun))
+	assert.Equal(t, filepath.Join(runner.LogDir, "prune.log"), prune.Log)
+
+	_, err = jobsReport(&config.Config{Jobs: map[string]config.JobConfig{"bad": {Schedule: "nightly", Run: "x"}}})
+	assert.ErrorContains(t, err, "invalid job bad")
+}
+
+func TestRunJob(t *testing.T) {
+	runner := stubJobRunner(t)
+	cfg := &config.Config{Jobs: map[string]config.JobConfig{
+		"ok":   {Schedule: "@daily", Run: "-c true"},
+		"fail": {Schedule: "@daily", Run: "-c false"},
+	}}
+
+	require.NoError(t, runJob(context.Background(), cfg, "ok"))
+	assert.True(t, jobs.LoadState(runner.StatePath).Last("ok").Succeeded())
+
+	err := runJob(context.Background(), cfg, "fail")
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeCommand))
+	assert.Equal(t, 1, jobs.LoadState(runner.StatePath).Last("fail").ExitCode)
+
+	err = runJob(context.Background(), cfg, "nope")
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeMissing))
+}
+
+func TestServeJobsNeedsDaemon(t *testing.T) {
+	stubJobRunner(t)
+	cfg := &config.Config{Jobs: map[string]config.JobConfig{"ok": {Schedule: "@daily", Run: "-c true"}}}
+
+	t.Setenv("GLIDE_FEATURES", "-daemon")
+	err := serveJobs(context.Background(), cfg)
+	assert.ErrorContains(t, err, "daemon feature")
+
+	t.Setenv("GLIDE_FEATURES", "daemon")
+	err = serveJobs(context.Background(), &config.Config{})
+	assert.ErrorContains(t, err, "no jobs are configured")
+}
@@ -0,0 +1,327 @@
+package cli
+
+import (
+	stdcontext "context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/internal/lock"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/progress"
+	"github.com/glide-cli/glide/v3/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// lockManager returns the manager of glide's locks and is replaced in
+	// tests
+	lockManager = func() *lock.Manager { return lock.NewManager(lock.DefaultDir()) }
+
+	// confirmQueue asks whether to wait for a held lock and is replaced in
+	// tests
+	confirmQueue = prompt.Confirm
+)
+
+// unsafeLockNameChars are replaced in lock names
+var unsafeLockNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// ExclusiveAnnotation lists, comma-separated, the exclusivity groups of a
+// command: commands sharing a group never run at the same time
+const ExclusiveAnnotation = "exclusive"
+
+// Exclusivity groups of the builtin commands
+const (
+	// GroupCompose starts or stops a worktree's containers
+	GroupCompose = "compose"
+	// GroupConfigWrite changes the global config file
+	GroupConfigWrite = "config-write"
+	// GroupPlugins changes the installed plugins
+	GroupPlugins = "plugins"
+)
+
+// worktreeGroups are the exclusivity groups held per worktree rather than
+// for the whole machine; they only apply inside a project
+var worktreeGroups = map[string]bool{GroupCompose: true}
+
+var (
+	// groupMutexes serialize the commands of a group within this process,
+	// which the file locks cannot, as they do not exclude their own process
+	groupMutexesMu sync.Mutex
+	groupMutexes   = make(map[string]*sync.Mutex)
+)
+
+// MarkExclusive puts cmd in exclusivity groups, such as GroupConfigWrite.
+// Commands of a group are serialized within and across glide processes.
+// GroupCompose is held per worktree; other groups are held machine-wide.
+func MarkExclusive(cmd *cobra.Command, groups ...string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	if existing := cmd.Annotations[ExclusiveAnnotation]; existing != "" {
+		groups = append(strings.Split(existing, ","), groups...)
+	}
+	slices.Sort(groups)
+	cmd.Annotations[ExclusiveAnnotation] = strings.Join(slices.Compact(groups), ",")
+}
+
+// CommandLocks makes commands hold the locks of their exclusivity groups
+// while they run, so they never race another command of the same group in
+// this or another glide process. `up` and `down` are always in the compose
+// group, however the plugins or .glide.yml provide them. When a lock is
+// held, --wait queues behind the holder; without it, interactive sessions
+// are asked and others fail. The locks are released whatever the outcome.
+func CommandLocks(ctx *context.ProjectContext) Middleware {
+	composeCommands := projectCommands(ctx, "up", "down")
+	return Middleware{
+		Name: "locks",
+		Applies: func(cmd *cobra.Command) bool {
+			return composeCommands(cmd) || cmd.Annotations[ExclusiveAnnotation] != ""
+		},
+		Wrap: func(cmd *cobra.Command, next RunFunc) RunFunc {
+			groups := exclusiveGroups(cmd, ctx, composeCommands(cmd))
+			return func(cmd *cobra.Command, args []string) error {
+				if IsDryRun(cmd) || len(groups) == 0 {
+					return next(cmd, args)
+				}
+				release, err := acquireGroups(cmd, groups, ctx)
+				if err != nil {
+					return err
+				}
+				defer release()
+				return next(cmd, args)
+			}
+		},
+	}
+}
+
+// exclusiveGroups returns the sorted exclusivity groups cmd holds, without
+// worktree groups outside a project
+func exclusiveGroups(cmd *cobra.Command, ctx *context.ProjectContext, compose bool) []string {
+	var groups []string
+	if compose {
+		groups = append(groups, GroupCompose)
+	}
+	for _, group := range strings.Split(cmd.Annotations[ExclusiveAnnotation], ",") {
+		group = strings.TrimSpace(group)
+		if group == "" || worktreeGroups[group] && (ctx == nil || ctx.ProjectRoot == "") {
+			continue
+		}
+		groups = append(groups, group)
+	}
+	slices.Sort(groups)
+	return slices.Compact(groups)
+}
+
+// acquireGroups holds the groups in order, so commands sharing several
+// never deadlock, and returns what releases them
+func acquireGroups(cmd *cobra.Command, groups []string, ctx *context.ProjectContext) (release func(), err error) {
+	var held []func()
+	release = func() {
+		for i := len(held) - 1; i >= 0; i-- {
+			held[i]()
+		}
+	}
+
+	owner := lockOwner(ctx)
+	for _, group := range groups {
+		mu := groupMutex(group)
+		mu.Lock()
+		held = append(held, mu.Unlock)
+
+		name, where := group, ""
+		if worktreeGroups[group] {
+			name, where = groupLockName(group, owner.Project, owner.Worktree), " for this worktree"
+		}
+		l, err := acquireCommandLock(cmd, name, where, owner)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		held = append(held, func() {
+			if err := l.Release(); err != nil {
+				logging.Debug("Could not release lock", "lock", l.Name(), "error", err)
+			}
+		})
+	}
+	return release, nil
+}
+
+// groupMutex returns the in-process mutex of an exclusivity group
+func groupMutex(group string) *sync.Mutex {
+	groupMutexesMu.Lock()
+	defer groupMutexesMu.Unlock()
+	mu, ok := groupMutexes[group]
+	if !ok {
+		mu = &sync.Mutex{}
+		groupMutexes[group] = mu
+	}
+	return mu
+}
+
+// lockOwner returns the project and worktree a lock holder records; both
+// are empty outside a project
+func lockOwner(ctx *context.ProjectContext) docker.Ownership {
+	if ctx == nil || ctx.ProjectRoot == "" {
+		return docker.Ownership{}
+	}
+	_, owner := worktreeOwnership(ctx)
+	return owner
+}
+
+// groupLockName names the lock of a worktree group, such as the compose
+// lock. The hash keeps worktrees of projects with the same directory name
+// apart.
+func groupLockName(group, project, worktree string) string {
+	sum := sha256.Sum256([]byte(project + "\n" + worktree))
+	base := unsafeLockNameChars.ReplaceAllString(filepath.Base(project)+"-"+worktree, "-")
+	return group + "-" + base + "-" + hex.EncodeToString(sum[:4])
+}
+
+// acquireCommandLock acquires the named lock for the running command,
+// queueing behind the holder as --wait or the user decides. where says
+// what the lock covers in messages, e.g. " for this worktree". A nil lock
+// with a nil error means locking is unavailable, which never blocks the
+// command.
+func acquireCommandLock(cmd *cobra.Command, name, where string, owner docker.Ownership) (*lock.Lock, error) {
+	hostname, _ := os.Hostname()
+	holder := lock.Holder{
+		PID:      os.Getpid(),
+		Command:  strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+		Project:  owner.Project,
+		Worktree: owner.Worktree,
+		Hostname: hostname,
+	}
+
+	manager := lockManager()
+	l, err := manager.TryAcquire(name, holder)
+	var held *lock.HeldError
+	if !errors.As(err, &held) {
+		if err != nil {
+			logging.Debug("Could not lock, continuing without", "lock", name, "error", err)
+			return nil, nil
+		}
+		return l, nil
+	}
+
+	wait, limit, err := waitPolicy(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if !wait && stdinIsTerminal() && !cmd.Flags().Changed("wait") {
+		output.Warning("%s is already running%s", describeHolder(held.Holder), where)
+		if wait, err = confirmQueue("Wait for it to finish?", true); err != nil {
+			return nil, err
+		}
+	}
+	if !wait {
+		suggestions := []string{"Wait for it to finish with --wait, or at most a while with --wait=10m"}
+		if held.Holder.PID != 0 {
+			suggestions = append(suggestions, fmt.Sprintf("If it is stuck, stop process %d", held.Holder.PID))
+		}
+		return nil, glideErrors.New(glideErrors.TypeCommand,
+			fmt.Sprintf("%s is already running%s", describeHolder(held.Holder), where),
+			glideErrors.WithSuggestions(suggestions...),
+		)
+	}
+
+	return waitForLock(cmd, manager, name, holder, held.Holder, limit)
+}
+
+// waitPolicy reads --wait: "true" waits without a limit, "false" never
+// waits, and a duration waits at most that long
+func waitPolicy(cmd *cobra.Command) (wait bool, limit time.Duration, err error) {
+	flag := cmd.Flags().Lookup("wait")
+	if flag == nil || !flag.Changed {
+		return false, 0, nil
+	}
+	switch value := flag.Value.String(); value {
+	case "true":
+		return true, 0, nil
+	case "false":
+		return false, 0, nil
+	default:
+		limit, err := time.ParseDuration(value)
+		if err != nil || limit <= 0 {
+			return false, 0, glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("invalid --wait value %q", value),
+				glideErrors.WithSuggestions(`Use --wait to wait as long as it takes, or a duration such as --wait=10m`),
+			)
+		}
+		return true, limit, nil
+	}
+}
+
+// waitForLock queues behind the holder with a spinner showing the expected
+// remaining time
+func waitForLock(cmd *cobra.Command, manager *lock.Manager, name string, holder, current lock.Holder, limit time.Duration) (*lock.Lock, error) {
+	waitCtx := cmd.Context()
+	if waitCtx == nil {
+		waitCtx = stdcontext.Background()
+	}
+	if limit > 0 {
+		var cancel stdcontext.CancelFunc
+		waitCtx, cancel = stdcontext.WithTimeout(waitCtx, limit)
+		defer cancel()
+	}
+
+	started := time.Now()
+	output.Info("⏳ Queued behind %s", describeHolder(current))
+	spinner := progress.NewSpinner(waitMessage(manager, name, current))
+	spinner.Start()
+	l, err := manager.Wait(waitCtx, name, holder, func(h lock.Holder) {
+		current = h
+		spinner.Update(waitMessage(manager, name, h))
+	})
+	if err != nil {
+		spinner.Error(fmt.Sprintf("Gave up waiting for %s", describeHolder(current)))
+		if errors.Is(err, stdcontext.DeadlineExceeded) {
+			return nil, glideErrors.New(glideErrors.TypeTimeout,
+				fmt.Sprintf("%s was still running after waiting %s", describeHolder(current), limit),
+				glideErrors.WithSuggestions("Wait longer with a larger --wait, or without a limit with --wait"),
+			)
+		}
+		return nil, err
+	}
+	spinner.Stop()
+	output.Info("Waited %s for %s", output.Duration(time.Since(started)), describeHolder(current))
+	return l, nil
+}
+
+// waitMessage describes what a queued command is waiting for and when it
+// expects to continue
+func waitMessage(manager *lock.Manager, name string, holder lock.Holder) string {
+	message := fmt.Sprintf("Waiting for %s to finish", describeHolder(holder))
+	remaining, ok := manager.Estimate(name, holder)
+	switch {
+	case !ok:
+		if !holder.Started.IsZero() {
+			message += fmt.Sprintf(" (running for %s)", output.Duration(time.Since(holder.Started)))
+		}
+	case remaining == 0:
+		message += " (should finish any moment)"
+	default:
+		message += fmt.Sprintf(" (ETA ~%s)", output.Duration(remaining))
+	}
+	return message
+}
+
+// describeHolder names the holder, with its host when it runs elsewhere
+func describeHolder(holder lock.Holder) string {
+	description := holder.String()
+	if hostname, _ := os.Hostname(); holder.Hostname != "" && holder.Hostname != hostname {
+		description += " on " + holder.Hostname
+	}
+	return description
+}
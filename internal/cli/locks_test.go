@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/lock"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubLocks points locking at a temporary directory and makes the session
+// non-interactive
+func stubLocks(t *testing.T) *lock.Manager {
+	t.Helper()
+	manager := lock.NewManager(t.TempDir())
+	manager.PollInterval = 10 * time.Millisecond
+	originalManager, originalTerminal := lockManager, stdinIsTerminal
+	lockManager = func() *lock.Manager { return manager }
+	stdinIsTerminal = func() bool { return false }
+	t.Cleanup(func() {
+		lockManager, stdinIsTerminal = originalManager, originalTerminal
+	})
+	return manager
+}
+
+// lockedTree returns a root with up and down holding the compose lock; up
+// runs hook while it holds the lock
+func lockedTree(ctx *context.ProjectContext, hook func()) *cobra.Command {
+	root := &cobra.Command{Use: "glide"}
+	root.PersistentFlags().String("wait", "", "")
+	root.PersistentFlags().Lookup("wait").NoOptDefVal = "true"
+	root.PersistentFlags().Bool("dry-run", false, "")
+	root.AddCommand(
+		&cobra.Command{Use: "up", Run: func(*cobra.Command, []string) { hook() }},
+		&cobra.Command{Use: "down", RunE: func(*cobra.Command, []string) error { return nil }},
+	)
+	Chain{CommandLocks(ctx)}.Apply(root)
+	return root
+}
+
+// holdComposeLock takes the worktree's compose lock as another process would
+func holdComposeLock(t *testing.T, manager *lock.Manager, ctx *context.ProjectContext) *lock.Lock {
+	t.Helper()
+	_, owner := worktreeOwnership(ctx)
+	l, err := manager.TryAcquire(groupLockName(GroupCompose, owner.Project, owner.Worktree), lock.Holder{PID: 4242, Command: "up"})
+	require.NoError(t, err)
+	return l
+}
+
+func TestCommandLocks(t *testing.T) {
+	manager := stubLocks(t)
+	ctx := &context.ProjectContext{ProjectRoot: t.TempDir()}
+	_, owner := worktreeOwnership(ctx)
+	name := groupLockName(GroupCompose, owner.Project, owner.Worktree)
+
+	var holder lock.Holder
+	root := lockedTree(ctx, func() {
+		holder, _ = manager.Holder(name)
+	})
+	root.SetArgs([]string{"up"})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "up", holder.Command, "up holds the lock while it runs")
+
+	_, err := manager.Holder(name)
+	assert.Error(t, err, "the lock is released afterwards")
+}
+
+func TestCommandLocks_Held(t *testing.T) {
+	manager := stubLocks(t)
+	ctx := &context.ProjectContext{ProjectRoot: t.TempDir()}
+	held := holdComposeLock(t, manager, ctx)
+	defer held.Release()
+
+	ran := false
+	root := lockedTree(ctx, func() { ran = true })
+	root.SetArgs([]string{"up"})
+	err := root.Execute()
+	require.Error(t, err)
+	assert.False(t, ran)
+	assert.Contains(t, err.Error(), "glide up (pid 4242) is already running")
+
+	root.SetArgs([]string{"up", "--dry-run"})
+	require.NoError(t, root.Execute(), "dry runs do not lock")
+	assert.True(t, ran)
+}
+
+func TestCommandLocks_Wait(t *testing.T) {
+	manager := stubLocks(t)
+	ctx := &context.ProjectContext{ProjectRoot: t.TempDir()}
+	held := holdComposeLock(t, manager, ctx)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		// Safe to ignore: the waiting command only needs the lock released
+		_ = held.Release()
+	}()
+
+	ran := false
+	root := lockedTree(ctx, func() { ran = true })
+	root.SetArgs([]string{"up", "--wait"})
+	require.NoError(t, root.Execute())
+	assert.True(t, ran)
+}
+
+func TestCommandLocks_WaitLimit(t *testing.T) {
+	manager := stubLocks(t)
+	ctx := &context.ProjectContext{ProjectRoot: t.TempDir()}
+	held := holdComposeLock(t, manager, ctx)
+	defer held.Release()
+
+	root := lockedTree(ctx, func() {})
+	root.SetArgs([]string{"down", "--wait=50ms"})
+	err := root.Execute()
+	var glideErr *glideErrors.GlideError
+	require.True(t, errors.As(err, &glideErr))
+	assert.Equal(t, glideErrors.TypeTimeout, glideErr.Type)
+
+	root.SetArgs([]string{"down", "--wait=soon"})
+	err = root.Execute()
+	require.True(t, errors.As(err, &glideErr))
+	assert.Equal(t, glideErrors.TypeInvalid, glideErr.Type)
+}
+
+func TestCommandLocks_Prompt(t *testing.T) {
+	manager := stubLocks(t)
+	stdinIsTerminal = func() bool { return true }
+	ctx := &context.ProjectContext{ProjectRoot: t.TempDir()}
+	held := holdComposeLock(t, manager, ctx)
+	defer held.Release()
+
+	asked := 0
+	original := confirmQueue
+	confirmQueue = func(string, bool) (bool, error) {
+		asked++
+		return false, nil
+	}
+	t.Cleanup(func() { confirmQueue = original })
+
+	root := lockedTree(ctx, func() {})
+	root.SetArgs([]string{"up"})
+	require.Error(t, root.Execute())
+	assert.Equal(t, 1, asked)
+
+	root.SetArgs([]string{"up", "--wait=false"})
+	require.Error(t, root.Execute())
+	assert.Equal(t, 1, asked, "--wait=false never asks")
+}
+
+func TestGroupLockName(t *testing.T) {
+	name := groupLockName(GroupCompose, "/src/my app", "feature/api")
+	assert.Regexp(t, `^compose-my-app-feature-api-[0-9a-f]{8}$`, name)
+	assert.NotEqual(t, name, groupLockName(GroupCompose, "/other/my app", "feature/api"))
+}
+
+func TestMarkExclusive(t *testing.T) {
+	cmd := &cobra.Command{Use: "install"}
+	MarkExclusive(cmd, GroupPlugins)
+	MarkExclusive(cmd, GroupConfigWrite, GroupPlugins)
+	assert.Equal(t, "config-write,plugins", cmd.Annotations[ExclusiveAnnotation])
+
+	project := &context.ProjectContext{ProjectRoot: t.TempDir()}
+	MarkExclusive(cmd, GroupCompose)
+	assert.Equal(t, []string{"compose", "config-write", "plugins"}, exclusiveGroups(cmd, project, false))
+	assert.Equal(t, []string{"config-write", "plugins"}, exclusiveGroups(cmd, nil, false),
+		"worktree groups only apply inside a project")
+	assert.Equal(t, []string{"compose"}, exclusiveGroups(&cobra.Command{Use: "up"}, project, true))
+}
+
+func TestCommandLocks_ExclusiveGroup(t *testing.T) {
+	manager := stubLocks(t)
+
+	var holder lock.Holder
+	root := &cobra.Command{Use: "glide"}
+	root.PersistentFlags().String("wait", "", "")
+	root.PersistentFlags().Bool("dry-run", false, "")
+	enable := &cobra.Command{Use: "enable", RunE: func(*cobra.Command, []string) error {
+		holder, _ = manager.Holder(GroupConfigWrite)
+		return nil
+	}}
+	MarkExclusive(enable, GroupConfigWrite)
+	root.AddCommand(enable)
+	Chain{CommandLocks(nil)}.Apply(root)
+
+	// Machine-wide groups lock outside a project too
+	root.SetArgs([]string{"enable"})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "enable", holder.Command)
+
+	held, err := manager.TryAcquire(GroupConfigWrite, lock.Holder{PID: 4242, Command: "setup"})
+	require.NoError(t, err)
+	defer held.Release()
+
+	root.SetArgs([]string{"enable"})
+	err = root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "glide setup (pid 4242) is already running")
+	assert.NotContains(t, err.Error(), "worktree")
+}
+
+func TestCommandLocks_SameProcess(t *testing.T) {
+	stubLocks(t)
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	var running, overlapped int32
+	run := func(*cobra.Command, []string) error {
+		if atomic.AddInt32(&running, 1) > 1 {
+			atomic.StoreInt32(&overlapped, 1)
+		}
+		started <- struct{}{}
+		<-finish
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+
+	var roots []*cobra.Command
+	for range 2 {
+		root := &cobra.Command{Use: "glide"}
+		root.PersistentFlags().String("wait", "", "")
+		root.PersistentFlags().Bool("dry-run", false, "")
+		install := &cobra.Command{Use: "install", RunE: run}
+		MarkExclusive(install, GroupPlugins)
+		root.AddCommand(install)
+		Chain{CommandLocks(nil)}.Apply(root)
+		root.SetArgs([]string{"install"})
+		roots = append(roots, root)
+	}
+
+	errs := make(chan error, len(roots))
+	for _, root := range roots {
+		go func() { errs <- root.Execute() }()
+	}
+	for range roots {
+		<-started
+		finish <- struct{}{}
+	}
+	for range roots {
+		require.NoError(t, <-errs, "commands of the same process queue rather than fail")
+	}
+	assert.Zero(t, atomic.LoadInt32(&overlapped))
+}
@@ -0,0 +1,485 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/internal/meta"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/progress"
+	"github.com/spf13/cobra"
+)
+
+// metaOutputTail is the number of output lines shown for a failed project
+const metaOutputTail = 20
+
+// runMetaProject runs glide with args in a project directory and is
+// replaced in tests
+var runMetaProject = func(dir string, args []string) ([]byte, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(exe, args...)
+	cmd.Dir = dir
+	cmd.Env = metaEnvironment()
+	return cmd.CombinedOutput()
+}
+
+// MetaResult is the structured outcome of a command in one project
+type MetaResult struct {
+	Project  string  `json:"project" yaml:"project"`
+	Dir      string  `json:"dir" yaml:"dir"`
+	Command  string  `json:"command" yaml:"command"`
+	Seconds  float64 `json:"seconds" yaml:"seconds"`
+	ExitCode int     `json:"exit_code" yaml:"exit_code"`
+	Error    string  `json:"error,omitempty" yaml:"error,omitempty"`
+	Output   string  `json:"output,omitempty" yaml:"output,omitempty"`
+}
+
+// MetaStatus is the structured state of one project
+type MetaStatus struct {
+	Project  string `json:"project" yaml:"project"`
+	Dir      string `json:"dir" yaml:"dir"`
+	Present  bool   `json:"present" yaml:"present"`
+	Repo     string `json:"repo,omitempty" yaml:"repo,omitempty"`
+	Branch   string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Modified bool   `json:"modified" yaml:"modified"`
+	Running  int    `json:"running" yaml:"running"`
+}
+
+// MetaCommand fans commands out across the projects of a meta-project
+type MetaCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+
+	only        []string
+	parallelism int
+}
+
+// NewMetaCommand creates the meta command group
+func NewMetaCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	mc := &MetaCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "meta",
+		Short: "Run commands across the projects of a meta-project",
+		Long: fmt.Sprintf(`Operate on several projects at once, e.g. a fleet of microservices
+checked out side by side.
+
+The projects are listed in a %s manifest, found in the current
+directory or the closest parent that has one:
+
+  parallelism: 4
+  projects:
+    - name: api
+      path: services/api                 # relative to the manifest
+      repo: git@github.com:acme/api.git  # shown when the project is missing
+    - name: web                          # path defaults to the name
+
+Commands run in every project in parallel, each through its own glide, so
+project commands, plugins, and configuration apply as usual. Output is
+collected per project and a summary is shown at the end.
+
+Available Commands:
+  status         Show the branch, changes, and containers of every project
+  up             Run 'glide up' in every project
+  test           Run 'glide test' in every project
+  run            Run any glide command in every project
+
+Examples:
+  glide meta status                     # Overview of the fleet
+  glide meta up                         # Start every project
+  glide meta test --only api,web        # Test two projects
+  glide meta test -- --coverage         # Pass flags through to each project
+  glide meta run lint -j 8              # Lint eight projects at a time
+  glide meta up --dry-run               # Show what would run where`, meta.ManifestFileName()),
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&mc.only, "only", nil, "Only operate on these projects")
+	cmd.PersistentFlags().IntVarP(&mc.parallelism, "parallel", "j", 0, "Number of projects to operate on at once (default: from the manifest)")
+
+	cmd.AddCommand(mc.newStatusCommand())
+	cmd.AddCommand(mc.newFanOutCommand("up", "Run 'glide up' in every project"))
+	cmd.AddCommand(mc.newFanOutCommand("test", "Run 'glide test' in every project"))
+	cmd.AddCommand(mc.newRunCommand())
+
+	return cmd
+}
+
+// newStatusCommand creates the meta status command
+func (mc *MetaCommand) newStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "status",
+		Short:         "Show the branch, changes, and containers of every project",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mc.status()
+		},
+	}
+}
+
+// newFanOutCommand creates a subcommand running a fixed glide command in
+// every project
+func (mc *MetaCommand) newFanOutCommand(name, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:           name + " [-- args...]",
+		Short:         short,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Annotations:   map[string]string{DryRunAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mc.fanOut(cmd, append([]string{name}, args...))
+		},
+	}
+}
+
+// newRunCommand creates the meta run command
+func (mc *MetaCommand) newRunCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "run <command> [-- args...]",
+		Short:         "Run any glide command in every project",
+		Args:          cobra.MinimumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Annotations:   map[string]string{DryRunAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mc.fanOut(cmd, args)
+		},
+	}
+}
+
+// load finds the manifest and selects the projects to operate on
+func (mc *MetaCommand) load() (*meta.Manifest, []meta.Project, error) {
+	dir := ""
+	if mc.ctx != nil {
+		dir = mc.ctx.WorkingDir
+	}
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, nil, err
+		}
+		dir = wd
+	}
+
+	path := meta.FindManifest(dir)
+	if path == "" {
+		return nil, nil, glideErrors.New(glideErrors.TypeConfig,
+			fmt.Sprintf("no meta-project manifest (%s) found", meta.ManifestFileName()),
+			glideErrors.WithContext("directory", dir),
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Create %s next to your projects listing them under 'projects:'", meta.ManifestFileName()),
+				fmt.Sprintf("See: %s meta --help", branding.CommandName),
+			),
+		)
+	}
+
+	manifest, err := meta.LoadManifest(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	projects, err := manifest.Select(mc.only)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(projects) == 0 {
+		return nil, nil, glideErrors.New(glideErrors.TypeConfig, "the meta-project has no projects",
+			glideErrors.WithContext("file", path),
+		)
+	}
+	return manifest, projects, nil
+}
+
+// workers returns the number of projects to operate on at once
+func (mc *MetaCommand) workers(manifest *meta.Manifest) int {
+	if mc.parallelism > 0 {
+		return mc.parallelism
+	}
+	return manifest.Parallelism
+}
+
+// fanOut runs a glide command line in every selected project
+func (mc *MetaCommand) fanOut(cmd *cobra.Command, args []string) error {
+	manifest, projects, err := mc.load()
+	if err != nil {
+		return err
+	}
+	command := strings.Join(append([]string{branding.CommandName}, args...), " ")
+	structured := output.GetFormat().IsStructured()
+
+	if IsDryRun(cmd) {
+		planned := make([]MetaResult, 0, len(projects))
+		for _, p := range projects {
+			planned = append(planned, MetaResult{Project: p.Name, Dir: p.Dir, Command: command})
+		}
+		if structured {
+			return output.Display(planned)
+		}
+		showMetaPlan(planned)
+		output.Info("Dry run: nothing was run")
+		return nil
+	}
+
+	task := func(p meta.Project) ([]byte, error) {
+		if !dirExists(p.Dir) {
+			return nil, missingProjectError(p)
+		}
+		return runMetaProject(p.Dir, args)
+	}
+
+	var results []meta.Result
+	if structured {
+		results = meta.Run(projects, mc.workers(manifest), task, nil)
+	} else {
+		multi := progress.NewMulti()
+		bar := multi.AddBar(len(projects), "Running "+command)
+		multi.Start()
+
+		var mu sync.Mutex
+		done := 0
+		results = meta.Run(projects, mc.workers(manifest), task, func(meta.Result) {
+			mu.Lock()
+			done++
+			current := done
+			mu.Unlock()
+			multi.UpdateBar(bar, current)
+		})
+		multi.Stop()
+	}
+
+	summary := make([]MetaResult, 0, len(results))
+	var failedNames []string
+	for _, r := range results {
+		result := MetaResult{
+			Project: r.Project.Name,
+			Dir:     r.Project.Dir,
+			Command: command,
+			Seconds: r.Duration.Round(time.Millisecond).Seconds(),
+			Output:  string(r.Output),
+		}
+		if r.Err != nil {
+			result.Error = r.Err.Error()
+			result.ExitCode = metaExitCode(r.Err)
+			failedNames = append(failedNames, r.Project.Name)
+		}
+		summary = append(summary, result)
+	}
+
+	if structured {
+		if err := output.Display(summary); err != nil {
+			return err
+		}
+	} else {
+		showMetaResults(summary)
+	}
+
+	if len(failedNames) > 0 {
+		return glideErrors.New(glideErrors.TypeCommand,
+			fmt.Sprintf("%s failed in %d of %d project(s): %s", command, len(failedNames), len(summary), strings.Join(failedNames, ", ")),
+			glideErrors.WithExitCode(1),
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Rerun the failed projects: %s meta %s --only %s", branding.CommandName, cmd.Name(), strings.Join(failedNames, ",")),
+				fmt.Sprintf("See the full output with: %s meta %s --format json", branding.CommandName, cmd.Name()),
+			),
+		)
+	}
+	return nil
+}
+
+// status shows the state of every selected project
+func (mc *MetaCommand) status() error {
+	manifest, projects, err := mc.load()
+	if err != nil {
+		return err
+	}
+
+	// One query for the whole fleet; without Docker nothing is running
+	containers, _ := listContainers()
+
+	var mu sync.Mutex
+	statuses := make(map[string]MetaStatus, len(projects))
+	meta.Run(projects, mc.workers(manifest), func(p meta.Project) ([]byte, error) {
+		status := metaProjectStatus(p, containers)
+		mu.Lock()
+		statuses[p.Name] = status
+		mu.Unlock()
+		return nil, nil
+	}, nil)
+
+	ordered := make([]MetaStatus, 0, len(projects))
+	for _, p := range projects {
+		ordered = append(ordered, statuses[p.Name])
+	}
+
+	if output.GetFormat().IsStructured() {
+		return output.Display(ordered)
+	}
+	showMetaStatus(ordered)
+	return nil
+}
+
+// metaProjectStatus collects the git and container state of a project
+func metaProjectStatus(p meta.Project, containers []docker.ProjectContainer) MetaStatus {
+	status := MetaStatus{Project: p.Name, Dir: p.Dir, Repo: p.Repo, Present: dirExists(p.Dir)}
+	if !status.Present {
+		return status
+	}
+
+	branchCmd := exec.Command("git", "branch", "--show-current")
+	branchCmd.Dir = p.Dir
+	if out, err := branchCmd.Output(); err == nil {
+		status.Branch = strings.TrimSpace(string(out))
+	}
+
+	statusCmd := exec.Command("git", "status", "--porcelain")
+	statusCmd.Dir = p.Dir
+	if out, err := statusCmd.Output(); err == nil {
+		status.Modified = len(strings.TrimSpace(string(out))) > 0
+	}
+
+	for _, c := range containers {
+		if c.State == "running" && containerInDir(c, p.Dir) {
+			status.Running++
+		}
+	}
+	return status
+}
+
+// containerInDir reports whether a container belongs to the project in dir,
+// by its ownership labels or, when unlabeled, its compose working directory
+func containerInDir(c docker.ProjectContainer, dir string) bool {
+	path := c.WorkingDir
+	if owner, ok := c.Owner(); ok {
+		path = owner.Project
+	}
+	if path == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// missingProjectError reports a project that is not checked out
+func missingProjectError(p meta.Project) error {
+	suggestion := "Check the project's path in " + meta.ManifestFileName()
+	if p.Repo != "" {
+		suggestion = fmt.Sprintf("Clone it with: git clone %s %s", p.Repo, p.Dir)
+	}
+	return glideErrors.NewFileNotFoundError(p.Dir, glideErrors.WithSuggestions(suggestion))
+}
+
+// metaExitCode returns the exit code of a failed project command
+func metaExitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
+
+// metaEnvironment returns the environment of project commands. COMPOSE_FILE
+// and the namespace glide applied were resolved for the current directory;
+// each project resolves its own.
+func metaEnvironment() []string {
+	resolved := append([]string{"COMPOSE_FILE"}, namespaceApplied...)
+	env := make([]string, 0, len(os.Environ()))
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		if !slices.Contains(resolved, key) {
+			env = append(env, kv)
+		}
+	}
+	return env
+}
+
+// dirExists reports whether path is a directory
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// showMetaPlan prints the commands a fan-out would run
+func showMetaPlan(planned []MetaResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, "PROJECT\tDIRECTORY\tCOMMAND")
+	for _, p := range planned {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", p.Project, p.Dir, p.Command)
+	}
+	_ = w.Flush()
+}
+
+// showMetaResults prints a summary table followed by the end of the output
+// of every failed project
+func showMetaResults(results []MetaResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, "PROJECT\tRESULT\tDURATION")
+	for _, r := range results {
+		status := "✓ ok"
+		if r.Error != "" {
+			status = fmt.Sprintf("✗ exit %d", r.ExitCode)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%.1fs\n", r.Project, status, r.Seconds)
+	}
+	_ = w.Flush()
+
+	for _, r := range results {
+		if r.Error == "" {
+			continue
+		}
+		output.Println()
+		output.Error("── %s: %s", r.Project, r.Error)
+		lines := strings.Split(strings.TrimRight(r.Output, "\n"), "\n")
+		if len(lines) > metaOutputTail {
+			output.Println(fmt.Sprintf("   ... %d earlier line(s) omitted", len(lines)-metaOutputTail))
+			lines = lines[len(lines)-metaOutputTail:]
+		}
+		for _, line := range lines {
+			if line != "" {
+				output.Println("   " + line)
+			}
+		}
+	}
+}
+
+// showMetaStatus prints the state of every project
+func showMetaStatus(statuses []MetaStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, "PROJECT\tBRANCH\tCHANGES\tRUNNING\tDIRECTORY")
+	for _, s := range statuses {
+		if !s.Present {
+			_, _ = fmt.Fprintf(w, "%s\t(missing)\t-\t-\t%s\n", s.Project, s.Dir)
+			continue
+		}
+		branch, changes := s.Branch, "clean"
+		if branch == "" {
+			branch = "-"
+		}
+		if s.Modified {
+			changes = "modified"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", s.Project, branch, changes, s.Running, s.Dir)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/internal/meta"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metaFleet creates a meta-project with api and web checked out and worker
+// missing
+func metaFleet(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, name := range []string{"api", "web"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, name), 0755))
+	}
+	manifest := "projects:\n  - name: api\n  - name: web\n  - name: worker\n    repo: git@example.com:acme/worker.git\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, meta.ManifestFileName()), []byte(manifest), 0644))
+	return root
+}
+
+// stubMetaProject records the project commands run and fails them in the
+// given directories
+func stubMetaProject(t *testing.T, failIn ...string) *[]string {
+	t.Helper()
+	var mu sync.Mutex
+	var runs []string
+	original := runMetaProject
+	runMetaProject = func(dir string, args []string) ([]byte, error) {
+		mu.Lock()
+		runs = append(runs, filepath.Base(dir)+": "+args[0])
+		mu.Unlock()
+		for _, name := range failIn {
+			if filepath.Base(dir) == name {
+				return []byte("tests failed"), errors.New("exit status 1")
+			}
+		}
+		return []byte("ok"), nil
+	}
+	t.Cleanup(func() { runMetaProject = original })
+	return &runs
+}
+
+// runMeta runs a meta subcommand from dir under a root that has the global
+// --dry-run flag
+func runMeta(t *testing.T, dir string, args ...string) error {
+	t.Helper()
+	root := &cobra.Command{Use: "glide"}
+	root.PersistentFlags().Bool("dry-run", false, "")
+	root.AddCommand(NewMetaCommand(&context.ProjectContext{WorkingDir: dir}, nil))
+	root.SetArgs(append([]string{"meta"}, args...))
+	return root.Execute()
+}
+
+func TestMetaCommand_FanOut(t *testing.T) {
+	root := metaFleet(t)
+
+	t.Run("runs in every selected project", func(t *testing.T) {
+		runs := stubMetaProject(t)
+		require.NoError(t, runMeta(t, filepath.Join(root, "api"), "test", "--only", "api,web"))
+		sort.Strings(*runs)
+		assert.Equal(t, []string{"api: test", "web: test"}, *runs)
+	})
+
+	t.Run("aggregates failures", func(t *testing.T) {
+		runs := stubMetaProject(t, "web")
+		err := runMeta(t, root, "run", "lint")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed in 2 of 3 project(s): web, worker")
+		assert.Len(t, *runs, 2, "missing projects are not run")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		runs := stubMetaProject(t)
+		require.NoError(t, runMeta(t, root, "up", "--dry-run"))
+		assert.Empty(t, *runs)
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		stubMetaProject(t)
+		err := runMeta(t, root, "up", "--only", "nope")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nope")
+	})
+
+	t.Run("no manifest", func(t *testing.T) {
+		err := runMeta(t, t.TempDir(), "status")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no meta-project manifest")
+	})
+}
+
+func TestMetaProjectStatus(t *testing.T) {
+	root := metaFleet(t)
+	api := filepath.Join(root, "api")
+	containers := []docker.ProjectContainer{
+		{Name: "api-php-1", State: "running", Labels: docker.Ownership{Project: api, Worktree: "api"}.Labels()},
+		{Name: "api-db-1", State: "running", WorkingDir: filepath.Join(api, "docker")},
+		{Name: "api-old-1", State: "exited", WorkingDir: api},
+		{Name: "web-php-1", State: "running", WorkingDir: filepath.Join(root, "web")},
+		{Name: "api2-php-1", State: "running", WorkingDir: api + "2"},
+	}
+
+	status := metaProjectStatus(meta.Project{Name: "api", Dir: api}, containers)
+	assert.True(t, status.Present)
+	assert.Equal(t, 2, status.Running)
+
+	missing := metaProjectStatus(meta.Project{Name: "worker", Dir: filepath.Join(root, "worker")}, containers)
+	assert.False(t, missing.Present)
+}
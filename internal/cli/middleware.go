@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/policy"
+	"github.com/glide-cli/glide/v3/pkg/audit"
+	"github.com/glide-cli/glide/v3/pkg/invocation"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+// RunFunc runs a command
+type RunFunc func(cmd *cobra.Command, args []string) error
+
+// Middleware applies one cross-cutting concern, such as auditing or
+// timing, to the commands it selects
+type Middleware struct {
+	// Name identifies the middleware in debug logs
+	Name string
+	// Applies selects the commands to wrap; nil selects every command
+	Applies func(cmd *cobra.Command) bool
+	// Setup prepares a selected command, e.g. adds the flags the
+	// middleware reads. It is optional.
+	Setup func(cmd *cobra.Command)
+	// Wrap returns the run function that replaces cmd's next one
+	Wrap func(cmd *cobra.Command, next RunFunc) RunFunc
+}
+
+// Chain is the middleware commands run through. The first middleware is
+// the outermost: it runs first and sees the final outcome.
+type Chain []Middleware
+
+// DefaultChain is the middleware every builtin, YAML, and plugin command
+// runs through
+func DefaultChain(ctx *context.ProjectContext, logger *audit.Logger, pol *policy.Policy) Chain {
+	return Chain{
+		// Record which command this invocation runs
+		InvocationTracking(),
+		// Refuse --dry-run for commands that would otherwise execute for real
+		DryRunCheck(),
+		// Restricted commands fail before anything else, without prompting
+		PolicyEnforcement(pol),
+		// Confirm and audit destructive commands
+		DestructiveGuard(ctx, logger, pol),
+		// Serialize commands of an exclusivity group, such as `up` and `down`
+		CommandLocks(ctx),
+		// Let `up` and `test` run in a git submodule or subtree with --module
+		ModuleTargeting(ctx),
+		// Refuse to start a worktree whose compose project another worktree owns
+		OwnershipChecks(ctx),
+		// Start the services worktrees share before `up`
+		SharedServicesLifecycle(ctx),
+		// Count plugin command runs for `plugins stats`
+		PluginTelemetry(ctx),
+		// Keep the timing history `perf report` analyzes
+		TimingHistory(ctx),
+		// Track development time while a worktree's containers are up
+		TimeTracking(ctx),
+		// Start file sync after `up` and stop it before `down`
+		SyncLifecycle(ctx),
+	}
+}
+
+// InvocationTracking records the running command's path in the invocation
+// its context carries, so logs and telemetry can name it
+func InvocationTracking() Middleware {
+	return Middleware{
+		Name: "invocation",
+		Wrap: func(_ *cobra.Command, next RunFunc) RunFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if inv := invocation.FromContext(cmd.Context()); inv != nil {
+					inv.SetCommand(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "))
+					logging.DebugContext(cmd.Context(), "Running command", "command", inv.Command(), "project", inv.Project())
+				}
+				return next(cmd, args)
+			}
+		},
+	}
+}
+
+// Apply wraps every command below root in the middleware that applies to
+// it. It must run once, after all YAML and plugin commands are added.
+// Commands without a run function only get the middleware's setup.
+func (c Chain) Apply(root *cobra.Command) {
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, child := range cmd.Commands() {
+			c.wrap(child)
+			walk(child)
+		}
+	}
+	walk(root)
+}
+
+// wrap installs the applicable middleware on a single command
+func (c Chain) wrap(cmd *cobra.Command) {
+	var applied []Middleware
+	for _, m := range c {
+		if m.Applies == nil || m.Applies(cmd) {
+			applied = append(applied, m)
+		}
+	}
+	for _, m := range applied {
+		if m.Setup != nil {
+			m.Setup(cmd)
+		}
+	}
+
+	run := runFunc(cmd)
+	if run == nil || len(applied) == 0 {
+		return
+	}
+	for i := len(applied) - 1; i >= 0; i-- {
+		logging.Debug("Applying command middleware", "command", cmd.CommandPath(), "middleware", applied[i].Name)
+		run = applied[i].Wrap(cmd, run)
+	}
+	cmd.Run = nil
+	cmd.RunE = run
+}
+
+// runFunc returns a command's run function, adapting Run to RunE, or nil
+// when the command only groups subcommands
+func runFunc(cmd *cobra.Command) RunFunc {
+	if cmd.RunE != nil {
+		return cmd.RunE
+	}
+	if cmd.Run == nil {
+		return nil
+	}
+	legacy := cmd.Run
+	return func(c *cobra.Command, args []string) error {
+		legacy(c, args)
+		return nil
+	}
+}
+
+// topLevel selects the commands directly below the root with one of the
+// given names, such as the `up` and `down` plugins or .glide.yml provide
+func topLevel(names ...string) func(cmd *cobra.Command) bool {
+	return func(cmd *cobra.Command) bool {
+		if cmd.Parent() == nil || cmd.Parent().HasParent() {
+			return false
+		}
+		for _, name := range names {
+			if cmd.Name() == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// projectCommands selects the top-level commands with the given names, but
+// only inside a project
+func projectCommands(ctx *context.ProjectContext, names ...string) func(cmd *cobra.Command) bool {
+	if ctx == nil || ctx.ProjectRoot == "" {
+		return never
+	}
+	return topLevel(names...)
+}
+
+// around runs before ahead of next and after once next succeeds
+func around(next RunFunc, before, after func()) RunFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		if before != nil {
+			before()
+		}
+		if err := next(cmd, args); err != nil {
+			return err
+		}
+		if after != nil {
+			after()
+		}
+		return nil
+	}
+}
+
+// never selects no command, for middleware that does not apply in the
+// current context
+func never(*cobra.Command) bool { return false }
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/pkg/invocation"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tracing returns middleware that records when it runs
+func tracing(name string, calls *[]string, applies func(*cobra.Command) bool) Middleware {
+	return Middleware{
+		Name:    name,
+		Applies: applies,
+		Wrap: func(_ *cobra.Command, next RunFunc) RunFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				*calls = append(*calls, name)
+				return next(cmd, args)
+			}
+		},
+	}
+}
+
+func TestChain_Apply(t *testing.T) {
+	var calls []string
+	root := &cobra.Command{Use: "glide"}
+	up := &cobra.Command{Use: "up", RunE: func(*cobra.Command, []string) error {
+		calls = append(calls, "run")
+		return nil
+	}}
+	legacy := &cobra.Command{Use: "legacy", Run: func(*cobra.Command, []string) {
+		calls = append(calls, "run")
+	}}
+	group := &cobra.Command{Use: "project"}
+	nested := &cobra.Command{Use: "up", RunE: func(*cobra.Command, []string) error {
+		calls = append(calls, "run")
+		return nil
+	}}
+	group.AddCommand(nested)
+	root.AddCommand(up, legacy, group)
+
+	var setUp []string
+	chain := Chain{
+		tracing("outer", &calls, nil),
+		tracing("up-only", &calls, topLevel("up")),
+		{
+			Name:  "setup",
+			Setup: func(cmd *cobra.Command) { setUp = append(setUp, cmd.CommandPath()) },
+			Wrap:  func(_ *cobra.Command, next RunFunc) RunFunc { return next },
+		},
+	}
+	chain.Apply(root)
+
+	t.Run("the first middleware is the outermost", func(t *testing.T) {
+		calls = nil
+		require.NoError(t, up.RunE(up, nil))
+		assert.Equal(t, []string{"outer", "up-only", "run"}, calls)
+	})
+
+	t.Run("legacy Run is adapted", func(t *testing.T) {
+		calls = nil
+		assert.Nil(t, legacy.Run)
+		require.NoError(t, legacy.RunE(legacy, nil))
+		assert.Equal(t, []string{"outer", "run"}, calls)
+	})
+
+	t.Run("nested commands are wrapped, but only selected ones", func(t *testing.T) {
+		calls = nil
+		require.NoError(t, nested.RunE(nested, nil))
+		assert.Equal(t, []string{"outer", "run"}, calls, "topLevel skips `project up`")
+	})
+
+	t.Run("command groups are set up but not made runnable", func(t *testing.T) {
+		assert.Nil(t, group.RunE)
+		assert.Contains(t, setUp, "glide project")
+		assert.NotContains(t, setUp, "glide", "the root is left alone")
+	})
+}
+
+func TestAround(t *testing.T) {
+	var calls []string
+	record := func(name string) func() {
+		return func() { calls = append(calls, name) }
+	}
+	run := func(err error) RunFunc {
+		return func(*cobra.Command, []string) error {
+			calls = append(calls, "run")
+			return err
+		}
+	}
+
+	calls = nil
+	require.NoError(t, around(run(nil), record("before"), record("after"))(nil, nil))
+	assert.Equal(t, []string{"before", "run", "after"}, calls)
+
+	calls = nil
+	assert.Error(t, around(run(errors.New("failed")), record("before"), record("after"))(nil, nil))
+	assert.Equal(t, []string{"before", "run"}, calls, "after only runs on success")
+}
+
+func TestInvocationTracking(t *testing.T) {
+	inv := invocation.New("/work/app")
+	root := &cobra.Command{Use: "glide"}
+	group := &cobra.Command{Use: "project"}
+	status := &cobra.Command{Use: "status", RunE: func(cmd *cobra.Command, _ []string) error {
+		assert.Equal(t, "project status", invocation.FromContext(cmd.Context()).Command())
+		return nil
+	}}
+	group.AddCommand(status)
+	root.AddCommand(group)
+	Chain{InvocationTracking()}.Apply(root)
+
+	status.SetContext(invocation.WithContext(context.Background(), inv))
+	require.NoError(t, status.RunE(status, nil))
+	assert.Equal(t, "project status", inv.Command())
+}
@@ -1,10 +1,14 @@
 package cli
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/glide-cli/glide/v3/internal/config"
 	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/pkg/branding"
 	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
 	"github.com/glide-cli/glide/v3/pkg/output"
 )
@@ -190,3 +194,65 @@ func ShowContextAwareHelp(ctx *context.ProjectContext, cfg *config.Config) error
 	helpCmd := NewHelpCommand(ctx, cfg)
 	return helpCmd.RunE(helpCmd, []string{})
 }
+
+// currentWorktree returns the directory and name of the worktree the user is
+// working in: a worktree under worktrees/, the main repository (vcs), or the
+// project root in single-repo mode
+func currentWorktree(ctx *context.ProjectContext) (string, string) {
+	switch {
+	case ctx.IsWorktree && ctx.WorktreeName != "":
+		return filepath.Join(ctx.ProjectRoot, "worktrees", ctx.WorktreeName), ctx.WorktreeName
+	case ctx.IsMainRepo:
+		return filepath.Join(ctx.ProjectRoot, "vcs"), "vcs"
+	default:
+		return ctx.ProjectRoot, filepath.Base(ctx.ProjectRoot)
+	}
+}
+
+// localProjectConfig loads and merges the .glide.yml files that apply to the
+// working directory, returning an empty config when there are none
+func localProjectConfig() *config.Config {
+	cwd, _ := os.Getwd()
+	configPaths, err := config.DiscoverConfigs(cwd)
+	if err != nil || len(configPaths) == 0 {
+		return &config.Config{}
+	}
+	merged, err := config.LoadAndMergeConfigs(configPaths)
+	if err != nil {
+		return &config.Config{}
+	}
+	return merged
+}
+
+// ProjectPlugins returns the runtime plugins the project enables and
+// disables
+func ProjectPlugins() config.PluginsConfig {
+	return localProjectConfig().Plugins
+}
+
+// CheckStrictConfig rejects unknown keys in the global and project configs
+// when --strict-config is given or any of them sets strict: true
+func CheckStrictConfig(force bool, cfg *config.Config) error {
+	cwd, _ := os.Getwd()
+	configPaths, _ := config.DiscoverConfigs(cwd)
+
+	if !force && (cfg == nil || !cfg.Strict) && !localProjectConfig().Strict {
+		return nil
+	}
+	globalPath := branding.GetConfigPath()
+	if !slices.Contains(configPaths, globalPath) {
+		configPaths = append(configPaths, globalPath)
+	}
+	return config.CheckKeys(configPaths)
+}
+
+// composeProjectDir returns the directory a command resolves the compose
+// project in: the current worktree, or the working directory outside a
+// project
+func composeProjectDir(ctx *context.ProjectContext) (string, error) {
+	if ctx != nil && ctx.ProjectRoot != "" {
+		dir, _ := currentWorktree(ctx)
+		return dir, nil
+	}
+	return os.Getwd()
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+// moduleCommands are the commands that can target a submodule or subtree
+var moduleCommands = []string{"up", "test"}
+
+// ModuleTargeting adds a --module flag to `up` and `test` that runs them in
+// a git submodule or subtree of the project instead of the current
+// directory
+func ModuleTargeting(ctx *context.ProjectContext) Middleware {
+	applies := never
+	if ctx != nil && len(ctx.Modules) > 0 {
+		moduleCommand := topLevel(moduleCommands...)
+		applies = func(cmd *cobra.Command) bool {
+			return moduleCommand(cmd) && cmd.Flags().Lookup("module") == nil
+		}
+	}
+
+	return Middleware{
+		Name:    "modules",
+		Applies: applies,
+		Setup: func(cmd *cobra.Command) {
+			cmd.Flags().String("module", "", fmt.Sprintf("Run in a git submodule or subtree (%s)", strings.Join(moduleNames(ctx), ", ")))
+			_ = cmd.RegisterFlagCompletionFunc("module", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+				return moduleNames(ctx), cobra.ShellCompDirectiveNoFileComp
+			})
+		},
+		Wrap: func(_ *cobra.Command, next RunFunc) RunFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				var name string
+				if cmd.DisableFlagParsing {
+					// Pass-through YAML commands receive --module among their arguments
+					name, args = extractModuleArg(args)
+				} else {
+					name, _ = cmd.Flags().GetString("module")
+				}
+				if name != "" {
+					if err := enterModule(ctx, name); err != nil {
+						return err
+					}
+				}
+				return next(cmd, args)
+			}
+		},
+	}
+}
+
+// extractModuleArg removes --module from arguments given before "--" and
+// returns its value
+func extractModuleArg(args []string) (string, []string) {
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return "", args
+		case arg == "--module" && i+1 < len(args):
+			rest := append(append([]string{}, args[:i]...), args[i+2:]...)
+			return args[i+1], rest
+		case strings.HasPrefix(arg, "--module="):
+			rest := append(append([]string{}, args[:i]...), args[i+1:]...)
+			return strings.TrimPrefix(arg, "--module="), rest
+		}
+	}
+	return "", args
+}
+
+// enterModule changes into a module's directory so the command, and the
+// tools it starts, operate on the module
+func enterModule(ctx *context.ProjectContext, name string) error {
+	module, ok := ctx.FindModule(name)
+	if !ok {
+		return glideErrors.New(glideErrors.TypeConfig,
+			fmt.Sprintf("no submodule or subtree named %q", name),
+			glideErrors.WithContext("repository", ctx.RepositoryDir()),
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Available modules: %s", strings.Join(moduleNames(ctx), ", ")),
+				fmt.Sprintf("List them with: %s context", branding.CommandName),
+			),
+		)
+	}
+	if _, err := os.Stat(module.Dir); err != nil {
+		return glideErrors.NewFileNotFoundError(module.Dir,
+			glideErrors.WithSuggestions("Check out submodules with: git submodule update --init --recursive"),
+		)
+	}
+
+	// COMPOSE_FILE was set for the superproject; the module resolves its own
+	_, owner := worktreeOwnership(ctx)
+	if strings.Contains(os.Getenv("COMPOSE_FILE"), ownershipOverridePath(ctx, owner.Worktree)) {
+		_ = os.Unsetenv("COMPOSE_FILE")
+	}
+
+	logging.Debug("Running in module", "module", module.Name, "dir", module.Dir)
+	return os.Chdir(module.Dir)
+}
+
+// moduleNames returns the sorted names of the project's modules
+func moduleNames(ctx *context.ProjectContext) []string {
+	names := make([]string, 0, len(ctx.Modules))
+	for _, m := range ctx.Modules {
+		names = append(names, m.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleTargeting(t *testing.T) {
+	root := t.TempDir()
+	moduleDir := filepath.Join(root, "libs", "payments")
+	require.NoError(t, os.MkdirAll(moduleDir, 0755))
+	moduleDir, _ = filepath.EvalSymlinks(moduleDir)
+	ctx := &context.ProjectContext{
+		ProjectRoot: root,
+		Modules:     []context.Module{{Name: "payments", Path: "libs/payments", Dir: moduleDir, Kind: context.ModuleSubmodule}},
+	}
+
+	var ranIn string
+	var ranArgs []string
+	newRoot := func() *cobra.Command {
+		rootCmd := &cobra.Command{Use: "glide"}
+		record := func(_ *cobra.Command, args []string) error {
+			ranIn, _ = os.Getwd()
+			ranArgs = args
+			return nil
+		}
+		rootCmd.AddCommand(&cobra.Command{Use: "up", RunE: record})
+		rootCmd.AddCommand(&cobra.Command{Use: "test", RunE: record, DisableFlagParsing: true})
+		rootCmd.AddCommand(&cobra.Command{Use: "down", RunE: record})
+		Chain{ModuleTargeting(ctx)}.Apply(rootCmd)
+		return rootCmd
+	}
+
+	t.Run("plugin command", func(t *testing.T) {
+		t.Chdir(root)
+		rootCmd := newRoot()
+		rootCmd.SetArgs([]string{"up", "--module", "payments"})
+		require.NoError(t, rootCmd.Execute())
+		assert.Equal(t, moduleDir, ranIn)
+	})
+
+	t.Run("pass-through command", func(t *testing.T) {
+		t.Chdir(root)
+		rootCmd := newRoot()
+		rootCmd.SetArgs([]string{"test", "--filter", "Unit", "--module=libs/payments"})
+		require.NoError(t, rootCmd.Execute())
+		assert.Equal(t, moduleDir, ranIn)
+		assert.Equal(t, []string{"--filter", "Unit"}, ranArgs)
+	})
+
+	t.Run("unknown module", func(t *testing.T) {
+		t.Chdir(root)
+		rootCmd := newRoot()
+		rootCmd.SetArgs([]string{"up", "--module", "nope"})
+		err := rootCmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `no submodule or subtree named "nope"`)
+	})
+
+	t.Run("other commands", func(t *testing.T) {
+		down, _, err := newRoot().Find([]string{"down"})
+		require.NoError(t, err)
+		assert.Nil(t, down.Flags().Lookup("module"))
+	})
+}
+
+func TestExtractModuleArg(t *testing.T) {
+	name, rest := extractModuleArg([]string{"--module", "api", "-v"})
+	assert.Equal(t, "api", name)
+	assert.Equal(t, []string{"-v"}, rest)
+
+	name, rest = extractModuleArg([]string{"--", "--module", "api"})
+	assert.Empty(t, name, "arguments after -- belong to the command")
+	assert.Equal(t, []string{"--", "--module", "api"}, rest)
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+)
+
+// Environment variables naming the current worktree's namespace. Compose
+// reads COMPOSE_PROJECT_NAME itself; compose files can use the others, e.g.
+// MYSQL_DATABASE: ${GLIDE_DB_NAME}.
+const (
+	composeProjectNameEnv = "COMPOSE_PROJECT_NAME"
+	databaseNameEnv       = "GLIDE_DB_NAME"
+	volumePrefixEnv       = "GLIDE_VOLUME_PREFIX"
+)
+
+// namespaceApplied lists the variables ApplyNamespace set, as opposed to
+// ones the user set
+var namespaceApplied []string
+
+// projectWorktrees returns the names of a multi-worktree project's
+// worktrees, with the empty name for the main checkout
+func projectWorktrees(projectRoot string) []string {
+	worktrees := []string{""}
+	entries, _ := os.ReadDir(filepath.Join(projectRoot, "worktrees"))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			worktrees = append(worktrees, entry.Name())
+		}
+	}
+	return worktrees
+}
+
+// worktreeNamespace returns the namespace of ctx's worktree; ok is false
+// outside the worktrees of a multi-worktree project
+func worktreeNamespace(ctx *context.ProjectContext) (docker.Namespace, bool) {
+	if ctx == nil || ctx.ProjectRoot == "" || ctx.DevelopmentMode != context.ModeMultiWorktree {
+		return docker.Namespace{}, false
+	}
+	worktree := ""
+	switch {
+	case ctx.IsWorktree && ctx.WorktreeName != "":
+		worktree = ctx.WorktreeName
+	case !ctx.IsMainRepo:
+		return docker.Namespace{}, false
+	}
+
+	worktrees := projectWorktrees(ctx.ProjectRoot)
+	found := false
+	for _, name := range worktrees {
+		found = found || name == worktree
+	}
+	if !found {
+		// A worktree being created
+		worktrees = append(worktrees, worktree)
+	}
+	return docker.DeriveNamespaces(filepath.Base(ctx.ProjectRoot), worktrees)[worktree], true
+}
+
+// namespaceEnv returns the variables naming ns
+func namespaceEnv(ns docker.Namespace) map[string]string {
+	return map[string]string{
+		composeProjectNameEnv: ns.Project,
+		databaseNameEnv:       ns.Database,
+		volumePrefixEnv:       ns.VolumePrefix,
+	}
+}
+
+// namespaceDefaults returns the variables naming ns that neither env nor
+// the worktree's .env set
+func namespaceDefaults(ctx *context.ProjectContext, ns docker.Namespace, env []string) map[string]string {
+	dir, _ := currentWorktree(ctx)
+	defaults := make(map[string]string)
+	for key, value := range namespaceEnv(ns) {
+		if envSets(env, key) || dotenvSets(filepath.Join(dir, ".env"), key) {
+			continue
+		}
+		defaults[key] = value
+	}
+	return defaults
+}
+
+// envSets reports whether env assigns key
+func envSets(env []string, key string) bool {
+	for _, kv := range env {
+		if strings.HasPrefix(kv, key+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyNamespace names the compose project, database, and volumes of the
+// current worktree after it, so worktrees never share data. Like the
+// ownership labels it works through the environment, which commands and
+// plugins started afterwards inherit. Variables set in the environment or
+// the worktree's .env are left alone.
+func ApplyNamespace(ctx *context.ProjectContext) {
+	ns, ok := worktreeNamespace(ctx)
+	if !ok {
+		return
+	}
+	for key, value := range namespaceDefaults(ctx, ns, os.Environ()) {
+		if err := os.Setenv(key, value); err != nil {
+			logging.Debug("Could not set namespace variable", "key", key, "error", err)
+			continue
+		}
+		namespaceApplied = append(namespaceApplied, key)
+	}
+	if len(ns.Collisions) > 0 {
+		logging.Debug("Worktree names collide, namespace is hashed", "worktree", ns.Worktree, "collisions", ns.Collisions)
+	}
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unsetNamespaceEnv clears the namespace variables for the test and
+// restores them and namespaceApplied afterwards
+func unsetNamespaceEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{composeProjectNameEnv, databaseNameEnv, volumePrefixEnv} {
+		t.Setenv(key, "")
+		require.NoError(t, os.Unsetenv(key))
+	}
+	original := namespaceApplied
+	namespaceApplied = nil
+	t.Cleanup(func() { namespaceApplied = original })
+}
+
+func TestWorktreeNamespace(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "shop")
+	for _, name := range []string{"feature-login", "Feature.Login", "api"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "worktrees", name), 0755))
+	}
+
+	ns, ok := worktreeNamespace(&glideContext.ProjectContext{ProjectRoot: root, DevelopmentMode: glideContext.ModeMultiWorktree, IsWorktree: true, WorktreeName: "api"})
+	require.True(t, ok)
+	assert.Equal(t, "shop-api", ns.Project)
+	assert.Equal(t, "shop_api", ns.Database)
+
+	ns, ok = worktreeNamespace(&glideContext.ProjectContext{ProjectRoot: root, DevelopmentMode: glideContext.ModeMultiWorktree, IsMainRepo: true})
+	require.True(t, ok)
+	assert.Equal(t, "shop", ns.Project)
+
+	ns, _ = worktreeNamespace(&glideContext.ProjectContext{ProjectRoot: root, DevelopmentMode: glideContext.ModeMultiWorktree, IsWorktree: true, WorktreeName: "feature-login"})
+	assert.Equal(t, []string{"Feature.Login"}, ns.Collisions)
+
+	ns, ok = worktreeNamespace(&glideContext.ProjectContext{ProjectRoot: root, DevelopmentMode: glideContext.ModeMultiWorktree, IsWorktree: true, WorktreeName: "new"})
+	require.True(t, ok, "worktrees being created have a namespace")
+	assert.Equal(t, "shop-new", ns.Project)
+
+	_, ok = worktreeNamespace(&glideContext.ProjectContext{ProjectRoot: root, DevelopmentMode: glideContext.ModeMultiWorktree})
+	assert.False(t, ok, "the project root is not a worktree")
+	_, ok = worktreeNamespace(&glideContext.ProjectContext{ProjectRoot: root, DevelopmentMode: glideContext.ModeSingleRepo})
+	assert.False(t, ok)
+}
+
+func TestApplyNamespace(t *testing.T) {
+	unsetNamespaceEnv(t)
+	root := filepath.Join(t.TempDir(), "shop")
+	dir := filepath.Join(root, "worktrees", "api")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("GLIDE_DB_NAME=custom\n"), 0644))
+	t.Setenv(volumePrefixEnv, "mine_")
+	t.Setenv("COMPOSE_FILE", "compose.yml")
+
+	ApplyNamespace(&glideContext.ProjectContext{ProjectRoot: root, DevelopmentMode: glideContext.ModeMultiWorktree, IsWorktree: true, WorktreeName: "api"})
+
+	assert.Equal(t, "shop-api", os.Getenv(composeProjectNameEnv))
+	_, set := os.LookupEnv(databaseNameEnv)
+	assert.False(t, set, "variables .env sets are left alone")
+	assert.Equal(t, "mine_", os.Getenv(volumePrefixEnv), "variables the environment sets are left alone")
+	assert.Equal(t, []string{composeProjectNameEnv}, namespaceApplied)
+
+	env := metaEnvironment()
+	assert.False(t, envSets(env, composeProjectNameEnv), "applied variables are resolved per project")
+	assert.False(t, envSets(env, "COMPOSE_FILE"))
+	assert.True(t, envSets(env, volumePrefixEnv))
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/notify"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// maxNotifiedErrorLength bounds the error message included in failure
+// notifications
+const maxNotifiedErrorLength = 200
+
+// sendNotification delivers a notification and is replaced in tests
+var sendNotification = notify.Send
+
+// NotifyCompletion notifies the user that a command finished when it is
+// opted in under notifications in the configuration and ran long enough, or
+// when --notify was given. Delivery problems are reported as warnings and
+// never change the command's outcome.
+func NotifyCompletion(cfg *config.Config, ctx *context.ProjectContext, executed *cobra.Command, started time.Time, cmdErr error) {
+	if executed == nil || strings.HasPrefix(executed.Name(), "__") {
+		return
+	}
+
+	var notifications config.NotificationsConfig
+	if cfg != nil {
+		notifications = cfg.Notifications
+	}
+	settings, err := notify.SettingsFromConfig(notifications)
+	if err != nil {
+		output.Warning("Notifications are disabled: %v", err)
+		return
+	}
+
+	commandPath := strings.TrimPrefix(executed.CommandPath(), executed.Root().Name()+" ")
+	duration := time.Since(started)
+	success := cmdErr == nil
+	forced, _ := executed.Flags().GetBool("notify")
+	if !forced && !settings.Wants(commandPath, success, duration) {
+		return
+	}
+
+	n := notify.Notification{
+		Command:  commandPath,
+		Success:  success,
+		Duration: duration,
+	}
+	if ctx != nil {
+		n.Project = ctx.ProjectRoot
+	}
+	if !success {
+		n.ExitCode = 1
+		var glideErr *glideErrors.GlideError
+		if errors.As(cmdErr, &glideErr) && glideErr.Code != 0 {
+			n.ExitCode = glideErr.Code
+		}
+		n.Error, _, _ = strings.Cut(cmdErr.Error(), "\n")
+		if len(n.Error) > maxNotifiedErrorLength {
+			n.Error = n.Error[:maxNotifiedErrorLength] + "…"
+		}
+	}
+
+	if err := sendNotification(settings.Notifiers, n); err != nil {
+		output.Warning("Notification not delivered: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/notify"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubNotifications records the notifications sent
+func stubNotifications(t *testing.T) *[]notify.Notification {
+	t.Helper()
+	var sent []notify.Notification
+	original := sendNotification
+	sendNotification = func(_ []notify.Notifier, n notify.Notification) error {
+		sent = append(sent, n)
+		return nil
+	}
+	t.Cleanup(func() { sendNotification = original })
+	return &sent
+}
+
+// executedCommand returns the "project down" command of a small tree
+func executedCommand(args ...string) *cobra.Command {
+	root := &cobra.Command{Use: "glide"}
+	root.PersistentFlags().Bool("notify", false, "")
+	project := &cobra.Command{Use: "project"}
+	down := &cobra.Command{Use: "down", Run: func(*cobra.Command, []string) {}}
+	project.AddCommand(down)
+	root.AddCommand(project)
+	root.SetArgs(append([]string{"project", "down"}, args...))
+	executed, _ := root.ExecuteC()
+	return executed
+}
+
+func TestNotifyCompletion(t *testing.T) {
+	sent := stubNotifications(t)
+	cfg := &config.Config{Notifications: config.NotificationsConfig{MinDuration: "1m", Commands: []string{"project"}}}
+	executed := executedCommand()
+
+	NotifyCompletion(cfg, nil, executed, time.Now().Add(-30*time.Second), nil)
+	assert.Empty(t, *sent, "short commands do not notify")
+
+	cmdErr := glideErrors.New(glideErrors.TypeCommand, "containers failed to stop\ndetails", glideErrors.WithExitCode(3))
+	NotifyCompletion(cfg, nil, executed, time.Now().Add(-2*time.Minute), cmdErr)
+	require.Len(t, *sent, 1)
+	n := (*sent)[0]
+	assert.Equal(t, "project down", n.Command)
+	assert.False(t, n.Success)
+	assert.Equal(t, 3, n.ExitCode)
+	assert.Equal(t, "containers failed to stop", n.Error)
+
+	NotifyCompletion(&config.Config{}, nil, executed, time.Now().Add(-2*time.Minute), nil)
+	assert.Len(t, *sent, 1, "commands are opted in")
+}
+
+func TestNotifyCompletion_Flag(t *testing.T) {
+	sent := stubNotifications(t)
+
+	NotifyCompletion(nil, nil, executedCommand("--notify"), time.Now(), nil)
+	require.Len(t, *sent, 1)
+	assert.True(t, (*sent)[0].Success)
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/prompt"
+	"github.com/glide-cli/glide/v3/pkg/update"
+	"github.com/spf13/cobra"
+)
+
+// NoOnboardingEnv skips the first-run wizard, e.g. in provisioning scripts
+const NoOnboardingEnv = "GLIDE_NO_ONBOARDING"
+
+// OnboardingChoices are the answers to the first-run wizard
+type OnboardingChoices struct {
+	// Colors is "auto", or "never" when the color test looked wrong
+	Colors     string
+	Completion bool
+	Telemetry  bool
+	// Channel is update.ChannelStable or update.ChannelPrerelease
+	Channel string
+}
+
+var (
+	// onboardingConfirm and onboardingSelect ask the wizard's questions and
+	// are replaced in tests
+	onboardingConfirm = prompt.Confirm
+	onboardingSelect  = prompt.Select
+
+	// installOnboardingCompletion installs tab completion for a shell and
+	// is replaced in tests
+	installOnboardingCompletion = func(root *cobra.Command, shell CompletionType) error {
+		cm := NewCompletionManager(nil, nil)
+		result, err := cm.Install(root, shell)
+		if err != nil {
+			return err
+		}
+		cm.printInstallResult(result)
+		return nil
+	}
+
+	// saveOnboarding saves the answers in the global configuration and is
+	// replaced in tests
+	saveOnboarding = func(apply func(*config.Config)) error {
+		return config.NewLoader().Update(apply)
+	}
+
+	// globalConfigExists reports whether the global configuration was
+	// written before and is replaced in tests
+	globalConfigExists = func() bool {
+		_, err := os.Stat(branding.GetConfigPath())
+		return err == nil
+	}
+)
+
+// skipOnboarding are the commands that never start the wizard: those that
+// scripts and shells run, and those writing the configuration themselves
+var skipOnboarding = map[string]bool{
+	"completion": true, "__complete": true, "__completeNoDesc": true,
+	"help": true, "version": true, "onboard": true, "setup": true, "import-setup": true,
+}
+
+// MaybeOnboard runs the first-run wizard before the first command a user
+// runs in a terminal, i.e. while there is no global configuration yet
+func MaybeOnboard(cmd *cobra.Command, cfg *config.Config) error {
+	if !shouldOnboard(cmd) {
+		return nil
+	}
+	return RunOnboarding(cmd.Root(), cfg)
+}
+
+// shouldOnboard reports whether cmd starts the first-run wizard
+func shouldOnboard(cmd *cobra.Command) bool {
+	if os.Getenv(NoOnboardingEnv) != "" || os.Getenv("CI") != "" {
+		return false
+	}
+	if !stdinIsTerminal() || output.IsPiped() || output.IsQuiet() || output.GetFormat() != output.FormatTable {
+		return false
+	}
+	// The first word after the root names the command
+	top := cmd
+	for top.HasParent() && top.Parent().HasParent() {
+		top = top.Parent()
+	}
+	if skipOnboarding[top.Name()] || cmd.Name() == "__complete" {
+		return false
+	}
+	return !globalConfigExists()
+}
+
+// RunOnboarding asks the first-run questions: whether colors show, whether
+// to install tab completion, whether to share anonymous usage data, and
+// which releases to be told about. The answers are saved in the global
+// configuration and applied to cfg. Interrupting the wizard saves the
+// defaults, so it does not come back.
+func RunOnboarding(root *cobra.Command, cfg *config.Config) error {
+	output.Info("Welcome to %s! A few questions before your first command; change the answers later in %s or run `%s onboard` again.",
+		branding.ProjectName, branding.GetConfigPath(), branding.CommandName)
+
+	choices, err := askOnboarding(root)
+	if err != nil {
+		output.Warning("Skipped the first-run questions (%v); using the defaults", err)
+		choices = OnboardingChoices{Colors: "auto", Channel: update.ChannelStable}
+	}
+
+	apply := func(c *config.Config) {
+		c.Defaults.Colors.Enabled = choices.Colors
+		c.Defaults.Telemetry.Enabled = choices.Telemetry
+		c.Defaults.Update.Channel = choices.Channel
+	}
+	if cfg != nil {
+		apply(cfg)
+	}
+	if choices.Colors == "never" {
+		output.DisableColors()
+	}
+
+	if err := saveOnboarding(apply); err != nil {
+		output.Warning("Could not save your answers to %s: %v", branding.GetConfigPath(), err)
+		return nil
+	}
+	output.Success("Saved your answers to %s", branding.GetConfigPath())
+	output.Println()
+	return nil
+}
+
+// askOnboarding asks the wizard's questions
+func askOnboarding(root *cobra.Command) (OnboardingChoices, error) {
+	choices := OnboardingChoices{Colors: "auto", Channel: update.ChannelStable}
+
+	// Color test
+	output.Printf("\n  %s  %s  %s  %s\n\n",
+		output.SuccessText("green"), output.WarningText("yellow"), output.ErrorText("red"), output.InfoText("blue"))
+	colorsShow, err := onboardingConfirm("Do the words above show in their colors?", true)
+	if err != nil {
+		return choices, err
+	}
+	if !colorsShow {
+		choices.Colors = "never"
+	}
+
+	// Tab completion
+	if shell := DetectShell(); shell != "" {
+		install, err := onboardingConfirm(fmt.Sprintf("Install tab completion for %s?", shell), true)
+		if err != nil {
+			return choices, err
+		}
+		if install {
+			if err := installOnboardingCompletion(root, shell); err != nil {
+				output.Warning("Could not install tab completion: %v", err)
+				output.Info("Install it later with: %s completion install", branding.CommandName)
+			} else {
+				choices.Completion = true
+			}
+		}
+	}
+
+	// Telemetry consent
+	output.Info("Anonymous usage data (which commands run, how long they take, and the types of errors; never arguments, paths, or output) helps decide what to improve.")
+	choices.Telemetry, err = onboardingConfirm("Share anonymous usage data?", false)
+	if err != nil {
+		return choices, err
+	}
+
+	// Update channel
+	channels := []string{
+		"Stable releases (recommended)",
+		"Prereleases too: betas and release candidates",
+	}
+	index, _, err := onboardingSelect("Which releases should update notifications offer?", channels, 0)
+	if err != nil {
+		return choices, err
+	}
+	if index == 1 {
+		choices.Channel = update.ChannelPrerelease
+	}
+
+	return choices, nil
+}
+
+// NewOnboardCommand creates the onboard command, which runs the first-run
+// wizard again
+func NewOnboardCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "onboard",
+		Short: "Answer the first-run questions again",
+		Long: `Run the wizard shown before the first command again: check that colors
+show, install tab completion, decide whether to share anonymous usage data,
+and choose which releases update notifications offer. The answers are saved
+in the global configuration.
+
+The wizard runs by itself the first time glide is used in a terminal, unless
+GLIDE_NO_ONBOARDING or CI is set.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !stdinIsTerminal() {
+				return fmt.Errorf("the first-run questions need a terminal")
+			}
+			return RunOnboarding(cmd.Root(), cfg)
+		},
+	}
+}
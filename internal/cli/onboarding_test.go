@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/pkg/update"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubOnboarding replaces the wizard's prompts, completion install, and
+// saving. confirms are the answers to the yes/no questions in order.
+func stubOnboarding(t *testing.T, confirms []bool, channel int, promptErr error) (saved *config.Config, installed *[]CompletionType) {
+	t.Helper()
+
+	defaults := config.GetDefaults()
+	saved = &defaults
+	installed = &[]CompletionType{}
+
+	origConfirm, origSelect := onboardingConfirm, onboardingSelect
+	origInstall, origSave := installOnboardingCompletion, saveOnboarding
+	onboardingConfirm = func(string, bool) (bool, error) {
+		if promptErr != nil {
+			return false, promptErr
+		}
+		require.NotEmpty(t, confirms, "unexpected question")
+		answer := confirms[0]
+		confirms = confirms[1:]
+		return answer, nil
+	}
+	onboardingSelect = func(_ string, options []string, _ int) (int, string, error) {
+		return channel, options[channel], nil
+	}
+	installOnboardingCompletion = func(_ *cobra.Command, shell CompletionType) error {
+		*installed = append(*installed, shell)
+		return nil
+	}
+	saveOnboarding = func(apply func(*config.Config)) error {
+		apply(saved)
+		return nil
+	}
+	t.Cleanup(func() {
+		onboardingConfirm, onboardingSelect = origConfirm, origSelect
+		installOnboardingCompletion, saveOnboarding = origInstall, origSave
+	})
+
+	return saved, installed
+}
+
+func TestRunOnboarding(t *testing.T) {
+	t.Setenv("SHELL", "/bin/zsh")
+	saved, installed := stubOnboarding(t, []bool{true, true, true}, 1, nil)
+
+	cfg := config.GetDefaults()
+	require.NoError(t, RunOnboarding(&cobra.Command{Use: "glide"}, &cfg))
+
+	assert.Equal(t, []CompletionType{CompletionZsh}, *installed)
+	for _, c := range []*config.Config{saved, &cfg} {
+		assert.Equal(t, "auto", c.Defaults.Colors.Enabled)
+		assert.True(t, c.Defaults.Telemetry.Enabled)
+		assert.Equal(t, update.ChannelPrerelease, c.Defaults.Update.Channel)
+	}
+}
+
+func TestRunOnboarding_DeclinesCompletionAndTelemetry(t *testing.T) {
+	t.Setenv("SHELL", "/bin/bash")
+	saved, installed := stubOnboarding(t, []bool{true, false, false}, 0, nil)
+
+	require.NoError(t, RunOnboarding(&cobra.Command{Use: "glide"}, nil))
+
+	assert.Empty(t, *installed)
+	assert.False(t, saved.Defaults.Telemetry.Enabled)
+	assert.Equal(t, update.ChannelStable, saved.Defaults.Update.Channel)
+}
+
+func TestRunOnboarding_CancelledSavesDefaults(t *testing.T) {
+	t.Setenv("SHELL", "/bin/bash")
+	saved, installed := stubOnboarding(t, nil, 0, errors.New("cancelled"))
+	saved.Defaults.Telemetry.Enabled = true
+
+	require.NoError(t, RunOnboarding(&cobra.Command{Use: "glide"}, nil))
+
+	// The wizard does not come back after an interruption, and shares
+	// nothing without consent
+	assert.Empty(t, *installed)
+	assert.Equal(t, "auto", saved.Defaults.Colors.Enabled)
+	assert.False(t, saved.Defaults.Telemetry.Enabled)
+	assert.Equal(t, update.ChannelStable, saved.Defaults.Update.Channel)
+}
+
+func TestShouldOnboard_Skipped(t *testing.T) {
+	origTerminal, origExists := stdinIsTerminal, globalConfigExists
+	stdinIsTerminal = func() bool { return true }
+	globalConfigExists = func() bool { return false }
+	t.Cleanup(func() { stdinIsTerminal, globalConfigExists = origTerminal, origExists })
+
+	root := &cobra.Command{Use: "glide"}
+	version := &cobra.Command{Use: "version"}
+	completion := &cobra.Command{Use: "completion"}
+	bash := &cobra.Command{Use: "bash"}
+	completion.AddCommand(bash)
+	root.AddCommand(version, completion)
+
+	t.Run("opted out", func(t *testing.T) {
+		t.Setenv(NoOnboardingEnv, "1")
+		assert.False(t, shouldOnboard(root))
+	})
+
+	t.Run("on CI", func(t *testing.T) {
+		t.Setenv("CI", "true")
+		assert.False(t, shouldOnboard(root))
+	})
+
+	t.Run("scripted commands", func(t *testing.T) {
+		t.Setenv("CI", "")
+		assert.False(t, shouldOnboard(version))
+		assert.False(t, shouldOnboard(bash))
+	})
+
+	t.Run("configured before", func(t *testing.T) {
+		t.Setenv("CI", "")
+		globalConfigExists = func() bool { return true }
+		defer func() { globalConfigExists = func() bool { return false } }()
+		assert.False(t, shouldOnboard(root))
+	})
+
+	t.Run("no terminal", func(t *testing.T) {
+		t.Setenv("CI", "")
+		stdinIsTerminal = func() bool { return false }
+		defer func() { stdinIsTerminal = func() bool { return true } }()
+		assert.False(t, shouldOnboard(root))
+	})
+}
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// loadComposeProject and listContainers are replaced in tests
+	loadComposeProject = docker.LoadComposeProject
+	listContainers     = docker.ListContainers
+)
+
+// worktreeOwnership returns the current worktree's directory and the labels
+// identifying its Docker resources
+func worktreeOwnership(ctx *context.ProjectContext) (string, docker.Ownership) {
+	dir, name := currentWorktree(ctx)
+	return dir, docker.Ownership{
+		Project:  ctx.ProjectRoot,
+		Worktree: name,
+		Version:  version.Get(),
+	}
+}
+
+// composeFreeCommands never run compose, so glide does not prepare the
+// compose environment for them
+var composeFreeCommands = map[string]bool{
+	"help":                          true,
+	"version":                       true,
+	"completion":                    true,
+	cobra.ShellCompRequestCmd:       true,
+	cobra.ShellCompNoDescRequestCmd: true,
+	"trust":                         true,
+	"features":                      true,
+	"cache":                         true,
+	"self-update":                   true,
+	"plugins":                       true,
+	"config":                        true,
+	"upgrade-config":                true,
+	"uninstall":                     true,
+	"jobs":                          true,
+	"setup":                         true,
+	"policy":                        true,
+	"time":                          true,
+}
+
+// MayRunCompose reports whether the command glide is invoked with, args
+// without the program name, may run compose. Help, version, and the other
+// commands that never do skip writing compose overrides.
+func MayRunCompose(args []string) bool {
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			return false
+		}
+	}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return !composeFreeCommands[arg]
+		}
+	}
+	// The root command shows help
+	return false
+}
+
+// ownershipOverridePath returns the generated labels override of a worktree
+func ownershipOverridePath(ctx *context.ProjectContext, worktree string) string {
+	return filepath.Join(ctx.ProjectRoot, branding.GetPluginDirName(), "compose", worktree+".labels.yml")
+}
+
+// ignoreGenerated writes a .gitignore ignoring everything in dir, unless
+// dir has one, so files glide generates inside a repository are never
+// committed
+func ignoreGenerated(dir string) {
+	path := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(path); err == nil {
+		return
+	}
+	if err := os.WriteFile(path, []byte("# Generated by "+branding.CommandName+"\n*\n"), 0644); err != nil {
+		logging.Debug("Could not write .gitignore", "path", path, "error", err)
+	}
+}
+
+// ApplyOwnershipLabels makes compose label everything it creates for the
+// current worktree with the project, worktree, and glide version. It writes
+// an override adding the labels and appends it to COMPOSE_FILE, which
+// commands and plugins started afterwards inherit. It must run before
+// plugins load, and never fails: without it resources are just unlabeled.
+func ApplyOwnershipLabels(ctx *context.ProjectContext) {
+	if ctx == nil || ctx.ProjectRoot == "" {
+		return
+	}
+	dir, _ := currentWorktree(ctx)
+	files, ok := ownershipComposeFiles(ctx, docker.ComposeFiles(dir))
+	if !ok {
+		return
+	}
+	if err := os.Setenv("COMPOSE_FILE", strings.Join(files, string(os.PathListSeparator))); err != nil {
+		logging.Debug("Could not set COMPOSE_FILE", "error", err)
+	}
+}
+
+// ownershipComposeFiles returns files, the compose files of ctx's worktree,
+// followed by its labels override, writing the override when it is out of
+// date. It reports false when the worktree's resources cannot be labeled or
+// files already include the override.
+func ownershipComposeFiles(ctx *context.ProjectContext, files []string) ([]string, bool) {
+	dir, owner := worktreeOwnership(ctx)
+	overridePath := ownershipOverridePath(ctx, owner.Worktree)
+
+	if len(files) == 0 {
+		return nil, false
+	}
+	for _, file := range files {
+		if file == overridePath {
+			// Already applied by the glide process that started this one
+			return nil, false
+		}
+	}
+	if dotenvSets(filepath.Join(dir, ".env"), "COMPOSE_FILE") {
+		logging.Debug("Not labeling compose resources: COMPOSE_FILE is set in .env", "dir", dir)
+		return nil, false
+	}
+
+	if !ownershipOverrideCurrent(overridePath, files, owner) {
+		project, err := loadComposeProject(dir, "*")
+		if err != nil {
+			logging.Debug("Not labeling compose resources", "error", err)
+			return nil, false
+		}
+		if err := project.OwnershipOverride(owner).WriteFile(overridePath); err != nil {
+			logging.Debug("Could not write compose labels override", "error", err)
+			return nil, false
+		}
+		ignoreGenerated(filepath.Dir(overridePath))
+	}
+
+	return append(files, overridePath), true
+}
+
+// ownershipOverrideCurrent reports whether the override at path is newer
+// than every compose file and carries owner's labels
+func ownershipOverrideCurrent(path string, files []string, owner docker.Ownership) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	for _, file := range files {
+		if fileInfo, err := os.Stat(file); err == nil && fileInfo.ModTime().After(info.ModTime()) {
+			return false
+		}
+	}
+
+	override, err := docker.LoadOverride(path)
+	if err != nil {
+		return false
+	}
+	want := owner.Labels()
+	for _, service := range override.Services {
+		if len(service.Labels) != len(want) {
+			return false
+		}
+		for key, value := range want {
+			if service.Labels[key] != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// dotenvSets reports whether a .env file assigns key
+func dotenvSets(path, key string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "export ")
+		if strings.HasPrefix(line, key+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnershipChecks makes `up` refuse to start when the worktree's compose
+// project name is already used by another worktree's containers, which
+// compose would otherwise silently take over
+func OwnershipChecks(ctx *context.ProjectContext) Middleware {
+	return Middleware{
+		Name:    "ownership",
+		Applies: projectCommands(ctx, "up"),
+		Wrap: func(_ *cobra.Command, next RunFunc) RunFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if err := checkOwnershipConflicts(ctx); err != nil {
+					return err
+				}
+				return next(cmd, args)
+			}
+		},
+	}
+}
+
+// checkOwnershipConflicts returns an error when containers of the current
+// compose project belong to a different glide project or worktree. Docker
+// errors are ignored so `up` can report them itself.
+func checkOwnershipConflicts(ctx *context.ProjectContext) error {
+	dir, owner := worktreeOwnership(ctx)
+	project, err := loadComposeProject(dir)
+	if err != nil || project.Name == "" {
+		return nil
+	}
+	containers, err := listContainers(docker.ComposeProjectLabel + "=" + project.Name)
+	if err != nil {
+		return nil
+	}
+
+	for _, c := range containers {
+		other, ok := c.Owner()
+		if !ok || other.SameOwner(owner) {
+			continue
+		}
+		return glideErrors.NewDockerError(
+			fmt.Sprintf("compose project %q is already used by worktree %q of %s", project.Name, other.Worktree, other.Project),
+			glideErrors.WithContext("container", c.Name),
+			glideErrors.WithSuggestions(
+				"Give each worktree its own project name, e.g. remove COMPOSE_PROJECT_NAME from .env",
+				fmt.Sprintf("Or stop the other worktree first: %s down", branding.CommandName),
+			),
+		)
+	}
+	return nil
+}
+
+// worktreeContainers returns the containers belonging to a worktree: those
+// labeled with its project and name, and unlabeled ones compose started in
+// its directory
+func worktreeContainers(containers []docker.ProjectContainer, projectRoot, worktree, dir string) []docker.ProjectContainer {
+	var matched []docker.ProjectContainer
+	for _, c := range containers {
+		if owner, ok := c.Owner(); ok {
+			if owner.Project == projectRoot && owner.Worktree == worktree {
+				matched = append(matched, c)
+			}
+			continue
+		}
+		if c.WorkingDir != "" && filepath.Clean(c.WorkingDir) == filepath.Clean(dir) {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
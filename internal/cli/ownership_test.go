@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubComposeProject replaces the compose project loaded for ownership
+func stubComposeProject(t *testing.T, project *docker.ComposeProject) *int {
+	t.Helper()
+	loads := 0
+	original := loadComposeProject
+	loadComposeProject = func(dir string, profiles ...string) (*docker.ComposeProject, error) {
+		loads++
+		return project, nil
+	}
+	t.Cleanup(func() { loadComposeProject = original })
+	return &loads
+}
+
+// stubListContainers replaces the containers docker reports
+func stubListContainers(t *testing.T, containers ...docker.ProjectContainer) {
+	t.Helper()
+	original := listContainers
+	listContainers = func(labelFilters ...string) ([]docker.ProjectContainer, error) {
+		return containers, nil
+	}
+	t.Cleanup(func() { listContainers = original })
+}
+
+func TestApplyOwnershipLabels(t *testing.T) {
+	t.Setenv("COMPOSE_FILE", "")
+
+	root := t.TempDir()
+	worktree := filepath.Join(root, "worktrees", "feature-x")
+	require.NoError(t, os.MkdirAll(worktree, 0755))
+	composeFile := filepath.Join(worktree, "compose.yaml")
+	require.NoError(t, os.WriteFile(composeFile, []byte("services: {}\n"), 0644))
+
+	loads := stubComposeProject(t, &docker.ComposeProject{
+		Name:     "feature-x",
+		Services: map[string]docker.ComposeService{"php": {}},
+	})
+	ctx := &context.ProjectContext{ProjectRoot: root, IsWorktree: true, WorktreeName: "feature-x"}
+
+	ApplyOwnershipLabels(ctx)
+
+	overridePath := filepath.Join(root, ".glide", "compose", "feature-x.labels.yml")
+	assert.Equal(t, composeFile+string(os.PathListSeparator)+overridePath, os.Getenv("COMPOSE_FILE"))
+	override, err := docker.LoadOverride(overridePath)
+	require.NoError(t, err)
+	assert.Equal(t, root, override.Services["php"].Labels[docker.LabelProject])
+	assert.Equal(t, "feature-x", override.Services["php"].Labels[docker.LabelWorktree])
+	assert.FileExists(t, filepath.Join(root, ".glide", "compose", ".gitignore"), "overrides are never committed")
+
+	// A nested glide process keeps the inherited list
+	ApplyOwnershipLabels(ctx)
+	assert.Equal(t, composeFile+string(os.PathListSeparator)+overridePath, os.Getenv("COMPOSE_FILE"))
+
+	// An up-to-date override is not regenerated
+	t.Setenv("COMPOSE_FILE", "")
+	ApplyOwnershipLabels(ctx)
+	assert.Equal(t, 1, *loads)
+}
+
+func TestApplyOwnershipLabels_Skipped(t *testing.T) {
+	t.Setenv("COMPOSE_FILE", "")
+	stubComposeProject(t, &docker.ComposeProject{Services: map[string]docker.ComposeService{"php": {}}})
+
+	t.Run("no compose file", func(t *testing.T) {
+		ApplyOwnershipLabels(&context.ProjectContext{ProjectRoot: t.TempDir()})
+		assert.Empty(t, os.Getenv("COMPOSE_FILE"))
+	})
+
+	t.Run("COMPOSE_FILE set in .env", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(root, "compose.yaml"), []byte("services: {}\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(root, ".env"), []byte("COMPOSE_FILE=compose.yaml:dev.yaml\n"), 0644))
+
+		ApplyOwnershipLabels(&context.ProjectContext{ProjectRoot: root})
+		assert.Empty(t, os.Getenv("COMPOSE_FILE"))
+	})
+}
+
+func TestMayRunCompose(t *testing.T) {
+	assert.True(t, MayRunCompose([]string{"up"}))
+	assert.True(t, MayRunCompose([]string{"--debug", "logs", "-f"}))
+	assert.True(t, MayRunCompose([]string{"project", "status"}))
+
+	assert.False(t, MayRunCompose(nil))
+	assert.False(t, MayRunCompose([]string{"version"}))
+	assert.False(t, MayRunCompose([]string{"help", "up"}))
+	assert.False(t, MayRunCompose([]string{"up", "--help"}))
+	assert.False(t, MayRunCompose([]string{"__complete", "up", ""}))
+}
+
+func TestCheckOwnershipConflicts(t *testing.T) {
+	root := "/src/myapp"
+	ctx := &context.ProjectContext{ProjectRoot: root, IsWorktree: true, WorktreeName: "feature-x"}
+	stubComposeProject(t, &docker.ComposeProject{Name: "myapp"})
+
+	labeled := func(worktree string) docker.ProjectContainer {
+		return docker.ProjectContainer{
+			Name:   "myapp-php-1",
+			Labels: docker.Ownership{Project: root, Worktree: worktree}.Labels(),
+		}
+	}
+
+	t.Run("own containers", func(t *testing.T) {
+		stubListContainers(t, labeled("feature-x"), docker.ProjectContainer{Name: "unlabeled"})
+		assert.NoError(t, checkOwnershipConflicts(ctx))
+	})
+
+	t.Run("another worktree's containers", func(t *testing.T) {
+		stubListContainers(t, labeled("feature-y"))
+		err := checkOwnershipConflicts(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `already used by worktree "feature-y"`)
+	})
+
+	t.Run("up is refused", func(t *testing.T) {
+		stubListContainers(t, labeled("feature-y"))
+		ran := false
+		root := &cobra.Command{Use: "glide"}
+		root.AddCommand(&cobra.Command{Use: "up", RunE: func(*cobra.Command, []string) error {
+			ran = true
+			return nil
+		}})
+		Chain{OwnershipChecks(ctx)}.Apply(root)
+
+		root.SetArgs([]string{"up"})
+		require.Error(t, root.Execute())
+		assert.False(t, ran)
+	})
+}
+
+func TestWorktreeContainers(t *testing.T) {
+	containers := []docker.ProjectContainer{
+		{Name: "labeled", Labels: docker.Ownership{Project: "/src/myapp", Worktree: "feature-x"}.Labels()},
+		{Name: "other-worktree", Labels: docker.Ownership{Project: "/src/myapp", Worktree: "feature-y"}.Labels()},
+		{Name: "moved", WorkingDir: "/src/myapp/worktrees/feature-x", Labels: docker.Ownership{Project: "/src/other", Worktree: "feature-x"}.Labels()},
+		{Name: "unlabeled", WorkingDir: "/src/myapp/worktrees/feature-x/"},
+		{Name: "elsewhere", WorkingDir: "/src/elsewhere"},
+	}
+
+	var names []string
+	for _, c := range worktreeContainers(containers, "/src/myapp", "feature-x", "/src/myapp/worktrees/feature-x") {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, "labeled,unlabeled", strings.Join(names, ","))
+}
@@ -0,0 +1,344 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/timings"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/performance"
+	"github.com/spf13/cobra"
+)
+
+// Sources of a performance budget
+const (
+	BudgetSourceDefault = "default"
+	BudgetSourceProject = "project"
+)
+
+// BudgetRow is a budget listed by `glide perf budgets`
+type BudgetRow struct {
+	Operation   string        `json:"operation" yaml:"operation"`
+	MaxDuration time.Duration `json:"max_duration_ns" yaml:"max_duration"`
+	Priority    string        `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Source      string        `json:"source" yaml:"source"`
+	// OverBudget counts this process's runs over budget
+	OverBudget  int64  `json:"over_budget" yaml:"over_budget"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// timingsLogPath returns the timing history log and is replaced in tests
+var timingsLogPath = timings.DefaultLogPath
+
+// PerfReport is the result of `glide perf report`
+type PerfReport struct {
+	Operations []timings.Stats `json:"operations" yaml:"operations"`
+	// Flagged is the number of flaky or degrading operations
+	Flagged int `json:"flagged" yaml:"flagged"`
+}
+
+// PerfCommand shows the performance budgets and timing history
+type PerfCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+
+	all bool
+}
+
+// NewPerfCommand creates the perf command group
+func NewPerfCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	pc := &PerfCommand{ctx: ctx, cfg: cfg}
+
+	cmd := &cobra.Command{
+		Use:   "perf",
+		Short: "Show performance budgets and timing history",
+		Long: `Show the budgets that context detection, config loading, and plugin
+discovery are measured against. Runs over budget are logged at debug level,
+or as warnings with GLIDE_PERF_WARN=1.
+
+Projects whose operations are legitimately slower, such as large monorepos,
+override or add budgets in .glide.yml:
+
+  performance:
+    budgets:
+      context_detection:
+        max_duration: 300ms
+      plugin_load:
+        max_duration: 1s
+        priority: P2
+
+Every successful up and test, and every plugin load, is timed and kept in
+~/.glide/timings.jsonl; 'perf report' flags operations whose timings are
+erratic or getting worse.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	budgets := &cobra.Command{
+		Use:   "budgets",
+		Short: "List performance budgets and where they come from",
+		Long: `List every performance budget, marking those the project's .glide.yml
+overrides or adds.
+
+Examples:
+  glide perf budgets                # Table of budgets
+  glide perf budgets --format json  # For tooling`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pc.executeBudgets()
+		},
+	}
+
+	report := &cobra.Command{
+		Use:   "report",
+		Short: "Flag flaky and degrading operations from their timing history",
+		Long: `Summarize the timing history of up, test, and plugin loading in the current
+project, over each operation's last 50 runs.
+
+An operation is flaky when its run times vary widely (standard deviation of
+at least half the mean), and degrading when its last 5 runs take at least
+1.5 times as long as the runs before. Either usually points at the local
+environment, such as a starved Docker VM or a filling disk. Operations are
+flagged only after 5 runs.
+
+Examples:
+  glide perf report                # The current project
+  glide perf report --all          # Every project
+  glide perf report --format json  # For tooling`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pc.executeReport()
+		},
+	}
+	report.Flags().BoolVar(&pc.all, "all", false, "Report every project, not only the current one")
+
+	cmd.AddCommand(budgets, report)
+	return cmd
+}
+
+// executeReport analyzes the timing history
+func (pc *PerfCommand) executeReport() error {
+	project := ""
+	if !pc.all {
+		if pc.ctx == nil || pc.ctx.ProjectRoot == "" {
+			return glideErrors.New(glideErrors.TypeMissing, "not in a project",
+				glideErrors.WithSuggestions("Run from a project, or use --all for every project"),
+			)
+		}
+		project = pc.ctx.ProjectRoot
+	}
+
+	samples, err := timings.Load(timingsLogPath())
+	if err != nil {
+		return glideErrors.NewPermissionError(timingsLogPath(), "failed to read the timing history", glideErrors.WithError(err))
+	}
+
+	report := PerfReport{Operations: []timings.Stats{}}
+	for _, stats := range timings.Analyze(samples, timings.DefaultThresholds()) {
+		if project != "" && stats.Project != project {
+			continue
+		}
+		report.Operations = append(report.Operations, stats)
+		if stats.Flagged() {
+			report.Flagged++
+		}
+	}
+
+	if output.GetFormat().IsStructured() {
+		return output.Display(report)
+	}
+	showPerfReport(report, pc.all)
+	return nil
+}
+
+// showPerfReport prints the report as a table
+func showPerfReport(report PerfReport, all bool) {
+	if len(report.Operations) == 0 {
+		output.Info("No timings were recorded yet; up, test, and plugin loading are timed as they run")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := "OPERATION\tRUNS\tMEDIAN\tSTDDEV\tTREND\tSTATUS"
+	if all {
+		header = "PROJECT\t" + header
+	}
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, header)
+	for _, stats := range report.Operations {
+		trend := "-"
+		if stats.Trend > 0 {
+			trend = fmt.Sprintf("%.2fx", stats.Trend)
+		}
+		line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", stats.Operation, output.Number(int64(stats.Samples)),
+			output.Duration(stats.Median), output.Duration(stats.StdDev), trend, timingStatus(stats))
+		if all {
+			line = stats.Project + "\t" + line
+		}
+		// Safe to ignore: Table formatting (informational display only)
+		_, _ = fmt.Fprintln(w, line)
+	}
+	// Safe to ignore: Table formatting (informational display only)
+	_ = w.Flush()
+
+	if report.Flagged > 0 {
+		output.Println()
+		output.Warning("%d operation(s) are flaky or degrading; check Docker resources, disk space, and background load", report.Flagged)
+	}
+}
+
+// timingStatus describes an operation's flags
+func timingStatus(stats timings.Stats) string {
+	switch {
+	case stats.Flaky && stats.Degrading:
+		return "flaky, degrading"
+	case stats.Flaky:
+		return "flaky"
+	case stats.Degrading:
+		return "degrading"
+	default:
+		return "ok"
+	}
+}
+
+// TimingHistory times every successful `up` and `test` of the project.
+// Recording never fails the wrapped command.
+func TimingHistory(ctx *context.ProjectContext) Middleware {
+	return Middleware{
+		Name:    "timing",
+		Applies: projectCommands(ctx, timings.OperationUp, timings.OperationTest),
+		Wrap: func(cmd *cobra.Command, next RunFunc) RunFunc {
+			operation := cmd.Name()
+			var started time.Time
+			return around(next, func() {
+				started = time.Now()
+			}, func() {
+				RecordTiming(ctx, operation, time.Since(started))
+			})
+		},
+	}
+}
+
+// RecordTiming adds a run of a key operation to the project's timing
+// history
+func RecordTiming(ctx *context.ProjectContext, operation string, duration time.Duration) {
+	if ctx == nil || ctx.ProjectRoot == "" {
+		return
+	}
+	sample := timings.Sample{Time: time.Now(), Project: ctx.ProjectRoot, Operation: operation, DurationMS: duration.Milliseconds()}
+	if err := timings.Append(timingsLogPath(), sample); err != nil {
+		logging.Debug("Could not record operation timing", "operation", operation, "error", err)
+	}
+}
+
+// executeBudgets lists the budgets, failing on invalid overrides so they
+// can be fixed
+func (pc *PerfCommand) executeBudgets() error {
+	overrides, err := budgetOverrides(localProjectConfig().Performance)
+	if err != nil {
+		return err
+	}
+	performance.SetOverrides(overrides)
+
+	var rows []BudgetRow
+	for _, b := range performance.ListBudgets() {
+		source := BudgetSourceDefault
+		if performance.IsOverridden(b.Name) {
+			source = BudgetSourceProject
+		}
+		rows = append(rows, BudgetRow{
+			Operation:   b.Name,
+			MaxDuration: b.MaxDuration,
+			Priority:    b.Priority,
+			Source:      source,
+			OverBudget:  performance.OverBudgetCount(b.Name),
+			Description: b.Description,
+		})
+	}
+
+	if output.GetFormat().IsStructured() {
+		return output.Display(rows)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, "OPERATION\tMAX\tPRIORITY\tSOURCE\tDESCRIPTION")
+	for _, row := range rows {
+		// Safe to ignore: Table formatting (informational display only)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", row.Operation, row.MaxDuration, row.Priority, row.Source, row.Description)
+	}
+	// Safe to ignore: Table formatting (informational display only)
+	_ = w.Flush()
+	return nil
+}
+
+// budgetOverrides converts the performance section of the configuration
+// into budgets. An override of a default budget keeps the default's values
+// for the fields it leaves empty.
+func budgetOverrides(cfg config.PerformanceConfig) ([]performance.Budget, error) {
+	names := make([]string, 0, len(cfg.Budgets))
+	for name := range cfg.Budgets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	budgets := make([]performance.Budget, 0, len(names))
+	for _, name := range names {
+		override := cfg.Budgets[name]
+		key := "performance.budgets." + name
+
+		budget, ok := performance.Budgets[name]
+		if !ok {
+			budget = performance.Budget{Name: name}
+		}
+		if override.MaxDuration == "" && !ok {
+			return nil, glideErrors.NewConfigError(fmt.Sprintf("%s has no max_duration", key),
+				glideErrors.WithSuggestions("Set max_duration to a duration such as 300ms"),
+			)
+		}
+		if override.MaxDuration != "" {
+			d, err := time.ParseDuration(override.MaxDuration)
+			if err != nil {
+				return nil, glideErrors.NewConfigError(fmt.Sprintf("invalid %s.max_duration: %q", key, override.MaxDuration),
+					glideErrors.WithError(err),
+					glideErrors.WithSuggestions("Use a Go duration such as 300ms or 2s"),
+				)
+			}
+			budget.MaxDuration = d
+		}
+		if override.Priority != "" {
+			budget.Priority = override.Priority
+		}
+		if override.Description != "" {
+			budget.Description = override.Description
+		}
+		if err := budget.Validate(); err != nil {
+			return nil, glideErrors.NewConfigError(fmt.Sprintf("invalid %s", key), glideErrors.WithError(err))
+		}
+		budgets = append(budgets, budget)
+	}
+	return budgets, nil
+}
+
+// ApplyBudgetOverrides measures instrumented operations against the budgets
+// the project's .glide.yml overrides. Invalid overrides are ignored with a
+// warning; `glide perf budgets` reports them in full.
+func ApplyBudgetOverrides() {
+	overrides, err := budgetOverrides(localProjectConfig().Performance)
+	if err != nil {
+		logging.Warn("Ignoring performance budget overrides", "error", err)
+		return
+	}
+	performance.SetOverrides(overrides)
+}
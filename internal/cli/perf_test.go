@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/timings"
+	"github.com/glide-cli/glide/v3/pkg/performance"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetOverrides(t *testing.T) {
+	t.Run("overrides keep the default's other fields", func(t *testing.T) {
+		budgets, err := budgetOverrides(config.PerformanceConfig{Budgets: map[string]config.BudgetConfig{
+			"context_detection": {MaxDuration: "300ms"},
+		}})
+		require.NoError(t, err)
+		require.Len(t, budgets, 1)
+
+		def := performance.Budgets["context_detection"]
+		assert.Equal(t, 300*time.Millisecond, budgets[0].MaxDuration)
+		assert.Equal(t, def.Priority, budgets[0].Priority)
+		assert.Equal(t, def.Description, budgets[0].Description)
+	})
+
+	t.Run("adds budgets for new operations", func(t *testing.T) {
+		budgets, err := budgetOverrides(config.PerformanceConfig{Budgets: map[string]config.BudgetConfig{
+			"asset_build": {MaxDuration: "2s", Priority: "P2", Description: "Build front-end assets"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []performance.Budget{{
+			Name:        "asset_build",
+			MaxDuration: 2 * time.Second,
+			Priority:    "P2",
+			Description: "Build front-end assets",
+		}}, budgets)
+	})
+
+	invalid := map[string]config.BudgetConfig{
+		"unparsable duration":        {MaxDuration: "fast"},
+		"negative duration":          {MaxDuration: "-1s"},
+		"unknown priority":           {MaxDuration: "1s", Priority: "urgent"},
+		"new operation, no duration": {Priority: "P1"},
+	}
+	for name, budget := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := budgetOverrides(config.PerformanceConfig{Budgets: map[string]config.BudgetConfig{
+				"asset_build": budget,
+			}})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "performance.budgets.asset_build")
+		})
+	}
+}
+
+// stubTimingsLog points the timing history at a temporary log
+func stubTimingsLog(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "timings.jsonl")
+	original := timingsLogPath
+	timingsLogPath = func() string { return path }
+	t.Cleanup(func() { timingsLogPath = original })
+	return path
+}
+
+func TestTimingHistory(t *testing.T) {
+	path := stubTimingsLog(t)
+
+	root := &cobra.Command{Use: "glide"}
+	failTest := false
+	root.AddCommand(
+		&cobra.Command{Use: "up", Run: func(*cobra.Command, []string) {}},
+		&cobra.Command{Use: "test", RunE: func(*cobra.Command, []string) error {
+			if failTest {
+				return assert.AnError
+			}
+			return nil
+		}},
+		&cobra.Command{Use: "down", Run: func(*cobra.Command, []string) {}},
+	)
+
+	ctx := &context.ProjectContext{ProjectRoot: t.TempDir()}
+	Chain{TimingHistory(ctx)}.Apply(root)
+
+	for _, args := range [][]string{{"up"}, {"test"}, {"down"}} {
+		root.SetArgs(args)
+		require.NoError(t, root.Execute())
+	}
+	failTest = true
+	root.SetArgs([]string{"test"})
+	require.Error(t, root.Execute())
+
+	samples, err := timings.Load(path)
+	require.NoError(t, err)
+	require.Len(t, samples, 2, "failed and untimed commands are not recorded")
+	assert.Equal(t, timings.OperationUp, samples[0].Operation)
+	assert.Equal(t, timings.OperationTest, samples[1].Operation)
+	assert.Equal(t, ctx.ProjectRoot, samples[0].Project)
+}
+
+func TestTimingStatus(t *testing.T) {
+	assert.Equal(t, "ok", timingStatus(timings.Stats{}))
+	assert.Equal(t, "flaky", timingStatus(timings.Stats{Flaky: true}))
+	assert.Equal(t, "flaky, degrading", timingStatus(timings.Stats{Flaky: true, Degrading: true}))
+}
@@ -0,0 +1,393 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/sandbox"
+	"github.com/glide-cli/glide/v3/internal/trust"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/plugin"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/spf13/cobra"
+)
+
+// Plan kinds describe how a command is executed
+const (
+	PlanKindBuiltin = "builtin"
+	PlanKindYAML    = "yaml"
+	PlanKindPlugin  = "plugin"
+)
+
+// DryRunAnnotation marks commands that honour the global --dry-run flag
+const DryRunAnnotation = "dry_run"
+
+// ExecutionPlan describes what a command would do without running it.
+// It is shared by `glide explain` and the global --dry-run flag.
+type ExecutionPlan struct {
+	Command     string            `json:"command" yaml:"command"`
+	Kind        string            `json:"kind" yaml:"kind"`
+	Source      string            `json:"source,omitempty" yaml:"source,omitempty"`
+	Destructive bool              `json:"destructive,omitempty" yaml:"destructive,omitempty"`
+	Steps       []PlanStep        `json:"steps" yaml:"steps"`
+	WorkingDir  string            `json:"working_dir" yaml:"working_dir"`
+	Env         map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	Worktrees   []string          `json:"worktrees,omitempty" yaml:"worktrees,omitempty"`
+	Config      map[string]string `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// PlanStep is a single invocation within an execution plan
+type PlanStep struct {
+	Description string   `json:"description" yaml:"description"`
+	Argv        []string `json:"argv,omitempty" yaml:"argv,omitempty"`
+}
+
+// Planner resolves execution plans for commands in the command tree
+type Planner struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+}
+
+// NewPlanner creates a planner for the given project context and configuration
+func NewPlanner(ctx *context.ProjectContext, cfg *config.Config) *Planner {
+	return &Planner{ctx: ctx, cfg: cfg}
+}
+
+// PlanArgs resolves a command line (without the binary name) against root
+// and returns its execution plan
+func (p *Planner) PlanArgs(root *cobra.Command, args []string) (*ExecutionPlan, error) {
+	target, rest, err := root.Find(args)
+	if err != nil || target == root {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return nil, fmt.Errorf("unknown command %q", name)
+	}
+
+	return p.Plan(target, rest)
+}
+
+// Plan builds the execution plan for running cmd with args
+func (p *Planner) Plan(cmd *cobra.Command, args []string) (*ExecutionPlan, error) {
+	plan := &ExecutionPlan{
+		Command:    strings.TrimSpace(cmd.CommandPath() + " " + strings.Join(args, " ")),
+		Kind:       PlanKindBuiltin,
+		WorkingDir: p.workingDir(),
+		Env:        glideEnvironment(),
+		Worktrees:  p.affectedWorktrees(cmd),
+		Config:     p.baseConfig(),
+		// Includes commands that only delete data when given certain flags
+		Destructive: cmd.Annotations[DestructiveAnnotation] != "",
+	}
+
+	switch {
+	case cmd.Annotations["yaml_command"] == "true":
+		expanded, err := prepareYAMLCommand(cmd.Annotations["yaml_cmd"], args)
+		if err != nil {
+			return nil, err
+		}
+		plan.Kind = PlanKindYAML
+		plan.Source = branding.ConfigFileName
+		plan.Config["yaml_sanitize_mode"] = yamlSanitizeMode()
+		if err := p.planYAMLSteps(plan, cmd.Annotations["yaml_dir"], expanded); err != nil {
+			return nil, err
+		}
+
+	case cmd.Annotations["plugin"] != "":
+		pluginName := cmd.Annotations["plugin"]
+		plan.Kind = PlanKindPlugin
+		plan.Source = pluginName
+		pluginPath, builtin := p.pluginLocation(cmd)
+		if builtin {
+			plan.Steps = []PlanStep{{
+				Description: fmt.Sprintf("Call ExecuteCommand(%q) on the %s plugin compiled into %s; the plugin decides what runs", cmd.Name(), pluginName, branding.CommandName),
+				Argv:        append([]string{cmd.Name()}, args...),
+			}}
+			plan.Config["plugin_builtin"] = "true"
+			break
+		}
+		plan.Steps = []PlanStep{
+			{
+				Description: fmt.Sprintf("Start the %s plugin", pluginName),
+				Argv:        []string{pluginPath},
+			},
+			{
+				Description: fmt.Sprintf("Call ExecuteCommand(%q) over gRPC; the plugin decides what runs", cmd.Name()),
+				Argv:        append([]string{cmd.Name()}, args...),
+			},
+		}
+		plan.Config["plugin_path"] = pluginPath
+
+	default:
+		plan.Steps = []PlanStep{{
+			Description: fmt.Sprintf("Handled internally by %s", branding.CommandName),
+			Argv:        append(strings.Fields(cmd.CommandPath()), args...),
+		}}
+	}
+
+	return plan, nil
+}
+
+// planYAMLSteps adds the steps of a YAML command defined by the project
+// config in dir, or by the global config when dir is empty. Like the real
+// run, a project config's commands run through the shell once it is
+// trusted, and otherwise run in the sandbox or are refused.
+func (p *Planner) planYAMLSteps(plan *ExecutionPlan, dir, expanded string) error {
+	run := PlanStep{
+		Description: "Run the command defined in " + branding.ConfigFileName + " through the shell",
+		Argv:        []string{"sh", "-c", expanded},
+	}
+	if dir == "" {
+		plan.Steps = []PlanStep{run}
+		return nil
+	}
+
+	status, err := trustStatus(dir)
+	if err != nil {
+		return err
+	}
+	plan.Config["trust"] = string(status)
+	if status == trust.Trusted {
+		plan.Steps = []PlanStep{run}
+		return nil
+	}
+
+	var untrusted PlanStep
+	if sandboxUntrusted() {
+		opts, err := sandboxOptions(dir)
+		if err != nil {
+			return err
+		}
+		args, err := sandbox.Args(opts, expanded, os.Environ())
+		if err != nil {
+			return err
+		}
+		plan.Config["sandbox"] = "no network, project mounted read-only"
+		untrusted = PlanStep{
+			Description: fmt.Sprintf("Run the command in a sandbox, because %s is not trusted", dir),
+			Argv:        append([]string{"docker"}, args...),
+		}
+	} else {
+		untrusted = PlanStep{
+			Description: fmt.Sprintf("Refuse to run the command, because %s is not trusted (%s trust %s allows it)", dir, branding.CommandName, dir),
+		}
+	}
+
+	if status == trust.Unknown && stdinIsTerminal() {
+		run.Description = "If you trust it, " + strings.ToLower(run.Description[:1]) + run.Description[1:]
+		untrusted.Description = "Otherwise, " + strings.ToLower(untrusted.Description[:1]) + untrusted.Description[1:]
+		plan.Steps = []PlanStep{
+			{Description: fmt.Sprintf("Ask whether to trust %s, and remember the answer", dir)},
+			run,
+			untrusted,
+		}
+		return nil
+	}
+	plan.Steps = []PlanStep{untrusted}
+	return nil
+}
+
+// pluginLocation returns the binary of the plugin cmd belongs to, or
+// whether it is compiled into glide. Plugin commands record where they were
+// loaded from; otherwise the plugin is looked up as the plugin manager
+// would.
+func (p *Planner) pluginLocation(cmd *cobra.Command) (path string, builtin bool) {
+	if cmd.Annotations["plugin_builtin"] == "true" {
+		return "", true
+	}
+	if path := cmd.Annotations["plugin_path"]; path != "" {
+		return path, false
+	}
+	name := cmd.Annotations["plugin"]
+	if path, builtin, ok := locatePlugin(name); ok {
+		return path, builtin
+	}
+	return filepath.Join(plugin.GetRuntimePluginPath(), name), false
+}
+
+// locatePlugin finds a plugin the way the plugin manager does
+func locatePlugin(name string) (path string, builtin, ok bool) {
+	return sdk.NewManager(nil).Locate(name)
+}
+
+// workingDir returns the directory commands run in
+func (p *Planner) workingDir() string {
+	if p.ctx != nil && p.ctx.WorkingDir != "" {
+		return p.ctx.WorkingDir
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	return wd
+}
+
+// affectedWorktrees lists worktrees a command operates on in multi-worktree mode
+func (p *Planner) affectedWorktrees(cmd *cobra.Command) []string {
+	if p.ctx == nil || p.ctx.DevelopmentMode != context.ModeMultiWorktree {
+		return nil
+	}
+
+	if p.ctx.IsWorktree {
+		return []string{p.ctx.WorktreeName}
+	}
+
+	// Project commands fan out across the main repo and every worktree
+	if !strings.HasPrefix(cmd.CommandPath(), cmd.Root().Name()+" project") {
+		if p.ctx.IsMainRepo {
+			return []string{"vcs"}
+		}
+		return nil
+	}
+
+	worktrees := []string{"vcs"}
+	entries, err := os.ReadDir(filepath.Join(p.ctx.ProjectRoot, "worktrees"))
+	if err != nil {
+		return worktrees
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			worktrees = append(worktrees, entry.Name())
+		}
+	}
+	return worktrees
+}
+
+// baseConfig returns the configuration values that apply to every command
+func (p *Planner) baseConfig() map[string]string {
+	values := map[string]string{
+		"config_file": branding.GetConfigPath(),
+	}
+	if p.ctx != nil {
+		if p.ctx.ProjectRoot != "" {
+			values["project_root"] = p.ctx.ProjectRoot
+		}
+		if p.ctx.DevelopmentMode != context.ModeUnknown {
+			values["development_mode"] = string(p.ctx.DevelopmentMode)
+		}
+	}
+	if p.cfg != nil && p.cfg.DefaultProject != "" {
+		values["default_project"] = p.cfg.DefaultProject
+	}
+	return values
+}
+
+// glideEnvironment returns the GLIDE_* environment variables that influence
+// command behaviour, with secret-looking values redacted
+func glideEnvironment() map[string]string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, "GLIDE_") {
+			continue
+		}
+		upper := strings.ToUpper(key)
+		if strings.Contains(upper, "TOKEN") || strings.Contains(upper, "SECRET") || strings.Contains(upper, "PASSWORD") {
+			value = "********"
+		}
+		env[key] = value
+	}
+	return env
+}
+
+// yamlSanitizeMode returns the effective YAML sanitizer mode
+func yamlSanitizeMode() string {
+	if mode := os.Getenv("GLIDE_YAML_SANITIZE_MODE"); mode != "" {
+		return mode
+	}
+	return "script"
+}
+
+// RenderPlan writes a plan using the current output format
+func RenderPlan(plan *ExecutionPlan) {
+	if output.GetFormat().IsStructured() {
+		_ = output.Display(plan)
+		return
+	}
+
+	output.Info("Execution plan for: %s", plan.Command)
+	output.Raw("\n")
+	output.Raw(fmt.Sprintf("  Kind:         %s\n", plan.Kind))
+	if plan.Source != "" {
+		output.Raw(fmt.Sprintf("  Source:       %s\n", plan.Source))
+	}
+	output.Raw(fmt.Sprintf("  Working dir:  %s\n", plan.WorkingDir))
+	if plan.Destructive {
+		output.Raw("  Destructive:  yes (asks for confirmation; --force skips it)\n")
+	}
+
+	output.Raw("\nSteps:\n")
+	for i, step := range plan.Steps {
+		output.Raw(fmt.Sprintf("  %d. %s\n", i+1, step.Description))
+		if len(step.Argv) > 0 {
+			output.Raw(fmt.Sprintf("     $ %s\n", strings.Join(step.Argv, " ")))
+		}
+	}
+
+	if len(plan.Worktrees) > 0 {
+		output.Raw("\nAffected worktrees:\n")
+		for _, wt := range plan.Worktrees {
+			output.Raw(fmt.Sprintf("  • %s\n", wt))
+		}
+	}
+
+	renderPlanMap("Environment", plan.Env)
+	renderPlanMap("Configuration", plan.Config)
+}
+
+// renderPlanMap prints a sorted key/value section of a plan
+func renderPlanMap(title string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	output.Raw(fmt.Sprintf("\n%s:\n", title))
+	for _, key := range keys {
+		output.Raw(fmt.Sprintf("  %s=%s\n", key, values[key]))
+	}
+}
+
+// IsDryRun reports whether the global --dry-run flag was given
+func IsDryRun(cmd *cobra.Command) bool {
+	flag := cmd.Root().PersistentFlags().Lookup("dry-run")
+	return flag != nil && flag.Changed
+}
+
+// CheckDryRunSupport returns an error when --dry-run is requested for a
+// command that cannot honour it, so nothing runs unexpectedly
+func CheckDryRunSupport(cmd *cobra.Command) error {
+	if !IsDryRun(cmd) || cmd.Annotations[DryRunAnnotation] == "true" {
+		return nil
+	}
+	return fmt.Errorf("%s does not support --dry-run; use '%s explain %s' to see what it would run",
+		cmd.CommandPath(), branding.CommandName, strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "))
+}
+
+// DryRunCheck refuses --dry-run for commands that would otherwise execute
+// for real
+func DryRunCheck() Middleware {
+	return Middleware{
+		Name: "dry-run",
+		Wrap: func(_ *cobra.Command, next RunFunc) RunFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if err := CheckDryRunSupport(cmd); err != nil {
+					return err
+				}
+				return next(cmd, args)
+			}
+		},
+	}
+}
@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/shell"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPlanTestRoot(t *testing.T) *cobra.Command {
+	t.Helper()
+	return newPlanTestRootWithRegistry(t, NewRegistry())
+}
+
+func newPlanTestRootWithRegistry(t *testing.T, registry *Registry) *cobra.Command {
+	t.Helper()
+
+	root := &cobra.Command{Use: "glide", TraverseChildren: true}
+	root.PersistentFlags().Bool("dry-run", false, "")
+
+	require.NoError(t, registry.AddYAMLCommand("greet", &config.Command{
+		Cmd:         "echo hello $1",
+		Description: "Say hello",
+	}))
+	for _, cmd := range registry.CreateAll() {
+		root.AddCommand(cmd)
+	}
+
+	root.AddCommand(&cobra.Command{
+		Use:         "up",
+		Annotations: map[string]string{"plugin": "docker"},
+		RunE:        func(cmd *cobra.Command, args []string) error { return nil },
+	})
+	root.AddCommand(&cobra.Command{
+		Use:  "version",
+		RunE: func(cmd *cobra.Command, args []string) error { return nil },
+	})
+
+	return root
+}
+
+func TestPlanner_PlanArgs(t *testing.T) {
+	SetYAMLCommandSanitizer(shell.NewSanitizer(shell.ScriptConfig()))
+	root := newPlanTestRoot(t)
+	planner := NewPlanner(&context.ProjectContext{WorkingDir: "/work"}, nil)
+
+	t.Run("yaml command expands to shell invocation", func(t *testing.T) {
+		plan, err := planner.PlanArgs(root, []string{"greet", "world"})
+		require.NoError(t, err)
+
+		assert.Equal(t, PlanKindYAML, plan.Kind)
+		assert.Equal(t, "glide greet world", plan.Command)
+		assert.Equal(t, "/work", plan.WorkingDir)
+		require.Len(t, plan.Steps, 1)
+		assert.Equal(t, []string{"sh", "-c", "echo hello world"}, plan.Steps[0].Argv)
+		assert.Contains(t, plan.Config, "yaml_sanitize_mode")
+	})
+
+	t.Run("yaml command with rejected arguments", func(t *testing.T) {
+		_, err := planner.PlanArgs(root, []string{"greet", "$(whoami)"})
+		assert.Error(t, err)
+	})
+
+	t.Run("plugin command", func(t *testing.T) {
+		plan, err := planner.PlanArgs(root, []string{"up", "-d"})
+		require.NoError(t, err)
+
+		assert.Equal(t, PlanKindPlugin, plan.Kind)
+		assert.Equal(t, "docker", plan.Source)
+		require.Len(t, plan.Steps, 2)
+		assert.Equal(t, []string{"up", "-d"}, plan.Steps[1].Argv)
+		assert.Contains(t, plan.Config, "plugin_path")
+	})
+
+	t.Run("builtin command", func(t *testing.T) {
+		plan, err := planner.PlanArgs(root, []string{"version"})
+		require.NoError(t, err)
+
+		assert.Equal(t, PlanKindBuiltin, plan.Kind)
+		require.Len(t, plan.Steps, 1)
+		assert.Equal(t, []string{"glide", "version"}, plan.Steps[0].Argv)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		_, err := planner.PlanArgs(root, []string{"does-not-exist"})
+		assert.Error(t, err)
+	})
+}
+
+func TestPlanner_PluginLocation(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	source := t.TempDir()
+	require.NoError(t, sdk.AddLink(sdk.DefaultLinksFile(), sdk.Link{Name: "docker", Dir: source}))
+
+	plan := func(annotations map[string]string) *ExecutionPlan {
+		t.Helper()
+		root := &cobra.Command{Use: "glide"}
+		root.AddCommand(&cobra.Command{
+			Use:         "up",
+			Annotations: annotations,
+			RunE:        func(cmd *cobra.Command, args []string) error { return nil },
+		})
+		plan, err := NewPlanner(nil, nil).PlanArgs(root, []string{"up"})
+		require.NoError(t, err)
+		return plan
+	}
+
+	t.Run("linked plugin", func(t *testing.T) {
+		got := plan(map[string]string{"plugin": "docker"})
+		require.Len(t, got.Steps, 2)
+		assert.Equal(t, []string{filepath.Join(source, "docker")}, got.Steps[0].Argv)
+		assert.Equal(t, filepath.Join(source, "docker"), got.Config["plugin_path"])
+	})
+
+	t.Run("recorded path", func(t *testing.T) {
+		got := plan(map[string]string{"plugin": "docker", "plugin_path": "/opt/plugins/docker"})
+		assert.Equal(t, []string{"/opt/plugins/docker"}, got.Steps[0].Argv)
+	})
+
+	t.Run("builtin plugin", func(t *testing.T) {
+		got := plan(map[string]string{"plugin": "docker", "plugin_builtin": "true"})
+		require.Len(t, got.Steps, 1, "nothing is started")
+		assert.Equal(t, []string{"up"}, got.Steps[0].Argv)
+		assert.Equal(t, "true", got.Config["plugin_builtin"])
+		assert.NotContains(t, got.Config, "plugin_path")
+	})
+}
+
+func TestPlanner_ProjectYAMLCommandTrust(t *testing.T) {
+	SetYAMLCommandSanitizer(shell.NewSanitizer(shell.ScriptConfig()))
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Cleanup(func() { ConfigureSandbox(nil) })
+
+	plan := func() *ExecutionPlan {
+		t.Helper()
+		registry := NewRegistry()
+		require.NoError(t, registry.AddYAMLCommand("lint", &config.Command{Cmd: "make lint $1", Dir: dir}))
+		root := &cobra.Command{Use: "glide"}
+		root.AddCommand(registry.CreateAll()...)
+		plan, err := NewPlanner(nil, nil).PlanArgs(root, []string{"lint", "api"})
+		require.NoError(t, err)
+		return plan
+	}
+
+	t.Run("trusted", func(t *testing.T) {
+		store, _ := stubTrust(t, false, false)
+		require.NoError(t, store.Set(dir, true))
+
+		got := plan()
+		require.Len(t, got.Steps, 1)
+		assert.Equal(t, []string{"sh", "-c", "make lint api"}, got.Steps[0].Argv)
+		assert.Equal(t, "trusted", got.Config["trust"])
+	})
+
+	t.Run("untrusted is refused", func(t *testing.T) {
+		stubTrust(t, false, false)
+		t.Setenv(SandboxEnv, "0")
+
+		got := plan()
+		require.Len(t, got.Steps, 1)
+		assert.Empty(t, got.Steps[0].Argv, "nothing runs")
+		assert.Contains(t, got.Steps[0].Description, "Refuse")
+	})
+
+	t.Run("untrusted runs in the sandbox", func(t *testing.T) {
+		store, _ := stubTrust(t, false, false)
+		require.NoError(t, store.Set(dir, false))
+		t.Setenv(SandboxEnv, "1")
+
+		got := plan()
+		require.Len(t, got.Steps, 1)
+		argv := got.Steps[0].Argv
+		require.NotEmpty(t, argv)
+		assert.Equal(t, []string{"docker", "run"}, argv[:2])
+		assert.Contains(t, argv, "none", "the sandbox has no network")
+		assert.Contains(t, argv, "--read-only")
+		assert.Equal(t, []string{"sh", "-c", "make lint api"}, argv[len(argv)-3:])
+		assert.Equal(t, "denied", got.Config["trust"])
+	})
+
+	t.Run("undecided asks in a terminal", func(t *testing.T) {
+		_, asked := stubTrust(t, true, true)
+		t.Setenv(SandboxEnv, "0")
+
+		got := plan()
+		require.Len(t, got.Steps, 3)
+		assert.Contains(t, got.Steps[0].Description, "Ask whether to trust")
+		assert.Equal(t, []string{"sh", "-c", "make lint api"}, got.Steps[1].Argv)
+		assert.Zero(t, *asked, "planning never asks")
+	})
+}
+
+func TestDryRunMatchesExplain(t *testing.T) {
+	SetYAMLCommandSanitizer(shell.NewSanitizer(shell.ScriptConfig()))
+	planner := NewPlanner(&context.ProjectContext{
+		WorkingDir:      "/work",
+		DevelopmentMode: context.ModeMultiWorktree,
+		IsWorktree:      true,
+		WorktreeName:    "feature-x",
+	}, nil)
+
+	render := func(args ...string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		output.SetGlobalManager(output.NewManager(output.FormatJSON, false, true, &buf))
+		t.Cleanup(func() {
+			output.SetGlobalManager(output.NewManager(output.FormatTable, false, false, os.Stdout))
+		})
+
+		registry := NewRegistry()
+		registry.SetPlanner(planner)
+		root := newPlanTestRootWithRegistry(t, registry)
+		root.AddCommand(NewExplainCommand(registry.Planner()))
+		root.SetArgs(args)
+		require.NoError(t, root.Execute())
+		return buf.String()
+	}
+
+	explained := render("explain", "greet", "world")
+	assert.Contains(t, explained, "/work")
+	assert.Contains(t, explained, "feature-x")
+	assert.Equal(t, explained, render("--dry-run", "greet", "world"))
+}
+
+func TestPlanner_RedactsSecrets(t *testing.T) {
+	t.Setenv("GLIDE_API_TOKEN", "abc123")
+	t.Setenv("GLIDE_LOG_LEVEL", "debug")
+
+	env := glideEnvironment()
+	assert.Equal(t, "********", env["GLIDE_API_TOKEN"])
+	assert.Equal(t, "debug", env["GLIDE_LOG_LEVEL"])
+}
+
+func TestPlanner_AffectedWorktrees(t *testing.T) {
+	root := newPlanTestRoot(t)
+	greet, _, err := root.Find([]string{"greet"})
+	require.NoError(t, err)
+
+	planner := NewPlanner(&context.ProjectContext{
+		DevelopmentMode: context.ModeMultiWorktree,
+		IsWorktree:      true,
+		WorktreeName:    "feature-x",
+	}, nil)
+	assert.Equal(t, []string{"feature-x"}, planner.affectedWorktrees(greet))
+
+	assert.Nil(t, NewPlanner(&context.ProjectContext{DevelopmentMode: context.ModeSingleRepo}, nil).affectedWorktrees(greet))
+}
+
+func TestCheckDryRunSupport(t *testing.T) {
+	root := newPlanTestRoot(t)
+	require.NoError(t, root.PersistentFlags().Set("dry-run", "true"))
+
+	greet, _, err := root.Find([]string{"greet"})
+	require.NoError(t, err)
+	assert.True(t, IsDryRun(greet))
+	assert.NoError(t, CheckDryRunSupport(greet))
+
+	version, _, err := root.Find([]string{"version"})
+	require.NoError(t, err)
+	err = CheckDryRunSupport(version)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "explain version")
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/spf13/cobra"
+)
+
+// pluginLinksFile is where linked plugins are recorded; tests replace it
+var pluginLinksFile = sdk.DefaultLinksFile
+
+// newPluginLinkCommand links a plugin under development for discovery
+func newPluginLinkCommand() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "link <dir>",
+		Short: "Use a plugin from the directory it is developed in",
+		Long: `Link a plugin's source directory, so Glide discovers the plugin there
+instead of in its plugin directories. Nothing is copied: the binary is
+built into the directory, named after the plugin.
+
+When the directory holds a Go module, Glide runs 'go build' before it
+loads the plugin whenever a Go source, go.mod, or go.sum changed since the
+last build, so every invocation runs your latest code. Otherwise the
+directory must already hold the built binary.
+
+A linked plugin takes precedence over an installed plugin of the same
+name until it is unlinked.`,
+		Example: `  glide plugins link .
+  glide plugins link ~/src/glide-plugin-docker --name docker
+  glide plugins unlink docker`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := filepath.Abs(args[0])
+			if err != nil {
+				return glideErrors.Wrap(err, "resolving the plugin directory")
+			}
+			if info, err := os.Stat(dir); err != nil {
+				return glideErrors.NewFileNotFoundError(dir)
+			} else if !info.IsDir() {
+				return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("%s is not a directory", dir),
+					glideErrors.WithSuggestions("Link the directory holding the plugin's go.mod or binary"))
+			}
+			if name == "" {
+				name = filepath.Base(dir)
+			}
+			if name == "" || name == "." || strings.ContainsAny(name, `/\`) {
+				return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("invalid plugin name %q", name),
+					glideErrors.WithSuggestions("Name the plugin with --name"))
+			}
+
+			link := sdk.Link{Name: name, Dir: dir}
+			if link.Buildable() {
+				ctx := cmd.Context()
+				if ctx == nil {
+					ctx = context.Background()
+				}
+				if _, err := link.Rebuild(ctx); err != nil {
+					return glideErrors.NewPluginError(name, "build failed", err)
+				}
+			} else if _, err := os.Stat(link.Binary()); err != nil {
+				return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("%s holds neither a go.mod nor a built %s binary", dir, name),
+					glideErrors.WithSuggestions(
+						fmt.Sprintf("Build the plugin to %s", link.Binary()),
+						"Name the plugin after its binary with --name",
+					))
+			}
+
+			if err := sdk.AddLink(pluginLinksFile(), link); err != nil {
+				return glideErrors.WrapWithOp(err, "recording the link", glideErrors.WithPath(pluginLinksFile()))
+			}
+			output.Success("Linked plugin '%s' from %s", name, output.Path(dir))
+			if link.Buildable() {
+				output.Info("It is rebuilt whenever its sources change")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Plugin name (default: the directory's name)")
+	MarkExclusive(cmd, GroupPlugins)
+	return cmd
+}
+
+// newPluginUnlinkCommand removes a link made by plugins link
+func newPluginUnlinkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unlink <plugin-name>",
+		Short: "Stop using a linked plugin",
+		Long: `Remove a link made with 'glide plugins link'. The plugin's directory
+and binary are left alone, and an installed plugin of the same name is
+used again.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			removed, err := sdk.RemoveLink(pluginLinksFile(), args[0])
+			if err != nil {
+				return glideErrors.WrapWithOp(err, "removing the link", glideErrors.WithPath(pluginLinksFile()))
+			}
+			if !removed {
+				return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("plugin '%s' is not linked", args[0]),
+					glideErrors.WithSuggestions("Run 'glide plugins list' to see linked plugins"))
+			}
+			output.Success("Unlinked plugin '%s'", args[0])
+			return nil
+		},
+	}
+	MarkExclusive(cmd, GroupPlugins)
+	return cmd
+}
+
+// linkedBinaries returns the binaries of the linked plugins
+func linkedBinaries() map[string]bool {
+	links, _ := sdk.LoadLinks(pluginLinksFile())
+	binaries := make(map[string]bool, len(links))
+	for _, link := range links {
+		binaries[link.Binary()] = true
+	}
+	return binaries
+}
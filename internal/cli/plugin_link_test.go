@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPluginLinks points plugin links at a temporary file
+func stubPluginLinks(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plugin-links.json")
+	original := pluginLinksFile
+	pluginLinksFile = func() string { return path }
+	t.Cleanup(func() { pluginLinksFile = original })
+	return path
+}
+
+func TestPluginLinkCommand(t *testing.T) {
+	path := stubPluginLinks(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker"), []byte("#!/bin/sh\n"), 0755))
+
+	run := func(cmd *cobra.Command, args ...string) error {
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	// A directory without go.mod must hold the plugin's binary
+	err := run(newPluginLinkCommand(), dir)
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid))
+
+	require.NoError(t, run(newPluginLinkCommand(), dir, "--name", "docker"))
+	links, err := sdk.LoadLinks(path)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.Link{{Name: "docker", Dir: dir}}, links)
+	assert.True(t, linkedBinaries()[filepath.Join(dir, "docker")])
+
+	require.NoError(t, run(newPluginUnlinkCommand(), "docker"))
+	links, err = sdk.LoadLinks(path)
+	require.NoError(t, err)
+	assert.Empty(t, links)
+
+	err = run(newPluginUnlinkCommand(), "docker")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not linked")
+}
+
+func TestPluginLinkCommand_NotADirectory(t *testing.T) {
+	stubPluginLinks(t)
+	file := filepath.Join(t.TempDir(), "docker")
+	require.NoError(t, os.WriteFile(file, []byte("#!/bin/sh\n"), 0755))
+
+	cmd := newPluginLinkCommand()
+	cmd.SetArgs([]string{file})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a directory")
+
+	cmd = newPluginLinkCommand()
+	cmd.SetArgs([]string{filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, cmd.Execute())
+}
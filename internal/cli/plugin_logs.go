@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+)
+
+// pluginLogDir is where plugin logs are read from; tests replace it
+var pluginLogDir = sdk.DefaultLogDir
+
+// logPollInterval is how often --follow checks for new log lines
+const logPollInterval = 500 * time.Millisecond
+
+// newPluginLogsCommand shows the logs captured from a plugin's stderr
+func newPluginLogsCommand() *cobra.Command {
+	var (
+		level  string
+		lines  int
+		follow bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "logs <plugin-name>",
+		Short: "Show the logs of a plugin",
+		Long: `Show the logs a runtime plugin wrote while Glide ran it.
+
+Each plugin's log output is kept in a rotating file, whether or not
+GLIDE_PLUGIN_DEBUG is set.`,
+		Example: `  glide plugins logs docker
+  glide plugins logs docker --level warn -n 100
+  glide plugins logs docker -f`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minLevel := hclog.LevelFromString(level)
+			if minLevel == hclog.NoLevel {
+				return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("unknown log level %q", level),
+					glideErrors.WithSuggestions("Use one of: trace, debug, info, warn, error"))
+			}
+
+			dir, name := pluginLogDir(), args[0]
+			// Follow from where the shown entries end
+			var offset int64
+			if info, err := os.Stat(sdk.LogPath(dir, name)); err == nil {
+				offset = info.Size()
+			}
+			entries, err := sdk.ReadLogs(dir, name, minLevel)
+			if os.IsNotExist(err) {
+				return noPluginLogsError(dir, name)
+			}
+			if err != nil {
+				return glideErrors.WrapWithOp(err, "reading plugin logs", glideErrors.WithPath(sdk.LogPath(dir, name)))
+			}
+
+			if lines > 0 && len(entries) > lines {
+				entries = entries[len(entries)-lines:]
+			}
+			out := cmd.OutOrStdout()
+			for _, entry := range entries {
+				fmt.Fprintln(out, entry)
+			}
+
+			if !follow {
+				return nil
+			}
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			return followPluginLog(ctx, out, sdk.LogPath(dir, name), offset, minLevel)
+		},
+	}
+
+	cmd.Flags().StringVar(&level, "level", "trace", "Show entries at or above this level (trace, debug, info, warn, error)")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 50, "Number of most recent entries to show (0 for all)")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing new entries as the plugin writes them")
+	return cmd
+}
+
+// noPluginLogsError reports a plugin without logs, listing those with logs
+func noPluginLogsError(dir, name string) error {
+	suggestions := []string{"Logs are written once Glide has run the plugin"}
+	if logged := sdk.LoggedPlugins(dir); len(logged) > 0 {
+		suggestions = append(suggestions, "Plugins with logs: "+strings.Join(logged, ", "))
+	}
+	return glideErrors.New(glideErrors.TypeMissing, fmt.Sprintf("no logs for plugin %s", name),
+		glideErrors.WithSuggestions(suggestions...))
+}
+
+// followPluginLog prints entries written to the log at path after offset
+// until ctx is done, starting over when the file is rotated
+func followPluginLog(ctx context.Context, out io.Writer, path string, offset int64, minLevel hclog.Level) error {
+	var partial string
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if info.Size() < offset {
+			// Rotated: the new file starts from scratch
+			offset, partial = 0, ""
+		}
+		if info.Size() == offset {
+			continue
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		data := make([]byte, info.Size()-offset)
+		n, _ := f.ReadAt(data, offset)
+		f.Close()
+		offset += int64(n)
+
+		text := partial + string(data[:n])
+		complete := strings.Split(text, "\n")
+		partial = complete[len(complete)-1]
+		for _, line := range complete[:len(complete)-1] {
+			if line == "" {
+				continue
+			}
+			if entry := sdk.ParseLogLine(line); entry.Level >= minLevel {
+				fmt.Fprintln(out, entry)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPluginLogs points plugin logs at a temporary directory
+func stubPluginLogs(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	original := pluginLogDir
+	pluginLogDir = func() string { return dir }
+	t.Cleanup(func() { pluginLogDir = original })
+	return dir
+}
+
+const pluginLogLines = `{"@level":"debug","@message":"connecting","@timestamp":"2026-03-01T12:00:00.000000Z"}
+{"@level":"warn","@message":"slow response","@timestamp":"2026-03-01T12:00:01.000000Z","ms":1200}
+{"@level":"error","@message":"query failed","@timestamp":"2026-03-01T12:00:02.000000Z"}
+`
+
+func TestPluginLogsCommand(t *testing.T) {
+	dir := stubPluginLogs(t)
+	require.NoError(t, os.WriteFile(sdk.LogPath(dir, "docker"), []byte(pluginLogLines), 0644))
+
+	run := func(args ...string) (string, error) {
+		cmd := newPluginLogsCommand()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("docker", "--level", "warn")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "connecting")
+	assert.Contains(t, out, "WARN  slow response ms=1200")
+	assert.Contains(t, out, "ERROR query failed")
+
+	out, err = run("docker", "-n", "1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, bytes.Count([]byte(out), []byte("\n")))
+	assert.Contains(t, out, "query failed")
+
+	_, err = run("docker", "--level", "loud")
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid))
+
+	_, err = run("mysql")
+	require.Error(t, err)
+	var glideErr *glideErrors.GlideError
+	require.ErrorAs(t, err, &glideErr)
+	assert.Contains(t, glideErr.Suggestions, "Plugins with logs: docker")
+}
+
+func TestFollowPluginLog(t *testing.T) {
+	dir := stubPluginLogs(t)
+	path := sdk.LogPath(dir, "docker")
+	require.NoError(t, os.WriteFile(path, []byte(pluginLogLines), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out bytes.Buffer
+	done := make(chan error)
+	go func() { done <- followPluginLog(ctx, &out, path, int64(len(pluginLogLines)), hclog.Info) }()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"@level":"info","@message":"reconnected"}` + "\n" + `{"@level":"debug","@message":"ping"}` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	time.Sleep(3 * logPollInterval)
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, "INFO  reconnected\n", out.String(), "only new entries at the level are printed")
+}
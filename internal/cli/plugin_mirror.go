@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/pluginregistry"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// newPluginMirrorCommand creates the plugins mirror command group
+func newPluginMirrorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mirror",
+		Short: "Keep a mirror of plugin releases for offline installs",
+		Long: `Keep a mirror of plugin releases in a directory, for air-gapped
+environments.
+
+Point Glide at the mirror with plugin_mirror in ~/.glide/config.yml or
+GLIDE_PLUGIN_MIRROR, as a directory, a file:// URL, or the URL of a static
+web server serving the directory. install, update, and import-setup then
+read every registry, github.com included, from the mirror.`,
+	}
+
+	cmd.AddCommand(newPluginMirrorSyncCommand())
+	return cmd
+}
+
+// newPluginMirrorSyncCommand copies the latest releases into a mirror
+func newPluginMirrorSyncCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync <dir> [source...]",
+		Short: "Copy the latest plugin releases into a mirror",
+		Long: `Copy the latest release of each plugin, with its binaries for every
+platform, into a mirror directory and record it in the mirror's index.json.
+
+Sources are repositories as 'glide plugins install' takes them. Without
+any, the plugins already in the mirror and the installed plugins are synced.
+Binaries already in the mirror are not downloaded again.`,
+		Example: `  glide plugins mirror sync /srv/glide-mirror github.com/glide-cli/glide-plugin-go
+  glide plugins mirror sync file:///srv/glide-mirror`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := pluginregistry.MirrorDir(args[0])
+			if err != nil {
+				return glideErrors.New(glideErrors.TypeInvalid, err.Error())
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			// Sync from the registries themselves, not from a mirror
+			registries, err := pluginregistry.FromConfig(cfg)
+			if err != nil {
+				return err
+			}
+			return syncPluginMirror(cmd.OutOrStdout(), dir, args[1:], registries)
+		},
+	}
+}
+
+// syncPluginMirror copies the latest release of each source into the
+// mirror in dir
+func syncPluginMirror(out io.Writer, dir string, sources []string, registries []*pluginregistry.Registry) error {
+	index, err := pluginregistry.LoadMirrorIndex(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(sources) == 0 {
+		for _, p := range index.Plugins {
+			sources = append(sources, p.Source)
+		}
+		installed, err := installedPlugins()
+		if err != nil {
+			return glideErrors.WrapWithOp(err, "listing installed plugins")
+		}
+		for _, p := range installed {
+			if p.Source != "" {
+				sources = append(sources, p.Source)
+			}
+		}
+	}
+	if len(sources) == 0 {
+		return glideErrors.New(glideErrors.TypeMissing, "nothing to mirror",
+			glideErrors.WithSuggestions("Name the plugins to mirror, e.g. 'glide plugins mirror sync <dir> github.com/glide-cli/glide-plugin-go'"))
+	}
+
+	synced := make(map[string]bool)
+	var failed int
+	for _, source := range sources {
+		reg, repo, ok := pluginregistry.Resolve(source, registries)
+		if !ok {
+			fmt.Fprintf(out, "❌ %s: not a repository of a known registry\n", source)
+			failed++
+			continue
+		}
+		key := reg.Host + "/" + repo
+		if synced[key] {
+			continue
+		}
+		synced[key] = true
+
+		tag, err := mirrorLatestRelease(dir, reg, repo)
+		if err != nil {
+			fmt.Fprintf(out, "❌ %s: %v\n", key, err)
+			failed++
+			continue
+		}
+		index.Add(key, tag, time.Now())
+		fmt.Fprintf(out, "✓ %s %s\n", key, tag)
+	}
+
+	if err := index.Save(dir); err != nil {
+		return glideErrors.WrapWithOp(err, "writing the mirror index", glideErrors.WithPath(dir))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d plugin(s) could not be mirrored", failed)
+	}
+	return nil
+}
+
+// mirrorLatestRelease copies the latest release of a repository and its
+// plugin binaries into the mirror and returns its tag
+func mirrorLatestRelease(dir string, reg *pluginregistry.Registry, repo string) (string, error) {
+	release, err := getLatestRelease(reg, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get latest release: %w", err)
+	}
+
+	// Binaries are named <repo>-<os>-<arch>
+	prefix := path.Base(repo) + "-"
+	mirrored := GitHubRelease{TagName: release.TagName}
+	for _, asset := range release.Assets {
+		if !strings.HasPrefix(asset.Name, prefix) {
+			continue
+		}
+		dest := pluginregistry.MirrorPath(dir, reg, repo, "download", release.TagName, asset.Name)
+		if _, err := os.Stat(dest); err != nil {
+			if err := mirrorAsset(reg, release.assetURL(reg, asset.Name), dest); err != nil {
+				return "", fmt.Errorf("failed to download %s: %w", asset.Name, err)
+			}
+		}
+		link := "download/" + release.TagName + "/" + asset.Name
+		mirrored.Assets = append(mirrored.Assets, asset)
+		last := &mirrored.Assets[len(mirrored.Assets)-1]
+		last.BrowserDownloadURL, last.URL = link, link
+	}
+	if len(mirrored.Assets) == 0 {
+		return "", fmt.Errorf("release %s has no binaries named %s<os>-<arch>", release.TagName, prefix)
+	}
+
+	// Links are relative to the release file: latest, or tags/<tag>
+	if err := writeMirroredRelease(pluginregistry.MirrorPath(dir, reg, repo, "tags", release.TagName), mirrored, "../"); err != nil {
+		return "", err
+	}
+	if err := writeMirroredRelease(pluginregistry.MirrorPath(dir, reg, repo, "latest"), mirrored, ""); err != nil {
+		return "", err
+	}
+	return release.TagName, nil
+}
+
+// mirrorAsset downloads an asset into the mirror
+func mirrorAsset(reg *pluginregistry.Registry, url, dest string) error {
+	tmpFile, err := downloadFile(reg, url)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(tmpFile)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dest, data, 0755)
+}
+
+// writeMirroredRelease writes a release file whose asset links are
+// prefixed with linkPrefix
+func writeMirroredRelease(dest string, release GitHubRelease, linkPrefix string) error {
+	release.Assets = append(release.Assets[:0:0], release.Assets...)
+	for i := range release.Assets {
+		release.Assets[i].BrowserDownloadURL = linkPrefix + release.Assets[i].BrowserDownloadURL
+		release.Assets[i].URL = linkPrefix + release.Assets[i].URL
+	}
+
+	data, err := json.MarshalIndent(release, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dest, append(data, '\n'), 0644)
+}
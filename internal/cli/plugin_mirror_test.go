@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/bundle"
+	"github.com/glide-cli/glide/v3/internal/pluginregistry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncPluginMirror(t *testing.T) {
+	downloads := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/platform/glide-plugin-deploy/releases/latest":
+			_, _ = w.Write([]byte(`{"tag_name":"v1.2.0","assets":[` +
+				`{"name":"glide-plugin-deploy-linux-amd64","browser_download_url":"` + server.URL + `/dl/linux"},` +
+				`{"name":"glide-plugin-deploy-darwin-arm64","browser_download_url":"` + server.URL + `/dl/darwin"},` +
+				`{"name":"checksums.txt","browser_download_url":"` + server.URL + `/dl/checksums"}]}`))
+		case "/dl/linux", "/dl/darwin":
+			downloads++
+			_, _ = w.Write([]byte("binary for " + r.URL.Path))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	upstream := []*pluginregistry.Registry{{Name: "acme", Host: "github.acme.com", API: server.URL}}
+	dir := t.TempDir()
+
+	var out bytes.Buffer
+	require.NoError(t, syncPluginMirror(&out, dir, []string{"github.acme.com/platform/glide-plugin-deploy"}, upstream))
+	assert.Contains(t, out.String(), "✓ github.acme.com/platform/glide-plugin-deploy v1.2.0")
+	assert.Equal(t, 2, downloads, "only plugin binaries are mirrored")
+
+	index, err := pluginregistry.LoadMirrorIndex(dir)
+	require.NoError(t, err)
+	require.Len(t, index.Plugins, 1)
+	assert.Equal(t, "v1.2.0", index.Plugins[0].Latest)
+
+	// Syncing again keeps the binaries it has
+	stubSetupPaths(t)
+	installedPlugins = func() ([]bundle.Plugin, error) { return nil, nil }
+	require.NoError(t, syncPluginMirror(&out, dir, nil, upstream))
+	assert.Equal(t, 2, downloads)
+
+	// Installs read the mirror, without the network
+	server.Close()
+	mirrored, err := pluginregistry.WithMirror(upstream, "file://"+dir)
+	require.NoError(t, err)
+	reg, repo, ok := pluginregistry.Resolve("github.acme.com/platform/glide-plugin-deploy", mirrored)
+	require.True(t, ok)
+
+	for _, find := range []func() (*GitHubRelease, error){
+		func() (*GitHubRelease, error) { return getLatestRelease(reg, repo) },
+		func() (*GitHubRelease, error) { return getReleaseByTag(reg, repo, "1.2.0") },
+	} {
+		release, err := find()
+		require.NoError(t, err)
+		assert.Equal(t, "v1.2.0", release.TagName)
+		path, err := downloadFile(reg, release.assetURL(reg, "glide-plugin-deploy-linux-amd64"))
+		require.NoError(t, err)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "binary for /dl/linux", string(data))
+		require.NoError(t, os.Remove(path))
+	}
+
+	err = syncPluginMirror(&out, dir, []string{"gitlab.com/a/b"}, upstream)
+	assert.ErrorContains(t, err, "1 plugin(s) could not be mirrored")
+}
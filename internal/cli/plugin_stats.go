@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/pluginstats"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/invocation"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/spf13/cobra"
+)
+
+// pluginStatsLogPath returns the plugin run log and is replaced in tests
+var pluginStatsLogPath = pluginstats.DefaultLogPath
+
+// PluginStatsReport is the result of `glide plugins stats`
+type PluginStatsReport struct {
+	Since time.Time `json:"since" yaml:"since"`
+	// Plugin is set when the report breaks one plugin down by command
+	Plugin string              `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+	Rows   []pluginstats.Stats `json:"rows" yaml:"rows"`
+}
+
+// newPluginStatsCommand shows how often plugin commands run, how long they
+// take, and how often they fail
+func newPluginStatsCommand() *cobra.Command {
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "stats [plugin-name]",
+		Short: "Show run counts, durations, and failure rates of plugins",
+		Long: `Show how often each runtime plugin's commands ran, how long they took, and
+how often they failed, slowest plugin first. Given a plugin, break it down
+by command.
+
+Every plugin command run is recorded in ~/.glide/plugin-stats.jsonl.
+
+--since takes a date (2006-01-02), "today", or an age such as "7d" or
+"36h" before now.`,
+		Example: `  glide plugins stats
+  glide plugins stats docker
+  glide plugins stats --since 7d --format json`,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := parseReportTime("--since", since, time.Now())
+			if err != nil {
+				return err
+			}
+			records, err := pluginstats.Load(pluginStatsLogPath())
+			if err != nil {
+				return glideErrors.NewPermissionError(pluginStatsLogPath(), "failed to read the plugin run log", glideErrors.WithError(err))
+			}
+
+			report := PluginStatsReport{Since: from}
+			if len(args) == 1 {
+				report.Plugin = args[0]
+			}
+			report.Rows = pluginstats.Summarize(records, from, report.Plugin)
+
+			if output.GetFormat().IsStructured() {
+				return output.Display(report)
+			}
+			showPluginStats(cmd.OutOrStdout(), report)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&since, "since", "30d", "Only runs since")
+
+	return cmd
+}
+
+// showPluginStats prints the report as a table
+func showPluginStats(out io.Writer, report PluginStatsReport) {
+	if len(report.Rows) == 0 {
+		if report.Plugin != "" {
+			output.Info("No runs of plugin %s were recorded since %s", report.Plugin, report.Since.Format("2006-01-02"))
+		} else {
+			output.Info("No plugin runs were recorded since %s", report.Since.Format("2006-01-02"))
+		}
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	name := "PLUGIN"
+	if report.Plugin != "" {
+		name = "COMMAND"
+	}
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintf(w, "%s\tRUNS\tFAILED\tFAILURE %%\tMEDIAN\tP95\tTOTAL\n", name)
+	for _, stats := range report.Rows {
+		name := stats.Plugin
+		if report.Plugin != "" {
+			name = stats.Command
+		}
+		// Safe to ignore: Table formatting (informational display only)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%.1f\t%s\t%s\t%s\n", name, output.Number(int64(stats.Runs)), output.Number(int64(stats.Failures)),
+			stats.FailureRate*100, output.Duration(stats.Median), output.Duration(stats.P95), output.Duration(stats.Total))
+	}
+	// Safe to ignore: Table formatting (informational display only)
+	_ = w.Flush()
+}
+
+// PluginTelemetry counts every run of a plugin command in the metrics,
+// labeled with the plugin, command, and project, and passes it to the
+// execution hooks, such as the one RecordPluginStats adds
+func PluginTelemetry(ctx *context.ProjectContext) Middleware {
+	return Middleware{
+		Name: "telemetry",
+		Applies: func(cmd *cobra.Command) bool {
+			return cmd.Annotations["plugin"] != ""
+		},
+		Wrap: func(_ *cobra.Command, next RunFunc) RunFunc {
+			return func(cmd *cobra.Command, args []string) (err error) {
+				started := time.Now()
+				defer func() {
+					sdk.RecordExecution(sdk.Execution{
+						Plugin:     cmd.Annotations["plugin"],
+						Command:    cmd.Name(),
+						Duration:   time.Since(started),
+						Err:        err,
+						Invocation: invocation.ID(cmd.Context()),
+						Project:    projectName(ctx),
+					})
+				}()
+				return next(cmd, args)
+			}
+		},
+	}
+}
+
+// RecordPluginStats keeps every plugin command run in the plugin run log
+// for `glide plugins stats`
+func RecordPluginStats() {
+	sdk.AddExecutionHook(recordPluginRun)
+}
+
+// recordPluginRun appends a plugin command run to the log. Recording never
+// fails the command.
+func recordPluginRun(e sdk.Execution) {
+	record := pluginstats.Record{
+		Time:       time.Now(),
+		Plugin:     e.Plugin,
+		Command:    e.Command,
+		DurationMS: e.Duration.Milliseconds(),
+		Failed:     e.Err != nil,
+		Invocation: e.Invocation,
+	}
+	if err := pluginstats.Append(pluginStatsLogPath(), record); err != nil {
+		logging.Debug("Could not record plugin run", "plugin", e.Plugin, "error", err)
+	}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/pluginstats"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPluginStatsLog points the plugin run log at a temporary file
+func stubPluginStatsLog(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plugin-stats.jsonl")
+	original := pluginStatsLogPath
+	pluginStatsLogPath = func() string { return path }
+	t.Cleanup(func() { pluginStatsLogPath = original })
+	return path
+}
+
+func TestPluginStatsCommand(t *testing.T) {
+	path := stubPluginStatsLog(t)
+	now := time.Now()
+	for _, record := range []pluginstats.Record{
+		{Time: now.Add(-time.Hour), Plugin: "docker", Command: "up", DurationMS: 9000},
+		{Time: now.Add(-time.Hour), Plugin: "docker", Command: "up", DurationMS: 11000, Failed: true},
+		{Time: now.Add(-time.Hour), Plugin: "git", Command: "status", DurationMS: 120},
+		{Time: now.Add(-60 * 24 * time.Hour), Plugin: "aws", Command: "login", DurationMS: 90000},
+	} {
+		require.NoError(t, pluginstats.Append(path, record))
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := newPluginStatsCommand()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs(append([]string{}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run()
+	require.NoError(t, err)
+	assert.Contains(t, out, "PLUGIN")
+	assert.Regexp(t, `docker\s+2\s+1\s+50\.0\s+9s\s+11s\s+20s`, out)
+	assert.Less(t, bytes.Index([]byte(out), []byte("docker")), bytes.Index([]byte(out), []byte("git")), "slowest plugin first")
+	assert.NotContains(t, out, "aws", "runs older than 30 days are left out")
+
+	out, err = run("docker")
+	require.NoError(t, err)
+	assert.Contains(t, out, "COMMAND")
+	assert.Regexp(t, `up\s+2\s+1`, out)
+	assert.NotContains(t, out, "git")
+
+	out, err = run("--since", "90d")
+	require.NoError(t, err)
+	assert.Contains(t, out, "aws")
+
+	_, err = run("--since", "last tuesday")
+	assert.ErrorContains(t, err, "invalid --since")
+}
+
+func TestRecordPluginRun(t *testing.T) {
+	path := stubPluginStatsLog(t)
+
+	recordPluginRun(sdk.Execution{Plugin: "docker", Command: "up", Duration: 1500 * time.Millisecond, Err: errors.New("exit status 1")})
+
+	records, err := pluginstats.Load(path)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "docker", records[0].Plugin)
+	assert.Equal(t, int64(1500), records[0].DurationMS)
+	assert.True(t, records[0].Failed)
+}
+
+func TestPluginTelemetry(t *testing.T) {
+	var runs []sdk.Execution
+	sdk.AddExecutionHook(func(e sdk.Execution) {
+		if e.Plugin == "telemetry-test" {
+			runs = append(runs, e)
+		}
+	})
+
+	root := &cobra.Command{Use: "glide"}
+	failing := &cobra.Command{Use: "migrate", Annotations: map[string]string{"plugin": "telemetry-test"},
+		RunE: func(*cobra.Command, []string) error { return errors.New("exit status 1") }}
+	builtin := &cobra.Command{Use: "version", RunE: func(*cobra.Command, []string) error { return nil }}
+	root.AddCommand(failing, builtin)
+	Chain{PluginTelemetry(&context.ProjectContext{ProjectRoot: "/src/shop"})}.Apply(root)
+
+	assert.Error(t, failing.RunE(failing, nil))
+	require.NoError(t, builtin.RunE(builtin, nil))
+
+	require.Len(t, runs, 1, "only plugin commands are counted")
+	assert.Equal(t, "migrate", runs[0].Command)
+	assert.Equal(t, "shop", runs[0].Project)
+	assert.Error(t, runs[0].Err)
+}
@@ -10,14 +10,34 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
-	"text/tabwriter"
 
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/pluginregistry"
+	"github.com/glide-cli/glide/v3/internal/plugins"
+	"github.com/glide-cli/glide/v3/pkg/artifacts"
 	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/glide-cli/glide/v3/pkg/output"
 	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
 	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"github.com/glide-cli/glide/v3/pkg/ratelimit"
+	"github.com/glide-cli/glide/v3/pkg/retry"
 	"github.com/spf13/cobra"
 )
 
+// githubClient fetches plugin releases, from registries or file:// mirrors;
+// checking many plugins for updates at once is rate limited per host
+var githubClient = &http.Client{Transport: ratelimit.Default.Transport(releaseTransport())}
+
+// artifactCache keeps downloaded plugin binaries; tests replace it
+var artifactCache = artifacts.Default
+
+// releaseTransport is the default transport that also reads file:// URLs
+func releaseTransport() http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.RegisterProtocol("file", pluginregistry.FileTransport)
+	return transport
+}
+
 // NewPluginsCommand creates the plugins management command
 func NewPluginsCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -32,7 +52,12 @@ func NewPluginsCommand() *cobra.Command {
 		newPluginInstallCommand(),
 		newPluginUpdateCommand(),
 		newPluginRemoveCommand(),
+		newPluginLinkCommand(),
+		newPluginUnlinkCommand(),
 		newPluginReloadCommand(),
+		newPluginLogsCommand(),
+		newPluginStatsCommand(),
+		newPluginMirrorCommand(),
 	)
 
 	return cmd
@@ -61,31 +86,31 @@ func newPluginListCommand() *cobra.Command {
 				return nil
 			}
 
-			// Display plugins in table format
-			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			// Safe to ignore: Table header formatting (informational display only)
-			_, _ = fmt.Fprintln(w, "NAME\tVERSION\tDESCRIPTION\tSTATUS")
-			_, _ = fmt.Fprintln(w, "----\t-------\t-----------\t------")
-
+			// Display plugins in table format, fitting long descriptions to
+			// the terminal
+			table := output.NewTable("NAME", "VERSION", "DESCRIPTION", "STATUS")
+			table.AddRow("----", "-------", "-----------", "------")
+			linked := linkedBinaries()
 			for _, p := range plugins {
 				status := "Loaded"
 				// Check if client has exited
-				if p.Client.Exited() {
+				if p.Builtin {
+					status = "Built in"
+				} else if p.Client.Exited() {
 					status = "Stopped"
+				} else if manager.PluginHealth(p.Name) != nil {
+					// The plugin's health service says it is not ready
+					status = "Unhealthy"
+				} else if linked[p.Path] {
+					status = "Linked"
 				}
 
 				// Use metadata directly
-				// Safe to ignore: Plugin list row formatting (informational display only)
 				metadata := p.Metadata
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-					metadata.Name,
-					metadata.Version,
-					metadata.Description,
-					status,
-				)
+				table.AddRow(metadata.Name, metadata.Version, metadata.Description, status)
 			}
-			// Safe to ignore: Table flush (informational display, operation continues if fails)
-			_ = w.Flush()
+			// Safe to ignore: Table rendering (informational display only)
+			_ = output.PrintTable(table)
 
 			return nil
 		},
@@ -120,10 +145,14 @@ func newPluginInfoCommand() *cobra.Command {
 			fmt.Printf("Version: %s\n", metadata.Version)
 			fmt.Printf("Author: %s\n", metadata.Author)
 			fmt.Printf("Description: %s\n", metadata.Description)
-			fmt.Printf("Path: %s\n", loadedPlugin.Path)
+			if loadedPlugin.Builtin {
+				fmt.Printf("Path: (built into %s)\n", branding.CommandName)
+			} else {
+				fmt.Printf("Path: %s\n", output.Path(loadedPlugin.Path))
+			}
 
 			if metadata.Homepage != "" {
-				fmt.Printf("Homepage: %s\n", metadata.Homepage)
+				fmt.Printf("Homepage: %s\n", output.URL(metadata.Homepage))
 			}
 
 			if metadata.License != "" {
@@ -185,27 +214,44 @@ func newPluginInfoCommand() *cobra.Command {
 // newPluginInstallCommand installs a new plugin
 func newPluginInstallCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "install <plugin-path-or-url>",
-		Short: "Install a plugin from a local file or GitHub release",
-		Long: `Install a plugin from a local file or GitHub repository.
+		Use:   "install <plugin-name-path-or-url>",
+		Short: "Install a plugin by name, from a local file, or from a GitHub release",
+		Long: `Install a plugin by name from the marketplace index configured as
+plugin_index in ~/.glide/config.yml, from a local file, from a GitHub
+repository, or from a private plugin registry configured under
+plugin_registries.
 
 Examples:
+  # Install by name from the marketplace index, verifying its checksum
+  glide plugins install docker-tools
+
   # Install from GitHub (downloads latest release)
   glide plugins install github.com/glide-cli/glide-plugin-go
 
+  # Install from a private registry, authenticating as it configures
+  glide plugins install github.acme.com/platform/glide-plugin-deploy
+
   # Install from local file
   glide plugins install ./glide-plugin-go
 
 Supported formats:
+  - <name> (downloads the binary the plugin index lists)
   - github.com/owner/repo (downloads latest release binary)
+  - <registry-host>/owner/repo (downloads latest release binary)
   - /path/to/plugin-binary (installs local file)`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			source := args[0]
 
-			// Check if source is a GitHub URL
-			if isGitHubURL(source) {
-				return installFromGitHub(cmd.Context(), source)
+			registries, err := pluginRegistries()
+			if err != nil {
+				return err
+			}
+			if reg, repo, ok := pluginregistry.Resolve(source, registries); ok {
+				return installFromRegistry(cmd.Context(), reg, repo)
+			}
+			if _, err := os.Stat(source); err != nil && plugins.IsName(source) {
+				return installFromIndex(cmd.Context(), source)
 			}
 
 			// Install from local file
@@ -213,26 +259,34 @@ Supported formats:
 		},
 	}
 
+	MarkExclusive(cmd, GroupPlugins)
 	return cmd
 }
 
-// isGitHubURL checks if the source looks like a GitHub repository
-func isGitHubURL(source string) bool {
-	return len(source) > 11 && (source[:11] == "github.com/" || source[:19] == "https://github.com/")
-}
-
-// installFromGitHub downloads and installs a plugin from GitHub releases
-func installFromGitHub(ctx context.Context, repo string) error {
-	// Parse repository (remove https:// prefix if present)
-	repo = filepath.Base(filepath.Dir(repo)) + "/" + filepath.Base(repo)
-	if repo[:11] == "github.com/" {
-		repo = repo[11:]
+// pluginRegistries returns the private plugin registries configured in
+// the global configuration, served from the plugin mirror if there is one
+func pluginRegistries() ([]*pluginregistry.Registry, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	registries, err := pluginregistry.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if mirror := pluginregistry.MirrorLocation(cfg); mirror != "" {
+		return pluginregistry.WithMirror(registries, mirror)
 	}
+	return registries, nil
+}
 
-	fmt.Printf("Installing plugin from github.com/%s...\n", repo)
+// installFromRegistry downloads and installs a plugin from the latest
+// release of a repository in a registry
+func installFromRegistry(ctx context.Context, reg *pluginregistry.Registry, repo string) error {
+	fmt.Printf("Installing plugin from %s/%s...\n", reg.Host, repo)
 
 	// Get latest release
-	release, err := getLatestRelease(repo)
+	release, err := getLatestRelease(reg, repo)
 	if err != nil {
 		return fmt.Errorf("failed to get latest release: %w", err)
 	}
@@ -244,21 +298,14 @@ func installFromGitHub(ctx context.Context, repo string) error {
 	}
 
 	// Find matching asset
-	var downloadURL string
-	for _, asset := range release.Assets {
-		if asset.Name == binaryName {
-			downloadURL = asset.BrowserDownloadURL
-			break
-		}
-	}
-
+	downloadURL := release.assetURL(reg, binaryName)
 	if downloadURL == "" {
 		return fmt.Errorf("no binary found for %s-%s in release %s", runtime.GOOS, runtime.GOARCH, release.TagName)
 	}
 
 	// Download binary
 	fmt.Printf("Downloading %s...\n", binaryName)
-	tempFile, err := downloadFile(downloadURL)
+	tempFile, err := downloadAsset(reg, downloadURL)
 	if err != nil {
 		return fmt.Errorf("failed to download plugin: %w", err)
 	}
@@ -269,6 +316,46 @@ func installFromGitHub(ctx context.Context, repo string) error {
 	return installFromFileWithName(tempFile, pluginName)
 }
 
+// installFromIndex downloads and installs a plugin the marketplace index
+// lists, after verifying the binary's checksum
+func installFromIndex(ctx context.Context, name string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	location := plugins.IndexLocation(cfg)
+	if location == "" {
+		return fmt.Errorf("cannot install %q by name: no plugin index is configured; set plugin_index in ~/.glide/config.yml or %s, or pass a path or github.com/owner/repo", name, plugins.IndexEnv)
+	}
+	market, err := plugins.NewMarketplace(location, githubClient)
+	if err != nil {
+		return err
+	}
+	market.Cache = artifactCache()
+
+	index, err := market.Index(ctx)
+	if err != nil {
+		return err
+	}
+	plugin, err := index.Lookup(name)
+	if err != nil {
+		return err
+	}
+	binary, err := plugin.Binary(runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Downloading %s %s for %s-%s...\n", plugin.Name, plugin.Version, runtime.GOOS, runtime.GOARCH)
+	tempFile, err := market.Download(ctx, binary)
+	if err != nil {
+		return fmt.Errorf("failed to download plugin: %w", err)
+	}
+	defer os.Remove(tempFile)
+
+	return installFromFileWithName(tempFile, plugin.Name)
+}
+
 // installFromFile installs a plugin from a local file
 // It derives the plugin name from the file path
 func installFromFile(pluginPath string) error {
@@ -344,7 +431,7 @@ func installFromFileWithName(pluginPath, pluginName string) error {
 		return fmt.Errorf("plugin validation failed: %w", err)
 	}
 
-	fmt.Printf("Plugin '%s' installed successfully to %s\n", pluginName, destPath)
+	fmt.Printf("Plugin '%s' installed successfully to %s\n", pluginName, output.Path(destPath))
 	fmt.Println("Run 'glide plugins list' to see all available plugins")
 
 	return nil
@@ -378,6 +465,11 @@ Examples:
 				return nil
 			}
 
+			registries, err := pluginRegistries()
+			if err != nil {
+				return err
+			}
+
 			// Determine which plugins to update
 			var pluginsToUpdate []*sdk.LoadedPlugin
 			if len(args) > 0 {
@@ -398,14 +490,23 @@ Examples:
 			for _, plugin := range pluginsToUpdate {
 				metadata := plugin.Metadata
 
+				// Builtin plugins are updated with glide itself
+				if plugin.Builtin {
+					fmt.Printf("⚠️  %s: Built into %s, skipping\n", metadata.Name, branding.CommandName)
+					continue
+				}
+
 				// Check if plugin has Homepage (GitHub URL)
 				if metadata.Homepage == "" {
 					fmt.Printf("⚠️  %s: No homepage specified, skipping\n", metadata.Name)
 					continue
 				}
 
-				// Parse GitHub repo from homepage
-				repo := extractGitHubRepo(metadata.Homepage)
+				// Parse the repo from the homepage, on GitHub or a registry
+				reg, repo := pluginregistry.GitHub, extractGitHubRepo(metadata.Homepage)
+				if registry, registryRepo, ok := pluginregistry.Resolve(metadata.Homepage, registries); ok {
+					reg, repo = registry, registryRepo
+				}
 				if repo == "" {
 					fmt.Printf("⚠️  %s: Homepage is not a GitHub URL, skipping\n", metadata.Name)
 					continue
@@ -414,7 +515,7 @@ Examples:
 				fmt.Printf("Checking %s...\n", metadata.Name)
 
 				// Get latest release
-				release, err := getLatestRelease(repo)
+				release, err := getLatestRelease(reg, repo)
 				if err != nil {
 					fmt.Printf("❌ %s: Failed to check for updates: %v\n", metadata.Name, err)
 					continue
@@ -429,7 +530,7 @@ Examples:
 				fmt.Printf("📦 %s: %s → %s\n", metadata.Name, metadata.Version, release.TagName)
 
 				// Download and install
-				if err := installPluginFromRelease(plugin.Path, repo, release); err != nil {
+				if err := installPluginFromRelease(reg, plugin.Path, repo, release); err != nil {
 					fmt.Printf("❌ %s: Update failed: %v\n", metadata.Name, err)
 					continue
 				}
@@ -448,6 +549,7 @@ Examples:
 		},
 	}
 
+	MarkExclusive(cmd, GroupPlugins)
 	return cmd
 }
 
@@ -474,8 +576,8 @@ func extractGitHubRepo(homepage string) string {
 	return parts[0] + "/" + parts[1]
 }
 
-// installPluginFromRelease installs a plugin from a GitHub release
-func installPluginFromRelease(existingPath, repo string, release *GitHubRelease) error {
+// installPluginFromRelease installs a plugin from a release in a registry
+func installPluginFromRelease(reg *pluginregistry.Registry, existingPath, repo string, release *GitHubRelease) error {
 	// Determine platform-specific binary name
 	pluginName := filepath.Base(repo)
 	binaryName := pluginName + "-" + runtime.GOOS + "-" + runtime.GOARCH
@@ -484,20 +586,13 @@ func installPluginFromRelease(existingPath, repo string, release *GitHubRelease)
 	}
 
 	// Find matching asset
-	var downloadURL string
-	for _, asset := range release.Assets {
-		if asset.Name == binaryName {
-			downloadURL = asset.BrowserDownloadURL
-			break
-		}
-	}
-
+	downloadURL := release.assetURL(reg, binaryName)
 	if downloadURL == "" {
 		return fmt.Errorf("no binary found for %s-%s", runtime.GOOS, runtime.GOARCH)
 	}
 
 	// Download to temporary file
-	tmpFile, err := downloadFile(downloadURL)
+	tmpFile, err := downloadAsset(reg, downloadURL)
 	if err != nil {
 		return fmt.Errorf("download failed: %w", err)
 	}
@@ -522,7 +617,7 @@ func installPluginFromRelease(existingPath, repo string, release *GitHubRelease)
 
 // newPluginRemoveCommand removes an installed plugin
 func newPluginRemoveCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "remove <plugin-name>",
 		Short: "Remove an installed plugin",
 		Args:  cobra.ExactArgs(1),
@@ -547,6 +642,8 @@ func newPluginRemoveCommand() *cobra.Command {
 			return nil
 		},
 	}
+	MarkExclusive(cmd, GroupPlugins)
+	return cmd
 }
 
 // newPluginReloadCommand reloads all plugins
@@ -579,45 +676,124 @@ type GitHubRelease struct {
 	Assets  []struct {
 		Name               string `json:"name"`
 		BrowserDownloadURL string `json:"browser_download_url"`
+		// URL is the asset's API URL, which authenticated requests
+		// download from
+		URL string `json:"url"`
 	} `json:"assets"`
 }
 
-// getLatestRelease fetches the latest release from GitHub
-func getLatestRelease(repo string) (*GitHubRelease, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repo)
+// assetURL returns where to download the named asset from, or "" when the
+// release has none. Private registries only serve assets through the API.
+func (r *GitHubRelease) assetURL(reg *pluginregistry.Registry, name string) string {
+	for _, asset := range r.Assets {
+		if asset.Name != name {
+			continue
+		}
+		if reg.Authenticated() && asset.URL != "" {
+			return asset.URL
+		}
+		return asset.BrowserDownloadURL
+	}
+	return ""
+}
+
+// getLatestRelease fetches the latest release from a registry
+func getLatestRelease(reg *pluginregistry.Registry, repo string) (*GitHubRelease, error) {
+	return fetchGitHubRelease(reg, reg.ReleasesURL(repo)+"/latest")
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+// getReleaseByTag fetches the release of a tag from a registry, trying the
+// tag with a "v" prefix when it has none
+func getReleaseByTag(reg *pluginregistry.Registry, repo, tag string) (*GitHubRelease, error) {
+	release, err := fetchGitHubRelease(reg, reg.ReleasesURL(repo)+"/tags/"+tag)
+	if err != nil && !strings.HasPrefix(tag, "v") {
+		if prefixed, prefixedErr := fetchGitHubRelease(reg, reg.ReleasesURL(repo)+"/tags/v"+tag); prefixedErr == nil {
+			return prefixed, nil
+		}
 	}
+	return release, err
+}
 
-	// Set User-Agent header (required by GitHub API)
-	req.Header.Set("User-Agent", "glide-cli")
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
+// fetchGitHubRelease fetches a release from a registry's API, retrying
+// network errors and server errors
+func fetchGitHubRelease(reg *pluginregistry.Registry, url string) (*GitHubRelease, error) {
+	var release GitHubRelease
+	err := retry.Do(context.Background(), retry.DefaultPolicy(), func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return retry.Permanent(err)
+		}
 
-	resp, err := http.DefaultClient.Do(req)
+		// Set User-Agent header (required by GitHub API)
+		req.Header.Set("User-Agent", "glide-cli")
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+		if err := reg.Authorize(ctx, req); err != nil {
+			return retry.Permanent(err)
+		}
+
+		resp, err := githubClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			err := fmt.Errorf("%s API returned status %d", reg.Name, resp.StatusCode)
+			if resp.StatusCode < http.StatusInternalServerError {
+				return retry.Permanent(err)
+			}
+			return err
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+			return retry.Permanent(err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
-	}
-
-	var release GitHubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, err
+	// Mirrors name their assets relative to the release
+	for i := range release.Assets {
+		asset := &release.Assets[i]
+		asset.BrowserDownloadURL = pluginregistry.ResolveLink(url, asset.BrowserDownloadURL)
+		asset.URL = pluginregistry.ResolveLink(url, asset.URL)
 	}
 
 	return &release, nil
 }
 
-// downloadFile downloads a file from a URL to a temporary file
-func downloadFile(url string) (string, error) {
-	// Validate URL to ensure it's from GitHub (security: G107)
-	if !isValidGitHubDownloadURL(url) {
-		return "", fmt.Errorf("invalid download URL: must be from github.com")
+// downloadAsset downloads a release asset to a temporary file, through the
+// artifact cache unless it comes from a local mirror
+func downloadAsset(reg *pluginregistry.Registry, url string) (string, error) {
+	if strings.HasPrefix(url, "file://") {
+		return downloadFile(reg, url)
+	}
+	return artifactCache().Download(url, func() (string, error) {
+		return downloadFile(reg, url)
+	})
+}
+
+// downloadFile downloads a file from a registry to a temporary file
+func downloadFile(reg *pluginregistry.Registry, url string) (string, error) {
+	// Validate URL to ensure it's from the registry (security: G107)
+	valid := reg.Owns(url)
+	if reg == pluginregistry.GitHub {
+		valid = isValidGitHubDownloadURL(url)
+	}
+	if !valid {
+		return "", fmt.Errorf("invalid download URL: must be from %s", reg.Host)
+	}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	// Asset API URLs serve the binary itself when asked for it
+	req.Header.Set("Accept", "application/octet-stream")
+	req.Header.Set("User-Agent", "glide-cli")
+	if err := reg.Authorize(req.Context(), req); err != nil {
+		return "", err
 	}
 
 	// Create temporary file
@@ -627,8 +803,8 @@ func downloadFile(url string) (string, error) {
 	}
 	defer tmpFile.Close()
 
-	// Download file #nosec G107 - URL is validated to be from github.com
-	resp, err := http.Get(url)
+	// Download file #nosec G107 - URL is validated to be from the registry
+	resp, err := githubClient.Do(req)
 	if err != nil {
 		os.Remove(tmpFile.Name())
 		return "", err
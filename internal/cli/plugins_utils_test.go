@@ -1,12 +1,18 @@
 package cli
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/pluginregistry"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestIsGitHubURL(t *testing.T) {
+func TestResolveGitHubSource(t *testing.T) {
 	tests := []struct {
 		name     string
 		source   string
@@ -61,8 +67,8 @@ func TestIsGitHubURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isGitHubURL(tt.source)
-			assert.Equal(t, tt.expected, result, "isGitHubURL(%q) = %v, want %v", tt.source, result, tt.expected)
+			_, _, result := pluginregistry.Resolve(tt.source, nil)
+			assert.Equal(t, tt.expected, result, "Resolve(%q) = %v, want %v", tt.source, result, tt.expected)
 		})
 	}
 }
@@ -198,3 +204,47 @@ func TestIsValidGitHubDownloadURL(t *testing.T) {
 		})
 	}
 }
+
+func TestPrivateRegistryRelease(t *testing.T) {
+	t.Setenv("ACME_TOKEN", "s3cr3t")
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/platform/glide-plugin-deploy/releases/latest":
+			_, _ = w.Write([]byte(`{"tag_name":"v1.2.0","assets":[{"name":"glide-plugin-deploy-linux-amd64",` +
+				`"browser_download_url":"https://github.acme.com/download","url":"` + server.URL + `/assets/7"}]}`))
+		case "/assets/7":
+			assert.Equal(t, "application/octet-stream", r.Header.Get("Accept"))
+			_, _ = w.Write([]byte("binary"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	reg, err := pluginregistry.New(config.PluginRegistryConfig{
+		Name: "acme", Host: "github.acme.com", API: server.URL,
+		Auth: config.RegistryAuthConfig{Type: pluginregistry.AuthBearer, SecretEnv: "ACME_TOKEN"},
+	})
+	require.NoError(t, err)
+
+	release, err := getLatestRelease(reg, "platform/glide-plugin-deploy")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", release.TagName)
+	url := release.assetURL(reg, "glide-plugin-deploy-linux-amd64")
+	assert.Equal(t, server.URL+"/assets/7", url, "private registries download through the API")
+
+	path, err := downloadFile(reg, url)
+	require.NoError(t, err)
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(data))
+
+	_, err = downloadFile(reg, "https://evil.example.com/binary")
+	assert.ErrorContains(t, err, "must be from github.acme.com")
+}
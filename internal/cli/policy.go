@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/policy"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// ShellEscapeAnnotation marks commands that hand the user an arbitrary shell
+const ShellEscapeAnnotation = "shell_escape"
+
+// policyExemptCommands can never be restricted so users can always find out
+// what is available and why
+var policyExemptCommands = map[string]bool{
+	"help":                          true,
+	"version":                       true,
+	"explain":                       true,
+	"completion":                    true,
+	"no-help":                       true,
+	cobra.ShellCompRequestCmd:       true,
+	cobra.ShellCompNoDescRequestCmd: true,
+}
+
+// PolicyEnforcement enforces a project policy on every command. Restricted
+// commands, and every command below a restricted one, are hidden from help
+// and fail with a permission error when invoked.
+func PolicyEnforcement(pol *policy.Policy) Middleware {
+	return Middleware{
+		Name: "policy",
+		Applies: func(cmd *cobra.Command) bool {
+			return policyRestriction(cmd, pol) != nil
+		},
+		Setup: func(cmd *cobra.Command) {
+			cmd.Hidden = true
+			cmd.ValidArgsFunction = nil
+			if runFunc(cmd) == nil {
+				// Restricted command groups fail rather than show their help
+				err := policyRestriction(cmd, pol)
+				cmd.RunE = func(*cobra.Command, []string) error { return err }
+			}
+		},
+		Wrap: func(cmd *cobra.Command, _ RunFunc) RunFunc {
+			err := policyRestriction(cmd, pol)
+			return func(*cobra.Command, []string) error { return err }
+		},
+	}
+}
+
+// policyRestriction returns the error a command fails with under the
+// policy. Its ancestors' plugins and shell escapes are checked outermost
+// first; command rules are checked against the command's own path, which
+// they match with its ancestors, so "project *" permits "project down".
+// A command group permitting any of its subcommands stays available.
+func policyRestriction(cmd *cobra.Command, pol *policy.Policy) error {
+	if pol == nil {
+		return nil
+	}
+	var ancestors []*cobra.Command
+	for c := cmd.Parent(); c != nil && c.HasParent(); c = c.Parent() {
+		ancestors = append(ancestors, c)
+	}
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if err := ancestorViolation(ancestors[i], pol); err != nil {
+			return err
+		}
+	}
+
+	err := PolicyViolation(cmd, pol)
+	if err != nil && runFunc(cmd) == nil {
+		for _, child := range cmd.Commands() {
+			if policyRestriction(child, pol) == nil {
+				return nil
+			}
+		}
+	}
+	return err
+}
+
+// ancestorViolation returns the error the commands below cmd fail with
+// because of cmd's plugin or shell escape
+func ancestorViolation(cmd *cobra.Command, pol *policy.Policy) error {
+	commandPath := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if policyExemptCommands[strings.Fields(commandPath)[0]] {
+		return nil
+	}
+	if pluginName := cmd.Annotations["plugin"]; pluginName != "" && !pol.AllowsPlugin(pluginName) {
+		return policyError(pol, fmt.Sprintf("plugin '%s' is not permitted in this project", pluginName))
+	}
+	if isShellEscape(cmd) && !pol.AllowsShellEscapes() {
+		return policyError(pol, fmt.Sprintf("command '%s' runs arbitrary shell input, which is not permitted in this project", commandPath))
+	}
+	return nil
+}
+
+// PolicyViolation returns the error a command would fail with under the
+// policy, or nil when the command is permitted
+func PolicyViolation(cmd *cobra.Command, pol *policy.Policy) error {
+	if pol == nil {
+		return nil
+	}
+
+	commandPath := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if policyExemptCommands[strings.Fields(commandPath)[0]] {
+		return nil
+	}
+
+	if err := ancestorViolation(cmd, pol); err != nil {
+		return err
+	}
+
+	if !pol.AllowsCommand(commandPath) {
+		return policyError(pol, fmt.Sprintf("command '%s' is not permitted in this project", commandPath))
+	}
+
+	return nil
+}
+
+// isShellEscape reports whether a command runs arbitrary shell input
+func isShellEscape(cmd *cobra.Command) bool {
+	return cmd.Annotations["yaml_command"] == "true" ||
+		cmd.Annotations["interactive"] == "true" ||
+		cmd.Annotations[ShellEscapeAnnotation] == "true"
+}
+
+// policyError builds the permission error shown for restricted commands
+func policyError(pol *policy.Policy, message string) error {
+	return glideErrors.New(glideErrors.TypePermission, message,
+		glideErrors.WithExitCode(126),
+		glideErrors.WithContext("policy", pol.Path()),
+		glideErrors.WithSuggestions(
+			fmt.Sprintf("This repository restricts %s commands in %s", branding.CommandName, pol.Path()),
+			"Ask the repository's platform team if you need access",
+		),
+	)
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/policy"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPolicyTestRoot() *cobra.Command {
+	noop := func(*cobra.Command, []string) error { return nil }
+
+	root := &cobra.Command{Use: "glide"}
+	project := &cobra.Command{Use: "project"}
+	project.AddCommand(&cobra.Command{Use: "down", RunE: noop}, &cobra.Command{Use: "status", RunE: noop})
+	root.AddCommand(
+		&cobra.Command{Use: "help", RunE: noop},
+		&cobra.Command{Use: "self-update", RunE: noop},
+		&cobra.Command{Use: "test", RunE: noop, Annotations: map[string]string{"yaml_command": "true"}},
+		&cobra.Command{Use: "shell", RunE: noop, Annotations: map[string]string{"plugin": "docker", "interactive": "true"}},
+		&cobra.Command{Use: "deploy", RunE: noop, Annotations: map[string]string{"plugin": "kube"}},
+		project,
+	)
+	return root
+}
+
+func findPolicyTestCommand(t *testing.T, root *cobra.Command, args ...string) *cobra.Command {
+	t.Helper()
+	cmd, _, err := root.Find(args)
+	require.NoError(t, err)
+	return cmd
+}
+
+func TestPolicyEnforcement(t *testing.T) {
+	shellEscapes := false
+	pol := &policy.Policy{
+		Commands:     policy.Rules{Deny: []string{"self-update", "help", "project"}},
+		Plugins:      policy.Rules{Deny: []string{"kube"}},
+		ShellEscapes: &shellEscapes,
+	}
+
+	root := newPolicyTestRoot()
+	Chain{PolicyEnforcement(pol)}.Apply(root)
+
+	restricted := [][]string{{"self-update"}, {"test"}, {"shell"}, {"deploy"}, {"project", "down"}}
+	for _, args := range restricted {
+		cmd := findPolicyTestCommand(t, root, args...)
+		assert.True(t, cmd.Hidden, "%v should be hidden", args)
+
+		err := cmd.RunE(cmd, nil)
+		require.Error(t, err, "%v should be refused", args)
+		assert.True(t, glideErrors.Is(err, glideErrors.TypePermission))
+	}
+
+	// help is exempt even when denied
+	help := findPolicyTestCommand(t, root, "help")
+	assert.False(t, help.Hidden)
+	assert.NoError(t, help.RunE(help, nil))
+}
+
+func TestPolicyEnforcement_NilPolicy(t *testing.T) {
+	root := newPolicyTestRoot()
+	Chain{PolicyEnforcement(nil)}.Apply(root)
+
+	cmd := findPolicyTestCommand(t, root, "self-update")
+	assert.False(t, cmd.Hidden)
+	assert.NoError(t, cmd.RunE(cmd, nil))
+}
+
+func TestPolicyViolation_AllowList(t *testing.T) {
+	pol := &policy.Policy{Commands: policy.Rules{Allow: []string{"test", "project"}}}
+	root := newPolicyTestRoot()
+
+	assert.NoError(t, PolicyViolation(findPolicyTestCommand(t, root, "test"), pol))
+	assert.NoError(t, PolicyViolation(findPolicyTestCommand(t, root, "project", "down"), pol))
+	assert.Error(t, PolicyViolation(findPolicyTestCommand(t, root, "deploy"), pol))
+}
+
+func TestPolicyEnforcement_AllowSubcommands(t *testing.T) {
+	// The documented example
+	pol := &policy.Policy{Commands: policy.Rules{Allow: []string{"up", "down", "status", "logs", "project *"}}}
+	root := newPolicyTestRoot()
+	Chain{PolicyEnforcement(pol)}.Apply(root)
+
+	for _, args := range [][]string{{"project", "down"}, {"project", "status"}} {
+		cmd := findPolicyTestCommand(t, root, args...)
+		assert.False(t, cmd.Hidden, "%v should be permitted", args)
+		assert.NoError(t, cmd.RunE(cmd, nil))
+	}
+	assert.False(t, findPolicyTestCommand(t, root, "project").Hidden, "a group with permitted subcommands stays visible")
+
+	deploy := findPolicyTestCommand(t, root, "deploy")
+	assert.True(t, deploy.Hidden)
+	assert.Error(t, deploy.RunE(deploy, nil))
+}
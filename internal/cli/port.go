@@ -0,0 +1,445 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// portDialTimeout bounds each host→container connection attempt
+const portDialTimeout = 2 * time.Second
+
+// Port statuses of `glide port`
+const (
+	PortPublished    = "published"
+	PortRemapped     = "remapped"
+	PortNotPublished = "not published"
+	PortNotRunning   = "not running"
+)
+
+var (
+	// dialHost and probeTCP are replaced in tests
+	dialHost = func(address string) error {
+		conn, err := net.DialTimeout("tcp", address, portDialTimeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	probeTCP = docker.ProbeTCP
+)
+
+// PortEntry is a container port of a service and where it is published
+type PortEntry struct {
+	Service   string `json:"service" yaml:"service"`
+	Container string `json:"container,omitempty" yaml:"container,omitempty"`
+	// ContainerPort is the port inside the container, e.g. 80/tcp
+	ContainerPort string `json:"container_port" yaml:"container_port"`
+	// HostAddress is where Docker publishes the port, e.g. 0.0.0.0:8080
+	HostAddress string `json:"host_address,omitempty" yaml:"host_address,omitempty"`
+	// Declared is the host port the compose files ask for, if any
+	Declared string `json:"declared,omitempty" yaml:"declared,omitempty"`
+	Status   string `json:"status" yaml:"status"`
+	// Reachable is set by --check: whether the host address accepts
+	// connections
+	Reachable *bool  `json:"reachable,omitempty" yaml:"reachable,omitempty"`
+	CheckErr  string `json:"check_error,omitempty" yaml:"check_error,omitempty"`
+}
+
+// ConnectivityCheck is a connection attempt from one service's container
+// to another service on the compose network
+type ConnectivityCheck struct {
+	From      string `json:"from" yaml:"from"`
+	To        string `json:"to" yaml:"to"`
+	Reachable bool   `json:"reachable" yaml:"reachable"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// PortReport is the output of `glide port`
+type PortReport struct {
+	Ports  []PortEntry         `json:"ports" yaml:"ports"`
+	Checks []ConnectivityCheck `json:"checks,omitempty" yaml:"checks,omitempty"`
+}
+
+// PortCommand shows the published ports of the current worktree and checks
+// their connectivity
+type PortCommand struct {
+	ctx *glideContext.ProjectContext
+	cfg *config.Config
+
+	check bool
+	from  string
+}
+
+// NewPortCommand creates the port command
+func NewPortCommand(ctx *glideContext.ProjectContext, cfg *config.Config) *cobra.Command {
+	pc := &PortCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "port [service]",
+		Short: "Show the published ports of this worktree and check connectivity",
+		Long: `Show the ports the services of the current project, or of the current worktree
+in multi-worktree mode, publish on the host, as Docker reports them, next to
+the host ports the compose files declare. A port Docker published elsewhere
+than declared is shown as remapped.
+
+--check connects to every published TCP port from the host. --from connects
+from a service's container to the ports of the other services by service
+name, over the compose network. Failed checks make the command fail.
+
+Examples:
+  glide port                    # Every service
+  glide port php                # One service
+  glide port --check            # Is each published port accepting connections?
+  glide port --from php         # Can php reach the other services?
+  glide port --format json`,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service := ""
+			if len(args) == 1 {
+				service = args[0]
+			}
+			return pc.Execute(service)
+		},
+	}
+
+	cmd.Flags().BoolVar(&pc.check, "check", false, "Connect to each published port from the host")
+	cmd.Flags().StringVar(&pc.from, "from", "", "Connect from this service's container to the other services")
+
+	return cmd
+}
+
+// Execute shows the ports and runs the requested checks
+func (pc *PortCommand) Execute(service string) error {
+	if pc.ctx == nil || pc.ctx.ProjectRoot == "" {
+		return glideErrors.New(glideErrors.TypeMissing, "not in a project",
+			glideErrors.WithSuggestions("Run this command from inside your project directory"),
+		)
+	}
+
+	dir, owner := worktreeOwnership(pc.ctx)
+	project, err := loadComposeProject(dir, "*")
+	if err != nil {
+		return err
+	}
+	if service != "" {
+		if _, ok := project.Services[service]; !ok {
+			return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("unknown service %s", service),
+				glideErrors.WithSuggestions("Services: "+strings.Join(project.ServiceNames(), ", ")),
+			)
+		}
+	}
+	containers, err := listContainers()
+	if err != nil {
+		return err
+	}
+	containers = worktreeContainers(containers, owner.Project, owner.Worktree, dir)
+
+	report := PortReport{Ports: portEntries(project, containers, service)}
+	if pc.check {
+		checkHostPorts(report.Ports)
+	}
+	if pc.from != "" {
+		if report.Checks, err = pc.checkServices(project, service); err != nil {
+			return err
+		}
+	}
+
+	if output.GetFormat().IsStructured() {
+		if err := output.Display(report); err != nil {
+			return err
+		}
+	} else {
+		renderPortReport(report, pc.check)
+	}
+	return portCheckFailures(report)
+}
+
+// checkServices connects from the --from service's container to the ports
+// of the other services, or only to service when it is set
+func (pc *PortCommand) checkServices(project *docker.ComposeProject, service string) ([]ConnectivityCheck, error) {
+	if _, ok := project.Services[pc.from]; !ok {
+		return nil, glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("unknown service %s", pc.from),
+			glideErrors.WithSuggestions("Services: "+strings.Join(project.ServiceNames(), ", ")),
+		)
+	}
+	container, err := serviceContainer(pc.ctx, pc.from)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []ConnectivityCheck{}
+	for _, name := range project.ServiceNames() {
+		if name == pc.from || (service != "" && name != service) {
+			continue
+		}
+		for _, port := range servicePorts(project.Services[name]) {
+			check := ConnectivityCheck{From: pc.from, To: name + ":" + strconv.Itoa(port), Reachable: true}
+			if err := probeTCP(container, name, port); err != nil {
+				check.Reachable = false
+				check.Error = "connection failed"
+				if errors.Is(err, docker.ErrNoProbeTool) {
+					check.Error = err.Error()
+				}
+			}
+			checks = append(checks, check)
+		}
+	}
+	return checks, nil
+}
+
+// portEntries lists the ports the running containers publish and the
+// declared ports that are not published, sorted by service and port
+func portEntries(project *docker.ComposeProject, containers []docker.ProjectContainer, service string) []PortEntry {
+	entries := []PortEntry{}
+	running := make(map[string]bool)
+	published := make(map[string]bool)
+
+	for _, c := range containers {
+		if c.State != "running" || (service != "" && c.Service != service) {
+			continue
+		}
+		running[c.Service] = true
+		declared := project.Services[c.Service].Ports
+		for _, b := range c.Ports {
+			entry := PortEntry{
+				Service:       c.Service,
+				Container:     c.Name,
+				ContainerPort: b.ContainerPort,
+				HostAddress:   net.JoinHostPort(b.HostIP, b.HostPort),
+				Status:        PortPublished,
+			}
+			if p, ok := declaredPort(declared, b.ContainerPort); ok {
+				entry.Declared = p.Published
+				published[c.Service+" "+b.ContainerPort] = true
+				if !publishedMatches(p.Published, b.HostPort) {
+					entry.Status = PortRemapped
+				}
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	for _, name := range project.ServiceNames() {
+		if service != "" && name != service {
+			continue
+		}
+		for _, p := range project.Services[name].Ports {
+			containerPort := fmt.Sprintf("%d/%s", p.Target, portProtocol(p.Protocol))
+			if published[name+" "+containerPort] {
+				continue
+			}
+			status := PortNotRunning
+			if running[name] {
+				status = PortNotPublished
+			}
+			entries = append(entries, PortEntry{Service: name, ContainerPort: containerPort, Declared: p.Published, Status: status})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Service != entries[j].Service {
+			return entries[i].Service < entries[j].Service
+		}
+		if entries[i].ContainerPort != entries[j].ContainerPort {
+			return entries[i].ContainerPort < entries[j].ContainerPort
+		}
+		return entries[i].Container < entries[j].Container
+	})
+	return entries
+}
+
+// declaredPort finds the compose port config of a container port, e.g.
+// 80/tcp
+func declaredPort(ports []docker.ComposePortConfig, containerPort string) (docker.ComposePortConfig, bool) {
+	for _, p := range ports {
+		if fmt.Sprintf("%d/%s", p.Target, portProtocol(p.Protocol)) == containerPort {
+			return p, true
+		}
+	}
+	return docker.ComposePortConfig{}, false
+}
+
+// publishedMatches reports whether hostPort satisfies a declared published
+// port: any port when none is declared, the port, or one of a range such
+// as 8000-8010
+func publishedMatches(declared, hostPort string) bool {
+	if declared == "" || declared == hostPort {
+		return true
+	}
+	low, high, ok := strings.Cut(declared, "-")
+	if !ok {
+		return false
+	}
+	port, err := strconv.Atoi(hostPort)
+	if err != nil {
+		return false
+	}
+	lo, errLo := strconv.Atoi(low)
+	hi, errHi := strconv.Atoi(high)
+	return errLo == nil && errHi == nil && port >= lo && port <= hi
+}
+
+// portProtocol defaults a compose port's protocol to tcp
+func portProtocol(protocol string) string {
+	if protocol == "" {
+		return "tcp"
+	}
+	return protocol
+}
+
+// servicePorts returns the TCP ports a service listens on for other
+// services: its published targets and exposed ports, sorted
+func servicePorts(svc docker.ComposeService) []int {
+	seen := make(map[int]bool)
+	var ports []int
+	add := func(port int) {
+		if port > 0 && !seen[port] {
+			seen[port] = true
+			ports = append(ports, port)
+		}
+	}
+	for _, p := range svc.Ports {
+		if portProtocol(p.Protocol) == "tcp" {
+			add(p.Target)
+		}
+	}
+	for _, e := range svc.Expose {
+		port, protocol, _ := strings.Cut(e, "/")
+		if protocol != "" && protocol != "tcp" {
+			continue
+		}
+		n, _ := strconv.Atoi(port)
+		add(n)
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+// checkHostPorts connects to each published TCP port from the host
+func checkHostPorts(entries []PortEntry) {
+	for i := range entries {
+		e := &entries[i]
+		if e.HostAddress == "" || !strings.HasSuffix(e.ContainerPort, "/tcp") {
+			continue
+		}
+		reachable := true
+		if err := dialHost(dialAddress(e.HostAddress)); err != nil {
+			reachable = false
+			e.CheckErr = err.Error()
+		}
+		e.Reachable = &reachable
+	}
+}
+
+// dialAddress turns a wildcard host address into the loopback address of
+// its family
+func dialAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	switch host {
+	case "", "0.0.0.0":
+		host = "127.0.0.1"
+	case "::":
+		host = "::1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// portCheckFailures returns an error counting the failed checks, if any
+func portCheckFailures(report PortReport) error {
+	failed, total := 0, 0
+	for _, e := range report.Ports {
+		if e.Reachable != nil {
+			total++
+			if !*e.Reachable {
+				failed++
+			}
+		}
+	}
+	for _, c := range report.Checks {
+		total++
+		if !c.Reachable {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return glideErrors.New(glideErrors.TypeConnection, fmt.Sprintf("%d of %d connectivity checks failed", failed, total),
+		glideErrors.WithExitCode(1),
+		glideErrors.WithSuggestions(
+			fmt.Sprintf("Check that the services are up with: %s ps", branding.CommandName),
+			"A service listening on 127.0.0.1 inside its container refuses connections from outside it; bind to 0.0.0.0",
+		),
+	)
+}
+
+// renderPortReport prints the ports, and the connectivity checks if any
+func renderPortReport(report PortReport, checked bool) {
+	if len(report.Ports) == 0 {
+		output.Info("No ports are declared or published")
+	} else {
+		headers := []string{"SERVICE", "PORT", "HOST", "DECLARED", "STATUS"}
+		if checked {
+			headers = append(headers, "CHECK")
+		}
+		table := output.NewTable(headers...)
+		separator := make([]string, len(headers))
+		for i, h := range headers {
+			separator[i] = strings.Repeat("-", len(h))
+		}
+		table.AddRow(separator...)
+		for _, e := range report.Ports {
+			row := []string{e.Service, e.ContainerPort, e.HostAddress, e.Declared, e.Status}
+			if checked {
+				row = append(row, checkResult(e.Reachable, e.CheckErr))
+			}
+			table.AddRow(row...)
+		}
+		_ = output.PrintTable(table)
+	}
+
+	if len(report.Checks) == 0 {
+		return
+	}
+	output.Println("")
+	table := output.NewTable("FROM", "TO", "CHECK")
+	table.AddRow("----", "--", "-----")
+	for _, c := range report.Checks {
+		reachable := c.Reachable
+		table.AddRow(c.From, c.To, checkResult(&reachable, c.Error))
+	}
+	_ = output.PrintTable(table)
+}
+
+// checkResult formats the outcome of a check
+func checkResult(reachable *bool, errMsg string) string {
+	switch {
+	case reachable == nil:
+		return ""
+	case *reachable:
+		return "✓ ok"
+	default:
+		return "✗ " + errMsg
+	}
+}
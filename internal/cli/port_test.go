@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPortProject() *docker.ComposeProject {
+	return &docker.ComposeProject{
+		Name: "myapp",
+		Services: map[string]docker.ComposeService{
+			"nginx": {Ports: []docker.ComposePortConfig{{Target: 80, Published: "8080"}, {Target: 443, Published: "8443"}}},
+			"mysql": {Ports: []docker.ComposePortConfig{{Target: 3306, Published: "3306"}}},
+			"php":   {Expose: []string{"9000"}},
+			"redis": {Ports: []docker.ComposePortConfig{{Target: 6379, Published: "6000-6010"}}},
+		},
+	}
+}
+
+func testPortContainers() []docker.ProjectContainer {
+	return []docker.ProjectContainer{
+		{Name: "myapp-nginx-1", Service: "nginx", State: "running", Ports: []docker.PortBinding{
+			{HostIP: "0.0.0.0", HostPort: "8081", ContainerPort: "80/tcp"},
+		}},
+		{Name: "myapp-redis-1", Service: "redis", State: "running", Ports: []docker.PortBinding{
+			{HostIP: "127.0.0.1", HostPort: "6003", ContainerPort: "6379/tcp"},
+		}},
+		{Name: "myapp-php-1", Service: "php", State: "running", WorkingDir: "/src/myapp"},
+		{Name: "myapp-mysql-1", Service: "mysql", State: "exited"},
+	}
+}
+
+func TestPortEntries(t *testing.T) {
+	entries := portEntries(testPortProject(), testPortContainers(), "")
+
+	assert.Equal(t, []PortEntry{
+		{Service: "mysql", ContainerPort: "3306/tcp", Declared: "3306", Status: PortNotRunning},
+		{Service: "nginx", ContainerPort: "443/tcp", Declared: "8443", Status: PortNotPublished},
+		{Service: "nginx", Container: "myapp-nginx-1", ContainerPort: "80/tcp", HostAddress: "0.0.0.0:8081", Declared: "8080", Status: PortRemapped},
+		{Service: "redis", Container: "myapp-redis-1", ContainerPort: "6379/tcp", HostAddress: "127.0.0.1:6003", Declared: "6000-6010", Status: PortPublished},
+	}, entries)
+
+	entries = portEntries(testPortProject(), testPortContainers(), "redis")
+	require.Len(t, entries, 1)
+	assert.Equal(t, "redis", entries[0].Service)
+
+	assert.Equal(t, []PortEntry{}, portEntries(&docker.ComposeProject{}, nil, ""))
+}
+
+func TestPublishedMatches(t *testing.T) {
+	assert.True(t, publishedMatches("", "32768"))
+	assert.True(t, publishedMatches("8080", "8080"))
+	assert.False(t, publishedMatches("8080", "8081"))
+	assert.True(t, publishedMatches("8000-8010", "8005"))
+	assert.False(t, publishedMatches("8000-8010", "8011"))
+}
+
+func TestServicePorts(t *testing.T) {
+	svc := docker.ComposeService{
+		Ports:  []docker.ComposePortConfig{{Target: 80}, {Target: 53, Protocol: "udp"}, {Target: 443}},
+		Expose: []string{"9000", "80", "514/udp"},
+	}
+	assert.Equal(t, []int{80, 443, 9000}, servicePorts(svc))
+}
+
+func TestDialAddress(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:8080", dialAddress("0.0.0.0:8080"))
+	assert.Equal(t, "[::1]:8080", dialAddress("[::]:8080"))
+	assert.Equal(t, "192.168.1.5:8080", dialAddress("192.168.1.5:8080"))
+}
+
+func TestCheckHostPorts(t *testing.T) {
+	original := dialHost
+	t.Cleanup(func() { dialHost = original })
+	var dialed []string
+	dialHost = func(address string) error {
+		dialed = append(dialed, address)
+		if address == "127.0.0.1:6003" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	entries := portEntries(testPortProject(), testPortContainers(), "")
+	checkHostPorts(entries)
+
+	assert.Equal(t, []string{"127.0.0.1:8081", "127.0.0.1:6003"}, dialed)
+	assert.Nil(t, entries[0].Reachable, "unpublished ports are not checked")
+	require.NotNil(t, entries[2].Reachable)
+	assert.True(t, *entries[2].Reachable)
+	require.NotNil(t, entries[3].Reachable)
+	assert.False(t, *entries[3].Reachable)
+	assert.Equal(t, "connection refused", entries[3].CheckErr)
+
+	err := portCheckFailures(PortReport{Ports: entries})
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeConnection))
+	assert.Contains(t, err.Error(), "1 of 2 connectivity checks failed")
+}
+
+func TestPortCommand_CheckServices(t *testing.T) {
+	stubListContainers(t, testPortContainers()...)
+	original := probeTCP
+	t.Cleanup(func() { probeTCP = original })
+	probeTCP = func(container, host string, port int) error {
+		switch host {
+		case "mysql":
+			return errors.New("exit status 1")
+		case "redis":
+			return docker.ErrNoProbeTool
+		}
+		return nil
+	}
+
+	pc := &PortCommand{ctx: &glideContext.ProjectContext{ProjectRoot: "/src/myapp"}, from: "php"}
+	checks, err := pc.checkServices(testPortProject(), "")
+	require.NoError(t, err)
+	assert.Equal(t, []ConnectivityCheck{
+		{From: "php", To: "mysql:3306", Error: "connection failed"},
+		{From: "php", To: "nginx:80", Reachable: true},
+		{From: "php", To: "nginx:443", Reachable: true},
+		{From: "php", To: "redis:6379", Error: docker.ErrNoProbeTool.Error()},
+	}, checks)
+
+	pc.from = "missing"
+	_, err = pc.checkServices(testPortProject(), "")
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid))
+}
+
+func TestPortCommand_OutsideProject(t *testing.T) {
+	cmd := NewPortCommand(&glideContext.ProjectContext{}, nil)
+	cmd.SetArgs(nil)
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeMissing))
+}
@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/progress"
+	"github.com/spf13/cobra"
+)
+
+// defaultPrefetchParallelism is the number of images pulled at once
+const defaultPrefetchParallelism = 4
+
+// PrefetchResult is the structured output of a single image pull
+type PrefetchResult struct {
+	Image    string   `json:"image" yaml:"image"`
+	Seconds  float64  `json:"seconds" yaml:"seconds"`
+	Error    string   `json:"error,omitempty" yaml:"error,omitempty"`
+	Pulled   bool     `json:"pulled,omitempty" yaml:"pulled,omitempty"`
+	Warmed   bool     `json:"warmed,omitempty" yaml:"warmed,omitempty"`
+	Services []string `json:"services,omitempty" yaml:"services,omitempty"`
+}
+
+// PrefetchCommand pulls a project's images ahead of time
+type PrefetchCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+
+	profiles    []string
+	parallelism int
+	warmCache   bool
+}
+
+// NewPrefetchCommand creates the prefetch command
+func NewPrefetchCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	pc := &PrefetchCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "prefetch",
+		Short: "Pull the project's container images ahead of time",
+		Long: `Pull every image referenced by the project's compose files in parallel,
+so the first 'glide up' of the day doesn't stall on downloads.
+
+Services behind a compose profile are only included when the profile is
+selected with --profile. Services built from a Dockerfile are skipped unless
+--warm-cache is given, which builds them to fill the BuildKit cache.
+
+Examples:
+  glide prefetch                        # Pull images for the default services
+  glide prefetch --profile debug        # Include services in the debug profile
+  glide prefetch --profile '*'          # Include every profile
+  glide prefetch --warm-cache           # Also build local images into the cache
+  glide prefetch --parallel 8           # Pull eight images at a time`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pc.Execute(cmd)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&pc.profiles, "profile", nil, "Compose profiles to include ('*' for all)")
+	cmd.Flags().IntVarP(&pc.parallelism, "parallel", "j", defaultPrefetchParallelism, "Number of images to pull at once")
+	cmd.Flags().BoolVar(&pc.warmCache, "warm-cache", false, "Build services with a build section to warm the BuildKit cache")
+
+	return cmd
+}
+
+// Execute pulls the images and, optionally, warms the build cache
+func (pc *PrefetchCommand) Execute(cmd *cobra.Command) error {
+	dir, err := composeProjectDir(pc.ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := docker.LoadComposeProject(dir, pc.profiles...)
+	if err != nil {
+		return err
+	}
+
+	images := project.Images()
+	buildServices := project.BuildServices()
+	if len(images) == 0 && (!pc.warmCache || len(buildServices) == 0) {
+		output.Info("Nothing to prefetch: no services reference a pullable image")
+		return nil
+	}
+
+	structured := output.GetFormat().IsStructured()
+
+	var pullResults []docker.PullResult
+	if len(images) > 0 {
+		pullResults = pc.pull(images, structured)
+	}
+
+	results := make([]PrefetchResult, 0, len(pullResults)+1)
+	failed := 0
+	for _, r := range pullResults {
+		result := PrefetchResult{Image: r.Image, Seconds: r.Duration.Round(time.Millisecond).Seconds(), Pulled: r.Err == nil}
+		if r.Err != nil {
+			result.Error = r.Err.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if pc.warmCache && len(buildServices) > 0 {
+		result := pc.warm(project, buildServices, structured)
+		if result.Error != "" {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if structured {
+		if err := output.Display(results); err != nil {
+			return err
+		}
+	} else {
+		showPrefetchResults(results)
+	}
+
+	if failed > 0 {
+		return glideErrors.NewDockerError(fmt.Sprintf("%d of %d prefetch step(s) failed", failed, len(results)),
+			glideErrors.WithSuggestions(
+				"Check your network connection and registry credentials",
+				"Retry the failed images with: docker pull <image>",
+			),
+		)
+	}
+	return nil
+}
+
+// pull pulls images in parallel, showing a bar for overall progress and a
+// spinner per worker
+func (pc *PrefetchCommand) pull(images []string, structured bool) []docker.PullResult {
+	if structured {
+		return docker.PullImages(images, pc.parallelism, nil, nil)
+	}
+
+	workers := pc.parallelism
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(images) {
+		workers = len(images)
+	}
+
+	multi := progress.NewMulti()
+	bar := multi.AddBar(len(images), "Pulling images")
+	spinners := make([]*progress.Spinner, workers)
+	for i := range spinners {
+		spinners[i] = multi.AddSpinner("waiting")
+	}
+	multi.Start()
+	defer multi.Stop()
+
+	var mu sync.Mutex
+	done := 0
+	return docker.PullImages(images, workers,
+		func(worker int, image string) {
+			multi.UpdateSpinner(spinners[worker], image)
+		},
+		func(worker int, result docker.PullResult) {
+			mu.Lock()
+			done++
+			current := done
+			mu.Unlock()
+			multi.UpdateBar(bar, current)
+			multi.UpdateSpinner(spinners[worker], "waiting")
+		},
+	)
+}
+
+// warm builds the project's local images so their layers are in the
+// BuildKit cache
+func (pc *PrefetchCommand) warm(project *docker.ComposeProject, services []string, structured bool) PrefetchResult {
+	result := PrefetchResult{Image: "(build cache)", Services: services}
+
+	args := []string{"compose"}
+	for _, profile := range pc.profiles {
+		args = append(args, "--profile", profile)
+	}
+	args = append(args, "build", "--pull")
+	args = append(args, services...)
+
+	build := exec.Command("docker", args...)
+	build.Dir = project.Dir
+	if !structured {
+		build.Stdout = os.Stdout
+		build.Stderr = os.Stderr
+		output.Info("🔥 Warming the build cache for: %s", strings.Join(services, ", "))
+	}
+
+	start := time.Now()
+	err := build.Run()
+	result.Seconds = time.Since(start).Round(time.Millisecond).Seconds()
+	result.Warmed = err == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// showPrefetchResults prints one line per pulled image
+func showPrefetchResults(results []PrefetchResult) {
+	for _, r := range results {
+		if r.Error != "" {
+			output.Error("✗ %s: %s", r.Image, r.Error)
+			continue
+		}
+		output.Success("✓ %s (%.1fs)", r.Image, r.Seconds)
+	}
+}
@@ -108,6 +108,7 @@ This command stops containers in:
 Options:
   --remove-orphans  Remove orphaned containers
   --volumes         Remove volumes (WARNING: deletes data)
+  --force           Skip the confirmation prompt for --volumes
 
 Examples:
   glide p down                    # Stop all containers
@@ -120,6 +121,8 @@ Examples:
 	cmd.Flags().Bool("remove-orphans", false, "Remove orphaned containers")
 	cmd.Flags().Bool("volumes", false, "Remove volumes (WARNING: deletes data)")
 
+	MarkHighlyDestructive(cmd, pc.downTargets, "volumes")
+
 	return cmd
 }
 
@@ -175,6 +178,7 @@ Options:
   --images       Also remove dangling images
   --all          Clean everything (containers, volumes, images)
   --dry-run      Show what would be cleaned without doing it
+  --force        Skip the confirmation prompt
 
 Examples:
   glide p clean                    # Interactive cleanup
@@ -191,6 +195,11 @@ Examples:
 	cmd.Flags().Bool("all", false, "Clean everything")
 	cmd.Flags().Bool("dry-run", false, "Show what would be cleaned")
 
+	// Without flags the command asks interactively what to clean
+	MarkDestructive(cmd, pc.cleanTargets, "orphaned", "volumes", "images", "all")
+	// Removing volumes cannot be undone, so it takes the project name
+	cmd.Annotations[TypedConfirmAnnotation] = "volumes,all"
+
 	return cmd
 }
 
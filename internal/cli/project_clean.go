@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"fmt"
 	"os/exec"
 	"strings"
 
@@ -66,6 +65,12 @@ func (c *ProjectCleanCommand) Execute(cmd *cobra.Command, args []string) error {
 			output.Info("No cleanup options selected. Exiting.")
 			return nil
 		}
+		if !dryRun {
+			proceed, err := c.confirmSelection(cmd, args, orphaned, volumes, images)
+			if !proceed {
+				return err
+			}
+		}
 	}
 
 	// Display header
@@ -103,27 +108,16 @@ func (c *ProjectCleanCommand) Execute(cmd *cobra.Command, args []string) error {
 	}
 
 	// Clean unused volumes
+	// Volume removal has already been confirmed, either by the destructive
+	// command guard or by the interactive prompt
 	if volumes {
-		output.Warning("⚠️  Warning: Cleaning volumes will delete data!")
-		if !dryRun {
-			output.Printf("Are you sure you want to continue? [y/N]: ")
-			var response string
-			fmt.Scanln(&response)
-			if strings.ToLower(response) != "y" {
-				output.Info("Skipping volume cleanup.")
-				volumes = false
-			}
-		}
-
-		if volumes {
-			output.Printf("🔍 Checking for unused volumes... ")
-			if err := c.cleanUnusedVolumes(dryRun, stats); err != nil {
-				output.Error("Failed: %v", err)
-			} else if stats.UnusedVolumes > 0 {
-				output.Success("Cleaned %d", stats.UnusedVolumes)
-			} else {
-				output.Success("None found")
-			}
+		output.Printf("🔍 Checking for unused volumes... ")
+		if err := c.cleanUnusedVolumes(dryRun, stats); err != nil {
+			output.Error("Failed: %v", err)
+		} else if stats.UnusedVolumes > 0 {
+			output.Success("Cleaned %d", stats.UnusedVolumes)
+		} else {
+			output.Success("None found")
 		}
 	}
 
@@ -172,19 +166,30 @@ func (c *ProjectCleanCommand) promptForCleanup() (orphaned, volumes, images bool
 		return false, false, false
 	}
 
-	// For volumes, use destructive confirmation since it can cause data loss
-	confirmed, err := prompt.ConfirmDestructive("remove unused volumes")
+	volumes, err = prompt.Confirm("Remove unused volumes? This cannot be undone", false)
 	if err != nil {
 		return false, false, false
 	}
-	if confirmed {
-		volumes = true
-	}
 
 	output.Println()
 	return
 }
 
+// confirmSelection sets the flags for what the user chose to clean and
+// confirms them through the destructive guard, so an interactive volume
+// prune takes the typed confirmation and is audited like --volumes
+func (c *ProjectCleanCommand) confirmSelection(cmd *cobra.Command, args []string, orphaned, volumes, images bool) (bool, error) {
+	for flag, selected := range map[string]bool{"orphaned": orphaned, "volumes": volumes, "images": images} {
+		if !selected || cmd.Flags().Lookup(flag) == nil {
+			continue
+		}
+		if err := cmd.Flags().Set(flag, "true"); err != nil {
+			return false, err
+		}
+	}
+	return confirmSelectedDestructive(cmd, args)
+}
+
 // cleanOrphanedContainers removes orphaned containers
 func (c *ProjectCleanCommand) cleanOrphanedContainers(dryRun bool, stats *CleanupStats) error {
 	// Find stopped containers that match our project name pattern
@@ -370,3 +375,34 @@ func (c *ProjectCleanCommand) displayCleanupSummary(stats *CleanupStats) {
 		output.Info("  Nothing to clean")
 	}
 }
+
+// cleanTargets describes what `project clean` deletes for the given flags
+func (pc *ProjectCommand) cleanTargets(cmd *cobra.Command, args []string) []string {
+	orphaned, _ := cmd.Flags().GetBool("orphaned")
+	volumes, _ := cmd.Flags().GetBool("volumes")
+	images, _ := cmd.Flags().GetBool("images")
+	if all, _ := cmd.Flags().GetBool("all"); all {
+		orphaned, volumes, images = true, true, true
+	}
+
+	var targets []string
+	if orphaned {
+		targets = append(targets, "Exited project containers")
+	}
+	if images {
+		targets = append(targets, "Dangling Docker images")
+	}
+	if volumes {
+		listCmd := exec.Command("docker", "volume", "ls", "-qf", "dangling=true")
+		listOutput, err := listCmd.Output()
+		if err != nil {
+			targets = append(targets, "All unused Docker volumes")
+		}
+		for _, volume := range strings.Fields(string(listOutput)) {
+			targets = append(targets, "Docker volume "+volume)
+		}
+	}
+	targets = append(targets, "Unused Docker networks")
+
+	return targets
+}
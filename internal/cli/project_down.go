@@ -42,18 +42,7 @@ func (c *ProjectDownCommand) Execute(cmd *cobra.Command, args []string) error {
 	removeOrphans, _ := cmd.Flags().GetBool("remove-orphans")
 	removeVolumes, _ := cmd.Flags().GetBool("volumes")
 
-	// Confirm if removing volumes
-	if removeVolumes {
-		output.Warning("⚠️  Warning: --volumes will delete all Docker volumes (data loss!)")
-		output.Printf("Are you sure you want to continue? [y/N]: ")
-
-		var response string
-		fmt.Scanln(&response)
-		if strings.ToLower(response) != "y" {
-			output.Info("Aborted.")
-			return nil
-		}
-	}
+	// Removing volumes is confirmed by the destructive command guard
 
 	// Display header
 	output.Info("🛑 Stopping Docker Containers Across All Worktrees")
@@ -113,6 +102,19 @@ func (c *ProjectDownCommand) Execute(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Stop the services the worktrees share once they no longer use them
+	if len(sharedServices(c.ctx)) > 0 {
+		output.Printf("📍 Shared services: ")
+		if err := stopSharedServices(c.ctx, removeOrphans, removeVolumes); err != nil {
+			output.Error("❌ Failed")
+			errors = append(errors, fmt.Sprintf("shared services: %v", err))
+			failureCount++
+		} else {
+			output.Success("✅ Stopped")
+			successCount++
+		}
+	}
+
 	// Summary
 	output.Println()
 	output.Println(strings.Repeat("-", 50))
@@ -195,3 +197,30 @@ func (c *ProjectDownCommand) stopContainers(dir string, removeOrphans bool, remo
 	spinner.Success("Stopped")
 	return nil
 }
+
+// downTargets describes what `project down --volumes` deletes
+func (pc *ProjectCommand) downTargets(cmd *cobra.Command, args []string) []string {
+	if pc.ctx == nil || pc.ctx.ProjectRoot == "" {
+		return nil
+	}
+
+	var targets []string
+	vcsDir := filepath.Join(pc.ctx.ProjectRoot, "vcs")
+	if _, err := os.Stat(vcsDir); err == nil {
+		targets = append(targets, fmt.Sprintf("Docker volumes of the compose project in %s", vcsDir))
+	}
+
+	worktreesDir := filepath.Join(pc.ctx.ProjectRoot, "worktrees")
+	entries, _ := os.ReadDir(worktreesDir)
+	for _, entry := range entries {
+		worktreePath := filepath.Join(worktreesDir, entry.Name())
+		if _, err := os.Stat(filepath.Join(worktreePath, ".git")); entry.IsDir() && err == nil {
+			targets = append(targets, fmt.Sprintf("Docker volumes of the compose project in %s", worktreePath))
+		}
+	}
+	if len(sharedServices(pc.ctx)) > 0 {
+		targets = append(targets, fmt.Sprintf("Docker volumes of the shared services (compose project %s)", sharedProjectName(pc.ctx)))
+	}
+
+	return targets
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/glide-cli/glide/v3/internal/config"
 	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
 	"github.com/glide-cli/glide/v3/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +19,9 @@ import (
 type ProjectListCommand struct {
 	ctx *context.ProjectContext
 	cfg *config.Config
+
+	// containers are every compose container on the machine
+	containers []docker.ProjectContainer
 }
 
 // WorktreeInfo contains information about a worktree
@@ -57,6 +61,10 @@ func (c *ProjectListCommand) Execute(cmd *cobra.Command, args []string) error {
 		output.Println()
 	}
 
+	// List compose containers once; they are matched to worktrees by their
+	// ownership labels. Without Docker, no worktree has containers.
+	c.containers, _ = listContainers()
+
 	// Collect worktree information
 	worktrees := []WorktreeInfo{}
 
@@ -152,14 +160,11 @@ func (c *ProjectListCommand) getWorktreeInfo(path string, name string) *Worktree
 		info.IsClean = len(strings.TrimSpace(string(output))) == 0
 	}
 
-	// Check for Docker containers
-	composeFile := filepath.Join(path, "docker-compose.yml")
-	if _, err := os.Stat(composeFile); err == nil {
-		// Try to check container status
-		psCmd := exec.Command("docker", "compose", "-f", composeFile, "ps", "-q")
-		psCmd.Dir = path
-		if output, err := psCmd.Output(); err == nil {
-			info.HasContainers = len(strings.TrimSpace(string(output))) > 0
+	// Check for running Docker containers
+	for _, container := range worktreeContainers(c.containers, c.ctx.ProjectRoot, name, path) {
+		if container.State == "running" {
+			info.HasContainers = true
+			break
 		}
 	}
 
@@ -196,7 +201,7 @@ func (c *ProjectListCommand) displayTable(worktrees []WorktreeInfo) {
 		if w.LastCommit != "" {
 			output.Printf("   Commit: %s", w.LastCommit)
 			if !w.CommitDate.IsZero() {
-				output.Printf(" (%s)", c.formatRelativeTime(w.CommitDate))
+				output.Printf(" (%s)", output.Ago(w.CommitDate))
 			}
 			output.Println()
 		}
@@ -238,42 +243,3 @@ func (c *ProjectListCommand) displayJSON(worktrees []WorktreeInfo) {
 	}
 	output.Println("]")
 }
-
-// formatRelativeTime formats a time as relative to now
-func (c *ProjectListCommand) formatRelativeTime(t time.Time) string {
-	duration := time.Since(t)
-
-	if duration < time.Minute {
-		return "just now"
-	} else if duration < time.Hour {
-		minutes := int(duration.Minutes())
-		if minutes == 1 {
-			return "1 minute ago"
-		}
-		return fmt.Sprintf("%d minutes ago", minutes)
-	} else if duration < 24*time.Hour {
-		hours := int(duration.Hours())
-		if hours == 1 {
-			return "1 hour ago"
-		}
-		return fmt.Sprintf("%d hours ago", hours)
-	} else if duration < 30*24*time.Hour {
-		days := int(duration.Hours() / 24)
-		if days == 1 {
-			return "1 day ago"
-		}
-		return fmt.Sprintf("%d days ago", days)
-	} else if duration < 365*24*time.Hour {
-		months := int(duration.Hours() / 24 / 30)
-		if months == 1 {
-			return "1 month ago"
-		}
-		return fmt.Sprintf("%d months ago", months)
-	}
-
-	years := int(duration.Hours() / 24 / 365)
-	if years == 1 {
-		return "1 year ago"
-	}
-	return fmt.Sprintf("%d years ago", years)
-}
@@ -17,6 +17,10 @@ import (
 type ProjectStatusCommand struct {
 	ctx *context.ProjectContext
 	cfg *config.Config
+
+	// containers are every compose container on the machine
+	containers    []docker.ProjectContainer
+	containersErr error
 }
 
 // ExecuteProjectStatus is called from project.go
@@ -40,6 +44,10 @@ func (c *ProjectStatusCommand) Execute(cmd *cobra.Command, args []string) error
 	output.Println(strings.Repeat("=", 50))
 	output.Println()
 
+	// List compose containers once; they are matched to worktrees by their
+	// ownership labels
+	c.containers, c.containersErr = listContainers()
+
 	// Track if any containers are running
 	hasRunningContainers := false
 
@@ -108,80 +116,41 @@ func (c *ProjectStatusCommand) Execute(cmd *cobra.Command, args []string) error
 	return nil
 }
 
-// getDockerStatus checks Docker status for a directory
+// getDockerStatus summarizes the containers of a worktree
 func (c *ProjectStatusCommand) getDockerStatus(dir string, name string) (string, bool) {
-	// Create a context for this directory
-	dirCtx := &context.ProjectContext{
-		WorkingDir:      dir,
-		ProjectRoot:     c.ctx.ProjectRoot,
-		DevelopmentMode: c.ctx.DevelopmentMode,
-		DockerRunning:   c.ctx.DockerRunning,
+	if c.containersErr != nil {
+		return output.ErrorText("  ❌ Error checking status: %v\n", c.containersErr), false
 	}
 
-	// Resolve Docker compose files
-	resolver := docker.NewResolver(dirCtx)
-	if err := resolver.Resolve(); err != nil {
-		return output.WarningText("  ⚠️  No docker-compose.yml found\n"), false
+	runningCount := 0
+	stoppedCount := 0
+	for _, container := range worktreeContainers(c.containers, c.ctx.ProjectRoot, name, dir) {
+		if container.State == "running" {
+			runningCount++
+		} else {
+			stoppedCount++
+		}
 	}
 
-	// Get compose command
-	args := resolver.GetComposeCommand("ps", "--format", "table")
-
-	// Execute docker compose ps
-	cmd := exec.Command("docker", args...)
-	cmd.Dir = dir
-
-	cmdOutput, err := cmd.Output()
-	if err != nil {
-		return output.ErrorText("  ❌ Error checking status: %v\n", err), false
+	if runningCount == 0 && stoppedCount == 0 {
+		return output.WarningText("  ⚠️  No containers\n"), false
 	}
 
-	outputStr := string(cmdOutput)
-	lines := strings.Split(strings.TrimSpace(outputStr), "\n")
-
-	// Check if any containers are running
-	hasRunning := false
 	var result strings.Builder
-
-	if len(lines) <= 1 || (len(lines) == 2 && strings.Contains(lines[0], "NAME")) {
-		result.WriteString(output.WarningText("  ⚠️  No containers\n"))
-	} else {
-		// Parse container status
-		runningCount := 0
-		stoppedCount := 0
-
-		for i, line := range lines {
-			if i == 0 || line == "" {
-				continue // Skip header
-			}
-
-			if strings.Contains(line, "Up") || strings.Contains(line, "running") {
-				runningCount++
-				hasRunning = true
-			} else if strings.Contains(line, "Exited") || strings.Contains(line, "stopped") {
-				stoppedCount++
-			}
-		}
-
+	if runningCount > 0 {
+		result.WriteString(output.SuccessText("  🟢 %d running", runningCount))
+	}
+	if stoppedCount > 0 {
 		if runningCount > 0 {
-			result.WriteString(output.SuccessText("  🟢 %d running", runningCount))
-		}
-		if stoppedCount > 0 {
-			if runningCount > 0 {
-				result.WriteString(", ")
-			} else {
-				result.WriteString("  ")
-			}
-			result.WriteString(output.WarningText("🟡 %d stopped", stoppedCount))
+			result.WriteString(", ")
+		} else {
+			result.WriteString("  ")
 		}
-		result.WriteString("\n")
-
-		// Show container details if verbose flag is set
-		// Note: cmd here is the exec.Cmd, not cobra.Command
-		// We'd need to pass the verbose flag from Execute method
+		result.WriteString(output.WarningText("🟡 %d stopped", stoppedCount))
 	}
+	result.WriteString("\n")
 
-	return result.String(), hasRunning
+	return result.String(), runningCount > 0
 }
 
 // getBranchName gets the current branch name for a worktree
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// PsService is a row of `glide ps`
+type PsService struct {
+	Service   string     `json:"service" yaml:"service"`
+	Container string     `json:"container" yaml:"container"`
+	State     string     `json:"state" yaml:"state"`
+	Health    string     `json:"health,omitempty" yaml:"health,omitempty"`
+	Ports     []string   `json:"ports" yaml:"ports"`
+	StartedAt *time.Time `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	// UptimeSeconds is how long a running container has been up
+	UptimeSeconds int64 `json:"uptime_seconds,omitempty" yaml:"uptime_seconds,omitempty"`
+}
+
+// PsCommand lists the services of the current project or worktree
+type PsCommand struct {
+	ctx *glideContext.ProjectContext
+	cfg *config.Config
+
+	all bool
+}
+
+// NewPsCommand creates the ps command
+func NewPsCommand(ctx *glideContext.ProjectContext, cfg *config.Config) *cobra.Command {
+	pc := &PsCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "ps",
+		Short: "List the services of this project with their state, health, and ports",
+		Long: `List the containers of the current project, or of the current worktree in
+multi-worktree mode, with their state, healthcheck status, published ports,
+and uptime. Containers are found by the labels glide gives them, so other
+projects and worktrees using the same compose file are left out.
+
+Examples:
+  glide ps                  # Running services
+  glide ps --all            # Stopped services too
+  glide ps --format json    # For scripts`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pc.Execute(cmd)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&pc.all, "all", "a", false, "Include stopped containers")
+
+	return cmd
+}
+
+// Execute lists the worktree's containers
+func (pc *PsCommand) Execute(cmd *cobra.Command) error {
+	if pc.ctx == nil || pc.ctx.ProjectRoot == "" {
+		return glideErrors.New(glideErrors.TypeMissing, "not in a project",
+			glideErrors.WithSuggestions("Run this command from inside your project directory"),
+		)
+	}
+
+	services, err := pc.services(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if output.GetFormat().IsStructured() {
+		return output.Display(services)
+	}
+	if len(services) == 0 {
+		if pc.all {
+			output.Info("No containers. Start the project with: glide up")
+		} else {
+			output.Info("No running containers. Start the project with: glide up, or include stopped ones with --all")
+		}
+		return nil
+	}
+
+	table := output.NewTable("SERVICE", "CONTAINER", "STATE", "HEALTH", "PORTS", "UPTIME")
+	table.AddRow("-------", "---------", "-----", "------", "-----", "------")
+	for _, s := range services {
+		uptime := ""
+		if s.UptimeSeconds > 0 {
+			uptime = output.Duration(time.Duration(s.UptimeSeconds) * time.Second)
+		}
+		table.AddRow(s.Service, s.Container, s.State, s.Health, strings.Join(s.Ports, ", "), uptime)
+	}
+	return output.PrintTable(table)
+}
+
+// services lists the rows of the worktree's containers
+func (pc *PsCommand) services(now time.Time) ([]PsService, error) {
+	containers, err := listContainers()
+	if err != nil {
+		return nil, err
+	}
+	dir, owner := worktreeOwnership(pc.ctx)
+	return psServices(worktreeContainers(containers, owner.Project, owner.Worktree, dir), pc.all, now), nil
+}
+
+// psServices turns containers into rows sorted by service and container,
+// leaving out stopped containers unless all is set
+func psServices(containers []docker.ProjectContainer, all bool, now time.Time) []PsService {
+	services := []PsService{}
+	for _, c := range containers {
+		running := c.State == "running"
+		if !running && !all {
+			continue
+		}
+
+		s := PsService{
+			Service:   c.Service,
+			Container: c.Name,
+			State:     c.State,
+			Health:    c.Health,
+			Ports:     []string{},
+		}
+		for _, port := range c.Ports {
+			s.Ports = append(s.Ports, port.String())
+		}
+		if !c.StartedAt.IsZero() {
+			started := c.StartedAt
+			s.StartedAt = &started
+			if running && now.After(started) {
+				s.UptimeSeconds = int64(now.Sub(started) / time.Second)
+			}
+		}
+		services = append(services, s)
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Service != services[j].Service {
+			return services[i].Service < services[j].Service
+		}
+		return services[i].Container < services[j].Container
+	})
+	return services
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPsServices(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	containers := []docker.ProjectContainer{
+		{
+			Name: "myapp-php-1", Service: "php", State: "running", Health: "healthy",
+			StartedAt: now.Add(-90 * time.Minute),
+			Ports:     []docker.PortBinding{{HostIP: "0.0.0.0", HostPort: "8080", ContainerPort: "80/tcp"}},
+		},
+		{Name: "myapp-mysql-1", Service: "mysql", State: "exited", StartedAt: now.Add(-time.Hour)},
+	}
+
+	services := psServices(containers, false, now)
+	require.Len(t, services, 1, "stopped containers are left out")
+	assert.Equal(t, "php", services[0].Service)
+	assert.Equal(t, "healthy", services[0].Health)
+	assert.Equal(t, []string{"0.0.0.0:8080->80/tcp"}, services[0].Ports)
+	assert.Equal(t, int64(90*60), services[0].UptimeSeconds)
+
+	services = psServices(containers, true, now)
+	require.Len(t, services, 2)
+	assert.Equal(t, "mysql", services[0].Service)
+	assert.Equal(t, "exited", services[0].State)
+	assert.Zero(t, services[0].UptimeSeconds, "stopped containers have no uptime")
+	assert.Equal(t, []string{}, services[0].Ports)
+
+	assert.Equal(t, []PsService{}, psServices(nil, true, now))
+}
+
+func TestPsCommand_FiltersToWorktree(t *testing.T) {
+	ctx := &glideContext.ProjectContext{ProjectRoot: "/src/myapp", IsWorktree: true, WorktreeName: "feature-x"}
+	stubListContainers(t,
+		docker.ProjectContainer{Name: "feature-x-php-1", Service: "php", State: "running",
+			Labels: docker.Ownership{Project: "/src/myapp", Worktree: "feature-x"}.Labels()},
+		docker.ProjectContainer{Name: "main-php-1", Service: "php", State: "running",
+			Labels: docker.Ownership{Project: "/src/myapp", Worktree: "main"}.Labels()},
+		docker.ProjectContainer{Name: "legacy-php-1", Service: "php", State: "running",
+			WorkingDir: "/src/myapp/worktrees/feature-x"},
+	)
+
+	services, err := (&PsCommand{ctx: ctx}).services(time.Now())
+	require.NoError(t, err)
+
+	var names []string
+	for _, s := range services {
+		names = append(names, s.Container)
+	}
+	assert.Equal(t, []string{"feature-x-php-1", "legacy-php-1"}, names)
+}
+
+func TestPsCommand_OutsideProject(t *testing.T) {
+	cmd := NewPsCommand(&glideContext.ProjectContext{}, nil)
+	cmd.SetArgs(nil)
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeMissing))
+}
@@ -614,4 +614,41 @@ commands:
 		assert.False(t, cmd.DisableFlagParsing,
 			"help command should not be overridden by YAML")
 	})
+
+	t.Run("overridable core commands yield to YAML", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		glideYAML := `
+commands:
+  build:
+    cmd: make build
+    description: Project build
+  clean:
+    cmd: make clean
+`
+		err := os.WriteFile(filepath.Join(tmpDir, ".glide.yml"), []byte(glideYAML), 0644)
+		require.NoError(t, err)
+
+		originalWd, _ := os.Getwd()
+		defer os.Chdir(originalWd)
+		os.Chdir(tmpDir)
+
+		builder := NewBuilder(&context.ProjectContext{}, &config.Config{}, output.NewManager(output.FormatTable, false, false, os.Stdout))
+
+		factory, exists := builder.GetRegistry().Get("build")
+		require.True(t, exists)
+		assert.False(t, factory().DisableFlagParsing, "core build command is registered by default")
+
+		builder.loadYAMLCommands()
+
+		factory, exists = builder.GetRegistry().Get("build")
+		require.True(t, exists)
+		cmd := factory()
+		assert.True(t, cmd.DisableFlagParsing, "build should be the YAML command")
+		assert.Equal(t, "make build", cmd.Annotations["yaml_cmd"])
+
+		factory, exists = builder.GetRegistry().Get("clean")
+		require.True(t, exists)
+		assert.Equal(t, "make clean", factory().Annotations["yaml_cmd"])
+	})
 }
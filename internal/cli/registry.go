@@ -18,6 +18,7 @@ type Registry struct {
 	*registry.Registry[Factory]
 	metaMu   sync.RWMutex
 	metadata map[string]Metadata
+	planner  *Planner
 }
 
 // Metadata holds metadata about a command
@@ -52,9 +53,21 @@ func NewRegistry() *Registry {
 	return &Registry{
 		Registry: registry.New[Factory](),
 		metadata: make(map[string]Metadata),
+		planner:  NewPlanner(nil, nil),
 	}
 }
 
+// SetPlanner sets the planner --dry-run on YAML commands uses. It should
+// be the one explain uses, so both show the same plan.
+func (r *Registry) SetPlanner(planner *Planner) {
+	r.planner = planner
+}
+
+// Planner returns the planner --dry-run on YAML commands uses
+func (r *Registry) Planner() *Planner {
+	return r.planner
+}
+
 // Register adds a command factory to the registry
 func (r *Registry) Register(name string, factory Factory, metadata Metadata) error {
 	r.metaMu.Lock()
@@ -160,6 +173,26 @@ func (r *Registry) AddYAMLCommand(name string, cmd *config.Command) error {
 			Short: cmd.Description,
 			Long:  cmd.Help,
 			RunE: func(c *cobra.Command, args []string) error {
+				if IsDryRun(c) {
+					plan, err := r.Planner().Plan(c, args)
+					if err != nil {
+						return err
+					}
+					RenderPlan(plan)
+					return nil
+				}
+
+				// Commands from a project config run once it is trusted,
+				// or in the sandbox when it is not and the user asked for it
+				if cmd.Dir != "" {
+					if err := requireTrust(cmd.Dir); err != nil {
+						if isUntrusted(err) && sandboxUntrusted() {
+							return ExecuteYAMLCommandSandboxed(cmd.Dir, cmd.Cmd, args)
+						}
+						return err
+					}
+				}
+
 				// Execute the YAML-defined command
 				return ExecuteYAMLCommand(cmd.Cmd, args)
 			},
@@ -170,6 +203,11 @@ func (r *Registry) AddYAMLCommand(name string, cmd *config.Command) error {
 			cobraCmd.Annotations = make(map[string]string)
 		}
 		cobraCmd.Annotations["yaml_command"] = "true"
+		cobraCmd.Annotations["yaml_cmd"] = cmd.Cmd
+		if cmd.Dir != "" {
+			cobraCmd.Annotations["yaml_dir"] = cmd.Dir
+		}
+		cobraCmd.Annotations[DryRunAnnotation] = "true"
 
 		// Set alias if defined
 		if cmd.Alias != "" {
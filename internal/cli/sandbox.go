@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/sandbox"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+)
+
+// SandboxEnv runs the commands of untrusted projects in the sandbox (1) or
+// refuses them (0), overriding defaults.sandbox.untrusted
+const SandboxEnv = "GLIDE_SANDBOX"
+
+var (
+	// sandboxSettings are the sandbox settings of the configuration, see
+	// ConfigureSandbox
+	sandboxSettings config.SandboxDefaults
+
+	// runSandboxCommand runs a script in the sandbox and is replaced in
+	// tests
+	runSandboxCommand = func(opts sandbox.Options, script string) error {
+		cmd, err := sandbox.Command(opts, script)
+		if err != nil {
+			return err
+		}
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+)
+
+// ConfigureSandbox applies the sandbox settings of the configuration
+func ConfigureSandbox(cfg *config.Config) {
+	sandboxSettings = config.SandboxDefaults{}
+	if cfg != nil {
+		sandboxSettings = cfg.Defaults.Sandbox
+	}
+}
+
+// sandboxUntrusted reports whether the commands of untrusted projects run
+// in the sandbox
+func sandboxUntrusted() bool {
+	if on, err := strconv.ParseBool(os.Getenv(SandboxEnv)); err == nil {
+		return on
+	}
+	return sandboxSettings.Untrusted
+}
+
+// isUntrusted reports whether requireTrust refused a project
+func isUntrusted(err error) bool {
+	return glideErrors.Is(err, glideErrors.TypePermission)
+}
+
+// sandboxOptions returns the sandbox a YAML command of the project in dir
+// runs in, starting in the current directory
+func sandboxOptions(dir string) (sandbox.Options, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return sandbox.Options{}, err
+	}
+	return sandbox.Options{
+		Image:      sandboxSettings.Image,
+		ProjectDir: dir,
+		WorkDir:    cwd,
+		Env:        sandboxSettings.Env,
+	}, nil
+}
+
+// ExecuteYAMLCommandSandboxed runs a YAML command of the project in dir in
+// the sandbox: in a container without network, with the project mounted
+// read-only and only a few environment variables. The command and its
+// arguments are validated like any YAML command.
+func ExecuteYAMLCommandSandboxed(dir, cmdStr string, args []string) error {
+	expanded, err := prepareYAMLCommand(cmdStr, args)
+	if err != nil {
+		return err
+	}
+
+	opts, err := sandboxOptions(dir)
+	if err != nil {
+		return err
+	}
+
+	output.Warning("%s is not trusted: running the command in a sandbox without network, with the project read-only", dir)
+	if err := runSandboxCommand(opts, expanded); err != nil {
+		return glideErrors.Wrap(err, "sandboxed command failed",
+			glideErrors.WithSuggestions(
+				"Sandboxed commands cannot reach the network, Docker, or files outside the project, and cannot write to it",
+				"Check that Docker is running: the sandbox is a container",
+				"Review "+dir+", then trust it to run commands normally: "+branding.CommandName+" trust "+dir,
+			),
+		)
+	}
+	return nil
+}
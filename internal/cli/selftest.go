@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/glide-cli/glide/v3/internal/selftest"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// runSelfTest runs the checks and is replaced in tests
+var runSelfTest = selftest.Run
+
+// NewSelfTestCommand creates the selftest command, which checks the
+// installation end to end
+func NewSelfTestCommand() *cobra.Command {
+	var skip []string
+
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Check that glide works on this machine, end to end",
+		Long: `Test the installation against this machine the way real commands use it:
+
+  plugin   start glide as a plugin over the plugin protocol and run a command
+  shell    run echo in the system shell and read its output
+  compose  create a compose project in a temporary directory and remove it
+           again; skipped when Docker is not running
+
+The report lists the version, platform, and the outcome of every check, and
+is meant to be pasted into support requests. With --format json it is one
+JSON document. glide selftest exits with an error when a check fails.
+
+Examples:
+  glide selftest
+  glide selftest --skip compose
+  glide selftest --format json > selftest.json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			exe, err := os.Executable()
+			if err != nil {
+				return glideErrors.Wrap(err, "failed to locate the glide binary")
+			}
+
+			var checks []selftest.Check
+			for _, check := range selftest.DefaultChecks(exe) {
+				if !slices.Contains(skip, check.Name) {
+					checks = append(checks, check)
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			report := runSelfTest(ctx, checks)
+
+			if output.GetFormat().IsStructured() {
+				if err := output.Display(report); err != nil {
+					return err
+				}
+			} else {
+				showSelfTest(report)
+			}
+
+			if failed := report.Count(selftest.StatusFailed); failed > 0 {
+				return glideErrors.New(glideErrors.TypeRuntime, fmt.Sprintf("%d self-test check(s) failed", failed),
+					glideErrors.WithSuggestions(
+						"Include the report above when asking for help",
+						"Run with --debug for more detail",
+					))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&skip, "skip", nil, "Checks to skip: plugin, shell, compose")
+
+	return cmd
+}
+
+// showSelfTest prints the report as text for support requests
+func showSelfTest(report selftest.Report) {
+	env := report.Environment
+	output.Println("Glide self-test")
+	output.Printf("  Version:     %s (%s)\n", env.Version, env.GitCommit)
+	output.Printf("  Platform:    %s/%s, %s\n", env.OS, env.Arch, env.GoVersion)
+	if env.Executable != "" {
+		output.Printf("  Executable:  %s\n", env.Executable)
+	}
+	if env.Shell != "" {
+		output.Printf("  Shell:       %s\n", env.Shell)
+	}
+	output.Printf("  Started:     %s\n", report.Started.Format("2006-01-02 15:04:05 MST"))
+	output.Println()
+
+	for _, result := range report.Results {
+		switch result.Status {
+		case selftest.StatusPassed:
+			output.Printf("  %s %-8s %s (%dms)\n", output.SuccessText("✓"), result.Name, result.Detail, result.DurationMS)
+		case selftest.StatusSkipped:
+			output.Printf("  %s %-8s skipped: %s\n", output.WarningText("-"), result.Name, result.Detail)
+		default:
+			output.Printf("  %s %-8s %s (%dms)\n", output.ErrorText("✗"), result.Name, result.Error, result.DurationMS)
+		}
+	}
+
+	output.Println()
+	output.Printf("%d passed, %d failed, %d skipped\n",
+		report.Count(selftest.StatusPassed), report.Count(selftest.StatusFailed), report.Count(selftest.StatusSkipped))
+}
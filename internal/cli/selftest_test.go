@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/selftest"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSelfTest replaces the checks with results and records the names of
+// the checks asked for
+func stubSelfTest(t *testing.T, results ...selftest.Result) *[]string {
+	t.Helper()
+
+	var ran []string
+	orig := runSelfTest
+	runSelfTest = func(_ context.Context, checks []selftest.Check) selftest.Report {
+		for _, check := range checks {
+			ran = append(ran, check.Name)
+		}
+		return selftest.Report{Results: results}
+	}
+	t.Cleanup(func() { runSelfTest = orig })
+
+	return &ran
+}
+
+func TestSelfTestCommand(t *testing.T) {
+	ran := stubSelfTest(t,
+		selftest.Result{Name: "plugin", Status: selftest.StatusPassed},
+		selftest.Result{Name: "compose", Status: selftest.StatusSkipped, Detail: "Docker is not running"},
+	)
+
+	cmd := NewSelfTestCommand()
+	cmd.SetArgs([]string{"--skip", "shell"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"plugin", "compose"}, *ran)
+}
+
+func TestSelfTestCommand_Failure(t *testing.T) {
+	stubSelfTest(t,
+		selftest.Result{Name: "plugin", Status: selftest.StatusPassed},
+		selftest.Result{Name: "shell", Status: selftest.StatusFailed, Error: "sh failed"},
+	)
+
+	cmd := NewSelfTestCommand()
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeRuntime))
+	assert.Contains(t, err.Error(), "1 self-test check(s) failed")
+}
@@ -2,11 +2,17 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/glide-cli/glide/v3/internal/config"
 	internalContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/pkg/artifacts"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
 	"github.com/glide-cli/glide/v3/pkg/output"
 	"github.com/glide-cli/glide/v3/pkg/update"
 	"github.com/glide-cli/glide/v3/pkg/version"
@@ -26,7 +32,8 @@ func NewSelfUpdateCommand(ctx *internalContext.ProjectContext, cfg *config.Confi
 		cfg: cfg,
 	}
 
-	var force bool
+	var force, check bool
+	var showChangelog string
 
 	cmd := &cobra.Command{
 		Use:   "self-update [flags]",
@@ -40,21 +47,33 @@ This command will:
 4. Replace the current binary with the new version
 5. Create a backup of the current binary
 
-The update process is atomic and will rollback on failure.
+The update process is atomic and will rollback on failure. The release notes
+of the new version are shown, grouped into breaking changes, features, and
+fixes, before you confirm.
 
 Examples:
-  glide self-update              # Check and install updates
-  glide self-update --force      # Force update even if already on latest`,
+  glide self-update                          # Check and install updates
+  glide self-update --force                  # Force update even if already on latest
+  glide self-update --check                  # Only report whether an update is available
+  glide self-update --show-changelog v3.2.0  # Only show a version's release notes`,
 		Aliases:       []string{"update", "upgrade"},
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if showChangelog != "" {
+				return suc.showChangelog(showChangelog)
+			}
+			if check {
+				return suc.check()
+			}
 			return suc.execute(cmd, args, force)
 		},
 	}
 
 	// Add flags
 	cmd.Flags().BoolVar(&force, "force", false, "Force update even if already on latest version")
+	cmd.Flags().BoolVar(&check, "check", false, "Only check for a newer version, without installing it")
+	cmd.Flags().StringVar(&showChangelog, "show-changelog", "", "Show the release notes of a version (or \"latest\") without updating")
 
 	return cmd
 }
@@ -71,7 +90,7 @@ func (suc *SelfUpdateCommand) execute(cmd *cobra.Command, args []string, force b
 		return fmt.Errorf("self-update not available for development builds")
 	}
 
-	output.Info(fmt.Sprintf("Current version: %s", currentVersion))
+	output.Info("Current version: %s", currentVersion)
 	output.Info("Checking for updates...")
 
 	// Check for updates first
@@ -81,20 +100,31 @@ func (suc *SelfUpdateCommand) execute(cmd *cobra.Command, args []string, force b
 
 	updateInfo, err := checker.CheckForUpdate(ctx)
 	if err != nil {
-		output.Error(fmt.Sprintf("Failed to check for updates: %v", err))
+		output.Error("Failed to check for updates: %v", err)
 		return err
 	}
 
 	if !updateInfo.Available && !force {
-		output.Success(fmt.Sprintf("You are already running the latest version (%s)", currentVersion))
+		output.Success("You are already running the latest version (%s)", currentVersion)
 		return nil
 	}
 
 	if force && !updateInfo.Available {
 		output.Warning("Forcing reinstall of current version")
 	} else {
-		output.Info(fmt.Sprintf("New version available: %s", updateInfo.LatestVersion))
-		output.Info(fmt.Sprintf("Release date: %s", updateInfo.PublishedAt.Format("2006-01-02")))
+		output.Info("New version available: %s", updateInfo.LatestVersion)
+		output.Info("Release date: %s", updateInfo.PublishedAt.Format("2006-01-02"))
+
+		notes := update.ReleaseNotes{
+			Version:     updateInfo.LatestVersion,
+			PublishedAt: updateInfo.PublishedAt,
+			URL:         updateInfo.ReleaseURL,
+			Sections:    update.ParseReleaseNotes(updateInfo.ReleaseNotes),
+		}
+		output.Raw("\n")
+		if err := output.Page(notes.Render()); err != nil {
+			return err
+		}
 	}
 
 	// Ask for confirmation
@@ -109,21 +139,74 @@ func (suc *SelfUpdateCommand) execute(cmd *cobra.Command, args []string, force b
 		return nil
 	}
 
-	// Perform the update
+	// Perform the update; Ctrl-C stops the download, which the next run
+	// resumes
 	output.Info("Downloading update...")
 
 	updater := update.NewUpdater(currentVersion)
 	ctx2, cancel2 := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel2()
+	ctx2, stop := signal.NotifyContext(ctx2, os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	if err := updater.SelfUpdate(ctx2); err != nil {
-		output.Error(fmt.Sprintf("Update failed: %v", err))
+		var partial *artifacts.PartialError
+		if errors.As(err, &partial) {
+			output.Warning("Download stopped after %s", output.Bytes(uint64(partial.Bytes)))
+			output.Info("Your current binary has not been modified")
+			output.Info("Run 'glide self-update' again to resume the download")
+			return err
+		}
+		output.Error("Update failed: %v", err)
 		output.Info("Your current binary has not been modified")
 		return err
 	}
 
-	output.Success(fmt.Sprintf("Successfully updated to version %s", updateInfo.LatestVersion))
+	output.Success("Successfully updated to version %s", updateInfo.LatestVersion)
 	output.Info("Please run 'glide version' to verify the update")
 
 	return nil
 }
+
+// check reports whether a newer version is available, e.g. for a
+// scheduled job
+func (suc *SelfUpdateCommand) check() error {
+	currentVersion := version.GetBuildInfo().Version
+	checker := update.NewChecker(currentVersion)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	updateInfo, err := checker.CheckForUpdate(ctx)
+	if err != nil {
+		return glideErrors.NewNetworkError("failed to check for updates",
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions("Check your network connection"),
+		)
+	}
+	if !updateInfo.Available {
+		output.Success("You are running the latest version (%s)", currentVersion)
+		return nil
+	}
+	output.Info("New version available: %s (you have %s)", updateInfo.LatestVersion, currentVersion)
+	output.Info("Run 'glide self-update' to install it")
+	return nil
+}
+
+// showChangelog prints the release notes of a version
+func (suc *SelfUpdateCommand) showChangelog(release string) error {
+	checker := update.NewChecker(version.GetBuildInfo().Version)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	notes, err := checker.FetchReleaseNotes(ctx, release)
+	if err != nil {
+		return glideErrors.Wrap(err, fmt.Sprintf("failed to fetch the release notes of %s", release),
+			glideErrors.WithSuggestions("Check the version, e.g. v3.2.0 or latest, and your network connection"),
+		)
+	}
+
+	if output.GetFormat().IsStructured() {
+		return output.Display(notes)
+	}
+	return output.Page(notes.Render())
+}
@@ -52,6 +52,7 @@ This command will:
 	cmd.Flags().BoolVar(&setup.nonInteractive, "non-interactive", false, "Run in non-interactive mode")
 	cmd.Flags().StringVar(&setup.mode, "mode", "", "Development mode: multi-worktree or single-repo")
 	cmd.Flags().StringVar(&setup.location, "path", "", "Project path (defaults to current directory)")
+	MarkExclusive(cmd, GroupConfigWrite)
 
 	return cmd
 }
@@ -646,7 +647,7 @@ func (s *SetupCommand) printSuccessMessage(projectPath string, mode context.Deve
 	output.Success("✅ Setup complete!")
 	output.Println()
 
-	output.Info("Your project is configured at:", projectPath)
+	output.Info("Your project is configured at: %s", projectPath)
 	output.Printf("Development mode: %s\n", mode)
 	output.Println()
 
@@ -0,0 +1,564 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/bundle"
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/pluginregistry"
+	"github.com/glide-cli/glide/v3/internal/trust"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/glide-cli/glide/v3/pkg/version"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Actions taken on a plugin or trusted directory during import
+const (
+	SetupActionInstall   = "install"
+	SetupActionReinstall = "reinstall"
+	SetupActionCurrent   = "up_to_date"
+	SetupActionKeep      = "keep"
+	SetupActionSkip      = "skip"
+	SetupActionTrust     = "trust"
+)
+
+var (
+	// setupConfigPath, setupTrustPath, setupPluginDir, installedPlugins,
+	// and installBundlePlugin are replaced in tests
+	setupConfigPath  = branding.GetConfigPath
+	setupTrustPath   = trust.DefaultPath
+	setupPluginDir   = branding.GetGlobalPluginDir
+	installedPlugins = listInstalledPlugins
+	// installBundlePlugin installs a pinned plugin release at dest
+	installBundlePlugin = func(p bundle.Plugin, dest string) error {
+		reg, repo := pluginregistry.GitHub, strings.TrimPrefix(strings.TrimPrefix(p.Source, "https://"), "github.com/")
+		registries, err := pluginRegistries()
+		if err != nil {
+			return err
+		}
+		if registry, registryRepo, ok := pluginregistry.Resolve(p.Source, registries); ok {
+			reg, repo = registry, registryRepo
+		}
+		release, err := getReleaseByTag(reg, repo, p.Version)
+		if err != nil {
+			return fmt.Errorf("failed to find release %s of %s: %w", p.Version, repo, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("failed to create plugins directory: %w", err)
+		}
+		return installPluginFromRelease(reg, dest, repo, release)
+	}
+)
+
+// SetupPluginImport is what importing does to one plugin
+type SetupPluginImport struct {
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	Action  string `json:"action" yaml:"action"`
+	Reason  string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// SetupTrustImport is what importing does to one trusted directory
+type SetupTrustImport struct {
+	Dir    string `json:"dir" yaml:"dir"`
+	Action string `json:"action" yaml:"action"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// SetupImport is the plan, and once applied the result, of import-setup
+type SetupImport struct {
+	Config  []bundle.ConfigChange `json:"config" yaml:"config"`
+	Plugins []SetupPluginImport   `json:"plugins" yaml:"plugins"`
+	Trusted []SetupTrustImport    `json:"trusted" yaml:"trusted"`
+}
+
+// SetupBundleCommand exports and imports setup bundles
+type SetupBundleCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+
+	force     bool
+	overwrite bool
+	trust     bool
+}
+
+// NewExportSetupCommand creates the export-setup command
+func NewExportSetupCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	sc := &SetupBundleCommand{ctx: ctx, cfg: cfg}
+
+	cmd := &cobra.Command{
+		Use:   "export-setup [file]",
+		Short: "Write a bundle of your setup for teammates to import",
+		Long: fmt.Sprintf(`Write a portable bundle of your working environment: the global
+configuration (~/%[2]s) without project paths, a lockfile pinning the
+installed plugins, and the directories you trust to run their commands.
+A teammate applies it with '%[1]s import-setup'.
+
+The bundle is written to %[3]s unless a file is given; "-" writes it to
+stdout. Review it before sharing: the configuration may hold values only
+meant for you.
+
+Examples:
+  %[1]s export-setup                     # Write %[3]s
+  %[1]s export-setup onboarding.yml      # Write another file
+  %[1]s export-setup - > setup.yml       # Write to stdout`,
+			branding.CommandName, branding.ConfigFileName, bundle.DefaultFileName),
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := bundle.DefaultFileName
+			if len(args) > 0 {
+				path = args[0]
+			}
+			return sc.executeExport(path)
+		},
+	}
+	cmd.Flags().BoolVar(&sc.force, "force", false, "Overwrite an existing bundle file")
+
+	return cmd
+}
+
+// NewImportSetupCommand creates the import-setup command
+func NewImportSetupCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	sc := &SetupBundleCommand{ctx: ctx, cfg: cfg}
+
+	cmd := &cobra.Command{
+		Use:   "import-setup <file>",
+		Short: "Apply a setup bundle exported by a teammate",
+		Long: fmt.Sprintf(`Apply a bundle written by '%[1]s export-setup': add its configuration
+to ~/%[2]s, install its plugins at the pinned versions, and, with --trust,
+trust its directories.
+
+Configuration keys you already set and plugins you already installed are
+kept; --overwrite replaces them. Trusting a directory lets its %[2]s run
+commands, so directories are only trusted with --trust. A plugin whose
+checksum does not match the bundle's is removed again.
+
+Examples:
+  %[1]s import-setup glide-setup.yml            # Apply, keeping your settings
+  %[1]s import-setup glide-setup.yml --trust    # Also trust the directories
+  %[1]s --dry-run import-setup glide-setup.yml  # Only show the plan`,
+			branding.CommandName, branding.ConfigFileName),
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Annotations:   map[string]string{DryRunAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sc.executeImport(cmd, args[0])
+		},
+	}
+	cmd.Flags().BoolVar(&sc.overwrite, "overwrite", false, "Replace configuration keys and plugins you already have")
+	cmd.Flags().BoolVar(&sc.trust, "trust", false, "Trust the bundle's directories to run their commands")
+
+	MarkDestructive(cmd, func(cmd *cobra.Command, args []string) []string {
+		b, err := bundle.Load(args[0])
+		if err != nil {
+			return nil
+		}
+		plan, err := sc.plan(b)
+		if err != nil {
+			return nil
+		}
+		return describeSetupReplacements(plan)
+	}, "overwrite")
+
+	return cmd
+}
+
+// executeExport writes the bundle
+func (sc *SetupBundleCommand) executeExport(path string) error {
+	if path != "-" && !sc.force {
+		if _, err := os.Stat(path); err == nil {
+			return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("%s already exists", path),
+				glideErrors.WithSuggestions("Re-run with --force to overwrite it, or give another file name"),
+			)
+		}
+	}
+
+	raw, err := readRawConfig(setupConfigPath())
+	if err != nil {
+		return err
+	}
+
+	b := &bundle.Bundle{
+		Version:      bundle.FormatVersion,
+		CreatedAt:    time.Now().UTC(),
+		GlideVersion: version.Get(),
+		Config:       bundle.ConfigSubset(raw),
+	}
+
+	if b.Plugins, err = installedPlugins(); err != nil {
+		return glideErrors.NewPermissionError(setupPluginDir(), "failed to list installed plugins", glideErrors.WithError(err))
+	}
+
+	decisions, err := trust.NewStore(setupTrustPath()).Decisions()
+	if err != nil {
+		return glideErrors.NewPermissionError(setupTrustPath(), "failed to read trust decisions", glideErrors.WithError(err))
+	}
+	home, _ := os.UserHomeDir()
+	for _, d := range decisions {
+		if d.Trusted {
+			b.Trusted = append(b.Trusted, bundle.PortablePath(d.Dir, home))
+		}
+	}
+
+	data, err := b.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle: %w", err)
+	}
+	if path == "-" {
+		output.Raw(string(data))
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return glideErrors.NewPermissionError(path, "failed to write bundle", glideErrors.WithError(err))
+	}
+
+	output.Success("Wrote %s: %d configuration key(s), %d plugin(s), %d trusted director(ies)",
+		path, len(b.Config), len(b.Plugins), len(b.Trusted))
+	output.Info("Teammates apply it with: %s import-setup %s", branding.CommandName, filepath.Base(path))
+	return nil
+}
+
+// executeImport plans the import and applies it unless this is a dry run
+func (sc *SetupBundleCommand) executeImport(cmd *cobra.Command, path string) error {
+	b, err := bundle.Load(path)
+	if err != nil {
+		return glideErrors.NewConfigError(fmt.Sprintf("invalid setup bundle %s", path), glideErrors.WithError(err))
+	}
+
+	plan, err := sc.plan(b)
+	if err != nil {
+		return err
+	}
+
+	structured := false
+	if output.GetFormat().IsStructured() {
+		structured = true
+	}
+
+	if IsDryRun(cmd) {
+		if structured {
+			return output.Display(plan)
+		}
+		showSetupImport(plan, true)
+		output.Info("Dry run: nothing was changed")
+		return nil
+	}
+
+	result, err := sc.apply(b, plan)
+	if err != nil {
+		return err
+	}
+	if structured {
+		if err := output.Display(result); err != nil {
+			return err
+		}
+	} else {
+		showSetupImport(result, false)
+	}
+
+	failed := 0
+	for _, p := range result.Plugins {
+		if p.Error != "" {
+			failed++
+		}
+	}
+	for _, t := range result.Trusted {
+		if t.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return glideErrors.New(glideErrors.TypeCommand, fmt.Sprintf("%d item(s) of the bundle could not be applied", failed),
+			glideErrors.WithExitCode(1),
+		)
+	}
+	return nil
+}
+
+// plan decides what importing the bundle changes
+func (sc *SetupBundleCommand) plan(b *bundle.Bundle) (*SetupImport, error) {
+	existing, err := readRawConfig(setupConfigPath())
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &SetupImport{Config: []bundle.ConfigChange{}, Plugins: []SetupPluginImport{}, Trusted: []SetupTrustImport{}}
+	if _, changes := bundle.MergeConfig(existing, b.Config, sc.overwrite); changes != nil {
+		plan.Config = changes
+	}
+
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	for _, p := range b.Plugins {
+		item := SetupPluginImport{Name: p.Name, Version: p.Version}
+		dest := filepath.Join(setupPluginDir(), p.Name)
+		switch {
+		case p.Source == "" || p.Version == "":
+			item.Action = SetupActionSkip
+			item.Reason = "the bundle does not say where to install it from"
+		case !fileExists(dest):
+			item.Action = SetupActionInstall
+		case p.Platform == platform && p.SHA256 != "" && checksumMatches(dest, p.SHA256):
+			item.Action = SetupActionCurrent
+		case sc.overwrite:
+			item.Action = SetupActionReinstall
+		default:
+			item.Action = SetupActionKeep
+			item.Reason = "already installed; --overwrite installs the bundle's version"
+		}
+		plan.Plugins = append(plan.Plugins, item)
+	}
+
+	home, _ := os.UserHomeDir()
+	store := trust.NewStore(setupTrustPath())
+	for _, portable := range b.Trusted {
+		dir := bundle.LocalPath(portable, home)
+		item := SetupTrustImport{Dir: dir}
+		status, decidedFor, err := store.Status(dir)
+		switch {
+		case err != nil:
+			return nil, glideErrors.NewPermissionError(store.Path(), "failed to read trust decisions", glideErrors.WithError(err))
+		case status == trust.Trusted:
+			item.Action = SetupActionCurrent
+		case status == trust.Denied && decidedFor == filepath.Clean(dir) && !sc.overwrite:
+			item.Action = SetupActionKeep
+			item.Reason = "you denied it; --overwrite --trust trusts it"
+		case !sc.trust:
+			item.Action = SetupActionSkip
+			item.Reason = "pass --trust to trust it"
+		default:
+			item.Action = SetupActionTrust
+		}
+		plan.Trusted = append(plan.Trusted, item)
+	}
+	return plan, nil
+}
+
+// apply carries out a plan, recording plugin and trust failures on their
+// items rather than stopping at the first
+func (sc *SetupBundleCommand) apply(b *bundle.Bundle, plan *SetupImport) (*SetupImport, error) {
+	if configChanges(plan.Config) > 0 {
+		existing, err := readRawConfig(setupConfigPath())
+		if err != nil {
+			return nil, err
+		}
+		merged, _ := bundle.MergeConfig(existing, b.Config, sc.overwrite)
+		if err := writeRawConfig(setupConfigPath(), merged); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, item := range plan.Plugins {
+		if item.Action != SetupActionInstall && item.Action != SetupActionReinstall {
+			continue
+		}
+		p := b.Plugins[i]
+		dest := filepath.Join(setupPluginDir(), p.Name)
+		if err := installBundlePlugin(p, dest); err != nil {
+			plan.Plugins[i].Error = err.Error()
+			continue
+		}
+		if p.Platform == runtime.GOOS+"/"+runtime.GOARCH && p.SHA256 != "" && !checksumMatches(dest, p.SHA256) {
+			_ = os.Remove(dest)
+			plan.Plugins[i].Error = "checksum does not match the bundle; the plugin was removed"
+		}
+	}
+
+	store := trust.NewStore(setupTrustPath())
+	for i, item := range plan.Trusted {
+		if item.Action != SetupActionTrust {
+			continue
+		}
+		if err := store.Set(item.Dir, true); err != nil {
+			plan.Trusted[i].Error = err.Error()
+		}
+	}
+	return plan, nil
+}
+
+// listInstalledPlugins pins the plugins in the global plugin directory,
+// reading versions and sources from the plugins' metadata
+func listInstalledPlugins() ([]bundle.Plugin, error) {
+	dir := setupPluginDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	metadata := make(map[string]*sdk.LoadedPlugin)
+	manager := sdk.NewManager(nil)
+	defer manager.Cleanup()
+	if err := manager.DiscoverPlugins(); err == nil {
+		for _, p := range manager.ListPlugins() {
+			metadata[p.Path] = p
+		}
+	}
+
+	var plugins []bundle.Plugin
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		sum, err := bundle.HashFile(path)
+		if err != nil {
+			return nil, err
+		}
+		p := bundle.Plugin{Name: entry.Name(), SHA256: sum, Platform: runtime.GOOS + "/" + runtime.GOARCH}
+		if loaded := metadata[path]; loaded != nil && loaded.Metadata != nil {
+			p.Version = loaded.Metadata.Version
+			if repo := extractGitHubRepo(loaded.Metadata.Homepage); repo != "" {
+				p.Source = "github.com/" + repo
+			}
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins, nil
+}
+
+// readRawConfig reads the global configuration as plain YAML values, so
+// keys this build does not know, such as plugin settings, survive
+func readRawConfig(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]any{}, nil
+		}
+		return nil, glideErrors.NewPermissionError(path, "failed to read the configuration", glideErrors.WithError(err))
+	}
+
+	raw := map[string]any{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, glideErrors.NewConfigError(fmt.Sprintf("invalid configuration %s", path), glideErrors.WithError(err))
+	}
+	return raw, nil
+}
+
+// writeRawConfig writes the global configuration
+func writeRawConfig(path string, raw map[string]any) error {
+	data, err := yaml.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return glideErrors.NewPermissionError(path, "failed to write the configuration", glideErrors.WithError(err))
+	}
+	return nil
+}
+
+// configChanges counts the keys an import adds or replaces
+func configChanges(changes []bundle.ConfigChange) int {
+	n := 0
+	for _, change := range changes {
+		if change.Action != bundle.ActionKeep {
+			n++
+		}
+	}
+	return n
+}
+
+// fileExists reports whether path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// checksumMatches reports whether a file's SHA256 is sum
+func checksumMatches(path, sum string) bool {
+	actual, err := bundle.HashFile(path)
+	return err == nil && strings.EqualFold(actual, strings.TrimPrefix(sum, "sha256:"))
+}
+
+// describeSetupReplacements lists what --overwrite replaces, for the
+// destructive confirmation
+func describeSetupReplacements(plan *SetupImport) []string {
+	var targets []string
+	for _, change := range plan.Config {
+		if change.Action == bundle.ActionReplace {
+			targets = append(targets, fmt.Sprintf("Configuration key %q in ~/%s", change.Key, branding.ConfigFileName))
+		}
+	}
+	for _, p := range plan.Plugins {
+		if p.Action == SetupActionReinstall {
+			targets = append(targets, fmt.Sprintf("Installed plugin %s, replaced by %s", p.Name, p.Version))
+		}
+	}
+	return targets
+}
+
+// showSetupImport prints the plan or the result of an import
+func showSetupImport(result *SetupImport, planned bool) {
+	if len(result.Config)+len(result.Plugins)+len(result.Trusted) == 0 {
+		output.Info("Your setup already matches the bundle")
+		return
+	}
+
+	verb := map[string]string{
+		bundle.ActionAdd:     "added",
+		bundle.ActionReplace: "replaced",
+		SetupActionInstall:   "installed",
+		SetupActionReinstall: "reinstalled",
+		SetupActionTrust:     "trusted",
+	}
+	if planned {
+		verb = map[string]string{
+			bundle.ActionAdd:     "add",
+			bundle.ActionReplace: "replace",
+			SetupActionInstall:   "install",
+			SetupActionReinstall: "reinstall",
+			SetupActionTrust:     "trust",
+		}
+	}
+	line := func(action, what, reason, errText string) {
+		switch {
+		case errText != "":
+			output.Error("  %s: %s", what, errText)
+		case verb[action] != "":
+			output.Success("  %s: %s", what, verb[action])
+		case action == SetupActionCurrent:
+			output.Println(output.Faint("  %s: already set up", what))
+		default:
+			output.Warning("  %s: skipped (%s)", what, reason)
+		}
+	}
+
+	if len(result.Config) > 0 {
+		output.Info("Configuration (~/%s):", branding.ConfigFileName)
+		for _, c := range result.Config {
+			reason := ""
+			if c.Action == bundle.ActionKeep {
+				reason = "you already set it; --overwrite replaces it"
+			}
+			line(c.Action, c.Key, reason, "")
+		}
+	}
+	if len(result.Plugins) > 0 {
+		output.Info("Plugins:")
+		for _, p := range result.Plugins {
+			line(p.Action, strings.TrimSpace(p.Name+" "+p.Version), p.Reason, p.Error)
+		}
+	}
+	if len(result.Trusted) > 0 {
+		output.Info("Trusted directories:")
+		for _, t := range result.Trusted {
+			line(t.Action, t.Dir, t.Reason, t.Error)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/bundle"
+	"github.com/glide-cli/glide/v3/internal/trust"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSetupPaths points the setup bundle commands at a temporary home and
+// returns it
+func stubSetupPaths(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	origConfig, origTrust, origPlugins := setupConfigPath, setupTrustPath, setupPluginDir
+	origInstalled, origInstall := installedPlugins, installBundlePlugin
+	setupConfigPath = func() string { return filepath.Join(home, ".glide.yml") }
+	setupTrustPath = func() string { return filepath.Join(home, ".glide", "trust.json") }
+	setupPluginDir = func() string { return filepath.Join(home, ".glide", "plugins") }
+	t.Cleanup(func() {
+		setupConfigPath, setupTrustPath, setupPluginDir = origConfig, origTrust, origPlugins
+		installedPlugins, installBundlePlugin = origInstalled, origInstall
+	})
+	return home
+}
+
+func TestExportSetup(t *testing.T) {
+	home := stubSetupPaths(t)
+	writeTestFile(t, filepath.Join(home, ".glide.yml"),
+		"projects:\n  acme:\n    path: /src/acme\ndefault_project: acme\ndefaults:\n  test:\n    processes: 4\n"+
+			"notifications:\n  webhook:\n    url: https://hooks.slack.com/services/T0/B0/xyz\n"+
+			"jobs:\n  prefetch:\n    schedule: \"@hourly\"\n    run: prefetch\n    dir: /src/acme\n")
+	require.NoError(t, trust.NewStore(setupTrustPath()).Set(filepath.Join(home, "src", "acme"), true))
+	require.NoError(t, trust.NewStore(setupTrustPath()).Set(filepath.Join(home, "src", "other"), false))
+	installedPlugins = func() ([]bundle.Plugin, error) {
+		return []bundle.Plugin{{Name: "glide-plugin-go", Version: "v1.4.0", Source: "github.com/glide-cli/glide-plugin-go"}}, nil
+	}
+
+	path := filepath.Join(t.TempDir(), "setup.yml")
+	sc := &SetupBundleCommand{}
+	require.NoError(t, sc.executeExport(path))
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(written), "hooks.slack.com", "the webhook URL is a secret")
+	assert.NotContains(t, string(written), "dir: /src/acme", "machine paths stay behind")
+
+	b, err := bundle.Load(path)
+	require.NoError(t, err)
+	assert.NotContains(t, b.Config, "projects")
+	assert.NotContains(t, b.Config, "jobs")
+	assert.NotContains(t, b.Config, "notifications")
+	assert.NotContains(t, b.Config, "default_project")
+	assert.Contains(t, b.Config, "defaults")
+	assert.Len(t, b.Plugins, 1)
+	assert.Equal(t, []string{"~/src/acme"}, b.Trusted)
+
+	t.Run("refuses to overwrite without --force", func(t *testing.T) {
+		assert.Error(t, sc.executeExport(path))
+		sc.force = true
+		assert.NoError(t, sc.executeExport(path))
+	})
+}
+
+func TestImportSetup(t *testing.T) {
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	pluginContent := []byte("plugin binary")
+	// SHA256 of pluginContent
+	pluginSum := "062fe192389ca66290320cc0af9cf2d3d0326687375f047dde9537f3bcfb7198"
+
+	newBundle := func() *bundle.Bundle {
+		return &bundle.Bundle{
+			Version: bundle.FormatVersion,
+			Config: map[string]any{
+				"defaults":   map[string]any{"test": map[string]any{"processes": 8}},
+				"git_policy": map[string]any{"branch_pattern": "^feat/"},
+			},
+			Plugins: []bundle.Plugin{
+				{Name: "glide-plugin-go", Version: "v1.4.0", Source: "github.com/glide-cli/glide-plugin-go", SHA256: pluginSum, Platform: platform},
+				{Name: "glide-plugin-local", SHA256: "abc"},
+			},
+			Trusted: []string{"~/src/acme"},
+		}
+	}
+
+	stubInstall := func(t *testing.T, content []byte) *[]string {
+		installed := &[]string{}
+		installBundlePlugin = func(p bundle.Plugin, dest string) error {
+			*installed = append(*installed, p.Name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(dest), 0755))
+			return os.WriteFile(dest, content, 0755)
+		}
+		return installed
+	}
+
+	t.Run("adds what is missing and keeps the rest", func(t *testing.T) {
+		home := stubSetupPaths(t)
+		writeTestFile(t, filepath.Join(home, ".glide.yml"), "defaults:\n  test:\n    processes: 2\n")
+		installed := stubInstall(t, pluginContent)
+		b := newBundle()
+
+		sc := &SetupBundleCommand{}
+		plan, err := sc.plan(b)
+		require.NoError(t, err)
+		assert.Equal(t, []bundle.ConfigChange{
+			{Key: "defaults", Action: bundle.ActionKeep},
+			{Key: "git_policy", Action: bundle.ActionAdd},
+		}, plan.Config)
+		assert.Equal(t, SetupActionInstall, plan.Plugins[0].Action)
+		assert.Equal(t, SetupActionSkip, plan.Plugins[1].Action)
+		assert.Equal(t, SetupActionSkip, plan.Trusted[0].Action)
+
+		result, err := sc.apply(b, plan)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"glide-plugin-go"}, *installed)
+		assert.Empty(t, result.Plugins[0].Error)
+
+		raw, err := readRawConfig(setupConfigPath())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"branch_pattern": "^feat/"}, raw["git_policy"])
+		assert.Equal(t, map[string]any{"test": map[string]any{"processes": 2}}, raw["defaults"])
+
+		status, _, err := trust.NewStore(setupTrustPath()).Status(filepath.Join(home, "src", "acme"))
+		require.NoError(t, err)
+		assert.Equal(t, trust.Unknown, status)
+
+		// A second import finds everything set up
+		plan, err = sc.plan(b)
+		require.NoError(t, err)
+		assert.Equal(t, SetupActionCurrent, plan.Plugins[0].Action)
+	})
+
+	t.Run("overwrite and trust", func(t *testing.T) {
+		home := stubSetupPaths(t)
+		writeTestFile(t, filepath.Join(home, ".glide.yml"), "defaults:\n  test:\n    processes: 2\n")
+		writeTestFile(t, filepath.Join(setupPluginDir(), "glide-plugin-go"), "older build")
+		stubInstall(t, pluginContent)
+		b := newBundle()
+
+		sc := &SetupBundleCommand{overwrite: true, trust: true}
+		plan, err := sc.plan(b)
+		require.NoError(t, err)
+		assert.Contains(t, plan.Config, bundle.ConfigChange{Key: "defaults", Action: bundle.ActionReplace})
+		assert.Equal(t, SetupActionReinstall, plan.Plugins[0].Action)
+		assert.Equal(t, SetupActionTrust, plan.Trusted[0].Action)
+		assert.Len(t, describeSetupReplacements(plan), 2)
+
+		_, err = sc.apply(b, plan)
+		require.NoError(t, err)
+
+		raw, err := readRawConfig(setupConfigPath())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"test": map[string]any{"processes": 8}}, raw["defaults"])
+
+		status, _, err := trust.NewStore(setupTrustPath()).Status(filepath.Join(home, "src", "acme", "api"))
+		require.NoError(t, err)
+		assert.Equal(t, trust.Trusted, status)
+	})
+
+	t.Run("removes a plugin whose checksum differs", func(t *testing.T) {
+		stubSetupPaths(t)
+		stubInstall(t, []byte("tampered"))
+		b := newBundle()
+
+		sc := &SetupBundleCommand{}
+		plan, err := sc.plan(b)
+		require.NoError(t, err)
+		result, err := sc.apply(b, plan)
+		require.NoError(t, err)
+
+		assert.Contains(t, result.Plugins[0].Error, "checksum")
+		assert.NoFileExists(t, filepath.Join(setupPluginDir(), "glide-plugin-go"))
+	})
+}
@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// sharedWorktree is the worktree label of the shared services' resources
+const sharedWorktree = "shared"
+
+var (
+	// sharedServicesConfig returns the shared_services of the project's
+	// .glide.yml and is replaced in tests
+	sharedServicesConfig = func() []string { return localProjectConfig().SharedServices }
+
+	// createDatabase and runDockerOutput are replaced in tests
+	createDatabase  = docker.CreateDatabase
+	runDockerOutput = func(dir string, args ...string) ([]byte, error) {
+		cmd := exec.Command("docker", args...)
+		cmd.Dir = dir
+		cmd.Env = metaEnvironment()
+		return cmd.CombinedOutput()
+	}
+
+	// databaseAttempts and databaseRetryDelay bound the wait for a shared
+	// database that was just started to accept connections
+	databaseAttempts   = 10
+	databaseRetryDelay = time.Second
+)
+
+// sharedServices returns the services the worktrees of ctx's project share;
+// none outside multi-worktree mode
+func sharedServices(ctx *context.ProjectContext) []string {
+	if ctx == nil || ctx.ProjectRoot == "" || ctx.DevelopmentMode != context.ModeMultiWorktree {
+		return nil
+	}
+	return sharedServicesConfig()
+}
+
+// sharedProjectName returns the compose project the shared services run in,
+// which also names the network worktrees reach them over
+func sharedProjectName(ctx *context.ProjectContext) string {
+	ns, _ := worktreeNamespace(&context.ProjectContext{
+		ProjectRoot:     ctx.ProjectRoot,
+		DevelopmentMode: context.ModeMultiWorktree,
+		IsMainRepo:      true,
+	})
+	return ns.Project + "-" + sharedWorktree
+}
+
+// sharedOverridePath returns the generated override making a worktree use
+// the shared services
+func sharedOverridePath(ctx *context.ProjectContext, worktree string) string {
+	return filepath.Join(ctx.ProjectRoot, branding.GetPluginDirName(), "compose", worktree+".shared.yml")
+}
+
+// ApplySharedServices makes compose use the project's shared services in the
+// current worktree instead of its own copies. Like ApplyOwnershipLabels it
+// appends a generated override to COMPOSE_FILE, and it never fails: without
+// it the worktree runs its own services.
+func ApplySharedServices(ctx *context.ProjectContext) {
+	if len(sharedServices(ctx)) == 0 {
+		return
+	}
+	dir, _ := currentWorktree(ctx)
+	files, ok := sharedComposeFiles(ctx, docker.ComposeFiles(dir))
+	if !ok {
+		return
+	}
+	if err := os.Setenv("COMPOSE_FILE", strings.Join(files, string(os.PathListSeparator))); err != nil {
+		logging.Debug("Could not set COMPOSE_FILE", "error", err)
+	}
+}
+
+// sharedComposeFiles returns files, the compose files of ctx's worktree,
+// followed by its shared services override, writing the override when it is
+// out of date. It reports false when the project shares no services or
+// files already include the override.
+func sharedComposeFiles(ctx *context.ProjectContext, files []string) ([]string, bool) {
+	shared := sharedServices(ctx)
+	dir, worktree := currentWorktree(ctx)
+	overridePath := sharedOverridePath(ctx, worktree)
+
+	if len(shared) == 0 || len(files) == 0 || slices.Contains(files, overridePath) {
+		return nil, false
+	}
+	if dotenvSets(filepath.Join(dir, ".env"), "COMPOSE_FILE") {
+		logging.Debug("Not using shared services: COMPOSE_FILE is set in .env", "dir", dir)
+		return nil, false
+	}
+
+	cwd, _ := os.Getwd()
+	configPaths, _ := config.DiscoverConfigs(cwd)
+	if !overrideNewer(overridePath, append(slices.Clone(files), configPaths...)) {
+		project, err := loadComposeProject(dir, "*")
+		if err != nil {
+			logging.Debug("Not using shared services", "error", err)
+			return nil, false
+		}
+		if err := project.SharedOverride(shared, sharedProjectName(ctx)).WriteFile(overridePath); err != nil {
+			logging.Debug("Could not write shared services override", "error", err)
+			return nil, false
+		}
+		ignoreGenerated(filepath.Dir(overridePath))
+	}
+
+	return append(files, overridePath), true
+}
+
+// overrideNewer reports whether the file at path exists and is newer than
+// every one of files
+func overrideNewer(path string, files []string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	for _, file := range files {
+		if fileInfo, err := os.Stat(file); err == nil && fileInfo.ModTime().After(info.ModTime()) {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutComposeEnv runs fn with COMPOSE_FILE and COMPOSE_PROJECT_NAME
+// unset, so compose resolves a directory as its own first glide command
+// would
+func withoutComposeEnv(fn func()) {
+	for _, key := range []string{"COMPOSE_FILE", composeProjectNameEnv} {
+		if current, ok := os.LookupEnv(key); ok {
+			_ = os.Unsetenv(key)
+			defer os.Setenv(key, current)
+		}
+	}
+	fn()
+}
+
+// SharedServicesLifecycle starts the project's shared services before `up`
+// and creates the worktree's database in them
+func SharedServicesLifecycle(ctx *context.ProjectContext) Middleware {
+	return Middleware{
+		Name:    "shared-services",
+		Applies: projectCommands(ctx, "up"),
+		Wrap: func(_ *cobra.Command, next RunFunc) RunFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if err := startSharedServices(ctx, os.Environ()); err != nil {
+					return err
+				}
+				return next(cmd, args)
+			}
+		},
+	}
+}
+
+// startSharedServices starts the shared services that are not running, in
+// their own compose project built from the main repository's compose files,
+// and creates the database of ctx's worktree, named by GLIDE_DB_NAME in env,
+// in the database services
+func startSharedServices(ctx *context.ProjectContext, env []string) error {
+	shared := sharedServices(ctx)
+	ns, ok := worktreeNamespace(ctx)
+	if len(shared) == 0 || !ok {
+		return nil
+	}
+	vcsDir := filepath.Join(ctx.ProjectRoot, "vcs")
+	name := sharedProjectName(ctx)
+
+	var files []string
+	var project *docker.ComposeProject
+	var err error
+	withoutComposeEnv(func() {
+		files = docker.ComposeFiles(vcsDir)
+		project, err = loadComposeProject(vcsDir, "*")
+	})
+	if err != nil {
+		return err
+	}
+	services, missing := project.SharedServiceNames(shared)
+	if len(missing) > 0 {
+		return glideErrors.NewConfigError(
+			fmt.Sprintf("shared service(s) %s are not defined in the compose files of vcs/", strings.Join(missing, ", ")),
+			glideErrors.WithSuggestions("Fix shared_services in .glide.yml"),
+		)
+	}
+
+	overridePath := filepath.Join(ctx.ProjectRoot, branding.GetPluginDirName(), "compose", sharedWorktree+"-services.yml")
+	override := project.SharedHostOverride(services, name)
+	owner := docker.Ownership{Project: ctx.ProjectRoot, Worktree: sharedWorktree, Version: version.Get()}
+	for key, value := range owner.Labels() {
+		for _, service := range services {
+			override.Service(service).SetLabel(key, value)
+		}
+		override.Network(docker.SharedNetworkKey).SetLabel(key, value)
+	}
+	if err := override.WriteFile(overridePath); err != nil {
+		return err
+	}
+	ignoreGenerated(filepath.Dir(overridePath))
+
+	started := false
+	if !sharedServicesRunning(name, services) {
+		output.Info("🔗 Starting shared services: %s", strings.Join(services, ", "))
+		upEnv := append(metaEnvironment(), "COMPOSE_FILE="+strings.Join(append(files, overridePath), string(os.PathListSeparator)))
+		args := append([]string{"compose", "--project-name", name, "up", "--detach", "--wait"}, services...)
+		if err := runWorktreeStep(vcsDir, upEnv, "docker", args...); err != nil {
+			return glideErrors.NewDockerError("failed to start the shared services",
+				glideErrors.WithError(err),
+				glideErrors.WithContext("compose_project", name),
+				glideErrors.WithSuggestions(
+					"Check their logs: docker compose --project-name "+name+" logs",
+					"Or run this worktree's own copies by removing shared_services from .glide.yml",
+				),
+			)
+		}
+		started = true
+	}
+
+	database := ns.Database
+	for _, kv := range env {
+		if value, ok := strings.CutPrefix(kv, databaseNameEnv+"="); ok && value != "" {
+			database = value
+		}
+	}
+	createSharedDatabases(project, name, services, database, started)
+	return nil
+}
+
+// sharedServicesRunning reports whether every one of services runs in the
+// compose project name
+func sharedServicesRunning(name string, services []string) bool {
+	containers, err := listContainers(docker.ComposeProjectLabel + "=" + name)
+	if err != nil {
+		return false
+	}
+	for _, service := range services {
+		if sharedContainer(containers, service) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// sharedContainer returns the running container of a shared service
+func sharedContainer(containers []docker.ProjectContainer, service string) string {
+	for _, c := range containers {
+		if c.Service == service && c.State == "running" {
+			return c.Name
+		}
+	}
+	return ""
+}
+
+// createSharedDatabases creates database in the shared database services.
+// Services just started get a few attempts while they come up. Failures
+// only warn, since the application may create its database itself.
+func createSharedDatabases(project *docker.ComposeProject, name string, services []string, database string, started bool) {
+	containers, _ := listContainers(docker.ComposeProjectLabel + "=" + name)
+	attempts := 1
+	if started {
+		attempts = databaseAttempts
+	}
+
+	for _, service := range services {
+		container := sharedContainer(containers, service)
+		if container == "" {
+			continue
+		}
+		var err error
+		for attempt := 0; attempt < attempts; attempt++ {
+			if attempt > 0 {
+				time.Sleep(databaseRetryDelay)
+			}
+			if err = createDatabase(container, project.Services[service].Image, database); err == nil || errors.Is(err, docker.ErrUnsupportedDatabase) {
+				break
+			}
+		}
+		if err != nil && !errors.Is(err, docker.ErrUnsupportedDatabase) {
+			output.Warning("⚠️  Could not create database %s in shared service %s: %v", database, service, err)
+		}
+	}
+}
+
+// stopSharedServices stops the shared services of the project, removing
+// their volumes with removeVolumes
+func stopSharedServices(ctx *context.ProjectContext, removeOrphans, removeVolumes bool) error {
+	args := []string{"compose", "--project-name", sharedProjectName(ctx), "down"}
+	if removeOrphans {
+		args = append(args, "--remove-orphans")
+	}
+	if removeVolumes {
+		args = append(args, "--volumes")
+	}
+	if out, err := runDockerOutput(filepath.Join(ctx.ProjectRoot, "vcs"), args...); err != nil {
+		return glideErrors.NewDockerError("failed to stop the shared services",
+			glideErrors.WithError(err),
+			glideErrors.WithContext("output", strings.TrimSpace(string(out))),
+		)
+	}
+	return nil
+}
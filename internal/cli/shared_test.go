@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSharedServices makes the project share services
+func stubSharedServices(t *testing.T, services ...string) {
+	t.Helper()
+	original := sharedServicesConfig
+	sharedServicesConfig = func() []string { return services }
+	t.Cleanup(func() { sharedServicesConfig = original })
+}
+
+// stubCreateDatabase records the databases created instead of creating them
+func stubCreateDatabase(t *testing.T, err error) *[]string {
+	t.Helper()
+	var created []string
+	original, delay := createDatabase, databaseRetryDelay
+	databaseRetryDelay = 0
+	createDatabase = func(container, image, name string) error {
+		created = append(created, container+" "+name)
+		if image == "redis:7" {
+			return docker.ErrUnsupportedDatabase
+		}
+		return err
+	}
+	t.Cleanup(func() { createDatabase, databaseRetryDelay = original, delay })
+	return &created
+}
+
+func testSharedContext(t *testing.T) *glideContext.ProjectContext {
+	t.Helper()
+	root := filepath.Join(t.TempDir(), "shop")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "vcs"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "worktrees", "feature-x"), 0755))
+	return &glideContext.ProjectContext{ProjectRoot: root, DevelopmentMode: glideContext.ModeMultiWorktree, IsWorktree: true, WorktreeName: "feature-x"}
+}
+
+func testSharedComposeProject() *docker.ComposeProject {
+	return &docker.ComposeProject{Services: map[string]docker.ComposeService{
+		"mysql": {Image: "mysql:8.0"},
+		"redis": {Image: "redis:7"},
+		"php":   {DependsOn: map[string]docker.ComposeDependency{"mysql": {}}},
+	}}
+}
+
+func TestSharedComposeFiles(t *testing.T) {
+	ctx := testSharedContext(t)
+	stubComposeProject(t, testSharedComposeProject())
+	t.Chdir(t.TempDir())
+
+	files := []string{filepath.Join(ctx.ProjectRoot, "worktrees", "feature-x", "compose.yaml")}
+	_, ok := sharedComposeFiles(ctx, files)
+	assert.False(t, ok, "nothing is shared by default")
+
+	stubSharedServices(t, "mysql")
+	withOverride, ok := sharedComposeFiles(ctx, files)
+	require.True(t, ok)
+	overridePath := filepath.Join(ctx.ProjectRoot, ".glide", "compose", "feature-x.shared.yml")
+	assert.Equal(t, append(files, overridePath), withOverride)
+
+	override, err := docker.LoadOverride(overridePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{docker.SharedProfile}, override.Services["mysql"].Profiles)
+	assert.Equal(t, "shop-shared", override.Networks[docker.SharedNetworkKey].Name)
+	assert.Empty(t, override.Services["php"].DependsOn.Services)
+
+	_, ok = sharedComposeFiles(ctx, withOverride)
+	assert.False(t, ok, "the override is only added once")
+}
+
+func TestStartSharedServices(t *testing.T) {
+	ctx := testSharedContext(t)
+	stubSharedServices(t, "mysql", "redis")
+	stubComposeProject(t, testSharedComposeProject())
+	stubListContainers(t)
+	steps := stubWorktreeSteps(t, "", nil)
+	created := stubCreateDatabase(t, nil)
+	t.Chdir(t.TempDir())
+
+	require.NoError(t, startSharedServices(ctx, []string{"GLIDE_DB_NAME=custom_db"}))
+	assert.Equal(t, []string{"docker compose --project-name shop-shared up --detach --wait mysql redis"}, *steps)
+	assert.Empty(t, *created, "no database without a running container")
+	override, err := docker.LoadOverride(filepath.Join(ctx.ProjectRoot, ".glide", "compose", "shared-services.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, sharedWorktree, override.Services["mysql"].Labels[docker.LabelWorktree])
+
+	stubListContainers(t,
+		docker.ProjectContainer{Name: "shop-shared-mysql-1", Service: "mysql", State: "running"},
+		docker.ProjectContainer{Name: "shop-shared-redis-1", Service: "redis", State: "running"},
+	)
+	steps = stubWorktreeSteps(t, "", nil)
+	require.NoError(t, startSharedServices(ctx, nil))
+	assert.Empty(t, *steps, "running shared services are not started again")
+	assert.Equal(t, []string{"shop-shared-mysql-1 shop_feature_x", "shop-shared-redis-1 shop_feature_x"}, *created)
+
+	created = stubCreateDatabase(t, errors.New("connection refused"))
+	require.NoError(t, startSharedServices(ctx, nil), "database failures only warn")
+	assert.Len(t, *created, 2, "running services get one attempt")
+}
+
+func TestStartSharedServices_Errors(t *testing.T) {
+	ctx := testSharedContext(t)
+	stubComposeProject(t, testSharedComposeProject())
+	stubListContainers(t)
+	t.Chdir(t.TempDir())
+
+	stubSharedServices(t, "mysql", "postgres")
+	err := startSharedServices(ctx, nil)
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeConfig))
+	assert.Contains(t, err.Error(), "postgres")
+
+	stubSharedServices(t, "mysql")
+	stubWorktreeSteps(t, "up", errors.New("exit status 1"))
+	err = startSharedServices(ctx, nil)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeDocker))
+
+	single := &glideContext.ProjectContext{ProjectRoot: ctx.ProjectRoot, DevelopmentMode: glideContext.ModeSingleRepo}
+	assert.NoError(t, startSharedServices(single, nil), "services are only shared between worktrees")
+}
@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/snapshot"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// SnapshotCommand handles workspace snapshots
+type SnapshotCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+}
+
+// NewSnapshotCommand creates the snapshot command group
+func NewSnapshotCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	sc := &SnapshotCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Capture and restore workspace state",
+		Long: `Capture and restore the state of the current worktree.
+
+A snapshot contains:
+  - Environment files (.env* by default)
+  - Database dumps for services listed under snapshot.databases in .glide.yml
+  - Archives of the compose project's named Docker volumes
+
+Snapshots are stored per worktree under .glide/snapshots/ in the project root.
+Restore one when switching between branches with divergent schemas.
+
+Configuration (.glide.yml):
+  snapshot:
+    env_files: [".env", ".env.local"]
+    databases:
+      - service: mysql
+        dump: mysqldump -uroot --all-databases
+        restore: mysql -uroot
+
+Examples:
+  glide snapshot create before-migration   # Capture the current state
+  glide snapshot list                      # List snapshots for this worktree
+  glide snapshot restore before-migration  # Restore a snapshot
+  glide snapshot delete before-migration   # Delete a snapshot`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(sc.newCreateCommand())
+	cmd.AddCommand(sc.newListCommand())
+	cmd.AddCommand(sc.newRestoreCommand())
+	cmd.AddCommand(sc.newDeleteCommand())
+
+	return cmd
+}
+
+// newCreateCommand creates the snapshot create subcommand
+func (sc *SnapshotCommand) newCreateCommand() *cobra.Command {
+	var opts snapshot.Options
+
+	cmd := &cobra.Command{
+		Use:           "create [name]",
+		Short:         "Capture a new snapshot (default name: current timestamp)",
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := sc.manager()
+			if err != nil {
+				return err
+			}
+
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+
+			if err := requireDatabaseTrust(opts); err != nil {
+				return err
+			}
+
+			output.Info("📸 Creating snapshot...")
+			manifest, err := manager.Create(name, opts)
+			if err != nil {
+				return err
+			}
+
+			output.Success("✅ Snapshot '%s' created", manifest.Name)
+			showSnapshotContents(manifest)
+			return nil
+		},
+	}
+
+	addSnapshotSkipFlags(cmd, &opts)
+	return cmd
+}
+
+// newListCommand creates the snapshot list subcommand
+func (sc *SnapshotCommand) newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "list",
+		Aliases:       []string{"ls"},
+		Short:         "List snapshots for the current worktree",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := sc.manager()
+			if err != nil {
+				return err
+			}
+
+			manifests, err := manager.List()
+			if err != nil {
+				return err
+			}
+
+			if output.GetFormat().IsStructured() {
+				return output.Display(manifests)
+			}
+
+			if len(manifests) == 0 {
+				output.Info("No snapshots found. Create one with: glide snapshot create")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			// Safe to ignore: Table formatting (informational display only)
+			_, _ = fmt.Fprintln(w, "NAME\tCREATED\tENV FILES\tDATABASES\tVOLUMES")
+			for _, m := range manifests {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n",
+					m.Name,
+					m.CreatedAt.Local().Format("2006-01-02 15:04"),
+					len(m.EnvFiles),
+					len(m.Databases),
+					len(m.Volumes),
+				)
+			}
+			_ = w.Flush()
+			return nil
+		},
+	}
+}
+
+// newRestoreCommand creates the snapshot restore subcommand
+func (sc *SnapshotCommand) newRestoreCommand() *cobra.Command {
+	var opts snapshot.Options
+
+	cmd := &cobra.Command{
+		Use:           "restore <name>",
+		Short:         "Restore a snapshot, replacing current state",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := sc.manager()
+			if err != nil {
+				return err
+			}
+
+			if err := requireDatabaseTrust(opts); err != nil {
+				return err
+			}
+
+			output.Info("⏪ Restoring snapshot '%s'...", args[0])
+			manifest, err := manager.Restore(args[0], opts)
+			if err != nil {
+				return err
+			}
+
+			output.Success("✅ Snapshot '%s' restored", manifest.Name)
+			return nil
+		},
+	}
+
+	addSnapshotSkipFlags(cmd, &opts)
+	MarkDestructive(cmd, sc.restoreTargets)
+	return cmd
+}
+
+// newDeleteCommand creates the snapshot delete subcommand
+func (sc *SnapshotCommand) newDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "delete <name>",
+		Aliases:       []string{"rm"},
+		Short:         "Delete a snapshot",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := sc.manager()
+			if err != nil {
+				return err
+			}
+
+			if err := manager.Delete(args[0]); err != nil {
+				return err
+			}
+
+			output.Success("✅ Snapshot '%s' deleted", args[0])
+			return nil
+		},
+	}
+
+	MarkDestructive(cmd, func(cmd *cobra.Command, args []string) []string {
+		manager, err := sc.manager()
+		if err != nil || len(args) == 0 {
+			return nil
+		}
+		return []string{fmt.Sprintf("Snapshot directory %s", filepath.Join(manager.StoreDir(), args[0]))}
+	})
+	return cmd
+}
+
+// restoreTargets describes what restoring a snapshot overwrites
+func (sc *SnapshotCommand) restoreTargets(cmd *cobra.Command, args []string) []string {
+	manager, err := sc.manager()
+	if err != nil || len(args) == 0 {
+		return nil
+	}
+	manifest, err := manager.Get(args[0])
+	if err != nil {
+		return nil
+	}
+
+	skipEnv, _ := cmd.Flags().GetBool("no-env")
+	skipDatabases, _ := cmd.Flags().GetBool("no-databases")
+	skipVolumes, _ := cmd.Flags().GetBool("no-volumes")
+
+	var targets []string
+	if !skipEnv {
+		for _, file := range manifest.EnvFiles {
+			targets = append(targets, "Current contents of "+file)
+		}
+	}
+	if !skipDatabases {
+		for _, service := range manifest.Databases {
+			targets = append(targets, fmt.Sprintf("Current data of the database in service %s", service))
+		}
+	}
+	if !skipVolumes {
+		for _, volume := range manifest.Volumes {
+			targets = append(targets, fmt.Sprintf("Current contents of Docker volume %s", volume))
+		}
+	}
+	return targets
+}
+
+// manager creates a snapshot manager for the current worktree
+func (sc *SnapshotCommand) manager() (*snapshot.Manager, error) {
+	if sc.ctx == nil || sc.ctx.ProjectRoot == "" {
+		return nil, glideErrors.NewConfigError("snapshots require a project",
+			glideErrors.WithSuggestions("Run this command from inside your project directory"),
+		)
+	}
+
+	worktreeDir, worktreeName := currentWorktree(sc.ctx)
+	storeDir := filepath.Join(sc.ctx.ProjectRoot, branding.GetPluginDirName(), "snapshots", worktreeName)
+
+	return snapshot.NewManager(storeDir, worktreeDir, worktreeName, localProjectConfig().Snapshot), nil
+}
+
+// requireDatabaseTrust checks that the project is trusted before running
+// the dump and restore commands its config defines for databases
+func requireDatabaseTrust(opts snapshot.Options) error {
+	if opts.SkipDatabases {
+		return nil
+	}
+	cwd, _ := os.Getwd()
+	dir := projectTrustDir(cwd)
+	if dir == "" || len(localProjectConfig().Snapshot.Databases) == 0 {
+		return nil
+	}
+	return requireTrust(dir)
+}
+
+// addSnapshotSkipFlags adds the flags that exclude parts of a snapshot
+func addSnapshotSkipFlags(cmd *cobra.Command, opts *snapshot.Options) {
+	cmd.Flags().BoolVar(&opts.SkipEnv, "no-env", false, "Skip environment files")
+	cmd.Flags().BoolVar(&opts.SkipDatabases, "no-databases", false, "Skip database dumps")
+	cmd.Flags().BoolVar(&opts.SkipVolumes, "no-volumes", false, "Skip Docker volume archives")
+}
+
+// showSnapshotContents prints what a snapshot contains
+func showSnapshotContents(m *snapshot.Manifest) {
+	if len(m.EnvFiles) > 0 {
+		output.Printf("  Env files:  %s\n", strings.Join(m.EnvFiles, ", "))
+	}
+	if len(m.Databases) > 0 {
+		output.Printf("  Databases:  %s\n", strings.Join(m.Databases, ", "))
+	}
+	if len(m.Volumes) > 0 {
+		output.Printf("  Volumes:    %s\n", strings.Join(m.Volumes, ", "))
+	}
+}
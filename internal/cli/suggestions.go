@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// RegisterContextSuggestions registers suggestion providers that use the
+// detected project context to propose next steps for Docker and container errors
+func RegisterContextSuggestions(ctx *context.ProjectContext) {
+	if ctx == nil {
+		return
+	}
+
+	provider := &containerSuggestionProvider{ctx: ctx}
+	glideErrors.RegisterSuggestionProvider(glideErrors.TypeDocker, provider)
+	glideErrors.RegisterSuggestionProvider(glideErrors.TypeContainer, provider)
+}
+
+// containerSuggestionProvider suggests commands based on the state of the
+// project's compose services
+type containerSuggestionProvider struct {
+	ctx *context.ProjectContext
+}
+
+// Suggest implements errors.SuggestionProvider
+func (p *containerSuggestionProvider) Suggest(err *glideErrors.GlideError) []string {
+	if p.ctx.ProjectRoot == "" {
+		if err.Type != glideErrors.TypeContainer {
+			return nil
+		}
+		return []string{
+			fmt.Sprintf("No %s project detected — run this command from inside your project directory", branding.ProjectName),
+		}
+	}
+
+	// Without container state there is nothing concrete to add
+	if len(p.ctx.ContainersStatus) == 0 {
+		return nil
+	}
+
+	service, ok := err.GetContext("service")
+	if !ok {
+		service, ok = err.GetContext("container")
+	}
+	if ok && service != "" {
+		return p.suggestForService(service)
+	}
+
+	var suggestions []string
+	for _, name := range p.serviceNames() {
+		suggestions = append(suggestions, p.suggestForService(name)...)
+	}
+	return suggestions
+}
+
+// suggestForService returns suggestions for a single compose service
+func (p *containerSuggestionProvider) suggestForService(service string) []string {
+	status, ok := p.ctx.ContainersStatus[service]
+	if !ok {
+		return []string{
+			fmt.Sprintf("Service '%s' is not defined in this project (available: %s)", service, strings.Join(p.serviceNames(), ", ")),
+		}
+	}
+
+	switch {
+	case status.Status != string(context.ContainerRunning):
+		return []string{
+			fmt.Sprintf("Service '%s' is not running — run `%s up %s`", service, branding.CommandName, service),
+		}
+	case status.Health == "unhealthy":
+		return []string{
+			fmt.Sprintf("Service '%s' is unhealthy — check `%s logs %s`", service, branding.CommandName, service),
+		}
+	default:
+		return nil
+	}
+}
+
+// serviceNames returns the known compose services in sorted order
+func (p *containerSuggestionProvider) serviceNames() []string {
+	names := make([]string, 0, len(p.ctx.ContainersStatus))
+	for name := range p.ctx.ContainersStatus {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
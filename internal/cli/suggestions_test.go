@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerSuggestionProvider(t *testing.T) {
+	ctx := &context.ProjectContext{
+		ProjectRoot: "/project",
+		ContainersStatus: map[string]context.ContainerStatus{
+			"app":   {Name: "app", Status: "running", Health: "healthy"},
+			"db":    {Name: "db", Status: "exited"},
+			"redis": {Name: "redis", Status: "running", Health: "unhealthy"},
+		},
+	}
+	p := &containerSuggestionProvider{ctx: ctx}
+
+	t.Run("stopped service", func(t *testing.T) {
+		suggestions := p.Suggest(glideErrors.NewContainerError("db", "connection refused"))
+		assert.Equal(t, []string{"Service 'db' is not running — run `glide up db`"}, suggestions)
+	})
+
+	t.Run("unhealthy service", func(t *testing.T) {
+		err := glideErrors.NewDockerError("timeout", glideErrors.WithContext("service", "redis"))
+		assert.Equal(t, []string{"Service 'redis' is unhealthy — check `glide logs redis`"}, p.Suggest(err))
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		suggestions := p.Suggest(glideErrors.NewContainerError("mysql", "no such container"))
+		assert.Equal(t, []string{"Service 'mysql' is not defined in this project (available: app, db, redis)"}, suggestions)
+	})
+
+	t.Run("no service in error lists problem services", func(t *testing.T) {
+		suggestions := p.Suggest(glideErrors.NewDockerError("compose failed"))
+		assert.Len(t, suggestions, 2)
+	})
+
+	t.Run("outside a project", func(t *testing.T) {
+		p := &containerSuggestionProvider{ctx: &context.ProjectContext{}}
+		assert.Len(t, p.Suggest(glideErrors.NewContainerError("db", "failed")), 1)
+		assert.Empty(t, p.Suggest(glideErrors.NewDockerError("daemon not running")))
+	})
+}
@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/pluginstats"
+	"github.com/glide-cli/glide/v3/internal/selftest"
+	"github.com/glide-cli/glide/v3/internal/supportbundle"
+	"github.com/glide-cli/glide/v3/pkg/audit"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/observability"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/glide-cli/glide/v3/pkg/progress"
+	"github.com/spf13/cobra"
+)
+
+// supportPlugins lists the installed plugins for a support bundle and is
+// replaced in tests
+var supportPlugins = func() ([]SupportPlugin, error) {
+	manager := sdk.NewManager(nil)
+	defer manager.Cleanup()
+	if err := manager.DiscoverPlugins(); err != nil {
+		return nil, err
+	}
+
+	plugins := []SupportPlugin{}
+	for _, p := range manager.ListPlugins() {
+		plugin := SupportPlugin{Name: p.Name, Path: p.Path, Builtin: p.Builtin}
+		if p.Metadata != nil {
+			plugin.Version = p.Metadata.Version
+			plugin.Description = p.Metadata.Description
+		}
+		plugins = append(plugins, plugin)
+	}
+	return plugins, nil
+}
+
+// SupportPlugin is a plugin listed in a support bundle
+type SupportPlugin struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Builtin     bool   `json:"builtin,omitempty"`
+}
+
+// supportContext is the project context as written to a support bundle,
+// with its detection error as text
+type supportContext struct {
+	*glideContext.ProjectContext
+	Error string `json:"Error,omitempty"`
+}
+
+// SupportCommand collects diagnostics for bug reports
+type SupportCommand struct {
+	ctx *glideContext.ProjectContext
+	cfg *config.Config
+
+	output     string
+	logLines   int
+	noSelfTest bool
+}
+
+// NewSupportCommand creates the support command group
+func NewSupportCommand(ctx *glideContext.ProjectContext, cfg *config.Config) *cobra.Command {
+	sc := &SupportCommand{ctx: ctx, cfg: cfg}
+
+	cmd := &cobra.Command{
+		Use:           "support",
+		Short:         "Collect diagnostics for bug reports",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	bundle := &cobra.Command{
+		Use:   "bundle",
+		Short: "Write an archive of logs, configuration, and diagnostics",
+		Long: `Gather what is needed to diagnose a problem into one archive to attach to
+a bug report:
+
+  system.json        version, platform, executable, and shell
+  config/            the global and project configuration, secrets redacted
+  context.json       the detected project context
+  plugins.json       the installed plugins and their versions
+  selftest.json      the results of glide selftest
+  metrics.json       metrics of this run and plugin statistics of the last week
+  env.json           the GLIDE_ environment variables that are set
+  logs/              the recent plugin logs, audit log, and plugin health
+
+Values of keys that look secret, such as tokens and passwords, are replaced
+with [REDACTED] and your home directory is written as ~. Sections that could
+not be collected are listed in manifest.json. Look through the archive
+before sharing it.
+
+Examples:
+  glide support bundle
+  glide support bundle -o /tmp/glide-support.tar.gz
+  glide support bundle --no-selftest --log-lines 100`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sc.executeBundle()
+		},
+	}
+	bundle.Flags().StringVarP(&sc.output, "output", "o", "", "Where to write the archive (default: glide-support-<time>.tar.gz)")
+	bundle.Flags().IntVar(&sc.logLines, "log-lines", 500, "Lines to keep from the end of each log; 0 keeps them whole")
+	bundle.Flags().BoolVar(&sc.noSelfTest, "no-selftest", false, "Leave out the self-test, which takes a while with Docker")
+
+	cmd.AddCommand(bundle)
+	return cmd
+}
+
+// executeBundle collects and writes the bundle
+func (sc *SupportCommand) executeBundle() error {
+	path := sc.output
+	if path == "" {
+		path = fmt.Sprintf("%s-support-%s.tar.gz", branding.CommandName, time.Now().Format("20060102-150405"))
+	}
+
+	spinner := progress.NewSpinner("Collecting diagnostics")
+	spinner.Start()
+	b := sc.collect()
+	spinner.Stop()
+
+	if err := b.Write(path); err != nil {
+		return glideErrors.NewPermissionError(path, "failed to write the support bundle", glideErrors.WithError(err))
+	}
+
+	manifest := b.Manifest()
+	output.Success("Wrote %s with %d files", path, len(manifest.Files)+1)
+	for name, reason := range manifest.Errors {
+		output.Warning("Could not collect %s: %s", name, reason)
+	}
+	output.Info("Secrets are redacted, but look through the archive before attaching it to a bug report")
+	return nil
+}
+
+// collect gathers the sections of the bundle
+func (sc *SupportCommand) collect() *supportbundle.Bundle {
+	home, _ := os.UserHomeDir()
+	env := selftest.CurrentEnvironment()
+	b := supportbundle.New(env.Version, home)
+
+	b.AddJSON("system.json", env)
+
+	b.AddConfig("config/global.yml", branding.GetConfigPath())
+	if sc.ctx != nil && sc.ctx.ProjectRoot != "" {
+		b.AddConfig("config/project.yml", filepath.Join(sc.ctx.ProjectRoot, branding.ConfigFileName))
+	}
+
+	if sc.ctx != nil {
+		report := supportContext{ProjectContext: sc.ctx}
+		if sc.ctx.Error != nil {
+			report.Error = sc.ctx.Error.Error()
+		}
+		b.AddJSON("context.json", report)
+	}
+
+	if plugins, err := supportPlugins(); err != nil {
+		b.Fail("plugins.json", err)
+	} else {
+		b.AddJSON("plugins.json", plugins)
+	}
+
+	if !sc.noSelfTest {
+		if exe, err := os.Executable(); err != nil {
+			b.Fail("selftest.json", err)
+		} else {
+			b.AddJSON("selftest.json", runSelfTest(context.Background(), selftest.DefaultChecks(exe)))
+		}
+	}
+
+	metrics := map[string]any{"run": observability.GetSnapshot()}
+	if records, err := pluginstats.Load(pluginstats.DefaultLogPath()); err == nil {
+		metrics["plugins"] = pluginstats.Summarize(records, time.Now().AddDate(0, 0, -7), "")
+	}
+	b.AddJSON("metrics.json", metrics)
+
+	b.AddJSON("env.json", envVarsReport(os.Environ(), true))
+
+	logDir := pluginLogDir()
+	for _, name := range sdk.LoggedPlugins(logDir) {
+		b.AddLog("logs/plugins/"+name+".log", sdk.LogPath(logDir, name), sc.logLines)
+	}
+	b.AddLog("logs/audit.log", audit.DefaultPath(), sc.logLines)
+	b.AddLog("logs/plugin-health.json", filepath.Join(home, branding.GetPluginDirName(), "plugin-health.json"), 0)
+
+	return b
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/selftest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupportBundleCommand(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".glide.yml"), []byte("plugins:\n  deploy:\n    api_token: abc\n"), 0o644))
+
+	origPlugins := supportPlugins
+	supportPlugins = func() ([]SupportPlugin, error) {
+		return []SupportPlugin{{Name: "docker", Version: "1.0.0"}}, nil
+	}
+	t.Cleanup(func() { supportPlugins = origPlugins })
+	stubSelfTest(t, selftest.Result{Name: "shell", Status: selftest.StatusPassed})
+
+	path := filepath.Join(t.TempDir(), "support.tar.gz")
+	ctx := &glideContext.ProjectContext{ProjectRoot: t.TempDir(), Error: errors.New("no compose file")}
+	cmd := NewSupportCommand(ctx, nil)
+	cmd.SetArgs([]string{"bundle", "-o", path})
+	require.NoError(t, cmd.Execute())
+
+	files := readSupportBundle(t, path)
+	assert.Contains(t, files, "manifest.json")
+	assert.Contains(t, files["plugins.json"], `"version": "1.0.0"`)
+	assert.Contains(t, files["selftest.json"], `"status": "passed"`)
+	assert.Contains(t, files["context.json"], `"Error": "no compose file"`)
+	assert.Contains(t, files["config/global.yml"], "api_token: '[REDACTED]'")
+	assert.NotContains(t, files["config/global.yml"], "abc")
+}
+
+func TestSupportBundleCommand_PluginsFail(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	origPlugins := supportPlugins
+	supportPlugins = func() ([]SupportPlugin, error) { return nil, errors.New("discovery failed") }
+	t.Cleanup(func() { supportPlugins = origPlugins })
+
+	path := filepath.Join(t.TempDir(), "support.tar.gz")
+	cmd := NewSupportCommand(nil, nil)
+	cmd.SetArgs([]string{"bundle", "--no-selftest", "-o", path})
+	require.NoError(t, cmd.Execute())
+
+	files := readSupportBundle(t, path)
+	assert.NotContains(t, files, "plugins.json")
+	assert.NotContains(t, files, "selftest.json")
+	assert.Contains(t, files["manifest.json"], `"plugins.json": "discovery failed"`)
+}
+
+// readSupportBundle returns the files of a support bundle by name
+func readSupportBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(data)
+	}
+}
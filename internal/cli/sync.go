@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/filesync"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// newSyncManager is replaced in tests
+var newSyncManager = func(ctx *context.ProjectContext) (*filesync.Manager, error) {
+	if ctx == nil || ctx.ProjectRoot == "" {
+		return nil, glideErrors.NewConfigError("file sync requires a project",
+			glideErrors.WithSuggestions("Run this command from inside your project directory"),
+		)
+	}
+
+	worktreeDir, worktreeName := currentWorktree(ctx)
+	stateFile := filepath.Join(ctx.ProjectRoot, branding.GetPluginDirName(), "sync", worktreeName+".json")
+	return filesync.NewManager(worktreeDir, stateFile, localProjectConfig().Sync), nil
+}
+
+// SyncCommand handles file sync into containers
+type SyncCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+}
+
+// NewSyncCommand creates the sync command group
+func NewSyncCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	sc := &SyncCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync project files into containers",
+		Long: `Sync project files into containers instead of bind-mounting them.
+
+Bind mounts are slow on macOS (osxfs/virtiofs). With sync enabled, files are
+copied into the container with mutagen (continuous, two-way) or rsync
+(one-shot). Sync starts after 'glide up' and stops before 'glide down'.
+
+Configuration (.glide.yml):
+  sync:
+    mode: mutagen            # or rsync; default for every service
+    services:
+      php:
+        source: .            # relative to the worktree
+        target: /var/www/html
+        ignore: [node_modules, vendor]
+
+Examples:
+  glide sync status   # Show sync state per service
+  glide sync start    # Start (or re-run) sync manually
+  glide sync stop     # Stop sync sessions`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(sc.newStatusCommand())
+	cmd.AddCommand(sc.newStartCommand())
+	cmd.AddCommand(sc.newStopCommand())
+
+	return cmd
+}
+
+// newStatusCommand creates the sync status subcommand
+func (sc *SyncCommand) newStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "status",
+		Short:         "Show sync state per service",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := newSyncManager(sc.ctx)
+			if err != nil {
+				return err
+			}
+
+			statuses, err := manager.Status()
+			if err != nil {
+				return err
+			}
+
+			if output.GetFormat().IsStructured() {
+				return output.Display(statuses)
+			}
+
+			if len(statuses) == 0 {
+				output.Info("File sync is not configured. Add a sync section to %s to enable it.", branding.ConfigFileName)
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			// Safe to ignore: Table formatting (informational display only)
+			_, _ = fmt.Fprintln(w, "SERVICE\tMODE\tSTATE\tTARGET\tLAST SYNC")
+			for _, s := range statuses {
+				lastSync := "-"
+				if !s.LastSync.IsZero() {
+					lastSync = output.Ago(s.LastSync)
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", s.Service, s.Mode, s.State, s.Target, lastSync)
+			}
+			_ = w.Flush()
+
+			for _, s := range statuses {
+				if s.Error != "" {
+					output.Warning("%s: %s", s.Service, s.Error)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// newStartCommand creates the sync start subcommand
+func (sc *SyncCommand) newStartCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "start",
+		Short:         "Start syncing files into running services",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := newSyncManager(sc.ctx)
+			if err != nil {
+				return err
+			}
+			if !manager.Enabled() {
+				output.Info("File sync is not configured. Add a sync section to %s to enable it.", branding.ConfigFileName)
+				return nil
+			}
+
+			if err := manager.Start(); err != nil {
+				return err
+			}
+			output.Success("✅ Syncing %d service(s)", len(manager.Services()))
+			return nil
+		},
+	}
+}
+
+// newStopCommand creates the sync stop subcommand
+func (sc *SyncCommand) newStopCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "stop",
+		Short:         "Stop sync sessions",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := newSyncManager(sc.ctx)
+			if err != nil {
+				return err
+			}
+
+			if err := manager.Stop(); err != nil {
+				return err
+			}
+			output.Success("✅ File sync stopped")
+			return nil
+		},
+	}
+}
+
+// SyncLifecycle starts file sync after `up` succeeds and stops it before
+// `down` runs. Sync failures are reported as warnings and never fail the
+// wrapped command.
+func SyncLifecycle(ctx *context.ProjectContext) Middleware {
+	start := func() {
+		manager, err := newSyncManager(ctx)
+		if err != nil || !manager.Enabled() {
+			return
+		}
+		if err := manager.Start(); err != nil {
+			output.Warning("File sync did not start: %v", err)
+			return
+		}
+		output.Info("🔄 Syncing %d service(s); see: %s sync status", len(manager.Services()), branding.CommandName)
+	}
+	stop := func() {
+		manager, err := newSyncManager(ctx)
+		if err != nil || !manager.Enabled() {
+			return
+		}
+		if err := manager.Stop(); err != nil {
+			output.Warning("File sync did not stop cleanly: %v", err)
+		}
+	}
+
+	return Middleware{
+		Name:    "sync",
+		Applies: topLevel("up", "down"),
+		Wrap: func(cmd *cobra.Command, next RunFunc) RunFunc {
+			if cmd.Name() == "up" {
+				return around(next, nil, start)
+			}
+			return around(next, stop, nil)
+		},
+	}
+}
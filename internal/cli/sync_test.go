@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/filesync"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncLifecycle(t *testing.T) {
+	var requested []string
+	original := newSyncManager
+	newSyncManager = func(ctx *context.ProjectContext) (*filesync.Manager, error) {
+		requested = append(requested, "manager")
+		return nil, errors.New("no project")
+	}
+	defer func() { newSyncManager = original }()
+
+	ran := map[string]bool{}
+	root := &cobra.Command{Use: "glide"}
+	for _, name := range []string{"up", "down", "status"} {
+		name := name
+		root.AddCommand(&cobra.Command{Use: name, RunE: func(*cobra.Command, []string) error {
+			ran[name] = true
+			return nil
+		}})
+	}
+
+	Chain{SyncLifecycle(nil)}.Apply(root)
+
+	for _, name := range []string{"up", "down", "status"} {
+		cmd, _, err := root.Find([]string{name})
+		require.NoError(t, err)
+		require.NoError(t, cmd.RunE(cmd, nil), "sync problems never fail %s", name)
+		assert.True(t, ran[name])
+	}
+
+	// Only up and down consult the sync configuration
+	assert.Len(t, requested, 2)
+}
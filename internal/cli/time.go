@@ -0,0 +1,296 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/cleanup"
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/internal/timetrack"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// timeLogPath returns the time tracking log and is replaced in tests
+var timeLogPath = timetrack.DefaultLogPath
+
+// TimeReport is the result of `glide time report`
+type TimeReport struct {
+	From       time.Time       `json:"from" yaml:"from"`
+	To         time.Time       `json:"to" yaml:"to"`
+	By         string          `json:"by" yaml:"by"`
+	Rows       []timetrack.Row `json:"rows" yaml:"rows"`
+	TotalHours float64         `json:"total_hours" yaml:"total_hours"`
+	// Running is the number of sessions whose containers are still up
+	Running int `json:"running" yaml:"running"`
+}
+
+// TimeCommand reports tracked development time
+type TimeCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+
+	since   string
+	until   string
+	by      string
+	project string
+}
+
+// NewTimeCommand creates the time command group
+func NewTimeCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	tc := &TimeCommand{ctx: ctx, cfg: cfg}
+
+	cmd := &cobra.Command{
+		Use:   "time",
+		Short: "Report development time per project and branch",
+		Long: `Report how long each worktree's containers were up, as a measure of active
+development time.
+
+A session starts when 'up' succeeds and ends when 'down' succeeds. Sessions
+whose containers were stopped some other way end when the last container
+stopped. Sessions are recorded in ~/.glide/time.jsonl.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	report := &cobra.Command{
+		Use:   "report",
+		Short: "Summarize development time over a date range",
+		Long: `Summarize development time per branch, project, or day.
+
+--since and --until take a date (2006-01-02), "today", or an age such as
+"7d" or "36h" before now.
+
+Examples:
+  glide time report                               # The last 7 days, per branch
+  glide time report --since 2026-10-01 --by day   # Per day since October 1st
+  glide time report --by project --project acme   # One project's total
+  glide time report --format json                 # For timesheet tooling`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tc.executeReport()
+		},
+	}
+	report.Flags().StringVar(&tc.since, "since", "7d", "Start of the range")
+	report.Flags().StringVar(&tc.until, "until", "", "End of the range (default: now)")
+	report.Flags().StringVar(&tc.by, "by", timetrack.ByBranch, "Group by branch, project, or day")
+	report.Flags().StringVar(&tc.project, "project", "", "Only this project, by directory name or path")
+
+	cmd.AddCommand(report)
+	return cmd
+}
+
+// executeReport summarizes the recorded sessions
+func (tc *TimeCommand) executeReport() error {
+	if !timetrack.ValidGrouping(tc.by) {
+		return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("invalid grouping %q", tc.by),
+			glideErrors.WithSuggestions("Use --by branch, --by project, or --by day"),
+		)
+	}
+	now := time.Now()
+	from, err := parseReportTime("--since", tc.since, now)
+	if err != nil {
+		return err
+	}
+	to := now
+	if tc.until != "" {
+		if to, err = parseReportTime("--until", tc.until, now); err != nil {
+			return err
+		}
+	}
+
+	events, err := timetrack.Load(timeLogPath())
+	if err != nil {
+		return glideErrors.NewPermissionError(timeLogPath(), "failed to read the time tracking log", glideErrors.WithError(err))
+	}
+
+	var sessions []timetrack.Session
+	running := 0
+	for _, session := range timetrack.Sessions(events) {
+		if !matchesProject(session.Project, tc.project) {
+			continue
+		}
+		if session.Open {
+			end, isRunning, ok := openSessionEnd(session, now)
+			if !ok {
+				logging.Debug("Cannot tell when a session ended", "project", session.Project, "worktree", session.Worktree)
+				continue
+			}
+			session.End = end
+			if isRunning && end.After(from) {
+				running++
+			}
+		}
+		sessions = append(sessions, session)
+	}
+
+	report := TimeReport{From: from, To: to, By: tc.by, Rows: timetrack.Summarize(sessions, from, to, tc.by), Running: running}
+	total := timetrack.Total(report.Rows)
+	report.TotalHours = float64(int64(total.Hours()*100+0.5)) / 100
+
+	if output.GetFormat().IsStructured() {
+		return output.Display(report)
+	}
+	showTimeReport(report, total)
+	return nil
+}
+
+// TimeTracking records a session start after `up` succeeds and a session
+// stop after `down` succeeds. Recording never fails the wrapped command.
+func TimeTracking(ctx *context.ProjectContext) Middleware {
+	return Middleware{
+		Name:    "time-tracking",
+		Applies: projectCommands(ctx, "up", "down"),
+		Wrap: func(cmd *cobra.Command, next RunFunc) RunFunc {
+			if cmd.Name() == "up" {
+				return around(next, func() {
+					closeStaleSession(ctx)
+				}, func() {
+					recordTimeEvent(ctx, timetrack.KindStart, time.Now())
+				})
+			}
+			return around(next, nil, func() {
+				recordTimeEvent(ctx, timetrack.KindStop, time.Now())
+			})
+		},
+	}
+}
+
+// recordTimeEvent appends an event for the current worktree
+func recordTimeEvent(ctx *context.ProjectContext, kind string, at time.Time) {
+	dir, owner := worktreeOwnership(ctx)
+	event := timetrack.Event{Time: at, Kind: kind, Project: owner.Project, Worktree: owner.Worktree}
+	if kind == timetrack.KindStart {
+		if branch, err := gitOutput(dir, "branch", "--show-current"); err == nil {
+			event.Branch = strings.TrimSpace(branch)
+		}
+	}
+	if err := timetrack.Append(timeLogPath(), event); err != nil {
+		logging.Debug("Could not record time tracking event", "error", err)
+	}
+}
+
+// closeStaleSession ends the open session of the current worktree when its
+// containers were stopped without glide, e.g. by a reboot, so the next `up`
+// starts a new session instead of continuing the old one
+func closeStaleSession(ctx *context.ProjectContext) {
+	_, owner := worktreeOwnership(ctx)
+	events, err := timetrack.Load(timeLogPath())
+	if err != nil {
+		return
+	}
+	session := timetrack.OpenSession(events, owner.Project, owner.Worktree)
+	if session == nil {
+		return
+	}
+	end, running, ok := openSessionEnd(*session, time.Now())
+	if !ok || running {
+		return
+	}
+	recordTimeEvent(ctx, timetrack.KindStop, end)
+}
+
+// openSessionEnd decides when an open session ended: now while any of the
+// worktree's containers run, else when the last of them stopped. ok is false
+// when Docker cannot tell, e.g. because the containers were removed.
+func openSessionEnd(session timetrack.Session, now time.Time) (end time.Time, running bool, ok bool) {
+	owner := docker.Ownership{Project: session.Project, Worktree: session.Worktree}
+	containers, err := listContainers(owner.Filters()...)
+	if err != nil {
+		return time.Time{}, false, false
+	}
+
+	for _, c := range containers {
+		if c.State == "running" {
+			return now, true, true
+		}
+		if c.FinishedAt.After(session.Start) && c.FinishedAt.After(end) {
+			end = c.FinishedAt
+		}
+	}
+	return end, false, !end.IsZero()
+}
+
+// parseReportTime parses a date, "today", or an age before now
+func parseReportTime(flag, value string, now time.Time) (time.Time, error) {
+	if value == "today" {
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+	age, err := cleanup.ParseAge(value)
+	if err != nil {
+		return time.Time{}, glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("invalid %s value %q", flag, value),
+			glideErrors.WithSuggestions(`Use a date such as 2026-10-01, "today", or an age such as "7d"`),
+		)
+	}
+	return now.Add(-age), nil
+}
+
+// matchesProject reports whether a project root matches a --project filter,
+// given as a directory name or path
+func matchesProject(root, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	if abs, err := filepath.Abs(filter); err == nil && abs == root {
+		return true
+	}
+	return filepath.Base(root) == filter
+}
+
+// showTimeReport prints the report as a table
+func showTimeReport(report TimeReport, total time.Duration) {
+	output.Info("⏱  Development time %s – %s", report.From.Format("2006-01-02 15:04"), report.To.Format("2006-01-02 15:04"))
+	output.Println()
+
+	if len(report.Rows) == 0 {
+		output.Info("No development time was recorded in this range")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	switch report.By {
+	case timetrack.ByBranch:
+		// Safe to ignore: Table formatting (informational display only)
+		_, _ = fmt.Fprintln(w, "PROJECT\tBRANCH\tSESSIONS\tTIME\tHOURS")
+	case timetrack.ByDay:
+		// Safe to ignore: Table formatting (informational display only)
+		_, _ = fmt.Fprintln(w, "PROJECT\tDAY\tSESSIONS\tTIME\tHOURS")
+	default:
+		// Safe to ignore: Table formatting (informational display only)
+		_, _ = fmt.Fprintln(w, "PROJECT\tSESSIONS\tTIME\tHOURS")
+	}
+	for _, row := range report.Rows {
+		group := row.Branch
+		if report.By == timetrack.ByDay {
+			group = row.Day
+		}
+		if report.By == timetrack.ByProject {
+			// Safe to ignore: Table formatting (informational display only)
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%.2f\n", row.Project, row.Sessions, timetrack.FormatDuration(row.Duration), row.Hours)
+			continue
+		}
+		// Safe to ignore: Table formatting (informational display only)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%.2f\n", row.Project, group, row.Sessions, timetrack.FormatDuration(row.Duration), row.Hours)
+	}
+	// Safe to ignore: Table formatting (informational display only)
+	_ = w.Flush()
+
+	output.Println()
+	output.Printf("Total: %s (%.2f hours)\n", timetrack.FormatDuration(total), report.TotalHours)
+	if report.Running > 0 {
+		output.Info("Includes %d session(s) still running", report.Running)
+	}
+}
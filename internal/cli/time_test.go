@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/internal/timetrack"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTimeLog points time tracking at a temporary log
+func stubTimeLog(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "time.jsonl")
+	original := timeLogPath
+	timeLogPath = func() string { return path }
+	t.Cleanup(func() { timeLogPath = original })
+	return path
+}
+
+func TestTimeTracking(t *testing.T) {
+	path := stubTimeLog(t)
+	stubListContainers(t)
+
+	root := &cobra.Command{Use: "glide"}
+	failUp := false
+	up := &cobra.Command{Use: "up", RunE: func(*cobra.Command, []string) error {
+		if failUp {
+			return assert.AnError
+		}
+		return nil
+	}}
+	root.AddCommand(up, &cobra.Command{Use: "down", Run: func(*cobra.Command, []string) {}})
+
+	ctx := &context.ProjectContext{ProjectRoot: t.TempDir()}
+	Chain{TimeTracking(ctx)}.Apply(root)
+
+	for _, args := range [][]string{{"up"}, {"down"}} {
+		root.SetArgs(args)
+		require.NoError(t, root.Execute())
+	}
+	failUp = true
+	root.SetArgs([]string{"up"})
+	require.Error(t, root.Execute())
+
+	events, err := timetrack.Load(path)
+	require.NoError(t, err)
+	require.Len(t, events, 2, "failed commands are not recorded")
+	assert.Equal(t, timetrack.KindStart, events[0].Kind)
+	assert.Equal(t, timetrack.KindStop, events[1].Kind)
+	assert.Equal(t, filepath.Base(ctx.ProjectRoot), events[0].Worktree)
+}
+
+func TestCloseStaleSession(t *testing.T) {
+	path := stubTimeLog(t)
+	ctx := &context.ProjectContext{ProjectRoot: t.TempDir()}
+	_, owner := worktreeOwnership(ctx)
+	started := time.Now().Add(-3 * time.Hour)
+	require.NoError(t, timetrack.Append(path, timetrack.Event{Time: started, Kind: timetrack.KindStart, Project: owner.Project, Worktree: owner.Worktree}))
+
+	// Containers still running keep the session open
+	stubListContainers(t, docker.ProjectContainer{State: "running"})
+	closeStaleSession(ctx)
+	events, _ := timetrack.Load(path)
+	assert.Len(t, events, 1)
+
+	// Containers stopped outside glide end it when the last one stopped
+	stopped := started.Add(90 * time.Minute)
+	stubListContainers(t,
+		docker.ProjectContainer{State: "exited", FinishedAt: started.Add(time.Hour)},
+		docker.ProjectContainer{State: "exited", FinishedAt: stopped},
+	)
+	closeStaleSession(ctx)
+	events, _ = timetrack.Load(path)
+	require.Len(t, events, 2)
+	assert.True(t, events[1].Time.Equal(stopped.UTC().Truncate(0)))
+}
+
+func TestParseReportTime(t *testing.T) {
+	now := time.Date(2026, 10, 15, 14, 30, 0, 0, time.Local)
+
+	got, err := parseReportTime("--since", "2026-10-01", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), got)
+
+	got, err = parseReportTime("--since", "today", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local), got)
+
+	got, err = parseReportTime("--since", "7d", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-7*24*time.Hour), got)
+
+	_, err = parseReportTime("--since", "last week", now)
+	assert.Error(t, err)
+}
+
+func TestMatchesProject(t *testing.T) {
+	assert.True(t, matchesProject("/src/acme", ""))
+	assert.True(t, matchesProject("/src/acme", "acme"))
+	assert.True(t, matchesProject("/src/acme", "/src/acme"))
+	assert.False(t, matchesProject("/src/acme", "other"))
+}
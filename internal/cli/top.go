@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/observability"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultTopInterval is the refresh interval of the live view
+const defaultTopInterval = 2 * time.Second
+
+// TopSample is one refresh of `glide top`
+type TopSample struct {
+	Time       time.Time               `json:"time" yaml:"time"`
+	Containers []docker.ContainerStats `json:"containers" yaml:"containers"`
+	Warnings   []string                `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// TopCommand shows resource usage of the project's containers
+type TopCommand struct {
+	ctx *glideContext.ProjectContext
+	cfg *config.Config
+
+	once     bool
+	interval time.Duration
+	profiles []string
+}
+
+// NewTopCommand creates the top command
+func NewTopCommand(ctx *glideContext.ProjectContext, cfg *config.Config) *cobra.Command {
+	tc := &TopCommand{
+		ctx: ctx,
+		cfg: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Show live CPU, memory, network, and disk usage per service",
+		Long: `Show live resource usage of the project's running containers, refreshed
+until interrupted. Use --once to print a single sample as JSON (or YAML with
+--format yaml) for scripts.
+
+Containers above the thresholds configured in .glide.yml are flagged:
+  top:
+    cpu_warn: 80       # percent of one CPU
+    memory_warn: 90    # percent of the container's memory limit
+
+Examples:
+  glide top                  # Live view
+  glide top --interval 5s    # Refresh every five seconds
+  glide top --once           # One JSON sample`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tc.Execute(cmd)
+		},
+	}
+
+	cmd.Flags().BoolVar(&tc.once, "once", false, "Print a single sample as JSON and exit")
+	cmd.Flags().DurationVar(&tc.interval, "interval", defaultTopInterval, "Refresh interval of the live view")
+	cmd.Flags().StringSliceVar(&tc.profiles, "profile", nil, "Compose profiles to include ('*' for all)")
+
+	return cmd
+}
+
+// Execute samples container stats once or until interrupted
+func (tc *TopCommand) Execute(cmd *cobra.Command) error {
+	if tc.interval <= 0 {
+		return glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("--interval must be positive, not %s", tc.interval),
+			glideErrors.WithSuggestions("Refresh every five seconds: glide top --interval 5s"),
+		)
+	}
+	dir, err := composeProjectDir(tc.ctx)
+	if err != nil {
+		return err
+	}
+	project, err := docker.LoadComposeProject(dir, tc.profiles...)
+	if err != nil {
+		return err
+	}
+	thresholds := localProjectConfig().Top
+
+	if tc.once {
+		sample, err := takeTopSample(project, thresholds)
+		if err != nil {
+			return err
+		}
+		return writeTopSample(os.Stdout, sample)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	interactive := stdinIsTerminal()
+	ticker := time.NewTicker(tc.interval)
+	defer ticker.Stop()
+
+	for {
+		sample, err := takeTopSample(project, thresholds)
+		if err != nil {
+			return err
+		}
+		if interactive {
+			// Clear the screen and move the cursor home
+			output.Raw("\033[H\033[2J")
+		}
+		renderTopSample(project.Name, sample)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// takeTopSample collects stats, applies thresholds, and records gauges
+func takeTopSample(project *docker.ComposeProject, thresholds config.TopConfig) (*TopSample, error) {
+	stats, err := project.Stats()
+	if err != nil {
+		return nil, err
+	}
+
+	sample := &TopSample{Time: time.Now().UTC(), Containers: stats}
+	if sample.Containers == nil {
+		sample.Containers = []docker.ContainerStats{}
+	}
+	sample.Warnings = topWarnings(stats, thresholds)
+	recordTopGauges(stats)
+	return sample, nil
+}
+
+// topWarnings returns a message per container above a threshold
+func topWarnings(stats []docker.ContainerStats, thresholds config.TopConfig) []string {
+	var warnings []string
+	for _, s := range stats {
+		if thresholds.CPUWarn > 0 && s.CPUPercent > thresholds.CPUWarn {
+			warnings = append(warnings, fmt.Sprintf("%s: CPU %.1f%% is above %.0f%%", s.Container, s.CPUPercent, thresholds.CPUWarn))
+		}
+		if thresholds.MemoryWarn > 0 && s.MemoryPercent > thresholds.MemoryWarn {
+			warnings = append(warnings, fmt.Sprintf("%s: memory %.1f%% is above %.0f%%", s.Container, s.MemoryPercent, thresholds.MemoryWarn))
+		}
+	}
+	return warnings
+}
+
+// recordTopGauges publishes the sample as observability gauges named
+// container_<metric>, labeled with the container
+func recordTopGauges(stats []docker.ContainerStats) {
+	for _, s := range stats {
+		labels := observability.Labels{observability.LabelContainer: s.Container}
+		observability.SetGaugeWithLabels("container_cpu_percent", s.CPUPercent, labels)
+		observability.SetGaugeWithLabels("container_memory_percent", s.MemoryPercent, labels)
+		observability.SetGaugeWithLabels("container_memory_bytes", float64(s.MemoryUsage), labels)
+		observability.SetGaugeWithLabels("container_network_rx_bytes", float64(s.NetworkRx), labels)
+		observability.SetGaugeWithLabels("container_network_tx_bytes", float64(s.NetworkTx), labels)
+		observability.SetGaugeWithLabels("container_block_read_bytes", float64(s.BlockRead), labels)
+		observability.SetGaugeWithLabels("container_block_write_bytes", float64(s.BlockWrite), labels)
+	}
+}
+
+// writeTopSample writes a sample as YAML when --format yaml is set, and as
+// JSON otherwise
+func writeTopSample(w io.Writer, sample *TopSample) error {
+	if output.GetFormat() == output.FormatYAML {
+		return yaml.NewEncoder(w).Encode(sample)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sample)
+}
+
+// renderTopSample prints a sample as a table
+func renderTopSample(projectName string, sample *TopSample) {
+	output.Info("%s — %s (Ctrl+C to exit)", projectName, sample.Time.Local().Format("15:04:05"))
+
+	if len(sample.Containers) == 0 {
+		output.Info("No running containers. Start the project with: glide up")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, "SERVICE\tCONTAINER\tCPU %\tMEMORY\tMEM %\tNET RX/TX\tBLOCK R/W")
+	for _, s := range sample.Containers {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%.1f\t%s / %s\t%.1f\t%s / %s\t%s / %s\n",
+			s.Service, s.Container, s.CPUPercent,
+			output.Bytes(s.MemoryUsage), output.Bytes(s.MemoryLimit), s.MemoryPercent,
+			output.Bytes(s.NetworkRx), output.Bytes(s.NetworkTx),
+			output.Bytes(s.BlockRead), output.Bytes(s.BlockWrite),
+		)
+	}
+	_ = w.Flush()
+
+	for _, warning := range sample.Warnings {
+		output.Warning("⚠ %s", warning)
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopWarnings(t *testing.T) {
+	stats := []docker.ContainerStats{
+		{Container: "myapp-php-1", CPUPercent: 95, MemoryPercent: 40},
+		{Container: "myapp-mysql-1", CPUPercent: 10, MemoryPercent: 92.5},
+		{Container: "myapp-redis-1", CPUPercent: 1, MemoryPercent: 1},
+	}
+
+	assert.Empty(t, topWarnings(stats, config.TopConfig{}), "thresholds are disabled by default")
+
+	warnings := topWarnings(stats, config.TopConfig{CPUWarn: 80, MemoryWarn: 90})
+	assert.Equal(t, []string{
+		"myapp-php-1: CPU 95.0% is above 80%",
+		"myapp-mysql-1: memory 92.5% is above 90%",
+	}, warnings)
+}
+
+func TestRecordTopGauges(t *testing.T) {
+	recordTopGauges([]docker.ContainerStats{{Container: "myapp-php-1", CPUPercent: 42.5, MemoryUsage: 1024}})
+
+	labels := observability.Labels{observability.LabelContainer: "myapp-php-1"}
+	assert.Equal(t, 42.5, observability.DefaultMetricsCollector.GetGaugeWithLabels("container_cpu_percent", labels))
+	assert.Equal(t, 1024.0, observability.DefaultMetricsCollector.GetGaugeWithLabels("container_memory_bytes", labels))
+}
+
+func TestWriteTopSample(t *testing.T) {
+	sample := &TopSample{
+		Time:       time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Containers: []docker.ContainerStats{{Service: "php", Container: "myapp-php-1", CPUPercent: 1.5}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeTopSample(&buf, sample))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "2026-01-02T03:04:05Z", decoded["time"])
+	containers := decoded["containers"].([]interface{})
+	require.Len(t, containers, 1)
+	assert.Equal(t, "php", containers[0].(map[string]interface{})["service"])
+}
+
+func TestTopRejectsNonPositiveInterval(t *testing.T) {
+	for _, interval := range []string{"0s", "-1s"} {
+		cmd := NewTopCommand(nil, nil)
+		require.NoError(t, cmd.Flags().Set("interval", interval))
+		err := cmd.RunE(cmd, nil)
+		require.Error(t, err, interval)
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid), "got %v", err)
+	}
+}
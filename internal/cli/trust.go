@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/trust"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// trustStore returns the store of trust decisions and is replaced in
+	// tests
+	trustStore = func() *trust.Store { return trust.NewStore(trust.DefaultPath()) }
+
+	// confirmTrust asks whether to trust a project directory and is
+	// replaced in tests
+	confirmTrust = prompt.Confirm
+)
+
+// projectTrustDir returns the directory whose trust governs the project
+// configs that apply in cwd: the directory of the outermost .glide.yml
+func projectTrustDir(cwd string) string {
+	configPaths, err := config.DiscoverConfigs(cwd)
+	if err != nil || len(configPaths) == 0 {
+		return ""
+	}
+	return filepath.Dir(configPaths[len(configPaths)-1])
+}
+
+// requireTrust checks that the commands defined by the project config in
+// dir may run. The first time, an interactive session is asked and the
+// answer remembered; without a terminal, an undecided project stays
+// restricted. GLIDE_TRUST_ALL trusts every project, for CI machines that
+// only check out vetted code.
+func requireTrust(dir string) error {
+	status, err := trustStatus(dir)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case trust.Trusted:
+		return nil
+	case trust.Denied:
+		return untrustedError(dir, "it was marked untrusted")
+	}
+
+	if !stdinIsTerminal() {
+		return untrustedError(dir, "it has not been trusted yet and no terminal is attached to ask")
+	}
+	output.Warning("⚠️  %s defines commands in %s that have not run on this machine before", dir, branding.ConfigFileName)
+	output.Info("Review %s before trusting it: its commands run with your permissions.", filepath.Join(dir, branding.ConfigFileName))
+	trusted, err := confirmTrust(fmt.Sprintf("Trust %s?", dir), false)
+	if err != nil {
+		return err
+	}
+	store := trustStore()
+	if err := store.Set(dir, trusted); err != nil {
+		return glideErrors.WrapWithOp(err, "saving trust decision", glideErrors.WithPath(store.Path()))
+	}
+	if !trusted {
+		return untrustedError(dir, "it was marked untrusted")
+	}
+	return nil
+}
+
+// trustStatus returns the trust decision for dir without asking for one.
+// GLIDE_TRUST_ALL trusts every directory.
+func trustStatus(dir string) (trust.Status, error) {
+	if os.Getenv("GLIDE_TRUST_ALL") != "" {
+		return trust.Trusted, nil
+	}
+	store := trustStore()
+	status, _, err := store.Status(dir)
+	if err != nil {
+		return status, glideErrors.WrapWithOp(err, "reading trust decisions", glideErrors.WithPath(store.Path()))
+	}
+	return status, nil
+}
+
+// untrustedError refuses to run a command from the config of an untrusted
+// project
+func untrustedError(dir, reason string) error {
+	return glideErrors.New(glideErrors.TypePermission,
+		fmt.Sprintf("%s runs in restricted mode because %s: commands from its %s are disabled", dir, reason, branding.ConfigFileName),
+		glideErrors.WithExitCode(1),
+		glideErrors.WithSuggestions(
+			fmt.Sprintf("Review %s, then trust it: %s trust %s", filepath.Join(dir, branding.ConfigFileName), branding.CommandName, dir),
+			fmt.Sprintf("Preview what a command would run: %s explain <command>", branding.CommandName),
+			fmt.Sprintf("Or run its commands in a sandbox without network: %s=1", SandboxEnv),
+		),
+	)
+}
+
+// NewTrustCommand creates the trust command
+func NewTrustCommand(ctx *context.ProjectContext) *cobra.Command {
+	var deny, forget bool
+
+	cmd := &cobra.Command{
+		Use:   "trust [directory]",
+		Short: "Allow a project's .glide.yml commands to run",
+		Long: `Decide whether the commands a project's .glide.yml defines may run.
+
+Commands from a .glide.yml run shell code with your permissions, so a
+project seen for the first time runs in restricted mode: its config is
+read, but its commands and snapshot database commands do not run
+until you trust it. Interactive sessions are asked once; decisions are
+kept in ~/.glide/trust.json and cover every directory below the trusted
+one. Set GLIDE_TRUST_ALL=1 to trust every project, e.g. on CI.
+
+The directory defaults to the current project.
+
+Examples:
+  glide trust                  # Trust the current project
+  glide trust ~/src/acme       # Trust another project
+  glide trust --deny           # Keep the current project restricted
+  glide trust --forget         # Ask again next time
+  glide trust list             # Show every decision`,
+		Args:          cobra.MaximumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deny && forget {
+				return glideErrors.New(glideErrors.TypeInvalid, "--deny and --forget cannot be combined")
+			}
+			dir, err := trustTarget(ctx, args)
+			if err != nil {
+				return err
+			}
+
+			store := trustStore()
+			if forget {
+				removed, err := store.Forget(dir)
+				if err != nil {
+					return glideErrors.WrapWithOp(err, "saving trust decision", glideErrors.WithPath(store.Path()))
+				}
+				if !removed {
+					output.Info("No trust decision recorded for %s", dir)
+					return nil
+				}
+				output.Success("✅ Forgot the trust decision for %s", dir)
+				return nil
+			}
+
+			if err := store.Set(dir, !deny); err != nil {
+				return glideErrors.WrapWithOp(err, "saving trust decision", glideErrors.WithPath(store.Path()))
+			}
+			if deny {
+				output.Success("🔒 %s runs in restricted mode", dir)
+			} else {
+				output.Success("✅ Trusted %s", dir)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&deny, "deny", false, "Keep the directory in restricted mode without asking again")
+	cmd.Flags().BoolVar(&forget, "forget", false, "Remove the decision, so the directory is asked about again")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:           "list",
+		Aliases:       []string{"ls"},
+		Short:         "List trust decisions",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := trustStore()
+			decisions, err := store.Decisions()
+			if err != nil {
+				return glideErrors.WrapWithOp(err, "reading trust decisions", glideErrors.WithPath(store.Path()))
+			}
+			if len(decisions) == 0 {
+				output.Info("No trust decisions recorded")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "STATUS\tDIRECTORY\tDECIDED")
+			for _, d := range decisions {
+				status := trust.Trusted
+				if !d.Trusted {
+					status = trust.Denied
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", status, d.Dir, d.Time.Local().Format("2006-01-02 15:04"))
+			}
+			return w.Flush()
+		},
+	})
+
+	return cmd
+}
+
+// trustTarget returns the directory a trust command applies to: the
+// argument, or else the current project
+func trustTarget(ctx *context.ProjectContext, args []string) (string, error) {
+	if len(args) > 0 {
+		dir, err := filepath.Abs(args[0])
+		if err != nil {
+			return "", glideErrors.WrapWithOp(err, "resolving directory", glideErrors.WithPath(args[0]))
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return "", glideErrors.New(glideErrors.TypeMissing, fmt.Sprintf("%s is not a directory", dir))
+		}
+		return dir, nil
+	}
+
+	cwd, _ := os.Getwd()
+	if dir := projectTrustDir(cwd); dir != "" {
+		return dir, nil
+	}
+	if ctx != nil && ctx.ProjectRoot != "" {
+		return ctx.ProjectRoot, nil
+	}
+	return cwd, nil
+}
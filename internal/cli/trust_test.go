@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/trust"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTrust points trust decisions at a temporary file and answers the
+// trust prompt with answer
+func stubTrust(t *testing.T, terminal, answer bool) (*trust.Store, *int) {
+	t.Helper()
+	t.Setenv("GLIDE_TRUST_ALL", "")
+	store := trust.NewStore(filepath.Join(t.TempDir(), "trust.json"))
+	asked := 0
+	originalStore, originalConfirm, originalTerminal := trustStore, confirmTrust, stdinIsTerminal
+	trustStore = func() *trust.Store { return store }
+	confirmTrust = func(string, bool) (bool, error) {
+		asked++
+		return answer, nil
+	}
+	stdinIsTerminal = func() bool { return terminal }
+	t.Cleanup(func() {
+		trustStore, confirmTrust, stdinIsTerminal = originalStore, originalConfirm, originalTerminal
+	})
+	return store, &asked
+}
+
+func TestRequireTrust(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("asks once and remembers trust", func(t *testing.T) {
+		store, asked := stubTrust(t, true, true)
+
+		require.NoError(t, requireTrust(dir))
+		require.NoError(t, requireTrust(dir))
+		assert.Equal(t, 1, *asked)
+
+		status, _, err := store.Status(dir)
+		require.NoError(t, err)
+		assert.Equal(t, trust.Trusted, status)
+	})
+
+	t.Run("declining restricts the project", func(t *testing.T) {
+		_, asked := stubTrust(t, true, false)
+
+		var glideErr *glideErrors.GlideError
+		require.True(t, errors.As(requireTrust(dir), &glideErr))
+		assert.Equal(t, glideErrors.TypePermission, glideErr.Type)
+
+		assert.Error(t, requireTrust(dir))
+		assert.Equal(t, 1, *asked, "a denial is remembered")
+	})
+
+	t.Run("undecided without a terminal is restricted", func(t *testing.T) {
+		store, asked := stubTrust(t, false, true)
+
+		assert.Error(t, requireTrust(dir))
+		assert.Zero(t, *asked)
+
+		status, _, err := store.Status(dir)
+		require.NoError(t, err)
+		assert.Equal(t, trust.Unknown, status, "nothing is recorded")
+	})
+
+	t.Run("GLIDE_TRUST_ALL trusts every project", func(t *testing.T) {
+		_, asked := stubTrust(t, false, false)
+		t.Setenv("GLIDE_TRUST_ALL", "1")
+
+		assert.NoError(t, requireTrust(dir))
+		assert.Zero(t, *asked)
+	})
+}
+
+func TestYAMLCommandTrust(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(t.TempDir(), "ran")
+
+	run := func(cmd *config.Command) error {
+		registry := NewRegistry()
+		require.NoError(t, registry.AddYAMLCommand("touch", cmd))
+		factory, ok := registry.Get("touch")
+		require.True(t, ok)
+		c := factory()
+		return c.RunE(c, nil)
+	}
+
+	t.Run("project command is refused until trusted", func(t *testing.T) {
+		store, _ := stubTrust(t, false, false)
+		cmd := &config.Command{Cmd: "touch " + marker, Dir: dir}
+
+		assert.Error(t, run(cmd))
+		assert.NoFileExists(t, marker)
+
+		require.NoError(t, store.Set(dir, true))
+		require.NoError(t, run(cmd))
+		assert.FileExists(t, marker)
+	})
+
+	t.Run("global command always runs", func(t *testing.T) {
+		_, asked := stubTrust(t, false, false)
+
+		assert.NoError(t, run(&config.Command{Cmd: "true"}))
+		assert.Zero(t, *asked)
+	})
+}
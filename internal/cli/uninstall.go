@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Kinds of files uninstall removes
+const (
+	UninstallBinary     = "binary"
+	UninstallBackup     = "backup"
+	UninstallCompletion = "completion"
+	UninstallRCBlock    = "rc_block"
+	UninstallCache      = "cache"
+	UninstallPlugins    = "plugins"
+	UninstallConfig     = "config"
+	UninstallData       = "data"
+)
+
+// uninstallCaches are the files and directories in the user's glide
+// directory that glide rebuilds on demand
+var uninstallCaches = []string{"locks", "logs", "cleanup.json", "plugin-health.json", "timings.jsonl", "plugin-stats.jsonl", "cache"}
+
+// UninstallItem is a file or directory uninstall removes
+type UninstallItem struct {
+	Kind  string `json:"kind" yaml:"kind"`
+	Path  string `json:"path" yaml:"path"`
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// UninstallCommand removes glide and the files it created
+type UninstallCommand struct {
+	ctx *context.ProjectContext
+	cfg *config.Config
+
+	plugins bool
+	purge   bool
+
+	// homeDir and executable are replaced in tests
+	homeDir    string
+	executable func() (string, error)
+}
+
+// NewUninstallCommand creates the uninstall command
+func NewUninstallCommand(ctx *context.ProjectContext, cfg *config.Config) *cobra.Command {
+	uc := &UninstallCommand{
+		ctx:        ctx,
+		cfg:        cfg,
+		executable: os.Executable,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: fmt.Sprintf("Remove %s and the files it created", branding.CommandName),
+		Long: fmt.Sprintf(`Remove the %[1]s binary, the shell completions installed with
+'%[1]s completion install', caches in ~/%[2]s, and backups of the binary
+and of ~/%[3]s. Everything to be removed is listed and confirmed first.
+
+Installed plugins, the global configuration, trust decisions, the audit log,
+and the time log are kept unless asked for. Projects' %[3]s files and %[2]s/
+directories are never touched.
+
+Examples:
+  %[1]s uninstall              # Remove the binary, completions, and caches
+  %[1]s uninstall --plugins    # Also remove installed plugins
+  %[1]s uninstall --purge      # Remove everything in ~/%[2]s and ~/%[3]s
+  %[1]s uninstall --dry-run    # Only list what would be removed`,
+			branding.CommandName, branding.GetPluginDirName(), branding.ConfigFileName),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Annotations:   map[string]string{DryRunAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return uc.Execute(cmd)
+		},
+	}
+
+	cmd.Flags().BoolVar(&uc.plugins, "plugins", false, "Also remove installed plugins")
+	cmd.Flags().BoolVar(&uc.purge, "purge", false, "Also remove plugins, configuration, trust decisions, and logs")
+
+	MarkDestructive(cmd, func(cmd *cobra.Command, args []string) []string {
+		items, err := uc.Plan()
+		if err != nil {
+			return nil
+		}
+		return describeUninstallItems(items)
+	})
+
+	return cmd
+}
+
+// Execute lists or removes what uninstall covers
+func (uc *UninstallCommand) Execute(cmd *cobra.Command) error {
+	items, err := uc.Plan()
+	if err != nil {
+		return err
+	}
+
+	structured := false
+	if output.GetFormat().IsStructured() {
+		structured = true
+	}
+
+	if IsDryRun(cmd) {
+		if structured {
+			return output.Display(items)
+		}
+		if len(items) == 0 {
+			output.Info("Nothing to remove")
+			return nil
+		}
+		output.Info("Would remove:")
+		for _, line := range describeUninstallItems(items) {
+			output.Printf("  • %s\n", line)
+		}
+		output.Info("Dry run: nothing was removed")
+		return nil
+	}
+
+	results := uc.Apply(items)
+	if structured {
+		if err := output.Display(results); err != nil {
+			return err
+		}
+	} else {
+		showUninstallResults(results)
+	}
+
+	var failed []UninstallItem
+	for _, r := range results {
+		if r.Error != "" {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) > 0 {
+		return glideErrors.NewPermissionError(failed[0].Path,
+			fmt.Sprintf("%d of %d item(s) could not be removed", len(failed), len(results)),
+			glideErrors.WithSuggestions("Remove the remaining files by hand, or re-run with sudo if the binary is in a system directory"),
+		)
+	}
+	return nil
+}
+
+// Plan returns what uninstall would remove, skipping files that do not
+// exist
+func (uc *UninstallCommand) Plan() ([]UninstallItem, error) {
+	home := uc.homeDir
+	if home == "" {
+		var err error
+		if home, err = os.UserHomeDir(); err != nil {
+			return nil, fmt.Errorf("failed to determine home directory: %w", err)
+		}
+	}
+	glideDir := filepath.Join(home, branding.GetPluginDirName())
+
+	var items []UninstallItem
+	add := func(kind, path string) {
+		if _, err := os.Lstat(path); err == nil {
+			items = append(items, UninstallItem{Kind: kind, Path: path})
+		}
+	}
+
+	if exe, err := uc.executable(); err == nil {
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			exe = resolved
+		}
+		add(UninstallBinary, exe)
+		// self-update leaves this behind when it cannot clean up
+		add(UninstallBackup, exe+".backup")
+	}
+
+	cm := &CompletionManager{homeDir: home}
+	for _, shell := range supportedShells() {
+		file, rcFile := cm.installedFiles(CompletionType(shell))
+		if file != "" {
+			add(UninstallCompletion, file)
+		}
+		if rcFile != "" {
+			add(UninstallRCBlock, rcFile)
+		}
+	}
+
+	configPath := filepath.Join(home, branding.ConfigFileName)
+	for _, pattern := range []string{configPath + ".bak*", configPath + ".backup*", configPath + "~"} {
+		matches, _ := filepath.Glob(pattern)
+		for _, match := range matches {
+			add(UninstallBackup, match)
+		}
+	}
+
+	if uc.purge {
+		add(UninstallData, glideDir)
+		add(UninstallConfig, configPath)
+		return items, nil
+	}
+
+	for _, name := range uninstallCaches {
+		add(UninstallCache, filepath.Join(glideDir, name))
+	}
+	if uc.plugins {
+		add(UninstallPlugins, filepath.Join(glideDir, "plugins"))
+	}
+	return items, nil
+}
+
+// Apply removes the planned items, recording failures on each item rather
+// than stopping at the first
+func (uc *UninstallCommand) Apply(items []UninstallItem) []UninstallItem {
+	results := make([]UninstallItem, 0, len(items))
+	var glideDir string
+	for _, item := range items {
+		var err error
+		if item.Kind == UninstallRCBlock {
+			_, err = removeMarkerBlock(item.Path)
+		} else {
+			err = os.RemoveAll(item.Path)
+		}
+		if err != nil {
+			item.Error = err.Error()
+		}
+		if item.Kind == UninstallCache || item.Kind == UninstallPlugins {
+			glideDir = filepath.Dir(item.Path)
+		}
+		results = append(results, item)
+	}
+
+	// Drop the glide directory once nothing that is kept remains in it;
+	// os.Remove refuses non-empty directories
+	if glideDir != "" {
+		_ = os.Remove(glideDir)
+	}
+	return results
+}
+
+// describeUninstallItems describes each item for confirmation and dry runs
+func describeUninstallItems(items []UninstallItem) []string {
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, describeUninstallItem(item))
+	}
+	return lines
+}
+
+// describeUninstallItem describes a single item
+func describeUninstallItem(item UninstallItem) string {
+	switch item.Kind {
+	case UninstallBinary:
+		return fmt.Sprintf("%s binary: %s", branding.CommandName, item.Path)
+	case UninstallBackup:
+		return fmt.Sprintf("Backup: %s", item.Path)
+	case UninstallCompletion:
+		return fmt.Sprintf("Shell completion: %s", item.Path)
+	case UninstallRCBlock:
+		return fmt.Sprintf("Completion setup in %s", item.Path)
+	case UninstallCache:
+		return fmt.Sprintf("Cache: %s", item.Path)
+	case UninstallPlugins:
+		return fmt.Sprintf("Installed plugins: %s", item.Path)
+	case UninstallConfig:
+		return fmt.Sprintf("Global configuration: %s", item.Path)
+	case UninstallData:
+		return fmt.Sprintf("All plugins, caches, trust decisions, and logs: %s", item.Path)
+	default:
+		return item.Path
+	}
+}
+
+// showUninstallResults reports what was removed
+func showUninstallResults(results []UninstallItem) {
+	if len(results) == 0 {
+		output.Info("Nothing to remove")
+		return
+	}
+
+	for _, r := range results {
+		if r.Error != "" {
+			output.Error("Could not remove %s: %s", r.Path, r.Error)
+			continue
+		}
+		output.Success("Removed %s", r.Path)
+	}
+	output.Info("Projects' %s files were left in place", branding.ConfigFileName)
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestUninstall lays out an installation in a temporary home: a binary
+// with a self-update backup, bash completions, caches, plugins, and config
+func newTestUninstall(t *testing.T) (*UninstallCommand, string) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	home := t.TempDir()
+	binDir := t.TempDir()
+	exe := filepath.Join(binDir, "glide")
+	writeTestFile(t, exe, "binary")
+	writeTestFile(t, exe+".backup", "old binary")
+
+	rootCmd := &cobra.Command{Use: "glide"}
+	cm := &CompletionManager{homeDir: home}
+	writeTestFile(t, filepath.Join(home, ".bashrc"), "export EDITOR=vim\n")
+	_, err := cm.Install(rootCmd, CompletionBash)
+	require.NoError(t, err)
+
+	glideDir := filepath.Join(home, ".glide")
+	writeTestFile(t, filepath.Join(glideDir, "locks", "compose.lock"), "")
+	writeTestFile(t, filepath.Join(glideDir, "timings.jsonl"), "{}\n")
+	writeTestFile(t, filepath.Join(glideDir, "plugins", "glide-plugin-docker"), "plugin")
+	writeTestFile(t, filepath.Join(glideDir, "trust.json"), "{}")
+	writeTestFile(t, filepath.Join(home, ".glide.yml"), "projects: {}\n")
+	writeTestFile(t, filepath.Join(home, ".glide.yml.bak"), "projects: {}\n")
+
+	uc := &UninstallCommand{
+		homeDir:    home,
+		executable: func() (string, error) { return exe, nil },
+	}
+	return uc, home
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func uninstallKinds(items []UninstallItem) map[string]int {
+	kinds := make(map[string]int)
+	for _, item := range items {
+		kinds[item.Kind]++
+	}
+	return kinds
+}
+
+func TestUninstallCommand_Plan(t *testing.T) {
+	t.Run("default keeps plugins and config", func(t *testing.T) {
+		uc, _ := newTestUninstall(t)
+
+		items, err := uc.Plan()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{
+			UninstallBinary:     1,
+			UninstallBackup:     2,
+			UninstallCompletion: 1,
+			UninstallRCBlock:    1,
+			UninstallCache:      2,
+		}, uninstallKinds(items))
+	})
+
+	t.Run("plugins", func(t *testing.T) {
+		uc, _ := newTestUninstall(t)
+		uc.plugins = true
+
+		items, err := uc.Plan()
+		require.NoError(t, err)
+		assert.Equal(t, 1, uninstallKinds(items)[UninstallPlugins])
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		uc, home := newTestUninstall(t)
+		uc.purge = true
+
+		items, err := uc.Plan()
+		require.NoError(t, err)
+		kinds := uninstallKinds(items)
+		assert.Equal(t, 1, kinds[UninstallData])
+		assert.Equal(t, 1, kinds[UninstallConfig])
+		assert.Zero(t, kinds[UninstallCache])
+		assert.Contains(t, items, UninstallItem{Kind: UninstallData, Path: filepath.Join(home, ".glide")})
+	})
+}
+
+func TestUninstallCommand_Apply(t *testing.T) {
+	uc, home := newTestUninstall(t)
+
+	items, err := uc.Plan()
+	require.NoError(t, err)
+	results := uc.Apply(items)
+	require.Len(t, results, len(items))
+	for _, r := range results {
+		assert.Empty(t, r.Error, r.Path)
+	}
+
+	exe, _ := uc.executable()
+	assert.NoFileExists(t, exe)
+	assert.NoFileExists(t, exe+".backup")
+	assert.NoDirExists(t, filepath.Join(home, ".glide", "locks"))
+	assert.NoFileExists(t, filepath.Join(home, ".glide.yml.bak"))
+
+	// Plugins, trust decisions, and config are kept
+	assert.FileExists(t, filepath.Join(home, ".glide", "plugins", "glide-plugin-docker"))
+	assert.FileExists(t, filepath.Join(home, ".glide", "trust.json"))
+	assert.FileExists(t, filepath.Join(home, ".glide.yml"))
+
+	// The rc file keeps everything but the completion block
+	rc, err := os.ReadFile(filepath.Join(home, ".bashrc"))
+	require.NoError(t, err)
+	assert.Equal(t, "export EDITOR=vim\n", string(rc))
+}
+
+func TestUninstallCommand_ApplyPurge(t *testing.T) {
+	uc, home := newTestUninstall(t)
+	uc.purge = true
+
+	items, err := uc.Plan()
+	require.NoError(t, err)
+	uc.Apply(items)
+
+	assert.NoDirExists(t, filepath.Join(home, ".glide"))
+	assert.NoFileExists(t, filepath.Join(home, ".glide.yml"))
+}
+
+func TestRemoveMarkerBlock(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("block between other content", func(t *testing.T) {
+		path := filepath.Join(dir, "zshrc")
+		writeTestFile(t, path, "alias ll='ls -l'\n")
+		_, err := upsertMarkerBlock(path, "fpath=(x $fpath)")
+		require.NoError(t, err)
+		data, _ := os.ReadFile(path)
+		writeTestFile(t, path, string(data)+"export PATH=$HOME/bin:$PATH\n")
+
+		removed, err := removeMarkerBlock(path)
+		require.NoError(t, err)
+		assert.True(t, removed)
+		data, _ = os.ReadFile(path)
+		assert.Equal(t, "alias ll='ls -l'\nexport PATH=$HOME/bin:$PATH\n", string(data))
+	})
+
+	t.Run("no block", func(t *testing.T) {
+		path := filepath.Join(dir, "bashrc")
+		writeTestFile(t, path, "export A=1\n")
+
+		removed, err := removeMarkerBlock(path)
+		require.NoError(t, err)
+		assert.False(t, removed)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		removed, err := removeMarkerBlock(filepath.Join(dir, "missing"))
+		require.NoError(t, err)
+		assert.False(t, removed)
+	})
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+// confirmUpgrade asks before a config file is rewritten and is replaced
+// in tests
+var confirmUpgrade = prompt.Confirm
+
+// UpgradeConfigCommand rewrites legacy config layouts
+type UpgradeConfigCommand struct {
+	ctx *glideContext.ProjectContext
+	yes bool
+}
+
+// NewUpgradeConfigCommand creates the upgrade-config command
+func NewUpgradeConfigCommand(ctx *glideContext.ProjectContext) *cobra.Command {
+	uc := &UpgradeConfigCommand{ctx: ctx}
+
+	cmd := &cobra.Command{
+		Use:   "upgrade-config [file...]",
+		Short: "Rewrite legacy configuration to the current format",
+		Long: `Find legacy layouts in configuration files, show the rewritten file as a
+diff, and write it after confirmation, keeping a backup of the original:
+
+  renamed-key     keys renamed since they were written
+  plugin-list     a list of plugins under plugins:, now plugins.enabled
+  plugin-section  plugin settings at the top level, now under plugins.<name>
+
+Renamed keys keep working until they are removed, since they are renamed as
+files load; the other layouts fail to load or are ignored. Layouts that
+need a decision, such as a plugin section set in both places, are reported
+and left alone. Comments are kept.
+
+Without arguments the global configuration and the project's .glide.yml
+are upgraded. The backup is written next to the file as <file>.bak-<time>.
+
+Examples:
+  glide upgrade-config
+  glide --dry-run upgrade-config        # Only show the diff
+  glide upgrade-config --yes .glide.yml`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return uc.execute(cmd, args)
+		},
+	}
+	cmd.Flags().BoolVarP(&uc.yes, "yes", "y", false, "Write the upgraded files without asking")
+
+	return cmd
+}
+
+// execute upgrades the files named, or the default ones
+func (uc *UpgradeConfigCommand) execute(cmd *cobra.Command, args []string) error {
+	paths, explicit := args, len(args) > 0
+	if !explicit {
+		paths = []string{branding.GetConfigPath()}
+		if uc.ctx != nil && uc.ctx.ProjectRoot != "" {
+			paths = append(paths, filepath.Join(uc.ctx.ProjectRoot, branding.ConfigFileName))
+		}
+	}
+
+	upgraded := 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) && !explicit {
+			continue
+		}
+		if err != nil {
+			return glideErrors.NewPermissionError(path, "failed to read the configuration", glideErrors.WithError(err))
+		}
+
+		written, err := uc.upgradeFile(cmd, path, data)
+		if err != nil {
+			return err
+		}
+		if written {
+			upgraded++
+		}
+	}
+
+	if upgraded == 0 && !IsDryRun(cmd) {
+		output.Info("No configuration was changed")
+	}
+	return nil
+}
+
+// upgradeFile shows and, once confirmed, writes the upgrade of one file
+func (uc *UpgradeConfigCommand) upgradeFile(cmd *cobra.Command, path string, data []byte) (bool, error) {
+	upgradedData, changes, err := config.UpgradeConfig(data)
+	if err != nil {
+		return false, glideErrors.NewConfigError(fmt.Sprintf("invalid configuration %s", path), glideErrors.WithError(err))
+	}
+	if len(changes) == 0 {
+		output.Success("%s is up to date", path)
+		return false, nil
+	}
+
+	output.Info("%s uses %d legacy layout(s):", path, len(changes))
+	for _, change := range changes {
+		target := "removed"
+		if change.NewKey != "" {
+			target = "-> " + change.NewKey
+		}
+		if change.Manual {
+			output.Warning("  line %d: %s: %s", change.Line, change.Key, change.Note)
+			continue
+		}
+		output.Printf("  line %d: %s %s (%s)\n", change.Line, change.Key, target, change.Note)
+	}
+
+	if string(upgradedData) == string(data) {
+		output.Info("Nothing in %s can be upgraded automatically", path)
+		return false, nil
+	}
+
+	// The result must load, or the file is left as it is
+	var check config.Config
+	if err := config.UnmarshalConfig(upgradedData, path, &check); err != nil {
+		return false, glideErrors.NewConfigError(fmt.Sprintf("the upgraded %s would not load", path),
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions("Fix the reported layouts by hand"),
+		)
+	}
+
+	output.Println()
+	output.Raw(output.DiffWithOptions(string(data), string(upgradedData), output.DiffOptions{
+		OldName: path,
+		NewName: path + " (upgraded)",
+		Context: output.DefaultDiffContext,
+	}))
+	output.Println()
+
+	if IsDryRun(cmd) {
+		output.Info("Dry run: %s was not changed", path)
+		return false, nil
+	}
+
+	if !uc.yes {
+		if !stdinIsTerminal() {
+			return false, glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("not upgrading %s without confirmation", path),
+				glideErrors.WithSuggestions(
+					"Re-run with --yes to write the upgraded file",
+					"Re-run with --dry-run to only show the diff",
+				))
+		}
+		ok, err := confirmUpgrade(fmt.Sprintf("Write the upgraded %s?", path), true)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			output.Info("Left %s unchanged", path)
+			return false, nil
+		}
+	}
+
+	backup, err := writeUpgradedConfig(path, data, upgradedData)
+	if err != nil {
+		return false, err
+	}
+	output.Success("Upgraded %s; the original is in %s", path, backup)
+	return true, nil
+}
+
+// writeUpgradedConfig backs up the original file and writes the upgraded
+// one with the same permissions, returning the backup's path
+func writeUpgradedConfig(path string, original, upgraded []byte) (string, error) {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	backup := path + ".bak-" + time.Now().Format("20060102-150405")
+	if err := os.WriteFile(backup, original, mode); err != nil {
+		return "", glideErrors.NewPermissionError(backup, "failed to back up the configuration", glideErrors.WithError(err))
+	}
+	if err := os.WriteFile(path, upgraded, mode); err != nil {
+		return "", glideErrors.NewPermissionError(path, "failed to write the configuration", glideErrors.WithError(err))
+	}
+	return backup, nil
+}
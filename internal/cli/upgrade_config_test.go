@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyConfig = "default_project: app\nplugins:\n  - docker\n"
+
+// runUpgradeConfig runs upgrade-config under a root with --dry-run
+func runUpgradeConfig(t *testing.T, args ...string) error {
+	t.Helper()
+
+	root := &cobra.Command{Use: "glide"}
+	root.PersistentFlags().Bool("dry-run", false, "")
+	root.AddCommand(NewUpgradeConfigCommand(nil))
+	root.SetArgs(append([]string{"upgrade-config"}, args...))
+	return root.Execute()
+}
+
+func writeLegacyConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".glide.yml")
+	require.NoError(t, os.WriteFile(path, []byte(legacyConfig), 0o600))
+	return path
+}
+
+func TestUpgradeConfigCommand(t *testing.T) {
+	path := writeLegacyConfig(t)
+
+	require.NoError(t, runUpgradeConfig(t, "--yes", path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "default_project: app\nplugins:\n  enabled:\n    - docker\n", string(data))
+
+	backups, _ := filepath.Glob(path + ".bak-*")
+	require.Len(t, backups, 1)
+	backup, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, legacyConfig, string(backup))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestUpgradeConfigCommand_DryRun(t *testing.T) {
+	path := writeLegacyConfig(t)
+
+	require.NoError(t, runUpgradeConfig(t, "--dry-run", path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, legacyConfig, string(data))
+	backups, _ := filepath.Glob(path + ".bak-*")
+	assert.Empty(t, backups)
+}
+
+func TestUpgradeConfigCommand_Confirmation(t *testing.T) {
+	origConfirm, origTerminal := confirmUpgrade, stdinIsTerminal
+	t.Cleanup(func() { confirmUpgrade, stdinIsTerminal = origConfirm, origTerminal })
+
+	t.Run("declined", func(t *testing.T) {
+		path := writeLegacyConfig(t)
+		stdinIsTerminal = func() bool { return true }
+		confirmUpgrade = func(string, bool) (bool, error) { return false, nil }
+
+		require.NoError(t, runUpgradeConfig(t, path))
+		data, _ := os.ReadFile(path)
+		assert.Equal(t, legacyConfig, string(data))
+	})
+
+	t.Run("no terminal", func(t *testing.T) {
+		path := writeLegacyConfig(t)
+		stdinIsTerminal = func() bool { return false }
+
+		err := runUpgradeConfig(t, path)
+		require.Error(t, err)
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid))
+	})
+}
+
+func TestUpgradeConfigCommand_UpToDate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".glide.yml")
+	require.NoError(t, os.WriteFile(path, []byte("plugins:\n  enabled: [docker]\n"), 0o644))
+
+	require.NoError(t, runUpgradeConfig(t, path))
+	backups, _ := filepath.Glob(path + ".bak-*")
+	assert.Empty(t, backups)
+}
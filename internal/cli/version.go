@@ -90,7 +90,7 @@ func (vc *VersionCommand) execute(cmd *cobra.Command, args []string, checkUpdate
 	// TODO: Get format from injected manager once commands are migrated
 
 	// For table/plain output, display formatted text
-	output.Info(version.GetVersionString())
+	output.Info("%s", version.GetVersionString())
 	output.Raw("\n")
 	output.Raw("Build Information:\n")
 	output.Raw(fmt.Sprintf("  Git Commit:    %s\n", buildInfo.GitCommit))
@@ -111,7 +111,7 @@ func (vc *VersionCommand) execute(cmd *cobra.Command, args []string, checkUpdate
 
 		updateInfo, err := checker.CheckForUpdate(ctx)
 		if err != nil {
-			output.Warning(fmt.Sprintf("Failed to check for updates: %v", err))
+			output.Warning("Failed to check for updates: %v", err)
 		} else {
 			output.Raw("\n")
 			output.Raw(update.FormatUpdateMessage(updateInfo))
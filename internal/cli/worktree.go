@@ -48,15 +48,24 @@ Arguments:
 Options:
   --from        Base branch or commit (default: main)
   --no-env      Don't copy .env file from vcs/
+  --template    Worktree template from worktree_templates in .glide.yml
+
+Subcommands:
+  remove        Remove a worktree and its working directory
+  sync          Rebase or merge every worktree's branch onto its upstream
+  from-pr       Create a worktree that checks out a pull/merge request
+  from-issue    Create a worktree with a branch named after an issue
 
 Examples:
   glide g worktree feature/api                    # Create from main
   glide g worktree fix/bug-123 --from develop     # Create from develop
   glide g worktree feature/ui --no-env            # Create without copying .env
+  glide g worktree fix/login --template hotfix    # Create from the hotfix template
 
 Workflow:
   1. Creates worktree in worktrees/[branch-name]
-  2. Copies .env from vcs/ (unless --no-env)`,
+  2. Copies .env from vcs/, or the template's env_template (unless --no-env)
+  3. Starts the template's services and runs its seed commands`,
 		RunE:          c.Execute,
 		Args:          cobra.ExactArgs(1),
 		SilenceUsage:  true,
@@ -66,6 +75,12 @@ Workflow:
 	// Add flags
 	cmd.Flags().String("from", "main", "Base branch or commit")
 	cmd.Flags().Bool("no-env", false, "Don't copy .env file")
+	c.addTemplateFlag(cmd)
+
+	cmd.AddCommand(c.newRemoveCommand())
+	cmd.AddCommand(c.newSyncCommand())
+	cmd.AddCommand(c.newFromPRCommand())
+	cmd.AddCommand(c.newFromIssueCommand())
 
 	return cmd
 }
@@ -83,7 +98,32 @@ func (c *WorktreeCommand) Execute(cmd *cobra.Command, args []string) error {
 	// Get flags
 	fromBranch, _ := cmd.Flags().GetString("from")
 	noEnv, _ := cmd.Flags().GetBool("no-env")
+	template, err := c.templateFlag(cmd)
+	if err != nil {
+		return err
+	}
+	if template != nil && template.From != "" && !cmd.Flags().Changed("from") {
+		fromBranch = template.From
+	}
+
+	// Check if this is a remote branch
+	remoteBranch := ""
+	if fromBranch != "" && fromBranch != "main" && fromBranch != "master" {
+		// Check if it's a remote branch reference
+		if strings.HasPrefix(fromBranch, "origin/") {
+			remoteBranch = fromBranch
+		}
+	}
 
+	return c.create(branchName, fromBranch, remoteBranch, noEnv, nil, template)
+}
+
+// create creates a worktree for branchName under worktrees/ and copies the
+// .env file into it. When remoteBranch is set the new branch tracks it,
+// otherwise it starts at fromBranch. prepare, when not nil, runs in vcs/
+// after fetching and before the worktree is added. template, when not nil,
+// supplies the .env file and is applied once the worktree exists.
+func (c *WorktreeCommand) create(branchName, fromBranch, remoteBranch string, noEnv bool, prepare func(vcsDir string) error, template *config.WorktreeTemplate) error {
 	// Display header
 	output.Info("🌳 Creating Worktree: %s", branchName)
 	output.Println(strings.Repeat("=", 40))
@@ -123,12 +163,9 @@ func (c *WorktreeCommand) Execute(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Check if this is a remote branch
-	remoteBranch := ""
-	if fromBranch != "" && fromBranch != "main" && fromBranch != "master" {
-		// Check if it's a remote branch reference
-		if strings.HasPrefix(fromBranch, "origin/") {
-			remoteBranch = fromBranch
+	if prepare != nil {
+		if err := prepare(vcsDir); err != nil {
+			return err
 		}
 	}
 
@@ -142,11 +179,23 @@ func (c *WorktreeCommand) Execute(cmd *cobra.Command, args []string) error {
 
 	// Copy .env file unless --no-env
 	if !noEnv {
-		if err := c.copyEnvFile(vcsDir, worktreePath); err != nil {
+		copyEnv := func() error { return c.copyEnvFile(vcsDir, worktreePath) }
+		if template != nil && template.EnvTemplate != "" {
+			copyEnv = func() error { return c.writeEnvTemplate(vcsDir, worktreePath, template.EnvTemplate, branchName) }
+		}
+		if err := copyEnv(); err != nil {
 			output.Warning("⚠️  Warning: %v", err)
 		}
 	}
 
+	if template != nil {
+		if err := c.applyTemplate(worktreePath, template); err != nil {
+			return err
+		}
+	}
+
+	c.warnNamespaceCollisions(filepath.Base(worktreePath))
+
 	// Show summary
 	c.showSummary(worktreePath, branchName, remoteBranch)
 
@@ -305,6 +354,19 @@ func (c *WorktreeCommand) copyEnvFile(vcsDir, worktreePath string) error {
 	return nil
 }
 
+// warnNamespaceCollisions warns when the new worktree's name sanitizes to
+// the same resource names as other worktrees', which then all get hashed
+// names
+func (c *WorktreeCommand) warnNamespaceCollisions(worktreeName string) {
+	ns, ok := worktreeNamespace(c.worktreeContext(worktreeName))
+	if !ok || len(ns.Collisions) == 0 {
+		return
+	}
+	output.Warning("⚠️  %s collides with worktree(s) %s; their compose projects and databases get hashed names (this one: %s)",
+		worktreeName, strings.Join(ns.Collisions, ", "), ns.Project)
+	output.Info("   Restart the services of the other worktree(s) to move them to their new names")
+}
+
 // showSummary displays the completion summary
 func (c *WorktreeCommand) showSummary(worktreePath, branchName, remoteBranch string) {
 	output.Println()
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/forge"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// maxIssueSlugLength bounds the part of an issue branch name taken from the
+// issue title
+const maxIssueSlugLength = 40
+
+// newForgeClient creates the hosting service client for a remote URL and is
+// replaced in tests
+var newForgeClient = forge.ForRemote
+
+// newFromPRCommand creates the worktree from-pr subcommand
+func (c *WorktreeCommand) newFromPRCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "from-pr <number>",
+		Short: "Create a worktree that checks out a pull/merge request",
+		Long: `Look up a GitHub pull request or GitLab merge request of the origin remote
+and create a worktree with its head checked out, then copy .env from vcs/.
+
+Branches of the repository itself are checked out under their own name and
+track the remote branch. Pull requests from forks are fetched from the
+pull request ref into a branch named pr-<number>-<branch>.
+
+The API token is read from GITHUB_TOKEN or GH_TOKEN (GitHub), GITLAB_TOKEN
+(GitLab), or else from your git credential helper. Public repositories need
+no token.
+
+Examples:
+  glide p worktree from-pr 42             # Check out pull request #42
+  glide p worktree from-pr 42 --no-env    # Without copying .env`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.executeFromPR,
+	}
+
+	cmd.Flags().Bool("no-env", false, "Don't copy .env file")
+	c.addTemplateFlag(cmd)
+
+	return cmd
+}
+
+// newFromIssueCommand creates the worktree from-issue subcommand
+func (c *WorktreeCommand) newFromIssueCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "from-issue <number>",
+		Short: "Create a worktree with a branch named after an issue",
+		Long: `Look up a GitHub or GitLab issue of the origin remote and create a worktree
+on a new branch named issue-<number>-<title>, then copy .env from vcs/.
+
+The API token is read the same way as for from-pr.
+
+Examples:
+  glide p worktree from-issue 17                  # Branch from main
+  glide p worktree from-issue 17 --from develop   # Branch from develop
+  glide p worktree from-issue 17 --template hotfix`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          c.executeFromIssue,
+	}
+
+	cmd.Flags().String("from", "main", "Base branch or commit")
+	cmd.Flags().Bool("no-env", false, "Don't copy .env file")
+	c.addTemplateFlag(cmd)
+
+	return cmd
+}
+
+// executeFromPR creates a worktree for a pull request
+func (c *WorktreeCommand) executeFromPR(cmd *cobra.Command, args []string) error {
+	if err := ValidateMultiWorktreeMode(c.ctx, "worktree from-pr"); err != nil {
+		return err
+	}
+	number, err := parseForgeNumber(args[0])
+	if err != nil {
+		return err
+	}
+	noEnv, _ := cmd.Flags().GetBool("no-env")
+	template, err := c.templateFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.forgeClient()
+	if err != nil {
+		return err
+	}
+	pr, err := client.PullRequest(cmd.Context(), number)
+	if err != nil {
+		return err
+	}
+
+	output.Info("🔀 #%d %s", pr.Number, pr.Title)
+	if pr.State != "open" && pr.State != "opened" {
+		output.Warning("⚠️  This pull request is %s", pr.State)
+	}
+	output.Println()
+
+	if !pr.FromFork {
+		remoteBranch := "origin/" + pr.Branch
+		if _, err := gitOutput(c.syncDir("vcs"), "show-ref", "--verify", "--quiet", "refs/heads/"+pr.Branch); err == nil {
+			// Reuse the local branch, which may carry unpushed work
+			remoteBranch = ""
+		}
+		return c.create(pr.Branch, pr.Branch, remoteBranch, noEnv, nil, template)
+	}
+
+	// The fork's branch is fetched into a ref of our own, outside
+	// refs/remotes so that pruning does not delete it
+	localRef := fmt.Sprintf("refs/%s/pr/%d", branding.CommandName, pr.Number)
+	fetchPR := func(vcsDir string) error {
+		if out, err := gitOutput(vcsDir, "fetch", "origin", "+"+pr.Ref+":"+localRef); err != nil {
+			return glideErrors.NewNetworkError(fmt.Sprintf("failed to fetch %s", pr.Ref),
+				glideErrors.WithError(err),
+				glideErrors.WithContext("output", out),
+				glideErrors.WithSuggestions("Try running: git fetch origin "+pr.Ref),
+			)
+		}
+		return nil
+	}
+	return c.create(fmt.Sprintf("pr-%d-%s", pr.Number, pr.Branch), localRef, "", noEnv, fetchPR, template)
+}
+
+// executeFromIssue creates a worktree for an issue
+func (c *WorktreeCommand) executeFromIssue(cmd *cobra.Command, args []string) error {
+	if err := ValidateMultiWorktreeMode(c.ctx, "worktree from-issue"); err != nil {
+		return err
+	}
+	number, err := parseForgeNumber(args[0])
+	if err != nil {
+		return err
+	}
+	fromBranch, _ := cmd.Flags().GetString("from")
+	noEnv, _ := cmd.Flags().GetBool("no-env")
+	template, err := c.templateFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.forgeClient()
+	if err != nil {
+		return err
+	}
+	issue, err := client.Issue(cmd.Context(), number)
+	if err != nil {
+		return err
+	}
+
+	output.Info("📌 #%d %s", issue.Number, issue.Title)
+	if issue.State != "open" && issue.State != "opened" {
+		output.Warning("⚠️  This issue is %s", issue.State)
+	}
+	output.Println()
+
+	if template != nil && template.From != "" && !cmd.Flags().Changed("from") {
+		fromBranch = template.From
+	}
+	return c.create(issueBranchName(issue), fromBranch, "", noEnv, nil, template)
+}
+
+// forgeClient returns the hosting service client for the origin remote
+func (c *WorktreeCommand) forgeClient() (forge.Client, error) {
+	remoteURL, err := gitOutput(c.syncDir("vcs"), "remote", "get-url", "origin")
+	if err != nil {
+		return nil, glideErrors.New(glideErrors.TypeConfig, "the repository in vcs/ has no origin remote",
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions("Add one: git -C vcs remote add origin <url>"),
+		)
+	}
+	return newForgeClient(strings.TrimSpace(remoteURL))
+}
+
+// parseForgeNumber parses a pull request or issue number, allowing a
+// leading # or !
+func parseForgeNumber(arg string) (int, error) {
+	number, err := strconv.Atoi(strings.TrimLeft(arg, "#!"))
+	if err != nil || number <= 0 {
+		return 0, glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("%q is not a pull request or issue number", arg))
+	}
+	return number, nil
+}
+
+// issueBranchName names the branch for an issue, e.g.
+// issue-17-fix-login-redirect
+func issueBranchName(issue *forge.Issue) string {
+	slug := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(issue.Title))
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+	slug = strings.Trim(slug, "-")
+	if len(slug) > maxIssueSlugLength {
+		// Cut at a word boundary
+		slug = slug[:maxIssueSlugLength+1]
+		if i := strings.LastIndex(slug, "-"); i > 0 {
+			slug = slug[:i]
+		} else {
+			slug = slug[:maxIssueSlugLength]
+		}
+	}
+
+	if slug == "" {
+		return fmt.Sprintf("issue-%d", issue.Number)
+	}
+	return fmt.Sprintf("issue-%d-%s", issue.Number, slug)
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	gocontext "context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/forge"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeForge serves fixed pull requests and issues
+type fakeForge struct {
+	pulls  map[int]*forge.PullRequest
+	issues map[int]*forge.Issue
+}
+
+func (f *fakeForge) PullRequest(_ gocontext.Context, number int) (*forge.PullRequest, error) {
+	return f.pulls[number], nil
+}
+
+func (f *fakeForge) Issue(_ gocontext.Context, number int) (*forge.Issue, error) {
+	return f.issues[number], nil
+}
+
+func stubForge(t *testing.T, client forge.Client) {
+	t.Helper()
+	original := newForgeClient
+	t.Cleanup(func() { newForgeClient = original })
+	newForgeClient = func(string) (forge.Client, error) { return client, nil }
+}
+
+func runWorktree(root string, args ...string) error {
+	cmd := &cobra.Command{Use: "glide"}
+	ctx := &context.ProjectContext{ProjectRoot: root, DevelopmentMode: context.ModeMultiWorktree}
+	cmd.AddCommand(NewWorktreeCommand(ctx, nil))
+	cmd.SetArgs(append([]string{"worktree"}, args...))
+	return cmd.Execute()
+}
+
+func TestWorktreeFromPR(t *testing.T) {
+	f := newSyncFixture(t)
+
+	// A branch of the repository and a pull request from a fork
+	f.git(f.other, "checkout", "-q", "-b", "review", "main")
+	f.commit(f.other, "review.txt", "review\n")
+	f.git(f.other, "push", "-q", "origin", "review")
+	f.git(f.other, "checkout", "-q", "-b", "forked", "main")
+	f.commit(f.other, "forked.txt", "forked\n")
+	f.git(f.other, "push", "-q", "origin", "forked:refs/pull/9/head")
+
+	stubForge(t, &fakeForge{pulls: map[int]*forge.PullRequest{
+		8: {Number: 8, Title: "Review", State: "open", Branch: "review", Ref: "refs/pull/8/head"},
+		9: {Number: 9, Title: "Forked", State: "open", Branch: "main", FromFork: true, Ref: "refs/pull/9/head"},
+	}})
+	require.NoError(t, os.WriteFile(filepath.Join(f.root, "vcs", ".env"), []byte("APP=1\n"), 0644))
+
+	require.NoError(t, runWorktree(f.root, "from-pr", "8"))
+	review := filepath.Join(f.root, "worktrees", "review")
+	assert.FileExists(t, filepath.Join(review, "review.txt"))
+	assert.FileExists(t, filepath.Join(review, ".env"))
+	assert.Equal(t, "origin/review\n", f.git(review, "rev-parse", "--abbrev-ref", "@{upstream}"))
+
+	require.NoError(t, runWorktree(f.root, "from-pr", "#9", "--no-env"))
+	forked := filepath.Join(f.root, "worktrees", "pr-9-main")
+	assert.FileExists(t, filepath.Join(forked, "forked.txt"))
+	assert.NoFileExists(t, filepath.Join(forked, ".env"))
+}
+
+func TestWorktreeFromIssue(t *testing.T) {
+	f := newSyncFixture(t)
+	stubForge(t, &fakeForge{issues: map[int]*forge.Issue{
+		17: {Number: 17, Title: "Login fails: redirect loop!", State: "open"},
+	}})
+
+	require.NoError(t, runWorktree(f.root, "from-issue", "17"))
+	dir := filepath.Join(f.root, "worktrees", "issue-17-login-fails-redirect-loop")
+	assert.Equal(t, "issue-17-login-fails-redirect-loop\n", f.git(dir, "branch", "--show-current"))
+}
+
+func TestIssueBranchName(t *testing.T) {
+	assert.Equal(t, "issue-3", issueBranchName(&forge.Issue{Number: 3, Title: "???"}))
+	assert.Equal(t, "issue-4-a-very-long-title-that-goes-on-and-on",
+		issueBranchName(&forge.Issue{Number: 4, Title: "A very long title that goes on and on and on forever"}))
+}
+
+func TestParseForgeNumber(t *testing.T) {
+	n, err := parseForgeNumber("!12")
+	require.NoError(t, err)
+	assert.Equal(t, 12, n)
+
+	_, err = parseForgeNumber("abc")
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// newRemoveCommand creates the worktree remove subcommand
+func (c *WorktreeCommand) newRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a worktree and its working directory",
+		Long: `Remove a worktree created with 'glide project worktree'.
+
+The worktree directory, including any uncommitted changes, is deleted.
+The branch itself is kept in the repository.
+
+Examples:
+  glide p worktree remove feature-api           # Remove worktrees/feature-api
+  glide p worktree remove feature-api --force   # Skip the confirmation prompt`,
+		Aliases:       []string{"rm"},
+		Args:          cobra.ExactArgs(1),
+		RunE:          c.executeRemove,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	MarkDestructive(cmd, c.removeTargets)
+
+	return cmd
+}
+
+// executeRemove removes a worktree
+func (c *WorktreeCommand) executeRemove(cmd *cobra.Command, args []string) error {
+	if err := ValidateMultiWorktreeMode(c.ctx, "worktree remove"); err != nil {
+		return err
+	}
+
+	worktreePath := c.worktreePath(args[0])
+	if _, err := os.Stat(worktreePath); err != nil {
+		return glideErrors.NewFileNotFoundError(worktreePath,
+			glideErrors.WithSuggestions("List worktrees with: glide project list"),
+		)
+	}
+
+	// The user has already confirmed the deletion, including uncommitted changes
+	removeCmd := exec.Command("git", "worktree", "remove", "--force", worktreePath)
+	removeCmd.Dir = filepath.Join(c.ctx.ProjectRoot, "vcs")
+	if cmdOutput, err := removeCmd.CombinedOutput(); err != nil {
+		return glideErrors.NewCommandError("git worktree remove", 1,
+			glideErrors.WithError(err),
+			glideErrors.WithContext("output", string(cmdOutput)),
+			glideErrors.WithSuggestions(
+				"Check for processes using the worktree directory",
+				"Try cleaning up: git worktree prune",
+			),
+		)
+	}
+
+	output.Success("✅ Removed worktree %s", args[0])
+	return nil
+}
+
+// removeTargets describes what removing a worktree deletes
+func (c *WorktreeCommand) removeTargets(cmd *cobra.Command, args []string) []string {
+	if len(args) == 0 || c.ctx == nil {
+		return nil
+	}
+
+	worktreePath := c.worktreePath(args[0])
+	targets := []string{fmt.Sprintf("Directory %s", worktreePath)}
+
+	statusCmd := exec.Command("git", "status", "--porcelain")
+	statusCmd.Dir = worktreePath
+	if statusOutput, err := statusCmd.Output(); err == nil {
+		if changes := strings.TrimSpace(string(statusOutput)); changes != "" {
+			targets = append(targets, fmt.Sprintf("%d uncommitted change(s) in that directory", len(strings.Split(changes, "\n"))))
+		}
+	}
+
+	return targets
+}
+
+// worktreePath returns the directory of a named worktree
+func (c *WorktreeCommand) worktreePath(name string) string {
+	return filepath.Join(c.ctx.ProjectRoot, "worktrees", c.sanitizeName(name))
+}
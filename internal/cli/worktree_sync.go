@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Sync strategies of `project worktree sync`
+const (
+	SyncStrategyRebase = "rebase"
+	SyncStrategyMerge  = "merge"
+)
+
+// Outcomes of syncing a worktree
+const (
+	SyncUpToDate = "up-to-date"
+	SyncUpdated  = "updated"
+	SyncSkipped  = "skipped"
+	SyncConflict = "conflict"
+	SyncFailed   = "failed"
+	SyncPlanned  = "planned"
+)
+
+// WorktreeSyncResult is the outcome of syncing one worktree
+type WorktreeSyncResult struct {
+	Worktree  string   `json:"worktree" yaml:"worktree"`
+	Branch    string   `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Upstream  string   `json:"upstream,omitempty" yaml:"upstream,omitempty"`
+	Behind    int      `json:"behind" yaml:"behind"`
+	Status    string   `json:"status" yaml:"status"`
+	Reason    string   `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Conflicts []string `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+}
+
+// newSyncCommand creates the worktree sync subcommand
+func (c *WorktreeCommand) newSyncCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync [worktree...]",
+		Short: "Rebase or merge every worktree's branch onto its upstream",
+		Long: `Fetch from every remote, then bring each worktree's branch up to date with
+its upstream branch, including the main repository in vcs/.
+
+Worktrees with uncommitted changes, a detached HEAD, or no upstream are
+skipped. When a rebase or merge conflicts it is aborted, leaving the worktree
+as it was, and the conflicting files are reported so you can resolve them
+by hand.
+
+The strategy defaults to rebase and can be set in the configuration:
+
+  defaults:
+    worktree:
+      sync_strategy: merge
+
+Examples:
+  glide p worktree sync                      # Sync every worktree
+  glide p worktree sync feature-api          # Sync one worktree
+  glide p worktree sync --strategy merge     # Merge instead of rebasing
+  glide p worktree sync --dry-run            # Show what would be synced`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Annotations:   map[string]string{DryRunAnnotation: "true"},
+		RunE:          c.executeSync,
+	}
+
+	cmd.Flags().String("strategy", "", "How to update branches: rebase or merge (default: from config, else rebase)")
+	cmd.Flags().Bool("no-fetch", false, "Don't fetch from remotes first")
+
+	return cmd
+}
+
+// executeSync syncs the selected worktrees one after the other, since they
+// share the repository's refs
+func (c *WorktreeCommand) executeSync(cmd *cobra.Command, args []string) error {
+	if err := ValidateMultiWorktreeMode(c.ctx, "worktree sync"); err != nil {
+		return err
+	}
+
+	strategy, err := c.syncStrategy(cmd)
+	if err != nil {
+		return err
+	}
+
+	worktrees, err := c.syncTargets(args)
+	if err != nil {
+		return err
+	}
+
+	dryRun := IsDryRun(cmd)
+	structured := output.GetFormat().IsStructured()
+
+	if noFetch, _ := cmd.Flags().GetBool("no-fetch"); !noFetch && !dryRun {
+		if structured {
+			if _, err := gitOutput(filepath.Join(c.ctx.ProjectRoot, "vcs"), "fetch", "--all", "--prune"); err != nil {
+				return glideErrors.NewNetworkError("failed to fetch from remotes", glideErrors.WithError(err))
+			}
+		} else if err := c.fetchLatest(filepath.Join(c.ctx.ProjectRoot, "vcs")); err != nil {
+			return err
+		}
+	}
+
+	results := make([]WorktreeSyncResult, 0, len(worktrees))
+	for _, name := range worktrees {
+		results = append(results, syncWorktree(c.syncDir(name), name, strategy, dryRun))
+	}
+
+	if structured {
+		if err := output.Display(results); err != nil {
+			return err
+		}
+	} else {
+		showSyncResults(results)
+	}
+
+	var failed []string
+	for _, r := range results {
+		if r.Status == SyncConflict || r.Status == SyncFailed {
+			failed = append(failed, r.Worktree)
+		}
+	}
+	if len(failed) > 0 {
+		return glideErrors.New(glideErrors.TypeCommand,
+			fmt.Sprintf("%d worktree(s) could not be synced: %s", len(failed), strings.Join(failed, ", ")),
+			glideErrors.WithExitCode(1),
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Resolve by hand: cd worktrees/<name> && git %s @{u}", strategy),
+				"The conflicting files are listed above",
+			),
+		)
+	}
+	if dryRun && !structured {
+		output.Info("Dry run: nothing was changed")
+	}
+	return nil
+}
+
+// syncStrategy returns the strategy from the flag or the configuration
+func (c *WorktreeCommand) syncStrategy(cmd *cobra.Command) (string, error) {
+	strategy, _ := cmd.Flags().GetString("strategy")
+	if strategy == "" && c.cfg != nil {
+		strategy = c.cfg.Defaults.Worktree.SyncStrategy
+	}
+	switch strategy {
+	case "":
+		return SyncStrategyRebase, nil
+	case SyncStrategyRebase, SyncStrategyMerge:
+		return strategy, nil
+	default:
+		return "", glideErrors.New(glideErrors.TypeConfig,
+			fmt.Sprintf("invalid sync strategy %q", strategy),
+			glideErrors.WithSuggestions("Use 'rebase' or 'merge' (--strategy or defaults.worktree.sync_strategy)"),
+		)
+	}
+}
+
+// syncTargets returns the worktrees to sync: the named ones, or vcs and
+// every directory under worktrees/
+func (c *WorktreeCommand) syncTargets(names []string) ([]string, error) {
+	if len(names) > 0 {
+		targets := make([]string, 0, len(names))
+		for _, name := range names {
+			if name != "vcs" {
+				name = c.sanitizeName(name)
+			}
+			if _, err := os.Stat(c.syncDir(name)); err != nil {
+				return nil, glideErrors.NewFileNotFoundError(c.syncDir(name),
+					glideErrors.WithSuggestions("List worktrees with: glide project list"),
+				)
+			}
+			targets = append(targets, name)
+		}
+		return targets, nil
+	}
+
+	targets := []string{"vcs"}
+	entries, err := os.ReadDir(filepath.Join(c.ctx.ProjectRoot, "worktrees"))
+	if err != nil {
+		return targets, nil
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			targets = append(targets, entry.Name())
+		}
+	}
+	return targets, nil
+}
+
+// syncDir returns the directory of a worktree, or of vcs/
+func (c *WorktreeCommand) syncDir(name string) string {
+	if name == "vcs" {
+		return filepath.Join(c.ctx.ProjectRoot, "vcs")
+	}
+	return filepath.Join(c.ctx.ProjectRoot, "worktrees", name)
+}
+
+// syncWorktree brings one worktree's branch up to date with its upstream
+func syncWorktree(dir, name, strategy string, dryRun bool) WorktreeSyncResult {
+	result := WorktreeSyncResult{Worktree: name}
+
+	branch, err := gitOutput(dir, "branch", "--show-current")
+	if err != nil {
+		return result.fail(err)
+	}
+	if branch == "" {
+		return result.skip("detached HEAD")
+	}
+	result.Branch = branch
+
+	upstream, err := gitOutput(dir, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	if err != nil {
+		return result.skip("no upstream branch")
+	}
+	result.Upstream = upstream
+
+	// Untracked files don't get in the way of a rebase or merge
+	changes, err := gitOutput(dir, "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return result.fail(err)
+	}
+	if changes != "" {
+		return result.skip("uncommitted changes")
+	}
+
+	behind, err := gitOutput(dir, "rev-list", "--count", "HEAD..@{upstream}")
+	if err != nil {
+		return result.fail(err)
+	}
+	result.Behind, _ = strconv.Atoi(behind)
+	if result.Behind == 0 {
+		result.Status = SyncUpToDate
+		return result
+	}
+	if dryRun {
+		result.Status = SyncPlanned
+		result.Reason = fmt.Sprintf("would %s onto %s", strategy, upstream)
+		return result
+	}
+
+	args := []string{"rebase", "@{upstream}"}
+	if strategy == SyncStrategyMerge {
+		args = []string{"merge", "--no-edit", "@{upstream}"}
+	}
+	if _, err := gitOutput(dir, args...); err != nil {
+		conflicts, _ := gitOutput(dir, "diff", "--name-only", "--diff-filter=U")
+		// Leave the worktree as it was rather than half-way through
+		_, _ = gitOutput(dir, strategy, "--abort")
+		if conflicts == "" {
+			return result.fail(err)
+		}
+		result.Status = SyncConflict
+		result.Conflicts = strings.Split(conflicts, "\n")
+		result.Reason = fmt.Sprintf("%s aborted", strategy)
+		return result
+	}
+
+	result.Status = SyncUpdated
+	return result
+}
+
+// skip marks the result as skipped
+func (r WorktreeSyncResult) skip(reason string) WorktreeSyncResult {
+	r.Status = SyncSkipped
+	r.Reason = reason
+	return r
+}
+
+// fail marks the result as failed
+func (r WorktreeSyncResult) fail(err error) WorktreeSyncResult {
+	r.Status = SyncFailed
+	r.Reason = err.Error()
+	return r
+}
+
+// gitOutput runs git in dir and returns its trimmed output. Errors include
+// git's stderr.
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// showSyncResults prints a table of sync outcomes followed by the
+// conflicting files
+func showSyncResults(results []WorktreeSyncResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Safe to ignore: Table formatting (informational display only)
+	_, _ = fmt.Fprintln(w, "WORKTREE\tBRANCH\tUPSTREAM\tRESULT")
+	for _, r := range results {
+		status := r.Status
+		switch r.Status {
+		case SyncUpdated:
+			status = fmt.Sprintf("✓ updated (%d commit(s))", r.Behind)
+		case SyncUpToDate:
+			status = "✓ up to date"
+		case SyncConflict:
+			status = fmt.Sprintf("✗ conflict in %d file(s), %s", len(r.Conflicts), r.Reason)
+		case SyncFailed:
+			status = "✗ " + r.Reason
+		case SyncSkipped:
+			status = "- skipped: " + r.Reason
+		case SyncPlanned:
+			status = fmt.Sprintf("%d commit(s) behind, %s", r.Behind, r.Reason)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Worktree, orDash(r.Branch), orDash(r.Upstream), status)
+	}
+	_ = w.Flush()
+
+	for _, r := range results {
+		if len(r.Conflicts) == 0 {
+			continue
+		}
+		output.Println()
+		output.Warning("Conflicts in %s:", r.Worktree)
+		for _, file := range r.Conflicts {
+			output.Println("  " + file)
+		}
+	}
+}
+
+// orDash returns s, or "-" when it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncFixture is a multi-worktree project whose remote has moved on
+type syncFixture struct {
+	t      *testing.T
+	root   string
+	remote string
+	other  string
+}
+
+func (f *syncFixture) git(dir string, args ...string) string {
+	f.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(f.t, err, string(out))
+	return string(out)
+}
+
+func (f *syncFixture) commit(dir, file, content string) {
+	f.t.Helper()
+	require.NoError(f.t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+	f.git(dir, "add", file)
+	f.git(dir, "commit", "-q", "-m", "Change "+file)
+}
+
+// newSyncFixture creates vcs/ on main and worktrees feature, dirty, and
+// clash, each tracking a branch the remote has new commits on
+func newSyncFixture(t *testing.T) *syncFixture {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+
+	base := t.TempDir()
+	f := &syncFixture{t: t, root: filepath.Join(base, "project"), remote: filepath.Join(base, "origin.git"), other: filepath.Join(base, "other")}
+	vcs := filepath.Join(f.root, "vcs")
+	require.NoError(t, os.MkdirAll(vcs, 0755))
+
+	f.git(base, "init", "-q", "--bare", "-b", "main", f.remote)
+	f.git(vcs, "init", "-q", "-b", "main")
+	f.git(vcs, "remote", "add", "origin", f.remote)
+	f.commit(vcs, "app.txt", "v1\n")
+	f.git(vcs, "push", "-q", "-u", "origin", "main")
+
+	for _, branch := range []string{"feature", "dirty", "clash"} {
+		f.git(vcs, "worktree", "add", "-q", "-b", branch, filepath.Join(f.root, "worktrees", branch))
+		f.git(filepath.Join(f.root, "worktrees", branch), "push", "-q", "-u", "origin", branch)
+	}
+
+	// Someone else pushes to every branch
+	f.git(base, "clone", "-q", f.remote, f.other)
+	for _, branch := range []string{"main", "feature", "dirty", "clash"} {
+		f.git(f.other, "checkout", "-q", branch)
+		f.commit(f.other, branch+".txt", "upstream\n")
+	}
+	f.git(f.other, "checkout", "-q", "clash")
+	f.commit(f.other, "app.txt", "theirs\n")
+	f.git(f.other, "push", "-q", "--all", "origin")
+
+	f.commit(filepath.Join(f.root, "worktrees", "feature"), "local.txt", "local\n")
+	f.commit(filepath.Join(f.root, "worktrees", "clash"), "app.txt", "ours\n")
+	require.NoError(t, os.WriteFile(filepath.Join(f.root, "worktrees", "dirty", "app.txt"), []byte("wip\n"), 0644))
+
+	return f
+}
+
+func (f *syncFixture) run(cfg *config.Config, args ...string) error {
+	root := &cobra.Command{Use: "glide"}
+	root.PersistentFlags().Bool("dry-run", false, "")
+	ctx := &context.ProjectContext{ProjectRoot: f.root, DevelopmentMode: context.ModeMultiWorktree}
+	root.AddCommand(NewWorktreeCommand(ctx, cfg))
+	root.SetArgs(append([]string{"worktree", "sync"}, args...))
+	return root.Execute()
+}
+
+func TestWorktreeSync(t *testing.T) {
+	f := newSyncFixture(t)
+
+	err := f.run(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 worktree(s) could not be synced: clash")
+
+	feature := filepath.Join(f.root, "worktrees", "feature")
+	assert.FileExists(t, filepath.Join(feature, "feature.txt"), "upstream commits are applied")
+	assert.Equal(t, "Change local.txt\n", f.git(feature, "log", "-1", "--format=%s"), "local commits are rebased on top")
+	assert.Equal(t, "0\n", f.git(feature, "rev-list", "--count", "--merges", "HEAD"), "rebase is the default")
+	assert.FileExists(t, filepath.Join(f.root, "vcs", "main.txt"))
+
+	dirty := filepath.Join(f.root, "worktrees", "dirty")
+	assert.NoFileExists(t, filepath.Join(dirty, "dirty.txt"), "dirty worktrees are skipped")
+
+	clash := filepath.Join(f.root, "worktrees", "clash")
+	assert.NoFileExists(t, filepath.Join(clash, "clash.txt"), "conflicting syncs are aborted")
+	assert.NoDirExists(t, filepath.Join(f.root, "vcs", ".git", "worktrees", "clash", "rebase-merge"))
+}
+
+func TestWorktreeSync_Merge(t *testing.T) {
+	f := newSyncFixture(t)
+	cfg := &config.Config{Defaults: config.DefaultsConfig{Worktree: config.WorktreeDefaults{SyncStrategy: SyncStrategyMerge}}}
+
+	require.NoError(t, f.run(cfg, "feature"))
+	feature := filepath.Join(f.root, "worktrees", "feature")
+	assert.Equal(t, "1\n", f.git(feature, "rev-list", "--count", "--merges", "HEAD"), "the upstream branch is merged")
+}
+
+func TestWorktreeSync_DryRun(t *testing.T) {
+	f := newSyncFixture(t)
+	f.git(filepath.Join(f.root, "vcs"), "fetch", "-q", "origin")
+
+	require.NoError(t, f.run(nil, "--dry-run", "feature"))
+	assert.NoFileExists(t, filepath.Join(f.root, "worktrees", "feature", "feature.txt"))
+}
+
+func TestWorktreeSync_InvalidStrategy(t *testing.T) {
+	f := &syncFixture{t: t, root: t.TempDir()}
+	err := f.run(nil, "--strategy", "squash")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid sync strategy "squash"`)
+}
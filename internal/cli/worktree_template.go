@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// runWorktreeStep runs a command of a worktree template in dir with env and
+// the terminal attached; it is replaced in tests
+var runWorktreeStep = func(dir string, env []string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// worktreeTemplates returns the templates the project's .glide.yml files
+// define, falling back to the global configuration's
+func (c *WorktreeCommand) worktreeTemplates() map[string]config.WorktreeTemplate {
+	templates := make(map[string]config.WorktreeTemplate)
+	if c.cfg != nil {
+		for name, t := range c.cfg.WorktreeTemplates {
+			templates[name] = t
+		}
+	}
+	for name, t := range localProjectConfig().WorktreeTemplates {
+		templates[name] = t
+	}
+	return templates
+}
+
+// addTemplateFlag adds --template to a worktree creating command
+func (c *WorktreeCommand) addTemplateFlag(cmd *cobra.Command) {
+	cmd.Flags().String("template", "", "Worktree template from worktree_templates in .glide.yml")
+	_ = cmd.RegisterFlagCompletionFunc("template", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		var names []string
+		for name, t := range c.worktreeTemplates() {
+			if t.Description != "" {
+				name += "\t" + t.Description
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// templateFlag returns the template --template selects, or nil. A
+// project's template that starts services or runs seed commands needs the
+// project to be trusted, like its other commands.
+func (c *WorktreeCommand) templateFlag(cmd *cobra.Command) (*config.WorktreeTemplate, error) {
+	name, _ := cmd.Flags().GetString("template")
+	if name == "" {
+		return nil, nil
+	}
+
+	templates := c.worktreeTemplates()
+	t, ok := templates[name]
+	if !ok {
+		names := make([]string, 0, len(templates))
+		for n := range templates {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		suggestion := "Define it under worktree_templates in .glide.yml"
+		if len(names) > 0 {
+			suggestion = "Available templates: " + strings.Join(names, ", ")
+		}
+		return nil, glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("unknown worktree template %q", name),
+			glideErrors.WithSuggestions(suggestion),
+		)
+	}
+
+	if _, fromProject := localProjectConfig().WorktreeTemplates[name]; fromProject && (len(t.Services) > 0 || len(t.Seed) > 0) {
+		cwd, _ := os.Getwd()
+		if dir := projectTrustDir(cwd); dir != "" {
+			if err := requireTrust(dir); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &t, nil
+}
+
+// writeEnvTemplate renders the template's env file from vcs/ into the
+// worktree's .env
+func (c *WorktreeCommand) writeEnvTemplate(vcsDir, worktreePath, envTemplate, branchName string) error {
+	source := filepath.Join(vcsDir, envTemplate)
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return glideErrors.NewFileNotFoundError(source,
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions("Fix env_template of the worktree template, relative to vcs/"),
+		)
+	}
+
+	output.Printf("📋 Writing .env from %s... ", envTemplate)
+	rendered := renderEnvTemplate(string(data), branchName, filepath.Base(worktreePath))
+	if err := os.WriteFile(filepath.Join(worktreePath, ".env"), []byte(rendered), 0644); err != nil {
+		output.Println()
+		return glideErrors.NewPermissionError(filepath.Join(worktreePath, ".env"), "failed to write .env file",
+			glideErrors.WithError(err),
+		)
+	}
+	output.Success("✓")
+	return nil
+}
+
+// renderEnvTemplate replaces the placeholders of an env file template
+func renderEnvTemplate(data, branchName, worktreeName string) string {
+	return strings.NewReplacer("{{branch}}", branchName, "{{worktree}}", worktreeName).Replace(data)
+}
+
+// applyTemplate starts the template's services in the new worktree and runs
+// its seed commands there. The services are labeled as the worktree's, as
+// when they are started from inside it.
+func (c *WorktreeCommand) applyTemplate(worktreePath string, t *config.WorktreeTemplate) error {
+	if len(t.Services) == 0 && len(t.Seed) == 0 {
+		return nil
+	}
+	env := c.worktreeEnv(filepath.Base(worktreePath))
+
+	if len(t.Services) > 0 {
+		ctx := c.worktreeContext(filepath.Base(worktreePath))
+		if err := startSharedServices(ctx, env); err != nil {
+			return err
+		}
+
+		// Naming a shared service would start the worktree's own copy
+		shared := sharedServices(ctx)
+		var services []string
+		for _, service := range t.Services {
+			if !slices.Contains(shared, service) {
+				services = append(services, service)
+			}
+		}
+		if len(services) > 0 {
+			output.Info("🐳 Starting %s", strings.Join(services, ", "))
+			args := append([]string{"compose", "up", "--detach"}, services...)
+			if err := runWorktreeStep(worktreePath, env, "docker", args...); err != nil {
+				return glideErrors.NewDockerError("failed to start the template's services",
+					glideErrors.WithError(err),
+					glideErrors.WithSuggestions(
+						"The worktree was created; start the services from it: cd "+worktreePath,
+					),
+				)
+			}
+		}
+	}
+
+	for _, seed := range t.Seed {
+		output.Info("🌱 %s", seed)
+		if err := runWorktreeStep(worktreePath, env, "sh", "-c", seed); err != nil {
+			return glideErrors.NewCommandError(seed, metaExitCode(err),
+				glideErrors.WithError(err),
+				glideErrors.WithSuggestions(
+					"The worktree was created; rerun the remaining seed commands from it: cd "+worktreePath,
+				),
+			)
+		}
+	}
+	return nil
+}
+
+// worktreeEnv returns the environment the template's commands run with:
+// glide's own, with COMPOSE_FILE naming the worktree's compose files and
+// generated overrides, and the worktree's namespace, instead of those of
+// the worktree glide runs in
+func (c *WorktreeCommand) worktreeEnv(worktreeName string) []string {
+	env := metaEnvironment()
+	ctx := c.worktreeContext(worktreeName)
+	if ns, ok := worktreeNamespace(ctx); ok {
+		for key, value := range namespaceDefaults(ctx, ns, env) {
+			env = append(env, key+"="+value)
+		}
+	}
+
+	withoutComposeEnv(func() {
+		dir, _ := currentWorktree(ctx)
+		files := docker.ComposeFiles(dir)
+		if labeled, ok := ownershipComposeFiles(ctx, files); ok {
+			files = labeled
+		}
+		if shared, ok := sharedComposeFiles(ctx, files); ok {
+			files = shared
+		}
+		if len(files) > 0 {
+			env = append(env, "COMPOSE_FILE="+strings.Join(files, string(os.PathListSeparator)))
+		}
+	})
+	return env
+}
+
+// worktreeContext returns the context glide would detect inside a worktree
+// of the project
+func (c *WorktreeCommand) worktreeContext(worktreeName string) *context.ProjectContext {
+	return &context.ProjectContext{
+		ProjectRoot:     c.ctx.ProjectRoot,
+		DevelopmentMode: context.ModeMultiWorktree,
+		IsWorktree:      true,
+		WorktreeName:    worktreeName,
+	}
+}
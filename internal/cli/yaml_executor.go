@@ -45,14 +45,32 @@ func init() {
 
 // ExecuteYAMLCommand runs a YAML-defined command
 func ExecuteYAMLCommand(cmdStr string, args []string) error {
+	expanded, err := prepareYAMLCommand(cmdStr, args)
+	if err != nil {
+		return err
+	}
+
+	// Execute as a shell script
+	// This properly handles:
+	// - Single commands
+	// - Multi-line scripts
+	// - Pipes and redirects (if allowed by sanitizer)
+	// - Control structures (if allowed by sanitizer)
+	// - Shell built-ins and functions
+	return executeShellCommand(expanded)
+}
+
+// prepareYAMLCommand validates a YAML command and its arguments and returns
+// the expanded shell script that would be executed
+func prepareYAMLCommand(cmdStr string, args []string) (string, error) {
 	// Validate command before expansion (check command string itself)
 	if err := yamlCommandSanitizer.Validate(cmdStr, []string{}); err != nil {
-		return fmt.Errorf("YAML command validation failed: %w\n\nTo disable sanitization (UNSAFE): export GLIDE_YAML_SANITIZE_MODE=disabled", err)
+		return "", fmt.Errorf("YAML command validation failed: %w\n\nTo disable sanitization (UNSAFE): export GLIDE_YAML_SANITIZE_MODE=disabled", err)
 	}
 
 	// Validate arguments before expansion
 	if err := yamlCommandSanitizer.Validate("", args); err != nil {
-		return fmt.Errorf("YAML command arguments validation failed: %w\n\nTo disable sanitization (UNSAFE): export GLIDE_YAML_SANITIZE_MODE=disabled", err)
+		return "", fmt.Errorf("YAML command arguments validation failed: %w\n\nTo disable sanitization (UNSAFE): export GLIDE_YAML_SANITIZE_MODE=disabled", err)
 	}
 
 	// Expand parameters
@@ -61,17 +79,10 @@ func ExecuteYAMLCommand(cmdStr string, args []string) error {
 	// Validate expanded command as final check
 	// This catches injection attempts that might occur during expansion
 	if err := yamlCommandSanitizer.Validate(expanded, []string{}); err != nil {
-		return fmt.Errorf("expanded YAML command validation failed: %w\n\nCommand after expansion: %s\n\nTo disable sanitization (UNSAFE): export GLIDE_YAML_SANITIZE_MODE=disabled", err, expanded)
+		return "", fmt.Errorf("expanded YAML command validation failed: %w\n\nCommand after expansion: %s\n\nTo disable sanitization (UNSAFE): export GLIDE_YAML_SANITIZE_MODE=disabled", err, expanded)
 	}
 
-	// Execute as a shell script
-	// This properly handles:
-	// - Single commands
-	// - Multi-line scripts
-	// - Pipes and redirects (if allowed by sanitizer)
-	// - Control structures (if allowed by sanitizer)
-	// - Shell built-ins and functions
-	return executeShellCommand(expanded)
+	return expanded, nil
 }
 
 // executeShellCommand runs a command through the shell
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// KeyAlias maps a renamed configuration key to the key that replaced it.
+// Keys are dotted paths from the root of the config file, e.g.
+// "defaults.docker.compose_timeout".
+type KeyAlias struct {
+	Old string
+	New string
+	// Since is the version that deprecated Old
+	Since string
+	// RemovedIn is the major version that stops accepting Old
+	RemovedIn string
+	// Migration points at the notes explaining the rename
+	Migration string
+}
+
+// keyAliases lists the renamed keys that still work. Add an entry when a
+// key is renamed and delete it in the major version named by RemovedIn.
+var keyAliases []KeyAlias
+
+// KeyAliases returns the registered configuration key aliases
+func KeyAliases() []KeyAlias {
+	return append([]KeyAlias(nil), keyAliases...)
+}
+
+// Deprecation records a deprecated key found in a config file
+type Deprecation struct {
+	KeyAlias
+	File string
+	// Ignored is set when the file also sets the new key, which wins
+	Ignored bool
+}
+
+// ApplyKeyAliases rewrites deprecated keys in YAML config data to their
+// replacements. Data without deprecated keys is returned unchanged.
+func ApplyKeyAliases(data []byte, file string) ([]byte, []Deprecation, error) {
+	if len(keyAliases) == 0 {
+		return data, nil, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil, nil
+	}
+	root := doc.Content[0]
+
+	var found []Deprecation
+	for _, alias := range keyAliases {
+		key, value := removeKey(root, strings.Split(alias.Old, "."))
+		if key == nil {
+			continue
+		}
+
+		d := Deprecation{KeyAlias: alias, File: file}
+		if !setKey(root, strings.Split(alias.New, "."), key, value) {
+			d.Ignored = true
+		}
+		found = append(found, d)
+	}
+	if len(found) == 0 {
+		return data, nil, nil
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, found, nil
+}
+
+// removeKey deletes the key at path from a mapping node and returns its
+// key and value nodes, or nil when the key is not set
+func removeKey(node *yaml.Node, path []string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != path[0] {
+			continue
+		}
+		key, value := node.Content[i], node.Content[i+1]
+		if len(path) == 1 {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return key, value
+		}
+		if value.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+		return removeKey(value, path[1:])
+	}
+	return nil, nil
+}
+
+// setKey stores value at path, creating intermediate mappings. It leaves
+// an existing value alone and reports false.
+func setKey(node *yaml.Node, path []string, key, value *yaml.Node) bool {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			return false
+		}
+		child := node.Content[i+1]
+		if child.Kind != yaml.MappingNode {
+			return false
+		}
+		return setKey(child, path[1:], key, value)
+	}
+
+	if len(path) > 1 {
+		child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}, child)
+		return setKey(child, path[1:], key, value)
+	}
+
+	renamed := *key
+	renamed.Value = path[0]
+	node.Content = append(node.Content, &renamed, value)
+	return true
+}
+
+// warnedDeprecations keeps a config that is loaded several times in one
+// run from repeating its warnings
+var warnedDeprecations sync.Map
+
+// warnDeprecations logs a structured warning for each deprecated key
+func warnDeprecations(found []Deprecation) {
+	for _, d := range found {
+		if _, seen := warnedDeprecations.LoadOrStore(d.File+"\x00"+d.Old, true); seen {
+			continue
+		}
+
+		msg := "Deprecated configuration key, use the replacement instead"
+		if d.Ignored {
+			msg = "Deprecated configuration key ignored because its replacement is also set"
+		}
+		logging.Warn(msg,
+			"file", d.File,
+			"key", d.Old,
+			"replacement", d.New,
+			"since", d.Since,
+			"removed_in", d.RemovedIn,
+			"migration", d.Migration,
+			"fix", "glide upgrade-config",
+		)
+	}
+}
+
+// unmarshalConfig parses config file data into cfg, honouring deprecated
+// key aliases
+func unmarshalConfig(data []byte, file string, cfg interface{}) ([]byte, error) {
+	data, found, err := ApplyKeyAliases(data, file)
+	if err != nil {
+		return nil, err
+	}
+	warnDeprecations(found)
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UnmarshalConfig parses the data of the config file at path into cfg,
+// rewriting deprecated keys and warning about them
+func UnmarshalConfig(data []byte, file string, cfg *Config) error {
+	if _, err := unmarshalConfig(data, file, cfg); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return nil
+}
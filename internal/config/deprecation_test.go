@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withKeyAliases registers aliases for the duration of a test
+func withKeyAliases(t *testing.T, aliases ...KeyAlias) {
+	t.Helper()
+	orig := keyAliases
+	keyAliases = aliases
+	t.Cleanup(func() { keyAliases = orig })
+}
+
+func TestApplyKeyAliases(t *testing.T) {
+	withKeyAliases(t,
+		KeyAlias{Old: "defaults.test.workers", New: "defaults.test.processes", Since: "3.2.0", RemovedIn: "4.0.0"},
+		KeyAlias{Old: "test_defaults", New: "defaults.test"},
+	)
+
+	t.Run("renames deprecated keys", func(t *testing.T) {
+		data, found, err := ApplyKeyAliases([]byte("defaults:\n  test:\n    workers: 6\n"), "/a/.glide.yml")
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "defaults.test.workers", found[0].Old)
+		assert.Equal(t, "/a/.glide.yml", found[0].File)
+		assert.False(t, found[0].Ignored)
+
+		var cfg Config
+		require.NoError(t, UnmarshalConfig(data, "/a/.glide.yml", &cfg))
+		assert.Equal(t, 6, cfg.Defaults.Test.Processes)
+	})
+
+	t.Run("creates missing parents", func(t *testing.T) {
+		data, found, err := ApplyKeyAliases([]byte("test_defaults:\n  processes: 2\n"), "")
+		require.NoError(t, err)
+		assert.Len(t, found, 1)
+
+		var cfg Config
+		require.NoError(t, UnmarshalConfig(data, "", &cfg))
+		assert.Equal(t, 2, cfg.Defaults.Test.Processes)
+	})
+
+	t.Run("the new key wins", func(t *testing.T) {
+		data, found, err := ApplyKeyAliases([]byte("defaults:\n  test:\n    workers: 6\n    processes: 4\n"), "")
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.True(t, found[0].Ignored)
+
+		var cfg Config
+		require.NoError(t, UnmarshalConfig(data, "", &cfg))
+		assert.Equal(t, 4, cfg.Defaults.Test.Processes)
+	})
+
+	t.Run("leaves current configs untouched", func(t *testing.T) {
+		in := []byte("defaults:\n  test:\n    processes: 4 # tuned\n")
+		data, found, err := ApplyKeyAliases(in, "")
+		require.NoError(t, err)
+		assert.Empty(t, found)
+		assert.Equal(t, in, data)
+	})
+}
+
+func TestLoader_Load_DeprecatedKeys(t *testing.T) {
+	withKeyAliases(t, KeyAlias{Old: "defaults.test.workers", New: "defaults.test.processes"})
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	configPath := filepath.Join(tempDir, ".glide.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("defaults:\n  test:\n    workers: 7\n"), 0644))
+
+	loader := &Loader{configPath: configPath}
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 7, cfg.Defaults.Test.Processes)
+}
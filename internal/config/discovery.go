@@ -4,9 +4,10 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/glide-cli/glide/v3/internal/chaos"
 	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/glide-cli/glide/v3/pkg/ignore"
 	"github.com/glide-cli/glide/v3/pkg/validation"
-	"gopkg.in/yaml.v3"
 )
 
 // DiscoverConfigs finds all configuration files up the directory tree
@@ -34,6 +35,10 @@ func DiscoverConfigs(startDir string) ([]string, error) {
 		// Check if we've reached project root (has .git)
 		gitPath := filepath.Join(current, ".git")
 		if _, err := os.Stat(gitPath); err == nil {
+			// Configs shipped inside ignored directories, such as a
+			// dependency in node_modules/, are not the project's
+			configs = withoutIgnored(configs, ignore.Load(current))
+
 			// Add this config if it exists and isn't already added
 			configPath := filepath.Join(current, branding.ConfigFileName)
 			if _, err := os.Stat(configPath); err == nil {
@@ -58,6 +63,17 @@ func DiscoverConfigs(startDir string) ([]string, error) {
 	return configs, nil
 }
 
+// withoutIgnored drops the configs in directories the rules ignore
+func withoutIgnored(configs []string, rules *ignore.Rules) []string {
+	kept := configs[:0]
+	for _, configPath := range configs {
+		if !rules.Match(filepath.Dir(configPath), true) {
+			kept = append(kept, configPath)
+		}
+	}
+	return kept
+}
+
 // LoadAndMergeConfigs loads multiple config files and merges them
 func LoadAndMergeConfigs(configPaths []string) (*Config, error) {
 	merged := &Config{
@@ -84,16 +100,19 @@ func LoadAndMergeConfigs(configPaths []string) (*Config, error) {
 			continue // Skip invalid paths
 		}
 
-		data, err := os.ReadFile(validatedPath)
+		data, err := chaos.ReadConfig(validatedPath)
 		if err != nil {
 			continue // Skip configs that can't be read
 		}
 
 		var cfg Config
-		if err := yaml.Unmarshal(data, &cfg); err != nil {
+		if _, err := unmarshalConfig(data, validatedPath, &cfg); err != nil {
 			continue // Skip invalid configs
 		}
 
+		// Any config asking for strict checking applies it to all of them
+		merged.Strict = merged.Strict || cfg.Strict
+
 		// Merge commands (later configs override earlier ones)
 		if cfg.Commands != nil {
 			for name, cmd := range cfg.Commands {
@@ -112,6 +131,93 @@ func LoadAndMergeConfigs(configPaths []string) (*Config, error) {
 		// The config loader extracts plugin configs from raw YAML and syncs them
 		// to the typed registry automatically.
 
+		// The nearest config that defines snapshot settings wins
+		if len(cfg.Snapshot.EnvFiles) > 0 || len(cfg.Snapshot.Databases) > 0 {
+			merged.Snapshot = cfg.Snapshot
+		}
+
+		// The nearest config that defines synced services wins
+		if len(cfg.Sync.Services) > 0 {
+			merged.Sync = cfg.Sync
+		}
+
+		// The nearest config that lists enabled or disabled plugins wins
+		if cfg.Plugins.Enabled != nil {
+			merged.Plugins.Enabled = cfg.Plugins.Enabled
+		}
+		if cfg.Plugins.Disabled != nil {
+			merged.Plugins.Disabled = cfg.Plugins.Disabled
+		}
+
+		// Build and top settings are merged field by field, nearest first
+		if cfg.Build.Parallelism != 0 {
+			merged.Build.Parallelism = cfg.Build.Parallelism
+		}
+		if len(cfg.Build.CacheFrom) > 0 {
+			merged.Build.CacheFrom = cfg.Build.CacheFrom
+		}
+		if len(cfg.Build.CacheTo) > 0 {
+			merged.Build.CacheTo = cfg.Build.CacheTo
+		}
+		if cfg.Top.CPUWarn != 0 {
+			merged.Top.CPUWarn = cfg.Top.CPUWarn
+		}
+		if cfg.Top.MemoryWarn != 0 {
+			merged.Top.MemoryWarn = cfg.Top.MemoryWarn
+		}
+
+		// Exec defaults are merged per service, nearest first
+		for service, execCfg := range cfg.Exec {
+			if merged.Exec == nil {
+				merged.Exec = make(map[string]ExecConfig)
+			}
+			merged.Exec[service] = execCfg
+		}
+
+		// Worktree templates are merged per template, nearest first
+		for name, template := range cfg.WorktreeTemplates {
+			if merged.WorktreeTemplates == nil {
+				merged.WorktreeTemplates = make(map[string]WorktreeTemplate)
+			}
+			merged.WorktreeTemplates[name] = template
+		}
+
+		if len(cfg.SharedServices) > 0 {
+			merged.SharedServices = cfg.SharedServices
+		}
+
+		// Git policy settings are merged field by field, nearest first
+		if cfg.GitPolicy.BranchPattern != "" {
+			merged.GitPolicy.BranchPattern = cfg.GitPolicy.BranchPattern
+		}
+		if cfg.GitPolicy.CommitPattern != "" {
+			merged.GitPolicy.CommitPattern = cfg.GitPolicy.CommitPattern
+		}
+		if len(cfg.GitPolicy.ExemptBranches) > 0 {
+			merged.GitPolicy.ExemptBranches = cfg.GitPolicy.ExemptBranches
+		}
+
+		// Performance budgets are merged per operation, nearest first
+		for name, budget := range cfg.Performance.Budgets {
+			if merged.Performance.Budgets == nil {
+				merged.Performance.Budgets = make(map[string]BudgetConfig)
+			}
+			merged.Performance.Budgets[name] = budget
+		}
+
+		// Flag defaults are merged per command and flag, nearest first
+		for command, flags := range cfg.Flags {
+			if merged.Flags == nil {
+				merged.Flags = make(map[string]map[string]interface{})
+			}
+			if merged.Flags[command] == nil {
+				merged.Flags[command] = make(map[string]interface{})
+			}
+			for name, value := range flags {
+				merged.Flags[command][name] = value
+			}
+		}
+
 		// Take the first non-empty default project
 		if merged.DefaultProject == "" && cfg.DefaultProject != "" {
 			merged.DefaultProject = cfg.DefaultProject
@@ -166,6 +272,9 @@ func mergeDefaults(target, source *DefaultsConfig) {
 	if !target.Worktree.RunMigrations && source.Worktree.RunMigrations {
 		target.Worktree.RunMigrations = source.Worktree.RunMigrations
 	}
+	if target.Worktree.SyncStrategy == "" && source.Worktree.SyncStrategy != "" {
+		target.Worktree.SyncStrategy = source.Worktree.SyncStrategy
+	}
 
 	// Update defaults - note: we use explicit false checks since defaults are true
 	// This means user must explicitly set to false to override
@@ -107,6 +107,23 @@ func TestDiscoverConfigs_GitRoot(t *testing.T) {
 	assert.Equal(t, rootConfig, configs[0])
 }
 
+func TestDiscoverConfigs_SkipsIgnoredDirectories(t *testing.T) {
+	// A dependency in node_modules/ ships its own config
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, ".git"), 0755))
+	rootConfig := filepath.Join(tempDir, branding.ConfigFileName)
+	require.NoError(t, os.WriteFile(rootConfig, []byte("{}"), 0644))
+
+	depDir := filepath.Join(tempDir, "node_modules", "some-tool")
+	require.NoError(t, os.MkdirAll(depDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(depDir, branding.ConfigFileName), []byte("{}"), 0644))
+
+	configs, err := DiscoverConfigs(depDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{rootConfig}, configs)
+}
+
 func TestDiscoverConfigs_NoConfig(t *testing.T) {
 	// Create empty directory
 	tempDir := t.TempDir()
@@ -237,6 +254,196 @@ commands:
 	assert.Equal(t, "golangci-lint run", merged.Commands["lint"], "Child's lint command should be added")
 }
 
+func TestLoadAndMergeConfigs_Snapshot(t *testing.T) {
+	tempDir := t.TempDir()
+
+	parentConfig := filepath.Join(tempDir, "parent.yml")
+	parentYAML := `
+snapshot:
+  env_files: [".env"]
+  databases:
+    - service: mysql
+      dump: mysqldump --all-databases
+      restore: mysql
+`
+	require.NoError(t, os.WriteFile(parentConfig, []byte(parentYAML), 0644))
+
+	childConfig := filepath.Join(tempDir, "child.yml")
+	childYAML := `
+commands:
+  test: "go test"
+`
+	require.NoError(t, os.WriteFile(childConfig, []byte(childYAML), 0644))
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tempDir)
+
+	// A child without snapshot settings keeps the parent's
+	merged, err := LoadAndMergeConfigs([]string{childConfig, parentConfig})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{".env"}, merged.Snapshot.EnvFiles)
+	require.Len(t, merged.Snapshot.Databases, 1)
+	assert.Equal(t, SnapshotDatabase{Service: "mysql", Dump: "mysqldump --all-databases", Restore: "mysql"}, merged.Snapshot.Databases[0])
+}
+
+func TestLoadAndMergeConfigs_GitPolicy(t *testing.T) {
+	tempDir := t.TempDir()
+
+	parentConfig := filepath.Join(tempDir, "parent.yml")
+	parentYAML := `
+git_policy:
+  branch_pattern: "^(feature|fix)/"
+  commit_pattern: "^[A-Z]"
+`
+	require.NoError(t, os.WriteFile(parentConfig, []byte(parentYAML), 0644))
+
+	childConfig := filepath.Join(tempDir, "child.yml")
+	childYAML := `
+git_policy:
+  commit_pattern: "^(feat|fix): "
+`
+	require.NoError(t, os.WriteFile(childConfig, []byte(childYAML), 0644))
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tempDir)
+
+	// The child overrides only the fields it sets
+	merged, err := LoadAndMergeConfigs([]string{childConfig, parentConfig})
+	require.NoError(t, err)
+
+	assert.Equal(t, "^(feature|fix)/", merged.GitPolicy.BranchPattern)
+	assert.Equal(t, "^(feat|fix): ", merged.GitPolicy.CommitPattern)
+}
+
+func TestLoadAndMergeConfigs_Plugins(t *testing.T) {
+	tempDir := t.TempDir()
+
+	parentConfig := filepath.Join(tempDir, "parent.yml")
+	parentYAML := `
+plugins:
+  enabled: [docker, aws]
+  disabled: [k8s]
+`
+	require.NoError(t, os.WriteFile(parentConfig, []byte(parentYAML), 0644))
+
+	childConfig := filepath.Join(tempDir, "child.yml")
+	childYAML := `
+plugins:
+  enabled: [docker]
+  docker:
+    compose_file: docker-compose.dev.yml
+`
+	require.NoError(t, os.WriteFile(childConfig, []byte(childYAML), 0644))
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tempDir)
+
+	// The child overrides only the list it sets, next to plugin sections
+	merged, err := LoadAndMergeConfigs([]string{childConfig, parentConfig})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"docker"}, merged.Plugins.Enabled)
+	assert.Equal(t, []string{"k8s"}, merged.Plugins.Disabled)
+}
+
+func TestLoadAndMergeConfigs_PerformanceBudgets(t *testing.T) {
+	tempDir := t.TempDir()
+
+	parentConfig := filepath.Join(tempDir, "parent.yml")
+	parentYAML := `
+performance:
+  budgets:
+    context_detection:
+      max_duration: 200ms
+    config_load:
+      max_duration: 80ms
+`
+	require.NoError(t, os.WriteFile(parentConfig, []byte(parentYAML), 0644))
+
+	childConfig := filepath.Join(tempDir, "child.yml")
+	childYAML := `
+performance:
+  budgets:
+    context_detection:
+      max_duration: 300ms
+`
+	require.NoError(t, os.WriteFile(childConfig, []byte(childYAML), 0644))
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tempDir)
+
+	// The child overrides only the budgets it sets
+	merged, err := LoadAndMergeConfigs([]string{childConfig, parentConfig})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]BudgetConfig{
+		"context_detection": {MaxDuration: "300ms"},
+		"config_load":       {MaxDuration: "80ms"},
+	}, merged.Performance.Budgets)
+}
+
+func TestLoadAndMergeConfigs_Exec(t *testing.T) {
+	tempDir := t.TempDir()
+
+	parentConfig := filepath.Join(tempDir, "parent.yml")
+	parentYAML := `
+exec:
+  php:
+    user: root
+  node:
+    workdir: /app
+`
+	require.NoError(t, os.WriteFile(parentConfig, []byte(parentYAML), 0644))
+
+	childConfig := filepath.Join(tempDir, "child.yml")
+	childYAML := `
+exec:
+  php:
+    user: www-data
+    env:
+      XDEBUG_MODE: "off"
+`
+	require.NoError(t, os.WriteFile(childConfig, []byte(childYAML), 0644))
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tempDir)
+
+	// The child replaces the services it sets
+	merged, err := LoadAndMergeConfigs([]string{childConfig, parentConfig})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]ExecConfig{
+		"php":  {User: "www-data", Env: map[string]string{"XDEBUG_MODE": "off"}},
+		"node": {Workdir: "/app"},
+	}, merged.Exec)
+}
+
+func TestLoadAndMergeConfigs_SharedServices(t *testing.T) {
+	tempDir := t.TempDir()
+
+	parentConfig := filepath.Join(tempDir, "parent.yml")
+	require.NoError(t, os.WriteFile(parentConfig, []byte("shared_services: [mysql, redis]\n"), 0644))
+	childConfig := filepath.Join(tempDir, "child.yml")
+	require.NoError(t, os.WriteFile(childConfig, []byte("shared_services: [postgres]\n"), 0644))
+	emptyConfig := filepath.Join(tempDir, "empty.yml")
+	require.NoError(t, os.WriteFile(emptyConfig, []byte("default_project: shop\n"), 0644))
+
+	// The nearest list replaces the others
+	merged, err := LoadAndMergeConfigs([]string{childConfig, parentConfig})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"postgres"}, merged.SharedServices)
+
+	merged, err = LoadAndMergeConfigs([]string{emptyConfig, parentConfig})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mysql", "redis"}, merged.SharedServices)
+}
+
 func TestLoadAndMergeConfigs_MergeProjects(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -398,6 +605,7 @@ func TestMergeDefaults_AllFields(t *testing.T) {
 			AutoSetup:     true,
 			CopyEnv:       true,
 			RunMigrations: true,
+			SyncStrategy:  "merge",
 		},
 	}
 
@@ -421,4 +629,5 @@ func TestMergeDefaults_AllFields(t *testing.T) {
 	assert.True(t, target.Worktree.AutoSetup)
 	assert.True(t, target.Worktree.CopyEnv)
 	assert.True(t, target.Worktree.RunMigrations)
+	assert.Equal(t, "merge", target.Worktree.SyncStrategy)
 }
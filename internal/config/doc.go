@@ -57,6 +57,26 @@
 //	loader.LoadWithContext(projectCtx)
 //	// Searches: ./glide.yml, ../.glide.yml, etc.
 //
+// # Renamed Keys
+//
+// A renamed key keeps working for one major version through an entry in
+// keyAliases. Config files using the old key are rewritten as they load,
+// and a warning names the file, the replacement, and the migration notes:
+//
+//	{Old: "defaults.test.workers", New: "defaults.test.processes",
+//	 Since: "3.2.0", RemovedIn: "4.0.0", Migration: "docs/releases/v3.2.0.md"}
+//
+// When a file sets both keys the new one wins.
+//
+// # Strict Mode
+//
+// Unknown keys are ignored unless a config sets strict: true or glide runs
+// with --strict-config. FindUnknownKeys walks the YAML alongside the Config
+// type, and the plugins section alongside registered plugin schemas:
+//
+//	keys, _ := config.FindUnknownKeys(data, path)
+//	// keys[0].Key == "defaults.test.procceses", Suggestion == "processes"
+//
 // # Security
 //
 // Path validation prevents directory traversal attacks:
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/glide-cli/glide/v3/internal/chaos"
 	"github.com/glide-cli/glide/v3/internal/context"
 	"github.com/glide-cli/glide/v3/pkg/branding"
 	pkgconfig "github.com/glide-cli/glide/v3/pkg/config"
@@ -64,18 +65,30 @@ func (l *Loader) Load() (*Config, error) {
 	logging.Debug("Reading config file", "path", validatedPath)
 
 	// Read config file
-	data, err := os.ReadFile(validatedPath)
+	data, err := chaos.ReadConfig(validatedPath)
 	if err != nil {
 		logging.Error("Failed to read config file", "path", validatedPath, "error", err)
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Parse YAML into main config
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	// Parse YAML into main config, renaming deprecated keys
+	data, err = unmarshalConfig(data, validatedPath, &config)
+	if err != nil {
 		logging.Error("Failed to parse config file", "path", validatedPath, "error", err)
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	// Strict configs reject keys no setting reads
+	if config.Strict {
+		keys, err := FindUnknownKeys(data, validatedPath)
+		if err == nil {
+			err = unknownKeysError(keys)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Also parse raw YAML to extract plugin configs for type-safe registry
 	var rawConfig map[string]interface{}
 	if err := yaml.Unmarshal(data, &rawConfig); err == nil {
@@ -161,6 +174,31 @@ func (l *Loader) AddProject(name, path, mode string) error {
 	return l.Save(config)
 }
 
+// SetFeature turns a feature flag on or off in the configuration
+func (l *Loader) SetFeature(name string, enabled bool) error {
+	config, err := l.Load()
+	if err != nil {
+		return err
+	}
+
+	if config.Features == nil {
+		config.Features = make(map[string]bool)
+	}
+	config.Features[name] = enabled
+
+	return l.Save(config)
+}
+
+// Update loads the configuration, changes it with fn, and saves it
+func (l *Loader) Update(fn func(*Config)) error {
+	config, err := l.Load()
+	if err != nil {
+		return err
+	}
+	fn(config)
+	return l.Save(config)
+}
+
 // detectActiveProject finds the project matching the current context
 func (l *Loader) detectActiveProject(config *Config, ctx *context.ProjectContext) *ProjectConfig {
 	if ctx == nil || ctx.ProjectRoot == "" {
@@ -255,6 +293,13 @@ func (l *Loader) validate(config *Config) error {
 		return fmt.Errorf("invalid color setting: %s (must be auto/always/never)", config.Defaults.Colors.Enabled)
 	}
 
+	// Validate the update channel
+	switch config.Defaults.Update.Channel {
+	case "", "stable", "prerelease":
+	default:
+		return fmt.Errorf("invalid update channel: %s (must be stable or prerelease)", config.Defaults.Update.Channel)
+	}
+
 	// Validate default project exists if specified
 	if config.DefaultProject != "" {
 		if _, ok := config.Projects[config.DefaultProject]; !ok {
@@ -294,17 +339,27 @@ func (l *Loader) syncPluginConfigsFromRaw(rawConfig map[string]interface{}) {
 		return
 	}
 
-	// For each plugin config in the YAML
-	for pluginName, rawPluginConfig := range plugins {
-		// Check if this plugin has registered a typed config
-		if !pkgconfig.Exists(pluginName) {
+	// Each plugin only gets its own section plus the shared one
+	pkgconfig.SetPluginSections(plugins)
+	_, hasShared := plugins[pkgconfig.SharedSection]
+
+	for pluginName := range plugins {
+		if pluginName == "enabled" || pluginName == "disabled" {
+			continue // project plugin lists, see PluginsConfig
+		}
+		if pluginName != pkgconfig.SharedSection && !pkgconfig.Exists(pluginName) {
 			logging.Debug("Plugin config not registered in typed registry",
 				"plugin", pluginName)
+		}
+	}
+
+	for _, pluginName := range pkgconfig.List() {
+		if _, ok := plugins[pluginName]; !ok && !hasShared {
 			continue
 		}
 
-		// Update the typed config with the raw YAML data
-		if err := pkgconfig.Update(pluginName, rawPluginConfig); err != nil {
+		// Update the typed config with the plugin's scope of the raw YAML data
+		if err := pkgconfig.Update(pluginName, pkgconfig.PluginScope(pluginName)); err != nil {
 			logging.Warn("Failed to update typed plugin config",
 				"plugin", pluginName,
 				"error", err)
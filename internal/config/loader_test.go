@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/glide-cli/glide/v3/internal/context"
+	pkgconfig "github.com/glide-cli/glide/v3/pkg/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -455,3 +456,86 @@ defaults:
 		})
 	}
 }
+
+func TestLoader_Load_PluginConfigScopes(t *testing.T) {
+	type apiConfig struct {
+		APIKey string `json:"api_key"`
+		Region string `json:"region"`
+	}
+	for _, name := range []string{"scope-deploy", "scope-billing"} {
+		require.NoError(t, pkgconfig.Register(name, apiConfig{}))
+		t.Cleanup(func() { _ = pkgconfig.Unregister(name) })
+	}
+	t.Cleanup(func() { pkgconfig.SetPluginSections(nil) })
+
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	configPath := filepath.Join(tempDir, ".glide.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`plugins:
+  shared:
+    region: eu-west-1
+  scope-deploy:
+    api_key: deploy-secret
+  scope-billing:
+    api_key: billing-secret
+    region: us-east-1
+`), 0644))
+
+	_, err := (&Loader{configPath: configPath}).Load()
+	require.NoError(t, err)
+
+	deploy, err := pkgconfig.GetValue[apiConfig]("scope-deploy")
+	require.NoError(t, err)
+	assert.Equal(t, apiConfig{APIKey: "deploy-secret", Region: "eu-west-1"}, deploy)
+
+	billing, err := pkgconfig.GetValue[apiConfig]("scope-billing")
+	require.NoError(t, err)
+	assert.Equal(t, apiConfig{APIKey: "billing-secret", Region: "us-east-1"}, billing)
+
+	assert.NotContains(t, pkgconfig.PluginScope("scope-deploy"), "scope-billing")
+}
+
+func TestLoader_SetFeature(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	loader := NewLoader()
+	require.NoError(t, loader.SetFeature("daemon", true))
+	require.NoError(t, loader.SetFeature("tui", false))
+
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"daemon": true, "tui": false}, cfg.Features)
+}
+
+func TestLoader_Update(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	loader := NewLoader()
+	require.NoError(t, loader.Update(func(cfg *Config) {
+		cfg.Defaults.Update.Channel = "prerelease"
+		cfg.Defaults.Telemetry.Enabled = true
+	}))
+
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "prerelease", cfg.Defaults.Update.Channel)
+	assert.True(t, cfg.Defaults.Telemetry.Enabled)
+}
+
+func TestLoader_Validate_InvalidUpdateChannel(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	yamlContent := `
+defaults:
+  update:
+    channel: nightly
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".glide.yml"), []byte(yamlContent), 0644))
+
+	_, err := NewLoader().Load()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid update channel")
+}
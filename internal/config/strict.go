@@ -0,0 +1,270 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	pkgconfig "github.com/glide-cli/glide/v3/pkg/config"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownKey is a key in a config file that no setting reads, usually a
+// typo that would otherwise silently fall back to the default
+type UnknownKey struct {
+	File string
+	Line int
+	// Key is the dotted path of the key, e.g. "defaults.test.procceses"
+	Key string
+	// Suggestion is the closest known key at the same level, if any
+	Suggestion string
+}
+
+func (k UnknownKey) String() string {
+	s := fmt.Sprintf("%s:%d: unknown key %q", k.File, k.Line, k.Key)
+	if k.Suggestion != "" {
+		s += fmt.Sprintf(" (did you mean %q?)", k.Suggestion)
+	}
+	return s
+}
+
+var yamlUnmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+// FindUnknownKeys reports the keys in config file data that glide does not
+// know. Plugin sections are checked against the schemas plugins register;
+// sections of plugins without one are accepted as they are.
+func FindUnknownKeys(data []byte, file string) ([]UnknownKey, error) {
+	data, _, err := ApplyKeyAliases(data, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	root := doc.Content[0]
+
+	var found []UnknownKey
+	fields := yamlFields(reflect.TypeOf(Config{}))
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if key.Value == "plugins" {
+			found = append(found, unknownPluginKeys(value, file)...)
+			continue
+		}
+		found = append(found, unknownKeys(key, value, fields, key.Value, file)...)
+	}
+	return found, nil
+}
+
+// CheckKeys fails when any of the config files holds unknown keys
+func CheckKeys(paths []string) error {
+	var found []UnknownKey
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		keys, err := FindUnknownKeys(data, path)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		found = append(found, keys...)
+	}
+	return unknownKeysError(found)
+}
+
+// unknownKeysError turns unknown keys into a config error listing them
+func unknownKeysError(found []UnknownKey) error {
+	if len(found) == 0 {
+		return nil
+	}
+
+	suggestions := make([]string, 0, len(found)+1)
+	for _, k := range found {
+		suggestions = append(suggestions, k.String())
+	}
+	suggestions = append(suggestions, "Fix or remove the keys, or drop strict: true / --strict-config to ignore them")
+
+	message := fmt.Sprintf("configuration has %d unknown keys", len(found))
+	if len(found) == 1 {
+		message = fmt.Sprintf("configuration has an unknown key: %s", found[0].Key)
+	}
+	return glideErrors.New(glideErrors.TypeConfig, message,
+		glideErrors.WithExitCode(78),
+		glideErrors.WithSuggestions(suggestions...),
+	)
+}
+
+// unknownKeys checks the value of a known key against its Go type
+func unknownKeys(key, value *yaml.Node, fields map[string]reflect.Type, path, file string) []UnknownKey {
+	typ, ok := fields[key.Value]
+	if !ok {
+		return []UnknownKey{{
+			File:       file,
+			Line:       key.Line,
+			Key:        path,
+			Suggestion: closestKey(key.Value, fields),
+		}}
+	}
+	return unknownKeysIn(value, typ, path, file)
+}
+
+// unknownKeysIn walks a YAML node alongside the type it decodes into
+func unknownKeysIn(node *yaml.Node, typ reflect.Type, path, file string) []UnknownKey {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	// Types decoding themselves accept whatever they like
+	if reflect.PointerTo(typ).Implements(yamlUnmarshalerType) {
+		return nil
+	}
+
+	var found []UnknownKey
+	switch typ.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		fields := yamlFields(typ)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Value == "<<" {
+				continue
+			}
+			found = append(found, unknownKeys(key, node.Content[i+1], fields, path+"."+key.Value, file)...)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			found = append(found, unknownKeysIn(node.Content[i+1], typ.Elem(), path+"."+node.Content[i].Value, file)...)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return nil
+		}
+		for i, item := range node.Content {
+			found = append(found, unknownKeysIn(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i), file)...)
+		}
+	}
+	return found
+}
+
+// unknownPluginKeys checks plugin sections against their registered schemas
+func unknownPluginKeys(node *yaml.Node, file string) []UnknownKey {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var found []UnknownKey
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, section := node.Content[i], node.Content[i+1]
+		schema, err := pkgconfig.GetSchema(name.Value)
+		if err != nil || section.Kind != yaml.MappingNode {
+			continue
+		}
+		properties, ok := schema["properties"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		known := make(map[string]reflect.Type, len(properties))
+		for key := range properties {
+			known[key] = nil
+		}
+		for j := 0; j+1 < len(section.Content); j += 2 {
+			key := section.Content[j]
+			if _, ok := known[key.Value]; !ok {
+				found = append(found, UnknownKey{
+					File:       file,
+					Line:       key.Line,
+					Key:        "plugins." + name.Value + "." + key.Value,
+					Suggestion: closestKey(key.Value, known),
+				})
+			}
+		}
+	}
+	return found
+}
+
+// yamlFields maps the YAML keys of a struct to their field types,
+// flattening inlined structs
+func yamlFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			for k, v := range yamlFields(field.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// closestKey returns the known key a typo most likely meant
+func closestKey(key string, known map[string]reflect.Type) string {
+	names := make([]string, 0, len(known))
+	for name := range known {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	best, bestDistance := "", len(key)/3+2
+	for _, name := range names {
+		if d := editDistance(key, name); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pkgconfig "github.com/glide-cli/glide/v3/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindUnknownKeys(t *testing.T) {
+	t.Run("catches typos with a suggestion", func(t *testing.T) {
+		data := []byte("defaults:\n  test:\n    procceses: 8\nnotifcations:\n  enabled: true\n")
+		keys, err := FindUnknownKeys(data, ".glide.yml")
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+
+		assert.Equal(t, "defaults.test.procceses", keys[0].Key)
+		assert.Equal(t, "processes", keys[0].Suggestion)
+		assert.Equal(t, 3, keys[0].Line)
+		assert.Equal(t, "notifcations", keys[1].Key)
+		assert.Equal(t, "notifications", keys[1].Suggestion)
+	})
+
+	t.Run("walks maps and lists", func(t *testing.T) {
+		data := []byte(`projects:
+  acme:
+    path: /src/acme
+    colour: red
+snapshot:
+  databases:
+    - service: db
+      dump: pg_dump
+      restroe: psql
+commands:
+  test:
+    cmd: go test ./...
+    anything: goes
+`)
+		keys, err := FindUnknownKeys(data, "")
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.Equal(t, "projects.acme.colour", keys[0].Key)
+		assert.Equal(t, "snapshot.databases[0].restroe", keys[1].Key)
+		assert.Equal(t, "restore", keys[1].Suggestion)
+	})
+
+	t.Run("checks plugin sections with a schema", func(t *testing.T) {
+		type pluginConfig struct {
+			Token string `json:"token" yaml:"token"`
+		}
+		require.NoError(t, pkgconfig.Register("strict-test-plugin", pluginConfig{}))
+		t.Cleanup(func() { _ = pkgconfig.Unregister("strict-test-plugin") })
+
+		data := []byte("plugins:\n  strict-test-plugin:\n    tokne: x\n  unregistered:\n    whatever: 1\n")
+		keys, err := FindUnknownKeys(data, "")
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, "plugins.strict-test-plugin.tokne", keys[0].Key)
+		assert.Equal(t, "token", keys[0].Suggestion)
+	})
+
+	t.Run("accepts a valid config", func(t *testing.T) {
+		data := []byte("strict: true\ndefaults:\n  test:\n    processes: 8\n")
+		keys, err := FindUnknownKeys(data, "")
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+}
+
+func TestCheckKeys(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.yml")
+	bad := filepath.Join(dir, "bad.yml")
+	require.NoError(t, os.WriteFile(good, []byte("defaults:\n  test:\n    processes: 8\n"), 0644))
+	require.NoError(t, os.WriteFile(bad, []byte("defualts: {}\n"), 0644))
+
+	assert.NoError(t, CheckKeys([]string{good, filepath.Join(dir, "missing.yml")}))
+
+	err := CheckKeys([]string{good, bad})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "defualts")
+}
+
+func TestLoader_Load_Strict(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	configPath := filepath.Join(tempDir, ".glide.yml")
+	loader := &Loader{configPath: configPath}
+
+	require.NoError(t, os.WriteFile(configPath, []byte("defaults:\n  test:\n    procceses: 8\n"), 0644))
+	_, err := loader.Load()
+	assert.NoError(t, err, "unknown keys are ignored unless strict")
+
+	require.NoError(t, os.WriteFile(configPath, []byte("strict: true\ndefaults:\n  test:\n    procceses: 8\n"), 0644))
+	_, err = loader.Load()
+	assert.ErrorContains(t, err, "defaults.test.procceses")
+}
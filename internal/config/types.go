@@ -13,14 +13,63 @@ type Command struct {
 	Description string `yaml:"description,omitempty"`
 	Help        string `yaml:"help,omitempty"`
 	Category    string `yaml:"category,omitempty"`
+
+	// Dir is the project directory whose trust decides whether the command
+	// runs. It is empty for global and plugin commands, which always run.
+	Dir string `yaml:"-"`
 }
 
 // Config represents the global Glide configuration
 type Config struct {
+	// Root overrides project root detection, relative to the config file,
+	// for a project nested in another
+	Root string `yaml:"root,omitempty"`
+	// Strict rejects unknown keys instead of ignoring them
+	Strict         bool                     `yaml:"strict,omitempty"`
 	Projects       map[string]ProjectConfig `yaml:"projects"`
 	DefaultProject string                   `yaml:"default_project"`
 	Defaults       DefaultsConfig           `yaml:"defaults"`
 	Commands       CommandMap               `yaml:"commands,omitempty"`
+	Snapshot       SnapshotConfig           `yaml:"snapshot,omitempty"`
+	Sync           SyncConfig               `yaml:"sync,omitempty"`
+	Build          BuildConfig              `yaml:"build,omitempty"`
+	Top            TopConfig                `yaml:"top,omitempty"`
+	Exec           map[string]ExecConfig    `yaml:"exec,omitempty"`
+	Cleanup        CleanupConfig            `yaml:"cleanup,omitempty"`
+	GitPolicy      GitPolicyConfig          `yaml:"git_policy,omitempty"`
+	Notifications  NotificationsConfig      `yaml:"notifications,omitempty"`
+	Performance    PerformanceConfig        `yaml:"performance,omitempty"`
+	// ExitCodes maps error types to exit codes, e.g. docker: 2; the
+	// --exit-code-map flag overrides it per invocation
+	ExitCodes map[string]int `yaml:"exit_codes,omitempty"`
+	// Flags sets default flag values per command path, e.g.
+	// logs: {follow: true}; --no-config-flags ignores them
+	Flags map[string]map[string]interface{} `yaml:"flags,omitempty"`
+	// Features turns experimental feature flags on or off, e.g. daemon:
+	// true; GLIDE_FEATURES overrides it per invocation
+	Features map[string]bool `yaml:"features,omitempty"`
+	// PluginRegistries are private hosts plugins are installed from
+	PluginRegistries []PluginRegistryConfig `yaml:"plugin_registries,omitempty"`
+	// PluginMirror serves every registry from a directory, file:// URL, or
+	// static web server that `glide plugins mirror sync` filled, for
+	// air-gapped environments; GLIDE_PLUGIN_MIRROR overrides it
+	PluginMirror string `yaml:"plugin_mirror,omitempty"`
+	// PluginIndex is the https:// URL of the marketplace index `glide
+	// plugins install <name>` resolves names against; GLIDE_PLUGIN_INDEX
+	// overrides it
+	PluginIndex string `yaml:"plugin_index,omitempty"`
+	// Plugins restricts which installed runtime plugins activate in a
+	// project. It shares the plugins key with the per-plugin sections.
+	Plugins PluginsConfig `yaml:"plugins,omitempty"`
+	// WorktreeTemplates tailor the worktrees `glide g worktree --template`
+	// creates, keyed by template name
+	WorktreeTemplates map[string]WorktreeTemplate `yaml:"worktree_templates,omitempty"`
+	// SharedServices are the compose services, such as a database, that
+	// run once for all worktrees of a multi-worktree project
+	SharedServices []string `yaml:"shared_services,omitempty"`
+	// Jobs are maintenance commands `glide jobs serve` runs on a schedule
+	// when the daemon feature is on, keyed by job name
+	Jobs map[string]JobConfig `yaml:"jobs,omitempty"`
 
 	// NOTE: Plugin configuration has been migrated to the type-safe pkg/config system.
 	// Plugins register their typed configs using config.Register() in their init() functions,
@@ -28,6 +77,219 @@ type Config struct {
 	// See pkg/config/MIGRATION.md for details.
 }
 
+// PluginsConfig lists the runtime plugins a project activates. When
+// Enabled is set only those plugins activate; Disabled plugins never do.
+type PluginsConfig struct {
+	Enabled  []string `yaml:"enabled,omitempty"`
+	Disabled []string `yaml:"disabled,omitempty"`
+}
+
+// PluginRegistryConfig is a GitHub-compatible host, such as GitHub
+// Enterprise, that `glide plugins install <host>/<owner>/<repo>` installs
+// plugin releases from
+type PluginRegistryConfig struct {
+	Name string `yaml:"name"`
+	// Host is the host install sources name, e.g. github.acme.com
+	Host string `yaml:"host"`
+	// API is the base URL of its REST API (default: https://<host>/api/v3)
+	API  string             `yaml:"api,omitempty"`
+	Auth RegistryAuthConfig `yaml:"auth,omitempty"`
+}
+
+// RegistryAuthConfig says how to authenticate to a plugin registry.
+// Secrets are never stored here: they come from the variable SecretEnv
+// names, or else from git's credential helper for the registry host.
+type RegistryAuthConfig struct {
+	// Type is basic, bearer, github-app, or oidc; empty for none
+	Type string `yaml:"type,omitempty"`
+	// Username for basic auth (default: the credential helper's)
+	Username string `yaml:"username,omitempty"`
+	// SecretEnv names the variable holding the password, token, or OIDC
+	// client secret
+	SecretEnv string `yaml:"secret_env,omitempty"`
+	// AppID and InstallationID identify a GitHub App installation
+	AppID          int64 `yaml:"app_id,omitempty"`
+	InstallationID int64 `yaml:"installation_id,omitempty"`
+	// PrivateKeyFile is the GitHub App's PEM private key
+	PrivateKeyFile string `yaml:"private_key_file,omitempty"`
+	// TokenURL, ClientID, and Scopes configure the OIDC client credentials
+	// grant
+	TokenURL string   `yaml:"token_url,omitempty"`
+	ClientID string   `yaml:"client_id,omitempty"`
+	Scopes   []string `yaml:"scopes,omitempty"`
+}
+
+// SnapshotConfig controls what `glide snapshot` captures
+type SnapshotConfig struct {
+	// EnvFiles are glob patterns, relative to the worktree, of environment
+	// files to capture (default: .env*)
+	EnvFiles []string `yaml:"env_files,omitempty"`
+	// Databases are dumped and restored through their compose service
+	Databases []SnapshotDatabase `yaml:"databases,omitempty"`
+}
+
+// SnapshotDatabase describes how to dump and restore a database service
+type SnapshotDatabase struct {
+	// Service is the compose service running the database
+	Service string `yaml:"service"`
+	// Dump is run inside the service and must write the dump to stdout
+	Dump string `yaml:"dump"`
+	// Restore is run inside the service and reads the dump from stdin
+	Restore string `yaml:"restore"`
+}
+
+// SyncConfig configures file sync into containers, used instead of slow
+// bind mounts on macOS
+type SyncConfig struct {
+	// Mode is the default sync tool for every service: "mutagen" or "rsync"
+	Mode string `yaml:"mode,omitempty"`
+	// Services maps compose service names to what is synced into them
+	Services map[string]SyncService `yaml:"services,omitempty"`
+}
+
+// SyncService describes the sync of one directory into a compose service
+type SyncService struct {
+	// Mode overrides SyncConfig.Mode for this service
+	Mode string `yaml:"mode,omitempty"`
+	// Source is the host directory, relative to the worktree (default: ".")
+	Source string `yaml:"source,omitempty"`
+	// Target is the absolute path inside the container
+	Target string `yaml:"target"`
+	// Ignore lists paths excluded from the sync
+	Ignore []string `yaml:"ignore,omitempty"`
+}
+
+// BuildConfig configures `glide build`
+type BuildConfig struct {
+	// Parallelism is the number of images built at once (default: 4)
+	Parallelism int `yaml:"parallelism,omitempty"`
+	// CacheFrom and CacheTo are buildx cache specs shared by every image,
+	// e.g. "type=registry,ref=ghcr.io/acme/app:buildcache"
+	CacheFrom []string `yaml:"cache_from,omitempty"`
+	CacheTo   []string `yaml:"cache_to,omitempty"`
+}
+
+// TopConfig configures `glide top`
+type TopConfig struct {
+	// CPUWarn highlights containers above this CPU percentage (0 disables)
+	CPUWarn float64 `yaml:"cpu_warn,omitempty"`
+	// MemoryWarn highlights containers above this percentage of their
+	// memory limit (0 disables)
+	MemoryWarn float64 `yaml:"memory_warn,omitempty"`
+}
+
+// ExecConfig holds the defaults `glide exec` runs commands in a compose
+// service with, keyed by service under exec:. Its flags override them.
+type ExecConfig struct {
+	// User runs commands as this user, e.g. www-data or 1000:1000
+	User string `yaml:"user,omitempty"`
+	// Workdir runs commands in this directory of the container
+	Workdir string `yaml:"workdir,omitempty"`
+	// Env sets environment variables for the commands
+	Env map[string]string `yaml:"env,omitempty"`
+}
+
+// WorktreeTemplate tailors a new worktree to a kind of work, such as a
+// hotfix or a spike
+type WorktreeTemplate struct {
+	// Description is shown when completing --template
+	Description string `yaml:"description,omitempty"`
+	// From is the base branch or commit (default: main); --from overrides it
+	From string `yaml:"from,omitempty"`
+	// EnvTemplate is a file, relative to vcs/, copied to the worktree's
+	// .env instead of vcs/.env. {{branch}} and {{worktree}} in it are
+	// replaced with the branch and worktree names.
+	EnvTemplate string `yaml:"env_template,omitempty"`
+	// Services are started with docker compose once the worktree exists
+	Services []string `yaml:"services,omitempty"`
+	// Seed commands run in the worktree, in order, after the services start
+	Seed []string `yaml:"seed,omitempty"`
+}
+
+// JobConfig is a scheduled maintenance job
+type JobConfig struct {
+	// Schedule is a cron expression, e.g. "0 3 * * *", or one of @hourly,
+	// @daily, @weekly, and @monthly
+	Schedule string `yaml:"schedule"`
+	// Run is the glide command the job runs, e.g. "cache prune"
+	Run string `yaml:"run"`
+	// Dir is the directory it runs in, for project commands such as
+	// prefetch (default: the home directory)
+	Dir string `yaml:"dir,omitempty"`
+	// Jitter delays each run by a random time up to this Go duration, e.g.
+	// "15m", so machines sharing a schedule do not run at once
+	Jitter string `yaml:"jitter,omitempty"`
+}
+
+// CleanupConfig is the retention policy `glide clean` applies to Docker
+// resources. It is machine-wide and read from the global configuration only.
+// Ages are Go durations with an additional "d" unit, e.g. "7d" or "36h".
+type CleanupConfig struct {
+	// DanglingImages removes dangling images older than this age (empty disables)
+	DanglingImages string `yaml:"dangling_images,omitempty"`
+	// IdleContainers stops running containers of glide projects in which no
+	// command has run for this long (empty disables)
+	IdleContainers string `yaml:"idle_containers,omitempty"`
+	// Opportunistic applies the policy in the background after commands
+	Opportunistic bool `yaml:"opportunistic,omitempty"`
+	// Interval is the minimum time between opportunistic runs (default: 24h)
+	Interval string `yaml:"interval,omitempty"`
+}
+
+// GitPolicyConfig holds the naming rules `glide policy check` and the git
+// hooks it installs enforce. Patterns are Go regular expressions.
+type GitPolicyConfig struct {
+	// BranchPattern must match the name of every branch
+	BranchPattern string `yaml:"branch_pattern,omitempty"`
+	// CommitPattern must match the subject line of every commit message
+	CommitPattern string `yaml:"commit_pattern,omitempty"`
+	// ExemptBranches are not checked (default: main, master, develop)
+	ExemptBranches []string `yaml:"exempt_branches,omitempty"`
+}
+
+// NotificationsConfig controls the notifications sent when long-running
+// commands finish. It is personal and read from the global configuration only.
+type NotificationsConfig struct {
+	// MinDuration is how long a command must run before it notifies
+	// (default: 60s)
+	MinDuration string `yaml:"min_duration,omitempty"`
+	// Commands opts commands in by path, e.g. "test" or "project *"; a
+	// command also covers its subcommands
+	Commands []string `yaml:"commands,omitempty"`
+	// Channels are "desktop", "bell", and "webhook" (default: desktop)
+	Channels []string `yaml:"channels,omitempty"`
+	// On limits notifications to "success" or "failure" (default: both)
+	On []string `yaml:"on,omitempty"`
+	// Webhook receives the notifications of the webhook channel
+	Webhook WebhookConfig `yaml:"webhook,omitempty"`
+}
+
+// WebhookConfig describes a webhook that notifications are posted to
+type WebhookConfig struct {
+	URL string `yaml:"url,omitempty"`
+	// Format is "slack" for a Slack-compatible {"text": ...} payload
+	// (default) or "json" for the full notification
+	Format string `yaml:"format,omitempty"`
+}
+
+// PerformanceConfig adjusts the budgets that instrumented operations such as
+// context detection are measured against
+type PerformanceConfig struct {
+	// Budgets override the default budgets by operation name, e.g.
+	// context_detection, or add budgets for other operations
+	Budgets map[string]BudgetConfig `yaml:"budgets,omitempty"`
+}
+
+// BudgetConfig is a performance budget. Fields left empty keep the values
+// of the default budget it overrides.
+type BudgetConfig struct {
+	// MaxDuration is a Go duration, e.g. "300ms"
+	MaxDuration string `yaml:"max_duration"`
+	// Priority is P0, P1, or P2
+	Priority    string `yaml:"priority,omitempty"`
+	Description string `yaml:"description,omitempty"`
+}
+
 // ProjectConfig represents a single project configuration
 type ProjectConfig struct {
 	Path     string     `yaml:"path"`
@@ -42,6 +304,40 @@ type DefaultsConfig struct {
 	Colors   ColorDefaults    `yaml:"colors"`
 	Worktree WorktreeDefaults `yaml:"worktree"`
 	Update   UpdateDefaults   `yaml:"update"`
+	Help     HelpDefaults     `yaml:"help"`
+	Sandbox  SandboxDefaults  `yaml:"sandbox,omitempty"`
+	// Telemetry records whether the user agreed to share anonymous usage
+	// data
+	Telemetry TelemetryDefaults `yaml:"telemetry"`
+	// Accessible turns on screen-reader-friendly output, like --accessible
+	Accessible bool `yaml:"accessible,omitempty"`
+}
+
+// TelemetryDefaults records the user's consent to anonymous usage data
+type TelemetryDefaults struct {
+	// Enabled is the answer to the first-run consent question; no usage
+	// data is shared without it
+	Enabled bool `yaml:"enabled"`
+}
+
+// SandboxDefaults configures the sandbox untrusted project commands can
+// run in
+type SandboxDefaults struct {
+	// Untrusted runs the commands of untrusted projects in a container with
+	// no network and a read-only project, instead of refusing them
+	Untrusted bool `yaml:"untrusted,omitempty"`
+	// Image is the image sandboxed commands run in (default: alpine:3)
+	Image string `yaml:"image,omitempty"`
+	// Env names variables passed into the sandbox besides TERM, LANG,
+	// LC_ALL, and TZ
+	Env []string `yaml:"env,omitempty"`
+}
+
+// HelpDefaults contains help output settings
+type HelpDefaults struct {
+	// CategoryPriorities overrides the display priority of help categories by ID
+	// (lower numbers appear first)
+	CategoryPriorities map[string]int `yaml:"category_priorities,omitempty"`
 }
 
 // UpdateDefaults contains update notification settings
@@ -52,6 +348,9 @@ type UpdateDefaults struct {
 	CheckIntervalHours int `yaml:"check_interval_hours"`
 	// NotifyEnabled controls whether update notifications are shown
 	NotifyEnabled bool `yaml:"notify_enabled"`
+	// Channel is "stable" (default) or "prerelease" to also be told about
+	// betas and release candidates
+	Channel string `yaml:"channel,omitempty"`
 }
 
 // TestDefaults contains default test settings
@@ -79,6 +378,9 @@ type WorktreeDefaults struct {
 	AutoSetup     bool `yaml:"auto_setup"`
 	CopyEnv       bool `yaml:"copy_env"`
 	RunMigrations bool `yaml:"run_migrations"`
+	// SyncStrategy is how `project worktree sync` brings branches up to
+	// date with their upstream: "rebase" (default) or "merge"
+	SyncStrategy string `yaml:"sync_strategy,omitempty"`
 }
 
 // CommandConfig represents runtime configuration with precedence applied
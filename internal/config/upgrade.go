@@ -0,0 +1,208 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	pkgconfig "github.com/glide-cli/glide/v3/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// UpgradeChange is a legacy layout `glide upgrade-config` rewrites, or
+// one it found but has to leave to the user
+type UpgradeChange struct {
+	// Rule names the layout, see UpgradeRules
+	Rule string `json:"rule" yaml:"rule"`
+	Line int    `json:"line" yaml:"line"`
+	// Key is the dotted path of the legacy key
+	Key string `json:"key" yaml:"key"`
+	// NewKey is where its value moves to; empty when it is dropped
+	NewKey string `json:"new_key,omitempty" yaml:"new_key,omitempty"`
+	// Manual is set when the change was not made and needs a decision,
+	// e.g. because the new key is already set
+	Manual bool   `json:"manual,omitempty" yaml:"manual,omitempty"`
+	Note   string `json:"note" yaml:"note"`
+}
+
+// UpgradeRule rewrites one legacy layout in the root mapping of a config
+// file and reports what it changed
+type UpgradeRule struct {
+	Name        string
+	Description string
+	Apply       func(root *yaml.Node) []UpgradeChange
+}
+
+// UpgradeRules returns the legacy layouts `glide upgrade-config` knows, in
+// the order they are applied. Renamed keys are also rewritten as files
+// load; the others are not, since they need a decision or a move the
+// loader cannot make silently.
+func UpgradeRules() []UpgradeRule {
+	return []UpgradeRule{
+		{Name: "renamed-key", Description: "Keys renamed since they were written", Apply: upgradeRenamedKeys},
+		{Name: "plugin-list", Description: "A list of plugins under plugins:, now plugins.enabled", Apply: upgradePluginList},
+		{Name: "plugin-section", Description: "Plugin settings at the top level, now under plugins.<name>", Apply: upgradePluginSections},
+	}
+}
+
+// UpgradeConfig rewrites the legacy layouts in config file data to the
+// current format. Comments are kept. Data without legacy layouts is
+// returned unchanged with no changes.
+func UpgradeConfig(data []byte) ([]byte, []UpgradeChange, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil, nil
+	}
+
+	var changes []UpgradeChange
+	for _, rule := range UpgradeRules() {
+		for _, change := range rule.Apply(doc.Content[0]) {
+			change.Rule = rule.Name
+			changes = append(changes, change)
+		}
+	}
+	if !slices.ContainsFunc(changes, func(c UpgradeChange) bool { return !c.Manual }) {
+		return data, changes, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), changes, nil
+}
+
+// upgradeRenamedKeys moves the keys in keyAliases to their replacements
+func upgradeRenamedKeys(root *yaml.Node) []UpgradeChange {
+	var changes []UpgradeChange
+	for _, alias := range keyAliases {
+		line := keyLine(root, strings.Split(alias.Old, "."))
+		key, value := removeKey(root, strings.Split(alias.Old, "."))
+		if key == nil {
+			continue
+		}
+
+		change := UpgradeChange{Line: line, Key: alias.Old, NewKey: alias.New, Note: fmt.Sprintf("renamed in %s", alias.Since)}
+		if !setKey(root, strings.Split(alias.New, "."), key, value) {
+			// The new key wins as the file loads, so the old one is dead
+			change.NewKey = ""
+			change.Note = fmt.Sprintf("dropped: %s is also set and wins", alias.New)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// upgradePluginList turns a list of plugins into plugins.enabled
+func upgradePluginList(root *yaml.Node) []UpgradeChange {
+	key, value := mappingEntry(root, "plugins")
+	if key == nil || value.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	list := *value
+	*value = yaml.Node{
+		Kind: yaml.MappingNode,
+		Tag:  "!!map",
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "enabled"},
+			&list,
+		},
+	}
+	return []UpgradeChange{{Line: key.Line, Key: "plugins", NewKey: "plugins.enabled", Note: "the plugins to activate are listed under enabled"}}
+}
+
+// upgradePluginSections moves top-level sections of plugins under
+// plugins:. A top-level key is a plugin section when it is no setting of
+// its own and names a plugin that registered its configuration or one
+// listed in plugins.enabled or plugins.disabled.
+func upgradePluginSections(root *yaml.Node) []UpgradeChange {
+	fields := yamlFields(reflect.TypeOf(Config{}))
+	listed := listedPlugins(root)
+
+	var changes []UpgradeChange
+	for i := 0; i+1 < len(root.Content); {
+		key, value := root.Content[i], root.Content[i+1]
+		name := key.Value
+		if _, known := fields[name]; known || value.Kind != yaml.MappingNode || !(pkgconfig.Exists(name) || listed[name]) {
+			i += 2
+			continue
+		}
+
+		_, plugins := mappingEntry(root, "plugins")
+		if plugins == nil {
+			plugins = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "plugins"}, plugins)
+		}
+		if plugins.Kind != yaml.MappingNode {
+			i += 2
+			continue
+		}
+		if existing, _ := mappingEntry(plugins, name); existing != nil {
+			changes = append(changes, UpgradeChange{
+				Line: key.Line, Key: name, NewKey: "plugins." + name, Manual: true,
+				Note: "plugins." + name + " is also set; merge the two sections by hand",
+			})
+			i += 2
+			continue
+		}
+
+		root.Content = append(root.Content[:i], root.Content[i+2:]...)
+		plugins.Content = append(plugins.Content, key, value)
+		changes = append(changes, UpgradeChange{Line: key.Line, Key: name, NewKey: "plugins." + name, Note: "plugin settings are read from plugins:"})
+	}
+	return changes
+}
+
+// listedPlugins returns the plugins named in plugins.enabled and
+// plugins.disabled
+func listedPlugins(root *yaml.Node) map[string]bool {
+	listed := map[string]bool{}
+	_, plugins := mappingEntry(root, "plugins")
+	if plugins == nil || plugins.Kind != yaml.MappingNode {
+		return listed
+	}
+	for _, list := range []string{"enabled", "disabled"} {
+		if _, names := mappingEntry(plugins, list); names != nil && names.Kind == yaml.SequenceNode {
+			for _, name := range names.Content {
+				listed[name.Value] = true
+			}
+		}
+	}
+	return listed
+}
+
+// mappingEntry returns the key and value nodes of a key in a mapping
+func mappingEntry(node *yaml.Node, name string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// keyLine returns the line of the key at path, or 0 when it is not set
+func keyLine(node *yaml.Node, path []string) int {
+	key, value := mappingEntry(node, path[0])
+	if key == nil {
+		return 0
+	}
+	if len(path) == 1 {
+		return key.Line
+	}
+	if value.Kind != yaml.MappingNode {
+		return 0
+	}
+	return keyLine(value, path[1:])
+}
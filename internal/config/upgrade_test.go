@@ -0,0 +1,81 @@
+package config
+
+import (
+	"testing"
+
+	pkgconfig "github.com/glide-cli/glide/v3/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeConfig(t *testing.T) {
+	t.Run("renamed keys", func(t *testing.T) {
+		withKeyAliases(t, KeyAlias{Old: "defaults.test.workers", New: "defaults.test.processes", Since: "3.2.0"})
+
+		out, changes, err := UpgradeConfig([]byte("defaults:\n  test:\n    # CI has 4 cores\n    workers: 4\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "defaults:\n  test:\n    # CI has 4 cores\n    processes: 4\n", string(out))
+		require.Len(t, changes, 1)
+		assert.Equal(t, UpgradeChange{Rule: "renamed-key", Line: 4, Key: "defaults.test.workers", NewKey: "defaults.test.processes", Note: "renamed in 3.2.0"}, changes[0])
+	})
+
+	t.Run("renamed key shadowed by its replacement", func(t *testing.T) {
+		withKeyAliases(t, KeyAlias{Old: "defaults.test.workers", New: "defaults.test.processes", Since: "3.2.0"})
+
+		out, changes, err := UpgradeConfig([]byte("defaults:\n  test:\n    workers: 4\n    processes: 8\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "defaults:\n  test:\n    processes: 8\n", string(out))
+		require.Len(t, changes, 1)
+		assert.Empty(t, changes[0].NewKey)
+		assert.Contains(t, changes[0].Note, "dropped")
+	})
+
+	t.Run("plugin list", func(t *testing.T) {
+		out, changes, err := UpgradeConfig([]byte("plugins:\n  - docker\n  - node\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "plugins:\n  enabled:\n    - docker\n    - node\n", string(out))
+		require.Len(t, changes, 1)
+		assert.Equal(t, "plugins.enabled", changes[0].NewKey)
+	})
+
+	t.Run("top-level plugin sections", func(t *testing.T) {
+		type pluginConfig struct {
+			Region string `json:"region" yaml:"region"`
+		}
+		require.NoError(t, pkgconfig.Register("upgrade-test-plugin", pluginConfig{}))
+		t.Cleanup(func() { _ = pkgconfig.Unregister("upgrade-test-plugin") })
+
+		data := "default_project: app\nupgrade-test-plugin:\n  region: eu\nnode:\n  version: 20\nplugins:\n  - node\n"
+		out, changes, err := UpgradeConfig([]byte(data))
+		require.NoError(t, err)
+		assert.Equal(t, "default_project: app\nplugins:\n  enabled:\n    - node\n  upgrade-test-plugin:\n    region: eu\n  node:\n    version: 20\n", string(out))
+
+		require.Len(t, changes, 3)
+		assert.Equal(t, "plugin-list", changes[0].Rule)
+		assert.Equal(t, "plugin-section", changes[1].Rule)
+		assert.Equal(t, "plugins.upgrade-test-plugin", changes[1].NewKey)
+		assert.Equal(t, "plugins.node", changes[2].NewKey)
+	})
+
+	t.Run("conflicting plugin section is left alone", func(t *testing.T) {
+		data := "node:\n  version: 20\nplugins:\n  enabled: [node]\n  node:\n    version: 22\n"
+		out, changes, err := UpgradeConfig([]byte(data))
+		require.NoError(t, err)
+		assert.Equal(t, data, string(out))
+		require.Len(t, changes, 1)
+		assert.True(t, changes[0].Manual)
+	})
+
+	t.Run("unknown top-level keys stay", func(t *testing.T) {
+		data := "default_project: app\nsomething:\n  else: 1\n"
+		out, changes, err := UpgradeConfig([]byte(data))
+		require.NoError(t, err)
+		assert.Equal(t, data, string(out))
+		assert.Empty(t, changes)
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		_, _, err := UpgradeConfig([]byte("plugins: [unclosed\n"))
+		assert.Error(t, err)
+	})
+}
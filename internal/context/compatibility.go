@@ -1,45 +1,28 @@
 package context
 
-// PopulateCompatibilityFields populates the deprecated Docker fields from the extensions map
+// PopulateCompatibilityFields populates the deprecated Docker fields from the Docker extension
 // This ensures backward compatibility with code that still uses the old Docker fields directly
 func PopulateCompatibilityFields(ctx *ProjectContext) {
-	if ctx.Extensions == nil {
-		return
-	}
-
-	dockerData, ok := ctx.Extensions["docker"]
+	docker, ok := GetExtension[DockerExtension](ctx, DockerExtensionName)
 	if !ok {
 		return
 	}
 
-	// Type assert to expected Docker context structure
-	dockerCtx, ok := dockerData.(map[string]interface{})
-	if !ok {
-		return
+	if len(docker.ComposeFiles) > 0 {
+		ctx.ComposeFiles = docker.ComposeFiles
 	}
-
-	// Populate ComposeFiles
-	if composeFiles, ok := dockerCtx["compose_files"].([]string); ok {
-		ctx.ComposeFiles = composeFiles
+	if docker.ComposeOverride != "" {
+		ctx.ComposeOverride = docker.ComposeOverride
 	}
-
-	// Populate ComposeOverride
-	if composeOverride, ok := dockerCtx["compose_override"].(string); ok {
-		ctx.ComposeOverride = composeOverride
+	if docker.DockerRunning {
+		ctx.DockerRunning = true
 	}
-
-	// Populate DockerRunning
-	if dockerRunning, ok := dockerCtx["docker_running"].(bool); ok {
-		ctx.DockerRunning = dockerRunning
-	}
-
-	// Populate ContainersStatus
-	if containersStatus, ok := dockerCtx["containers_status"].(map[string]ContainerStatus); ok {
-		ctx.ContainersStatus = containersStatus
+	if len(docker.ContainersStatus) > 0 {
+		ctx.ContainersStatus = docker.ContainersStatus
 	}
 }
 
-// UpdateExtensionsFromCompatibility updates the extensions map from the deprecated Docker fields
+// UpdateExtensionsFromCompatibility updates the Docker extension from the deprecated Docker fields
 // This allows plugins to access Docker data through the extensions system while maintaining
 // backward compatibility with code that sets the old fields
 func UpdateExtensionsFromCompatibility(ctx *ProjectContext) {
@@ -57,21 +40,11 @@ func UpdateExtensionsFromCompatibility(ctx *ProjectContext) {
 		return
 	}
 
-	dockerCtx := make(map[string]interface{})
-
-	if len(ctx.ComposeFiles) > 0 {
-		dockerCtx["compose_files"] = ctx.ComposeFiles
-	}
-
-	if ctx.ComposeOverride != "" {
-		dockerCtx["compose_override"] = ctx.ComposeOverride
-	}
-
-	dockerCtx["docker_running"] = ctx.DockerRunning
-
-	if len(ctx.ContainersStatus) > 0 {
-		dockerCtx["containers_status"] = ctx.ContainersStatus
-	}
-
-	ctx.Extensions["docker"] = dockerCtx
+	// Safe to ignore: DockerExtension is registered in this package
+	_ = SetExtension(ctx, DockerExtensionName, DockerExtension{
+		ComposeFiles:     ctx.ComposeFiles,
+		ComposeOverride:  ctx.ComposeOverride,
+		DockerRunning:    ctx.DockerRunning,
+		ContainersStatus: ctx.ContainersStatus,
+	})
 }
@@ -1,11 +1,15 @@
 package context
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"time"
 
 	"github.com/glide-cli/glide/v3/pkg/logging"
+	"golang.org/x/sync/errgroup"
 )
 
 // Detector is a refactored context detector using composition
@@ -15,9 +19,11 @@ type Detector struct {
 	modeDetector       DevelopmentModeDetector
 	locationIdentifier LocationIdentifier
 	composeResolver    ComposeFileResolver
+	moduleDetector     ModuleDetector
 	extensionRegistry  ExtensionRegistry
-	skipDockerCheck    bool // Skip expensive Docker daemon check
-	lazyDockerCheck    bool // Check Docker status lazily on first use
+	skipDockerCheck    bool          // Skip expensive Docker daemon check
+	lazyDockerCheck    bool          // Check Docker status lazily on first use
+	budget             time.Duration // Deadline for optional probes, 0 for none
 }
 
 // ExtensionRegistry interface for plugin-provided context extensions
@@ -25,6 +31,19 @@ type ExtensionRegistry interface {
 	DetectAll(projectRoot string) (map[string]interface{}, error)
 }
 
+// contextExtensionRegistry is an ExtensionRegistry that gives each of its
+// detectors its own deadline, budget after it starts, and stops it through
+// its context when it runs late
+type contextExtensionRegistry interface {
+	DetectAllContext(ctx context.Context, projectRoot string, budget time.Duration) (map[string]interface{}, error)
+}
+
+// contextModuleDetector is a ModuleDetector that can be stopped at the
+// detection deadline
+type contextModuleDetector interface {
+	DetectModulesContext(ctx context.Context, repoDir string) []Module
+}
+
 // NewDetector creates a new context detector with default strategies
 func NewDetector() (*Detector, error) {
 	wd, err := os.Getwd()
@@ -38,7 +57,9 @@ func NewDetector() (*Detector, error) {
 		modeDetector:       NewStandardDevelopmentModeDetector(),
 		locationIdentifier: NewStandardLocationIdentifier(),
 		composeResolver:    NewStandardComposeFileResolver(),
+		moduleDetector:     NewStandardModuleDetector(),
 		lazyDockerCheck:    true, // Default to lazy Docker checks for startup performance
+		budget:             DetectionBudget,
 	}, nil
 }
 
@@ -56,7 +77,9 @@ func NewDetectorFast() (*Detector, error) {
 		modeDetector:       NewStandardDevelopmentModeDetector(),
 		locationIdentifier: NewStandardLocationIdentifier(),
 		composeResolver:    NewStandardComposeFileResolver(),
+		moduleDetector:     &StandardModuleDetector{skipSubtrees: true},
 		skipDockerCheck:    true,
+		budget:             DetectionBudget,
 	}, nil
 }
 
@@ -78,6 +101,8 @@ func NewDetectorWithStrategies(
 		modeDetector:       modeDetector,
 		locationIdentifier: locationIdentifier,
 		composeResolver:    composeResolver,
+		moduleDetector:     NewStandardModuleDetector(),
+		budget:             DetectionBudget,
 	}, nil
 }
 
@@ -101,11 +126,22 @@ func (d *Detector) SetComposeResolver(resolver ComposeFileResolver) {
 	d.composeResolver = resolver
 }
 
+// SetModuleDetector sets a custom submodule and subtree detector
+func (d *Detector) SetModuleDetector(detector ModuleDetector) {
+	d.moduleDetector = detector
+}
+
 // SetExtensionRegistry sets a custom extension registry
 func (d *Detector) SetExtensionRegistry(registry ExtensionRegistry) {
 	d.extensionRegistry = registry
 }
 
+// SetBudget sets the deadline for module and extension detection; 0
+// waits for them however long they take
+func (d *Detector) SetBudget(budget time.Duration) {
+	d.budget = budget
+}
+
 // Detect analyzes the current environment and returns project context
 func (d *Detector) Detect() (*ProjectContext, error) {
 	logging.Debug("Detecting project context", "workingDir", d.workingDir)
@@ -116,30 +152,77 @@ func (d *Detector) Detect() (*ProjectContext, error) {
 	}
 
 	// Find project root
-	projectRoot, err := d.rootFinder.FindRoot(d.workingDir)
+	projectRoot, source, err := d.findRoot(ctx.WorkingDir)
+	if errors.Is(err, ErrProjectRootNotFound) {
+		// A symlink may lead into the project from outside it
+		if resolved := resolvePath(ctx.WorkingDir); resolved != ctx.WorkingDir {
+			if projectRoot, source, err = d.findRoot(resolved); err == nil {
+				ctx.WorkingDir = resolved
+			}
+		}
+	}
 	if err != nil {
 		logging.Error("Failed to find project root", "workingDir", d.workingDir, "error", err)
 		ctx.Error = err
 		return ctx, err
 	}
+	if !containsLexically(projectRoot, ctx.WorkingDir) {
+		// Reached through different symlinks, e.g. /var and /private/var on
+		// macOS; compare them along the resolved paths
+		projectRoot, ctx.WorkingDir = resolvePath(projectRoot), resolvePath(ctx.WorkingDir)
+	}
 	ctx.ProjectRoot = projectRoot
-	logging.Debug("Found project root", "root", projectRoot)
-
-	// Detect development mode
-	ctx.DevelopmentMode = d.modeDetector.DetectMode(ctx.ProjectRoot)
-	logging.Debug("Detected development mode", "mode", ctx.DevelopmentMode)
-
-	// Identify current location
-	ctx.Location = d.locationIdentifier.IdentifyLocation(ctx, d.workingDir)
-	logging.Debug("Identified location", "location", ctx.Location)
+	ctx.RootSource = source
+	logging.Debug("Found project root", "root", projectRoot, "source", source)
+
+	// Probe the layout, git history, and plugin extensions concurrently,
+	// under a shared deadline
+	probeCtx := context.Background()
+	if d.budget > 0 {
+		var cancel context.CancelFunc
+		probeCtx, cancel = context.WithTimeout(probeCtx, d.budget)
+		defer cancel()
+	}
+	var g errgroup.Group
+
+	g.Go(func() error {
+		// Detect development mode
+		ctx.DevelopmentMode = d.modeDetector.DetectMode(ctx.ProjectRoot)
+		logging.Debug("Detected development mode", "mode", ctx.DevelopmentMode)
+
+		// Identify current location
+		ctx.Location = d.locationIdentifier.IdentifyLocation(ctx, ctx.WorkingDir)
+		logging.Debug("Identified location", "location", ctx.Location)
+
+		// Detect submodules and subtrees of the current repository
+		if d.moduleDetector != nil {
+			modules, ok := d.detectModules(probeCtx, ctx.RepositoryDir())
+			if !ok {
+				logging.Debug("Module detection exceeded the detection budget", "budget", d.budget)
+			}
+			ctx.Modules = modules
+			ctx.CurrentModule = moduleContaining(ctx.Modules, ctx.WorkingDir)
+			logging.Debug("Detected modules", "count", len(ctx.Modules), "current", ctx.CurrentModule)
+		}
+		return nil
+	})
 
-	// Detect plugin-provided context extensions
+	// Detect plugin-provided context extensions. Each detector has its own
+	// deadline, so those that finish in time are kept.
+	var extensions map[string]interface{}
 	if d.extensionRegistry != nil {
-		extensions, err := d.extensionRegistry.DetectAll(ctx.ProjectRoot)
-		if err == nil && extensions != nil {
-			ctx.Extensions = extensions
-			logging.Debug("Detected context extensions", "count", len(extensions))
-		}
+		projectRoot := ctx.ProjectRoot
+		g.Go(func() error {
+			extensions = d.detectExtensions(probeCtx, projectRoot)
+			return nil
+		})
+	}
+
+	// Safe to ignore: probes report problems by returning less
+	_ = g.Wait()
+	if extensions != nil {
+		ctx.Extensions = extensions
+		logging.Debug("Detected context extensions", "count", len(extensions))
 	}
 
 	// Populate compatibility fields from extensions
@@ -160,7 +243,7 @@ func (d *Detector) Detect() (*ProjectContext, error) {
 		logging.Debug("Docker status checked", "running", ctx.DockerRunning)
 	} else if d.lazyDockerCheck {
 		// Mark for lazy checking - Docker status will be checked on first use
-		ctx.Extensions["_dockerCheckDeferred"] = true
+		ctx.dockerCheckDeferred = true
 		logging.Debug("Docker status check deferred for lazy loading")
 	}
 
@@ -170,6 +253,54 @@ func (d *Detector) Detect() (*ProjectContext, error) {
 	return ctx, nil
 }
 
+// findRoot runs the root finder, asking it what identified the root when
+// it can tell
+func (d *Detector) findRoot(workingDir string) (string, RootSource, error) {
+	if finder, ok := d.rootFinder.(rootSourceFinder); ok {
+		return finder.FindRootWithSource(workingDir)
+	}
+	root, err := d.rootFinder.FindRoot(workingDir)
+	return root, RootFromCustom, err
+}
+
+// detectModules runs the module detector, reporting false when it was cut
+// off by the deadline
+func (d *Detector) detectModules(ctx context.Context, repoDir string) ([]Module, bool) {
+	if detector, ok := d.moduleDetector.(contextModuleDetector); ok {
+		modules := detector.DetectModulesContext(ctx, repoDir)
+		return modules, ctx.Err() == nil
+	}
+	return within(ctx, func() []Module { return d.moduleDetector.DetectModules(repoDir) })
+}
+
+// detectExtensions runs the extension detectors, reporting false when they
+// were cut off by the deadline
+func (d *Detector) detectExtensions(probeCtx context.Context, projectRoot string) map[string]interface{} {
+	if registry, ok := d.extensionRegistry.(contextExtensionRegistry); ok {
+		// Detectors still running when detection is done are stopped
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		extensions, err := registry.DetectAllContext(ctx, projectRoot, d.budget)
+		if err != nil {
+			return nil
+		}
+		return extensions
+	}
+
+	// A registry that cannot be stopped gets the shared deadline as a whole
+	extensions, ok := within(probeCtx, func() map[string]interface{} {
+		extensions, err := d.extensionRegistry.DetectAll(projectRoot)
+		if err != nil {
+			return nil
+		}
+		return extensions
+	})
+	if !ok {
+		logging.Warn("Context extension detection exceeded the detection budget; extension data is left out", "budget", d.budget)
+	}
+	return extensions
+}
+
 // checkDockerStatus checks if Docker daemon is running
 func (d *Detector) checkDockerStatus(ctx *ProjectContext) {
 	cmd := exec.Command("docker", "info")
@@ -194,9 +325,9 @@ func (d *Detector) EnsureDockerStatus(ctx *ProjectContext) {
 	}
 
 	// Check if was marked as deferred
-	if _, ok := ctx.Extensions["_dockerCheckDeferred"]; ok {
+	if ctx.dockerCheckDeferred {
 		d.checkDockerStatus(ctx)
-		delete(ctx.Extensions, "_dockerCheckDeferred")
+		ctx.dockerCheckDeferred = false
 		logging.Debug("Docker status lazy checked", "running", ctx.DockerRunning)
 	}
 }
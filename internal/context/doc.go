@@ -42,6 +42,21 @@
 //	    Extensions       map[string]interface{} // Plugin-provided extensions
 //	}
 //
+// # Typed Extensions
+//
+// Extension data is read through a typed accessor backed by registered
+// schemas, rather than by type-asserting the Extensions map:
+//
+//	context.RegisterExtension[KubeExtension]("kubernetes")
+//
+//	if docker, ok := context.GetExtension[context.DockerExtension](ctx, "docker"); ok {
+//	    fmt.Println(docker.ComposeFiles)
+//	}
+//
+// Data a plugin provides as a generic map is decoded into the registered
+// type. GetExtension reports false when the extension is missing, is
+// registered as another type, or does not decode.
+//
 // # Development Modes
 //
 // Two development modes are supported:
@@ -59,6 +74,16 @@
 //	detector := context.NewDetector()
 //	detector.SetExtensionRegistry(registry)
 //
+// # Detection Budget
+//
+// Root markers are checked in all candidate directories at once. Once the
+// root is known, the layout, submodule and subtree detection, and plugin
+// extension detectors run concurrently under a shared deadline,
+// DetectionBudget. Probes still running at the deadline are dropped: late
+// extensions are left out and subtrees are skipped. Adjust it per detector:
+//
+//	detector.SetBudget(0) // wait for every probe
+//
 // # Lazy Docker Checking
 //
 // By default, Docker status is checked lazily to improve startup time:
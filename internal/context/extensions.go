@@ -0,0 +1,144 @@
+package context
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/glide-cli/glide/v3/pkg/logging"
+)
+
+// DockerExtensionName is the name of the extension holding Docker data
+const DockerExtensionName = "docker"
+
+// DockerExtension is the context data provided by the Docker plugin
+type DockerExtension struct {
+	ComposeFiles     []string                   `json:"compose_files,omitempty"`
+	ComposeOverride  string                     `json:"compose_override,omitempty"`
+	DockerRunning    bool                       `json:"docker_running"`
+	ContainersStatus map[string]ContainerStatus `json:"containers_status,omitempty"`
+}
+
+// ExtensionSchema describes the type of a registered context extension
+type ExtensionSchema struct {
+	Name string
+	Type reflect.Type
+}
+
+var (
+	schemasMu sync.RWMutex
+	schemas   = map[string]ExtensionSchema{}
+)
+
+func init() {
+	RegisterExtension[DockerExtension](DockerExtensionName)
+}
+
+// RegisterExtension declares that the extension called name holds a T.
+// Registering a name again with another type panics, as two consumers
+// would disagree on the shape of the same data.
+func RegisterExtension[T any](name string) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+	if existing, ok := schemas[name]; ok && existing.Type != typ {
+		panic(fmt.Sprintf("context extension %q already registered as %s", name, existing.Type))
+	}
+	schemas[name] = ExtensionSchema{Name: name, Type: typ}
+}
+
+// RegisteredExtensions returns the registered extension schemas by name
+func RegisteredExtensions() []ExtensionSchema {
+	schemasMu.RLock()
+	defer schemasMu.RUnlock()
+
+	result := make([]ExtensionSchema, 0, len(schemas))
+	for _, schema := range schemas {
+		result = append(result, schema)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// schemaFor returns the schema of name when it is registered as a T
+func schemaFor[T any](name string) (ExtensionSchema, error) {
+	schemasMu.RLock()
+	schema, ok := schemas[name]
+	schemasMu.RUnlock()
+
+	if !ok {
+		return schema, fmt.Errorf("context extension %q is not registered", name)
+	}
+	if want := reflect.TypeOf((*T)(nil)).Elem(); schema.Type != want {
+		return schema, fmt.Errorf("context extension %q is a %s, not a %s", name, schema.Type, want)
+	}
+	return schema, nil
+}
+
+// GetExtension returns the data of the extension called name as a T. It
+// reports false when the extension is absent, is not registered as a T, or
+// holds data that does not decode into one. Data provided in another
+// shape, such as the generic maps plugins send, is decoded through JSON
+// and cached in its typed form.
+func GetExtension[T any](ctx *ProjectContext, name string) (T, bool) {
+	var zero T
+	if ctx == nil || ctx.Extensions == nil {
+		return zero, false
+	}
+	if _, err := schemaFor[T](name); err != nil {
+		logging.Debug("Rejected context extension access", "error", err)
+		return zero, false
+	}
+
+	data, ok := ctx.Extensions[name]
+	if !ok || data == nil {
+		return zero, false
+	}
+	switch value := data.(type) {
+	case T:
+		return value, true
+	case *T:
+		if value == nil {
+			return zero, false
+		}
+		return *value, true
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		logging.Debug("Invalid context extension data", "extension", name, "error", err)
+		return zero, false
+	}
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		logging.Debug("Invalid context extension data", "extension", name, "error", err)
+		return zero, false
+	}
+	ctx.Extensions[name] = value
+	return value, true
+}
+
+// SetExtension stores value as the data of the extension called name,
+// which must be registered as a T
+func SetExtension[T any](ctx *ProjectContext, name string, value T) error {
+	if _, err := schemaFor[T](name); err != nil {
+		return err
+	}
+	if ctx.Extensions == nil {
+		ctx.Extensions = make(map[string]interface{})
+	}
+	ctx.Extensions[name] = value
+	return nil
+}
+
+// HasExtension reports whether the context holds data for name
+func HasExtension(ctx *ProjectContext, name string) bool {
+	if ctx == nil || ctx.Extensions == nil {
+		return false
+	}
+	data, ok := ctx.Extensions[name]
+	return ok && data != nil
+}
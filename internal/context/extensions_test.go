@@ -0,0 +1,111 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testExtension struct {
+	Cluster string `json:"cluster"`
+	Nodes   int    `json:"nodes"`
+}
+
+func init() {
+	RegisterExtension[testExtension]("test-kube")
+}
+
+func TestGetExtension(t *testing.T) {
+	t.Run("typed value", func(t *testing.T) {
+		ctx := &ProjectContext{}
+		require.NoError(t, SetExtension(ctx, "test-kube", testExtension{Cluster: "dev", Nodes: 3}))
+
+		ext, ok := GetExtension[testExtension](ctx, "test-kube")
+		require.True(t, ok)
+		assert.Equal(t, testExtension{Cluster: "dev", Nodes: 3}, ext)
+	})
+
+	t.Run("generic map from a plugin", func(t *testing.T) {
+		ctx := &ProjectContext{Extensions: map[string]interface{}{
+			"test-kube": map[string]interface{}{"cluster": "prod", "nodes": 5},
+		}}
+
+		ext, ok := GetExtension[testExtension](ctx, "test-kube")
+		require.True(t, ok)
+		assert.Equal(t, testExtension{Cluster: "prod", Nodes: 5}, ext)
+		assert.IsType(t, testExtension{}, ctx.Extensions["test-kube"], "decoded value should be cached")
+	})
+
+	t.Run("pointer value", func(t *testing.T) {
+		ctx := &ProjectContext{Extensions: map[string]interface{}{
+			"test-kube": &testExtension{Cluster: "ptr"},
+		}}
+
+		ext, ok := GetExtension[testExtension](ctx, "test-kube")
+		require.True(t, ok)
+		assert.Equal(t, "ptr", ext.Cluster)
+	})
+
+	t.Run("data of the wrong shape", func(t *testing.T) {
+		ctx := &ProjectContext{Extensions: map[string]interface{}{
+			"test-kube": map[string]interface{}{"nodes": "many"},
+		}}
+
+		_, ok := GetExtension[testExtension](ctx, "test-kube")
+		assert.False(t, ok)
+	})
+
+	t.Run("wrong type parameter", func(t *testing.T) {
+		ctx := &ProjectContext{}
+		require.NoError(t, SetExtension(ctx, "test-kube", testExtension{}))
+
+		_, ok := GetExtension[DockerExtension](ctx, "test-kube")
+		assert.False(t, ok)
+	})
+
+	t.Run("unregistered and missing", func(t *testing.T) {
+		ctx := &ProjectContext{Extensions: map[string]interface{}{"other": "x"}}
+
+		_, ok := GetExtension[string](ctx, "other")
+		assert.False(t, ok)
+		_, ok = GetExtension[testExtension](ctx, "test-kube")
+		assert.False(t, ok)
+		_, ok = GetExtension[testExtension](nil, "test-kube")
+		assert.False(t, ok)
+	})
+}
+
+func TestSetExtension_RejectsMismatchedType(t *testing.T) {
+	ctx := &ProjectContext{}
+
+	assert.Error(t, SetExtension(ctx, "test-kube", "not a kube extension"))
+	assert.Error(t, SetExtension(ctx, "unregistered", testExtension{}))
+	assert.False(t, HasExtension(ctx, "test-kube"))
+}
+
+func TestRegisterExtension_ConflictingTypePanics(t *testing.T) {
+	assert.NotPanics(t, func() { RegisterExtension[testExtension]("test-kube") })
+	assert.Panics(t, func() { RegisterExtension[string]("test-kube") })
+}
+
+func TestDockerExtension_Compatibility(t *testing.T) {
+	ctx := &ProjectContext{Extensions: map[string]interface{}{
+		"docker": map[string]interface{}{
+			"compose_files":    []string{"docker-compose.yml"},
+			"compose_override": "docker-compose.override.yml",
+			"docker_running":   true,
+		},
+	}}
+
+	PopulateCompatibilityFields(ctx)
+	assert.Equal(t, []string{"docker-compose.yml"}, ctx.ComposeFiles)
+	assert.Equal(t, "docker-compose.override.yml", ctx.ComposeOverride)
+	assert.True(t, ctx.DockerRunning)
+
+	ctx.ComposeFiles = append(ctx.ComposeFiles, "docker-compose.local.yml")
+	UpdateExtensionsFromCompatibility(ctx)
+	docker := ctx.GetDockerContext()
+	require.NotNil(t, docker)
+	assert.Len(t, docker.ComposeFiles, 2)
+}
@@ -0,0 +1,230 @@
+package context
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ModuleKind tells how a module is embedded in its repository
+type ModuleKind string
+
+const (
+	ModuleSubmodule ModuleKind = "submodule"
+	ModuleSubtree   ModuleKind = "subtree"
+)
+
+// Module is a repository embedded in the project's repository as a git
+// submodule or subtree
+type Module struct {
+	Name string     // Submodule name from .gitmodules, or the path of a subtree
+	Path string     // Path relative to the repository, slash-separated
+	Dir  string     // Absolute directory
+	URL  string     // Remote URL (submodules only)
+	Kind ModuleKind // submodule or subtree
+}
+
+// ModuleDetector finds the submodules and subtrees of a repository
+type ModuleDetector interface {
+	DetectModules(repoDir string) []Module
+}
+
+// StandardModuleDetector reads submodules from .gitmodules and subtrees
+// from the git-subtree-dir trailers `git subtree` leaves in commit messages
+type StandardModuleDetector struct {
+	skipSubtrees bool // Subtrees need a history search, skipped for fast startup
+}
+
+// NewStandardModuleDetector creates a new module detector
+func NewStandardModuleDetector() *StandardModuleDetector {
+	return &StandardModuleDetector{}
+}
+
+// DetectModules returns the submodules, then the subtrees, of the
+// repository in repoDir
+func (d *StandardModuleDetector) DetectModules(repoDir string) []Module {
+	return d.DetectModulesContext(context.Background(), repoDir)
+}
+
+// DetectModulesContext is DetectModules, leaving out the subtrees when ctx
+// is done before the history search finishes
+func (d *StandardModuleDetector) DetectModulesContext(ctx context.Context, repoDir string) []Module {
+	if repoDir == "" {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, ".git")); err != nil {
+		return nil
+	}
+
+	modules := parseGitmodules(repoDir)
+	if d.skipSubtrees {
+		return modules
+	}
+
+	known := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		known[m.Path] = true
+	}
+	for _, path := range detectSubtrees(ctx, repoDir) {
+		if known[path] {
+			continue
+		}
+		known[path] = true
+		dir := filepath.Join(repoDir, filepath.FromSlash(path))
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			// Removed since it was added
+			continue
+		}
+		modules = append(modules, Module{Name: path, Path: path, Dir: dir, Kind: ModuleSubtree})
+	}
+	return modules
+}
+
+// parseGitmodules reads the submodules declared in .gitmodules
+func parseGitmodules(repoDir string) []Module {
+	file, err := os.Open(filepath.Join(repoDir, ".gitmodules"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var modules []Module
+	var current *Module
+	flush := func() {
+		if current != nil && current.Path != "" {
+			current.Path = filepath.ToSlash(filepath.Clean(current.Path))
+			current.Dir = filepath.Join(repoDir, filepath.FromSlash(current.Path))
+			modules = append(modules, *current)
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			flush()
+			// [submodule "name"]
+			section := strings.Trim(line, "[]")
+			if kind, name, ok := strings.Cut(section, " "); ok && kind == "submodule" {
+				current = &Module{Name: strings.Trim(name, `"`), Kind: ModuleSubmodule}
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "path":
+			current.Path = strings.TrimSpace(value)
+		case "url":
+			current.URL = strings.TrimSpace(value)
+		}
+	}
+	flush()
+
+	return modules
+}
+
+// detectSubtrees returns the paths of subtrees added or merged with
+// `git subtree`, in order of first appearance in the history
+func detectSubtrees(ctx context.Context, repoDir string) []string {
+	cmd := exec.CommandContext(ctx, "git", "log", "--grep=^git-subtree-dir:", "--format=%B")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(out), "\n") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), "git-subtree-dir:")
+		if !ok {
+			continue
+		}
+		path := filepath.ToSlash(filepath.Clean(strings.TrimSpace(value)))
+		if path == "." || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// isSubmoduleCheckout reports whether dir is the checkout of a submodule,
+// whose .git is a file pointing into the superproject's .git/modules
+func isSubmoduleCheckout(dir string) bool {
+	data, err := os.ReadFile(filepath.Join(dir, ".git"))
+	if err != nil {
+		// Missing, or a directory: a repository of its own
+		return false
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return false
+	}
+	return strings.Contains(filepath.ToSlash(strings.TrimSpace(gitdir)), "/modules/")
+}
+
+// RepositoryDir returns the directory of the git repository commands run
+// against: the current worktree or vcs/ in multi-worktree mode, the project
+// root otherwise
+func (c *ProjectContext) RepositoryDir() string {
+	if c.ProjectRoot == "" {
+		return ""
+	}
+	if c.DevelopmentMode == ModeMultiWorktree {
+		if c.IsWorktree && c.WorktreeName != "" {
+			return filepath.Join(c.ProjectRoot, "worktrees", c.WorktreeName)
+		}
+		return filepath.Join(c.ProjectRoot, "vcs")
+	}
+	return c.ProjectRoot
+}
+
+// FindModule returns the module with the given name, path, or directory
+// name
+func (c *ProjectContext) FindModule(name string) (Module, bool) {
+	name = strings.TrimSuffix(filepath.ToSlash(name), "/")
+	for _, m := range c.Modules {
+		if m.Name == name || m.Path == name {
+			return m, true
+		}
+	}
+	var match Module
+	matches := 0
+	for _, m := range c.Modules {
+		if filepath.Base(m.Dir) == name {
+			match = m
+			matches++
+		}
+	}
+	return match, matches == 1
+}
+
+// moduleContaining returns the name of the innermost module containing dir
+func moduleContaining(modules []Module, dir string) string {
+	name, longest := "", 0
+	for _, m := range modules {
+		rel, err := filepath.Rel(m.Dir, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if len(m.Dir) > longest {
+			name, longest = m.Name, len(m.Dir)
+		}
+	}
+	return name
+}
@@ -0,0 +1,121 @@
+package context
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// superproject creates a repository with a checked-out submodule at
+// libs/payments
+func superproject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "modules", "payments"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".gitmodules"), []byte(`[submodule "payments"]
+	path = libs/payments
+	url = git@example.com:acme/payments.git
+[submodule "docs"]
+	path = docs/
+	url = ../docs.git
+`), 0644))
+
+	sub := filepath.Join(root, "libs", "payments")
+	require.NoError(t, os.MkdirAll(filepath.Join(sub, "src"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sub, ".git"), []byte("gitdir: ../../.git/modules/payments\n"), 0644))
+	return root
+}
+
+func TestStandardProjectRootFinder_Submodule(t *testing.T) {
+	root := superproject(t)
+	finder := NewStandardProjectRootFinder()
+
+	found, err := finder.FindRoot(filepath.Join(root, "libs", "payments", "src"))
+	require.NoError(t, err)
+	assert.Equal(t, root, found, "the superproject is the project root")
+
+	// A git worktree also has a .git file, but is a repository of its own
+	worktree := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: /src/app/.git/worktrees/feature\n"), 0644))
+	found, err = finder.FindRoot(worktree)
+	require.NoError(t, err)
+	assert.Equal(t, worktree, found)
+}
+
+func TestStandardModuleDetector_Submodules(t *testing.T) {
+	root := superproject(t)
+
+	modules := (&StandardModuleDetector{skipSubtrees: true}).DetectModules(root)
+	require.Len(t, modules, 2)
+	assert.Equal(t, Module{
+		Name: "payments",
+		Path: "libs/payments",
+		Dir:  filepath.Join(root, "libs", "payments"),
+		URL:  "git@example.com:acme/payments.git",
+		Kind: ModuleSubmodule,
+	}, modules[0])
+	assert.Equal(t, "docs", modules[1].Path)
+
+	assert.Empty(t, NewStandardModuleDetector().DetectModules(t.TempDir()), "not a repository")
+}
+
+func TestStandardModuleDetector_Subtrees(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = root
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "vendor", "ui"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "vendor", "ui", "README"), []byte("ui\n"), 0644))
+	git("add", ".")
+	git("commit", "-q", "-m", "Add 'vendor/ui/' from commit 'abc'\n\ngit-subtree-dir: vendor/ui\ngit-subtree-mainline: def\ngit-subtree-split: abc")
+	git("commit", "-q", "--allow-empty", "-m", "Squashed 'vendor/gone/' content\n\ngit-subtree-dir: vendor/gone")
+
+	modules := NewStandardModuleDetector().DetectModules(root)
+	require.Len(t, modules, 1, "removed subtrees are ignored")
+	assert.Equal(t, Module{Name: "vendor/ui", Path: "vendor/ui", Dir: filepath.Join(root, "vendor", "ui"), Kind: ModuleSubtree}, modules[0])
+}
+
+func TestProjectContext_FindModule(t *testing.T) {
+	ctx := &ProjectContext{Modules: []Module{
+		{Name: "payments", Path: "libs/payments", Dir: "/src/app/libs/payments"},
+		{Name: "vendor/ui", Path: "vendor/ui", Dir: "/src/app/vendor/ui"},
+		{Name: "other/ui", Path: "other/ui", Dir: "/src/app/other/ui"},
+	}}
+
+	for _, name := range []string{"payments", "libs/payments", "libs/payments/"} {
+		m, ok := ctx.FindModule(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, "payments", m.Name)
+	}
+	_, ok := ctx.FindModule("ui")
+	assert.False(t, ok, "ambiguous directory names do not match")
+	_, ok = ctx.FindModule("nope")
+	assert.False(t, ok)
+
+	assert.Equal(t, "payments", moduleContaining(ctx.Modules, "/src/app/libs/payments/src"))
+	assert.Empty(t, moduleContaining(ctx.Modules, "/src/app/libs"))
+}
+
+func TestDetector_Modules(t *testing.T) {
+	root := superproject(t)
+	detector, err := NewDetectorFast()
+	require.NoError(t, err)
+	detector.workingDir = filepath.Join(root, "libs", "payments", "src")
+
+	ctx, err := detector.Detect()
+	require.NoError(t, err)
+	assert.Equal(t, root, ctx.ProjectRoot)
+	assert.Len(t, ctx.Modules, 2)
+	assert.Equal(t, "payments", ctx.CurrentModule)
+}
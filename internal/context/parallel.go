@@ -0,0 +1,77 @@
+package context
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DetectionBudget bounds the optional probes of context detection: git
+	// history searches, and each plugin extension detector on its own.
+	// Results that arrive later are dropped rather than slowing down every
+	// command.
+	DetectionBudget = 100 * time.Millisecond
+
+	// maxDetectionWorkers bounds the goroutines probing at once
+	maxDetectionWorkers = 8
+)
+
+// within runs fn and returns its result, or gives up when ctx is done
+// first. fn keeps running in the background when it ignores ctx.
+func within[T any](ctx context.Context, fn func() T) (T, bool) {
+	done := make(chan T, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case result := <-done:
+		return result, true
+	case <-ctx.Done():
+		var zero T
+		return zero, false
+	}
+}
+
+// rootMarkers are the files a directory is checked for when looking for the
+// project root
+type rootMarkers struct {
+	glideConfig  bool // .glide.yml
+	vcsRepo      bool // vcs/.git, in a vcs/ directory
+	git          bool // .git
+	submoduleGit bool // .git is a submodule's pointer file
+}
+
+// probeRootMarkers checks dirs for root markers concurrently, which matters
+// on slow filesystems when the working directory is deep in a monorepo
+func probeRootMarkers(dirs []string) []rootMarkers {
+	markers := make([]rootMarkers, len(dirs))
+
+	var g errgroup.Group
+	g.SetLimit(maxDetectionWorkers)
+	for i, dir := range dirs {
+		g.Go(func() error {
+			m := &markers[i]
+			if _, err := os.Stat(filepath.Join(dir, ".glide.yml")); err == nil {
+				m.glideConfig = true
+			}
+			if info, err := os.Stat(filepath.Join(dir, "vcs")); err == nil && info.IsDir() {
+				if _, err := os.Stat(filepath.Join(dir, "vcs", ".git")); err == nil {
+					m.vcsRepo = true
+				}
+			}
+			if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+				m.git = true
+				m.submoduleGit = isSubmoduleCheckout(dir)
+			}
+			return nil
+		})
+	}
+	// Safe to ignore: the probes never fail, a missing marker is a result
+	_ = g.Wait()
+	return markers
+}
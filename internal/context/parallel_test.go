@@ -0,0 +1,142 @@
+package context
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowRegistry is an extension registry whose detection ignores deadlines
+type slowRegistry struct {
+	delay time.Duration
+}
+
+func (r *slowRegistry) DetectAll(projectRoot string) (map[string]interface{}, error) {
+	time.Sleep(r.delay)
+	return map[string]interface{}{"slow": true}, nil
+}
+
+// slowModuleDetector is a module detector that ignores deadlines
+type slowModuleDetector struct {
+	delay time.Duration
+}
+
+func (d *slowModuleDetector) DetectModules(repoDir string) []Module {
+	time.Sleep(d.delay)
+	return []Module{{Name: "late"}}
+}
+
+func TestWithin(t *testing.T) {
+	result, ok := within(context.Background(), func() int { return 42 })
+	assert.True(t, ok)
+	assert.Equal(t, 42, result)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, ok = within(ctx, func() int {
+		time.Sleep(time.Second)
+		return 1
+	})
+	assert.False(t, ok)
+}
+
+func TestDetector_Budget(t *testing.T) {
+	root := superproject(t)
+
+	t.Run("drops probes past the deadline", func(t *testing.T) {
+		detector, err := NewDetectorFast()
+		require.NoError(t, err)
+		detector.workingDir = root
+		detector.SetModuleDetector(&slowModuleDetector{delay: time.Second})
+		detector.SetExtensionRegistry(&slowRegistry{delay: time.Second})
+		detector.SetBudget(20 * time.Millisecond)
+
+		start := time.Now()
+		ctx, err := detector.Detect()
+		require.NoError(t, err)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+		assert.Equal(t, root, ctx.ProjectRoot)
+		assert.Equal(t, ModeSingleRepo, ctx.DevelopmentMode)
+		assert.Empty(t, ctx.Modules)
+		assert.False(t, HasExtension(ctx, "slow"))
+	})
+
+	t.Run("runs probes concurrently", func(t *testing.T) {
+		detector, err := NewDetectorFast()
+		require.NoError(t, err)
+		detector.workingDir = root
+		detector.SetModuleDetector(&slowModuleDetector{delay: 100 * time.Millisecond})
+		detector.SetExtensionRegistry(&slowRegistry{delay: 100 * time.Millisecond})
+		detector.SetBudget(0)
+
+		start := time.Now()
+		ctx, err := detector.Detect()
+		require.NoError(t, err)
+		assert.Less(t, time.Since(start), 190*time.Millisecond, "probes should overlap")
+		assert.Len(t, ctx.Modules, 1)
+		assert.True(t, HasExtension(ctx, "slow"))
+	})
+}
+
+func TestProbeRootMarkers(t *testing.T) {
+	root := superproject(t)
+	sub := filepath.Join(root, "libs", "payments")
+
+	markers := probeRootMarkers([]string{sub, filepath.Join(root, "libs"), root})
+	require.Len(t, markers, 3)
+	assert.Equal(t, rootMarkers{git: true, submoduleGit: true}, markers[0])
+	assert.Equal(t, rootMarkers{}, markers[1])
+	assert.Equal(t, rootMarkers{git: true}, markers[2])
+}
+
+// timedExtension is a context extension that takes delay to detect, or
+// until its context is done
+type timedExtension struct {
+	name    string
+	delay   time.Duration
+	stopped chan struct{}
+}
+
+func (e *timedExtension) ProvideContext() sdk.ContextExtension { return e }
+func (e *timedExtension) Name() string                         { return e.name }
+func (e *timedExtension) Merge(_, data interface{}) (interface{}, error) {
+	return data, nil
+}
+
+func (e *timedExtension) Detect(ctx context.Context, projectRoot string) (interface{}, error) {
+	select {
+	case <-time.After(e.delay):
+		return map[string]interface{}{"root": projectRoot}, nil
+	case <-ctx.Done():
+		close(e.stopped)
+		return nil, ctx.Err()
+	}
+}
+
+func TestDetector_BudgetPerExtension(t *testing.T) {
+	root := superproject(t)
+	fast := &timedExtension{name: "fast", stopped: make(chan struct{})}
+	slow := &timedExtension{name: "slow", delay: time.Second, stopped: make(chan struct{})}
+
+	detector, err := NewDetectorFast()
+	require.NoError(t, err)
+	detector.workingDir = root
+	detector.SetExtensionRegistry(newPluginExtensionRegistry([]interface{}{fast, slow}))
+	detector.SetBudget(20 * time.Millisecond)
+
+	ctx, err := detector.Detect()
+	require.NoError(t, err)
+	assert.True(t, HasExtension(ctx, "fast"), "detectors that finish in time are kept")
+	assert.False(t, HasExtension(ctx, "slow"))
+
+	select {
+	case <-slow.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the late detector was not stopped")
+	}
+}
@@ -2,8 +2,12 @@ package context
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	"github.com/glide-cli/glide/v3/pkg/logging"
 	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"golang.org/x/sync/errgroup"
 )
 
 // pluginExtensionAdapter adapts the plugin system to the context ExtensionRegistry interface
@@ -13,9 +17,18 @@ type pluginExtensionAdapter struct {
 
 // DetectAll runs detection for all registered plugins that provide context extensions
 func (a *pluginExtensionAdapter) DetectAll(projectRoot string) (map[string]interface{}, error) {
+	return a.DetectAllContext(context.Background(), projectRoot, 0)
+}
+
+// DetectAllContext runs the plugins' detectors concurrently. Each has
+// budget (0 for no limit) from when it starts; one still running then is
+// dropped, with a warning, and stopped by cancelling its context.
+func (a *pluginExtensionAdapter) DetectAllContext(ctx context.Context, projectRoot string, budget time.Duration) (map[string]interface{}, error) {
+	var mu sync.Mutex
 	results := make(map[string]interface{})
-	ctx := context.Background()
 
+	var g errgroup.Group
+	g.SetLimit(maxDetectionWorkers)
 	for _, p := range a.providers {
 		// Check if plugin provides context extension
 		provider, ok := p.(sdk.ContextProvider)
@@ -28,18 +41,43 @@ func (a *pluginExtensionAdapter) DetectAll(projectRoot string) (map[string]inter
 			continue
 		}
 
-		// Detect extension data
-		data, err := ext.Detect(ctx, projectRoot)
-		if err != nil {
+		g.Go(func() error {
+			var detectCtx context.Context
+			var cancel context.CancelFunc
+			if budget > 0 {
+				detectCtx, cancel = context.WithTimeout(ctx, budget)
+			} else {
+				detectCtx, cancel = context.WithCancel(ctx)
+			}
+			defer cancel()
+
+			type detection struct {
+				data interface{}
+				err  error
+			}
+			result, ok := within(detectCtx, func() detection {
+				data, err := ext.Detect(detectCtx, projectRoot)
+				return detection{data, err}
+			})
+			if !ok {
+				logging.Warn("Context extension detector exceeded the detection budget; its data is left out",
+					"extension", ext.Name(), "budget", budget)
+				return nil
+			}
 			// Continue with other extensions if one fails
 			// Don't break the entire detection process
-			continue
-		}
+			if result.err != nil || result.data == nil {
+				return nil
+			}
 
-		if data != nil {
-			results[ext.Name()] = data
-		}
+			mu.Lock()
+			results[ext.Name()] = result.data
+			mu.Unlock()
+			return nil
+		})
 	}
+	// Safe to ignore: failed detectors are skipped above
+	_ = g.Wait()
 
 	return results, nil
 }
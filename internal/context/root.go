@@ -0,0 +1,130 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// RootSource tells what identified the project root
+type RootSource string
+
+const (
+	RootFromOverride      RootSource = "root-override"  // root: in the nearest .glide.yml
+	RootFromConfig        RootSource = "glide-config"   // Directory holding .glide.yml
+	RootFromMultiWorktree RootSource = "multi-worktree" // Directory holding vcs/.git
+	RootFromGit           RootSource = "git"            // Directory holding .git
+	RootFromWorktreePath  RootSource = "worktree-path"  // Path inside worktrees/*/
+	RootFromVCSPath       RootSource = "vcs-path"       // Path inside vcs/
+	RootFromCustom        RootSource = "custom"         // A custom ProjectRootFinder
+	RootSourceUnknown     RootSource = ""
+)
+
+// rootSourceFinder is a ProjectRootFinder that reports why it chose a root
+type rootSourceFinder interface {
+	FindRootWithSource(workingDir string) (string, RootSource, error)
+}
+
+// rootOverride returns the root: setting of the config file in dir,
+// resolved against dir
+func rootOverride(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, ".glide.yml"))
+	if err != nil {
+		return ""
+	}
+	var cfg struct {
+		Root string `yaml:"root"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil || cfg.Root == "" {
+		return ""
+	}
+	root := cfg.Root
+	if strings.HasPrefix(root, "~/") {
+		home, _ := os.UserHomeDir()
+		root = filepath.Join(home, root[2:])
+	}
+	if !filepath.IsAbs(root) {
+		root = filepath.Join(dir, root)
+	}
+	return filepath.Clean(root)
+}
+
+// enclosingMultiWorktreeRoot returns the multi-worktree project whose vcs/
+// or worktrees/*/ checkout contains dir. A checkout carries the project's
+// .glide.yml and .git, but belongs to the project around it.
+func enclosingMultiWorktreeRoot(dir string) (string, RootSource, bool) {
+	root, source := "", RootSourceUnknown
+	switch {
+	case strings.Contains(dir, "/worktrees/"):
+		root, source = strings.Split(dir, "/worktrees/")[0], RootFromWorktreePath
+	case strings.Contains(dir, "/vcs"):
+		root, source = strings.Split(dir, "/vcs")[0], RootFromVCSPath
+	default:
+		return "", RootSourceUnknown, false
+	}
+	if _, err := os.Stat(filepath.Join(root, "vcs", ".git")); err != nil {
+		return "", RootSourceUnknown, false
+	}
+	return root, source, true
+}
+
+// containsPath reports whether dir is root or inside it, comparing the
+// paths with symlinks resolved so /var and /private/var are the same
+func containsPath(root, dir string) bool {
+	return containsLexically(resolvePath(root), resolvePath(dir))
+}
+
+// containsLexically reports whether dir is root or inside it, comparing
+// the paths as written
+func containsLexically(root, dir string) bool {
+	rel, err := filepath.Rel(root, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// respell returns root spelled along the same path as dir, which root
+// contains, so paths relative to one another stay valid when the two were
+// reached through different symlinks
+func respell(root, dir string) string {
+	rel, err := filepath.Rel(resolvePath(root), resolvePath(dir))
+	if err != nil {
+		return root
+	}
+	if rel == "." {
+		return dir
+	}
+	if suffix := string(filepath.Separator) + rel; strings.HasSuffix(dir, suffix) {
+		return strings.TrimSuffix(dir, suffix)
+	}
+	return root
+}
+
+// resolvePath returns path with symlinks resolved, or unchanged when it
+// cannot be resolved
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// applyRootOverride follows the root: setting of the .glide.yml in dir, when
+// it names a directory containing workingDir
+func applyRootOverride(dir, workingDir string) (string, bool) {
+	override := rootOverride(dir)
+	if override == "" {
+		return "", false
+	}
+	if info, err := os.Stat(override); err != nil || !info.IsDir() {
+		logging.Warn("Ignoring root: in .glide.yml, not a directory", "config", filepath.Join(dir, ".glide.yml"), "root", override)
+		return "", false
+	}
+	if !containsPath(override, workingDir) {
+		logging.Warn("Ignoring root: in .glide.yml, it does not contain the working directory",
+			"config", filepath.Join(dir, ".glide.yml"), "root", override, "workingDir", workingDir)
+		return "", false
+	}
+	return respell(override, workingDir), true
+}
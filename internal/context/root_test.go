@@ -0,0 +1,120 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mkdirs creates directories under root
+func mkdirs(t *testing.T, root string, dirs ...string) {
+	t.Helper()
+	for _, dir := range dirs {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.FromSlash(dir)), 0755))
+	}
+}
+
+// writeFile writes a file under root, creating its directory
+func writeFile(t *testing.T, root, file, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(file))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestStandardProjectRootFinder_Sources(t *testing.T) {
+	finder := NewStandardProjectRootFinder()
+
+	t.Run("nearest marker wins in nested projects", func(t *testing.T) {
+		outer := t.TempDir()
+		mkdirs(t, outer, ".git", "tools/gen/.git", "tools/gen/src")
+
+		root, source, err := finder.FindRootWithSource(filepath.Join(outer, "tools", "gen", "src"))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(outer, "tools", "gen"), root)
+		assert.Equal(t, RootFromGit, source)
+	})
+
+	t.Run("config beats git in the same directory", func(t *testing.T) {
+		dir := t.TempDir()
+		mkdirs(t, dir, ".git")
+		writeFile(t, dir, ".glide.yml", "commands: {}\n")
+
+		root, source, err := finder.FindRootWithSource(dir)
+		require.NoError(t, err)
+		assert.Equal(t, dir, root)
+		assert.Equal(t, RootFromConfig, source)
+	})
+
+	t.Run("root override", func(t *testing.T) {
+		outer := t.TempDir()
+		mkdirs(t, outer, ".git", "tools/gen/src")
+		writeFile(t, outer, "tools/gen/.glide.yml", "root: ../..\n")
+
+		root, source, err := finder.FindRootWithSource(filepath.Join(outer, "tools", "gen", "src"))
+		require.NoError(t, err)
+		assert.Equal(t, outer, root)
+		assert.Equal(t, RootFromOverride, source)
+	})
+
+	t.Run("root override must contain the working directory", func(t *testing.T) {
+		outer := t.TempDir()
+		mkdirs(t, outer, "elsewhere", "project")
+		writeFile(t, outer, "project/.glide.yml", "root: ../elsewhere\n")
+
+		root, source, err := finder.FindRootWithSource(filepath.Join(outer, "project"))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(outer, "project"), root)
+		assert.Equal(t, RootFromConfig, source)
+	})
+
+	t.Run("config in a worktree belongs to the multi-worktree project", func(t *testing.T) {
+		project := t.TempDir()
+		mkdirs(t, project, "vcs/.git", "worktrees/feature/src")
+		writeFile(t, project, "worktrees/feature/.glide.yml", "commands: {}\n")
+		writeFile(t, project, "worktrees/feature/.git", "gitdir: ../../vcs/.git/worktrees/feature\n")
+
+		root, source, err := finder.FindRootWithSource(filepath.Join(project, "worktrees", "feature", "src"))
+		require.NoError(t, err)
+		assert.Equal(t, project, root)
+		assert.Equal(t, RootFromWorktreePath, source)
+	})
+}
+
+func TestDetector_SymlinkedWorkingDir(t *testing.T) {
+	base := t.TempDir()
+	project := filepath.Join(base, "project")
+	mkdirs(t, project, ".git", "src/deep")
+
+	// A shortcut from outside the project into it
+	link := filepath.Join(base, "shortcut")
+	require.NoError(t, os.Symlink(filepath.Join(project, "src", "deep"), link))
+
+	detector, err := NewDetectorFast()
+	require.NoError(t, err)
+	detector.workingDir = link
+
+	ctx, err := detector.Detect()
+	require.NoError(t, err)
+	assert.Equal(t, resolvePath(project), ctx.ProjectRoot)
+	assert.Equal(t, resolvePath(filepath.Join(project, "src", "deep")), ctx.WorkingDir)
+	assert.Equal(t, RootFromGit, ctx.RootSource)
+	assert.Equal(t, LocationProject, ctx.Location)
+}
+
+func TestRespell(t *testing.T) {
+	base := t.TempDir()
+	real := filepath.Join(base, "real")
+	mkdirs(t, real, "app/src")
+	alias := filepath.Join(base, "alias")
+	require.NoError(t, os.Symlink(real, alias))
+
+	// The root as resolved, the working directory through the alias
+	assert.Equal(t, filepath.Join(alias, "app"), respell(filepath.Join(real, "app"), filepath.Join(alias, "app", "src")))
+	assert.Equal(t, filepath.Join(alias, "app"), respell(filepath.Join(real, "app"), filepath.Join(alias, "app")))
+	assert.True(t, containsPath(filepath.Join(real, "app"), filepath.Join(alias, "app", "src")))
+	assert.False(t, containsLexically(filepath.Join(real, "app"), filepath.Join(alias, "app", "src")))
+}
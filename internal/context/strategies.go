@@ -51,33 +51,52 @@ func NewStandardProjectRootFinder() *StandardProjectRootFinder {
 
 // FindRoot finds the project root directory
 func (f *StandardProjectRootFinder) FindRoot(workingDir string) (string, error) {
-	current := workingDir
-	traversed := 0
+	root, _, err := f.FindRootWithSource(workingDir)
+	return root, err
+}
 
-	for traversed < f.maxTraversal {
-		// Check for .glide.yml file (indicates a Glide project)
-		glidePath := filepath.Join(current, ".glide.yml")
-		if _, err := os.Stat(glidePath); err == nil {
-			// Found .glide.yml, this is a project root
-			return current, nil
+// FindRootWithSource finds the project root directory and what identified
+// it. Walking up from workingDir, the nearest directory with a marker wins;
+// within one directory .glide.yml beats vcs/.git beats .git, and the .git of
+// a submodule checkout is skipped. A .glide.yml found this way can name the
+// root explicitly with root:, and otherwise a marker inside the vcs/ or
+// worktrees/*/ checkout of a multi-worktree project resolves to that project.
+func (f *StandardProjectRootFinder) FindRootWithSource(workingDir string) (string, RootSource, error) {
+	// Candidate directories, nearest first
+	var dirs []string
+	for current := workingDir; len(dirs) < f.maxTraversal; {
+		dirs = append(dirs, current)
+		parent := filepath.Dir(current)
+		if parent == current {
+			break // Reached filesystem root
 		}
+		current = parent
+	}
 
-		// Check for multi-worktree structure (has vcs/ directory)
-		vcsPath := filepath.Join(current, "vcs")
-		if info, err := os.Stat(vcsPath); err == nil && info.IsDir() {
-			// Check if vcs contains a git repo
-			gitPath := filepath.Join(vcsPath, ".git")
-			if _, err := os.Stat(gitPath); err == nil {
-				return current, nil
+	markers := probeRootMarkers(dirs)
+	for i, current := range dirs {
+		// Check for .glide.yml file (indicates a Glide project)
+		if markers[i].glideConfig {
+			if root, ok := applyRootOverride(current, workingDir); ok {
+				return root, RootFromOverride, nil
+			}
+			if root, source, ok := enclosingMultiWorktreeRoot(current); ok {
+				return root, source, nil
 			}
+			return current, RootFromConfig, nil
 		}
 
-		// Check for single-repo structure (has .git in current)
-		gitPath := filepath.Join(current, ".git")
-		if _, err := os.Stat(gitPath); err == nil {
+		// Check for multi-worktree structure (vcs/ directory holding a git repo)
+		if markers[i].vcsRepo {
+			return current, RootFromMultiWorktree, nil
+		}
+
+		// Check for single-repo structure (has .git in current). A submodule
+		// checkout also has one, but belongs to the repository above it.
+		if markers[i].git && !markers[i].submoduleGit {
 			// Make sure this isn't inside vcs/ or worktrees/
 			if !strings.Contains(current, "/vcs") && !strings.Contains(current, "/worktrees/") {
-				return current, nil
+				return current, RootFromGit, nil
 			}
 		}
 
@@ -86,7 +105,7 @@ func (f *StandardProjectRootFinder) FindRoot(workingDir string) (string, error)
 			// Find the project root (should be two levels up from worktrees/*/
 			parts := strings.Split(current, "/worktrees/")
 			if len(parts) > 0 {
-				return parts[0], nil
+				return parts[0], RootFromWorktreePath, nil
 			}
 		}
 
@@ -95,20 +114,12 @@ func (f *StandardProjectRootFinder) FindRoot(workingDir string) (string, error)
 			// Project root should be one level up
 			parts := strings.Split(current, "/vcs")
 			if len(parts) > 0 {
-				return parts[0], nil
+				return parts[0], RootFromVCSPath, nil
 			}
 		}
-
-		// Move up one directory
-		parent := filepath.Dir(current)
-		if parent == current {
-			break // Reached filesystem root
-		}
-		current = parent
-		traversed++
 	}
 
-	return "", ErrProjectRootNotFound
+	return "", RootSourceUnknown, ErrProjectRootNotFound
 }
 
 // StandardDevelopmentModeDetector implements standard mode detection
@@ -53,9 +53,10 @@ type ContainerStatus struct {
 // ProjectContext contains all context information about the current project
 type ProjectContext struct {
 	// Core paths
-	WorkingDir  string // Current working directory
-	ProjectRoot string // Project root directory
-	ProjectName string // Name of the project from config
+	WorkingDir  string     // Current working directory
+	ProjectRoot string     // Project root directory
+	RootSource  RootSource // What identified the project root, for debugging
+	ProjectName string     // Name of the project from config
 
 	// Development mode and location
 	DevelopmentMode DevelopmentMode // multi-worktree or single-repo
@@ -67,10 +68,17 @@ type ProjectContext struct {
 	IsWorktree   bool   // True if in worktrees/*/ (multi-worktree only)
 	WorktreeName string // Name of current worktree if applicable
 
-	// Plugin extensions
-	Extensions map[string]interface{} // Plugin-provided context extensions
+	// Git submodules and subtrees of the current repository
+	Modules       []Module // Submodules and subtrees, see RepositoryDir
+	CurrentModule string   // Name of the module containing the working directory, if any
 
-	// Docker configuration (DEPRECATED: Use Extensions["docker"] instead)
+	// Plugin extensions, keyed by extension name
+	//
+	// Deprecated: read and write extensions with GetExtension and
+	// SetExtension, which check the data against its registered schema.
+	Extensions map[string]interface{}
+
+	// Docker configuration (DEPRECATED: Use GetExtension[DockerExtension] instead)
 	ComposeFiles     []string                   // Resolved docker-compose files
 	ComposeOverride  string                     // Path to override file
 	DockerRunning    bool                       // Is Docker daemon running
@@ -87,6 +95,9 @@ type ProjectContext struct {
 
 	// Error if context detection failed
 	Error error
+
+	// dockerCheckDeferred marks a Docker status check left for EnsureDockerStatus
+	dockerCheckDeferred bool
 }
 
 // IsValid returns true if the context was successfully detected
@@ -116,9 +127,10 @@ func (c *ProjectContext) GetComposeCommand() []string {
 // GetDockerContext retrieves Docker-specific context data
 // This is a helper method that accesses the Docker extension data
 // Returns nil if Docker extension is not present
-func (c *ProjectContext) GetDockerContext() interface{} {
-	if c.Extensions == nil {
+func (c *ProjectContext) GetDockerContext() *DockerExtension {
+	docker, ok := GetExtension[DockerExtension](c, DockerExtensionName)
+	if !ok {
 		return nil
 	}
-	return c.Extensions["docker"]
+	return &docker
 }
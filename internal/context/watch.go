@@ -0,0 +1,213 @@
+package context
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WatchEventType is what a watch event reports
+type WatchEventType string
+
+const (
+	// WatchStarted carries the context when watching begins
+	WatchStarted WatchEventType = "started"
+	// BranchChanged reports a checkout of another branch or commit
+	BranchChanged WatchEventType = "branch_changed"
+	// ComposeChanged reports compose files that were edited, added, or
+	// removed
+	ComposeChanged WatchEventType = "compose_changed"
+	// DockerChanged reports the Docker daemon starting or stopping
+	DockerChanged WatchEventType = "docker_changed"
+)
+
+// WatchState is the part of a project's context a Watcher follows
+type WatchState struct {
+	ProjectRoot   string   `json:"project_root"`
+	Branch        string   `json:"branch,omitempty"`
+	ComposeFiles  []string `json:"compose_files,omitempty"`
+	DockerRunning bool     `json:"docker_running"`
+
+	// composeStamp changes whenever a compose file is edited
+	composeStamp string
+}
+
+// WatchEvent is a change to the context, carrying the context after it
+type WatchEvent struct {
+	Type WatchEventType `json:"type"`
+	Time time.Time      `json:"time"`
+	// Detail says what changed, e.g. "main -> feature/login"
+	Detail  string     `json:"detail,omitempty"`
+	Context WatchState `json:"context"`
+}
+
+// Watcher polls a project's context and reports changes as events
+type Watcher struct {
+	// Interval is how often the branch and compose files are checked
+	Interval time.Duration
+	// DockerInterval is how often the Docker daemon is checked, which
+	// takes longer
+	DockerInterval time.Duration
+	// Debounce is how long a change must last before it is reported, so
+	// a burst of edits or a rebase yields one event
+	Debounce time.Duration
+
+	ctx *ProjectContext
+
+	// Probes, replaced in tests
+	branch  func(dir string) string
+	compose func(ctx *ProjectContext) []string
+	docker  func() bool
+	now     func() time.Time
+}
+
+// NewWatcher returns a watcher of a detected project context
+func NewWatcher(ctx *ProjectContext) *Watcher {
+	resolver := NewStandardComposeFileResolver()
+	return &Watcher{
+		Interval:       500 * time.Millisecond,
+		DockerInterval: 5 * time.Second,
+		Debounce:       time.Second,
+		ctx:            ctx,
+		branch:         gitBranch,
+		compose: func(ctx *ProjectContext) []string {
+			probe := *ctx
+			if files := resolver.ResolveFiles(&probe); len(files) > 0 {
+				return files
+			}
+			return ctx.ComposeFiles
+		},
+		docker: func() bool { return exec.Command("docker", "info").Run() == nil },
+		now:    time.Now,
+	}
+}
+
+// Watch reports the current context as a WatchStarted event, then every
+// change to it, until ctx is done
+func (w *Watcher) Watch(ctx context.Context, emit func(WatchEvent)) error {
+	var (
+		lastDockerCheck time.Time
+		dockerRunning   bool
+	)
+	probe := func() WatchState {
+		if now := w.now(); now.Sub(lastDockerCheck) >= w.DockerInterval {
+			dockerRunning, lastDockerCheck = w.docker(), now
+		}
+		return w.probe(dockerRunning)
+	}
+
+	emitted := probe()
+	emit(WatchEvent{Type: WatchStarted, Time: w.now(), Context: emitted})
+
+	// seen is the latest state, which is reported once it has not
+	// changed for Debounce
+	seen, changedAt := emitted, w.now()
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current := probe()
+		if !current.equal(seen) {
+			seen, changedAt = current, w.now()
+		}
+		if seen.equal(emitted) || w.now().Sub(changedAt) < w.Debounce {
+			continue
+		}
+		for _, event := range diffWatchStates(emitted, seen) {
+			event.Time = w.now()
+			emit(event)
+		}
+		emitted = seen
+	}
+}
+
+// probe reads the followed context
+func (w *Watcher) probe(dockerRunning bool) WatchState {
+	files := w.compose(w.ctx)
+	return WatchState{
+		ProjectRoot:   w.ctx.ProjectRoot,
+		Branch:        w.branch(w.ctx.WorkingDir),
+		ComposeFiles:  files,
+		DockerRunning: dockerRunning,
+		composeStamp:  fileStamp(files),
+	}
+}
+
+// equal reports whether two states are the same
+func (s WatchState) equal(other WatchState) bool {
+	return s.Branch == other.Branch &&
+		s.DockerRunning == other.DockerRunning &&
+		s.composeStamp == other.composeStamp &&
+		slices.Equal(s.ComposeFiles, other.ComposeFiles)
+}
+
+// diffWatchStates returns an event for every kind of change from old to
+// updated
+func diffWatchStates(old, updated WatchState) []WatchEvent {
+	var events []WatchEvent
+	if old.Branch != updated.Branch {
+		events = append(events, WatchEvent{
+			Type:    BranchChanged,
+			Detail:  fmt.Sprintf("%s -> %s", orNone(old.Branch), orNone(updated.Branch)),
+			Context: updated,
+		})
+	}
+	if old.composeStamp != updated.composeStamp || !slices.Equal(old.ComposeFiles, updated.ComposeFiles) {
+		events = append(events, WatchEvent{
+			Type:    ComposeChanged,
+			Detail:  strings.Join(updated.ComposeFiles, ", "),
+			Context: updated,
+		})
+	}
+	if old.DockerRunning != updated.DockerRunning {
+		detail := "stopped"
+		if updated.DockerRunning {
+			detail = "running"
+		}
+		events = append(events, WatchEvent{Type: DockerChanged, Detail: detail, Context: updated})
+	}
+	return events
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// fileStamp fingerprints files by size and modification time, so an edit
+// to any of them changes it
+func fileStamp(files []string) string {
+	var b strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			fmt.Fprintf(&b, "%s:missing;", file)
+			continue
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", file, info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String()
+}
+
+// gitBranch returns the branch checked out in dir, the short commit when
+// HEAD is detached, or "" outside a git repository
+func gitBranch(dir string) string {
+	if out, err := exec.Command("git", "-C", dir, "symbolic-ref", "--short", "-q", "HEAD").Output(); err == nil {
+		return strings.TrimSpace(string(out))
+	}
+	if out, err := exec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD").Output(); err == nil {
+		return strings.TrimSpace(string(out))
+	}
+	return ""
+}
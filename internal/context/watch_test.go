@@ -0,0 +1,110 @@
+package context
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWatcher watches a project whose branch and Docker status the test sets
+type fakeWatcher struct {
+	*Watcher
+	mu     sync.Mutex
+	branch string
+	docker bool
+}
+
+func newFakeWatcher(t *testing.T, composeFile string) *fakeWatcher {
+	f := &fakeWatcher{branch: "main", docker: true}
+	f.Watcher = NewWatcher(&ProjectContext{ProjectRoot: t.TempDir()})
+	f.Interval = 5 * time.Millisecond
+	f.DockerInterval = 0
+	f.Debounce = 30 * time.Millisecond
+	f.Watcher.branch = func(string) string { f.mu.Lock(); defer f.mu.Unlock(); return f.branch }
+	f.Watcher.docker = func() bool { f.mu.Lock(); defer f.mu.Unlock(); return f.docker }
+	f.compose = func(*ProjectContext) []string { return []string{composeFile} }
+	return f
+}
+
+func (f *fakeWatcher) set(branch string, docker bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.branch, f.docker = branch, docker
+}
+
+// watch runs the watcher until stopped, collecting its events
+func (f *fakeWatcher) watch(t *testing.T) (events func() []WatchEvent, stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	var collected []WatchEvent
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = f.Watch(ctx, func(e WatchEvent) {
+			mu.Lock()
+			collected = append(collected, e)
+			mu.Unlock()
+		})
+	}()
+	t.Cleanup(func() { cancel(); <-done })
+
+	return func() []WatchEvent {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]WatchEvent(nil), collected...)
+		}, func() {
+			cancel()
+			<-done
+		}
+}
+
+func eventTypes(events []WatchEvent) []WatchEventType {
+	var types []WatchEventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestWatcher(t *testing.T) {
+	compose := filepath.Join(t.TempDir(), "docker-compose.yml")
+	require.NoError(t, os.WriteFile(compose, []byte("services: {}\n"), 0o644))
+
+	f := newFakeWatcher(t, compose)
+	events, _ := f.watch(t)
+
+	require.Eventually(t, func() bool { return len(events()) == 1 }, time.Second, 5*time.Millisecond)
+	started := events()[0]
+	assert.Equal(t, WatchStarted, started.Type)
+	assert.Equal(t, "main", started.Context.Branch)
+	assert.True(t, started.Context.DockerRunning)
+
+	f.set("feature/login", false)
+	require.NoError(t, os.WriteFile(compose, []byte("services:\n  web: {}\n"), 0o644))
+	require.Eventually(t, func() bool { return len(events()) == 4 }, time.Second, 5*time.Millisecond)
+
+	got := events()[1:]
+	assert.Equal(t, []WatchEventType{BranchChanged, ComposeChanged, DockerChanged}, eventTypes(got))
+	assert.Equal(t, "main -> feature/login", got[0].Detail)
+	assert.Equal(t, "stopped", got[2].Detail)
+	assert.Equal(t, "feature/login", got[2].Context.Branch)
+}
+
+func TestWatcher_Debounce(t *testing.T) {
+	f := newFakeWatcher(t, filepath.Join(t.TempDir(), "missing.yml"))
+	f.Debounce = time.Hour
+	events, stop := f.watch(t)
+
+	require.Eventually(t, func() bool { return len(events()) == 1 }, time.Second, 5*time.Millisecond)
+	f.set("other", true)
+	time.Sleep(50 * time.Millisecond)
+	stop()
+
+	assert.Equal(t, []WatchEventType{WatchStarted}, eventTypes(events()), "changes shorter than the debounce are not reported")
+}
@@ -1,12 +1,23 @@
 package detection
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DetectionTimeout bounds a whole detection run; detectors that have not
+	// answered by then are skipped
+	DetectionTimeout = 100 * time.Millisecond
+
+	// maxWorkers bounds the detectors running at once
+	maxWorkers = 8
 )
 
 // FrameworkDetector aggregates all plugin detections
@@ -45,57 +56,57 @@ func (fd *FrameworkDetector) RegisterDetector(d sdk.FrameworkDetector) {
 	fd.detectors = append(fd.detectors, d)
 }
 
-// DetectFrameworks runs all plugin detections in parallel
+// DetectFrameworks runs all plugin detections in parallel, under a shared
+// deadline
 func (fd *FrameworkDetector) DetectFrameworks(projectPath string) ([]FrameworkResult, error) {
 	// Check cache first
 	if cached := fd.getFromCache(projectPath); cached != nil {
 		return fd.convertToFrameworkResults(cached), nil
 	}
 
-	results := make(chan FrameworkResult, len(fd.detectors))
-	var wg sync.WaitGroup
-
-	// Parallel detection with timeout
-	timeout := 100 * time.Millisecond
-	for i, d := range fd.detectors {
-		wg.Add(1)
-		go func(detector sdk.FrameworkDetector, priority int) {
-			defer wg.Done()
-
+	fd.mu.RLock()
+	detectors := append([]sdk.FrameworkDetector(nil), fd.detectors...)
+	fd.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), DetectionTimeout)
+	defer cancel()
+
+	// One slot per detector keeps results in registration order
+	found := make([]*sdk.DetectionResult, len(detectors))
+	var g errgroup.Group
+	g.SetLimit(maxWorkers)
+	for i, d := range detectors {
+		g.Go(func() error {
 			done := make(chan *sdk.DetectionResult, 1)
 			go func() {
-				result, err := detector.Detect(projectPath)
-				if err == nil && result != nil {
-					done <- result
+				result, err := d.Detect(projectPath)
+				if err != nil {
+					result = nil
 				}
-				close(done)
+				done <- result
 			}()
 
 			select {
 			case result := <-done:
-				if result != nil && result.Detected {
-					results <- FrameworkResult{
-						DetectionResult: *result,
-						PluginName:      fmt.Sprintf("detector-%d", priority),
-						Priority:        priority,
-					}
-				}
-			case <-time.After(timeout):
-				// Timeout, skip this detector
+				found[i] = result
+			case <-ctx.Done():
+				// Past the deadline, skip this detector
 			}
-		}(d, i)
+			return nil
+		})
 	}
+	// Safe to ignore: failed detectors are skipped above
+	_ = g.Wait()
 
-	// Wait for all detections to complete
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	// Collect results
 	var frameworks []FrameworkResult
-	for result := range results {
-		frameworks = append(frameworks, result)
+	for priority, result := range found {
+		if result != nil && result.Detected {
+			frameworks = append(frameworks, FrameworkResult{
+				DetectionResult: *result,
+				PluginName:      fmt.Sprintf("detector-%d", priority),
+				Priority:        priority,
+			})
+		}
 	}
 
 	// Resolve conflicts and cache
@@ -181,6 +181,24 @@ func TestFrameworkDetector(t *testing.T) {
 		assert.Equal(t, "fast", results[0].Framework.Name)
 	})
 
+	t.Run("deadline is shared by all detectors", func(t *testing.T) {
+		detector := NewFrameworkDetector()
+
+		// More slow detectors than workers must not stretch the deadline
+		for i := 0; i < maxWorkers*2; i++ {
+			detector.RegisterDetector(&MockFrameworkDetector{
+				result: &sdk.DetectionResult{Detected: true, Framework: sdk.FrameworkInfo{Name: "slow"}},
+				delay:  time.Second,
+			})
+		}
+
+		start := time.Now()
+		results, err := detector.DetectFrameworks("/test/path")
+		require.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Less(t, time.Since(start), 3*DetectionTimeout)
+	})
+
 	t.Run("caching detection results", func(t *testing.T) {
 		detector := NewFrameworkDetector()
 
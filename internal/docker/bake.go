@@ -0,0 +1,153 @@
+package docker
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// BakeFile is a buildx bake definition in its JSON form
+type BakeFile struct {
+	Group  map[string]BakeGroup  `json:"group"`
+	Target map[string]BakeTarget `json:"target"`
+}
+
+// BakeGroup lists targets built together
+type BakeGroup struct {
+	Targets []string `json:"targets"`
+}
+
+// BakeTarget is a single image build
+type BakeTarget struct {
+	Context    string            `json:"context"`
+	Dockerfile string            `json:"dockerfile,omitempty"`
+	Target     string            `json:"target,omitempty"`
+	Args       map[string]string `json:"args,omitempty"`
+	Tags       []string          `json:"tags"`
+	CacheFrom  []string          `json:"cache-from,omitempty"`
+	CacheTo    []string          `json:"cache-to,omitempty"`
+}
+
+// BakeOptions configures how a project's images are baked
+type BakeOptions struct {
+	// CacheFrom and CacheTo are applied to every target, e.g.
+	// "type=registry,ref=ghcr.io/acme/app:cache"
+	CacheFrom []string
+	CacheTo   []string
+	NoCache   bool
+	Pull      bool
+}
+
+// BakeMetadata is the per-target result buildx writes with --metadata-file
+type BakeMetadata struct {
+	Digest    string `json:"containerimage.digest"`
+	ImageName string `json:"image.name"`
+}
+
+// ImageName returns the image a service is built as: its image field, or
+// the name compose gives it by default
+func (p *ComposeProject) ImageName(service string) string {
+	if image := p.Services[service].Image; image != "" {
+		return image
+	}
+	return p.Name + "-" + service
+}
+
+// BakeFile returns a bake definition building the given services, with the
+// shared cache settings applied to each target. Services without a build
+// section are ignored.
+func (p *ComposeProject) BakeFile(services []string, opts BakeOptions) BakeFile {
+	file := BakeFile{
+		Group:  map[string]BakeGroup{"default": {Targets: []string{}}},
+		Target: make(map[string]BakeTarget),
+	}
+
+	for _, name := range services {
+		service, ok := p.Services[name]
+		if !ok || service.Build == nil {
+			continue
+		}
+
+		file.Target[name] = BakeTarget{
+			Context:    service.Build.Context,
+			Dockerfile: service.Build.Dockerfile,
+			Target:     service.Build.Target,
+			Args:       service.Build.Args,
+			Tags:       []string{p.ImageName(name)},
+			CacheFrom:  opts.CacheFrom,
+			CacheTo:    opts.CacheTo,
+		}
+	}
+
+	targets := make([]string, 0, len(file.Target))
+	for name := range file.Target {
+		targets = append(targets, name)
+	}
+	sort.Strings(targets)
+	file.Group["default"] = BakeGroup{Targets: targets}
+
+	return file
+}
+
+// runBake runs `docker buildx bake` and is replaced in tests
+var runBake = func(cmd *exec.Cmd) error {
+	return cmd.Run()
+}
+
+// Bake builds the given targets of a bake file in one `docker buildx bake`
+// invocation, loading the images into the local image store. It returns the
+// metadata buildx reports for each target.
+func Bake(dir string, file BakeFile, targets []string, opts BakeOptions) (map[string]BakeMetadata, error) {
+	tmp, err := os.MkdirTemp("", "glide-bake-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	bakeFile := filepath.Join(tmp, "docker-bake.json")
+	if err := os.WriteFile(bakeFile, data, 0600); err != nil {
+		return nil, err
+	}
+	metadataFile := filepath.Join(tmp, "metadata.json")
+
+	args := []string{"buildx", "bake", "--file", bakeFile, "--metadata-file", metadataFile, "--load"}
+	if opts.NoCache {
+		args = append(args, "--no-cache")
+	}
+	if opts.Pull {
+		args = append(args, "--pull")
+	}
+	args = append(args, targets...)
+
+	cmd := exec.Command("docker", args...)
+	cmd.Dir = dir
+	// Build progress goes to stderr so structured output on stdout stays clean
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := runBake(cmd); err != nil {
+		return nil, glideErrors.NewDockerError("docker buildx bake failed",
+			glideErrors.WithError(err),
+			glideErrors.WithContext("targets", strings.Join(targets, ",")),
+			glideErrors.WithSuggestions(
+				"Check that buildx is installed: docker buildx version",
+				"Re-run a single service to see the full build log: docker compose build <service>",
+			),
+		)
+	}
+
+	metadata := make(map[string]BakeMetadata)
+	if data, err := os.ReadFile(metadataFile); err == nil {
+		// Safe to ignore: metadata only enriches the results with digests
+		_ = json.Unmarshal(data, &metadata)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"testing"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bakeTestProject() *ComposeProject {
+	return &ComposeProject{
+		Name: "myapp",
+		Services: map[string]ComposeService{
+			"php": {
+				Image: "acme/php:dev",
+				Build: &ComposeBuild{Context: "/src/myapp", Dockerfile: "docker/php/Dockerfile", Target: "dev", Args: map[string]string{"PHP_VERSION": "8.3"}},
+			},
+			"worker": {Build: &ComposeBuild{Context: "/src/myapp/worker"}},
+			"mysql":  {Image: "mysql:8"},
+		},
+	}
+}
+
+func TestComposeProject_ImageName(t *testing.T) {
+	project := bakeTestProject()
+	assert.Equal(t, "acme/php:dev", project.ImageName("php"))
+	assert.Equal(t, "myapp-worker", project.ImageName("worker"))
+}
+
+func TestComposeProject_BakeFile(t *testing.T) {
+	project := bakeTestProject()
+	opts := BakeOptions{
+		CacheFrom: []string{"type=registry,ref=ghcr.io/acme/cache"},
+		CacheTo:   []string{"type=registry,ref=ghcr.io/acme/cache,mode=max"},
+	}
+
+	file := project.BakeFile([]string{"worker", "php", "mysql"}, opts)
+
+	assert.Equal(t, []string{"php", "worker"}, file.Group["default"].Targets, "services without a build section are skipped")
+	require.Contains(t, file.Target, "php")
+	assert.Equal(t, BakeTarget{
+		Context:    "/src/myapp",
+		Dockerfile: "docker/php/Dockerfile",
+		Target:     "dev",
+		Args:       map[string]string{"PHP_VERSION": "8.3"},
+		Tags:       []string{"acme/php:dev"},
+		CacheFrom:  opts.CacheFrom,
+		CacheTo:    opts.CacheTo,
+	}, file.Target["php"])
+	assert.Equal(t, []string{"myapp-worker"}, file.Target["worker"].Tags)
+
+	data, err := json.Marshal(file.Target["worker"])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"cache-from"`)
+}
+
+func TestBake(t *testing.T) {
+	project := bakeTestProject()
+	file := project.BakeFile(project.BuildServices(), BakeOptions{})
+
+	var gotArgs []string
+	var gotFile BakeFile
+	original := runBake
+	runBake = func(cmd *exec.Cmd) error {
+		gotArgs = cmd.Args[1:]
+
+		data, err := os.ReadFile(cmd.Args[4])
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &gotFile))
+
+		metadata := `{"php": {"containerimage.digest": "sha256:abc", "image.name": "acme/php:dev"}, "buildx.build.warnings": []}`
+		return os.WriteFile(cmd.Args[6], []byte(metadata), 0600)
+	}
+	defer func() { runBake = original }()
+
+	metadata, err := Bake("/src/myapp", file, []string{"php"}, BakeOptions{NoCache: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "buildx", gotArgs[0])
+	assert.Equal(t, "bake", gotArgs[1])
+	assert.Equal(t, []string{"--load", "--no-cache", "php"}, gotArgs[6:])
+	assert.Equal(t, file, gotFile)
+	assert.Equal(t, "sha256:abc", metadata["php"].Digest)
+}
+
+func TestBake_Failure(t *testing.T) {
+	original := runBake
+	runBake = func(cmd *exec.Cmd) error { return &exec.ExitError{} }
+	defer func() { runBake = original }()
+
+	_, err := Bake("/src/myapp", BakeFile{}, []string{"php"}, BakeOptions{})
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeDocker))
+}
@@ -0,0 +1,194 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+	"sort"
+	"strings"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// ComposeProject is the fully resolved compose model for a directory, as
+// reported by `docker compose config`
+type ComposeProject struct {
+	Name     string                    `json:"name"`
+	Services map[string]ComposeService `json:"services"`
+	Volumes  map[string]ComposeVolume  `json:"volumes"`
+	Networks map[string]ComposeNetwork `json:"networks"`
+
+	// Dir is the directory compose was resolved in
+	Dir string `json:"-"`
+}
+
+// ComposeService is a single service in a resolved compose project
+type ComposeService struct {
+	Image    string              `json:"image,omitempty"`
+	Build    *ComposeBuild       `json:"build,omitempty"`
+	Profiles []string            `json:"profiles,omitempty"`
+	Volumes  []ComposeMount      `json:"volumes,omitempty"`
+	Labels   map[string]string   `json:"labels,omitempty"`
+	Ports    []ComposePortConfig `json:"ports,omitempty"`
+	// Expose lists ports reachable from other services only, e.g. "9000"
+	Expose []string `json:"expose,omitempty"`
+	// DependsOn are the services started before this one, by name
+	DependsOn map[string]ComposeDependency `json:"depends_on,omitempty"`
+	// Networks are the networks the service joins, by the project's key
+	Networks map[string]any `json:"networks,omitempty"`
+}
+
+// ComposeDependency is a dependency of a service on another
+type ComposeDependency struct {
+	Condition string `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Restart   bool   `json:"restart,omitempty" yaml:"restart,omitempty"`
+	Required  *bool  `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// ComposeBuild is the build section of a service
+type ComposeBuild struct {
+	Context    string            `json:"context"`
+	Dockerfile string            `json:"dockerfile,omitempty"`
+	Target     string            `json:"target,omitempty"`
+	Args       map[string]string `json:"args,omitempty"`
+}
+
+// ComposeMount is a volume or bind mount attached to a service
+type ComposeMount struct {
+	Type     string `json:"type"`
+	Source   string `json:"source,omitempty"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"read_only,omitempty"`
+}
+
+// ComposePortConfig is a published port of a service
+type ComposePortConfig struct {
+	Target    int    `json:"target"`
+	Published string `json:"published,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+}
+
+// ComposeVolume is a named volume declared by the project
+type ComposeVolume struct {
+	Name     string `json:"name"`
+	External bool   `json:"external,omitempty"`
+}
+
+// ComposeNetwork is a network declared by the project, including the
+// implicit default network
+type ComposeNetwork struct {
+	Name     string `json:"name"`
+	External bool   `json:"external,omitempty"`
+}
+
+// composeConfigOutput runs `docker compose config` and is replaced in tests
+var composeConfigOutput = func(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("docker", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, glideErrors.NewDockerError("failed to resolve the compose project",
+			glideErrors.WithError(err),
+			glideErrors.WithContext("dir", dir),
+			glideErrors.WithContext("output", strings.TrimSpace(stderr.String())),
+			glideErrors.WithSuggestions(
+				"Check that a compose file exists in this directory",
+				"Validate it with: docker compose config",
+			),
+		)
+	}
+	return out, nil
+}
+
+// LoadComposeProject resolves the compose project in dir. Services behind
+// profiles are only included when their profile is listed; pass "*" to
+// include every service.
+func LoadComposeProject(dir string, profiles ...string) (*ComposeProject, error) {
+	args := []string{"compose"}
+	for _, profile := range profiles {
+		args = append(args, "--profile", profile)
+	}
+	args = append(args, "config", "--format", "json")
+
+	out, err := composeConfigOutput(dir, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var project ComposeProject
+	if err := json.Unmarshal(out, &project); err != nil {
+		return nil, glideErrors.NewDockerError("failed to parse compose configuration",
+			glideErrors.WithError(err),
+			glideErrors.WithContext("dir", dir),
+		)
+	}
+	project.Dir = dir
+
+	return &project, nil
+}
+
+// ServiceNames returns the project's services in sorted order
+func (p *ComposeProject) ServiceNames() []string {
+	names := make([]string, 0, len(p.Services))
+	for name := range p.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// VolumeNames returns the Docker names of the project's own (non-external)
+// volumes in sorted order
+func (p *ComposeProject) VolumeNames() []string {
+	var names []string
+	for key, volume := range p.Volumes {
+		if volume.External {
+			continue
+		}
+		name := volume.Name
+		if name == "" {
+			name = p.Name + "_" + key
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ComposeArgs returns the arguments for running a compose subcommand
+// against this project, e.g. ComposeArgs("exec", "-T", "db") for use with
+// exec.Command("docker", ...)
+func (p *ComposeProject) ComposeArgs(args ...string) []string {
+	return append([]string{"compose", "--project-name", p.Name}, args...)
+}
+
+// Images returns the images the project pulls, in sorted order. Services
+// with a build section are skipped, since their image is built locally.
+func (p *ComposeProject) Images() []string {
+	seen := make(map[string]bool)
+	var images []string
+	for _, service := range p.Services {
+		if service.Build != nil || service.Image == "" || seen[service.Image] {
+			continue
+		}
+		seen[service.Image] = true
+		images = append(images, service.Image)
+	}
+	sort.Strings(images)
+	return images
+}
+
+// BuildServices returns the services built from a Dockerfile, in sorted order
+func (p *ComposeProject) BuildServices() []string {
+	var names []string
+	for name, service := range p.Services {
+		if service.Build != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,111 @@
+package docker
+
+import (
+	"testing"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleComposeConfig = `{
+  "name": "myapp",
+  "services": {
+    "php": {
+      "build": {"context": "/src/myapp", "dockerfile": "docker/php/Dockerfile"},
+      "ports": [{"target": 80, "published": "8080", "protocol": "tcp"}],
+      "volumes": [{"type": "volume", "source": "vendor", "target": "/app/vendor"}]
+    },
+    "mysql": {
+      "image": "mysql:8",
+      "labels": {"com.example.role": "database"}
+    },
+    "mailpit": {
+      "image": "axllent/mailpit",
+      "profiles": ["debug"]
+    }
+  },
+  "volumes": {
+    "db-data": {"name": "myapp_db-data"},
+    "vendor": {},
+    "shared": {"name": "shared-cache", "external": true}
+  }
+}`
+
+func stubComposeConfig(t *testing.T, out string, err error) *[]string {
+	t.Helper()
+
+	var gotArgs []string
+	original := composeConfigOutput
+	composeConfigOutput = func(dir string, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(out), err
+	}
+	t.Cleanup(func() { composeConfigOutput = original })
+	return &gotArgs
+}
+
+func TestLoadComposeProject(t *testing.T) {
+	args := stubComposeConfig(t, sampleComposeConfig, nil)
+
+	project, err := LoadComposeProject("/src/myapp", "debug")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"compose", "--profile", "debug", "config", "--format", "json"}, *args)
+	assert.Equal(t, "myapp", project.Name)
+	assert.Equal(t, "/src/myapp", project.Dir)
+	assert.Equal(t, []string{"mailpit", "mysql", "php"}, project.ServiceNames())
+
+	php := project.Services["php"]
+	require.NotNil(t, php.Build)
+	assert.Equal(t, "docker/php/Dockerfile", php.Build.Dockerfile)
+	assert.Equal(t, "8080", php.Ports[0].Published)
+	assert.Equal(t, []string{"debug"}, project.Services["mailpit"].Profiles)
+}
+
+func TestLoadComposeProject_Errors(t *testing.T) {
+	t.Run("command failure", func(t *testing.T) {
+		stubComposeConfig(t, "", glideErrors.NewDockerError("boom"))
+		_, err := LoadComposeProject("/src/myapp")
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeDocker))
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		stubComposeConfig(t, "not json", nil)
+		_, err := LoadComposeProject("/src/myapp")
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeDocker))
+	})
+}
+
+func TestComposeProject_VolumeNames(t *testing.T) {
+	stubComposeConfig(t, sampleComposeConfig, nil)
+
+	project, err := LoadComposeProject("/src/myapp")
+	require.NoError(t, err)
+
+	// External volumes are skipped; unnamed volumes get the project prefix
+	assert.Equal(t, []string{"myapp_db-data", "myapp_vendor"}, project.VolumeNames())
+}
+
+func TestComposeProject_ComposeArgs(t *testing.T) {
+	project := &ComposeProject{Name: "myapp"}
+	assert.Equal(t,
+		[]string{"compose", "--project-name", "myapp", "exec", "-T", "mysql"},
+		project.ComposeArgs("exec", "-T", "mysql"),
+	)
+}
+
+func TestComposeProject_ImagesAndBuildServices(t *testing.T) {
+	project := &ComposeProject{
+		Services: map[string]ComposeService{
+			"php":     {Image: "myapp-php", Build: &ComposeBuild{Context: "."}},
+			"mysql":   {Image: "mysql:8"},
+			"replica": {Image: "mysql:8"},
+			"redis":   {Image: "redis:7"},
+			"worker":  {Build: &ComposeBuild{Context: "./worker"}},
+		},
+	}
+
+	assert.Equal(t, []string{"mysql:8", "redis:7"}, project.Images(), "built images are not pulled and duplicates are removed")
+	assert.Equal(t, []string{"php", "worker"}, project.BuildServices())
+}
@@ -0,0 +1,45 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultComposeFiles are the files compose looks for, in order
+var defaultComposeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// ComposeFiles returns the absolute paths of the compose files compose loads
+// for dir: the COMPOSE_FILE list when set, otherwise the first default file
+// found and its override file. It returns nil when dir has no compose file.
+func ComposeFiles(dir string) []string {
+	if env := os.Getenv("COMPOSE_FILE"); env != "" {
+		var files []string
+		for _, file := range filepath.SplitList(env) {
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(dir, file)
+			}
+			files = append(files, file)
+		}
+		return files
+	}
+
+	for _, name := range defaultComposeFiles {
+		base := filepath.Join(dir, name)
+		if _, err := os.Stat(base); err != nil {
+			continue
+		}
+		files := []string{base}
+
+		stem := strings.TrimSuffix(name, filepath.Ext(name))
+		for _, ext := range []string{".yaml", ".yml"} {
+			override := filepath.Join(dir, stem+".override"+ext)
+			if _, err := os.Stat(override); err == nil {
+				files = append(files, override)
+				break
+			}
+		}
+		return files
+	}
+	return nil
+}
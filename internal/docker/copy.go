@@ -0,0 +1,289 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/validation"
+)
+
+// errCopyStopped ends the side of a copy whose other side finished first
+var errCopyStopped = errors.New("copy stopped")
+
+// dockerStream runs a docker command reading stdin from in and writing
+// stdout to out; it is replaced in tests
+var dockerStream = func(in io.Reader, out io.Writer, args ...string) error {
+	cmd := exec.Command("docker", args...)
+	cmd.Stdin = in
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return glideErrors.NewDockerError(fmt.Sprintf("docker %s failed", args[0]), glideErrors.WithError(err))
+	}
+
+	// A reader that gives up must not leave docker blocked on a full pipe
+	if _, err := io.Copy(out, stdout); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return err
+	}
+	if err := cmd.Wait(); err != nil {
+		return glideErrors.NewDockerError(fmt.Sprintf("docker %s failed", args[0]),
+			glideErrors.WithError(err),
+			glideErrors.WithContext("output", strings.TrimSpace(stderr.String())),
+		)
+	}
+	return nil
+}
+
+// CopyFromContainer copies src, a file or directory in container, to dest
+// on the host. When dest is an existing directory or ends in a separator,
+// src is copied into it; otherwise it is copied as dest. progress, if set,
+// is called with the total bytes of file content copied so far.
+//
+// Entries of the archive docker sends are validated, so none can be written
+// outside dest, directly or through a symlink.
+func CopyFromContainer(container, src, dest string, progress func(copied int64)) error {
+	dir, root := dest, ""
+	if info, err := os.Stat(dest); (err != nil || !info.IsDir()) && !strings.HasSuffix(dest, string(filepath.Separator)) {
+		dir, root = filepath.Dir(dest), filepath.Base(dest)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return glideErrors.Wrap(err, "failed to create "+dir)
+	}
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := dockerStream(nil, pw, "cp", container+":"+src, "-")
+		pw.CloseWithError(err)
+		done <- err
+	}()
+
+	extractErr := extractArchive(pr, dir, root, progress)
+	pr.CloseWithError(errCopyStopped)
+	if err := <-done; err != nil && !errors.Is(err, errCopyStopped) {
+		return err
+	}
+	return extractErr
+}
+
+// CopyToContainer copies src, a file or directory on the host, to dest in
+// container. When dest ends in a slash, src is copied into that directory;
+// otherwise it is copied as dest, whose parent directory must exist.
+// progress, if set, is called with the total bytes of file content copied
+// so far.
+func CopyToContainer(container, src, dest string, progress func(copied int64)) error {
+	if _, err := os.Lstat(src); err != nil {
+		return glideErrors.NewFileNotFoundError(src)
+	}
+
+	dir, root := dest, filepath.Base(src)
+	if !strings.HasSuffix(dest, "/") {
+		dir, root = path.Dir(dest), path.Base(dest)
+	}
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := writeArchive(pw, src, root, progress)
+		pw.CloseWithError(err)
+		done <- err
+	}()
+
+	copyErr := dockerStream(pr, io.Discard, "cp", "-", container+":"+dir)
+	pr.CloseWithError(errCopyStopped)
+	if err := <-done; err != nil && !errors.Is(err, errCopyStopped) {
+		return err
+	}
+	return copyErr
+}
+
+// writeArchive writes src to w as a tar archive whose top-level entry is
+// named root. Symlinks are archived as links, not followed.
+func writeArchive(w io.Writer, src, root string, progress func(copied int64)) error {
+	tw := tar.NewWriter(w)
+	var copied int64
+
+	err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(root, filepath.ToSlash(rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		n, err := io.Copy(tw, f)
+		copied += n
+		if progress != nil {
+			progress(copied)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// extractArchive extracts the tar archive r into dir, renaming its
+// top-level entry to root when root is set. Entries that would land
+// outside dir, and symlinks pointing outside it, are rejected with
+// validation.ErrPathTraversal or validation.ErrSymlinkTraversal.
+func extractArchive(r io.Reader, dir, root string, progress func(copied int64)) error {
+	tr := tar.NewReader(r)
+	var copied int64
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name, err := archiveEntryName(header.Name, root)
+		if err != nil {
+			return err
+		}
+		// Links replace whatever is at their name, so only files and
+		// directories are written through an existing symlink
+		isLink := header.Typeflag == tar.TypeSymlink || header.Typeflag == tar.TypeLink
+		target, err := validation.ValidatePath(name, validation.PathValidationOptions{
+			BaseDir:        dir,
+			FollowSymlinks: !isLink,
+		})
+		if err != nil {
+			return err
+		}
+		mode := fs.FileMode(header.Mode).Perm()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			// A symlink left at target, e.g. a dangling one ValidatePath
+			// could not resolve, would be followed by OpenFile
+			if info, err := os.Lstat(target); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+				return fmt.Errorf("%w: %s is a symlink", validation.ErrSymlinkTraversal, name)
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+			if err != nil {
+				return err
+			}
+			n, err := io.Copy(f, tr)
+			copied += n
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+			if progress != nil {
+				progress(copied)
+			}
+		case tar.TypeSymlink:
+			linkTarget := header.Linkname
+			if !filepath.IsAbs(linkTarget) {
+				linkTarget = filepath.Join(filepath.Dir(target), linkTarget)
+			}
+			if _, err := validation.ValidatePath(linkTarget, validation.PathValidationOptions{
+				BaseDir:        dir,
+				AllowAbsolute:  true,
+				FollowSymlinks: true,
+			}); err != nil {
+				return fmt.Errorf("%w: %s links to %s", validation.ErrSymlinkTraversal, name, header.Linkname)
+			}
+			_ = os.Remove(target)
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			linkName, err := archiveEntryName(header.Linkname, root)
+			if err != nil {
+				return err
+			}
+			source, err := validation.ValidatePath(linkName, validation.PathValidationOptions{
+				BaseDir:        dir,
+				FollowSymlinks: true,
+			})
+			if err != nil {
+				return err
+			}
+			_ = os.Remove(target)
+			if err := os.Link(source, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// archiveEntryName cleans an archive entry's name, replacing its first
+// element with root when root is set, and rejects names escaping the
+// archive
+func archiveEntryName(name, root string) (string, error) {
+	clean := path.Clean(name)
+	if path.IsAbs(name) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("%w: archive entry %s", validation.ErrPathTraversal, name)
+	}
+	if root != "" {
+		if i := strings.Index(clean, "/"); i >= 0 {
+			clean = root + clean[i:]
+		} else {
+			clean = root
+		}
+	}
+	return filepath.FromSlash(clean), nil
+}
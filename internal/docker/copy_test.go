@@ -0,0 +1,180 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveEntry is a file, directory (name ending in /), or symlink
+// (linkname set) of a test archive
+type archiveEntry struct {
+	name     string
+	body     string
+	linkname string
+}
+
+func testArchive(t *testing.T, entries ...archiveEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+		switch {
+		case e.linkname != "":
+			header.Typeflag, header.Linkname, header.Size = tar.TypeSymlink, e.linkname, 0
+		case e.name[len(e.name)-1] == '/':
+			header.Typeflag, header.Mode = tar.TypeDir, 0755
+		}
+		require.NoError(t, tw.WriteHeader(header))
+		_, err := tw.Write([]byte(e.body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+// stubDockerStream answers docker commands with archive and records their
+// arguments and stdin
+func stubDockerStream(t *testing.T, archive []byte) (*[]string, *bytes.Buffer) {
+	t.Helper()
+	var args []string
+	var stdin bytes.Buffer
+	original := dockerStream
+	dockerStream = func(in io.Reader, out io.Writer, a ...string) error {
+		args = a
+		if in != nil {
+			if _, err := io.Copy(&stdin, in); err != nil {
+				return err
+			}
+		}
+		_, err := out.Write(archive)
+		return err
+	}
+	t.Cleanup(func() { dockerStream = original })
+	return &args, &stdin
+}
+
+func TestCopyFromContainer(t *testing.T) {
+	archive := testArchive(t,
+		archiveEntry{name: "log/"},
+		archiveEntry{name: "log/app.log", body: "hello"},
+		archiveEntry{name: "log/current", linkname: "app.log"},
+	)
+
+	t.Run("into an existing directory", func(t *testing.T) {
+		dest := t.TempDir()
+		args, _ := stubDockerStream(t, archive)
+
+		var copied int64
+		require.NoError(t, CopyFromContainer("myapp-php-1", "/var/log", dest, func(n int64) { copied = n }))
+
+		assert.Equal(t, []string{"cp", "myapp-php-1:/var/log", "-"}, *args)
+		data, err := os.ReadFile(filepath.Join(dest, "log", "app.log"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		link, err := os.Readlink(filepath.Join(dest, "log", "current"))
+		require.NoError(t, err)
+		assert.Equal(t, "app.log", link)
+		assert.Equal(t, int64(5), copied)
+	})
+
+	t.Run("as a new path", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "logs")
+		stubDockerStream(t, archive)
+
+		require.NoError(t, CopyFromContainer("myapp-php-1", "/var/log", dest, nil))
+		assert.FileExists(t, filepath.Join(dest, "app.log"))
+	})
+}
+
+func TestCopyFromContainer_Traversal(t *testing.T) {
+	for name, archive := range map[string][]byte{
+		"parent entry": testArchive(t, archiveEntry{name: "../evil", body: "x"}),
+		"nested parent entry": testArchive(t,
+			archiveEntry{name: "log/"},
+			archiveEntry{name: "log/../../evil", body: "x"},
+		),
+		"absolute entry": testArchive(t, archiveEntry{name: "/tmp/evil", body: "x"}),
+		"through a symlink": testArchive(t,
+			archiveEntry{name: "log/"},
+			archiveEntry{name: "log/out", linkname: "/tmp"},
+			archiveEntry{name: "log/out/evil", body: "x"},
+		),
+		"through a dangling symlink": testArchive(t,
+			archiveEntry{name: "log/"},
+			archiveEntry{name: "log/evil", linkname: "../../evil"},
+			archiveEntry{name: "log/evil", body: "x"},
+		),
+	} {
+		t.Run(name, func(t *testing.T) {
+			base := t.TempDir()
+			dest := filepath.Join(base, "dest")
+			require.NoError(t, os.Mkdir(dest, 0755))
+			stubDockerStream(t, archive)
+
+			err := CopyFromContainer("myapp-php-1", "/var/log", dest+string(filepath.Separator), nil)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, validation.ErrPathTraversal) || errors.Is(err, validation.ErrSymlinkTraversal), "got %v", err)
+			assert.NoFileExists(t, filepath.Join(base, "evil"))
+		})
+	}
+}
+
+func TestCopyFromContainer_ExistingDanglingSymlink(t *testing.T) {
+	base := t.TempDir()
+	dest := filepath.Join(base, "dest")
+	require.NoError(t, os.MkdirAll(filepath.Join(dest, "log"), 0755))
+	require.NoError(t, os.Symlink(filepath.Join(base, "evil"), filepath.Join(dest, "log", "app.log")))
+	stubDockerStream(t, testArchive(t,
+		archiveEntry{name: "log/"},
+		archiveEntry{name: "log/app.log", body: "x"},
+	))
+
+	err := CopyFromContainer("myapp-php-1", "/var/log", dest+string(filepath.Separator), nil)
+	assert.True(t, errors.Is(err, validation.ErrSymlinkTraversal), "got %v", err)
+	assert.NoFileExists(t, filepath.Join(base, "evil"))
+}
+
+func TestCopyToContainer(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "fixtures")
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("abc"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("de"), 0644))
+
+	names := func(archive []byte) []string {
+		var names []string
+		tr := tar.NewReader(bytes.NewReader(archive))
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				return names
+			}
+			require.NoError(t, err)
+			names = append(names, header.Name)
+		}
+	}
+
+	args, stdin := stubDockerStream(t, nil)
+	var copied int64
+	require.NoError(t, CopyToContainer("myapp-php-1", src, "/tmp/", func(n int64) { copied = n }))
+	assert.Equal(t, []string{"cp", "-", "myapp-php-1:/tmp/"}, *args)
+	assert.Equal(t, []string{"fixtures/", "fixtures/a.txt", "fixtures/sub/", "fixtures/sub/b.txt"}, names(stdin.Bytes()))
+	assert.Equal(t, int64(5), copied)
+
+	args, stdin = stubDockerStream(t, nil)
+	require.NoError(t, CopyToContainer("myapp-php-1", filepath.Join(src, "a.txt"), "/tmp/renamed.txt", nil))
+	assert.Equal(t, []string{"cp", "-", "myapp-php-1:/tmp"}, *args)
+	assert.Equal(t, []string{"renamed.txt"}, names(stdin.Bytes()))
+
+	err := CopyToContainer("myapp-php-1", filepath.Join(src, "missing"), "/tmp/", nil)
+	require.Error(t, err)
+}
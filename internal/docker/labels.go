@@ -0,0 +1,102 @@
+package docker
+
+// Labels glide attaches to the containers, networks, and volumes of the
+// compose projects it runs, so they can be found by owner instead of by
+// naming convention
+const (
+	LabelProject  = "dev.glide.project"
+	LabelWorktree = "dev.glide.worktree"
+	LabelVersion  = "dev.glide.version"
+)
+
+// Ownership identifies the glide project and worktree a resource belongs to
+type Ownership struct {
+	// Project is the project root directory
+	Project string `json:"project" yaml:"project"`
+	// Worktree is the worktree name; the project directory's name outside
+	// multi-worktree mode
+	Worktree string `json:"worktree" yaml:"worktree"`
+	// Version is the glide version that created the resource
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// Labels returns the ownership as resource labels
+func (o Ownership) Labels() map[string]string {
+	labels := map[string]string{
+		LabelProject:  o.Project,
+		LabelWorktree: o.Worktree,
+	}
+	if o.Version != "" {
+		labels[LabelVersion] = o.Version
+	}
+	return labels
+}
+
+// Filters returns label filters matching resources of the same project and
+// worktree, whatever glide version created them. An empty Worktree matches
+// every worktree of the project.
+func (o Ownership) Filters() []string {
+	filters := []string{LabelProject + "=" + o.Project}
+	if o.Worktree != "" {
+		filters = append(filters, LabelWorktree+"="+o.Worktree)
+	}
+	return filters
+}
+
+// SameOwner reports whether both belong to the same project and worktree
+func (o Ownership) SameOwner(other Ownership) bool {
+	return o.Project == other.Project && o.Worktree == other.Worktree
+}
+
+// OwnershipFromLabels reads ownership from resource labels; ok is false for
+// resources glide did not label
+func OwnershipFromLabels(labels map[string]string) (Ownership, bool) {
+	project := labels[LabelProject]
+	if project == "" {
+		return Ownership{}, false
+	}
+	return Ownership{
+		Project:  project,
+		Worktree: labels[LabelWorktree],
+		Version:  labels[LabelVersion],
+	}, true
+}
+
+// OwnedContainers lists the containers, running or not, of a project or
+// worktree
+func OwnedContainers(owner Ownership) ([]ProjectContainer, error) {
+	return ListContainers(owner.Filters()...)
+}
+
+// OwnershipOverride returns an override that labels every service and every
+// network and volume the project creates with owner
+func (p *ComposeProject) OwnershipOverride(owner Ownership) *Override {
+	labels := owner.Labels()
+
+	override := NewOverride()
+	for name := range p.Services {
+		service := override.Service(name)
+		for key, value := range labels {
+			service.SetLabel(key, value)
+		}
+	}
+	for name, network := range p.Networks {
+		if network.External {
+			continue
+		}
+		resource := override.Network(name)
+		for key, value := range labels {
+			resource.SetLabel(key, value)
+		}
+	}
+	for name, volume := range p.Volumes {
+		if volume.External {
+			continue
+		}
+		resource := override.Volume(name)
+		for key, value := range labels {
+			resource.SetLabel(key, value)
+		}
+	}
+	return override
+}
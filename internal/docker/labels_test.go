@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnership_Labels(t *testing.T) {
+	owner := Ownership{Project: "/src/myapp", Worktree: "feature-x", Version: "3.1.0"}
+
+	labels := owner.Labels()
+	assert.Equal(t, map[string]string{
+		LabelProject:  "/src/myapp",
+		LabelWorktree: "feature-x",
+		LabelVersion:  "3.1.0",
+	}, labels)
+	assert.Equal(t, []string{"dev.glide.project=/src/myapp", "dev.glide.worktree=feature-x"}, owner.Filters())
+
+	parsed, ok := OwnershipFromLabels(labels)
+	require.True(t, ok)
+	assert.Equal(t, owner, parsed)
+	assert.True(t, parsed.SameOwner(Ownership{Project: "/src/myapp", Worktree: "feature-x", Version: "3.2.0"}))
+
+	_, ok = OwnershipFromLabels(map[string]string{ComposeProjectLabel: "myapp"})
+	assert.False(t, ok)
+}
+
+func TestComposeProject_OwnershipOverride(t *testing.T) {
+	project := &ComposeProject{
+		Name:     "myapp",
+		Services: map[string]ComposeService{"php": {}, "mysql": {}},
+		Networks: map[string]ComposeNetwork{"default": {Name: "myapp_default"}, "proxy": {Name: "proxy", External: true}},
+		Volumes:  map[string]ComposeVolume{"mysql-data": {Name: "myapp_mysql-data"}, "shared": {Name: "shared", External: true}},
+	}
+	owner := Ownership{Project: "/src/myapp", Worktree: "feature-x"}
+
+	override := project.OwnershipOverride(owner)
+	assert.Equal(t, owner.Labels(), override.Services["php"].Labels)
+	assert.Equal(t, owner.Labels(), override.Services["mysql"].Labels)
+	assert.Equal(t, owner.Labels(), override.Networks["default"].Labels)
+	assert.Equal(t, owner.Labels(), override.Volumes["mysql-data"].Labels)
+	assert.NotContains(t, override.Networks, "proxy", "external networks are not glide's")
+	assert.NotContains(t, override.Volumes, "shared", "external volumes are not glide's")
+}
+
+func TestOwnedContainers(t *testing.T) {
+	calls := stubDockerOutput(t, map[string]string{
+		"ps": "c1",
+		"inspect": `[{"Id":"c1","Name":"/myapp-php-1","State":{"Status":"running"},
+  "Config":{"Labels":{"com.docker.compose.project":"myapp","dev.glide.project":"/src/myapp","dev.glide.worktree":"feature-x"}}}]`,
+	})
+
+	containers, err := OwnedContainers(Ownership{Project: "/src/myapp", Worktree: "feature-x"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"ps", "--all", "--quiet", "--no-trunc",
+		"--filter", "label=com.docker.compose.project",
+		"--filter", "label=dev.glide.project=/src/myapp",
+		"--filter", "label=dev.glide.worktree=feature-x"}, (*calls)[0])
+	require.Len(t, containers, 1)
+	owner, ok := containers[0].Owner()
+	require.True(t, ok)
+	assert.Equal(t, "feature-x", owner.Worktree)
+}
+
+func TestComposeFiles(t *testing.T) {
+	t.Setenv("COMPOSE_FILE", "")
+
+	dir := t.TempDir()
+	assert.Nil(t, ComposeFiles(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker-compose.yml"), []byte("services: {}\n"), 0644))
+	assert.Equal(t, []string{filepath.Join(dir, "docker-compose.yml")}, ComposeFiles(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker-compose.override.yml"), []byte("services: {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services: {}\n"), 0644))
+	assert.Equal(t, []string{filepath.Join(dir, "compose.yaml")}, ComposeFiles(dir), "compose.yaml takes precedence")
+
+	t.Setenv("COMPOSE_FILE", "base.yml"+string(os.PathListSeparator)+"/abs/dev.yml")
+	assert.Equal(t, []string{filepath.Join(dir, "base.yml"), "/abs/dev.yml"}, ComposeFiles(dir))
+}
@@ -0,0 +1,132 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// maxNameLength keeps derived names within the identifier limits of
+// PostgreSQL (63) and MySQL (64), and of hostnames compose derives from the
+// project name
+const maxNameLength = 63
+
+// Namespace is the names a worktree's resources are created under, derived
+// from the project and worktree names so that worktrees never share
+// containers, volumes, or databases
+type Namespace struct {
+	// Worktree is the worktree name; empty for the main checkout
+	Worktree string `json:"worktree" yaml:"worktree"`
+	// Project is the compose project name
+	Project string `json:"compose_project" yaml:"compose_project"`
+	// Database is the database name
+	Database string `json:"database" yaml:"database"`
+	// VolumePrefix is the prefix compose gives the project's volumes
+	VolumePrefix string `json:"volume_prefix" yaml:"volume_prefix"`
+	// Collisions are the other worktrees whose names sanitize to the same
+	// names; a hash of the worktree name then tells them apart
+	Collisions []string `json:"collisions,omitempty" yaml:"collisions,omitempty"`
+}
+
+// DeriveNamespaces returns the namespace of each of a project's worktrees,
+// by worktree name. The main checkout is the empty worktree name and gets
+// the project's own names.
+func DeriveNamespaces(project string, worktrees []string) map[string]Namespace {
+	namespaces := make(map[string]Namespace, len(worktrees))
+	byProject := make(map[string][]string)
+	byDatabase := make(map[string][]string)
+	for _, worktree := range worktrees {
+		name := project
+		if worktree != "" {
+			name += "-" + worktree
+		}
+		ns := Namespace{
+			Worktree: worktree,
+			Project:  truncateName(composeProjectName(name), "", ""),
+			Database: truncateName(databaseName(name), "", ""),
+		}
+		namespaces[worktree] = ns
+		byProject[ns.Project] = append(byProject[ns.Project], worktree)
+		byDatabase[ns.Database] = append(byDatabase[ns.Database], worktree)
+	}
+
+	for worktree, ns := range namespaces {
+		collisions := make(map[string]bool)
+		for _, other := range append(byProject[ns.Project], byDatabase[ns.Database]...) {
+			if other != worktree {
+				collisions[other] = true
+			}
+		}
+		if len(collisions) > 0 {
+			suffix := nameHash(worktree)
+			ns.Project = truncateName(ns.Project, "-", suffix)
+			ns.Database = truncateName(ns.Database, "_", suffix)
+			for other := range collisions {
+				ns.Collisions = append(ns.Collisions, other)
+			}
+			sort.Strings(ns.Collisions)
+		}
+		ns.VolumePrefix = ns.Project + "_"
+		namespaces[worktree] = ns
+	}
+	return namespaces
+}
+
+// composeProjectName sanitizes name into a compose project name: lowercase
+// letters, digits, dashes, and underscores, starting with a letter or digit
+func composeProjectName(name string) string {
+	return sanitizeName(name, '-', func(r rune) bool {
+		return r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_'
+	})
+}
+
+// databaseName sanitizes name into an identifier every database accepts
+// unquoted: lowercase letters, digits, and underscores, starting with a
+// letter
+func databaseName(name string) string {
+	db := sanitizeName(name, '_', func(r rune) bool {
+		return r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_'
+	})
+	if db == "" || db[0] >= '0' && db[0] <= '9' {
+		db = "db_" + db
+	}
+	return db
+}
+
+// sanitizeName lowercases name and replaces each run of runes valid rejects
+// with sep, trimming separators from both ends
+func sanitizeName(name string, sep rune, valid func(rune) bool) string {
+	var b strings.Builder
+	pending := false
+	for _, r := range strings.ToLower(name) {
+		if !valid(r) {
+			pending = b.Len() > 0
+			continue
+		}
+		if pending {
+			b.WriteRune(sep)
+			pending = false
+		}
+		b.WriteRune(r)
+	}
+	return strings.Trim(b.String(), "-_")
+}
+
+// truncateName shortens name so that it fits maxNameLength with sep and
+// suffix appended
+func truncateName(name, sep, suffix string) string {
+	if limit := maxNameLength - len(sep) - len(suffix); len(name) > limit {
+		name = strings.TrimRight(name[:limit], "-_")
+	}
+	if suffix == "" {
+		return name
+	}
+	return name + sep + suffix
+}
+
+// nameHash returns a short, stable hash of a worktree name
+func nameHash(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:3])
+}
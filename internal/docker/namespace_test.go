@@ -0,0 +1,57 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeriveNamespaces(t *testing.T) {
+	namespaces := DeriveNamespaces("My App", []string{"", "feature-login", "Feature.Login", "2024-hotfix"})
+
+	assert.Equal(t, Namespace{
+		Project:      "my-app",
+		Database:     "my_app",
+		VolumePrefix: "my-app_",
+	}, namespaces[""], "the main checkout gets the project's names")
+	assert.Equal(t, Namespace{
+		Worktree:     "2024-hotfix",
+		Project:      "my-app-2024-hotfix",
+		Database:     "my_app_2024_hotfix",
+		VolumePrefix: "my-app-2024-hotfix_",
+	}, namespaces["2024-hotfix"])
+
+	login, dotted := namespaces["feature-login"], namespaces["Feature.Login"]
+	assert.Equal(t, []string{"Feature.Login"}, login.Collisions)
+	assert.Equal(t, []string{"feature-login"}, dotted.Collisions)
+	assert.Equal(t, "my-app-feature-login-"+nameHash("feature-login"), login.Project)
+	assert.Equal(t, "my_app_feature_login_"+nameHash("feature-login"), login.Database)
+	assert.NotEqual(t, login.Project, dotted.Project)
+	assert.NotEqual(t, login.Database, dotted.Database)
+	assert.Equal(t, login.Project+"_", login.VolumePrefix)
+}
+
+func TestDeriveNamespaces_Long(t *testing.T) {
+	a := "feature-" + strings.Repeat("x", 70) + "-a"
+	b := "feature-" + strings.Repeat("x", 70) + "-b"
+	namespaces := DeriveNamespaces("app", []string{a, b})
+
+	for _, ns := range namespaces {
+		assert.LessOrEqual(t, len(ns.Project), maxNameLength)
+		assert.LessOrEqual(t, len(ns.Database), maxNameLength)
+		assert.Len(t, ns.Collisions, 1, "names truncated alike collide")
+	}
+	assert.NotEqual(t, namespaces[a].Project, namespaces[b].Project)
+}
+
+func TestDatabaseName(t *testing.T) {
+	assert.Equal(t, "db_3d_app", databaseName("3D-App"))
+	assert.Equal(t, "db_", databaseName("---"))
+	assert.Equal(t, "shop_feature_login", databaseName("shop/feature--login"))
+}
+
+func TestComposeProjectName(t *testing.T) {
+	assert.Equal(t, "shop-feature-login", composeProjectName("_Shop feature/login"))
+	assert.Equal(t, "shop-feature_login", composeProjectName("shop-feature_login"))
+}
@@ -0,0 +1,37 @@
+package docker
+
+import (
+	"errors"
+	"os/exec"
+	"strconv"
+)
+
+// ErrNoProbeTool is returned by ProbeTCP when the container lacks a shell
+// with nc or bash to open a connection with
+var ErrNoProbeTool = errors.New("the container has no shell, nc, or bash to probe with")
+
+// probeScript opens a TCP connection to $1:$2 with nc, or bash's /dev/tcp,
+// giving up after two seconds, and exits 127 when neither is installed
+const probeScript = `t=""
+command -v timeout >/dev/null 2>&1 && t="timeout 2"
+if command -v nc >/dev/null 2>&1; then exec $t nc -z -w 2 "$1" "$2"; fi
+if command -v bash >/dev/null 2>&1; then exec $t bash -c 'exec 3<>"/dev/tcp/$0/$1"' "$1" "$2"; fi
+exit 127`
+
+// probeExec runs the probe in a container and is replaced in tests
+var probeExec = func(container, host string, port int) error {
+	return exec.Command("docker", "exec", container, "sh", "-c", probeScript, "sh", host, strconv.Itoa(port)).Run()
+}
+
+// ProbeTCP opens a TCP connection from inside container to host:port, such
+// as another service's name and port on the compose network. It returns
+// nil when the connection succeeds and ErrNoProbeTool when the container
+// cannot probe.
+func ProbeTCP(container, host string, port int) error {
+	err := probeExec(container, host, port)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 127 {
+		return ErrNoProbeTool
+	}
+	return err
+}
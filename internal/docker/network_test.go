@@ -0,0 +1,34 @@
+package docker
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeTCP(t *testing.T) {
+	original := probeExec
+	t.Cleanup(func() { probeExec = original })
+
+	var probed []string
+	exitWith := func(code string) func(container, host string, port int) error {
+		return func(container, host string, port int) error {
+			probed = []string{container, host}
+			return exec.Command("sh", "-c", "exit "+code).Run()
+		}
+	}
+
+	probeExec = exitWith("0")
+	require.NoError(t, ProbeTCP("myapp-php-1", "mysql", 3306))
+	assert.Equal(t, []string{"myapp-php-1", "mysql"}, probed)
+
+	probeExec = exitWith("1")
+	err := ProbeTCP("myapp-php-1", "mysql", 3306)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNoProbeTool)
+
+	probeExec = exitWith("127")
+	assert.ErrorIs(t, ProbeTCP("myapp-php-1", "mysql", 3306), ErrNoProbeTool)
+}
@@ -0,0 +1,370 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// overrideHeader opens every generated override file
+const overrideHeader = "# Generated by glide. Changes are overwritten; edit .glide.yml instead.\n"
+
+// Override is a compose override file built from Go values instead of text
+// templates. Marshal output is deterministic: map keys, ports, and profiles
+// are sorted, so regenerating an unchanged override yields identical bytes.
+//
+// Compose merges an override into the base files: environment and labels
+// are merged by key, profiles replace the base ones, and ports are appended
+// to the base service's ports.
+type Override struct {
+	Services map[string]*ServiceOverride  `yaml:"services,omitempty"`
+	Networks map[string]*ResourceOverride `yaml:"networks,omitempty"`
+	Volumes  map[string]*ResourceOverride `yaml:"volumes,omitempty"`
+}
+
+// ServiceOverride is the part of a service an override can set
+type ServiceOverride struct {
+	Profiles    []string                   `yaml:"profiles,omitempty"`
+	Ports       []PortMapping              `yaml:"ports,omitempty"`
+	Environment map[string]string          `yaml:"environment,omitempty"`
+	Labels      map[string]string          `yaml:"labels,omitempty"`
+	Networks    map[string]*ServiceNetwork `yaml:"networks,omitempty"`
+	DependsOn   *DependsOnOverride         `yaml:"depends_on,omitempty"`
+}
+
+// ServiceNetwork is a network a service joins
+type ServiceNetwork struct {
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// DependsOnOverride replaces a service's dependencies instead of adding
+// to them, written with compose's !override tag (compose 2.24.4 or later)
+type DependsOnOverride struct {
+	Services map[string]ComposeDependency
+}
+
+// ResourceOverride is the part of a network or volume an override can set
+type ResourceOverride struct {
+	// Name is the Docker name, used as is instead of prefixed with the
+	// project name
+	Name     string            `yaml:"name,omitempty"`
+	External bool              `yaml:"external,omitempty"`
+	Labels   map[string]string `yaml:"labels,omitempty"`
+}
+
+// PortMapping publishes a container port on the host, written in compose's
+// short syntax: [host_ip:]host:container[/protocol]
+type PortMapping struct {
+	HostIP    string
+	Host      int
+	Container int
+	Protocol  string
+}
+
+// NewOverride creates an empty override
+func NewOverride() *Override {
+	return &Override{}
+}
+
+// Service returns the override of a service, adding it if needed
+func (o *Override) Service(name string) *ServiceOverride {
+	if o.Services == nil {
+		o.Services = make(map[string]*ServiceOverride)
+	}
+	if o.Services[name] == nil {
+		o.Services[name] = &ServiceOverride{}
+	}
+	return o.Services[name]
+}
+
+// Network returns the override of a network, adding it if needed
+func (o *Override) Network(name string) *ResourceOverride {
+	if o.Networks == nil {
+		o.Networks = make(map[string]*ResourceOverride)
+	}
+	if o.Networks[name] == nil {
+		o.Networks[name] = &ResourceOverride{}
+	}
+	return o.Networks[name]
+}
+
+// Volume returns the override of a volume, adding it if needed
+func (o *Override) Volume(name string) *ResourceOverride {
+	if o.Volumes == nil {
+		o.Volumes = make(map[string]*ResourceOverride)
+	}
+	if o.Volumes[name] == nil {
+		o.Volumes[name] = &ResourceOverride{}
+	}
+	return o.Volumes[name]
+}
+
+// SetEnv sets an environment variable of the service
+func (s *ServiceOverride) SetEnv(key, value string) *ServiceOverride {
+	if s.Environment == nil {
+		s.Environment = make(map[string]string)
+	}
+	s.Environment[key] = value
+	return s
+}
+
+// SetLabel sets a label of the service
+func (s *ServiceOverride) SetLabel(key, value string) *ServiceOverride {
+	if s.Labels == nil {
+		s.Labels = make(map[string]string)
+	}
+	s.Labels[key] = value
+	return s
+}
+
+// AddProfile adds the service to a profile
+func (s *ServiceOverride) AddProfile(profile string) *ServiceOverride {
+	for _, p := range s.Profiles {
+		if p == profile {
+			return s
+		}
+	}
+	s.Profiles = append(s.Profiles, profile)
+	return s
+}
+
+// Publish publishes a port, replacing any mapping of the same container
+// port and protocol
+func (s *ServiceOverride) Publish(port PortMapping) *ServiceOverride {
+	for i, existing := range s.Ports {
+		if existing.Container == port.Container && existing.protocol() == port.protocol() {
+			s.Ports[i] = port
+			return s
+		}
+	}
+	s.Ports = append(s.Ports, port)
+	return s
+}
+
+// JoinNetwork adds the service to a network of the project
+func (s *ServiceOverride) JoinNetwork(name string) *ServiceOverride {
+	if s.Networks == nil {
+		s.Networks = make(map[string]*ServiceNetwork)
+	}
+	if s.Networks[name] == nil {
+		s.Networks[name] = &ServiceNetwork{}
+	}
+	return s
+}
+
+// MarshalYAML writes the dependencies as a mapping tagged !override
+func (d *DependsOnOverride) MarshalYAML() (interface{}, error) {
+	var node yaml.Node
+	services := d.Services
+	if services == nil {
+		services = map[string]ComposeDependency{}
+	}
+	if err := node.Encode(services); err != nil {
+		return nil, err
+	}
+	node.Tag = "!override"
+	return &node, nil
+}
+
+// UnmarshalYAML reads the dependencies, with or without the !override tag
+func (d *DependsOnOverride) UnmarshalYAML(node *yaml.Node) error {
+	untagged := *node
+	untagged.Tag = ""
+	return untagged.Decode(&d.Services)
+}
+
+// SetLabel sets a label of the network or volume
+func (r *ResourceOverride) SetLabel(key, value string) *ResourceOverride {
+	if r.Labels == nil {
+		r.Labels = make(map[string]string)
+	}
+	r.Labels[key] = value
+	return r
+}
+
+// Marshal renders the override as YAML
+func (o *Override) Marshal() ([]byte, error) {
+	o.normalize()
+
+	var buf bytes.Buffer
+	buf.WriteString(overrideHeader)
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(o); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteFile writes the override to path, leaving the file untouched when
+// its content would not change
+func (o *Override) WriteFile(path string) error {
+	data, err := o.Marshal()
+	if err != nil {
+		return err
+	}
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return glideErrors.NewPermissionError(filepath.Dir(path), "failed to create override directory", glideErrors.WithError(err))
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return glideErrors.NewPermissionError(path, "failed to write compose override", glideErrors.WithError(err))
+	}
+	return os.Rename(tmp, path)
+}
+
+// ParseOverride parses an override file
+func ParseOverride(data []byte) (*Override, error) {
+	o := NewOverride()
+	if err := yaml.Unmarshal(data, o); err != nil {
+		return nil, glideErrors.NewConfigError("invalid compose override",
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions("Delete the file so glide can regenerate it"),
+		)
+	}
+	o.normalize()
+	return o, nil
+}
+
+// LoadOverride reads an override file, returning an empty override when
+// the file does not exist
+func LoadOverride(path string) (*Override, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewOverride(), nil
+	}
+	if err != nil {
+		return nil, glideErrors.WrapWithOp(err, "reading compose override", glideErrors.WithPath(path))
+	}
+	o, err := ParseOverride(data)
+	if err != nil {
+		return nil, glideErrors.WrapWithOp(err, "loading compose override", glideErrors.WithPath(path))
+	}
+	return o, nil
+}
+
+// normalize sorts list values so output is deterministic
+func (o *Override) normalize() {
+	for _, s := range o.Services {
+		if s == nil {
+			continue
+		}
+		sort.Strings(s.Profiles)
+		sort.SliceStable(s.Ports, func(i, j int) bool {
+			a, b := s.Ports[i], s.Ports[j]
+			if a.Container != b.Container {
+				return a.Container < b.Container
+			}
+			if a.protocol() != b.protocol() {
+				return a.protocol() < b.protocol()
+			}
+			return a.String() < b.String()
+		})
+	}
+}
+
+// protocol returns the mapping's protocol, defaulting to tcp
+func (p PortMapping) protocol() string {
+	if p.Protocol == "" {
+		return "tcp"
+	}
+	return p.Protocol
+}
+
+// String returns the mapping in compose's short syntax
+func (p PortMapping) String() string {
+	var b strings.Builder
+	if p.HostIP != "" {
+		if strings.Contains(p.HostIP, ":") {
+			b.WriteString("[" + p.HostIP + "]:")
+		} else {
+			b.WriteString(p.HostIP + ":")
+		}
+	}
+	if p.Host != 0 {
+		b.WriteString(strconv.Itoa(p.Host) + ":")
+	} else if p.HostIP != "" {
+		b.WriteString(":")
+	}
+	b.WriteString(strconv.Itoa(p.Container))
+	if p.Protocol != "" && p.Protocol != "tcp" {
+		b.WriteString("/" + p.Protocol)
+	}
+	return b.String()
+}
+
+// MarshalYAML writes the mapping as a quoted string, since YAML 1.1
+// parsers read unquoted values like 22:22 as base-60 numbers
+func (p PortMapping) MarshalYAML() (interface{}, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Style: yaml.DoubleQuotedStyle, Value: p.String()}, nil
+}
+
+// UnmarshalYAML reads the short syntax
+func (p *PortMapping) UnmarshalYAML(node *yaml.Node) error {
+	parsed, err := ParsePortMapping(node.Value)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// ParsePortMapping parses compose's short port syntax, e.g. "8080:80",
+// "127.0.0.1:5432:5432", or "53:53/udp". Port ranges are not supported.
+func ParsePortMapping(s string) (PortMapping, error) {
+	var p PortMapping
+	rest := strings.TrimSpace(s)
+
+	if spec, protocol, ok := strings.Cut(rest, "/"); ok {
+		rest = spec
+		if protocol != "tcp" {
+			p.Protocol = protocol
+		}
+	}
+
+	if strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]:")
+		if end < 0 {
+			return PortMapping{}, fmt.Errorf("invalid port mapping %q", s)
+		}
+		p.HostIP = rest[1:end]
+		rest = rest[end+2:]
+	}
+
+	parts := strings.Split(rest, ":")
+	if p.HostIP == "" && len(parts) == 3 {
+		p.HostIP, parts = parts[0], parts[1:]
+	}
+	if len(parts) > 2 {
+		return PortMapping{}, fmt.Errorf("invalid port mapping %q", s)
+	}
+
+	container, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return PortMapping{}, fmt.Errorf("invalid container port in %q", s)
+	}
+	p.Container = container
+
+	if len(parts) == 2 && parts[0] != "" {
+		host, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return PortMapping{}, fmt.Errorf("invalid host port in %q", s)
+		}
+		p.Host = host
+	}
+	return p, nil
+}
@@ -0,0 +1,135 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOverride() *Override {
+	o := NewOverride()
+	o.Service("php").
+		SetEnv("APP_URL", "http://localhost:8081").
+		SetEnv("DEBUG", "true").
+		SetLabel("dev.glide.worktree", "feature-x").
+		Publish(PortMapping{Host: 9001, Container: 9000}).
+		Publish(PortMapping{Host: 8081, Container: 80}).
+		AddProfile("web").
+		AddProfile("app").
+		AddProfile("web")
+	o.Service("mysql").Publish(PortMapping{HostIP: "127.0.0.1", Host: 33061, Container: 3306})
+	o.Network("default").SetLabel("dev.glide.worktree", "feature-x")
+	o.Volume("mysql-data").SetLabel("dev.glide.worktree", "feature-x")
+	return o
+}
+
+func TestOverride_Marshal(t *testing.T) {
+	data, err := testOverride().Marshal()
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Generated by glide. Changes are overwritten; edit .glide.yml instead.
+services:
+  mysql:
+    ports:
+      - "127.0.0.1:33061:3306"
+  php:
+    profiles:
+      - app
+      - web
+    ports:
+      - "8081:80"
+      - "9001:9000"
+    environment:
+      APP_URL: http://localhost:8081
+      DEBUG: "true"
+    labels:
+      dev.glide.worktree: feature-x
+networks:
+  default:
+    labels:
+      dev.glide.worktree: feature-x
+volumes:
+  mysql-data:
+    labels:
+      dev.glide.worktree: feature-x
+`, string(data))
+
+	again, err := testOverride().Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, data, again, "output is deterministic")
+}
+
+func TestOverride_RoundTrip(t *testing.T) {
+	data, err := testOverride().Marshal()
+	require.NoError(t, err)
+
+	parsed, err := ParseOverride(data)
+	require.NoError(t, err)
+	assert.Equal(t, "true", parsed.Services["php"].Environment["DEBUG"], "string values stay strings")
+
+	reencoded, err := parsed.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, string(data), string(reencoded))
+}
+
+func TestOverride_Publish(t *testing.T) {
+	s := &ServiceOverride{}
+	s.Publish(PortMapping{Host: 8080, Container: 80})
+	s.Publish(PortMapping{Host: 8081, Container: 80})
+	s.Publish(PortMapping{Host: 8081, Container: 80, Protocol: "udp"})
+
+	assert.Equal(t, []PortMapping{
+		{Host: 8081, Container: 80},
+		{Host: 8081, Container: 80, Protocol: "udp"},
+	}, s.Ports)
+}
+
+func TestOverride_WriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "compose.override.yml")
+
+	require.NoError(t, testOverride().WriteFile(path))
+	loaded, err := LoadOverride(path)
+	require.NoError(t, err)
+	assert.Equal(t, testOverride().Services["mysql"].Ports, loaded.Services["mysql"].Ports)
+
+	// An unchanged override leaves the file alone
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+	require.NoError(t, testOverride().WriteFile(path))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(old))
+
+	missing, err := LoadOverride(filepath.Join(t.TempDir(), "missing.yml"))
+	require.NoError(t, err)
+	assert.Empty(t, missing.Services)
+}
+
+func TestParsePortMapping(t *testing.T) {
+	tests := map[string]PortMapping{
+		"80":                  {Container: 80},
+		"8080:80":             {Host: 8080, Container: 80},
+		"8080:80/tcp":         {Host: 8080, Container: 80},
+		"53:53/udp":           {Host: 53, Container: 53, Protocol: "udp"},
+		"127.0.0.1:5432:5432": {HostIP: "127.0.0.1", Host: 5432, Container: 5432},
+		"127.0.0.1::80":       {HostIP: "127.0.0.1", Container: 80},
+		"[::1]:8080:80":       {HostIP: "::1", Host: 8080, Container: 80},
+	}
+	for input, want := range tests {
+		got, err := ParsePortMapping(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	assert.Equal(t, "[::1]:8080:80", PortMapping{HostIP: "::1", Host: 8080, Container: 80}.String())
+	assert.Equal(t, "127.0.0.1::80", PortMapping{HostIP: "127.0.0.1", Container: 80}.String())
+
+	for _, input := range []string{"", "http", "8000-8010:80", "a:b:c:d", "[::1:80"} {
+		_, err := ParsePortMapping(input)
+		assert.Error(t, err, input)
+	}
+}
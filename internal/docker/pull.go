@@ -0,0 +1,79 @@
+package docker
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// PullResult is the outcome of pulling a single image
+type PullResult struct {
+	Image    string
+	Duration time.Duration
+	Err      error
+}
+
+// pullImage pulls one image and is replaced in tests
+var pullImage = func(image string) error {
+	cmd := exec.Command("docker", "pull", "--quiet", image)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return glideErrors.NewDockerError("failed to pull "+image,
+			glideErrors.WithError(err),
+			glideErrors.WithContext("image", image),
+			glideErrors.WithContext("output", strings.TrimSpace(stderr.String())),
+		)
+	}
+	return nil
+}
+
+// PullImages pulls images with at most parallelism pulls at a time and
+// returns the results in the order of images. onStart and onDone, if set,
+// are called from worker goroutines with the index of the worker.
+func PullImages(images []string, parallelism int, onStart func(worker int, image string), onDone func(worker int, result PullResult)) []PullResult {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > len(images) {
+		parallelism = len(images)
+	}
+
+	results := make([]PullResult, len(images))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < parallelism; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := range jobs {
+				image := images[i]
+				if onStart != nil {
+					onStart(worker, image)
+				}
+
+				start := time.Now()
+				err := pullImage(image)
+				results[i] = PullResult{Image: image, Duration: time.Since(start), Err: err}
+
+				if onDone != nil {
+					onDone(worker, results[i])
+				}
+			}
+		}(worker)
+	}
+
+	for i := range images {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
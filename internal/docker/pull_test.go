@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullImages(t *testing.T) {
+	var inFlight, maxInFlight int32
+	original := pullImage
+	pullImage = func(image string) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+
+		if image == "broken:latest" {
+			return errors.New("manifest unknown")
+		}
+		return nil
+	}
+	defer func() { pullImage = original }()
+
+	images := []string{"mysql:8", "redis:7", "broken:latest", "nginx:1", "node:20"}
+
+	var mu sync.Mutex
+	var started, finished []string
+	results := PullImages(images, 2,
+		func(worker int, image string) {
+			mu.Lock()
+			started = append(started, image)
+			mu.Unlock()
+		},
+		func(worker int, result PullResult) {
+			mu.Lock()
+			finished = append(finished, result.Image)
+			mu.Unlock()
+		},
+	)
+
+	require.Len(t, results, len(images))
+	for i, result := range results {
+		assert.Equal(t, images[i], result.Image, "results keep the order of images")
+	}
+	assert.EqualError(t, results[2].Err, "manifest unknown")
+	assert.NoError(t, results[0].Err)
+
+	assert.ElementsMatch(t, images, started)
+	assert.ElementsMatch(t, images, finished)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestPullImages_Empty(t *testing.T) {
+	assert.Empty(t, PullImages(nil, 4, nil, nil))
+}
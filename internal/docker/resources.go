@@ -0,0 +1,221 @@
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// Labels compose sets on the containers it creates
+const (
+	ComposeProjectLabel    = "com.docker.compose.project"
+	ComposeServiceLabel    = "com.docker.compose.service"
+	ComposeWorkingDirLabel = "com.docker.compose.project.working_dir"
+)
+
+// dockerTimeLayout is the format of CreatedAt in `docker image ls` output
+const dockerTimeLayout = "2006-01-02 15:04:05 -0700 MST"
+
+// Image is a local image as listed by `docker image ls`
+type Image struct {
+	ID         string    `json:"id" yaml:"id"`
+	Repository string    `json:"repository" yaml:"repository"`
+	Tag        string    `json:"tag" yaml:"tag"`
+	Size       string    `json:"size" yaml:"size"`
+	Created    time.Time `json:"created" yaml:"created"`
+}
+
+// ProjectContainer is a container created by docker compose
+type ProjectContainer struct {
+	ID         string    `json:"id" yaml:"id"`
+	Name       string    `json:"name" yaml:"name"`
+	Project    string    `json:"project" yaml:"project"`
+	Service    string    `json:"service" yaml:"service"`
+	WorkingDir string    `json:"working_dir" yaml:"working_dir"`
+	State      string    `json:"state" yaml:"state"`
+	StartedAt  time.Time `json:"started_at" yaml:"started_at"`
+	FinishedAt time.Time `json:"finished_at" yaml:"finished_at"`
+
+	// Health is the healthcheck status: starting, healthy, or unhealthy;
+	// empty without a healthcheck
+	Health string `json:"health,omitempty" yaml:"health,omitempty"`
+
+	// Ports are the container ports published on the host
+	Ports []PortBinding `json:"ports,omitempty" yaml:"ports,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// PortBinding is a container port published on the host
+type PortBinding struct {
+	HostIP        string `json:"host_ip" yaml:"host_ip"`
+	HostPort      string `json:"host_port" yaml:"host_port"`
+	ContainerPort string `json:"container_port" yaml:"container_port"` // e.g. 80/tcp
+}
+
+// String formats the binding the way docker ps does, e.g.
+// 0.0.0.0:8080->80/tcp
+func (b PortBinding) String() string {
+	return b.HostIP + ":" + b.HostPort + "->" + b.ContainerPort
+}
+
+// Owner returns the glide project and worktree that created the container
+func (c ProjectContainer) Owner() (Ownership, bool) {
+	return OwnershipFromLabels(c.Labels)
+}
+
+// imageRow is a row of `docker image ls --format '{{json .}}'`
+type imageRow struct {
+	ID         string `json:"ID"`
+	Repository string `json:"Repository"`
+	Tag        string `json:"Tag"`
+	Size       string `json:"Size"`
+	CreatedAt  string `json:"CreatedAt"`
+}
+
+// inspectedContainer is the part of `docker inspect` output glide reads
+type inspectedContainer struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name"`
+	State struct {
+		Status     string    `json:"Status"`
+		StartedAt  time.Time `json:"StartedAt"`
+		FinishedAt time.Time `json:"FinishedAt"`
+		Health     *struct {
+			Status string `json:"Status"`
+		} `json:"Health"`
+	} `json:"State"`
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	NetworkSettings struct {
+		Ports map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string `json:"HostPort"`
+		} `json:"Ports"`
+	} `json:"NetworkSettings"`
+}
+
+// portBindings returns the container's published ports, sorted by container
+// port
+func (c inspectedContainer) portBindings() []PortBinding {
+	var bindings []PortBinding
+	for port, hosts := range c.NetworkSettings.Ports {
+		for _, host := range hosts {
+			bindings = append(bindings, PortBinding{HostIP: host.HostIP, HostPort: host.HostPort, ContainerPort: port})
+		}
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		if bindings[i].ContainerPort != bindings[j].ContainerPort {
+			return bindings[i].ContainerPort < bindings[j].ContainerPort
+		}
+		return bindings[i].String() < bindings[j].String()
+	})
+	return bindings
+}
+
+// DanglingImages lists untagged images no other image depends on
+func DanglingImages() ([]Image, error) {
+	out, err := dockerOutput("", "image", "ls", "--filter", "dangling=true", "--no-trunc", "--format", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var images []Image
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var row imageRow
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return nil, glideErrors.NewDockerError("failed to parse docker image ls output", glideErrors.WithError(err))
+		}
+		// Images with an unparseable date keep the zero time and are treated
+		// as older than any retention age
+		created, _ := time.Parse(dockerTimeLayout, row.CreatedAt)
+		images = append(images, Image{
+			ID:         row.ID,
+			Repository: row.Repository,
+			Tag:        row.Tag,
+			Size:       row.Size,
+			Created:    created,
+		})
+	}
+	return images, nil
+}
+
+// ComposeContainers lists every container, running or not, that docker
+// compose created on this machine
+func ComposeContainers() ([]ProjectContainer, error) {
+	return ListContainers(ComposeProjectLabel)
+}
+
+// ListContainers lists the compose containers, running or not, matching
+// every label filter. A filter is a label key, or key=value.
+func ListContainers(labelFilters ...string) ([]ProjectContainer, error) {
+	args := []string{"ps", "--all", "--quiet", "--no-trunc", "--filter", "label=" + ComposeProjectLabel}
+	for _, filter := range labelFilters {
+		if filter != ComposeProjectLabel {
+			args = append(args, "--filter", "label="+filter)
+		}
+	}
+
+	out, err := dockerOutput("", args...)
+	if err != nil {
+		return nil, err
+	}
+	ids := strings.Fields(string(out))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	out, err = dockerOutput("", append([]string{"inspect"}, ids...)...)
+	if err != nil {
+		return nil, err
+	}
+	var inspected []inspectedContainer
+	if err := json.Unmarshal(out, &inspected); err != nil {
+		return nil, glideErrors.NewDockerError("failed to parse docker inspect output", glideErrors.WithError(err))
+	}
+
+	containers := make([]ProjectContainer, 0, len(inspected))
+	for _, c := range inspected {
+		var health string
+		if c.State.Health != nil {
+			health = c.State.Health.Status
+		}
+		containers = append(containers, ProjectContainer{
+			ID:         c.ID,
+			Name:       strings.TrimPrefix(c.Name, "/"),
+			Project:    c.Config.Labels[ComposeProjectLabel],
+			Service:    c.Config.Labels[ComposeServiceLabel],
+			WorkingDir: c.Config.Labels[ComposeWorkingDirLabel],
+			State:      c.State.Status,
+			StartedAt:  c.State.StartedAt,
+			FinishedAt: c.State.FinishedAt,
+			Health:     health,
+			Ports:      c.portBindings(),
+			Labels:     c.Config.Labels,
+		})
+	}
+	return containers, nil
+}
+
+// RemoveImage removes an image by ID
+func RemoveImage(id string) error {
+	_, err := dockerOutput("", "image", "rm", id)
+	return err
+}
+
+// StopContainer stops a running container by ID
+func StopContainer(id string) error {
+	_, err := dockerOutput("", "stop", id)
+	return err
+}
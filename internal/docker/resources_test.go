@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDanglingImages(t *testing.T) {
+	calls := stubDockerOutput(t, map[string]string{
+		"image ls": `{"ID":"sha256:aaa","Repository":"<none>","Tag":"<none>","Size":"1.2GB","CreatedAt":"2024-03-01 10:00:00 +0000 UTC"}
+{"ID":"sha256:bbb","Repository":"<none>","Tag":"<none>","Size":"12MB","CreatedAt":"not a date"}`,
+	})
+
+	images, err := DanglingImages()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"image", "ls", "--filter", "dangling=true", "--no-trunc", "--format", "{{json .}}"}, (*calls)[0])
+	require.Len(t, images, 2)
+	assert.Equal(t, "sha256:aaa", images[0].ID)
+	assert.Equal(t, "1.2GB", images[0].Size)
+	assert.True(t, images[0].Created.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)))
+	assert.True(t, images[1].Created.IsZero())
+}
+
+func TestComposeContainers(t *testing.T) {
+	calls := stubDockerOutput(t, map[string]string{
+		"ps": "c1\nc2\n",
+		"inspect": `[
+  {"Id":"c1","Name":"/myapp-php-1","State":{"Status":"running","StartedAt":"2024-03-01T10:00:00Z","FinishedAt":"0001-01-01T00:00:00Z"},
+   "Config":{"Labels":{"com.docker.compose.project":"myapp","com.docker.compose.service":"php","com.docker.compose.project.working_dir":"/src/myapp"}}},
+  {"Id":"c2","Name":"/other-db-1","State":{"Status":"running","StartedAt":"2024-03-01T10:00:00Z","FinishedAt":"2024-03-02T10:00:00Z","Health":{"Status":"healthy"}},
+   "Config":{"Labels":{"com.docker.compose.project":"other"}},
+   "NetworkSettings":{"Ports":{"5432/tcp":[{"HostIp":"0.0.0.0","HostPort":"5432"},{"HostIp":"::","HostPort":"5432"}],"80/tcp":null}}}
+]`,
+	})
+
+	containers, err := ComposeContainers()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"ps", "--all", "--quiet", "--no-trunc", "--filter", "label=com.docker.compose.project"}, (*calls)[0])
+	assert.Equal(t, []string{"inspect", "c1", "c2"}, (*calls)[1])
+	require.Len(t, containers, 2)
+	assert.Equal(t, ProjectContainer{
+		ID:         "c1",
+		Name:       "myapp-php-1",
+		Project:    "myapp",
+		Service:    "php",
+		WorkingDir: "/src/myapp",
+		State:      "running",
+		StartedAt:  time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		FinishedAt: time.Time{},
+		Labels: map[string]string{
+			"com.docker.compose.project":             "myapp",
+			"com.docker.compose.service":             "php",
+			"com.docker.compose.project.working_dir": "/src/myapp",
+		},
+	}, containers[0])
+	assert.Equal(t, "healthy", containers[1].Health)
+	assert.Equal(t, []PortBinding{
+		{HostIP: "0.0.0.0", HostPort: "5432", ContainerPort: "5432/tcp"},
+		{HostIP: "::", HostPort: "5432", ContainerPort: "5432/tcp"},
+	}, containers[1].Ports)
+	assert.Equal(t, "0.0.0.0:5432->5432/tcp", containers[1].Ports[0].String())
+}
+
+func TestComposeContainers_None(t *testing.T) {
+	calls := stubDockerOutput(t, map[string]string{"ps": "\n"})
+
+	containers, err := ComposeContainers()
+	require.NoError(t, err)
+	assert.Empty(t, containers)
+	assert.Len(t, *calls, 1)
+}
@@ -0,0 +1,169 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// Shared services run once per project, in a compose project of their own,
+// and the worktrees' services reach them over a network that project
+// creates
+const (
+	// SharedNetworkKey is the key of the shared network in overrides
+	SharedNetworkKey = "glide-shared"
+	// SharedProfile disables a worktree's own copy of a shared service
+	SharedProfile = "glide-shared"
+)
+
+// ErrUnsupportedDatabase is returned by CreateDatabase for images it does
+// not know how to create a database in
+var ErrUnsupportedDatabase = errors.New("not a MySQL, MariaDB, or PostgreSQL image")
+
+// SharedHostOverride returns the override of the compose project running
+// the shared services: they join the shared network, named network, under
+// their service names
+func (p *ComposeProject) SharedHostOverride(shared []string, network string) *Override {
+	override := NewOverride()
+	override.Network(SharedNetworkKey).Name = network
+	for _, name := range shared {
+		service, ok := p.Services[name]
+		if !ok {
+			continue
+		}
+		joinNetworks(override.Service(name), service)
+	}
+	return override
+}
+
+// SharedOverride returns the override making a worktree's services use the
+// shared services instead of their own: the shared services are moved to a
+// profile nothing enables, the other services join the shared network, and
+// dependencies on shared services are dropped.
+func (p *ComposeProject) SharedOverride(shared []string, network string) *Override {
+	isShared := make(map[string]bool, len(shared))
+	for _, name := range shared {
+		isShared[name] = true
+	}
+
+	override := NewOverride()
+	sharedNetwork := override.Network(SharedNetworkKey)
+	sharedNetwork.Name = network
+	sharedNetwork.External = true
+
+	for name, service := range p.Services {
+		if isShared[name] {
+			override.Service(name).AddProfile(SharedProfile)
+			continue
+		}
+		s := override.Service(name)
+		joinNetworks(s, service)
+
+		dropped := false
+		kept := make(map[string]ComposeDependency)
+		for dep, condition := range service.DependsOn {
+			if isShared[dep] {
+				dropped = true
+				continue
+			}
+			kept[dep] = condition
+		}
+		if dropped {
+			s.DependsOn = &DependsOnOverride{Services: kept}
+		}
+	}
+	return override
+}
+
+// joinNetworks adds the shared network to the networks a service already
+// joins, which an override must list again to keep
+func joinNetworks(s *ServiceOverride, service ComposeService) {
+	if len(service.Networks) == 0 {
+		s.JoinNetwork("default")
+	}
+	for network := range service.Networks {
+		s.JoinNetwork(network)
+	}
+	s.JoinNetwork(SharedNetworkKey)
+}
+
+// databaseExec runs a command in a container and is replaced in tests
+var databaseExec = func(container string, args ...string) ([]byte, error) {
+	return exec.Command("docker", append([]string{"exec", container}, args...)...).CombinedOutput()
+}
+
+// CreateDatabase creates a database in a running MySQL, MariaDB, or
+// PostgreSQL container unless it exists, as the superuser the image's
+// environment configures. It returns ErrUnsupportedDatabase for other
+// images.
+func CreateDatabase(container, image, name string) error {
+	if !validDatabaseName(name) {
+		return fmt.Errorf("invalid database name %q", name)
+	}
+
+	var script string
+	switch engine := databaseEngine(image); engine {
+	case "mysql", "mariadb":
+		// MariaDB 11 images only ship the mariadb client
+		script = fmt.Sprintf(`%s -uroot -p"${MYSQL_ROOT_PASSWORD:-$MARIADB_ROOT_PASSWORD}" -e 'CREATE DATABASE IF NOT EXISTS `+"`%s`"+`'`, engine, name)
+	case "postgres":
+		script = fmt.Sprintf(`u="${POSTGRES_USER:-postgres}"; psql -U "$u" -d postgres -tAc "SELECT 1 FROM pg_database WHERE datname='%s'" | grep -q 1 || createdb -U "$u" '%s'`, name, name)
+	default:
+		return ErrUnsupportedDatabase
+	}
+
+	if out, err := databaseExec(container, "sh", "-c", script); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// validDatabaseName reports whether name can be created without quoting
+// concerns: letters, digits, underscores, and dashes
+func validDatabaseName(name string) bool {
+	if name == "" || len(name) > maxNameLength {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// databaseEngine returns the database an image runs, from its repository
+// name: mysql, mariadb, or postgres
+func databaseEngine(image string) string {
+	repository := image
+	if i := strings.LastIndex(repository, "/"); i >= 0 {
+		repository = repository[i+1:]
+	}
+	repository, _, _ = strings.Cut(repository, "@")
+	repository, _, _ = strings.Cut(repository, ":")
+	for _, engine := range []string{"mysql", "mariadb", "postgres"} {
+		if repository == engine || strings.HasPrefix(repository, engine+"-") {
+			return engine
+		}
+	}
+	return ""
+}
+
+// SharedServiceNames returns the shared services the project defines, in
+// sorted order, and the ones it does not
+func (p *ComposeProject) SharedServiceNames(shared []string) (found, missing []string) {
+	for _, name := range shared {
+		if _, ok := p.Services[name]; ok {
+			found = append(found, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(found)
+	return found, missing
+}
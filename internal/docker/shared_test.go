@@ -0,0 +1,121 @@
+package docker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSharedProject() *ComposeProject {
+	return &ComposeProject{
+		Name: "shop-feature-x",
+		Services: map[string]ComposeService{
+			"mysql": {Image: "mysql:8.0", Networks: map[string]any{"default": nil}},
+			"redis": {Image: "redis:7"},
+			"php": {
+				Networks: map[string]any{"default": nil, "backend": nil},
+				DependsOn: map[string]ComposeDependency{
+					"mysql": {Condition: "service_healthy"},
+					"redis": {Condition: "service_started"},
+				},
+			},
+			"nginx": {DependsOn: map[string]ComposeDependency{"php": {Condition: "service_started"}}},
+		},
+	}
+}
+
+func TestComposeProject_SharedOverride(t *testing.T) {
+	data, err := testSharedProject().SharedOverride([]string{"mysql"}, "shop-shared").Marshal()
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Generated by glide. Changes are overwritten; edit .glide.yml instead.
+services:
+  mysql:
+    profiles:
+      - glide-shared
+  nginx:
+    networks:
+      default: {}
+      glide-shared: {}
+  php:
+    networks:
+      backend: {}
+      default: {}
+      glide-shared: {}
+    depends_on: !override
+      redis:
+        condition: service_started
+  redis:
+    networks:
+      default: {}
+      glide-shared: {}
+networks:
+  glide-shared:
+    name: shop-shared
+    external: true
+`, string(data))
+
+	parsed, err := ParseOverride(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ComposeDependency{"redis": {Condition: "service_started"}}, parsed.Services["php"].DependsOn.Services)
+}
+
+func TestComposeProject_SharedHostOverride(t *testing.T) {
+	o := testSharedProject().SharedHostOverride([]string{"mysql", "missing"}, "shop-shared")
+
+	assert.Equal(t, "shop-shared", o.Networks[SharedNetworkKey].Name)
+	assert.False(t, o.Networks[SharedNetworkKey].External, "the shared project creates the network")
+	require.Len(t, o.Services, 1)
+	assert.Contains(t, o.Services["mysql"].Networks, "default")
+	assert.Contains(t, o.Services["mysql"].Networks, SharedNetworkKey)
+
+	found, missing := testSharedProject().SharedServiceNames([]string{"redis", "mysql", "missing"})
+	assert.Equal(t, []string{"mysql", "redis"}, found)
+	assert.Equal(t, []string{"missing"}, missing)
+}
+
+func TestCreateDatabase(t *testing.T) {
+	original := databaseExec
+	t.Cleanup(func() { databaseExec = original })
+	var scripts []string
+	databaseExec = func(container string, args ...string) ([]byte, error) {
+		scripts = append(scripts, container+" "+args[len(args)-1])
+		return nil, nil
+	}
+
+	require.NoError(t, CreateDatabase("shop-shared-mysql-1", "mysql:8.0", "shop_feature_x"))
+	require.NoError(t, CreateDatabase("shop-shared-mariadb-1", "docker.io/library/mariadb:11", "shop_feature_x"))
+	require.NoError(t, CreateDatabase("shop-shared-postgres-1", "postgres:16-alpine", "shop_feature_x"))
+	require.Len(t, scripts, 3)
+	assert.Contains(t, scripts[0], "shop-shared-mysql-1 mysql -uroot")
+	assert.Contains(t, scripts[0], "CREATE DATABASE IF NOT EXISTS `shop_feature_x`")
+	assert.True(t, strings.HasPrefix(scripts[1], "shop-shared-mariadb-1 mariadb "))
+	assert.Contains(t, scripts[2], "createdb -U \"$u\" 'shop_feature_x'")
+
+	assert.ErrorIs(t, CreateDatabase("shop-shared-redis-1", "redis:7", "shop_feature_x"), ErrUnsupportedDatabase)
+	assert.Error(t, CreateDatabase("shop-shared-mysql-1", "mysql:8.0", "x'; DROP"))
+	assert.Len(t, scripts, 3)
+
+	databaseExec = func(container string, args ...string) ([]byte, error) {
+		return []byte("ERROR 2002: Can't connect\n"), errors.New("exit status 1")
+	}
+	err := CreateDatabase("shop-shared-mysql-1", "mysql:8.0", "shop_feature_x")
+	assert.EqualError(t, err, "exit status 1: ERROR 2002: Can't connect")
+}
+
+func TestDatabaseEngine(t *testing.T) {
+	for image, engine := range map[string]string{
+		"mysql":                          "mysql",
+		"mysql:8.0@sha256:abc":           "mysql",
+		"bitnami/mariadb:11":             "mariadb",
+		"registry:5000/postgres:16":      "postgres",
+		"ghcr.io/acme/postgres-pgvector": "postgres",
+		"redis:7":                        "",
+		"postgis/postgis":                "",
+	} {
+		assert.Equal(t, engine, databaseEngine(image), image)
+	}
+}
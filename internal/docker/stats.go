@@ -0,0 +1,202 @@
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// ContainerStats is a point-in-time resource usage sample of one container
+type ContainerStats struct {
+	Service       string  `json:"service" yaml:"service"`
+	Container     string  `json:"container" yaml:"container"`
+	CPUPercent    float64 `json:"cpu_percent" yaml:"cpu_percent"`
+	MemoryUsage   uint64  `json:"memory_usage_bytes" yaml:"memory_usage_bytes"`
+	MemoryLimit   uint64  `json:"memory_limit_bytes" yaml:"memory_limit_bytes"`
+	MemoryPercent float64 `json:"memory_percent" yaml:"memory_percent"`
+	NetworkRx     uint64  `json:"network_rx_bytes" yaml:"network_rx_bytes"`
+	NetworkTx     uint64  `json:"network_tx_bytes" yaml:"network_tx_bytes"`
+	BlockRead     uint64  `json:"block_read_bytes" yaml:"block_read_bytes"`
+	BlockWrite    uint64  `json:"block_write_bytes" yaml:"block_write_bytes"`
+}
+
+// dockerOutput runs a docker command and returns its stdout; it is
+// replaced in tests
+var dockerOutput = func(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("docker", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, glideErrors.NewDockerError(fmt.Sprintf("docker %s failed", args[0]),
+			glideErrors.WithError(err),
+			glideErrors.WithContext("output", strings.TrimSpace(stderr.String())),
+			glideErrors.WithSuggestions("Check that Docker is running: docker info"),
+		)
+	}
+	return out, nil
+}
+
+// composeContainer is a row of `docker compose ps --format json`
+type composeContainer struct {
+	ID      string `json:"ID"`
+	Name    string `json:"Name"`
+	Service string `json:"Service"`
+}
+
+// dockerStatsRow is a row of `docker stats --format '{{json .}}'`
+type dockerStatsRow struct {
+	ID       string `json:"ID"`
+	Name     string `json:"Name"`
+	CPUPerc  string `json:"CPUPerc"`
+	MemUsage string `json:"MemUsage"`
+	MemPerc  string `json:"MemPerc"`
+	NetIO    string `json:"NetIO"`
+	BlockIO  string `json:"BlockIO"`
+}
+
+// Stats samples the resource usage of the project's running containers,
+// sorted by service and container name
+func (p *ComposeProject) Stats() ([]ContainerStats, error) {
+	out, err := dockerOutput(p.Dir, p.ComposeArgs("ps", "--format", "json")...)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := parseComposePS(out)
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
+	byID := make(map[string]composeContainer, len(containers))
+	args := []string{"stats", "--no-stream", "--format", "{{json .}}"}
+	for _, c := range containers {
+		byID[c.ID] = c
+		args = append(args, c.ID)
+	}
+
+	out, err = dockerOutput(p.Dir, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []ContainerStats
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var row dockerStatsRow
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return nil, glideErrors.NewDockerError("failed to parse docker stats output", glideErrors.WithError(err))
+		}
+
+		s := ContainerStats{Container: row.Name}
+		if c, ok := matchContainer(byID, row.ID); ok {
+			s.Service = c.Service
+		}
+		s.CPUPercent = parsePercent(row.CPUPerc)
+		s.MemoryPercent = parsePercent(row.MemPerc)
+		s.MemoryUsage, s.MemoryLimit = parseSizePair(row.MemUsage)
+		s.NetworkRx, s.NetworkTx = parseSizePair(row.NetIO)
+		s.BlockRead, s.BlockWrite = parseSizePair(row.BlockIO)
+		stats = append(stats, s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Service != stats[j].Service {
+			return stats[i].Service < stats[j].Service
+		}
+		return stats[i].Container < stats[j].Container
+	})
+	return stats, nil
+}
+
+// parseComposePS accepts both the JSON array older compose versions print
+// and the JSON lines newer versions print
+func parseComposePS(out []byte) ([]composeContainer, error) {
+	trimmed := bytes.TrimSpace(out)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	var containers []composeContainer
+	if trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &containers); err != nil {
+			return nil, glideErrors.NewDockerError("failed to parse docker compose ps output", glideErrors.WithError(err))
+		}
+		return containers, nil
+	}
+
+	for _, line := range bytes.Split(trimmed, []byte("\n")) {
+		var c composeContainer
+		if err := json.Unmarshal(line, &c); err != nil {
+			return nil, glideErrors.NewDockerError("failed to parse docker compose ps output", glideErrors.WithError(err))
+		}
+		containers = append(containers, c)
+	}
+	return containers, nil
+}
+
+// matchContainer finds a container by ID; docker stats reports short IDs
+func matchContainer(byID map[string]composeContainer, id string) (composeContainer, bool) {
+	if c, ok := byID[id]; ok {
+		return c, true
+	}
+	for fullID, c := range byID {
+		if strings.HasPrefix(fullID, id) || strings.HasPrefix(id, fullID) {
+			return c, true
+		}
+	}
+	return composeContainer{}, false
+}
+
+// parsePercent parses values like "12.34%"
+func parsePercent(s string) float64 {
+	v, _ := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	return v
+}
+
+// parseSizePair parses values like "1.5MiB / 2GiB"
+func parseSizePair(s string) (uint64, uint64) {
+	left, right, _ := strings.Cut(s, "/")
+	return parseSize(left), parseSize(right)
+}
+
+// sizeUnits maps docker's size suffixes to bytes, longest suffixes first
+var sizeUnits = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"kB", 1e3}, {"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"B", 1},
+}
+
+// parseSize parses a human-readable size such as "512MiB" or "1.2kB"
+func parseSize(s string) uint64 {
+	s = strings.TrimSpace(s)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, unit.suffix)), 64)
+			if err != nil {
+				return 0
+			}
+			return uint64(v * unit.multiplier)
+		}
+	}
+	v, _ := strconv.ParseFloat(s, 64)
+	return uint64(v)
+}
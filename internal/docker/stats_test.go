@@ -0,0 +1,98 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubDockerOutput(t *testing.T, responses map[string]string) *[][]string {
+	t.Helper()
+
+	var calls [][]string
+	original := dockerOutput
+	dockerOutput = func(dir string, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		for prefix, out := range responses {
+			if strings.HasPrefix(strings.Join(args, " "), prefix) {
+				return []byte(out), nil
+			}
+		}
+		return nil, nil
+	}
+	t.Cleanup(func() { dockerOutput = original })
+	return &calls
+}
+
+func TestComposeProject_Stats(t *testing.T) {
+	for name, psOutput := range map[string]string{
+		"json lines": `{"ID":"aaaaaaaaaaaa1111","Name":"myapp-php-1","Service":"php"}
+{"ID":"bbbbbbbbbbbb2222","Name":"myapp-mysql-1","Service":"mysql"}`,
+		"json array": `[{"ID":"aaaaaaaaaaaa1111","Name":"myapp-php-1","Service":"php"},
+{"ID":"bbbbbbbbbbbb2222","Name":"myapp-mysql-1","Service":"mysql"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls := stubDockerOutput(t, map[string]string{
+				"compose": psOutput,
+				"stats": `{"ID":"aaaaaaaaaaaa","Name":"myapp-php-1","CPUPerc":"12.50%","MemUsage":"256MiB / 2GiB","MemPerc":"12.50%","NetIO":"1.5kB / 3MB","BlockIO":"0B / 4.1MB"}
+{"ID":"bbbbbbbbbbbb","Name":"myapp-mysql-1","CPUPerc":"0.30%","MemUsage":"512MiB / 1GiB","MemPerc":"50.00%","NetIO":"0B / 0B","BlockIO":"10MB / 0B"}`,
+			})
+
+			project := &ComposeProject{Name: "myapp", Dir: "/src/myapp"}
+			stats, err := project.Stats()
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"compose", "--project-name", "myapp", "ps", "--format", "json"}, (*calls)[0])
+			assert.Equal(t, []string{"stats", "--no-stream", "--format", "{{json .}}", "aaaaaaaaaaaa1111", "bbbbbbbbbbbb2222"}, (*calls)[1])
+
+			require.Len(t, stats, 2)
+			assert.Equal(t, ContainerStats{
+				Service:       "mysql",
+				Container:     "myapp-mysql-1",
+				CPUPercent:    0.3,
+				MemoryUsage:   512 << 20,
+				MemoryLimit:   1 << 30,
+				MemoryPercent: 50,
+				BlockRead:     10_000_000,
+			}, stats[0])
+			assert.Equal(t, "php", stats[1].Service)
+			assert.Equal(t, 12.5, stats[1].CPUPercent)
+			assert.Equal(t, uint64(1500), stats[1].NetworkRx)
+			assert.Equal(t, uint64(3_000_000), stats[1].NetworkTx)
+			assert.Equal(t, uint64(2<<30), stats[1].MemoryLimit)
+		})
+	}
+}
+
+func TestComposeProject_Stats_NoContainers(t *testing.T) {
+	calls := stubDockerOutput(t, map[string]string{"compose": ""})
+
+	stats, err := (&ComposeProject{Name: "myapp"}).Stats()
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+	assert.Len(t, *calls, 1, "docker stats is not called without containers")
+}
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]uint64{
+		"0B":       0,
+		"512B":     512,
+		"1.5kB":    1500,
+		"2KiB":     2048,
+		"3MB":      3_000_000,
+		"1.5MiB":   1572864,
+		"1GiB":     1 << 30,
+		" 2GB ":    2_000_000_000,
+		"garbage":  0,
+		"":         0,
+		"1.2TiB":   1319413953331,
+		"42":       42,
+		"7.5 MiB":  7864320,
+		"not-a-kB": 0,
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, parseSize(input), input)
+	}
+}
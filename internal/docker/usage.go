@@ -0,0 +1,109 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// VolumeUsage is the disk space a volume takes
+type VolumeUsage struct {
+	Name   string            `json:"name" yaml:"name"`
+	Bytes  uint64            `json:"bytes" yaml:"bytes"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// ImageUsage is the disk space an image takes
+type ImageUsage struct {
+	ID        string `json:"id" yaml:"id"`
+	Reference string `json:"reference" yaml:"reference"`
+	Bytes     uint64 `json:"bytes" yaml:"bytes"`
+}
+
+// systemDF is the output of `docker system df -v --format json`
+type systemDF struct {
+	Volumes []struct {
+		Name   string `json:"Name"`
+		Size   string `json:"Size"`
+		Labels string `json:"Labels"`
+	} `json:"Volumes"`
+}
+
+// imageRowJSON is a row of `docker image ls --format json`
+type imageRowJSON struct {
+	ID         string `json:"ID"`
+	Repository string `json:"Repository"`
+	Tag        string `json:"Tag"`
+	Size       string `json:"Size"`
+}
+
+// VolumeSizes returns the volumes on the machine with their sizes, sorted
+// by name. Docker computes the sizes, which takes a moment with many
+// volumes.
+func VolumeSizes() ([]VolumeUsage, error) {
+	out, err := dockerOutput("", "system", "df", "--verbose", "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	var df systemDF
+	if err := json.Unmarshal(bytes.TrimSpace(out), &df); err != nil {
+		return nil, glideErrors.NewDockerError("failed to parse docker system df output", glideErrors.WithError(err))
+	}
+	volumes := make([]VolumeUsage, 0, len(df.Volumes))
+	for _, v := range df.Volumes {
+		volumes = append(volumes, VolumeUsage{
+			Name:   v.Name,
+			Bytes:  parseSize(v.Size),
+			Labels: parseLabelList(v.Labels),
+		})
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].Name < volumes[j].Name })
+	return volumes, nil
+}
+
+// ComposeImages returns the images compose built for a compose project,
+// which it labels with the project name
+func ComposeImages(project string) ([]ImageUsage, error) {
+	out, err := dockerOutput("", "image", "ls", "--filter", "label="+ComposeProjectLabel+"="+project, "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	var images []ImageUsage
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var row imageRowJSON
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return nil, glideErrors.NewDockerError("failed to parse docker image ls output", glideErrors.WithError(err))
+		}
+		reference := row.Repository
+		if row.Tag != "" && row.Tag != "<none>" {
+			reference += ":" + row.Tag
+		}
+		if reference == "<none>" {
+			reference = row.ID
+		}
+		images = append(images, ImageUsage{ID: row.ID, Reference: reference, Bytes: parseSize(row.Size)})
+	}
+	return images, nil
+}
+
+// parseLabelList parses labels docker lists as "key=value,key=value"
+func parseLabelList(s string) map[string]string {
+	if s == "" {
+		return nil
+	}
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		key, value, _ := strings.Cut(pair, "=")
+		labels[key] = value
+	}
+	return labels
+}
@@ -0,0 +1,43 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeSizes(t *testing.T) {
+	stubDockerOutput(t, map[string]string{
+		"system df": `{"Images":[],"Volumes":[
+			{"Name":"shop-feature-x_mysql","Size":"1.5GB","Labels":"com.docker.compose.project=shop-feature-x,dev.glide.worktree=feature-x"},
+			{"Name":"anonymous","Size":"0B","Labels":""}
+		]}`,
+	})
+
+	volumes, err := VolumeSizes()
+	require.NoError(t, err)
+	assert.Equal(t, []VolumeUsage{
+		{Name: "anonymous"},
+		{Name: "shop-feature-x_mysql", Bytes: 1.5e9, Labels: map[string]string{
+			ComposeProjectLabel: "shop-feature-x",
+			LabelWorktree:       "feature-x",
+		}},
+	}, volumes)
+}
+
+func TestComposeImages(t *testing.T) {
+	calls := stubDockerOutput(t, map[string]string{
+		"image ls": `{"ID":"abc123","Repository":"shop-feature-x-php","Tag":"latest","Size":"512MB"}
+{"ID":"def456","Repository":"<none>","Tag":"<none>","Size":"1.2kB"}
+`,
+	})
+
+	images, err := ComposeImages("shop-feature-x")
+	require.NoError(t, err)
+	assert.Equal(t, []ImageUsage{
+		{ID: "abc123", Reference: "shop-feature-x-php:latest", Bytes: 512e6},
+		{ID: "def456", Reference: "def456", Bytes: 1200},
+	}, images)
+	assert.Contains(t, (*calls)[0], "label="+ComposeProjectLabel+"=shop-feature-x")
+}
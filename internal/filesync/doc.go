@@ -0,0 +1,36 @@
+// Package filesync syncs project files into running containers.
+//
+// Bind mounts are slow on macOS because every file access crosses the
+// VM boundary. Instead of mounting source code, services can be configured
+// in .glide.yml to receive a copy of it:
+//
+//	sync:
+//	  mode: mutagen
+//	  services:
+//	    php:
+//	      source: .
+//	      target: /var/www/html
+//	      ignore: [node_modules]
+//
+// Two modes are supported:
+//
+//   - mutagen: a continuous two-way session per service, labelled with the
+//     compose project name so all of a project's sessions can be listed
+//     and terminated together
+//   - rsync: a one-shot copy over `docker exec`; the result is recorded in a
+//     state file so it shows up in `glide sync status`
+//
+// # Usage
+//
+//	manager := filesync.NewManager(worktreeDir, stateFile, cfg.Sync)
+//	if manager.Enabled() {
+//	    if err := manager.Start(); err != nil {
+//	        return err
+//	    }
+//	}
+//
+//	statuses, err := manager.Status()
+//
+// The CLI starts sync after `glide up` succeeds and stops it before
+// `glide down`.
+package filesync
@@ -0,0 +1,474 @@
+package filesync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/ignore"
+)
+
+// Sync modes
+const (
+	ModeMutagen = "mutagen"
+	ModeRsync   = "rsync"
+)
+
+// DefaultMode is used when neither the sync config nor a service sets a mode
+const DefaultMode = ModeMutagen
+
+// Session states reported by Status
+const (
+	StateWatching = "watching"
+	StateSynced   = "synced"
+	StateStopped  = "stopped"
+	StateError    = "error"
+)
+
+// labelKey groups a project's mutagen sessions so they can be listed and
+// terminated together
+const labelKey = "glide-project"
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9-]+`)
+
+// Status describes the sync of a single service
+type Status struct {
+	Service  string    `json:"service" yaml:"service"`
+	Mode     string    `json:"mode" yaml:"mode"`
+	Source   string    `json:"source" yaml:"source"`
+	Target   string    `json:"target" yaml:"target"`
+	State    string    `json:"state" yaml:"state"`
+	LastSync time.Time `json:"last_sync,omitempty" yaml:"last_sync,omitempty"`
+	Error    string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// rsyncState is persisted between runs, since rsync syncs once and exits
+type rsyncState struct {
+	LastSync time.Time `json:"last_sync"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Manager starts, stops, and reports on the sync sessions of one worktree
+type Manager struct {
+	worktreeDir string
+	stateFile   string
+	cfg         config.SyncConfig
+
+	// The following are replaced in tests
+	run         func(cmd *exec.Cmd) error
+	output      func(cmd *exec.Cmd) ([]byte, error)
+	lookPath    func(file string) (string, error)
+	loadProject func(dir string) (*docker.ComposeProject, error)
+}
+
+// NewManager creates a manager for the compose project in worktreeDir.
+// stateFile records the result of rsync runs.
+func NewManager(worktreeDir, stateFile string, cfg config.SyncConfig) *Manager {
+	return &Manager{
+		worktreeDir: worktreeDir,
+		stateFile:   stateFile,
+		cfg:         cfg,
+		run:         func(cmd *exec.Cmd) error { return cmd.Run() },
+		output:      func(cmd *exec.Cmd) ([]byte, error) { return cmd.Output() },
+		lookPath:    exec.LookPath,
+		loadProject: func(dir string) (*docker.ComposeProject, error) {
+			return docker.LoadComposeProject(dir)
+		},
+	}
+}
+
+// Enabled reports whether any service is configured for sync
+func (m *Manager) Enabled() bool {
+	return len(m.cfg.Services) > 0
+}
+
+// Services returns the synced service names in sorted order
+func (m *Manager) Services() []string {
+	names := make([]string, 0, len(m.cfg.Services))
+	for name := range m.cfg.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Start syncs every configured service. Mutagen sessions keep watching for
+// changes until Stop; rsync copies once. The services must be running.
+func (m *Manager) Start() error {
+	if !m.Enabled() {
+		return nil
+	}
+	if err := m.validate(); err != nil {
+		return err
+	}
+
+	project, err := m.loadProject(m.worktreeDir)
+	if err != nil {
+		return err
+	}
+
+	// Replace sessions left over from a previous start
+	if err := m.stopMutagen(project); err != nil {
+		return err
+	}
+
+	state := m.readRsyncState()
+	for _, service := range m.Services() {
+		svc := m.cfg.Services[service]
+
+		container, err := m.containerID(project, service)
+		if err != nil {
+			return err
+		}
+
+		switch m.mode(svc) {
+		case ModeMutagen:
+			if err := m.startMutagen(project, service, svc, container); err != nil {
+				return err
+			}
+		case ModeRsync:
+			if err := m.runRsync(service, svc, container); err != nil {
+				state[service] = rsyncState{Error: err.Error()}
+				m.writeRsyncState(state)
+				return err
+			}
+			state[service] = rsyncState{LastSync: time.Now().UTC()}
+		}
+	}
+
+	m.writeRsyncState(state)
+	return nil
+}
+
+// Stop terminates the project's mutagen sessions. rsync has nothing running
+// between syncs, so only its recorded state is cleared.
+func (m *Manager) Stop() error {
+	if !m.Enabled() {
+		return nil
+	}
+
+	if m.usesMode(ModeMutagen) {
+		project, err := m.loadProject(m.worktreeDir)
+		if err != nil {
+			return err
+		}
+		if err := m.stopMutagen(project); err != nil {
+			return err
+		}
+	}
+
+	if m.stateFile != "" {
+		if err := os.Remove(m.stateFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Status reports the state of every configured service
+func (m *Manager) Status() ([]Status, error) {
+	if !m.Enabled() {
+		return nil, nil
+	}
+
+	var sessions map[string]mutagenSession
+	if m.usesMode(ModeMutagen) {
+		project, err := m.loadProject(m.worktreeDir)
+		if err != nil {
+			return nil, err
+		}
+		sessions, err = m.listMutagen(project)
+		if err != nil {
+			return nil, err
+		}
+	}
+	state := m.readRsyncState()
+
+	statuses := make([]Status, 0, len(m.cfg.Services))
+	for _, service := range m.Services() {
+		svc := m.cfg.Services[service]
+		status := Status{
+			Service: service,
+			Mode:    m.mode(svc),
+			Source:  m.source(svc),
+			Target:  svc.Target,
+			State:   StateStopped,
+		}
+
+		switch status.Mode {
+		case ModeMutagen:
+			if session, ok := sessions[service]; ok {
+				status.State = session.state()
+				status.Error = session.LastError
+			}
+		case ModeRsync:
+			if s, ok := state[service]; ok {
+				status.LastSync = s.LastSync
+				status.Error = s.Error
+				status.State = StateSynced
+				if s.Error != "" {
+					status.State = StateError
+				}
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// validate checks the configuration and that the sync tools are installed
+func (m *Manager) validate() error {
+	for _, service := range m.Services() {
+		svc := m.cfg.Services[service]
+
+		mode := m.mode(svc)
+		if mode != ModeMutagen && mode != ModeRsync {
+			return glideErrors.NewConfigError(fmt.Sprintf("unknown sync mode %q for service %s", mode, service),
+				glideErrors.WithSuggestions("Set sync.mode to mutagen or rsync in .glide.yml"),
+			)
+		}
+		if !strings.HasPrefix(svc.Target, "/") {
+			return glideErrors.NewConfigError(fmt.Sprintf("sync target for service %s must be an absolute container path", service),
+				glideErrors.WithSuggestions(fmt.Sprintf("Set sync.services.%s.target, e.g. /var/www/html", service)),
+			)
+		}
+		if _, err := m.lookPath(mode); err != nil {
+			return glideErrors.NewDependencyError(mode, fmt.Sprintf("%s is required to sync service %s", mode, service),
+				glideErrors.WithSuggestions(installHint(mode)),
+			)
+		}
+	}
+	return nil
+}
+
+// containerID returns the running container of a compose service
+func (m *Manager) containerID(project *docker.ComposeProject, service string) (string, error) {
+	cmd := exec.Command("docker", project.ComposeArgs("ps", "-q", service)...)
+	cmd.Dir = m.worktreeDir
+	out, err := m.output(cmd)
+	id := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	if err != nil || id == "" {
+		return "", glideErrors.NewContainerError(service, fmt.Sprintf("service %s is not running, so it cannot be synced", service),
+			glideErrors.WithSuggestions("Start the project first: glide up"),
+		)
+	}
+	return id, nil
+}
+
+// startMutagen creates a two-way mutagen session into the container
+func (m *Manager) startMutagen(project *docker.ComposeProject, service string, svc config.SyncService, container string) error {
+	args := []string{"sync", "create",
+		"--name", sessionName(project, service),
+		"--label", labelKey + "=" + labelValue(project),
+		"--sync-mode", "two-way-resolved",
+		"--ignore-vcs",
+	}
+	for _, pattern := range m.ignores(svc) {
+		args = append(args, "--ignore", pattern)
+	}
+	args = append(args, m.source(svc), "docker://"+container+svc.Target)
+
+	cmd := exec.Command("mutagen", args...)
+	cmd.Dir = m.worktreeDir
+	cmd.Stderr = os.Stderr
+	if err := m.run(cmd); err != nil {
+		return glideErrors.New(glideErrors.TypeCommand, fmt.Sprintf("failed to start mutagen sync for service %s", service),
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions("Check the mutagen daemon: mutagen daemon start"),
+		)
+	}
+	return nil
+}
+
+// stopMutagen terminates all of the project's mutagen sessions
+func (m *Manager) stopMutagen(project *docker.ComposeProject) error {
+	if !m.usesMode(ModeMutagen) {
+		return nil
+	}
+
+	cmd := exec.Command("mutagen", "sync", "terminate", "--label-selector", labelKey+"="+labelValue(project))
+	cmd.Dir = m.worktreeDir
+	if err := m.run(cmd); err != nil {
+		return glideErrors.New(glideErrors.TypeCommand, "failed to stop mutagen sync sessions",
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions("List sessions with: mutagen sync list"),
+		)
+	}
+	return nil
+}
+
+// mutagenSession is the subset of `mutagen sync list` JSON output used here
+type mutagenSession struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels"`
+	Status    string            `json:"status"`
+	Paused    bool              `json:"paused"`
+	LastError string            `json:"lastError"`
+}
+
+// state maps a mutagen session to a sync state
+func (s mutagenSession) state() string {
+	switch {
+	case s.LastError != "":
+		return StateError
+	case s.Paused:
+		return StateStopped
+	case s.Status == "":
+		return StateWatching
+	default:
+		return s.Status
+	}
+}
+
+// listMutagen returns the project's mutagen sessions keyed by service
+func (m *Manager) listMutagen(project *docker.ComposeProject) (map[string]mutagenSession, error) {
+	cmd := exec.Command("mutagen", "sync", "list",
+		"--label-selector", labelKey+"="+labelValue(project),
+		"--template", "{{ json . }}")
+	cmd.Dir = m.worktreeDir
+	out, err := m.output(cmd)
+	if err != nil {
+		// No daemon or no sessions: nothing is syncing
+		return nil, nil
+	}
+
+	var list []mutagenSession
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, glideErrors.New(glideErrors.TypeCommand, "failed to parse mutagen session list", glideErrors.WithError(err))
+	}
+
+	sessions := make(map[string]mutagenSession, len(list))
+	for _, session := range list {
+		for _, service := range m.Services() {
+			if session.Name == sessionName(project, service) {
+				sessions[service] = session
+			}
+		}
+	}
+	return sessions, nil
+}
+
+// runRsync copies the source directory into the container once. rsync must
+// be installed in the container as well.
+func (m *Manager) runRsync(service string, svc config.SyncService, container string) error {
+	args := []string{"-az", "--delete", "--blocking-io", "-e", "docker exec -i"}
+	for _, pattern := range m.ignores(svc) {
+		// rsync has no re-include syntax for excludes
+		if !strings.HasPrefix(pattern, "!") {
+			args = append(args, "--exclude", pattern)
+		}
+	}
+	args = append(args,
+		strings.TrimSuffix(m.source(svc), "/")+"/",
+		container+":"+strings.TrimSuffix(svc.Target, "/")+"/")
+
+	cmd := exec.Command("rsync", args...)
+	cmd.Dir = m.worktreeDir
+	cmd.Stderr = os.Stderr
+	if err := m.run(cmd); err != nil {
+		return glideErrors.New(glideErrors.TypeCommand, fmt.Sprintf("rsync into service %s failed", service),
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions(fmt.Sprintf("Make sure rsync is installed in the %s image", service)),
+		)
+	}
+	return nil
+}
+
+// readRsyncState loads recorded rsync results
+func (m *Manager) readRsyncState() map[string]rsyncState {
+	state := make(map[string]rsyncState)
+	if m.stateFile == "" {
+		return state
+	}
+	data, err := os.ReadFile(m.stateFile)
+	if err != nil {
+		return state
+	}
+	_ = json.Unmarshal(data, &state)
+	return state
+}
+
+// writeRsyncState records rsync results; failures only affect `sync status`
+func (m *Manager) writeRsyncState(state map[string]rsyncState) {
+	if m.stateFile == "" || !m.usesMode(ModeRsync) {
+		return
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(m.stateFile), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(m.stateFile, data, 0644)
+}
+
+// mode returns the effective sync mode of a service
+func (m *Manager) mode(svc config.SyncService) string {
+	switch {
+	case svc.Mode != "":
+		return svc.Mode
+	case m.cfg.Mode != "":
+		return m.cfg.Mode
+	default:
+		return DefaultMode
+	}
+}
+
+// usesMode reports whether any service uses the given mode
+func (m *Manager) usesMode(mode string) bool {
+	for _, svc := range m.cfg.Services {
+		if m.mode(svc) == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// source returns the absolute host directory synced into a service
+func (m *Manager) source(svc config.SyncService) string {
+	source := svc.Source
+	if source == "" {
+		source = "."
+	}
+	if filepath.IsAbs(source) {
+		return source
+	}
+	return filepath.Join(m.worktreeDir, source)
+}
+
+// ignores returns the paths left out of a service's sync: the worktree's
+// .glideignore patterns, then the service's own
+func (m *Manager) ignores(svc config.SyncService) []string {
+	// Safe to ignore: without a .glideignore only the service's patterns apply
+	patterns, _ := ignore.ReadFile(filepath.Join(m.worktreeDir, ignore.FileName))
+	return append(patterns, svc.Ignore...)
+}
+
+// sessionName returns the mutagen session name of a service
+func sessionName(project *docker.ComposeProject, service string) string {
+	return "glide-" + labelValue(project) + "-" + unsafeNameChars.ReplaceAllString(service, "-")
+}
+
+// labelValue identifies a compose project in mutagen labels
+func labelValue(project *docker.ComposeProject) string {
+	return unsafeNameChars.ReplaceAllString(project.Name, "-")
+}
+
+// installHint suggests how to install a sync tool
+func installHint(mode string) string {
+	if mode == ModeMutagen {
+		return "Install mutagen: brew install mutagen-io/mutagen/mutagen"
+	}
+	return "Install rsync with your package manager, e.g. brew install rsync"
+}
@@ -0,0 +1,224 @@
+package filesync
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTools records commands instead of running them
+type fakeTools struct {
+	commands   []string
+	containers map[string]string
+	sessions   string
+	fail       string
+}
+
+func (f *fakeTools) run(cmd *exec.Cmd) error {
+	line := strings.Join(cmd.Args, " ")
+	f.commands = append(f.commands, line)
+	if f.fail != "" && strings.HasPrefix(line, f.fail) {
+		return errors.New("exit status 1")
+	}
+	return nil
+}
+
+func (f *fakeTools) output(cmd *exec.Cmd) ([]byte, error) {
+	line := strings.Join(cmd.Args, " ")
+	f.commands = append(f.commands, line)
+
+	switch {
+	case strings.Contains(line, " ps -q "):
+		service := cmd.Args[len(cmd.Args)-1]
+		return []byte(f.containers[service] + "\n"), nil
+	case strings.HasPrefix(line, "mutagen sync list"):
+		if f.sessions == "" {
+			return nil, errors.New("no sessions")
+		}
+		return []byte(f.sessions), nil
+	}
+	return nil, nil
+}
+
+func newTestManager(t *testing.T, cfg config.SyncConfig) (*Manager, *fakeTools, string) {
+	t.Helper()
+
+	worktreeDir := t.TempDir()
+	stateFile := filepath.Join(t.TempDir(), "sync", "vcs.json")
+
+	fake := &fakeTools{containers: map[string]string{"php": "abc123", "node": "def456"}}
+	m := NewManager(worktreeDir, stateFile, cfg)
+	m.run = fake.run
+	m.output = fake.output
+	m.lookPath = func(file string) (string, error) { return "/usr/bin/" + file, nil }
+	m.loadProject = func(dir string) (*docker.ComposeProject, error) {
+		return &docker.ComposeProject{Name: "my_app", Dir: dir}, nil
+	}
+	return m, fake, worktreeDir
+}
+
+func TestStart_Mutagen(t *testing.T) {
+	m, fake, worktreeDir := newTestManager(t, config.SyncConfig{
+		Services: map[string]config.SyncService{
+			"php": {Target: "/var/www/html", Ignore: []string{"node_modules"}},
+		},
+	})
+
+	require.NoError(t, m.Start())
+
+	assert.Equal(t, []string{
+		"mutagen sync terminate --label-selector glide-project=my-app",
+		"docker compose --project-name my_app ps -q php",
+		"mutagen sync create --name glide-my-app-php --label glide-project=my-app --sync-mode two-way-resolved --ignore-vcs --ignore node_modules " +
+			worktreeDir + " docker://abc123/var/www/html",
+	}, fake.commands)
+}
+
+func TestStart_Rsync(t *testing.T) {
+	m, fake, worktreeDir := newTestManager(t, config.SyncConfig{
+		Mode: ModeRsync,
+		Services: map[string]config.SyncService{
+			"node": {Source: "frontend", Target: "/app/", Ignore: []string{"dist"}},
+		},
+	})
+
+	require.NoError(t, m.Start())
+
+	assert.Equal(t, []string{
+		"docker compose --project-name my_app ps -q node",
+		"rsync -az --delete --blocking-io -e docker exec -i --exclude dist " +
+			filepath.Join(worktreeDir, "frontend") + "/ def456:/app/",
+	}, fake.commands)
+
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, StateSynced, statuses[0].State)
+	assert.False(t, statuses[0].LastSync.IsZero())
+}
+
+func TestStart_Glideignore(t *testing.T) {
+	m, fake, worktreeDir := newTestManager(t, config.SyncConfig{
+		Mode: ModeRsync,
+		Services: map[string]config.SyncService{
+			"node": {Target: "/app/", Ignore: []string{"dist"}},
+		},
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeDir, ".glideignore"), []byte("# caches\n.cache/\n!keep.log\n"), 0644))
+
+	require.NoError(t, m.Start())
+
+	assert.Contains(t, fake.commands[len(fake.commands)-1], "--exclude .cache/ --exclude dist ")
+	assert.NotContains(t, fake.commands[len(fake.commands)-1], "keep.log")
+}
+
+func TestStart_Errors(t *testing.T) {
+	t.Run("relative target", func(t *testing.T) {
+		m, _, _ := newTestManager(t, config.SyncConfig{
+			Services: map[string]config.SyncService{"php": {Target: "var/www"}},
+		})
+		assert.True(t, glideErrors.Is(m.Start(), glideErrors.TypeConfig))
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		m, _, _ := newTestManager(t, config.SyncConfig{
+			Mode:     "unison",
+			Services: map[string]config.SyncService{"php": {Target: "/var/www"}},
+		})
+		assert.True(t, glideErrors.Is(m.Start(), glideErrors.TypeConfig))
+	})
+
+	t.Run("tool not installed", func(t *testing.T) {
+		m, _, _ := newTestManager(t, config.SyncConfig{
+			Services: map[string]config.SyncService{"php": {Target: "/var/www"}},
+		})
+		m.lookPath = func(file string) (string, error) { return "", exec.ErrNotFound }
+		assert.True(t, glideErrors.Is(m.Start(), glideErrors.TypeDependency))
+	})
+
+	t.Run("service not running", func(t *testing.T) {
+		m, _, _ := newTestManager(t, config.SyncConfig{
+			Services: map[string]config.SyncService{"mysql": {Target: "/var/lib/mysql"}},
+		})
+		assert.True(t, glideErrors.Is(m.Start(), glideErrors.TypeContainer))
+	})
+
+	t.Run("rsync failure is recorded", func(t *testing.T) {
+		m, fake, _ := newTestManager(t, config.SyncConfig{
+			Mode:     ModeRsync,
+			Services: map[string]config.SyncService{"php": {Target: "/var/www"}},
+		})
+		fake.fail = "rsync"
+		assert.Error(t, m.Start())
+
+		statuses, err := m.Status()
+		require.NoError(t, err)
+		assert.Equal(t, StateError, statuses[0].State)
+		assert.NotEmpty(t, statuses[0].Error)
+	})
+}
+
+func TestStop(t *testing.T) {
+	m, fake, _ := newTestManager(t, config.SyncConfig{
+		Services: map[string]config.SyncService{
+			"php":  {Target: "/var/www/html"},
+			"node": {Mode: ModeRsync, Target: "/app"},
+		},
+	})
+	require.NoError(t, m.Start())
+	require.FileExists(t, m.stateFile)
+	fake.commands = nil
+
+	require.NoError(t, m.Stop())
+	assert.Equal(t, []string{"mutagen sync terminate --label-selector glide-project=my-app"}, fake.commands)
+
+	_, err := os.Stat(m.stateFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestStatus_Mutagen(t *testing.T) {
+	m, fake, _ := newTestManager(t, config.SyncConfig{
+		Services: map[string]config.SyncService{
+			"php":  {Target: "/var/www/html"},
+			"node": {Target: "/app"},
+		},
+	})
+
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, StateStopped, statuses[0].State, "no daemon means nothing is syncing")
+
+	fake.sessions = `[{"name": "glide-my-app-php", "status": "watching"},
+		{"name": "glide-my-app-node", "status": "scanning", "lastError": "permission denied"}]`
+
+	statuses, err = m.Status()
+	require.NoError(t, err)
+	assert.Equal(t, "node", statuses[0].Service)
+	assert.Equal(t, StateError, statuses[0].State)
+	assert.Equal(t, "permission denied", statuses[0].Error)
+	assert.Equal(t, "php", statuses[1].Service)
+	assert.Equal(t, StateWatching, statuses[1].State)
+	assert.Equal(t, "/var/www/html", statuses[1].Target)
+}
+
+func TestDisabled(t *testing.T) {
+	m, fake, _ := newTestManager(t, config.SyncConfig{})
+
+	assert.False(t, m.Enabled())
+	assert.NoError(t, m.Start())
+	assert.NoError(t, m.Stop())
+	statuses, err := m.Status()
+	assert.NoError(t, err)
+	assert.Empty(t, statuses)
+	assert.Empty(t, fake.commands)
+}
@@ -0,0 +1,135 @@
+// Package forge looks up pull requests and issues on the code hosting
+// service a repository's remote points to: GitHub (including GitHub
+// Enterprise) or GitLab.
+//
+//	client, err := forge.ForRemote("git@github.com:acme/app.git")
+//	if err != nil {
+//	    return err
+//	}
+//	pr, err := client.PullRequest(ctx, 42)
+//
+// API tokens come from GITHUB_TOKEN or GH_TOKEN (GitHub), GITLAB_TOKEN
+// (GitLab), or else from git's credential helper for the host. Public
+// repositories work without a token.
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// requestTimeout bounds every API request
+const requestTimeout = 10 * time.Second
+
+// PullRequest is a GitHub pull request or GitLab merge request
+type PullRequest struct {
+	Number int
+	Title  string
+	URL    string
+	State  string
+	// Branch is the source branch name
+	Branch string
+	// FromFork is true when the source branch lives in another repository
+	FromFork bool
+	// Ref is the ref of the head commit in the target repository, which
+	// can be fetched even for forks
+	Ref string
+}
+
+// Issue is an issue of the repository
+type Issue struct {
+	Number int
+	Title  string
+	URL    string
+	State  string
+}
+
+// Client looks up pull requests and issues of one repository
+type Client interface {
+	PullRequest(ctx context.Context, number int) (*PullRequest, error)
+	Issue(ctx context.Context, number int) (*Issue, error)
+}
+
+// Remote identifies a repository on a hosting service
+type Remote struct {
+	// Host is the hostname, e.g. "github.com"
+	Host string
+	// Path is the repository path without .git, e.g. "acme/app" or
+	// "group/subgroup/app" on GitLab
+	Path string
+}
+
+// ParseRemote parses a git remote URL in scp-like (git@host:path), ssh://,
+// or https:// form
+func ParseRemote(remoteURL string) (Remote, error) {
+	raw := strings.TrimSpace(remoteURL)
+	var host, path string
+
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return Remote{}, fmt.Errorf("invalid remote URL %q: %w", remoteURL, err)
+		}
+		host, path = u.Hostname(), u.Path
+	} else if at, rest, ok := strings.Cut(raw, ":"); ok && !strings.Contains(at, "/") {
+		// scp-like: [user@]host:path
+		if _, h, ok := strings.Cut(at, "@"); ok {
+			at = h
+		}
+		host, path = at, rest
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || !strings.Contains(path, "/") {
+		return Remote{}, fmt.Errorf("cannot tell the repository from remote URL %q", remoteURL)
+	}
+	return Remote{Host: host, Path: path}, nil
+}
+
+// ForRemote returns a client for the service a remote URL points to
+func ForRemote(remoteURL string) (Client, error) {
+	remote, err := ParseRemote(remoteURL)
+	if err != nil {
+		return nil, glideErrors.New(glideErrors.TypeConfig, err.Error(),
+			glideErrors.WithSuggestions("Check the origin remote: git remote get-url origin"),
+		)
+	}
+
+	switch {
+	case strings.Contains(remote.Host, "github"):
+		return NewGitHub(remote, ""), nil
+	case strings.Contains(remote.Host, "gitlab"):
+		return NewGitLab(remote, ""), nil
+	default:
+		return nil, glideErrors.New(glideErrors.TypeConfig,
+			fmt.Sprintf("%s is not a supported hosting service", remote.Host),
+			glideErrors.WithSuggestions("Pull requests and issues can be looked up on GitHub and GitLab"),
+		)
+	}
+}
+
+// apiError converts an unexpected API response into an error
+func apiError(service, what string, status int) error {
+	switch status {
+	case 401, 403:
+		return glideErrors.New(glideErrors.TypePermission,
+			fmt.Sprintf("%s refused access to %s (status %d)", service, what, status),
+			glideErrors.WithSuggestions(
+				"Set a token with read access, e.g. GITHUB_TOKEN or GITLAB_TOKEN",
+				"Or store one with your git credential helper",
+			),
+		)
+	case 404:
+		return glideErrors.New(glideErrors.TypeFileNotFound,
+			fmt.Sprintf("%s not found on %s", what, service),
+			glideErrors.WithSuggestions("Check the number, and that your token can read private repositories"),
+		)
+	default:
+		return glideErrors.NewNetworkError(fmt.Sprintf("%s returned status %d for %s", service, status, what))
+	}
+}
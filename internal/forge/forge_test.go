@@ -0,0 +1,137 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemote(t *testing.T) {
+	tests := []struct {
+		url  string
+		want Remote
+	}{
+		{"git@github.com:acme/app.git", Remote{Host: "github.com", Path: "acme/app"}},
+		{"https://github.com/acme/app", Remote{Host: "github.com", Path: "acme/app"}},
+		{"https://user@github.example.com/acme/app.git", Remote{Host: "github.example.com", Path: "acme/app"}},
+		{"ssh://git@gitlab.com:2222/group/sub/app.git", Remote{Host: "gitlab.com", Path: "group/sub/app"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := ParseRemote(tt.url)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, bad := range []string{"", "/srv/git/app.git", "https://github.com/app"} {
+		_, err := ParseRemote(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestForRemote(t *testing.T) {
+	client, err := ForRemote("git@github.com:acme/app.git")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.github.com", client.(*GitHub).BaseURL)
+
+	client, err = ForRemote("git@github.acme.com:acme/app.git")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.acme.com/api/v3", client.(*GitHub).BaseURL)
+
+	client, err = ForRemote("https://gitlab.com/group/app.git")
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitlab.com/api/v4", client.(*GitLab).BaseURL)
+
+	_, err = ForRemote("git@bitbucket.org:acme/app.git")
+	assert.Error(t, err)
+}
+
+// serve starts an API server answering path with body and returns its URL
+func serve(t *testing.T, path, body string, check func(*http.Request)) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != path {
+			http.NotFound(w, r)
+			return
+		}
+		if check != nil {
+			check(r)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestGitHub_PullRequest(t *testing.T) {
+	body := `{"number": 42, "title": "Add API", "html_url": "https://github.com/acme/app/pull/42", "state": "open",
+		"head": {"ref": "feature/api", "repo": {"full_name": "someone/app"}}}`
+	gh := NewGitHub(Remote{Host: "github.com", Path: "acme/app"}, "secret")
+	gh.BaseURL = serve(t, "/repos/acme/app/pulls/42", body, func(r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+	})
+
+	pr, err := gh.PullRequest(context.Background(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "feature/api", pr.Branch)
+	assert.True(t, pr.FromFork)
+	assert.Equal(t, "refs/pull/42/head", pr.Ref)
+	assert.Equal(t, "Add API", pr.Title)
+}
+
+func TestGitHub_Issue(t *testing.T) {
+	gh := NewGitHub(Remote{Host: "github.com", Path: "acme/app"}, "secret")
+	gh.BaseURL = serve(t, "/repos/acme/app/issues/7", `{"number": 7, "title": "Login fails", "state": "open"}`, nil)
+
+	issue, err := gh.Issue(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Login fails", issue.Title)
+
+	_, err = gh.Issue(context.Background(), 8)
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeFileNotFound))
+}
+
+func TestGitLab_PullRequest(t *testing.T) {
+	body := `{"iid": 5, "title": "Fix cache", "web_url": "https://gitlab.com/group/sub/app/-/merge_requests/5",
+		"state": "opened", "source_branch": "fix-cache", "source_project_id": 1, "target_project_id": 1}`
+	gl := NewGitLab(Remote{Host: "gitlab.com", Path: "group/sub/app"}, "secret")
+	gl.BaseURL = serve(t, "/projects/group%2Fsub%2Fapp/merge_requests/5", body, func(r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+	})
+
+	pr, err := gl.PullRequest(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "fix-cache", pr.Branch)
+	assert.False(t, pr.FromFork)
+	assert.Equal(t, "refs/merge-requests/5/head", pr.Ref)
+}
+
+func TestAPIError(t *testing.T) {
+	assert.True(t, glideErrors.Is(apiError("GitHub", "issue #1", 401), glideErrors.TypePermission))
+	assert.True(t, glideErrors.Is(apiError("GitHub", "issue #1", 404), glideErrors.TypeFileNotFound))
+	assert.True(t, glideErrors.Is(apiError("GitHub", "issue #1", 500), glideErrors.TypeNetwork))
+}
+
+func TestLookupToken(t *testing.T) {
+	original := credentialFill
+	defer func() { credentialFill = original }()
+	credentialFill = func(host string) (string, string) { return "me", "from-" + host }
+
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "gh")
+	assert.Equal(t, "gh", LookupToken("github.com", "GITHUB_TOKEN", "GH_TOKEN"))
+
+	t.Setenv("GH_TOKEN", "")
+	assert.Equal(t, "from-github.com", LookupToken("github.com", "GITHUB_TOKEN", "GH_TOKEN"))
+
+	username, password := LookupCredentials("github.acme.com")
+	assert.Equal(t, "me", username)
+	assert.Equal(t, "from-github.acme.com", password)
+}
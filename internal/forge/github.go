@@ -0,0 +1,112 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/pkg/ratelimit"
+)
+
+// GitHub looks up pull requests and issues on github.com or a GitHub
+// Enterprise server
+type GitHub struct {
+	// BaseURL is the API root, e.g. https://api.github.com
+	BaseURL string
+	Remote  Remote
+	// Token authenticates requests; when empty it is looked up on first use
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewGitHub creates a GitHub client for a repository. An empty token is
+// looked up from the environment or git's credential helper.
+func NewGitHub(remote Remote, token string) *GitHub {
+	baseURL := "https://api.github.com"
+	if remote.Host != "github.com" {
+		baseURL = "https://" + remote.Host + "/api/v3"
+	}
+	return &GitHub{
+		BaseURL:    baseURL,
+		Remote:     remote,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: requestTimeout, Transport: ratelimit.Default.Transport(nil)},
+	}
+}
+
+type githubPull struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+	Head    struct {
+		Ref  string `json:"ref"`
+		Repo *struct {
+			FullName string `json:"full_name"`
+		} `json:"repo"`
+	} `json:"head"`
+}
+
+type githubIssue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	HTMLURL     string    `json:"html_url"`
+	State       string    `json:"state"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// PullRequest returns a pull request
+func (g *GitHub) PullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	var pull githubPull
+	what := fmt.Sprintf("pull request #%d of %s", number, g.Remote.Path)
+	if err := g.get(ctx, fmt.Sprintf("/repos/%s/pulls/%d", g.Remote.Path, number), what, &pull); err != nil {
+		return nil, err
+	}
+
+	// The head repository is gone when a fork was deleted
+	fromFork := pull.Head.Repo == nil || !strings.EqualFold(pull.Head.Repo.FullName, g.Remote.Path)
+	return &PullRequest{
+		Number:   pull.Number,
+		Title:    pull.Title,
+		URL:      pull.HTMLURL,
+		State:    pull.State,
+		Branch:   pull.Head.Ref,
+		FromFork: fromFork,
+		Ref:      fmt.Sprintf("refs/pull/%d/head", pull.Number),
+	}, nil
+}
+
+// Issue returns an issue
+func (g *GitHub) Issue(ctx context.Context, number int) (*Issue, error) {
+	var issue githubIssue
+	what := fmt.Sprintf("issue #%d of %s", number, g.Remote.Path)
+	if err := g.get(ctx, fmt.Sprintf("/repos/%s/issues/%d", g.Remote.Path, number), what, &issue); err != nil {
+		return nil, err
+	}
+	if issue.PullRequest != nil {
+		// GitHub serves pull requests as issues too
+		return nil, fmt.Errorf("#%d is a pull request, not an issue", number)
+	}
+	return &Issue{Number: issue.Number, Title: issue.Title, URL: issue.HTMLURL, State: issue.State}, nil
+}
+
+// get fetches an API path
+func (g *GitHub) get(ctx context.Context, path, what string, v interface{}) error {
+	if g.Token == "" {
+		g.Token = LookupToken(g.Remote.Host, "GITHUB_TOKEN", "GH_TOKEN")
+	}
+	headers := map[string]string{"Accept": "application/vnd.github+json"}
+	if g.Token != "" {
+		headers["Authorization"] = "Bearer " + g.Token
+	}
+
+	status, err := getJSON(ctx, g.HTTPClient, strings.TrimSuffix(g.BaseURL, "/")+path, headers, v)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return apiError("GitHub", what, status)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/pkg/ratelimit"
+)
+
+// GitLab looks up merge requests and issues on gitlab.com or a self-managed
+// GitLab server
+type GitLab struct {
+	// BaseURL is the API root, e.g. https://gitlab.com/api/v4
+	BaseURL string
+	Remote  Remote
+	// Token authenticates requests; when empty it is looked up on first use
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewGitLab creates a GitLab client for a repository. An empty token is
+// looked up from the environment or git's credential helper.
+func NewGitLab(remote Remote, token string) *GitLab {
+	return &GitLab{
+		BaseURL:    "https://" + remote.Host + "/api/v4",
+		Remote:     remote,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: requestTimeout, Transport: ratelimit.Default.Transport(nil)},
+	}
+}
+
+type gitlabMergeRequest struct {
+	IID             int    `json:"iid"`
+	Title           string `json:"title"`
+	WebURL          string `json:"web_url"`
+	State           string `json:"state"`
+	SourceBranch    string `json:"source_branch"`
+	SourceProjectID int    `json:"source_project_id"`
+	TargetProjectID int    `json:"target_project_id"`
+}
+
+type gitlabIssue struct {
+	IID    int    `json:"iid"`
+	Title  string `json:"title"`
+	WebURL string `json:"web_url"`
+	State  string `json:"state"`
+}
+
+// PullRequest returns a merge request
+func (g *GitLab) PullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	var mr gitlabMergeRequest
+	what := fmt.Sprintf("merge request !%d of %s", number, g.Remote.Path)
+	if err := g.get(ctx, fmt.Sprintf("/merge_requests/%d", number), what, &mr); err != nil {
+		return nil, err
+	}
+	return &PullRequest{
+		Number:   mr.IID,
+		Title:    mr.Title,
+		URL:      mr.WebURL,
+		State:    mr.State,
+		Branch:   mr.SourceBranch,
+		FromFork: mr.SourceProjectID != mr.TargetProjectID,
+		Ref:      fmt.Sprintf("refs/merge-requests/%d/head", mr.IID),
+	}, nil
+}
+
+// Issue returns an issue
+func (g *GitLab) Issue(ctx context.Context, number int) (*Issue, error) {
+	var issue gitlabIssue
+	what := fmt.Sprintf("issue #%d of %s", number, g.Remote.Path)
+	if err := g.get(ctx, fmt.Sprintf("/issues/%d", number), what, &issue); err != nil {
+		return nil, err
+	}
+	return &Issue{Number: issue.IID, Title: issue.Title, URL: issue.WebURL, State: issue.State}, nil
+}
+
+// get fetches an API path below the project
+func (g *GitLab) get(ctx context.Context, path, what string, v interface{}) error {
+	if g.Token == "" {
+		g.Token = LookupToken(g.Remote.Host, "GITLAB_TOKEN")
+	}
+	headers := map[string]string{}
+	if g.Token != "" {
+		headers["PRIVATE-TOKEN"] = g.Token
+	}
+
+	endpoint := strings.TrimSuffix(g.BaseURL, "/") + "/projects/" + url.PathEscape(g.Remote.Path) + path
+	status, err := getJSON(ctx, g.HTTPClient, endpoint, headers, v)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return apiError("GitLab", what, status)
+	}
+	return nil
+}
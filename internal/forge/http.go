@@ -0,0 +1,39 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// getJSON fetches url into v with the given headers and returns the
+// response status
+func getJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, v interface{}) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "glide-cli")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, glideErrors.NewNetworkError("request failed: "+url, glideErrors.WithError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return resp.StatusCode, glideErrors.NewNetworkError("invalid response from "+url, glideErrors.WithError(err))
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,52 @@
+package forge
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// credentialFill asks git's credential helper for the credentials of a host
+// and is replaced in tests
+var credentialFill = func(host string) (username, password string) {
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=" + host + "\n\n")
+	// Only use stored credentials, never prompt
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", ""
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), "=")
+		switch key {
+		case "username":
+			username = value
+		case "password":
+			password = value
+		}
+	}
+	return username, password
+}
+
+// LookupToken returns the first of the environment variables that is set,
+// or else the password git's credential helper stores for host, or "" when
+// there is none
+func LookupToken(host string, envVars ...string) string {
+	for _, name := range envVars {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+	_, password := credentialFill(host)
+	return password
+}
+
+// LookupCredentials returns the username and password git's credential
+// helper stores for host, or empty strings when there are none
+func LookupCredentials(host string) (username, password string) {
+	return credentialFill(host)
+}
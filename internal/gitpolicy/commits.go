@@ -0,0 +1,49 @@
+package gitpolicy
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Commit is a commit whose message is checked
+type Commit struct {
+	Hash    string
+	Message string
+}
+
+// Commits lists the commits selected by git log arguments, e.g.
+// "origin/main..HEAD" or "abc123 --not --remotes", in the repository
+// containing dir
+func Commits(dir string, revisions ...string) ([]Commit, error) {
+	args := append([]string{"-C", dir, "log", "--no-merges", "--format=%h%x00%B%x1e"}, revisions...)
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git log %s: %s", strings.Join(revisions, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(string(out), "\x1e") {
+		hash, message, ok := strings.Cut(strings.TrimLeft(record, "\n"), "\x00")
+		if !ok {
+			continue
+		}
+		commits = append(commits, Commit{Hash: hash, Message: message})
+	}
+	return commits, nil
+}
+
+// CheckCommits returns the violations among commits
+func (r Rules) CheckCommits(commits []Commit) []Violation {
+	var violations []Violation
+	for _, commit := range commits {
+		if v := r.CheckCommit(commit.Message); v != nil {
+			v.Commit = commit.Hash
+			violations = append(violations, *v)
+		}
+	}
+	return violations
+}
@@ -0,0 +1,23 @@
+// Package gitpolicy enforces branch and commit naming rules configured in
+// .glide.yml.
+//
+//	# .glide.yml
+//	git_policy:
+//	  branch_pattern: '^(feature|fix|chore)/[a-z0-9-]+$'
+//	  commit_pattern: '^(feat|fix|docs|chore|refactor|test)(\(.+\))?: .+'
+//	  exempt_branches: [main, develop]
+//
+// Rules are checked by `glide policy check`, which CI can run directly, and
+// by git hooks that InstallHooks writes into a repository: a commit-msg hook
+// for commit messages and a pre-push hook for branch names and pushed
+// commits. Messages git generates itself, such as merges, reverts, and
+// fixup! commits, are not checked.
+//
+//	rules, err := gitpolicy.RulesFromConfig(cfg.GitPolicy)
+//	if err != nil {
+//	    return err
+//	}
+//	if v := rules.CheckBranch("feature/login"); v != nil {
+//	    // report the violation
+//	}
+package gitpolicy
@@ -0,0 +1,143 @@
+package gitpolicy
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRules(t *testing.T) Rules {
+	t.Helper()
+	rules, err := RulesFromConfig(config.GitPolicyConfig{
+		BranchPattern: `^(feature|fix)/[a-z0-9-]+$`,
+		CommitPattern: `^(feat|fix|docs): .+`,
+	})
+	require.NoError(t, err)
+	return rules
+}
+
+func TestRulesFromConfig(t *testing.T) {
+	rules, err := RulesFromConfig(config.GitPolicyConfig{})
+	require.NoError(t, err)
+	assert.True(t, rules.Empty())
+	assert.Equal(t, DefaultExemptBranches, rules.ExemptBranches)
+
+	_, err = RulesFromConfig(config.GitPolicyConfig{CommitPattern: "(unclosed"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "git_policy.commit_pattern")
+}
+
+func TestCheckBranch(t *testing.T) {
+	rules := testRules(t)
+
+	assert.Nil(t, rules.CheckBranch("feature/login"))
+	assert.Nil(t, rules.CheckBranch("main"), "exempt branches are not checked")
+
+	v := rules.CheckBranch("my-branch")
+	require.NotNil(t, v)
+	assert.Equal(t, KindBranch, v.Kind)
+	assert.Equal(t, "my-branch", v.Subject)
+}
+
+func TestCheckCommit(t *testing.T) {
+	rules := testRules(t)
+
+	assert.Nil(t, rules.CheckCommit("feat: add login\n\nBody text"))
+	assert.Nil(t, rules.CheckCommit("# Please enter the commit message\nfix: typo\n"), "comment lines are skipped")
+	assert.Nil(t, rules.CheckCommit("Merge branch 'main' into feature/login"))
+	assert.Nil(t, rules.CheckCommit("fixup! feat: add login"))
+	assert.Nil(t, rules.CheckCommit(""), "empty messages are rejected by git itself")
+
+	v := rules.CheckCommit("wip\n\nfeat: hidden in the body")
+	require.NotNil(t, v)
+	assert.Equal(t, "wip", v.Subject)
+}
+
+// initRepo creates a repository with one commit per message
+func initRepo(t *testing.T, messages ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	run("init", "-q", "-b", "main")
+	for _, message := range messages {
+		run("commit", "-q", "--allow-empty", "-m", message)
+	}
+	return dir
+}
+
+func TestCommits(t *testing.T) {
+	dir := initRepo(t, "feat: first", "wip", "docs: third")
+
+	commits, err := Commits(dir, "HEAD")
+	require.NoError(t, err)
+	require.Len(t, commits, 3)
+	assert.Equal(t, "docs: third\n", commits[0].Message)
+
+	violations := testRules(t).CheckCommits(commits)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "wip", violations[0].Subject)
+	assert.Equal(t, commits[1].Hash, violations[0].Commit)
+
+	_, err = Commits(dir, "no-such-ref")
+	assert.Error(t, err)
+}
+
+func TestInstallHooks(t *testing.T) {
+	dir := initRepo(t)
+	hooksDir := filepath.Join(dir, ".git", "hooks")
+
+	paths, err := InstallHooks(dir, "/opt/my tools/glide", false)
+	require.NoError(t, err)
+	require.Len(t, paths, 2)
+
+	data, err := os.ReadFile(filepath.Join(hooksDir, "commit-msg"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `exec '/opt/my tools/glide' policy check --commit-msg-file "$1"`)
+
+	// Reinstalling replaces our own hooks
+	_, err = InstallHooks(dir, "glide", false)
+	require.NoError(t, err)
+
+	removed, err := UninstallHooks(dir)
+	require.NoError(t, err)
+	assert.Len(t, removed, 2)
+	assert.NoFileExists(t, filepath.Join(hooksDir, "pre-push"))
+}
+
+func TestInstallHooks_ForeignHook(t *testing.T) {
+	dir := initRepo(t)
+	foreign := filepath.Join(dir, ".git", "hooks", "pre-push")
+	require.NoError(t, os.WriteFile(foreign, []byte("#!/bin/sh\nmake lint\n"), 0755))
+
+	_, err := InstallHooks(dir, "glide", false)
+	require.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, ".git", "hooks", "commit-msg"), "nothing is written")
+
+	removed, err := UninstallHooks(dir)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+	assert.FileExists(t, foreign, "other tools' hooks are kept")
+
+	_, err = InstallHooks(dir, "glide", true)
+	require.NoError(t, err)
+}
@@ -0,0 +1,127 @@
+package gitpolicy
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// hookMarker identifies hooks written by InstallHooks, so they can be
+// replaced and removed without touching hooks from other tools
+var hookMarker = "# Installed by " + branding.CommandName + " policy install-hooks"
+
+// hookScripts are the hooks InstallHooks writes. %s is the quoted command
+// that runs glide.
+var hookScripts = map[string]string{
+	"commit-msg": `#!/bin/sh
+` + hookMarker + `
+exec %s policy check --commit-msg-file "$1"
+`,
+	"pre-push": `#!/bin/sh
+` + hookMarker + `
+while read local_ref local_sha remote_ref remote_sha; do
+	case "$local_ref" in refs/heads/*) ;; *) continue ;; esac
+	# Deleting a branch
+	case "$local_sha" in *[!0]*) ;; *) continue ;; esac
+	case "$remote_sha" in
+	*[!0]*) range="$remote_sha..$local_sha" ;;
+	*) range="$local_sha --not --remotes" ;;
+	esac
+	%s policy check --branch "${local_ref#refs/heads/}" --range "$range" </dev/null || exit 1
+done
+`,
+}
+
+// HookNames returns the names of the hooks InstallHooks writes
+func HookNames() []string {
+	return []string{"commit-msg", "pre-push"}
+}
+
+// HooksDir returns the hooks directory of the repository containing dir,
+// honouring core.hooksPath. Worktrees share the hooks of their repository.
+func HooksDir(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", fmt.Errorf("%s is not inside a git repository", dir)
+	}
+	path := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return path, nil
+}
+
+// InstallHooks writes the policy hooks into the repository containing dir.
+// command is how the hooks run glide, usually the absolute path of the
+// executable. Existing hooks from other tools are only replaced when force
+// is set. It returns the paths written.
+func InstallHooks(dir, command string, force bool) ([]string, error) {
+	hooksDir, err := HooksDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, name := range HookNames() {
+		path := filepath.Join(hooksDir, name)
+		if !force && isForeignHook(path) {
+			return paths, fmt.Errorf("%s already exists and was not installed by glide", path)
+		}
+		paths = append(paths, path)
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return nil, err
+	}
+	quoted := shellQuote(command)
+	for i, name := range HookNames() {
+		script := strings.ReplaceAll(hookScripts[name], "%s", quoted)
+		if err := os.WriteFile(paths[i], []byte(script), 0755); err != nil {
+			return paths[:i], err
+		}
+	}
+	return paths, nil
+}
+
+// UninstallHooks removes the policy hooks from the repository containing
+// dir, leaving hooks from other tools in place. It returns the paths
+// removed.
+func UninstallHooks(dir string) ([]string, error) {
+	hooksDir, err := HooksDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, name := range HookNames() {
+		path := filepath.Join(hooksDir, name)
+		data, err := os.ReadFile(path)
+		if err != nil || !strings.Contains(string(data), hookMarker) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+// isForeignHook reports whether a hook exists that InstallHooks did not
+// write. Git's own *.sample files are not hooks.
+func isForeignHook(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return !strings.Contains(string(data), hookMarker)
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,128 @@
+package gitpolicy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// DefaultExemptBranches are not checked unless exempt_branches is set
+var DefaultExemptBranches = []string{"main", "master", "develop"}
+
+// generatedPrefixes start the subjects of commit messages git writes itself
+var generatedPrefixes = []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "}
+
+// Kinds of violations
+const (
+	KindBranch = "branch"
+	KindCommit = "commit"
+)
+
+// Rules are compiled naming rules. A nil pattern allows any name.
+type Rules struct {
+	Branch         *regexp.Regexp
+	Commit         *regexp.Regexp
+	ExemptBranches []string
+}
+
+// Violation is a branch name or commit message that breaks a rule
+type Violation struct {
+	Kind string `json:"kind" yaml:"kind"`
+	// Subject is the branch name or the commit message's subject line
+	Subject string `json:"subject" yaml:"subject"`
+	// Commit is the abbreviated commit hash, if the message is committed
+	Commit  string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Pattern string `json:"pattern" yaml:"pattern"`
+}
+
+// RulesFromConfig compiles the git_policy section of the configuration
+func RulesFromConfig(cfg config.GitPolicyConfig) (Rules, error) {
+	rules := Rules{ExemptBranches: cfg.ExemptBranches}
+	if len(rules.ExemptBranches) == 0 {
+		rules.ExemptBranches = DefaultExemptBranches
+	}
+
+	fields := []struct {
+		key   string
+		value string
+		dest  **regexp.Regexp
+	}{
+		{"git_policy.branch_pattern", cfg.BranchPattern, &rules.Branch},
+		{"git_policy.commit_pattern", cfg.CommitPattern, &rules.Commit},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		re, err := regexp.Compile(field.value)
+		if err != nil {
+			return Rules{}, glideErrors.NewConfigError(fmt.Sprintf("invalid %s: %q", field.key, field.value),
+				glideErrors.WithError(err),
+				glideErrors.WithSuggestions("Use Go regular expression syntax, and quote the pattern in YAML"),
+			)
+		}
+		*field.dest = re
+	}
+	return rules, nil
+}
+
+// Empty reports whether there are no rules
+func (r Rules) Empty() bool {
+	return r.Branch == nil && r.Commit == nil
+}
+
+// CheckBranch returns the violation of a branch name, or nil
+func (r Rules) CheckBranch(name string) *Violation {
+	if r.Branch == nil || r.exempt(name) || r.Branch.MatchString(name) {
+		return nil
+	}
+	return &Violation{Kind: KindBranch, Subject: name, Pattern: r.Branch.String()}
+}
+
+// CheckCommit returns the violation of a commit message, or nil. Only the
+// subject line is matched.
+func (r Rules) CheckCommit(message string) *Violation {
+	subject := Subject(message)
+	if r.Commit == nil || subject == "" || generated(subject) || r.Commit.MatchString(subject) {
+		return nil
+	}
+	return &Violation{Kind: KindCommit, Subject: subject, Pattern: r.Commit.String()}
+}
+
+// exempt reports whether a branch is exempt from the rules
+func (r Rules) exempt(name string) bool {
+	for _, branch := range r.ExemptBranches {
+		if branch == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Subject returns the first line of a commit message, skipping the comment
+// lines git adds to the message file
+func Subject(message string) string {
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// generated reports whether a subject line was written by git
+func generated(subject string) bool {
+	for _, prefix := range generatedPrefixes {
+		if strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
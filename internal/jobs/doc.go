@@ -0,0 +1,35 @@
+// Package jobs runs scheduled maintenance jobs, such as a nightly cache
+// prune or an image prefetch before the working day, for the daemon.
+//
+// Jobs are glide commands configured in ~/.glide.yml with a cron
+// schedule and an optional jitter:
+//
+//	jobs:
+//	  prune:
+//	    schedule: "0 3 * * *"     # minute hour day-of-month month day-of-week
+//	    run: cache prune
+//	    jitter: 30m
+//	  prefetch:
+//	    schedule: "0 8 * * 1-5"
+//	    run: prefetch
+//	    dir: ~/code/acme          # project commands need a project
+//	  update-check:
+//	    schedule: "@daily"
+//	    run: self-update --check
+//
+// A Runner runs a job's command with the current glide binary, appending
+// its output to a rotating log per job under ~/.glide/logs/jobs, and
+// records the run in ~/.glide/jobs.json. A lock per job keeps a scheduled
+// run and `glide jobs run` from overlapping:
+//
+//	runner, _ := jobs.NewRunner()
+//	list, err := jobs.FromConfig(cfg.Jobs)
+//	if err != nil {
+//	    return err
+//	}
+//	return runner.Serve(ctx, list)
+//
+// Serve runs each job when its schedule next matches after its last run,
+// delayed by a random time up to its jitter, so a job whose time passed
+// while nothing served it runs once on start.
+package jobs
@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// Job is a parsed scheduled job
+type Job struct {
+	Name     string
+	Schedule *Schedule
+	// Args are the glide arguments the job runs, e.g. [cache prune]
+	Args   []string
+	Dir    string
+	Jitter time.Duration
+}
+
+// FromConfig parses the jobs of the configuration, sorted by name
+func FromConfig(cfg map[string]config.JobConfig) ([]Job, error) {
+	jobs := make([]Job, 0, len(cfg))
+	for name, jc := range cfg {
+		job, err := parseJob(name, jc)
+		if err != nil {
+			return nil, glideErrors.NewConfigError(fmt.Sprintf("invalid job %s: %v", name, err),
+				glideErrors.WithError(err),
+				glideErrors.WithSuggestions(`Fix jobs.`+name+` in ~/.glide.yml, e.g. schedule: "0 3 * * *" and run: cache prune`),
+			)
+		}
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs, nil
+}
+
+// parseJob parses one job's configuration
+func parseJob(name string, jc config.JobConfig) (Job, error) {
+	schedule, err := ParseSchedule(jc.Schedule)
+	if err != nil {
+		return Job{}, err
+	}
+	args := strings.Fields(jc.Run)
+	if len(args) == 0 {
+		return Job{}, fmt.Errorf("run names no command")
+	}
+
+	job := Job{Name: name, Schedule: schedule, Args: args, Dir: jc.Dir}
+	if strings.HasPrefix(job.Dir, "~/") {
+		homeDir, _ := os.UserHomeDir()
+		job.Dir = filepath.Join(homeDir, job.Dir[2:])
+	}
+	if jc.Jitter != "" {
+		if job.Jitter, err = time.ParseDuration(jc.Jitter); err != nil || job.Jitter < 0 {
+			return Job{}, fmt.Errorf("invalid jitter %q", jc.Jitter)
+		}
+	}
+	return job, nil
+}
+
+// Find returns the job named name
+func Find(jobs []Job, name string) (Job, bool) {
+	for _, job := range jobs {
+		if job.Name == name {
+			return job, true
+		}
+	}
+	return Job{}, false
+}
+
+// Command returns the job's command line, e.g. "cache prune"
+func (j Job) Command() string {
+	return strings.Join(j.Args, " ")
+}
+
+// randomJitter returns a random delay below max and is replaced in tests
+var randomJitter = func(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max)
+}
+
+// NextRun returns when the job runs next, once the schedule matches after
+// the last run, or after since when it never ran. The schedule is read in
+// since's location. Jitter is not included.
+func (j Job) NextRun(last Run, since time.Time) time.Time {
+	if !last.Started.IsZero() {
+		since = last.Started.In(since.Location())
+	}
+	return j.Schedule.Next(since)
+}
@@ -0,0 +1,199 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testNow is a Sunday
+var testNow = time.Date(2026, 3, 8, 12, 30, 15, 0, time.UTC)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 8, 12, 31, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, 3, 9, 3, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 8, 12, 45, 0, 0, time.UTC)},
+		{"5,40 12 * * *", time.Date(2026, 3, 8, 12, 40, 0, 0, time.UTC)},
+		{"0 8 * * 1-5", time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Either day field matches when both are restricted
+		{"0 0 20 * 2", time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 3, 8, 13, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		s, err := ParseSchedule(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, s.Next(testNow), tt.expr)
+		assert.Equal(t, tt.expr, s.String())
+	}
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@yearly"} {
+		_, err := ParseSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	homeDir, _ := os.UserHomeDir()
+	list, err := FromConfig(map[string]config.JobConfig{
+		"update-check": {Schedule: "@daily", Run: "self-update --check"},
+		"prefetch":     {Schedule: "0 8 * * 1-5", Run: "prefetch", Dir: "~/code/acme", Jitter: "15m"},
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "prefetch", list[0].Name)
+	assert.Equal(t, []string{"prefetch"}, list[0].Args)
+	assert.Equal(t, filepath.Join(homeDir, "code", "acme"), list[0].Dir)
+	assert.Equal(t, 15*time.Minute, list[0].Jitter)
+	assert.Equal(t, "self-update --check", list[1].Command())
+
+	job, ok := Find(list, "update-check")
+	assert.True(t, ok)
+	assert.Equal(t, "update-check", job.Name)
+	_, ok = Find(list, "nope")
+	assert.False(t, ok)
+
+	for name, jc := range map[string]config.JobConfig{
+		"schedule": {Schedule: "daily", Run: "cache prune"},
+		"run":      {Schedule: "@daily"},
+		"jitter":   {Schedule: "@daily", Run: "cache prune", Jitter: "soon"},
+	} {
+		_, err := FromConfig(map[string]config.JobConfig{name: jc})
+		assert.ErrorContains(t, err, "invalid job "+name)
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	s, err := ParseSchedule("0 3 * * *")
+	require.NoError(t, err)
+	job := Job{Name: "prune", Schedule: s}
+
+	assert.Equal(t, time.Date(2026, 3, 9, 3, 0, 0, 0, time.UTC), job.NextRun(Run{}, testNow))
+	// A run missed since the last one is due right away
+	last := Run{Started: time.Date(2026, 3, 5, 3, 0, 0, 0, time.UTC)}
+	assert.Equal(t, time.Date(2026, 3, 6, 3, 0, 0, 0, time.UTC), job.NextRun(last, testNow))
+}
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	state := LoadState(path)
+	assert.True(t, state.Last("prune").Started.IsZero())
+
+	state.Runs["prune"] = Run{Started: testNow, Duration: time.Second, ExitCode: 2}
+	require.NoError(t, state.Save())
+
+	last := LoadState(path).Last("prune")
+	assert.Equal(t, testNow, last.Started)
+	assert.False(t, last.Succeeded())
+}
+
+// newTestRunner returns a runner of sh, so a job's args are a script
+func newTestRunner(t *testing.T) *Runner {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("jobs are run with sh in tests")
+	}
+	dir := t.TempDir()
+	return &Runner{
+		Exe:       "/bin/sh",
+		LogDir:    filepath.Join(dir, "logs"),
+		StatePath: filepath.Join(dir, "jobs.json"),
+		Locks:     lock.NewManager(filepath.Join(dir, "locks")),
+	}
+}
+
+func TestRunnerRun(t *testing.T) {
+	runner := newTestRunner(t)
+	job := Job{Name: "greet", Args: []string{"-c", "echo hello; exit 3"}, Dir: t.TempDir()}
+
+	var out bytes.Buffer
+	run, err := runner.Run(context.Background(), job, &out, false)
+	require.NoError(t, err)
+	assert.Equal(t, 3, run.ExitCode)
+	assert.False(t, run.Succeeded())
+	assert.Equal(t, "hello\n", out.String())
+
+	log, err := os.ReadFile(LogPath(runner.LogDir, "greet"))
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "-c echo hello; exit 3 (manual) ===\nhello\n=== exit 3 after")
+
+	assert.Equal(t, 3, LoadState(runner.StatePath).Last("greet").ExitCode)
+}
+
+func TestRunnerRunAlreadyRunning(t *testing.T) {
+	runner := newTestRunner(t)
+	held, err := runner.Locks.TryAcquire("job-greet", lock.Holder{Command: "jobs run greet"})
+	require.NoError(t, err)
+	defer held.Release()
+
+	_, err = runner.Run(context.Background(), Job{Name: "greet", Args: []string{"-c", "true"}}, nil, true)
+	var heldErr *lock.HeldError
+	assert.True(t, errors.As(err, &heldErr))
+}
+
+func TestRunnerServeRunsMissedJobs(t *testing.T) {
+	runner := newTestRunner(t)
+	runner.now = func() time.Time { return testNow }
+	original := randomJitter
+	randomJitter = func(max time.Duration) time.Duration { return 0 }
+	t.Cleanup(func() { randomJitter = original })
+
+	// The job last ran two days ago, so a nightly run was missed
+	state := LoadState(runner.StatePath)
+	state.Runs["prune"] = Run{Started: testNow.AddDate(0, 0, -2)}
+	require.NoError(t, state.Save())
+
+	s, err := ParseSchedule("0 3 * * *")
+	require.NoError(t, err)
+	job := Job{Name: "prune", Schedule: s, Args: []string{"-c", "echo pruned"}, Dir: t.TempDir()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	var logged []string
+	runner.Logf = func(format string, args ...any) {
+		mu.Lock()
+		defer mu.Unlock()
+		logged = append(logged, format)
+		if strings.HasPrefix(format, "Job %s finished") {
+			cancel()
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- runner.Serve(ctx, []Job{job}) }()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Serve did not run the missed job")
+	}
+
+	last := LoadState(runner.StatePath).Last("prune")
+	assert.True(t, last.Scheduled)
+	assert.Equal(t, testNow.UTC(), last.Started)
+	log, err := os.ReadFile(LogPath(runner.LogDir, "prune"))
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "(scheduled) ===\npruned\n")
+}
@@ -0,0 +1,198 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/lock"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+)
+
+// maxWait bounds how long Serve sleeps at once, so that runs are not
+// missed when the machine sleeps through a timer
+const maxWait = time.Minute
+
+// Runner runs jobs, writing their output to a log per job and recording
+// each run in the state file
+type Runner struct {
+	// Exe is the glide binary that runs the jobs' commands
+	Exe       string
+	LogDir    string
+	StatePath string
+	// Locks keeps a job from running twice at once
+	Locks *lock.Manager
+	// Logf reports what Serve does; nil discards it
+	Logf func(format string, args ...any)
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+// NewRunner returns a runner of the current glide binary, with the default
+// log directory, state file, and locks
+func NewRunner() (*Runner, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{
+		Exe:       exe,
+		LogDir:    DefaultLogDir(),
+		StatePath: DefaultStatePath(),
+		Locks:     lock.NewManager(lock.DefaultDir()),
+	}, nil
+}
+
+// Run runs a job now, copying its output to out as well as its log when out
+// is not nil. The error is set when the job could not start, including when
+// it is already running; a job that fails only has a non-zero ExitCode.
+func (r *Runner) Run(ctx context.Context, job Job, out io.Writer, scheduled bool) (Run, error) {
+	started := r.clock()
+	held, err := r.Locks.TryAcquire("job-"+job.Name, lock.Holder{
+		PID:     os.Getpid(),
+		Command: "jobs run " + job.Name,
+		Started: started,
+	})
+	if err != nil {
+		return Run{}, err
+	}
+	defer held.Release()
+
+	logFile, err := sdk.OpenRotatingWriter(LogPath(r.LogDir, job.Name))
+	if err != nil {
+		return Run{}, fmt.Errorf("failed to open the log of job %s: %w", job.Name, err)
+	}
+	defer logFile.Close()
+
+	trigger := "manual"
+	if scheduled {
+		trigger = "scheduled"
+	}
+	fmt.Fprintf(logFile, "=== %s %s %s (%s) ===\n", started.Format(time.RFC3339), branding.CommandName, job.Command(), trigger)
+
+	var output io.Writer = logFile
+	if out != nil {
+		output = io.MultiWriter(logFile, out)
+	}
+	cmd := exec.CommandContext(ctx, r.Exe, job.Args...)
+	cmd.Dir = job.Dir
+	if cmd.Dir == "" {
+		cmd.Dir, _ = os.UserHomeDir()
+	}
+	// Nobody is there to answer first-run questions
+	cmd.Env = append(os.Environ(), "GLIDE_NO_ONBOARDING=1")
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	run := Run{Started: started.UTC(), Scheduled: scheduled}
+	err = cmd.Run()
+	run.Duration = r.clock().Sub(started)
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		run.ExitCode = exitErr.ExitCode()
+		err = nil
+	case err != nil:
+		run.ExitCode = -1
+		run.Error = err.Error()
+	}
+	fmt.Fprintf(logFile, "=== exit %d after %s ===\n", run.ExitCode, run.Duration.Round(time.Millisecond))
+
+	state := LoadState(r.StatePath)
+	state.Runs[job.Name] = run
+	if saveErr := state.Save(); saveErr != nil {
+		r.logf("Could not record the run of job %s: %v", job.Name, saveErr)
+	}
+	return run, err
+}
+
+// Serve runs jobs on their schedules, one at a time, until ctx is done. A
+// job whose scheduled time passed while nothing served it, e.g. overnight
+// with the machine off, runs once right away.
+func (r *Runner) Serve(ctx context.Context, jobs []Job) error {
+	state := LoadState(r.StatePath)
+	start := r.clock()
+	next := make([]time.Time, len(jobs))
+	for i, job := range jobs {
+		next[i] = r.schedule(job, state.Last(job.Name).Started, start)
+		r.logf("Job %s (%s) runs next at %s", job.Name, job.Command(), next[i].Format(time.RFC3339))
+	}
+
+	for {
+		i := earliest(next)
+		if i < 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(min(max(next[i].Sub(r.clock()), 0), maxWait))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		if r.clock().Before(next[i]) {
+			continue
+		}
+
+		job := jobs[i]
+		r.logf("Running job %s: %s %s", job.Name, branding.CommandName, job.Command())
+		run, err := r.Run(ctx, job, nil, true)
+		var heldErr *lock.HeldError
+		switch {
+		case errors.As(err, &heldErr):
+			r.logf("Skipped job %s: it is already running", job.Name)
+		case err != nil:
+			r.logf("Job %s could not run: %v", job.Name, err)
+		case !run.Succeeded():
+			r.logf("Job %s failed with exit code %d; see %s", job.Name, run.ExitCode, LogPath(r.LogDir, job.Name))
+		default:
+			r.logf("Job %s finished in %s", job.Name, run.Duration.Round(time.Millisecond))
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		next[i] = r.schedule(job, r.clock(), start)
+	}
+}
+
+// schedule returns when a job that last ran at last runs next, with jitter
+func (r *Runner) schedule(job Job, last, since time.Time) time.Time {
+	next := job.NextRun(Run{Started: last}, since)
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(randomJitter(job.Jitter))
+}
+
+// earliest returns the index of the earliest time that is not zero, or -1
+func earliest(times []time.Time) int {
+	found := -1
+	for i, t := range times {
+		if !t.IsZero() && (found < 0 || t.Before(times[found])) {
+			found = i
+		}
+	}
+	return found
+}
+
+// clock returns the current time
+func (r *Runner) clock() time.Time {
+	if r.now == nil {
+		return time.Now()
+	}
+	return r.now()
+}
+
+// logf reports what Serve does
+func (r *Runner) logf(format string, args ...any) {
+	if r.Logf != nil {
+		r.Logf(format, args...)
+	}
+}
@@ -0,0 +1,159 @@
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// shorthands are the @ schedules and the cron expressions they stand for
+var shorthands = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// Schedule is a parsed cron expression: minute, hour, day of month, month,
+// and day of week, each a bit set of the values it matches
+type Schedule struct {
+	expr                          string
+	minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted  bool
+}
+
+// field describes a cron field's range
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseSchedule parses a five-field cron expression, where each field is
+// *, a value, a range a-b, or a list of them, optionally stepped with /n,
+// or an @ shorthand such as @daily. Day of week 0 and 7 are Sunday.
+func ParseSchedule(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if full, ok := shorthands[spec]; ok {
+		spec = full
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("schedule %q must have 5 fields (minute hour day-of-month month day-of-week) or be @hourly, @daily, @weekly, or @monthly", expr)
+	}
+
+	s := &Schedule{expr: strings.TrimSpace(expr)}
+	sets := []*uint64{&s.minute, &s.hour, &s.dom, &s.month, &s.dow}
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", expr, err)
+		}
+		*sets[i] = set
+	}
+	// Sunday is both 0 and 7
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	// Like cron, a field starting with * does not restrict the day
+	s.domRestricted = !strings.HasPrefix(parts[2], "*")
+	s.dowRestricted = !strings.HasPrefix(parts[4], "*")
+	return s, nil
+}
+
+// parseField parses one comma-separated cron field into a bit set
+func parseField(spec string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(spec, ",") {
+		rangeSpec, stepSpec, stepped := strings.Cut(item, "/")
+		step := 1
+		if stepped {
+			n, err := strconv.Atoi(stepSpec)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s", stepSpec, f.name)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		if rangeSpec != "*" {
+			from, to, isRange := strings.Cut(rangeSpec, "-")
+			var err error
+			if lo, err = fieldValue(from, f); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = fieldValue(to, f); err != nil {
+					return 0, err
+				}
+			} else if stepped {
+				hi = f.max
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q in %s", rangeSpec, f.name)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// fieldValue parses a value of a cron field, checking its range
+func fieldValue(s string, f field) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q (want %d-%d)", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// String returns the expression the schedule was parsed from
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first time after t the schedule matches, in t's
+// location, or the zero time when it never does (e.g. February 30th)
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		year, month, day := t.Date()
+		switch {
+		case s.month&(1<<uint(month)) == 0:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(year, month, day, t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's day rule: when both day of month and day of
+// week are restricted, either may match
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
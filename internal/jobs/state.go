@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// Run is the outcome of a job's run
+type Run struct {
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	ExitCode int           `json:"exit_code"`
+	// Error is why the job could not run or failed
+	Error string `json:"error,omitempty"`
+	// Scheduled is false for runs started with `glide jobs run`
+	Scheduled bool `json:"scheduled,omitempty"`
+}
+
+// Succeeded reports whether the run finished without error
+func (r Run) Succeeded() bool {
+	return r.Error == "" && r.ExitCode == 0
+}
+
+// State records the last run of each job. It is shared by every glide
+// process on the machine.
+type State struct {
+	Runs map[string]Run `json:"runs"`
+
+	path string
+}
+
+// DefaultStatePath returns the state file in the user's glide directory
+func DefaultStatePath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "jobs.json")
+}
+
+// DefaultLogDir returns the directory job logs are written to
+func DefaultLogDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "logs", "jobs")
+}
+
+// LogPath returns the path of a job's current log file
+func LogPath(dir, name string) string {
+	return filepath.Join(dir, name+".log")
+}
+
+// LoadState reads the state file. A missing or unreadable file yields an
+// empty state, as if no job had run.
+func LoadState(path string) *State {
+	state := &State{path: path}
+	if data, err := os.ReadFile(path); err == nil {
+		// Safe to ignore: a corrupt state file is replaced on the next save
+		_ = json.Unmarshal(data, state)
+	}
+	if state.Runs == nil {
+		state.Runs = make(map[string]Run)
+	}
+	return state
+}
+
+// Last returns the last run of the named job; its Started is zero when the
+// job never ran
+func (s *State) Last(name string) Run {
+	return s.Runs[name]
+}
+
+// Save writes the state file atomically
+func (s *State) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,28 @@
+// Package lock serializes mutually exclusive operations across glide
+// processes, such as two `up` commands for the same worktree.
+//
+// Locks are advisory file locks in the user's glide directory, so the
+// operating system releases them when the holding process exits, however it
+// exits. Next to each lock the holder records who it is, which lets a
+// waiting process explain what it is waiting for:
+//
+//	~/.glide/locks/compose-acme-feature-api-1f2e3d4c.lock
+//	~/.glide/locks/compose-acme-feature-api-1f2e3d4c.json
+//	{"pid":4242,"command":"up","project":"/src/acme","worktree":"feature-api","hostname":"dev","started":"2026-10-12T09:02:11Z"}
+//
+// When a lock is released, how long it was held is added to the lock's
+// history, from which Estimate predicts how long the current holder has left:
+//
+//	manager := lock.NewManager(lock.DefaultDir())
+//	l, err := manager.TryAcquire(name, holder)
+//	var held *lock.HeldError
+//	if errors.As(err, &held) {
+//	    l, err = manager.Wait(ctx, name, holder, func(h lock.Holder) {
+//	        if eta, ok := manager.Estimate(name, h); ok { ... }
+//	    })
+//	}
+//	if err != nil {
+//	    return err
+//	}
+//	defer l.Release()
+package lock
@@ -0,0 +1,61 @@
+package lock
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+)
+
+// historySize is how many hold durations are kept per command
+const historySize = 10
+
+// history maps a holder's command to how long it held the lock, in seconds,
+// most recent last
+type history map[string][]float64
+
+// Estimate predicts how much longer holder will hold the named lock: the
+// median of the command's recent holds minus the time already held. ok is
+// false without history for the command. The remaining time is never
+// negative, as a holder running longer than usual may finish any moment.
+func (m *Manager) Estimate(name string, holder Holder) (remaining time.Duration, ok bool) {
+	durations := m.loadHistory(name)[holder.Command]
+	if len(durations) == 0 || holder.Started.IsZero() {
+		return 0, false
+	}
+
+	sorted := append([]float64(nil), durations...)
+	sort.Float64s(sorted)
+	median := time.Duration(sorted[len(sorted)/2] * float64(time.Second))
+
+	remaining = median - time.Since(holder.Started)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
+// record adds a hold duration to the named lock's history. The history only
+// informs estimates, so failures are ignored.
+func (m *Manager) record(name, command string, held time.Duration) {
+	h := m.loadHistory(name)
+	durations := append(h[command], held.Seconds())
+	if len(durations) > historySize {
+		durations = durations[len(durations)-historySize:]
+	}
+	h[command] = durations
+
+	if data, err := json.Marshal(h); err == nil {
+		_ = os.WriteFile(m.path(name, ".history.json"), data, 0600)
+	}
+}
+
+// loadHistory reads the named lock's history; a missing or unreadable
+// history is empty
+func (m *Manager) loadHistory(name string) history {
+	h := make(history)
+	if data, err := os.ReadFile(m.path(name, ".history.json")); err == nil {
+		_ = json.Unmarshal(data, &h)
+	}
+	return h
+}
@@ -0,0 +1,170 @@
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// DefaultPollInterval is how often Wait retries a held lock
+const DefaultPollInterval = 250 * time.Millisecond
+
+// Holder describes the process holding a lock
+type Holder struct {
+	PID int `json:"pid"`
+	// Command is the command path without the binary name, e.g. "up"
+	Command  string    `json:"command"`
+	Project  string    `json:"project,omitempty"`
+	Worktree string    `json:"worktree,omitempty"`
+	Hostname string    `json:"hostname,omitempty"`
+	Started  time.Time `json:"started"`
+}
+
+// String describes the holder, e.g. "glide up (pid 4242)"
+func (h Holder) String() string {
+	if h.Command == "" {
+		return "another glide process"
+	}
+	return fmt.Sprintf("%s %s (pid %d)", branding.CommandName, h.Command, h.PID)
+}
+
+// HeldError is returned when a lock is held by another process
+type HeldError struct {
+	Name string
+	// Holder is the zero Holder when the holder has not recorded itself yet
+	Holder Holder
+}
+
+func (e *HeldError) Error() string {
+	return fmt.Sprintf("lock %s is held by %s", e.Name, e.Holder)
+}
+
+// Manager acquires locks stored in a directory
+type Manager struct {
+	Dir          string
+	PollInterval time.Duration
+}
+
+// NewManager creates a manager for the locks in dir
+func NewManager(dir string) *Manager {
+	return &Manager{Dir: dir, PollInterval: DefaultPollInterval}
+}
+
+// DefaultDir returns the lock directory in the user's glide directory
+func DefaultDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "locks")
+}
+
+// Lock is an acquired lock
+type Lock struct {
+	manager *Manager
+	name    string
+	holder  Holder
+	file    *os.File
+}
+
+// TryAcquire acquires the named lock without waiting. When another process
+// holds it, the error is a *HeldError.
+func (m *Manager) TryAcquire(name string, holder Holder) (*Lock, error) {
+	if err := os.MkdirAll(m.Dir, 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(m.path(name, ".lock"), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	acquired, err := tryLockFile(f)
+	if err != nil || !acquired {
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		current, _ := m.Holder(name)
+		return nil, &HeldError{Name: name, Holder: current}
+	}
+
+	if holder.Started.IsZero() {
+		holder.Started = time.Now()
+	}
+	holder.Started = holder.Started.UTC()
+	if data, err := json.Marshal(holder); err == nil {
+		// The holder record is informational; waiting processes cope without it
+		_ = os.WriteFile(m.path(name, ".json"), data, 0600)
+	}
+	return &Lock{manager: m, name: name, holder: holder, file: f}, nil
+}
+
+// Wait acquires the named lock, polling until the holder releases it or ctx
+// is done. progress, which may be nil, is called with the current holder
+// before every retry.
+func (m *Manager) Wait(ctx context.Context, name string, holder Holder, progress func(Holder)) (*Lock, error) {
+	interval := m.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		l, err := m.TryAcquire(name, holder)
+		held, isHeld := err.(*HeldError)
+		if !isHeld {
+			return l, err
+		}
+		if progress != nil {
+			progress(held.Holder)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Holder reads the recorded holder of the named lock. It is only meaningful
+// while the lock is held.
+func (m *Manager) Holder(name string) (Holder, error) {
+	data, err := os.ReadFile(m.path(name, ".json"))
+	if err != nil {
+		return Holder{}, err
+	}
+	var holder Holder
+	if err := json.Unmarshal(data, &holder); err != nil {
+		return Holder{}, err
+	}
+	return holder, nil
+}
+
+// Name returns the lock's name
+func (l *Lock) Name() string { return l.name }
+
+// Release records how long the lock was held and releases it
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	l.manager.record(l.name, l.holder.Command, time.Since(l.holder.Started))
+	// Safe to ignore: a stale holder record is only read while the lock is held
+	_ = os.Remove(l.manager.path(l.name, ".json"))
+
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+// path returns a file of the named lock
+func (m *Manager) path(name, ext string) string {
+	return filepath.Join(m.Dir, name+ext)
+}
@@ -0,0 +1,119 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryAcquire(t *testing.T) {
+	manager := NewManager(t.TempDir())
+	holder := Holder{PID: 4242, Command: "up", Project: "/src/acme", Worktree: "vcs"}
+
+	l, err := manager.TryAcquire("compose", holder)
+	require.NoError(t, err)
+
+	_, err = manager.TryAcquire("compose", Holder{PID: 1, Command: "down"})
+	var held *HeldError
+	require.True(t, errors.As(err, &held))
+	assert.Equal(t, "up", held.Holder.Command)
+	assert.Equal(t, 4242, held.Holder.PID)
+	assert.Equal(t, "glide up (pid 4242)", held.Holder.String())
+
+	other, err := manager.TryAcquire("sync", holder)
+	require.NoError(t, err, "locks are independent")
+	require.NoError(t, other.Release())
+
+	require.NoError(t, l.Release())
+	require.NoError(t, l.Release(), "releasing twice is harmless")
+
+	l, err = manager.TryAcquire("compose", Holder{Command: "down"})
+	require.NoError(t, err)
+	require.NoError(t, l.Release())
+}
+
+func TestWait(t *testing.T) {
+	manager := NewManager(t.TempDir())
+	manager.PollInterval = 10 * time.Millisecond
+
+	l, err := manager.TryAcquire("compose", Holder{Command: "up"})
+	require.NoError(t, err)
+
+	var seen []Holder
+	done := make(chan struct{})
+	var waited *Lock
+	go func() {
+		defer close(done)
+		waited, err = manager.Wait(context.Background(), "compose", Holder{Command: "down"}, func(h Holder) {
+			seen = append(seen, h)
+			if len(seen) == 3 {
+				// Safe to ignore: the waiter only needs the lock released
+				_ = l.Release()
+			}
+		})
+	}()
+	<-done
+
+	require.NoError(t, err)
+	require.Len(t, seen, 3)
+	assert.Equal(t, "up", seen[0].Command)
+	require.NoError(t, waited.Release())
+}
+
+func TestWait_Cancelled(t *testing.T) {
+	manager := NewManager(t.TempDir())
+	manager.PollInterval = 10 * time.Millisecond
+
+	l, err := manager.TryAcquire("compose", Holder{Command: "up"})
+	require.NoError(t, err)
+	defer l.Release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = manager.Wait(ctx, "compose", Holder{Command: "down"}, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestEstimate(t *testing.T) {
+	manager := NewManager(t.TempDir())
+
+	_, ok := manager.Estimate("compose", Holder{Command: "up", Started: time.Now()})
+	assert.False(t, ok, "no history")
+
+	manager.record("compose", "up", 40*time.Second)
+	manager.record("compose", "up", 60*time.Second)
+	manager.record("compose", "up", 300*time.Second)
+	manager.record("compose", "down", 5*time.Second)
+
+	remaining, ok := manager.Estimate("compose", Holder{Command: "up", Started: time.Now().Add(-20 * time.Second)})
+	require.True(t, ok)
+	assert.InDelta(t, 40*time.Second, remaining, float64(time.Second), "median of the command's holds")
+
+	remaining, ok = manager.Estimate("compose", Holder{Command: "down", Started: time.Now().Add(-time.Minute)})
+	require.True(t, ok)
+	assert.Zero(t, remaining, "overdue holders may finish any moment")
+
+	for i := 0; i < historySize+5; i++ {
+		manager.record("compose", "down", time.Second)
+	}
+	assert.Len(t, manager.loadHistory("compose")["down"], historySize)
+}
+
+func TestRelease_RecordsHistory(t *testing.T) {
+	manager := NewManager(t.TempDir())
+
+	l, err := manager.TryAcquire("compose", Holder{Command: "up", Started: time.Now().Add(-30 * time.Second)})
+	require.NoError(t, err)
+	require.NoError(t, l.Release())
+
+	durations := manager.loadHistory("compose")["up"]
+	require.Len(t, durations, 1)
+	assert.InDelta(t, 30, durations[0], 1)
+
+	_, err = manager.Holder("compose")
+	assert.Error(t, err, "the holder record is removed")
+}
@@ -0,0 +1,24 @@
+// Package meta groups several glide projects into a meta-project, so
+// operations can fan out across a fleet of repositories checked out side by
+// side.
+//
+// A meta-project is described by a manifest at its root:
+//
+//	# .glide-meta.yml
+//	parallelism: 4
+//	projects:
+//	  - name: api
+//	    path: services/api
+//	    repo: git@github.com:acme/api.git
+//	  - name: web           # path defaults to the name
+//
+// The manifest is found by walking up from the current directory, like the
+// project configuration. Run executes a task in every project with a
+// bounded number of workers and returns the results in manifest order:
+//
+//	manifest, err := meta.LoadManifest(path)
+//	if err != nil {
+//	    return err
+//	}
+//	results := meta.Run(manifest.Projects, manifest.Parallelism, task, nil)
+package meta
@@ -0,0 +1,147 @@
+package meta
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultParallelism is the number of projects operated on at once when the
+// manifest does not say otherwise
+const DefaultParallelism = 4
+
+// Manifest lists the projects of a meta-project
+type Manifest struct {
+	// Path is the manifest file the projects were loaded from
+	Path string `yaml:"-"`
+	// Parallelism is the number of projects operated on at once
+	Parallelism int `yaml:"parallelism,omitempty"`
+	// Projects are kept in manifest order
+	Projects []Project `yaml:"projects"`
+}
+
+// Project is a member of a meta-project
+type Project struct {
+	// Name identifies the project in commands and results
+	Name string `yaml:"name"`
+	// Path is the project directory, relative to the manifest (default: Name)
+	Path string `yaml:"path,omitempty"`
+	// Repo is where the project is cloned from when it is missing
+	Repo string `yaml:"repo,omitempty"`
+	// Dir is the absolute project directory
+	Dir string `yaml:"-"`
+}
+
+// ManifestFileName returns the name of the manifest file, e.g. ".glide-meta.yml"
+func ManifestFileName() string {
+	return branding.GetPluginDirName() + "-meta.yml"
+}
+
+// FindManifest returns the manifest in dir or its closest ancestor that has
+// one, or "" when there is none
+func FindManifest(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, ManifestFileName())
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadManifest reads and validates a manifest, resolving project
+// directories relative to it
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, glideErrors.NewFileNotFoundError(path, glideErrors.WithError(err))
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, glideErrors.NewConfigError("invalid meta-project manifest",
+			glideErrors.WithError(err),
+			glideErrors.WithContext("file", path),
+		)
+	}
+	m.Path = path
+	if m.Parallelism < 1 {
+		m.Parallelism = DefaultParallelism
+	}
+
+	root := filepath.Dir(path)
+	seen := make(map[string]bool, len(m.Projects))
+	for i := range m.Projects {
+		p := &m.Projects[i]
+		if p.Name == "" {
+			return nil, manifestError(path, fmt.Sprintf("project %d has no name", i+1))
+		}
+		if seen[p.Name] {
+			return nil, manifestError(path, fmt.Sprintf("project %q is listed twice", p.Name))
+		}
+		seen[p.Name] = true
+
+		if p.Path == "" {
+			p.Path = p.Name
+		}
+		p.Dir = p.Path
+		if !filepath.IsAbs(p.Dir) {
+			p.Dir = filepath.Join(root, p.Dir)
+		}
+		p.Dir = filepath.Clean(p.Dir)
+	}
+	return &m, nil
+}
+
+// Select returns the projects with the given names in manifest order, or
+// every project when names is empty
+func (m *Manifest) Select(names []string) ([]Project, error) {
+	if len(names) == 0 {
+		return m.Projects, nil
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	var selected []Project
+	for _, p := range m.Projects {
+		if wanted[p.Name] {
+			selected = append(selected, p)
+			delete(wanted, p.Name)
+		}
+	}
+	if len(wanted) > 0 {
+		unknown := make([]string, 0, len(wanted))
+		for name := range wanted {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, glideErrors.New(glideErrors.TypeConfig, fmt.Sprintf("unknown meta-project member(s): %s", strings.Join(unknown, ", ")),
+			glideErrors.WithSuggestions("List the members with: "+branding.CommandName+" meta status"),
+		)
+	}
+	return selected, nil
+}
+
+// manifestError reports an invalid manifest
+func manifestError(path, message string) error {
+	return glideErrors.New(glideErrors.TypeConfig, message,
+		glideErrors.WithContext("file", path),
+		glideErrors.WithSuggestions("Fix the projects list in "+path),
+	)
+}
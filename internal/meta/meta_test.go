@@ -0,0 +1,116 @@
+package meta
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifest(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, ManifestFileName())
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadManifest(t *testing.T) {
+	root := t.TempDir()
+	path := writeManifest(t, root, `
+projects:
+  - name: api
+    path: services/api
+    repo: git@example.com:acme/api.git
+  - name: web
+  - name: shared
+    path: /opt/shared
+`)
+
+	m, err := LoadManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultParallelism, m.Parallelism)
+	require.Len(t, m.Projects, 3)
+	assert.Equal(t, filepath.Join(root, "services", "api"), m.Projects[0].Dir)
+	assert.Equal(t, "git@example.com:acme/api.git", m.Projects[0].Repo)
+	assert.Equal(t, filepath.Join(root, "web"), m.Projects[1].Dir, "path defaults to the name")
+	assert.Equal(t, "/opt/shared", m.Projects[2].Dir)
+}
+
+func TestLoadManifest_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"missing name": "projects:\n  - path: api\n",
+		"duplicate":    "projects:\n  - name: api\n  - name: api\n",
+		"bad yaml":     "projects: [\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadManifest(writeManifest(t, t.TempDir(), content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFindManifest(t *testing.T) {
+	root := t.TempDir()
+	path := writeManifest(t, root, "projects: []\n")
+	nested := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	assert.Equal(t, path, FindManifest(nested))
+	assert.Equal(t, path, FindManifest(root))
+	assert.Empty(t, FindManifest(t.TempDir()))
+}
+
+func TestManifest_Select(t *testing.T) {
+	m := &Manifest{Projects: []Project{{Name: "api"}, {Name: "web"}, {Name: "worker"}}}
+
+	all, err := m.Select(nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	selected, err := m.Select([]string{"worker", "api"})
+	require.NoError(t, err)
+	assert.Equal(t, []Project{{Name: "api"}, {Name: "worker"}}, selected, "manifest order is kept")
+
+	_, err = m.Select([]string{"api", "nope"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nope")
+}
+
+func TestRun(t *testing.T) {
+	projects := []Project{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}
+
+	var running, peak, done int32
+	results := Run(projects, 2, func(p Project) ([]byte, error) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		if p.Name == "c" {
+			return []byte("boom"), errors.New("failed")
+		}
+		return []byte(p.Name), nil
+	}, func(Result) {
+		atomic.AddInt32(&done, 1)
+	})
+
+	require.Len(t, results, 5)
+	for i, r := range results {
+		assert.Equal(t, projects[i].Name, r.Project.Name, "results are in project order")
+	}
+	assert.Equal(t, "boom", string(results[2].Output))
+	assert.Equal(t, 1, Failed(results))
+	assert.LessOrEqual(t, peak, int32(2))
+	assert.Equal(t, int32(5), done)
+}
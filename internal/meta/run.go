@@ -0,0 +1,69 @@
+package meta
+
+import (
+	"sync"
+	"time"
+)
+
+// Task is an operation run in one project. It returns the output to show
+// for the project and whether the operation failed.
+type Task func(p Project) ([]byte, error)
+
+// Result is the outcome of a task in one project
+type Result struct {
+	Project  Project
+	Output   []byte
+	Duration time.Duration
+	Err      error
+}
+
+// Run runs task in every project with at most parallelism tasks at a time
+// and returns the results in the order of projects. onDone, if set, is
+// called from worker goroutines as each task finishes.
+func Run(projects []Project, parallelism int, task Task, onDone func(Result)) []Result {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > len(projects) {
+		parallelism = len(projects)
+	}
+
+	results := make([]Result, len(projects))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < parallelism; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				start := time.Now()
+				out, err := task(projects[i])
+				results[i] = Result{Project: projects[i], Output: out, Duration: time.Since(start), Err: err}
+
+				if onDone != nil {
+					onDone(results[i])
+				}
+			}
+		}()
+	}
+
+	for i := range projects {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// Failed returns the number of results with an error
+func Failed(results []Result) int {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// webhookTimeout bounds webhook requests so a slow endpoint cannot hold the
+// command's exit for long
+const webhookTimeout = 5 * time.Second
+
+// runCommand runs a notification helper and is replaced in tests
+var runCommand = func(name string, args ...string) error {
+	return exec.Command(name, args...).Run()
+}
+
+// Desktop shows a desktop notification
+type Desktop struct {
+	// GOOS selects the helper; empty means the running system
+	GOOS string
+}
+
+// Name returns "desktop"
+func (d Desktop) Name() string { return "desktop" }
+
+// Notify shows the notification with osascript on macOS or notify-send on
+// Linux. Failures use an alert sound or critical urgency.
+func (d Desktop) Notify(n Notification) error {
+	goos := d.GOOS
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+
+	switch goos {
+	case "darwin":
+		sound := "Glass"
+		if !n.Success {
+			sound = "Basso"
+		}
+		script := fmt.Sprintf("display notification %s with title %s sound name %s",
+			appleScriptString(n.Body()), appleScriptString(n.Title()), appleScriptString(sound))
+		return runCommand("osascript", "-e", script)
+	case "linux":
+		urgency := "normal"
+		if !n.Success {
+			urgency = "critical"
+		}
+		return runCommand("notify-send", "--urgency="+urgency, "--app-name="+branding.CommandName, n.Title(), n.Body())
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", goos)
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(s)
+	return `"` + s + `"`
+}
+
+// Bell rings the terminal bell, twice when the command failed
+type Bell struct {
+	Out io.Writer
+}
+
+// Name returns "bell"
+func (b Bell) Name() string { return "bell" }
+
+// Notify rings the bell
+func (b Bell) Notify(n Notification) error {
+	bell := "\a"
+	if !n.Success {
+		bell = "\a\a"
+	}
+	_, err := io.WriteString(b.Out, bell)
+	return err
+}
+
+// Webhook formats
+const (
+	FormatSlack = "slack"
+	FormatJSON  = "json"
+)
+
+// Webhook posts notifications to a URL
+type Webhook struct {
+	URL string
+	// Format is FormatSlack or FormatJSON
+	Format     string
+	HTTPClient *http.Client
+}
+
+// Name returns "webhook"
+func (w Webhook) Name() string { return "webhook" }
+
+// Notify posts the notification
+func (w Webhook) Notify(n Notification) error {
+	var payload interface{} = n
+	if w.Format != FormatJSON {
+		payload = map[string]string{"text": n.Title() + "\n" + n.Body()}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := w.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	resp, err := client.Post(w.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+// Package notify tells the user when a long-running command finishes, so
+// they can switch away while `test` or `up` runs.
+//
+// Notifications are opted into per command in the global configuration:
+//
+//	# ~/.glide.yml
+//	notifications:
+//	  min_duration: 30s           # default: 60s
+//	  commands: [test, build, "project *"]
+//	  channels: [desktop, bell, webhook]
+//	  on: [failure]               # default: success and failure
+//	  webhook:
+//	    url: https://hooks.slack.com/services/T000/B000/XXXX
+//	    format: slack             # or json
+//
+// Channels are notifiers: Desktop uses osascript on macOS and notify-send
+// on Linux, Bell rings the terminal bell (twice on failure), and Webhook
+// posts to a URL. Failures are sent with a distinct title, icon, and sound.
+//
+//	settings, err := notify.SettingsFromConfig(cfg.Notifications)
+//	if err != nil {
+//	    return err
+//	}
+//	if settings.Wants("test", n.Success, n.Duration) {
+//	    err = notify.Send(settings.Notifiers, n)
+//	}
+package notify
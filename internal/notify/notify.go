@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// Notification describes a finished command
+type Notification struct {
+	// Command is the command path without the binary name, e.g. "project down"
+	Command  string        `json:"command"`
+	Success  bool          `json:"success"`
+	ExitCode int           `json:"exit_code"`
+	Duration time.Duration `json:"-"`
+	Seconds  float64       `json:"duration_seconds"`
+	// Project is the project root directory, if any
+	Project string `json:"project,omitempty"`
+	// Error is the command's error message on failure
+	Error string `json:"error,omitempty"`
+}
+
+// Notifier delivers notifications over one channel
+type Notifier interface {
+	// Name is the channel name used in the configuration
+	Name() string
+	Notify(n Notification) error
+}
+
+// Title returns a one-line summary, e.g. "✅ glide test finished"
+func (n Notification) Title() string {
+	if n.Success {
+		return fmt.Sprintf("✅ %s %s finished", branding.CommandName, n.Command)
+	}
+	return fmt.Sprintf("❌ %s %s failed (exit %d)", branding.CommandName, n.Command, n.ExitCode)
+}
+
+// Body returns the details shown below the title
+func (n Notification) Body() string {
+	body := "Took " + n.Duration.Round(time.Second).String()
+	if n.Project != "" {
+		body += " in " + filepath.Base(n.Project)
+	}
+	if !n.Success && n.Error != "" {
+		body += ": " + n.Error
+	}
+	return body
+}
+
+// Send delivers a notification over every notifier, continuing past
+// failures, and returns the failures joined
+func Send(notifiers []Notifier, n Notification) error {
+	n.Seconds = n.Duration.Round(time.Second).Seconds()
+
+	var errs []error
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(n); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", notifier.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var failed = Notification{Command: "test", Success: false, ExitCode: 2, Duration: 95 * time.Second, Project: "/src/acme", Error: "3 tests failed"}
+
+func TestSettingsFromConfig(t *testing.T) {
+	settings, err := SettingsFromConfig(config.NotificationsConfig{})
+	require.NoError(t, err)
+	assert.False(t, settings.Enabled())
+	assert.Equal(t, DefaultMinDuration, settings.MinDuration)
+	assert.True(t, settings.OnSuccess && settings.OnFailure)
+	require.Len(t, settings.Notifiers, 1)
+	assert.Equal(t, "desktop", settings.Notifiers[0].Name())
+
+	settings, err = SettingsFromConfig(config.NotificationsConfig{
+		MinDuration: "30s",
+		Commands:    []string{"test"},
+		Channels:    []string{"bell", "webhook"},
+		On:          []string{"failure"},
+		Webhook:     config.WebhookConfig{URL: "https://example.com/hook"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, settings.MinDuration)
+	assert.False(t, settings.OnSuccess)
+	require.Len(t, settings.Notifiers, 2)
+
+	invalid := []config.NotificationsConfig{
+		{MinDuration: "soon"},
+		{On: []string{"always"}},
+		{Channels: []string{"sms"}},
+		{Channels: []string{"webhook"}},
+		{Channels: []string{"webhook"}, Webhook: config.WebhookConfig{URL: "https://example.com", Format: "xml"}},
+	}
+	for _, cfg := range invalid {
+		_, err := SettingsFromConfig(cfg)
+		assert.Error(t, err, "%+v", cfg)
+	}
+}
+
+func TestSettings_Wants(t *testing.T) {
+	settings := Settings{
+		MinDuration: time.Minute,
+		Commands:    []string{"test", "project *"},
+		OnFailure:   true,
+	}
+
+	assert.True(t, settings.Wants("test", false, 2*time.Minute))
+	assert.True(t, settings.Wants("test unit", false, 2*time.Minute), "subcommands are covered")
+	assert.True(t, settings.Wants("project down", false, 2*time.Minute))
+	assert.False(t, settings.Wants("test", false, 30*time.Second), "short commands do not notify")
+	assert.False(t, settings.Wants("test", true, 2*time.Minute), "successes are not selected")
+	assert.False(t, settings.Wants("up", false, 2*time.Minute), "commands are opted in")
+}
+
+func TestNotification_Text(t *testing.T) {
+	assert.Equal(t, "❌ glide test failed (exit 2)", failed.Title())
+	assert.Equal(t, "Took 1m35s in acme: 3 tests failed", failed.Body())
+
+	ok := Notification{Command: "up", Success: true, Duration: time.Minute}
+	assert.Equal(t, "✅ glide up finished", ok.Title())
+	assert.Equal(t, "Took 1m0s", ok.Body())
+}
+
+func TestBell(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Bell{Out: &out}.Notify(failed))
+	assert.Equal(t, "\a\a", out.String())
+}
+
+func TestDesktop(t *testing.T) {
+	var calls [][]string
+	original := runCommand
+	runCommand = func(name string, args ...string) error {
+		calls = append(calls, append([]string{name}, args...))
+		return nil
+	}
+	defer func() { runCommand = original }()
+
+	require.NoError(t, Desktop{GOOS: "linux"}.Notify(failed))
+	require.NoError(t, Desktop{GOOS: "darwin"}.Notify(failed))
+	assert.Error(t, Desktop{GOOS: "plan9"}.Notify(failed))
+
+	require.Len(t, calls, 2)
+	assert.Equal(t, []string{"notify-send", "--urgency=critical", "--app-name=glide", failed.Title(), failed.Body()}, calls[0])
+	assert.Equal(t, "osascript", calls[1][0])
+	assert.Contains(t, calls[1][2], `with title "❌ glide test failed (exit 2)" sound name "Basso"`)
+}
+
+func TestWebhook(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+	}))
+	defer server.Close()
+
+	require.NoError(t, Send([]Notifier{Webhook{URL: server.URL}, Webhook{URL: server.URL, Format: FormatJSON}}, failed))
+	require.Len(t, bodies, 2)
+	assert.Equal(t, failed.Title()+"\n"+failed.Body(), bodies[0]["text"])
+	assert.Equal(t, "test", bodies[1]["command"])
+	assert.Equal(t, float64(95), bodies[1]["duration_seconds"])
+}
+
+// failingNotifier always fails
+type failingNotifier struct{}
+
+func (failingNotifier) Name() string              { return "broken" }
+func (failingNotifier) Notify(Notification) error { return errors.New("unreachable") }
+
+func TestSend_ContinuesPastFailures(t *testing.T) {
+	var out bytes.Buffer
+	err := Send([]Notifier{failingNotifier{}, Bell{Out: &out}}, failed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken: unreachable")
+	assert.Equal(t, "\a\a", out.String())
+}
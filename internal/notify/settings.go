@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+// DefaultMinDuration is how long a command must run before it notifies
+const DefaultMinDuration = time.Minute
+
+// Settings are the parsed notifications configuration
+type Settings struct {
+	MinDuration time.Duration
+	// Commands are the opted-in command patterns
+	Commands  []string
+	Notifiers []Notifier
+	// OnSuccess and OnFailure select which outcomes notify
+	OnSuccess bool
+	OnFailure bool
+}
+
+// SettingsFromConfig parses the notifications section of the configuration
+func SettingsFromConfig(cfg config.NotificationsConfig) (Settings, error) {
+	settings := Settings{MinDuration: DefaultMinDuration, Commands: cfg.Commands}
+
+	if cfg.MinDuration != "" {
+		d, err := time.ParseDuration(cfg.MinDuration)
+		if err != nil || d < 0 {
+			return Settings{}, settingsError(fmt.Sprintf("invalid notifications.min_duration: %q", cfg.MinDuration),
+				`Use a duration such as "30s" or "2m"`)
+		}
+		settings.MinDuration = d
+	}
+
+	if len(cfg.On) == 0 {
+		settings.OnSuccess, settings.OnFailure = true, true
+	}
+	for _, outcome := range cfg.On {
+		switch outcome {
+		case "success":
+			settings.OnSuccess = true
+		case "failure":
+			settings.OnFailure = true
+		default:
+			return Settings{}, settingsError(fmt.Sprintf("invalid notifications.on value %q", outcome), "Use success and/or failure")
+		}
+	}
+
+	channels := cfg.Channels
+	if len(channels) == 0 {
+		channels = []string{"desktop"}
+	}
+	for _, channel := range channels {
+		switch channel {
+		case "desktop":
+			settings.Notifiers = append(settings.Notifiers, Desktop{})
+		case "bell":
+			settings.Notifiers = append(settings.Notifiers, Bell{Out: os.Stderr})
+		case "webhook":
+			if cfg.Webhook.URL == "" {
+				return Settings{}, settingsError("notifications.webhook.url is required for the webhook channel",
+					"Set the URL of a Slack incoming webhook or another endpoint")
+			}
+			if cfg.Webhook.Format != "" && cfg.Webhook.Format != FormatSlack && cfg.Webhook.Format != FormatJSON {
+				return Settings{}, settingsError(fmt.Sprintf("invalid notifications.webhook.format %q", cfg.Webhook.Format),
+					"Use slack or json")
+			}
+			settings.Notifiers = append(settings.Notifiers, Webhook{URL: cfg.Webhook.URL, Format: cfg.Webhook.Format})
+		default:
+			return Settings{}, settingsError(fmt.Sprintf("invalid notifications channel %q", channel),
+				"Use desktop, bell, or webhook")
+		}
+	}
+
+	return settings, nil
+}
+
+// Enabled reports whether any command is opted in
+func (s Settings) Enabled() bool {
+	return len(s.Commands) > 0
+}
+
+// Wants reports whether a finished command should notify: it is opted in,
+// ran for at least MinDuration, and its outcome is selected
+func (s Settings) Wants(commandPath string, success bool, d time.Duration) bool {
+	if d < s.MinDuration || !s.OptedIn(commandPath) {
+		return false
+	}
+	return s.Outcome(success)
+}
+
+// Outcome reports whether notifications are sent for the outcome
+func (s Settings) Outcome(success bool) bool {
+	if success {
+		return s.OnSuccess
+	}
+	return s.OnFailure
+}
+
+// OptedIn reports whether a command path matches an opted-in pattern. A
+// pattern covers the command's subcommands and may use shell wildcards.
+func (s Settings) OptedIn(commandPath string) bool {
+	fields := strings.Fields(commandPath)
+	for _, pattern := range s.Commands {
+		pattern = strings.Join(strings.Fields(pattern), " ")
+		for i := len(fields); i > 0; i-- {
+			if matched, err := path.Match(pattern, strings.Join(fields[:i], " ")); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// settingsError builds a configuration error without the generic config
+// suggestions
+func settingsError(message, suggestion string) error {
+	return glideErrors.New(glideErrors.TypeConfig, message, glideErrors.WithSuggestions(suggestion))
+}
@@ -0,0 +1,324 @@
+package pluginregistry
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/forge"
+)
+
+// httpClient exchanges credentials for access tokens
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// tokenLeeway renews access tokens this long before they expire
+const tokenLeeway = time.Minute
+
+// newAuthenticator creates the authenticator an auth configuration
+// describes, or nil for none
+func newAuthenticator(host, api string, cfg config.RegistryAuthConfig) (authenticator, error) {
+	switch cfg.Type {
+	case "":
+		return nil, nil
+	case AuthBasic:
+		return &basicAuth{host: host, username: cfg.Username, secretEnv: cfg.SecretEnv}, nil
+	case AuthBearer:
+		return &bearerAuth{host: host, secretEnv: cfg.SecretEnv}, nil
+	case AuthGitHubApp:
+		if cfg.AppID == 0 || cfg.InstallationID == 0 || cfg.PrivateKeyFile == "" {
+			return nil, errors.New("github-app auth needs app_id, installation_id, and private_key_file")
+		}
+		return &githubAppAuth{
+			api:            api,
+			appID:          cfg.AppID,
+			installationID: cfg.InstallationID,
+			keyFile:        cfg.PrivateKeyFile,
+		}, nil
+	case AuthOIDC:
+		if cfg.TokenURL == "" || cfg.ClientID == "" {
+			return nil, errors.New("oidc auth needs token_url and client_id")
+		}
+		tokenURL, err := url.Parse(cfg.TokenURL)
+		if err != nil || tokenURL.Scheme != "https" {
+			return nil, fmt.Errorf("oidc token_url must be an https URL, not %q", cfg.TokenURL)
+		}
+		return &oidcAuth{
+			tokenURL:  cfg.TokenURL,
+			clientID:  cfg.ClientID,
+			scopes:    cfg.Scopes,
+			secretEnv: cfg.SecretEnv,
+			host:      tokenURL.Host,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown auth type %q (use %s, %s, %s, or %s)", cfg.Type, AuthBasic, AuthBearer, AuthGitHubApp, AuthOIDC)
+	}
+}
+
+// lookupSecret returns the value of secretEnv, or else the username and
+// password git's credential helper stores for host
+func lookupSecret(host, secretEnv string) (username, secret string, err error) {
+	if secretEnv != "" {
+		if secret := os.Getenv(secretEnv); secret != "" {
+			return "", secret, nil
+		}
+		return "", "", fmt.Errorf("%s is not set", secretEnv)
+	}
+	username, secret = forge.LookupCredentials(host)
+	if secret == "" {
+		return "", "", fmt.Errorf("no credentials for %s; set secret_env or store them with 'git credential approve'", host)
+	}
+	return username, secret, nil
+}
+
+// basicAuth sends a username and password
+type basicAuth struct {
+	host      string
+	username  string
+	secretEnv string
+}
+
+func (a *basicAuth) authorize(_ context.Context, req *http.Request) error {
+	username, password, err := lookupSecret(a.host, a.secretEnv)
+	if err != nil {
+		return err
+	}
+	if a.username != "" {
+		username = a.username
+	}
+	if username == "" {
+		return fmt.Errorf("basic auth for %s needs a username", a.host)
+	}
+	req.SetBasicAuth(username, password)
+	return nil
+}
+
+// bearerAuth sends a static token
+type bearerAuth struct {
+	host      string
+	secretEnv string
+}
+
+func (a *bearerAuth) authorize(_ context.Context, req *http.Request) error {
+	_, token, err := lookupSecret(a.host, a.secretEnv)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// cachedToken is an access token reused until shortly before it expires
+type cachedToken struct {
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// get returns the cached token or fetches a new one
+func (c *cachedToken) get(ctx context.Context, fetch func(context.Context) (string, time.Time, error)) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Now().Add(tokenLeeway).Before(c.expires) {
+		return c.token, nil
+	}
+	token, expires, err := fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.token, c.expires = token, expires
+	return token, nil
+}
+
+// githubAppAuth sends an installation access token of a GitHub App, which
+// it exchanges for a JWT signed with the app's private key
+type githubAppAuth struct {
+	api            string
+	appID          int64
+	installationID int64
+	keyFile        string
+
+	cachedToken
+}
+
+func (a *githubAppAuth) authorize(ctx context.Context, req *http.Request) error {
+	token, err := a.get(ctx, a.fetchToken)
+	if err != nil {
+		return fmt.Errorf("failed to get a GitHub App installation token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// fetchToken exchanges a JWT for an installation access token
+func (a *githubAppAuth) fetchToken(ctx context.Context) (string, time.Time, error) {
+	jwt, err := a.signJWT(time.Now())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", a.api, a.installationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "glide-cli")
+
+	var body struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := postJSON(req, &body); err != nil {
+		return "", time.Time{}, err
+	}
+	return body.Token, body.ExpiresAt, nil
+}
+
+// signJWT creates the short-lived JWT that authenticates as the app
+func (a *githubAppAuth) signJWT(now time.Time) (string, error) {
+	key, err := loadPrivateKey(a.keyFile)
+	if err != nil {
+		return "", err
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]any{
+		// Allow for clock drift, as GitHub recommends
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(a.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// loadPrivateKey reads a PEM RSA private key in PKCS#1 or PKCS#8 form
+func loadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, rest)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not a PEM private key", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not an RSA private key: %w", path, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an RSA private key", path)
+	}
+	return key, nil
+}
+
+// oidcAuth sends an access token from an OIDC provider's client
+// credentials grant
+type oidcAuth struct {
+	tokenURL  string
+	clientID  string
+	scopes    []string
+	secretEnv string
+	// host is the token endpoint's, whose credential helper entry holds
+	// the client secret
+	host string
+
+	cachedToken
+}
+
+func (a *oidcAuth) authorize(ctx context.Context, req *http.Request) error {
+	token, err := a.get(ctx, a.fetchToken)
+	if err != nil {
+		return fmt.Errorf("failed to get an access token from %s: %w", a.host, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// fetchToken runs the client credentials grant
+func (a *oidcAuth) fetchToken(ctx context.Context) (string, time.Time, error) {
+	_, secret, err := lookupSecret(a.host, a.secretEnv)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {a.clientID},
+		"client_secret": {secret},
+	}
+	if len(a.scopes) > 0 {
+		form.Set("scope", strings.Join(a.scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := postJSON(req, &body); err != nil {
+		return "", time.Time{}, err
+	}
+	if body.AccessToken == "" {
+		return "", time.Time{}, errors.New("the token response has no access_token")
+	}
+	return body.AccessToken, time.Now().Add(time.Duration(body.ExpiresIn) * time.Second), nil
+}
+
+// postJSON sends a token request and decodes its JSON response
+func postJSON(req *http.Request, into any) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(into)
+}
@@ -0,0 +1,35 @@
+// Package pluginregistry resolves where `glide plugins install` downloads a
+// plugin from and authenticates to private registries.
+//
+// A registry is a GitHub-compatible host whose releases carry plugin
+// binaries: github.com, which needs no configuration, or an internal host
+// such as GitHub Enterprise configured in ~/.glide/config.yml:
+//
+//	plugin_registries:
+//	  - name: acme
+//	    host: github.acme.com
+//	    auth:
+//	      type: github-app
+//	      app_id: 1234
+//	      installation_id: 5678
+//	      private_key_file: ~/.config/acme/glide-plugins.pem
+//
+// Auth types are basic, bearer, github-app, and oidc (the client
+// credentials grant, for registries behind SSO). Secrets never live in the
+// configuration: passwords, tokens, and client secrets come from the
+// variable secret_env names, or else from git's credential helper for the
+// registry host.
+//
+// Air-gapped environments read every registry from a mirror instead: a
+// directory, file:// URL, or static web server that `glide plugins mirror
+// sync` filled, named by plugin_mirror or GLIDE_PLUGIN_MIRROR. A mirror
+// keeps each host's releases in the layout of the releases API, under
+// <host>/repos/<owner>/<repo>/releases, and lists what it holds in
+// index.json.
+//
+//	reg, repo, ok := pluginregistry.Resolve("github.acme.com/platform/glide-plugin-deploy", registries)
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, reg.ReleasesURL(repo)+"/latest", nil)
+//	if err := reg.Authorize(ctx, req); err != nil {
+//	    return err
+//	}
+package pluginregistry
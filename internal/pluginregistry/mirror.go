@@ -0,0 +1,178 @@
+package pluginregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+)
+
+// MirrorIndexFile lists what a mirror holds, at its root
+const MirrorIndexFile = "index.json"
+
+// FileTransport serves file:// URLs, so that registries mirrored into a
+// local directory are read like any other
+var FileTransport = http.NewFileTransport(http.Dir("/"))
+
+// MirrorBase returns the URL a mirror location is read from: a directory,
+// a file:// URL, or an http(s):// URL of a static web server
+func MirrorBase(location string) (string, error) {
+	if strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://") {
+		return strings.TrimSuffix(location, "/"), nil
+	}
+	dir, err := MirrorDir(location)
+	if err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(dir)}).String(), nil
+}
+
+// MirrorDir returns the directory of a local mirror given as a path or a
+// file:// URL
+func MirrorDir(location string) (string, error) {
+	if strings.HasPrefix(location, "file://") {
+		u, err := url.Parse(location)
+		if err != nil {
+			return "", fmt.Errorf("invalid mirror %q: %w", location, err)
+		}
+		location = filepath.FromSlash(u.Path)
+	} else if strings.Contains(location, "://") {
+		return "", fmt.Errorf("mirror %q must be a directory or a file:// URL", location)
+	}
+	if location == "" {
+		return "", errors.New("mirror location is empty")
+	}
+	return filepath.Abs(location)
+}
+
+// Mirrored returns the registry served from a mirror, under the directory
+// named after its host. Mirrors need no credentials.
+func (r *Registry) Mirrored(base string) *Registry {
+	return &Registry{Name: r.Name + " (mirror)", Host: r.Host, API: base + "/" + r.Host}
+}
+
+// MirrorPath returns where a mirror stores a file of a repository's
+// releases, laid out like the releases API: latest, tags/<tag>, and
+// download/<tag>/<asset>
+func MirrorPath(dir string, reg *Registry, repo string, elem ...string) string {
+	parts := append([]string{dir, reg.Host, "repos", filepath.FromSlash(repo), "releases"}, elem...)
+	return filepath.Join(parts...)
+}
+
+// MirroredPlugin is a repository a mirror holds releases of
+type MirroredPlugin struct {
+	// Source is what `glide plugins install` takes, e.g.
+	// github.com/glide-cli/glide-plugin-go
+	Source string    `json:"source"`
+	Latest string    `json:"latest"`
+	Tags   []string  `json:"tags"`
+	Synced time.Time `json:"synced"`
+}
+
+// MirrorIndex lists what a mirror holds
+type MirrorIndex struct {
+	Plugins []MirroredPlugin `json:"plugins"`
+}
+
+// LoadMirrorIndex reads a mirror's index; a new mirror has an empty one
+func LoadMirrorIndex(dir string) (*MirrorIndex, error) {
+	data, err := os.ReadFile(filepath.Join(dir, MirrorIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return &MirrorIndex{Plugins: []MirroredPlugin{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var index MirrorIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("invalid mirror index %s: %w", filepath.Join(dir, MirrorIndexFile), err)
+	}
+	return &index, nil
+}
+
+// Add records that the mirror holds a release, which becomes the latest
+func (idx *MirrorIndex) Add(source, tag string, synced time.Time) {
+	for i := range idx.Plugins {
+		p := &idx.Plugins[i]
+		if p.Source != source {
+			continue
+		}
+		p.Latest, p.Synced = tag, synced
+		if !slices.Contains(p.Tags, tag) {
+			p.Tags = append(p.Tags, tag)
+		}
+		return
+	}
+	idx.Plugins = append(idx.Plugins, MirroredPlugin{Source: source, Latest: tag, Tags: []string{tag}, Synced: synced})
+	sort.Slice(idx.Plugins, func(i, j int) bool { return idx.Plugins[i].Source < idx.Plugins[j].Source })
+}
+
+// Save writes the index to the mirror
+func (idx *MirrorIndex) Save(dir string) error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, MirrorIndexFile), append(data, '\n'), 0644)
+}
+
+// MirrorEnv names a mirror for one run, overriding plugin_mirror
+const MirrorEnv = "GLIDE_PLUGIN_MIRROR"
+
+// MirrorLocation returns the mirror plugins are installed from, if any:
+// GLIDE_PLUGIN_MIRROR, or else plugin_mirror in the configuration
+func MirrorLocation(cfg *config.Config) string {
+	if location := os.Getenv(MirrorEnv); location != "" {
+		return location
+	}
+	if cfg == nil {
+		return ""
+	}
+	return cfg.PluginMirror
+}
+
+// WithMirror returns the registries served from a mirror, including
+// github.com, so that nothing is fetched from the network
+func WithMirror(registries []*Registry, location string) ([]*Registry, error) {
+	base, err := MirrorBase(location)
+	if err != nil {
+		return nil, err
+	}
+
+	mirrored := make([]*Registry, 0, len(registries)+1)
+	hasGitHub := false
+	for _, reg := range registries {
+		mirrored = append(mirrored, reg.Mirrored(base))
+		hasGitHub = hasGitHub || reg.Host == GitHub.Host
+	}
+	if !hasGitHub {
+		mirrored = append(mirrored, GitHub.Mirrored(base))
+	}
+	return mirrored, nil
+}
+
+// ResolveLink resolves a link in a release read from base, as mirrors name
+// release assets relative to the release
+func ResolveLink(base, link string) string {
+	ref, err := url.Parse(link)
+	if err != nil || link == "" || ref.IsAbs() {
+		return link
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return link
+	}
+	return baseURL.ResolveReference(ref).String()
+}
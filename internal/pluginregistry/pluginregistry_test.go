@@ -0,0 +1,194 @@
+package pluginregistry
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	reg, err := New(config.PluginRegistryConfig{Host: "github.acme.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "github.acme.com", reg.Name)
+	assert.Equal(t, "https://github.acme.com/api/v3", reg.API)
+	assert.False(t, reg.Authenticated())
+
+	_, err = New(config.PluginRegistryConfig{Name: "acme"})
+	assert.ErrorContains(t, err, "has no host")
+	_, err = New(config.PluginRegistryConfig{Host: "h", Auth: config.RegistryAuthConfig{Type: "kerberos"}})
+	assert.ErrorContains(t, err, `unknown auth type "kerberos"`)
+	_, err = New(config.PluginRegistryConfig{Host: "h", Auth: config.RegistryAuthConfig{Type: AuthGitHubApp, AppID: 1}})
+	assert.ErrorContains(t, err, "needs app_id, installation_id, and private_key_file")
+	_, err = New(config.PluginRegistryConfig{Host: "h", Auth: config.RegistryAuthConfig{Type: AuthOIDC, TokenURL: "http://sso", ClientID: "glide"}})
+	assert.ErrorContains(t, err, "must be an https URL")
+}
+
+func TestResolve(t *testing.T) {
+	acme := &Registry{Name: "acme", Host: "github.acme.com", API: "https://github.acme.com/api/v3"}
+	registries := []*Registry{acme}
+
+	reg, repo, ok := Resolve("https://github.acme.com/platform/glide-plugin-deploy.git", registries)
+	require.True(t, ok)
+	assert.Same(t, acme, reg)
+	assert.Equal(t, "platform/glide-plugin-deploy", repo)
+
+	reg, repo, ok = Resolve("github.com/glide-cli/glide-plugin-go", registries)
+	require.True(t, ok)
+	assert.Same(t, GitHub, reg)
+	assert.Equal(t, "glide-cli/glide-plugin-go", repo)
+
+	for _, source := range []string{"gitlab.com/a/b", "github.com/a", "./glide-plugin-go", "github.com/a/b/c"} {
+		_, _, ok = Resolve(source, registries)
+		assert.False(t, ok, source)
+	}
+}
+
+func TestAuthorize_Static(t *testing.T) {
+	t.Setenv("ACME_TOKEN", "s3cr3t")
+
+	bearer, err := New(config.PluginRegistryConfig{Name: "acme", Host: "github.acme.com", Auth: config.RegistryAuthConfig{Type: AuthBearer, SecretEnv: "ACME_TOKEN"}})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, bearer.ReleasesURL("a/b"), nil)
+	require.NoError(t, bearer.Authorize(context.Background(), req))
+	assert.Equal(t, "Bearer s3cr3t", req.Header.Get("Authorization"))
+
+	elsewhere := httptest.NewRequest(http.MethodGet, "https://objects.example.com/asset", nil)
+	assert.ErrorContains(t, bearer.Authorize(context.Background(), elsewhere), "refusing to send acme credentials")
+
+	basic, err := New(config.PluginRegistryConfig{Name: "acme", Host: "github.acme.com", Auth: config.RegistryAuthConfig{Type: AuthBasic, Username: "ci", SecretEnv: "ACME_TOKEN"}})
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodGet, basic.ReleasesURL("a/b"), nil)
+	require.NoError(t, basic.Authorize(context.Background(), req))
+	username, password, _ := req.BasicAuth()
+	assert.Equal(t, "ci", username)
+	assert.Equal(t, "s3cr3t", password)
+
+	t.Setenv("ACME_TOKEN", "")
+	assert.ErrorContains(t, bearer.Authorize(context.Background(), req), "ACME_TOKEN is not set")
+}
+
+func TestAuthorize_GitHubApp(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "app.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600))
+
+	exchanges := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges++
+		assert.Equal(t, "/app/installations/5678/access_tokens", r.URL.Path)
+		jwt := strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), ".")
+		require.Len(t, jwt, 3)
+		claims, err := base64.RawURLEncoding.DecodeString(jwt[1])
+		require.NoError(t, err)
+		assert.Contains(t, string(claims), `"iss":"1234"`)
+		_, _ = w.Write([]byte(`{"token":"ghs_installation","expires_at":"2999-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	reg, err := New(config.PluginRegistryConfig{Host: "github.acme.com", API: server.URL, Auth: config.RegistryAuthConfig{
+		Type: AuthGitHubApp, AppID: 1234, InstallationID: 5678, PrivateKeyFile: keyFile,
+	}})
+	require.NoError(t, err)
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodGet, reg.ReleasesURL("a/b"), nil)
+		require.NoError(t, reg.Authorize(context.Background(), req))
+		assert.Equal(t, "Bearer ghs_installation", req.Header.Get("Authorization"))
+	}
+	assert.Equal(t, 1, exchanges, "the installation token is reused until it expires")
+}
+
+func TestAuthorize_OIDC(t *testing.T) {
+	t.Setenv("ACME_CLIENT_SECRET", "client-secret")
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "glide", r.PostForm.Get("client_id"))
+		assert.Equal(t, "client-secret", r.PostForm.Get("client_secret"))
+		assert.Equal(t, "plugins:read openid", r.PostForm.Get("scope"))
+		_, _ = w.Write([]byte(`{"access_token":"sso-token","expires_in":3600}`))
+	}))
+	defer server.Close()
+	original := httpClient
+	httpClient = server.Client()
+	defer func() { httpClient = original }()
+
+	reg, err := New(config.PluginRegistryConfig{Host: "plugins.acme.com", Auth: config.RegistryAuthConfig{
+		Type: AuthOIDC, TokenURL: server.URL + "/token", ClientID: "glide",
+		Scopes: []string{"plugins:read", "openid"}, SecretEnv: "ACME_CLIENT_SECRET",
+	}})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, reg.ReleasesURL("a/b"), nil)
+	require.NoError(t, reg.Authorize(context.Background(), req))
+	assert.Equal(t, "Bearer sso-token", req.Header.Get("Authorization"))
+}
+
+func TestWithMirror(t *testing.T) {
+	acme := &Registry{Name: "acme", Host: "github.acme.com", API: "https://github.acme.com/api/v3", auth: &bearerAuth{}}
+	dir := t.TempDir()
+
+	mirrored, err := WithMirror([]*Registry{acme}, dir)
+	require.NoError(t, err)
+	require.Len(t, mirrored, 2, "github.com is mirrored too")
+	assert.Equal(t, "file://"+filepath.ToSlash(dir)+"/github.acme.com", mirrored[0].API)
+	assert.False(t, mirrored[0].Authenticated(), "mirrors need no credentials")
+	assert.Equal(t, "file://"+filepath.ToSlash(dir)+"/github.com", mirrored[1].API)
+
+	mirrored, err = WithMirror(nil, "https://mirror.acme.com/glide/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://mirror.acme.com/glide/github.com", mirrored[0].API)
+
+	_, err = WithMirror(nil, "s3://bucket")
+	assert.ErrorContains(t, err, "must be a directory or a file:// URL")
+}
+
+func TestMirrorLocation(t *testing.T) {
+	t.Setenv(MirrorEnv, "")
+	assert.Empty(t, MirrorLocation(nil))
+	assert.Equal(t, "/srv/mirror", MirrorLocation(&config.Config{PluginMirror: "/srv/mirror"}))
+	t.Setenv(MirrorEnv, "/mnt/usb")
+	assert.Equal(t, "/mnt/usb", MirrorLocation(&config.Config{PluginMirror: "/srv/mirror"}))
+}
+
+func TestMirrorIndex(t *testing.T) {
+	dir := t.TempDir()
+	index, err := LoadMirrorIndex(dir)
+	require.NoError(t, err)
+	assert.Empty(t, index.Plugins)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	index.Add("github.com/b/plugin", "v1.0.0", now)
+	index.Add("github.com/a/plugin", "v2.0.0", now)
+	index.Add("github.com/b/plugin", "v1.1.0", now)
+	require.NoError(t, index.Save(dir))
+
+	index, err = LoadMirrorIndex(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []MirroredPlugin{
+		{Source: "github.com/a/plugin", Latest: "v2.0.0", Tags: []string{"v2.0.0"}, Synced: now},
+		{Source: "github.com/b/plugin", Latest: "v1.1.0", Tags: []string{"v1.0.0", "v1.1.0"}, Synced: now},
+	}, index.Plugins)
+}
+
+func TestResolveLink(t *testing.T) {
+	base := "file:///srv/mirror/github.com/repos/a/b/releases/tags/v1"
+	assert.Equal(t, "file:///srv/mirror/github.com/repos/a/b/releases/download/v1/b-linux-amd64", ResolveLink(base, "../download/v1/b-linux-amd64"))
+	assert.Equal(t, "https://github.com/a/b/x", ResolveLink(base, "https://github.com/a/b/x"))
+	assert.Empty(t, ResolveLink(base, ""))
+}
@@ -0,0 +1,126 @@
+package pluginregistry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+)
+
+// Auth types of RegistryAuthConfig.Type
+const (
+	AuthBasic     = "basic"
+	AuthBearer    = "bearer"
+	AuthGitHubApp = "github-app"
+	AuthOIDC      = "oidc"
+)
+
+// Registry is a host plugin releases are downloaded from
+type Registry struct {
+	Name string
+	Host string
+	// API is the base URL of the host's REST API
+	API string
+
+	auth authenticator
+}
+
+// GitHub is the public registry, used for github.com sources
+var GitHub = &Registry{Name: "github", Host: "github.com", API: "https://api.github.com"}
+
+// authenticator adds credentials to requests to a registry
+type authenticator interface {
+	authorize(ctx context.Context, req *http.Request) error
+}
+
+// New creates the registry a configuration entry describes
+func New(cfg config.PluginRegistryConfig) (*Registry, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("plugin registry %q has no host", cfg.Name)
+	}
+	name := cfg.Name
+	if name == "" {
+		name = cfg.Host
+	}
+	api := strings.TrimSuffix(cfg.API, "/")
+	if api == "" {
+		api = "https://" + cfg.Host + "/api/v3"
+	}
+
+	reg := &Registry{Name: name, Host: cfg.Host, API: api}
+	auth, err := newAuthenticator(cfg.Host, api, cfg.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("plugin registry %s: %w", name, err)
+	}
+	reg.auth = auth
+	return reg, nil
+}
+
+// FromConfig creates the configured registries
+func FromConfig(cfg *config.Config) ([]*Registry, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	registries := make([]*Registry, 0, len(cfg.PluginRegistries))
+	for _, entry := range cfg.PluginRegistries {
+		reg, err := New(entry)
+		if err != nil {
+			return nil, err
+		}
+		registries = append(registries, reg)
+	}
+	return registries, nil
+}
+
+// Resolve finds the registry of an install source such as
+// github.acme.com/platform/glide-plugin-deploy, optionally with https://,
+// and returns it with the owner/repo. github.com sources resolve to GitHub
+// unless a registry configures that host.
+func Resolve(source string, registries []*Registry) (*Registry, string, bool) {
+	source = strings.TrimPrefix(source, "https://")
+	source = strings.TrimSuffix(source, ".git")
+	parts := strings.Split(strings.Trim(source, "/"), "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return nil, "", false
+	}
+	repo := parts[1] + "/" + parts[2]
+
+	for _, reg := range registries {
+		if reg.Host == parts[0] {
+			return reg, repo, true
+		}
+	}
+	if parts[0] == GitHub.Host {
+		return GitHub, repo, true
+	}
+	return nil, "", false
+}
+
+// ReleasesURL returns the API URL of a repository's releases
+func (r *Registry) ReleasesURL(repo string) string {
+	return r.API + "/repos/" + repo + "/releases"
+}
+
+// Authenticated reports whether requests carry credentials
+func (r *Registry) Authenticated() bool {
+	return r.auth != nil
+}
+
+// Owns reports whether url points into the registry, so that credentials
+// are only ever sent to it
+func (r *Registry) Owns(url string) bool {
+	return strings.HasPrefix(url, r.API+"/") || strings.HasPrefix(url, "https://"+r.Host+"/")
+}
+
+// Authorize adds the registry's credentials to a request to it
+func (r *Registry) Authorize(ctx context.Context, req *http.Request) error {
+	if r.auth == nil {
+		return nil
+	}
+	if !r.Owns(req.URL.String()) {
+		return fmt.Errorf("refusing to send %s credentials to %s", r.Name, req.URL.Host)
+	}
+	return r.auth.authorize(ctx, req)
+}
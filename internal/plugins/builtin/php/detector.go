@@ -154,7 +154,7 @@ func (d *PHPDetector) Detect(projectPath string) (*sdk.DetectionResult, error) {
 		result.Confidence += 10
 	}
 	// Check for PHP files
-	if d.hasFileWithExtension(projectPath, []string{".php"}) {
+	if sdk.HasFileWithExtension(projectPath, []string{".php"}) {
 		result.Confidence += 10
 	}
 	result.Confidence = min(100, result.Confidence)
@@ -477,26 +477,6 @@ func appendToList(existing, value string) string {
 	return existing + "," + value
 }
 
-// hasFileWithExtension checks if any files with given extensions exist
-func (d *PHPDetector) hasFileWithExtension(projectPath string, extensions []string) bool {
-	for _, ext := range extensions {
-		pattern := filepath.Join(projectPath, "*"+ext)
-		matches, err := filepath.Glob(pattern)
-		if err == nil && len(matches) > 0 {
-			return true
-		}
-		// Also check in common directories
-		for _, dir := range []string{"src", "app", "public", "tests"} {
-			pattern = filepath.Join(projectPath, dir, "*"+ext)
-			matches, err = filepath.Glob(pattern)
-			if err == nil && len(matches) > 0 {
-				return true
-			}
-		}
-	}
-	return false
-}
-
 // min returns the minimum of two integers
 func min(a, b int) int {
 	if a < b {
@@ -29,6 +29,19 @@
 //	registry := plugins.NewBuiltinRegistry()
 //	registry.RegisterAll(root)
 //
+// # Marketplace
+//
+// `glide plugins install <name>` resolves plugin names against a
+// marketplace index, an https:// JSON file named by plugin_index or
+// GLIDE_PLUGIN_INDEX, listing each plugin's binaries by platform with their
+// sha256 checksums:
+//
+//	market, _ := plugins.NewMarketplace(plugins.IndexLocation(cfg), client)
+//	index, _ := market.Index(ctx)
+//	plugin, _ := index.Lookup("docker-tools")
+//	binary, _ := plugin.Binary(runtime.GOOS, runtime.GOARCH)
+//	path, err := market.Download(ctx, binary) // checksum verified
+//
 // # Plugin Paths
 //
 // Standard plugin locations:
@@ -0,0 +1,231 @@
+package plugins
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/pluginregistry"
+	"github.com/glide-cli/glide/v3/pkg/artifacts"
+)
+
+// IndexEnv names a marketplace index for one run, overriding plugin_index
+const IndexEnv = "GLIDE_PLUGIN_INDEX"
+
+// ErrNotInIndex is returned by Lookup for plugins the index does not list
+var ErrNotInIndex = errors.New("not in the plugin index")
+
+// namePattern matches the plugin names `glide plugins install` resolves
+// against the index, as opposed to paths and repository sources
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Index lists the plugins a marketplace offers
+type Index struct {
+	Plugins []IndexPlugin `json:"plugins"`
+}
+
+// IndexPlugin is a plugin of the index, at the version it offers. The
+// plugin is installed under its name.
+type IndexPlugin struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+	Homepage    string `json:"homepage,omitempty"`
+	// Binaries are keyed by platform, e.g. linux-amd64
+	Binaries map[string]IndexBinary `json:"binaries"`
+}
+
+// IndexBinary is a plugin binary for one platform
+type IndexBinary struct {
+	// URL is absolute or relative to the index
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+// Marketplace installs plugins by name from the index at URL
+type Marketplace struct {
+	URL    string
+	Client *http.Client
+	// Cache keeps downloaded binaries when set
+	Cache *artifacts.Cache
+}
+
+// IndexLocation returns the index plugin names are resolved against, if
+// any: GLIDE_PLUGIN_INDEX, or else plugin_index in the configuration
+func IndexLocation(cfg *config.Config) string {
+	if location := os.Getenv(IndexEnv); location != "" {
+		return location
+	}
+	if cfg == nil {
+		return ""
+	}
+	return cfg.PluginIndex
+}
+
+// IsName reports whether an install source is a plugin name, such as
+// docker-tools, rather than a path or a repository
+func IsName(source string) bool {
+	return namePattern.MatchString(source) && source != "." && source != ".."
+}
+
+// NewMarketplace creates the marketplace of an index URL, which must be
+// https://, or file:// for an index on disk
+func NewMarketplace(location string, client *http.Client) (*Marketplace, error) {
+	if !strings.HasPrefix(location, "https://") && !strings.HasPrefix(location, "file://") {
+		return nil, fmt.Errorf("plugin index %q must be an https:// or file:// URL", location)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Marketplace{URL: location, Client: client}, nil
+}
+
+// Index fetches the index, resolving the binary URLs it holds
+func (m *Marketplace) Index(ctx context.Context) (*Index, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "glide-cli")
+
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plugin index: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("plugin index %s returned status %d", m.URL, resp.StatusCode)
+	}
+
+	var index Index
+	if err := json.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return nil, fmt.Errorf("invalid plugin index %s: %w", m.URL, err)
+	}
+	for i := range index.Plugins {
+		for platform, binary := range index.Plugins[i].Binaries {
+			binary.URL = pluginregistry.ResolveLink(m.URL, binary.URL)
+			index.Plugins[i].Binaries[platform] = binary
+		}
+	}
+	return &index, nil
+}
+
+// Lookup returns the plugin of the index named name
+func (idx *Index) Lookup(name string) (*IndexPlugin, error) {
+	for i := range idx.Plugins {
+		if idx.Plugins[i].Name == name {
+			return &idx.Plugins[i], nil
+		}
+	}
+	return nil, fmt.Errorf("plugin %q: %w", name, ErrNotInIndex)
+}
+
+// Binary returns the plugin's binary for a platform
+func (p *IndexPlugin) Binary(goos, goarch string) (IndexBinary, error) {
+	binary, ok := p.Binaries[goos+"-"+goarch]
+	if !ok {
+		platforms := make([]string, 0, len(p.Binaries))
+		for platform := range p.Binaries {
+			platforms = append(platforms, platform)
+		}
+		sort.Strings(platforms)
+		return IndexBinary{}, fmt.Errorf("plugin %s %s has no binary for %s-%s (available: %s)",
+			p.Name, p.Version, goos, goarch, strings.Join(platforms, ", "))
+	}
+	if _, err := hex.DecodeString(binary.SHA256); err != nil || len(binary.SHA256) != sha256.Size*2 {
+		return IndexBinary{}, fmt.Errorf("plugin %s %s has no valid sha256 checksum for %s-%s", p.Name, p.Version, goos, goarch)
+	}
+	return binary, nil
+}
+
+// Download downloads a binary to a temporary file, which the caller owns,
+// and verifies its checksum. Binaries of an https:// index must be served
+// over https.
+func (m *Marketplace) Download(ctx context.Context, binary IndexBinary) (string, error) {
+	u, err := url.Parse(binary.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid download URL %q: %w", binary.URL, err)
+	}
+	if u.Scheme != "https" && !(u.Scheme == "file" && strings.HasPrefix(m.URL, "file://")) {
+		return "", fmt.Errorf("invalid download URL %q: must be https", binary.URL)
+	}
+
+	download := func() (string, error) { return m.downloadFile(ctx, binary.URL) }
+	var path string
+	if m.Cache != nil && u.Scheme == "https" {
+		// Keyed by checksum too, so a binary replaced at the same URL is
+		// downloaded again
+		path, err = m.Cache.Download(binary.URL+"#sha256="+binary.SHA256, download)
+	} else {
+		path, err = download()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := VerifyChecksum(path, binary.SHA256); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// downloadFile downloads a URL to a temporary file
+func (m *Marketplace) downloadFile(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "glide-cli")
+
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	tmpFile, err := os.CreateTemp("", "glide-plugin-*")
+	if err != nil {
+		return "", err
+	}
+	defer tmpFile.Close()
+	if _, err := io.Copy(tmpFile, resp.Body); err != nil {
+		os.Remove(tmpFile.Name())
+		return "", err
+	}
+	return tmpFile.Name(), nil
+}
+
+// VerifyChecksum checks that the file at path has the sha256 checksum sum,
+// given in hex
+func VerifyChecksum(path, sum string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, sum) {
+		return fmt.Errorf("checksum mismatch: expected sha256 %s, got %s", strings.ToLower(sum), got)
+	}
+	return nil
+}
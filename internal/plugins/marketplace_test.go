@@ -0,0 +1,174 @@
+package plugins
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/pkg/artifacts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checksum(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// serveIndex serves an index listing docker-tools with a linux-amd64
+// binary, whose checksum is sum
+func serveIndex(t *testing.T, sum string) (*httptest.Server, *int) {
+	t.Helper()
+	downloads := 0
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Index{Plugins: []IndexPlugin{{
+			Name:    "docker-tools",
+			Version: "1.2.0",
+			Binaries: map[string]IndexBinary{
+				"linux-amd64":  {URL: "bin/docker-tools-linux-amd64", SHA256: sum},
+				"darwin-arm64": {URL: server.URL + "/bin/docker-tools-darwin-arm64", SHA256: sum},
+			},
+		}}})
+	})
+	mux.HandleFunc("/bin/", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		_, _ = w.Write([]byte("plugin binary"))
+	})
+	server = httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+	return server, &downloads
+}
+
+func TestIsName(t *testing.T) {
+	for source, want := range map[string]bool{
+		"docker-tools":                         true,
+		"go":                                   true,
+		"plugin_1.0":                           true,
+		"./docker-tools":                       false,
+		"github.com/glide-cli/glide-plugin-go": false,
+		"..":                                   false,
+		"-rf":                                  false,
+		"":                                     false,
+	} {
+		assert.Equal(t, want, IsName(source), source)
+	}
+}
+
+func TestIndexLocation(t *testing.T) {
+	t.Setenv(IndexEnv, "")
+	assert.Empty(t, IndexLocation(nil))
+	assert.Equal(t, "https://plugins.acme.com/index.json", IndexLocation(&config.Config{PluginIndex: "https://plugins.acme.com/index.json"}))
+
+	t.Setenv(IndexEnv, "https://override/index.json")
+	assert.Equal(t, "https://override/index.json", IndexLocation(&config.Config{PluginIndex: "https://plugins.acme.com/index.json"}))
+}
+
+func TestNewMarketplace(t *testing.T) {
+	_, err := NewMarketplace("http://plugins.acme.com/index.json", nil)
+	assert.ErrorContains(t, err, "must be an https:// or file:// URL")
+
+	m, err := NewMarketplace("https://plugins.acme.com/index.json", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.DefaultClient, m.Client)
+}
+
+func TestMarketplaceInstall(t *testing.T) {
+	sum := checksum("plugin binary")
+	server, downloads := serveIndex(t, sum)
+	m, err := NewMarketplace(server.URL+"/index.json", server.Client())
+	require.NoError(t, err)
+	m.Cache = artifacts.New(t.TempDir(), 1<<20)
+
+	index, err := m.Index(context.Background())
+	require.NoError(t, err)
+
+	_, err = index.Lookup("nope")
+	assert.True(t, errors.Is(err, ErrNotInIndex))
+
+	plugin, err := index.Lookup("docker-tools")
+	require.NoError(t, err)
+	binary, err := plugin.Binary("linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/bin/docker-tools-linux-amd64", binary.URL, "relative URLs resolve against the index")
+
+	_, err = plugin.Binary("windows", "amd64")
+	assert.ErrorContains(t, err, "no binary for windows-amd64 (available: darwin-arm64, linux-amd64)")
+
+	for range 2 {
+		path, err := m.Download(context.Background(), binary)
+		require.NoError(t, err)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "plugin binary", string(data))
+		os.Remove(path)
+	}
+	assert.Equal(t, 1, *downloads, "the second download comes from the cache")
+}
+
+func TestMarketplaceDownloadChecksumMismatch(t *testing.T) {
+	server, _ := serveIndex(t, checksum("another binary"))
+	m, err := NewMarketplace(server.URL+"/index.json", server.Client())
+	require.NoError(t, err)
+
+	index, err := m.Index(context.Background())
+	require.NoError(t, err)
+	plugin, err := index.Lookup("docker-tools")
+	require.NoError(t, err)
+	binary, err := plugin.Binary("linux", "amd64")
+	require.NoError(t, err)
+
+	_, err = m.Download(context.Background(), binary)
+	assert.ErrorContains(t, err, "checksum mismatch")
+}
+
+func TestMarketplaceRejectsInsecureBinaries(t *testing.T) {
+	m, err := NewMarketplace("https://plugins.acme.com/index.json", nil)
+	require.NoError(t, err)
+
+	for _, u := range []string{"http://plugins.acme.com/bin/x", "file:///tmp/x"} {
+		_, err = m.Download(context.Background(), IndexBinary{URL: u, SHA256: checksum("x")})
+		assert.ErrorContains(t, err, "must be https", u)
+	}
+}
+
+func TestIndexBinaryNeedsChecksum(t *testing.T) {
+	plugin := IndexPlugin{Name: "x", Version: "1", Binaries: map[string]IndexBinary{"linux-amd64": {URL: "https://h/x", SHA256: "abc"}}}
+	_, err := plugin.Binary("linux", "amd64")
+	assert.ErrorContains(t, err, "no valid sha256 checksum")
+}
+
+func TestFileIndex(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "x-linux-amd64"), []byte("x"), 0644))
+	data, err := json.Marshal(Index{Plugins: []IndexPlugin{{Name: "x", Version: "1", Binaries: map[string]IndexBinary{
+		"linux-amd64": {URL: "x-linux-amd64", SHA256: checksum("x")},
+	}}}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.json"), data, 0644))
+
+	transport := &http.Transport{}
+	transport.RegisterProtocol("file", http.NewFileTransport(http.Dir("/")))
+	m, err := NewMarketplace("file://"+filepath.ToSlash(dir)+"/index.json", &http.Client{Transport: transport})
+	require.NoError(t, err)
+
+	index, err := m.Index(context.Background())
+	require.NoError(t, err)
+	plugin, err := index.Lookup("x")
+	require.NoError(t, err)
+	binary, err := plugin.Binary("linux", "amd64")
+	require.NoError(t, err)
+	path, err := m.Download(context.Background(), binary)
+	require.NoError(t, err)
+	defer os.Remove(path)
+	assert.NoError(t, VerifyChecksum(path, checksum("x")))
+}
@@ -0,0 +1,17 @@
+// Package pluginstats keeps a history of plugin command runs so users can
+// see which plugin is slowing down their workflow.
+//
+// Every plugin command glide runs appends a record to a log in the user's
+// glide directory:
+//
+//	~/.glide/plugin-stats.jsonl
+//	{"time":"2026-10-15T09:02:11Z","plugin":"docker","command":"up","duration_ms":8412,"failed":false}
+//
+// Summarize totals the records per plugin, or per command of one plugin,
+// slowest first:
+//
+//	records, err := pluginstats.Load(pluginstats.DefaultLogPath())
+//	for _, stats := range pluginstats.Summarize(records, since, "") {
+//	    fmt.Println(stats.Plugin, stats.Runs, stats.Total)
+//	}
+package pluginstats
@@ -0,0 +1,81 @@
+package pluginstats
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// Record is one run of a plugin command
+type Record struct {
+	Time    time.Time `json:"time"`
+	Plugin  string    `json:"plugin"`
+	Command string    `json:"command"`
+	// DurationMS is the run time in milliseconds
+	DurationMS int64 `json:"duration_ms"`
+	Failed     bool  `json:"failed"`
+	// Invocation is the ID of the glide run, to find the run's logs
+	Invocation string `json:"invocation,omitempty"`
+}
+
+// Duration returns how long the run took
+func (r Record) Duration() time.Duration {
+	return time.Duration(r.DurationMS) * time.Millisecond
+}
+
+// DefaultLogPath returns the run log in the user's glide directory
+func DefaultLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "plugin-stats.jsonl")
+}
+
+// Append adds a record to the log. Each record is a single small write, so
+// concurrent glide processes do not interleave lines.
+func Append(path string, record Record) error {
+	record.Time = record.Time.UTC()
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads every record in the log. A missing log has no records, and
+// lines that cannot be parsed are skipped.
+func Load(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Time.IsZero() || record.Plugin == "" {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
@@ -0,0 +1,70 @@
+package pluginstats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plugin-stats.jsonl")
+
+	records, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	require.NoError(t, Append(path, Record{Time: start, Plugin: "docker", Command: "up", DurationMS: 8400}))
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, _ = f.WriteString("not json\n")
+	f.Close()
+	require.NoError(t, Append(path, Record{Time: start.Add(time.Hour), Plugin: "docker", Command: "test", DurationMS: 12000, Failed: true}))
+
+	records, err = Load(path)
+	require.NoError(t, err)
+	require.Len(t, records, 2, "corrupt lines are skipped")
+	assert.Equal(t, 8400*time.Millisecond, records[0].Duration())
+	assert.True(t, records[1].Failed)
+}
+
+func TestSummarize(t *testing.T) {
+	records := []Record{
+		{Time: start, Plugin: "docker", Command: "up", DurationMS: 8000},
+		{Time: start.Add(time.Minute), Plugin: "docker", Command: "up", DurationMS: 10000, Failed: true},
+		{Time: start.Add(2 * time.Minute), Plugin: "docker", Command: "ps", DurationMS: 200},
+		{Time: start.Add(3 * time.Minute), Plugin: "git", Command: "status", DurationMS: 100},
+		{Time: start.Add(-48 * time.Hour), Plugin: "aws", Command: "login", DurationMS: 60000},
+	}
+
+	t.Run("per plugin, slowest first", func(t *testing.T) {
+		stats := Summarize(records, start, "")
+		require.Len(t, stats, 2, "runs before since are left out")
+		docker := stats[0]
+		assert.Equal(t, "docker", docker.Plugin)
+		assert.Empty(t, docker.Command)
+		assert.Equal(t, 3, docker.Runs)
+		assert.Equal(t, 1, docker.Failures)
+		assert.InDelta(t, 1.0/3, docker.FailureRate, 0.001)
+		assert.Equal(t, 8*time.Second, docker.Median)
+		assert.Equal(t, 10*time.Second, docker.P95)
+		assert.Equal(t, 18200*time.Millisecond, docker.Total)
+		assert.Equal(t, start.Add(2*time.Minute), docker.Last)
+		assert.Equal(t, "git", stats[1].Plugin)
+	})
+
+	t.Run("per command of one plugin", func(t *testing.T) {
+		stats := Summarize(records, start, "docker")
+		require.Len(t, stats, 2)
+		assert.Equal(t, "up", stats[0].Command)
+		assert.Equal(t, 2, stats[0].Runs)
+		assert.Equal(t, "ps", stats[1].Command)
+	})
+
+	assert.Empty(t, Summarize(records, start, "unknown"))
+}
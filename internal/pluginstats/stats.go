@@ -0,0 +1,86 @@
+package pluginstats
+
+import (
+	"sort"
+	"time"
+)
+
+// Stats summarizes the runs of a plugin, or of one of its commands
+type Stats struct {
+	Plugin string `json:"plugin" yaml:"plugin"`
+	// Command is empty when the stats cover the whole plugin
+	Command  string `json:"command,omitempty" yaml:"command,omitempty"`
+	Runs     int    `json:"runs" yaml:"runs"`
+	Failures int    `json:"failures" yaml:"failures"`
+	// FailureRate is Failures over Runs
+	FailureRate float64       `json:"failure_rate" yaml:"failure_rate"`
+	Median      time.Duration `json:"median_ns" yaml:"median"`
+	P95         time.Duration `json:"p95_ns" yaml:"p95"`
+	Total       time.Duration `json:"total_ns" yaml:"total"`
+	Last        time.Time     `json:"last" yaml:"last"`
+}
+
+// Summarize totals the records since a time per plugin or, when plugin is
+// set, per command of that plugin. The plugins or commands that took the
+// most time in total come first.
+func Summarize(records []Record, since time.Time, plugin string) []Stats {
+	type key struct{ plugin, command string }
+	groups := make(map[key][]Record)
+	for _, r := range records {
+		if r.Time.Before(since) || (plugin != "" && r.Plugin != plugin) {
+			continue
+		}
+		k := key{plugin: r.Plugin}
+		if plugin != "" {
+			k.command = r.Command
+		}
+		groups[k] = append(groups[k], r)
+	}
+
+	stats := make([]Stats, 0, len(groups))
+	for k, group := range groups {
+		stats = append(stats, summarizeGroup(k.plugin, k.command, group))
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Total != stats[j].Total {
+			return stats[i].Total > stats[j].Total
+		}
+		if stats[i].Plugin != stats[j].Plugin {
+			return stats[i].Plugin < stats[j].Plugin
+		}
+		return stats[i].Command < stats[j].Command
+	})
+	return stats
+}
+
+// summarizeGroup computes the statistics of a group of runs
+func summarizeGroup(plugin, command string, records []Record) Stats {
+	st := Stats{Plugin: plugin, Command: command, Runs: len(records)}
+	durations := make([]time.Duration, len(records))
+	for i, r := range records {
+		durations[i] = r.Duration()
+		st.Total += r.Duration()
+		if r.Failed {
+			st.Failures++
+		}
+		if r.Time.After(st.Last) {
+			st.Last = r.Time
+		}
+	}
+	st.FailureRate = float64(st.Failures) / float64(st.Runs)
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	st.Median = percentile(durations, 0.5)
+	st.P95 = percentile(durations, 0.95)
+	return st
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
@@ -0,0 +1,46 @@
+// Package policy loads and evaluates per-project command policies.
+//
+// A policy file at .glide/policy.yml lets platform teams restrict which
+// commands, plugins, and shell escapes are available in a repository. The
+// policy is enforced by the CLI dispatcher, so restricted commands cannot be
+// run and are hidden from help.
+//
+// # Policy File
+//
+//	# .glide/policy.yml
+//	commands:
+//	  allow: [up, down, status, logs, "project *"]
+//	  deny: [self-update, "plugins install"]
+//	plugins:
+//	  allow: [docker]
+//	shell_escapes: false
+//	confirm:
+//	  typed: ["worktree remove"]
+//	  simple: ["project clean"]
+//
+// # Rules
+//
+// Command entries are command paths without the binary name. An entry
+// matches the command itself and all of its subcommands, and may use
+// shell-style wildcards. Deny rules always win over allow rules. An empty
+// allow list permits everything that is not denied.
+//
+// Shell escapes are commands that hand the user an arbitrary shell: YAML
+// commands, interactive plugin commands, and debug shells. They are allowed
+// unless shell_escapes is set to false.
+//
+// Highly destructive commands, such as removing volumes, are confirmed by
+// typing the project name. confirm.typed requires that for more
+// destructive commands, and confirm.simple lets a yes/no answer confirm
+// highly destructive ones; typed entries win.
+//
+// # Usage
+//
+//	pol, err := policy.Load(ctx.ProjectRoot)
+//	if err != nil {
+//	    return err
+//	}
+//	if pol != nil && !pol.AllowsCommand("self-update") {
+//	    // refuse to run
+//	}
+package policy
@@ -0,0 +1,182 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Dir is the project directory that holds the policy file
+	Dir = ".glide"
+	// FileName is the name of the policy file within Dir
+	FileName = "policy.yml"
+)
+
+// Policy restricts what can be run inside a project
+type Policy struct {
+	Commands     Rules        `yaml:"commands"`
+	Plugins      Rules        `yaml:"plugins"`
+	ShellEscapes *bool        `yaml:"shell_escapes,omitempty"`
+	Confirm      ConfirmRules `yaml:"confirm"`
+
+	path string
+}
+
+// ConfirmRules chooses how destructive commands are confirmed
+type ConfirmRules struct {
+	// Typed lists destructive commands that must be confirmed by typing
+	// the project name, in addition to those glide considers highly
+	// destructive
+	Typed []string `yaml:"typed,omitempty"`
+	// Simple lists highly destructive commands a yes/no answer confirms
+	Simple []string `yaml:"simple,omitempty"`
+}
+
+// Rules is an allow/deny list of names or command paths
+type Rules struct {
+	Allow []string `yaml:"allow,omitempty"`
+	Deny  []string `yaml:"deny,omitempty"`
+}
+
+// FilePath returns the policy file location for a project root
+func FilePath(projectRoot string) string {
+	return filepath.Join(projectRoot, Dir, FileName)
+}
+
+// Load reads the policy for a project. It returns nil without error when
+// the project has no policy file.
+func Load(projectRoot string) (*Policy, error) {
+	if projectRoot == "" {
+		return nil, nil
+	}
+
+	policyPath := FilePath(projectRoot)
+	data, err := os.ReadFile(policyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read policy file %s: %w", policyPath, err)
+	}
+
+	pol, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", policyPath, err)
+	}
+	pol.path = policyPath
+
+	return pol, nil
+}
+
+// Parse decodes a policy from YAML
+func Parse(data []byte) (*Policy, error) {
+	var pol Policy
+	if err := yaml.Unmarshal(data, &pol); err != nil {
+		return nil, err
+	}
+
+	patterns := append(append([]string{}, pol.Commands.Allow...), pol.Commands.Deny...)
+	patterns = append(append(patterns, pol.Confirm.Typed...), pol.Confirm.Simple...)
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid command pattern %q: %w", pattern, err)
+		}
+	}
+
+	return &pol, nil
+}
+
+// Path returns the file the policy was loaded from
+func (p *Policy) Path() string {
+	return p.path
+}
+
+// AllowsCommand reports whether a command path (without the binary name,
+// e.g. "project down") may run
+func (p *Policy) AllowsCommand(commandPath string) bool {
+	if p == nil {
+		return true
+	}
+	return p.Commands.permits(commandPath, matchCommand)
+}
+
+// AllowsPlugin reports whether commands provided by the named plugin may run
+func (p *Policy) AllowsPlugin(name string) bool {
+	if p == nil {
+		return true
+	}
+	return p.Plugins.permits(name, matchName)
+}
+
+// AllowsShellEscapes reports whether commands that run arbitrary shell
+// input may run
+func (p *Policy) AllowsShellEscapes() bool {
+	return p == nil || p.ShellEscapes == nil || *p.ShellEscapes
+}
+
+// RequiresTypedConfirm reports whether a destructive command path must be
+// confirmed by typing the project name. highlyDestructive is what glide
+// requires without a policy; Typed entries win over Simple ones.
+func (p *Policy) RequiresTypedConfirm(commandPath string, highlyDestructive bool) bool {
+	if p == nil {
+		return highlyDestructive
+	}
+	for _, pattern := range p.Confirm.Typed {
+		if matchCommand(pattern, commandPath) {
+			return true
+		}
+	}
+	for _, pattern := range p.Confirm.Simple {
+		if matchCommand(pattern, commandPath) {
+			return false
+		}
+	}
+	return highlyDestructive
+}
+
+// permits applies deny-before-allow evaluation
+func (r Rules) permits(value string, match func(pattern, value string) bool) bool {
+	for _, pattern := range r.Deny {
+		if match(pattern, value) {
+			return false
+		}
+	}
+
+	if len(r.Allow) == 0 {
+		return true
+	}
+
+	for _, pattern := range r.Allow {
+		if match(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCommand matches a pattern against a command path and its ancestors,
+// so that "project" also covers "project down"
+func matchCommand(pattern, commandPath string) bool {
+	pattern = strings.Join(strings.Fields(pattern), " ")
+	fields := strings.Fields(commandPath)
+	for i := len(fields); i > 0; i-- {
+		if matchName(pattern, strings.Join(fields[:i], " ")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchName matches a pattern against a single name
+func matchName(pattern, name string) bool {
+	if pattern == name {
+		return true
+	}
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
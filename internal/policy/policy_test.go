@@ -0,0 +1,123 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("missing policy file", func(t *testing.T) {
+		pol, err := Load(t.TempDir())
+		require.NoError(t, err)
+		assert.Nil(t, pol)
+	})
+
+	t.Run("empty project root", func(t *testing.T) {
+		pol, err := Load("")
+		require.NoError(t, err)
+		assert.Nil(t, pol)
+	})
+
+	t.Run("valid policy file", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, Dir), 0755))
+		require.NoError(t, os.WriteFile(FilePath(root), []byte(`
+commands:
+  deny: [self-update]
+shell_escapes: false
+`), 0644))
+
+		pol, err := Load(root)
+		require.NoError(t, err)
+		require.NotNil(t, pol)
+		assert.Equal(t, FilePath(root), pol.Path())
+		assert.False(t, pol.AllowsCommand("self-update"))
+		assert.False(t, pol.AllowsShellEscapes())
+	})
+
+	t.Run("invalid policy file", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, Dir), 0755))
+		require.NoError(t, os.WriteFile(FilePath(root), []byte("commands: [\n"), 0644))
+
+		_, err := Load(root)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_InvalidPattern(t *testing.T) {
+	_, err := Parse([]byte("commands:\n  allow: [\"[\"]\n"))
+	assert.Error(t, err)
+}
+
+func TestPolicy_AllowsCommand(t *testing.T) {
+	pol := &Policy{
+		Commands: Rules{
+			Allow: []string{"up", "down", "project *", "plugins"},
+			Deny:  []string{"plugins install"},
+		},
+	}
+
+	tests := []struct {
+		command string
+		allowed bool
+	}{
+		{"up", true},
+		{"down", true},
+		{"project down", true},
+		{"plugins list", true},
+		{"plugins install", false},
+		{"self-update", false},
+		{"shell", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, pol.AllowsCommand(tt.command))
+		})
+	}
+}
+
+func TestPolicy_EmptyAllowPermitsAll(t *testing.T) {
+	pol := &Policy{Commands: Rules{Deny: []string{"self-update"}}}
+
+	assert.True(t, pol.AllowsCommand("anything"))
+	assert.False(t, pol.AllowsCommand("self-update"))
+}
+
+func TestPolicy_AllowsPlugin(t *testing.T) {
+	pol := &Policy{Plugins: Rules{Allow: []string{"docker", "node-*"}}}
+
+	assert.True(t, pol.AllowsPlugin("docker"))
+	assert.True(t, pol.AllowsPlugin("node-tools"))
+	assert.False(t, pol.AllowsPlugin("kubectl"))
+}
+
+func TestPolicy_Nil(t *testing.T) {
+	var pol *Policy
+
+	assert.True(t, pol.AllowsCommand("anything"))
+	assert.True(t, pol.AllowsPlugin("anything"))
+	assert.True(t, pol.AllowsShellEscapes())
+}
+
+func TestPolicy_RequiresTypedConfirm(t *testing.T) {
+	pol, err := Parse([]byte(`
+confirm:
+  typed: ["worktree remove"]
+  simple: ["project down"]
+`))
+	require.NoError(t, err)
+
+	assert.True(t, pol.RequiresTypedConfirm("worktree remove", false))
+	assert.False(t, pol.RequiresTypedConfirm("project down", true))
+	assert.True(t, pol.RequiresTypedConfirm("project clean", true), "glide's default applies to unlisted commands")
+	assert.False(t, pol.RequiresTypedConfirm("snapshot restore", false))
+
+	var none *Policy
+	assert.True(t, none.RequiresTypedConfirm("project down", true))
+}
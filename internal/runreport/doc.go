@@ -0,0 +1,31 @@
+// Package runreport writes a machine-readable report of one glide run, for
+// CI analytics and support tickets.
+//
+// A Recorder collects the report as the run goes: how long each phase took,
+// the warnings printed, and, once the command has finished, its exit code
+// and a snapshot of the metrics collected while it ran. Write saves the
+// report as JSON:
+//
+//	recorder := runreport.NewRecorder(inv.ID, os.Args[1:])
+//	err := recorder.Track("config_load", loadConfig)
+//	...
+//	recorder.Finish("glide up", exitCode, err)
+//	if err := recorder.Write("glide-report.json"); err != nil {
+//	    ...
+//	}
+//
+// The report looks like this:
+//
+//	{
+//	  "command": "glide up",
+//	  "args": ["up", "--report-file", "glide-report.json"],
+//	  "exit_code": 0,
+//	  "duration_ms": 8412,
+//	  "phases": [
+//	    {"name": "config_load", "duration_ms": 3},
+//	    {"name": "command", "duration_ms": 8380}
+//	  ],
+//	  "warnings": ["Port 8080 is already in use"],
+//	  "metrics": {"counters": {...}, "gauges": {...}, "timings": {...}}
+//	}
+package runreport
@@ -0,0 +1,153 @@
+package runreport
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/observability"
+	"github.com/glide-cli/glide/v3/pkg/performance"
+	"github.com/glide-cli/glide/v3/pkg/version"
+)
+
+// FileEnv names the file a run report is written to, like --report-file
+const FileEnv = "GLIDE_REPORT_FILE"
+
+// Phase is a timed part of the run
+type Phase struct {
+	Name       string `json:"name"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Report describes one glide run
+type Report struct {
+	Version      string    `json:"version"`
+	InvocationID string    `json:"invocation_id,omitempty"`
+	Command      string    `json:"command,omitempty"`
+	Args         []string  `json:"args"`
+	Project      string    `json:"project,omitempty"`
+	Started      time.Time `json:"started"`
+	Finished     time.Time `json:"finished"`
+	DurationMS   int64     `json:"duration_ms"`
+	ExitCode     int       `json:"exit_code"`
+	// Error is the message of the error the run failed with
+	Error    string   `json:"error,omitempty"`
+	Phases   []Phase  `json:"phases"`
+	Warnings []string `json:"warnings"`
+	// OverBudget counts the phases that exceeded their performance budget
+	OverBudget map[string]int64              `json:"over_budget,omitempty"`
+	Metrics    observability.MetricsSnapshot `json:"metrics"`
+}
+
+// Recorder collects the report of a run as it goes
+type Recorder struct {
+	mu     sync.Mutex
+	report Report
+
+	now func() time.Time
+}
+
+// NewRecorder starts recording the report of a run with its invocation ID
+// and arguments
+func NewRecorder(invocationID string, args []string) *Recorder {
+	return newRecorder(invocationID, args, time.Now)
+}
+
+func newRecorder(invocationID string, args []string, now func() time.Time) *Recorder {
+	r := &Recorder{now: now}
+	r.report = Report{
+		Version:      version.Get(),
+		InvocationID: invocationID,
+		Args:         append([]string{}, args...),
+		Started:      r.now(),
+		Phases:       []Phase{},
+		Warnings:     []string{},
+	}
+	return r
+}
+
+// Track runs fn through performance.Track and records how long it took as
+// a phase. fn's error is returned unchanged.
+func (r *Recorder) Track(name string, fn func() error) error {
+	start := r.now()
+	err := performance.Track(name, fn)
+	r.AddPhase(name, r.now().Sub(start))
+	return err
+}
+
+// AddPhase records a phase that took d
+func (r *Recorder) AddPhase(name string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Phases = append(r.report.Phases, Phase{Name: name, DurationMS: d.Milliseconds()})
+}
+
+// SetProject records the project root the run belongs to
+func (r *Recorder) SetProject(root string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Project = root
+}
+
+// Finish completes the report with the command that ran, its exit code and
+// error, the warnings printed, and a snapshot of the metrics
+func (r *Recorder) Finish(command string, exitCode int, err error, warnings []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.report.Command = command
+	r.report.ExitCode = exitCode
+	if err != nil {
+		r.report.Error = err.Error()
+	}
+	r.report.Warnings = append(r.report.Warnings, warnings...)
+	if counts := performance.OverBudgetCounts(); len(counts) > 0 {
+		r.report.OverBudget = counts
+	}
+	r.report.Metrics = observability.GetSnapshot()
+	r.report.Finished = r.now()
+	r.report.DurationMS = r.report.Finished.Sub(r.report.Started).Milliseconds()
+}
+
+// Report returns a copy of the report recorded so far
+func (r *Recorder) Report() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := r.report
+	report.Phases = append([]Phase{}, r.report.Phases...)
+	report.Warnings = append([]string{}, r.report.Warnings...)
+	return report
+}
+
+// Write saves the report as indented JSON at path, creating its directory.
+// The file is replaced atomically, so CI never picks up half a report.
+func (r *Recorder) Write(path string) error {
+	data, err := json.MarshalIndent(r.Report(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".glide-report-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,73 @@
+package runreport
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns a clock advancing by step on every reading
+func fakeClock(step time.Duration) func() time.Time {
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	recorder := newRecorder("3f9a1c0e52b7d846", []string{"up", "--report-file", "report.json"}, fakeClock(10*time.Millisecond))
+
+	require.NoError(t, recorder.Track("config_load", func() error { return nil }))
+	failure := errors.New("boom")
+	assert.Equal(t, failure, recorder.Track("plugin_discovery", func() error { return failure }))
+	recorder.AddPhase("command", 2*time.Second)
+	recorder.SetProject("/src/acme")
+	recorder.Finish("glide up", 3, errors.New("docker is not running"), []string{"Port 8080 is in use"})
+
+	report := recorder.Report()
+	assert.Equal(t, "3f9a1c0e52b7d846", report.InvocationID)
+	assert.Equal(t, "glide up", report.Command)
+	assert.Equal(t, []string{"up", "--report-file", "report.json"}, report.Args)
+	assert.Equal(t, "/src/acme", report.Project)
+	assert.Equal(t, 3, report.ExitCode)
+	assert.Equal(t, "docker is not running", report.Error)
+	assert.Equal(t, []string{"Port 8080 is in use"}, report.Warnings)
+	assert.Equal(t, []Phase{
+		{Name: "config_load", DurationMS: 10},
+		{Name: "plugin_discovery", DurationMS: 10},
+		{Name: "command", DurationMS: 2000},
+	}, report.Phases)
+	assert.Equal(t, int64(50), report.DurationMS)
+	assert.NotNil(t, report.Metrics.Counters)
+}
+
+func TestRecorderWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "artifacts", "glide-report.json")
+
+	recorder := NewRecorder("", nil)
+	recorder.Finish("glide test", 0, nil, nil)
+	require.NoError(t, recorder.Write(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "glide test", decoded["command"])
+	assert.Equal(t, float64(0), decoded["exit_code"])
+	assert.NotContains(t, decoded, "error")
+	assert.Equal(t, []interface{}{}, decoded["warnings"])
+	assert.Equal(t, []interface{}{}, decoded["phases"])
+	assert.Contains(t, decoded, "metrics")
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files are left behind")
+}
@@ -0,0 +1,22 @@
+// Package sandbox runs shell scripts in a throwaway container, so commands
+// from a project nobody has vetted cannot reach the network, the user's
+// files, or their credentials.
+//
+// The project is mounted read-only at /workspace and the script starts in
+// the same directory below it as the user's shell. The container has no
+// network, no capabilities, a writable /tmp only, and runs as the user's
+// uid. Only a few harmless variables of the environment, such as TERM and
+// LANG, are passed through, plus the names listed in Options.Env:
+//
+//	cmd, err := sandbox.Command(sandbox.Options{
+//	    ProjectDir: "/src/acme",
+//	    WorkDir:    "/src/acme/services/api",
+//	}, "make lint")
+//	if err != nil {
+//	    return err
+//	}
+//	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+//	return cmd.Run()
+//
+// The script runs with `docker run`, so Docker must be available.
+package sandbox
@@ -0,0 +1,137 @@
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/pkg/validation"
+)
+
+const (
+	// DefaultImage is the image scripts run in unless Options.Image is set
+	DefaultImage = "alpine:3"
+
+	// MountPoint is where the project is mounted in the container
+	MountPoint = "/workspace"
+)
+
+// DefaultEnv are the variables always passed into the sandbox
+var DefaultEnv = []string{"TERM", "LANG", "LC_ALL", "TZ"}
+
+// Options configures a sandbox
+type Options struct {
+	// Image is the image the script runs in, DefaultImage if empty
+	Image string
+	// ProjectDir is mounted read-only at MountPoint
+	ProjectDir string
+	// WorkDir is the directory the script starts in; it must be within
+	// ProjectDir and defaults to it
+	WorkDir string
+	// Env names variables passed in besides DefaultEnv
+	Env []string
+}
+
+// Command returns the command running a script in the sandbox, with the
+// variables of the current environment it lets through
+func Command(opts Options, script string) (*exec.Cmd, error) {
+	args, err := Args(opts, script, os.Environ())
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("docker", args...)
+	// docker run -e NAME takes the value from docker's own environment
+	cmd.Env = os.Environ()
+	return cmd, nil
+}
+
+// Args returns the docker arguments running a script in the sandbox,
+// passing in the variables of environ that the options allow
+func Args(opts Options, script string, environ []string) ([]string, error) {
+	projectDir, workDir, err := directories(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := filepath.Rel(projectDir, workDir)
+	if err != nil {
+		return nil, err
+	}
+	containerDir := path.Join(MountPoint, filepath.ToSlash(rel))
+
+	image := opts.Image
+	if image == "" {
+		image = DefaultImage
+	}
+
+	args := []string{
+		"run", "--rm", "--interactive",
+		"--network", "none",
+		"--cap-drop", "ALL",
+		"--security-opt", "no-new-privileges",
+		"--read-only",
+		"--tmpfs", "/tmp",
+		"--pids-limit", "256",
+		"--volume", projectDir + ":" + MountPoint + ":ro",
+		"--workdir", containerDir,
+	}
+	if runtime.GOOS != "windows" {
+		args = append(args, "--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()))
+	}
+	for _, name := range allowedEnv(opts.Env, environ) {
+		args = append(args, "--env", name)
+	}
+	args = append(args, image, "sh", "-c", script)
+	return args, nil
+}
+
+// directories validates the project and working directories
+func directories(opts Options) (string, string, error) {
+	if opts.ProjectDir == "" {
+		return "", "", fmt.Errorf("sandbox needs a project directory")
+	}
+	projectDir, err := filepath.Abs(opts.ProjectDir)
+	if err != nil {
+		return "", "", err
+	}
+	if info, err := os.Stat(projectDir); err != nil || !info.IsDir() {
+		return "", "", fmt.Errorf("project directory %s does not exist", projectDir)
+	}
+
+	workDir := opts.WorkDir
+	if workDir == "" {
+		workDir = projectDir
+	}
+	// The script only sees the project, so it cannot start outside it
+	workDir, err = validation.ValidatePath(workDir, validation.PathValidationOptions{
+		BaseDir:       projectDir,
+		AllowAbsolute: true,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("sandboxed commands run within %s: %w", projectDir, err)
+	}
+	return projectDir, workDir, nil
+}
+
+// allowedEnv returns the names of the variables set in environ that
+// DefaultEnv or extra allow
+func allowedEnv(extra, environ []string) []string {
+	allowed := make(map[string]bool, len(DefaultEnv)+len(extra))
+	for _, name := range append(append([]string{}, DefaultEnv...), extra...) {
+		allowed[name] = true
+	}
+
+	var names []string
+	for _, entry := range environ {
+		name, _, _ := strings.Cut(entry, "=")
+		if allowed[name] {
+			names = append(names, name)
+			delete(allowed, name)
+		}
+	}
+	return names
+}
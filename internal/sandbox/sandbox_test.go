@@ -0,0 +1,56 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgs(t *testing.T) {
+	project := t.TempDir()
+	workDir := filepath.Join(project, "services", "api")
+	require.NoError(t, os.MkdirAll(workDir, 0755))
+
+	environ := []string{"TERM=xterm-256color", "AWS_SECRET_ACCESS_KEY=hunter2", "CI=true", "HOME=/home/me"}
+	args, err := Args(Options{ProjectDir: project, WorkDir: workDir, Env: []string{"CI"}}, "make lint", environ)
+	require.NoError(t, err)
+	line := strings.Join(args, " ")
+
+	assert.Equal(t, "run", args[0])
+	assert.Contains(t, line, "--network none")
+	assert.Contains(t, line, "--read-only")
+	assert.Contains(t, line, "--cap-drop ALL")
+	assert.Contains(t, line, "--volume "+project+":/workspace:ro")
+	assert.Contains(t, line, "--workdir /workspace/services/api")
+	assert.Contains(t, line, "--env TERM")
+	assert.Contains(t, line, "--env CI")
+	assert.NotContains(t, line, "AWS_SECRET_ACCESS_KEY")
+	assert.NotContains(t, line, "HOME")
+	if runtime.GOOS != "windows" {
+		assert.Contains(t, line, "--user ")
+	}
+	assert.Equal(t, []string{DefaultImage, "sh", "-c", "make lint"}, args[len(args)-4:])
+}
+
+func TestArgsDirectories(t *testing.T) {
+	project := t.TempDir()
+
+	args, err := Args(Options{ProjectDir: project, Image: "node:22-alpine"}, "true", nil)
+	require.NoError(t, err)
+	assert.Contains(t, strings.Join(args, " "), "--workdir /workspace ")
+	assert.Contains(t, args, "node:22-alpine")
+
+	_, err = Args(Options{ProjectDir: project, WorkDir: t.TempDir()}, "true", nil)
+	assert.Error(t, err, "the script cannot start outside the project")
+
+	_, err = Args(Options{ProjectDir: filepath.Join(project, "missing")}, "true", nil)
+	assert.Error(t, err)
+
+	_, err = Args(Options{}, "true", nil)
+	assert.Error(t, err)
+}
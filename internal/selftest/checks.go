@@ -0,0 +1,206 @@
+package selftest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/docker"
+	"github.com/glide-cli/glide/v3/internal/shell"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"github.com/glide-cli/glide/v3/pkg/version"
+	"github.com/hashicorp/go-hclog"
+	goplugin "github.com/hashicorp/go-plugin"
+)
+
+// PluginArg is the only argument of a glide binary started as the
+// self-test's plugin
+const PluginArg = "__selftest-plugin"
+
+// PluginName is the name the self-test's plugin reports
+const PluginName = "selftest"
+
+// ComposeImage is the image of the compose project the compose check
+// creates; its container is never started
+var ComposeImage = "alpine:3"
+
+// ServePlugin serves the self-test's plugin, which has a single echo
+// command, until glide stops it. It returns the exit code for main.
+func ServePlugin() int {
+	plugin := v1.NewBasePlugin(&v1.PluginMetadata{
+		Name:        PluginName,
+		Version:     version.Get(),
+		Description: "Plugin glide starts to test itself",
+	})
+	plugin.RegisterCommand("echo", v1.NewSimpleCommand(
+		&v1.CommandInfo{Name: "echo", Description: "Print the arguments"},
+		func(_ context.Context, req *v1.ExecuteRequest) (*v1.ExecuteResponse, error) {
+			return &v1.ExecuteResponse{Success: true, Stdout: []byte(strings.Join(req.Args, " ") + "\n")}, nil
+		},
+	))
+
+	if err := v1.RunPlugin(plugin); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// CheckPlugin starts exe as the self-test's plugin over the plugin
+// protocol, as runtime plugins are, and runs its echo command
+func CheckPlugin(ctx context.Context, exe string) (string, error) {
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig:  v1.HandshakeConfig,
+		Plugins:          v1.PluginMap,
+		Cmd:              exec.Command(exe, PluginArg),
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		Logger:           hclog.NewNullLogger(),
+	})
+	defer client.Kill()
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		return "", fmt.Errorf("failed to start %s as a plugin: %w", exe, err)
+	}
+	raw, err := rpcClient.Dispense("glide")
+	if err != nil {
+		return "", fmt.Errorf("failed to dispense the plugin: %w", err)
+	}
+	plugin, ok := raw.(v1.GlidePluginClient)
+	if !ok {
+		return "", fmt.Errorf("plugin does not implement GlidePlugin interface")
+	}
+
+	metadata, err := plugin.GetMetadata(ctx, &v1.Empty{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get plugin metadata: %w", err)
+	}
+
+	token := nonce()
+	resp, err := plugin.ExecuteCommand(ctx, &v1.ExecuteRequest{Command: "echo", Args: []string{token}})
+	if err != nil {
+		return "", fmt.Errorf("command execution failed: %w", err)
+	}
+	if !resp.Success {
+		return "", fmt.Errorf("command failed: %s", resp.Error)
+	}
+	if got := strings.TrimSpace(string(resp.Stdout)); got != token {
+		return "", fmt.Errorf("plugin echoed %q instead of %q", got, token)
+	}
+
+	return fmt.Sprintf("loaded %s v%s over gRPC and ran its echo command", metadata.Name, metadata.Version), nil
+}
+
+// CheckShell runs echo in the system shell and reads its output
+func CheckShell(ctx context.Context) (string, error) {
+	name, args := "sh", []string{"-c"}
+	if runtime.GOOS == "windows" {
+		name, args = "cmd", []string{"/C"}
+	}
+	token := nonce()
+
+	cmd := shell.NewCommand(name, append(args, "echo "+token)...)
+	cmd.Options = shell.CommandOptions{CaptureOutput: true}
+	result, err := shell.NewExecutor(shell.Options{}).ExecuteWithContext(ctx, cmd)
+	if err == nil && result.Error != nil {
+		err = result.Error
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %w", name, err)
+	}
+	if got := strings.TrimSpace(string(result.Stdout)); got != token {
+		return "", fmt.Errorf("%s printed %q instead of %q", name, got, token)
+	}
+
+	return fmt.Sprintf("ran echo in %s and read its output", name), nil
+}
+
+// CheckCompose creates a compose project in a temporary directory, checks
+// its container exists, and removes it again. It is skipped without a
+// running Docker daemon.
+func CheckCompose(ctx context.Context) (string, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return "", Skip("docker is not installed")
+	}
+	if err := exec.CommandContext(ctx, "docker", "info").Run(); err != nil {
+		return "", Skip("Docker is not running")
+	}
+
+	dir, err := os.MkdirTemp("", "glide-selftest-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	name := "glide-selftest-" + nonce()
+	composeFile := fmt.Sprintf("name: %s\nservices:\n  selftest:\n    image: %s\n    command: [\"true\"]\n", name, ComposeImage)
+	if err := os.WriteFile(filepath.Join(dir, "compose.yml"), []byte(composeFile), 0644); err != nil {
+		return "", fmt.Errorf("failed to write the compose file: %w", err)
+	}
+
+	project, err := docker.LoadComposeProject(dir)
+	if err != nil {
+		return "", err
+	}
+	if project.Name != name {
+		return "", fmt.Errorf("compose project is named %q instead of %q", project.Name, name)
+	}
+
+	compose := func(ctx context.Context, args ...string) error {
+		cmd := exec.CommandContext(ctx, "docker", project.ComposeArgs(args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("docker compose %s failed: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+
+	removed := false
+	defer func() {
+		// Clean up after a failure too, without the expired deadline
+		if !removed {
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			_ = compose(cleanupCtx, "down", "--volumes", "--remove-orphans")
+		}
+	}()
+
+	if err := compose(ctx, "up", "--no-start"); err != nil {
+		return "", err
+	}
+	owned := docker.ComposeProjectLabel + "=" + name
+	containers, err := docker.ListContainers(owned)
+	if err != nil {
+		return "", err
+	}
+	if len(containers) != 1 {
+		return "", fmt.Errorf("compose created %d containers instead of 1", len(containers))
+	}
+
+	if err := compose(ctx, "down", "--volumes", "--remove-orphans"); err != nil {
+		return "", err
+	}
+	removed = true
+	if containers, err = docker.ListContainers(owned); err != nil {
+		return "", err
+	}
+	if len(containers) > 0 {
+		return "", fmt.Errorf("%d containers were left after removing the project", len(containers))
+	}
+
+	return fmt.Sprintf("created and removed compose project %s", name), nil
+}
+
+// nonce returns a random token, so a check cannot pass on stale output
+func nonce() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,21 @@
+// Package selftest checks a glide installation end to end against the
+// machine it runs on, for `glide selftest` and support tickets.
+//
+// Each Check exercises one path a real command takes: loading a plugin
+// over the plugin protocol, running a shell command, and creating and
+// removing a compose project. A check that cannot run here, such as the
+// compose check without Docker, is skipped rather than failed:
+//
+//	report := selftest.Run(ctx, selftest.DefaultChecks(exe))
+//	if !report.Passed() {
+//	    ...
+//	}
+//
+// The plugin check starts the glide binary itself as a plugin: main serves
+// a trivial plugin when it is started with PluginArg, so no plugin has to
+// be installed:
+//
+//	if len(os.Args) == 2 && os.Args[1] == selftest.PluginArg {
+//	    os.Exit(selftest.ServePlugin())
+//	}
+package selftest
@@ -0,0 +1,155 @@
+package selftest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/version"
+)
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// DefaultTimeout bounds each check, so a hung Docker daemon or plugin
+// fails its check instead of the whole self-test
+var DefaultTimeout = 2 * time.Minute
+
+// Check is one end-to-end test of the installation
+type Check struct {
+	Name string
+	// Run performs the check and returns what it found. Returning an
+	// error made by Skip skips the check.
+	Run func(ctx context.Context) (detail string, err error)
+}
+
+// skipError is returned by checks that cannot run on this machine
+type skipError struct{ reason string }
+
+func (e *skipError) Error() string { return e.reason }
+
+// Skip returns the error a check returns when it cannot run here
+func Skip(format string, args ...interface{}) error {
+	return &skipError{reason: fmt.Sprintf(format, args...)}
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Name       string `json:"name" yaml:"name"`
+	Status     Status `json:"status" yaml:"status"`
+	Detail     string `json:"detail,omitempty" yaml:"detail,omitempty"`
+	Error      string `json:"error,omitempty" yaml:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms" yaml:"duration_ms"`
+}
+
+// Environment describes the installation and machine the checks ran on
+type Environment struct {
+	Version    string `json:"version" yaml:"version"`
+	GitCommit  string `json:"git_commit" yaml:"git_commit"`
+	GoVersion  string `json:"go_version" yaml:"go_version"`
+	OS         string `json:"os" yaml:"os"`
+	Arch       string `json:"arch" yaml:"arch"`
+	Executable string `json:"executable,omitempty" yaml:"executable,omitempty"`
+	Shell      string `json:"shell,omitempty" yaml:"shell,omitempty"`
+}
+
+// Report is the outcome of a self-test
+type Report struct {
+	Environment Environment `json:"environment" yaml:"environment"`
+	Started     time.Time   `json:"started" yaml:"started"`
+	Results     []Result    `json:"results" yaml:"results"`
+}
+
+// Passed reports whether no check failed
+func (r Report) Passed() bool {
+	for _, result := range r.Results {
+		if result.Status == StatusFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns how many checks ended with a status
+func (r Report) Count(status Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// CurrentEnvironment describes this installation
+func CurrentEnvironment() Environment {
+	info := version.GetBuildInfo()
+	exe, _ := os.Executable()
+	return Environment{
+		Version:    info.Version,
+		GitCommit:  info.GitCommit,
+		GoVersion:  runtime.Version(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Executable: exe,
+		Shell:      os.Getenv("SHELL"),
+	}
+}
+
+// Run runs the checks in order, each bounded by DefaultTimeout, and
+// reports their outcomes. A failing check does not stop the others.
+func Run(ctx context.Context, checks []Check) Report {
+	report := Report{Environment: CurrentEnvironment(), Started: time.Now(), Results: []Result{}}
+	for _, check := range checks {
+		report.Results = append(report.Results, runCheck(ctx, check))
+	}
+	return report
+}
+
+// runCheck runs one check, turning a panic into a failure
+func runCheck(ctx context.Context, check Check) (result Result) {
+	result = Result{Name: check.Name}
+	started := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			result.Status, result.Error = StatusFailed, fmt.Sprintf("panic: %v", r)
+		}
+		result.DurationMS = time.Since(started).Milliseconds()
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	detail, err := check.Run(ctx)
+	result.Detail = detail
+
+	var skip *skipError
+	switch {
+	case err == nil:
+		result.Status = StatusPassed
+	case errors.As(err, &skip):
+		result.Status, result.Detail = StatusSkipped, skip.reason
+	default:
+		result.Status, result.Error = StatusFailed, err.Error()
+	}
+	return result
+}
+
+// DefaultChecks are the checks of `glide selftest`; exe is the glide
+// binary, which the plugin check starts as a plugin
+func DefaultChecks(exe string) []Check {
+	return []Check{
+		{Name: "plugin", Run: func(ctx context.Context) (string, error) { return CheckPlugin(ctx, exe) }},
+		{Name: "shell", Run: CheckShell},
+		{Name: "compose", Run: CheckCompose},
+	}
+}
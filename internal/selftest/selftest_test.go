@@ -0,0 +1,73 @@
+package selftest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain serves the self-test's plugin when the plugin check starts the
+// test binary, as main does for glide
+func TestMain(m *testing.M) {
+	if len(os.Args) == 2 && os.Args[1] == PluginArg {
+		os.Exit(ServePlugin())
+	}
+	os.Exit(m.Run())
+}
+
+func TestRun(t *testing.T) {
+	report := Run(context.Background(), []Check{
+		{Name: "passes", Run: func(context.Context) (string, error) { return "fine", nil }},
+		{Name: "fails", Run: func(context.Context) (string, error) { return "", errors.New("broken") }},
+		{Name: "skipped", Run: func(context.Context) (string, error) { return "", Skip("no %s here", "docker") }},
+		{Name: "panics", Run: func(context.Context) (string, error) { panic("boom") }},
+	})
+
+	require.Len(t, report.Results, 4)
+	assert.Equal(t, Result{Name: "passes", Status: StatusPassed, Detail: "fine", DurationMS: report.Results[0].DurationMS}, report.Results[0])
+	assert.Equal(t, StatusFailed, report.Results[1].Status)
+	assert.Equal(t, "broken", report.Results[1].Error)
+	assert.Equal(t, StatusSkipped, report.Results[2].Status)
+	assert.Equal(t, "no docker here", report.Results[2].Detail)
+	assert.Equal(t, StatusFailed, report.Results[3].Status)
+	assert.Equal(t, "panic: boom", report.Results[3].Error)
+
+	assert.False(t, report.Passed())
+	assert.Equal(t, 2, report.Count(StatusFailed))
+	assert.Equal(t, runtime.GOOS, report.Environment.OS)
+}
+
+func TestRun_SkippedPasses(t *testing.T) {
+	report := Run(context.Background(), []Check{
+		{Name: "skipped", Run: func(context.Context) (string, error) { return "", Skip("not here") }},
+	})
+	assert.True(t, report.Passed())
+}
+
+func TestCheckPlugin(t *testing.T) {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	detail, err := CheckPlugin(context.Background(), exe)
+	require.NoError(t, err)
+	assert.Contains(t, detail, "loaded "+PluginName)
+}
+
+func TestCheckPlugin_NotAPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	_, err := CheckPlugin(context.Background(), "/bin/true")
+	assert.Error(t, err)
+}
+
+func TestCheckShell(t *testing.T) {
+	detail, err := CheckShell(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, detail, "ran echo")
+}
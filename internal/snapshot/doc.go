@@ -0,0 +1,31 @@
+// Package snapshot captures and restores the state of a worktree.
+//
+// A snapshot bundles a worktree's environment files, database dumps, and
+// archives of its compose project's named Docker volumes into a directory
+// with a JSON manifest. Restoring a snapshot puts all of them back, which
+// makes switching between branches with divergent schemas painless.
+//
+// # Layout
+//
+//	<store>/<name>/
+//	    manifest.json
+//	    env/.env
+//	    databases/<service>.dump
+//	    volumes/<volume>.tar.gz
+//
+// # Usage
+//
+//	manager := snapshot.NewManager(storeDir, worktreeDir, "feature-x", cfg.Snapshot)
+//
+//	manifest, err := manager.Create("before-migration", snapshot.Options{})
+//	if err != nil {
+//	    return err
+//	}
+//
+//	_, err = manager.Restore("before-migration", snapshot.Options{SkipVolumes: true})
+//
+// Database dumps run the configured dump command inside the service with
+// `docker compose exec -T` and capture its stdout; restores stream the dump
+// to the restore command's stdin. Volumes are archived with a throwaway
+// container, and the project is stopped while volumes are restored.
+package snapshot
@@ -0,0 +1,495 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+const (
+	manifestFile = "manifest.json"
+	envDir       = "env"
+	volumesDir   = "volumes"
+	databasesDir = "databases"
+
+	// archiveImage is the image used to archive and restore volume contents
+	archiveImage = "alpine:3"
+)
+
+// DefaultEnvFiles are captured when no env_files are configured
+var DefaultEnvFiles = []string{".env*"}
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Manifest describes the contents of a snapshot
+type Manifest struct {
+	Name      string    `json:"name" yaml:"name"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	Worktree  string    `json:"worktree" yaml:"worktree"`
+	EnvFiles  []string  `json:"env_files,omitempty" yaml:"env_files,omitempty"`
+	Volumes   []string  `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+	Databases []string  `json:"databases,omitempty" yaml:"databases,omitempty"`
+}
+
+// Options selects what a snapshot captures or restores
+type Options struct {
+	SkipEnv       bool
+	SkipVolumes   bool
+	SkipDatabases bool
+}
+
+// Manager creates and restores snapshots of a single worktree
+type Manager struct {
+	storeDir    string
+	worktreeDir string
+	worktree    string
+	cfg         config.SnapshotConfig
+
+	// run executes external commands and loadProject resolves the compose
+	// project; both are replaced in tests
+	run         func(cmd *exec.Cmd) error
+	loadProject func(dir string) (*docker.ComposeProject, error)
+}
+
+// NewManager creates a manager that stores snapshots of worktreeDir under
+// storeDir. worktree is the display name recorded in manifests.
+func NewManager(storeDir, worktreeDir, worktree string, cfg config.SnapshotConfig) *Manager {
+	return &Manager{
+		storeDir:    storeDir,
+		worktreeDir: worktreeDir,
+		worktree:    worktree,
+		cfg:         cfg,
+		run:         func(cmd *exec.Cmd) error { return cmd.Run() },
+		loadProject: func(dir string) (*docker.ComposeProject, error) {
+			return docker.LoadComposeProject(dir)
+		},
+	}
+}
+
+// StoreDir returns the directory snapshots are stored in
+func (m *Manager) StoreDir() string {
+	return m.storeDir
+}
+
+// Create captures a new snapshot
+func (m *Manager) Create(name string, opts Options) (*Manifest, error) {
+	if name == "" {
+		name = time.Now().Format("20060102-150405")
+	}
+	if !validName.MatchString(name) {
+		return nil, glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("invalid snapshot name %q", name),
+			glideErrors.WithSuggestions("Use letters, digits, dots, dashes, and underscores"),
+		)
+	}
+
+	dir := m.path(name)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("snapshot %q already exists", name),
+			glideErrors.WithSuggestions(
+				"Choose a different name",
+				fmt.Sprintf("Delete the old snapshot first: glide snapshot delete %s", name),
+			),
+		)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, glideErrors.NewPermissionError(dir, "failed to create snapshot directory")
+	}
+	m.ignoreStore()
+
+	manifest := &Manifest{
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+		Worktree:  m.worktree,
+	}
+
+	err := m.capture(dir, manifest, opts)
+	if err == nil {
+		err = writeManifest(dir, manifest)
+	}
+	if err != nil {
+		// Safe to ignore: never leave a half-written snapshot behind
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// capture writes each part of the snapshot into dir
+func (m *Manager) capture(dir string, manifest *Manifest, opts Options) error {
+	if !opts.SkipEnv {
+		files, err := m.captureEnvFiles(dir)
+		if err != nil {
+			return err
+		}
+		manifest.EnvFiles = files
+	}
+
+	if opts.SkipVolumes && (opts.SkipDatabases || len(m.cfg.Databases) == 0) {
+		return nil
+	}
+
+	project, err := m.loadProject(m.worktreeDir)
+	if err != nil {
+		return err
+	}
+
+	if !opts.SkipDatabases {
+		for _, db := range m.cfg.Databases {
+			if err := m.dumpDatabase(project, dir, db); err != nil {
+				return err
+			}
+			manifest.Databases = append(manifest.Databases, db.Service)
+		}
+	}
+
+	if !opts.SkipVolumes {
+		for _, volume := range project.VolumeNames() {
+			if err := m.archiveVolume(dir, volume); err != nil {
+				return err
+			}
+			manifest.Volumes = append(manifest.Volumes, volume)
+		}
+	}
+
+	return nil
+}
+
+// captureEnvFiles copies the configured environment files
+func (m *Manager) captureEnvFiles(dir string) ([]string, error) {
+	patterns := m.cfg.EnvFiles
+	if len(patterns) == 0 {
+		patterns = DefaultEnvFiles
+	}
+
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(m.worktreeDir, pattern))
+		if err != nil {
+			return nil, glideErrors.NewConfigError(fmt.Sprintf("invalid env_files pattern %q", pattern))
+		}
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil || info.IsDir() {
+				continue
+			}
+			rel, _ := filepath.Rel(m.worktreeDir, match)
+			if err := copyFile(match, filepath.Join(dir, envDir, rel)); err != nil {
+				return nil, err
+			}
+			files = append(files, rel)
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// dumpDatabase streams a database dump from its service into the snapshot
+func (m *Manager) dumpDatabase(project *docker.ComposeProject, dir string, db config.SnapshotDatabase) error {
+	if _, ok := project.Services[db.Service]; !ok {
+		return glideErrors.NewConfigError(fmt.Sprintf("snapshot database service %q is not defined in the compose project", db.Service),
+			glideErrors.WithSuggestions(fmt.Sprintf("Available services: %s", strings.Join(project.ServiceNames(), ", "))),
+		)
+	}
+
+	target := filepath.Join(dir, databasesDir, db.Service+".dump")
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cmd := exec.Command("docker", project.ComposeArgs("exec", "-T", db.Service, "sh", "-c", db.Dump)...)
+	cmd.Dir = m.worktreeDir
+	cmd.Stdout = f
+	cmd.Stderr = os.Stderr
+	if err := m.run(cmd); err != nil {
+		return glideErrors.NewDatabaseError(fmt.Sprintf("failed to dump database in service %s", db.Service),
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Make sure the service is running: glide up %s", db.Service),
+				"Check the snapshot.databases dump command in .glide.yml",
+			),
+		)
+	}
+	return nil
+}
+
+// archiveVolume tars the contents of a Docker volume into the snapshot
+func (m *Manager) archiveVolume(dir, volume string) error {
+	if err := os.MkdirAll(filepath.Join(dir, volumesDir), 0700); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("docker", "run", "--rm",
+		"-v", volume+":/volume:ro",
+		"-v", filepath.Join(dir, volumesDir)+":/backup",
+		archiveImage, "tar", "czf", "/backup/"+volume+".tar.gz", "-C", "/volume", ".")
+	cmd.Stderr = os.Stderr
+	if err := m.run(cmd); err != nil {
+		return glideErrors.NewDockerError(fmt.Sprintf("failed to archive volume %s", volume), glideErrors.WithError(err))
+	}
+	return nil
+}
+
+// Restore replaces the worktree's environment files, databases, and volumes
+// with the contents of a snapshot
+func (m *Manager) Restore(name string, opts Options) (*Manifest, error) {
+	manifest, err := m.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	dir := m.path(name)
+
+	if !opts.SkipEnv {
+		for _, rel := range manifest.EnvFiles {
+			if err := copyFile(filepath.Join(dir, envDir, rel), filepath.Join(m.worktreeDir, rel)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	needVolumes := !opts.SkipVolumes && len(manifest.Volumes) > 0
+	needDatabases := !opts.SkipDatabases && len(manifest.Databases) > 0
+	if !needVolumes && !needDatabases {
+		return manifest, nil
+	}
+
+	project, err := m.loadProject(m.worktreeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if needVolumes {
+		if err := m.restoreVolumes(project, dir, manifest.Volumes); err != nil {
+			return nil, err
+		}
+	}
+
+	if needDatabases {
+		for _, db := range m.cfg.Databases {
+			if !contains(manifest.Databases, db.Service) {
+				continue
+			}
+			if err := m.restoreDatabase(project, dir, db); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return manifest, nil
+}
+
+// ignoreStore writes a .gitignore into the store, which may be inside the
+// repository in single-repo mode, so snapshots of .env files and databases
+// are never committed
+func (m *Manager) ignoreStore() {
+	path := filepath.Join(m.storeDir, ".gitignore")
+	if _, err := os.Stat(path); err == nil {
+		return
+	}
+	// Safe to ignore: snapshots still work, the directory is just not ignored
+	_ = os.WriteFile(path, []byte("# Snapshots may hold secrets; never commit them\n*\n"), 0600)
+}
+
+// restoreVolumes stops the project, replaces volume contents, and starts it
+// again so no container writes to a volume while it is being restored. The
+// project is started again when a volume fails to restore, too.
+func (m *Manager) restoreVolumes(project *docker.ComposeProject, dir string, volumes []string) error {
+	stop := exec.Command("docker", project.ComposeArgs("stop")...)
+	stop.Dir = m.worktreeDir
+	if err := m.run(stop); err != nil {
+		return glideErrors.NewDockerError("failed to stop the project before restoring volumes", glideErrors.WithError(err))
+	}
+
+	if err := m.restoreVolumeContents(dir, volumes); err != nil {
+		start := exec.Command("docker", project.ComposeArgs("start")...)
+		start.Dir = m.worktreeDir
+		if startErr := m.run(start); startErr != nil {
+			return glideErrors.NewDockerError(err.Error()+", and the project could not be restarted",
+				glideErrors.WithError(err),
+				glideErrors.WithSuggestions("Start it again: glide up"),
+			)
+		}
+		return err
+	}
+
+	start := exec.Command("docker", project.ComposeArgs("start")...)
+	start.Dir = m.worktreeDir
+	if err := m.run(start); err != nil {
+		return glideErrors.NewDockerError("failed to restart the project after restoring volumes", glideErrors.WithError(err))
+	}
+	return nil
+}
+
+// restoreVolumeContents replaces the contents of volumes with their
+// archives in the snapshot at dir
+func (m *Manager) restoreVolumeContents(dir string, volumes []string) error {
+	for _, volume := range volumes {
+		cmd := exec.Command("docker", "run", "--rm",
+			"-v", volume+":/volume",
+			"-v", filepath.Join(dir, volumesDir)+":/backup:ro",
+			archiveImage, "sh", "-c",
+			fmt.Sprintf("find /volume -mindepth 1 -delete && tar xzf /backup/%s.tar.gz -C /volume", volume))
+		cmd.Stderr = os.Stderr
+		if err := m.run(cmd); err != nil {
+			return glideErrors.NewDockerError(fmt.Sprintf("failed to restore volume %s", volume), glideErrors.WithError(err))
+		}
+	}
+	return nil
+}
+
+// restoreDatabase streams a dump back into its service
+func (m *Manager) restoreDatabase(project *docker.ComposeProject, dir string, db config.SnapshotDatabase) error {
+	f, err := os.Open(filepath.Join(dir, databasesDir, db.Service+".dump"))
+	if err != nil {
+		return glideErrors.NewFileNotFoundError(filepath.Join(dir, databasesDir, db.Service+".dump"))
+	}
+	defer f.Close()
+
+	cmd := exec.Command("docker", project.ComposeArgs("exec", "-T", db.Service, "sh", "-c", db.Restore)...)
+	cmd.Dir = m.worktreeDir
+	cmd.Stdin = f
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := m.run(cmd); err != nil {
+		return glideErrors.NewDatabaseError(fmt.Sprintf("failed to restore database in service %s", db.Service),
+			glideErrors.WithError(err),
+			glideErrors.WithSuggestions(
+				fmt.Sprintf("Make sure the service is running: glide up %s", db.Service),
+				"Check the snapshot.databases restore command in .glide.yml",
+			),
+		)
+	}
+	return nil
+}
+
+// List returns all snapshots, newest first
+func (m *Manager) List() ([]*Manifest, error) {
+	entries, err := os.ReadDir(m.storeDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var manifests []*Manifest
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		manifest, err := readManifest(filepath.Join(m.storeDir, entry.Name()))
+		if err != nil {
+			continue // Skip incomplete snapshots
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].CreatedAt.After(manifests[j].CreatedAt)
+	})
+	return manifests, nil
+}
+
+// Get returns the manifest of a named snapshot
+func (m *Manager) Get(name string) (*Manifest, error) {
+	if !validName.MatchString(name) {
+		return nil, glideErrors.New(glideErrors.TypeInvalid, fmt.Sprintf("invalid snapshot name %q", name))
+	}
+
+	manifest, err := readManifest(m.path(name))
+	if err != nil {
+		return nil, glideErrors.New(glideErrors.TypeMissing, fmt.Sprintf("snapshot %q not found", name),
+			glideErrors.WithSuggestions("List snapshots with: glide snapshot list"),
+		)
+	}
+	return manifest, nil
+}
+
+// Delete removes a snapshot
+func (m *Manager) Delete(name string) error {
+	if _, err := m.Get(name); err != nil {
+		return err
+	}
+	return os.RemoveAll(m.path(name))
+}
+
+// path returns the directory of a named snapshot
+func (m *Manager) path(name string) string {
+	return filepath.Join(m.storeDir, name)
+}
+
+// writeManifest saves a manifest into a snapshot directory
+func writeManifest(dir string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFile), data, 0600)
+}
+
+// readManifest loads a manifest from a snapshot directory
+func readManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// copyFile copies src to dst, creating parent directories and preserving
+// the source file mode
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// contains reports whether values includes value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,262 @@
+package snapshot
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/config"
+	"github.com/glide-cli/glide/v3/internal/docker"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDocker records commands instead of running them. Database dumps
+// write "dump of <service>" to stdout; restores capture their stdin.
+type fakeDocker struct {
+	commands [][]string
+	restored map[string]string
+	fail     string
+}
+
+func (f *fakeDocker) run(cmd *exec.Cmd) error {
+	args := cmd.Args[1:]
+	f.commands = append(f.commands, args)
+
+	joined := strings.Join(args, " ")
+	if f.fail != "" && strings.Contains(joined, f.fail) {
+		return assert.AnError
+	}
+
+	if len(args) > 5 && args[3] == "exec" {
+		service := args[5]
+		if strings.Contains(joined, "dump") {
+			_, _ = io.WriteString(cmd.Stdout, "dump of "+service)
+		} else {
+			data, _ := io.ReadAll(cmd.Stdin)
+			f.restored[service] = string(data)
+		}
+	}
+	return nil
+}
+
+func newTestManager(t *testing.T, cfg config.SnapshotConfig) (*Manager, *fakeDocker, string) {
+	t.Helper()
+
+	worktreeDir := t.TempDir()
+	storeDir := filepath.Join(t.TempDir(), "snapshots")
+
+	fake := &fakeDocker{restored: map[string]string{}}
+	m := NewManager(storeDir, worktreeDir, "feature-x", cfg)
+	m.run = fake.run
+	m.loadProject = func(dir string) (*docker.ComposeProject, error) {
+		return &docker.ComposeProject{
+			Name:     "feature-x",
+			Services: map[string]docker.ComposeService{"mysql": {}, "php": {}},
+			Volumes: map[string]docker.ComposeVolume{
+				"db-data": {Name: "feature-x_db-data"},
+				"shared":  {Name: "shared", External: true},
+			},
+			Dir: dir,
+		}, nil
+	}
+	return m, fake, worktreeDir
+}
+
+var testDatabases = config.SnapshotConfig{
+	Databases: []config.SnapshotDatabase{
+		{Service: "mysql", Dump: "mysqldump --all-databases", Restore: "mysql"},
+	},
+}
+
+func TestCreate(t *testing.T) {
+	m, fake, worktreeDir := newTestManager(t, testDatabases)
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeDir, ".env"), []byte("APP_ENV=local"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeDir, ".env.testing"), []byte("APP_ENV=testing"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeDir, "README.md"), []byte("readme"), 0600))
+
+	manifest, err := m.Create("before-migration", Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "before-migration", manifest.Name)
+	assert.Equal(t, "feature-x", manifest.Worktree)
+	assert.Equal(t, []string{".env", ".env.testing"}, manifest.EnvFiles)
+	assert.Equal(t, []string{"mysql"}, manifest.Databases)
+	assert.Equal(t, []string{"feature-x_db-data"}, manifest.Volumes, "external volumes are not captured")
+
+	dir := filepath.Join(m.StoreDir(), "before-migration")
+	data, err := os.ReadFile(filepath.Join(dir, "env", ".env"))
+	require.NoError(t, err)
+	assert.Equal(t, "APP_ENV=local", string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "databases", "mysql.dump"))
+	require.NoError(t, err)
+	assert.Equal(t, "dump of mysql", string(data))
+
+	require.Len(t, fake.commands, 2)
+	assert.Equal(t, []string{"compose", "--project-name", "feature-x", "exec", "-T", "mysql", "sh", "-c", "mysqldump --all-databases"}, fake.commands[0])
+	assert.Equal(t, []string{
+		"run", "--rm",
+		"-v", "feature-x_db-data:/volume:ro",
+		"-v", filepath.Join(dir, "volumes") + ":/backup",
+		"alpine:3", "tar", "czf", "/backup/feature-x_db-data.tar.gz", "-C", "/volume", ".",
+	}, fake.commands[1])
+
+	got, err := m.Get("before-migration")
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Name, got.Name)
+
+	ignore, err := os.ReadFile(filepath.Join(m.StoreDir(), ".gitignore"))
+	require.NoError(t, err)
+	assert.Contains(t, string(ignore), "*", "snapshots are never committed")
+}
+
+func TestCreate_DefaultName(t *testing.T) {
+	m, _, _ := newTestManager(t, config.SnapshotConfig{})
+
+	manifest, err := m.Create("", Options{SkipVolumes: true})
+	require.NoError(t, err)
+	assert.Regexp(t, `^\d{8}-\d{6}$`, manifest.Name)
+}
+
+func TestCreate_ConfiguredEnvFiles(t *testing.T) {
+	m, _, worktreeDir := newTestManager(t, config.SnapshotConfig{EnvFiles: []string{"config/*.env"}})
+	require.NoError(t, os.MkdirAll(filepath.Join(worktreeDir, "config"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeDir, "config", "app.env"), []byte("A=1"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeDir, ".env"), []byte("B=2"), 0600))
+
+	manifest, err := m.Create("configured", Options{SkipVolumes: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("config", "app.env")}, manifest.EnvFiles)
+}
+
+func TestCreate_Errors(t *testing.T) {
+	t.Run("invalid name", func(t *testing.T) {
+		m, _, _ := newTestManager(t, config.SnapshotConfig{})
+		_, err := m.Create("../escape", Options{})
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid))
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		m, _, _ := newTestManager(t, config.SnapshotConfig{})
+		_, err := m.Create("dup", Options{SkipVolumes: true})
+		require.NoError(t, err)
+		_, err = m.Create("dup", Options{SkipVolumes: true})
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid))
+	})
+
+	t.Run("unknown database service", func(t *testing.T) {
+		m, _, _ := newTestManager(t, config.SnapshotConfig{
+			Databases: []config.SnapshotDatabase{{Service: "postgres", Dump: "pg_dumpall"}},
+		})
+		_, err := m.Create("broken", Options{SkipVolumes: true})
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeConfig))
+	})
+
+	t.Run("failed capture leaves nothing behind", func(t *testing.T) {
+		m, fake, _ := newTestManager(t, testDatabases)
+		fake.fail = "tar czf"
+		_, err := m.Create("partial", Options{})
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeDocker))
+		assert.NoDirExists(t, filepath.Join(m.StoreDir(), "partial"))
+	})
+}
+
+func TestRestore(t *testing.T) {
+	m, fake, worktreeDir := newTestManager(t, testDatabases)
+	envPath := filepath.Join(worktreeDir, ".env")
+	require.NoError(t, os.WriteFile(envPath, []byte("APP_ENV=snapshot"), 0600))
+
+	_, err := m.Create("snap", Options{})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(envPath, []byte("APP_ENV=changed"), 0600))
+	fake.commands = nil
+
+	_, err = m.Restore("snap", Options{})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(envPath)
+	require.NoError(t, err)
+	assert.Equal(t, "APP_ENV=snapshot", string(data))
+	assert.Equal(t, "dump of mysql", fake.restored["mysql"])
+
+	require.Len(t, fake.commands, 4)
+	assert.Equal(t, []string{"compose", "--project-name", "feature-x", "stop"}, fake.commands[0])
+	assert.Contains(t, strings.Join(fake.commands[1], " "), "tar xzf /backup/feature-x_db-data.tar.gz -C /volume")
+	assert.Equal(t, []string{"compose", "--project-name", "feature-x", "start"}, fake.commands[2])
+	assert.Equal(t, []string{"compose", "--project-name", "feature-x", "exec", "-T", "mysql", "sh", "-c", "mysql"}, fake.commands[3])
+}
+
+func TestRestore_VolumeFailureRestartsProject(t *testing.T) {
+	m, fake, _ := newTestManager(t, testDatabases)
+	_, err := m.Create("snap", Options{})
+	require.NoError(t, err)
+
+	fake.commands = nil
+	fake.fail = "tar xzf"
+	_, err = m.Restore("snap", Options{SkipDatabases: true})
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeDocker))
+
+	require.Len(t, fake.commands, 3)
+	assert.Equal(t, []string{"compose", "--project-name", "feature-x", "start"}, fake.commands[2])
+}
+
+func TestRestore_SkipOptions(t *testing.T) {
+	m, fake, worktreeDir := newTestManager(t, testDatabases)
+	envPath := filepath.Join(worktreeDir, ".env")
+	require.NoError(t, os.WriteFile(envPath, []byte("APP_ENV=snapshot"), 0600))
+
+	_, err := m.Create("snap", Options{})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(envPath, []byte("APP_ENV=changed"), 0600))
+	fake.commands = nil
+
+	_, err = m.Restore("snap", Options{SkipEnv: true, SkipVolumes: true, SkipDatabases: true})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(envPath)
+	require.NoError(t, err)
+	assert.Equal(t, "APP_ENV=changed", string(data))
+	assert.Empty(t, fake.commands)
+}
+
+func TestRestore_NotFound(t *testing.T) {
+	m, _, _ := newTestManager(t, config.SnapshotConfig{})
+	_, err := m.Restore("missing", Options{})
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeMissing))
+}
+
+func TestListAndDelete(t *testing.T) {
+	m, _, _ := newTestManager(t, config.SnapshotConfig{})
+
+	manifests, err := m.List()
+	require.NoError(t, err)
+	assert.Empty(t, manifests)
+
+	_, err = m.Create("first", Options{SkipVolumes: true})
+	require.NoError(t, err)
+	_, err = m.Create("second", Options{SkipVolumes: true})
+	require.NoError(t, err)
+
+	// Directories without a manifest are ignored
+	require.NoError(t, os.MkdirAll(filepath.Join(m.StoreDir(), "incomplete"), 0700))
+
+	manifests, err = m.List()
+	require.NoError(t, err)
+	require.Len(t, manifests, 2)
+
+	require.NoError(t, m.Delete("first"))
+	manifests, err = m.List()
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	assert.Equal(t, "second", manifests[0].Name)
+
+	err = m.Delete("first")
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeMissing))
+}
@@ -0,0 +1,210 @@
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestName is the name of the manifest in the archive
+const ManifestName = "manifest.json"
+
+// Manifest describes the contents of a bundle
+type Manifest struct {
+	Version string    `json:"version"`
+	Created time.Time `json:"created"`
+	// Files are the names of the files in the archive, besides the manifest
+	Files []string `json:"files"`
+	// Errors are the sections that could not be collected, and why
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// Bundle collects the files of a support archive
+type Bundle struct {
+	home     string
+	manifest Manifest
+	files    map[string][]byte
+}
+
+// New returns an empty bundle made by a glide version. Paths under home
+// are written as ~ in configuration and logs.
+func New(version, home string) *Bundle {
+	return &Bundle{
+		home:     home,
+		manifest: Manifest{Version: version, Created: time.Now().UTC(), Errors: map[string]string{}},
+		files:    map[string][]byte{},
+	}
+}
+
+// Add adds a file as is
+func (b *Bundle) Add(name string, data []byte) {
+	b.files[name] = data
+	delete(b.manifest.Errors, name)
+}
+
+// AddJSON adds a value as indented JSON, redacted
+func (b *Bundle) AddJSON(name string, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.Fail(name, err)
+		return
+	}
+	b.Add(name, []byte(RedactText(string(data), b.home)+"\n"))
+}
+
+// AddConfig adds a YAML configuration file with its secrets redacted. A
+// missing file is left out.
+func (b *Bundle) AddConfig(name, path string) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		b.Fail(name, err)
+		return
+	}
+
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		// Keep what can be read of a broken file, since it may be the bug
+		b.Add(name, []byte(RedactText(string(data), b.home)))
+		return
+	}
+	redacted, err := yaml.Marshal(RedactConfig(raw, b.home))
+	if err != nil {
+		b.Fail(name, err)
+		return
+	}
+	b.Add(name, redacted)
+}
+
+// AddLog adds the last lines of a log file, redacted. A missing file is
+// left out; lines of 0 or less adds the whole file.
+func (b *Bundle) AddLog(name, path string, lines int) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		b.Fail(name, err)
+		return
+	}
+	b.Add(name, []byte(RedactText(tail(string(data), lines), b.home)))
+}
+
+// Fail records a section that could not be collected
+func (b *Bundle) Fail(name string, err error) {
+	b.manifest.Errors[name] = RedactText(err.Error(), b.home)
+}
+
+// Manifest returns the manifest of the files added so far
+func (b *Bundle) Manifest() Manifest {
+	manifest := b.manifest
+	manifest.Files = make([]string, 0, len(b.files))
+	for name := range b.files {
+		manifest.Files = append(manifest.Files, name)
+	}
+	sort.Strings(manifest.Files)
+	return manifest
+}
+
+// WriteTo writes the bundle as a gzipped tarball, manifest first
+func (b *Bundle) WriteTo(w io.Writer) (int64, error) {
+	counter := &countingWriter{w: w}
+	gz := gzip.NewWriter(counter)
+	tw := tar.NewWriter(gz)
+
+	manifest := b.Manifest()
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return counter.n, err
+	}
+	if err := writeTarFile(tw, ManifestName, append(data, '\n'), manifest.Created); err != nil {
+		return counter.n, err
+	}
+	for _, name := range manifest.Files {
+		if err := writeTarFile(tw, name, b.files[name], manifest.Created); err != nil {
+			return counter.n, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return counter.n, err
+	}
+	err = gz.Close()
+	return counter.n, err
+}
+
+// Write saves the bundle at path, readable only by the user, since even
+// redacted logs can reveal more than intended
+func (b *Bundle) Write(path string) error {
+	var buf bytes.Buffer
+	if _, err := b.WriteTo(&buf); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".glide-support-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime, Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// tail returns the last lines of text
+func tail(text string, lines int) string {
+	if lines <= 0 {
+		return text
+	}
+	trimmed := strings.TrimSuffix(text, "\n")
+	all := strings.Split(trimmed, "\n")
+	if len(all) <= lines {
+		return text
+	}
+	return strings.Join(all[len(all)-lines:], "\n") + "\n"
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,21 @@
+// Package supportbundle gathers what is needed to diagnose a problem into
+// a single archive to attach to bug reports.
+//
+// A Bundle collects files in memory: recent logs, the configuration with
+// secrets redacted, and JSON documents such as the project context. A
+// section that could not be collected is listed in the manifest with its
+// error instead of failing the whole bundle:
+//
+//	b := supportbundle.New(version.Get(), home)
+//	b.AddConfig("config/global.yml", configPath)
+//	b.AddJSON("context.json", ctx)
+//	b.AddLog("logs/audit.log", audit.DefaultPath(), 500)
+//	if err := b.Write("glide-support.tar.gz"); err != nil {
+//	    ...
+//	}
+//
+// The archive is a gzipped tarball with manifest.json at its root. Values
+// of keys that look secret (token, password, api_key, ...) are replaced
+// with [REDACTED], in configuration and in KEY=value text alike, and the
+// home directory is written as ~.
+package supportbundle
@@ -0,0 +1,121 @@
+package supportbundle
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secret values
+const Redacted = "[REDACTED]"
+
+// secretWords are the parts of key names whose values are secret
+var secretWords = []string{"token", "secret", "password", "passwd", "apikey", "api_key", "api-key", "credential", "private_key", "authorization", "bearer", "webhook"}
+
+// urlSecretSections are the parts of section names under which URLs are
+// secret, such as a Slack webhook URL in notifications
+var urlSecretSections = []string{"notification", "webhook"}
+
+// IsSecretKey reports whether values of a configuration key or
+// environment variable are secret
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range secretWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizationLine matches an Authorization header or bearer token up to
+// the end of the line, since its value may hold spaces ("Bearer abc")
+var authorizationLine = regexp.MustCompile(`(?i)\b(authorization|bearer)("?\s*[=:]?\s*)[^\r\n]+`)
+
+// urlUserinfo matches the user:password@ part of a URL
+var urlUserinfo = regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.-]*://)[^/\s]+@`)
+
+// secretAssignment matches KEY=value, key: value, and "key": "value"
+// pairs in text, such as environment variables in logs and commands
+var secretAssignment = regexp.MustCompile(`([A-Za-z0-9_.-]+)("?\s*[=:]\s*)("[^"]*"|'[^']*'|[^\s"',;&|}]+)`)
+
+// RedactText replaces the values assigned to secret keys, everything after
+// an Authorization header or bearer token, and the credentials in URLs, and
+// writes the home directory as ~
+func RedactText(text, home string) string {
+	text = authorizationLine.ReplaceAllString(text, "${1}${2}"+Redacted)
+	text = urlUserinfo.ReplaceAllString(text, "${1}")
+	text = secretAssignment.ReplaceAllStringFunc(text, func(match string) string {
+		parts := secretAssignment.FindStringSubmatch(match)
+		if !IsSecretKey(parts[1]) {
+			return match
+		}
+		if quote := parts[3][0]; quote == '"' || quote == '\'' {
+			return parts[1] + parts[2] + string(quote) + Redacted + string(quote)
+		}
+		return parts[1] + parts[2] + Redacted
+	})
+	if home != "" && home != "/" {
+		text = strings.ReplaceAll(text, home, "~")
+	}
+	return text
+}
+
+// RedactConfig returns a copy of decoded YAML configuration with the
+// values of secret keys and of URLs under notifications replaced, and
+// RedactText applied to every string
+func RedactConfig(value any, home string) any {
+	return redactConfig(value, home, false)
+}
+
+// redactConfig redacts value; urlsSecret is set within sections whose URLs
+// are secret
+func redactConfig(value any, home string, urlsSecret bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, item := range v {
+			redacted[key] = redactEntry(key, item, home, urlsSecret)
+		}
+		return redacted
+	case map[any]any:
+		redacted := make(map[any]any, len(v))
+		for key, item := range v {
+			name, _ := key.(string)
+			redacted[key] = redactEntry(name, item, home, urlsSecret)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redactConfig(item, home, urlsSecret)
+		}
+		return redacted
+	case string:
+		return RedactText(v, home)
+	}
+	return value
+}
+
+// redactEntry redacts the value of a key, hiding it whole when the key is
+// secret and it is not a nested section
+func redactEntry(key string, value any, home string, urlsSecret bool) any {
+	if IsSecretKey(key) || urlsSecret && strings.Contains(strings.ToLower(key), "url") {
+		switch value.(type) {
+		case map[string]any, map[any]any, []any, nil:
+		default:
+			return Redacted
+		}
+	}
+	return redactConfig(value, home, urlsSecret || isURLSecretSection(key))
+}
+
+// isURLSecretSection reports whether the URLs in a section are secret
+func isURLSecretSection(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range urlSecretSections {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
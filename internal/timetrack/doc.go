@@ -0,0 +1,18 @@
+// Package timetrack records when the containers of each worktree are up and
+// summarizes the recorded development time.
+//
+// Every successful `up` appends a start event and every `down` a stop event
+// to a log in the user's glide directory:
+//
+//	~/.glide/time.jsonl
+//	{"time":"2026-10-12T09:02:11Z","kind":"start","project":"/src/acme","worktree":"feature-api","branch":"feature/api"}
+//	{"time":"2026-10-12T12:31:40Z","kind":"stop","project":"/src/acme","worktree":"feature-api"}
+//
+// Sessions pairs the events of each worktree. A session without a stop event
+// is still open: its containers are either running, or were stopped without
+// glide, in which case the caller closes it when the containers finished.
+//
+//	events, err := timetrack.Load(timetrack.DefaultLogPath())
+//	sessions := timetrack.Sessions(events)
+//	rows := timetrack.Summarize(sessions, from, to, timetrack.ByBranch)
+package timetrack
@@ -0,0 +1,81 @@
+package timetrack
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// Kinds of events
+const (
+	KindStart = "start"
+	KindStop  = "stop"
+)
+
+// Event is a worktree's containers starting or stopping
+type Event struct {
+	Time time.Time `json:"time"`
+	Kind string    `json:"kind"`
+	// Project is the project root directory
+	Project  string `json:"project"`
+	Worktree string `json:"worktree"`
+	// Branch is the branch checked out when the containers started
+	Branch string `json:"branch,omitempty"`
+}
+
+// DefaultLogPath returns the event log in the user's glide directory
+func DefaultLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "time.jsonl")
+}
+
+// Append adds an event to the log. Each event is a single small write, so
+// concurrent glide processes do not interleave lines.
+func Append(path string, event Event) error {
+	event.Time = event.Time.UTC()
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads every event in the log. A missing log has no events, and
+// lines that cannot be parsed are skipped.
+func Load(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.Time.IsZero() {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
@@ -0,0 +1,139 @@
+package timetrack
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Groupings of a report
+const (
+	ByBranch  = "branch"
+	ByProject = "project"
+	ByDay     = "day"
+)
+
+// Row is the development time of one group
+type Row struct {
+	// Project is the project directory's name
+	Project string `json:"project" yaml:"project"`
+	// Branch is set when grouping by branch
+	Branch string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	// Day is set when grouping by day, as YYYY-MM-DD in local time
+	Day      string        `json:"day,omitempty" yaml:"day,omitempty"`
+	Sessions int           `json:"sessions" yaml:"sessions"`
+	Duration time.Duration `json:"-" yaml:"-"`
+	Seconds  int64         `json:"seconds" yaml:"seconds"`
+	Hours    float64       `json:"hours" yaml:"hours"`
+}
+
+// ValidGrouping reports whether by is a known grouping
+func ValidGrouping(by string) bool {
+	return by == ByBranch || by == ByProject || by == ByDay
+}
+
+// Summarize adds up the time sessions spent within [from, to), grouped by
+// branch, project, or day. Sessions without an end are skipped, so open
+// sessions must be closed first. Rows are ordered by project, then branch
+// or day.
+func Summarize(sessions []Session, from, to time.Time, by string) []Row {
+	rows := make(map[string]*Row)
+	add := func(session Session, start, end time.Time) {
+		row := Row{Project: filepath.Base(session.Project)}
+		switch by {
+		case ByBranch:
+			row.Branch = session.Branch
+			if row.Branch == "" {
+				row.Branch = session.Worktree
+			}
+		case ByDay:
+			row.Day = start.Local().Format("2006-01-02")
+		}
+
+		key := row.Project + "\x00" + row.Branch + "\x00" + row.Day
+		if rows[key] == nil {
+			rows[key] = &row
+		}
+		rows[key].Duration += end.Sub(start)
+		rows[key].Sessions++
+	}
+
+	for _, session := range sessions {
+		if session.End.IsZero() {
+			continue
+		}
+		start, end := clip(session.Start, session.End, from, to)
+		if !end.After(start) {
+			continue
+		}
+		if by != ByDay {
+			add(session, start, end)
+			continue
+		}
+		// Split sessions that span midnight
+		for day := start; day.Before(end); {
+			next := nextMidnight(day)
+			if next.After(end) {
+				next = end
+			}
+			add(session, day, next)
+			day = next
+		}
+	}
+
+	result := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		row.Seconds = int64(row.Duration / time.Second)
+		row.Hours = roundHours(row.Duration)
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Project != result[j].Project {
+			return result[i].Project < result[j].Project
+		}
+		if result[i].Day != result[j].Day {
+			return result[i].Day < result[j].Day
+		}
+		return result[i].Branch < result[j].Branch
+	})
+	return result
+}
+
+// Total adds up the time of every row
+func Total(rows []Row) time.Duration {
+	var total time.Duration
+	for _, row := range rows {
+		total += row.Duration
+	}
+	return total
+}
+
+// FormatDuration formats a duration as hours and minutes, e.g. "12h 05m"
+func FormatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+// clip limits [start, end) to [from, to); a zero bound is open
+func clip(start, end, from, to time.Time) (time.Time, time.Time) {
+	if !from.IsZero() && start.Before(from) {
+		start = from
+	}
+	if !to.IsZero() && end.After(to) {
+		end = to
+	}
+	return start, end
+}
+
+// nextMidnight returns the start of the local day after t
+func nextMidnight(t time.Time) time.Time {
+	local := t.Local()
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, time.Local)
+}
+
+// roundHours converts a duration to hours with two decimals, as used on
+// timesheets
+func roundHours(d time.Duration) float64 {
+	return float64(int64(d.Hours()*100+0.5)) / 100
+}
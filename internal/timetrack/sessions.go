@@ -0,0 +1,83 @@
+package timetrack
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Session is a period during which a worktree's containers were up
+type Session struct {
+	Project  string    `json:"project" yaml:"project"`
+	Worktree string    `json:"worktree" yaml:"worktree"`
+	Branch   string    `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Start    time.Time `json:"start" yaml:"start"`
+	// End is zero while the session is open
+	End time.Time `json:"end,omitempty" yaml:"end,omitempty"`
+	// Open is true when no stop event was recorded
+	Open bool `json:"open,omitempty" yaml:"open,omitempty"`
+}
+
+// Duration returns the length of a closed session, or zero
+func (s Session) Duration() time.Duration {
+	if s.End.Before(s.Start) {
+		return 0
+	}
+	return s.End.Sub(s.Start)
+}
+
+// Sessions pairs the start and stop events of each worktree, ordered by
+// start time. Repeated starts of a running worktree continue its session
+// unless the branch changed; stops without a session are ignored.
+func Sessions(events []Event) []Session {
+	sorted := append([]Event(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	var sessions []Session
+	open := make(map[string]*Session)
+	for _, event := range sorted {
+		key := filepath.Clean(event.Project) + "\x00" + event.Worktree
+		current := open[key]
+
+		switch event.Kind {
+		case KindStart:
+			if current != nil {
+				if current.Branch == event.Branch {
+					continue
+				}
+				current.End = event.Time
+				sessions = append(sessions, *current)
+			}
+			open[key] = &Session{
+				Project:  filepath.Clean(event.Project),
+				Worktree: event.Worktree,
+				Branch:   event.Branch,
+				Start:    event.Time,
+			}
+		case KindStop:
+			if current == nil {
+				continue
+			}
+			current.End = event.Time
+			sessions = append(sessions, *current)
+			delete(open, key)
+		}
+	}
+
+	for _, session := range open {
+		session.Open = true
+		sessions = append(sessions, *session)
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].Start.Before(sessions[j].Start) })
+	return sessions
+}
+
+// OpenSession returns the open session of a worktree, or nil
+func OpenSession(events []Event, project, worktree string) *Session {
+	for _, session := range Sessions(events) {
+		if session.Open && session.Project == filepath.Clean(project) && session.Worktree == worktree {
+			return &session
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package timetrack
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var day = time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local)
+
+func at(hour, minute int) time.Time {
+	return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "time.jsonl")
+
+	events, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	require.NoError(t, Append(path, Event{Time: at(9, 0), Kind: KindStart, Project: "/src/acme", Worktree: "vcs", Branch: "main"}))
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, _ = f.WriteString("not json\n")
+	f.Close()
+	require.NoError(t, Append(path, Event{Time: at(10, 0), Kind: KindStop, Project: "/src/acme", Worktree: "vcs"}))
+
+	events, err = Load(path)
+	require.NoError(t, err)
+	require.Len(t, events, 2, "corrupt lines are skipped")
+	assert.True(t, events[0].Time.Equal(at(9, 0)))
+	assert.Equal(t, "main", events[0].Branch)
+}
+
+func TestSessions(t *testing.T) {
+	events := []Event{
+		{Time: at(9, 0), Kind: KindStart, Project: "/src/acme", Worktree: "api", Branch: "feature/api"},
+		{Time: at(9, 30), Kind: KindStart, Project: "/src/acme", Worktree: "api", Branch: "feature/api"},
+		{Time: at(11, 0), Kind: KindStop, Project: "/src/acme", Worktree: "api"},
+		{Time: at(11, 5), Kind: KindStop, Project: "/src/acme", Worktree: "api"},
+		{Time: at(12, 0), Kind: KindStart, Project: "/src/acme", Worktree: "vcs", Branch: "main"},
+		{Time: at(13, 0), Kind: KindStart, Project: "/src/acme", Worktree: "vcs", Branch: "release"},
+	}
+
+	sessions := Sessions(events)
+	require.Len(t, sessions, 3)
+
+	assert.Equal(t, 2*time.Hour, sessions[0].Duration(), "a repeated start continues the session")
+	assert.Equal(t, "main", sessions[1].Branch)
+	assert.Equal(t, time.Hour, sessions[1].Duration(), "a branch change ends the session")
+	assert.True(t, sessions[2].Open)
+	assert.Equal(t, "release", sessions[2].Branch)
+
+	open := OpenSession(events, "/src/acme", "vcs")
+	require.NotNil(t, open)
+	assert.Equal(t, "release", open.Branch)
+	assert.Nil(t, OpenSession(events, "/src/acme", "api"))
+}
+
+func TestSummarize(t *testing.T) {
+	sessions := []Session{
+		{Project: "/src/acme", Branch: "feature/api", Start: at(9, 0), End: at(11, 0)},
+		{Project: "/src/acme", Branch: "feature/api", Start: at(14, 0), End: at(14, 30)},
+		{Project: "/src/acme", Branch: "main", Start: at(22, 0), End: at(26, 0)},
+		{Project: "/src/other", Worktree: "vcs", Start: at(8, 0), End: at(9, 0)},
+		{Project: "/src/acme", Branch: "open", Start: at(8, 0)},
+	}
+
+	rows := Summarize(sessions, day, day.Add(48*time.Hour), ByBranch)
+	require.Len(t, rows, 3)
+	assert.Equal(t, Row{Project: "acme", Branch: "feature/api", Sessions: 2, Duration: 150 * time.Minute, Seconds: 9000, Hours: 2.5}, rows[0])
+	assert.Equal(t, "vcs", rows[2].Branch, "the worktree stands in for an unknown branch")
+	assert.Equal(t, 7*time.Hour+30*time.Minute, Total(rows))
+
+	// The range clips sessions
+	rows = Summarize(sessions, at(10, 0), at(23, 0), ByProject)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 2*time.Hour+30*time.Minute, rows[0].Duration)
+
+	// Sessions spanning midnight are split
+	rows = Summarize(sessions, time.Time{}, time.Time{}, ByDay)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "2026-10-12", rows[0].Day)
+	assert.Equal(t, 4*time.Hour+30*time.Minute, rows[0].Duration)
+	assert.Equal(t, "2026-10-13", rows[1].Day)
+	assert.Equal(t, 2*time.Hour, rows[1].Duration)
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0h 00m", FormatDuration(0))
+	assert.Equal(t, "12h 05m", FormatDuration(12*time.Hour+5*time.Minute+20*time.Second))
+}
@@ -0,0 +1,148 @@
+package timings
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Thresholds decide when an operation is flagged
+type Thresholds struct {
+	// Window is how many of the latest samples of an operation are analyzed
+	Window int
+	// MinSamples is how many samples an operation needs to be flagged
+	MinSamples int
+	// FlakyCV flags operations whose coefficient of variation (standard
+	// deviation over mean) reaches it
+	FlakyCV float64
+	// Recent is how many of the latest samples are compared to the earlier
+	// ones to detect degradation
+	Recent int
+	// DegradingRatio flags operations whose recent median reaches this
+	// multiple of the earlier median
+	DegradingRatio float64
+}
+
+// DefaultThresholds returns the thresholds `glide perf report` uses
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		Window:         50,
+		MinSamples:     5,
+		FlakyCV:        0.5,
+		Recent:         5,
+		DegradingRatio: 1.5,
+	}
+}
+
+// Stats summarizes the recent samples of one operation in one project
+type Stats struct {
+	Project   string        `json:"project" yaml:"project"`
+	Operation string        `json:"operation" yaml:"operation"`
+	Samples   int           `json:"samples" yaml:"samples"`
+	Median    time.Duration `json:"median_ns" yaml:"median"`
+	Mean      time.Duration `json:"mean_ns" yaml:"mean"`
+	StdDev    time.Duration `json:"stddev_ns" yaml:"stddev"`
+	// CV is the coefficient of variation, StdDev over Mean
+	CV float64 `json:"cv" yaml:"cv"`
+	// Trend is the median of the recent samples over the median of the
+	// earlier ones; 0 when there are too few samples to tell
+	Trend     float64   `json:"trend,omitempty" yaml:"trend,omitempty"`
+	Flaky     bool      `json:"flaky" yaml:"flaky"`
+	Degrading bool      `json:"degrading" yaml:"degrading"`
+	Last      time.Time `json:"last" yaml:"last"`
+}
+
+// Flagged reports whether the operation is flaky or degrading
+func (s Stats) Flagged() bool {
+	return s.Flaky || s.Degrading
+}
+
+// Analyze summarizes the latest samples of each project's operations,
+// sorted by project and operation
+func Analyze(samples []Sample, th Thresholds) []Stats {
+	type key struct{ project, operation string }
+	groups := make(map[key][]Sample)
+	for _, s := range samples {
+		k := key{s.Project, s.Operation}
+		groups[k] = append(groups[k], s)
+	}
+
+	stats := make([]Stats, 0, len(groups))
+	for k, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool { return group[i].Time.Before(group[j].Time) })
+		if th.Window > 0 && len(group) > th.Window {
+			group = group[len(group)-th.Window:]
+		}
+		stats = append(stats, analyzeGroup(k.project, k.operation, group, th))
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Project != stats[j].Project {
+			return stats[i].Project < stats[j].Project
+		}
+		return stats[i].Operation < stats[j].Operation
+	})
+	return stats
+}
+
+// analyzeGroup computes the statistics of samples sorted oldest first
+func analyzeGroup(project, operation string, samples []Sample, th Thresholds) Stats {
+	durations := make([]float64, len(samples))
+	var sum float64
+	for i, s := range samples {
+		durations[i] = float64(s.DurationMS)
+		sum += durations[i]
+	}
+	mean := sum / float64(len(durations))
+
+	var variance float64
+	for _, d := range durations {
+		variance += (d - mean) * (d - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(durations)))
+
+	st := Stats{
+		Project:   project,
+		Operation: operation,
+		Samples:   len(samples),
+		Median:    millis(median(durations)),
+		Mean:      millis(mean),
+		StdDev:    millis(stddev),
+		Last:      samples[len(samples)-1].Time,
+	}
+	if mean > 0 {
+		st.CV = stddev / mean
+	}
+
+	// Degradation compares the latest runs to at least as many earlier ones
+	if th.Recent > 0 && len(durations) >= 2*th.Recent {
+		split := len(durations) - th.Recent
+		if earlier := median(durations[:split]); earlier > 0 {
+			st.Trend = median(durations[split:]) / earlier
+		}
+	}
+
+	if len(samples) >= th.MinSamples {
+		st.Flaky = st.CV >= th.FlakyCV
+		st.Degrading = st.Trend >= th.DegradingRatio
+	}
+	return st
+}
+
+// median returns the median of values without reordering them
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// millis converts milliseconds to a duration
+func millis(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
@@ -0,0 +1,21 @@
+// Package timings keeps a history of how long key operations take in each
+// project and flags operations whose timings are erratic or getting worse.
+//
+// Every successful `up` and `test`, and every plugin load, appends a sample
+// to a log in the user's glide directory:
+//
+//	~/.glide/timings.jsonl
+//	{"time":"2026-10-12T09:02:11Z","project":"/src/acme","operation":"up","duration_ms":8412}
+//
+// Analyze summarizes the recent samples of each operation. An operation is
+// flaky when its durations vary widely from run to run, and degrading when
+// its latest runs are much slower than the ones before them; both usually
+// point at the local environment, e.g. a starved Docker VM.
+//
+//	samples, err := timings.Load(timings.DefaultLogPath())
+//	for _, stats := range timings.Analyze(samples, timings.DefaultThresholds()) {
+//	    if stats.Flaky {
+//	        fmt.Println(stats.Operation, "is flaky")
+//	    }
+//	}
+package timings
@@ -0,0 +1,86 @@
+package timings
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// Key operations whose timings are recorded
+const (
+	OperationUp         = "up"
+	OperationTest       = "test"
+	OperationPluginLoad = "plugin_load"
+)
+
+// Sample is one run of an operation
+type Sample struct {
+	Time time.Time `json:"time"`
+	// Project is the project root directory
+	Project   string `json:"project"`
+	Operation string `json:"operation"`
+	// DurationMS is the run time in milliseconds
+	DurationMS int64 `json:"duration_ms"`
+}
+
+// Duration returns how long the run took
+func (s Sample) Duration() time.Duration {
+	return time.Duration(s.DurationMS) * time.Millisecond
+}
+
+// DefaultLogPath returns the sample log in the user's glide directory
+func DefaultLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "timings.jsonl")
+}
+
+// Append adds a sample to the log. Each sample is a single small write, so
+// concurrent glide processes do not interleave lines.
+func Append(path string, sample Sample) error {
+	sample.Time = sample.Time.UTC()
+	data, err := json.Marshal(sample)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads every sample in the log. A missing log has no samples, and
+// lines that cannot be parsed are skipped.
+func Load(path string) ([]Sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var samples []Sample
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var sample Sample
+		if err := json.Unmarshal(scanner.Bytes(), &sample); err != nil || sample.Time.IsZero() || sample.Operation == "" {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples, scanner.Err()
+}
@@ -0,0 +1,86 @@
+package timings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+// series returns one sample per duration, a minute apart
+func series(project, operation string, durationsMS ...int64) []Sample {
+	samples := make([]Sample, len(durationsMS))
+	for i, ms := range durationsMS {
+		samples[i] = Sample{Time: start.Add(time.Duration(i) * time.Minute), Project: project, Operation: operation, DurationMS: ms}
+	}
+	return samples
+}
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timings.jsonl")
+
+	samples, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, samples)
+
+	require.NoError(t, Append(path, Sample{Time: start, Project: "/src/acme", Operation: OperationUp, DurationMS: 8400}))
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, _ = f.WriteString("not json\n")
+	f.Close()
+	require.NoError(t, Append(path, Sample{Time: start.Add(time.Hour), Project: "/src/acme", Operation: OperationTest, DurationMS: 12000}))
+
+	samples, err = Load(path)
+	require.NoError(t, err)
+	require.Len(t, samples, 2, "corrupt lines are skipped")
+	assert.Equal(t, 8400*time.Millisecond, samples[0].Duration())
+	assert.Equal(t, OperationTest, samples[1].Operation)
+}
+
+func TestAnalyze(t *testing.T) {
+	th := DefaultThresholds()
+
+	t.Run("steady operations are not flagged", func(t *testing.T) {
+		stats := Analyze(series("/src/acme", OperationUp, 1000, 1100, 950, 1050, 1000, 1020), th)
+		require.Len(t, stats, 1)
+		assert.Equal(t, 6, stats[0].Samples)
+		assert.Equal(t, 1010*time.Millisecond, stats[0].Median)
+		assert.False(t, stats[0].Flagged())
+	})
+
+	t.Run("erratic operations are flaky", func(t *testing.T) {
+		stats := Analyze(series("/src/acme", OperationTest, 1000, 6000, 900, 7000, 1200, 800), th)
+		require.Len(t, stats, 1)
+		assert.True(t, stats[0].Flaky)
+		assert.Greater(t, stats[0].CV, th.FlakyCV)
+	})
+
+	t.Run("slower recent runs are degrading", func(t *testing.T) {
+		stats := Analyze(series("/src/acme", OperationUp, 1000, 1000, 1000, 1000, 1000, 2000, 2100, 2000, 2200, 2000), th)
+		require.Len(t, stats, 1)
+		assert.True(t, stats[0].Degrading)
+		assert.InDelta(t, 2.0, stats[0].Trend, 0.01)
+	})
+
+	t.Run("too few samples are never flagged", func(t *testing.T) {
+		stats := Analyze(series("/src/acme", OperationUp, 100, 9000, 100), th)
+		require.Len(t, stats, 1)
+		assert.False(t, stats[0].Flagged())
+	})
+
+	t.Run("groups by project and operation and keeps the window", func(t *testing.T) {
+		th := th
+		th.Window = 3
+		samples := append(series("/src/b", OperationUp, 1, 2, 3, 4, 5), series("/src/a", OperationUp, 1)...)
+		stats := Analyze(samples, th)
+		require.Len(t, stats, 2)
+		assert.Equal(t, "/src/a", stats[0].Project)
+		assert.Equal(t, 3, stats[1].Samples)
+		assert.Equal(t, 4*time.Millisecond, stats[1].Median)
+	})
+}
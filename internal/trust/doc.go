@@ -0,0 +1,19 @@
+// Package trust records which project directories the user trusts to run
+// the commands their .glide.yml files define.
+//
+// A .glide.yml can run arbitrary shell commands, so a freshly cloned
+// project must not run them before the user has looked at it. Decisions
+// are kept in the user's glide directory, keyed by directory, and apply to
+// everything below that directory unless a nearer decision overrides them:
+//
+//	~/.glide/trust.json
+//	{"directories":{"/src/acme":{"trusted":true,"time":"2026-10-12T09:02:11Z"}}}
+//
+// Usage:
+//
+//	store := trust.NewStore(trust.DefaultPath())
+//	status, decidedAt, err := store.Status(projectRoot)
+//	if status == trust.Unknown {
+//	    // ask, then store.Set(projectRoot, answer)
+//	}
+package trust
@@ -0,0 +1,184 @@
+package trust
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// Status is whether the commands of a directory may run
+type Status string
+
+// Statuses of a directory
+const (
+	Trusted Status = "trusted"
+	Denied  Status = "denied"
+	Unknown Status = "unknown"
+)
+
+// Decision is the user's answer for one directory
+type Decision struct {
+	Dir     string    `json:"-"`
+	Trusted bool      `json:"trusted"`
+	Time    time.Time `json:"time"`
+}
+
+// file is the on-disk layout of the store
+type file struct {
+	Directories map[string]Decision `json:"directories"`
+}
+
+// Store keeps trust decisions in a JSON file
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DefaultPath returns the trust file in the user's glide directory
+func DefaultPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "trust.json")
+}
+
+// NewStore returns a store kept in the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the file the decisions are kept in
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Status returns whether dir is trusted, following the decision of dir or
+// its nearest ancestor, and the directory that decision was made for
+func (s *Store) Status(dir string) (Status, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := s.load()
+	if err != nil {
+		return Unknown, "", err
+	}
+	for d := normalize(dir); ; d = filepath.Dir(d) {
+		if decision, ok := f.Directories[d]; ok {
+			if decision.Trusted {
+				return Trusted, d, nil
+			}
+			return Denied, d, nil
+		}
+		if parent := filepath.Dir(d); parent == d {
+			return Unknown, "", nil
+		}
+	}
+}
+
+// Set records whether dir is trusted
+func (s *Store) Set(dir string, trusted bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := s.load()
+	if err != nil {
+		return err
+	}
+	f.Directories[normalize(dir)] = Decision{Trusted: trusted, Time: time.Now().UTC()}
+	return s.save(f)
+}
+
+// Forget removes the decision recorded for dir itself, reporting whether
+// there was one. Decisions of its ancestors still apply.
+func (s *Store) Forget(dir string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := s.load()
+	if err != nil {
+		return false, err
+	}
+	dir = normalize(dir)
+	if _, ok := f.Directories[dir]; !ok {
+		return false, nil
+	}
+	delete(f.Directories, dir)
+	return true, s.save(f)
+}
+
+// Decisions returns every recorded decision, ordered by directory
+func (s *Store) Decisions() ([]Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	decisions := make([]Decision, 0, len(f.Directories))
+	for dir, decision := range f.Directories {
+		decision.Dir = dir
+		decisions = append(decisions, decision)
+	}
+	sort.Slice(decisions, func(i, j int) bool { return decisions[i].Dir < decisions[j].Dir })
+	return decisions, nil
+}
+
+// load reads the store. A missing file has no decisions.
+func (s *Store) load() (*file, error) {
+	f := &file{}
+	data, err := os.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if err := json.Unmarshal(data, f); err != nil {
+			return nil, err
+		}
+	}
+	if f.Directories == nil {
+		f.Directories = make(map[string]Decision)
+	}
+	return f, nil
+}
+
+// save replaces the store through a rename, so a concurrent reader never
+// sees a partial file
+func (s *Store) save(f *file) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".trust-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// normalize returns dir as an absolute path with symlinks resolved, so a
+// project reached through a symlink shares its decision
+func normalize(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		return resolved
+	}
+	return filepath.Clean(dir)
+}
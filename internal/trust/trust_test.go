@@ -0,0 +1,89 @@
+package trust
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	tmp := t.TempDir()
+	project := filepath.Join(tmp, "src", "acme")
+	nested := filepath.Join(project, "services", "api")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	store := NewStore(filepath.Join(tmp, "glide", "trust.json"))
+
+	t.Run("unknown without decisions", func(t *testing.T) {
+		status, dir, err := store.Status(project)
+		require.NoError(t, err)
+		assert.Equal(t, Unknown, status)
+		assert.Empty(t, dir)
+	})
+
+	t.Run("decision applies below the directory", func(t *testing.T) {
+		require.NoError(t, store.Set(project, true))
+
+		status, dir, err := store.Status(nested)
+		require.NoError(t, err)
+		assert.Equal(t, Trusted, status)
+		assert.Equal(t, normalize(project), dir)
+	})
+
+	t.Run("nearest decision wins", func(t *testing.T) {
+		require.NoError(t, store.Set(nested, false))
+
+		status, _, err := store.Status(nested)
+		require.NoError(t, err)
+		assert.Equal(t, Denied, status)
+
+		status, _, err = store.Status(project)
+		require.NoError(t, err)
+		assert.Equal(t, Trusted, status)
+	})
+
+	t.Run("symlinked path shares the decision", func(t *testing.T) {
+		link := filepath.Join(tmp, "link")
+		require.NoError(t, os.Symlink(project, link))
+
+		status, _, err := store.Status(link)
+		require.NoError(t, err)
+		assert.Equal(t, Trusted, status)
+	})
+
+	t.Run("decisions are listed by directory", func(t *testing.T) {
+		decisions, err := store.Decisions()
+		require.NoError(t, err)
+		require.Len(t, decisions, 2)
+		assert.Equal(t, normalize(project), decisions[0].Dir)
+		assert.True(t, decisions[0].Trusted)
+		assert.Equal(t, normalize(nested), decisions[1].Dir)
+		assert.False(t, decisions[1].Trusted)
+	})
+
+	t.Run("forget removes only the directory's own decision", func(t *testing.T) {
+		removed, err := store.Forget(nested)
+		require.NoError(t, err)
+		assert.True(t, removed)
+
+		status, _, err := store.Status(nested)
+		require.NoError(t, err)
+		assert.Equal(t, Trusted, status, "the project's decision applies again")
+
+		removed, err = store.Forget(nested)
+		require.NoError(t, err)
+		assert.False(t, removed)
+	})
+
+	t.Run("corrupt file is an error", func(t *testing.T) {
+		bad := NewStore(filepath.Join(tmp, "bad.json"))
+		require.NoError(t, os.WriteFile(bad.Path(), []byte("{"), 0644))
+
+		_, _, err := bad.Status(project)
+		assert.Error(t, err)
+		assert.Error(t, bad.Set(project, true))
+	})
+}
@@ -0,0 +1,359 @@
+package artifacts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+)
+
+const (
+	// DirEnv moves the cache, e.g. onto a filesystem machines share
+	DirEnv = "GLIDE_ARTIFACT_CACHE"
+	// MaxSizeEnv limits the cache's size, e.g. 5GB; 0 turns caching off
+	MaxSizeEnv = "GLIDE_ARTIFACT_CACHE_MAX"
+
+	// DefaultMaxSize is the size the cache is pruned to
+	DefaultMaxSize = 1 << 30
+)
+
+// Cache is a content-addressed store of downloaded files
+type Cache struct {
+	Dir string
+	// MaxSize is the size in bytes the cache is pruned to after storing a
+	// file; 0 turns caching off
+	MaxSize int64
+}
+
+// New creates a cache in dir
+func New(dir string, maxSize int64) *Cache {
+	return &Cache{Dir: dir, MaxSize: maxSize}
+}
+
+// DefaultDir returns where the cache lives: GLIDE_ARTIFACT_CACHE, or else
+// ~/.glide/cache/artifacts
+func DefaultDir() string {
+	if dir := os.Getenv(DirEnv); dir != "" {
+		return dir
+	}
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "cache", "artifacts")
+}
+
+// Default returns the cache in DefaultDir, limited by
+// GLIDE_ARTIFACT_CACHE_MAX
+func Default() *Cache {
+	maxSize := int64(DefaultMaxSize)
+	if value := os.Getenv(MaxSizeEnv); value != "" {
+		size, err := ParseSize(value)
+		if err != nil {
+			logging.Warn("Ignoring invalid artifact cache size", "variable", MaxSizeEnv, "value", value)
+		} else {
+			maxSize = size
+		}
+	}
+	return New(DefaultDir(), maxSize)
+}
+
+// Enabled reports whether files are cached
+func (c *Cache) Enabled() bool {
+	return c.MaxSize > 0
+}
+
+// Lookup returns the path of the file cached under key. Its modification
+// time is bumped, so pruning keeps recently used files.
+func (c *Cache) Lookup(key string) (string, bool) {
+	if !c.Enabled() {
+		return "", false
+	}
+	ref, err := os.ReadFile(c.refPath(key))
+	if err != nil {
+		return "", false
+	}
+	sum, _, _ := strings.Cut(string(ref), "\n")
+	path := c.blobPath(sum)
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// Store copies the file at src into the cache under key and prunes the
+// cache to its limit
+func (c *Cache) Store(key, src string) error {
+	if !c.Enabled() {
+		return nil
+	}
+	sum, err := hashFile(src)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(c.blobPath(sum), now, now); err != nil {
+		if err := copyAtomic(src, c.blobPath(sum), 0755); err != nil {
+			return err
+		}
+	}
+	if err := writeAtomic(c.refPath(key), []byte(sum+"\n"+key+"\n")); err != nil {
+		return err
+	}
+
+	_, err = c.Prune(c.MaxSize, time.Time{})
+	return err
+}
+
+// Download returns a temporary copy of the file cached under key, or
+// downloads it with download, which returns a temporary file, and caches
+// it. The caller owns the returned file. Cache errors are only logged;
+// downloads work without a cache.
+func (c *Cache) Download(key string, download func() (string, error)) (string, error) {
+	if cached, ok := c.Lookup(key); ok {
+		tmp, err := copyToTemp(cached)
+		if err == nil {
+			logging.Debug("Using cached artifact", "key", key, "path", cached)
+			return tmp, nil
+		}
+		logging.Debug("Failed to copy cached artifact", "key", key, "error", err)
+	}
+
+	path, err := download()
+	if err != nil {
+		return "", err
+	}
+	if err := c.Store(key, path); err != nil {
+		logging.Debug("Failed to cache artifact", "key", key, "error", err)
+	}
+	return path, nil
+}
+
+// PruneResult is what pruning removed and what is left
+type PruneResult struct {
+	Removed int   `json:"removed" yaml:"removed"`
+	Freed   int64 `json:"freed" yaml:"freed"`
+	Kept    int   `json:"kept" yaml:"kept"`
+	Size    int64 `json:"size" yaml:"size"`
+}
+
+// Prune removes the files last used before unusedSince, if it is set, and
+// then the least recently used files until the cache is at most maxSize
+// bytes. References to removed files are removed too, and so are partial
+// downloads older than unusedSince or PartialMaxAge, or all of them when
+// maxSize is 0.
+func (c *Cache) Prune(maxSize int64, unusedSince time.Time) (PruneResult, error) {
+	var result PruneResult
+	blobs, err := c.blobs()
+	if err != nil {
+		return result, err
+	}
+
+	// Oldest first
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].ModTime().Before(blobs[j].ModTime()) })
+	for _, blob := range blobs {
+		result.Size += blob.Size()
+	}
+	for _, blob := range blobs {
+		if result.Size <= maxSize && !blob.ModTime().Before(unusedSince) {
+			result.Kept++
+			continue
+		}
+		if err := os.Remove(c.blobPath(blob.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return result, err
+		}
+		result.Removed++
+		result.Freed += blob.Size()
+		result.Size -= blob.Size()
+	}
+
+	if result.Removed > 0 {
+		c.removeDanglingRefs()
+	}
+
+	cutoff := time.Now().Add(-PartialMaxAge)
+	if maxSize <= 0 {
+		cutoff = time.Now().Add(time.Minute)
+	} else if unusedSince.After(cutoff) {
+		cutoff = unusedSince
+	}
+	removed, freed := c.prunePartials(cutoff)
+	result.Removed += removed
+	result.Freed += freed
+	return result, nil
+}
+
+// Usage returns the number of cached files and their size
+func (c *Cache) Usage() (int, int64, error) {
+	blobs, err := c.blobs()
+	if err != nil {
+		return 0, 0, err
+	}
+	var size int64
+	for _, blob := range blobs {
+		size += blob.Size()
+	}
+	return len(blobs), size, nil
+}
+
+// blobs lists the cached files
+func (c *Cache) blobs() ([]os.FileInfo, error) {
+	entries, err := os.ReadDir(filepath.Join(c.Dir, "blobs"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		// Skip files other processes are still writing
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			blobs = append(blobs, info)
+		}
+	}
+	return blobs, nil
+}
+
+// removeDanglingRefs removes references to files no longer cached
+func (c *Cache) removeDanglingRefs() {
+	entries, err := os.ReadDir(filepath.Join(c.Dir, "refs"))
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		path := filepath.Join(c.Dir, "refs", entry.Name())
+		ref, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		sum, _, _ := strings.Cut(string(ref), "\n")
+		if _, err := os.Stat(c.blobPath(sum)); errors.Is(err, os.ErrNotExist) {
+			// Safe to ignore: another process may have removed it first
+			_ = os.Remove(path)
+		}
+	}
+}
+
+func (c *Cache) blobPath(sum string) string {
+	return filepath.Join(c.Dir, "blobs", sum)
+}
+
+func (c *Cache) refPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, "refs", hex.EncodeToString(sum[:]))
+}
+
+// hashFile returns the hex SHA-256 of a file
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// copyAtomic copies src to dst through a temporary file in dst's
+// directory, so readers never see a partial file
+func copyAtomic(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// writeAtomic writes data to path through a temporary file
+func writeAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// copyToTemp copies a cached file to a temporary file the caller owns
+func copyToTemp(path string) (string, error) {
+	tmp, err := os.CreateTemp("", "glide-artifact-*")
+	if err != nil {
+		return "", err
+	}
+	tmp.Close()
+	if err := copyAtomic(path, tmp.Name(), 0755); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+// ParseSize parses a size in bytes with an optional unit, e.g. "512MB"
+func ParseSize(value string) (int64, error) {
+	s := strings.TrimSpace(value)
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix     string
+		multiplier int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(s, unit.suffix) {
+			s, multiplier = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix)), unit.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q, e.g. 500MB or 2GB", value)
+	}
+	return n * multiplier, nil
+}
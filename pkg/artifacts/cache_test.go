@@ -0,0 +1,109 @@
+package artifacts
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTemp writes a temporary file as a download would
+func writeTemp(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "download")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestCache_Download(t *testing.T) {
+	cache := New(t.TempDir(), DefaultMaxSize)
+	downloads := 0
+	download := func() (string, error) {
+		downloads++
+		return writeTemp(t, "plugin binary"), nil
+	}
+
+	for range 2 {
+		path, err := cache.Download("https://github.com/a/b/releases/download/v1/b-linux-amd64", download)
+		require.NoError(t, err)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "plugin binary", string(data))
+		require.NoError(t, os.Remove(path), "the caller owns the file")
+	}
+	assert.Equal(t, 1, downloads)
+
+	// The same content under another key is stored once
+	_, err := cache.Download("https://mirror/b-linux-amd64", download)
+	require.NoError(t, err)
+	files, size, err := cache.Usage()
+	require.NoError(t, err)
+	assert.Equal(t, 1, files)
+	assert.Equal(t, int64(len("plugin binary")), size)
+
+	_, err = cache.Download("https://github.com/a/b/missing", func() (string, error) { return "", errors.New("404") })
+	assert.EqualError(t, err, "404")
+}
+
+func TestCache_Disabled(t *testing.T) {
+	cache := New(t.TempDir(), 0)
+	require.NoError(t, cache.Store("key", writeTemp(t, "x")))
+	_, ok := cache.Lookup("key")
+	assert.False(t, ok)
+}
+
+func TestCache_Prune(t *testing.T) {
+	cache := New(t.TempDir(), DefaultMaxSize)
+	now := time.Now()
+	for i, key := range []string{"old", "recent", "newest"} {
+		require.NoError(t, cache.Store(key, writeTemp(t, key+" content")))
+		path, ok := cache.Lookup(key)
+		require.True(t, ok)
+		used := now.Add(time.Duration(i-3) * time.Hour)
+		require.NoError(t, os.Chtimes(path, used, used))
+	}
+
+	result, err := cache.Prune(DefaultMaxSize, now.Add(-150*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Removed)
+	assert.Equal(t, int64(len("old content")), result.Freed)
+	_, ok := cache.Lookup("old")
+	assert.False(t, ok)
+
+	// Over the limit, the least recently used go first
+	result, err = cache.Prune(int64(len("newest content")), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, PruneResult{Removed: 1, Freed: int64(len("recent content")), Kept: 1, Size: int64(len("newest content"))}, result)
+	_, ok = cache.Lookup("newest")
+	assert.True(t, ok)
+
+	refs, err := os.ReadDir(filepath.Join(cache.Dir, "refs"))
+	require.NoError(t, err)
+	assert.Len(t, refs, 1, "references to pruned files are removed")
+}
+
+func TestDefault(t *testing.T) {
+	t.Setenv(DirEnv, "/shared/glide")
+	t.Setenv(MaxSizeEnv, "5GB")
+	cache := Default()
+	assert.Equal(t, "/shared/glide", cache.Dir)
+	assert.Equal(t, int64(5<<30), cache.MaxSize)
+
+	t.Setenv(MaxSizeEnv, "lots")
+	assert.Equal(t, int64(DefaultMaxSize), Default().MaxSize)
+}
+
+func TestParseSize(t *testing.T) {
+	size, err := ParseSize("512MB")
+	require.NoError(t, err)
+	assert.Equal(t, int64(512<<20), size)
+	size, err = ParseSize("100")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), size)
+	_, err = ParseSize("-1GB")
+	assert.ErrorContains(t, err, `invalid size "-1GB"`)
+}
@@ -0,0 +1,32 @@
+// Package artifacts caches downloaded plugin binaries and update archives,
+// so that installing the same release again, in another worktree or on
+// another machine sharing the cache, does not download it again.
+//
+// The cache is content-addressed: each file is stored once under its
+// SHA-256, and keys, usually the download URL of a release asset, refer to
+// it. Writes are atomic renames, so glide processes on several machines can
+// share a cache on a network filesystem:
+//
+//	~/.glide/cache/artifacts/blobs/<sha256>
+//	~/.glide/cache/artifacts/refs/<sha256 of key>
+//
+// Download returns a temporary copy of a cached file, or downloads and
+// caches it:
+//
+//	path, err := artifacts.Default().Download(url, func() (string, error) {
+//	    return downloadToTemp(url)
+//	})
+//	defer os.Remove(path)
+//
+// Fetch downloads a large file, such as a glide release, resumably. What
+// an interrupted download wrote is kept under partial/, with the file's
+// ETag or Last-Modified, and the next Fetch of the URL asks the server for
+// the rest only while that still matches:
+//
+//	~/.glide/cache/artifacts/partial/<sha256 of url>
+//	~/.glide/cache/artifacts/partial/<sha256 of url>.json
+//
+// The least recently used files are pruned when the cache outgrows its
+// limit (GLIDE_ARTIFACT_CACHE_MAX, 1GB by default; 0 turns caching off).
+// GLIDE_ARTIFACT_CACHE moves the cache, e.g. onto a shared filesystem.
+package artifacts
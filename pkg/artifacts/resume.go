@@ -0,0 +1,287 @@
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/retry"
+)
+
+// PartialMaxAge is how long a partial download is kept for resuming
+const PartialMaxAge = 7 * 24 * time.Hour
+
+// fetchPolicy retries a dropped download, resuming it each time. Large
+// downloads take longer than retry.DefaultPolicy allows in total, so only
+// attempts are limited. It is replaced in tests.
+var fetchPolicy = retry.Policy{
+	InitialInterval: time.Second,
+	MaxInterval:     10 * time.Second,
+	Multiplier:      2,
+	Jitter:          0.2,
+	MaxAttempts:     4,
+}
+
+// PartialError is returned by Fetch when a download stopped, e.g. on
+// Ctrl-C or a dropped connection, and what was downloaded is kept for the
+// next Fetch of the URL to resume
+type PartialError struct {
+	URL string
+	// Bytes is how much is downloaded
+	Bytes int64
+	Err   error
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("download interrupted after %d bytes: %v", e.Bytes, e.Err)
+}
+
+func (e *PartialError) Unwrap() error { return e.Err }
+
+// partialMeta identifies the file a partial download is of, so it is only
+// resumed while the server still serves that file
+type partialMeta struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// validator returns what If-Range compares, preferring the ETag
+func (m partialMeta) validator() string {
+	if m.ETag != "" {
+		return m.ETag
+	}
+	return m.LastModified
+}
+
+// Fetch downloads url with client to a temporary file the caller owns. A
+// download that stops part way is kept in the cache directory and resumed,
+// by the retries of this call or by the next Fetch of the URL, with a range
+// request that only succeeds while the file's ETag or Last-Modified is
+// unchanged; otherwise it starts over. When it gives up with part of the
+// file downloaded, the error is a *PartialError. When the cache is off or
+// cannot be written, the file is downloaded without it and cannot be
+// resumed.
+func (c *Cache) Fetch(ctx context.Context, client *http.Client, url string) (string, error) {
+	if !c.Enabled() || c.Dir == "" {
+		return fetchToTemp(ctx, client, url)
+	}
+	path := c.partialPath(url)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		logging.Warn("Artifact cache is not writable; downloading without resume", "dir", c.Dir, "error", err)
+		return fetchToTemp(ctx, client, url)
+	}
+
+	meta := c.loadPartialMeta(url)
+	err := retry.Do(ctx, fetchPolicy, func(ctx context.Context) error {
+		return c.fetchOnce(ctx, client, url, &meta)
+	})
+	var cacheErr *cacheError
+	if errors.As(err, &cacheErr) {
+		logging.Warn("Artifact cache is not writable; downloading without resume", "dir", c.Dir, "error", cacheErr.err)
+		c.removePartial(url)
+		return fetchToTemp(ctx, client, url)
+	}
+	if err != nil {
+		info, statErr := os.Stat(path)
+		if statErr == nil && info.Size() > 0 && meta.validator() != "" {
+			return "", &PartialError{URL: url, Bytes: info.Size(), Err: err}
+		}
+		c.removePartial(url)
+		return "", err
+	}
+
+	tmp, err := copyToTemp(path)
+	if err != nil {
+		return "", err
+	}
+	c.removePartial(url)
+	return tmp, nil
+}
+
+// cacheError is a failure to write the cache directory, after which Fetch
+// downloads without it
+type cacheError struct {
+	err error
+}
+
+func (e *cacheError) Error() string { return e.err.Error() }
+
+// fetchToTemp downloads url to a temporary file the caller owns, starting
+// over on each retry
+func fetchToTemp(ctx context.Context, client *http.Client, url string) (string, error) {
+	f, err := os.CreateTemp("", "glide-artifact-*")
+	if err != nil {
+		return "", err
+	}
+	err = retry.Do(ctx, fetchPolicy, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return retry.Permanent(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+		case resp.StatusCode >= http.StatusInternalServerError:
+			return fmt.Errorf("download failed with status %d", resp.StatusCode)
+		default:
+			return retry.Permanent(fmt.Errorf("download failed with status %d", resp.StatusCode))
+		}
+		if err := f.Truncate(0); err != nil {
+			return retry.Permanent(err)
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return retry.Permanent(err)
+		}
+		_, err = io.Copy(f, resp.Body)
+		return err
+	})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// fetchOnce downloads url into its partial file, resuming what is there
+// when meta says which file it is
+func (c *Cache) fetchOnce(ctx context.Context, client *http.Client, url string, meta *partialMeta) error {
+	path := c.partialPath(url)
+	var offset int64
+	if info, err := os.Stat(path); err == nil && meta.validator() != "" {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return retry.Permanent(err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", meta.validator())
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0 &&
+		strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)):
+		flags |= os.O_APPEND
+		logging.Debug("Resuming download", "url", url, "offset", offset)
+	case resp.StatusCode == http.StatusOK:
+		// The whole file, because the server cannot resume or it changed
+		flags |= os.O_TRUNC
+		*meta = partialMeta{URL: url, ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+		if err := c.savePartialMeta(*meta); err != nil {
+			return retry.Permanent(&cacheError{err})
+		}
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// The partial file does not fit the file served; start over
+		*meta = partialMeta{}
+		c.removePartial(url)
+		return fmt.Errorf("download failed with status %d", resp.StatusCode)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return fmt.Errorf("download failed with status %d", resp.StatusCode)
+	default:
+		return retry.Permanent(fmt.Errorf("download failed with status %d", resp.StatusCode))
+	}
+
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return retry.Permanent(&cacheError{err})
+	}
+	_, copyErr := io.Copy(f, resp.Body)
+	if err := f.Close(); err != nil && copyErr == nil {
+		copyErr = err
+	}
+	return copyErr
+}
+
+// PartialSize returns how much of url a stopped download kept, or 0
+func (c *Cache) PartialSize(url string) int64 {
+	if c.loadPartialMeta(url).validator() == "" {
+		return 0
+	}
+	info, err := os.Stat(c.partialPath(url))
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// prunePartials removes partial downloads last written before cutoff and
+// returns how many files and bytes it removed
+func (c *Cache) prunePartials(cutoff time.Time) (int, int64) {
+	dir := filepath.Join(c.Dir, "partial")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, 0
+	}
+	removed, freed := 0, int64(0)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if os.Remove(filepath.Join(dir, entry.Name())) == nil && !strings.HasSuffix(entry.Name(), ".json") {
+			removed++
+			freed += info.Size()
+		}
+	}
+	return removed, freed
+}
+
+func (c *Cache) partialPath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.Dir, "partial", hex.EncodeToString(sum[:]))
+}
+
+// loadPartialMeta reads what the partial download of url is of; the zero
+// partialMeta when there is none
+func (c *Cache) loadPartialMeta(url string) partialMeta {
+	var meta partialMeta
+	data, err := os.ReadFile(c.partialPath(url) + ".json")
+	if err != nil || json.Unmarshal(data, &meta) != nil || meta.URL != url {
+		return partialMeta{}
+	}
+	return meta
+}
+
+func (c *Cache) savePartialMeta(meta partialMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return writeAtomic(c.partialPath(meta.URL)+".json", data)
+}
+
+// removePartial removes the partial download of url
+func (c *Cache) removePartial(url string) {
+	path := c.partialPath(url)
+	// Safe to ignore: a leftover partial file is pruned later
+	_ = os.Remove(path)
+	_ = os.Remove(path + ".json")
+}
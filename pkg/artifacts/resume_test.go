@@ -0,0 +1,162 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rangeServer serves content with an ETag and range requests. While cut is
+// set, it drops the connection after cut bytes of a full response.
+type rangeServer struct {
+	mu      sync.Mutex
+	content []byte
+	etag    string
+	cut     int
+	ranges  []string
+}
+
+func (s *rangeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	content, etag, cut := s.content, s.etag, s.cut
+	s.ranges = append(s.ranges, r.Header.Get("Range"))
+	s.mu.Unlock()
+
+	w.Header().Set("ETag", etag)
+	if cut > 0 && r.Header.Get("Range") == "" {
+		w.Header().Set("Content-Length", "100000")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(content[:cut])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	http.ServeContent(w, r, "glide", time.Time{}, bytes.NewReader(content))
+}
+
+// singleAttempt makes Fetch give up after one attempt
+func singleAttempt(t *testing.T) {
+	original := fetchPolicy
+	fetchPolicy = retry.Policy{MaxAttempts: 1}
+	t.Cleanup(func() { fetchPolicy = original })
+}
+
+func TestCache_FetchResumes(t *testing.T) {
+	singleAttempt(t)
+	content := []byte(strings.Repeat("glide binary ", 1000))
+	srv := &rangeServer{content: content, etag: `"v1"`, cut: 4000}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+	cache := New(t.TempDir(), DefaultMaxSize)
+
+	_, err := cache.Fetch(context.Background(), server.Client(), server.URL)
+	var partial *PartialError
+	require.True(t, errors.As(err, &partial), "got %v", err)
+	assert.Equal(t, int64(4000), partial.Bytes)
+	assert.Equal(t, int64(4000), cache.PartialSize(server.URL))
+
+	srv.cut = 0
+	path, err := cache.Fetch(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+	assert.Equal(t, []string{"", "bytes=4000-"}, srv.ranges)
+	assert.Zero(t, cache.PartialSize(server.URL), "the partial download is removed")
+}
+
+func TestCache_FetchRestartsChangedFile(t *testing.T) {
+	singleAttempt(t)
+	srv := &rangeServer{content: []byte(strings.Repeat("old ", 2000)), etag: `"v1"`, cut: 3000}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+	cache := New(t.TempDir(), DefaultMaxSize)
+
+	_, err := cache.Fetch(context.Background(), server.Client(), server.URL)
+	require.Error(t, err)
+
+	// A new release is served under the same URL
+	srv.content, srv.etag, srv.cut = []byte(strings.Repeat("new ", 1500)), `"v2"`, 0
+	path, err := cache.Fetch(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, srv.content, data)
+}
+
+func TestCache_FetchHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	cache := New(t.TempDir(), DefaultMaxSize)
+
+	_, err := cache.Fetch(context.Background(), server.Client(), server.URL)
+	assert.EqualError(t, err, "download failed with status 404")
+	var partial *PartialError
+	assert.False(t, errors.As(err, &partial))
+}
+
+func TestCache_PrunePartials(t *testing.T) {
+	singleAttempt(t)
+	srv := &rangeServer{content: []byte(strings.Repeat("x", 5000)), etag: `"v1"`, cut: 1000}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+	cache := New(t.TempDir(), DefaultMaxSize)
+
+	_, err := cache.Fetch(context.Background(), server.Client(), server.URL)
+	require.Error(t, err)
+
+	result, err := cache.Prune(DefaultMaxSize, time.Time{})
+	require.NoError(t, err)
+	assert.Zero(t, result.Removed, "recent partial downloads are kept")
+
+	result, err = cache.Prune(0, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Removed)
+	assert.Equal(t, int64(1000), result.Freed)
+	assert.Zero(t, cache.PartialSize(server.URL))
+}
+
+func TestCache_FetchWithoutUsableCache(t *testing.T) {
+	singleAttempt(t)
+	content := []byte(strings.Repeat("glide binary ", 100))
+	server := httptest.NewServer(&rangeServer{content: content, etag: `"v1"`})
+	defer server.Close()
+
+	// A file where the cache directory should be cannot be written to,
+	// even by root
+	blocker := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(blocker, nil, 0644))
+	disabledDir := t.TempDir()
+
+	for name, cache := range map[string]*Cache{
+		"unwritable":   New(filepath.Join(blocker, "artifacts"), DefaultMaxSize),
+		"no directory": New("", DefaultMaxSize),
+		"disabled":     New(disabledDir, 0),
+	} {
+		t.Run(name, func(t *testing.T) {
+			path, err := cache.Fetch(context.Background(), server.Client(), server.URL)
+			require.NoError(t, err, "downloads work without a cache")
+			defer os.Remove(path)
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, content, data)
+		})
+	}
+
+	entries, err := os.ReadDir(disabledDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "a disabled cache keeps no partial downloads")
+}
@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// Outcome describes what happened to a destructive operation
+type Outcome string
+
+const (
+	// OutcomeConfirmed means the user confirmed the prompt
+	OutcomeConfirmed Outcome = "confirmed"
+	// OutcomeForced means confirmation was bypassed with --force
+	OutcomeForced Outcome = "forced"
+	// OutcomeCancelled means the user declined the prompt
+	OutcomeCancelled Outcome = "cancelled"
+	// OutcomeRefused means the operation was blocked, e.g. in a
+	// non-interactive session without --force
+	OutcomeRefused Outcome = "refused"
+)
+
+// Entry is a single audit log record
+type Entry struct {
+	Time       time.Time `json:"time"`
+	User       string    `json:"user,omitempty"`
+	Command    string    `json:"command"`
+	WorkingDir string    `json:"working_dir,omitempty"`
+	Targets    []string  `json:"targets,omitempty"`
+	Outcome    Outcome   `json:"outcome"`
+}
+
+// Logger appends entries to an audit log file
+type Logger struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewLogger creates a logger that writes to path
+func NewLogger(path string) *Logger {
+	return &Logger{path: path}
+}
+
+// DefaultPath returns the audit log location, honouring GLIDE_AUDIT_LOG
+func DefaultPath() string {
+	if path := os.Getenv("GLIDE_AUDIT_LOG"); path != "" {
+		return path
+	}
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "audit.log")
+}
+
+// Path returns the file the logger writes to
+func (l *Logger) Path() string {
+	return l.path
+}
+
+// Record appends an entry to the log. Time, user, and working directory are
+// filled in when left empty.
+func (l *Logger) Record(entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	if entry.User == "" {
+		if u, err := user.Current(); err == nil {
+			entry.User = u.Username
+		}
+	}
+	if entry.WorkingDir == "" {
+		entry.WorkingDir, _ = os.Getwd()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Entries reads all entries from the log in the order they were recorded
+func (l *Logger) Entries() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("corrupt audit log entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger_RecordAndEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "audit.log")
+	logger := NewLogger(path)
+
+	require.NoError(t, logger.Record(Entry{
+		Command: "glide project down --volumes",
+		Targets: []string{"volumes in vcs/"},
+		Outcome: OutcomeConfirmed,
+	}))
+	require.NoError(t, logger.Record(Entry{
+		Command: "glide project clean --all",
+		Outcome: OutcomeCancelled,
+	}))
+
+	entries, err := logger.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "glide project down --volumes", entries[0].Command)
+	assert.Equal(t, []string{"volumes in vcs/"}, entries[0].Targets)
+	assert.Equal(t, OutcomeConfirmed, entries[0].Outcome)
+	assert.False(t, entries[0].Time.IsZero())
+	assert.NotEmpty(t, entries[0].WorkingDir)
+	assert.Equal(t, OutcomeCancelled, entries[1].Outcome)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestLogger_EntriesMissingFile(t *testing.T) {
+	entries, err := NewLogger(filepath.Join(t.TempDir(), "audit.log")).Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("GLIDE_AUDIT_LOG", "/tmp/custom-audit.log")
+	assert.Equal(t, "/tmp/custom-audit.log", DefaultPath())
+
+	t.Setenv("GLIDE_AUDIT_LOG", "")
+	assert.Equal(t, "audit.log", filepath.Base(DefaultPath()))
+}
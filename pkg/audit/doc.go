@@ -0,0 +1,23 @@
+// Package audit records destructive operations to an append-only log.
+//
+// Every time a command marked as destructive runs (or is refused or
+// cancelled), an Entry is appended to the audit log as a single JSON line.
+// The log lives at ~/.glide/audit.log by default and can be relocated with
+// the GLIDE_AUDIT_LOG environment variable.
+//
+// # Recording Entries
+//
+//	logger := audit.NewLogger(audit.DefaultPath())
+//	err := logger.Record(audit.Entry{
+//	    Command: "glide project down --volumes",
+//	    Targets: []string{"Docker volumes of the compose project in vcs/"},
+//	    Outcome: audit.OutcomeConfirmed,
+//	})
+//
+// # Reading Entries
+//
+//	entries, err := logger.Entries()
+//	for _, e := range entries {
+//	    fmt.Println(e.Time, e.Command, e.Outcome)
+//	}
+package audit
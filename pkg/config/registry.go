@@ -53,6 +53,10 @@ func NewRegistry() *Registry {
 //	    Timeout: 30,
 //	})
 func Register[T any](name string, defaults T) error {
+	if name == SharedSection {
+		return fmt.Errorf("configuration name %q is reserved for the shared plugin section", name)
+	}
+
 	globalRegistry.mu.Lock()
 	defer globalRegistry.mu.Unlock()
 
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// SharedSection is the section of the plugins: configuration every plugin
+// receives. Everything else under plugins: is only given to the plugin it
+// is named after, so one plugin cannot read another's API keys:
+//
+//	plugins:
+//	  shared:
+//	    region: eu-west-1
+//	  deploy:
+//	    api_key: ...      # only the deploy plugin sees this
+//	    region: us-east-1 # overrides the shared value for deploy
+const SharedSection = "shared"
+
+var (
+	pluginSectionsMu sync.RWMutex
+	// pluginSections holds the plugins: configuration by section
+	pluginSections map[string]map[string]interface{}
+)
+
+// SetPluginSections stores the plugins: section of the loaded
+// configuration, from which each plugin is given its scope. Sections that
+// are not maps are ignored.
+func SetPluginSections(plugins map[string]interface{}) {
+	sections := make(map[string]map[string]interface{}, len(plugins))
+	for name, raw := range plugins {
+		if section, ok := raw.(map[string]interface{}); ok {
+			sections[name] = section
+		}
+	}
+
+	pluginSectionsMu.Lock()
+	defer pluginSectionsMu.Unlock()
+	pluginSections = sections
+}
+
+// PluginScope returns the configuration a plugin may see: the shared
+// section overlaid with the plugin's own. The result is a copy, so the
+// plugin cannot change what others receive.
+func PluginScope(name string) map[string]interface{} {
+	pluginSectionsMu.RLock()
+	defer pluginSectionsMu.RUnlock()
+	return scopeOf(pluginSections, name)
+}
+
+// scopeOf overlays a plugin's section on the shared one
+func scopeOf(sections map[string]map[string]interface{}, name string) map[string]interface{} {
+	scope := make(map[string]interface{})
+	if name == SharedSection {
+		return scope
+	}
+	for key, value := range sections[SharedSection] {
+		scope[key] = deepCopy(value)
+	}
+	for key, value := range sections[name] {
+		scope[key] = deepCopy(value)
+	}
+	return scope
+}
+
+// deepCopy copies the maps and lists of a decoded YAML value
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// Flatten turns a plugin scope into the string map runtime plugins are
+// configured with. Nested keys are joined with dots; lists are JSON.
+func Flatten(scope map[string]interface{}) map[string]string {
+	flat := make(map[string]string)
+	flattenInto(flat, "", scope)
+	return flat
+}
+
+func flattenInto(flat map[string]string, prefix string, scope map[string]interface{}) {
+	keys := make([]string, 0, len(scope))
+	for key := range scope {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		switch v := scope[key].(type) {
+		case map[string]interface{}:
+			flattenInto(flat, name, v)
+		case []interface{}:
+			data, err := json.Marshal(v)
+			if err != nil {
+				data = []byte(fmt.Sprint(v))
+			}
+			flat[name] = string(data)
+		case nil:
+			flat[name] = ""
+		default:
+			flat[name] = fmt.Sprint(v)
+		}
+	}
+}
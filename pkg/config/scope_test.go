@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginScope(t *testing.T) {
+	t.Cleanup(func() { SetPluginSections(nil) })
+	SetPluginSections(map[string]interface{}{
+		SharedSection: map[string]interface{}{"region": "eu-west-1", "tags": []interface{}{"team-a"}},
+		"deploy":      map[string]interface{}{"api_key": "deploy-secret", "region": "us-east-1"},
+		"billing":     map[string]interface{}{"api_key": "billing-secret"},
+		"broken":      "not a map",
+	})
+
+	deploy := PluginScope("deploy")
+	assert.Equal(t, map[string]interface{}{
+		"api_key": "deploy-secret",
+		"region":  "us-east-1",
+		"tags":    []interface{}{"team-a"},
+	}, deploy)
+
+	billing := PluginScope("billing")
+	assert.Equal(t, "billing-secret", billing["api_key"], "a plugin only sees its own keys")
+	assert.Equal(t, "eu-west-1", billing["region"])
+
+	assert.Equal(t, map[string]interface{}{"region": "eu-west-1", "tags": []interface{}{"team-a"}}, PluginScope("unconfigured"))
+	assert.Empty(t, PluginScope(SharedSection))
+
+	// Scopes are copies
+	deploy["tags"].([]interface{})[0] = "changed"
+	assert.Equal(t, []interface{}{"team-a"}, PluginScope("billing")["tags"])
+}
+
+func TestFlatten(t *testing.T) {
+	flat := Flatten(map[string]interface{}{
+		"api_key": "secret",
+		"retries": 3,
+		"aws":     map[string]interface{}{"region": "eu-west-1", "profile": nil},
+		"tags":    []interface{}{"a", "b"},
+	})
+	assert.Equal(t, map[string]string{
+		"api_key":     "secret",
+		"retries":     "3",
+		"aws.region":  "eu-west-1",
+		"aws.profile": "",
+		"tags":        `["a","b"]`,
+	}, flat)
+}
+
+func TestRegister_SharedSectionReserved(t *testing.T) {
+	err := Register(SharedSection, struct{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reserved")
+}
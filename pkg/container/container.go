@@ -41,6 +41,9 @@ type Container struct {
 //   - ShellExecutor (from internal/shell)
 //   - PluginRegistry (from pkg/plugin)
 //
+// Services of registered plugins implementing plugin.PluginServiceProvider
+// start and stop with the container.
+//
 // Options can be used to override default providers for testing.
 //
 // Example:
@@ -82,6 +85,9 @@ func New(opts ...fx.Option) (*Container, error) {
 			// Lifecycle hooks - defined in lifecycle.go
 			fx.Invoke(registerLifecycleHooks),
 
+			// Plugin services - defined in services.go
+			fx.Invoke(registerPluginServices),
+
 			// Use NopLogger to suppress fx debug output by default
 			fx.NopLogger,
 		},
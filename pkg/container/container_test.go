@@ -10,6 +10,8 @@ import (
 
 	"github.com/glide-cli/glide/v3/internal/config"
 	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/plugin"
+	"github.com/glide-cli/glide/v3/pkg/plugin/plugintest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -206,3 +208,103 @@ func TestOptions_WithConfig(t *testing.T) {
 	// Config injection verified by successful start
 	require.NotNil(t, c)
 }
+
+// recordingService records when it starts and stops
+type recordingService struct {
+	name     string
+	events   *[]string
+	startErr error
+}
+
+func (s *recordingService) Name() string { return s.name }
+
+func (s *recordingService) Start(ctx context.Context) error {
+	if s.startErr != nil {
+		return s.startErr
+	}
+	*s.events = append(*s.events, "start "+s.name)
+	return nil
+}
+
+func (s *recordingService) Stop(ctx context.Context) error {
+	*s.events = append(*s.events, "stop "+s.name)
+	return nil
+}
+
+// servicePlugin runs its services with the container
+type servicePlugin struct {
+	*plugintest.MockPlugin
+	services []plugin.Service
+	err      error
+}
+
+func (p *servicePlugin) RegisterServices(host plugin.ServiceHost) error {
+	for _, svc := range p.services {
+		host.RunService(svc)
+	}
+	return p.err
+}
+
+func newServiceRegistry(t *testing.T, p *servicePlugin) *plugin.Registry {
+	t.Helper()
+	registry := plugin.NewRegistry()
+	require.NoError(t, registry.RegisterPlugin(p))
+	return registry
+}
+
+func TestPluginServices_Lifecycle(t *testing.T) {
+	var events []string
+	p := &servicePlugin{
+		MockPlugin: plugintest.NewMockPlugin("watcher"),
+		services: []plugin.Service{
+			&recordingService{name: "file-watcher", events: &events},
+			&recordingService{name: "cache-warmer", events: &events},
+		},
+	}
+
+	c, err := New(WithPluginRegistry(newServiceRegistry(t, p)))
+	require.NoError(t, err)
+
+	err = c.Run(context.Background(), func() error {
+		events = append(events, "run")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"start file-watcher",
+		"start cache-warmer",
+		"run",
+		"stop cache-warmer",
+		"stop file-watcher",
+	}, events)
+}
+
+func TestPluginServices_StartFailure(t *testing.T) {
+	var events []string
+	p := &servicePlugin{
+		MockPlugin: plugintest.NewMockPlugin("watcher"),
+		services: []plugin.Service{
+			&recordingService{name: "file-watcher", events: &events},
+			&recordingService{name: "cache-warmer", events: &events, startErr: errors.New("cache unreachable")},
+		},
+	}
+
+	c, err := New(WithPluginRegistry(newServiceRegistry(t, p)))
+	require.NoError(t, err)
+
+	err = c.Start(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "plugin watcher service cache-warmer failed to start")
+	require.Equal(t, []string{"start file-watcher", "stop file-watcher"}, events, "started services are stopped again")
+}
+
+func TestPluginServices_RegisterFailure(t *testing.T) {
+	p := &servicePlugin{
+		MockPlugin: plugintest.NewMockPlugin("watcher"),
+		err:        errors.New("no watch directory"),
+	}
+
+	_, err := New(WithPluginRegistry(newServiceRegistry(t, p)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "plugin watcher failed to register services")
+}
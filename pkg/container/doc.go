@@ -49,5 +49,15 @@
 //	    return nil
 //	})
 //
+// # Plugin Services
+//
+// Plugins in the container's registry that implement
+// plugin.PluginServiceProvider hand it long-lived services, such as file
+// watchers, through plugin.ServiceHost. Each service starts with the
+// container and stops, in reverse order, when it shuts down:
+//
+//	c, _ := container.New(container.WithPluginRegistry(plugin.GetGlobalRegistry()))
+//	c.Run(ctx, run) // services run while run does
+//
 // See docs/adr/ADR-013-dependency-injection.md for design rationale.
 package container
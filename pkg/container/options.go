@@ -6,6 +6,7 @@ import (
 	"github.com/glide-cli/glide/v3/internal/config"
 	"github.com/glide-cli/glide/v3/internal/context"
 	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/plugin"
 	"go.uber.org/fx"
 )
 
@@ -70,6 +71,18 @@ func WithProjectContext(ctx *context.ProjectContext) Option {
 	})
 }
 
+// WithPluginRegistry overrides the plugin registry provider.
+//
+// The plugins registered in it when the container is created can run
+// services with the container's lifecycle.
+//
+// Example:
+//
+//	c, _ := container.New(container.WithPluginRegistry(plugin.GetGlobalRegistry()))
+func WithPluginRegistry(registry *plugin.Registry) Option {
+	return fx.Replace(registry)
+}
+
 // WithoutLifecycle disables lifecycle hooks for faster tests.
 //
 // This prevents OnStart and OnStop hooks from executing,
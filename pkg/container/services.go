@@ -0,0 +1,69 @@
+package container
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	"github.com/glide-cli/glide/v3/pkg/plugin"
+	"go.uber.org/fx"
+)
+
+// PluginServicesParams groups dependencies for registering plugin services.
+type PluginServicesParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Registry  *plugin.Registry
+	Logger    *logging.Logger
+}
+
+// registerPluginServices ties the services of registered plugins to the
+// container's lifecycle.
+//
+// Each plugin implementing plugin.PluginServiceProvider registers its
+// services when the container is created. They start with the container
+// and stop, in reverse order, when it stops. A plugin failing to register
+// fails the container; a service failing to start fails Start, which stops
+// the services already started.
+func registerPluginServices(params PluginServicesParams) error {
+	for _, p := range params.Registry.List() {
+		provider, ok := p.(plugin.PluginServiceProvider)
+		if !ok {
+			continue
+		}
+		host := &serviceHost{plugin: p.Name(), lifecycle: params.Lifecycle, logger: params.Logger}
+		if err := provider.RegisterServices(host); err != nil {
+			return fmt.Errorf("plugin %s failed to register services: %w", p.Name(), err)
+		}
+	}
+	return nil
+}
+
+// serviceHost implements plugin.ServiceHost for one plugin by appending
+// lifecycle hooks.
+type serviceHost struct {
+	plugin    string
+	lifecycle fx.Lifecycle
+	logger    *logging.Logger
+}
+
+// RunService implements plugin.ServiceHost.
+func (h *serviceHost) RunService(svc plugin.Service) {
+	h.lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			h.logger.Debug("Starting plugin service", "plugin", h.plugin, "service", svc.Name())
+			if err := svc.Start(ctx); err != nil {
+				return fmt.Errorf("plugin %s service %s failed to start: %w", h.plugin, svc.Name(), err)
+			}
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			h.logger.Debug("Stopping plugin service", "plugin", h.plugin, "service", svc.Name())
+			if err := svc.Stop(ctx); err != nil {
+				return fmt.Errorf("plugin %s service %s failed to stop: %w", h.plugin, svc.Name(), err)
+			}
+			return nil
+		},
+	})
+}
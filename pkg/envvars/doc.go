@@ -0,0 +1,22 @@
+// Package envvars is the registry of the GLIDE_* environment variables that
+// change glide's behavior.
+//
+// Core lists the variables glide itself reads. Build-time plugins declare
+// their own from init, so that `glide env vars` documents them and they are
+// not reported as unknown:
+//
+//	func init() {
+//	    _ = envvars.Register(envvars.Var{
+//	        Name:        "GLIDE_ACME_TOKEN",
+//	        Description: "API token for the Acme deploy service",
+//	        Source:      "acme",
+//	        Secret:      true,
+//	    })
+//	}
+//
+// Set variables that nothing declared are usually typos:
+//
+//	for _, name := range envvars.Unknown(os.Environ()) {
+//	    fmt.Println("unknown:", name)
+//	}
+package envvars
@@ -0,0 +1,175 @@
+package envvars
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Prefix is the prefix of every environment variable glide reads
+const Prefix = "GLIDE_"
+
+// SourceCore marks variables read by glide itself
+const SourceCore = "core"
+
+// Var describes an environment variable that changes glide's behavior
+type Var struct {
+	// Name is the variable, e.g. "GLIDE_LOG_LEVEL"
+	Name string `json:"name" yaml:"name"`
+
+	// Description explains what the variable does and what it accepts
+	Description string `json:"description" yaml:"description"`
+
+	// Default is the behavior when the variable is unset
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+
+	// Source is SourceCore or the name of the plugin that declared it
+	Source string `json:"source" yaml:"source"`
+
+	// Secret hides the value when listing variables
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
+
+	// Internal marks variables glide sets for its child processes
+	Internal bool `json:"internal,omitempty" yaml:"internal,omitempty"`
+}
+
+// Core lists the variables glide itself reads
+var Core = []Var{
+	{Name: "GLIDE_DEBUG", Description: "Enable debug logging, like --debug"},
+	{Name: "GLIDE_LOG_LEVEL", Description: "Minimum log level: debug, info, warn, or error", Default: "warn"},
+	{Name: "GLIDE_LOG_FORMAT", Description: "Log format: text or json", Default: "text"},
+	{Name: "GLIDE_LOG_SOURCE", Description: "Include the source location in log lines", Default: "false"},
+	{Name: "GLIDE_HELP_DEBUG", Description: "Log how help decides which commands to show"},
+	{Name: "GLIDE_COLORS", Description: "Color output: auto, always, or never", Default: "auto"},
+	{Name: "GLIDE_COLOR_DEPTH", Description: "Colors the terminal can show: none, 16, 256, or truecolor", Default: "detected from the terminal"},
+	{Name: "GLIDE_ASCII_ICONS", Description: "Use ASCII instead of emoji icons and spinners"},
+	{Name: "GLIDE_ACCESSIBLE", Description: "Screen-reader-friendly output, like --accessible"},
+	{Name: "GLIDE_HYPERLINKS", Description: "Show paths and URLs as clickable terminal hyperlinks (1) or plain text (0)", Default: "detected from the terminal"},
+	{Name: "GLIDE_PAGER", Description: "Pager for long output such as release notes; cat disables paging", Default: "PAGER, then less -R"},
+	{Name: "GLIDE_CHAOS", Description: "Inject failures for testing error handling: docker-down, plugin-timeout, slow-disk, config-corrupt"},
+	{Name: "GLIDE_PERF_WARN", Description: "Warn when key operations exceed their performance budgets"},
+	{Name: "GLIDE_REPORT_FILE", Description: "Write a JSON report of every run to this file, like --report-file"},
+	{Name: "GLIDE_NO_ONBOARDING", Description: "Skip the first-run questions, e.g. in provisioning scripts"},
+	{Name: "GLIDE_NO_UPDATE_CHECK", Description: "Disable the background check for new releases"},
+	{Name: "GLIDE_SANDBOX", Description: "Run the commands of untrusted projects in a container without network (1) or refuse them (0)", Default: "defaults.sandbox.untrusted"},
+	{Name: "GLIDE_TRUST_ALL", Description: "Trust every project's .glide.yml without asking, e.g. on CI"},
+	{Name: "GLIDE_AUDIT_LOG", Description: "Location of the audit log of destructive commands", Default: "~/.glide/audit.log"},
+	{Name: "GLIDE_YAML_SANITIZE_MODE", Description: "Validation of YAML commands: script, strict, warn, or disabled (unsafe)", Default: "script"},
+	{Name: "GLIDE_TEST_PARALLEL", Description: "Override defaults.test.parallel"},
+	{Name: "GLIDE_TEST_PROCESSES", Description: "Override defaults.test.processes"},
+	{Name: "GLIDE_TEST_COVERAGE", Description: "Override defaults.test.coverage"},
+	{Name: "GLIDE_DOCKER_TIMEOUT", Description: "Override defaults.docker.compose_timeout, in seconds"},
+	{Name: "GLIDE_DOCKER_AUTO_START", Description: "Override defaults.docker.auto_start"},
+	{Name: "GLIDE_WORKTREE_AUTO_SETUP", Description: "Override defaults.worktree.auto_setup"},
+	{Name: "GLIDE_PLUGIN_DEBUG", Description: "Print runtime plugin logs to the terminal"},
+	{Name: "GLIDE_PLUGIN_TRACE", Description: "Print runtime plugin logs, including trace messages, to the terminal"},
+	{Name: "GLIDE_PLUGIN_TIMEOUT", Description: "How long a runtime plugin may take to answer calls such as listing its commands", Default: "10s"},
+	{Name: "GLIDE_PLUGIN_EXECUTE_TIMEOUT", Description: "How long a non-interactive plugin command may run", Default: "no limit"},
+	{Name: "GLIDE_PLUGIN_COMPLETE_TIMEOUT", Description: "How long a plugin may take to supply shell completions", Default: "2s"},
+	{Name: "GLIDE_PLUGIN_MAX_RESPONSE", Description: "Largest single response a runtime plugin may send, e.g. 16MB", Default: "4MB"},
+	{Name: "GLIDE_PLUGIN_MAX_OUTPUT", Description: "Most output a plugin command may print before the rest is dropped; 0 for no limit", Default: "64MB"},
+	{Name: "GLIDE_PLUGIN_MIRROR", Description: "Directory, file:// URL, or web server to install plugins from instead of their registries", Default: "plugin_mirror"},
+	{Name: "GLIDE_PLUGIN_INDEX", Description: "https:// URL of the marketplace index plugin names are installed from", Default: "plugin_index"},
+	{Name: "GLIDE_ARTIFACT_CACHE", Description: "Directory of the cache of downloaded plugins and updates, e.g. on a shared filesystem", Default: "~/.glide/cache/artifacts"},
+	{Name: "GLIDE_ARTIFACT_CACHE_MAX", Description: "Size the artifact cache is pruned to, e.g. 5GB; 0 turns caching off", Default: "1GB"},
+	{Name: "GLIDE_PLUGIN_MAX_RATE", Description: "Bytes per second a plugin may stream, e.g. 1MB; faster plugins are slowed down", Default: "no limit"},
+	{Name: "GLIDE_FEATURES", Description: "Feature flags to turn on for this run, comma-separated; a leading - turns one off, e.g. tui,-daemon"},
+	{Name: "GLIDE_PROMPT_ANSWERS", Description: "YAML list of answers to give prompts in order, for scripted runs"},
+	{Name: "GLIDE_PROMPT_COMMAND", Description: "Program that shows each prompt instead of the terminal"},
+	{Name: "GLIDE_PROMPT_REQUEST", Description: "The prompt request passed to GLIDE_PROMPT_COMMAND", Internal: true},
+	{Name: "GLIDE_INVOCATION_ID", Description: "ID of the glide run that started this process; nested runs log it as their parent", Internal: true},
+	{Name: "GLIDE_PLUGIN_MAGIC", Description: "Handshake cookie passed to runtime plugins", Internal: true, Secret: true},
+}
+
+// registry holds every declared variable by name
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Var)
+)
+
+func init() {
+	for _, v := range Core {
+		v.Source = SourceCore
+		registry[v.Name] = v
+	}
+}
+
+// Register declares an environment variable a plugin reads, so that it is
+// documented by `glide env vars` and not reported as unknown. Plugins call
+// it from init with their own name as Source.
+func Register(v Var) error {
+	if !strings.HasPrefix(v.Name, Prefix) {
+		return fmt.Errorf("environment variable %s must start with %s", v.Name, Prefix)
+	}
+	if v.Source == "" {
+		return fmt.Errorf("environment variable %s has no source", v.Name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if existing, ok := registry[v.Name]; ok && existing.Source != v.Source {
+		return fmt.Errorf("environment variable %s is already declared by %s", v.Name, existing.Source)
+	}
+	registry[v.Name] = v
+	return nil
+}
+
+// Unregister removes a variable a plugin declared
+func Unregister(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if v, ok := registry[name]; ok && v.Source != SourceCore {
+		delete(registry, name)
+	}
+}
+
+// Lookup returns a declared variable
+func Lookup(name string) (Var, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	v, ok := registry[name]
+	return v, ok
+}
+
+// List returns every declared variable sorted by name
+func List() []Var {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	vars := make([]Var, 0, len(registry))
+	for _, v := range registry {
+		vars = append(vars, v)
+	}
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars
+}
+
+// Value returns the current value of a variable, masking secrets, and
+// whether it is set
+func Value(v Var) (string, bool) {
+	value, ok := os.LookupEnv(v.Name)
+	if ok && v.Secret && value != "" {
+		value = "********"
+	}
+	return value, ok
+}
+
+// Unknown returns the names of the GLIDE_ variables in environ, in the
+// KEY=value form of os.Environ, that nothing declared. These are usually
+// typos or variables a removed plugin read.
+func Unknown(environ []string) []string {
+	var unknown []string
+	for _, kv := range environ {
+		name, _, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(name, Prefix) {
+			continue
+		}
+		if _, ok := Lookup(name); !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
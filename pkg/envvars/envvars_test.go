@@ -0,0 +1,57 @@
+package envvars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(func() { Unregister("GLIDE_ACME_TOKEN") })
+
+	require.NoError(t, Register(Var{Name: "GLIDE_ACME_TOKEN", Source: "acme", Secret: true}))
+	v, ok := Lookup("GLIDE_ACME_TOKEN")
+	require.True(t, ok)
+	assert.Equal(t, "acme", v.Source)
+
+	assert.Error(t, Register(Var{Name: "ACME_TOKEN", Source: "acme"}), "needs the GLIDE_ prefix")
+	assert.Error(t, Register(Var{Name: "GLIDE_OTHER"}), "needs a source")
+	assert.ErrorContains(t, Register(Var{Name: "GLIDE_DEBUG", Source: "acme"}), "already declared by core")
+	assert.ErrorContains(t, Register(Var{Name: "GLIDE_ACME_TOKEN", Source: "other"}), "already declared by acme")
+
+	Unregister("GLIDE_DEBUG")
+	_, ok = Lookup("GLIDE_DEBUG")
+	assert.True(t, ok, "core variables stay declared")
+}
+
+func TestList(t *testing.T) {
+	vars := List()
+	assert.Len(t, vars, len(Core))
+	for i := 1; i < len(vars); i++ {
+		assert.Less(t, vars[i-1].Name, vars[i].Name)
+	}
+	for _, v := range vars {
+		assert.Equal(t, SourceCore, v.Source)
+		assert.NotEmpty(t, v.Description, v.Name)
+	}
+}
+
+func TestValue(t *testing.T) {
+	t.Setenv("GLIDE_LOG_LEVEL", "debug")
+	t.Setenv("GLIDE_PLUGIN_MAGIC", "cookie")
+
+	level, _ := Lookup("GLIDE_LOG_LEVEL")
+	value, set := Value(level)
+	assert.True(t, set)
+	assert.Equal(t, "debug", value)
+
+	magic, _ := Lookup("GLIDE_PLUGIN_MAGIC")
+	value, _ = Value(magic)
+	assert.Equal(t, "********", value)
+}
+
+func TestUnknown(t *testing.T) {
+	environ := []string{"GLIDE_LOG_LEVEL=debug", "GLIDE_LOG_LEVLE=debug", "HOME=/home/me", "GLIDE_HOME=/tmp", "GLIDEX=1"}
+	assert.Equal(t, []string{"GLIDE_HOME", "GLIDE_LOG_LEVLE"}, Unknown(environ))
+}
@@ -43,6 +43,18 @@
 //	        "See documentation for valid values",
 //	    ))
 //
+// # Operation Context
+//
+// WrapWithOp records the operation that failed, its context, and the call
+// site's stack, keeping the wrapped error's type and suggestions:
+//
+//	if err != nil {
+//	    return errors.WrapWithOp(err, "loading compose file", errors.WithPath(file))
+//	}
+//
+// A Handler with Debug set (glide --debug) shows the operation chain and a
+// condensed stack of glide frames.
+//
 // # Error Handling
 //
 // Use the Handler for consistent error display:
@@ -51,6 +63,18 @@
 //	exitCode := handler.Handle(err)
 //	os.Exit(exitCode)
 //
+// # Suggestion Providers
+//
+// Providers add context-aware suggestions at display time, keyed on error type:
+//
+//	errors.RegisterSuggestionProvider(errors.TypeContainer,
+//	    errors.SuggestionProviderFunc(func(err *errors.GlideError) []string {
+//	        container, _ := err.GetContext("container")
+//	        return []string{fmt.Sprintf("Start it with: glide up %s", container)}
+//	    }))
+//
+// Provider suggestions are shown before the error's own suggestions.
+//
 // # Exit Codes
 //
 // Standard exit codes are used for different error types:
@@ -58,4 +82,9 @@
 //   - 125: Docker errors
 //   - 126: Permission errors
 //   - 127: File not found / dependency errors
+//
+// A Handler's ExitCodes override these per error type, e.g. from
+// --exit-code-map docker=2,validation=3:
+//
+//	handler.ExitCodes, err = errors.ParseExitCodeMap("docker=2,validation=3")
 package errors
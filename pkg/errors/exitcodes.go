@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// knownTypes are the error types an ExitCodeMap accepts
+var knownTypes = []ErrorType{
+	TypeDocker, TypeContainer,
+	TypePermission, TypeFileNotFound,
+	TypeDependency, TypeMissing,
+	TypeConfig, TypeInvalid,
+	TypeNetwork, TypeConnection,
+	TypeMode, TypeWrongMode,
+	TypeDatabase,
+	TypeCommand, TypeTimeout, TypeRuntime, TypeUnknown,
+}
+
+// typeAliases are shorter or more familiar names for error types
+var typeAliases = map[string]ErrorType{
+	"config":     TypeConfig,
+	"validation": TypeInvalid,
+	"not_found":  TypeFileNotFound,
+}
+
+// ExitCodeMap overrides the exit codes of error types, so wrapper scripts
+// can tell failure classes apart without parsing messages
+type ExitCodeMap map[ErrorType]int
+
+// ParseExitCodeMap parses a comma-separated list of type=code entries, e.g.
+// "docker=2,validation=3"
+func ParseExitCodeMap(spec string) (ExitCodeMap, error) {
+	m := ExitCodeMap{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		code, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || err != nil {
+			return nil, New(TypeInvalid, fmt.Sprintf("invalid exit code mapping %q", entry),
+				WithExitCode(2),
+				WithSuggestions("Use type=code entries, e.g. --exit-code-map docker=2,validation=3"))
+		}
+		if err := m.Set(strings.TrimSpace(name), code); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Set maps the error type with the name, or one of its aliases, to code
+func (m ExitCodeMap) Set(name string, code int) error {
+	errType, ok := lookupType(name)
+	if !ok {
+		return New(TypeInvalid, fmt.Sprintf("unknown error type %q", name),
+			WithExitCode(2),
+			WithSuggestions("Known error types: "+strings.Join(typeNames(), ", ")))
+	}
+	if code < 1 || code > 255 {
+		return New(TypeInvalid, fmt.Sprintf("exit code %d for %s is out of range", code, name),
+			WithExitCode(2),
+			WithSuggestions("Use an exit code from 1 to 255"))
+	}
+	m[errType] = code
+	return nil
+}
+
+// Merge returns the mappings of m overridden by those of other
+func (m ExitCodeMap) Merge(other ExitCodeMap) ExitCodeMap {
+	merged := ExitCodeMap{}
+	for errType, code := range m {
+		merged[errType] = code
+	}
+	for errType, code := range other {
+		merged[errType] = code
+	}
+	return merged
+}
+
+// lookupType resolves an error type name or alias
+func lookupType(name string) (ErrorType, bool) {
+	name = strings.ToLower(name)
+	if errType, ok := typeAliases[name]; ok {
+		return errType, true
+	}
+	for _, errType := range knownTypes {
+		if string(errType) == name {
+			return errType, true
+		}
+	}
+	return "", false
+}
+
+// typeNames returns the sorted names of the known error types
+func typeNames() []string {
+	names := make([]string, 0, len(knownTypes))
+	for _, errType := range knownTypes {
+		names = append(names, string(errType))
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExitCodeMap(t *testing.T) {
+	codes, err := ParseExitCodeMap("docker=2, validation=3,,timeout=124")
+	require.NoError(t, err)
+	assert.Equal(t, ExitCodeMap{TypeDocker: 2, TypeInvalid: 3, TypeTimeout: 124}, codes)
+
+	for _, spec := range []string{"docker", "docker=two", "dokcer=2", "docker=0", "docker=256"} {
+		_, err := ParseExitCodeMap(spec)
+		assert.Error(t, err, spec)
+		assert.True(t, Is(err, TypeInvalid), spec)
+	}
+}
+
+func TestExitCodeMap_Merge(t *testing.T) {
+	configured := ExitCodeMap{TypeDocker: 2, TypeConfig: 4}
+	merged := configured.Merge(ExitCodeMap{TypeDocker: 5})
+
+	assert.Equal(t, ExitCodeMap{TypeDocker: 5, TypeConfig: 4}, merged)
+	assert.Equal(t, 2, configured[TypeDocker], "merging leaves the original alone")
+}
+
+func TestHandler_ExitCodeMap(t *testing.T) {
+	handler := &Handler{
+		Writer:    &bytes.Buffer{},
+		NoColor:   true,
+		ExitCodes: ExitCodeMap{TypeDocker: 2, TypeUnknown: 9},
+	}
+
+	assert.Equal(t, 2, handler.Handle(NewDockerError("daemon not running")))
+	assert.Equal(t, 126, handler.Handle(NewPermissionError("/etc/hosts", "cannot write")), "unmapped types keep their code")
+	assert.Equal(t, 9, handler.Handle(fmt.Errorf("plain error")))
+}
@@ -15,6 +15,12 @@ type Handler struct {
 	Verbose     bool
 	NoColor     bool
 	ShowContext bool
+	// Debug shows the operations an error passed through and a condensed
+	// stack of where it was wrapped, e.g. for --debug
+	Debug bool
+	// ExitCodes overrides the exit codes of error types, e.g. for
+	// --exit-code-map
+	ExitCodes ExitCodeMap
 }
 
 // DefaultHandler creates a handler with default settings
@@ -38,23 +44,34 @@ func (h *Handler) Handle(err error) int {
 	if !ok {
 		// Handle as generic error
 		h.displayGenericError(err)
+		if code, ok := h.ExitCodes[TypeUnknown]; ok {
+			return code
+		}
 		return 1
 	}
 
 	// Display the error
 	h.displayError(glideErr)
 
-	// Display suggestions if available
-	if glideErr.HasSuggestions() {
-		h.displaySuggestions(glideErr.Suggestions)
+	// Context-aware suggestions are more specific, so they come first
+	suggestions := uniqueStrings(append(ProviderSuggestions(glideErr), glideErr.Suggestions...))
+	if len(suggestions) > 0 {
+		h.displaySuggestions(suggestions)
 	}
 
 	// Display context if verbose mode
-	if h.Verbose && len(glideErr.Context) > 0 {
+	if (h.Verbose || h.Debug) && len(glideErr.Context) > 0 {
 		h.displayContext(glideErr.Context)
 	}
 
+	if h.Debug {
+		h.displayProvenance(err)
+	}
+
 	// Return the appropriate exit code
+	if code, ok := h.ExitCodes[glideErr.Type]; ok {
+		return code
+	}
 	if glideErr.Code > 0 {
 		return glideErr.Code
 	}
@@ -76,14 +93,19 @@ func (h *Handler) displayError(err *GlideError) {
 		fmt.Fprintf(&msg, "%s %s: ", icon, color.RedString(typeStr))
 	}
 
-	// Error message
-	msg.WriteString(err.Message)
+	// Error message; an operation alone does not say what went wrong, so
+	// operation errors show their cause too
+	if err.Op != "" {
+		msg.WriteString(err.Error())
+	} else {
+		msg.WriteString(err.Message)
+	}
 
 	// Write to output
 	fmt.Fprintln(h.Writer, msg.String())
 
 	// If there's an underlying error and we're in verbose mode, show it
-	if h.Verbose && err.Err != nil {
+	if h.Verbose && err.Err != nil && err.Op == "" {
 		if h.NoColor {
 			fmt.Fprintf(h.Writer, "  Underlying error: %v\n", err.Err)
 		} else {
@@ -160,6 +182,32 @@ func (h *Handler) displayContext(context map[string]string) {
 	}
 }
 
+// displayProvenance shows the operations an error passed through and where
+// it was wrapped
+func (h *Handler) displayProvenance(err error) {
+	label := func(s string) string {
+		if h.NoColor {
+			return s
+		}
+		return color.HiBlackString(s)
+	}
+
+	if ops := Ops(err); len(ops) > 0 {
+		fmt.Fprintln(h.Writer)
+		fmt.Fprintf(h.Writer, "%s %s\n", label("Operation:"), strings.Join(ops, " → "))
+	}
+
+	stack := CondensedStack(StackOf(err))
+	if len(stack) == 0 {
+		return
+	}
+	fmt.Fprintln(h.Writer)
+	fmt.Fprintln(h.Writer, label("Stack:"))
+	for _, frame := range stack {
+		fmt.Fprintf(h.Writer, "  %s\n", frame)
+	}
+}
+
 // getErrorIcon returns an appropriate icon for the error type
 func (h *Handler) getErrorIcon(errType ErrorType) string {
 	switch errType {
@@ -0,0 +1,138 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	// maxStackDepth is the number of frames recorded by WrapWithOp
+	maxStackDepth = 32
+
+	// condensedStackFrames is the number of frames shown by CondensedStack
+	condensedStackFrames = 8
+
+	// modulePath prefixes the functions kept in a condensed stack
+	modulePath = "github.com/glide-cli/glide/"
+)
+
+// Frame is one call site in an error's stack
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// String formats the frame as "function (file:line)"
+func (f Frame) String() string {
+	return fmt.Sprintf("%s (%s:%d)", f.Function, filepath.Base(f.File), f.Line)
+}
+
+// WrapWithOp wraps err with the operation that was in progress, so deep
+// docker and plugin errors keep their provenance:
+//
+//	return errors.WrapWithOp(err, "loading compose file", errors.WithPath(file))
+//
+// The error keeps the type, exit code, and suggestions of a wrapped
+// GlideError, adds the options as context, and records the call site's
+// stack unless the wrapped error already has one closer to the failure.
+func WrapWithOp(err error, op string, opts ...ErrorOption) *GlideError {
+	if err == nil {
+		return nil
+	}
+
+	wrapped := &GlideError{
+		Type:    TypeUnknown,
+		Message: op,
+		Err:     err,
+		Op:      op,
+	}
+
+	var inner *GlideError
+	if errors.As(err, &inner) {
+		wrapped.Type = inner.Type
+		wrapped.Code = inner.Code
+		wrapped.Suggestions = append([]string(nil), inner.Suggestions...)
+		for key, value := range inner.Context {
+			wrapped.AddContext(key, value)
+		}
+	}
+	if StackOf(err) == nil {
+		wrapped.Stack = callers(3)
+	}
+
+	for _, opt := range opts {
+		opt(wrapped)
+	}
+	return wrapped
+}
+
+// WithPath records the file path an operation was working on
+func WithPath(path string) ErrorOption {
+	return WithContext("path", path)
+}
+
+// Ops returns the operations an error was wrapped with, outermost first
+func Ops(err error) []string {
+	var ops []string
+	for err != nil {
+		if glideErr, ok := err.(*GlideError); ok && glideErr.Op != "" {
+			ops = append(ops, glideErr.Op)
+		}
+		err = errors.Unwrap(err)
+	}
+	return ops
+}
+
+// StackOf returns the stack recorded closest to where err happened, or nil
+func StackOf(err error) []Frame {
+	var stack []Frame
+	for err != nil {
+		if glideErr, ok := err.(*GlideError); ok && len(glideErr.Stack) > 0 {
+			stack = glideErr.Stack
+		}
+		err = errors.Unwrap(err)
+	}
+	return stack
+}
+
+// CondensedStack returns the first frames of a stack that are inside glide,
+// with the module path trimmed from their function names
+func CondensedStack(stack []Frame) []Frame {
+	var condensed []Frame
+	for _, frame := range stack {
+		if !strings.HasPrefix(frame.Function, modulePath) {
+			continue
+		}
+		function := strings.TrimPrefix(frame.Function, modulePath)
+		// Drop the major version directory, e.g. "v3/"
+		if i := strings.Index(function, "/"); i > 0 && function[0] == 'v' {
+			function = function[i+1:]
+		}
+		condensed = append(condensed, Frame{Function: function, File: frame.File, Line: frame.Line})
+		if len(condensed) == condensedStackFrames {
+			break
+		}
+	}
+	return condensed
+}
+
+// callers records the stack, skipping skip frames
+func callers(skip int) []Frame {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []Frame
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		if !more {
+			break
+		}
+	}
+	return stack
+}
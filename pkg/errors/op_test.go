@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadComposeFile() error {
+	cause := New(TypeConfig, "invalid compose file", WithSuggestions("Check the YAML syntax"))
+	return WrapWithOp(cause, "loading compose file", WithPath("docker-compose.yml"))
+}
+
+func TestWrapWithOp(t *testing.T) {
+	assert.Nil(t, WrapWithOp(nil, "loading compose file"))
+
+	err := WrapWithOp(loadComposeFile(), "starting services")
+
+	assert.Equal(t, TypeConfig, err.Type, "the wrapped error's type is kept")
+	assert.Equal(t, []string{"Check the YAML syntax"}, err.Suggestions)
+	path, ok := err.GetContext("path")
+	assert.True(t, ok)
+	assert.Equal(t, "docker-compose.yml", path)
+	assert.Equal(t, "starting services: loading compose file: invalid compose file", err.Error())
+	assert.Equal(t, []string{"starting services", "loading compose file"}, Ops(err))
+	assert.Empty(t, err.Stack, "the inner stack is closer to the failure")
+
+	stack := StackOf(err)
+	require.NotEmpty(t, stack)
+	assert.Equal(t, "github.com/glide-cli/glide/v3/pkg/errors.loadComposeFile", stack[0].Function)
+}
+
+func TestWrapWithOp_GenericError(t *testing.T) {
+	err := WrapWithOp(fmt.Errorf("permission denied"), "reading plugin manifest")
+
+	assert.Equal(t, TypeUnknown, err.Type)
+	assert.Equal(t, "reading plugin manifest: permission denied", err.Error())
+	assert.NotEmpty(t, err.Stack)
+}
+
+func TestCondensedStack(t *testing.T) {
+	stack := []Frame{
+		{Function: "runtime.goexit", File: "/go/src/runtime/asm.s", Line: 1},
+		{Function: "github.com/glide-cli/glide/v3/internal/docker.LoadOverride", File: "/src/internal/docker/override.go", Line: 203},
+		{Function: "github.com/spf13/cobra.(*Command).execute", File: "/mod/cobra/command.go", Line: 940},
+	}
+
+	condensed := CondensedStack(stack)
+	require.Len(t, condensed, 1)
+	assert.Equal(t, "internal/docker.LoadOverride (override.go:203)", condensed[0].String())
+}
+
+func TestHandler_Debug(t *testing.T) {
+	err := WrapWithOp(loadComposeFile(), "starting services")
+
+	buf := &bytes.Buffer{}
+	handler := &Handler{Writer: buf, NoColor: true}
+	handler.Handle(err)
+	assert.Contains(t, buf.String(), "starting services: loading compose file: invalid compose file")
+	assert.NotContains(t, buf.String(), "Stack:")
+
+	buf.Reset()
+	handler.Debug = true
+	handler.Handle(err)
+	output := buf.String()
+	assert.Contains(t, output, "Operation: starting services → loading compose file")
+	assert.Contains(t, output, "path: docker-compose.yml")
+	assert.Contains(t, output, "Stack:")
+	assert.Contains(t, output, "pkg/errors.loadComposeFile (op_test.go:")
+}
@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"sync"
+)
+
+// SuggestionProvider proposes concrete next steps for an error, typically by
+// consulting state the error itself does not carry (project layout, running
+// services, configuration).
+type SuggestionProvider interface {
+	Suggest(err *GlideError) []string
+}
+
+// SuggestionProviderFunc adapts a function to the SuggestionProvider interface
+type SuggestionProviderFunc func(err *GlideError) []string
+
+// Suggest implements SuggestionProvider
+func (f SuggestionProviderFunc) Suggest(err *GlideError) []string {
+	return f(err)
+}
+
+var (
+	providersMu         sync.RWMutex
+	suggestionProviders = make(map[ErrorType][]SuggestionProvider)
+)
+
+// RegisterSuggestionProvider adds a provider consulted by the Handler for
+// errors of the given type. Providers run in registration order.
+func RegisterSuggestionProvider(errType ErrorType, provider SuggestionProvider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	suggestionProviders[errType] = append(suggestionProviders[errType], provider)
+}
+
+// ClearSuggestionProviders removes all registered providers
+func ClearSuggestionProviders() {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	suggestionProviders = make(map[ErrorType][]SuggestionProvider)
+}
+
+// ProviderSuggestions collects suggestions from all providers registered for
+// the error's type
+func ProviderSuggestions(err *GlideError) []string {
+	if err == nil {
+		return nil
+	}
+
+	providersMu.RLock()
+	providers := append([]SuggestionProvider(nil), suggestionProviders[err.Type]...)
+	providersMu.RUnlock()
+
+	var suggestions []string
+	for _, provider := range providers {
+		suggestions = append(suggestions, provider.Suggest(err)...)
+	}
+	return suggestions
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderSuggestions_KeyedOnType(t *testing.T) {
+	ClearSuggestionProviders()
+	t.Cleanup(ClearSuggestionProviders)
+
+	RegisterSuggestionProvider(TypeContainer, SuggestionProviderFunc(func(err *GlideError) []string {
+		container, _ := err.GetContext("container")
+		return []string{"Service '" + container + "' is not running"}
+	}))
+
+	assert.Equal(t,
+		[]string{"Service 'db' is not running"},
+		ProviderSuggestions(NewContainerError("db", "connection refused")))
+	assert.Empty(t, ProviderSuggestions(NewDockerError("daemon not running")))
+	assert.Nil(t, ProviderSuggestions(nil))
+}
+
+func TestHandler_HandleWithProviders(t *testing.T) {
+	ClearSuggestionProviders()
+	t.Cleanup(ClearSuggestionProviders)
+
+	RegisterSuggestionProvider(TypeContainer, SuggestionProviderFunc(func(err *GlideError) []string {
+		return []string{"Service 'db' is not running — run `glide up db`", "Check Docker status"}
+	}))
+
+	buf := &bytes.Buffer{}
+	handler := &Handler{Writer: buf, NoColor: true}
+
+	err := NewContainerError("db", "connection refused", WithSuggestions("Check Docker status"))
+	handler.Handle(err)
+
+	out := buf.String()
+	assert.Contains(t, out, "Service 'db' is not running")
+	assert.Less(t, strings.Index(out, "Service 'db'"), strings.Index(out, "Check Docker status"),
+		"provider suggestions should come first")
+	assert.Equal(t, 1, strings.Count(out, "Check Docker status"), "duplicates should be removed")
+}
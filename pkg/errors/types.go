@@ -51,6 +51,8 @@ type GlideError struct {
 	Suggestions []string          // Helpful suggestions
 	Context     map[string]string // Additional context
 	Code        int               // Exit code
+	Op          string            // Operation in progress, see WrapWithOp
+	Stack       []Frame           // Call site stack, see WrapWithOp
 }
 
 // Error implements the error interface
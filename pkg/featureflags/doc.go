@@ -0,0 +1,25 @@
+// Package featureflags lets experimental subsystems ship dark: their code
+// is built in, but only runs for users who turn their flag on.
+//
+// Core lists glide's own flags. Build-time plugins declare theirs from init:
+//
+//	func init() {
+//	    _ = featureflags.Register(featureflags.Flag{
+//	        Name:        "acme-preview",
+//	        Description: "Deploy previews to Acme",
+//	        Source:      "acme",
+//	    })
+//	}
+//
+// Code behind a flag checks it before doing anything:
+//
+//	if featureflags.Enabled("daemon") {
+//	    startDaemon()
+//	}
+//
+// Users turn flags on with `glide features enable <name>`, which stores
+// them under features: in the global configuration, or per run with the
+// comma-separated GLIDE_FEATURES variable, where a leading - turns a flag
+// off, e.g. GLIDE_FEATURES=tui,-daemon. The variable wins over the
+// configuration, which wins over the flag's default.
+package featureflags
@@ -0,0 +1,185 @@
+package featureflags
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EnvVar lists the flags turned on, or off with a leading -, for one run
+const EnvVar = "GLIDE_FEATURES"
+
+// SourceCore marks flags declared by glide itself
+const SourceCore = "core"
+
+// Where a flag's state comes from
+const (
+	FromDefault = "default"
+	FromConfig  = "config"
+	FromEnv     = "env"
+)
+
+// Flag describes an experimental feature that is off unless turned on
+type Flag struct {
+	// Name identifies the flag, e.g. "daemon"
+	Name string `json:"name" yaml:"name"`
+
+	// Description explains what the flag turns on
+	Description string `json:"description" yaml:"description"`
+
+	// Source is SourceCore or the name of the plugin that declared it
+	Source string `json:"source" yaml:"source"`
+
+	// Default is the state when neither the configuration nor the
+	// environment set the flag
+	Default bool `json:"default" yaml:"default"`
+}
+
+// Core lists the flags glide itself declares
+var Core = []Flag{
+	{Name: "daemon", Description: "Keep a background daemon that caches project context between runs and runs scheduled jobs"},
+	{Name: "tui", Description: "Interactive terminal UI for project status and logs"},
+	{Name: "wasm-plugins", Description: "Load plugins compiled to WebAssembly"},
+}
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Flag)
+	// configured holds the flags set in the configuration
+	configured map[string]bool
+)
+
+func init() {
+	for _, f := range Core {
+		f.Source = SourceCore
+		registry[f.Name] = f
+	}
+}
+
+// Register declares a flag a plugin checks, so that it is listed by
+// `glide features list` and can be enabled. Plugins call it from init with
+// their own name as Source.
+func Register(f Flag) error {
+	if !validName.MatchString(f.Name) {
+		return fmt.Errorf("feature flag %q must be lowercase letters, digits, and dashes", f.Name)
+	}
+	if f.Source == "" {
+		return fmt.Errorf("feature flag %s has no source", f.Name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if existing, ok := registry[f.Name]; ok && existing.Source != f.Source {
+		return fmt.Errorf("feature flag %s is already declared by %s", f.Name, existing.Source)
+	}
+	registry[f.Name] = f
+	return nil
+}
+
+// Unregister removes a flag a plugin declared
+func Unregister(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if f, ok := registry[name]; ok && f.Source != SourceCore {
+		delete(registry, name)
+	}
+}
+
+// Lookup returns a declared flag
+func Lookup(name string) (Flag, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	f, ok := registry[name]
+	return f, ok
+}
+
+// List returns every declared flag sorted by name
+func List() []Flag {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	flags := make([]Flag, 0, len(registry))
+	for _, f := range registry {
+		flags = append(flags, f)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+// Configure sets the flags turned on or off in the configuration
+func Configure(settings map[string]bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	configured = make(map[string]bool, len(settings))
+	for name, on := range settings {
+		configured[name] = on
+	}
+}
+
+// Enabled reports whether a flag is on. Undeclared flags are off.
+func Enabled(name string) bool {
+	on, _ := State(name)
+	return on
+}
+
+// State reports whether a flag is on and whether that comes from
+// FromEnv, FromConfig, or FromDefault
+func State(name string) (bool, string) {
+	f, ok := Lookup(name)
+	if !ok {
+		return false, FromDefault
+	}
+	if on, ok := envSettings()[name]; ok {
+		return on, FromEnv
+	}
+
+	mu.RLock()
+	on, ok := configured[name]
+	mu.RUnlock()
+	if ok {
+		return on, FromConfig
+	}
+	return f.Default, FromDefault
+}
+
+// Unknown returns the flags the configuration or GLIDE_FEATURES set that
+// nothing declared, usually typos or flags of a removed plugin
+func Unknown() []string {
+	names := make(map[string]bool)
+	for name := range envSettings() {
+		names[name] = true
+	}
+	mu.RLock()
+	for name := range configured {
+		names[name] = true
+	}
+	mu.RUnlock()
+
+	var unknown []string
+	for name := range names {
+		if _, ok := Lookup(name); !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// envSettings parses GLIDE_FEATURES
+func envSettings() map[string]bool {
+	settings := make(map[string]bool)
+	for _, name := range strings.Split(os.Getenv(EnvVar), ",") {
+		name = strings.TrimSpace(name)
+		if off, ok := strings.CutPrefix(name, "-"); ok {
+			settings[off] = false
+		} else if name != "" {
+			settings[name] = true
+		}
+	}
+	return settings
+}
@@ -0,0 +1,68 @@
+package featureflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(func() { Unregister("acme-preview") })
+
+	require.NoError(t, Register(Flag{Name: "acme-preview", Source: "acme"}))
+	f, ok := Lookup("acme-preview")
+	require.True(t, ok)
+	assert.Equal(t, "acme", f.Source)
+
+	assert.Error(t, Register(Flag{Name: "Acme Preview", Source: "acme"}), "needs a valid name")
+	assert.Error(t, Register(Flag{Name: "other"}), "needs a source")
+	assert.ErrorContains(t, Register(Flag{Name: "daemon", Source: "acme"}), "already declared by core")
+
+	Unregister("daemon")
+	_, ok = Lookup("daemon")
+	assert.True(t, ok, "core flags stay declared")
+}
+
+func TestList(t *testing.T) {
+	flags := List()
+	assert.Len(t, flags, len(Core))
+	for i := 1; i < len(flags); i++ {
+		assert.Less(t, flags[i-1].Name, flags[i].Name)
+	}
+	for _, f := range flags {
+		assert.Equal(t, SourceCore, f.Source)
+		assert.NotEmpty(t, f.Description, f.Name)
+	}
+}
+
+func TestState(t *testing.T) {
+	t.Cleanup(func() { Configure(nil) })
+	t.Setenv(EnvVar, "")
+
+	on, from := State("daemon")
+	assert.False(t, on)
+	assert.Equal(t, FromDefault, from)
+
+	Configure(map[string]bool{"daemon": true, "tui": true})
+	on, from = State("daemon")
+	assert.True(t, on)
+	assert.Equal(t, FromConfig, from)
+
+	t.Setenv(EnvVar, " wasm-plugins, -daemon ")
+	on, from = State("daemon")
+	assert.False(t, on, "the environment wins over the configuration")
+	assert.Equal(t, FromEnv, from)
+	assert.True(t, Enabled("wasm-plugins"))
+	assert.True(t, Enabled("tui"))
+
+	assert.False(t, Enabled("teleport"), "undeclared flags are off")
+}
+
+func TestUnknown(t *testing.T) {
+	t.Cleanup(func() { Configure(nil) })
+	t.Setenv(EnvVar, "tui,deamon")
+	Configure(map[string]bool{"daemon": true, "teleport": false})
+
+	assert.Equal(t, []string{"deamon", "teleport"}, Unknown())
+}
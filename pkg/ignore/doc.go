@@ -0,0 +1,41 @@
+// Package ignore decides which paths directory scans skip.
+//
+// Detection, file sync, and config discovery look inside the project. Its
+// dependency and build directories are large and full of other projects'
+// marker files, so scanning them is slow and causes false positives: a
+// Go project with node_modules/ is not a Node project.
+//
+// Rules come from three places, later ones overriding earlier ones:
+//
+//  1. DefaultPatterns: .git/, node_modules/, vendor/
+//  2. the project's .gitignore
+//  3. the project's .glideignore
+//
+// Both files use gitignore syntax: # comments, ! to re-include, a trailing
+// / for directories only, a leading / to anchor at the project root, and
+// ** to match any number of directories. Only the files at the project
+// root are read.
+//
+// # Matching Paths
+//
+//	rules := ignore.Load(projectRoot)
+//	if rules.Match("web/node_modules/left-pad", true) {
+//	    // skip it
+//	}
+//
+// # Walking
+//
+// Walk is filepath.WalkDir without the ignored paths:
+//
+//	err := rules.Walk(func(path string, d fs.DirEntry, err error) error {
+//	    fmt.Println(path)
+//	    return err
+//	})
+//
+// A .glideignore can bring back a default, for a project that commits its
+// vendor/ directory:
+//
+//	# .glideignore
+//	!vendor/
+//	build/
+package ignore
@@ -0,0 +1,194 @@
+package ignore
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FileName is the name of a project's Glide-specific ignore file
+const FileName = ".glideignore"
+
+// DefaultPatterns are ignored in every project unless re-included
+var DefaultPatterns = []string{".git/", "node_modules/", "vendor/"}
+
+// rule is one parsed ignore pattern
+type rule struct {
+	segments []string
+	negate   bool // ! re-includes matching paths
+	dirOnly  bool // trailing / matches directories only
+	anchored bool // matched from the root rather than by name at any depth
+}
+
+// Rules is the set of ignore patterns of a directory tree
+type Rules struct {
+	root  string
+	rules []rule
+}
+
+// New creates rules for the tree at root from patterns
+func New(root string, patterns ...string) *Rules {
+	r := &Rules{root: root}
+	r.Add(patterns...)
+	return r
+}
+
+// Load creates the rules for the project at root: the defaults, then its
+// .gitignore, then its .glideignore. Missing files are skipped.
+func Load(root string) *Rules {
+	r := New(root, DefaultPatterns...)
+	for _, name := range []string{".gitignore", FileName} {
+		// Safe to ignore: a missing or unreadable file adds no rules
+		patterns, _ := ReadFile(filepath.Join(root, name))
+		r.Add(patterns...)
+	}
+	return r
+}
+
+// ReadFile returns the patterns of an ignore file, without blank lines and
+// comments
+func ReadFile(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, scanner.Err()
+}
+
+// Add appends patterns; later patterns override earlier ones
+func (r *Rules) Add(patterns ...string) {
+	for _, pattern := range patterns {
+		if rule, ok := parseRule(pattern); ok {
+			r.rules = append(r.rules, rule)
+		}
+	}
+}
+
+// parseRule parses a gitignore-style pattern
+func parseRule(pattern string) (rule, bool) {
+	var r rule
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return r, false
+	}
+	if strings.HasPrefix(pattern, "!") {
+		r.negate = true
+		pattern = pattern[1:]
+	} else if strings.HasPrefix(pattern, `\`) {
+		// \# and \! escape a literal first character
+		pattern = pattern[1:]
+	}
+	if strings.HasSuffix(pattern, "/") {
+		r.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+	if strings.HasPrefix(pattern, "/") {
+		r.anchored = true
+		pattern = strings.TrimLeft(pattern, "/")
+	}
+	if pattern == "" {
+		return r, false
+	}
+	// A slash anywhere but the end anchors the pattern too
+	if strings.Contains(pattern, "/") {
+		r.anchored = true
+	}
+	r.segments = strings.Split(pattern, "/")
+	return r, true
+}
+
+// Match reports whether the path is ignored, either itself or through one
+// of its parent directories. The path is relative to the root, or an
+// absolute path inside it.
+func (r *Rules) Match(p string, isDir bool) bool {
+	if filepath.IsAbs(p) {
+		rel, err := filepath.Rel(r.root, p)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return false
+		}
+		p = rel
+	}
+	p = path.Clean(filepath.ToSlash(p))
+	if p == "." || p == "" {
+		return false
+	}
+
+	parts := strings.Split(p, "/")
+	for i := 1; i < len(parts); i++ {
+		if r.matchParts(parts[:i], true) {
+			return true
+		}
+	}
+	return r.matchParts(parts, isDir)
+}
+
+// matchParts applies the rules to one path; the last matching rule wins
+func (r *Rules) matchParts(parts []string, isDir bool) bool {
+	ignored := false
+	for _, rule := range r.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		var matched bool
+		if rule.anchored {
+			matched = matchSegments(rule.segments, parts)
+		} else {
+			matched = matchSegments(rule.segments, parts[len(parts)-1:])
+		}
+		if matched {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// matchSegments matches path segments against pattern segments, where **
+// matches any number of segments
+func matchSegments(pattern, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchSegments(pattern[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 {
+		return false
+	}
+	if ok, err := path.Match(pattern[0], parts[0]); err != nil || !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], parts[1:])
+}
+
+// Walk walks the tree like filepath.WalkDir, skipping ignored files and
+// not descending into ignored directories
+func (r *Rules) Walk(fn fs.WalkDirFunc) error {
+	return filepath.WalkDir(r.root, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && p != r.root && r.Match(p, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		return fn(p, d, err)
+	})
+}
@@ -0,0 +1,94 @@
+package ignore
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules_Match(t *testing.T) {
+	rules := New("/project",
+		"*.log",
+		"build/",
+		"/dist",
+		"docs/generated",
+		"**/fixtures/**",
+		"!keep.log",
+	)
+
+	tests := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"app.log", false, true},
+		{"logs/app.log", false, true},
+		{"keep.log", false, false},
+		{"build", true, true},
+		{"build", false, false},
+		{"src/build/out.o", false, true},
+		{"dist", true, true},
+		{"src/dist", true, false},
+		{"docs/generated", true, true},
+		{"docs/generated/api.md", false, true},
+		{"src/docs/generated", true, false},
+		{"test/fixtures/a/b.go", false, true},
+		{"main.go", false, false},
+		{"/project/app.log", false, true},
+		{"/elsewhere/app.log", false, false},
+		{".", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.ignored, rules.Match(tt.path, tt.isDir))
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".gitignore"), []byte("# build output\n*.tmp\ncoverage/\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, FileName), []byte("!vendor/\n!coverage/\nfixtures/\n"), 0644))
+
+	rules := Load(root)
+	assert.True(t, rules.Match("node_modules", true), "default pattern")
+	assert.True(t, rules.Match("a.tmp", false), ".gitignore pattern")
+	assert.True(t, rules.Match("fixtures", true), ".glideignore pattern")
+	assert.False(t, rules.Match("vendor", true), ".glideignore re-includes a default")
+	assert.False(t, rules.Match("coverage", true), ".glideignore overrides .gitignore")
+}
+
+func TestRules_Walk(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{
+		"main.go",
+		"node_modules/pkg/index.js",
+		"vendor/lib/lib.go",
+		"src/app.go",
+		"src/app.log",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, nil, 0644))
+	}
+
+	rules := New(root, append(DefaultPatterns, "*.log")...)
+	var files []string
+	err := rules.Walk(func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			rel, _ := filepath.Rel(root, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.go", "src/app.go"}, files)
+}
@@ -0,0 +1,21 @@
+// Package invocation carries the metadata of one run of glide, so that
+// everything the run logs or starts can be correlated.
+//
+// The root command starts an invocation before any command runs:
+//
+//	inv := invocation.Start("")
+//	inv.SetProject(projectRoot)
+//	root.ExecuteContext(invocation.WithContext(context.Background(), inv))
+//
+// Start makes the invocation current and exports its ID as
+// GLIDE_INVOCATION_ID, which every process glide starts inherits: plugins,
+// shell commands, and nested glide runs, which record it as their Parent.
+// Log records carry the ID as the "invocation" attribute.
+//
+// Code with a context looks the invocation up from it, and other code uses
+// the current one:
+//
+//	if inv := invocation.FromContext(ctx); inv != nil {
+//	    fmt.Println(inv.ID, inv.Command(), time.Since(inv.Started))
+//	}
+package invocation
@@ -0,0 +1,131 @@
+package invocation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sync"
+	"time"
+)
+
+// EnvID is the environment variable carrying the invocation ID to the
+// processes glide starts
+const EnvID = "GLIDE_INVOCATION_ID"
+
+// Invocation is one run of glide
+type Invocation struct {
+	// ID identifies the run, e.g. 3f9a1c0e52b7d846
+	ID string
+	// Parent is the ID of the glide run that started this one, if any
+	Parent  string
+	Started time.Time
+
+	mu      sync.RWMutex
+	project string
+	command string
+}
+
+// New creates an invocation started now. A glide run started by another
+// records the other's ID as its Parent.
+func New(project string) *Invocation {
+	return &Invocation{
+		ID:      newID(),
+		Parent:  os.Getenv(EnvID),
+		Started: time.Now(),
+		project: project,
+	}
+}
+
+// Project returns the project root, empty outside a project
+func (i *Invocation) Project() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.project
+}
+
+// SetProject records the project root once it is detected
+func (i *Invocation) SetProject(root string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.project = root
+}
+
+// Command returns the path of the running command without the root, e.g.
+// "plugins stats", once it is known
+func (i *Invocation) Command() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.command
+}
+
+// SetCommand records the path of the running command
+func (i *Invocation) SetCommand(path string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.command = path
+}
+
+var (
+	currentMu sync.RWMutex
+	current   *Invocation
+)
+
+// Start creates an invocation, makes it current, and exports its ID to the
+// processes glide starts
+func Start(project string) *Invocation {
+	inv := New(project)
+	SetCurrent(inv)
+	// Safe to ignore: only fails for invalid names
+	_ = os.Setenv(EnvID, inv.ID)
+	return inv
+}
+
+// SetCurrent makes inv the invocation of code without a context. A nil inv
+// clears it.
+func SetCurrent(inv *Invocation) {
+	currentMu.Lock()
+	defer currentMu.Unlock()
+	current = inv
+}
+
+// Current returns the current invocation, or nil before one started
+func Current() *Invocation {
+	currentMu.RLock()
+	defer currentMu.RUnlock()
+	return current
+}
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx carrying inv
+func WithContext(ctx context.Context, inv *Invocation) context.Context {
+	return context.WithValue(ctx, contextKey{}, inv)
+}
+
+// FromContext returns the invocation ctx carries, else the current one
+func FromContext(ctx context.Context) *Invocation {
+	if ctx != nil {
+		if inv, ok := ctx.Value(contextKey{}).(*Invocation); ok {
+			return inv
+		}
+	}
+	return Current()
+}
+
+// ID returns the ID of the invocation ctx carries, else of the current
+// one, or "" when there is none
+func ID(ctx context.Context) string {
+	if inv := FromContext(ctx); inv != nil {
+		return inv.ID
+	}
+	return ""
+}
+
+// newID returns 8 random bytes in hex
+func newID() string {
+	b := make([]byte, 8)
+	// Safe to ignore: crypto/rand.Read never fails on supported platforms
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
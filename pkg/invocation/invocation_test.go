@@ -0,0 +1,59 @@
+package invocation
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Setenv(EnvID, "")
+
+	inv := New("/src/acme")
+	assert.Len(t, inv.ID, 16)
+	assert.Empty(t, inv.Parent)
+	assert.Equal(t, "/src/acme", inv.Project())
+	assert.False(t, inv.Started.IsZero())
+	assert.NotEqual(t, inv.ID, New("").ID)
+
+	t.Setenv(EnvID, "parent")
+	assert.Equal(t, "parent", New("").Parent, "nested runs record the run that started them")
+}
+
+func TestStart(t *testing.T) {
+	t.Setenv(EnvID, "")
+	t.Cleanup(func() { SetCurrent(nil) })
+
+	inv := Start("/src/acme")
+	assert.Same(t, inv, Current())
+	assert.Equal(t, inv.ID, os.Getenv(EnvID), "started processes inherit the ID")
+}
+
+func TestFromContext(t *testing.T) {
+	t.Cleanup(func() { SetCurrent(nil) })
+
+	assert.Nil(t, FromContext(context.Background()))
+	assert.Empty(t, ID(context.Background()))
+
+	current := New("")
+	SetCurrent(current)
+	assert.Same(t, current, FromContext(context.Background()), "falls back to the current invocation")
+
+	carried := New("")
+	ctx := WithContext(context.Background(), carried)
+	require.Same(t, carried, FromContext(ctx))
+	assert.Equal(t, carried.ID, ID(ctx))
+}
+
+func TestInvocation_Setters(t *testing.T) {
+	inv := New("")
+	assert.Empty(t, inv.Command())
+	inv.SetCommand("plugins stats")
+	assert.Equal(t, "plugins stats", inv.Command())
+
+	inv.SetProject("/src/acme")
+	assert.Equal(t, "/src/acme", inv.Project())
+}
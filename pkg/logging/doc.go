@@ -56,6 +56,14 @@
 //   - GLIDE_LOG_LEVEL: debug, info, warn, error
 //   - GLIDE_LOG_FORMAT: text, json
 //
+// # Progress Indicators
+//
+// Log records written while a spinner or progress bar is drawn would be
+// printed into the middle of it. SetInterceptor lets the progress package
+// erase its indicators around every record:
+//
+//	logging.SetInterceptor(progress.Interrupt)
+//
 // # Integration with Container
 //
 // The container automatically provides a configured logger:
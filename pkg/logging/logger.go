@@ -2,10 +2,13 @@ package logging
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"runtime"
 	"sync"
 	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/invocation"
 )
 
 // Logger provides structured logging functionality wrapping log/slog
@@ -25,14 +28,16 @@ func New(config *Config) *Logger {
 	levelVar := &slog.LevelVar{}
 	levelVar.Set(config.Level)
 
+	output := &interceptWriter{w: config.Output}
+
 	var handler slog.Handler
 	if config.Format == FormatJSON {
-		handler = slog.NewJSONHandler(config.Output, &slog.HandlerOptions{
+		handler = slog.NewJSONHandler(output, &slog.HandlerOptions{
 			Level:     levelVar,
 			AddSource: config.AddSource,
 		})
 	} else {
-		handler = slog.NewTextHandler(config.Output, &slog.HandlerOptions{
+		handler = slog.NewTextHandler(output, &slog.HandlerOptions{
 			Level:     levelVar,
 			AddSource: config.AddSource,
 		})
@@ -58,6 +63,43 @@ func SetDefault(logger *Logger) {
 	defaultLogger = logger
 }
 
+// Interceptor runs write, which prints one log record, and may draw around
+// it, e.g. to print the record above a live progress display
+type Interceptor func(write func())
+
+var (
+	interceptorMu sync.RWMutex
+	interceptor   Interceptor
+)
+
+// SetInterceptor routes the output of every logger through i. A nil i
+// writes records directly.
+func SetInterceptor(i Interceptor) {
+	interceptorMu.Lock()
+	defer interceptorMu.Unlock()
+	interceptor = i
+}
+
+// interceptWriter writes records through the current Interceptor
+type interceptWriter struct {
+	w io.Writer
+}
+
+// Write implements io.Writer; slog handlers write one record per call
+func (iw *interceptWriter) Write(p []byte) (n int, err error) {
+	interceptorMu.RLock()
+	i := interceptor
+	interceptorMu.RUnlock()
+
+	if i == nil {
+		return iw.w.Write(p)
+	}
+	i(func() {
+		n, err = iw.w.Write(p)
+	})
+	return n, err
+}
+
 // SetLevel changes the minimum log level
 func (l *Logger) SetLevel(level slog.Level) {
 	l.level.Set(level)
@@ -128,6 +170,10 @@ func (l *Logger) log(ctx context.Context, level slog.Level, msg string, args ...
 	runtime_Callers(3, pcs[:])
 	r := slog.NewRecord(timeNow(), level, msg, pcs[0])
 	r.Add(args...)
+	// Every record of one run of glide can be correlated
+	if id := invocation.ID(ctx); id != "" {
+		r.AddAttrs(slog.String("invocation", id))
+	}
 	// Safe to ignore: slog.Handler.Handle rarely fails, and if it does, we can't log the error
 	// (infinite recursion). Handler implementations are expected to not error on normal use.
 	_ = l.handler.Handle(ctx, r)
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"sync"
 	"testing"
+
+	"github.com/glide-cli/glide/v3/pkg/invocation"
 )
 
 func TestNew(t *testing.T) {
@@ -199,6 +201,31 @@ func TestLogger_Context(t *testing.T) {
 	}
 }
 
+func TestLogger_Invocation(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := New(&Config{Level: slog.LevelInfo, Format: FormatText, Output: buf})
+
+	logger.Info("before")
+	if strings.Contains(buf.String(), "invocation=") {
+		t.Error("records are not tagged before an invocation starts")
+	}
+
+	current := invocation.New("")
+	invocation.SetCurrent(current)
+	defer invocation.SetCurrent(nil)
+	logger.Info("current")
+	if !strings.Contains(buf.String(), "invocation="+current.ID) {
+		t.Errorf("record not tagged with the current invocation: %s", buf.String())
+	}
+
+	buf.Reset()
+	carried := invocation.New("")
+	logger.InfoContext(invocation.WithContext(context.Background(), carried), "carried")
+	if !strings.Contains(buf.String(), "invocation="+carried.ID) {
+		t.Errorf("record not tagged with the context's invocation: %s", buf.String())
+	}
+}
+
 func TestLogger_JSONFormat(t *testing.T) {
 	buf := &bytes.Buffer{}
 	config := &Config{
@@ -384,3 +411,34 @@ func TestGlobalSetLevel(t *testing.T) {
 		t.Error("Debug not logged at Debug level")
 	}
 }
+
+func TestSetInterceptor(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := New(&Config{Level: slog.LevelInfo, Format: FormatText, Output: buf})
+
+	var calls int
+	SetInterceptor(func(write func()) {
+		calls++
+		buf.WriteString("[before]")
+		write()
+		buf.WriteString("[after]")
+	})
+	t.Cleanup(func() { SetInterceptor(nil) })
+
+	logger.Info("first")
+	logger.Debug("filtered")
+	if calls != 1 {
+		t.Errorf("interceptor called %d times, want 1", calls)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "[before]") || !strings.HasSuffix(out, "[after]") || !strings.Contains(out, "msg=first") {
+		t.Errorf("record not written inside the interceptor: %q", out)
+	}
+
+	SetInterceptor(nil)
+	buf.Reset()
+	logger.Info("second")
+	if strings.Contains(buf.String(), "[before]") {
+		t.Errorf("cleared interceptor still ran: %q", buf.String())
+	}
+}
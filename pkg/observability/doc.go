@@ -22,6 +22,25 @@
 //	mc.RecordTiming("api_latency", 150*time.Millisecond)
 //	stats := mc.GetTimingStats("api_latency") // Min, Max, Avg, P95
 //
+// # Labels
+//
+// Break a metric down by command, plugin, or project with labels. Each
+// label set is its own series, keyed like a Prometheus series:
+//
+//	labels := observability.Labels{
+//	    observability.LabelPlugin:  "docker",
+//	    observability.LabelCommand: "up",
+//	}
+//	collector.IncrementCounterWithLabels("plugin_commands_total", labels)
+//	collector.RecordTimingWithLabels("plugin_command_duration", duration, labels)
+//
+//	// plugin_commands_total{command="up",plugin="docker"}
+//	byPlugin := collector.Snapshot().CountersBy("plugin_commands_total", observability.LabelPlugin)
+//
+// Each metric keeps at most DefaultMaxSeries label sets apart (see
+// SetMaxSeries); later label sets are counted together under
+// OverflowValue.
+//
 // # Timer Utility
 //
 // Measure operation duration:
@@ -2,6 +2,9 @@
 package observability
 
 import (
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -97,6 +100,81 @@ func (h *HistogramMetric) Summary() (sum float64, count int64, buckets []Histogr
 	return h.sum, h.count, buckets
 }
 
+// Labels break a metric down into series, e.g. by command, plugin, or
+// project
+type Labels map[string]string
+
+// Names of the labels glide breaks metrics down by
+const (
+	LabelCommand   = "command"
+	LabelPlugin    = "plugin"
+	LabelProject   = "project"
+	LabelContainer = "container"
+)
+
+const (
+	// DefaultMaxSeries is how many label sets a metric keeps apart by
+	// default
+	DefaultMaxSeries = 100
+
+	// OverflowValue replaces every label value of the label sets a metric
+	// gets past its bound, so they are counted together
+	OverflowValue = "other"
+)
+
+// SeriesKey names the series of a metric with labels the way Prometheus
+// does, e.g. plugin_commands_total{command="up",plugin="docker"}. Labels
+// are sorted by name; a metric without labels is just its name.
+func SeriesKey(name string, labels Labels) string {
+	if len(labels) == 0 {
+		return name
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[k]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// ParseSeriesKey splits a key made by SeriesKey into the metric's name and
+// labels
+func ParseSeriesKey(key string) (string, Labels) {
+	name, rest, ok := strings.Cut(key, "{")
+	if !ok || !strings.HasSuffix(rest, "}") {
+		return key, nil
+	}
+	rest = strings.TrimSuffix(rest, "}")
+
+	labels := Labels{}
+	for rest != "" {
+		k, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			return key, nil
+		}
+		quoted, err := strconv.QuotedPrefix(value)
+		if err != nil {
+			return key, nil
+		}
+		labels[k], _ = strconv.Unquote(quoted)
+		rest = strings.TrimPrefix(value[len(quoted):], ",")
+	}
+	return name, labels
+}
+
 // MetricsCollector collects and aggregates metrics
 type MetricsCollector struct {
 	mu         sync.RWMutex
@@ -106,6 +184,11 @@ type MetricsCollector struct {
 	timings    map[string][]time.Duration
 	enabled    bool
 	maxSamples int
+
+	// series holds the series keys of each labeled metric, which are at
+	// most maxSeries
+	series    map[string]map[string]bool
+	maxSeries int
 }
 
 // DefaultMetricsCollector is the global metrics collector
@@ -120,9 +203,48 @@ func NewMetricsCollector() *MetricsCollector {
 		timings:    make(map[string][]time.Duration),
 		enabled:    true,
 		maxSamples: 1000, // Keep last 1000 timing samples
+		series:     make(map[string]map[string]bool),
+		maxSeries:  DefaultMaxSeries,
 	}
 }
 
+// SetMaxSeries bounds how many label sets each metric keeps apart. Label
+// sets past the bound are counted together, with every value replaced by
+// OverflowValue, so a label such as the command cannot grow the metrics
+// without limit.
+func (mc *MetricsCollector) SetMaxSeries(n int) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.maxSeries = n
+}
+
+// seriesKey returns the key a metric's labels are recorded under, folding
+// label sets past maxSeries into the overflow series. Caller must hold
+// mc.mu.Lock().
+func (mc *MetricsCollector) seriesKey(name string, labels Labels) string {
+	key := SeriesKey(name, labels)
+	if len(labels) == 0 {
+		return key
+	}
+	known := mc.series[name]
+	if known == nil {
+		known = make(map[string]bool)
+		mc.series[name] = known
+	}
+	if known[key] {
+		return key
+	}
+	if len(known) >= mc.maxSeries {
+		overflow := make(Labels, len(labels))
+		for k := range labels {
+			overflow[k] = OverflowValue
+		}
+		key = SeriesKey(name, overflow)
+	}
+	known[key] = true
+	return key
+}
+
 // Enable enables metrics collection
 func (mc *MetricsCollector) Enable() {
 	mc.mu.Lock()
@@ -151,33 +273,59 @@ func (mc *MetricsCollector) IncrementCounter(name string) {
 
 // IncrementCounterBy increments a counter metric by a specific value
 func (mc *MetricsCollector) IncrementCounterBy(name string, delta int64) {
+	mc.IncrementCounterByWithLabels(name, delta, nil)
+}
+
+// IncrementCounterWithLabels increments the series of a counter metric
+// with labels
+func (mc *MetricsCollector) IncrementCounterWithLabels(name string, labels Labels) {
+	mc.IncrementCounterByWithLabels(name, 1, labels)
+}
+
+// IncrementCounterByWithLabels increments the series of a counter metric
+// with labels by a specific value
+func (mc *MetricsCollector) IncrementCounterByWithLabels(name string, delta int64, labels Labels) {
 	if !mc.IsEnabled() {
 		return
 	}
 
 	mc.mu.Lock()
-	if mc.counters[name] == nil {
-		var v int64
-		mc.counters[name] = &v
+	key := mc.seriesKey(name, labels)
+	counter := mc.counters[key]
+	if counter == nil {
+		counter = new(int64)
+		mc.counters[key] = counter
 	}
 	mc.mu.Unlock()
 
-	atomic.AddInt64(mc.counters[name], delta)
+	atomic.AddInt64(counter, delta)
 }
 
 // GetCounter returns the current value of a counter
 func (mc *MetricsCollector) GetCounter(name string) int64 {
+	return mc.GetCounterWithLabels(name, nil)
+}
+
+// GetCounterWithLabels returns the current value of a counter's series
+// with labels
+func (mc *MetricsCollector) GetCounterWithLabels(name string, labels Labels) int64 {
 	mc.mu.RLock()
-	defer mc.mu.RUnlock()
+	counter := mc.counters[SeriesKey(name, labels)]
+	mc.mu.RUnlock()
 
-	if mc.counters[name] == nil {
+	if counter == nil {
 		return 0
 	}
-	return atomic.LoadInt64(mc.counters[name])
+	return atomic.LoadInt64(counter)
 }
 
 // SetGauge sets a gauge metric value
 func (mc *MetricsCollector) SetGauge(name string, value float64) {
+	mc.SetGaugeWithLabels(name, value, nil)
+}
+
+// SetGaugeWithLabels sets the series of a gauge metric with labels
+func (mc *MetricsCollector) SetGaugeWithLabels(name string, value float64, labels Labels) {
 	if !mc.IsEnabled() {
 		return
 	}
@@ -185,25 +333,39 @@ func (mc *MetricsCollector) SetGauge(name string, value float64) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
-	if mc.gauges[name] == nil {
-		mc.gauges[name] = new(float64)
+	key := mc.seriesKey(name, labels)
+	if mc.gauges[key] == nil {
+		mc.gauges[key] = new(float64)
 	}
-	*mc.gauges[name] = value
+	*mc.gauges[key] = value
 }
 
 // GetGauge returns the current value of a gauge
 func (mc *MetricsCollector) GetGauge(name string) float64 {
+	return mc.GetGaugeWithLabels(name, nil)
+}
+
+// GetGaugeWithLabels returns the current value of a gauge's series with
+// labels
+func (mc *MetricsCollector) GetGaugeWithLabels(name string, labels Labels) float64 {
 	mc.mu.RLock()
 	defer mc.mu.RUnlock()
 
-	if mc.gauges[name] == nil {
+	gauge := mc.gauges[SeriesKey(name, labels)]
+	if gauge == nil {
 		return 0
 	}
-	return *mc.gauges[name]
+	return *gauge
 }
 
 // RecordTiming records a timing measurement
 func (mc *MetricsCollector) RecordTiming(name string, duration time.Duration) {
+	mc.RecordTimingWithLabels(name, duration, nil)
+}
+
+// RecordTimingWithLabels records a timing measurement in the series of a
+// timing metric with labels
+func (mc *MetricsCollector) RecordTimingWithLabels(name string, duration time.Duration, labels Labels) {
 	if !mc.IsEnabled() {
 		return
 	}
@@ -211,25 +373,35 @@ func (mc *MetricsCollector) RecordTiming(name string, duration time.Duration) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
-	timings := mc.timings[name]
+	key := mc.seriesKey(name, labels)
+	timings := mc.timings[key]
 	if len(timings) >= mc.maxSamples {
 		// Remove oldest sample (FIFO)
 		timings = timings[1:]
 	}
-	mc.timings[name] = append(timings, duration)
+	mc.timings[key] = append(timings, duration)
 
 	// Also update histogram if it exists
-	if h, ok := mc.histograms[name]; ok {
+	if h, ok := mc.histograms[key]; ok {
 		h.Observe(float64(duration.Nanoseconds()))
 	}
 }
 
 // GetTimingStats returns statistics for a timing metric
 func (mc *MetricsCollector) GetTimingStats(name string) TimingStats {
+	return mc.GetTimingStatsWithLabels(name, nil)
+}
+
+// GetTimingStatsWithLabels returns statistics for the series of a timing
+// metric with labels
+func (mc *MetricsCollector) GetTimingStatsWithLabels(name string, labels Labels) TimingStats {
 	mc.mu.RLock()
 	defer mc.mu.RUnlock()
+	return timingStats(mc.timings[SeriesKey(name, labels)])
+}
 
-	timings := mc.timings[name]
+// timingStats summarizes timing samples
+func timingStats(timings []time.Duration) TimingStats {
 	if len(timings) == 0 {
 		return TimingStats{}
 	}
@@ -305,8 +477,8 @@ func (mc *MetricsCollector) Snapshot() MetricsSnapshot {
 		snapshot.Gauges[name] = *gauge
 	}
 
-	for name := range mc.timings {
-		snapshot.Timings[name] = mc.GetTimingStats(name)
+	for key, timings := range mc.timings {
+		snapshot.Timings[key] = timingStats(timings)
 	}
 
 	return snapshot
@@ -321,9 +493,11 @@ func (mc *MetricsCollector) Reset() {
 	mc.gauges = make(map[string]*float64)
 	mc.histograms = make(map[string]*HistogramMetric)
 	mc.timings = make(map[string][]time.Duration)
+	mc.series = make(map[string]map[string]bool)
 }
 
-// MetricsSnapshot contains a point-in-time snapshot of all metrics
+// MetricsSnapshot contains a point-in-time snapshot of all metrics. Series
+// of metrics with labels are keyed by SeriesKey.
 type MetricsSnapshot struct {
 	Timestamp time.Time              `json:"timestamp"`
 	Counters  map[string]int64       `json:"counters"`
@@ -331,6 +505,20 @@ type MetricsSnapshot struct {
 	Timings   map[string]TimingStats `json:"timings"`
 }
 
+// CountersBy sums the series of a counter by the value of one label, e.g.
+// plugin_commands_total by plugin. Series without the label are summed
+// under "".
+func (s MetricsSnapshot) CountersBy(name, label string) map[string]int64 {
+	sums := make(map[string]int64)
+	for key, value := range s.Counters {
+		metric, labels := ParseSeriesKey(key)
+		if metric == name {
+			sums[labels[label]] += value
+		}
+	}
+	return sums
+}
+
 // Timer provides a convenient way to measure operation duration
 type Timer struct {
 	name      string
@@ -381,6 +569,24 @@ func RecordTiming(name string, duration time.Duration) {
 	DefaultMetricsCollector.RecordTiming(name, duration)
 }
 
+// IncrementCounterWithLabels increments a counter's series with labels
+// using the default collector
+func IncrementCounterWithLabels(name string, labels Labels) {
+	DefaultMetricsCollector.IncrementCounterWithLabels(name, labels)
+}
+
+// SetGaugeWithLabels sets a gauge's series with labels using the default
+// collector
+func SetGaugeWithLabels(name string, value float64, labels Labels) {
+	DefaultMetricsCollector.SetGaugeWithLabels(name, value, labels)
+}
+
+// RecordTimingWithLabels records timing in a series with labels using the
+// default collector
+func RecordTimingWithLabels(name string, duration time.Duration, labels Labels) {
+	DefaultMetricsCollector.RecordTimingWithLabels(name, duration, labels)
+}
+
 // GetSnapshot returns a snapshot from the default collector
 func GetSnapshot() MetricsSnapshot {
 	return DefaultMetricsCollector.Snapshot()
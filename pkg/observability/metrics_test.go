@@ -153,6 +153,101 @@ func TestMetricsCollector_Reset(t *testing.T) {
 	assert.Equal(t, 0, stats.Count)
 }
 
+func TestSeriesKey(t *testing.T) {
+	assert.Equal(t, "commands_total", SeriesKey("commands_total", nil))
+
+	labels := Labels{LabelPlugin: "docker", LabelCommand: `say "hi"`}
+	key := SeriesKey("commands_total", labels)
+	assert.Equal(t, `commands_total{command="say \"hi\"",plugin="docker"}`, key)
+
+	name, parsed := ParseSeriesKey(key)
+	assert.Equal(t, "commands_total", name)
+	assert.Equal(t, labels, parsed)
+
+	name, parsed = ParseSeriesKey("commands_total")
+	assert.Equal(t, "commands_total", name)
+	assert.Nil(t, parsed)
+}
+
+func TestMetricsCollector_Labels(t *testing.T) {
+	mc := NewMetricsCollector()
+	up := Labels{LabelPlugin: "docker", LabelCommand: "up"}
+	down := Labels{LabelPlugin: "docker", LabelCommand: "down"}
+
+	mc.IncrementCounterWithLabels("commands_total", up)
+	mc.IncrementCounterByWithLabels("commands_total", 2, down)
+	mc.IncrementCounter("commands_total")
+	assert.Equal(t, int64(1), mc.GetCounterWithLabels("commands_total", up))
+	assert.Equal(t, int64(2), mc.GetCounterWithLabels("commands_total", down))
+	assert.Equal(t, int64(1), mc.GetCounter("commands_total"), "the unlabeled series is separate")
+
+	mc.SetGaugeWithLabels("container_cpu", 12.5, Labels{LabelContainer: "web"})
+	assert.Equal(t, 12.5, mc.GetGaugeWithLabels("container_cpu", Labels{LabelContainer: "web"}))
+	assert.Equal(t, 0.0, mc.GetGaugeWithLabels("container_cpu", Labels{LabelContainer: "db"}))
+
+	mc.RecordTimingWithLabels("command_duration", 10*time.Millisecond, up)
+	mc.RecordTimingWithLabels("command_duration", 30*time.Millisecond, up)
+	stats := mc.GetTimingStatsWithLabels("command_duration", up)
+	assert.Equal(t, 2, stats.Count)
+	assert.Equal(t, 20*time.Millisecond, stats.Avg)
+
+	snapshot := mc.Snapshot()
+	assert.Equal(t, map[string]int64{"docker": 3, "": 1}, snapshot.CountersBy("commands_total", LabelPlugin))
+	assert.Equal(t, map[string]int64{"up": 1, "down": 2, "": 1}, snapshot.CountersBy("commands_total", LabelCommand))
+}
+
+func TestMetricsCollector_MaxSeries(t *testing.T) {
+	mc := NewMetricsCollector()
+	mc.SetMaxSeries(2)
+
+	for _, command := range []string{"up", "down", "logs", "exec", "up"} {
+		mc.IncrementCounterWithLabels("commands_total", Labels{LabelCommand: command, LabelPlugin: "docker"})
+	}
+
+	assert.Equal(t, int64(2), mc.GetCounterWithLabels("commands_total", Labels{LabelCommand: "up", LabelPlugin: "docker"}))
+	assert.Equal(t, int64(1), mc.GetCounterWithLabels("commands_total", Labels{LabelCommand: "down", LabelPlugin: "docker"}))
+	assert.Equal(t, int64(2), mc.GetCounterWithLabels("commands_total", Labels{LabelCommand: OverflowValue, LabelPlugin: OverflowValue}))
+	assert.Len(t, mc.Snapshot().Counters, 3)
+
+	// The bound is per metric
+	mc.IncrementCounterWithLabels("failures_total", Labels{LabelCommand: "logs"})
+	assert.Equal(t, int64(1), mc.GetCounterWithLabels("failures_total", Labels{LabelCommand: "logs"}))
+
+	mc.Reset()
+	mc.IncrementCounterWithLabels("commands_total", Labels{LabelCommand: "logs", LabelPlugin: "docker"})
+	assert.Equal(t, int64(1), mc.GetCounterWithLabels("commands_total", Labels{LabelCommand: "logs", LabelPlugin: "docker"}))
+}
+
+func TestMetricsCollector_LabelsConcurrent(t *testing.T) {
+	mc := NewMetricsCollector()
+	labels := Labels{LabelPlugin: "docker"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				mc.IncrementCounterWithLabels("commands_total", labels)
+				mc.SetGaugeWithLabels("container_cpu", float64(j), labels)
+				mc.RecordTimingWithLabels("command_duration", time.Millisecond, labels)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				mc.Snapshot()
+				mc.Reset()
+			}
+		}()
+	}
+	wg.Wait()
+
+	mc.Reset()
+	mc.IncrementCounterWithLabels("commands_total", labels)
+	assert.Equal(t, int64(1), mc.GetCounterWithLabels("commands_total", labels))
+}
+
 func TestTimer(t *testing.T) {
 	mc := NewMetricsCollector()
 
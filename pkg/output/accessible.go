@@ -0,0 +1,142 @@
+package output
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// AccessibleEnv turns accessible output on (1) or off (0), like the
+// --accessible flag
+const AccessibleEnv = "GLIDE_ACCESSIBLE"
+
+// Labels messages start with in accessible mode, in place of icons
+const (
+	LabelSuccess = "SUCCESS:"
+	LabelError   = "ERROR:"
+	LabelWarning = "WARNING:"
+	LabelInfo    = "INFO:"
+)
+
+// accessible is set by SetAccessible: 0 until it is called, then 1 for on
+// and 2 for off
+var accessible atomic.Int32
+
+// SetAccessible turns accessible output on or off. Accessible output is
+// meant for screen readers: messages start with spoken labels such as
+// "ERROR:", and emoji, box drawing and spinners are left out.
+// SetAccessible overrides GLIDE_ACCESSIBLE.
+func SetAccessible(on bool) {
+	if on {
+		accessible.Store(1)
+	} else {
+		accessible.Store(2)
+	}
+}
+
+// Accessible reports whether accessible output is on, through
+// SetAccessible or else GLIDE_ACCESSIBLE
+func Accessible() bool {
+	switch accessible.Load() {
+	case 1:
+		return true
+	case 2:
+		return false
+	}
+	on, _ := strconv.ParseBool(os.Getenv(AccessibleEnv))
+	return on
+}
+
+// accessibleSymbols are the symbols read out poorly by screen readers, and
+// what is shown in their place
+var accessibleSymbols = map[rune]string{
+	'•': "-",
+	'→': "->",
+	'←': "<-",
+	'…': "...",
+	'›': ">",
+	'✓': "",
+	'✔': "",
+	'✗': "",
+	'✘': "",
+	'ℹ': "",
+}
+
+// Plain rewrites text for screen readers in accessible mode: emoji and
+// status symbols are left out, and box drawing, bullets and arrows become
+// ASCII. Text is returned unchanged otherwise.
+func Plain(text string) string {
+	if !Accessible() {
+		return text
+	}
+	return plain(text)
+}
+
+func plain(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	// dropped is set after a symbol was left out at the start of a line or
+	// word, so the space that followed it goes too
+	dropped := false
+	for _, r := range text {
+		replacement, symbol := accessibleSymbols[r]
+		switch {
+		case symbol:
+		case isBoxDrawing(r):
+			replacement = boxDrawing(r)
+		case isPictograph(r):
+			replacement = ""
+		default:
+			if dropped && r == ' ' {
+				continue
+			}
+			dropped = false
+			b.WriteRune(r)
+			continue
+		}
+
+		if replacement == "" {
+			out := b.String()
+			dropped = out == "" || strings.HasSuffix(out, " ") || strings.HasSuffix(out, "\n")
+			continue
+		}
+		dropped = false
+		b.WriteString(replacement)
+	}
+	return b.String()
+}
+
+// isPictograph reports whether r is an emoji, a dingbat, or one of the
+// invisible characters emoji are composed with
+func isPictograph(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // emoji and pictographs
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // stars, heavy arrows
+		return true
+	case r >= 0x23E9 && r <= 0x23FA: // media and clock symbols
+		return true
+	case r == 0xFE0F || r == 0xFE0E || r == 0x200D: // variation selectors, joiner
+		return true
+	}
+	return false
+}
+
+func isBoxDrawing(r rune) bool {
+	return r >= 0x2500 && r <= 0x257F
+}
+
+// boxDrawing returns the ASCII character drawing the same line as r
+func boxDrawing(r rune) string {
+	switch r {
+	case '─', '━', '═', '╌', '┄':
+		return "-"
+	case '│', '┃', '║', '╎', '┆':
+		return "|"
+	default:
+		return "+"
+	}
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessible(t *testing.T) {
+	t.Cleanup(func() { accessible.Store(0) })
+
+	t.Setenv(AccessibleEnv, "1")
+	assert.True(t, Accessible())
+	SetAccessible(false)
+	assert.False(t, Accessible(), "SetAccessible overrides the environment")
+
+	SetAccessible(true)
+	assert.Equal(t, LabelError, GetIcon(IconError))
+	assert.Equal(t, "-", GetIcon(IconBullet))
+}
+
+func TestPlain(t *testing.T) {
+	assert.Equal(t, "Snapshot created", plain("✅ Snapshot created"))
+	assert.Equal(t, "Warning: low disk", plain("⚠️  Warning: low disk"))
+	assert.Equal(t, "  - api -> web", plain("  • api → web"))
+	assert.Equal(t, "-- Services --", plain("── Services ──"))
+	assert.Equal(t, "+-+\n| |\n+-+", plain("┌─┐\n│ │\n└─┘"))
+	assert.Equal(t, "ok\nLocked", plain("✓ ok\n🔒 Locked"))
+	assert.Equal(t, "Copying...", plain("Copying…"))
+	assert.Equal(t, "plain text  stays", plain("plain text  stays"))
+}
+
+func TestTableFormatter_Accessible(t *testing.T) {
+	t.Cleanup(func() { accessible.Store(0) })
+	SetAccessible(true)
+
+	var buf bytes.Buffer
+	f := NewTableFormatter(&buf, true, false)
+	assert.NoError(t, f.Success("🎉 Done"))
+	assert.NoError(t, f.Error("Build failed"))
+	assert.NoError(t, f.Raw("── Status ──\n"))
+	assert.Equal(t, "SUCCESS: Done\nERROR: Build failed\n-- Status --\n", buf.String())
+
+	buf.Reset()
+	p := NewPlainFormatter(&buf, true, false)
+	assert.NoError(t, p.Warning("⚠️ Disk almost full"))
+	assert.Equal(t, "WARNING: Disk almost full\n", buf.String())
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// RecordKind identifies the call that produced a Record
+type RecordKind string
+
+// Record kinds
+const (
+	RecordInfo    RecordKind = "info"
+	RecordSuccess RecordKind = "success"
+	RecordWarning RecordKind = "warning"
+	RecordError   RecordKind = "error"
+	RecordDisplay RecordKind = "display"
+	RecordRaw     RecordKind = "raw"
+	RecordTable   RecordKind = "table"
+)
+
+// Record is one output call seen by a Capture
+type Record struct {
+	Kind RecordKind
+	// Message is the formatted message of Info, Success, Warning, and Error,
+	// and the text of Raw
+	Message string
+	// Data is the value given to Display, or the *Table given to Table
+	Data interface{}
+}
+
+// Capture collects a manager's output while it is active: the rendered
+// text in a buffer, and a Record of every call, so tests and plugins can
+// assert on what was reported rather than how it was formatted
+type Capture struct {
+	manager *Manager
+
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	records []Record
+
+	// restore holds the manager state from before the capture
+	previous  *Capture
+	writer    io.Writer
+	errWriter io.Writer
+	piped     bool
+	released  bool
+}
+
+// Capture redirects the manager's output into a new Capture until its
+// Release is called. Captures nest; releasing one restores the output the
+// manager had when it was created.
+//
+//	capture := manager.Capture()
+//	defer capture.Release()
+//	runCommand()
+//	assert.Equal(t, []string{"Containers started"}, capture.Messages(output.RecordSuccess))
+func (m *Manager) Capture() *Capture {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := &Capture{
+		manager:   m,
+		previous:  m.capture,
+		writer:    m.writer,
+		errWriter: m.errWriter,
+		piped:     m.piped,
+	}
+	m.capture = c
+	m.writer = &c.buf
+	m.errWriter = &c.buf
+	m.piped = false
+	m.createFormatters()
+	return c
+}
+
+// Release stops capturing and restores the manager's previous output. It
+// is safe to call more than once.
+func (c *Capture) Release() {
+	m := c.manager
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c.released {
+		return
+	}
+	c.released = true
+	m.capture = c.previous
+	m.writer = c.writer
+	m.errWriter = c.errWriter
+	m.piped = c.piped
+	m.createFormatters()
+}
+
+// String returns the captured text
+func (c *Capture) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.String()
+}
+
+// Records returns every captured call in order
+func (c *Capture) Records() []Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Record(nil), c.records...)
+}
+
+// Messages returns the messages of the captured calls of a kind
+func (c *Capture) Messages(kind RecordKind) []string {
+	var messages []string
+	for _, r := range c.Records() {
+		if r.Kind == kind {
+			messages = append(messages, r.Message)
+		}
+	}
+	return messages
+}
+
+// Tables returns the captured tables
+func (c *Capture) Tables() []*Table {
+	var tables []*Table
+	for _, r := range c.Records() {
+		if table, ok := r.Data.(*Table); ok && r.Kind == RecordTable {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+// record adds a call; c may be nil when nothing is captured
+func (c *Capture) record(kind RecordKind, message string, data interface{}) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = append(c.records, Record{Kind: kind, Message: message, Data: data})
+}
+
+// recordf adds a message call, formatting the message only when captured
+func (c *Capture) recordf(kind RecordKind, format string, args ...interface{}) {
+	if c != nil {
+		c.record(kind, strings.TrimSpace(fmt.Sprintf(format, args...)), nil)
+	}
+}
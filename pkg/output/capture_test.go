@@ -0,0 +1,57 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerCapture(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	manager := NewManager(FormatPlain, false, true, stdout)
+
+	capture := manager.Capture()
+	require.NoError(t, manager.Success("Started %d containers", 3))
+	require.NoError(t, manager.Warning("Port %d is in use", 8080))
+	require.NoError(t, manager.Display(map[string]interface{}{"status": "ok"}))
+	table := &Table{Headers: []string{"NAME"}, Rows: [][]string{{"docker"}}}
+	require.NoError(t, manager.Table(table))
+	require.NoError(t, manager.Printf("done\n"))
+	capture.Release()
+
+	require.NoError(t, manager.Info("after"))
+	assert.NotContains(t, stdout.String(), "Started", "captured output stays in the capture")
+	assert.Contains(t, stdout.String(), "after", "release restores the writer")
+
+	assert.Equal(t, []string{"Started 3 containers"}, capture.Messages(RecordSuccess))
+	assert.Equal(t, []string{"Port 8080 is in use"}, capture.Messages(RecordWarning))
+	assert.Equal(t, []*Table{table}, capture.Tables())
+	assert.Equal(t, []string{"done\n"}, capture.Messages(RecordRaw))
+
+	records := capture.Records()
+	require.Len(t, records, 5)
+	assert.Equal(t, RecordDisplay, records[2].Kind)
+	assert.Equal(t, map[string]interface{}{"status": "ok"}, records[2].Data)
+
+	assert.Contains(t, capture.String(), "Started 3 containers")
+	assert.Contains(t, capture.String(), "docker")
+	assert.NotContains(t, capture.String(), "after")
+}
+
+func TestManagerCapture_Nested(t *testing.T) {
+	manager := NewManager(FormatPlain, false, true, &bytes.Buffer{})
+
+	outer := manager.Capture()
+	require.NoError(t, manager.Info("outer"))
+	inner := manager.Capture()
+	require.NoError(t, manager.Info("inner"))
+	inner.Release()
+	inner.Release()
+	require.NoError(t, manager.Info("outer again"))
+	outer.Release()
+
+	assert.Equal(t, []string{"inner"}, inner.Messages(RecordInfo))
+	assert.Equal(t, []string{"outer", "outer again"}, outer.Messages(RecordInfo))
+}
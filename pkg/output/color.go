@@ -12,6 +12,7 @@ import (
 type ColorConfig struct {
 	Enabled bool
 	Theme   Theme
+	Depth   ColorDepth
 }
 
 // Theme represents the color theme
@@ -73,6 +74,13 @@ func InitColors() *ColorConfig {
 		}
 	}
 
+	// Map the theme to the colors the terminal can show
+	config.Depth = DetectColorDepth()
+	SetColorDepth(config.Depth)
+	if config.Depth == DepthNone {
+		config.Enabled = false
+	}
+
 	return config
 }
 
@@ -139,7 +147,7 @@ func Bold(format string, args ...interface{}) string {
 
 // Faint formats text in faint/dim style
 func Faint(format string, args ...interface{}) string {
-	if color.NoColor {
+	if color.NoColor || plainFaint {
 		return fmt.Sprintf(format, args...)
 	}
 	return ColorFaint.Sprintf(format, args...)
@@ -165,6 +173,24 @@ const (
 
 // GetIcon returns the appropriate icon based on terminal capabilities
 func GetIcon(icon string) string {
+	// Screen readers get words for the status icons
+	if Accessible() {
+		switch icon {
+		case IconSuccess:
+			return LabelSuccess
+		case IconError:
+			return LabelError
+		case IconWarning:
+			return LabelWarning
+		case IconInfo:
+			return LabelInfo
+		case IconBullet:
+			return "-"
+		case IconArrow:
+			return IconArrowASCII
+		}
+	}
+
 	// Check if we should use ASCII icons
 	if os.Getenv("GLIDE_ASCII_ICONS") != "" || os.Getenv("TERM") == "dumb" {
 		switch icon {
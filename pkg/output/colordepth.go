@@ -0,0 +1,173 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// ColorDepthEnv overrides the detected color depth: none, 16, 256, or
+// truecolor
+const ColorDepthEnv = "GLIDE_COLOR_DEPTH"
+
+// ColorDepth is how many colors a terminal can show
+type ColorDepth int
+
+const (
+	// DepthNone shows no colors
+	DepthNone ColorDepth = iota
+	// Depth16 shows the 16 ANSI colors, as legacy Windows consoles and most
+	// CI logs do
+	Depth16
+	// Depth256 shows the xterm 256-color palette
+	Depth256
+	// DepthTrueColor shows 24-bit colors
+	DepthTrueColor
+)
+
+// String returns the name ParseColorDepth accepts
+func (d ColorDepth) String() string {
+	switch d {
+	case DepthNone:
+		return "none"
+	case Depth16:
+		return "16"
+	case Depth256:
+		return "256"
+	default:
+		return "truecolor"
+	}
+}
+
+// ParseColorDepth parses none, 16, 256, or truecolor (also 24bit)
+func ParseColorDepth(s string) (ColorDepth, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "none", "0", "never":
+		return DepthNone, nil
+	case "16", "8", "ansi":
+		return Depth16, nil
+	case "256":
+		return Depth256, nil
+	case "truecolor", "24bit", "16m":
+		return DepthTrueColor, nil
+	}
+	return DepthNone, fmt.Errorf("invalid color depth %q (must be none, 16, 256, or truecolor)", s)
+}
+
+// truecolorTerminals are the TERM_PROGRAM values of terminals known to
+// show 24-bit colors
+var truecolorTerminals = []string{"iTerm.app", "WezTerm", "vscode", "Hyper", "ghostty", "Tabby"}
+
+// DetectColorDepth returns the color depth of the terminal glide runs in,
+// from GLIDE_COLOR_DEPTH or else the environment
+func DetectColorDepth() ColorDepth {
+	return detectColorDepth(os.Getenv, runtime.GOOS)
+}
+
+func detectColorDepth(getenv func(string) string, goos string) ColorDepth {
+	if depth, err := ParseColorDepth(getenv(ColorDepthEnv)); err == nil {
+		return depth
+	}
+	if getenv("NO_COLOR") != "" {
+		return DepthNone
+	}
+
+	colorterm := strings.ToLower(getenv("COLORTERM"))
+	if colorterm == "truecolor" || colorterm == "24bit" {
+		return DepthTrueColor
+	}
+
+	// CI logs are rendered by the CI system, not a terminal
+	switch {
+	case getenv("GITHUB_ACTIONS") != "" || getenv("GITEA_ACTIONS") != "":
+		return DepthTrueColor
+	case getenv("GITLAB_CI") != "" || getenv("BUILDKITE") != "":
+		return Depth256
+	case getenv("CI") != "":
+		return Depth16
+	}
+
+	program := getenv("TERM_PROGRAM")
+	for _, terminal := range truecolorTerminals {
+		if program == terminal {
+			return DepthTrueColor
+		}
+	}
+
+	// Windows Terminal and ConEmu show 24-bit colors; the legacy console
+	// only shows the 16 ANSI ones, and sets no TERM
+	if goos == "windows" {
+		if getenv("WT_SESSION") != "" || getenv("ConEmuANSI") == "ON" {
+			return DepthTrueColor
+		}
+		return Depth16
+	}
+
+	term := getenv("TERM")
+	switch {
+	case term == "" || term == "dumb":
+		return DepthNone
+	case strings.HasSuffix(term, "-direct") || term == "xterm-kitty" || term == "alacritty" || term == "xterm-ghostty":
+		return DepthTrueColor
+	case strings.Contains(term, "256color") || program == "Apple_Terminal":
+		return Depth256
+	}
+	return Depth16
+}
+
+// ThemeColor is a color of the theme at every color depth, so terminals
+// that show fewer colors get the nearest one they have
+type ThemeColor struct {
+	RGB     [3]int
+	ANSI256 int
+	// ANSI16 is the 16-color attribute; 0 leaves the text unstyled
+	ANSI16 color.Attribute
+}
+
+// at returns the color for a color depth
+func (t ThemeColor) at(depth ColorDepth) *color.Color {
+	switch depth {
+	case DepthTrueColor:
+		return color.RGB(t.RGB[0], t.RGB[1], t.RGB[2])
+	case Depth256:
+		return color.New(38, 5, color.Attribute(t.ANSI256))
+	case Depth16:
+		if t.ANSI16 == 0 {
+			return color.New()
+		}
+		return color.New(t.ANSI16)
+	}
+	return color.New()
+}
+
+// The theme's colors. Faint text is plain in 16 colors, where the faint
+// attribute and bright black are missing or unreadable, e.g. in CI logs
+// and legacy Windows consoles.
+var (
+	ThemeSuccess = ThemeColor{RGB: [3]int{80, 200, 120}, ANSI256: 78, ANSI16: color.FgGreen}
+	ThemeError   = ThemeColor{RGB: [3]int{235, 87, 87}, ANSI256: 167, ANSI16: color.FgRed}
+	ThemeWarning = ThemeColor{RGB: [3]int{235, 180, 60}, ANSI256: 214, ANSI16: color.FgYellow}
+	ThemeInfo    = ThemeColor{RGB: [3]int{80, 180, 230}, ANSI256: 74, ANSI16: color.FgCyan}
+	ThemeFaint   = ThemeColor{RGB: [3]int{140, 140, 140}, ANSI256: 245}
+)
+
+// SetColorDepth maps the theme's colors to a color depth, turning colors
+// off for DepthNone
+func SetColorDepth(depth ColorDepth) {
+	if depth == DepthNone {
+		DisableColors()
+		return
+	}
+	ColorSuccess = ThemeSuccess.at(depth)
+	ColorError = ThemeError.at(depth)
+	ColorWarning = ThemeWarning.at(depth)
+	ColorInfo = ThemeInfo.at(depth)
+	ColorFaint = ThemeFaint.at(depth)
+	plainFaint = ThemeFaint.ANSI16 == 0 && depth == Depth16
+}
+
+// plainFaint leaves faint text unstyled, see SetColorDepth
+var plainFaint bool
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectColorDepth(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	tests := []struct {
+		name string
+		vars map[string]string
+		goos string
+		want ColorDepth
+	}{
+		{"override", map[string]string{ColorDepthEnv: "256", "COLORTERM": "truecolor"}, "linux", Depth256},
+		{"NO_COLOR", map[string]string{"NO_COLOR": "1", "TERM": "xterm-256color"}, "linux", DepthNone},
+		{"COLORTERM", map[string]string{"COLORTERM": "truecolor", "TERM": "xterm"}, "linux", DepthTrueColor},
+		{"256-color TERM", map[string]string{"TERM": "xterm-256color"}, "linux", Depth256},
+		{"16-color TERM", map[string]string{"TERM": "xterm"}, "linux", Depth16},
+		{"direct TERM", map[string]string{"TERM": "xterm-direct"}, "linux", DepthTrueColor},
+		{"dumb", map[string]string{"TERM": "dumb"}, "linux", DepthNone},
+		{"Apple Terminal", map[string]string{"TERM_PROGRAM": "Apple_Terminal", "TERM": "xterm"}, "darwin", Depth256},
+		{"iTerm", map[string]string{"TERM_PROGRAM": "iTerm.app", "TERM": "xterm-256color"}, "darwin", DepthTrueColor},
+		{"GitHub Actions", map[string]string{"CI": "true", "GITHUB_ACTIONS": "true"}, "linux", DepthTrueColor},
+		{"other CI", map[string]string{"CI": "true", "TERM": "xterm-256color"}, "linux", Depth16},
+		{"legacy Windows console", nil, "windows", Depth16},
+		{"Windows Terminal", map[string]string{"WT_SESSION": "abc"}, "windows", DepthTrueColor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectColorDepth(env(tt.vars), tt.goos))
+		})
+	}
+}
+
+func TestParseColorDepth(t *testing.T) {
+	for _, depth := range []ColorDepth{DepthNone, Depth16, Depth256, DepthTrueColor} {
+		parsed, err := ParseColorDepth(depth.String())
+		assert.NoError(t, err)
+		assert.Equal(t, depth, parsed)
+	}
+	_, err := ParseColorDepth("lots")
+	assert.Error(t, err)
+}
+
+func TestThemeColor(t *testing.T) {
+	sprint := func(depth ColorDepth, theme ThemeColor) string {
+		c := theme.at(depth)
+		c.EnableColor()
+		return c.Sprint("x")
+	}
+
+	assert.True(t, strings.HasPrefix(sprint(DepthTrueColor, ThemeInfo), "\x1b[38;2;80;180;230mx\x1b[0"))
+	assert.True(t, strings.HasPrefix(sprint(Depth256, ThemeInfo), "\x1b[38;5;74mx\x1b[0"))
+	assert.Equal(t, "\x1b[36mx\x1b[0m", sprint(Depth16, ThemeInfo))
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// DataRenderer writes the data a command displays in a custom format.
+// data is the structured model JSON output encodes, decoded from its JSON
+// encoding: map[string]interface{}, []interface{}, string, float64, bool,
+// or nil.
+type DataRenderer func(w io.Writer, data interface{}) error
+
+// RegisterDataFormat registers a custom output format, such as an HTML
+// report or TAP, in the global registry. It is selected with --format like
+// the built-in formats, and commands display the same structured data in it
+// as in JSON, which render receives as StructuredModel returns it. Messages
+// are written as plain text.
+//
+// Plugins compiled into glide call it from an init function:
+//
+//	func init() {
+//	    output.RegisterDataFormat("tap", func(w io.Writer, data interface{}) error {
+//	        return writeTAP(w, data)
+//	    })
+//	}
+func RegisterDataFormat(format Format, render DataRenderer) error {
+	return globalRegistry.RegisterStructured(format, func(w io.Writer, noColor, quiet bool) Formatter {
+		return NewDataFormatter(w, quiet, render)
+	})
+}
+
+// StructuredModel returns data as JSON output encodes it, decoded into
+// maps, slices, and scalars
+func StructuredModel(data interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var model interface{}
+	if err := json.Unmarshal(encoded, &model); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// DataFormatter displays data through a DataRenderer and writes messages
+// as plain text
+type DataFormatter struct {
+	*PlainFormatter
+	render DataRenderer
+}
+
+// NewDataFormatter creates a formatter displaying data through render
+func NewDataFormatter(w io.Writer, quiet bool, render DataRenderer) *DataFormatter {
+	if w == nil {
+		w = os.Stdout
+	}
+
+	return &DataFormatter{
+		PlainFormatter: NewPlainFormatter(w, true, quiet),
+		render:         render,
+	}
+}
+
+// Display renders data's structured model
+func (f *DataFormatter) Display(data interface{}) error {
+	if f.quiet {
+		return nil
+	}
+
+	model, err := StructuredModel(data)
+	if err != nil {
+		return err
+	}
+	return f.render(f.writer, model)
+}
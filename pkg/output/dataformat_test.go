@@ -0,0 +1,82 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerTestFormat registers a data format for the duration of a test
+func registerTestFormat(t *testing.T, format Format, render DataRenderer) {
+	t.Helper()
+	require.NoError(t, RegisterDataFormat(format, render))
+	t.Cleanup(func() {
+		globalRegistry.Remove(string(format))
+		globalRegistry.structuredMu.Lock()
+		delete(globalRegistry.structured, string(format))
+		globalRegistry.structuredMu.Unlock()
+	})
+}
+
+func TestRegisterDataFormat(t *testing.T) {
+	type check struct {
+		Name   string `json:"name"`
+		Passed bool   `json:"passed"`
+	}
+
+	var rendered interface{}
+	registerTestFormat(t, "tap", func(w io.Writer, data interface{}) error {
+		rendered = data
+		checks := data.([]interface{})
+		fmt.Fprintf(w, "1..%d\n", len(checks))
+		for i, c := range checks {
+			c := c.(map[string]interface{})
+			status := "ok"
+			if !c["passed"].(bool) {
+				status = "not ok"
+			}
+			fmt.Fprintf(w, "%s %d - %s\n", status, i+1, c["name"])
+		}
+		return nil
+	})
+
+	format, err := ParseFormat("tap")
+	require.NoError(t, err)
+	assert.Equal(t, Format("tap"), format)
+	assert.True(t, format.IsStructured())
+	assert.Contains(t, FormatNames(), "tap")
+
+	buf := &bytes.Buffer{}
+	m := NewManager(format, false, false, buf)
+	require.NoError(t, m.Display([]check{{Name: "docker", Passed: true}, {Name: "compose", Passed: false}}))
+	assert.Equal(t, "1..2\nok 1 - docker\nnot ok 2 - compose\n", buf.String())
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "docker", "passed": true},
+		map[string]interface{}{"name": "compose", "passed": false},
+	}, rendered, "the renderer gets the model JSON output encodes")
+
+	buf.Reset()
+	require.NoError(t, m.Info("done"))
+	assert.Equal(t, "[INFO] done\n", buf.String(), "messages are plain text")
+}
+
+func TestFormatIsStructured(t *testing.T) {
+	assert.True(t, FormatJSON.IsStructured())
+	assert.True(t, FormatYAML.IsStructured())
+	assert.False(t, FormatTable.IsStructured())
+	assert.False(t, FormatPlain.IsStructured())
+
+	_, err := ParseFormat("html")
+	assert.Error(t, err, "unregistered formats are rejected")
+}
+
+func TestGetFormats_BuiltinFirst(t *testing.T) {
+	registerTestFormat(t, "html", func(w io.Writer, data interface{}) error { return nil })
+	registerTestFormat(t, "afmt", func(w io.Writer, data interface{}) error { return nil })
+
+	assert.Equal(t, []string{"table", "json", "yaml", "plain", "afmt", "html"}, FormatNames())
+}
@@ -0,0 +1,173 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultDiffContext is the number of unchanged lines shown around changes
+const DefaultDiffContext = 3
+
+// DiffOptions configures diff rendering
+type DiffOptions struct {
+	// OldName and NewName label the two sides in the header; without them
+	// the header is omitted
+	OldName string
+	NewName string
+	// Context is the number of unchanged lines shown around changes
+	Context int
+}
+
+// diffOp is one line of an edit script
+type diffOp struct {
+	kind byte // ' ', '-', or '+'
+	line string
+}
+
+// Diff renders the changes from old to new as a colorized unified diff, so
+// every "what will change" view looks the same. It returns "" when the texts
+// are equal.
+func Diff(old, new string) string {
+	return DiffWithOptions(old, new, DiffOptions{Context: DefaultDiffContext})
+}
+
+// DiffWithOptions renders a unified diff with the given options
+func DiffWithOptions(old, new string, opts DiffOptions) string {
+	if old == new {
+		return ""
+	}
+	ops := diffLines(splitLines(old), splitLines(new))
+	context := max(opts.Context, 0)
+
+	var b strings.Builder
+	if opts.OldName != "" || opts.NewName != "" {
+		b.WriteString(Bold("--- %s", opts.OldName) + "\n")
+		b.WriteString(Bold("+++ %s", opts.NewName) + "\n")
+	}
+
+	for start := 0; start < len(ops); {
+		// Find the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are within twice the context
+		from := max(start-context, 0)
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*context {
+				break
+			}
+		}
+		to := min(end+context, len(ops))
+
+		writeHunk(&b, ops, from, to)
+		start = to
+	}
+	return b.String()
+}
+
+// writeHunk renders ops[from:to] with its @@ header
+func writeHunk(b *strings.Builder, ops []diffOp, from, to int) {
+	oldStart, newStart := 1, 1
+	for _, op := range ops[:from] {
+		if op.kind != '+' {
+			oldStart++
+		}
+		if op.kind != '-' {
+			newStart++
+		}
+	}
+	oldCount, newCount := 0, 0
+	for _, op := range ops[from:to] {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+	// An empty side starts at the line before, as in diff -u
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+
+	b.WriteString(InfoText("@@ -%s +%s @@", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount)) + "\n")
+	for _, op := range ops[from:to] {
+		switch op.kind {
+		case '-':
+			b.WriteString(ErrorText("-%s", op.line) + "\n")
+		case '+':
+			b.WriteString(SuccessText("+%s", op.line) + "\n")
+		default:
+			b.WriteString(" " + op.line + "\n")
+		}
+	}
+}
+
+// hunkRange formats a hunk's start and line count
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// splitLines splits text into lines without their line endings
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes an edit script from a to b using their longest common
+// subsequence
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	assert.Empty(t, Diff("same\n", "same\n"))
+
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	new := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+	assert.Equal(t, `@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -10,3 +10,4 @@
+ j
+ k
+ l
++m
+`, Diff(old, new))
+
+	assert.Equal(t, `--- ~/.glide.yml
++++ ~/.glide.yml (new)
+@@ -1,2 +1,2 @@
+ defaults:
+-  parallel: true
++  parallel: false
+`, DiffWithOptions("defaults:\n  parallel: true\n", "defaults:\n  parallel: false\n", DiffOptions{
+		OldName: "~/.glide.yml",
+		NewName: "~/.glide.yml (new)",
+		Context: DefaultDiffContext,
+	}))
+
+	assert.Equal(t, "@@ -0,0 +1 @@\n+created\n", Diff("", "created\n"))
+	assert.Equal(t, "@@ -1,2 +1 @@\n-x\n y\n", DiffWithOptions("x\ny\n", "y\n", DiffOptions{Context: 1}))
+}
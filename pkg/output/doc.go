@@ -55,9 +55,24 @@
 //	manager := output.NewManager(format, quiet, noColor, writer)
 //	// noColor=true disables all color output
 //
+// When the writer is a file other than a terminal, e.g. stdout piped into
+// grep, the manager is piped: colors are off and Info, Success, Warning, and
+// Error go to stderr, leaving only data on stdout:
+//
+//	if manager.IsPiped() {
+//	    manager.SetFormat(output.FormatPlain)
+//	}
+//
 // Environment variable support:
 //   - NO_COLOR: Disables colors when set
 //   - TERM=dumb: Disables colors
+//   - GLIDE_COLOR_DEPTH: none, 16, 256, or truecolor instead of detecting it
+//
+// Theme colors are defined for truecolor, 256-color and 16-color
+// terminals. InitColors detects the depth and maps the colors SuccessText,
+// InfoText and friends use:
+//
+//	output.SetColorDepth(output.Depth16) // e.g. for a CI log
 //
 // # Quiet Mode
 //
@@ -67,6 +82,18 @@
 //	manager.Info("This is suppressed in quiet mode")
 //	manager.Error("Errors are still shown")
 //
+// # Capturing Output
+//
+// Capture redirects a manager's output into a scoped buffer and records
+// every Info, Success, Warning, Error, Display, Raw, and Table call, so
+// tests and in-process plugins can assert on what was reported:
+//
+//	capture := manager.Capture()
+//	defer capture.Release()
+//	manager.Success("Started %d containers", 3)
+//	capture.Messages(output.RecordSuccess) // ["Started 3 containers"]
+//	capture.String()                       // the rendered text
+//
 // # Progress Indicators
 //
 // Show progress for long operations:
@@ -76,4 +103,34 @@
 //	    progress.Update(i, 100)
 //	}
 //	progress.Complete()
+//
+// # Humanized Values
+//
+// Tables and messages show numbers, sizes, durations, and times the same way
+// everywhere, with the separators of the user's locale (LC_ALL, LC_NUMERIC,
+// or LANG). JSON and YAML output keep the raw values.
+//
+//	output.Number(12345)                         // "12,345" ("12.345" in de_DE)
+//	output.Bytes(1536 * 1024)                    // "1.5MiB"
+//	output.Duration(125 * time.Second)           // "2m 5s"
+//	output.Ago(time.Now().Add(-2 * time.Minute)) // "2m ago"
+//
+// # Hyperlinks
+//
+// In terminals that support OSC 8 hyperlinks, paths and URLs can be made
+// clickable; elsewhere, and with GLIDE_HYPERLINKS=0, they stay plain text:
+//
+//	output.Info("Installed to %s", output.Path(dest))
+//	output.Info("Release notes: %s", output.URL(release.HTMLURL))
+//	output.Raw(output.Linkify(topic.Content))
+//
+// # Accessible Output
+//
+// With --accessible or GLIDE_ACCESSIBLE=1, messages start with labels such
+// as "ERROR:" instead of icons, and Plain rewrites messages, raw text and
+// table cells for screen readers, leaving emoji out and turning box
+// drawing, bullets and arrows into ASCII:
+//
+//	output.SetAccessible(true)
+//	output.Success("🎉 Deployed") // SUCCESS: Deployed
 package output
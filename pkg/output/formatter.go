@@ -52,10 +52,20 @@ func ParseFormat(s string) (Format, error) {
 	case "plain", "text":
 		return FormatPlain, nil
 	default:
+		if globalRegistry.IsRegistered(Format(s)) {
+			return Format(s), nil
+		}
 		return "", fmt.Errorf("unknown format: %s", s)
 	}
 }
 
+// IsStructured reports whether commands display structured data in the
+// format, as for JSON and YAML and the formats added with
+// RegisterDataFormat, rather than tables and text
+func (f Format) IsStructured() bool {
+	return globalRegistry.IsStructured(f)
+}
+
 // BaseFormatter provides common functionality for all formatters
 type BaseFormatter struct {
 	writer  io.Writer
@@ -0,0 +1,176 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale holds the separators numbers are shown with
+type Locale struct {
+	// Decimal separates the fraction, e.g. "." in 1.5
+	Decimal string
+	// Group separates thousands, e.g. "," in 12,345
+	Group string
+}
+
+// DefaultLocale is used for the C and English locales, and for languages
+// without separators of their own below
+var DefaultLocale = Locale{Decimal: ".", Group: ","}
+
+// localeSeparators are the separators of languages that differ from the
+// default, by ISO 639-1 code. Thousands separated by a space use a no-break
+// space, so a number never wraps.
+var localeSeparators = map[string]Locale{
+	"de": {Decimal: ",", Group: "."},
+	"es": {Decimal: ",", Group: "."},
+	"it": {Decimal: ",", Group: "."},
+	"nl": {Decimal: ",", Group: "."},
+	"pt": {Decimal: ",", Group: "."},
+	"da": {Decimal: ",", Group: "."},
+	"tr": {Decimal: ",", Group: "."},
+	"id": {Decimal: ",", Group: "."},
+	"fr": {Decimal: ",", Group: "\u00a0"},
+	"ru": {Decimal: ",", Group: "\u00a0"},
+	"uk": {Decimal: ",", Group: "\u00a0"},
+	"pl": {Decimal: ",", Group: "\u00a0"},
+	"cs": {Decimal: ",", Group: "\u00a0"},
+	"sv": {Decimal: ",", Group: "\u00a0"},
+	"fi": {Decimal: ",", Group: "\u00a0"},
+	"nb": {Decimal: ",", Group: "\u00a0"},
+}
+
+// CurrentLocale returns the separators of the user's locale, from
+// LC_ALL, LC_NUMERIC, or LANG, e.g. de_DE.UTF-8
+func CurrentLocale() Locale {
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return ParseLocale(value)
+		}
+	}
+	return DefaultLocale
+}
+
+// ParseLocale returns the separators of a POSIX locale name such as
+// fr_FR.UTF-8
+func ParseLocale(name string) Locale {
+	language, _, _ := strings.Cut(name, "_")
+	language, _, _ = strings.Cut(language, ".")
+	if locale, ok := localeSeparators[strings.ToLower(language)]; ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Number formats an integer with the locale's thousands separator, e.g.
+// 12,345
+func Number(n int64) string {
+	return CurrentLocale().number(n)
+}
+
+func (l Locale) number(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(digit)
+	}
+	return sign + b.String()
+}
+
+// decimal formats a number with one decimal in the locale
+func (l Locale) decimal(f float64) string {
+	return strings.Replace(strconv.FormatFloat(f, 'f', 1, 64), ".", l.Decimal, 1)
+}
+
+// Bytes formats a byte count with a binary unit, e.g. 1.5MiB
+func Bytes(n uint64) string {
+	return CurrentLocale().bytes(n)
+}
+
+func (l Locale) bytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return l.decimal(float64(n)/float64(div)) + string("KMGTPE"[exp]) + "iB"
+}
+
+// Duration formats a duration in its two largest units, e.g. 450ms, 1.5s,
+// 2m 5s, 3h 20m, or 2d 4h
+func Duration(d time.Duration) string {
+	return CurrentLocale().duration(d)
+}
+
+func (l Locale) duration(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < 0:
+		return "-" + l.duration(-d)
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Round(time.Millisecond)/time.Millisecond)
+	case d < 10*time.Second && d.Round(100*time.Millisecond)%time.Second != 0:
+		return l.decimal(d.Seconds()) + "s"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", d.Round(time.Second)/time.Second)
+	case d < time.Hour:
+		return units(d.Round(time.Second), time.Minute, "m", time.Second, "s")
+	case d < day:
+		return units(d.Round(time.Minute), time.Hour, "h", time.Minute, "m")
+	default:
+		return units(d.Round(time.Hour), day, "d", time.Hour, "h")
+	}
+}
+
+// units formats d in a major and a minor unit, leaving a zero minor one out
+func units(d, major time.Duration, majorSuffix string, minor time.Duration, minorSuffix string) string {
+	whole, rest := d/major, (d%major)/minor
+	if rest == 0 {
+		return fmt.Sprintf("%d%s", whole, majorSuffix)
+	}
+	return fmt.Sprintf("%d%s %d%s", whole, majorSuffix, rest, minorSuffix)
+}
+
+// Ago formats how long ago t was in its largest unit, e.g. 2m ago or
+// 3d ago; times in the future read e.g. in 5m
+func Ago(t time.Time) string {
+	return ago(t, time.Now())
+}
+
+func ago(t, now time.Time) string {
+	const day = 24 * time.Hour
+	d := now.Sub(t)
+	format := "%d%s ago"
+	if d < 0 {
+		d, format = -d, "in %d%s"
+	}
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf(format, d/time.Minute, "m")
+	case d < day:
+		return fmt.Sprintf(format, d/time.Hour, "h")
+	case d < 30*day:
+		return fmt.Sprintf(format, d/day, "d")
+	case d < 365*day:
+		return fmt.Sprintf(format, d/(30*day), "mo")
+	default:
+		return fmt.Sprintf(format, d/(365*day), "y")
+	}
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocale(t *testing.T) {
+	assert.Equal(t, DefaultLocale, ParseLocale("C"))
+	assert.Equal(t, DefaultLocale, ParseLocale("en_US.UTF-8"))
+	assert.Equal(t, Locale{Decimal: ",", Group: "."}, ParseLocale("de_DE.UTF-8"))
+	assert.Equal(t, Locale{Decimal: ",", Group: "\u00a0"}, ParseLocale("fr"))
+}
+
+func TestCurrentLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "de_DE.UTF-8")
+	t.Setenv("LANG", "en_US.UTF-8")
+	assert.Equal(t, "12.345.678", Number(12345678))
+	assert.Equal(t, "1,5MiB", Bytes(1536*1024))
+	assert.Equal(t, "2,5s", Duration(2500*time.Millisecond))
+
+	t.Setenv("LC_ALL", "C")
+	assert.Equal(t, "12,345,678", Number(12345678))
+	assert.Equal(t, "1.5MiB", Bytes(1536*1024))
+}
+
+func TestNumber(t *testing.T) {
+	l := DefaultLocale
+	assert.Equal(t, "0", l.number(0))
+	assert.Equal(t, "999", l.number(999))
+	assert.Equal(t, "1,000", l.number(1000))
+	assert.Equal(t, "-123,456", l.number(-123456))
+}
+
+func TestBytes(t *testing.T) {
+	l := DefaultLocale
+	assert.Equal(t, "0B", l.bytes(0))
+	assert.Equal(t, "1023B", l.bytes(1023))
+	assert.Equal(t, "1.0KiB", l.bytes(1024))
+	assert.Equal(t, "1.5MiB", l.bytes(1536*1024))
+	assert.Equal(t, "2.0GiB", l.bytes(2<<30))
+}
+
+func TestDuration(t *testing.T) {
+	l := DefaultLocale
+	tests := map[time.Duration]string{
+		0:                                "0ms",
+		450 * time.Millisecond:           "450ms",
+		1500 * time.Millisecond:          "1.5s",
+		9 * time.Second:                  "9s",
+		42 * time.Second:                 "42s",
+		2 * time.Minute:                  "2m",
+		2*time.Minute + 5*time.Second:    "2m 5s",
+		3*time.Hour + 20*time.Minute:     "3h 20m",
+		7 * 24 * time.Hour:               "7d",
+		52 * time.Hour:                   "2d 4h",
+		-(2*time.Minute + 5*time.Second): "-2m 5s",
+	}
+	for d, want := range tests {
+		assert.Equal(t, want, l.duration(d), d.String())
+	}
+}
+
+func TestAgo(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, "just now", ago(now.Add(-30*time.Second), now))
+	assert.Equal(t, "2m ago", ago(now.Add(-2*time.Minute), now))
+	assert.Equal(t, "5h ago", ago(now.Add(-5*time.Hour), now))
+	assert.Equal(t, "3d ago", ago(now.Add(-3*24*time.Hour), now))
+	assert.Equal(t, "2mo ago", ago(now.Add(-65*24*time.Hour), now))
+	assert.Equal(t, "1y ago", ago(now.Add(-400*24*time.Hour), now))
+	assert.Equal(t, "in 5m", ago(now.Add(5*time.Minute), now))
+}
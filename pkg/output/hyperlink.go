@@ -0,0 +1,108 @@
+package output
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// HyperlinkEnv turns terminal hyperlinks on (1) or off (0) regardless of
+// what the terminal appears to support
+const HyperlinkEnv = "GLIDE_HYPERLINKS"
+
+// hyperlinkTerminals are the TERM_PROGRAM values of terminals known to show
+// OSC 8 hyperlinks
+var hyperlinkTerminals = []string{"iTerm.app", "WezTerm", "vscode", "Hyper", "ghostty", "Tabby"}
+
+// urlPattern finds web URLs in text; trailing punctuation is trimmed
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"'()]+`)
+
+// HyperlinksSupported reports whether stdout is a terminal that shows
+// OSC 8 hyperlinks. GLIDE_HYPERLINKS overrides the detection.
+func HyperlinksSupported() bool {
+	return hyperlinksSupported(os.Getenv, isTerminal())
+}
+
+func hyperlinksSupported(getenv func(string) string, terminal bool) bool {
+	if forced, err := strconv.ParseBool(getenv(HyperlinkEnv)); err == nil {
+		return forced
+	}
+	term := getenv("TERM")
+	if !terminal || term == "dumb" || getenv("CI") != "" {
+		return false
+	}
+
+	for _, program := range hyperlinkTerminals {
+		if getenv("TERM_PROGRAM") == program {
+			return true
+		}
+	}
+	if getenv("WT_SESSION") != "" || getenv("KONSOLE_VERSION") != "" || getenv("DOMTERM") != "" {
+		return true
+	}
+	if vte, err := strconv.Atoi(getenv("VTE_VERSION")); err == nil && vte >= 5000 {
+		return true
+	}
+	return term == "xterm-kitty" || term == "alacritty" || strings.HasPrefix(term, "foot")
+}
+
+// Link shows text as a hyperlink to target when the terminal supports
+// them, and as plain text otherwise. Pass the URL itself as text when the
+// reader needs it without hyperlinks. Table cells must stay plain, as
+// their widths would count the escape codes.
+func Link(target, text string) string {
+	if !HyperlinksSupported() {
+		return text
+	}
+	return hyperlink(target, text)
+}
+
+// hyperlink wraps text in the OSC 8 escape codes linking it to target
+func hyperlink(target, text string) string {
+	return "\x1b]8;;" + target + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// URL shows a URL as a hyperlink to itself
+func URL(u string) string {
+	return Link(u, u)
+}
+
+// Path shows a file path as a hyperlink that opens it
+func Path(path string) string {
+	if !HyperlinksSupported() {
+		return path
+	}
+	return hyperlink(fileURL(path), path)
+}
+
+// fileURL returns the file:// URL of a path on this host
+func fileURL(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows drive letters
+	}
+	hostname, _ := os.Hostname()
+	return (&url.URL{Scheme: "file", Host: hostname, Path: path}).String()
+}
+
+// Linkify shows every web URL in text as a hyperlink, e.g. in release
+// notes or help topics
+func Linkify(text string) string {
+	if !HyperlinksSupported() {
+		return text
+	}
+	return linkify(text)
+}
+
+func linkify(text string) string {
+	return urlPattern.ReplaceAllStringFunc(text, func(match string) string {
+		u := strings.TrimRight(match, ".,;:!?")
+		return hyperlink(u, u) + match[len(u):]
+	})
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHyperlinksSupported(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	assert.False(t, hyperlinksSupported(env(nil), true), "unknown terminals get plain text")
+	assert.True(t, hyperlinksSupported(env(map[string]string{"TERM_PROGRAM": "iTerm.app"}), true))
+	assert.True(t, hyperlinksSupported(env(map[string]string{"VTE_VERSION": "7200"}), true))
+	assert.False(t, hyperlinksSupported(env(map[string]string{"VTE_VERSION": "4600"}), true))
+	assert.True(t, hyperlinksSupported(env(map[string]string{"TERM": "xterm-kitty"}), true))
+	assert.False(t, hyperlinksSupported(env(map[string]string{"TERM_PROGRAM": "WezTerm"}), false),
+		"output that is not a terminal gets plain text")
+	assert.False(t, hyperlinksSupported(env(map[string]string{"TERM_PROGRAM": "WezTerm", "CI": "true"}), true))
+
+	assert.True(t, hyperlinksSupported(env(map[string]string{HyperlinkEnv: "1"}), false))
+	assert.False(t, hyperlinksSupported(env(map[string]string{HyperlinkEnv: "0", "TERM_PROGRAM": "WezTerm"}), true))
+}
+
+func TestLink(t *testing.T) {
+	t.Setenv(HyperlinkEnv, "0")
+	assert.Equal(t, "release notes", Link("https://example.com/v3", "release notes"))
+	assert.Equal(t, "https://example.com", URL("https://example.com"))
+	assert.Equal(t, "/tmp/glide", Path("/tmp/glide"))
+	assert.Equal(t, "See https://example.com.", Linkify("See https://example.com."))
+
+	t.Setenv(HyperlinkEnv, "1")
+	assert.Equal(t, "\x1b]8;;https://example.com/v3\x1b\\release notes\x1b]8;;\x1b\\", Link("https://example.com/v3", "release notes"))
+	assert.True(t, strings.HasPrefix(Path("/tmp/my plugins"), "\x1b]8;;file://"))
+	assert.Contains(t, Path("/tmp/my plugins"), "/tmp/my%20plugins\x1b\\/tmp/my plugins")
+}
+
+func TestLinkify(t *testing.T) {
+	assert.Equal(t,
+		"See "+hyperlink("https://example.com/docs", "https://example.com/docs")+", or "+
+			hyperlink("http://localhost:8080/a?b=c", "http://localhost:8080/a?b=c")+".",
+		linkify("See https://example.com/docs, or http://localhost:8080/a?b=c."))
+	assert.Equal(t, "no links here", linkify("no links here"))
+}
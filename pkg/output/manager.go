@@ -10,45 +10,85 @@ import (
 // Manager manages output formatting
 type Manager struct {
 	formatter Formatter
+	// messages formats Info, Success, Warning, and Error; it writes to
+	// errWriter when stdout is piped, and is formatter otherwise
+	messages  Formatter
 	format    Format
 	quiet     bool
 	noColor   bool
 	writer    io.Writer
+	errWriter io.Writer
+	piped     bool
+	noTrunc   bool
+	capture   *Capture
 	mu        sync.RWMutex
+
+	// warnings are the messages of every Warning, for run reports
+	warnings []string
+	warnMu   sync.Mutex
 }
 
-// NewManager creates a new output manager
+// NewManager creates a new output manager. When writer is a file that is
+// not a terminal, e.g. stdout piped into grep, the manager is piped: colors
+// are disabled and human messages go to stderr, leaving only data on
+// writer.
 func NewManager(format Format, quiet, noColor bool, writer io.Writer) *Manager {
 	if writer == nil {
 		writer = os.Stdout
 	}
 
 	m := &Manager{
-		format:  format,
-		quiet:   quiet,
-		noColor: noColor,
-		writer:  writer,
+		format:    format,
+		quiet:     quiet,
+		noColor:   noColor,
+		writer:    writer,
+		errWriter: os.Stderr,
+		piped:     isPipedWriter(writer),
 	}
 
 	// Initialize colors based on settings
-	if noColor {
+	if noColor || m.piped {
 		DisableColors()
 	} else {
 		InitColors()
 	}
 
 	// Create the appropriate formatter
-	m.formatter = m.createFormatter()
+	m.createFormatters()
 
 	return m
 }
 
+// isPipedWriter reports whether w is a file other than a terminal. Writers
+// that are not files, such as buffers in tests, are never piped.
+func isPipedWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
+
+// createFormatters creates the data and message formatters for the current
+// settings
+func (m *Manager) createFormatters() {
+	m.formatter = m.createFormatter(m.writer)
+	m.messages = m.formatter
+	if m.piped {
+		m.messages = m.createFormatter(m.errWriter)
+	}
+}
+
 // createFormatter creates a formatter based on the current format setting using the registry
-func (m *Manager) createFormatter() Formatter {
-	formatter, err := CreateFormatter(m.format, m.writer, m.noColor, m.quiet)
+func (m *Manager) createFormatter(w io.Writer) Formatter {
+	formatter, err := CreateFormatter(m.format, w, m.noColor || m.piped, m.quiet)
 	if err != nil {
 		// Fallback to table formatter if format not found
-		return NewTableFormatter(m.writer, m.noColor, m.quiet)
+		formatter = NewTableFormatter(w, m.noColor || m.piped, m.quiet)
+	}
+	if table, ok := formatter.(*TableFormatter); ok {
+		table.noTrunc = m.noTrunc
 	}
 	return formatter
 }
@@ -58,7 +98,7 @@ func (m *Manager) SetFormat(format Format) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.format = format
-	m.formatter = m.createFormatter()
+	m.createFormatters()
 }
 
 // SetQuiet enables or disables quiet mode
@@ -66,7 +106,7 @@ func (m *Manager) SetQuiet(quiet bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.quiet = quiet
-	m.formatter = m.createFormatter()
+	m.createFormatters()
 }
 
 // SetNoColor enables or disables color output
@@ -74,12 +114,12 @@ func (m *Manager) SetNoColor(noColor bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.noColor = noColor
-	if noColor {
+	if noColor || m.piped {
 		DisableColors()
 	} else {
 		EnableColors()
 	}
-	m.formatter = m.createFormatter()
+	m.createFormatters()
 }
 
 // SetWriter sets the output writer
@@ -87,13 +127,47 @@ func (m *Manager) SetWriter(w io.Writer) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.writer = w
-	m.formatter.SetWriter(w)
+	m.piped = isPipedWriter(w)
+	m.createFormatters()
+}
+
+// SetErrorWriter sets where human messages go while stdout is piped
+// (default: stderr)
+func (m *Manager) SetErrorWriter(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errWriter = w
+	m.createFormatters()
+}
+
+// SetNoTrunc disables truncating table cells to fit the terminal
+func (m *Manager) SetNoTrunc(noTrunc bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.noTrunc = noTrunc
+	m.createFormatters()
+}
+
+// NoTrunc returns whether table cells are printed in full
+func (m *Manager) NoTrunc() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.noTrunc
+}
+
+// IsPiped reports whether stdout is piped or redirected rather than a
+// terminal
+func (m *Manager) IsPiped() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.piped
 }
 
 // Display outputs data using the current formatter
 func (m *Manager) Display(data interface{}) error {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	m.capture.record(RecordDisplay, "", data)
 	return m.formatter.Display(data)
 }
 
@@ -101,37 +175,64 @@ func (m *Manager) Display(data interface{}) error {
 func (m *Manager) Info(format string, args ...interface{}) error {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.formatter.Info(format, args...)
+	m.capture.recordf(RecordInfo, format, args...)
+	return m.messages.Info(format, args...)
 }
 
 // Success outputs a success message
 func (m *Manager) Success(format string, args ...interface{}) error {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.formatter.Success(format, args...)
+	m.capture.recordf(RecordSuccess, format, args...)
+	return m.messages.Success(format, args...)
 }
 
 // Error outputs an error message
 func (m *Manager) Error(format string, args ...interface{}) error {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.formatter.Error(format, args...)
+	m.capture.recordf(RecordError, format, args...)
+	return m.messages.Error(format, args...)
 }
 
 // Warning outputs a warning message
 func (m *Manager) Warning(format string, args ...interface{}) error {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.formatter.Warning(format, args...)
+	m.capture.recordf(RecordWarning, format, args...)
+	m.warnMu.Lock()
+	m.warnings = append(m.warnings, fmt.Sprintf(format, args...))
+	m.warnMu.Unlock()
+	return m.messages.Warning(format, args...)
+}
+
+// Warnings returns the message of every warning the manager has output,
+// including quiet and captured ones
+func (m *Manager) Warnings() []string {
+	m.warnMu.Lock()
+	defer m.warnMu.Unlock()
+	return append([]string{}, m.warnings...)
 }
 
 // Raw outputs raw text
 func (m *Manager) Raw(text string) error {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	m.capture.record(RecordRaw, text, nil)
 	return m.formatter.Raw(text)
 }
 
+// Table renders a table on the output writer
+func (m *Manager) Table(table *Table) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.capture.record(RecordTable, "", table)
+	if m.quiet {
+		return nil
+	}
+	return table.Render(m.writer)
+}
+
 // Printf is a convenience method that formats and outputs text
 func (m *Manager) Printf(format string, args ...interface{}) error {
 	text := fmt.Sprintf(format, args...)
@@ -2,6 +2,8 @@ package output
 
 import (
 	"bytes"
+	"io"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -160,6 +162,17 @@ func TestManagerMessages(t *testing.T) {
 	}
 }
 
+func TestManagerWarnings(t *testing.T) {
+	buf := &bytes.Buffer{}
+	manager := NewManager(FormatTable, true, false, buf)
+
+	require.NoError(t, manager.Warning("Port %d is in use", 8080))
+	manager.Capture().Release()
+	require.NoError(t, manager.Warning("Plugin %s is outdated", "db"))
+
+	assert.Equal(t, []string{"Port 8080 is in use", "Plugin db is outdated"}, manager.Warnings())
+}
+
 func TestManagerRaw(t *testing.T) {
 	t.Run("outputs raw text", func(t *testing.T) {
 		buf := &bytes.Buffer{}
@@ -319,6 +332,40 @@ func TestNoGlobalState(t *testing.T) {
 	})
 }
 
+func TestManagerPiped(t *testing.T) {
+	t.Run("buffers are not piped", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		manager := NewManager(FormatTable, false, false, buf)
+		assert.False(t, manager.IsPiped())
+
+		require.NoError(t, manager.Info("message"))
+		assert.Contains(t, buf.String(), "message")
+	})
+
+	t.Run("piped stdout keeps messages on stderr", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		defer r.Close()
+
+		stderr := &bytes.Buffer{}
+		manager := NewManager(FormatPlain, false, false, w)
+		manager.SetErrorWriter(stderr)
+		assert.True(t, manager.IsPiped())
+
+		require.NoError(t, manager.Info("starting"))
+		require.NoError(t, manager.Warning("careful"))
+		require.NoError(t, manager.Display("data"))
+		require.NoError(t, w.Close())
+
+		piped, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "data\n", string(piped))
+		assert.Contains(t, stderr.String(), "starting")
+		assert.Contains(t, stderr.String(), "careful")
+		assert.NotContains(t, stderr.String(), "\x1b[", "piped output has no colors")
+	})
+}
+
 func TestTemporaryGlobalFunctions(t *testing.T) {
 	t.Run("global functions use global manager", func(t *testing.T) {
 		// Set up a test global manager
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Page shows content through a pager when it is longer than the terminal
+// on stdout, and prints it directly otherwise. The pager is GLIDE_PAGER,
+// then PAGER, then "less -R" (which keeps colors); setting either variable
+// to an empty string or "cat" turns paging off.
+func Page(content string) error {
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	pager := pagerCommand()
+	if pager == "" || !exceedsTerminal(content) {
+		_, err := io.WriteString(os.Stdout, content)
+		return err
+	}
+
+	args := strings.Fields(pager)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		// Without a working pager the content is still worth showing
+		_, err = io.WriteString(os.Stdout, content)
+		return err
+	}
+	return nil
+}
+
+// pagerCommand returns the pager to run, or "" to print directly
+func pagerCommand() string {
+	for _, env := range []string{"GLIDE_PAGER", "PAGER"} {
+		if value, ok := os.LookupEnv(env); ok {
+			if value = strings.TrimSpace(value); value == "cat" {
+				return ""
+			}
+			return value
+		}
+	}
+	if _, err := exec.LookPath("less"); err != nil {
+		return ""
+	}
+	return "less -R"
+}
+
+// exceedsTerminal reports whether content has more lines than the terminal
+// on stdout; it is false when stdout is not a terminal
+func exceedsTerminal(content string) bool {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return false
+	}
+	_, height, err := term.GetSize(fd)
+	return err == nil && height > 0 && strings.Count(content, "\n") >= height
+}
@@ -146,29 +146,47 @@ func (f *PlainFormatter) displaySlice(v reflect.Value) error {
 
 // Info outputs informational messages
 func (f *PlainFormatter) Info(format string, args ...interface{}) error {
-	msg := fmt.Sprintf(format, args...)
-	return f.write(fmt.Sprintf("[INFO] %s\n", msg))
+	msg := Plain(fmt.Sprintf(format, args...))
+	return f.write(fmt.Sprintf("%s %s\n", plainLabel(IconInfo), msg))
 }
 
 // Success outputs success messages
 func (f *PlainFormatter) Success(format string, args ...interface{}) error {
-	msg := fmt.Sprintf(format, args...)
-	return f.write(fmt.Sprintf("[OK] %s\n", msg))
+	msg := Plain(fmt.Sprintf(format, args...))
+	return f.write(fmt.Sprintf("%s %s\n", plainLabel(IconSuccess), msg))
 }
 
 // Error outputs error messages
 func (f *PlainFormatter) Error(format string, args ...interface{}) error {
-	msg := fmt.Sprintf(format, args...)
-	return f.writeError(fmt.Sprintf("[ERROR] %s\n", msg))
+	msg := Plain(fmt.Sprintf(format, args...))
+	return f.writeError(fmt.Sprintf("%s %s\n", plainLabel(IconError), msg))
 }
 
 // Warning outputs warning messages
 func (f *PlainFormatter) Warning(format string, args ...interface{}) error {
-	msg := fmt.Sprintf(format, args...)
-	return f.write(fmt.Sprintf("[WARN] %s\n", msg))
+	msg := Plain(fmt.Sprintf(format, args...))
+	return f.write(fmt.Sprintf("%s %s\n", plainLabel(IconWarning), msg))
 }
 
 // Raw outputs raw text without any formatting
 func (f *PlainFormatter) Raw(text string) error {
-	return f.write(text)
+	return f.write(Plain(text))
+}
+
+// plainLabel returns the bracketed ASCII label for a message icon, or the
+// spoken label in accessible mode
+func plainLabel(icon string) string {
+	if Accessible() {
+		return GetIcon(icon)
+	}
+	switch icon {
+	case IconSuccess:
+		return IconSuccessASCII
+	case IconError:
+		return IconErrorASCII
+	case IconWarning:
+		return IconWarningASCII
+	default:
+		return IconInfoASCII
+	}
 }
@@ -46,10 +46,17 @@ func NewSpinner(message string) *Spinner {
 	}
 }
 
-// Start begins the spinner animation
+// Start begins the spinner animation. Spinners stay hidden while stdout is
+// piped, and in accessible mode the message is printed once instead.
 func (s *Spinner) Start() {
 	s.mu.Lock()
-	if s.stopped {
+	if s.stopped || IsPiped() {
+		s.mu.Unlock()
+		return
+	}
+	if Accessible() {
+		// Safe to ignore: Spinner display errors don't affect program operation.
+		_, _ = fmt.Fprintf(s.writer, "%s...\n", Plain(s.message))
 		s.mu.Unlock()
 		return
 	}
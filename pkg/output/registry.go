@@ -3,6 +3,8 @@ package output
 import (
 	"fmt"
 	"io"
+	"sort"
+	"sync"
 
 	"github.com/glide-cli/glide/v3/pkg/registry"
 )
@@ -13,6 +15,11 @@ type Factory func(w io.Writer, noColor, quiet bool) Formatter
 // Registry manages formatter registration and creation
 type Registry struct {
 	*registry.Registry[Factory]
+
+	// structured holds the formats commands display structured data in,
+	// as for JSON, rather than tables and text
+	structured   map[string]bool
+	structuredMu sync.RWMutex
 }
 
 // globalRegistry is the default registry instance
@@ -21,7 +28,8 @@ var globalRegistry = NewRegistry()
 // NewRegistry creates a new formatter registry
 func NewRegistry() *Registry {
 	return &Registry{
-		Registry: registry.New[Factory](),
+		Registry:   registry.New[Factory](),
+		structured: make(map[string]bool),
 	}
 }
 
@@ -30,6 +38,26 @@ func (r *Registry) Register(format Format, factory Factory) error {
 	return r.Registry.Register(string(format), factory)
 }
 
+// RegisterStructured adds a formatter factory for a format that commands
+// display structured data in, as for JSON
+func (r *Registry) RegisterStructured(format Format, factory Factory) error {
+	if err := r.Register(format, factory); err != nil {
+		return err
+	}
+	r.structuredMu.Lock()
+	defer r.structuredMu.Unlock()
+	r.structured[string(format)] = true
+	return nil
+}
+
+// IsStructured reports whether format was registered with
+// RegisterStructured
+func (r *Registry) IsStructured(format Format) bool {
+	r.structuredMu.RLock()
+	defer r.structuredMu.RUnlock()
+	return r.structured[string(format)]
+}
+
 // Create creates a formatter instance for the given format
 func (r *Registry) Create(format Format, w io.Writer, noColor, quiet bool) (Formatter, error) {
 	factory, ok := r.Get(string(format))
@@ -45,24 +73,33 @@ func (r *Registry) IsRegistered(format Format) bool {
 	return r.Has(string(format))
 }
 
-// GetFormats returns all registered formats
+// GetFormats returns all registered formats, the built-in ones first
 func (r *Registry) GetFormats() []Format {
+	builtin := map[Format]int{FormatTable: 0, FormatJSON: 1, FormatYAML: 2, FormatPlain: 3}
 	names := r.ListNames()
 	formats := make([]Format, len(names))
 	for i, name := range names {
 		formats[i] = Format(name)
 	}
+	sort.SliceStable(formats, func(i, j int) bool {
+		oi, iBuiltin := builtin[formats[i]]
+		oj, jBuiltin := builtin[formats[j]]
+		if iBuiltin != jBuiltin {
+			return iBuiltin
+		}
+		return iBuiltin && oi < oj
+	})
 	return formats
 }
 
 // InitDefaultRegistry initializes the default formatter registry
 func InitDefaultRegistry() {
 	// Register all built-in formatters
-	globalRegistry.Register(FormatJSON, func(w io.Writer, noColor, quiet bool) Formatter {
+	globalRegistry.RegisterStructured(FormatJSON, func(w io.Writer, noColor, quiet bool) Formatter {
 		return NewJSONFormatter(w, noColor, quiet)
 	})
 
-	globalRegistry.Register(FormatYAML, func(w io.Writer, noColor, quiet bool) Formatter {
+	globalRegistry.RegisterStructured(FormatYAML, func(w io.Writer, noColor, quiet bool) Formatter {
 		return NewYAMLFormatter(w, noColor, quiet)
 	})
 
@@ -85,6 +122,23 @@ func RegisterFormatter(format Format, factory Factory) error {
 	return globalRegistry.Register(format, factory)
 }
 
+// GetFormats returns the formats registered in the global registry, the
+// built-in ones first
+func GetFormats() []Format {
+	return globalRegistry.GetFormats()
+}
+
+// FormatNames returns the names of the formats in the global registry, for
+// --format's help and completion
+func FormatNames() []string {
+	formats := GetFormats()
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = string(f)
+	}
+	return names
+}
+
 // CreateFormatter creates a formatter from the global registry
 func CreateFormatter(format Format, w io.Writer, noColor, quiet bool) (Formatter, error) {
 	return globalRegistry.Create(format, w, noColor, quiet)
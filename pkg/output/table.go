@@ -5,14 +5,18 @@ import (
 	"io"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"text/tabwriter"
+	"unicode/utf8"
 )
 
 // TableFormatter formats output as human-readable tables
 type TableFormatter struct {
 	*BaseFormatter
 	writer *tabwriter.Writer
+	// noTrunc prints table cells in full instead of fitting the terminal
+	noTrunc bool
 }
 
 // NewTableFormatter creates a new table formatter
@@ -82,34 +86,38 @@ func (f *TableFormatter) displayTable(data []map[string]interface{}) error {
 	for key := range data[0] {
 		headers = append(headers, key)
 	}
+	sort.Strings(headers)
 
-	// Write headers
-	headerLine := strings.Join(headers, "\t") + "\n"
-	if err := f.write(Bold("%s", headerLine)); err != nil {
-		return err
-	}
-
-	// Write separator
-	var separators []string
-	for range headers {
-		separators = append(separators, strings.Repeat("-", 10))
-	}
-	if err := f.write(strings.Join(separators, "\t") + "\n"); err != nil {
-		return err
-	}
-
-	// Write data rows
+	table := f.newTable(headers)
 	for _, row := range data {
 		var values []string
 		for _, header := range headers {
 			values = append(values, fmt.Sprintf("%v", row[header]))
 		}
-		if err := f.write(strings.Join(values, "\t") + "\n"); err != nil {
-			return err
-		}
+		table.AddRow(values...)
 	}
+	return f.writeTable(table)
+}
 
-	return f.writer.Flush()
+// newTable creates a table fitting the writer's terminal, with a separator
+// under the headers
+func (f *TableFormatter) newTable(headers []string) *Table {
+	table := &Table{Headers: headers, MaxWidth: terminalWidth(f.BaseFormatter.writer), NoTrunc: f.noTrunc}
+	var separators []string
+	for _, header := range headers {
+		separators = append(separators, strings.Repeat("-", utf8.RuneCountInString(header)))
+	}
+	table.AddRow(separators...)
+	return table
+}
+
+// writeTable renders a table unless quiet
+func (f *TableFormatter) writeTable(table *Table) error {
+	var b strings.Builder
+	if err := table.Render(&b); err != nil {
+		return err
+	}
+	return f.write(b.String())
 }
 
 // displayReflect uses reflection to display structs
@@ -205,21 +213,7 @@ func (f *TableFormatter) displaySlice(v reflect.Value) error {
 		fieldIndices = append(fieldIndices, i)
 	}
 
-	// Write headers
-	if err := f.write(Bold("%s", strings.Join(headers, "\t")+"\n")); err != nil {
-		return err
-	}
-
-	// Write separator
-	var separators []string
-	for range headers {
-		separators = append(separators, strings.Repeat("-", 10))
-	}
-	if err := f.write(strings.Join(separators, "\t") + "\n"); err != nil {
-		return err
-	}
-
-	// Write data rows
+	table := f.newTable(headers)
 	for i := 0; i < v.Len(); i++ {
 		elem := v.Index(i)
 		if elem.Kind() == reflect.Ptr {
@@ -230,43 +224,40 @@ func (f *TableFormatter) displaySlice(v reflect.Value) error {
 		for _, idx := range fieldIndices {
 			values = append(values, fmt.Sprintf("%v", elem.Field(idx).Interface()))
 		}
-		if err := f.write(strings.Join(values, "\t") + "\n"); err != nil {
-			return err
-		}
+		table.AddRow(values...)
 	}
-
-	return f.writer.Flush()
+	return f.writeTable(table)
 }
 
 // Info outputs informational messages
 func (f *TableFormatter) Info(format string, args ...interface{}) error {
-	msg := fmt.Sprintf(format, args...)
+	msg := Plain(fmt.Sprintf(format, args...))
 	icon := GetIcon(IconInfo)
 	return f.write(fmt.Sprintf("%s %s\n", InfoText("%s", icon), msg))
 }
 
 // Success outputs success messages
 func (f *TableFormatter) Success(format string, args ...interface{}) error {
-	msg := fmt.Sprintf(format, args...)
+	msg := Plain(fmt.Sprintf(format, args...))
 	icon := GetIcon(IconSuccess)
 	return f.write(fmt.Sprintf("%s %s\n", SuccessText("%s", icon), msg))
 }
 
 // Error outputs error messages
 func (f *TableFormatter) Error(format string, args ...interface{}) error {
-	msg := fmt.Sprintf(format, args...)
+	msg := Plain(fmt.Sprintf(format, args...))
 	icon := GetIcon(IconError)
 	return f.writeError(fmt.Sprintf("%s %s\n", ErrorText("%s", icon), msg))
 }
 
 // Warning outputs warning messages
 func (f *TableFormatter) Warning(format string, args ...interface{}) error {
-	msg := fmt.Sprintf(format, args...)
+	msg := Plain(fmt.Sprintf(format, args...))
 	icon := GetIcon(IconWarning)
 	return f.write(fmt.Sprintf("%s %s\n", WarningText("%s", icon), msg))
 }
 
 // Raw outputs raw text without formatting
 func (f *TableFormatter) Raw(text string) error {
-	return f.write(text)
+	return f.write(Plain(text))
 }
@@ -60,11 +60,21 @@ func Warning(format string, args ...interface{}) error {
 	return getGlobalManager().Warning(format, args...)
 }
 
+// Warnings returns the warnings output through the global manager
+func Warnings() []string {
+	return getGlobalManager().Warnings()
+}
+
 // Raw outputs raw text
 func Raw(text string) error {
 	return getGlobalManager().Raw(text)
 }
 
+// PrintTable renders a table using the global manager
+func PrintTable(table *Table) error {
+	return getGlobalManager().Table(table)
+}
+
 // Printf formats and outputs text
 func Printf(format string, args ...interface{}) error {
 	return getGlobalManager().Printf(format, args...)
@@ -94,3 +104,23 @@ func SetNoColor(noColor bool) {
 func IsQuiet() bool {
 	return getGlobalManager().IsQuiet()
 }
+
+// GetFormat returns the global output format
+func GetFormat() Format {
+	return getGlobalManager().GetFormat()
+}
+
+// IsPiped returns whether the global output is piped rather than a terminal
+func IsPiped() bool {
+	return getGlobalManager().IsPiped()
+}
+
+// SetNoTrunc disables truncating table cells globally
+func SetNoTrunc(noTrunc bool) {
+	getGlobalManager().SetNoTrunc(noTrunc)
+}
+
+// NoTrunc returns whether table cells are printed in full globally
+func NoTrunc() bool {
+	return getGlobalManager().NoTrunc()
+}
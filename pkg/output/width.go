@@ -0,0 +1,228 @@
+package output
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+const (
+	// columnGap is the space between table columns
+	columnGap = 2
+
+	// minColumnWidth is the narrowest a column is truncated to, unless its
+	// header is narrower still
+	minColumnWidth = 8
+
+	// ellipsis marks truncated cells
+	ellipsis = "…"
+)
+
+// TerminalWidth returns the width of the terminal on stdout, or 0 when
+// stdout is not a terminal and tables are not width-limited. COLUMNS
+// overrides a width that cannot be measured.
+func TerminalWidth() int {
+	return terminalWidth(os.Stdout)
+}
+
+// terminalWidth returns the width of the terminal w writes to, or 0
+func terminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok {
+		return 0
+	}
+	fd := int(f.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	if width, _, err := term.GetSize(fd); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
+
+// Table renders rows in aligned columns that fit a maximum width. Cells of
+// the widest columns are truncated with an ellipsis, or wrapped onto
+// continuation lines, until the table fits.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+	// MaxWidth is the width to fit; 0 means unlimited
+	MaxWidth int
+	// NoTrunc prints every cell in full, e.g. for --no-trunc
+	NoTrunc bool
+	// Wrap wraps long cells instead of truncating them
+	Wrap bool
+}
+
+// NewTable creates a table fitting the terminal that honors --no-trunc
+func NewTable(headers ...string) *Table {
+	return &Table{Headers: headers, MaxWidth: TerminalWidth(), NoTrunc: NoTrunc()}
+}
+
+// AddRow appends a row
+func (t *Table) AddRow(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Render writes the table to w
+func (t *Table) Render(w io.Writer) error {
+	rows := t.Rows
+	if len(t.Headers) > 0 {
+		rows = append([][]string{t.Headers}, rows...)
+	}
+	if Accessible() {
+		rows = plainRows(rows)
+	}
+	widths := t.columnWidths(rows)
+
+	var b strings.Builder
+	for i, row := range rows {
+		header := i == 0 && len(t.Headers) > 0
+		for _, line := range t.layoutRow(row, widths, header) {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// plainRows returns a copy of rows with every cell rewritten by Plain
+func plainRows(rows [][]string) [][]string {
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		out[i] = make([]string, len(row))
+		for c, cell := range row {
+			out[i][c] = plain(cell)
+		}
+	}
+	return out
+}
+
+// columnWidths returns the width of each column: its widest cell, narrowed
+// as evenly as possible to fit MaxWidth
+func (t *Table) columnWidths(rows [][]string) []int {
+	var natural, minimum []int
+	for _, row := range rows {
+		for c, cell := range row {
+			if c == len(natural) {
+				natural = append(natural, 0)
+				minimum = append(minimum, 0)
+			}
+			natural[c] = max(natural[c], utf8.RuneCountInString(cell))
+		}
+	}
+	if t.NoTrunc || t.MaxWidth <= 0 || fits(natural, t.MaxWidth) {
+		return natural
+	}
+
+	for c := range minimum {
+		minimum[c] = min(natural[c], minColumnWidth)
+		if c < len(t.Headers) {
+			minimum[c] = min(natural[c], max(minimum[c], utf8.RuneCountInString(t.Headers[c])))
+		}
+	}
+
+	// Find the largest cap on column widths for which the table fits
+	capped := func(limit int) []int {
+		widths := make([]int, len(natural))
+		for c := range natural {
+			widths[c] = max(min(natural[c], limit), minimum[c])
+		}
+		return widths
+	}
+	low, high := 0, t.MaxWidth
+	for low < high {
+		mid := (low + high + 1) / 2
+		if fits(capped(mid), t.MaxWidth) {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return capped(low)
+}
+
+// fits reports whether columns of the widths fit in maxWidth
+func fits(widths []int, maxWidth int) bool {
+	total := columnGap * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	return total <= maxWidth
+}
+
+// layoutRow renders a row as one line, or several when cells wrap. Headers
+// are bold and never wrapped.
+func (t *Table) layoutRow(row []string, widths []int, header bool) []string {
+	cells := make([][]string, len(row))
+	lines := 1
+	for c, cell := range row {
+		switch {
+		case utf8.RuneCountInString(cell) <= widths[c]:
+			cells[c] = []string{cell}
+		case t.Wrap && !header:
+			cells[c] = wrapCell(cell, widths[c])
+		default:
+			cells[c] = []string{truncateCell(cell, widths[c])}
+		}
+		lines = max(lines, len(cells[c]))
+	}
+
+	out := make([]string, lines)
+	for l := range out {
+		var b strings.Builder
+		for c := range cells {
+			cell := ""
+			if l < len(cells[c]) {
+				cell = cells[c][l]
+			}
+			if header {
+				b.WriteString(Bold("%s", cell))
+			} else {
+				b.WriteString(cell)
+			}
+			if c < len(cells)-1 {
+				b.WriteString(strings.Repeat(" ", widths[c]-utf8.RuneCountInString(cell)+columnGap))
+			}
+		}
+		out[l] = strings.TrimRight(b.String(), " ")
+	}
+	return out
+}
+
+// truncateCell shortens s to width runes, ending in an ellipsis
+func truncateCell(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + ellipsis
+}
+
+// wrapCell splits s into lines of at most width runes, breaking at spaces
+// where possible
+func wrapCell(s string, width int) []string {
+	var lines []string
+	runes := []rune(s)
+	for len(runes) > width {
+		cut := width
+		for i := width; i > 0; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	return append(lines, string(runes))
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renderTable renders a plugin table of the given width without colors
+func renderTable(t *testing.T, table *Table) []string {
+	t.Helper()
+	noColor := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	var buf bytes.Buffer
+	require.NoError(t, table.Render(&buf))
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
+func pluginTable(maxWidth int) *Table {
+	table := &Table{Headers: []string{"NAME", "VERSION", "DESCRIPTION"}, MaxWidth: maxWidth}
+	table.AddRow("docker", "1.2.0", "Manage Docker Compose services for every worktree of the project")
+	table.AddRow("db", "0.3.1", "Database tools")
+	return table
+}
+
+func TestTable_Aligns(t *testing.T) {
+	lines := renderTable(t, pluginTable(0))
+	assert.Equal(t, []string{
+		"NAME    VERSION  DESCRIPTION",
+		"docker  1.2.0    Manage Docker Compose services for every worktree of the project",
+		"db      0.3.1    Database tools",
+	}, lines)
+}
+
+func TestTable_Truncates(t *testing.T) {
+	lines := renderTable(t, pluginTable(40))
+	for _, line := range lines {
+		assert.LessOrEqual(t, utf8.RuneCountInString(line), 40, line)
+	}
+	assert.Equal(t, "docker  1.2.0    Manage Docker Compose …", lines[1])
+	assert.Equal(t, "db      0.3.1    Database tools", lines[2])
+
+	table := pluginTable(40)
+	table.NoTrunc = true
+	assert.Equal(t, renderTable(t, pluginTable(0)), renderTable(t, table))
+}
+
+func TestTable_Wraps(t *testing.T) {
+	table := pluginTable(40)
+	table.Wrap = true
+	lines := renderTable(t, table)
+	assert.Equal(t, []string{
+		"NAME    VERSION  DESCRIPTION",
+		"docker  1.2.0    Manage Docker Compose",
+		"                 services for every",
+		"                 worktree of the project",
+		"db      0.3.1    Database tools",
+	}, lines)
+}
+
+func TestTable_NarrowsWidestColumnsFirst(t *testing.T) {
+	table := &Table{Headers: []string{"NAME", "PATH"}, MaxWidth: 30}
+	table.AddRow("a-rather-long-plugin-name", "/usr/local/lib/glide/plugins/a-rather-long-plugin-name")
+	lines := renderTable(t, table)
+	assert.Equal(t, "a-rather-long…  /usr/local/li…", lines[1])
+}
@@ -0,0 +1,154 @@
+package performance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// DefaultBenchmarkTolerance is how far past its budget a benchmark may
+	// run before CheckBenchmark reports it, absorbing CI noise. It matches
+	// the regression threshold of the benchmark workflow.
+	DefaultBenchmarkTolerance = 0.15
+
+	// BenchmarkModeEnv names the environment variable that, set to "warn",
+	// makes CheckBenchmark mark benchmarks over budget instead of failing
+	// them
+	BenchmarkModeEnv = "GLIDE_BENCH_BUDGETS"
+
+	// BenchmarkReportEnv names the environment variable holding the path
+	// WriteBenchmarkReport writes the recorded results to
+	BenchmarkReportEnv = "GLIDE_BENCH_REPORT"
+)
+
+// BenchmarkResult is a benchmark's time per operation measured against the
+// budget of the operation it exercises
+type BenchmarkResult struct {
+	// Benchmark is the benchmark's name, e.g. BenchmarkContextDetection
+	Benchmark string `json:"benchmark"`
+
+	// Operation is the budgeted operation, e.g. context_detection
+	Operation string `json:"operation"`
+
+	// N is how many iterations the measurement averages
+	N int `json:"n"`
+
+	// NsPerOp is the measured time per operation in nanoseconds
+	NsPerOp int64 `json:"ns_per_op"`
+
+	// BudgetNs is the operation's MaxDuration in nanoseconds, 0 when the
+	// operation has no budget
+	BudgetNs int64 `json:"budget_ns"`
+
+	// Tolerance is the fraction past the budget allowed before failing
+	Tolerance float64 `json:"tolerance"`
+
+	// Passes reports whether NsPerOp is within the budget and tolerance
+	Passes bool `json:"passes"`
+}
+
+var (
+	benchMu      sync.Mutex
+	benchResults = make(map[string]BenchmarkResult)
+)
+
+// CheckBenchmark measures b's time per operation so far against the budget
+// of the named operation, allowing DefaultBenchmarkTolerance. Call it after
+// the benchmark loop. A benchmark over budget fails, or is only marked in
+// its log when GLIDE_BENCH_BUDGETS=warn. Every check is recorded for
+// WriteBenchmarkResults; later runs of a benchmark replace earlier ones.
+func CheckBenchmark(b *testing.B, operation string) BenchmarkResult {
+	b.Helper()
+	return CheckBenchmarkWithTolerance(b, operation, DefaultBenchmarkTolerance)
+}
+
+// CheckBenchmarkWithTolerance is CheckBenchmark allowing tolerance, a
+// fraction of the budget, instead of DefaultBenchmarkTolerance
+func CheckBenchmarkWithTolerance(b *testing.B, operation string, tolerance float64) BenchmarkResult {
+	b.Helper()
+
+	result := benchmarkResult(b.Name(), operation, b.N, b.Elapsed(), tolerance)
+	benchMu.Lock()
+	benchResults[result.Benchmark] = result
+	benchMu.Unlock()
+
+	if result.Passes {
+		return result
+	}
+
+	msg := fmt.Sprintf("%s over its %s budget: %v/op exceeds %v by more than %.0f%%",
+		result.Benchmark, operation, time.Duration(result.NsPerOp), time.Duration(result.BudgetNs), tolerance*100)
+	if os.Getenv(BenchmarkModeEnv) == "warn" {
+		b.Logf("OVER BUDGET: %s", msg)
+	} else {
+		b.Error(msg)
+	}
+	return result
+}
+
+// benchmarkResult measures n iterations taking elapsed against the budget
+// of operation
+func benchmarkResult(benchmark, operation string, n int, elapsed time.Duration, tolerance float64) BenchmarkResult {
+	result := BenchmarkResult{
+		Benchmark: benchmark,
+		Operation: operation,
+		N:         n,
+		Tolerance: tolerance,
+		Passes:    true,
+	}
+	if n > 0 {
+		result.NsPerOp = elapsed.Nanoseconds() / int64(n)
+	}
+	if budget, ok := GetBudget(operation); ok {
+		result.BudgetNs = budget.MaxDuration.Nanoseconds()
+		result.Passes = float64(result.NsPerOp) <= float64(result.BudgetNs)*(1+tolerance)
+	}
+	return result
+}
+
+// BenchmarkResults returns the results CheckBenchmark recorded, sorted by
+// benchmark name
+func BenchmarkResults() []BenchmarkResult {
+	benchMu.Lock()
+	defer benchMu.Unlock()
+
+	results := make([]BenchmarkResult, 0, len(benchResults))
+	for _, r := range benchResults {
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Benchmark < results[j].Benchmark })
+	return results
+}
+
+// WriteBenchmarkResults writes the recorded results to w as JSON, for CI to
+// compare against a baseline
+func WriteBenchmarkResults(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(BenchmarkResults())
+}
+
+// WriteBenchmarkReport writes the recorded results to the file named by
+// GLIDE_BENCH_REPORT, and does nothing when it is unset or nothing was
+// recorded. Call it from TestMain after m.Run.
+func WriteBenchmarkReport() error {
+	path := os.Getenv(BenchmarkReportEnv)
+	if path == "" || len(BenchmarkResults()) == 0 {
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteBenchmarkResults(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,68 @@
+package performance
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchmarkResult(t *testing.T) {
+	// context_detection allows 100ms
+	result := benchmarkResult("BenchmarkContextDetection", "context_detection", 10, 900*time.Millisecond, 0.15)
+	assert.Equal(t, int64(90*time.Millisecond), result.NsPerOp)
+	assert.Equal(t, int64(100*time.Millisecond), result.BudgetNs)
+	assert.True(t, result.Passes)
+
+	result = benchmarkResult("BenchmarkContextDetection", "context_detection", 10, 1100*time.Millisecond, 0.15)
+	assert.True(t, result.Passes, "within tolerance")
+
+	result = benchmarkResult("BenchmarkContextDetection", "context_detection", 10, 1200*time.Millisecond, 0.15)
+	assert.False(t, result.Passes)
+
+	result = benchmarkResult("BenchmarkSomething", "unbudgeted", 10, time.Hour, 0.15)
+	assert.True(t, result.Passes, "operations without a budget pass")
+	assert.Zero(t, result.BudgetNs)
+}
+
+func TestCheckBenchmark(t *testing.T) {
+	SetOverrides([]Budget{{Name: "bench_test_op", MaxDuration: time.Hour}})
+	defer SetOverrides(nil)
+
+	testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			time.Sleep(time.Microsecond)
+		}
+		CheckBenchmark(b, "bench_test_op")
+	})
+
+	var recorded *BenchmarkResult
+	for _, r := range BenchmarkResults() {
+		if r.Operation == "bench_test_op" {
+			recorded = &r
+		}
+	}
+	require.NotNil(t, recorded)
+	assert.True(t, recorded.Passes)
+	assert.Positive(t, recorded.N)
+	assert.GreaterOrEqual(t, recorded.NsPerOp, int64(time.Microsecond))
+	assert.Equal(t, DefaultBenchmarkTolerance, recorded.Tolerance)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBenchmarkResults(&buf))
+	var written []BenchmarkResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &written))
+	assert.Contains(t, written, *recorded)
+
+	path := filepath.Join(t.TempDir(), "bench.json")
+	t.Setenv(BenchmarkReportEnv, path)
+	require.NoError(t, WriteBenchmarkReport())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, buf.String(), string(data))
+}
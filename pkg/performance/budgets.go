@@ -2,7 +2,12 @@
 // for measuring and validating operation performance against targets.
 package performance
 
-import "time"
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
 
 // Budget defines a performance budget for an operation
 type Budget struct {
@@ -163,25 +168,67 @@ var Budgets = map[string]Budget{
 	},
 }
 
+var (
+	overridesMu sync.RWMutex
+	// overrides replace or add to Budgets, see SetOverrides
+	overrides map[string]Budget
+)
+
+// SetOverrides replaces the budgets that override or add to Budgets, e.g.
+// those a project configures. Passing nil restores the defaults.
+func SetOverrides(budgets []Budget) {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+
+	overrides = make(map[string]Budget, len(budgets))
+	for _, b := range budgets {
+		overrides[b.Name] = b
+	}
+}
+
+// IsOverridden reports whether the budget for name comes from SetOverrides
+func IsOverridden(name string) bool {
+	overridesMu.RLock()
+	defer overridesMu.RUnlock()
+	_, ok := overrides[name]
+	return ok
+}
+
 // GetBudget returns the budget for a given operation name
 func GetBudget(name string) (Budget, bool) {
-	budget, ok := Budgets[name]
+	overridesMu.RLock()
+	budget, ok := overrides[name]
+	overridesMu.RUnlock()
+	if ok {
+		return budget, true
+	}
+	budget, ok = Budgets[name]
 	return budget, ok
 }
 
-// ListBudgets returns all defined budgets
+// ListBudgets returns all defined budgets, overrides included, sorted by
+// name
 func ListBudgets() []Budget {
-	result := make([]Budget, 0, len(Budgets))
-	for _, b := range Budgets {
+	overridesMu.RLock()
+	defer overridesMu.RUnlock()
+
+	result := make([]Budget, 0, len(Budgets)+len(overrides))
+	for name, b := range Budgets {
+		if _, ok := overrides[name]; !ok {
+			result = append(result, b)
+		}
+	}
+	for _, b := range overrides {
 		result = append(result, b)
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
 	return result
 }
 
 // ListByPriority returns budgets filtered by priority
 func ListByPriority(priority string) []Budget {
 	var result []Budget
-	for _, b := range Budgets {
+	for _, b := range ListBudgets() {
 		if b.Priority == priority {
 			result = append(result, b)
 		}
@@ -189,6 +236,25 @@ func ListByPriority(priority string) []Budget {
 	return result
 }
 
+// Validate checks that the budget can be measured against
+func (b Budget) Validate() error {
+	if b.Name == "" {
+		return fmt.Errorf("budget has no operation name")
+	}
+	if b.MaxDuration <= 0 {
+		return fmt.Errorf("budget %q must allow a positive duration", b.Name)
+	}
+	if b.MaxAllocations < 0 || b.MaxBytes < 0 {
+		return fmt.Errorf("budget %q cannot allow negative allocations", b.Name)
+	}
+	switch b.Priority {
+	case "", "P0", "P1", "P2":
+	default:
+		return fmt.Errorf("budget %q has priority %q, want P0, P1, or P2", b.Name, b.Priority)
+	}
+	return nil
+}
+
 // MeasurementResult captures the result of a performance measurement
 type MeasurementResult struct {
 	// Operation is the name of the measured operation
@@ -214,3 +214,34 @@ func TestMeasurementResult(t *testing.T) {
 	assert.Equal(t, int64(100), result.Allocations)
 	assert.Equal(t, int64(15*1024), result.Bytes)
 }
+
+func TestSetOverrides(t *testing.T) {
+	t.Cleanup(func() { SetOverrides(nil) })
+
+	SetOverrides([]Budget{
+		{Name: "context_detection", MaxDuration: 300 * time.Millisecond, Priority: "P0"},
+		{Name: "asset_build", MaxDuration: 2 * time.Second},
+	})
+
+	budget, ok := GetBudget("context_detection")
+	require.True(t, ok)
+	assert.Equal(t, 300*time.Millisecond, budget.MaxDuration)
+	assert.True(t, IsOverridden("context_detection"))
+	assert.False(t, IsOverridden("config_load"))
+
+	_, ok = GetBudget("asset_build")
+	assert.True(t, ok)
+	assert.Len(t, ListBudgets(), len(Budgets)+1)
+
+	SetOverrides(nil)
+	budget, _ = GetBudget("context_detection")
+	assert.Equal(t, 100*time.Millisecond, budget.MaxDuration)
+}
+
+func TestBudgetValidate(t *testing.T) {
+	assert.NoError(t, Budget{Name: "op", MaxDuration: time.Second, Priority: "P1"}.Validate())
+	assert.Error(t, Budget{MaxDuration: time.Second}.Validate())
+	assert.Error(t, Budget{Name: "op"}.Validate())
+	assert.Error(t, Budget{Name: "op", MaxDuration: time.Second, MaxBytes: -1}.Validate())
+	assert.Error(t, Budget{Name: "op", MaxDuration: time.Second, Priority: "critical"}.Validate())
+}
@@ -31,6 +31,18 @@
 //	    }
 //	}
 //
+// # Tracking Operations
+//
+// Guard production code paths with their budget:
+//
+//	err := performance.Track("config_load", func() error {
+//	    cfg, err = loader.Load()
+//	    return err
+//	})
+//
+// Runs over budget increment OverBudgetCount and are logged with the
+// delta, as warnings once SetWarnings(true) is called.
+//
 // # Standard Budgets
 //
 // Pre-defined budgets for Glide operations:
@@ -42,16 +54,21 @@
 //
 // # CI Integration
 //
-// Use in benchmarks for regression detection:
+// Check benchmarks against their operation's budget for regression
+// detection:
 //
 //	func BenchmarkContextDetection(b *testing.B) {
-//	    budget := performance.MustGetBudget("context_detection")
 //	    for i := 0; i < b.N; i++ {
 //	        detector.Detect()
 //	    }
-//	    // CI compares against budget
+//	    performance.CheckBenchmark(b, "context_detection")
 //	}
 //
+// A benchmark more than DefaultBenchmarkTolerance over budget fails, or is
+// marked in its log when GLIDE_BENCH_BUDGETS=warn. Calling
+// WriteBenchmarkReport from TestMain writes every check as JSON to the file
+// named by GLIDE_BENCH_REPORT, for CI to compare against a baseline.
+//
 // # Custom Budgets
 //
 // Register application-specific budgets:
@@ -0,0 +1,88 @@
+package performance
+
+import (
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/logging"
+)
+
+var (
+	trackMu     sync.Mutex
+	overBudget  = make(map[string]int64)
+	warnOnTrack bool
+)
+
+// SetWarnings makes Track log a warning, rather than a debug message, each
+// time an operation exceeds its budget
+func SetWarnings(enabled bool) {
+	trackMu.Lock()
+	defer trackMu.Unlock()
+	warnOnTrack = enabled
+}
+
+// Track runs fn and measures it against the budget registered for name.
+// Runs that take longer than the budget's MaxDuration are counted, see
+// OverBudgetCount, and logged with how far over budget they went. Operations
+// without a budget are run unmeasured. fn's error is returned unchanged.
+func Track(name string, fn func() error) error {
+	budget, ok := GetBudget(name)
+	if !ok {
+		return fn()
+	}
+
+	start := time.Now()
+	err := fn()
+	duration := time.Since(start)
+
+	if duration <= budget.MaxDuration {
+		return err
+	}
+
+	trackMu.Lock()
+	overBudget[name]++
+	warn := warnOnTrack
+	trackMu.Unlock()
+
+	args := []any{
+		"operation", name,
+		"duration", duration,
+		"budget", budget.MaxDuration,
+		"over", duration - budget.MaxDuration,
+		"priority", budget.Priority,
+	}
+	if warn {
+		logging.Warn("Operation exceeded its performance budget", args...)
+	} else {
+		logging.Debug("Operation exceeded its performance budget", args...)
+	}
+	return err
+}
+
+// OverBudgetCount returns how many tracked runs of the named operation
+// exceeded its budget
+func OverBudgetCount(name string) int64 {
+	trackMu.Lock()
+	defer trackMu.Unlock()
+	return overBudget[name]
+}
+
+// OverBudgetCounts returns the over-budget count of every operation that
+// exceeded its budget at least once, keyed by operation name
+func OverBudgetCounts() map[string]int64 {
+	trackMu.Lock()
+	defer trackMu.Unlock()
+
+	counts := make(map[string]int64, len(overBudget))
+	for name, n := range overBudget {
+		counts[name] = n
+	}
+	return counts
+}
+
+// ResetOverBudgetCounts clears the over-budget counters
+func ResetOverBudgetCounts() {
+	trackMu.Lock()
+	defer trackMu.Unlock()
+	overBudget = make(map[string]int64)
+}
@@ -0,0 +1,52 @@
+package performance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrack(t *testing.T) {
+	Budgets["test_track"] = Budget{Name: "test_track", MaxDuration: time.Millisecond, Priority: "P2"}
+	t.Cleanup(func() {
+		delete(Budgets, "test_track")
+		ResetOverBudgetCounts()
+	})
+
+	t.Run("within budget is not counted", func(t *testing.T) {
+		ResetOverBudgetCounts()
+		err := Track("test_track", func() error { return nil })
+		assert.NoError(t, err)
+		assert.Zero(t, OverBudgetCount("test_track"))
+	})
+
+	t.Run("over budget is counted", func(t *testing.T) {
+		ResetOverBudgetCounts()
+		for i := 0; i < 2; i++ {
+			_ = Track("test_track", func() error {
+				time.Sleep(2 * time.Millisecond)
+				return nil
+			})
+		}
+		assert.Equal(t, int64(2), OverBudgetCount("test_track"))
+		assert.Equal(t, map[string]int64{"test_track": 2}, OverBudgetCounts())
+	})
+
+	t.Run("returns the operation error", func(t *testing.T) {
+		want := errors.New("boom")
+		assert.Equal(t, want, Track("test_track", func() error { return want }))
+	})
+
+	t.Run("operations without a budget still run", func(t *testing.T) {
+		ResetOverBudgetCounts()
+		ran := false
+		assert.NoError(t, Track("no_such_budget", func() error {
+			ran = true
+			return nil
+		}))
+		assert.True(t, ran)
+		assert.Empty(t, OverBudgetCounts())
+	})
+}
@@ -30,7 +30,7 @@ func TestCustomCategories(t *testing.T) {
 		},
 	}
 
-	integration.registerCustomCategories(categories)
+	integration.registerCustomCategories("aws", categories)
 
 	// Verify they were registered
 	assert.Equal(t, 2, len(integration.GetCustomCategories()))
@@ -38,13 +38,14 @@ func TestCustomCategories(t *testing.T) {
 
 	// Verify content
 	global := GetGlobalPluginCategories()
-	assert.Equal(t, "infrastructure", global[0].Id)
-	assert.Equal(t, "Infrastructure Management", global[0].Name)
-	assert.Equal(t, int32(110), global[0].Priority)
+	assert.Equal(t, "aws", global[0].Plugin)
+	assert.Equal(t, "infrastructure", global[0].Category.Id)
+	assert.Equal(t, "Infrastructure Management", global[0].Category.Name)
+	assert.Equal(t, int32(110), global[0].Category.Priority)
 
-	assert.Equal(t, "monitoring", global[1].Id)
-	assert.Equal(t, "Monitoring", global[1].Name)
-	assert.Equal(t, int32(120), global[1].Priority)
+	assert.Equal(t, "monitoring", global[1].Category.Id)
+	assert.Equal(t, "Monitoring", global[1].Category.Name)
+	assert.Equal(t, int32(120), global[1].Category.Priority)
 }
 
 func TestCustomCategoriesMultiplePlugins(t *testing.T) {
@@ -71,12 +72,28 @@ func TestCustomCategoriesMultiplePlugins(t *testing.T) {
 		},
 	}
 
-	integration1.registerCustomCategories(categories1)
-	integration2.registerCustomCategories(categories2)
+	integration1.registerCustomCategories("cloud", categories1)
+	integration2.registerCustomCategories("security", categories2)
 
 	// Global should have both
 	global := GetGlobalPluginCategories()
 	assert.Equal(t, 2, len(global))
-	assert.Equal(t, "cloud", global[0].Id)
-	assert.Equal(t, "security", global[1].Id)
+	assert.Equal(t, "cloud", global[0].Category.Id)
+	assert.Equal(t, "security", global[1].Category.Id)
+}
+
+func TestCustomCategoriesDuplicateIDs(t *testing.T) {
+	globalPluginCategories = nil
+	t.Cleanup(func() { globalPluginCategories = nil })
+
+	NewRuntimePluginIntegration().registerCustomCategories("aws", []*v1.CustomCategory{{Id: "cloud", Name: "AWS"}})
+	NewRuntimePluginIntegration().registerCustomCategories("gcp", []*v1.CustomCategory{{Id: "cloud", Name: "GCP"}})
+
+	// Each declaration keeps its own plugin, so the conflict can be reported
+	global := GetGlobalPluginCategories()
+	assert.Len(t, global, 2)
+	assert.Equal(t, "aws", global[0].Plugin)
+	assert.Equal(t, "AWS", global[0].Category.Name)
+	assert.Equal(t, "gcp", global[1].Plugin)
+	assert.Equal(t, "GCP", global[1].Category.Name)
 }
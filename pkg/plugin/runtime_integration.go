@@ -2,13 +2,18 @@ package plugin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/glide-cli/glide/v3/pkg/branding"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
 	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
 	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	v2 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v2"
 	"github.com/spf13/cobra"
 )
 
@@ -19,7 +24,23 @@ type RuntimePluginIntegration struct {
 }
 
 // globalPluginCategories stores custom categories from all loaded plugins
-var globalPluginCategories []*v1.CustomCategory
+var globalPluginCategories []PluginCategory
+
+// globalPluginHelpTopics stores help topics from all loaded plugins
+var globalPluginHelpTopics []PluginHelpTopic
+
+// PluginCategory is a custom category together with the plugin that
+// declares it
+type PluginCategory struct {
+	Plugin   string
+	Category *v1.CustomCategory
+}
+
+// PluginHelpTopic is a help topic together with the plugin that provides it
+type PluginHelpTopic struct {
+	Plugin string
+	Topic  v2.HelpTopic
+}
 
 // NewRuntimePluginIntegration creates a new runtime plugin integration
 func NewRuntimePluginIntegration() *RuntimePluginIntegration {
@@ -49,7 +70,13 @@ func (r *RuntimePluginIntegration) LoadRuntimePlugins(rootCmd *cobra.Command) (*
 
 	// Add commands from each plugin
 	for _, plugin := range plugins {
-		if err := r.addPluginCommands(rootCmd, plugin); err != nil {
+		existing := rootCmd.Commands()
+		err := r.addPluginCommands(rootCmd, plugin)
+		if health := r.manager.PluginHealth(plugin.Name); health != nil {
+			hideAddedCommands(rootCmd, existing)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Plugin %s is unhealthy, its commands are left out of completion: %v", plugin.Name, health))
+		}
+		if err != nil {
 			// Collect error but continue loading other plugins
 			result.Failed = append(result.Failed, PluginError{
 				Name:    plugin.Name,
@@ -65,17 +92,29 @@ func (r *RuntimePluginIntegration) LoadRuntimePlugins(rootCmd *cobra.Command) (*
 	return result, nil
 }
 
+// hideAddedCommands hides the commands of rootCmd that are not in existing,
+// so completion leaves them out while they still run
+func hideAddedCommands(rootCmd *cobra.Command, existing []*cobra.Command) {
+	for _, cmd := range rootCmd.Commands() {
+		if !slices.Contains(existing, cmd) {
+			cmd.Hidden = true
+		}
+	}
+}
+
 // addPluginCommands adds commands from a plugin to the root command
 func (r *RuntimePluginIntegration) addPluginCommands(rootCmd *cobra.Command, plugin *sdk.LoadedPlugin) error {
 	// Use plugin directly as it's already the correct type
 	glidePlugin := plugin.Plugin
 
-	// Get command list from plugin
+	// The plugin's commands and help are read through the v2 interfaces;
+	// commands still run over the v1 protocol, which streams and cancels
 	ctx := context.Background()
-	commandList, err := glidePlugin.ListCommands(ctx, &v1.Empty{})
+	adapted, err := v2.AdaptV1Client(ctx, glidePlugin, plugin.Metadata)
 	if err != nil {
 		return fmt.Errorf("failed to get command list: %w", err)
 	}
+	commands := adapted.Commands()
 
 	// Get metadata
 	metadata := plugin.Metadata
@@ -87,7 +126,12 @@ func (r *RuntimePluginIntegration) addPluginCommands(rootCmd *cobra.Command, plu
 		fmt.Fprintf(os.Stderr, "Warning: failed to get custom categories from plugin %s: %v\n", plugin.Name, err)
 	} else if customCategories != nil && len(customCategories.Categories) > 0 {
 		// Register custom categories with the help system
-		r.registerCustomCategories(customCategories.Categories)
+		r.registerCustomCategories(plugin.Name, customCategories.Categories)
+	}
+
+	// Register long-form help topics with the help system
+	if topics := adapted.Metadata().HelpTopics; len(topics) > 0 {
+		registerHelpTopics(plugin.Name, topics)
 	}
 
 	// Check if plugin wants global registration (not namespaced)
@@ -100,7 +144,7 @@ func (r *RuntimePluginIntegration) addPluginCommands(rootCmd *cobra.Command, plu
 	// If plugin requests global registration (not namespaced)
 	if !namespaced {
 		// Add commands directly to root
-		for _, cmd := range commandList.Commands {
+		for _, cmd := range commands {
 			pluginCommand := r.createPluginCommand(plugin, glidePlugin, cmd)
 			// Mark as coming from a plugin for help display
 			if pluginCommand.Annotations == nil {
@@ -131,7 +175,7 @@ func (r *RuntimePluginIntegration) addPluginCommands(rootCmd *cobra.Command, plu
 
 	// Default namespaced behavior (existing code)
 	// Create a group command for the plugin if it has multiple commands
-	if len(commandList.Commands) > 1 {
+	if len(commands) > 1 {
 		// Create group command
 		pluginCmd := &cobra.Command{
 			Use:   metadata.Name,
@@ -149,15 +193,15 @@ func (r *RuntimePluginIntegration) addPluginCommands(rootCmd *cobra.Command, plu
 		}
 
 		// Add individual commands to group
-		for _, cmd := range commandList.Commands {
+		for _, cmd := range commands {
 			subCmd := r.createPluginCommand(plugin, glidePlugin, cmd)
 			pluginCmd.AddCommand(subCmd)
 		}
 
 		rootCmd.AddCommand(pluginCmd)
-	} else if len(commandList.Commands) == 1 {
+	} else if len(commands) == 1 {
 		// Single command - check if we need a group for plugin aliases
-		cmd := commandList.Commands[0]
+		cmd := commands[0]
 
 		// If the plugin has aliases, create a group command to support them
 		if len(metadata.Aliases) > 0 {
@@ -189,7 +233,9 @@ func (r *RuntimePluginIntegration) addPluginCommands(rootCmd *cobra.Command, plu
 }
 
 // createPluginCommand creates a cobra command for a plugin command
-func (r *RuntimePluginIntegration) createPluginCommand(plugin *sdk.LoadedPlugin, glidePlugin v1.GlidePluginClient, cmdInfo *v1.CommandInfo) *cobra.Command {
+func (r *RuntimePluginIntegration) createPluginCommand(plugin *sdk.LoadedPlugin, glidePlugin v1.GlidePluginClient, cmdInfo v2.Command) *cobra.Command {
+	_, streaming := cmdInfo.Handler.(v2.StreamingCommandHandler)
+
 	cmd := &cobra.Command{
 		Use:   cmdInfo.Name,
 		Short: cmdInfo.Description,
@@ -206,9 +252,26 @@ func (r *RuntimePluginIntegration) createPluginCommand(plugin *sdk.LoadedPlugin,
 					Command: cmdInfo.Name,
 					Args:    args,
 				}
+				// The plugin process keeps the directory glide started in;
+				// tell it where the command runs now (e.g. after --module)
+				if wd, err := os.Getwd(); err == nil {
+					req.WorkDir = wd
+				}
 
-				resp, err := glidePlugin.ExecuteCommand(ctx, req)
+				// Ctrl+C asks the plugin to stop instead of killing it
+				resp, err := sdk.ExecuteCancellable(ctx, glidePlugin, req, func(ctx context.Context) (*v1.ExecuteResponse, error) {
+					// Streaming commands show their output and progress as they run
+					if streaming {
+						return sdk.ExecuteStreaming(ctx, glidePlugin, req, os.Stdout, os.Stderr)
+					}
+					return glidePlugin.ExecuteCommand(ctx, req)
+				})
 				if err != nil {
+					// Plugin errors, e.g. a recovered panic, are shown as they are
+					var glideErr *glideErrors.GlideError
+					if errors.As(err, &glideErr) {
+						return glideErr
+					}
 					return fmt.Errorf("command execution failed: %w", err)
 				}
 
@@ -234,11 +297,19 @@ func (r *RuntimePluginIntegration) createPluginCommand(plugin *sdk.LoadedPlugin,
 		},
 	}
 
+	// The host checks positional arguments before calling the plugin
+	cmdInfo.ApplyArgs(cmd)
+
 	// Add aliases if any
 	if len(cmdInfo.Aliases) > 0 {
 		cmd.Aliases = cmdInfo.Aliases
 	}
 
+	// Add usage examples for --help output
+	if len(cmdInfo.Examples) > 0 {
+		cmd.Example = v2.FormatExamples(cmdInfo.Examples)
+	}
+
 	// Mark as hidden if needed
 	if cmdInfo.Hidden {
 		cmd.Hidden = true
@@ -247,8 +318,13 @@ func (r *RuntimePluginIntegration) createPluginCommand(plugin *sdk.LoadedPlugin,
 	// Add annotations
 	cmd.Annotations = make(map[string]string)
 
-	// Mark as a plugin command
+	// Mark as a plugin command, and record where it was loaded from
 	cmd.Annotations["plugin"] = plugin.Name
+	if plugin.Builtin {
+		cmd.Annotations["plugin_builtin"] = "true"
+	} else {
+		cmd.Annotations["plugin_path"] = plugin.Path
+	}
 
 	// Add category - default to "plugin" if not specified
 	if cmdInfo.Category != "" {
@@ -264,6 +340,16 @@ func (r *RuntimePluginIntegration) createPluginCommand(plugin *sdk.LoadedPlugin,
 		cmd.Annotations["visibility"] = v1.VisibilityAlways
 	}
 
+	// Interactive commands attach the user's terminal to the plugin
+	if cmdInfo.Interactive {
+		cmd.Annotations["interactive"] = "true"
+	}
+
+	// Destructive commands are confirmed and audited by the host
+	if cmdInfo.Destructive {
+		cmd.Annotations["destructive"] = "true"
+	}
+
 	return cmd
 }
 
@@ -273,9 +359,20 @@ func (r *RuntimePluginIntegration) executeInteractiveCommand(ctx context.Context
 	return r.manager.ExecuteInteractive(plugin, command, args)
 }
 
-// LoadAllRuntimePlugins is the main entry point for loading runtime plugins
-func LoadAllRuntimePlugins(rootCmd *cobra.Command) (*PluginLoadResult, error) {
-	integration := NewRuntimePluginIntegration()
+// LoadAllRuntimePlugins is the main entry point for loading runtime plugins.
+// projectRoot is where plugins whose manifest asks for the project root
+// run; pass "" outside a project. enabled and disabled are the project's
+// plugin lists: when enabled is set only those plugins activate, and
+// disabled plugins never do.
+func LoadAllRuntimePlugins(rootCmd *cobra.Command, projectRoot string, enabled, disabled []string) (*PluginLoadResult, error) {
+	config := sdk.DefaultConfig()
+	config.ProjectRoot = projectRoot
+	config.EnabledPlugins = enabled
+	config.DisabledPlugins = disabled
+	integration := &RuntimePluginIntegration{
+		manager:          sdk.NewManager(config),
+		customCategories: make([]*v1.CustomCategory, 0),
+	}
 	return integration.LoadRuntimePlugins(rootCmd)
 }
 
@@ -306,10 +403,12 @@ func IsRuntimePluginInstalled(name string) bool {
 }
 
 // registerCustomCategories stores custom categories from a plugin
-func (r *RuntimePluginIntegration) registerCustomCategories(categories []*v1.CustomCategory) {
+func (r *RuntimePluginIntegration) registerCustomCategories(pluginName string, categories []*v1.CustomCategory) {
 	r.customCategories = append(r.customCategories, categories...)
 	// Also update global variable
-	globalPluginCategories = append(globalPluginCategories, categories...)
+	for _, cat := range categories {
+		globalPluginCategories = append(globalPluginCategories, PluginCategory{Plugin: pluginName, Category: cat})
+	}
 }
 
 // GetCustomCategories returns all custom categories defined by plugins
@@ -317,7 +416,43 @@ func (r *RuntimePluginIntegration) GetCustomCategories() []*v1.CustomCategory {
 	return r.customCategories
 }
 
-// GetGlobalPluginCategories returns all custom categories from loaded plugins
-func GetGlobalPluginCategories() []*v1.CustomCategory {
+// GetGlobalPluginCategories returns all custom categories from loaded
+// plugins, with the plugin declaring each, in load order. Two plugins may
+// declare the same ID; each declaration is kept.
+func GetGlobalPluginCategories() []PluginCategory {
 	return globalPluginCategories
 }
+
+// registerHelpTopics stores help topics from a plugin
+func registerHelpTopics(pluginName string, topics []v2.HelpTopic) {
+	for _, topic := range topics {
+		if topic.Name == "" {
+			continue
+		}
+		globalPluginHelpTopics = append(globalPluginHelpTopics, PluginHelpTopic{
+			Plugin: pluginName,
+			Topic:  topic,
+		})
+	}
+}
+
+// GetGlobalPluginHelpTopics returns all help topics from loaded plugins
+func GetGlobalPluginHelpTopics() []PluginHelpTopic {
+	return globalPluginHelpTopics
+}
+
+// FindPluginHelpTopic looks up a plugin help topic by name or alias.
+// Topics are matched in plugin load order.
+func FindPluginHelpTopic(name string) (PluginHelpTopic, bool) {
+	for _, t := range globalPluginHelpTopics {
+		if strings.EqualFold(t.Topic.Name, name) {
+			return t, true
+		}
+		for _, alias := range t.Topic.Aliases {
+			if strings.EqualFold(alias, name) {
+				return t, true
+			}
+		}
+	}
+	return PluginHelpTopic{}, false
+}
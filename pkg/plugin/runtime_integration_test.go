@@ -6,6 +6,7 @@ import (
 
 	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
 	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	v2 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v2"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -49,6 +50,30 @@ func (m *MockGlidePlugin) ExecuteCommand(ctx context.Context, in *v1.ExecuteRequ
 	return args.Get(0).(*v1.ExecuteResponse), args.Error(1)
 }
 
+func (m *MockGlidePlugin) ExecuteCommandStream(ctx context.Context, in *v1.ExecuteRequest, opts ...grpc.CallOption) (v1.GlidePlugin_ExecuteCommandStreamClient, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(v1.GlidePlugin_ExecuteCommandStreamClient), args.Error(1)
+}
+
+func (m *MockGlidePlugin) Cancel(ctx context.Context, in *v1.CancelRequest, opts ...grpc.CallOption) (*v1.CancelResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*v1.CancelResponse), args.Error(1)
+}
+
+func (m *MockGlidePlugin) Complete(ctx context.Context, in *v1.CompleteRequest, opts ...grpc.CallOption) (*v1.CompleteResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*v1.CompleteResponse), args.Error(1)
+}
+
 func (m *MockGlidePlugin) StartInteractive(ctx context.Context, opts ...grpc.CallOption) (v1.GlidePlugin_StartInteractiveClient, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -76,12 +101,12 @@ func (m *MockGlidePlugin) GetCustomCategories(ctx context.Context, in *v1.Empty,
 func TestCreatePluginCommand_VisibilityAnnotation(t *testing.T) {
 	tests := []struct {
 		name               string
-		cmdInfo            *v1.CommandInfo
+		cmdInfo            v2.Command
 		expectedVisibility string
 	}{
 		{
 			name: "command with always visibility",
-			cmdInfo: &v1.CommandInfo{
+			cmdInfo: v2.Command{
 				Name:        "test-always",
 				Description: "Test command with always visibility",
 				Visibility:  v1.VisibilityAlways,
@@ -90,7 +115,7 @@ func TestCreatePluginCommand_VisibilityAnnotation(t *testing.T) {
 		},
 		{
 			name: "command with project-only visibility",
-			cmdInfo: &v1.CommandInfo{
+			cmdInfo: v2.Command{
 				Name:        "test-project",
 				Description: "Test command with project-only visibility",
 				Visibility:  v1.VisibilityProjectOnly,
@@ -99,7 +124,7 @@ func TestCreatePluginCommand_VisibilityAnnotation(t *testing.T) {
 		},
 		{
 			name: "command with worktree-only visibility",
-			cmdInfo: &v1.CommandInfo{
+			cmdInfo: v2.Command{
 				Name:        "test-worktree",
 				Description: "Test command with worktree-only visibility",
 				Visibility:  v1.VisibilityWorktreeOnly,
@@ -108,7 +133,7 @@ func TestCreatePluginCommand_VisibilityAnnotation(t *testing.T) {
 		},
 		{
 			name: "command with root-only visibility",
-			cmdInfo: &v1.CommandInfo{
+			cmdInfo: v2.Command{
 				Name:        "test-root",
 				Description: "Test command with root-only visibility",
 				Visibility:  v1.VisibilityRootOnly,
@@ -117,7 +142,7 @@ func TestCreatePluginCommand_VisibilityAnnotation(t *testing.T) {
 		},
 		{
 			name: "command with non-root visibility",
-			cmdInfo: &v1.CommandInfo{
+			cmdInfo: v2.Command{
 				Name:        "test-non-root",
 				Description: "Test command with non-root visibility",
 				Visibility:  v1.VisibilityNonRoot,
@@ -126,7 +151,7 @@ func TestCreatePluginCommand_VisibilityAnnotation(t *testing.T) {
 		},
 		{
 			name: "command without visibility defaults to always",
-			cmdInfo: &v1.CommandInfo{
+			cmdInfo: v2.Command{
 				Name:        "test-default",
 				Description: "Test command without visibility",
 				Visibility:  "", // Empty visibility
@@ -175,7 +200,7 @@ func TestCreatePluginCommand_AllAnnotations(t *testing.T) {
 	}
 
 	// Test command with all fields set
-	cmdInfo := &v1.CommandInfo{
+	cmdInfo := v2.Command{
 		Name:        "test-cmd",
 		Description: "Test command",
 		Category:    "testing",
@@ -198,6 +223,59 @@ func TestCreatePluginCommand_AllAnnotations(t *testing.T) {
 	assert.True(t, cmd.Hidden, "Command should be hidden")
 }
 
+func TestAddPluginCommands_HelpTopicsAndExamples(t *testing.T) {
+	globalPluginHelpTopics = nil
+	t.Cleanup(func() { globalPluginHelpTopics = nil })
+
+	r := NewRuntimePluginIntegration()
+	rootCmd := &cobra.Command{Use: "root"}
+
+	mockPlugin := new(MockGlidePlugin)
+	plugin := &sdk.LoadedPlugin{
+		Name: "deployer",
+		Metadata: &v1.PluginMetadata{
+			Name:        "deployer",
+			Description: "Deployment tools",
+			HelpTopics: []*v1.HelpTopic{
+				{Name: "deployment", Summary: "Deploying with glide", Content: "Long guide", Aliases: []string{"deploy-guide"}},
+				{Summary: "Topics without a name are ignored"},
+			},
+		},
+		Plugin: mockPlugin,
+	}
+
+	commandList := &v1.CommandList{
+		Commands: []*v1.CommandInfo{
+			{
+				Name:        "deploy",
+				Description: "Deploy the app",
+				Examples: []*v1.CommandExample{
+					{Description: "Deploy to staging", Command: "glide deploy --env staging"},
+				},
+			},
+		},
+	}
+	mockPlugin.On("ListCommands", mock.Anything, mock.Anything).Return(commandList, nil)
+	mockPlugin.On("GetCustomCategories", mock.Anything, mock.Anything).Return(&v1.CategoryList{}, nil)
+
+	err := r.addPluginCommands(rootCmd, plugin)
+	assert.NoError(t, err)
+
+	assert.Len(t, GetGlobalPluginHelpTopics(), 1)
+
+	topic, ok := FindPluginHelpTopic("Deploy-Guide")
+	assert.True(t, ok, "Should find topic by alias, ignoring case")
+	assert.Equal(t, "deployer", topic.Plugin)
+	assert.Equal(t, "deployment", topic.Topic.Name)
+
+	_, ok = FindPluginHelpTopic("unknown")
+	assert.False(t, ok)
+
+	cmd := findCommand(rootCmd, "deploy")
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "  # Deploy to staging\n  glide deploy --env staging", cmd.Example)
+}
+
 func TestAddPluginCommands_GlobalRegistration(t *testing.T) {
 	r := NewRuntimePluginIntegration()
 	rootCmd := &cobra.Command{Use: "root"}
@@ -418,6 +496,39 @@ func TestRuntimePluginCustomCategories(t *testing.T) {
 
 	// Check global categories
 	globalCats := GetGlobalPluginCategories()
-	assert.Contains(t, globalCats, customCategories.Categories[0])
-	assert.Contains(t, globalCats, customCategories.Categories[1])
+	assert.Contains(t, globalCats, PluginCategory{Plugin: plugin.Name, Category: customCategories.Categories[0]})
+	assert.Contains(t, globalCats, PluginCategory{Plugin: plugin.Name, Category: customCategories.Categories[1]})
+}
+
+func TestAddPluginCommands_SafetyAnnotations(t *testing.T) {
+	r := NewRuntimePluginIntegration()
+	rootCmd := &cobra.Command{Use: "root"}
+
+	mockPlugin := new(MockGlidePlugin)
+	plugin := &sdk.LoadedPlugin{
+		Name:     "db",
+		Metadata: &v1.PluginMetadata{Name: "db", Description: "Database tools"},
+		Plugin:   mockPlugin,
+	}
+
+	commandList := &v1.CommandList{
+		Commands: []*v1.CommandInfo{
+			{Name: "reset", Description: "Drop and recreate the database", Destructive: true},
+			{Name: "console", Description: "Open a database shell", Interactive: true},
+		},
+	}
+	mockPlugin.On("ListCommands", mock.Anything, mock.Anything).Return(commandList, nil)
+	mockPlugin.On("GetCustomCategories", mock.Anything, mock.Anything).Return(&v1.CategoryList{}, nil)
+
+	assert.NoError(t, r.addPluginCommands(rootCmd, plugin))
+
+	reset := findCommand(rootCmd, "reset")
+	assert.NotNil(t, reset)
+	assert.Equal(t, "true", reset.Annotations["destructive"])
+	assert.Empty(t, reset.Annotations["interactive"])
+
+	console := findCommand(rootCmd, "console")
+	assert.NotNil(t, console)
+	assert.Equal(t, "true", console.Annotations["interactive"])
+	assert.Empty(t, console.Annotations["destructive"])
 }
@@ -2,10 +2,13 @@ package sdk
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/glide-cli/glide/v3/pkg/ignore"
 )
 
 // BaseFrameworkDetector provides base implementation for framework detection
@@ -185,12 +188,39 @@ func (d *BaseFrameworkDetector) checkFileContent(projectPath string, pattern Con
 }
 
 func (d *BaseFrameworkDetector) hasFileWithExtension(projectPath string, extensions []string) bool {
-	for _, ext := range extensions {
-		pattern := filepath.Join(projectPath, "*"+ext)
-		matches, err := filepath.Glob(pattern)
-		if err == nil && len(matches) > 0 {
-			return true
+	return HasFileWithExtension(projectPath, extensions)
+}
+
+// MaxScanDepth is how many directory levels below the project root
+// HasFileWithExtension looks into
+const MaxScanDepth = 3
+
+// HasFileWithExtension reports whether the project has a file with one of
+// the extensions at most MaxScanDepth directories below its root. Paths
+// ignored by the project's .gitignore and .glideignore, and dependency
+// directories such as node_modules/ and vendor/, are skipped, so their
+// files do not make the project look like another kind.
+func HasFileWithExtension(projectPath string, extensions []string) bool {
+	found := false
+	// Safe to ignore: unreadable directories are skipped
+	_ = ignore.Load(projectPath).Walk(func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
 		}
-	}
-	return false
+		if entry.IsDir() {
+			rel, _ := filepath.Rel(projectPath, path)
+			if rel != "." && strings.Count(filepath.ToSlash(rel), "/") >= MaxScanDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, ext := range extensions {
+			if strings.HasSuffix(entry.Name(), ext) {
+				found = true
+				return filepath.SkipAll
+			}
+		}
+		return nil
+	})
+	return found
 }
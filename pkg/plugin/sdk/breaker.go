@@ -0,0 +1,154 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+)
+
+const (
+	// DefaultFailureThreshold is the number of consecutive failures after
+	// which a plugin is marked unhealthy
+	DefaultFailureThreshold = 3
+
+	// DefaultUnhealthyCooldown is how long an unhealthy plugin is skipped
+	// before it is tried again
+	DefaultUnhealthyCooldown = 10 * time.Minute
+)
+
+// PluginHealth is the failure record of a plugin
+type PluginHealth struct {
+	// Failures counts consecutive failed calls and loads
+	Failures int `json:"failures"`
+	// LastError describes the most recent failure
+	LastError string `json:"last_error,omitempty"`
+	// UnhealthySince is when the plugin reached the failure threshold
+	UnhealthySince time.Time `json:"unhealthy_since,omitempty"`
+}
+
+// CircuitBreaker tracks plugin failures so a plugin that keeps hanging or
+// crashing is skipped instead of slowing down every command. Records
+// persist across invocations when the breaker has a file.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failures that open the breaker
+	Threshold int
+	// Cooldown is how long an open breaker skips its plugin; afterwards the
+	// plugin gets one more try
+	Cooldown time.Duration
+
+	mu     sync.Mutex
+	path   string
+	health map[string]*PluginHealth
+	now    func() time.Time
+}
+
+// NewCircuitBreaker creates a breaker persisting to path, or kept in memory
+// when path is empty
+func NewCircuitBreaker(path string) *CircuitBreaker {
+	b := &CircuitBreaker{
+		Threshold: DefaultFailureThreshold,
+		Cooldown:  DefaultUnhealthyCooldown,
+		path:      path,
+		health:    make(map[string]*PluginHealth),
+		now:       time.Now,
+	}
+	if path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			// Safe to ignore: a corrupt record only forgets past failures
+			_ = json.Unmarshal(data, &b.health)
+		}
+	}
+	return b
+}
+
+// Allow returns an error while the plugin is unhealthy and its cooldown has
+// not passed
+func (b *CircuitBreaker) Allow(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h, ok := b.health[name]
+	if !ok || h.UnhealthySince.IsZero() {
+		return nil
+	}
+	retry := h.UnhealthySince.Add(b.Cooldown)
+	if !b.now().Before(retry) {
+		return nil
+	}
+	return glideErrors.NewPluginError(name,
+		fmt.Sprintf("unhealthy after %d failures (last: %s)", h.Failures, h.LastError), nil).
+		AddSuggestion(fmt.Sprintf("It is skipped until %s", retry.Format("15:04"))).
+		AddSuggestion("Run with GLIDE_PLUGIN_DEBUG=true to see the plugin's logs")
+}
+
+// RecordSuccess clears the plugin's failures
+func (b *CircuitBreaker) RecordSuccess(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.health[name]; !ok {
+		return
+	}
+	delete(b.health, name)
+	b.save()
+}
+
+// RecordFailure counts a failure, and reports whether it made the plugin
+// unhealthy
+func (b *CircuitBreaker) RecordFailure(name string, err error) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h, ok := b.health[name]
+	if !ok {
+		h = &PluginHealth{}
+		b.health[name] = h
+	}
+	h.Failures++
+	h.LastError = err.Error()
+
+	opened := false
+	if h.Failures >= b.Threshold && (h.UnhealthySince.IsZero() || !b.now().Before(h.UnhealthySince.Add(b.Cooldown))) {
+		// Reached the threshold, or failed its retry after the cooldown
+		h.UnhealthySince = b.now()
+		opened = true
+	}
+	b.save()
+	return opened
+}
+
+// Health returns the plugin's failure record
+func (b *CircuitBreaker) Health(name string) PluginHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if h, ok := b.health[name]; ok {
+		return *h
+	}
+	return PluginHealth{}
+}
+
+// save writes the records; caller must hold b.mu
+func (b *CircuitBreaker) save() {
+	if b.path == "" {
+		return
+	}
+	data, err := json.MarshalIndent(b.health, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return
+	}
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	// Safe to ignore: losing a record only delays marking a plugin unhealthy
+	_ = os.Rename(tmp, b.path)
+}
@@ -0,0 +1,42 @@
+package sdk
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plugin-health.json")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(path)
+	breaker.now = func() time.Time { return now }
+
+	hang := errors.New("did not answer GetMetadata")
+	assert.False(t, breaker.RecordFailure("docker", hang))
+	assert.False(t, breaker.RecordFailure("docker", hang))
+	assert.NoError(t, breaker.Allow("docker"))
+	assert.True(t, breaker.RecordFailure("docker", hang), "the third failure opens the breaker")
+	require.Error(t, breaker.Allow("docker"))
+
+	// The record survives the process
+	reloaded := NewCircuitBreaker(path)
+	reloaded.now = breaker.now
+	assert.Error(t, reloaded.Allow("docker"))
+	assert.Equal(t, 3, reloaded.Health("docker").Failures)
+
+	// After the cooldown the plugin gets another try
+	now = now.Add(DefaultUnhealthyCooldown)
+	assert.NoError(t, breaker.Allow("docker"))
+	assert.True(t, breaker.RecordFailure("docker", hang), "failing the retry reopens it")
+	assert.Error(t, breaker.Allow("docker"))
+
+	now = now.Add(DefaultUnhealthyCooldown)
+	breaker.RecordSuccess("docker")
+	assert.NoError(t, breaker.Allow("docker"))
+	assert.Equal(t, PluginHealth{}, breaker.Health("docker"))
+}
@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+)
+
+// CancelGrace is how long a cancelled plugin command may take to clean up
+// and return before glide stops waiting for it
+var CancelGrace = 10 * time.Second
+
+// interruptSignals make glide cancel the running plugin command
+var interruptSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// exitInterrupted is the exit code of a command interrupted with Ctrl+C
+const exitInterrupted = 130
+
+// ExecuteCancellable runs a non-interactive plugin command with run. When
+// the user interrupts glide (Ctrl+C) or ctx is done, the plugin is asked to
+// stop through the Cancel RPC and given CancelGrace to clean up, instead of
+// being killed as glide exits. A second interrupt stops waiting.
+func ExecuteCancellable(ctx context.Context, client v1.GlidePluginClient, req *v1.ExecuteRequest, run func(ctx context.Context) (*v1.ExecuteResponse, error)) (*v1.ExecuteResponse, error) {
+	if req.ExecutionId == "" {
+		req.ExecutionId = newExecutionID()
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, interruptSignals...)
+	defer signal.Stop(interrupted)
+
+	// The call is not cancelled with ctx, so the plugin can still return
+	// once it has cleaned up
+	callCtx, abandon := context.WithCancel(context.WithoutCancel(ctx))
+	defer abandon()
+
+	type result struct {
+		resp *v1.ExecuteResponse
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := run(callCtx)
+		done <- result{resp, err}
+	}()
+
+	var reason string
+	select {
+	case r := <-done:
+		if glideErrors.Is(r.err, glideErrors.TypeTimeout) {
+			// The deadline already ended the call; tell the plugin why
+			cancelExecution(client, req.ExecutionId, "timed out")
+		}
+		return r.resp, r.err
+	case <-interrupted:
+		reason = "interrupted"
+	case <-ctx.Done():
+		reason = "cancelled"
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			reason = "timed out"
+		}
+	}
+
+	logging.Debug("Cancelling plugin command", "command", req.Command, "reason", reason)
+	cancelExecution(client, req.ExecutionId, reason)
+
+	select {
+	case <-done:
+		return nil, cancelledError(req.Command, reason, "")
+	case <-interrupted:
+		return nil, cancelledError(req.Command, reason, "glide stopped waiting for it to clean up")
+	case <-time.After(CancelGrace):
+		return nil, cancelledError(req.Command, reason, fmt.Sprintf("it did not stop within %s", CancelGrace))
+	}
+}
+
+// cancelExecution asks the plugin to stop a command. Failures are only
+// logged: the command ends with glide either way.
+func cancelExecution(client v1.GlidePluginClient, id, reason string) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCallTimeout)
+	defer cancel()
+
+	resp, err := client.Cancel(ctx, &v1.CancelRequest{ExecutionId: id, Reason: reason})
+	if err != nil {
+		logging.Debug("Plugin did not accept cancellation", "execution", id, "error", err)
+		return
+	}
+	if !resp.GetCancelled() {
+		logging.Debug("Plugin command was no longer running", "execution", id)
+	}
+}
+
+// cancelledError reports a command that was cancelled
+func cancelledError(command, reason, detail string) error {
+	message := fmt.Sprintf("%s was %s", command, reason)
+	if reason == "timed out" {
+		message = fmt.Sprintf("%s timed out", command)
+	}
+	if detail != "" {
+		message += "; " + detail
+	}
+	if reason == "timed out" {
+		return glideErrors.New(glideErrors.TypeTimeout, message,
+			glideErrors.WithSuggestions("Allow more time with GLIDE_PLUGIN_EXECUTE_TIMEOUT, e.g. 10m"))
+	}
+	return glideErrors.New(glideErrors.TypeCommand, message, glideErrors.WithExitCode(exitInterrupted))
+}
+
+// newExecutionID returns an ID for a command run
+func newExecutionID() string {
+	b := make([]byte, 8)
+	// Safe to ignore: crypto/rand.Read does not fail on supported platforms
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// cancelRecorder is a plugin that records Cancel calls
+type cancelRecorder struct {
+	v1.GlidePluginClient
+	mu        sync.Mutex
+	requests  []*v1.CancelRequest
+	cancelled chan struct{}
+}
+
+func (c *cancelRecorder) Cancel(_ context.Context, in *v1.CancelRequest, _ ...grpc.CallOption) (*v1.CancelResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, in)
+	if c.cancelled != nil {
+		close(c.cancelled)
+		c.cancelled = nil
+	}
+	return &v1.CancelResponse{Cancelled: true}, nil
+}
+
+func TestExecuteCancellable(t *testing.T) {
+	t.Run("returns the result of commands that finish", func(t *testing.T) {
+		client := &cancelRecorder{}
+		req := &v1.ExecuteRequest{Command: "build"}
+		resp, err := ExecuteCancellable(context.Background(), client, req, func(context.Context) (*v1.ExecuteResponse, error) {
+			return &v1.ExecuteResponse{Success: true}, nil
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Success)
+		assert.NotEmpty(t, req.ExecutionId)
+		assert.Empty(t, client.requests)
+	})
+
+	t.Run("asks the plugin to stop and waits for it", func(t *testing.T) {
+		cancelled := make(chan struct{})
+		client := &cancelRecorder{cancelled: cancelled}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := ExecuteCancellable(ctx, client, &v1.ExecuteRequest{Command: "deploy", ExecutionId: "run-1"}, func(callCtx context.Context) (*v1.ExecuteResponse, error) {
+			<-cancelled
+			assert.NoError(t, callCtx.Err(), "the call outlives ctx so the plugin can clean up")
+			return &v1.ExecuteResponse{}, nil
+		})
+		assert.EqualError(t, err, "deploy was cancelled")
+		require.Len(t, client.requests, 1)
+		assert.Equal(t, "run-1", client.requests[0].ExecutionId)
+		assert.Equal(t, "cancelled", client.requests[0].Reason)
+	})
+
+	t.Run("stops waiting after the grace period", func(t *testing.T) {
+		grace := CancelGrace
+		CancelGrace = 10 * time.Millisecond
+		t.Cleanup(func() { CancelGrace = grace })
+
+		client := &cancelRecorder{}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		_, err := ExecuteCancellable(ctx, client, &v1.ExecuteRequest{Command: "deploy"}, func(callCtx context.Context) (*v1.ExecuteResponse, error) {
+			<-callCtx.Done()
+			return nil, callCtx.Err()
+		})
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeTimeout))
+		assert.EqualError(t, err, "deploy timed out; it did not stop within 10ms")
+	})
+}
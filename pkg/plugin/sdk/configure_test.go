@@ -0,0 +1,45 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	pkgconfig "github.com/glide-cli/glide/v3/pkg/config"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// configureRecorder records the configuration a plugin is sent
+type configureRecorder struct {
+	v1.GlidePluginClient
+	calls []map[string]string
+}
+
+func (r *configureRecorder) Configure(_ context.Context, in *v1.ConfigureRequest, _ ...grpc.CallOption) (*v1.ConfigureResponse, error) {
+	r.calls = append(r.calls, in.Config)
+	return &v1.ConfigureResponse{Success: true}, nil
+}
+
+func TestConfigurePlugin(t *testing.T) {
+	t.Cleanup(func() { pkgconfig.SetPluginSections(nil) })
+	pkgconfig.SetPluginSections(map[string]interface{}{
+		pkgconfig.SharedSection: map[string]interface{}{"region": "eu-west-1"},
+		"deploy":                map[string]interface{}{"api_key": "deploy-secret"},
+		"billing":               map[string]interface{}{"api_key": "billing-secret"},
+	})
+
+	recorder := &configureRecorder{}
+	configurePlugin(recorder, "deploy")
+	assert.Equal(t, []map[string]string{{"api_key": "deploy-secret", "region": "eu-west-1"}}, recorder.calls)
+
+	pkgconfig.SetPluginSections(nil)
+	configurePlugin(recorder, "deploy")
+	assert.Len(t, recorder.calls, 1, "plugins without configuration are not configured")
+}
+
+func TestScopeName(t *testing.T) {
+	assert.Equal(t, "deploy", scopeName(&PluginInfo{Path: "/plugins/deploy"}))
+	assert.Equal(t, "go", scopeName(&PluginInfo{Path: "/plugins/glide-plugin-go"}))
+	assert.Equal(t, "go", scopeName(&PluginInfo{Path: `glide-plugin-go.exe`}))
+}
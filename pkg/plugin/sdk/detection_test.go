@@ -184,6 +184,48 @@ func TestBaseFrameworkDetector(t *testing.T) {
 	})
 }
 
+func TestHasFileWithExtension(t *testing.T) {
+	write := func(t *testing.T, root, file, content string) {
+		t.Helper()
+		path := filepath.Join(root, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	t.Run("finds files in subdirectories", func(t *testing.T) {
+		root := t.TempDir()
+		write(t, root, "src/app/main.go", "")
+
+		assert.True(t, HasFileWithExtension(root, []string{".go"}))
+	})
+
+	t.Run("skips dependency directories", func(t *testing.T) {
+		root := t.TempDir()
+		write(t, root, "main.go", "")
+		write(t, root, "node_modules/pkg/index.js", "")
+
+		assert.False(t, HasFileWithExtension(root, []string{".js"}))
+	})
+
+	t.Run("honors .glideignore and .gitignore", func(t *testing.T) {
+		root := t.TempDir()
+		write(t, root, "dist/bundle.js", "")
+		write(t, root, "legacy/old.php", "")
+		write(t, root, ".gitignore", "dist/\n")
+		write(t, root, ".glideignore", "legacy/\n")
+
+		assert.False(t, HasFileWithExtension(root, []string{".js"}))
+		assert.False(t, HasFileWithExtension(root, []string{".php"}))
+	})
+
+	t.Run("stops at the scan depth", func(t *testing.T) {
+		root := t.TempDir()
+		write(t, root, "a/b/c/d/deep.rs", "")
+
+		assert.False(t, HasFileWithExtension(root, []string{".rs"}))
+	})
+}
+
 func TestContentPattern(t *testing.T) {
 	t.Run("regex pattern matching", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/glide-cli/glide/v3/pkg/logging"
+)
+
+// Working directory policies for EnvironmentSpec.WorkDir
+const (
+	// WorkDirCwd runs the plugin in the directory glide started in
+	WorkDirCwd = "cwd"
+	// WorkDirProjectRoot runs the plugin in the project root, or in the
+	// directory glide started in outside a project
+	WorkDirProjectRoot = "project-root"
+)
+
+// baseEnv are the host variables every plugin receives.
+// A trailing * matches a prefix.
+var baseEnv = []string{
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "TERM", "COLORTERM",
+	"LANG", "LC_*", "TZ", "TMPDIR", "NO_COLOR", "GLIDE_*",
+	// Windows
+	"SYSTEMROOT", "COMSPEC", "PATHEXT", "USERPROFILE", "APPDATA", "LOCALAPPDATA", "TEMP", "TMP",
+}
+
+// EnvironmentSpec controls the environment a plugin process starts in
+type EnvironmentSpec struct {
+	// Passthrough lists the host variables the plugin receives besides the
+	// basics such as PATH and HOME, e.g. AWS_PROFILE or AWS_*
+	Passthrough []string `yaml:"passthrough"`
+	// WorkDir is WorkDirCwd (the default) or WorkDirProjectRoot
+	WorkDir string `yaml:"workdir"`
+}
+
+// ManifestPath returns where the manifest of the plugin at pluginPath
+// lives: next to it, as <name>.yaml
+func ManifestPath(pluginPath string) string {
+	return strings.TrimSuffix(pluginPath, ".exe") + ".yaml"
+}
+
+// LoadManifest reads the manifest of the plugin at pluginPath. Plugins
+// without one get nil.
+func LoadManifest(pluginPath string) (*PluginManifest, error) {
+	data, err := os.ReadFile(ManifestPath(pluginPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest PluginManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid plugin manifest %s: %w", ManifestPath(pluginPath), err)
+	}
+	switch manifest.Spec.Environment.WorkDir {
+	case "", WorkDirCwd, WorkDirProjectRoot:
+	default:
+		return nil, fmt.Errorf("invalid plugin manifest %s: workdir must be %s or %s, not %q",
+			ManifestPath(pluginPath), WorkDirCwd, WorkDirProjectRoot, manifest.Spec.Environment.WorkDir)
+	}
+	return &manifest, nil
+}
+
+// pluginCommand builds the command that starts a plugin. Every plugin
+// receives only the basic host variables; a manifest widens that with the
+// variables it passes through and those it requires, and sets where the
+// plugin runs. skipHostEnv tells go-plugin not to add the host environment
+// back.
+func (m *Manager) pluginCommand(info *PluginInfo) (cmd *exec.Cmd, skipHostEnv bool, err error) {
+	cmd = exec.Command(info.Path)
+
+	manifest, err := LoadManifest(info.Path)
+	if err != nil {
+		return nil, false, err
+	}
+	if manifest == nil {
+		logging.Debug("Plugin has no manifest and receives only the basic environment", "plugin", info.Name)
+		cmd.Env = filterEnv(os.Environ(), baseEnv)
+		return cmd, true, nil
+	}
+	if err := m.validator.ValidateManifest(ManifestPath(info.Path)); err != nil {
+		return nil, false, fmt.Errorf("plugin manifest validation failed: %w", err)
+	}
+
+	env := manifest.Spec.Environment
+	required := manifest.Spec.Capabilities.RequiredEnvVars
+	cmd.Env = filterEnv(os.Environ(), slices.Concat(baseEnv, env.Passthrough, required))
+	for _, name := range required {
+		if _, ok := os.LookupEnv(name); !ok {
+			logging.Warn("Plugin requires an environment variable that is not set", "plugin", info.Name, "variable", name)
+		}
+	}
+
+	if env.WorkDir == WorkDirProjectRoot && m.config.ProjectRoot != "" {
+		cmd.Dir = m.config.ProjectRoot
+	}
+	return cmd, true, nil
+}
+
+// filterEnv keeps the variables of environ named in allowed
+func filterEnv(environ, allowed []string) []string {
+	kept := []string{}
+	for _, kv := range environ {
+		name, _, _ := strings.Cut(kv, "=")
+		for _, pattern := range allowed {
+			prefix, isPrefix := strings.CutSuffix(pattern, "*")
+			if name == pattern || isPrefix && strings.HasPrefix(name, prefix) {
+				kept = append(kept, kv)
+				break
+			}
+		}
+	}
+	return kept
+}
@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	plugin := filepath.Join(dir, "aws")
+
+	manifest, err := LoadManifest(plugin)
+	require.NoError(t, err)
+	assert.Nil(t, manifest, "plugins without a manifest have none")
+
+	require.NoError(t, os.WriteFile(plugin+".yaml", []byte(`
+metadata:
+  name: aws
+spec:
+  capabilities:
+    required_env_vars: [AWS_REGION]
+  environment:
+    passthrough: [AWS_PROFILE, "AWS_*"]
+    workdir: project-root
+`), 0644))
+	manifest, err = LoadManifest(plugin)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AWS_PROFILE", "AWS_*"}, manifest.Spec.Environment.Passthrough)
+	assert.Equal(t, WorkDirProjectRoot, manifest.Spec.Environment.WorkDir)
+	assert.Equal(t, []string{"AWS_REGION"}, manifest.Spec.Capabilities.RequiredEnvVars)
+
+	require.NoError(t, os.WriteFile(plugin+".yaml", []byte("spec:\n  environment:\n    workdir: home\n"), 0644))
+	_, err = LoadManifest(plugin)
+	assert.ErrorContains(t, err, `workdir must be cwd or project-root, not "home"`)
+}
+
+func TestFilterEnv(t *testing.T) {
+	environ := []string{"PATH=/usr/bin", "AWS_PROFILE=dev", "AWS_SECRET_ACCESS_KEY=s3cr3t", "GITHUB_TOKEN=ghp", "LC_ALL=C"}
+
+	assert.Equal(t, []string{"PATH=/usr/bin", "LC_ALL=C"}, filterEnv(environ, baseEnv))
+	assert.Equal(t, []string{"PATH=/usr/bin", "AWS_PROFILE=dev", "AWS_SECRET_ACCESS_KEY=s3cr3t", "LC_ALL=C"},
+		filterEnv(environ, append([]string{"AWS_*"}, baseEnv...)))
+}
+
+func TestManager_PluginCommand(t *testing.T) {
+	t.Setenv("AWS_PROFILE", "dev")
+	t.Setenv("GITHUB_TOKEN", "ghp")
+
+	dir := t.TempDir()
+	config := DefaultConfig()
+	config.PluginDirs = []string{dir}
+	config.ProjectRoot = t.TempDir()
+	m := NewManager(config)
+
+	// Without a manifest the plugin receives only the basics
+	info := &PluginInfo{Name: "legacy", Path: filepath.Join(dir, "legacy")}
+	cmd, skipHostEnv, err := m.pluginCommand(info)
+	require.NoError(t, err)
+	assert.True(t, skipHostEnv)
+	assert.Contains(t, cmd.Env, "PATH="+os.Getenv("PATH"))
+	assert.NotContains(t, cmd.Env, "AWS_PROFILE=dev")
+	assert.NotContains(t, cmd.Env, "GITHUB_TOKEN=ghp")
+	assert.Empty(t, cmd.Dir)
+
+	info = &PluginInfo{Name: "aws", Path: filepath.Join(dir, "aws")}
+	require.NoError(t, os.WriteFile(info.Path+".yaml", []byte(`
+spec:
+  environment:
+    passthrough: [AWS_PROFILE]
+    workdir: project-root
+`), 0644))
+	cmd, skipHostEnv, err = m.pluginCommand(info)
+	require.NoError(t, err)
+	assert.True(t, skipHostEnv)
+	assert.Contains(t, cmd.Env, "AWS_PROFILE=dev")
+	assert.NotContains(t, cmd.Env, "GITHUB_TOKEN=ghp")
+	assert.Equal(t, config.ProjectRoot, cmd.Dir)
+
+	// Outside a project the plugin stays where glide started
+	config.ProjectRoot = ""
+	cmd, _, err = m.pluginCommand(info)
+	require.NoError(t, err)
+	assert.Empty(t, cmd.Dir)
+}
@@ -0,0 +1,278 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/chaos"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultCallTimeout bounds plugin RPCs other than command execution
+	DefaultCallTimeout = 10 * time.Second
+
+	// DefaultCompleteTimeout bounds shell completion by a plugin
+	DefaultCompleteTimeout = 2 * time.Second
+
+	// executeMethod is the RPC running a non-interactive plugin command
+	executeMethod = v1.GlidePlugin_ExecuteCommand_FullMethodName
+
+	// executeStreamMethod is the RPC running a streaming plugin command
+	executeStreamMethod = v1.GlidePlugin_ExecuteCommandStream_FullMethodName
+
+	// completeMethod is the RPC completing a plugin command's arguments
+	completeMethod = v1.GlidePlugin_Complete_FullMethodName
+)
+
+// callGuard wraps every RPC to a plugin: it skips unhealthy plugins, gives
+// calls a deadline, bounds what the plugin may send, turns panics into
+// PluginErrors, and counts failures
+type callGuard struct {
+	plugin         string
+	callTimeout    time.Duration
+	executeTimeout time.Duration
+	// completeTimeout bounds completion, which the user waits on at the
+	// shell prompt
+	completeTimeout time.Duration
+	breaker         *CircuitBreaker
+
+	// maxResponseSize bounds a single message from the plugin; 0 keeps
+	// gRPC's default
+	maxResponseSize int64
+	// maxOutput bounds the output of a command; 0 means none
+	maxOutput int64
+	// maxRate bounds the bytes per second streams deliver; 0 means none
+	maxRate int64
+}
+
+// dialOptions installs the guard's interceptors on a plugin's connection
+func (g *callGuard) dialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(g.unary),
+		grpc.WithChainStreamInterceptor(g.stream),
+	}
+	if g.maxResponseSize > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(g.maxResponseSize))))
+	}
+	return opts
+}
+
+// unary guards a unary RPC
+func (g *callGuard) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) (err error) {
+	call := v1.CallName(method, req)
+	if err := g.breaker.Allow(g.plugin); err != nil {
+		return err
+	}
+
+	timeout := g.callTimeout
+	switch method {
+	case executeMethod:
+		timeout = g.executeTimeout
+	case completeMethod:
+		timeout = g.completeTimeout
+	}
+	if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	failed := false
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Debug("Recovered plugin panic", "plugin", g.plugin, "command", call, "stack", string(debug.Stack()))
+			err, failed = NewPanicError(g.plugin, call, r), true
+		}
+		g.record(err, failed)
+	}()
+
+	if method == executeMethod && chaos.PluginTimesOut(g.plugin) {
+		err = injectedTimeout(call)
+	} else {
+		err = invoker(ctx, method, req, reply, cc, opts...)
+	}
+	err, failed = g.translate(call, timeout, err)
+	if method == completeMethod && glideErrors.Is(err, glideErrors.TypeTimeout) {
+		// Completing slowly is not a reason to skip the plugin's commands
+		failed = false
+	}
+	if resp, ok := reply.(*v1.ExecuteResponse); ok && err == nil {
+		g.truncateResponse(call, resp)
+	}
+	return err
+}
+
+// stream guards a streaming RPC. Streamed commands get the execute
+// deadline; interactive sessions have none.
+func (g *callGuard) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (stream grpc.ClientStream, err error) {
+	call := v1.CallName(method, nil)
+	if err := g.breaker.Allow(g.plugin); err != nil {
+		return nil, err
+	}
+
+	var timeout time.Duration
+	cancel := context.CancelFunc(func() {})
+	if method == executeStreamMethod {
+		timeout = g.executeTimeout
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+		}
+	}
+
+	failed := false
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Debug("Recovered plugin panic", "plugin", g.plugin, "command", call, "stack", string(debug.Stack()))
+			err, failed = NewPanicError(g.plugin, call, r), true
+		}
+		if err != nil {
+			// The stream never started, so nothing else releases its deadline
+			cancel()
+		}
+		g.record(err, failed)
+	}()
+
+	if method == executeStreamMethod && chaos.PluginTimesOut(g.plugin) {
+		err = injectedTimeout(call)
+	} else {
+		stream, err = streamer(ctx, desc, cc, method, opts...)
+	}
+	err, failed = g.translate(call, timeout, err)
+	if err != nil {
+		return nil, err
+	}
+	if method != executeStreamMethod && g.maxRate <= 0 {
+		return stream, nil
+	}
+
+	guarded := &guardedStream{ClientStream: stream, guard: g, call: call, timeout: timeout, cancel: cancel}
+	if method == executeStreamMethod {
+		guarded.output = &outputLimit{guard: g, call: call}
+	}
+	if g.maxRate > 0 {
+		guarded.limiter = newRateLimiter(g.maxRate)
+	}
+	return guarded, nil
+}
+
+// injectedTimeout fails a command the way a plugin that never answers
+// does, for GLIDE_CHAOS=plugin-timeout
+func injectedTimeout(call string) error {
+	return status.Errorf(codes.DeadlineExceeded, "%s timed out (injected by %s)", call, chaos.Env)
+}
+
+// guardedStream slows streams down to the rate limit. For streamed
+// commands it also cuts output off at the output limit, translates errors
+// the way unary calls are, and releases the deadline once the stream ends.
+type guardedStream struct {
+	grpc.ClientStream
+	guard   *callGuard
+	call    string
+	timeout time.Duration
+	cancel  context.CancelFunc
+
+	// output is set for streamed commands
+	output  *outputLimit
+	limiter *rateLimiter
+}
+
+// RecvMsg implements grpc.ClientStream
+func (s *guardedStream) RecvMsg(m interface{}) error {
+	for {
+		err := s.ClientStream.RecvMsg(m)
+		if err != nil {
+			return s.end(err)
+		}
+		if s.limiter != nil {
+			if msg, ok := m.(proto.Message); ok {
+				s.limiter.wait(s.Context(), proto.Size(msg))
+			}
+		}
+		// Output past the limit is dropped, waiting for the result
+		if event, ok := m.(*v1.ExecuteEvent); !ok || s.output == nil || s.output.truncateEvent(event) {
+			return nil
+		}
+	}
+}
+
+// end handles the error that ended the stream
+func (s *guardedStream) end(err error) error {
+	if s.output == nil {
+		return err
+	}
+	s.cancel()
+	if errors.Is(err, io.EOF) {
+		return err
+	}
+	err, failed := s.guard.translate(s.call, s.timeout, err)
+	if failed {
+		s.guard.record(err, failed)
+	}
+	return err
+}
+
+// translate converts the errors of a misbehaving plugin, and reports
+// whether err is one: a timeout, a lost connection, or a panic
+func (g *callGuard) translate(call string, timeout time.Duration, err error) (error, bool) {
+	s, ok := status.FromError(err)
+	if err == nil || !ok {
+		return err, false
+	}
+	switch s.Code() {
+	case codes.DeadlineExceeded:
+		within := fmt.Sprintf("within %s", timeout)
+		if timeout <= 0 {
+			within = "in time"
+		}
+		return glideErrors.New(glideErrors.TypeTimeout,
+			fmt.Sprintf("plugin '%s' did not answer %s %s", g.plugin, call, within),
+			glideErrors.WithError(err),
+			glideErrors.WithContext("plugin", g.plugin),
+			glideErrors.WithContext("command", call),
+			glideErrors.WithSuggestions("Allow more time with GLIDE_PLUGIN_TIMEOUT or GLIDE_PLUGIN_EXECUTE_TIMEOUT, e.g. 1m"),
+		), true
+	case codes.Unavailable:
+		return err, true
+	case codes.ResourceExhausted:
+		if g.maxResponseSize <= 0 {
+			return err, false
+		}
+		return glideErrors.New(glideErrors.TypeRuntime,
+			fmt.Sprintf("plugin '%s' sent more than %s in answer to %s", g.plugin, formatSize(g.maxResponseSize), call),
+			glideErrors.WithError(err),
+			glideErrors.WithContext("plugin", g.plugin),
+			glideErrors.WithContext("command", call),
+			glideErrors.WithSuggestions("Allow larger responses with GLIDE_PLUGIN_MAX_RESPONSE, e.g. 16MB"),
+		), true
+	case codes.Internal:
+		panicErr := remotePanicError(g.plugin, call, err)
+		return panicErr, panicErr != err
+	}
+	return err, false
+}
+
+// record counts the outcome of a call, warning when the plugin becomes
+// unhealthy
+func (g *callGuard) record(err error, failed bool) {
+	if !failed {
+		if err == nil {
+			g.breaker.RecordSuccess(g.plugin)
+		}
+		return
+	}
+	if g.breaker.RecordFailure(g.plugin, err) {
+		logging.Warn("Plugin keeps failing and is skipped for a while", "plugin", g.plugin,
+			"failures", g.breaker.Health(g.plugin).Failures, "cooldown", g.breaker.Cooldown)
+	}
+}
@@ -0,0 +1,164 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/internal/chaos"
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const getMetadataMethod = v1.GlidePlugin_GetMetadata_FullMethodName
+
+// invoker returns a unary invoker that calls fn
+func invoker(fn func(ctx context.Context) error) grpc.UnaryInvoker {
+	return func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		return fn(ctx)
+	}
+}
+
+func newTestGuard() *callGuard {
+	return &callGuard{
+		plugin:      "docker",
+		callTimeout: 20 * time.Millisecond,
+		breaker:     NewCircuitBreaker(""),
+	}
+}
+
+func TestCallGuard_Panics(t *testing.T) {
+	guard := newTestGuard()
+	req := &v1.ExecuteRequest{Command: "up"}
+
+	// A panic in the plugin process arrives as an Internal status
+	err := guard.unary(context.Background(), executeMethod, req, nil, nil, invoker(func(context.Context) error {
+		return status.Error(codes.Internal, v1.PanicPrefix+" in up: nil pointer dereference")
+	}))
+	require.Error(t, err)
+	assert.Equal(t, "plugin 'docker': up panicked: nil pointer dereference", err.Error())
+
+	err = guard.unary(context.Background(), getMetadataMethod, &v1.Empty{}, nil, nil, invoker(func(context.Context) error {
+		panic("closed connection")
+	}))
+	require.Error(t, err)
+	assert.Equal(t, "plugin 'docker': GetMetadata panicked: closed connection", err.Error())
+	assert.Equal(t, 2, guard.breaker.Health("docker").Failures)
+
+	other := status.Error(codes.NotFound, "no such service")
+	err = guard.unary(context.Background(), executeMethod, req, nil, nil, invoker(func(context.Context) error {
+		return other
+	}))
+	assert.Equal(t, other, err, "errors the plugin returns pass through")
+	assert.Equal(t, 2, guard.breaker.Health("docker").Failures, "and are not plugin failures")
+}
+
+func TestCallGuard_Timeout(t *testing.T) {
+	guard := newTestGuard()
+	hang := invoker(func(ctx context.Context) error {
+		<-ctx.Done()
+		return status.FromContextError(ctx.Err()).Err()
+	})
+
+	err := guard.unary(context.Background(), getMetadataMethod, &v1.Empty{}, nil, nil, hang)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeTimeout))
+	assert.Contains(t, err.Error(), "plugin 'docker' did not answer GetMetadata within 20ms")
+
+	// Commands have no deadline unless one is configured
+	hasDeadline := true
+	err = guard.unary(context.Background(), executeMethod, &v1.ExecuteRequest{Command: "up"}, nil, nil, invoker(func(ctx context.Context) error {
+		_, hasDeadline = ctx.Deadline()
+		return nil
+	}))
+	require.NoError(t, err)
+	assert.False(t, hasDeadline)
+}
+
+func TestCallGuard_StreamTimeout(t *testing.T) {
+	guard := newTestGuard()
+	guard.executeTimeout = 20 * time.Millisecond
+
+	var streamCtx context.Context
+	streamer := func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		streamCtx = ctx
+		return &hangingStream{ctx: ctx}, nil
+	}
+
+	// Streamed commands get the execute deadline
+	stream, err := guard.stream(context.Background(), nil, nil, executeStreamMethod, streamer)
+	require.NoError(t, err)
+	_, hasDeadline := streamCtx.Deadline()
+	assert.True(t, hasDeadline)
+
+	err = stream.RecvMsg(&v1.ExecuteEvent{})
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeTimeout))
+	assert.Contains(t, err.Error(), "plugin 'docker' did not answer ExecuteCommandStream within 20ms")
+
+	// Interactive sessions have none
+	_, err = guard.stream(context.Background(), nil, nil, v1.GlidePlugin_StartInteractive_FullMethodName, streamer)
+	require.NoError(t, err)
+	_, hasDeadline = streamCtx.Deadline()
+	assert.False(t, hasDeadline)
+}
+
+func TestCallGuard_InjectedTimeout(t *testing.T) {
+	chaos.Set(chaos.Faults{chaos.PluginTimeout: "docker"})
+	t.Cleanup(func() { chaos.Set(nil) })
+
+	guard := newTestGuard()
+	called := false
+	call := invoker(func(context.Context) error {
+		called = true
+		return nil
+	})
+
+	err := guard.unary(context.Background(), executeMethod, &v1.ExecuteRequest{Command: "up"}, nil, nil, call)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeTimeout))
+	assert.Contains(t, err.Error(), "plugin 'docker' did not answer up in time")
+	assert.False(t, called, "the plugin is not called")
+
+	_, err = guard.stream(context.Background(), nil, nil, executeStreamMethod, nil)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeTimeout))
+
+	require.NoError(t, guard.unary(context.Background(), getMetadataMethod, &v1.Empty{}, nil, nil, call))
+	assert.True(t, called, "only commands time out")
+
+	guard.plugin = "acme"
+	require.NoError(t, guard.unary(context.Background(), executeMethod, &v1.ExecuteRequest{Command: "up"}, nil, nil, call),
+		"other plugins answer")
+}
+
+// hangingStream is a client stream that never receives anything
+type hangingStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *hangingStream) RecvMsg(interface{}) error {
+	<-s.ctx.Done()
+	return status.FromContextError(s.ctx.Err()).Err()
+}
+
+func TestCallGuard_Breaker(t *testing.T) {
+	guard := newTestGuard()
+	guard.breaker.Threshold = 2
+	calls := 0
+	unavailable := invoker(func(context.Context) error {
+		calls++
+		return status.Error(codes.Unavailable, "connection refused")
+	})
+
+	for i := 0; i < 2; i++ {
+		// Safe to ignore: the failures are what is being counted
+		_ = guard.unary(context.Background(), getMetadataMethod, &v1.Empty{}, nil, nil, unavailable)
+	}
+	err := guard.unary(context.Background(), getMetadataMethod, &v1.Empty{}, nil, nil, unavailable)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "plugin 'docker': unhealthy after 2 failures")
+	assert.Equal(t, 2, calls, "an unhealthy plugin is not called")
+}
@@ -0,0 +1,277 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	builtinsMu sync.Mutex
+	builtins   []v1.GlidePluginServer
+)
+
+// RegisterBuiltin compiles a plugin into glide. Its commands are served
+// in-process, without a subprocess or gRPC, but otherwise behave as those
+// of a runtime plugin; a runtime plugin of the same name is not loaded.
+// Call it from an init function, in a file behind a build tag.
+func RegisterBuiltin(server v1.GlidePluginServer) {
+	builtinsMu.Lock()
+	defer builtinsMu.Unlock()
+	builtins = append(builtins, server)
+}
+
+// registeredBuiltins returns the plugins compiled into glide
+func registeredBuiltins() []v1.GlidePluginServer {
+	builtinsMu.Lock()
+	defer builtinsMu.Unlock()
+	return append([]v1.GlidePluginServer(nil), builtins...)
+}
+
+// inProcessClient calls a plugin compiled into glide directly. Calls go
+// through the same guard as those to runtime plugins, so they get the same
+// deadlines, limits and panic handling.
+type inProcessClient struct {
+	server v1.GlidePluginServer
+	guard  *callGuard
+}
+
+func newInProcessClient(server v1.GlidePluginServer, guard *callGuard) v1.GlidePluginClient {
+	return &inProcessClient{server: server, guard: guard}
+}
+
+// invoke runs a unary call through the guard
+func invoke[Req any, Resp proto.Message](c *inProcessClient, ctx context.Context, method string, in *Req, reply Resp, call func(context.Context, *Req) (Resp, error)) (Resp, error) {
+	err := c.guard.unary(ctx, method, in, reply, nil, func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		resp, err := call(ctx, in)
+		if err != nil {
+			return inProcessError(ctx, err)
+		}
+		if resp.ProtoReflect().IsValid() {
+			proto.Merge(reply, resp)
+		}
+		return nil
+	})
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+	return reply, nil
+}
+
+// inProcessError reports a call that ended with its context the way gRPC
+// does, so the guard recognises timeouts
+func inProcessError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+		return status.FromContextError(ctxErr).Err()
+	}
+	return err
+}
+
+// GetMetadata implements v1.GlidePluginClient
+func (c *inProcessClient) GetMetadata(ctx context.Context, in *v1.Empty, _ ...grpc.CallOption) (*v1.PluginMetadata, error) {
+	return invoke(c, ctx, v1.GlidePlugin_GetMetadata_FullMethodName, in, new(v1.PluginMetadata), c.server.GetMetadata)
+}
+
+// Configure implements v1.GlidePluginClient
+func (c *inProcessClient) Configure(ctx context.Context, in *v1.ConfigureRequest, _ ...grpc.CallOption) (*v1.ConfigureResponse, error) {
+	return invoke(c, ctx, v1.GlidePlugin_Configure_FullMethodName, in, new(v1.ConfigureResponse), c.server.Configure)
+}
+
+// ListCommands implements v1.GlidePluginClient
+func (c *inProcessClient) ListCommands(ctx context.Context, in *v1.Empty, _ ...grpc.CallOption) (*v1.CommandList, error) {
+	return invoke(c, ctx, v1.GlidePlugin_ListCommands_FullMethodName, in, new(v1.CommandList), c.server.ListCommands)
+}
+
+// ExecuteCommand implements v1.GlidePluginClient
+func (c *inProcessClient) ExecuteCommand(ctx context.Context, in *v1.ExecuteRequest, _ ...grpc.CallOption) (*v1.ExecuteResponse, error) {
+	return invoke(c, ctx, v1.GlidePlugin_ExecuteCommand_FullMethodName, in, new(v1.ExecuteResponse), c.server.ExecuteCommand)
+}
+
+// Cancel implements v1.GlidePluginClient
+func (c *inProcessClient) Cancel(ctx context.Context, in *v1.CancelRequest, _ ...grpc.CallOption) (*v1.CancelResponse, error) {
+	return invoke(c, ctx, v1.GlidePlugin_Cancel_FullMethodName, in, new(v1.CancelResponse), c.server.Cancel)
+}
+
+// Complete implements v1.GlidePluginClient
+func (c *inProcessClient) Complete(ctx context.Context, in *v1.CompleteRequest, _ ...grpc.CallOption) (*v1.CompleteResponse, error) {
+	return invoke(c, ctx, v1.GlidePlugin_Complete_FullMethodName, in, new(v1.CompleteResponse), c.server.Complete)
+}
+
+// GetCapabilities implements v1.GlidePluginClient
+func (c *inProcessClient) GetCapabilities(ctx context.Context, in *v1.Empty, _ ...grpc.CallOption) (*v1.Capabilities, error) {
+	return invoke(c, ctx, v1.GlidePlugin_GetCapabilities_FullMethodName, in, new(v1.Capabilities), c.server.GetCapabilities)
+}
+
+// GetCustomCategories implements v1.GlidePluginClient
+func (c *inProcessClient) GetCustomCategories(ctx context.Context, in *v1.Empty, _ ...grpc.CallOption) (*v1.CategoryList, error) {
+	return invoke(c, ctx, v1.GlidePlugin_GetCustomCategories_FullMethodName, in, new(v1.CategoryList), c.server.GetCustomCategories)
+}
+
+// ExecuteCommandStream implements v1.GlidePluginClient
+func (c *inProcessClient) ExecuteCommandStream(ctx context.Context, in *v1.ExecuteRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[v1.ExecuteEvent], error) {
+	stream, err := c.openStream(ctx, &v1.GlidePlugin_ServiceDesc.Streams[0], v1.GlidePlugin_ExecuteCommandStream_FullMethodName, func(ss grpc.ServerStream) error {
+		return c.server.ExecuteCommandStream(in, &grpc.GenericServerStream[v1.ExecuteRequest, v1.ExecuteEvent]{ServerStream: ss})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &grpc.GenericClientStream[v1.ExecuteRequest, v1.ExecuteEvent]{ClientStream: stream}, nil
+}
+
+// StartInteractive implements v1.GlidePluginClient
+func (c *inProcessClient) StartInteractive(ctx context.Context, _ ...grpc.CallOption) (grpc.BidiStreamingClient[v1.StreamMessage, v1.StreamMessage], error) {
+	stream, err := c.openStream(ctx, &v1.GlidePlugin_ServiceDesc.Streams[1], v1.GlidePlugin_StartInteractive_FullMethodName, func(ss grpc.ServerStream) error {
+		return c.server.StartInteractive(&grpc.GenericServerStream[v1.StreamMessage, v1.StreamMessage]{ServerStream: ss})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &grpc.GenericClientStream[v1.StreamMessage, v1.StreamMessage]{ClientStream: stream}, nil
+}
+
+// openStream starts a streaming call through the guard, running handler
+// as the plugin's side of the stream
+func (c *inProcessClient) openStream(ctx context.Context, desc *grpc.StreamDesc, method string, handler func(grpc.ServerStream) error) (grpc.ClientStream, error) {
+	return c.guard.stream(ctx, desc, nil, method, func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, method string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		p := newPipe(ctx)
+		go func() {
+			// Panics end the stream as they do in a plugin process
+			err := v1.RecoveryStreamInterceptor(nil, serverEnd{p}, &grpc.StreamServerInfo{FullMethod: method}, func(_ interface{}, ss grpc.ServerStream) error {
+				return handler(ss)
+			})
+			p.finish(err)
+		}()
+		return clientEnd{p}, nil
+	})
+}
+
+// pipe carries the messages of an in-process stream. Messages are copied
+// as they are sent, as they would be on the wire.
+type pipe struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	toServer  chan proto.Message
+	closeSend sync.Once
+	toClient  chan proto.Message
+
+	// done is closed once the plugin's handler has returned with err
+	done chan struct{}
+	err  error
+}
+
+func newPipe(ctx context.Context) *pipe {
+	ctx, cancel := context.WithCancel(ctx)
+	return &pipe{
+		ctx:      ctx,
+		cancel:   cancel,
+		toServer: make(chan proto.Message),
+		toClient: make(chan proto.Message),
+		done:     make(chan struct{}),
+	}
+}
+
+// finish ends the stream with the handler's result
+func (p *pipe) finish(err error) {
+	p.err = inProcessError(p.ctx, err)
+	close(p.done)
+	p.cancel()
+}
+
+// end is what glide receives once the handler has returned
+func (p *pipe) end() error {
+	if p.err != nil {
+		return p.err
+	}
+	return io.EOF
+}
+
+// send hands a copy of m to the other side
+func (p *pipe) send(to chan proto.Message, m interface{}) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "in-process stream cannot send %T", m)
+	}
+	select {
+	case to <- proto.Clone(msg):
+		return nil
+	case <-p.done:
+		return io.EOF
+	case <-p.ctx.Done():
+		return status.FromContextError(p.ctx.Err()).Err()
+	}
+}
+
+// receive copies the next message into m
+func receive(m interface{}, msg proto.Message) {
+	dst := m.(proto.Message)
+	proto.Reset(dst)
+	proto.Merge(dst, msg)
+}
+
+// clientEnd is glide's side of a pipe
+type clientEnd struct{ p *pipe }
+
+func (c clientEnd) Header() (metadata.MD, error) { return nil, nil }
+func (c clientEnd) Trailer() metadata.MD         { return nil }
+func (c clientEnd) Context() context.Context     { return c.p.ctx }
+
+func (c clientEnd) CloseSend() error {
+	c.p.closeSend.Do(func() { close(c.p.toServer) })
+	return nil
+}
+
+func (c clientEnd) SendMsg(m interface{}) error {
+	return c.p.send(c.p.toServer, m)
+}
+
+func (c clientEnd) RecvMsg(m interface{}) error {
+	select {
+	case msg := <-c.p.toClient:
+		receive(m, msg)
+		return nil
+	case <-c.p.done:
+		return c.p.end()
+	case <-c.p.ctx.Done():
+		select {
+		case <-c.p.done:
+			return c.p.end()
+		default:
+			return status.FromContextError(c.p.ctx.Err()).Err()
+		}
+	}
+}
+
+// serverEnd is the plugin's side of a pipe
+type serverEnd struct{ p *pipe }
+
+func (s serverEnd) SetHeader(metadata.MD) error  { return nil }
+func (s serverEnd) SendHeader(metadata.MD) error { return nil }
+func (s serverEnd) SetTrailer(metadata.MD)       {}
+func (s serverEnd) Context() context.Context     { return s.p.ctx }
+
+func (s serverEnd) SendMsg(m interface{}) error {
+	return s.p.send(s.p.toClient, m)
+}
+
+func (s serverEnd) RecvMsg(m interface{}) error {
+	select {
+	case msg, ok := <-s.p.toServer:
+		if !ok {
+			return io.EOF
+		}
+		receive(m, msg)
+		return nil
+	case <-s.p.ctx.Done():
+		return status.FromContextError(s.p.ctx.Err()).Err()
+	}
+}
@@ -0,0 +1,172 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBuiltinPlugin returns a plugin with a plain, a streaming, a slow and a
+// panicking command
+func newBuiltinPlugin() *v1.BasePlugin {
+	p := v1.NewBasePlugin(&v1.PluginMetadata{Name: "docker", Version: "1.0.0"})
+	p.RegisterCommand("ps", v1.NewSimpleCommand(&v1.CommandInfo{Name: "ps"}, func(_ context.Context, req *v1.ExecuteRequest) (*v1.ExecuteResponse, error) {
+		return &v1.ExecuteResponse{Success: true, Stdout: []byte("running: " + req.Args[0] + "\n")}, nil
+	}))
+	p.RegisterCommand("build", v1.NewStreamingCommand(&v1.CommandInfo{Name: "build"}, func(_ context.Context, _ *v1.ExecuteRequest, out *v1.StreamWriter) (*v1.ExecuteResponse, error) {
+		_, _ = io.WriteString(out.Stdout(), "step 1\n")
+		_, _ = io.WriteString(out.Stderr(), "warning\n")
+		return &v1.ExecuteResponse{Success: true}, nil
+	}))
+	p.RegisterCommand("logs", v1.NewSimpleCommand(&v1.CommandInfo{Name: "logs"}, func(ctx context.Context, _ *v1.ExecuteRequest) (*v1.ExecuteResponse, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+	p.RegisterCommand("crash", v1.NewStreamingCommand(&v1.CommandInfo{Name: "crash"}, func(context.Context, *v1.ExecuteRequest, *v1.StreamWriter) (*v1.ExecuteResponse, error) {
+		panic("nil pointer dereference")
+	}))
+	return p
+}
+
+func TestInProcessClient_Execute(t *testing.T) {
+	client := newInProcessClient(newBuiltinPlugin(), newTestGuard())
+
+	resp, err := client.ExecuteCommand(context.Background(), &v1.ExecuteRequest{Command: "ps", Args: []string{"web"}})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Equal(t, "running: web\n", string(resp.Stdout))
+
+	meta, err := client.GetMetadata(context.Background(), &v1.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "docker", meta.Name)
+}
+
+func TestInProcessClient_Timeout(t *testing.T) {
+	guard := newTestGuard()
+	guard.executeTimeout = 20 * time.Millisecond
+	client := newInProcessClient(newBuiltinPlugin(), guard)
+
+	_, err := client.ExecuteCommand(context.Background(), &v1.ExecuteRequest{Command: "logs"})
+	require.Error(t, err)
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeTimeout), "got %v", err)
+	assert.Contains(t, err.Error(), "plugin 'docker' did not answer logs within 20ms")
+}
+
+func TestInProcessClient_Stream(t *testing.T) {
+	client := newInProcessClient(newBuiltinPlugin(), newTestGuard())
+
+	var stdout, stderr bytes.Buffer
+	resp, err := ExecuteStreaming(context.Background(), client, &v1.ExecuteRequest{Command: "build"}, &stdout, &stderr)
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Equal(t, "step 1\n", stdout.String())
+	assert.Equal(t, "warning\n", stderr.String())
+}
+
+func TestInProcessClient_StreamPanic(t *testing.T) {
+	client := newInProcessClient(newBuiltinPlugin(), newTestGuard())
+
+	_, err := ExecuteStreaming(context.Background(), client, &v1.ExecuteRequest{Command: "crash"}, io.Discard, io.Discard)
+	require.Error(t, err)
+	assert.Equal(t, "plugin 'docker': ExecuteCommandStream panicked: nil pointer dereference", err.Error())
+}
+
+// echoServer is an interactive plugin that echoes its input
+type echoServer struct {
+	v1.UnimplementedGlidePluginServer
+}
+
+func (echoServer) StartInteractive(stream v1.GlidePlugin_StartInteractiveServer) error {
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func TestInProcessClient_Interactive(t *testing.T) {
+	client := newInProcessClient(echoServer{}, newTestGuard())
+
+	stream, err := client.StartInteractive(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&v1.StreamMessage{Type: v1.StreamMessage_STDIN, Data: []byte("ls\n")}))
+	msg, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "ls\n", string(msg.Data))
+
+	require.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestManager_LoadsBuiltins(t *testing.T) {
+	builtinsMu.Lock()
+	saved := builtins
+	builtins = nil
+	builtinsMu.Unlock()
+	t.Cleanup(func() {
+		builtinsMu.Lock()
+		builtins = saved
+		builtinsMu.Unlock()
+	})
+
+	RegisterBuiltin(newBuiltinPlugin())
+
+	config := DefaultConfig()
+	config.PluginDirs = []string{t.TempDir()}
+	config.HealthFile = ""
+	m := NewManager(config)
+	require.NoError(t, m.DiscoverPlugins())
+
+	loaded, err := m.GetPlugin("docker")
+	require.NoError(t, err)
+	assert.True(t, loaded.Builtin)
+	assert.Nil(t, loaded.Client)
+
+	resp, err := loaded.Plugin.ExecuteCommand(context.Background(), &v1.ExecuteRequest{Command: "ps", Args: []string{"db"}})
+	require.NoError(t, err)
+	assert.Equal(t, "running: db\n", string(resp.Stdout))
+
+	m.Cleanup()
+}
+
+// slowCompleter never finishes completing
+type slowCompleter struct {
+	v1.UnimplementedGlidePluginServer
+}
+
+func (slowCompleter) Complete(ctx context.Context, _ *v1.CompleteRequest) (*v1.CompleteResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestInProcessClient_CompleteTimeout(t *testing.T) {
+	guard := newTestGuard()
+	guard.callTimeout = time.Minute
+	guard.completeTimeout = 20 * time.Millisecond
+	client := newInProcessClient(slowCompleter{}, guard)
+
+	for i := 0; i < 5; i++ {
+		_, err := client.Complete(context.Background(), &v1.CompleteRequest{Command: "logs"})
+		require.Error(t, err)
+		assert.True(t, glideErrors.Is(err, glideErrors.TypeTimeout), "got %v", err)
+	}
+	// Slow completion does not count against the plugin
+	assert.NoError(t, guard.breaker.Allow("docker"))
+}
@@ -2,8 +2,15 @@ package sdk
 
 import (
 	"context"
+	"time"
+
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
 )
 
+// healthCheckTimeout bounds asking a plugin's health service whether it is
+// ready
+const healthCheckTimeout = 5 * time.Second
+
 // lifecycleAdapter adapts a LoadedPlugin to the Lifecycle interface
 // This allows the LifecycleManager to manage plugin processes
 type lifecycleAdapter struct {
@@ -41,10 +48,15 @@ func (a *lifecycleAdapter) Stop(ctx context.Context) error {
 	return nil
 }
 
-// HealthCheck verifies the plugin is responsive
+// HealthCheck verifies the plugin is running and, through its gRPC health
+// service, ready to run commands
 func (a *lifecycleAdapter) HealthCheck() error {
 	// Check if the client is still alive by pinging it
 	// If the plugin process has died, this will fail
+	if a.loaded.Builtin {
+		// Builtin plugins run inside glide
+		return nil
+	}
 	if a.loaded.Client == nil {
 		return NewLifecycleError("HealthCheck", a.loaded.Name, "plugin client is nil", nil)
 	}
@@ -53,6 +65,13 @@ func (a *lifecycleAdapter) HealthCheck() error {
 		return NewLifecycleError("HealthCheck", a.loaded.Name, "plugin process has exited", nil)
 	}
 
-	// Plugin is alive - could be extended with actual RPC health check in v2
+	if a.loaded.health == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	if err := v1.CheckPluginHealth(ctx, a.loaded.health); err != nil {
+		return NewLifecycleError("HealthCheck", a.loaded.Name, "plugin is not ready", err)
+	}
 	return nil
 }
@@ -66,9 +66,8 @@ func NewLifecycleManager(config *LifecycleConfig) *LifecycleManager {
 	}
 
 	return &LifecycleManager{
-		plugins:      make(map[string]*ManagedPlugin),
-		config:       config,
-		shutdownChan: make(chan struct{}),
+		plugins: make(map[string]*ManagedPlugin),
+		config:  config,
 	}
 }
 
@@ -237,10 +236,7 @@ func (lm *LifecycleManager) StartAll(ctx context.Context) error {
 		}
 	}
 
-	// Start health checking if configured
-	if lm.config.HealthCheckInterval > 0 {
-		lm.startHealthChecking()
-	}
+	lm.StartHealthChecking()
 
 	return nil
 }
@@ -316,9 +312,21 @@ func (lm *LifecycleManager) HealthCheckPlugin(name string) error {
 	}
 }
 
-// startHealthChecking begins periodic health checks
-func (lm *LifecycleManager) startHealthChecking() {
-	lm.healthCheckTicker = time.NewTicker(lm.config.HealthCheckInterval)
+// StartHealthChecking begins checking the health of every operational
+// plugin each HealthCheckInterval, unless the interval is 0 or checks are
+// already running. StopAll stops them.
+func (lm *LifecycleManager) StartHealthChecking() {
+	if lm.config.HealthCheckInterval <= 0 {
+		return
+	}
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	if lm.healthCheckTicker != nil {
+		return
+	}
+	ticker := time.NewTicker(lm.config.HealthCheckInterval)
+	shutdown := make(chan struct{})
+	lm.healthCheckTicker, lm.shutdownChan = ticker, shutdown
 
 	lm.wg.Add(1)
 	go func() {
@@ -326,10 +334,10 @@ func (lm *LifecycleManager) startHealthChecking() {
 
 		for {
 			select {
-			case <-lm.healthCheckTicker.C:
+			case <-ticker.C:
 				lm.runHealthChecks()
 
-			case <-lm.shutdownChan:
+			case <-shutdown:
 				return
 			}
 		}
@@ -338,9 +346,14 @@ func (lm *LifecycleManager) startHealthChecking() {
 
 // stopHealthChecking stops periodic health checks
 func (lm *LifecycleManager) stopHealthChecking() {
-	if lm.healthCheckTicker != nil {
-		lm.healthCheckTicker.Stop()
-		close(lm.shutdownChan)
+	lm.mu.Lock()
+	ticker, shutdown := lm.healthCheckTicker, lm.shutdownChan
+	lm.healthCheckTicker, lm.shutdownChan = nil, nil
+	lm.mu.Unlock()
+
+	if ticker != nil {
+		ticker.Stop()
+		close(shutdown)
 		lm.wg.Wait()
 	}
 }
@@ -589,3 +589,37 @@ func TestLifecycleManager_GetPluginHealth_NotRegistered(t *testing.T) {
 		t.Error("GetPluginHealth() should error for non-registered plugin")
 	}
 }
+
+func TestLifecycleManager_StartHealthChecking(t *testing.T) {
+	lm := NewLifecycleManager(&LifecycleConfig{
+		HealthCheckInterval: 20 * time.Millisecond,
+		HealthCheckTimeout:  50 * time.Millisecond,
+	})
+	_ = lm.Register("test-plugin", &slowLifecycle{})
+	_ = lm.InitPlugin(context.Background(), "test-plugin")
+	_ = lm.StartPlugin(context.Background(), "test-plugin")
+
+	// Starting twice runs one set of checks
+	lm.StartHealthChecking()
+	lm.StartHealthChecking()
+	time.Sleep(60 * time.Millisecond)
+	lm.stopHealthChecking()
+
+	if lastCheck, _ := lm.GetPluginHealth("test-plugin"); lastCheck.IsZero() {
+		t.Error("LastHealthCheck should be set by periodic checks")
+	}
+
+	// Checks can be started again after they stopped
+	lm.StartHealthChecking()
+	lm.stopHealthChecking()
+	lm.stopHealthChecking()
+}
+
+func TestLifecycleManager_StartHealthChecking_Disabled(t *testing.T) {
+	lm := NewLifecycleManager(&LifecycleConfig{HealthCheckInterval: 0})
+	lm.StartHealthChecking()
+
+	if lm.healthCheckTicker != nil {
+		t.Error("StartHealthChecking() should not check without an interval")
+	}
+}
@@ -3,7 +3,13 @@ package sdk
 import (
 	"context"
 	"errors"
+	"os/exec"
 	"testing"
+
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	goplugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func TestLifecycleError_Error(t *testing.T) {
@@ -238,3 +244,32 @@ func TestStateTransitionError_Formatting(t *testing.T) {
 		t.Errorf("Error() = %v, want %v", got, expectedMsg)
 	}
 }
+
+// fakeHealthClient answers health checks with a fixed status
+type fakeHealthClient struct {
+	grpc_health_v1.HealthClient
+	status grpc_health_v1.HealthCheckResponse_ServingStatus
+}
+
+func (f *fakeHealthClient) Check(context.Context, *grpc_health_v1.HealthCheckRequest, ...grpc.CallOption) (*grpc_health_v1.HealthCheckResponse, error) {
+	return &grpc_health_v1.HealthCheckResponse{Status: f.status}, nil
+}
+
+func TestLifecycleAdapter_HealthCheck(t *testing.T) {
+	health := &fakeHealthClient{status: grpc_health_v1.HealthCheckResponse_SERVING}
+	loaded := &LoadedPlugin{
+		Name:   "docker",
+		Client: goplugin.NewClient(&goplugin.ClientConfig{HandshakeConfig: v1.HandshakeConfig, Cmd: exec.Command("true")}),
+		health: health,
+	}
+	adapter := newLifecycleAdapter(loaded)
+
+	if err := adapter.HealthCheck(); err != nil {
+		t.Errorf("HealthCheck() error = %v, want nil for a serving plugin", err)
+	}
+
+	health.status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if err := adapter.HealthCheck(); !errors.Is(err, v1.ErrNotServing) {
+		t.Errorf("HealthCheck() error = %v, want %v", err, v1.ErrNotServing)
+	}
+}
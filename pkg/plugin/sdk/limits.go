@@ -0,0 +1,160 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"github.com/glide-cli/glide/v3/pkg/progress"
+)
+
+const (
+	// DefaultMaxResponseSize bounds a single message from a plugin, as
+	// gRPC does by default
+	DefaultMaxResponseSize = 4 << 20
+
+	// DefaultMaxOutputSize bounds the output of a plugin command
+	DefaultMaxOutputSize = 64 << 20
+)
+
+// sizeUnits maps size suffixes to bytes, longest suffixes first
+var sizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+	{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30},
+	{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30},
+	{"B", 1},
+}
+
+// envSize reads a size such as "64MB" from an environment variable, or
+// returns fallback when it is unset or invalid. 0 means no limit.
+func envSize(name string, fallback int64) int64 {
+	if size, ok := parseSize(os.Getenv(name)); ok {
+		return size
+	}
+	return fallback
+}
+
+// parseSize parses a size in bytes with an optional unit, e.g. "512KB"
+func parseSize(s string) (int64, bool) {
+	s = strings.TrimSpace(s)
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s, multiplier = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix)), unit.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n * multiplier, true
+}
+
+// formatSize shows a size in bytes in the largest whole unit
+func formatSize(n int64) string {
+	for _, unit := range []struct {
+		suffix     string
+		multiplier int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}} {
+		if n >= unit.multiplier && n%unit.multiplier == 0 {
+			return fmt.Sprintf("%d%s", n/unit.multiplier, unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
+
+// outputLimit cuts a command's output off at the output limit, warning
+// once when it does
+type outputLimit struct {
+	guard    *callGuard
+	call     string
+	received int64
+	warned   bool
+}
+
+// allow returns how much of a chunk of output may still be shown
+func (l *outputLimit) allow(chunk []byte) []byte {
+	limit := l.guard.maxOutput
+	if limit <= 0 {
+		return chunk
+	}
+
+	remaining := limit - l.received
+	l.received += int64(len(chunk))
+	if int64(len(chunk)) <= remaining {
+		return chunk
+	}
+	if !l.warned {
+		l.warned = true
+		progress.Interrupt(func() {
+			logging.Warn("Plugin output was truncated; raise the limit with GLIDE_PLUGIN_MAX_OUTPUT",
+				"plugin", l.guard.plugin, "command", l.call, "limit", formatSize(limit))
+		})
+	}
+	return chunk[:max(remaining, 0)]
+}
+
+// truncateResponse cuts a command's response to the output limit
+func (g *callGuard) truncateResponse(call string, resp *v1.ExecuteResponse) {
+	limit := &outputLimit{guard: g, call: call}
+	resp.Stdout = limit.allow(resp.Stdout)
+	resp.Stderr = limit.allow(resp.Stderr)
+}
+
+// truncateEvent cuts the output of a streamed command to the output limit,
+// reporting whether anything of the event is left to show
+func (l *outputLimit) truncateEvent(event *v1.ExecuteEvent) bool {
+	switch e := event.Event.(type) {
+	case *v1.ExecuteEvent_Stdout:
+		e.Stdout = l.allow(e.Stdout)
+		return len(e.Stdout) > 0
+	case *v1.ExecuteEvent_Stderr:
+		e.Stderr = l.allow(e.Stderr)
+		return len(e.Stderr) > 0
+	}
+	return true
+}
+
+// rateLimiter slows a stream down to a rate in bytes per second. It allows
+// bursts of up to a second's worth.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate int64) *rateLimiter {
+	return &rateLimiter{rate: float64(rate), tokens: float64(rate), last: time.Now()}
+}
+
+// wait blocks until n more bytes may be received
+func (l *rateLimiter) wait(ctx context.Context, n int) {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"512":    512,
+		"64KB":   64 << 10,
+		"16MB":   16 << 20,
+		"2 GiB":  2 << 30,
+		"1M":     1 << 20,
+		"0":      0,
+		" 10B  ": 10,
+	}
+	for input, want := range tests {
+		size, ok := parseSize(input)
+		assert.True(t, ok, input)
+		assert.Equal(t, want, size, input)
+	}
+
+	for _, input := range []string{"", "lots", "-1MB", "1.5MB"} {
+		_, ok := parseSize(input)
+		assert.False(t, ok, input)
+	}
+
+	assert.Equal(t, "64MB", formatSize(64<<20))
+	assert.Equal(t, "1000 bytes", formatSize(1000))
+}
+
+func TestCallGuard_TruncatesResponses(t *testing.T) {
+	guard := newTestGuard()
+	guard.maxOutput = 8
+
+	resp := &v1.ExecuteResponse{Stdout: []byte("0123456789"), Stderr: []byte("error")}
+	err := guard.unary(context.Background(), executeMethod, &v1.ExecuteRequest{Command: "logs"}, resp, nil, invoker(func(context.Context) error {
+		return nil
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "01234567", string(resp.Stdout))
+	assert.Empty(t, resp.Stderr)
+}
+
+func TestCallGuard_ResponseTooLarge(t *testing.T) {
+	guard := newTestGuard()
+	guard.maxResponseSize = 4 << 20
+
+	err := guard.unary(context.Background(), executeMethod, &v1.ExecuteRequest{Command: "logs"}, nil, nil, invoker(func(context.Context) error {
+		return status.Error(codes.ResourceExhausted, "grpc: received message larger than max")
+	}))
+	assert.True(t, glideErrors.Is(err, glideErrors.TypeRuntime))
+	assert.Contains(t, err.Error(), "plugin 'docker' sent more than 4MB in answer to logs")
+	assert.Equal(t, 1, guard.breaker.Health("docker").Failures)
+}
+
+// replayStream is a client stream that replays events
+type replayStream struct {
+	grpc.ClientStream
+	events []*v1.ExecuteEvent
+}
+
+func (s *replayStream) Context() context.Context { return context.Background() }
+
+func (s *replayStream) RecvMsg(m interface{}) error {
+	if len(s.events) == 0 {
+		return io.EOF
+	}
+	proto.Reset(m.(proto.Message))
+	proto.Merge(m.(proto.Message), s.events[0])
+	s.events = s.events[1:]
+	return nil
+}
+
+func TestCallGuard_LimitsStreamedOutput(t *testing.T) {
+	guard := newTestGuard()
+	guard.maxOutput = 6
+
+	stdout := func(s string) *v1.ExecuteEvent {
+		return &v1.ExecuteEvent{Event: &v1.ExecuteEvent_Stdout{Stdout: []byte(s)}}
+	}
+	replay := &replayStream{events: []*v1.ExecuteEvent{
+		stdout("abcd"), stdout("efgh"), stdout("ijkl"),
+		{Event: &v1.ExecuteEvent_Result{Result: &v1.ExecuteResponse{Success: true}}},
+	}}
+	stream, err := guard.stream(context.Background(), nil, nil, executeStreamMethod, func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return replay, nil
+	})
+	require.NoError(t, err)
+
+	var received []string
+	for {
+		event := &v1.ExecuteEvent{}
+		if err := stream.RecvMsg(event); err != nil {
+			assert.Equal(t, io.EOF, err)
+			break
+		}
+		if event.GetResult() != nil {
+			received = append(received, "result")
+			continue
+		}
+		received = append(received, string(event.GetStdout()))
+	}
+	assert.Equal(t, []string{"abcd", "ef", "result"}, received, "output past the limit is dropped")
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(1000)
+
+	start := time.Now()
+	limiter.wait(context.Background(), 1000)
+	assert.Less(t, time.Since(start), 50*time.Millisecond, "a second's worth is allowed at once")
+
+	limiter.wait(context.Background(), 50)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "more has to wait")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start = time.Now()
+	limiter.wait(ctx, 1_000_000)
+	assert.Less(t, time.Since(start), 50*time.Millisecond, "waiting ends with the stream")
+}
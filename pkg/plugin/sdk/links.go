@@ -0,0 +1,230 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+)
+
+// Link is a runtime plugin discovered in the directory it is developed in,
+// and rebuilt there whenever its sources change
+type Link struct {
+	Name string `json:"name"`
+	// Dir is the plugin's source directory, which also holds its binary
+	Dir string `json:"dir"`
+}
+
+// DefaultLinksFile returns the file `glide plugins link` records links in
+func DefaultLinksFile() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "plugin-links.json")
+}
+
+// Binary returns the path the linked plugin is built to
+func (l Link) Binary() string {
+	name := l.Name
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(l.Dir, name)
+}
+
+// LoadLinks reads the links in path; a missing file has none
+func LoadLinks(path string) ([]Link, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var links []Link
+	if err := json.Unmarshal(data, &links); err != nil {
+		return nil, fmt.Errorf("invalid plugin links file %s: %w", path, err)
+	}
+	return links, nil
+}
+
+// SaveLinks replaces the links in path
+func SaveLinks(path string, links []Link) error {
+	sort.Slice(links, func(i, j int) bool { return links[i].Name < links[j].Name })
+	data, err := json.MarshalIndent(links, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// AddLink records a link in path, replacing any link of the same name
+func AddLink(path string, link Link) error {
+	links, err := LoadLinks(path)
+	if err != nil {
+		return err
+	}
+	links = removeLink(links, link.Name)
+	return SaveLinks(path, append(links, link))
+}
+
+// RemoveLink removes the link named name from path, reporting whether
+// there was one
+func RemoveLink(path, name string) (bool, error) {
+	links, err := LoadLinks(path)
+	if err != nil {
+		return false, err
+	}
+	kept := removeLink(links, name)
+	if len(kept) == len(links) {
+		return false, nil
+	}
+	return true, SaveLinks(path, kept)
+}
+
+func removeLink(links []Link, name string) []Link {
+	kept := links[:0:0]
+	for _, l := range links {
+		if l.Name != name {
+			kept = append(kept, l)
+		}
+	}
+	return kept
+}
+
+// Buildable reports whether the link's directory holds a Go module glide
+// can rebuild it from, rather than only a binary built some other way
+func (l Link) Buildable() bool {
+	_, err := os.Stat(filepath.Join(l.Dir, "go.mod"))
+	return err == nil
+}
+
+// Stale reports whether the linked plugin's binary is missing or older than
+// any of its Go sources, go.mod, or go.sum. Tests, hidden directories and
+// testdata do not count.
+func (l Link) Stale() (bool, error) {
+	binary, err := os.Stat(l.Binary())
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	built := binary.ModTime()
+
+	stale := false
+	err = filepath.WalkDir(l.Dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if path != l.Dir && (strings.HasPrefix(name, ".") || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isBuildSource(name) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(built) {
+			stale = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return stale, err
+}
+
+func isBuildSource(name string) bool {
+	if name == "go.mod" || name == "go.sum" {
+		return true
+	}
+	return strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+}
+
+// Build compiles the linked plugin with `go build` in its directory
+func (l Link) Build(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", l.Binary(), ".")
+	cmd.Dir = l.Dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to build linked plugin %s in %s: %w\n%s", l.Name, l.Dir, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Rebuild builds the linked plugin when its sources changed since it was
+// last built, reporting whether it did
+func (l Link) Rebuild(ctx context.Context) (bool, error) {
+	if !l.Buildable() {
+		return false, nil
+	}
+	stale, err := l.Stale()
+	if err != nil || !stale {
+		return false, err
+	}
+	return true, l.Build(ctx)
+}
+
+// DefaultLinkBuildTimeout bounds rebuilding a linked plugin before it is
+// loaded
+const DefaultLinkBuildTimeout = 5 * time.Minute
+
+// rebuildLinked rebuilds a linked plugin before it is loaded, when its
+// sources changed
+func rebuildLinked(info *PluginInfo) error {
+	link := Link{Name: info.Name, Dir: info.LinkDir}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultLinkBuildTimeout)
+	defer cancel()
+
+	started := time.Now()
+	rebuilt, err := link.Rebuild(ctx)
+	if err != nil {
+		return err
+	}
+	if rebuilt {
+		fmt.Fprintf(os.Stderr, "Rebuilt linked plugin %s in %s\n", info.Name, time.Since(started).Round(time.Millisecond))
+	}
+	return nil
+}
+
+// linkedPlugins returns the plugins linked in the links file, trusting the
+// directories they are built in
+func (m *Manager) linkedPlugins() []*PluginInfo {
+	if m.config.LinksFile == "" {
+		return nil
+	}
+	links, err := LoadLinks(m.config.LinksFile)
+	if err != nil {
+		logging.Warn("Ignoring linked plugins", "file", m.config.LinksFile, "error", err)
+		return nil
+	}
+
+	plugins := make([]*PluginInfo, 0, len(links))
+	for _, link := range links {
+		if !contains(m.validator.trustedPaths, link.Dir) {
+			m.validator.AddTrustedPath(link.Dir)
+		}
+		plugins = append(plugins, &PluginInfo{Name: link.Name, Path: link.Binary(), LinkDir: link.Dir})
+	}
+	return plugins
+}
@@ -0,0 +1,144 @@
+package sdk
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".glide", "plugin-links.json")
+
+	links, err := LoadLinks(path)
+	require.NoError(t, err)
+	assert.Empty(t, links, "a missing file has no links")
+
+	require.NoError(t, AddLink(path, Link{Name: "docker", Dir: "/src/docker"}))
+	require.NoError(t, AddLink(path, Link{Name: "aws", Dir: "/src/aws"}))
+	require.NoError(t, AddLink(path, Link{Name: "docker", Dir: "/src/docker-v2"}))
+
+	links, err = LoadLinks(path)
+	require.NoError(t, err)
+	assert.Equal(t, []Link{{Name: "aws", Dir: "/src/aws"}, {Name: "docker", Dir: "/src/docker-v2"}}, links)
+
+	removed, err := RemoveLink(path, "docker")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = RemoveLink(path, "docker")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	links, err = LoadLinks(path)
+	require.NoError(t, err)
+	assert.Equal(t, []Link{{Name: "aws", Dir: "/src/aws"}}, links)
+}
+
+func TestLinkStale(t *testing.T) {
+	dir := t.TempDir()
+	link := Link{Name: "hello", Dir: dir}
+	write := func(name string, at time.Time) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("package main\n"), 0644))
+		require.NoError(t, os.Chtimes(path, at, at))
+	}
+	built := time.Now().Add(-time.Hour)
+
+	write("main.go", built.Add(-time.Minute))
+	stale, err := link.Stale()
+	require.NoError(t, err)
+	assert.True(t, stale, "a missing binary is stale")
+
+	write("hello", built)
+	stale, err = link.Stale()
+	require.NoError(t, err)
+	assert.False(t, stale)
+
+	// Tests, testdata and hidden directories are not built into the plugin
+	write("main_test.go", built.Add(time.Minute))
+	write("testdata/fixture.go", built.Add(time.Minute))
+	write(".git/hooks.go", built.Add(time.Minute))
+	stale, err = link.Stale()
+	require.NoError(t, err)
+	assert.False(t, stale)
+
+	write("internal/server.go", built.Add(time.Minute))
+	stale, err = link.Stale()
+	require.NoError(t, err)
+	assert.True(t, stale)
+}
+
+func TestLinkRebuild(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/hello\n\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+	link := Link{Name: "hello", Dir: dir}
+
+	rebuilt, err := link.Rebuild(context.Background())
+	require.NoError(t, err)
+	assert.True(t, rebuilt)
+	assert.FileExists(t, link.Binary())
+
+	rebuilt, err = link.Rebuild(context.Background())
+	require.NoError(t, err)
+	assert.False(t, rebuilt, "unchanged sources are not rebuilt")
+
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() { undefined() }\n"), 0644))
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "main.go"), future, future))
+	_, err = link.Rebuild(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "undefined")
+}
+
+func TestDiscoverPlugins_Linked(t *testing.T) {
+	installed := t.TempDir()
+	for _, name := range []string{"docker", "aws"} {
+		require.NoError(t, os.WriteFile(filepath.Join(installed, name), []byte("#!/bin/sh\n"), 0755))
+	}
+	source := t.TempDir()
+	linksFile := filepath.Join(t.TempDir(), "plugin-links.json")
+	require.NoError(t, AddLink(linksFile, Link{Name: "docker", Dir: source}))
+
+	m := NewManager(&ManagerConfig{PluginDirs: []string{installed}, LinksFile: linksFile})
+	require.NoError(t, m.DiscoverPluginsLazy())
+
+	// The linked plugin shadows the installed one
+	require.Contains(t, m.discovered, "docker")
+	assert.Equal(t, filepath.Join(source, "docker"), m.discovered["docker"].Path)
+	assert.Equal(t, source, m.discovered["docker"].LinkDir)
+	assert.Equal(t, filepath.Join(installed, "aws"), m.discovered["aws"].Path)
+	assert.True(t, m.validator.isInTrustedPath(filepath.Join(source, "docker")))
+}
+
+func TestManagerLocate(t *testing.T) {
+	installed := t.TempDir()
+	for _, name := range []string{"docker", "aws"} {
+		require.NoError(t, os.WriteFile(filepath.Join(installed, name), []byte("#!/bin/sh\n"), 0755))
+	}
+	source := t.TempDir()
+	linksFile := filepath.Join(t.TempDir(), "plugin-links.json")
+	require.NoError(t, AddLink(linksFile, Link{Name: "docker", Dir: source}))
+	m := NewManager(&ManagerConfig{PluginDirs: []string{installed}, LinksFile: linksFile})
+
+	path, builtin, ok := m.Locate("docker")
+	require.True(t, ok)
+	assert.False(t, builtin)
+	assert.Equal(t, filepath.Join(source, "docker"), path, "a linked plugin is run from its source directory")
+
+	path, _, ok = m.Locate("aws")
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(installed, "aws"), path)
+
+	_, _, ok = m.Locate("missing")
+	assert.False(t, ok)
+}
@@ -0,0 +1,247 @@
+package sdk
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultLogMaxSize is the size at which a plugin's log file is rotated
+	DefaultLogMaxSize = 1 << 20
+
+	// DefaultLogBackups is the number of rotated log files kept per plugin
+	DefaultLogBackups = 3
+)
+
+// DefaultLogDir returns the directory plugin logs are written to
+func DefaultLogDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, branding.GetPluginDirName(), "logs", "plugins")
+}
+
+// LogPath returns the path of a plugin's current log file
+func LogPath(dir, name string) string {
+	return filepath.Join(dir, name+".log")
+}
+
+// LoggedPlugins returns the names of the plugins with logs in dir
+func LoggedPlugins(dir string) []string {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.log"))
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		names = append(names, strings.TrimSuffix(filepath.Base(path), ".log"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RotatingWriter appends to a log file, moving it to path.1, path.2, ...
+// when it would grow past MaxSize
+type RotatingWriter struct {
+	MaxSize int64
+	Backups int
+
+	mu   sync.Mutex
+	path string
+	file *os.File
+	size int64
+}
+
+// OpenRotatingWriter opens path for appending
+func OpenRotatingWriter(path string) (*RotatingWriter, error) {
+	w := &RotatingWriter{MaxSize: DefaultLogMaxSize, Backups: DefaultLogBackups, path: path}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write appends p, rotating first when the file is full
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+	if w.size > 0 && w.size+int64(len(p)) > w.MaxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the file
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// open opens the current file; caller must hold w.mu
+func (w *RotatingWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	w.file, w.size = file, info.Size()
+	return nil
+}
+
+// rotate shifts the backups and starts a new file; caller must hold w.mu
+func (w *RotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	// Safe to ignore: a missing backup only means fewer were kept
+	_ = os.Remove(fmt.Sprintf("%s.%d", w.path, w.Backups))
+	for i := w.Backups - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i+1))
+	}
+	if w.Backups > 0 {
+		_ = os.Rename(w.path, w.path+".1")
+	} else {
+		_ = os.Remove(w.path)
+	}
+	return w.open()
+}
+
+// pluginLogger returns the logger a plugin's output is sent to: its log
+// file, plus stderr with GLIDE_PLUGIN_DEBUG or GLIDE_PLUGIN_TRACE. The
+// returned closer, which may be nil, closes the log file.
+func pluginLogger(logDir, name string) (hclog.Logger, io.Closer) {
+	var output io.Writer = io.Discard
+	var closer io.Closer
+	if logDir != "" {
+		if w, err := OpenRotatingWriter(LogPath(logDir, name)); err == nil {
+			output, closer = w, w
+		}
+	}
+
+	logger := hclog.NewInterceptLogger(&hclog.LoggerOptions{
+		Name:       name,
+		Level:      hclog.Trace,
+		Output:     output,
+		JSONFormat: true,
+	})
+
+	switch {
+	case os.Getenv("GLIDE_PLUGIN_TRACE") == "true" || os.Getenv("PLUGIN_TRACE") == "true":
+		logger.RegisterSink(hclog.NewSinkAdapter(&hclog.LoggerOptions{Name: "plugin", Level: hclog.Trace, Output: os.Stderr}))
+	case os.Getenv("GLIDE_PLUGIN_DEBUG") == "true" || os.Getenv("PLUGIN_DEBUG") == "true":
+		logger.RegisterSink(hclog.NewSinkAdapter(&hclog.LoggerOptions{Name: "plugin", Level: hclog.Debug, Output: os.Stderr}))
+	}
+	return logger, closer
+}
+
+// LogEntry is one line of a plugin's log
+type LogEntry struct {
+	Time    time.Time
+	Level   hclog.Level
+	Message string
+	// Fields are the entry's key/value pairs, sorted by key
+	Fields [][2]string
+}
+
+// String formats the entry as "time LEVEL message key=value"
+func (e LogEntry) String() string {
+	var b strings.Builder
+	if !e.Time.IsZero() {
+		b.WriteString(e.Time.Local().Format("2006-01-02 15:04:05") + " ")
+	}
+	fmt.Fprintf(&b, "%-5s %s", strings.ToUpper(e.Level.String()), e.Message)
+	for _, field := range e.Fields {
+		fmt.Fprintf(&b, " %s=%s", field[0], field[1])
+	}
+	return b.String()
+}
+
+// ParseLogLine parses a line written by a plugin logger. Lines that are
+// not JSON are kept as info messages.
+func ParseLogLine(line string) LogEntry {
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &raw); err != nil {
+		return LogEntry{Level: hclog.Info, Message: line}
+	}
+
+	entry := LogEntry{Level: hclog.Info}
+	for key, value := range raw {
+		switch key {
+		case "@timestamp":
+			if s, ok := value.(string); ok {
+				entry.Time, _ = time.Parse(hclog.TimeFormatJSON, s)
+			}
+		case "@level":
+			if s, ok := value.(string); ok {
+				entry.Level = hclog.LevelFromString(s)
+			}
+		case "@message":
+			entry.Message = fmt.Sprint(value)
+		case "@module", "@caller":
+		default:
+			entry.Fields = append(entry.Fields, [2]string{key, fmt.Sprint(value)})
+		}
+	}
+	sort.Slice(entry.Fields, func(i, j int) bool { return entry.Fields[i][0] < entry.Fields[j][0] })
+	return entry
+}
+
+// ReadLogs returns a plugin's log entries at or above level, oldest first,
+// including the rotated files
+func ReadLogs(dir, name string, level hclog.Level) ([]LogEntry, error) {
+	path := LogPath(dir, name)
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	backups, _ := filepath.Glob(path + ".*")
+	// Highest numbered backups are oldest
+	sort.Slice(backups, func(i, j int) bool { return backups[i] > backups[j] })
+
+	var entries []LogEntry
+	for _, file := range append(backups, path) {
+		f, err := os.Open(file)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if line := scanner.Text(); line != "" {
+				if entry := ParseLogLine(line); entry.Level >= level {
+					entries = append(entries, entry)
+				}
+			}
+		}
+		f.Close()
+	}
+	return entries, nil
+}
@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "docker.log")
+	w, err := OpenRotatingWriter(path)
+	require.NoError(t, err)
+	w.MaxSize, w.Backups = 10, 2
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	read := func(name string) string {
+		data, _ := os.ReadFile(name)
+		return string(data)
+	}
+	assert.Equal(t, "fourth\n", read(path))
+	assert.Equal(t, "third\n", read(path+".1"))
+	assert.Equal(t, "second\n", read(path+".2"))
+	assert.NoFileExists(t, path+".3", "only the backups are kept")
+}
+
+func TestPluginLogger(t *testing.T) {
+	dir := t.TempDir()
+	logger, closer := pluginLogger(dir, "docker")
+	require.NotNil(t, closer)
+
+	logger.Debug("connecting", "host", "unix")
+	logger.Warn("slow response", "ms", 1200)
+	require.NoError(t, closer.Close())
+
+	entries, err := ReadLogs(dir, "docker", hclog.Info)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, hclog.Warn, entries[0].Level)
+	assert.Equal(t, "slow response", entries[0].Message)
+	assert.Equal(t, [][2]string{{"ms", "1200"}}, entries[0].Fields)
+	assert.False(t, entries[0].Time.IsZero())
+
+	all, err := ReadLogs(dir, "docker", hclog.Trace)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, []string{"docker"}, LoggedPlugins(dir))
+}
+
+func TestReadLogs_Rotated(t *testing.T) {
+	dir := t.TempDir()
+	path := LogPath(dir, "docker")
+	require.NoError(t, os.WriteFile(path+".2", []byte("oldest\n"), 0644))
+	require.NoError(t, os.WriteFile(path+".1", []byte("older\n"), 0644))
+	require.NoError(t, os.WriteFile(path, []byte("newest\n"), 0644))
+
+	entries, err := ReadLogs(dir, "docker", hclog.Trace)
+	require.NoError(t, err)
+	var messages []string
+	for _, entry := range entries {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{"oldest", "older", "newest"}, messages)
+
+	_, err = ReadLogs(dir, "missing", hclog.Trace)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestParseLogLine(t *testing.T) {
+	entry := ParseLogLine(`{"@level":"error","@message":"query failed","@module":"db","@timestamp":"2026-03-01T12:00:00.000000Z","table":"users"}`)
+	assert.Equal(t, hclog.Error, entry.Level)
+	assert.True(t, strings.HasSuffix(entry.String(), "ERROR query failed table=users"))
+
+	plain := ParseLogLine("panic: runtime error")
+	assert.Equal(t, hclog.Info, plain.Level)
+	assert.Equal(t, "INFO  panic: runtime error", plain.String())
+}
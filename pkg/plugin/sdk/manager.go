@@ -7,20 +7,35 @@ import (
 	"io"
 	"log"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/glide-cli/glide/v3/pkg/branding"
+	pkgconfig "github.com/glide-cli/glide/v3/pkg/config"
+	"github.com/glide-cli/glide/v3/pkg/invocation"
+	"github.com/glide-cli/glide/v3/pkg/logging"
 	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
-	"github.com/hashicorp/go-hclog"
+	"github.com/glide-cli/glide/v3/pkg/retry"
 	goplugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
+// handshakeRetryPolicy retries a plugin's first call once when its gRPC
+// server is not serving yet. Each failed attempt counts against the
+// plugin's circuit breaker, so it is not retried more often.
+var handshakeRetryPolicy = retry.Policy{
+	InitialInterval: 200 * time.Millisecond,
+	Jitter:          0.2,
+	MaxAttempts:     2,
+}
+
 // Cache is a simple plugin cache
 type Cache struct {
 	mu    sync.RWMutex
@@ -66,6 +81,7 @@ type Manager struct {
 	config           *ManagerConfig
 	lifecycleManager *LifecycleManager
 	resolver         *DependencyResolver
+	breaker          *CircuitBreaker
 }
 
 // LoadedPlugin represents a loaded and running plugin
@@ -77,6 +93,10 @@ type LoadedPlugin struct {
 	Metadata *v1.PluginMetadata
 	LastUsed time.Time
 	State    *StateTracker // Lifecycle state tracking
+	Builtin  bool          // Compiled into glide and served in-process
+
+	logFile io.Closer                   // the plugin's log file, if any
+	health  grpc_health_v1.HealthClient // the plugin's gRPC health service
 }
 
 // ManagerConfig configures the plugin manager
@@ -86,6 +106,45 @@ type ManagerConfig struct {
 	MaxPlugins     int
 	EnableDebug    bool
 	SecurityStrict bool
+	// CallTimeout bounds plugin RPCs such as GetMetadata; 0 means none
+	CallTimeout time.Duration
+	// ExecuteTimeout bounds non-interactive plugin commands; 0 means none
+	ExecuteTimeout time.Duration
+	// CompleteTimeout bounds shell completion by a plugin; 0 means none
+	CompleteTimeout time.Duration
+	// MaxResponseSize bounds a single message from a plugin, in bytes
+	MaxResponseSize int64
+	// MaxOutputSize bounds the output of a plugin command, in bytes; output
+	// past it is dropped with a warning. 0 means no limit.
+	MaxOutputSize int64
+	// MaxStreamRate slows plugins streaming more bytes per second down; 0
+	// means no limit
+	MaxStreamRate int64
+	// LogDir receives a rotating log file per plugin; empty discards logs
+	LogDir string
+	// ProjectRoot is where plugins whose manifest asks for the project root
+	// run; empty outside a project
+	ProjectRoot string
+	// HealthFile records plugin failures across invocations, so a plugin
+	// that keeps failing is skipped; empty keeps them in memory
+	HealthFile string
+	// EnabledPlugins, when set, lists the only runtime plugins discovery
+	// activates; DisabledPlugins are never activated. A project sets them to
+	// keep plugins irrelevant to it out of its commands.
+	EnabledPlugins  []string
+	DisabledPlugins []string
+	// LinksFile lists plugins linked from the directories they are
+	// developed in; they take precedence over installed plugins of the
+	// same name. Empty ignores links.
+	LinksFile string
+}
+
+// Activates reports whether discovery activates the runtime plugin name
+func (c *ManagerConfig) Activates(name string) bool {
+	if slices.Contains(c.DisabledPlugins, name) {
+		return false
+	}
+	return len(c.EnabledPlugins) == 0 || slices.Contains(c.EnabledPlugins, name)
 }
 
 // DefaultConfig returns default manager configuration
@@ -112,13 +171,35 @@ func DefaultConfig() *ManagerConfig {
 		pluginDirs = append(pluginDirs, systemPluginDir)
 	}
 
+	homeDir, _ := os.UserHomeDir()
+
 	return &ManagerConfig{
 		PluginDirs:     pluginDirs,
 		CacheTimeout:   5 * time.Minute,
 		MaxPlugins:     10,
 		EnableDebug:    os.Getenv("GLIDE_PLUGIN_DEBUG") == "1",
 		SecurityStrict: true,
+		CallTimeout:    envDuration("GLIDE_PLUGIN_TIMEOUT", DefaultCallTimeout),
+		ExecuteTimeout: envDuration("GLIDE_PLUGIN_EXECUTE_TIMEOUT", 0),
+		// Slow completions give up rather than hang the shell
+		CompleteTimeout: envDuration("GLIDE_PLUGIN_COMPLETE_TIMEOUT", DefaultCompleteTimeout),
+		// Limits keep a misbehaving plugin from exhausting memory
+		MaxResponseSize: envSize("GLIDE_PLUGIN_MAX_RESPONSE", DefaultMaxResponseSize),
+		MaxOutputSize:   envSize("GLIDE_PLUGIN_MAX_OUTPUT", DefaultMaxOutputSize),
+		MaxStreamRate:   envSize("GLIDE_PLUGIN_MAX_RATE", 0),
+		LogDir:          DefaultLogDir(),
+		HealthFile:      filepath.Join(homeDir, branding.GetPluginDirName(), "plugin-health.json"),
+		LinksFile:       DefaultLinksFile(),
+	}
+}
+
+// envDuration reads a duration from an environment variable, or returns
+// fallback when it is unset or invalid
+func envDuration(name string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(name)); err == nil {
+		return d
 	}
+	return fallback
 }
 
 // NewManager creates a new plugin manager
@@ -149,6 +230,7 @@ func NewManager(config *ManagerConfig) *Manager {
 		config:           config,
 		lifecycleManager: lifecycleManager,
 		resolver:         resolver,
+		breaker:          NewCircuitBreaker(config.HealthFile),
 	}
 }
 
@@ -169,10 +251,29 @@ func (m *Manager) DiscoverPluginsWithOptions(lazy bool) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	plugins, err := m.discoverer.Scan()
+	// Plugins compiled into glide take precedence over runtime plugins of
+	// the same name
+	m.loadBuiltinsUnlocked()
+
+	scanned, err := m.discoverer.Scan()
 	if err != nil {
 		return fmt.Errorf("plugin discovery failed: %w", err)
 	}
+	// Linked plugins shadow installed ones, so a plugin under development
+	// replaces its release
+	plugins := m.linkedPlugins()
+	for _, p := range scanned {
+		if !slices.ContainsFunc(plugins, func(linked *PluginInfo) bool { return linked.Name == p.Name }) {
+			plugins = append(plugins, p)
+		}
+	}
+	plugins = slices.DeleteFunc(plugins, func(p *PluginInfo) bool {
+		if m.config.Activates(p.Name) {
+			return false
+		}
+		logging.Debug("Skipping plugin the project does not enable", "plugin", p.Name, "path", p.Path)
+		return true
+	})
 
 	if lazy {
 		// Just store discovered plugins without loading
@@ -183,6 +284,7 @@ func (m *Manager) DiscoverPluginsWithOptions(lazy bool) error {
 
 			// Skip if already loaded or discovered
 			if _, exists := m.plugins[p.Name]; exists {
+				logging.Debug("Skipping plugin that is already loaded", "plugin", p.Name, "path", p.Path)
 				continue
 			}
 			if _, exists := m.discovered[p.Name]; exists {
@@ -198,6 +300,44 @@ func (m *Manager) DiscoverPluginsWithOptions(lazy bool) error {
 	return m.loadPluginsSequential(plugins)
 }
 
+// Locate returns where the plugin name is loaded from, preferring sources
+// as DiscoverPlugins does: compiled into glide (builtin, with no path),
+// linked with `glide plugins link`, then the plugin directories. ok is
+// false when no source has it.
+func (m *Manager) Locate(name string) (path string, builtin, ok bool) {
+	for _, server := range registeredBuiltins() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		metadata, err := server.GetMetadata(ctx, &v1.Empty{})
+		cancel()
+		if err == nil && metadata.Name == name {
+			return "", true, true
+		}
+	}
+
+	if m.config.LinksFile != "" {
+		links, err := LoadLinks(m.config.LinksFile)
+		if err != nil {
+			logging.Warn("Ignoring linked plugins", "file", m.config.LinksFile, "error", err)
+		}
+		for _, link := range links {
+			if link.Name == name {
+				return link.Binary(), false, true
+			}
+		}
+	}
+
+	scanned, err := m.discoverer.Scan()
+	if err != nil {
+		return "", false, false
+	}
+	for _, p := range scanned {
+		if p.Name == name {
+			return p.Path, false, true
+		}
+	}
+	return "", false, false
+}
+
 // loadPluginsSequential loads multiple plugins one at a time.
 // Note: Parallel loading was removed due to a data race in hashicorp/go-plugin v1.7.0
 // (race between goroutines in Client.Start). Sequential loading is sufficient for
@@ -206,6 +346,13 @@ func (m *Manager) loadPluginsSequential(plugins []*PluginInfo) error {
 	for _, p := range plugins {
 		// Skip if already loaded
 		if _, exists := m.plugins[p.Name]; exists {
+			logging.Debug("Skipping plugin that is already loaded", "plugin", p.Name, "path", p.Path)
+			continue
+		}
+
+		// Skip plugins that keep hanging or crashing
+		if err := m.breaker.Allow(p.Name); err != nil {
+			logging.Warn("Skipping plugin", "plugin", p.Name, "reason", err)
 			continue
 		}
 
@@ -222,9 +369,33 @@ func (m *Manager) loadPluginsSequential(plugins []*PluginInfo) error {
 	return nil
 }
 
+// handshakeMetadata fetches a freshly started plugin's metadata, retrying
+// while the plugin is unavailable
+func handshakeMetadata(ctx context.Context, plugin v1.GlidePluginClient) (*v1.PluginMetadata, error) {
+	var metadata *v1.PluginMetadata
+	err := retry.Do(ctx, handshakeRetryPolicy, func(ctx context.Context) (err error) {
+		metadata, err = plugin.GetMetadata(ctx, &v1.Empty{})
+		if status.Code(err) != codes.Unavailable {
+			return retry.Permanent(err)
+		}
+		return err
+	})
+	return metadata, err
+}
+
 // loadPluginUnlocked loads a plugin without holding the lock (for parallel loading)
 // Note: Caller must hold m.mu.Lock()
 func (m *Manager) loadPluginUnlocked(info *PluginInfo) error {
+	if err := m.breaker.Allow(info.Name); err != nil {
+		return err
+	}
+
+	if info.LinkDir != "" {
+		if err := rebuildLinked(info); err != nil {
+			return err
+		}
+	}
+
 	// Validate plugin
 	if err := m.validator.Validate(info.Path); err != nil {
 		return fmt.Errorf("plugin validation failed: %w", err)
@@ -236,39 +407,51 @@ func (m *Manager) loadPluginUnlocked(info *PluginInfo) error {
 		return nil
 	}
 
-	// Configure plugin logger based on environment
-	var logger hclog.Logger
-	switch {
-	case os.Getenv("GLIDE_PLUGIN_DEBUG") == "true" || os.Getenv("PLUGIN_DEBUG") == "true":
-		logger = hclog.New(&hclog.LoggerOptions{
-			Name:   "plugin",
-			Level:  hclog.Debug,
-			Output: os.Stderr,
-		})
-	case os.Getenv("GLIDE_PLUGIN_TRACE") == "true" || os.Getenv("PLUGIN_TRACE") == "true":
-		logger = hclog.New(&hclog.LoggerOptions{
-			Name:   "plugin",
-			Level:  hclog.Trace,
-			Output: os.Stderr,
-		})
-	default:
-		logger = hclog.NewNullLogger()
+	// The plugin's logs go to its log file, and to stderr when debugging
+	logger, logFile := pluginLogger(m.config.LogDir, info.Name)
+	started := false
+	defer func() {
+		if !started && logFile != nil {
+			logFile.Close()
+		}
+	}()
+
+	// Every call is bounded, recovered, and counted against the plugin
+	guard := &callGuard{
+		plugin:          info.Name,
+		callTimeout:     m.config.CallTimeout,
+		executeTimeout:  m.config.ExecuteTimeout,
+		completeTimeout: m.config.CompleteTimeout,
+		breaker:         m.breaker,
+
+		maxResponseSize: m.config.MaxResponseSize,
+		maxOutput:       m.config.MaxOutputSize,
+		maxRate:         m.config.MaxStreamRate,
+	}
+
+	// The plugin's manifest decides its environment and directory
+	cmd, skipHostEnv, err := m.pluginCommand(info)
+	if err != nil {
+		return err
 	}
 
 	// Create plugin client
 	client := goplugin.NewClient(&goplugin.ClientConfig{
 		HandshakeConfig:  v1.HandshakeConfig,
 		Plugins:          v1.PluginMap,
-		Cmd:              exec.Command(info.Path),
+		Cmd:              cmd,
+		SkipHostEnv:      skipHostEnv,
 		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
 		Managed:          true,
 		Logger:           logger,
+		GRPCDialOptions:  guard.dialOptions(),
 	})
 
 	// Connect to plugin
 	rpcClient, err := client.Client()
 	if err != nil {
 		client.Kill()
+		guard.record(err, true)
 		return fmt.Errorf("failed to connect to plugin: %w", err)
 	}
 
@@ -289,12 +472,22 @@ func (m *Manager) loadPluginUnlocked(info *PluginInfo) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	metadata, err := glidePlugin.GetMetadata(ctx, &v1.Empty{})
+	metadata, err := handshakeMetadata(ctx, glidePlugin)
 	if err != nil {
 		client.Kill()
 		return fmt.Errorf("failed to get plugin metadata: %w", err)
 	}
 
+	// Hand the plugin its own configuration and the shared section, never
+	// the whole plugins: map. The scope follows the binary discovered, not
+	// the name the plugin reports, so it cannot claim another's secrets.
+	scope := scopeName(info)
+	if metadata.Name != scope {
+		logging.Warn("Plugin reports a name other than its binary's; it is configured as its binary",
+			"plugin", metadata.Name, "binary", info.Path, "scope", scope)
+	}
+	configurePlugin(glidePlugin, scope)
+
 	// Create loaded plugin with state tracker
 	loaded := &LoadedPlugin{
 		Name:     metadata.Name,
@@ -304,6 +497,10 @@ func (m *Manager) loadPluginUnlocked(info *PluginInfo) error {
 		Metadata: metadata,
 		LastUsed: time.Now(),
 		State:    NewStateTracker(metadata.Name),
+		logFile:  logFile,
+	}
+	if conn, ok := rpcClient.(*goplugin.GRPCClient); ok {
+		loaded.health = grpc_health_v1.NewHealthClient(conn.Conn)
 	}
 
 	// Store in manager and cache
@@ -334,6 +531,12 @@ func (m *Manager) loadPluginUnlocked(info *PluginInfo) error {
 		return fmt.Errorf("failed to start plugin: %w", err)
 	}
 
+	// Commands of a plugin that is not ready are left out of completion
+	// until a later check finds it recovered
+	_ = m.lifecycleManager.HealthCheckPlugin(metadata.Name)
+	m.lifecycleManager.StartHealthChecking()
+
+	started = true
 	if m.config.EnableDebug {
 		log.Printf("Loaded plugin: %s v%s", metadata.Name, metadata.Version)
 	}
@@ -341,6 +544,102 @@ func (m *Manager) loadPluginUnlocked(info *PluginInfo) error {
 	return nil
 }
 
+// loadBuiltinsUnlocked loads the plugins compiled into glide that are not
+// loaded yet. A builtin that fails to load is skipped, as runtime plugins are.
+// Note: Caller must hold m.mu.Lock()
+func (m *Manager) loadBuiltinsUnlocked() {
+	for _, server := range registeredBuiltins() {
+		if err := m.loadBuiltinUnlocked(server); err != nil {
+			logging.Warn("Failed to load builtin plugin", "error", err)
+		}
+	}
+}
+
+// loadBuiltinUnlocked loads a plugin compiled into glide
+// Note: Caller must hold m.mu.Lock()
+func (m *Manager) loadBuiltinUnlocked(server v1.GlidePluginServer) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The guard needs the plugin's name before anything else is called
+	metadata, err := server.GetMetadata(ctx, &v1.Empty{})
+	if err != nil {
+		return fmt.Errorf("failed to get plugin metadata: %w", err)
+	}
+	if _, exists := m.plugins[metadata.Name]; exists {
+		return nil
+	}
+
+	guard := &callGuard{
+		plugin:          metadata.Name,
+		callTimeout:     m.config.CallTimeout,
+		executeTimeout:  m.config.ExecuteTimeout,
+		completeTimeout: m.config.CompleteTimeout,
+		breaker:         m.breaker,
+
+		maxOutput: m.config.MaxOutputSize,
+		maxRate:   m.config.MaxStreamRate,
+	}
+	glidePlugin := newInProcessClient(server, guard)
+	configurePlugin(glidePlugin, metadata.Name)
+
+	loaded := &LoadedPlugin{
+		Name:     metadata.Name,
+		Plugin:   glidePlugin,
+		Metadata: metadata,
+		LastUsed: time.Now(),
+		State:    NewStateTracker(metadata.Name),
+		Builtin:  true,
+	}
+	m.plugins[metadata.Name] = loaded
+
+	if err := m.lifecycleManager.Register(metadata.Name, newLifecycleAdapter(loaded)); err != nil {
+		delete(m.plugins, metadata.Name)
+		return fmt.Errorf("failed to register plugin with lifecycle manager: %w", err)
+	}
+	if err := m.lifecycleManager.InitPlugin(ctx, metadata.Name); err != nil {
+		delete(m.plugins, metadata.Name)
+		_ = m.lifecycleManager.Unregister(metadata.Name)
+		return fmt.Errorf("failed to initialize plugin: %w", err)
+	}
+	if err := m.lifecycleManager.StartPlugin(ctx, metadata.Name); err != nil {
+		delete(m.plugins, metadata.Name)
+		_ = m.lifecycleManager.Unregister(metadata.Name)
+		return fmt.Errorf("failed to start plugin: %w", err)
+	}
+
+	logging.Debug("Loaded builtin plugin", "plugin", metadata.Name, "version", metadata.Version)
+	return nil
+}
+
+// scopeName returns the name a discovered plugin's configuration is kept
+// under: its binary's name without the glide-plugin- prefix
+func scopeName(info *PluginInfo) string {
+	name := strings.TrimSuffix(filepath.Base(info.Path), ".exe")
+	return strings.TrimPrefix(name, "glide-plugin-")
+}
+
+// configurePlugin sends a runtime plugin its configuration scope. Plugins
+// that do not implement Configure, or reject their configuration, still
+// load; their commands report problems when they run.
+func configurePlugin(p v1.GlidePluginClient, name string) {
+	scope := pkgconfig.PluginScope(name)
+	if len(scope) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := p.Configure(ctx, &v1.ConfigureRequest{Config: pkgconfig.Flatten(scope)})
+	switch {
+	case err != nil:
+		logging.Debug("Plugin did not accept configuration", "plugin", name, "error", err)
+	case !resp.GetSuccess():
+		logging.Warn("Plugin rejected its configuration", "plugin", name, "message", resp.GetMessage())
+	}
+}
+
 // LoadPlugin loads a specific plugin by path
 func (m *Manager) LoadPlugin(path string) error {
 	m.mu.Lock()
@@ -366,7 +665,7 @@ func (m *Manager) GetPlugin(name string) (*LoadedPlugin, error) {
 		plugin.LastUsed = time.Now()
 
 		// Check if client is still alive
-		if plugin.Client.Exited() {
+		if plugin.Client != nil && plugin.Client.Exited() {
 			return nil, fmt.Errorf("plugin %s has exited", name)
 		}
 
@@ -408,7 +707,7 @@ func (m *Manager) GetPlugin(name string) (*LoadedPlugin, error) {
 }
 
 // ExecuteCommand runs a plugin command
-func (m *Manager) ExecuteCommand(pluginName, command string, args []string) error {
+func (m *Manager) ExecuteCommand(pluginName, command string, args []string) (err error) {
 	plugin, err := m.GetPlugin(pluginName)
 	if err != nil {
 		return err
@@ -434,6 +733,12 @@ func (m *Manager) ExecuteCommand(pluginName, command string, args []string) erro
 		return fmt.Errorf("command %s not found in plugin %s", command, pluginName)
 	}
 
+	// Every run counts towards the plugin's statistics
+	started := time.Now()
+	defer func() {
+		RecordExecution(Execution{Plugin: plugin.Name, Command: command, Duration: time.Since(started), Err: err, Invocation: invocation.ID(context.Background())})
+	}()
+
 	// Execute command
 	if cmdInfo.Interactive {
 		// Handle interactive command
@@ -445,7 +750,12 @@ func (m *Manager) ExecuteCommand(pluginName, command string, args []string) erro
 			Args:    args,
 		}
 
-		resp, err := plugin.Plugin.ExecuteCommand(ctx, req)
+		resp, err := ExecuteCancellable(ctx, plugin.Plugin, req, func(ctx context.Context) (*v1.ExecuteResponse, error) {
+			if cmdInfo.Streaming {
+				return ExecuteStreaming(ctx, plugin.Plugin, req, os.Stdout, os.Stderr)
+			}
+			return plugin.Plugin.ExecuteCommand(ctx, req)
+		})
 		if err != nil {
 			return fmt.Errorf("command execution failed: %w", err)
 		}
@@ -591,6 +901,16 @@ func (m *Manager) ListPlugins() []*LoadedPlugin {
 	return plugins
 }
 
+// PluginHealth returns why the last health check found a loaded plugin
+// unhealthy, or nil while it is healthy
+func (m *Manager) PluginHealth(name string) error {
+	if _, err := m.lifecycleManager.GetPluginState(name); err != nil {
+		return nil
+	}
+	_, err := m.lifecycleManager.GetPluginHealth(name)
+	return err
+}
+
 // ListDiscoveredPlugins returns all discovered plugin names (both loaded and unloaded)
 func (m *Manager) ListDiscoveredPlugins() []string {
 	m.mu.RLock()
@@ -649,8 +969,11 @@ func (m *Manager) Cleanup() {
 	}
 
 	// Unregister all plugins from lifecycle manager
-	for name := range m.plugins {
+	for name, plugin := range m.plugins {
 		_ = m.lifecycleManager.Unregister(name)
+		if plugin.logFile != nil {
+			plugin.logFile.Close()
+		}
 	}
 
 	m.plugins = make(map[string]*LoadedPlugin)
@@ -666,6 +989,9 @@ type Discoverer struct {
 type PluginInfo struct {
 	Name string
 	Path string
+	// LinkDir is the source directory of a plugin linked with `glide
+	// plugins link`, which is rebuilt before it is loaded
+	LinkDir string
 }
 
 // NewDiscoverer creates a plugin discoverer
@@ -1,12 +1,17 @@
 package sdk
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
 
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestFindAncestorPluginDirs(t *testing.T) {
@@ -200,3 +205,80 @@ func TestDiscovererWithAncestorDirs(t *testing.T) {
 	assert.True(t, foundProject, "Should find project-level plugin")
 	assert.True(t, foundSub, "Should find sub-directory plugin")
 }
+
+func TestDiscoverPluginsLazy_ProjectLists(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"docker", "aws", "k8s"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0755))
+	}
+
+	discover := func(enabled, disabled []string) *Manager {
+		m := NewManager(&ManagerConfig{
+			PluginDirs:      []string{dir},
+			EnabledPlugins:  enabled,
+			DisabledPlugins: disabled,
+		})
+		require.NoError(t, m.DiscoverPluginsLazy())
+		return m
+	}
+
+	m := discover(nil, nil)
+	assert.True(t, m.IsPluginDiscovered("docker"))
+	assert.True(t, m.IsPluginDiscovered("aws"))
+	assert.True(t, m.IsPluginDiscovered("k8s"))
+
+	// Only enabled plugins activate
+	m = discover([]string{"docker", "aws"}, nil)
+	assert.True(t, m.IsPluginDiscovered("docker"))
+	assert.True(t, m.IsPluginDiscovered("aws"))
+	assert.False(t, m.IsPluginDiscovered("k8s"))
+
+	// Disabled plugins never do, even when enabled
+	m = discover([]string{"docker", "aws"}, []string{"aws"})
+	assert.True(t, m.IsPluginDiscovered("docker"))
+	assert.False(t, m.IsPluginDiscovered("aws"))
+
+	m = discover(nil, []string{"k8s"})
+	assert.True(t, m.IsPluginDiscovered("aws"))
+	assert.False(t, m.IsPluginDiscovered("k8s"))
+}
+
+// flakyMetadata fails GetMetadata with the given errors before answering
+type flakyMetadata struct {
+	v1.GlidePluginClient
+	errs  []error
+	calls int
+}
+
+func (f *flakyMetadata) GetMetadata(context.Context, *v1.Empty, ...grpc.CallOption) (*v1.PluginMetadata, error) {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return nil, f.errs[f.calls-1]
+	}
+	return &v1.PluginMetadata{Name: "flaky"}, nil
+}
+
+func TestHandshakeMetadata(t *testing.T) {
+	t.Run("retries a plugin that is not serving yet", func(t *testing.T) {
+		plugin := &flakyMetadata{errs: []error{status.Error(codes.Unavailable, "connection refused")}}
+		metadata, err := handshakeMetadata(context.Background(), plugin)
+		require.NoError(t, err)
+		assert.Equal(t, "flaky", metadata.Name)
+		assert.Equal(t, 2, plugin.calls)
+	})
+
+	t.Run("gives up after one retry", func(t *testing.T) {
+		unavailable := status.Error(codes.Unavailable, "connection refused")
+		plugin := &flakyMetadata{errs: []error{unavailable, unavailable, unavailable}}
+		_, err := handshakeMetadata(context.Background(), plugin)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 2, plugin.calls)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		plugin := &flakyMetadata{errs: []error{status.Error(codes.Unimplemented, "unknown method")}}
+		_, err := handshakeMetadata(context.Background(), plugin)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		assert.Equal(t, 1, plugin.calls)
+	})
+}
@@ -0,0 +1,50 @@
+package sdk
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/glide-cli/glide/v3/pkg/logging"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoverPanic converts a panic in a plugin call into a PluginError naming
+// the plugin and command, so one bad plugin cannot crash the CLI. Defer it
+// in functions that call plugin code:
+//
+//	func run() (err error) {
+//	    defer sdk.RecoverPanic(pluginName, command, &err)
+//	    return handler.Execute(ctx, req)
+//	}
+func RecoverPanic(pluginName, command string, errp *error) {
+	if r := recover(); r != nil {
+		logging.Debug("Recovered plugin panic", "plugin", pluginName, "command", command, "stack", string(debug.Stack()))
+		*errp = NewPanicError(pluginName, command, r)
+	}
+}
+
+// NewPanicError creates the error reported when a plugin panics
+func NewPanicError(pluginName, command string, recovered interface{}) *glideErrors.GlideError {
+	err := glideErrors.NewPluginError(pluginName, fmt.Sprintf("%s panicked: %v", command, recovered), nil)
+	err.AddContext("command", command)
+	err.AddSuggestion(fmt.Sprintf("This is a bug in the %s plugin; report it to its author", pluginName))
+	return err
+}
+
+// remotePanicError converts the error of a panic in the plugin process into
+// a PluginError, and returns other errors unchanged
+func remotePanicError(pluginName, call string, err error) error {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.Internal || !strings.HasPrefix(s.Message(), v1.PanicPrefix) {
+		return err
+	}
+	recovered := s.Message()
+	if _, value, found := strings.Cut(recovered, ": "); found {
+		recovered = value
+	}
+	return NewPanicError(pluginName, call, recovered)
+}
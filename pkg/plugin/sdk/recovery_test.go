@@ -0,0 +1,26 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverPanic(t *testing.T) {
+	run := func() (err error) {
+		defer RecoverPanic("docker", "up", &err)
+		panic("index out of range")
+	}
+
+	err := run()
+	var glideErr *glideErrors.GlideError
+	require.True(t, errors.As(err, &glideErr))
+	assert.Equal(t, "plugin 'docker': up panicked: index out of range", glideErr.Message)
+	plugin, _ := glideErr.GetContext("plugin")
+	command, _ := glideErr.GetContext("command")
+	assert.Equal(t, "docker", plugin)
+	assert.Equal(t, "up", command)
+}
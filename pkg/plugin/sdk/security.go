@@ -299,13 +299,13 @@ func (cv *CapabilityValidator) validateCommand(command string) error {
 
 // Capabilities represents plugin capability requirements
 type Capabilities struct {
-	RequiresDocker     bool     `json:"requires_docker"`
-	RequiresNetwork    bool     `json:"requires_network"`
-	RequiresFilesystem bool     `json:"requires_filesystem"`
-	RequiredPaths      []string `json:"required_paths"`
-	RequiredCommands   []string `json:"required_commands"`
-	RequiredEnvVars    []string `json:"required_env_vars"`
-	RequiredConfig     []string `json:"required_config"`
+	RequiresDocker     bool     `json:"requires_docker" yaml:"requires_docker"`
+	RequiresNetwork    bool     `json:"requires_network" yaml:"requires_network"`
+	RequiresFilesystem bool     `json:"requires_filesystem" yaml:"requires_filesystem"`
+	RequiredPaths      []string `json:"required_paths" yaml:"required_paths"`
+	RequiredCommands   []string `json:"required_commands" yaml:"required_commands"`
+	RequiredEnvVars    []string `json:"required_env_vars" yaml:"required_env_vars"`
+	RequiredConfig     []string `json:"required_config" yaml:"required_config"`
 }
 
 // PluginManifest represents a plugin manifest file
@@ -332,6 +332,8 @@ type ManifestSpec struct {
 	Commands     []CommandSpec  `yaml:"commands"`
 	Capabilities Capabilities   `yaml:"capabilities"`
 	Config       ConfigSpec     `yaml:"config"`
+	// Environment controls the environment and directory the plugin runs in
+	Environment EnvironmentSpec `yaml:"environment"`
 }
 
 // ExecutableSpec contains executable information
@@ -0,0 +1,163 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"github.com/glide-cli/glide/v3/pkg/progress"
+)
+
+// ExecuteStreaming runs a command marked streaming, writing its output to
+// stdout and stderr and showing its progress as they arrive, and returns
+// the command's result
+func ExecuteStreaming(ctx context.Context, client v1.GlidePluginClient, req *v1.ExecuteRequest, stdout, stderr io.Writer) (*v1.ExecuteResponse, error) {
+	stream, err := client.ExecuteCommandStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	r := newStreamRenderer(stdout, stderr)
+	defer r.finish()
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("plugin ended %s without a result", req.Command)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch e := event.Event.(type) {
+		case *v1.ExecuteEvent_Stdout:
+			// Safe to ignore: lineWriter never fails
+			_, _ = r.stdout.Write(e.Stdout)
+		case *v1.ExecuteEvent_Stderr:
+			// Safe to ignore: lineWriter never fails
+			_, _ = r.stderr.Write(e.Stderr)
+		case *v1.ExecuteEvent_Progress:
+			r.progress(e.Progress)
+		case *v1.ExecuteEvent_Result:
+			return e.Result, nil
+		}
+	}
+}
+
+// streamRenderer shows the events of a streamed command. Output is printed
+// a line at a time above the progress indicator so the two do not mix.
+type streamRenderer struct {
+	stdout *lineWriter
+	stderr *lineWriter
+
+	bar        *progress.Bar
+	barMessage string
+	barTotal   int64
+	spinner    *progress.Spinner
+}
+
+func newStreamRenderer(stdout, stderr io.Writer) *streamRenderer {
+	return &streamRenderer{
+		stdout: &lineWriter{w: progress.Writer(stdout)},
+		stderr: &lineWriter{w: progress.Writer(stderr)},
+	}
+}
+
+// progress shows a bar when the amount of work is known and a spinner
+// otherwise. A bar is completed when the message moves on to the next step.
+func (r *streamRenderer) progress(p *v1.Progress) {
+	if p.GetTotal() <= 0 {
+		r.stopBar()
+		if r.spinner == nil {
+			r.spinner = progress.NewSpinner(p.GetMessage())
+			r.spinner.Start()
+		} else {
+			r.spinner.Update(p.GetMessage())
+		}
+		return
+	}
+
+	r.stopSpinner()
+	if r.bar != nil && r.barMessage != p.GetMessage() {
+		r.bar.Finish()
+		r.bar = nil
+	}
+	if r.bar == nil {
+		r.bar = progress.NewBar(int(p.GetTotal()), p.GetMessage())
+		r.barMessage, r.barTotal = p.GetMessage(), p.GetTotal()
+		r.bar.Start()
+	} else if r.barTotal != p.GetTotal() {
+		r.bar.SetTotal(int(p.GetTotal()))
+		r.barTotal = p.GetTotal()
+	}
+	r.bar.Update(int(p.GetCurrent()))
+}
+
+func (r *streamRenderer) stopBar() {
+	if r.bar != nil {
+		r.bar.Stop()
+		r.bar = nil
+	}
+}
+
+func (r *streamRenderer) stopSpinner() {
+	if r.spinner != nil {
+		r.spinner.Stop()
+		r.spinner = nil
+	}
+}
+
+// finish removes the indicators and prints any unfinished lines
+func (r *streamRenderer) finish() {
+	r.stopBar()
+	r.stopSpinner()
+	r.stdout.Flush()
+	r.stderr.Flush()
+}
+
+// maxLineBuffer is the most of an unfinished line lineWriter holds back
+const maxLineBuffer = 64 << 10
+
+// lineWriter holds back output until it has complete lines, as
+// progress.Writer expects
+type lineWriter struct {
+	mu  sync.Mutex
+	w   io.Writer
+	buf []byte
+}
+
+// Write implements io.Writer
+func (lw *lineWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	lw.buf = append(lw.buf, p...)
+	if i := bytes.LastIndexByte(lw.buf, '\n'); i >= 0 {
+		// Safe to ignore: Plugin output is shown best-effort
+		_, _ = lw.w.Write(lw.buf[:i+1])
+		lw.buf = append(lw.buf[:0], lw.buf[i+1:]...)
+	}
+	// Output that never ends a line is not held back indefinitely
+	if len(lw.buf) >= maxLineBuffer {
+		// Safe to ignore: Plugin output is shown best-effort
+		_, _ = lw.w.Write(lw.buf)
+		lw.buf = lw.buf[:0]
+	}
+	return len(p), nil
+}
+
+// Flush writes whatever is left of an unfinished line
+func (lw *lineWriter) Flush() {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	if len(lw.buf) > 0 {
+		// Safe to ignore: Plugin output is shown best-effort
+		_, _ = lw.w.Write(lw.buf)
+		lw.buf = nil
+	}
+}
@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// eventStream is a client stream that replays events
+type eventStream struct {
+	grpc.ClientStream
+	events []*v1.ExecuteEvent
+}
+
+func (s *eventStream) Recv() (*v1.ExecuteEvent, error) {
+	if len(s.events) == 0 {
+		return nil, io.EOF
+	}
+	event := s.events[0]
+	s.events = s.events[1:]
+	return event, nil
+}
+
+// streamingClient is a plugin whose streamed commands send events
+type streamingClient struct {
+	v1.GlidePluginClient
+	events []*v1.ExecuteEvent
+}
+
+func (c *streamingClient) ExecuteCommandStream(_ context.Context, _ *v1.ExecuteRequest, _ ...grpc.CallOption) (v1.GlidePlugin_ExecuteCommandStreamClient, error) {
+	return &eventStream{events: c.events}, nil
+}
+
+func TestExecuteStreaming(t *testing.T) {
+	stdout := func(s string) *v1.ExecuteEvent {
+		return &v1.ExecuteEvent{Event: &v1.ExecuteEvent_Stdout{Stdout: []byte(s)}}
+	}
+	stderr := func(s string) *v1.ExecuteEvent {
+		return &v1.ExecuteEvent{Event: &v1.ExecuteEvent_Stderr{Stderr: []byte(s)}}
+	}
+	prog := func(current, total int64) *v1.ExecuteEvent {
+		return &v1.ExecuteEvent{Event: &v1.ExecuteEvent_Progress{Progress: &v1.Progress{Message: "Building", Current: current, Total: total}}}
+	}
+	result := &v1.ExecuteEvent{Event: &v1.ExecuteEvent_Result{Result: &v1.ExecuteResponse{Success: true, ExitCode: 0}}}
+
+	t.Run("writes output as it arrives and returns the result", func(t *testing.T) {
+		client := &streamingClient{events: []*v1.ExecuteEvent{
+			prog(0, 0), stdout("Step 1/2"), stdout(" done\n"), prog(1, 2), stderr("warning\n"), stdout("no newline"), result,
+		}}
+		var out, errOut bytes.Buffer
+		resp, err := ExecuteStreaming(context.Background(), client, &v1.ExecuteRequest{Command: "build"}, &out, &errOut)
+		require.NoError(t, err)
+		assert.True(t, resp.Success)
+		assert.Equal(t, "Step 1/2 done\nno newline", out.String(), "partial lines are printed at the end")
+		assert.Equal(t, "warning\n", errOut.String())
+	})
+
+	t.Run("fails when the stream ends without a result", func(t *testing.T) {
+		client := &streamingClient{events: []*v1.ExecuteEvent{stdout("partial\n")}}
+		var out bytes.Buffer
+		_, err := ExecuteStreaming(context.Background(), client, &v1.ExecuteRequest{Command: "build"}, &out, io.Discard)
+		assert.EqualError(t, err, "plugin ended build without a result")
+		assert.Equal(t, "partial\n", out.String())
+	})
+}
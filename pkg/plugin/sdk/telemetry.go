@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"sync"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/observability"
+)
+
+// Execution is one run of a plugin command
+type Execution struct {
+	Plugin   string
+	Command  string
+	Duration time.Duration
+	// Err is what the command failed with, if it did
+	Err error
+	// Invocation is the ID of the glide run the command ran in
+	Invocation string
+	// Project is the name of the project the command ran in, if any
+	Project string
+}
+
+// ExecutionHook is told about every plugin command that has run
+type ExecutionHook func(Execution)
+
+var (
+	hooksMu sync.Mutex
+	hooks   []ExecutionHook
+)
+
+// AddExecutionHook registers a hook called after every plugin command, e.g.
+// to keep a history of runs. Hooks run before the command's result is
+// shown, so they must be quick.
+func AddExecutionHook(hook ExecutionHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// RecordExecution counts a run of a plugin command in the metrics and
+// passes it to the execution hooks
+func RecordExecution(e Execution) {
+	labels := observability.Labels{
+		observability.LabelPlugin:  e.Plugin,
+		observability.LabelCommand: e.Command,
+	}
+	if e.Project != "" {
+		labels[observability.LabelProject] = e.Project
+	}
+	observability.IncrementCounterWithLabels("plugin_commands_total", labels)
+	if e.Err != nil {
+		observability.IncrementCounterWithLabels("plugin_command_failures_total", labels)
+	}
+	observability.RecordTimingWithLabels("plugin_command_duration", e.Duration, labels)
+
+	hooksMu.Lock()
+	registered := append([]ExecutionHook(nil), hooks...)
+	hooksMu.Unlock()
+	for _, hook := range registered {
+		hook(e)
+	}
+}
@@ -0,0 +1,34 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordExecution(t *testing.T) {
+	var seen []Execution
+	AddExecutionHook(func(e Execution) { seen = append(seen, e) })
+
+	byPlugin := func(name string) int64 {
+		return observability.GetSnapshot().CountersBy(name, observability.LabelPlugin)["telemetry"]
+	}
+	runs := byPlugin("plugin_commands_total")
+	failures := byPlugin("plugin_command_failures_total")
+
+	RecordExecution(Execution{Plugin: "telemetry", Command: "ok", Duration: time.Second})
+	RecordExecution(Execution{Plugin: "telemetry", Command: "fail", Duration: time.Second, Err: errors.New("boom"), Project: "shop"})
+
+	assert.Equal(t, runs+2, byPlugin("plugin_commands_total"))
+	assert.Equal(t, failures+1, byPlugin("plugin_command_failures_total"))
+	assert.Equal(t, 1, observability.DefaultMetricsCollector.GetTimingStatsWithLabels("plugin_command_duration", observability.Labels{
+		observability.LabelPlugin: "telemetry", observability.LabelCommand: "fail", observability.LabelProject: "shop",
+	}).Count)
+	require.Len(t, seen, 2)
+	assert.Equal(t, "fail", seen[1].Command)
+	assert.Error(t, seen[1].Err)
+}
@@ -28,6 +28,12 @@ type BasePlugin struct {
 
 	// Configuration storage
 	config map[string]interface{}
+
+	// Running commands, for Cancel
+	executions Executions
+
+	// Readiness reported to glide's health checks
+	health health
 }
 
 // NewBasePlugin creates a new base plugin with the given metadata
@@ -68,7 +74,11 @@ func (p *BasePlugin) ListCommands(ctx context.Context, _ *Empty) (*CommandList,
 	var cmdList []*CommandInfo
 
 	for _, handler := range p.commands {
-		cmdList = append(cmdList, handler.Info())
+		info := handler.Info()
+		if _, ok := handler.(CompletingCommandHandler); ok && info != nil {
+			info.Completes = true
+		}
+		cmdList = append(cmdList, info)
 	}
 
 	return &CommandList{
@@ -86,6 +96,8 @@ func (p *BasePlugin) ExecuteCommand(ctx context.Context, req *ExecuteRequest) (*
 		}, nil
 	}
 
+	ctx, done := p.executions.Start(ctx, req.ExecutionId)
+	defer done()
 	return handler.Execute(ctx, req)
 }
 
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrCancelled is the cause of a command's context when the host cancels
+// it, e.g. because the user pressed Ctrl+C. Commands should stop, clean up
+// and return; context.Cause(ctx) tells why.
+var ErrCancelled = errors.New("cancelled by glide")
+
+// Executions tracks the running commands of a plugin so the host can
+// cancel them through the Cancel RPC. BasePlugin uses it for every
+// command; servers implementing GlidePluginServer directly can too.
+type Executions struct {
+	mu      sync.Mutex
+	running map[string]context.CancelCauseFunc
+}
+
+// Start derives the context a command runs with, which Cancel cancels.
+// Call the returned function once the command has finished.
+func (e *Executions) Start(ctx context.Context, id string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	if id == "" {
+		return ctx, func() { cancel(nil) }
+	}
+
+	e.mu.Lock()
+	if e.running == nil {
+		e.running = make(map[string]context.CancelCauseFunc)
+	}
+	e.running[id] = cancel
+	e.mu.Unlock()
+
+	return ctx, func() {
+		e.mu.Lock()
+		delete(e.running, id)
+		e.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// Cancel cancels a running command, reporting whether it was running
+func (e *Executions) Cancel(id, reason string) bool {
+	e.mu.Lock()
+	cancel, ok := e.running[id]
+	e.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	cause := ErrCancelled
+	if reason != "" {
+		cause = fmt.Errorf("%w: %s", ErrCancelled, reason)
+	}
+	cancel(cause)
+	return true
+}
+
+// Cancel cancels a running command
+func (p *BasePlugin) Cancel(ctx context.Context, req *CancelRequest) (*CancelResponse, error) {
+	return &CancelResponse{Cancelled: p.executions.Cancel(req.ExecutionId, req.Reason)}, nil
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasePlugin_Cancel(t *testing.T) {
+	p := NewBasePlugin(&PluginMetadata{Name: "deploy"})
+	started := make(chan struct{})
+	p.RegisterCommand("ship", NewSimpleCommand(&CommandInfo{Name: "ship"}, func(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
+		close(started)
+		<-ctx.Done()
+		// Clean up, then report why the command stopped
+		return &ExecuteResponse{Success: false, Error: context.Cause(ctx).Error()}, nil
+	}))
+
+	result := make(chan *ExecuteResponse, 1)
+	go func() {
+		resp, err := p.ExecuteCommand(context.Background(), &ExecuteRequest{Command: "ship", ExecutionId: "run-1"})
+		assert.NoError(t, err)
+		result <- resp
+	}()
+	<-started
+
+	resp, err := p.Cancel(context.Background(), &CancelRequest{ExecutionId: "other"})
+	require.NoError(t, err)
+	assert.False(t, resp.Cancelled, "unknown executions are not cancelled")
+
+	resp, err = p.Cancel(context.Background(), &CancelRequest{ExecutionId: "run-1", Reason: "interrupted"})
+	require.NoError(t, err)
+	assert.True(t, resp.Cancelled)
+	assert.Equal(t, "cancelled by glide: interrupted", (<-result).Error)
+
+	resp, err = p.Cancel(context.Background(), &CancelRequest{ExecutionId: "run-1"})
+	require.NoError(t, err)
+	assert.False(t, resp.Cancelled, "finished executions are forgotten")
+}
+
+func TestExecutions(t *testing.T) {
+	var e Executions
+
+	ctx, done := e.Start(context.Background(), "run-1")
+	assert.True(t, e.Cancel("run-1", ""))
+	assert.True(t, errors.Is(context.Cause(ctx), ErrCancelled))
+	done()
+
+	// Runs without an ID cannot be cancelled but still get a context
+	ctx, done = e.Start(context.Background(), "")
+	assert.False(t, e.Cancel("", ""))
+	done()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
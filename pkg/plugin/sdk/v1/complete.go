@@ -0,0 +1,34 @@
+package v1
+
+import "context"
+
+// CompletingCommandHandler is implemented by commands that complete their
+// positional arguments with values only the plugin knows, such as container
+// or migration names. BasePlugin marks such commands Completes, and the
+// host calls Complete while the user presses Tab.
+//
+// Completion runs under a short deadline (GLIDE_PLUGIN_COMPLETE_TIMEOUT);
+// commands should answer from what is at hand rather than do slow work.
+type CompletingCommandHandler interface {
+	Complete(ctx context.Context, req *CompleteRequest) ([]string, error)
+}
+
+// Complete completes an argument of a registered command. Commands that do
+// not complete their arguments, and unknown commands, get no values, so the
+// host falls back to their argument specs.
+func (p *BasePlugin) Complete(ctx context.Context, req *CompleteRequest) (*CompleteResponse, error) {
+	handler, ok := p.commands[req.Command]
+	if !ok {
+		return &CompleteResponse{}, nil
+	}
+	completer, ok := handler.(CompletingCommandHandler)
+	if !ok {
+		return &CompleteResponse{}, nil
+	}
+
+	values, err := completer.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &CompleteResponse{Values: values}, nil
+}
@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// migrateCommand completes migration names
+type migrateCommand struct {
+	*SimpleCommand
+}
+
+func (migrateCommand) Complete(_ context.Context, req *CompleteRequest) ([]string, error) {
+	return []string{req.ToComplete + "_create_users", req.ToComplete + "_add_index"}, nil
+}
+
+func TestBasePlugin_Complete(t *testing.T) {
+	p := NewBasePlugin(&PluginMetadata{Name: "db"})
+	run := func(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
+		return &ExecuteResponse{Success: true}, nil
+	}
+	p.RegisterCommand("migrate", migrateCommand{NewSimpleCommand(&CommandInfo{Name: "migrate"}, run)})
+	p.RegisterCommand("seed", NewSimpleCommand(&CommandInfo{Name: "seed"}, run))
+
+	list, err := p.ListCommands(context.Background(), &Empty{})
+	require.NoError(t, err)
+	completes := map[string]bool{}
+	for _, info := range list.Commands {
+		completes[info.Name] = info.Completes
+	}
+	assert.Equal(t, map[string]bool{"migrate": true, "seed": false}, completes)
+
+	resp, err := p.Complete(context.Background(), &CompleteRequest{Command: "migrate", ToComplete: "2024"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024_create_users", "2024_add_index"}, resp.Values)
+
+	resp, err = p.Complete(context.Background(), &CompleteRequest{Command: "seed"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Values)
+}
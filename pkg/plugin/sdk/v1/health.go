@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthServiceName is the service glide asks a plugin's standard gRPC
+// health service about to learn whether the plugin is ready to run commands
+var HealthServiceName = GlidePlugin_ServiceDesc.ServiceName
+
+// HealthChecker is implemented by plugins that can tell whether they are
+// ready to run commands, e.g. whether a service they depend on is
+// reachable. Plugins that do not implement it are healthy while they serve.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// health is the state BasePlugin reports through CheckHealth
+type health struct {
+	mu  sync.RWMutex
+	err error
+}
+
+// SetUnhealthy marks the plugin not ready to run commands for reason, e.g.
+// after losing a connection it needs, so glide leaves its commands out of
+// completion. A nil reason marks it healthy again.
+func (p *BasePlugin) SetUnhealthy(reason error) {
+	p.health.mu.Lock()
+	defer p.health.mu.Unlock()
+	p.health.err = reason
+}
+
+// CheckHealth returns the reason given to SetUnhealthy, or nil while the
+// plugin is healthy
+func (p *BasePlugin) CheckHealth(ctx context.Context) error {
+	p.health.mu.RLock()
+	defer p.health.mu.RUnlock()
+	return p.health.err
+}
+
+// HealthUnaryInterceptor answers standard gRPC health checks of
+// HealthServiceName, and of the server as a whole, from impl's CheckHealth.
+// Checks of the service go-plugin registers for its own liveness pass
+// through, so they keep reporting that the process serves.
+func HealthUnaryInterceptor(impl GlidePluginServer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		check, ok := req.(*grpc_health_v1.HealthCheckRequest)
+		if info.FullMethod != grpc_health_v1.Health_Check_FullMethodName || !ok || check.Service == plugin.GRPCServiceName {
+			return handler(ctx, req)
+		}
+		if check.Service != "" && check.Service != HealthServiceName {
+			return nil, status.Errorf(codes.NotFound, "unknown service %q", check.Service)
+		}
+		return &grpc_health_v1.HealthCheckResponse{Status: servingStatus(ctx, impl)}, nil
+	}
+}
+
+// servingStatus asks impl whether it is ready to run commands
+func servingStatus(ctx context.Context, impl GlidePluginServer) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	checker, ok := impl.(HealthChecker)
+	if !ok {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	if err := checker.CheckHealth(ctx); err != nil {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+// ErrNotServing is returned by CheckPluginHealth for a plugin that reports
+// it is not ready to run commands
+var ErrNotServing = errors.New("plugin reports it is not ready to run commands")
+
+// CheckPluginHealth asks a plugin's health service whether it is ready to
+// run commands. Plugins built with an SDK that predates health checks are
+// taken to be healthy.
+func CheckPluginHealth(ctx context.Context, client grpc_health_v1.HealthClient) error {
+	resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: HealthServiceName})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound, codes.Unimplemented:
+		return nil
+	default:
+		return err
+	}
+	if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return ErrNotServing
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestHealthUnaryInterceptor(t *testing.T) {
+	impl := NewBasePlugin(&PluginMetadata{Name: "docker"})
+	intercept := HealthUnaryInterceptor(impl)
+	info := &grpc.UnaryServerInfo{FullMethod: grpc_health_v1.Health_Check_FullMethodName}
+	passedThrough := &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN}
+	handler := func(context.Context, interface{}) (interface{}, error) { return passedThrough, nil }
+
+	check := func(service string) (grpc_health_v1.HealthCheckResponse_ServingStatus, error) {
+		resp, err := intercept(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service}, info, handler)
+		if err != nil {
+			return 0, err
+		}
+		return resp.(*grpc_health_v1.HealthCheckResponse).Status, nil
+	}
+
+	got, err := check(HealthServiceName)
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, got)
+
+	impl.SetUnhealthy(errors.New("database unreachable"))
+	got, err = check(HealthServiceName)
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, got)
+	got, err = check("")
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, got, "the server as a whole is not ready either")
+
+	impl.SetUnhealthy(nil)
+	got, err = check(HealthServiceName)
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, got)
+
+	// go-plugin's own liveness check is answered by go-plugin
+	got, err = check("plugin")
+	require.NoError(t, err)
+	assert.Equal(t, passedThrough.Status, got)
+
+	_, err = check("other.Service")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// fakeHealthClient answers health checks with a fixed response
+type fakeHealthClient struct {
+	grpc_health_v1.HealthClient
+	resp *grpc_health_v1.HealthCheckResponse
+	err  error
+}
+
+func (f *fakeHealthClient) Check(context.Context, *grpc_health_v1.HealthCheckRequest, ...grpc.CallOption) (*grpc_health_v1.HealthCheckResponse, error) {
+	return f.resp, f.err
+}
+
+func TestCheckPluginHealth(t *testing.T) {
+	ctx := context.Background()
+	serving := &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}
+	notServing := &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}
+
+	assert.NoError(t, CheckPluginHealth(ctx, &fakeHealthClient{resp: serving}))
+	assert.ErrorIs(t, CheckPluginHealth(ctx, &fakeHealthClient{resp: notServing}), ErrNotServing)
+
+	// Plugins built before health checks do not know the service
+	assert.NoError(t, CheckPluginHealth(ctx, &fakeHealthClient{err: status.Error(codes.NotFound, "unknown service")}))
+	assert.NoError(t, CheckPluginHealth(ctx, &fakeHealthClient{err: status.Error(codes.Unimplemented, "")}))
+
+	err := CheckPluginHealth(ctx, &fakeHealthClient{err: status.Error(codes.Unavailable, "connection refused")})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -85,7 +85,7 @@ func (x StreamMessage_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use StreamMessage_Type.Descriptor instead.
 func (StreamMessage_Type) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{12, 0}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{21, 0}
 }
 
 // Empty message for RPC calls with no parameters
@@ -137,9 +137,10 @@ type PluginMetadata struct {
 	License       string                 `protobuf:"bytes,8,opt,name=license,proto3" json:"license,omitempty"`
 	Tags          []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"` // Tags for categorization (e.g., "database", "testing")
 	Extra         map[string]string      `protobuf:"bytes,10,rep,name=extra,proto3" json:"extra,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Aliases       []string               `protobuf:"bytes,11,rep,name=aliases,proto3" json:"aliases,omitempty"`           // Plugin name aliases (e.g., "db" for "database")
-	Namespaced    bool                   `protobuf:"varint,12,opt,name=namespaced,proto3" json:"namespaced,omitempty"`    // If true, commands are namespaced under plugin name (default: true)
-	Dependencies  []*PluginDependency    `protobuf:"bytes,13,rep,name=dependencies,proto3" json:"dependencies,omitempty"` // Plugin dependencies for load order resolution
+	Aliases       []string               `protobuf:"bytes,11,rep,name=aliases,proto3" json:"aliases,omitempty"`                         // Plugin name aliases (e.g., "db" for "database")
+	Namespaced    bool                   `protobuf:"varint,12,opt,name=namespaced,proto3" json:"namespaced,omitempty"`                  // If true, commands are namespaced under plugin name (default: true)
+	Dependencies  []*PluginDependency    `protobuf:"bytes,13,rep,name=dependencies,proto3" json:"dependencies,omitempty"`               // Plugin dependencies for load order resolution
+	HelpTopics    []*HelpTopic           `protobuf:"bytes,14,rep,name=help_topics,json=helpTopics,proto3" json:"help_topics,omitempty"` // Long-form topics shown by `glide help <topic>`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -265,6 +266,82 @@ func (x *PluginMetadata) GetDependencies() []*PluginDependency {
 	return nil
 }
 
+func (x *PluginMetadata) GetHelpTopics() []*HelpTopic {
+	if x != nil {
+		return x.HelpTopics
+	}
+	return nil
+}
+
+// HelpTopic is a long-form help page contributed by a plugin
+type HelpTopic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // Topic name (e.g., "deployment")
+	Summary       string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"` // One-line summary shown in topic listings
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"` // Full topic text
+	Aliases       []string               `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty"` // Alternative topic names
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HelpTopic) Reset() {
+	*x = HelpTopic{}
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HelpTopic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelpTopic) ProtoMessage() {}
+
+func (x *HelpTopic) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelpTopic.ProtoReflect.Descriptor instead.
+func (*HelpTopic) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HelpTopic) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HelpTopic) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *HelpTopic) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *HelpTopic) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
 type PluginDependency struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`          // Plugin name (e.g., "docker")
@@ -276,7 +353,7 @@ type PluginDependency struct {
 
 func (x *PluginDependency) Reset() {
 	*x = PluginDependency{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[2]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -288,7 +365,7 @@ func (x *PluginDependency) String() string {
 func (*PluginDependency) ProtoMessage() {}
 
 func (x *PluginDependency) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[2]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +378,7 @@ func (x *PluginDependency) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginDependency.ProtoReflect.Descriptor instead.
 func (*PluginDependency) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{2}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PluginDependency) GetName() string {
@@ -335,14 +412,19 @@ type CommandInfo struct {
 	Hidden        bool                   `protobuf:"varint,6,opt,name=hidden,proto3" json:"hidden,omitempty"`
 	RequiresTty   bool                   `protobuf:"varint,7,opt,name=requires_tty,json=requiresTty,proto3" json:"requires_tty,omitempty"`
 	RequiresAuth  bool                   `protobuf:"varint,8,opt,name=requires_auth,json=requiresAuth,proto3" json:"requires_auth,omitempty"`
-	Visibility    string                 `protobuf:"bytes,9,opt,name=visibility,proto3" json:"visibility,omitempty"` // Context visibility: "always", "project-only", "worktree-only", "root-only", "non-root"
+	Visibility    string                 `protobuf:"bytes,9,opt,name=visibility,proto3" json:"visibility,omitempty"`     // Context visibility: "always", "project-only", "worktree-only", "root-only", "non-root"
+	Examples      []*CommandExample      `protobuf:"bytes,10,rep,name=examples,proto3" json:"examples,omitempty"`        // Usage examples shown in --help output
+	Destructive   bool                   `protobuf:"varint,11,opt,name=destructive,proto3" json:"destructive,omitempty"` // Deletes data; the host asks for confirmation before running it
+	Streaming     bool                   `protobuf:"varint,12,opt,name=streaming,proto3" json:"streaming,omitempty"`     // Streams output as it runs through ExecuteCommandStream
+	Args          []*ArgSpec             `protobuf:"bytes,13,rep,name=args,proto3" json:"args,omitempty"`                // Positional arguments, validated and completed by the host
+	Completes     bool                   `protobuf:"varint,14,opt,name=completes,proto3" json:"completes,omitempty"`     // Completes its arguments through Complete
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CommandInfo) Reset() {
 	*x = CommandInfo{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[3]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -354,7 +436,7 @@ func (x *CommandInfo) String() string {
 func (*CommandInfo) ProtoMessage() {}
 
 func (x *CommandInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[3]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -367,7 +449,7 @@ func (x *CommandInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandInfo.ProtoReflect.Descriptor instead.
 func (*CommandInfo) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{3}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CommandInfo) GetName() string {
@@ -433,6 +515,187 @@ func (x *CommandInfo) GetVisibility() string {
 	return ""
 }
 
+func (x *CommandInfo) GetExamples() []*CommandExample {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+func (x *CommandInfo) GetDestructive() bool {
+	if x != nil {
+		return x.Destructive
+	}
+	return false
+}
+
+func (x *CommandInfo) GetStreaming() bool {
+	if x != nil {
+		return x.Streaming
+	}
+	return false
+}
+
+func (x *CommandInfo) GetArgs() []*ArgSpec {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *CommandInfo) GetCompletes() bool {
+	if x != nil {
+		return x.Completes
+	}
+	return false
+}
+
+// CommandExample is a usage example for a plugin command
+type CommandExample struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"` // What the example does
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`         // Full command line (e.g., "glide deploy --env staging")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandExample) Reset() {
+	*x = CommandExample{}
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandExample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandExample) ProtoMessage() {}
+
+func (x *CommandExample) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandExample.ProtoReflect.Descriptor instead.
+func (*CommandExample) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CommandExample) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CommandExample) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+// ArgSpec describes a positional argument of a plugin command
+type ArgSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Shown in usage, e.g. <env>
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Required      bool                   `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	Variadic      bool                   `protobuf:"varint,4,opt,name=variadic,proto3" json:"variadic,omitempty"`    // Takes the remaining arguments; must be last
+	Completion    string                 `protobuf:"bytes,5,opt,name=completion,proto3" json:"completion,omitempty"` // Shell completion: "file", "directory" or "none"
+	Choices       []string               `protobuf:"bytes,6,rep,name=choices,proto3" json:"choices,omitempty"`       // Accepted values, also offered as completions
+	Pattern       string                 `protobuf:"bytes,7,opt,name=pattern,proto3" json:"pattern,omitempty"`       // Regular expression every value must match
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArgSpec) Reset() {
+	*x = ArgSpec{}
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArgSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArgSpec) ProtoMessage() {}
+
+func (x *ArgSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArgSpec.ProtoReflect.Descriptor instead.
+func (*ArgSpec) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ArgSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ArgSpec) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ArgSpec) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *ArgSpec) GetVariadic() bool {
+	if x != nil {
+		return x.Variadic
+	}
+	return false
+}
+
+func (x *ArgSpec) GetCompletion() string {
+	if x != nil {
+		return x.Completion
+	}
+	return ""
+}
+
+func (x *ArgSpec) GetChoices() []string {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+func (x *ArgSpec) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
 type CommandList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Commands      []*CommandInfo         `protobuf:"bytes,1,rep,name=commands,proto3" json:"commands,omitempty"`
@@ -442,7 +705,7 @@ type CommandList struct {
 
 func (x *CommandList) Reset() {
 	*x = CommandList{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[4]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -454,7 +717,7 @@ func (x *CommandList) String() string {
 func (*CommandList) ProtoMessage() {}
 
 func (x *CommandList) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[4]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -467,7 +730,7 @@ func (x *CommandList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandList.ProtoReflect.Descriptor instead.
 func (*CommandList) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{4}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CommandList) GetCommands() []*CommandInfo {
@@ -486,7 +749,7 @@ type ConfigureRequest struct {
 
 func (x *ConfigureRequest) Reset() {
 	*x = ConfigureRequest{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[5]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -498,7 +761,7 @@ func (x *ConfigureRequest) String() string {
 func (*ConfigureRequest) ProtoMessage() {}
 
 func (x *ConfigureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[5]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -511,7 +774,7 @@ func (x *ConfigureRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigureRequest.ProtoReflect.Descriptor instead.
 func (*ConfigureRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{5}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ConfigureRequest) GetConfig() map[string]string {
@@ -531,7 +794,7 @@ type ConfigureResponse struct {
 
 func (x *ConfigureResponse) Reset() {
 	*x = ConfigureResponse{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[6]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -543,7 +806,7 @@ func (x *ConfigureResponse) String() string {
 func (*ConfigureResponse) ProtoMessage() {}
 
 func (x *ConfigureResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[6]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,7 +819,7 @@ func (x *ConfigureResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigureResponse.ProtoReflect.Descriptor instead.
 func (*ConfigureResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{6}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ConfigureResponse) GetSuccess() bool {
@@ -581,13 +844,14 @@ type ExecuteRequest struct {
 	Env           map[string]string      `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	WorkDir       string                 `protobuf:"bytes,5,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
 	Stdin         []byte                 `protobuf:"bytes,6,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,7,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"` // Identifies the run to Cancel
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExecuteRequest) Reset() {
 	*x = ExecuteRequest{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[7]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -599,7 +863,7 @@ func (x *ExecuteRequest) String() string {
 func (*ExecuteRequest) ProtoMessage() {}
 
 func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[7]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -612,7 +876,7 @@ func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteRequest.ProtoReflect.Descriptor instead.
 func (*ExecuteRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{7}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ExecuteRequest) GetCommand() string {
@@ -657,6 +921,13 @@ func (x *ExecuteRequest) GetStdin() []byte {
 	return nil
 }
 
+func (x *ExecuteRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
 type ExecuteResponse struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	Success             bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -672,7 +943,7 @@ type ExecuteResponse struct {
 
 func (x *ExecuteResponse) Reset() {
 	*x = ExecuteResponse{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[8]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -684,7 +955,7 @@ func (x *ExecuteResponse) String() string {
 func (*ExecuteResponse) ProtoMessage() {}
 
 func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[8]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -697,7 +968,7 @@ func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
 func (*ExecuteResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{8}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ExecuteResponse) GetSuccess() bool {
@@ -749,6 +1020,385 @@ func (x *ExecuteResponse) GetExtra() map[string]string {
 	return nil
 }
 
+// ExecuteEvent is one event of a streamed command: a chunk of output, a
+// progress update, or the final result, which is always sent last
+type ExecuteEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*ExecuteEvent_Stdout
+	//	*ExecuteEvent_Stderr
+	//	*ExecuteEvent_Progress
+	//	*ExecuteEvent_Result
+	Event         isExecuteEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteEvent) Reset() {
+	*x = ExecuteEvent{}
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteEvent) ProtoMessage() {}
+
+func (x *ExecuteEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteEvent.ProtoReflect.Descriptor instead.
+func (*ExecuteEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExecuteEvent) GetEvent() isExecuteEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ExecuteEvent) GetStdout() []byte {
+	if x != nil {
+		if x, ok := x.Event.(*ExecuteEvent_Stdout); ok {
+			return x.Stdout
+		}
+	}
+	return nil
+}
+
+func (x *ExecuteEvent) GetStderr() []byte {
+	if x != nil {
+		if x, ok := x.Event.(*ExecuteEvent_Stderr); ok {
+			return x.Stderr
+		}
+	}
+	return nil
+}
+
+func (x *ExecuteEvent) GetProgress() *Progress {
+	if x != nil {
+		if x, ok := x.Event.(*ExecuteEvent_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *ExecuteEvent) GetResult() *ExecuteResponse {
+	if x != nil {
+		if x, ok := x.Event.(*ExecuteEvent_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isExecuteEvent_Event interface {
+	isExecuteEvent_Event()
+}
+
+type ExecuteEvent_Stdout struct {
+	Stdout []byte `protobuf:"bytes,1,opt,name=stdout,proto3,oneof"`
+}
+
+type ExecuteEvent_Stderr struct {
+	Stderr []byte `protobuf:"bytes,2,opt,name=stderr,proto3,oneof"`
+}
+
+type ExecuteEvent_Progress struct {
+	Progress *Progress `protobuf:"bytes,3,opt,name=progress,proto3,oneof"`
+}
+
+type ExecuteEvent_Result struct {
+	Result *ExecuteResponse `protobuf:"bytes,4,opt,name=result,proto3,oneof"`
+}
+
+func (*ExecuteEvent_Stdout) isExecuteEvent_Event() {}
+
+func (*ExecuteEvent_Stderr) isExecuteEvent_Event() {}
+
+func (*ExecuteEvent_Progress) isExecuteEvent_Event() {}
+
+func (*ExecuteEvent_Result) isExecuteEvent_Event() {}
+
+// Progress reports how far a streamed command has got
+type Progress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // What the command is doing (e.g., "Pushing image")
+	Current       int64                  `protobuf:"varint,2,opt,name=current,proto3" json:"current,omitempty"`
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"` // 0 when the amount of work is unknown
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Progress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Progress) GetCurrent() int64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *Progress) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// CancelRequest asks a plugin to stop a running command
+type CancelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"` // The execution_id of the ExecuteRequest
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`                              // Why, e.g. "interrupted" or "timed out"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRequest) Reset() {
+	*x = CancelRequest{}
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRequest) ProtoMessage() {}
+
+func (x *CancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRequest.ProtoReflect.Descriptor instead.
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *CancelRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cancelled     bool                   `protobuf:"varint,1,opt,name=cancelled,proto3" json:"cancelled,omitempty"` // False when no such command is running
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelResponse) Reset() {
+	*x = CancelResponse{}
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelResponse) ProtoMessage() {}
+
+func (x *CancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelResponse.ProtoReflect.Descriptor instead.
+func (*CancelResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelResponse) GetCancelled() bool {
+	if x != nil {
+		return x.Cancelled
+	}
+	return false
+}
+
+// CompleteRequest asks a plugin for completions of a command's argument
+type CompleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Args          []string               `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`                               // Arguments already on the command line
+	ToComplete    string                 `protobuf:"bytes,3,opt,name=to_complete,json=toComplete,proto3" json:"to_complete,omitempty"` // What the user has typed of the next one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteRequest) Reset() {
+	*x = CompleteRequest{}
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteRequest) ProtoMessage() {}
+
+func (x *CompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteRequest.ProtoReflect.Descriptor instead.
+func (*CompleteRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CompleteRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *CompleteRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *CompleteRequest) GetToComplete() string {
+	if x != nil {
+		return x.ToComplete
+	}
+	return ""
+}
+
+type CompleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"` // Candidates; "value\tdescription" adds a description
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteResponse) Reset() {
+	*x = CompleteResponse{}
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteResponse) ProtoMessage() {}
+
+func (x *CompleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteResponse.ProtoReflect.Descriptor instead.
+func (*CompleteResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CompleteResponse) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 type Capabilities struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	RequiresDocker      bool                   `protobuf:"varint,1,opt,name=requires_docker,json=requiresDocker,proto3" json:"requires_docker,omitempty"`
@@ -764,7 +1414,7 @@ type Capabilities struct {
 
 func (x *Capabilities) Reset() {
 	*x = Capabilities{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[9]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -776,7 +1426,7 @@ func (x *Capabilities) String() string {
 func (*Capabilities) ProtoMessage() {}
 
 func (x *Capabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[9]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -789,7 +1439,7 @@ func (x *Capabilities) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
 func (*Capabilities) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{9}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Capabilities) GetRequiresDocker() bool {
@@ -848,13 +1498,14 @@ type CustomCategory struct {
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`               // Display name (e.g., "Infrastructure Management")
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"` // Category description
 	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`      // Display priority (lower = higher priority)
+	Color         string                 `protobuf:"bytes,5,opt,name=color,proto3" json:"color,omitempty"`             // Optional header color (e.g., "cyan"); defaults to yellow
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CustomCategory) Reset() {
 	*x = CustomCategory{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[10]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -866,7 +1517,7 @@ func (x *CustomCategory) String() string {
 func (*CustomCategory) ProtoMessage() {}
 
 func (x *CustomCategory) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[10]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -879,7 +1530,7 @@ func (x *CustomCategory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CustomCategory.ProtoReflect.Descriptor instead.
 func (*CustomCategory) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{10}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *CustomCategory) GetId() string {
@@ -910,6 +1561,13 @@ func (x *CustomCategory) GetPriority() int32 {
 	return 0
 }
 
+func (x *CustomCategory) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
 // CategoryList contains custom categories defined by a plugin
 type CategoryList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -920,7 +1578,7 @@ type CategoryList struct {
 
 func (x *CategoryList) Reset() {
 	*x = CategoryList{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[11]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -932,7 +1590,7 @@ func (x *CategoryList) String() string {
 func (*CategoryList) ProtoMessage() {}
 
 func (x *CategoryList) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[11]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -945,7 +1603,7 @@ func (x *CategoryList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CategoryList.ProtoReflect.Descriptor instead.
 func (*CategoryList) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{11}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CategoryList) GetCategories() []*CustomCategory {
@@ -971,7 +1629,7 @@ type StreamMessage struct {
 
 func (x *StreamMessage) Reset() {
 	*x = StreamMessage{}
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[12]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -983,7 +1641,7 @@ func (x *StreamMessage) String() string {
 func (*StreamMessage) ProtoMessage() {}
 
 func (x *StreamMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[12]
+	mi := &file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -996,7 +1654,7 @@ func (x *StreamMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamMessage.ProtoReflect.Descriptor instead.
 func (*StreamMessage) Descriptor() ([]byte, []int) {
-	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{12}
+	return file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *StreamMessage) GetType() StreamMessage_Type {
@@ -1060,7 +1718,7 @@ var File_pkg_plugin_sdk_v1_plugin_proto protoreflect.FileDescriptor
 const file_pkg_plugin_sdk_v1_plugin_proto_rawDesc = "" +
 	"\n" +
 	"\x1epkg/plugin/sdk/v1/plugin.proto\x12\x02v1\"\a\n" +
-	"\x05Empty\"\xe6\x03\n" +
+	"\x05Empty\"\x96\x04\n" +
 	"\x0ePluginMetadata\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x16\n" +
@@ -1077,15 +1735,22 @@ const file_pkg_plugin_sdk_v1_plugin_proto_rawDesc = "" +
 	"\n" +
 	"namespaced\x18\f \x01(\bR\n" +
 	"namespaced\x128\n" +
-	"\fdependencies\x18\r \x03(\v2\x14.v1.PluginDependencyR\fdependencies\x1a8\n" +
+	"\fdependencies\x18\r \x03(\v2\x14.v1.PluginDependencyR\fdependencies\x12.\n" +
+	"\vhelp_topics\x18\x0e \x03(\v2\r.v1.HelpTopicR\n" +
+	"helpTopics\x1a8\n" +
 	"\n" +
 	"ExtraEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"m\n" +
+	"\tHelpTopic\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x18\n" +
+	"\aaliases\x18\x04 \x03(\tR\aaliases\"\\\n" +
 	"\x10PluginDependency\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1a\n" +
-	"\boptional\x18\x03 \x01(\bR\boptional\"\x9b\x02\n" +
+	"\boptional\x18\x03 \x01(\bR\boptional\"\xca\x03\n" +
 	"\vCommandInfo\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
@@ -1097,7 +1762,26 @@ const file_pkg_plugin_sdk_v1_plugin_proto_rawDesc = "" +
 	"\rrequires_auth\x18\b \x01(\bR\frequiresAuth\x12\x1e\n" +
 	"\n" +
 	"visibility\x18\t \x01(\tR\n" +
-	"visibility\":\n" +
+	"visibility\x12.\n" +
+	"\bexamples\x18\n" +
+	" \x03(\v2\x12.v1.CommandExampleR\bexamples\x12 \n" +
+	"\vdestructive\x18\v \x01(\bR\vdestructive\x12\x1c\n" +
+	"\tstreaming\x18\f \x01(\bR\tstreaming\x12\x1f\n" +
+	"\x04args\x18\r \x03(\v2\v.v1.ArgSpecR\x04args\x12\x1c\n" +
+	"\tcompletes\x18\x0e \x01(\bR\tcompletes\"L\n" +
+	"\x0eCommandExample\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\"\xcb\x01\n" +
+	"\aArgSpec\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\brequired\x18\x03 \x01(\bR\brequired\x12\x1a\n" +
+	"\bvariadic\x18\x04 \x01(\bR\bvariadic\x12\x1e\n" +
+	"\n" +
+	"completion\x18\x05 \x01(\tR\n" +
+	"completion\x12\x18\n" +
+	"\achoices\x18\x06 \x03(\tR\achoices\x12\x18\n" +
+	"\apattern\x18\a \x01(\tR\apattern\":\n" +
 	"\vCommandList\x12+\n" +
 	"\bcommands\x18\x01 \x03(\v2\x0f.v1.CommandInfoR\bcommands\"\x87\x01\n" +
 	"\x10ConfigureRequest\x128\n" +
@@ -1107,14 +1791,15 @@ const file_pkg_plugin_sdk_v1_plugin_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"G\n" +
 	"\x11ConfigureResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xc5\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xe8\x02\n" +
 	"\x0eExecuteRequest\x12\x18\n" +
 	"\acommand\x18\x01 \x01(\tR\acommand\x12\x12\n" +
 	"\x04args\x18\x02 \x03(\tR\x04args\x123\n" +
 	"\x05flags\x18\x03 \x03(\v2\x1d.v1.ExecuteRequest.FlagsEntryR\x05flags\x12-\n" +
 	"\x03env\x18\x04 \x03(\v2\x1b.v1.ExecuteRequest.EnvEntryR\x03env\x12\x19\n" +
 	"\bwork_dir\x18\x05 \x01(\tR\aworkDir\x12\x14\n" +
-	"\x05stdin\x18\x06 \x01(\fR\x05stdin\x1a8\n" +
+	"\x05stdin\x18\x06 \x01(\fR\x05stdin\x12!\n" +
+	"\fexecution_id\x18\a \x01(\tR\vexecutionId\x1a8\n" +
 	"\n" +
 	"FlagsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
@@ -1133,7 +1818,29 @@ const file_pkg_plugin_sdk_v1_plugin_proto_rawDesc = "" +
 	"\n" +
 	"ExtraEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc6\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x01\n" +
+	"\fExecuteEvent\x12\x18\n" +
+	"\x06stdout\x18\x01 \x01(\fH\x00R\x06stdout\x12\x18\n" +
+	"\x06stderr\x18\x02 \x01(\fH\x00R\x06stderr\x12*\n" +
+	"\bprogress\x18\x03 \x01(\v2\f.v1.ProgressH\x00R\bprogress\x12-\n" +
+	"\x06result\x18\x04 \x01(\v2\x13.v1.ExecuteResponseH\x00R\x06resultB\a\n" +
+	"\x05event\"T\n" +
+	"\bProgress\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\acurrent\x18\x02 \x01(\x03R\acurrent\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\"J\n" +
+	"\rCancelRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\".\n" +
+	"\x0eCancelResponse\x12\x1c\n" +
+	"\tcancelled\x18\x01 \x01(\bR\tcancelled\"`\n" +
+	"\x0fCompleteRequest\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x12\n" +
+	"\x04args\x18\x02 \x03(\tR\x04args\x12\x1f\n" +
+	"\vto_complete\x18\x03 \x01(\tR\n" +
+	"toComplete\"*\n" +
+	"\x10CompleteResponse\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xc6\x02\n" +
 	"\fCapabilities\x12'\n" +
 	"\x0frequires_docker\x18\x01 \x01(\bR\x0erequiresDocker\x12)\n" +
 	"\x10requires_network\x18\x02 \x01(\bR\x0frequiresNetwork\x12/\n" +
@@ -1141,12 +1848,13 @@ const file_pkg_plugin_sdk_v1_plugin_proto_rawDesc = "" +
 	"\x14requires_interactive\x18\x04 \x01(\bR\x13requiresInteractive\x12+\n" +
 	"\x11required_commands\x18\x05 \x03(\tR\x10requiredCommands\x12%\n" +
 	"\x0erequired_paths\x18\x06 \x03(\tR\rrequiredPaths\x12*\n" +
-	"\x11required_env_vars\x18\a \x03(\tR\x0frequiredEnvVars\"r\n" +
+	"\x11required_env_vars\x18\a \x03(\tR\x0frequiredEnvVars\"\x88\x01\n" +
 	"\x0eCustomCategory\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
-	"\bpriority\x18\x04 \x01(\x05R\bpriority\"B\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12\x14\n" +
+	"\x05color\x18\x05 \x01(\tR\x05color\"B\n" +
 	"\fCategoryList\x122\n" +
 	"\n" +
 	"categories\x18\x01 \x03(\v2\x12.v1.CustomCategoryR\n" +
@@ -1173,12 +1881,15 @@ const file_pkg_plugin_sdk_v1_plugin_proto_rawDesc = "" +
 	"\x04EXIT\x10\x05\x12\t\n" +
 	"\x05ERROR\x10\x06\x12\b\n" +
 	"\x04PING\x10\a\x12\b\n" +
-	"\x04PONG\x10\b2\xfe\x02\n" +
+	"\x04PONG\x10\b2\xa6\x04\n" +
 	"\vGlidePlugin\x12,\n" +
 	"\vGetMetadata\x12\t.v1.Empty\x1a\x12.v1.PluginMetadata\x128\n" +
 	"\tConfigure\x12\x14.v1.ConfigureRequest\x1a\x15.v1.ConfigureResponse\x12*\n" +
 	"\fListCommands\x12\t.v1.Empty\x1a\x0f.v1.CommandList\x129\n" +
-	"\x0eExecuteCommand\x12\x12.v1.ExecuteRequest\x1a\x13.v1.ExecuteResponse\x12<\n" +
+	"\x0eExecuteCommand\x12\x12.v1.ExecuteRequest\x1a\x13.v1.ExecuteResponse\x12>\n" +
+	"\x14ExecuteCommandStream\x12\x12.v1.ExecuteRequest\x1a\x10.v1.ExecuteEvent0\x01\x12/\n" +
+	"\x06Cancel\x12\x11.v1.CancelRequest\x1a\x12.v1.CancelResponse\x125\n" +
+	"\bComplete\x12\x13.v1.CompleteRequest\x1a\x14.v1.CompleteResponse\x12<\n" +
 	"\x10StartInteractive\x12\x11.v1.StreamMessage\x1a\x11.v1.StreamMessage(\x010\x01\x12.\n" +
 	"\x0fGetCapabilities\x12\t.v1.Empty\x1a\x10.v1.Capabilities\x122\n" +
 	"\x13GetCustomCategories\x12\t.v1.Empty\x1a\x10.v1.CategoryListB1Z/github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1b\x06proto3"
@@ -1196,57 +1907,77 @@ func file_pkg_plugin_sdk_v1_plugin_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_plugin_sdk_v1_plugin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pkg_plugin_sdk_v1_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_pkg_plugin_sdk_v1_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_pkg_plugin_sdk_v1_plugin_proto_goTypes = []any{
 	(StreamMessage_Type)(0),   // 0: v1.StreamMessage.Type
 	(*Empty)(nil),             // 1: v1.Empty
 	(*PluginMetadata)(nil),    // 2: v1.PluginMetadata
-	(*PluginDependency)(nil),  // 3: v1.PluginDependency
-	(*CommandInfo)(nil),       // 4: v1.CommandInfo
-	(*CommandList)(nil),       // 5: v1.CommandList
-	(*ConfigureRequest)(nil),  // 6: v1.ConfigureRequest
-	(*ConfigureResponse)(nil), // 7: v1.ConfigureResponse
-	(*ExecuteRequest)(nil),    // 8: v1.ExecuteRequest
-	(*ExecuteResponse)(nil),   // 9: v1.ExecuteResponse
-	(*Capabilities)(nil),      // 10: v1.Capabilities
-	(*CustomCategory)(nil),    // 11: v1.CustomCategory
-	(*CategoryList)(nil),      // 12: v1.CategoryList
-	(*StreamMessage)(nil),     // 13: v1.StreamMessage
-	nil,                       // 14: v1.PluginMetadata.ExtraEntry
-	nil,                       // 15: v1.ConfigureRequest.ConfigEntry
-	nil,                       // 16: v1.ExecuteRequest.FlagsEntry
-	nil,                       // 17: v1.ExecuteRequest.EnvEntry
-	nil,                       // 18: v1.ExecuteResponse.ExtraEntry
+	(*HelpTopic)(nil),         // 3: v1.HelpTopic
+	(*PluginDependency)(nil),  // 4: v1.PluginDependency
+	(*CommandInfo)(nil),       // 5: v1.CommandInfo
+	(*CommandExample)(nil),    // 6: v1.CommandExample
+	(*ArgSpec)(nil),           // 7: v1.ArgSpec
+	(*CommandList)(nil),       // 8: v1.CommandList
+	(*ConfigureRequest)(nil),  // 9: v1.ConfigureRequest
+	(*ConfigureResponse)(nil), // 10: v1.ConfigureResponse
+	(*ExecuteRequest)(nil),    // 11: v1.ExecuteRequest
+	(*ExecuteResponse)(nil),   // 12: v1.ExecuteResponse
+	(*ExecuteEvent)(nil),      // 13: v1.ExecuteEvent
+	(*Progress)(nil),          // 14: v1.Progress
+	(*CancelRequest)(nil),     // 15: v1.CancelRequest
+	(*CancelResponse)(nil),    // 16: v1.CancelResponse
+	(*CompleteRequest)(nil),   // 17: v1.CompleteRequest
+	(*CompleteResponse)(nil),  // 18: v1.CompleteResponse
+	(*Capabilities)(nil),      // 19: v1.Capabilities
+	(*CustomCategory)(nil),    // 20: v1.CustomCategory
+	(*CategoryList)(nil),      // 21: v1.CategoryList
+	(*StreamMessage)(nil),     // 22: v1.StreamMessage
+	nil,                       // 23: v1.PluginMetadata.ExtraEntry
+	nil,                       // 24: v1.ConfigureRequest.ConfigEntry
+	nil,                       // 25: v1.ExecuteRequest.FlagsEntry
+	nil,                       // 26: v1.ExecuteRequest.EnvEntry
+	nil,                       // 27: v1.ExecuteResponse.ExtraEntry
 }
 var file_pkg_plugin_sdk_v1_plugin_proto_depIdxs = []int32{
-	14, // 0: v1.PluginMetadata.extra:type_name -> v1.PluginMetadata.ExtraEntry
-	3,  // 1: v1.PluginMetadata.dependencies:type_name -> v1.PluginDependency
-	4,  // 2: v1.CommandList.commands:type_name -> v1.CommandInfo
-	15, // 3: v1.ConfigureRequest.config:type_name -> v1.ConfigureRequest.ConfigEntry
-	16, // 4: v1.ExecuteRequest.flags:type_name -> v1.ExecuteRequest.FlagsEntry
-	17, // 5: v1.ExecuteRequest.env:type_name -> v1.ExecuteRequest.EnvEntry
-	18, // 6: v1.ExecuteResponse.extra:type_name -> v1.ExecuteResponse.ExtraEntry
-	11, // 7: v1.CategoryList.categories:type_name -> v1.CustomCategory
-	0,  // 8: v1.StreamMessage.type:type_name -> v1.StreamMessage.Type
-	1,  // 9: v1.GlidePlugin.GetMetadata:input_type -> v1.Empty
-	6,  // 10: v1.GlidePlugin.Configure:input_type -> v1.ConfigureRequest
-	1,  // 11: v1.GlidePlugin.ListCommands:input_type -> v1.Empty
-	8,  // 12: v1.GlidePlugin.ExecuteCommand:input_type -> v1.ExecuteRequest
-	13, // 13: v1.GlidePlugin.StartInteractive:input_type -> v1.StreamMessage
-	1,  // 14: v1.GlidePlugin.GetCapabilities:input_type -> v1.Empty
-	1,  // 15: v1.GlidePlugin.GetCustomCategories:input_type -> v1.Empty
-	2,  // 16: v1.GlidePlugin.GetMetadata:output_type -> v1.PluginMetadata
-	7,  // 17: v1.GlidePlugin.Configure:output_type -> v1.ConfigureResponse
-	5,  // 18: v1.GlidePlugin.ListCommands:output_type -> v1.CommandList
-	9,  // 19: v1.GlidePlugin.ExecuteCommand:output_type -> v1.ExecuteResponse
-	13, // 20: v1.GlidePlugin.StartInteractive:output_type -> v1.StreamMessage
-	10, // 21: v1.GlidePlugin.GetCapabilities:output_type -> v1.Capabilities
-	12, // 22: v1.GlidePlugin.GetCustomCategories:output_type -> v1.CategoryList
-	16, // [16:23] is the sub-list for method output_type
-	9,  // [9:16] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	23, // 0: v1.PluginMetadata.extra:type_name -> v1.PluginMetadata.ExtraEntry
+	4,  // 1: v1.PluginMetadata.dependencies:type_name -> v1.PluginDependency
+	3,  // 2: v1.PluginMetadata.help_topics:type_name -> v1.HelpTopic
+	6,  // 3: v1.CommandInfo.examples:type_name -> v1.CommandExample
+	7,  // 4: v1.CommandInfo.args:type_name -> v1.ArgSpec
+	5,  // 5: v1.CommandList.commands:type_name -> v1.CommandInfo
+	24, // 6: v1.ConfigureRequest.config:type_name -> v1.ConfigureRequest.ConfigEntry
+	25, // 7: v1.ExecuteRequest.flags:type_name -> v1.ExecuteRequest.FlagsEntry
+	26, // 8: v1.ExecuteRequest.env:type_name -> v1.ExecuteRequest.EnvEntry
+	27, // 9: v1.ExecuteResponse.extra:type_name -> v1.ExecuteResponse.ExtraEntry
+	14, // 10: v1.ExecuteEvent.progress:type_name -> v1.Progress
+	12, // 11: v1.ExecuteEvent.result:type_name -> v1.ExecuteResponse
+	20, // 12: v1.CategoryList.categories:type_name -> v1.CustomCategory
+	0,  // 13: v1.StreamMessage.type:type_name -> v1.StreamMessage.Type
+	1,  // 14: v1.GlidePlugin.GetMetadata:input_type -> v1.Empty
+	9,  // 15: v1.GlidePlugin.Configure:input_type -> v1.ConfigureRequest
+	1,  // 16: v1.GlidePlugin.ListCommands:input_type -> v1.Empty
+	11, // 17: v1.GlidePlugin.ExecuteCommand:input_type -> v1.ExecuteRequest
+	11, // 18: v1.GlidePlugin.ExecuteCommandStream:input_type -> v1.ExecuteRequest
+	15, // 19: v1.GlidePlugin.Cancel:input_type -> v1.CancelRequest
+	17, // 20: v1.GlidePlugin.Complete:input_type -> v1.CompleteRequest
+	22, // 21: v1.GlidePlugin.StartInteractive:input_type -> v1.StreamMessage
+	1,  // 22: v1.GlidePlugin.GetCapabilities:input_type -> v1.Empty
+	1,  // 23: v1.GlidePlugin.GetCustomCategories:input_type -> v1.Empty
+	2,  // 24: v1.GlidePlugin.GetMetadata:output_type -> v1.PluginMetadata
+	10, // 25: v1.GlidePlugin.Configure:output_type -> v1.ConfigureResponse
+	8,  // 26: v1.GlidePlugin.ListCommands:output_type -> v1.CommandList
+	12, // 27: v1.GlidePlugin.ExecuteCommand:output_type -> v1.ExecuteResponse
+	13, // 28: v1.GlidePlugin.ExecuteCommandStream:output_type -> v1.ExecuteEvent
+	16, // 29: v1.GlidePlugin.Cancel:output_type -> v1.CancelResponse
+	18, // 30: v1.GlidePlugin.Complete:output_type -> v1.CompleteResponse
+	22, // 31: v1.GlidePlugin.StartInteractive:output_type -> v1.StreamMessage
+	19, // 32: v1.GlidePlugin.GetCapabilities:output_type -> v1.Capabilities
+	21, // 33: v1.GlidePlugin.GetCustomCategories:output_type -> v1.CategoryList
+	24, // [24:34] is the sub-list for method output_type
+	14, // [14:24] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_pkg_plugin_sdk_v1_plugin_proto_init() }
@@ -1254,13 +1985,19 @@ func file_pkg_plugin_sdk_v1_plugin_proto_init() {
 	if File_pkg_plugin_sdk_v1_plugin_proto != nil {
 		return
 	}
+	file_pkg_plugin_sdk_v1_plugin_proto_msgTypes[12].OneofWrappers = []any{
+		(*ExecuteEvent_Stdout)(nil),
+		(*ExecuteEvent_Stderr)(nil),
+		(*ExecuteEvent_Progress)(nil),
+		(*ExecuteEvent_Result)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_plugin_sdk_v1_plugin_proto_rawDesc), len(file_pkg_plugin_sdk_v1_plugin_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   18,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
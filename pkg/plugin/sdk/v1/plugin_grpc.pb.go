@@ -19,13 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GlidePlugin_GetMetadata_FullMethodName         = "/v1.GlidePlugin/GetMetadata"
-	GlidePlugin_Configure_FullMethodName           = "/v1.GlidePlugin/Configure"
-	GlidePlugin_ListCommands_FullMethodName        = "/v1.GlidePlugin/ListCommands"
-	GlidePlugin_ExecuteCommand_FullMethodName      = "/v1.GlidePlugin/ExecuteCommand"
-	GlidePlugin_StartInteractive_FullMethodName    = "/v1.GlidePlugin/StartInteractive"
-	GlidePlugin_GetCapabilities_FullMethodName     = "/v1.GlidePlugin/GetCapabilities"
-	GlidePlugin_GetCustomCategories_FullMethodName = "/v1.GlidePlugin/GetCustomCategories"
+	GlidePlugin_GetMetadata_FullMethodName          = "/v1.GlidePlugin/GetMetadata"
+	GlidePlugin_Configure_FullMethodName            = "/v1.GlidePlugin/Configure"
+	GlidePlugin_ListCommands_FullMethodName         = "/v1.GlidePlugin/ListCommands"
+	GlidePlugin_ExecuteCommand_FullMethodName       = "/v1.GlidePlugin/ExecuteCommand"
+	GlidePlugin_ExecuteCommandStream_FullMethodName = "/v1.GlidePlugin/ExecuteCommandStream"
+	GlidePlugin_Cancel_FullMethodName               = "/v1.GlidePlugin/Cancel"
+	GlidePlugin_Complete_FullMethodName             = "/v1.GlidePlugin/Complete"
+	GlidePlugin_StartInteractive_FullMethodName     = "/v1.GlidePlugin/StartInteractive"
+	GlidePlugin_GetCapabilities_FullMethodName      = "/v1.GlidePlugin/GetCapabilities"
+	GlidePlugin_GetCustomCategories_FullMethodName  = "/v1.GlidePlugin/GetCustomCategories"
 )
 
 // GlidePluginClient is the client API for GlidePlugin service.
@@ -42,6 +45,16 @@ type GlidePluginClient interface {
 	ListCommands(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CommandList, error)
 	// Execute a non-interactive command
 	ExecuteCommand(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
+	// Execute a non-interactive command, streaming its output and progress
+	// as it runs; called for commands marked streaming
+	ExecuteCommandStream(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecuteEvent], error)
+	// Cancel a running command, e.g. after Ctrl+C, so it can stop and clean
+	// up before returning its result
+	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
+	// Complete a command's next positional argument with values only the
+	// plugin knows, such as container names; called for commands marked
+	// completes while the user presses Tab
+	Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResponse, error)
 	// Start an interactive session
 	StartInteractive(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamMessage, StreamMessage], error)
 	// Get required capabilities
@@ -98,9 +111,48 @@ func (c *glidePluginClient) ExecuteCommand(ctx context.Context, in *ExecuteReque
 	return out, nil
 }
 
+func (c *glidePluginClient) ExecuteCommandStream(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecuteEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GlidePlugin_ServiceDesc.Streams[0], GlidePlugin_ExecuteCommandStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecuteRequest, ExecuteEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GlidePlugin_ExecuteCommandStreamClient = grpc.ServerStreamingClient[ExecuteEvent]
+
+func (c *glidePluginClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelResponse)
+	err := c.cc.Invoke(ctx, GlidePlugin_Cancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *glidePluginClient) Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteResponse)
+	err := c.cc.Invoke(ctx, GlidePlugin_Complete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *glidePluginClient) StartInteractive(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamMessage, StreamMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GlidePlugin_ServiceDesc.Streams[0], GlidePlugin_StartInteractive_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GlidePlugin_ServiceDesc.Streams[1], GlidePlugin_StartInteractive_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +197,16 @@ type GlidePluginServer interface {
 	ListCommands(context.Context, *Empty) (*CommandList, error)
 	// Execute a non-interactive command
 	ExecuteCommand(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
+	// Execute a non-interactive command, streaming its output and progress
+	// as it runs; called for commands marked streaming
+	ExecuteCommandStream(*ExecuteRequest, grpc.ServerStreamingServer[ExecuteEvent]) error
+	// Cancel a running command, e.g. after Ctrl+C, so it can stop and clean
+	// up before returning its result
+	Cancel(context.Context, *CancelRequest) (*CancelResponse, error)
+	// Complete a command's next positional argument with values only the
+	// plugin knows, such as container names; called for commands marked
+	// completes while the user presses Tab
+	Complete(context.Context, *CompleteRequest) (*CompleteResponse, error)
 	// Start an interactive session
 	StartInteractive(grpc.BidiStreamingServer[StreamMessage, StreamMessage]) error
 	// Get required capabilities
@@ -173,6 +235,15 @@ func (UnimplementedGlidePluginServer) ListCommands(context.Context, *Empty) (*Co
 func (UnimplementedGlidePluginServer) ExecuteCommand(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExecuteCommand not implemented")
 }
+func (UnimplementedGlidePluginServer) ExecuteCommandStream(*ExecuteRequest, grpc.ServerStreamingServer[ExecuteEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method ExecuteCommandStream not implemented")
+}
+func (UnimplementedGlidePluginServer) Cancel(context.Context, *CancelRequest) (*CancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedGlidePluginServer) Complete(context.Context, *CompleteRequest) (*CompleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Complete not implemented")
+}
 func (UnimplementedGlidePluginServer) StartInteractive(grpc.BidiStreamingServer[StreamMessage, StreamMessage]) error {
 	return status.Errorf(codes.Unimplemented, "method StartInteractive not implemented")
 }
@@ -275,6 +346,53 @@ func _GlidePlugin_ExecuteCommand_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GlidePlugin_ExecuteCommandStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecuteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GlidePluginServer).ExecuteCommandStream(m, &grpc.GenericServerStream[ExecuteRequest, ExecuteEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GlidePlugin_ExecuteCommandStreamServer = grpc.ServerStreamingServer[ExecuteEvent]
+
+func _GlidePlugin_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlidePluginServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlidePlugin_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlidePluginServer).Cancel(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GlidePlugin_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GlidePluginServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GlidePlugin_Complete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GlidePluginServer).Complete(ctx, req.(*CompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GlidePlugin_StartInteractive_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(GlidePluginServer).StartInteractive(&grpc.GenericServerStream[StreamMessage, StreamMessage]{ServerStream: stream})
 }
@@ -341,6 +459,14 @@ var GlidePlugin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExecuteCommand",
 			Handler:    _GlidePlugin_ExecuteCommand_Handler,
 		},
+		{
+			MethodName: "Cancel",
+			Handler:    _GlidePlugin_Cancel_Handler,
+		},
+		{
+			MethodName: "Complete",
+			Handler:    _GlidePlugin_Complete_Handler,
+		},
 		{
 			MethodName: "GetCapabilities",
 			Handler:    _GlidePlugin_GetCapabilities_Handler,
@@ -351,6 +477,11 @@ var GlidePlugin_ServiceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExecuteCommandStream",
+			Handler:       _GlidePlugin_ExecuteCommandStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "StartInteractive",
 			Handler:       _GlidePlugin_StartInteractive_Handler,
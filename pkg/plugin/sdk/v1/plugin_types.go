@@ -102,7 +102,14 @@ func RunPlugin(impl GlidePluginServer) error {
 		Plugins: map[string]plugin.Plugin{
 			"glide": &GlidePluginImpl{Impl: impl},
 		},
-		GRPCServer: plugin.DefaultGRPCServer,
+		// A panicking handler fails its call instead of the plugin process,
+		// and health checks ask the plugin whether it is ready
+		GRPCServer: func(opts []grpc.ServerOption) *grpc.Server {
+			return plugin.DefaultGRPCServer(append(opts,
+				grpc.ChainUnaryInterceptor(RecoveryUnaryInterceptor, HealthUnaryInterceptor(impl)),
+				grpc.ChainStreamInterceptor(RecoveryStreamInterceptor),
+			))
+		},
 	})
 
 	return nil
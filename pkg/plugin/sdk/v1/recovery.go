@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PanicPrefix starts the message of the error a plugin returns when one of
+// its handlers panics, so the host can report it as a plugin crash
+const PanicPrefix = "plugin panicked"
+
+// RecoveryUnaryInterceptor turns a panic in a plugin's RPC handler into an
+// Internal error, keeping the plugin process alive
+func RecoveryUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicStatus(CallName(info.FullMethod, req), r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// RecoveryStreamInterceptor turns a panic in a plugin's streaming handler
+// into an Internal error
+func RecoveryStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicStatus(CallName(info.FullMethod, nil), r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// CallName names an RPC for error messages: the command of an
+// ExecuteRequest, or else the method, e.g. "GetMetadata"
+func CallName(fullMethod string, req interface{}) string {
+	if execute, ok := req.(*ExecuteRequest); ok && execute.GetCommand() != "" {
+		return execute.GetCommand()
+	}
+	return path.Base(fullMethod)
+}
+
+// panicStatus is the error returned for a recovered panic
+func panicStatus(call string, recovered interface{}) error {
+	return status.Error(codes.Internal, fmt.Sprintf("%s in %s: %v", PanicPrefix, call, recovered))
+}
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/glide.v1.GlidePlugin/ExecuteCommand"}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		panic("nil map write")
+	}
+
+	_, err := RecoveryUnaryInterceptor(context.Background(), &ExecuteRequest{Command: "deploy"}, info, handler)
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, PanicPrefix+" in deploy: nil map write", status.Convert(err).Message())
+}
+
+func TestCallName(t *testing.T) {
+	assert.Equal(t, "deploy", CallName("/glide.v1.GlidePlugin/ExecuteCommand", &ExecuteRequest{Command: "deploy"}))
+	assert.Equal(t, "GetMetadata", CallName("/glide.v1.GlidePlugin/GetMetadata", &Empty{}))
+}
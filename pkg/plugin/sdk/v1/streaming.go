@@ -0,0 +1,172 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// StreamingCommandHandler is implemented by non-interactive commands that
+// stream their output and progress while they run, such as builds and
+// deploys. The host calls ExecuteCommandStream for commands whose info is
+// marked Streaming.
+type StreamingCommandHandler interface {
+	ExecuteStream(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error)
+}
+
+// StreamWriter sends a streamed command's output and progress to the host
+type StreamWriter struct {
+	mu   sync.Mutex
+	send func(*ExecuteEvent) error
+}
+
+// NewStreamWriter creates a writer that sends events to a stream
+func NewStreamWriter(stream GlidePlugin_ExecuteCommandStreamServer) *StreamWriter {
+	return &StreamWriter{send: stream.Send}
+}
+
+// NewBufferedStreamWriter creates a writer that collects output in stdout
+// and stderr and drops progress, for running a streaming command where the
+// host does not stream
+func NewBufferedStreamWriter(stdout, stderr io.Writer) *StreamWriter {
+	return &StreamWriter{send: func(event *ExecuteEvent) error {
+		var err error
+		switch e := event.Event.(type) {
+		case *ExecuteEvent_Stdout:
+			_, err = stdout.Write(e.Stdout)
+		case *ExecuteEvent_Stderr:
+			_, err = stderr.Write(e.Stderr)
+		}
+		return err
+	}}
+}
+
+// Stdout returns a writer whose writes are shown on the host's stdout
+func (w *StreamWriter) Stdout() io.Writer {
+	return streamOutput{w: w, stderr: false}
+}
+
+// Stderr returns a writer whose writes are shown on the host's stderr
+func (w *StreamWriter) Stderr() io.Writer {
+	return streamOutput{w: w, stderr: true}
+}
+
+// Progress reports how far the command has got. A total of 0 means the
+// amount of work is unknown, in which case only the message is shown.
+func (w *StreamWriter) Progress(message string, current, total int64) error {
+	return w.sendEvent(&ExecuteEvent{Event: &ExecuteEvent_Progress{Progress: &Progress{
+		Message: message,
+		Current: current,
+		Total:   total,
+	}}})
+}
+
+func (w *StreamWriter) sendEvent(event *ExecuteEvent) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.send(event)
+}
+
+// streamOutput sends writes as stdout or stderr events
+type streamOutput struct {
+	w      *StreamWriter
+	stderr bool
+}
+
+func (o streamOutput) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	// The stream may hold on to the event, so it gets its own copy
+	data := append([]byte(nil), p...)
+
+	event := &ExecuteEvent{Event: &ExecuteEvent_Stdout{Stdout: data}}
+	if o.stderr {
+		event = &ExecuteEvent{Event: &ExecuteEvent_Stderr{Stderr: data}}
+	}
+	if err := o.w.sendEvent(event); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ExecuteCommandStream runs a registered command, streaming its output and
+// progress. Commands that do not stream are run as with ExecuteCommand and
+// only their result is sent.
+func (p *BasePlugin) ExecuteCommandStream(req *ExecuteRequest, stream GlidePlugin_ExecuteCommandStreamServer) error {
+	handler, ok := p.commands[req.Command]
+	if !ok {
+		return SendResult(stream, &ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("unknown command: %s", req.Command),
+		})
+	}
+
+	ctx, done := p.executions.Start(stream.Context(), req.ExecutionId)
+	defer done()
+
+	var resp *ExecuteResponse
+	var err error
+	if streaming, ok := handler.(StreamingCommandHandler); ok {
+		resp, err = streaming.ExecuteStream(ctx, req, NewStreamWriter(stream))
+	} else {
+		resp, err = handler.Execute(ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+	return SendResult(stream, resp)
+}
+
+// SendResult ends a streamed command with its result
+func SendResult(stream GlidePlugin_ExecuteCommandStreamServer, resp *ExecuteResponse) error {
+	if resp == nil {
+		resp = &ExecuteResponse{Success: true}
+	}
+	return stream.Send(&ExecuteEvent{Event: &ExecuteEvent_Result{Result: resp}})
+}
+
+// StreamingCommand is a helper struct for non-interactive commands that
+// stream their output and progress
+type StreamingCommand struct {
+	info    *CommandInfo
+	handler func(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error)
+}
+
+// NewStreamingCommand creates a new streaming command
+func NewStreamingCommand(info *CommandInfo, handler func(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error)) *StreamingCommand {
+	// Ensure the host asks for the command's output as a stream
+	info.Streaming = true
+	return &StreamingCommand{
+		info:    info,
+		handler: handler,
+	}
+}
+
+// Info returns the command information
+func (c *StreamingCommand) Info() *CommandInfo {
+	return c.info
+}
+
+// ExecuteStream runs the command handler, streaming its output
+func (c *StreamingCommand) ExecuteStream(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error) {
+	return c.handler(ctx, req, out)
+}
+
+// Execute runs the command handler for hosts that do not stream, returning
+// its output in the response once it has finished. Progress is dropped.
+func (c *StreamingCommand) Execute(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
+	var stdout, stderr bytes.Buffer
+	resp, err := c.handler(ctx, req, NewBufferedStreamWriter(&stdout, &stderr))
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = &ExecuteResponse{Success: true}
+	}
+	resp.Stdout = append(stdout.Bytes(), resp.Stdout...)
+	resp.Stderr = append(stderr.Bytes(), resp.Stderr...)
+	return resp, nil
+}
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// eventRecorder is a server stream that records the events sent on it
+type eventRecorder struct {
+	grpc.ServerStream
+	events []*ExecuteEvent
+}
+
+func (r *eventRecorder) Context() context.Context {
+	return context.Background()
+}
+
+func (r *eventRecorder) Send(event *ExecuteEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestBasePlugin_ExecuteCommandStream(t *testing.T) {
+	p := NewBasePlugin(&PluginMetadata{Name: "deploy"})
+	info := &CommandInfo{Name: "ship"}
+	p.RegisterCommand("ship", NewStreamingCommand(info, func(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error) {
+		require.NoError(t, out.Progress("Uploading", 1, 2))
+		_, err := out.Stdout().Write([]byte("uploaded\n"))
+		require.NoError(t, err)
+		_, err = out.Stderr().Write([]byte("slow network\n"))
+		require.NoError(t, err)
+		return &ExecuteResponse{Success: true}, nil
+	}))
+	p.RegisterCommand("status", NewSimpleCommand(&CommandInfo{Name: "status"}, func(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
+		return &ExecuteResponse{Success: true, Stdout: []byte("ok\n")}, nil
+	}))
+
+	assert.True(t, info.Streaming, "streaming commands are marked for the host")
+
+	t.Run("streams output and progress before the result", func(t *testing.T) {
+		stream := &eventRecorder{}
+		require.NoError(t, p.ExecuteCommandStream(&ExecuteRequest{Command: "ship"}, stream))
+		require.Len(t, stream.events, 4)
+		assert.Equal(t, "Uploading", stream.events[0].GetProgress().GetMessage())
+		assert.Equal(t, int64(2), stream.events[0].GetProgress().GetTotal())
+		assert.Equal(t, []byte("uploaded\n"), stream.events[1].GetStdout())
+		assert.Equal(t, []byte("slow network\n"), stream.events[2].GetStderr())
+		assert.True(t, stream.events[3].GetResult().GetSuccess())
+	})
+
+	t.Run("sends only the result of other commands", func(t *testing.T) {
+		stream := &eventRecorder{}
+		require.NoError(t, p.ExecuteCommandStream(&ExecuteRequest{Command: "status"}, stream))
+		require.Len(t, stream.events, 1)
+		assert.Equal(t, []byte("ok\n"), stream.events[0].GetResult().GetStdout())
+	})
+
+	t.Run("reports unknown commands in the result", func(t *testing.T) {
+		stream := &eventRecorder{}
+		require.NoError(t, p.ExecuteCommandStream(&ExecuteRequest{Command: "missing"}, stream))
+		require.Len(t, stream.events, 1)
+		assert.Equal(t, "unknown command: missing", stream.events[0].GetResult().GetError())
+	})
+}
+
+func TestStreamingCommand_Execute(t *testing.T) {
+	cmd := NewStreamingCommand(&CommandInfo{Name: "build"}, func(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error) {
+		_, _ = out.Stdout().Write([]byte("step 1\n"))
+		_ = out.Progress("Compiling", 0, 0)
+		_, _ = out.Stdout().Write([]byte("step 2\n"))
+		return &ExecuteResponse{Success: true, Stdout: []byte("done\n")}, nil
+	})
+
+	// Hosts that do not stream get the output once the command finishes
+	resp, err := cmd.Execute(context.Background(), &ExecuteRequest{Command: "build"})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Equal(t, "step 1\nstep 2\ndone\n", string(resp.Stdout))
+	assert.Empty(t, resp.Stderr)
+}
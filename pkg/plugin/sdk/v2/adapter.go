@@ -3,10 +3,13 @@ package v2
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 
+	pkgconfig "github.com/glide-cli/glide/v3/pkg/config"
 	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
 	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
 )
@@ -46,12 +49,29 @@ func AdaptV1GRPCPlugin(v1Plugin v1.GlidePluginClient) Plugin[map[string]interfac
 
 	// Fetch commands from v1 plugin
 	if v1Commands, err := v1Plugin.ListCommands(ctx, &v1.Empty{}); err == nil {
-		adapter.commands = convertV1Commands(v1Commands.Commands)
+		adapter.commands = convertV1Commands(v1Plugin, v1Commands.Commands)
 	}
 
 	return adapter
 }
 
+// AdaptV1Client wraps a v1 gRPC plugin whose metadata the host already
+// has, so the rest of the host can use it through the v2 interfaces. Only
+// the plugin's commands are fetched.
+func AdaptV1Client(ctx context.Context, v1Plugin v1.GlidePluginClient, v1Meta *v1.PluginMetadata) (Plugin[map[string]interface{}], error) {
+	v1Commands, err := v1Plugin.ListCommands(ctx, &v1.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &V1Adapter{
+		v1Plugin: v1Plugin,
+		metadata: convertV1Metadata(v1Meta),
+		commands: convertV1Commands(v1Plugin, v1Commands.GetCommands()),
+		state:    sdk.NewStateTracker(v1Meta.GetName()),
+	}, nil
+}
+
 // AdaptV1InProcessPlugin wraps a v1 in-process plugin for v2 compatibility.
 // The v1 in-process plugin interface is defined in pkg/plugin/interface.go.
 func AdaptV1InProcessPlugin(v1Plugin interface{}) Plugin[map[string]interface{}] {
@@ -97,11 +117,7 @@ func (a *V1Adapter) Configure(ctx context.Context, config map[string]interface{}
 	switch p := a.v1Plugin.(type) {
 	case v1.GlidePluginClient:
 		// Convert map to v1 ConfigureRequest
-		configMap := make(map[string]string)
-		for k, v := range config {
-			configMap[k] = fmt.Sprintf("%v", v)
-		}
-		req := &v1.ConfigureRequest{Config: configMap}
+		req := &v1.ConfigureRequest{Config: pkgconfig.Flatten(config)}
 		resp, err := p.Configure(ctx, req)
 		if err != nil {
 			return err
@@ -209,14 +225,27 @@ func convertV1Metadata(v1Meta *v1.PluginMetadata) Metadata {
 		}
 	}
 
+	if len(v1Meta.HelpTopics) > 0 {
+		meta.HelpTopics = make([]HelpTopic, len(v1Meta.HelpTopics))
+		for i, topic := range v1Meta.HelpTopics {
+			meta.HelpTopics[i] = HelpTopic{
+				Name:    topic.Name,
+				Summary: topic.Summary,
+				Content: topic.Content,
+				Aliases: topic.Aliases,
+			}
+		}
+	}
+
 	// Note: Capabilities are fetched via separate RPC call in v1 (GetCapabilities)
 	// We don't set them here since they're not part of PluginMetadata
 
 	return meta
 }
 
-// convertV1Commands converts v1 protobuf commands to v2 Commands.
-func convertV1Commands(v1Commands []*v1.CommandInfo) []Command {
+// convertV1Commands converts v1 protobuf commands to v2 Commands whose
+// handlers run them through the v1 plugin.
+func convertV1Commands(v1Plugin v1.GlidePluginClient, v1Commands []*v1.CommandInfo) []Command {
 	commands := make([]Command, len(v1Commands))
 
 	for i, v1Cmd := range v1Commands {
@@ -227,17 +256,46 @@ func convertV1Commands(v1Commands []*v1.CommandInfo) []Command {
 			Aliases:      v1Cmd.Aliases,
 			Hidden:       v1Cmd.Hidden,
 			Interactive:  v1Cmd.Interactive,
+			Destructive:  v1Cmd.Destructive,
 			RequiresTTY:  v1Cmd.RequiresTty,
 			RequiresAuth: v1Cmd.RequiresAuth,
 			Visibility:   v1Cmd.Visibility,
-			// Handler will be set up separately by the CLI
-			// since it needs to dispatch to the v1 plugin
+			Examples:     convertV1Examples(v1Cmd.Examples),
+			Args:         fromV1Args(v1Cmd.Args),
+		}
+		if v1Cmd.Completes {
+			commands[i].Complete = v1Completer(v1Plugin, v1Cmd.Name)
+		}
+
+		switch {
+		case v1Cmd.Interactive:
+			commands[i].InteractiveHandler = NewV1InteractiveCommandAdapter(v1Plugin, v1Cmd.Name)
+		case v1Cmd.Streaming:
+			commands[i].Handler = NewV1StreamingCommandAdapter(v1Plugin, v1Cmd.Name)
+		default:
+			commands[i].Handler = NewV1CommandAdapter(v1Plugin, v1Cmd.Name)
 		}
 	}
 
 	return commands
 }
 
+// convertV1Examples converts v1 protobuf command examples to v2 Examples.
+func convertV1Examples(v1Examples []*v1.CommandExample) []Example {
+	if len(v1Examples) == 0 {
+		return nil
+	}
+
+	examples := make([]Example, len(v1Examples))
+	for i, ex := range v1Examples {
+		examples[i] = Example{
+			Description: ex.Description,
+			Command:     ex.Command,
+		}
+	}
+	return examples
+}
+
 // V1CommandAdapter wraps a v1 command handler to implement v2 CommandHandler.
 type V1CommandAdapter struct {
 	v1Plugin v1.GlidePluginClient
@@ -254,22 +312,77 @@ func NewV1CommandAdapter(v1Plugin v1.GlidePluginClient, command string) CommandH
 
 // Execute adapts v2 ExecuteRequest to v1 and back.
 func (a *V1CommandAdapter) Execute(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
-	// Convert v2 request to v1
+	v1Resp, err := a.v1Plugin.ExecuteCommand(ctx, toV1Request(a.command, req))
+	if err != nil {
+		return nil, err
+	}
+	return fromV1Response(v1Resp), nil
+}
+
+// V1StreamingCommandAdapter wraps a v1 command marked streaming to
+// implement v2 StreamingCommandHandler.
+type V1StreamingCommandAdapter struct {
+	V1CommandAdapter
+}
+
+// NewV1StreamingCommandAdapter creates an adapter for a v1 streaming command.
+func NewV1StreamingCommandAdapter(v1Plugin v1.GlidePluginClient, command string) StreamingCommandHandler {
+	return &V1StreamingCommandAdapter{V1CommandAdapter{v1Plugin: v1Plugin, command: command}}
+}
+
+// ExecuteStream runs the command through ExecuteCommandStream, passing its
+// output and progress on to out as they arrive.
+func (a *V1StreamingCommandAdapter) ExecuteStream(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error) {
+	stream, err := a.v1Plugin.ExecuteCommandStream(ctx, toV1Request(a.command, req))
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("plugin ended %s without a result", a.command)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch e := event.Event.(type) {
+		case *v1.ExecuteEvent_Stdout:
+			_, err = out.Stdout().Write(e.Stdout)
+		case *v1.ExecuteEvent_Stderr:
+			_, err = out.Stderr().Write(e.Stderr)
+		case *v1.ExecuteEvent_Progress:
+			err = out.Progress(e.Progress.GetMessage(), e.Progress.GetCurrent(), e.Progress.GetTotal())
+		case *v1.ExecuteEvent_Result:
+			return fromV1Response(e.Result), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// toV1Request converts a v2 request to v1. Flags are sent as strings.
+func toV1Request(command string, req *ExecuteRequest) *v1.ExecuteRequest {
 	v1Req := &v1.ExecuteRequest{
-		Command: req.Command,
+		Command: command,
 		Args:    req.Args,
 		Env:     req.Env,
 		WorkDir: req.WorkingDir,
 	}
-
-	// Execute via v1 plugin
-	v1Resp, err := a.v1Plugin.ExecuteCommand(ctx, v1Req)
-	if err != nil {
-		return nil, err
+	if len(req.Flags) > 0 {
+		v1Req.Flags = make(map[string]string, len(req.Flags))
+		for name, value := range req.Flags {
+			v1Req.Flags[name] = fmt.Sprint(value)
+		}
 	}
+	return v1Req
+}
 
-	// Convert v1 response to v2
-	// Combine stdout and stderr into single output
+// fromV1Response converts a v1 response to v2. Stdout and stderr are
+// combined into the output.
+func fromV1Response(v1Resp *v1.ExecuteResponse) *ExecuteResponse {
 	output := string(v1Resp.Stdout)
 	if len(v1Resp.Stderr) > 0 {
 		if len(output) > 0 {
@@ -278,13 +391,17 @@ func (a *V1CommandAdapter) Execute(ctx context.Context, req *ExecuteRequest) (*E
 		output += string(v1Resp.Stderr)
 	}
 
-	v2Resp := &ExecuteResponse{
-		ExitCode: int(v1Resp.ExitCode),
+	// A failure without an exit code still fails
+	exitCode := int(v1Resp.ExitCode)
+	if !v1Resp.Success && exitCode == 0 {
+		exitCode = 1
+	}
+
+	return &ExecuteResponse{
+		ExitCode: exitCode,
 		Output:   output,
 		Error:    v1Resp.Error,
 	}
-
-	return v2Resp, nil
 }
 
 // V1InteractiveCommandAdapter wraps a v1 interactive command handler.
@@ -386,7 +503,8 @@ func (a *V2ToV1Adapter[C]) HealthCheck() error {
 // This allows v2 plugins to run as standalone gRPC plugin processes.
 type V2GRPCServer[C any] struct {
 	v1.UnimplementedGlidePluginServer
-	v2Plugin Plugin[C]
+	v2Plugin   Plugin[C]
+	executions v1.Executions
 }
 
 // NewV2GRPCServer creates a gRPC server wrapper for a v2 plugin.
@@ -397,6 +515,17 @@ func NewV2GRPCServer[C any](plugin Plugin[C]) *V2GRPCServer[C] {
 // GetMetadata implements v1.GlidePluginServer.
 func (s *V2GRPCServer[C]) GetMetadata(ctx context.Context, _ *v1.Empty) (*v1.PluginMetadata, error) {
 	meta := s.v2Plugin.Metadata()
+
+	helpTopics := make([]*v1.HelpTopic, len(meta.HelpTopics))
+	for i, topic := range meta.HelpTopics {
+		helpTopics[i] = &v1.HelpTopic{
+			Name:    topic.Name,
+			Summary: topic.Summary,
+			Content: topic.Content,
+			Aliases: topic.Aliases,
+		}
+	}
+
 	return &v1.PluginMetadata{
 		Name:        meta.Name,
 		Version:     meta.Version,
@@ -405,6 +534,7 @@ func (s *V2GRPCServer[C]) GetMetadata(ctx context.Context, _ *v1.Empty) (*v1.Plu
 		Homepage:    meta.Homepage,
 		License:     meta.License,
 		Tags:        meta.Tags,
+		HelpTopics:  helpTopics,
 	}, nil
 }
 
@@ -427,6 +557,14 @@ func (s *V2GRPCServer[C]) ListCommands(ctx context.Context, _ *v1.Empty) (*v1.Co
 	v1Commands := make([]*v1.CommandInfo, len(v2Commands))
 
 	for i, cmd := range v2Commands {
+		examples := make([]*v1.CommandExample, len(cmd.Examples))
+		for j, ex := range cmd.Examples {
+			examples[j] = &v1.CommandExample{
+				Description: ex.Description,
+				Command:     ex.Command,
+			}
+		}
+
 		v1Commands[i] = &v1.CommandInfo{
 			Name:         cmd.Name,
 			Description:  cmd.Description,
@@ -434,9 +572,16 @@ func (s *V2GRPCServer[C]) ListCommands(ctx context.Context, _ *v1.Empty) (*v1.Co
 			Aliases:      cmd.Aliases,
 			Hidden:       cmd.Hidden,
 			Interactive:  cmd.Interactive,
+			Destructive:  cmd.Destructive,
 			RequiresTty:  cmd.RequiresTTY,
 			RequiresAuth: cmd.RequiresAuth,
 			Visibility:   cmd.Visibility,
+			Examples:     examples,
+			Args:         toV1Args(cmd.Args),
+			Completes:    cmd.Complete != nil,
+		}
+		if _, ok := cmd.Handler.(StreamingCommandHandler); ok {
+			v1Commands[i].Streaming = true
 		}
 	}
 
@@ -445,41 +590,119 @@ func (s *V2GRPCServer[C]) ListCommands(ctx context.Context, _ *v1.Empty) (*v1.Co
 
 // ExecuteCommand implements v1.GlidePluginServer.
 func (s *V2GRPCServer[C]) ExecuteCommand(ctx context.Context, req *v1.ExecuteRequest) (*v1.ExecuteResponse, error) {
-	// Find the command
-	var handler CommandHandler
+	cmd, ok := s.command(req.Command)
+	if !ok || cmd.Handler == nil {
+		return unknownCommandResponse(req.Command), nil
+	}
+	handler := cmd.Handler
+	if err := cmd.ValidateArgs(req.Args); err != nil {
+		return toV1Response(nil, err), nil
+	}
+
+	ctx, done := s.executions.Start(ctx, req.ExecutionId)
+	defer done()
+
+	// Execute via v2 handler
+	v2Resp, err := handler.Execute(ctx, toV2Request(req))
+	return toV1Response(v2Resp, err), nil
+}
+
+// ExecuteCommandStream implements v1.GlidePluginServer. Output and progress
+// of streaming handlers are sent as they run; other handlers only send
+// their result.
+func (s *V2GRPCServer[C]) ExecuteCommandStream(req *v1.ExecuteRequest, stream v1.GlidePlugin_ExecuteCommandStreamServer) error {
+	cmd, ok := s.command(req.Command)
+	if !ok || cmd.Handler == nil {
+		return v1.SendResult(stream, unknownCommandResponse(req.Command))
+	}
+	handler := cmd.Handler
+	if err := cmd.ValidateArgs(req.Args); err != nil {
+		return v1.SendResult(stream, toV1Response(nil, err))
+	}
+
+	ctx, done := s.executions.Start(stream.Context(), req.ExecutionId)
+	defer done()
+
+	var v2Resp *ExecuteResponse
+	var err error
+	if streaming, ok := handler.(StreamingCommandHandler); ok {
+		v2Resp, err = streaming.ExecuteStream(ctx, toV2Request(req), v1.NewStreamWriter(stream))
+	} else {
+		v2Resp, err = handler.Execute(ctx, toV2Request(req))
+	}
+	return v1.SendResult(stream, toV1Response(v2Resp, err))
+}
+
+// Cancel implements v1.GlidePluginServer. The command's context is
+// cancelled with v1.ErrCancelled as its cause.
+func (s *V2GRPCServer[C]) Cancel(ctx context.Context, req *v1.CancelRequest) (*v1.CancelResponse, error) {
+	return &v1.CancelResponse{Cancelled: s.executions.Cancel(req.ExecutionId, req.Reason)}, nil
+}
+
+// Complete implements v1.GlidePluginServer. Commands without Complete get
+// no values, so the host falls back to their argument specs.
+func (s *V2GRPCServer[C]) Complete(ctx context.Context, req *v1.CompleteRequest) (*v1.CompleteResponse, error) {
+	cmd, ok := s.command(req.Command)
+	if !ok || cmd.Complete == nil {
+		return &v1.CompleteResponse{}, nil
+	}
+	values, err := cmd.Complete(ctx, req.Args, req.ToComplete)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.CompleteResponse{Values: values}, nil
+}
+
+// CheckHealth implements v1.HealthChecker, so glide's health checks ask
+// the plugin's HealthCheck whether it is ready
+func (s *V2GRPCServer[C]) CheckHealth(ctx context.Context) error {
+	return s.v2Plugin.HealthCheck(ctx)
+}
+
+// command finds a command by name
+func (s *V2GRPCServer[C]) command(name string) (Command, bool) {
 	for _, cmd := range s.v2Plugin.Commands() {
-		if cmd.Name == req.Command {
-			handler = cmd.Handler
-			break
+		if cmd.Name == name {
+			return cmd, true
 		}
 	}
+	return Command{}, false
+}
 
-	if handler == nil {
-		return &v1.ExecuteResponse{
-			ExitCode: 1,
-			Error:    fmt.Sprintf("unknown command: %s", req.Command),
-		}, nil
+func unknownCommandResponse(command string) *v1.ExecuteResponse {
+	return &v1.ExecuteResponse{
+		ExitCode: 1,
+		Error:    fmt.Sprintf("unknown command: %s", command),
 	}
+}
 
-	// Convert v1 request to v2
-	v2Req := &ExecuteRequest{
+// toV2Request converts a v1 request to v2
+func toV2Request(req *v1.ExecuteRequest) *ExecuteRequest {
+	flags := make(map[string]interface{}, len(req.Flags))
+	for name, value := range req.Flags {
+		flags[name] = value
+	}
+	return &ExecuteRequest{
 		Command:    req.Command,
 		Args:       req.Args,
-		Flags:      make(map[string]interface{}),
+		Flags:      flags,
 		Env:        req.Env,
 		WorkingDir: req.WorkDir,
 	}
+}
 
-	// Execute via v2 handler
-	v2Resp, err := handler.Execute(ctx, v2Req)
+// toV1Response converts the result of a v2 handler to a v1 response
+func toV1Response(v2Resp *ExecuteResponse, err error) *v1.ExecuteResponse {
 	if err != nil {
 		return &v1.ExecuteResponse{
 			ExitCode: 1,
 			Error:    err.Error(),
-		}, nil
+		}
+	}
+	if v2Resp == nil {
+		v2Resp = &ExecuteResponse{}
 	}
 
-	// Convert v2 response to v1
 	// Safely convert exit code to int32 (exit codes are typically 0-255)
 	exitCode := v2Resp.ExitCode
 	if exitCode > 127 {
@@ -489,10 +712,11 @@ func (s *V2GRPCServer[C]) ExecuteCommand(ctx context.Context, req *v1.ExecuteReq
 	}
 
 	return &v1.ExecuteResponse{
+		Success:  exitCode == 0,
 		ExitCode: int32(exitCode), //nolint:gosec // exit codes are bounded above
 		Stdout:   []byte(v2Resp.Output),
 		Error:    v2Resp.Error,
-	}, nil
+	}
 }
 
 // GetCapabilities implements v1.GlidePluginServer.
@@ -526,3 +750,19 @@ func Serve[C any](plugin Plugin[C]) error {
 	server := NewV2GRPCServer(plugin)
 	return v1.RunPlugin(server)
 }
+
+// RegisterBuiltin compiles a v2 plugin into glide. Its commands are served
+// in-process instead of by a plugin binary, with no subprocess or gRPC in
+// between, and otherwise behave the same. Call it from an init function in
+// a file of cmd/glide behind a build tag:
+//
+//	//go:build docker
+//
+//	package main
+//
+//	func init() {
+//	    v2.RegisterBuiltin(docker.New())
+//	}
+func RegisterBuiltin[C any](plugin Plugin[C]) {
+	sdk.RegisterBuiltin(NewV2GRPCServer(plugin))
+}
@@ -1,14 +1,19 @@
 package v2
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
 )
 
 // MockV1InProcessPlugin simulates a v1 in-process plugin
@@ -249,6 +254,110 @@ func TestConvertV1Commands(t *testing.T) {
 	assert.True(t, commands[1].RequiresTTY)
 }
 
+func TestV2GRPCServer_HelpTopicsAndExamples(t *testing.T) {
+	plugin := &BasePlugin[struct{}]{}
+	plugin.SetMetadata(Metadata{
+		Name:    "deployer",
+		Version: "1.0.0",
+		HelpTopics: []HelpTopic{
+			{Name: "deployment", Summary: "Deploying with glide", Content: "Long guide", Aliases: []string{"deploy-guide"}},
+		},
+	})
+	plugin.AddCommand(Command{
+		Name:     "deploy",
+		Examples: []Example{{Description: "Deploy to staging", Command: "glide deploy --env staging"}},
+	})
+
+	server := NewV2GRPCServer[struct{}](plugin)
+
+	v1Meta, err := server.GetMetadata(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, v1Meta.HelpTopics, 1)
+	assert.Equal(t, "deployment", v1Meta.HelpTopics[0].Name)
+	assert.Equal(t, []string{"deploy-guide"}, v1Meta.HelpTopics[0].Aliases)
+
+	list, err := server.ListCommands(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, list.Commands, 1)
+	require.Len(t, list.Commands[0].Examples, 1)
+	assert.Equal(t, "glide deploy --env staging", list.Commands[0].Examples[0].Command)
+
+	// Round-trip back through the v1 conversion
+	meta := convertV1Metadata(v1Meta)
+	assert.Equal(t, plugin.Metadata().HelpTopics, meta.HelpTopics)
+	commands := convertV1Commands(nil, list.Commands)
+	assert.Equal(t, plugin.Commands()[0].Examples, commands[0].Examples)
+}
+
+// unreadyPlugin fails its health checks
+type unreadyPlugin struct {
+	BasePlugin[struct{}]
+}
+
+func (p *unreadyPlugin) HealthCheck(context.Context) error {
+	return errors.New("database unreachable")
+}
+
+func TestV2GRPCServer_CheckHealth(t *testing.T) {
+	var server v1.GlidePluginServer = NewV2GRPCServer[struct{}](&BasePlugin[struct{}]{})
+	checker, ok := server.(v1.HealthChecker)
+	require.True(t, ok, "health checks reach v2 plugins")
+	assert.NoError(t, checker.CheckHealth(context.Background()))
+
+	checker = NewV2GRPCServer[struct{}](&unreadyPlugin{})
+	assert.EqualError(t, checker.CheckHealth(context.Background()), "database unreachable")
+}
+
+// eventRecorder is a server stream that records the events sent on it
+type eventRecorder struct {
+	grpc.ServerStream
+	events []*v1.ExecuteEvent
+}
+
+func (r *eventRecorder) Context() context.Context { return context.Background() }
+
+func (r *eventRecorder) Send(event *v1.ExecuteEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestV2GRPCServer_ExecuteCommandStream(t *testing.T) {
+	build := StreamingCommandFunc(func(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error) {
+		_ = out.Progress("Building", 1, 2)
+		_, _ = out.Stdout().Write([]byte("built app\n"))
+		return &ExecuteResponse{ExitCode: 0, Output: "done\n"}, nil
+	})
+	plugin := &BasePlugin[struct{}]{}
+	plugin.AddCommand(Command{Name: "build", Handler: build})
+	plugin.AddCommand(Command{Name: "status", Handler: SimpleCommandHandler(func(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
+		return &ExecuteResponse{Output: "ok\n"}, nil
+	})})
+	server := NewV2GRPCServer[struct{}](plugin)
+
+	list, err := server.ListCommands(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, list.Commands, 2)
+	assert.True(t, list.Commands[0].Streaming)
+	assert.False(t, list.Commands[1].Streaming)
+
+	stream := &eventRecorder{}
+	require.NoError(t, server.ExecuteCommandStream(&v1.ExecuteRequest{Command: "build"}, stream))
+	require.Len(t, stream.events, 3)
+	assert.Equal(t, "Building", stream.events[0].GetProgress().GetMessage())
+	assert.Equal(t, []byte("built app\n"), stream.events[1].GetStdout())
+	assert.Equal(t, []byte("done\n"), stream.events[2].GetResult().GetStdout())
+
+	stream = &eventRecorder{}
+	require.NoError(t, server.ExecuteCommandStream(&v1.ExecuteRequest{Command: "status"}, stream))
+	require.Len(t, stream.events, 1)
+	assert.Equal(t, []byte("ok\n"), stream.events[0].GetResult().GetStdout())
+
+	// Without streaming, the output arrives with the result
+	resp, err := build.Execute(context.Background(), &ExecuteRequest{Command: "build"})
+	require.NoError(t, err)
+	assert.Equal(t, "built app\ndone\n", resp.Output)
+}
+
 func TestV1Adapter_StateTracking(t *testing.T) {
 	v1Plugin := &MockV1InProcessPlugin{
 		name: "v1-test",
@@ -309,3 +418,113 @@ func TestV1Adapter_NoLifecyclePlugin(t *testing.T) {
 	// State should still transition
 	assert.Equal(t, sdk.StateStopped, adapter.state.Get())
 }
+
+// serverClient calls a v1 server directly, as a v1 client would over gRPC
+type serverClient struct {
+	v1.GlidePluginClient
+	server v1.GlidePluginServer
+}
+
+func (c *serverClient) ListCommands(ctx context.Context, in *v1.Empty, _ ...grpc.CallOption) (*v1.CommandList, error) {
+	return c.server.ListCommands(ctx, in)
+}
+
+func (c *serverClient) ExecuteCommand(ctx context.Context, in *v1.ExecuteRequest, _ ...grpc.CallOption) (*v1.ExecuteResponse, error) {
+	return c.server.ExecuteCommand(ctx, in)
+}
+
+func (c *serverClient) Complete(ctx context.Context, in *v1.CompleteRequest, _ ...grpc.CallOption) (*v1.CompleteResponse, error) {
+	return c.server.Complete(ctx, in)
+}
+
+func (c *serverClient) ExecuteCommandStream(ctx context.Context, in *v1.ExecuteRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[v1.ExecuteEvent], error) {
+	recorded := &eventRecorder{}
+	if err := c.server.ExecuteCommandStream(in, recorded); err != nil {
+		return nil, err
+	}
+	return &eventReplay{events: recorded.events}, nil
+}
+
+// eventReplay is a client stream that replays recorded events
+type eventReplay struct {
+	grpc.ClientStream
+	events []*v1.ExecuteEvent
+}
+
+func (r *eventReplay) Recv() (*v1.ExecuteEvent, error) {
+	if len(r.events) == 0 {
+		return nil, io.EOF
+	}
+	event := r.events[0]
+	r.events = r.events[1:]
+	return event, nil
+}
+
+func TestAdaptV1Client(t *testing.T) {
+	plugin := &BasePlugin[struct{}]{}
+	plugin.AddCommand(Command{Name: "deploy", Flags: []Flag{{Name: "env", Type: "string"}}, Handler: SimpleCommandHandler(func(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
+		if req.Flags["env"] != "staging" {
+			return &ExecuteResponse{ExitCode: 2, Error: "unknown environment"}, nil
+		}
+		return &ExecuteResponse{Output: "deployed " + req.Args[0] + "\n"}, nil
+	})})
+	plugin.AddCommand(Command{Name: "build", Handler: StreamingCommandFunc(func(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error) {
+		_ = out.Progress("Building", 1, 2)
+		_, _ = out.Stdout().Write([]byte("built app\n"))
+		return &ExecuteResponse{}, nil
+	})})
+	plugin.AddCommand(Command{Name: "shell", Interactive: true})
+	client := &serverClient{server: NewV2GRPCServer[struct{}](plugin)}
+
+	adapted, err := AdaptV1Client(context.Background(), client, &v1.PluginMetadata{
+		Name:       "deployer",
+		HelpTopics: []*v1.HelpTopic{{Name: "deployment", Summary: "How deploys work"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "deployer", adapted.Metadata().Name)
+	assert.Equal(t, "How deploys work", adapted.Metadata().HelpTopics[0].Summary)
+
+	commands := adapted.Commands()
+	require.Len(t, commands, 3)
+
+	t.Run("runs commands through the v1 plugin", func(t *testing.T) {
+		resp, err := commands[0].Handler.Execute(context.Background(), &ExecuteRequest{
+			Args:  []string{"api"},
+			Flags: map[string]interface{}{"env": "staging"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, resp.ExitCode)
+		assert.Equal(t, "deployed api\n", resp.Output)
+
+		resp, err = commands[0].Handler.Execute(context.Background(), &ExecuteRequest{Flags: map[string]interface{}{"env": "prod"}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.ExitCode)
+		assert.Equal(t, "unknown environment", resp.Error)
+	})
+
+	t.Run("streams streaming commands", func(t *testing.T) {
+		streaming, ok := commands[1].Handler.(StreamingCommandHandler)
+		require.True(t, ok)
+
+		var stdout bytes.Buffer
+		resp, err := streaming.ExecuteStream(context.Background(), &ExecuteRequest{}, v1.NewBufferedStreamWriter(&stdout, io.Discard))
+		require.NoError(t, err)
+		assert.Equal(t, 0, resp.ExitCode)
+		assert.Equal(t, "built app\n", stdout.String())
+	})
+
+	t.Run("keeps interactive commands interactive", func(t *testing.T) {
+		assert.True(t, commands[2].Interactive)
+		assert.NotNil(t, commands[2].InteractiveHandler)
+		assert.Nil(t, commands[2].Handler)
+	})
+}
+
+func TestFromV1Response(t *testing.T) {
+	resp := fromV1Response(&v1.ExecuteResponse{Stdout: []byte("out"), Stderr: []byte("err"), Error: "failed"})
+	assert.Equal(t, 1, resp.ExitCode, "a failure without an exit code still fails")
+	assert.Equal(t, "out\nerr", resp.Output)
+
+	resp = fromV1Response(&v1.ExecuteResponse{Success: true})
+	assert.Equal(t, 0, resp.ExitCode)
+}
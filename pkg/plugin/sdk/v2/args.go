@@ -0,0 +1,231 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+)
+
+// Completion hints for ArgSpec.Completion
+const (
+	CompleteFile      = "file"
+	CompleteDirectory = "directory"
+	CompleteNone      = "none"
+)
+
+// CompleteFunc completes a command's next positional argument. args are
+// the arguments already given and toComplete what the user has typed of the
+// next one. A value may carry a description after a tab, e.g.
+// "web\tnginx:1.27".
+type CompleteFunc func(ctx context.Context, args []string, toComplete string) ([]string, error)
+
+// Usage renders the command's usage line from its arguments, e.g.
+// "deploy <env> [services...]"
+func (c Command) Usage() string {
+	parts := []string{c.Name}
+	for _, arg := range c.Args {
+		name := arg.Name
+		if arg.Variadic {
+			name += "..."
+		}
+		if arg.Required {
+			parts = append(parts, "<"+name+">")
+		} else {
+			parts = append(parts, "["+name+"]")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ValidateArgs checks positional arguments against the command's specs:
+// that there are as many as it takes, and that each value is one of the
+// choices, matches the pattern, and passes Validate. Commands without specs
+// take any arguments.
+func (c Command) ValidateArgs(args []string) error {
+	if len(c.Args) == 0 {
+		return nil
+	}
+
+	required := 0
+	for _, spec := range c.Args {
+		if spec.Required {
+			required++
+		}
+	}
+	if len(args) < required {
+		return c.argsError(fmt.Sprintf("%s requires <%s>", c.Name, c.Args[len(args)].Name))
+	}
+
+	last := c.Args[len(c.Args)-1]
+	if !last.Variadic && len(args) > len(c.Args) {
+		return c.argsError(fmt.Sprintf("%s takes at most %d argument(s), got %d", c.Name, len(c.Args), len(args)))
+	}
+
+	for i, value := range args {
+		spec, _ := c.argAt(i)
+		if err := spec.check(value); err != nil {
+			return c.argsError(err.Error())
+		}
+	}
+	return nil
+}
+
+// argAt returns the spec of the argument at position i
+func (c Command) argAt(i int) (ArgSpec, bool) {
+	if i < len(c.Args) {
+		return c.Args[i], true
+	}
+	if len(c.Args) > 0 && c.Args[len(c.Args)-1].Variadic {
+		return c.Args[len(c.Args)-1], true
+	}
+	return ArgSpec{}, false
+}
+
+// argsError reports arguments that do not fit the command
+func (c Command) argsError(message string) error {
+	return glideErrors.New(glideErrors.TypeInvalid, message,
+		glideErrors.WithSuggestions("Usage: "+c.Usage()))
+}
+
+// check validates a single value
+func (a ArgSpec) check(value string) error {
+	if len(a.Choices) > 0 && !slices.Contains(a.Choices, value) {
+		return fmt.Errorf("invalid %s %q: must be one of %s", a.Name, value, strings.Join(a.Choices, ", "))
+	}
+	if a.Pattern != "" {
+		re, err := regexp.Compile("^(?:" + a.Pattern + ")$")
+		if err != nil {
+			return fmt.Errorf("argument %s has an invalid pattern: %w", a.Name, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("invalid %s %q: must match %s", a.Name, value, a.Pattern)
+		}
+	}
+	if a.Validate != nil {
+		if err := a.Validate(value); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", a.Name, value, err)
+		}
+	}
+	return nil
+}
+
+// CompleteArgs completes the next positional argument from its spec
+func (c Command) CompleteArgs(args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	spec, ok := c.argAt(len(args))
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	if len(spec.Choices) > 0 {
+		var matches []string
+		for _, choice := range spec.Choices {
+			if strings.HasPrefix(choice, toComplete) {
+				matches = append(matches, choice)
+			}
+		}
+		return matches, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	switch spec.Completion {
+	case CompleteNone:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	case CompleteDirectory:
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	default:
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+}
+
+// Completions completes the next positional argument, asking Complete
+// first and falling back to the argument's spec
+func (c Command) Completions(ctx context.Context, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if c.Complete != nil {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		values, err := c.Complete(ctx, args, toComplete)
+		if err == nil && len(values) > 0 {
+			return values, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+	return c.CompleteArgs(args, toComplete)
+}
+
+// ApplyArgs sets up a Cobra command from the command's argument specs:
+// its usage line, validation and completion. Commands without specs or
+// Complete are left as they are.
+func (c Command) ApplyArgs(cmd *cobra.Command) {
+	if len(c.Args) > 0 {
+		cmd.Use = c.Usage()
+		cmd.Args = func(_ *cobra.Command, args []string) error {
+			return c.ValidateArgs(args)
+		}
+	}
+	if len(c.Args) > 0 || c.Complete != nil {
+		cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return c.Completions(cmd.Context(), args, toComplete)
+		}
+	}
+}
+
+// v1Completer completes a v1 command's arguments through the Complete RPC
+func v1Completer(client v1.GlidePluginClient, command string) CompleteFunc {
+	return func(ctx context.Context, args []string, toComplete string) ([]string, error) {
+		resp, err := client.Complete(ctx, &v1.CompleteRequest{
+			Command:    command,
+			Args:       args,
+			ToComplete: toComplete,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Values, nil
+	}
+}
+
+// toV1Args converts argument specs for the wire. Validate stays behind.
+func toV1Args(specs []ArgSpec) []*v1.ArgSpec {
+	if len(specs) == 0 {
+		return nil
+	}
+	args := make([]*v1.ArgSpec, len(specs))
+	for i, spec := range specs {
+		args[i] = &v1.ArgSpec{
+			Name:        spec.Name,
+			Description: spec.Description,
+			Required:    spec.Required,
+			Variadic:    spec.Variadic,
+			Completion:  spec.Completion,
+			Choices:     spec.Choices,
+			Pattern:     spec.Pattern,
+		}
+	}
+	return args
+}
+
+// fromV1Args converts argument specs from the wire
+func fromV1Args(v1Args []*v1.ArgSpec) []ArgSpec {
+	if len(v1Args) == 0 {
+		return nil
+	}
+	specs := make([]ArgSpec, len(v1Args))
+	for i, arg := range v1Args {
+		specs[i] = ArgSpec{
+			Name:        arg.Name,
+			Description: arg.Description,
+			Required:    arg.Required,
+			Variadic:    arg.Variadic,
+			Completion:  arg.Completion,
+			Choices:     arg.Choices,
+			Pattern:     arg.Pattern,
+		}
+	}
+	return specs
+}
@@ -0,0 +1,191 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	glideErrors "github.com/glide-cli/glide/v3/pkg/errors"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
+)
+
+func deployCommand() Command {
+	return Command{
+		Name: "deploy",
+		Args: []ArgSpec{
+			{Name: "env", Required: true, Choices: []string{"staging", "production"}},
+			{Name: "services", Variadic: true, Pattern: `[a-z][a-z0-9-]*`, Validate: func(value string) error {
+				if strings.HasSuffix(value, "-") {
+					return errors.New("must not end with a hyphen")
+				}
+				return nil
+			}},
+		},
+	}
+}
+
+func TestCommand_Usage(t *testing.T) {
+	assert.Equal(t, "deploy <env> [services...]", deployCommand().Usage())
+	assert.Equal(t, "status", Command{Name: "status"}.Usage())
+}
+
+func TestCommand_ValidateArgs(t *testing.T) {
+	cmd := deployCommand()
+
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{name: "required only", args: []string{"staging"}},
+		{name: "variadic", args: []string{"production", "api", "web"}},
+		{name: "missing required", args: nil, err: "deploy requires <env>"},
+		{name: "not a choice", args: []string{"qa"}, err: `invalid env "qa": must be one of staging, production`},
+		{name: "pattern", args: []string{"staging", "API"}, err: `invalid services "API": must match [a-z][a-z0-9-]*`},
+		{name: "validator", args: []string{"staging", "api-"}, err: `invalid services "api-": must not end with a hyphen`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := cmd.ValidateArgs(tt.args)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.err, err.Error())
+			assert.True(t, glideErrors.Is(err, glideErrors.TypeInvalid))
+		})
+	}
+
+	t.Run("too many", func(t *testing.T) {
+		cmd := Command{Name: "logs", Args: []ArgSpec{{Name: "service"}}}
+		assert.EqualError(t, cmd.ValidateArgs([]string{"api", "web"}), "logs takes at most 1 argument(s), got 2")
+	})
+
+	t.Run("commands without specs take anything", func(t *testing.T) {
+		assert.NoError(t, Command{Name: "run"}.ValidateArgs([]string{"a", "b", "c"}))
+	})
+}
+
+func TestCommand_CompleteArgs(t *testing.T) {
+	cmd := deployCommand()
+
+	completions, directive := cmd.CompleteArgs(nil, "st")
+	assert.Equal(t, []string{"staging"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	_, directive = cmd.CompleteArgs([]string{"staging", "api"}, "")
+	assert.Equal(t, cobra.ShellCompDirectiveDefault, directive)
+
+	cmd = Command{Name: "cd", Args: []ArgSpec{{Name: "dir", Completion: CompleteDirectory}}}
+	_, directive = cmd.CompleteArgs(nil, "")
+	assert.Equal(t, cobra.ShellCompDirectiveFilterDirs, directive)
+	_, directive = cmd.CompleteArgs([]string{"src"}, "")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive, "no arguments are left to complete")
+}
+
+func TestCommand_ApplyArgs(t *testing.T) {
+	cobraCmd := &cobra.Command{Use: "deploy"}
+	deployCommand().ApplyArgs(cobraCmd)
+
+	assert.Equal(t, "deploy <env> [services...]", cobraCmd.Use)
+	assert.NoError(t, cobraCmd.Args(cobraCmd, []string{"staging"}))
+	assert.Error(t, cobraCmd.Args(cobraCmd, nil))
+	assert.NotNil(t, cobraCmd.ValidArgsFunction)
+}
+
+func TestV2GRPCServer_ArgSpecs(t *testing.T) {
+	cmd := deployCommand()
+	called := false
+	cmd.Handler = SimpleCommandHandler(func(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
+		called = true
+		return &ExecuteResponse{}, nil
+	})
+	plugin := &BasePlugin[struct{}]{}
+	plugin.AddCommand(cmd)
+	server := NewV2GRPCServer[struct{}](plugin)
+
+	// Specs travel to the host without the validator
+	list, err := server.ListCommands(context.Background(), nil)
+	require.NoError(t, err)
+	specs := convertV1Commands(nil, list.Commands)[0].Args
+	require.Len(t, specs, 2)
+	assert.Equal(t, []string{"staging", "production"}, specs[0].Choices)
+	assert.Equal(t, `[a-z][a-z0-9-]*`, specs[1].Pattern)
+	assert.Nil(t, specs[1].Validate)
+
+	// The plugin still runs the validator before the handler
+	resp, err := server.ExecuteCommand(context.Background(), &v1.ExecuteRequest{Command: "deploy", Args: []string{"staging", "api-"}})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, `invalid services "api-": must not end with a hyphen`, resp.Error)
+	assert.False(t, called)
+
+	resp, err = server.ExecuteCommand(context.Background(), &v1.ExecuteRequest{Command: "deploy", Args: []string{"staging", "api"}})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.True(t, called)
+}
+
+func TestCommand_Completions(t *testing.T) {
+	cmd := deployCommand()
+	cmd.Complete = func(ctx context.Context, args []string, toComplete string) ([]string, error) {
+		if len(args) == 0 {
+			return nil, nil
+		}
+		if toComplete == "w" {
+			return nil, errors.New("docker is not running")
+		}
+		return []string{"api\tphp:8.3", "web\tnginx:1.27"}, nil
+	}
+
+	completions, directive := cmd.Completions(context.Background(), []string{"staging"}, "")
+	assert.Equal(t, []string{"api\tphp:8.3", "web\tnginx:1.27"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	// Without values from Complete, the argument's spec completes it
+	completions, _ = cmd.Completions(context.Background(), nil, "prod")
+	assert.Equal(t, []string{"production"}, completions)
+	_, directive = cmd.Completions(context.Background(), []string{"staging"}, "w")
+	assert.Equal(t, cobra.ShellCompDirectiveDefault, directive)
+
+	cobraCmd := &cobra.Command{Use: "logs"}
+	Command{Name: "logs", Complete: cmd.Complete}.ApplyArgs(cobraCmd)
+	assert.Equal(t, "logs", cobraCmd.Use, "commands without specs keep their usage")
+	completions, _ = cobraCmd.ValidArgsFunction(cobraCmd, []string{"staging"}, "")
+	assert.Len(t, completions, 2)
+}
+
+func TestV2GRPCServer_Complete(t *testing.T) {
+	cmd := deployCommand()
+	cmd.Handler = SimpleCommandHandler(func(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
+		return &ExecuteResponse{}, nil
+	})
+	cmd.Complete = func(ctx context.Context, args []string, toComplete string) ([]string, error) {
+		return []string{toComplete + "-api", toComplete + "-web"}, nil
+	}
+	plugin := &BasePlugin[struct{}]{}
+	plugin.AddCommand(cmd)
+	plugin.AddCommand(Command{Name: "status", Handler: cmd.Handler})
+	client := &serverClient{server: NewV2GRPCServer[struct{}](plugin)}
+
+	adapted, err := AdaptV1Client(context.Background(), client, &v1.PluginMetadata{Name: "deployer"})
+	require.NoError(t, err)
+	commands := adapted.Commands()
+	require.Len(t, commands, 2)
+	require.NotNil(t, commands[0].Complete)
+	assert.Nil(t, commands[1].Complete, "commands without Complete are not asked")
+
+	values, err := commands[0].Complete(context.Background(), []string{"staging"}, "eu")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eu-api", "eu-web"}, values)
+
+	resp, err := client.Complete(context.Background(), &v1.CompleteRequest{Command: "status"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Values)
+}
@@ -13,9 +13,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
+	v1 "github.com/glide-cli/glide/v3/pkg/plugin/sdk/v1"
 )
 
 // Plugin is the core v2 plugin interface that all plugins must implement.
@@ -93,6 +97,24 @@ type Metadata struct {
 
 	// Capabilities declares what system resources the plugin needs.
 	Capabilities Capabilities
+
+	// HelpTopics are long-form guides merged into `glide help <topic>`.
+	HelpTopics []HelpTopic
+}
+
+// HelpTopic is a long-form help page shipped with a plugin.
+type HelpTopic struct {
+	// Name is the topic users pass to `glide help` (e.g., "deployment").
+	Name string
+
+	// Summary is a one-line description shown in topic listings.
+	Summary string
+
+	// Content is the full topic text.
+	Content string
+
+	// Aliases are alternative topic names.
+	Aliases []string
 }
 
 // Dependency represents a dependency on another plugin.
@@ -179,11 +201,26 @@ type Command struct {
 	// Interactive indicates this command requires an interactive terminal.
 	Interactive bool
 
+	// Destructive marks commands that delete data. The host asks the user
+	// for confirmation (or --force) and records the run in the audit log.
+	Destructive bool
+
 	// Flags defines command-line flags for this command.
 	Flags []Flag
 
-	// Args defines positional arguments for this command.
-	Args []Arg
+	// Args defines positional arguments for this command. When set, the
+	// host validates them before the handler runs, builds the usage line
+	// from them, and completes them in the shell.
+	Args []ArgSpec
+
+	// Complete supplies completions for positional arguments that only the
+	// plugin knows, such as container or migration names. The host calls it
+	// while the user presses Tab, under a short deadline, and falls back to
+	// Args when it fails or returns nothing.
+	Complete CompleteFunc
+
+	// Examples are shown in the command's --help output.
+	Examples []Example
 
 	// Handler is called when the command is executed.
 	// For in-process plugins, this is a direct function call.
@@ -229,19 +266,63 @@ type Flag struct {
 	Deprecated string
 }
 
-// Arg represents a positional command argument.
-type Arg struct {
+// ArgSpec describes a positional command argument.
+type ArgSpec struct {
 	// Name is the argument name shown in usage text.
 	Name string
 
 	// Description is shown in help text.
 	Description string
 
-	// Required indicates this argument must be provided.
+	// Required indicates this argument must be provided. Required
+	// arguments come before optional ones.
 	Required bool
 
-	// Variadic indicates this argument accepts multiple values.
+	// Variadic indicates this argument accepts multiple values. Only the
+	// last argument may be variadic.
 	Variadic bool
+
+	// Completion hints how the shell completes the argument:
+	// CompleteFile (the default), CompleteDirectory or CompleteNone.
+	Completion string
+
+	// Choices lists the values the argument accepts. They are also offered
+	// as completions.
+	Choices []string
+
+	// Pattern is a regular expression every value must match in full.
+	Pattern string
+
+	// Validate checks a value beyond Choices and Pattern. It runs in the
+	// plugin, before the handler, so it is not sent to the host.
+	Validate func(value string) error
+}
+
+// Arg represents a positional command argument.
+//
+// Deprecated: Use ArgSpec.
+type Arg = ArgSpec
+
+// Example is a usage example for a command.
+type Example struct {
+	// Description explains what the example does.
+	Description string
+
+	// Command is the full command line (e.g., "glide deploy --env staging").
+	Command string
+}
+
+// FormatExamples renders examples in the indented style Cobra uses for the
+// Examples section of --help output.
+func FormatExamples(examples []Example) string {
+	lines := make([]string, 0, len(examples)*2)
+	for _, ex := range examples {
+		if ex.Description != "" {
+			lines = append(lines, "  # "+ex.Description)
+		}
+		lines = append(lines, "  "+ex.Command)
+	}
+	return strings.Join(lines, "\n")
 }
 
 // CommandHandler handles non-interactive command execution.
@@ -435,6 +516,61 @@ func (h SimpleCommandHandler) Execute(ctx context.Context, req *ExecuteRequest)
 	return h(ctx, req)
 }
 
+// StreamWriter sends a streaming command's output and progress to the host.
+// Write to Stdout and Stderr as the command runs and call Progress with a
+// message and, when the amount of work is known, current and total.
+type StreamWriter = v1.StreamWriter
+
+// StreamingCommandHandler is implemented by handlers of long-running
+// commands, such as builds and deploys, that show their output and progress
+// while they run instead of only when they finish. Execute is used where
+// the output cannot be streamed.
+type StreamingCommandHandler interface {
+	CommandHandler
+
+	// ExecuteStream runs the command, writing its output to out as it goes.
+	ExecuteStream(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error)
+}
+
+// StreamingCommandFunc creates a StreamingCommandHandler from a function.
+//
+// Example:
+//
+//	cmd := v2.Command{
+//	    Name: "build",
+//	    Description: "Build the images",
+//	    Handler: v2.StreamingCommandFunc(func(ctx context.Context, req *v2.ExecuteRequest, out *v2.StreamWriter) (*v2.ExecuteResponse, error) {
+//	        for i, image := range images {
+//	            _ = out.Progress("Building "+image, int64(i), int64(len(images)))
+//	            if err := build(ctx, image, out.Stdout()); err != nil {
+//	                return nil, err
+//	            }
+//	        }
+//	        return &v2.ExecuteResponse{ExitCode: 0}, nil
+//	    }),
+//	}
+type StreamingCommandFunc func(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error)
+
+// ExecuteStream implements StreamingCommandHandler.
+func (h StreamingCommandFunc) ExecuteStream(ctx context.Context, req *ExecuteRequest, out *StreamWriter) (*ExecuteResponse, error) {
+	return h(ctx, req, out)
+}
+
+// Execute implements CommandHandler, returning the streamed output once the
+// command has finished. Progress is dropped.
+func (h StreamingCommandFunc) Execute(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
+	var output strings.Builder
+	resp, err := h(ctx, req, v1.NewBufferedStreamWriter(&output, &output))
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = &ExecuteResponse{}
+	}
+	resp.Output = output.String() + resp.Output
+	return resp, nil
+}
+
 // CobraAdapter adapts a v2 Plugin to work with Cobra commands.
 // This is used internally by the CLI to bridge v2 plugins to the existing Cobra infrastructure.
 type CobraAdapter[C any] struct {
@@ -456,11 +592,19 @@ func (a *CobraAdapter[C]) BuildCommands() []*cobra.Command {
 			Short:   cmd.Description,
 			Aliases: cmd.Aliases,
 			Hidden:  cmd.Hidden,
+			Example: FormatExamples(cmd.Examples),
 			RunE: func(cobraCmd *cobra.Command, args []string) error {
 				return a.executeCommand(cobraCmd.Context(), cmd, args, cobraCmd)
 			},
 		}
 
+		if cmd.Destructive {
+			cobraCmd.Annotations = map[string]string{"destructive": "true"}
+		}
+
+		// Usage, validation and completion of positional arguments
+		cmd.ApplyArgs(cobraCmd)
+
 		// Add flags
 		for _, flag := range cmd.Flags {
 			a.addFlag(cobraCmd, flag)
@@ -472,7 +616,10 @@ func (a *CobraAdapter[C]) BuildCommands() []*cobra.Command {
 	return commands
 }
 
-func (a *CobraAdapter[C]) executeCommand(ctx context.Context, cmd Command, args []string, cobraCmd *cobra.Command) error {
+func (a *CobraAdapter[C]) executeCommand(ctx context.Context, cmd Command, args []string, cobraCmd *cobra.Command) (err error) {
+	// A panicking handler fails the command instead of the CLI
+	defer sdk.RecoverPanic(a.plugin.Metadata().Name, cmd.Name, &err)
+
 	// Get working directory
 	workingDir, err := os.Getwd()
 	if err != nil {
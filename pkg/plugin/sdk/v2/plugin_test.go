@@ -322,6 +322,51 @@ func TestCobraAdapter_BuildCommands(t *testing.T) {
 	assert.NotNil(t, cobraCmd.RunE)
 }
 
+func TestCobraAdapter_BuildCommandsWithExamples(t *testing.T) {
+	plugin := &BasePlugin[TestConfig]{}
+	plugin.AddCommand(Command{
+		Name:        "deploy",
+		Description: "Deploy the app",
+		Examples: []Example{
+			{Description: "Deploy to staging", Command: "glide deploy --env staging"},
+			{Command: "glide deploy"},
+		},
+	})
+
+	commands := NewCobraAdapter[TestConfig](plugin).BuildCommands()
+	require.Len(t, commands, 1)
+	assert.Equal(t, "  # Deploy to staging\n  glide deploy --env staging\n  glide deploy", commands[0].Example)
+}
+
+func TestCobraAdapter_BuildCommandsDestructive(t *testing.T) {
+	plugin := &BasePlugin[TestConfig]{}
+	plugin.AddCommand(Command{Name: "reset", Description: "Reset data", Destructive: true})
+	plugin.AddCommand(Command{Name: "status", Description: "Show status"})
+
+	commands := NewCobraAdapter[TestConfig](plugin).BuildCommands()
+	require.Len(t, commands, 2)
+	assert.Equal(t, "true", commands[0].Annotations["destructive"])
+	assert.Empty(t, commands[1].Annotations["destructive"])
+}
+
+func TestCobraAdapter_RecoversPanics(t *testing.T) {
+	plugin := &BasePlugin[TestConfig]{}
+	plugin.SetMetadata(Metadata{Name: "flaky"})
+	plugin.AddCommand(Command{
+		Name: "boom",
+		Handler: SimpleCommandHandler(func(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
+			panic("nil map write")
+		}),
+	})
+
+	commands := NewCobraAdapter[TestConfig](plugin).BuildCommands()
+	require.Len(t, commands, 1)
+	commands[0].SetArgs([]string{})
+	err := commands[0].Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "plugin 'flaky': boom panicked: nil map write")
+}
+
 // TestPluginWithCustomSchema tests a plugin with custom config schema
 func TestPluginWithCustomSchema(t *testing.T) {
 	type CustomConfig struct {
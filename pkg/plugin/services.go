@@ -0,0 +1,60 @@
+package plugin
+
+import "context"
+
+// Service is a long-lived background task a plugin runs alongside glide,
+// such as a file watcher or a cache warmer.
+//
+// Start must return once the service is running; work that continues
+// belongs in a goroutine that Stop ends. Stop must return once that work
+// has finished, or when ctx is done.
+//
+// Example:
+//
+//	type watcher struct{ cancel context.CancelFunc }
+//
+//	func (w *watcher) Name() string { return "file-watcher" }
+//
+//	func (w *watcher) Start(ctx context.Context) error {
+//	    runCtx, cancel := context.WithCancel(context.Background())
+//	    w.cancel = cancel
+//	    go w.watch(runCtx)
+//	    return nil
+//	}
+//
+//	func (w *watcher) Stop(ctx context.Context) error {
+//	    w.cancel()
+//	    return nil
+//	}
+type Service interface {
+	// Name identifies the service in logs and errors
+	Name() string
+
+	// Start starts the service
+	Start(ctx context.Context) error
+
+	// Stop stops the service
+	Stop(ctx context.Context) error
+}
+
+// ServiceHost is the host services API through which plugins hand their
+// services to glide, which starts them with the application and stops
+// them, in reverse order, when it shuts down.
+type ServiceHost interface {
+	// RunService has glide manage the service's startup and shutdown
+	RunService(svc Service)
+}
+
+// PluginServiceProvider is implemented by plugins that run background
+// services. RegisterServices is called once, when the application is
+// created, before any service starts.
+//
+// Example:
+//
+//	func (p *MyPlugin) RegisterServices(host ServiceHost) error {
+//	    host.RunService(&watcher{})
+//	    return nil
+//	}
+type PluginServiceProvider interface {
+	RegisterServices(host ServiceHost) error
+}
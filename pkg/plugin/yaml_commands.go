@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/glide-cli/glide/v3/internal/chaos"
 	"github.com/glide-cli/glide/v3/internal/config"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -43,7 +44,7 @@ func LoadPluginYAMLCommands(pluginPath string) (map[string]*config.Command, erro
 	}
 
 	// Read the commands file
-	data, err := os.ReadFile(commandsPath)
+	data, err := chaos.ReadConfig(commandsPath)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,62 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// AnnounceInterval is how often spinners and bars in accessible mode
+// repeat what they are doing
+var AnnounceInterval = 10 * time.Second
+
+// accessible is set by SetAccessible
+var accessible bool
+
+// SetAccessible makes new spinners and bars announce their progress as
+// lines of text every AnnounceInterval instead of animating, and mark
+// their final messages with words such as "SUCCESS:" rather than symbols,
+// for screen readers
+func SetAccessible(on bool) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	accessible = on
+}
+
+// accessibleMode returns whether SetAccessible turned accessible mode on
+func accessibleMode() bool {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return accessible
+}
+
+// mark returns the symbol a final message starts with, or the word read
+// out in its place in accessible mode
+func (o *Options) mark(symbol, label string) string {
+	if o.Accessible {
+		return label
+	}
+	return symbol
+}
+
+// announce writes the line returned by next every AnnounceInterval until
+// the returned channel is closed. Empty lines are skipped.
+func announce(w io.Writer, next func() string) chan struct{} {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(AnnounceInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if line := next(); line != "" {
+					// Safe to ignore: Progress announcements are informational only
+					_, _ = fmt.Fprintln(w, line)
+				}
+			}
+		}
+	}()
+	return stop
+}
@@ -22,6 +22,9 @@ type Bar struct {
 	startTime  time.Time
 	lastUpdate time.Time
 	lastLine   string
+	suspended  bool
+	// announcing stops the periodic announcements of accessible mode
+	announcing chan struct{}
 
 	// For throughput calculation
 	startValue int
@@ -66,7 +69,7 @@ func (b *Bar) Start() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if b.active || b.options.Quiet || !b.options.IsTTY {
+	if b.active || b.options.Quiet || !(b.options.IsTTY || b.options.Accessible) {
 		return
 	}
 
@@ -81,7 +84,34 @@ func (b *Bar) Start() {
 		value: b.current,
 	})
 
+	if b.options.Accessible {
+		// Safe to ignore: Progress announcements are informational only
+		_, _ = fmt.Fprintf(b.options.Writer, "%s... (%d of %d)\n", b.message, b.current, b.total)
+		b.announcing = announce(b.options.Writer, b.announcement)
+		return
+	}
 	b.render()
+	register(b)
+}
+
+// announcement returns the line accessible mode repeats while the bar runs
+func (b *Bar) announcement() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.active {
+		return ""
+	}
+	return b.progressLine()
+}
+
+// progressLine reads out how far the bar is, e.g. "Copying: 40% (4 of 10)"
+func (b *Bar) progressLine() string {
+	percentage := 0.0
+	if b.total > 0 {
+		percentage = float64(b.current) / float64(b.total) * 100
+	}
+	return fmt.Sprintf("%s: %.0f%% (%d of %d)", b.message, percentage, b.current, b.total)
 }
 
 // Update updates the progress bar's current value
@@ -148,6 +178,16 @@ func (b *Bar) Finish() {
 	b.current = b.total
 	b.render()
 	b.active = false
+	unregister(b)
+
+	if b.options.Accessible {
+		close(b.announcing)
+		if !b.options.Quiet {
+			// Safe to ignore: Progress announcements are informational only
+			_, _ = fmt.Fprintln(b.options.Writer, b.progressLine())
+		}
+		return
+	}
 
 	if b.options.IsTTY && !b.options.Quiet {
 		// Safe to ignore: Newline after progress bar completion (cosmetic only)
@@ -163,13 +203,13 @@ func (b *Bar) Success(message string) {
 		if b.options.ShowElapsedTime && duration != "" {
 			// Safe to ignore: Success message formatting (informational only)
 			_, _ = fmt.Fprintf(b.options.Writer, "%s %s %s\n",
-				color.GreenString("✓"),
+				color.GreenString(b.options.mark("✓", "SUCCESS:")),
 				message,
 				color.HiBlackString(duration))
 		} else {
 			// Safe to ignore: Success message formatting (informational only)
 			_, _ = fmt.Fprintf(b.options.Writer, "%s %s\n",
-				color.GreenString("✓"),
+				color.GreenString(b.options.mark("✓", "SUCCESS:")),
 				message)
 		}
 	}
@@ -181,7 +221,7 @@ func (b *Bar) Error(message string) {
 	if !b.options.Quiet {
 		// Safe to ignore: Error message formatting (informational only)
 		_, _ = fmt.Fprintf(b.options.Writer, "%s %s\n",
-			color.RedString("✗"),
+			color.RedString(b.options.mark("✗", "ERROR:")),
 			message)
 	}
 }
@@ -192,7 +232,7 @@ func (b *Bar) Warning(message string) {
 	if !b.options.Quiet {
 		// Safe to ignore: Warning message formatting (informational only)
 		_, _ = fmt.Fprintf(b.options.Writer, "%s %s\n",
-			color.YellowString("⚠"),
+			color.YellowString(b.options.mark("⚠", "WARNING:")),
 			message)
 	}
 }
@@ -207,6 +247,11 @@ func (b *Bar) Stop() {
 	}
 
 	b.active = false
+	unregister(b)
+	if b.options.Accessible {
+		close(b.announcing)
+		return
+	}
 	if b.options.IsTTY && !b.options.Quiet {
 		b.clearLine()
 		// Safe to ignore: Newline after stopping progress bar (cosmetic only)
@@ -214,9 +259,33 @@ func (b *Bar) Stop() {
 	}
 }
 
+// suspend erases the bar until resume, see Interrupt
+func (b *Bar) suspend() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.active {
+		return
+	}
+	b.clearLine()
+	b.lastLine = ""
+	b.suspended = true
+}
+
+// resume redraws the bar after suspend
+func (b *Bar) resume() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.suspended = false
+	if b.active {
+		b.render()
+	}
+}
+
 // render draws the progress bar
 func (b *Bar) render() {
-	if b.options.Quiet || !b.options.IsTTY {
+	if b.options.Quiet || !b.options.IsTTY || b.suspended {
 		return
 	}
 
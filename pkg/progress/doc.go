@@ -47,9 +47,25 @@
 //	}
 //	bar.Finish()
 //
+// # Printing Around Indicators
+//
+// Output written to the terminal while an indicator is drawn corrupts it.
+// Interrupt erases the active indicators, runs a write and redraws them
+// below it; Writer wraps an io.Writer the same way:
+//
+//	progress.Interrupt(func() { fmt.Fprintln(os.Stderr, "step done") })
+//	log.SetOutput(progress.Writer(os.Stderr))
+//
 // # Non-TTY Handling
 //
 // Progress indicators gracefully degrade in non-TTY environments:
 //   - Spinners show start/end messages only
 //   - Progress bars show percentage updates
+//
+// # Accessible Mode
+//
+// After SetAccessible(true), new spinners and bars do not animate. They
+// print their message when started, repeat it with the elapsed time or
+// percentage every AnnounceInterval, and mark their final message with
+// SUCCESS:, WARNING: or ERROR:, which screen readers can follow.
 package progress
@@ -0,0 +1,88 @@
+package progress
+
+import (
+	"io"
+	"sync"
+)
+
+// liveRegion is an indicator that redraws itself in place and must be
+// erased before other output can be printed
+type liveRegion interface {
+	// suspend erases the region and pauses redrawing
+	suspend()
+	// resume redraws the region at the cursor and continues redrawing
+	resume()
+}
+
+var (
+	// interruptMu serializes output printed above the live regions
+	interruptMu sync.Mutex
+
+	// regionsMu guards regions. It is never held while a region's own lock
+	// is taken.
+	regionsMu sync.Mutex
+	regions   []liveRegion
+)
+
+// register marks r as drawn on the terminal
+func register(r liveRegion) {
+	regionsMu.Lock()
+	defer regionsMu.Unlock()
+	regions = append(regions, r)
+}
+
+// unregister marks r as no longer drawn
+func unregister(r liveRegion) {
+	regionsMu.Lock()
+	defer regionsMu.Unlock()
+	for i, region := range regions {
+		if region == r {
+			regions = append(regions[:i], regions[i+1:]...)
+			return
+		}
+	}
+}
+
+// activeRegions returns a snapshot of the drawn regions
+func activeRegions() []liveRegion {
+	regionsMu.Lock()
+	defer regionsMu.Unlock()
+	return append([]liveRegion(nil), regions...)
+}
+
+// Interrupt erases every active spinner, bar and multi-progress display,
+// runs write and redraws them below whatever write printed. Output that
+// goes to the same terminal as a live indicator, such as log lines, should
+// be printed from write so it does not corrupt the indicator.
+func Interrupt(write func()) {
+	interruptMu.Lock()
+	defer interruptMu.Unlock()
+
+	active := activeRegions()
+	for _, r := range active {
+		r.suspend()
+	}
+	write()
+	for _, r := range active {
+		r.resume()
+	}
+}
+
+// Writer returns a writer whose writes are printed above any active
+// indicators. Each Write should hold complete lines.
+func Writer(w io.Writer) io.Writer {
+	return &interruptWriter{w: w}
+}
+
+// interruptWriter writes through Interrupt
+type interruptWriter struct {
+	w io.Writer
+}
+
+// Write implements io.Writer
+func (iw *interruptWriter) Write(p []byte) (n int, err error) {
+	Interrupt(func() {
+		n, err = iw.w.Write(p)
+	})
+	return n, err
+}
@@ -21,8 +21,9 @@ type Multi struct {
 	writer   io.Writer
 
 	// Terminal management
-	lines    int
-	lastDraw time.Time
+	lines     int
+	lastDraw  time.Time
+	suspended bool
 }
 
 type multiItem struct {
@@ -123,6 +124,8 @@ func (m *Multi) Start() {
 	m.render()
 	m.mu.Unlock()
 
+	register(m)
+
 	// Start render loop
 	go m.renderLoop()
 }
@@ -138,6 +141,7 @@ func (m *Multi) Stop() {
 
 	m.active = false
 	close(m.stopChan)
+	unregister(m)
 
 	// Stop all items
 	for _, item := range m.items {
@@ -173,9 +177,33 @@ func (m *Multi) renderLoop() {
 	}
 }
 
+// suspend erases every line of the display until resume, see Interrupt
+func (m *Multi) suspend() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.active {
+		return
+	}
+	m.clearAll()
+	m.lines = 0
+	m.suspended = true
+}
+
+// resume redraws the display below the cursor after suspend
+func (m *Multi) resume() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.suspended = false
+	if m.active {
+		m.render()
+	}
+}
+
 // render updates the multi-progress display
 func (m *Multi) render() {
-	if m.options.Quiet || !m.options.IsTTY {
+	if m.options.Quiet || !m.options.IsTTY || m.suspended {
 		return
 	}
 
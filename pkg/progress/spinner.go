@@ -47,6 +47,7 @@ type Spinner struct {
 	stopChan  chan struct{}
 	frame     int
 	lastLine  string
+	suspended bool
 }
 
 // NewSpinner creates a new spinner with default style
@@ -79,17 +80,24 @@ func NewSpinnerWithOptions(message string, opts *Options) *Spinner {
 // Start begins the spinner animation
 func (s *Spinner) Start() {
 	s.mu.Lock()
-	if s.active || s.options.Quiet || !s.options.IsTTY {
+	if s.active || s.options.Quiet || !(s.options.IsTTY || s.options.Accessible) {
 		s.mu.Unlock()
 		return
 	}
 
 	s.active = true
 	s.startTime = time.Now()
-	s.stopChan = make(chan struct{})
 	s.frame = 0
+	if s.options.Accessible {
+		fmt.Fprintf(s.options.Writer, "%s...\n", s.message)
+		s.stopChan = announce(s.options.Writer, s.announcement)
+		s.mu.Unlock()
+		return
+	}
+	s.stopChan = make(chan struct{})
 	s.mu.Unlock()
 
+	register(s)
 	go s.animate()
 }
 
@@ -104,9 +112,10 @@ func (s *Spinner) Stop() {
 
 	s.active = false
 	close(s.stopChan)
+	unregister(s)
 
 	// Clear the line
-	if s.options.IsTTY && !s.options.Quiet {
+	if s.options.IsTTY && !s.options.Quiet && !s.options.Accessible {
 		s.clearLine()
 	}
 }
@@ -118,12 +127,12 @@ func (s *Spinner) Success(message string) {
 		duration := s.getElapsedTime()
 		if s.options.ShowElapsedTime && duration != "" {
 			fmt.Fprintf(s.options.Writer, "%s %s %s\n",
-				color.GreenString("✓"),
+				color.GreenString(s.options.mark("✓", "SUCCESS:")),
 				message,
 				color.HiBlackString(duration))
 		} else {
 			fmt.Fprintf(s.options.Writer, "%s %s\n",
-				color.GreenString("✓"),
+				color.GreenString(s.options.mark("✓", "SUCCESS:")),
 				message)
 		}
 	}
@@ -136,12 +145,12 @@ func (s *Spinner) Error(message string) {
 		duration := s.getElapsedTime()
 		if s.options.ShowElapsedTime && duration != "" {
 			fmt.Fprintf(s.options.Writer, "%s %s %s\n",
-				color.RedString("✗"),
+				color.RedString(s.options.mark("✗", "ERROR:")),
 				message,
 				color.HiBlackString(duration))
 		} else {
 			fmt.Fprintf(s.options.Writer, "%s %s\n",
-				color.RedString("✗"),
+				color.RedString(s.options.mark("✗", "ERROR:")),
 				message)
 		}
 	}
@@ -154,12 +163,12 @@ func (s *Spinner) Warning(message string) {
 		duration := s.getElapsedTime()
 		if s.options.ShowElapsedTime && duration != "" {
 			fmt.Fprintf(s.options.Writer, "%s %s %s\n",
-				color.YellowString("⚠"),
+				color.YellowString(s.options.mark("⚠", "WARNING:")),
 				message,
 				color.HiBlackString(duration))
 		} else {
 			fmt.Fprintf(s.options.Writer, "%s %s\n",
-				color.YellowString("⚠"),
+				color.YellowString(s.options.mark("⚠", "WARNING:")),
 				message)
 		}
 	}
@@ -172,6 +181,18 @@ func (s *Spinner) Update(message string) {
 	s.mu.Unlock()
 }
 
+// announcement returns the line accessible mode repeats while the spinner
+// runs
+func (s *Spinner) announcement() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.active {
+		return ""
+	}
+	return fmt.Sprintf("Still working: %s (%s)", s.message, formatDuration(time.Since(s.startTime)))
+}
+
 // animate runs the spinner animation
 func (s *Spinner) animate() {
 	ticker := time.NewTicker(time.Second / time.Duration(s.style.FPS))
@@ -192,9 +213,33 @@ func (s *Spinner) animate() {
 	}
 }
 
+// suspend erases the spinner until resume, see Interrupt
+func (s *Spinner) suspend() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.active {
+		return
+	}
+	s.clearLine()
+	s.lastLine = ""
+	s.suspended = true
+}
+
+// resume redraws the spinner after suspend
+func (s *Spinner) resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.suspended = false
+	if s.active {
+		s.render()
+	}
+}
+
 // render draws the current spinner frame
 func (s *Spinner) render() {
-	if s.options.Quiet || !s.options.IsTTY {
+	if s.options.Quiet || !s.options.IsTTY || s.suspended {
 		return
 	}
 
@@ -24,6 +24,9 @@ type Options struct {
 	IsTTY bool
 	// Whether quiet mode is enabled
 	Quiet bool
+	// Whether to announce progress as periodic lines of text for screen
+	// readers instead of animating, see SetAccessible
+	Accessible bool
 }
 
 // DefaultOptions returns default options
@@ -34,8 +37,9 @@ func DefaultOptions() *Options {
 		ShowETA:         true,
 		RefreshRate:     100 * time.Millisecond,
 		MinDuration:     100 * time.Millisecond,
-		IsTTY:           checkTTY(),
+		IsTTY:           checkTTY() && !animationsDisabled() && !accessibleMode(),
 		Quiet:           isQuietMode(),
+		Accessible:      accessibleMode(),
 	}
 }
 
@@ -43,8 +47,26 @@ func DefaultOptions() *Options {
 var (
 	globalOptions = DefaultOptions()
 	globalMu      sync.RWMutex
+
+	// noAnimation hides animated indicators, see SetAnimated
+	noAnimation bool
 )
 
+// SetAnimated enables or disables animated spinners and bars, e.g. while
+// stdout is piped. Disabled indicators still print their final messages.
+func SetAnimated(animated bool) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	noAnimation = !animated
+}
+
+// animationsDisabled returns whether SetAnimated disabled animation
+func animationsDisabled() bool {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return noAnimation
+}
+
 // SetQuiet sets global quiet mode
 func SetQuiet(quiet bool) {
 	globalMu.Lock()
@@ -0,0 +1,118 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Environment variables that select a backend
+const (
+	// AnswersEnv names a file of scripted answers, see LoadScripted
+	AnswersEnv = "GLIDE_PROMPT_ANSWERS"
+
+	// CommandEnv names a program that shows the prompts, see NewExternal
+	CommandEnv = "GLIDE_PROMPT_COMMAND"
+)
+
+// Backend shows prompts and reads the answers. The classic line-based
+// terminal prompts, scripted answers for tests and CI, and external
+// programs are backends, and white-label builds can install their own UI
+// with SetBackend.
+//
+// Every backend answers with the same semantics: an empty answer takes the
+// default, Confirm accepts yes/no answers as parsed by ParseConfirmAnswer,
+// Select accepts an option's number or text as matched by MatchOption, and
+// Input only returns answers the validator accepts.
+type Backend interface {
+	Confirm(message string, defaultValue bool) (bool, error)
+	Select(message string, options []string, defaultIndex int) (int, string, error)
+	Input(message string, defaultValue string, validator InputValidator) (string, error)
+	Password(message string) (string, error)
+}
+
+var (
+	backendMu sync.RWMutex
+	backend   Backend = New()
+)
+
+// SetBackend makes b answer the package-level prompts. A nil b restores
+// the terminal prompts.
+func SetBackend(b Backend) {
+	if b == nil {
+		b = New()
+	}
+	backendMu.Lock()
+	backend = b
+	backendMu.Unlock()
+}
+
+// CurrentBackend returns the backend answering the package-level prompts
+func CurrentBackend() Backend {
+	backendMu.RLock()
+	defer backendMu.RUnlock()
+	return backend
+}
+
+// BackendFromEnv returns the backend chosen through GLIDE_PROMPT_ANSWERS
+// or GLIDE_PROMPT_COMMAND, or nil when neither is set
+func BackendFromEnv() (Backend, error) {
+	if path := os.Getenv(AnswersEnv); path != "" {
+		return LoadScripted(path)
+	}
+	if command := strings.Fields(os.Getenv(CommandEnv)); len(command) > 0 {
+		return NewExternal(command[0], command[1:]...), nil
+	}
+	return nil, nil
+}
+
+// ParseConfirmAnswer interprets the answer to a confirmation prompt. An
+// empty answer is defaultValue.
+func ParseConfirmAnswer(answer string, defaultValue bool) (bool, error) {
+	switch strings.TrimSpace(strings.ToLower(answer)) {
+	case "":
+		return defaultValue, nil
+	case "y", "yes", "true", "1":
+		return true, nil
+	case "n", "no", "false", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("%w: %q is not yes or no", ErrInvalidInput, answer)
+}
+
+// MatchOption returns the index of the option an answer picks: its 1-based
+// number, its text, or the start of its text, ignoring case. An empty
+// answer picks defaultIndex.
+func MatchOption(answer string, options []string, defaultIndex int) (int, error) {
+	if len(options) == 0 {
+		return -1, ErrNoOptions
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		if defaultIndex < 0 || defaultIndex >= len(options) {
+			defaultIndex = 0
+		}
+		return defaultIndex, nil
+	}
+
+	if n, err := strconv.Atoi(answer); err == nil {
+		if n < 1 || n > len(options) {
+			return -1, fmt.Errorf("%w: choose 1-%d", ErrInvalidInput, len(options))
+		}
+		return n - 1, nil
+	}
+	lower := strings.ToLower(answer)
+	for i, option := range options {
+		if strings.ToLower(option) == lower {
+			return i, nil
+		}
+	}
+	for i, option := range options {
+		if strings.HasPrefix(strings.ToLower(option), lower) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: %q matches no option", ErrInvalidInput, answer)
+}
@@ -0,0 +1,220 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfirmAnswer(t *testing.T) {
+	for answer, want := range map[string]bool{"y": true, "YES": true, " 1 ": true, "n": false, "No": false, "false": false} {
+		got, err := ParseConfirmAnswer(answer, !want)
+		require.NoError(t, err, answer)
+		assert.Equal(t, want, got, answer)
+	}
+
+	got, err := ParseConfirmAnswer("", true)
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	_, err = ParseConfirmAnswer("maybe", true)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestMatchOption(t *testing.T) {
+	options := []string{"Single-repo", "Multi-worktree", "Multi"}
+
+	tests := []struct {
+		answer string
+		want   int
+	}{
+		{"", 1},
+		{"1", 0},
+		{"multi", 2}, // Exact text beats a prefix
+		{"multi-w", 1},
+		{"SINGLE", 0},
+	}
+	for _, tt := range tests {
+		got, err := MatchOption(tt.answer, options, 1)
+		require.NoError(t, err, tt.answer)
+		assert.Equal(t, tt.want, got, tt.answer)
+	}
+
+	_, err := MatchOption("4", options, 0)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	_, err = MatchOption("other", options, 0)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	_, err = MatchOption("1", nil, 0)
+	assert.ErrorIs(t, err, ErrNoOptions)
+}
+
+func TestScripted(t *testing.T) {
+	t.Run("answers in order", func(t *testing.T) {
+		s := NewScripted(
+			ScriptedAnswer{Answer: "yes"},
+			ScriptedAnswer{Prompt: "development mode", Answer: "2"},
+			ScriptedAnswer{Answer: ""},
+			ScriptedAnswer{Answer: "s3cret"},
+		)
+
+		confirmed, err := s.Confirm("Continue?", false)
+		require.NoError(t, err)
+		assert.True(t, confirmed)
+
+		idx, value, err := s.Select("Select Development Mode", []string{"single", "multi"}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, idx)
+		assert.Equal(t, "multi", value)
+
+		name, err := s.Input("Project name", "acme", RequiredValidator)
+		require.NoError(t, err)
+		assert.Equal(t, "acme", name, "an empty answer takes the default")
+
+		password, err := s.Password("Password")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", password)
+		assert.Zero(t, s.Remaining())
+
+		_, err = s.Confirm("One more?", true)
+		assert.ErrorIs(t, err, ErrNoAnswer)
+	})
+
+	t.Run("answer for another prompt", func(t *testing.T) {
+		s := NewScripted(ScriptedAnswer{Prompt: "Set as default", Answer: "y"})
+
+		_, err := s.Confirm("Remove volumes?", false)
+		assert.ErrorIs(t, err, ErrUnexpectedPrompt)
+		assert.Equal(t, 1, s.Remaining(), "the answer is kept")
+	})
+
+	t.Run("refused input", func(t *testing.T) {
+		s := NewScripted(ScriptedAnswer{Answer: ""})
+
+		_, err := s.Input("Name", "", RequiredValidator)
+		assert.ErrorIs(t, err, ErrValidationFailed)
+	})
+
+	t.Run("loads answers from a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "answers.yml")
+		require.NoError(t, os.WriteFile(path, []byte("- prompt: Continue\n  answer: \"no\"\n- 2\n"), 0644))
+
+		s, err := LoadScripted(path)
+		require.NoError(t, err)
+
+		confirmed, err := s.Confirm("Continue?", true)
+		require.NoError(t, err)
+		assert.False(t, confirmed)
+
+		idx, _, err := s.Select("Pick", []string{"a", "b"}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, idx)
+	})
+}
+
+// promptProgram writes an external prompt program that runs script
+func promptProgram(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "prompt.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755))
+	return path
+}
+
+func TestExternal(t *testing.T) {
+	t.Run("answers through the program", func(t *testing.T) {
+		// Pick the option named in the request, echoing the kind back
+		program := promptProgram(t, `case "$GLIDE_PROMPT_REQUEST" in
+  *'"kind":"select"'*) echo '{"answer":"beta"}' ;;
+  *'"kind":"confirm"'*) echo '{"answer":"y"}' ;;
+  *) echo '{"answer":""}' ;;
+esac`)
+		e := NewExternal(program)
+
+		idx, value, err := e.Select("Pick", []string{"alpha", "beta"}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, idx)
+		assert.Equal(t, "beta", value)
+
+		confirmed, err := e.Confirm("Continue?", false)
+		require.NoError(t, err)
+		assert.True(t, confirmed)
+
+		name, err := e.Input("Name", "acme", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "acme", name)
+	})
+
+	t.Run("refused answers are asked again a few times", func(t *testing.T) {
+		count := filepath.Join(t.TempDir(), "count")
+		program := promptProgram(t, `echo x >> `+count+`
+echo '{"answer":"maybe"}'`)
+
+		_, err := NewExternal(program).Confirm("Continue?", false)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+
+		data, err := os.ReadFile(count)
+		require.NoError(t, err)
+		assert.Equal(t, "x\nx\nx\n", string(data))
+	})
+
+	t.Run("exit status 130 cancels", func(t *testing.T) {
+		_, err := NewExternal(promptProgram(t, "exit 130")).Password("Password")
+		assert.ErrorIs(t, err, ErrInterrupted)
+	})
+}
+
+func TestBackendFromEnv(t *testing.T) {
+	t.Setenv(AnswersEnv, "")
+	t.Setenv(CommandEnv, "")
+	backend, err := BackendFromEnv()
+	require.NoError(t, err)
+	assert.Nil(t, backend)
+
+	t.Setenv(CommandEnv, "my-prompt --theme dark")
+	backend, err = BackendFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, NewExternal("my-prompt", "--theme", "dark"), backend)
+
+	path := filepath.Join(t.TempDir(), "answers.yml")
+	require.NoError(t, os.WriteFile(path, []byte("- yes\n"), 0644))
+	t.Setenv(AnswersEnv, path)
+	backend, err = BackendFromEnv()
+	require.NoError(t, err)
+	assert.IsType(t, &Scripted{}, backend, "scripted answers take precedence")
+}
+
+func TestSetBackend(t *testing.T) {
+	t.Cleanup(func() { SetBackend(nil) })
+
+	SetBackend(NewScripted(ScriptedAnswer{Answer: "y"}))
+	confirmed, err := Confirm("Continue?", false)
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	SetBackend(nil)
+	assert.IsType(t, &DefaultPrompter{}, CurrentBackend())
+}
+
+func TestTypedConfirm(t *testing.T) {
+	t.Cleanup(func() { SetBackend(nil) })
+
+	SetBackend(NewScripted(
+		ScriptedAnswer{Prompt: `type "shop" to confirm`, Answer: "shop"},
+		ScriptedAnswer{Answer: "y"},
+		ScriptedAnswer{Answer: ""},
+	))
+
+	confirmed, err := TypedConfirm("remove all volumes", "shop")
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	confirmed, err = TypedConfirm("remove all volumes", "shop")
+	require.NoError(t, err)
+	assert.False(t, confirmed, "yes is not the phrase")
+
+	confirmed, err = TypedConfirm("remove all volumes", "shop")
+	require.NoError(t, err)
+	assert.False(t, confirmed)
+}
@@ -25,6 +25,11 @@
 //	    // User confirmed
 //	}
 //
+// Operations that cannot be undone can ask for a typed phrase instead,
+// like deleting a repository on GitHub:
+//
+//	confirmed, err := prompt.TypedConfirm("remove all volumes", projectName)
+//
 // # Selection Prompts
 //
 // Let users choose from options:
@@ -61,6 +66,25 @@
 //	prompter := prompt.New(prompt.WithNoColor())
 //	// Disables color output
 //
+// # Backends
+//
+// The package-level prompts are shown by the current Backend, so the
+// classic CLI, a full-screen UI, and white-label builds share the same
+// prompt semantics. Install another backend with SetBackend:
+//
+//	prompt.SetBackend(myUI)                       // A custom UI
+//	prompt.SetBackend(prompt.NewExternal("fzf-prompt")) // A program per prompt
+//
+// Tests and CI answer prompts from a script instead of a terminal:
+//
+//	prompt.SetBackend(prompt.NewScripted(
+//	    prompt.ScriptedAnswer{Prompt: "Select development mode", Answer: "2"},
+//	    prompt.ScriptedAnswer{Answer: "yes"},
+//	))
+//
+// BackendFromEnv selects these backends from GLIDE_PROMPT_ANSWERS (a YAML
+// file of answers) and GLIDE_PROMPT_COMMAND (an external program).
+//
 // # Integration with Container
 //
 // The container can provide a configured prompter:
@@ -0,0 +1,160 @@
+package prompt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// requestEnv carries the request to an external prompt program
+const requestEnv = "GLIDE_PROMPT_REQUEST"
+
+const (
+	// exitCancelled is the status an external program exits with when the
+	// user cancels, as a shell does on Ctrl+C
+	exitCancelled = 130
+
+	// maxExternalAttempts bounds how often a refused answer is asked again,
+	// so a broken program cannot loop forever
+	maxExternalAttempts = 3
+)
+
+// ExternalRequest describes a prompt to an external program
+type ExternalRequest struct {
+	// Kind is "confirm", "select", "input", or "password"
+	Kind    string   `json:"kind"`
+	Message string   `json:"message"`
+	Default string   `json:"default,omitempty"`
+	Options []string `json:"options,omitempty"`
+	// Error explains why the previous answer was refused
+	Error string `json:"error,omitempty"`
+}
+
+// ExternalResponse is an external program's answer, given as it would be
+// typed
+type ExternalResponse struct {
+	Answer string `json:"answer"`
+}
+
+// External is a backend that runs a separate program for every prompt,
+// so white-label builds can bring a UI written in any language. The
+// program finds an ExternalRequest as JSON in GLIDE_PROMPT_REQUEST, keeps
+// the terminal on stdin and stderr, and prints an ExternalResponse as JSON
+// on stdout. Exiting with status 130 cancels the prompt.
+type External struct {
+	Command string
+	Args    []string
+}
+
+// NewExternal returns a backend running command with args for every prompt
+func NewExternal(command string, args ...string) *External {
+	return &External{Command: command, Args: args}
+}
+
+// ask runs the program for one request
+func (e *External) ask(req ExternalRequest) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(e.Command, e.Args...)
+	cmd.Env = append(os.Environ(), requestEnv+"="+string(data))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == exitCancelled {
+			return "", ErrInterrupted
+		}
+		return "", fmt.Errorf("prompt program %s failed: %w", e.Command, err)
+	}
+
+	var resp ExternalResponse
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &resp); err != nil {
+		return "", fmt.Errorf("prompt program %s gave an invalid answer: %w", e.Command, err)
+	}
+	return resp.Answer, nil
+}
+
+// Confirm asks the program a yes/no question
+func (e *External) Confirm(message string, defaultValue bool) (bool, error) {
+	req := ExternalRequest{Kind: "confirm", Message: message, Default: "no"}
+	if defaultValue {
+		req.Default = "yes"
+	}
+	for attempt := 1; ; attempt++ {
+		answer, err := e.ask(req)
+		if err != nil {
+			return false, err
+		}
+		confirmed, err := ParseConfirmAnswer(answer, defaultValue)
+		if err == nil || attempt == maxExternalAttempts {
+			return confirmed, err
+		}
+		req.Error = err.Error()
+	}
+}
+
+// Select asks the program to pick an option
+func (e *External) Select(message string, options []string, defaultIndex int) (int, string, error) {
+	if len(options) == 0 {
+		return -1, "", ErrNoOptions
+	}
+	if defaultIndex < 0 || defaultIndex >= len(options) {
+		defaultIndex = 0
+	}
+	req := ExternalRequest{Kind: "select", Message: message, Options: options, Default: strconv.Itoa(defaultIndex + 1)}
+	for attempt := 1; ; attempt++ {
+		answer, err := e.ask(req)
+		if err != nil {
+			return -1, "", err
+		}
+		choice, err := MatchOption(answer, options, defaultIndex)
+		if err == nil {
+			return choice, options[choice], nil
+		}
+		if attempt == maxExternalAttempts {
+			return -1, "", err
+		}
+		req.Error = err.Error()
+	}
+}
+
+// Input asks the program for text, asking again when the validator
+// refuses the answer
+func (e *External) Input(message string, defaultValue string, validator InputValidator) (string, error) {
+	req := ExternalRequest{Kind: "input", Message: message, Default: defaultValue}
+	for attempt := 1; ; attempt++ {
+		answer, err := e.ask(req)
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(answer) == "" {
+			answer = defaultValue
+		}
+		if validator == nil {
+			return answer, nil
+		}
+		err = validator(answer)
+		if err == nil {
+			return answer, nil
+		}
+		if attempt == maxExternalAttempts {
+			return "", fmt.Errorf("%w: %v", ErrValidationFailed, err)
+		}
+		req.Error = err.Error()
+	}
+}
+
+// Password asks the program for a secret
+func (e *External) Password(message string) (string, error) {
+	return e.ask(ExternalRequest{Kind: "password", Message: message})
+}
@@ -9,18 +9,14 @@ import (
 	"github.com/fatih/color"
 )
 
-// Prompter interface for testing
-type Prompter interface {
-	Confirm(message string, defaultValue bool) (bool, error)
-	Select(message string, options []string, defaultIndex int) (int, string, error)
-	Input(message string, defaultValue string, validator InputValidator) (string, error)
-	Password(message string) (string, error)
-}
+// Prompter is the former name of Backend
+type Prompter = Backend
 
 // InputValidator is a function type for validating user input
 type InputValidator func(input string) error
 
-// DefaultPrompter implements the Prompter interface using stdin/stdout
+// DefaultPrompter is the backend showing classic line-based prompts on
+// stdin/stdout
 type DefaultPrompter struct {
 	reader *bufio.Reader
 	writer *os.File
@@ -56,24 +52,12 @@ func (p *DefaultPrompter) Confirm(message string, defaultValue bool) (bool, erro
 		return false, fmt.Errorf("failed to read input: %w", err)
 	}
 
-	// Trim whitespace
-	input = strings.TrimSpace(strings.ToLower(input))
-
-	// Handle empty input (use default)
-	if input == "" {
-		return defaultValue, nil
-	}
-
-	// Parse response
-	switch input {
-	case "y", "yes", "true", "1":
-		return true, nil
-	case "n", "no", "false", "0":
-		return false, nil
-	default:
-		// If invalid input, return default
+	// Invalid input takes the default
+	confirmed, err := ParseConfirmAnswer(input, defaultValue)
+	if err != nil {
 		return defaultValue, nil
 	}
+	return confirmed, nil
 }
 
 // Select displays a selection prompt with options
@@ -115,35 +99,11 @@ func (p *DefaultPrompter) Select(message string, options []string, defaultIndex
 		return -1, "", fmt.Errorf("failed to read input: %w", err)
 	}
 
-	input = strings.TrimSpace(input)
-
-	// Handle empty input (use default)
-	if input == "" {
-		return defaultIndex, options[defaultIndex], nil
-	}
-
-	// Parse selection
-	var choice int
-	_, err = fmt.Sscanf(input, "%d", &choice)
+	// Invalid input takes the default
+	choice, err := MatchOption(input, options, defaultIndex)
 	if err != nil {
-		// Try to match by string
-		inputLower := strings.ToLower(input)
-		for i, option := range options {
-			if strings.ToLower(option) == inputLower ||
-				strings.HasPrefix(strings.ToLower(option), inputLower) {
-				return i, option, nil
-			}
-		}
-		// Invalid input, use default
-		return defaultIndex, options[defaultIndex], nil
-	}
-
-	// Validate choice (1-based from user perspective)
-	choice--
-	if choice < 0 || choice >= len(options) {
-		return defaultIndex, options[defaultIndex], nil
+		choice = defaultIndex
 	}
-
 	return choice, options[choice], nil
 }
 
@@ -265,28 +225,26 @@ func ChainValidators(validators ...InputValidator) InputValidator {
 	}
 }
 
-// Convenience functions using the default prompter
+// Convenience functions using the current backend
 
-var defaultPrompter = New()
-
-// Confirm is a convenience function using the default prompter
+// Confirm is a convenience function using the current backend
 func Confirm(message string, defaultValue bool) (bool, error) {
-	return defaultPrompter.Confirm(message, defaultValue)
+	return CurrentBackend().Confirm(message, defaultValue)
 }
 
-// Select is a convenience function using the default prompter
+// Select is a convenience function using the current backend
 func Select(message string, options []string, defaultIndex int) (int, string, error) {
-	return defaultPrompter.Select(message, options, defaultIndex)
+	return CurrentBackend().Select(message, options, defaultIndex)
 }
 
-// Input is a convenience function using the default prompter
+// Input is a convenience function using the current backend
 func Input(message string, defaultValue string, validator InputValidator) (string, error) {
-	return defaultPrompter.Input(message, defaultValue, validator)
+	return CurrentBackend().Input(message, defaultValue, validator)
 }
 
-// Password is a convenience function using the default prompter
+// Password is a convenience function using the current backend
 func Password(message string) (string, error) {
-	return defaultPrompter.Password(message)
+	return CurrentBackend().Password(message)
 }
 
 // ConfirmDestructive displays a confirmation prompt for destructive operations
@@ -313,6 +271,30 @@ func ConfirmDestructive(operation string) (bool, error) {
 	return confirmed, nil
 }
 
+// TypedConfirm asks the user to type phrase, such as the project or
+// resource name, to confirm a destructive operation that cannot be undone.
+// Only the exact phrase confirms; any other answer cancels.
+func TypedConfirm(operation, phrase string) (bool, error) {
+	fmt.Fprintf(os.Stdout, "\n%s This is a destructive operation and cannot be undone!\n",
+		color.RedString("⚠"),
+	)
+
+	message := fmt.Sprintf("To %s, type %q to confirm", operation, phrase)
+	answer, err := Input(message, "", nil)
+	if err != nil {
+		return false, err
+	}
+
+	if strings.TrimSpace(answer) != phrase {
+		fmt.Fprintf(os.Stdout, "%s Operation cancelled: the text did not match %q\n",
+			color.YellowString("→"),
+			phrase,
+		)
+		return false, nil
+	}
+	return true, nil
+}
+
 // SelectProject displays a project selection prompt
 func SelectProject(projects []string, current string) (string, error) {
 	if len(projects) == 0 {
@@ -0,0 +1,133 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ScriptedAnswer is an answer prepared for a prompt. When Prompt is set,
+// it must appear in the message of the prompt being answered, so a script
+// that drifts out of step fails instead of answering the wrong question.
+type ScriptedAnswer struct {
+	Prompt string `yaml:"prompt,omitempty"`
+	Answer string `yaml:"answer"`
+}
+
+// UnmarshalYAML accepts a bare answer as well as a prompt/answer mapping
+func (a *ScriptedAnswer) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		a.Answer = node.Value
+		return nil
+	}
+	type plain ScriptedAnswer
+	return node.Decode((*plain)(a))
+}
+
+// Scripted is a backend answering prompts from a prepared list, in order,
+// for tests and CI. Answers are given as they would be typed.
+type Scripted struct {
+	mu      sync.Mutex
+	answers []ScriptedAnswer
+	next    int
+}
+
+// NewScripted returns a backend giving answers in order
+func NewScripted(answers ...ScriptedAnswer) *Scripted {
+	return &Scripted{answers: answers}
+}
+
+// LoadScripted reads the answers of a scripted backend from a YAML list,
+// whose entries are answers or prompt/answer mappings:
+//
+//	# answers.yml
+//	- prompt: Select development mode
+//	  answer: "2"
+//	- "yes"
+func LoadScripted(path string) (*Scripted, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt answers: %w", err)
+	}
+	var answers []ScriptedAnswer
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("invalid prompt answers in %s: %w", path, err)
+	}
+	return NewScripted(answers...), nil
+}
+
+// Remaining returns the number of answers not used yet
+func (s *Scripted) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.answers) - s.next
+}
+
+// answer takes the next answer, which must be meant for message
+func (s *Scripted) answer(message string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.next >= len(s.answers) {
+		return "", fmt.Errorf("%w for %q", ErrNoAnswer, message)
+	}
+	a := s.answers[s.next]
+	if a.Prompt != "" && !containsFold(message, a.Prompt) {
+		return "", fmt.Errorf("%w %q: the next answer is for %q", ErrUnexpectedPrompt, message, a.Prompt)
+	}
+	s.next++
+	return a.Answer, nil
+}
+
+// Confirm answers a confirmation prompt
+func (s *Scripted) Confirm(message string, defaultValue bool) (bool, error) {
+	answer, err := s.answer(message)
+	if err != nil {
+		return false, err
+	}
+	return ParseConfirmAnswer(answer, defaultValue)
+}
+
+// Select answers a selection prompt
+func (s *Scripted) Select(message string, options []string, defaultIndex int) (int, string, error) {
+	answer, err := s.answer(message)
+	if err != nil {
+		return -1, "", err
+	}
+	choice, err := MatchOption(answer, options, defaultIndex)
+	if err != nil {
+		return -1, "", err
+	}
+	return choice, options[choice], nil
+}
+
+// Input answers a text prompt. An answer the validator refuses is an
+// error, as there is nobody to ask again.
+func (s *Scripted) Input(message string, defaultValue string, validator InputValidator) (string, error) {
+	answer, err := s.answer(message)
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		answer = defaultValue
+	}
+	if validator != nil {
+		if err := validator(answer); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrValidationFailed, err)
+		}
+	}
+	return answer, nil
+}
+
+// Password answers a password prompt
+func (s *Scripted) Password(message string) (string, error) {
+	return s.answer(message)
+}
+
+// containsFold reports whether substr is within s, ignoring case
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -15,6 +15,13 @@ var (
 
 	// ErrValidationFailed is returned when input validation fails
 	ErrValidationFailed = errors.New("validation failed")
+
+	// ErrNoAnswer is returned when a scripted backend has no answer left
+	ErrNoAnswer = errors.New("no scripted answer left")
+
+	// ErrUnexpectedPrompt is returned when the next scripted answer is
+	// meant for another prompt
+	ErrUnexpectedPrompt = errors.New("unexpected prompt")
 )
 
 // PromptConfig holds configuration for prompts
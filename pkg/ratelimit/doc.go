@@ -0,0 +1,26 @@
+// Package ratelimit spaces out requests to external APIs so that bulk
+// operations, such as checking many plugins for updates, stay under the
+// APIs' rate limits.
+//
+// A Limiter keeps a token bucket per host: each request takes a token,
+// tokens refill at a steady rate, and a full bucket allows a short burst.
+// Requests to different hosts never wait for each other.
+//
+// # HTTP Clients
+//
+// Wrap a client's transport to limit every request it sends:
+//
+//	client := &http.Client{
+//	    Timeout:   10 * time.Second,
+//	    Transport: ratelimit.Default.Transport(nil),
+//	}
+//
+// Default is shared by every client in the process, so requests to a host
+// are limited together however many clients send them.
+//
+// # Waiting Directly
+//
+//	if err := limiter.Wait(ctx, "api.github.com"); err != nil {
+//	    return err // ctx was done first
+//	}
+package ratelimit
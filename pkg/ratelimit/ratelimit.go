@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRate is how many requests per second Default allows each host
+	DefaultRate = 5
+	// DefaultBurst is how many requests Default allows a host at once
+	DefaultBurst = 10
+)
+
+// Default limits the requests of glide's API clients
+var Default = New(DefaultRate, DefaultBurst)
+
+// Limiter keeps a token bucket per host
+type Limiter struct {
+	rate  float64
+	burst float64
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// bucket holds a host's tokens as of last
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// New creates a limiter allowing each host rate requests per second, in
+// bursts of up to burst requests
+func New(rate float64, burst int) *Limiter {
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(max(burst, 1)),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Wait blocks until a request to host may be sent, or until ctx is done
+func (l *Limiter) Wait(ctx context.Context, host string) error {
+	delay := l.reserve(host)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.release(host)
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token for host and returns how long to wait for it
+func (l *Limiter) reserve(host string) time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[host] = b
+	}
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*l.rate, l.burst)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / l.rate * float64(time.Second))
+}
+
+// release gives back a token whose request was not sent
+func (l *Limiter) release(host string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.buckets[host]; ok {
+		b.tokens = min(b.tokens+1, l.burst)
+	}
+}
+
+// Transport wraps base, or http.DefaultTransport when nil, so that every
+// request waits for its host's turn
+func (l *Limiter) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{limiter: l, base: base}
+}
+
+// transport is a rate limited http.RoundTripper
+type transport struct {
+	limiter *Limiter
+	base    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context(), req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frozen returns a limiter whose clock only moves when advanced
+func frozen(rate float64, burst int) (*Limiter, func(time.Duration)) {
+	l := New(rate, burst)
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestLimiter_Reserve(t *testing.T) {
+	l, advance := frozen(2, 3)
+
+	for i := 0; i < 3; i++ {
+		assert.Zero(t, l.reserve("api.github.com"), "the burst is free")
+	}
+	assert.Equal(t, 500*time.Millisecond, l.reserve("api.github.com"))
+	assert.Equal(t, time.Second, l.reserve("api.github.com"), "waits queue up")
+	assert.Zero(t, l.reserve("gitlab.com"), "hosts have their own buckets")
+
+	advance(time.Hour)
+	assert.Zero(t, l.reserve("api.github.com"), "tokens refill")
+	for i := 0; i < 2; i++ {
+		assert.Zero(t, l.reserve("api.github.com"))
+	}
+	assert.Positive(t, l.reserve("api.github.com"), "refills stop at the burst")
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	l := New(20, 1)
+	require.NoError(t, l.Wait(context.Background(), "api.github.com"))
+
+	started := time.Now()
+	require.NoError(t, l.Wait(context.Background(), "api.github.com"))
+	assert.GreaterOrEqual(t, time.Since(started), 40*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow := New(0.001, 1)
+	require.NoError(t, slow.Wait(ctx, "api.github.com"))
+	assert.ErrorIs(t, slow.Wait(ctx, "api.github.com"), context.Canceled)
+}
+
+func TestLimiter_Transport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: New(0.001, 1).Transport(nil)}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the second request waits for its turn")
+	assert.Equal(t, 1, requests)
+}
+
+func TestNew_UnlimitedRate(t *testing.T) {
+	l := New(0, 1)
+	for i := 0; i < 100; i++ {
+		assert.Zero(t, l.reserve("api.github.com"))
+	}
+}
@@ -0,0 +1,101 @@
+package release
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// debianArchitectures maps Go architectures to Debian's
+var debianArchitectures = map[string]string{
+	"amd64": "amd64",
+	"386":   "i386",
+	"arm64": "arm64",
+	"arm":   "armhf",
+}
+
+// AptPackages generates the Packages index of a flat apt repository
+// serving the .deb artifacts. Each entry's Filename is the artifact's name,
+// so the .deb files are published next to the index.
+func AptPackages(info Info, artifacts []Artifact) (string, error) {
+	if err := info.Validate(); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, artifact := range ofKind(artifacts, KindDeb) {
+		arch, ok := debianArchitectures[artifact.Arch]
+		if artifact.OS != "linux" || !ok {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		field(&b, "Package", info.Name)
+		field(&b, "Version", packageVersion(info))
+		field(&b, "Architecture", arch)
+		field(&b, "Maintainer", info.Maintainer)
+		field(&b, "Filename", artifact.Name)
+		field(&b, "Size", fmt.Sprint(artifact.Size))
+		field(&b, "SHA256", artifact.SHA256)
+		field(&b, "Homepage", info.Homepage)
+		field(&b, "Description", info.Description)
+	}
+	if b.Len() == 0 {
+		return "", fmt.Errorf("no .deb packages to index")
+	}
+	return b.String(), nil
+}
+
+// AptRelease generates the Release file of a flat apt repository, listing
+// the checksums of its index files, e.g. {"Packages": ...}
+func AptRelease(info Info, artifacts []Artifact, indexes map[string]string, now time.Time) string {
+	archSet := make(map[string]bool)
+	for _, artifact := range ofKind(artifacts, KindDeb) {
+		if arch, ok := debianArchitectures[artifact.Arch]; ok && artifact.OS == "linux" {
+			archSet[arch] = true
+		}
+	}
+	arches := make([]string, 0, len(archSet))
+	for arch := range archSet {
+		arches = append(arches, arch)
+	}
+	sort.Strings(arches)
+
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	field(&b, "Origin", info.Name)
+	field(&b, "Label", info.Name)
+	field(&b, "Version", packageVersion(info))
+	field(&b, "Date", now.UTC().Format("Mon, 02 Jan 2006 15:04:05 UTC"))
+	field(&b, "Architectures", strings.Join(arches, " "))
+	field(&b, "Description", info.Description)
+	b.WriteString("SHA256:\n")
+	for _, name := range names {
+		sum := sha256.Sum256([]byte(indexes[name]))
+		fmt.Fprintf(&b, " %s %d %s\n", hex.EncodeToString(sum[:]), len(indexes[name]), name)
+	}
+	return b.String()
+}
+
+// field writes a control file field, skipping empty values
+func field(b *strings.Builder, name, value string) {
+	if value != "" {
+		fmt.Fprintf(b, "%s: %s\n", name, value)
+	}
+}
+
+// packageVersion returns the version in the form apt and rpm order
+// correctly: a pre-release suffix such as "-rc.1" sorts before the release
+// only when written "~rc.1"
+func packageVersion(info Info) string {
+	return strings.ReplaceAll(info.PackageVersion(), "-", "~")
+}
@@ -0,0 +1,29 @@
+// Package release generates package manager metadata for a release.
+//
+// From the binaries and packages a build produces, this package writes a
+// Homebrew formula, a Scoop manifest, and the indexes of flat apt and
+// yum/dnf repositories. Names, descriptions, and URLs come from the
+// build's branding, so white-label distributions publish packages under
+// their own name without maintaining scripts of their own.
+//
+// # Artifacts
+//
+// Artifacts are named <name>-<os>-<arch>, as scripts/build.sh and the
+// release workflow name them, with an .exe, .deb, or .rpm extension where
+// one applies:
+//
+//	info := release.NewInfo("v3.2.0")
+//	baseURL := release.GitHubDownloadURL(branding.RepositoryURL, "v3.2.0")
+//	artifacts, err := release.LoadArtifacts("dist", info.Name, baseURL)
+//
+// # Generating Metadata
+//
+// Generate builds every format the artifacts support, keyed by the path
+// it is published under:
+//
+//	files, err := release.Generate(info, artifacts, time.Now())
+//	paths, err := release.WriteFiles("dist/packaging", files)
+//
+// Homebrew, Scoop, AptPackages, AptRelease, and RPMRepodata build a
+// single format each.
+package release
@@ -0,0 +1,67 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Generate builds the metadata of every package format the artifacts
+// support, keyed by the path each file is published under:
+//
+//	homebrew/Formula/<name>.rb         macOS and Linux binaries
+//	scoop/bucket/<name>.json           Windows binaries
+//	apt/Packages, apt/Release          .deb packages
+//	rpm/repodata/primary.xml,
+//	rpm/repodata/repomd.xml            .rpm packages
+//
+// Formats without artifacts are skipped; it is an error when none remain.
+func Generate(info Info, artifacts []Artifact, now time.Time) (map[string][]byte, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	if formula, err := Homebrew(info, artifacts); err == nil {
+		files[filepath.Join("homebrew", "Formula", info.Name+".rb")] = []byte(formula)
+	}
+	if manifest, err := Scoop(info, artifacts); err == nil {
+		files[filepath.Join("scoop", "bucket", info.Name+".json")] = manifest
+	}
+	if packages, err := AptPackages(info, artifacts); err == nil {
+		files[filepath.Join("apt", "Packages")] = []byte(packages)
+		files[filepath.Join("apt", "Release")] = []byte(AptRelease(info, artifacts, map[string]string{"Packages": packages}, now))
+	}
+	if primary, repomd, err := RPMRepodata(info, artifacts, now); err == nil {
+		files[filepath.Join("rpm", "repodata", "primary.xml")] = primary
+		files[filepath.Join("rpm", "repodata", "repomd.xml")] = repomd
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no artifacts of %s %s can be packaged", info.Name, info.Version)
+	}
+	return files, nil
+}
+
+// WriteFiles writes generated metadata under dir, returning the paths
+// written in order
+func WriteFiles(dir string, files map[string][]byte) ([]string, error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		target := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		if err := os.WriteFile(target, files[path], 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,93 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// homebrewFormula is the template of a formula installing prebuilt binaries
+var homebrewFormula = template.Must(template.New("formula").Parse(`class {{ .Class }} < Formula
+  desc {{ printf "%q" .Info.Description }}
+{{- if .Info.Homepage }}
+  homepage {{ printf "%q" .Info.Homepage }}
+{{- end }}
+  version {{ printf "%q" .Info.PackageVersion }}
+{{- if .Info.License }}
+  license {{ printf "%q" .Info.License }}
+{{- end }}
+{{ range .Platforms }}
+  {{ .Block }} do
+{{- range .Arches }}
+    {{ .Block }} do
+      url {{ printf "%q" .Artifact.URL }}
+      sha256 {{ printf "%q" .Artifact.SHA256 }}
+    end
+{{- end }}
+  end
+{{ end }}
+  def install
+    bin.install Dir["{{ .Info.Name }}-*"].first => {{ printf "%q" .Info.Name }}
+  end
+
+  test do
+    assert_match version.to_s, shell_output("#{bin}/{{ .Info.Name }} version")
+  end
+end
+`))
+
+type homebrewArch struct {
+	Block    string
+	Artifact Artifact
+}
+
+type homebrewPlatform struct {
+	Block  string
+	Arches []homebrewArch
+}
+
+// Homebrew generates a Homebrew formula installing the macOS and Linux
+// binaries among artifacts. The formula is written to Formula/<name>.rb in
+// a tap.
+func Homebrew(info Info, artifacts []Artifact) (string, error) {
+	if err := info.Validate(); err != nil {
+		return "", err
+	}
+
+	var platforms []homebrewPlatform
+	for _, p := range []struct{ os, block string }{{"darwin", "on_macos"}, {"linux", "on_linux"}} {
+		platform := homebrewPlatform{Block: p.block}
+		for _, a := range []struct{ arch, block string }{{"arm64", "on_arm"}, {"amd64", "on_intel"}} {
+			if artifact, ok := find(artifacts, KindBinary, p.os, a.arch); ok {
+				platform.Arches = append(platform.Arches, homebrewArch{Block: a.block, Artifact: artifact})
+			}
+		}
+		if len(platform.Arches) > 0 {
+			platforms = append(platforms, platform)
+		}
+	}
+	if len(platforms) == 0 {
+		return "", fmt.Errorf("no macOS or Linux binaries to build a formula from")
+	}
+
+	var b strings.Builder
+	err := homebrewFormula.Execute(&b, struct {
+		Class     string
+		Info      Info
+		Platforms []homebrewPlatform
+	}{formulaClass(info.Name), info, platforms})
+	if err != nil {
+		return "", fmt.Errorf("failed to render formula: %w", err)
+	}
+	return b.String(), nil
+}
+
+// formulaClass returns the Ruby class name Homebrew expects for a formula,
+// e.g. "my-cli" becomes "MyCli"
+func formulaClass(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
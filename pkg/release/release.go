@@ -0,0 +1,186 @@
+package release
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/glide-cli/glide/v3/pkg/branding"
+)
+
+// Kinds of build artifacts
+const (
+	KindBinary = "binary"
+	KindDeb    = "deb"
+	KindRPM    = "rpm"
+)
+
+// Info describes the product being packaged. The zero value of every
+// optional field is left out of the generated metadata.
+type Info struct {
+	// Name is the command name, e.g. "glide"
+	Name string
+	// Version is the release version; a leading "v" is dropped where
+	// package managers expect a bare version
+	Version     string
+	Description string
+	Homepage    string
+	License     string
+	// Maintainer is "Name <email>", as apt and rpm expect it
+	Maintainer string
+}
+
+// NewInfo returns the Info of this build's branding at version, so
+// white-label builds package under their own name
+func NewInfo(version string) Info {
+	return Info{
+		Name:        branding.CommandName,
+		Version:     version,
+		Description: branding.GetShortDescription(),
+		Homepage:    branding.RepositoryURL,
+		License:     "MIT",
+	}
+}
+
+// PackageVersion returns the version without its "v" prefix
+func (i Info) PackageVersion() string {
+	return strings.TrimPrefix(i.Version, "v")
+}
+
+// Validate checks that the fields every generator needs are set
+func (i Info) Validate() error {
+	if i.Name == "" {
+		return fmt.Errorf("release info has no name")
+	}
+	if i.PackageVersion() == "" {
+		return fmt.Errorf("release info has no version")
+	}
+	return nil
+}
+
+// Artifact is a build output published with a release
+type Artifact struct {
+	// Name is the file name, e.g. "glide-darwin-arm64"
+	Name string
+	// Kind is KindBinary, KindDeb, or KindRPM
+	Kind string
+	// OS and Arch are Go's GOOS and GOARCH, e.g. "linux" and "arm64"
+	OS   string
+	Arch string
+	// URL is where the artifact is downloaded from
+	URL    string
+	SHA256 string
+	Size   int64
+}
+
+// ParseArtifactName splits an artifact file name of the form
+// <name>-<os>-<arch>[.exe|.deb|.rpm], as scripts/build.sh and the release
+// workflow name them, into its parts
+func ParseArtifactName(name, file string) (Artifact, bool) {
+	rest, ok := strings.CutPrefix(file, name+"-")
+	if !ok {
+		return Artifact{}, false
+	}
+
+	kind := KindBinary
+	switch ext := filepath.Ext(rest); ext {
+	case ".deb":
+		kind = KindDeb
+		rest = strings.TrimSuffix(rest, ext)
+	case ".rpm":
+		kind = KindRPM
+		rest = strings.TrimSuffix(rest, ext)
+	case ".exe":
+		rest = strings.TrimSuffix(rest, ext)
+	case "":
+	default:
+		// Checksums, signatures, and archives are not artifacts
+		return Artifact{}, false
+	}
+
+	osName, arch, ok := strings.Cut(rest, "-")
+	if !ok || osName == "" || arch == "" || strings.Contains(arch, "-") {
+		return Artifact{}, false
+	}
+	return Artifact{Name: file, Kind: kind, OS: osName, Arch: arch}, true
+}
+
+// LoadArtifacts reads the artifacts of the named command in dir, such as
+// dist/, computing their checksums and sizes. Each artifact's URL is
+// baseURL joined with its file name; the artifacts are sorted by name.
+func LoadArtifacts(dir, name, baseURL string) ([]Artifact, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifacts: %w", err)
+	}
+
+	var artifacts []Artifact
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		artifact, ok := ParseArtifactName(name, entry.Name())
+		if !ok {
+			continue
+		}
+		sum, size, err := checksum(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		artifact.SHA256 = sum
+		artifact.Size = size
+		artifact.URL = strings.TrimSuffix(baseURL, "/") + "/" + entry.Name()
+		artifacts = append(artifacts, artifact)
+	}
+
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Name < artifacts[j].Name })
+	return artifacts, nil
+}
+
+// GitHubDownloadURL returns the base URL of a GitHub release's assets, e.g.
+// https://github.com/glide-cli/glide/releases/download/v3.2.0
+func GitHubDownloadURL(repositoryURL, version string) string {
+	return strings.TrimSuffix(repositoryURL, "/") + "/releases/download/" + version
+}
+
+// checksum returns the SHA256 and size of a file
+func checksum(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to hash %s: %w", filepath.Base(path), err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// find returns the first artifact of a kind for an OS and architecture
+func find(artifacts []Artifact, kind, osName, arch string) (Artifact, bool) {
+	for _, a := range artifacts {
+		if a.Kind == kind && a.OS == osName && a.Arch == arch {
+			return a, true
+		}
+	}
+	return Artifact{}, false
+}
+
+// ofKind returns the artifacts of a kind
+func ofKind(artifacts []Artifact, kind string) []Artifact {
+	var matched []Artifact
+	for _, a := range artifacts {
+		if a.Kind == kind {
+			matched = append(matched, a)
+		}
+	}
+	return matched
+}
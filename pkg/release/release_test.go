@@ -0,0 +1,213 @@
+package release
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBaseURL = "https://example.com/releases/download/v3.2.0"
+
+func testInfo() Info {
+	return Info{
+		Name:        "mycli",
+		Version:     "v3.2.0",
+		Description: "MyProject context-aware development CLI",
+		Homepage:    "https://example.com/mycli",
+		License:     "MIT",
+		Maintainer:  "Platform Team <platform@example.com>",
+	}
+}
+
+func writeArtifacts(t *testing.T, files ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("contents of "+name), 0644))
+	}
+	return dir
+}
+
+func TestParseArtifactName(t *testing.T) {
+	tests := []struct {
+		file string
+		ok   bool
+		kind string
+		os   string
+		arch string
+	}{
+		{"mycli-darwin-arm64", true, KindBinary, "darwin", "arm64"},
+		{"mycli-windows-amd64.exe", true, KindBinary, "windows", "amd64"},
+		{"mycli-linux-amd64.deb", true, KindDeb, "linux", "amd64"},
+		{"mycli-linux-arm64.rpm", true, KindRPM, "linux", "arm64"},
+		{"mycli-linux-amd64.sha256", false, "", "", ""},
+		{"checksums.txt", false, "", "", ""},
+		{"other-linux-amd64", false, "", "", ""},
+		{"mycli-linux", false, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			artifact, ok := ParseArtifactName("mycli", tt.file)
+			assert.Equal(t, tt.ok, ok)
+			if ok {
+				assert.Equal(t, tt.kind, artifact.Kind)
+				assert.Equal(t, tt.os, artifact.OS)
+				assert.Equal(t, tt.arch, artifact.Arch)
+			}
+		})
+	}
+}
+
+func TestLoadArtifacts(t *testing.T) {
+	dir := writeArtifacts(t, "mycli-linux-amd64", "mycli-darwin-arm64", "checksums.txt")
+
+	artifacts, err := LoadArtifacts(dir, "mycli", testBaseURL+"/")
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+
+	assert.Equal(t, "mycli-darwin-arm64", artifacts[0].Name)
+	assert.Equal(t, testBaseURL+"/mycli-darwin-arm64", artifacts[0].URL)
+	assert.Equal(t, int64(len("contents of mycli-darwin-arm64")), artifacts[0].Size)
+	assert.Len(t, artifacts[0].SHA256, 64)
+}
+
+func TestHomebrew(t *testing.T) {
+	dir := writeArtifacts(t, "mycli-darwin-arm64", "mycli-darwin-amd64", "mycli-linux-amd64", "mycli-windows-amd64.exe")
+	artifacts, err := LoadArtifacts(dir, "mycli", testBaseURL)
+	require.NoError(t, err)
+
+	formula, err := Homebrew(testInfo(), artifacts)
+	require.NoError(t, err)
+
+	assert.Contains(t, formula, "class Mycli < Formula")
+	assert.Contains(t, formula, `version "3.2.0"`)
+	assert.Contains(t, formula, `url "`+testBaseURL+`/mycli-darwin-arm64"`)
+	assert.Contains(t, formula, "on_linux do")
+	assert.NotContains(t, formula, "windows")
+	assert.Contains(t, formula, `bin.install Dir["mycli-*"].first => "mycli"`)
+	// Arm is listed before Intel, and linux has no arm binary
+	assert.Less(t, strings.Index(formula, "on_arm"), strings.Index(formula, "on_intel"))
+	assert.Equal(t, 1, strings.Count(formula, "on_arm"))
+
+	_, err = Homebrew(testInfo(), nil)
+	assert.Error(t, err)
+}
+
+func TestFormulaClass(t *testing.T) {
+	assert.Equal(t, "Glide", formulaClass("glide"))
+	assert.Equal(t, "MyCli", formulaClass("my-cli"))
+	assert.Equal(t, "AcmeDevTool", formulaClass("acme_dev.tool"))
+}
+
+func TestScoop(t *testing.T) {
+	dir := writeArtifacts(t, "mycli-windows-amd64.exe", "mycli-windows-arm64.exe", "mycli-linux-amd64")
+	artifacts, err := LoadArtifacts(dir, "mycli", testBaseURL)
+	require.NoError(t, err)
+
+	data, err := Scoop(testInfo(), artifacts)
+	require.NoError(t, err)
+
+	var manifest ScoopManifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, "3.2.0", manifest.Version)
+	require.Len(t, manifest.Architecture, 2)
+	assert.Equal(t, testBaseURL+"/mycli-windows-amd64.exe", manifest.Architecture["64bit"].URL)
+	assert.Equal(t, [][]string{{"mycli-windows-amd64.exe", "mycli"}}, manifest.Architecture["64bit"].Bin)
+	assert.Contains(t, manifest.Architecture, "arm64")
+
+	_, err = Scoop(testInfo(), ofKind(artifacts, KindDeb))
+	assert.Error(t, err)
+}
+
+func TestAptPackagesAndRelease(t *testing.T) {
+	dir := writeArtifacts(t, "mycli-linux-amd64.deb", "mycli-linux-arm64.deb", "mycli-linux-amd64")
+	artifacts, err := LoadArtifacts(dir, "mycli", testBaseURL)
+	require.NoError(t, err)
+
+	info := testInfo()
+	info.Version = "v3.2.0-rc.1"
+	packages, err := AptPackages(info, artifacts)
+	require.NoError(t, err)
+
+	stanzas := strings.Split(packages, "\n\n")
+	require.Len(t, stanzas, 2)
+	assert.Contains(t, stanzas[0], "Package: mycli\n")
+	assert.Contains(t, stanzas[0], "Version: 3.2.0~rc.1\n")
+	assert.Contains(t, stanzas[0], "Architecture: amd64\n")
+	assert.Contains(t, stanzas[0], "Filename: mycli-linux-amd64.deb\n")
+	assert.Contains(t, stanzas[1], "Architecture: arm64\n")
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	release := AptRelease(info, artifacts, map[string]string{"Packages": packages}, now)
+	assert.Contains(t, release, "Date: Sun, 01 Mar 2026 12:00:00 UTC\n")
+	assert.Contains(t, release, "Architectures: amd64 arm64\n")
+	assert.Regexp(t, `SHA256:\n [0-9a-f]{64} \d+ Packages\n`, release)
+
+	_, err = AptPackages(info, artifacts[:1])
+	assert.Error(t, err)
+}
+
+func TestRPMRepodata(t *testing.T) {
+	dir := writeArtifacts(t, "mycli-linux-amd64.rpm", "mycli-linux-arm64.rpm")
+	artifacts, err := LoadArtifacts(dir, "mycli", testBaseURL)
+	require.NoError(t, err)
+
+	primary, repomd, err := RPMRepodata(testInfo(), artifacts, time.Unix(1700000000, 0))
+	require.NoError(t, err)
+
+	var metadata rpmPrimary
+	require.NoError(t, xml.Unmarshal(primary, &metadata))
+	require.Len(t, metadata.Package, 2)
+	assert.Equal(t, "x86_64", metadata.Package[0].Arch)
+	assert.Equal(t, "aarch64", metadata.Package[1].Arch)
+	assert.Equal(t, "3.2.0", metadata.Package[0].Version.Ver)
+	assert.Equal(t, "mycli-linux-amd64.rpm", metadata.Package[0].Location.Href)
+	assert.Contains(t, string(primary), `packages="2"`)
+
+	assert.Contains(t, string(repomd), `<data type="primary">`)
+	assert.Contains(t, string(repomd), `<location href="repodata/primary.xml"></location>`)
+	assert.Contains(t, string(repomd), "<revision>1700000000</revision>")
+
+	_, _, err = RPMRepodata(testInfo(), nil, time.Now())
+	assert.Error(t, err)
+}
+
+func TestGenerateAndWriteFiles(t *testing.T) {
+	dir := writeArtifacts(t, "mycli-darwin-arm64", "mycli-linux-amd64.deb")
+	artifacts, err := LoadArtifacts(dir, "mycli", testBaseURL)
+	require.NoError(t, err)
+
+	files, err := Generate(testInfo(), artifacts, time.Now())
+	require.NoError(t, err)
+
+	out := t.TempDir()
+	paths, err := WriteFiles(out, files)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join("apt", "Packages"),
+		filepath.Join("apt", "Release"),
+		filepath.Join("homebrew", "Formula", "mycli.rb"),
+	}, paths)
+	assert.FileExists(t, filepath.Join(out, "homebrew", "Formula", "mycli.rb"))
+
+	_, err = Generate(testInfo(), nil, time.Now())
+	assert.Error(t, err)
+
+	_, err = Generate(Info{Name: "mycli"}, artifacts, time.Now())
+	assert.Error(t, err)
+}
+
+func TestNewInfo(t *testing.T) {
+	info := NewInfo("v1.0.0")
+	assert.Equal(t, "glide", info.Name)
+	assert.Equal(t, "1.0.0", info.PackageVersion())
+	assert.NotEmpty(t, info.Homepage)
+}
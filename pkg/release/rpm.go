@@ -0,0 +1,176 @@
+package release
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// rpmArchitectures maps Go architectures to RPM's
+var rpmArchitectures = map[string]string{
+	"amd64": "x86_64",
+	"386":   "i686",
+	"arm64": "aarch64",
+	"arm":   "armv7hl",
+}
+
+// rpmPrimary is repodata/primary.xml
+type rpmPrimary struct {
+	XMLName  xml.Name     `xml:"metadata"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	XmlnsRPM string       `xml:"xmlns:rpm,attr"`
+	Packages int          `xml:"packages,attr"`
+	Package  []rpmPackage `xml:"package"`
+}
+
+type rpmPackage struct {
+	Type        string      `xml:"type,attr"`
+	Name        string      `xml:"name"`
+	Arch        string      `xml:"arch"`
+	Version     rpmVersion  `xml:"version"`
+	Checksum    rpmChecksum `xml:"checksum"`
+	Summary     string      `xml:"summary"`
+	Description string      `xml:"description"`
+	Packager    string      `xml:"packager,omitempty"`
+	URL         string      `xml:"url,omitempty"`
+	Time        rpmTime     `xml:"time"`
+	Size        rpmSize     `xml:"size"`
+	Location    rpmLocation `xml:"location"`
+	Format      rpmFormat   `xml:"format"`
+}
+
+type rpmVersion struct {
+	Epoch string `xml:"epoch,attr"`
+	Ver   string `xml:"ver,attr"`
+	Rel   string `xml:"rel,attr"`
+}
+
+type rpmEntry struct {
+	Name  string `xml:"name,attr"`
+	Flags string `xml:"flags,attr"`
+	Epoch string `xml:"epoch,attr"`
+	Ver   string `xml:"ver,attr"`
+	Rel   string `xml:"rel,attr"`
+}
+
+type rpmChecksum struct {
+	Type  string `xml:"type,attr"`
+	PkgID string `xml:"pkgid,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type rpmTime struct {
+	File  int64 `xml:"file,attr"`
+	Build int64 `xml:"build,attr"`
+}
+
+type rpmSize struct {
+	Package int64 `xml:"package,attr"`
+}
+
+type rpmLocation struct {
+	Href string `xml:"href,attr"`
+}
+
+type rpmFormat struct {
+	License  string     `xml:"rpm:license,omitempty"`
+	Provides []rpmEntry `xml:"rpm:provides>rpm:entry"`
+}
+
+// rpmRepomd is repodata/repomd.xml
+type rpmRepomd struct {
+	XMLName  xml.Name  `xml:"repomd"`
+	Xmlns    string    `xml:"xmlns,attr"`
+	XmlnsRPM string    `xml:"xmlns:rpm,attr"`
+	Revision int64     `xml:"revision"`
+	Data     []rpmData `xml:"data"`
+}
+
+type rpmData struct {
+	Type      string      `xml:"type,attr"`
+	Checksum  rpmChecksum `xml:"checksum"`
+	Location  rpmLocation `xml:"location"`
+	Timestamp int64       `xml:"timestamp"`
+	Size      int         `xml:"size"`
+}
+
+// RPMRepodata generates the repodata/primary.xml and repodata/repomd.xml
+// of a yum/dnf repository serving the .rpm artifacts, which are published
+// in the repository's root
+func RPMRepodata(info Info, artifacts []Artifact, now time.Time) (primary, repomd []byte, err error) {
+	if err := info.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	ver := packageVersion(info)
+	metadata := rpmPrimary{
+		Xmlns:    "http://linux.duke.edu/metadata/common",
+		XmlnsRPM: "http://linux.duke.edu/metadata/rpm",
+	}
+	for _, artifact := range ofKind(artifacts, KindRPM) {
+		arch, ok := rpmArchitectures[artifact.Arch]
+		if artifact.OS != "linux" || !ok {
+			continue
+		}
+		metadata.Package = append(metadata.Package, rpmPackage{
+			Type:        "rpm",
+			Name:        info.Name,
+			Arch:        arch,
+			Version:     rpmVersion{Epoch: "0", Ver: ver, Rel: "1"},
+			Checksum:    rpmChecksum{Type: "sha256", PkgID: "YES", Value: artifact.SHA256},
+			Summary:     info.Description,
+			Description: info.Description,
+			Packager:    info.Maintainer,
+			URL:         info.Homepage,
+			Time:        rpmTime{File: now.Unix(), Build: now.Unix()},
+			Size:        rpmSize{Package: artifact.Size},
+			Location:    rpmLocation{Href: artifact.Name},
+			Format: rpmFormat{
+				License:  info.License,
+				Provides: []rpmEntry{{Name: info.Name, Flags: "EQ", Epoch: "0", Ver: ver, Rel: "1"}},
+			},
+		})
+	}
+	if len(metadata.Package) == 0 {
+		return nil, nil, fmt.Errorf("no .rpm packages to index")
+	}
+	metadata.Packages = len(metadata.Package)
+
+	if primary, err = marshalXML(metadata); err != nil {
+		return nil, nil, err
+	}
+
+	sum := sha256.Sum256(primary)
+	if repomd, err = marshalXML(rpmRepomd{
+		Xmlns:    "http://linux.duke.edu/metadata/repo",
+		XmlnsRPM: "http://linux.duke.edu/metadata/rpm",
+		Revision: now.Unix(),
+		Data: []rpmData{{
+			Type:      "primary",
+			Checksum:  rpmChecksum{Type: "sha256", Value: hex.EncodeToString(sum[:])},
+			Location:  rpmLocation{Href: "repodata/primary.xml"},
+			Timestamp: now.Unix(),
+			Size:      len(primary),
+		}},
+	}); err != nil {
+		return nil, nil, err
+	}
+	return primary, repomd, nil
+}
+
+// RPMRepoFile generates the .repo file users drop into /etc/yum.repos.d to
+// install from the repository at baseURL
+func RPMRepoFile(info Info, baseURL string) string {
+	return fmt.Sprintf("[%s]\nname=%s\nbaseurl=%s\nenabled=1\ngpgcheck=0\n", info.Name, info.Description, baseURL)
+}
+
+// marshalXML encodes a metadata document with its XML declaration
+func marshalXML(v any) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode repository metadata: %w", err)
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
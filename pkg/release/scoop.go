@@ -0,0 +1,66 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ScoopManifest is a Scoop app manifest
+type ScoopManifest struct {
+	Version      string                       `json:"version"`
+	Description  string                       `json:"description,omitempty"`
+	Homepage     string                       `json:"homepage,omitempty"`
+	License      string                       `json:"license,omitempty"`
+	Architecture map[string]ScoopArchitecture `json:"architecture"`
+}
+
+// ScoopArchitecture is the download of one architecture. Bin renames the
+// downloaded binary, e.g. glide-windows-amd64.exe, to the command name.
+type ScoopArchitecture struct {
+	URL  string     `json:"url"`
+	Hash string     `json:"hash"`
+	Bin  [][]string `json:"bin"`
+}
+
+// scoopArchitectures maps Go architectures to Scoop's
+var scoopArchitectures = map[string]string{
+	"amd64": "64bit",
+	"386":   "32bit",
+	"arm64": "arm64",
+}
+
+// Scoop generates a Scoop manifest installing the Windows binaries among
+// artifacts. The manifest is written to bucket/<name>.json in a bucket.
+func Scoop(info Info, artifacts []Artifact) ([]byte, error) {
+	if err := info.Validate(); err != nil {
+		return nil, err
+	}
+
+	manifest := ScoopManifest{
+		Version:      info.PackageVersion(),
+		Description:  info.Description,
+		Homepage:     info.Homepage,
+		License:      info.License,
+		Architecture: make(map[string]ScoopArchitecture),
+	}
+	for _, artifact := range ofKind(artifacts, KindBinary) {
+		arch, ok := scoopArchitectures[artifact.Arch]
+		if artifact.OS != "windows" || !ok {
+			continue
+		}
+		manifest.Architecture[arch] = ScoopArchitecture{
+			URL:  artifact.URL,
+			Hash: artifact.SHA256,
+			Bin:  [][]string{{artifact.Name, info.Name}},
+		}
+	}
+	if len(manifest.Architecture) == 0 {
+		return nil, fmt.Errorf("no Windows binaries to build a manifest from")
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return append(data, '\n'), nil
+}
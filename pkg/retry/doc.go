@@ -0,0 +1,31 @@
+// Package retry retries operations that fail transiently, such as network
+// requests and plugin handshakes, with exponential backoff.
+//
+// # Basic Usage
+//
+//	err := retry.Do(ctx, retry.DefaultPolicy(), func(ctx context.Context) error {
+//	    return fetch(ctx)
+//	})
+//
+// Waits grow from InitialInterval by Multiplier up to MaxInterval, and each
+// is randomized by Jitter so that many clients do not retry in lockstep.
+// Retrying stops after MaxAttempts attempts or once MaxElapsed has passed,
+// returning the last error.
+//
+// # Permanent Errors
+//
+// Errors that retrying cannot fix, such as a 404, stop retrying at once
+// when wrapped with Permanent:
+//
+//	if resp.StatusCode == http.StatusNotFound {
+//	    return retry.Permanent(fmt.Errorf("no such release"))
+//	}
+//
+// Do returns the wrapped error, not the wrapper.
+//
+// # Cancellation
+//
+// Do stops waiting as soon as the context is done and returns the context's
+// error joined with the last error, so both errors.Is(err, context.Canceled)
+// and the operation's own error checks hold.
+package retry
@@ -0,0 +1,113 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/logging"
+)
+
+// Policy decides how often and how long an operation is retried
+type Policy struct {
+	// InitialInterval is the wait before the first retry
+	InitialInterval time.Duration
+	// MaxInterval caps the wait between attempts
+	MaxInterval time.Duration
+	// Multiplier grows the wait after every attempt
+	Multiplier float64
+	// Jitter randomizes each wait by up to this fraction, e.g. 0.2 for ±20%
+	Jitter float64
+	// MaxElapsed stops retrying once this much time has passed; zero means
+	// no limit
+	MaxElapsed time.Duration
+	// MaxAttempts stops retrying after this many attempts, the first
+	// included; zero means no limit
+	MaxAttempts int
+}
+
+// DefaultPolicy retries for up to 3 attempts, waiting about 500ms and 1s
+func DefaultPolicy() Policy {
+	return Policy{
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+		MaxElapsed:      15 * time.Second,
+		MaxAttempts:     3,
+	}
+}
+
+// permanentError marks an error that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent stops Do from retrying err. Permanent(nil) is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Do runs op until it succeeds, fails permanently, the policy gives up, or
+// ctx is done, and returns op's last error
+func Do(ctx context.Context, policy Policy, op func(ctx context.Context) error) error {
+	started := time.Now()
+	wait := policy.InitialInterval
+
+	for attempt := 1; ; attempt++ {
+		err := op(ctx)
+		if err == nil {
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if ctx.Err() != nil {
+			return errors.Join(ctx.Err(), err)
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		delay := policy.jitter(wait)
+		if policy.MaxElapsed > 0 && time.Since(started)+delay > policy.MaxElapsed {
+			return err
+		}
+		logging.Debug("Retrying after a failed attempt", "attempt", attempt, "delay", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+		wait = policy.next(wait)
+	}
+}
+
+// next returns the wait after wait
+func (p Policy) next(wait time.Duration) time.Duration {
+	next := time.Duration(float64(wait) * max(p.Multiplier, 1))
+	if p.MaxInterval > 0 && next > p.MaxInterval {
+		return p.MaxInterval
+	}
+	return next
+}
+
+// jitter randomizes wait by up to the policy's jitter fraction
+func (p Policy) jitter(wait time.Duration) time.Duration {
+	if p.Jitter <= 0 || wait <= 0 {
+		return wait
+	}
+	spread := float64(wait) * min(p.Jitter, 1)
+	return wait + time.Duration(spread*(2*rand.Float64()-1))
+}
@@ -0,0 +1,102 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastPolicy retries quickly and without jitter
+func fastPolicy() Policy {
+	return Policy{InitialInterval: time.Millisecond, MaxInterval: 4 * time.Millisecond, Multiplier: 2, MaxAttempts: 5}
+}
+
+var errFlaky = errors.New("connection reset")
+
+func TestDo(t *testing.T) {
+	t.Run("retries until the operation succeeds", func(t *testing.T) {
+		attempts := 0
+		err := Do(context.Background(), fastPolicy(), func(context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errFlaky
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("gives up after MaxAttempts with the last error", func(t *testing.T) {
+		attempts := 0
+		err := Do(context.Background(), fastPolicy(), func(context.Context) error {
+			attempts++
+			return errFlaky
+		})
+		assert.ErrorIs(t, err, errFlaky)
+		assert.Equal(t, 5, attempts)
+	})
+
+	t.Run("permanent errors stop retrying", func(t *testing.T) {
+		attempts := 0
+		notFound := errors.New("not found")
+		err := Do(context.Background(), fastPolicy(), func(context.Context) error {
+			attempts++
+			return Permanent(notFound)
+		})
+		assert.Equal(t, notFound, err, "the wrapped error is returned")
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("gives up once MaxElapsed has passed", func(t *testing.T) {
+		policy := Policy{InitialInterval: 50 * time.Millisecond, Multiplier: 1, MaxElapsed: 120 * time.Millisecond}
+		attempts := 0
+		err := Do(context.Background(), policy, func(context.Context) error {
+			attempts++
+			return errFlaky
+		})
+		assert.ErrorIs(t, err, errFlaky)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("cancellation stops the wait", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		policy := Policy{InitialInterval: time.Hour}
+		started := time.Now()
+		err := Do(ctx, policy, func(context.Context) error {
+			cancel()
+			return errFlaky
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, err, errFlaky)
+		assert.Less(t, time.Since(started), time.Second)
+	})
+}
+
+func TestPolicy_Next(t *testing.T) {
+	p := Policy{Multiplier: 2, MaxInterval: 3 * time.Second}
+	assert.Equal(t, 2*time.Second, p.next(time.Second))
+	assert.Equal(t, 3*time.Second, p.next(2*time.Second), "capped at MaxInterval")
+	assert.Equal(t, time.Second, Policy{}.next(time.Second), "no multiplier keeps the wait")
+}
+
+func TestPolicy_Jitter(t *testing.T) {
+	p := Policy{Jitter: 0.2}
+	for range 100 {
+		wait := p.jitter(time.Second)
+		assert.GreaterOrEqual(t, wait, 800*time.Millisecond)
+		assert.LessOrEqual(t, wait, 1200*time.Millisecond)
+	}
+	assert.Equal(t, time.Second, Policy{}.jitter(time.Second))
+}
+
+func TestPermanent(t *testing.T) {
+	assert.NoError(t, Permanent(nil))
+	err := Permanent(errFlaky)
+	assert.ErrorIs(t, err, errFlaky)
+	assert.Equal(t, errFlaky.Error(), err.Error())
+}
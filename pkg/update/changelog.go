@@ -0,0 +1,279 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/glide-cli/glide/v3/pkg/output"
+)
+
+var (
+	// GitHub API endpoint for a release by tag
+	githubReleaseByTagURL = "https://api.github.com/repos/ivannovak/glide/releases/tags/%s"
+)
+
+// Kinds of release note sections, in the order they are shown
+const (
+	SectionBreaking = "breaking"
+	SectionFeatures = "features"
+	SectionFixes    = "fixes"
+	SectionOther    = "other"
+)
+
+// sectionOrder ranks the kinds of sections
+var sectionOrder = []string{SectionBreaking, SectionFeatures, SectionFixes, SectionOther}
+
+// sectionTitles are the headings sections are rendered under
+var sectionTitles = map[string]string{
+	SectionBreaking: "⚠ Breaking changes",
+	SectionFeatures: "Features",
+	SectionFixes:    "Fixes",
+	SectionOther:    "Other changes",
+}
+
+var (
+	// commitLink matches the commit references release tooling appends to
+	// entries, e.g. " ([abc1234](https://github.com/.../commit/abc1234))"
+	commitLink = regexp.MustCompile(`\s*\(\[[0-9a-f]{7,40}\]\([^)]*\)\)`)
+	// boldScope matches a bold scope prefix such as "**config:** "
+	boldScope = regexp.MustCompile(`^\*\*([^*]+?):?\*\*:?\s*`)
+	// conventional matches a conventional commit prefix such as "feat(api)!: "
+	conventional = regexp.MustCompile(`^(feat|feature|fix|perf|refactor|docs|chore|build|ci|style|test|revert)(\([^)]*\))?(!)?:\s*`)
+)
+
+// Section is a group of release note entries
+type Section struct {
+	Kind  string   `json:"kind" yaml:"kind"`
+	Items []string `json:"items" yaml:"items"`
+}
+
+// ReleaseNotes are the parsed notes of a release
+type ReleaseNotes struct {
+	Version     string    `json:"version" yaml:"version"`
+	PublishedAt time.Time `json:"published_at" yaml:"published_at"`
+	URL         string    `json:"url,omitempty" yaml:"url,omitempty"`
+	Sections    []Section `json:"sections" yaml:"sections"`
+}
+
+// NewReleaseNotes parses the notes of a release
+func NewReleaseNotes(release *Release) ReleaseNotes {
+	return ReleaseNotes{
+		Version:     release.TagName,
+		PublishedAt: release.PublishedAt,
+		URL:         release.HTMLURL,
+		Sections:    ParseReleaseNotes(release.Body),
+	}
+}
+
+// ParseReleaseNotes splits a Markdown release body into breaking changes,
+// features, fixes, and other changes. Entries are grouped by the heading
+// they are listed under ("Features", "Bug Fixes", "BREAKING CHANGES", ...),
+// or by their conventional commit prefix ("feat:", "fix!:") when no heading
+// says. Empty sections are omitted.
+func ParseReleaseNotes(body string) []Section {
+	items := make(map[string][]string)
+	heading := ""
+	last := ""
+
+	for _, raw := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "":
+			last = ""
+		case strings.HasPrefix(line, "#"):
+			heading = classifyHeading(strings.TrimLeft(line, "# "))
+			last = ""
+		case isBullet(line):
+			// Classify before scopes are unbolded, so "**perf:** ..." stays
+			// a scope rather than a commit type
+			kind, text := classifyEntry(heading, strings.TrimSpace(line[2:]))
+			if text = cleanEntry(text); text == "" {
+				continue
+			}
+			items[kind] = append(items[kind], text)
+			last = kind
+		case last != "" && raw != line:
+			// An indented line continues the previous entry
+			entries := items[last]
+			entries[len(entries)-1] += " " + cleanEntry(line)
+		case heading != "":
+			// Paragraphs under a heading, e.g. a breaking change's migration
+			// notes, are entries too
+			if text := cleanEntry(line); text != "" {
+				items[heading] = append(items[heading], text)
+			}
+		}
+	}
+
+	var sections []Section
+	for _, kind := range sectionOrder {
+		if len(items[kind]) > 0 {
+			sections = append(sections, Section{Kind: kind, Items: items[kind]})
+		}
+	}
+	return sections
+}
+
+// isBullet reports whether a trimmed line is a list entry
+func isBullet(line string) bool {
+	return len(line) > 2 && strings.ContainsRune("-*+", rune(line[0])) && line[1] == ' '
+}
+
+// classifyHeading returns the kind of section a heading starts, or "" for
+// headings that do not group entries, such as the version title
+func classifyHeading(text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "breaking"):
+		return SectionBreaking
+	case strings.Contains(lower, "feature"), strings.Contains(lower, "added"), strings.Contains(lower, "new"):
+		return SectionFeatures
+	case strings.Contains(lower, "fix"), strings.Contains(lower, "bug"):
+		return SectionFixes
+	case strings.Contains(lower, "change"), strings.Contains(lower, "perf"), strings.Contains(lower, "improve"),
+		strings.Contains(lower, "refactor"), strings.Contains(lower, "doc"), strings.Contains(lower, "other"):
+		return SectionOther
+	default:
+		return ""
+	}
+}
+
+// classifyEntry returns the kind of an entry and its text without a
+// conventional commit prefix
+func classifyEntry(heading, text string) (string, string) {
+	kind := heading
+	if m := conventional.FindStringSubmatch(text); m != nil {
+		prefixKind := SectionOther
+		switch strings.ToLower(m[1]) {
+		case "feat", "feature":
+			prefixKind = SectionFeatures
+		case "fix":
+			prefixKind = SectionFixes
+		}
+		if m[3] == "!" {
+			prefixKind = SectionBreaking
+		}
+		if kind == "" || prefixKind == SectionBreaking {
+			kind = prefixKind
+		}
+		text = strings.TrimSpace(text[len(m[0]):])
+		if m[2] != "" {
+			text = strings.Trim(m[2], "()") + ": " + text
+		}
+	}
+	if strings.HasPrefix(text, "BREAKING CHANGE") {
+		kind = SectionBreaking
+		text = strings.TrimSpace(strings.TrimLeft(strings.TrimPrefix(strings.TrimPrefix(text, "BREAKING CHANGES"), "BREAKING CHANGE"), ":"))
+	}
+	if kind == "" {
+		kind = SectionOther
+	}
+	return kind, text
+}
+
+// cleanEntry drops commit links and bold scope markers from an entry
+func cleanEntry(text string) string {
+	text = commitLink.ReplaceAllString(text, "")
+	text = boldScope.ReplaceAllString(text, "$1: ")
+	return strings.TrimSpace(text)
+}
+
+// Render formats the notes for the terminal, with a colored heading per
+// section
+func (n ReleaseNotes) Render() string {
+	var b strings.Builder
+	b.WriteString(output.Bold("Glide %s", n.Version))
+	if !n.PublishedAt.IsZero() {
+		b.WriteString(output.Faint(" (released %s)", n.PublishedAt.Format("2006-01-02")))
+	}
+	b.WriteString("\n")
+
+	if len(n.Sections) == 0 {
+		b.WriteString("\nNo release notes were published for this version.\n")
+	}
+	for _, section := range n.Sections {
+		b.WriteString("\n")
+		title := sectionTitles[section.Kind]
+		switch section.Kind {
+		case SectionBreaking:
+			b.WriteString(output.ErrorText("%s", title))
+		case SectionFeatures:
+			b.WriteString(output.SuccessText("%s", title))
+		case SectionFixes:
+			b.WriteString(output.InfoText("%s", title))
+		default:
+			b.WriteString(output.Bold("%s", title))
+		}
+		b.WriteString("\n")
+		for _, item := range section.Items {
+			b.WriteString("  • " + item + "\n")
+		}
+	}
+
+	if n.URL != "" {
+		b.WriteString("\n" + output.Faint("Full release notes: %s", output.URL(n.URL)) + "\n")
+	}
+	return b.String()
+}
+
+// FetchReleaseNotes fetches and parses the notes of a release. version is
+// a tag such as v3.2.0 (the "v" may be omitted), or "latest".
+func (c *Checker) FetchReleaseNotes(ctx context.Context, version string) (ReleaseNotes, error) {
+	var release *Release
+	var err error
+	if version == "latest" {
+		release, err = c.fetchLatestRelease(ctx)
+	} else {
+		release, err = c.fetchReleaseByTag(ctx, normalizeTag(version))
+	}
+	if err != nil {
+		return ReleaseNotes{}, err
+	}
+	return NewReleaseNotes(release), nil
+}
+
+// normalizeTag adds the "v" prefix release tags have
+func normalizeTag(version string) string {
+	if version != "" && version[0] >= '0' && version[0] <= '9' {
+		return "v" + version
+	}
+	return version
+}
+
+// fetchReleaseByTag fetches the release information of a tag from GitHub
+func (c *Checker) fetchReleaseByTag(ctx context.Context, tag string) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(githubReleaseByTagURL, url.PathEscape(tag)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "glide-cli-updater")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("no release %s was found", tag)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitHub API returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &release, nil
+}
@@ -0,0 +1,101 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReleaseNotes(t *testing.T) {
+	t.Run("semantic-release headings", func(t *testing.T) {
+		body := "## [3.2.0](https://github.com/ivannovak/glide/compare/v3.1.0...v3.2.0) (2026-10-01)\n" +
+			"\n" +
+			"### ⚠ BREAKING CHANGES\n" +
+			"\n" +
+			"* **config:** `defaults.docker` moved to `docker`\n" +
+			"\n" +
+			"### Features\n" +
+			"\n" +
+			"* **perf:** add budget overrides ([abc1234](https://github.com/ivannovak/glide/commit/abc1234))\n" +
+			"* add `glide perf report`\n" +
+			"  with flaky detection\n" +
+			"\n" +
+			"### Bug Fixes\n" +
+			"\n" +
+			"* detect terminals correctly ([def5678](https://github.com/ivannovak/glide/commit/def5678))\n"
+
+		sections := ParseReleaseNotes(body)
+		assert.Equal(t, []Section{
+			{Kind: SectionBreaking, Items: []string{"config: `defaults.docker` moved to `docker`"}},
+			{Kind: SectionFeatures, Items: []string{"perf: add budget overrides", "add `glide perf report` with flaky detection"}},
+			{Kind: SectionFixes, Items: []string{"detect terminals correctly"}},
+		}, sections)
+	})
+
+	t.Run("conventional commit entries without headings", func(t *testing.T) {
+		body := "- feat(api): add streaming\r\n- fix: handle EOF\r\n- feat!: drop v1 plugins\r\n- chore: bump deps\r\n"
+		sections := ParseReleaseNotes(body)
+		assert.Equal(t, []Section{
+			{Kind: SectionBreaking, Items: []string{"drop v1 plugins"}},
+			{Kind: SectionFeatures, Items: []string{"api: add streaming"}},
+			{Kind: SectionFixes, Items: []string{"handle EOF"}},
+			{Kind: SectionOther, Items: []string{"bump deps"}},
+		}, sections)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		assert.Empty(t, ParseReleaseNotes(""))
+	})
+}
+
+func TestReleaseNotesRender(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	notes := ReleaseNotes{
+		Version:     "v3.2.0",
+		PublishedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		URL:         "https://github.com/ivannovak/glide/releases/tag/v3.2.0",
+		Sections:    []Section{{Kind: SectionFixes, Items: []string{"handle EOF"}}},
+	}
+	rendered := notes.Render()
+	assert.Contains(t, rendered, "Glide v3.2.0 (released 2026-10-01)")
+	assert.Contains(t, rendered, "Fixes\n  • handle EOF\n")
+	assert.Contains(t, rendered, notes.URL)
+
+	assert.Contains(t, ReleaseNotes{Version: "v3.1.0"}.Render(), "No release notes")
+}
+
+func TestFetchReleaseNotes(t *testing.T) {
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		if r.URL.Path != "/tags/v3.2.0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(Release{TagName: "v3.2.0", Body: "### Bug Fixes\n* handle EOF\n"})
+	}))
+	defer server.Close()
+
+	oldURL := githubReleaseByTagURL
+	githubReleaseByTagURL = server.URL + "/tags/%s"
+	defer func() { githubReleaseByTagURL = oldURL }()
+
+	checker := NewChecker("v3.1.0")
+	notes, err := checker.FetchReleaseNotes(context.Background(), "3.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "/tags/v3.2.0", requested, "the v prefix is added")
+	assert.Equal(t, "v3.2.0", notes.Version)
+	assert.Equal(t, []Section{{Kind: SectionFixes, Items: []string{"handle EOF"}}}, notes.Sections)
+
+	_, err = checker.FetchReleaseNotes(context.Background(), "v9.9.9")
+	assert.ErrorContains(t, err, "no release v9.9.9")
+}
@@ -11,11 +11,26 @@ import (
 	"time"
 
 	"github.com/Masterminds/semver/v3"
+
+	"github.com/glide-cli/glide/v3/pkg/output"
+	"github.com/glide-cli/glide/v3/pkg/ratelimit"
+	"github.com/glide-cli/glide/v3/pkg/retry"
 )
 
 var (
 	// GitHub API endpoint for latest release
 	githubAPIURL = "https://api.github.com/repos/ivannovak/glide/releases/latest"
+
+	// GitHub API endpoint for recent releases, including prereleases
+	githubReleasesURL = "https://api.github.com/repos/ivannovak/glide/releases?per_page=20"
+)
+
+// Update channels
+const (
+	// ChannelStable offers stable releases only
+	ChannelStable = "stable"
+	// ChannelPrerelease also offers betas and release candidates
+	ChannelPrerelease = "prerelease"
 )
 
 const (
@@ -31,6 +46,8 @@ type Release struct {
 	PublishedAt time.Time `json:"published_at"`
 	HTMLURL     string    `json:"html_url"`
 	Assets      []Asset   `json:"assets"`
+	Draft       bool      `json:"draft,omitempty"`
+	Prerelease  bool      `json:"prerelease,omitempty"`
 }
 
 // Asset represents a release asset
@@ -54,8 +71,12 @@ type UpdateInfo struct {
 
 // Checker handles version update checking
 type Checker struct {
+	// Channel is the update channel, ChannelStable if empty
+	Channel string
+
 	currentVersion string
 	httpClient     *http.Client
+	retryPolicy    retry.Policy
 }
 
 // NewChecker creates a new update checker
@@ -63,8 +84,10 @@ func NewChecker(currentVersion string) *Checker {
 	return &Checker{
 		currentVersion: currentVersion,
 		httpClient: &http.Client{
-			Timeout: requestTimeout,
+			Timeout:   requestTimeout,
+			Transport: ratelimit.Default.Transport(nil),
 		},
+		retryPolicy: retry.DefaultPolicy(),
 	}
 }
 
@@ -130,34 +153,82 @@ func (c *Checker) CheckForUpdate(ctx context.Context) (*UpdateInfo, error) {
 	}, nil
 }
 
-// fetchLatestRelease fetches the latest release information from GitHub
+// fetchLatestRelease fetches the latest release of the checker's channel
+// from GitHub
 func (c *Checker) fetchLatestRelease(ctx context.Context) (*Release, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubAPIURL, nil)
-	if err != nil {
-		return nil, err
+	if c.Channel != ChannelPrerelease {
+		var release Release
+		if err := c.fetchJSON(ctx, githubAPIURL, &release); err != nil {
+			return nil, err
+		}
+		return &release, nil
 	}
 
-	// Set headers
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "glide-cli-updater")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	var releases []Release
+	if err := c.fetchJSON(ctx, githubReleasesURL, &releases); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API returned %d: %s", resp.StatusCode, string(body))
+	latest := newestRelease(releases)
+	if latest == nil {
+		return nil, fmt.Errorf("no published releases found")
 	}
+	return latest, nil
+}
 
-	var release Release
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// newestRelease returns the published release with the highest version
+func newestRelease(releases []Release) *Release {
+	var (
+		newest        *Release
+		newestVersion *semver.Version
+	)
+	for i := range releases {
+		if releases[i].Draft {
+			continue
+		}
+		v, err := semver.NewVersion(releases[i].TagName)
+		if err != nil {
+			continue
+		}
+		if newestVersion == nil || v.GreaterThan(newestVersion) {
+			newest, newestVersion = &releases[i], v
+		}
 	}
+	return newest
+}
+
+// fetchJSON decodes a GitHub API response into v, retrying network errors
+// and server errors
+func (c *Checker) fetchJSON(ctx context.Context, url string, v interface{}) error {
+	return retry.Do(ctx, c.retryPolicy, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return retry.Permanent(err)
+		}
 
-	return &release, nil
+		// Set headers
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+		req.Header.Set("User-Agent", "glide-cli-updater")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			err := fmt.Errorf("GitHub API returned %d: %s", resp.StatusCode, string(body))
+			if resp.StatusCode < http.StatusInternalServerError {
+				return retry.Permanent(err)
+			}
+			return err
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return retry.Permanent(fmt.Errorf("failed to decode response: %w", err))
+		}
+		return nil
+	})
 }
 
 // getDownloadURL returns the appropriate download URL for the current platform
@@ -200,9 +271,9 @@ func FormatUpdateMessage(info *UpdateInfo) string {
 	msg.WriteString(fmt.Sprintf("Released: %s\n", info.PublishedAt.Format("2006-01-02")))
 
 	if info.DownloadURL != "" && !strings.Contains(info.DownloadURL, "github.com/glide-cli/glide/v3/releases") {
-		msg.WriteString(fmt.Sprintf("\nDownload: %s\n", info.DownloadURL))
+		msg.WriteString(fmt.Sprintf("\nDownload: %s\n", output.URL(info.DownloadURL)))
 	} else {
-		msg.WriteString(fmt.Sprintf("\nView release: %s\n", info.ReleaseURL))
+		msg.WriteString(fmt.Sprintf("\nView release: %s\n", output.URL(info.ReleaseURL)))
 	}
 
 	msg.WriteString("\nUpdate with: curl -fsSL https://raw.githubusercontent.com/ivannovak/glide/main/install.sh | bash")
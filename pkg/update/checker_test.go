@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/glide-cli/glide/v3/pkg/retry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -88,6 +89,32 @@ func TestCheckForUpdate_NewVersionAvailable(t *testing.T) {
 	assert.Equal(t, release.Body, info.ReleaseNotes)
 }
 
+func TestCheckForUpdate_PrereleaseChannel(t *testing.T) {
+	releases := []Release{
+		{TagName: "v2.2.0-rc.1", Prerelease: true, HTMLURL: "https://example.com/rc"},
+		{TagName: "v2.3.0-beta.1", Draft: true},
+		{TagName: "v2.1.0", HTMLURL: "https://example.com/stable"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(releases)
+	}))
+	defer server.Close()
+
+	oldURL := githubReleasesURL
+	githubReleasesURL = server.URL
+	defer func() { githubReleasesURL = oldURL }()
+
+	checker := NewChecker("v2.1.0")
+	checker.Channel = ChannelPrerelease
+
+	info, err := checker.CheckForUpdate(context.Background())
+	require.NoError(t, err)
+	assert.True(t, info.Available)
+	assert.Equal(t, "v2.2.0-rc.1", info.LatestVersion, "drafts are skipped")
+	assert.Equal(t, "https://example.com/rc", info.ReleaseURL)
+}
+
 func TestCheckForUpdate_SameVersion(t *testing.T) {
 	// Create mock server
 	release := Release{
@@ -149,7 +176,9 @@ func TestCheckForUpdate_OlderVersionAvailable(t *testing.T) {
 }
 
 func TestCheckForUpdate_APIError(t *testing.T) {
+	requests := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte("Internal Server Error"))
 	}))
@@ -161,12 +190,14 @@ func TestCheckForUpdate_APIError(t *testing.T) {
 	defer func() { githubAPIURL = oldURL }()
 
 	checker := NewChecker("v1.0.0")
+	checker.retryPolicy = retry.Policy{InitialInterval: time.Millisecond, MaxAttempts: 3}
 	ctx := context.Background()
 
 	info, err := checker.CheckForUpdate(ctx)
 	assert.Error(t, err)
 	assert.Nil(t, info)
 	assert.Contains(t, err.Error(), "GitHub API returned 500")
+	assert.Equal(t, 3, requests, "server errors are retried")
 }
 
 func TestCheckForUpdate_InvalidJSON(t *testing.T) {
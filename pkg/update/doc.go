@@ -45,6 +45,14 @@
 //	    DownloadURL    string
 //	}
 //
+// # Release Notes
+//
+// Release bodies are parsed into breaking changes, features, fixes, and
+// other changes, from their headings or conventional commit prefixes:
+//
+//	notes, err := checker.FetchReleaseNotes(ctx, "v3.2.0")
+//	output.Page(notes.Render())
+//
 // # Platform Detection
 //
 // Downloads are automatically selected for the current platform:
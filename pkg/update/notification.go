@@ -33,6 +33,9 @@ type NotificationConfig struct {
 
 	// LastNotifiedVersion is the version we last notified about (to avoid repeat notifications)
 	LastNotifiedVersion string `json:"last_notified_version,omitempty" yaml:"last_notified_version,omitempty"`
+
+	// Channel is the update channel notifications offer releases of
+	Channel string `json:"channel,omitempty" yaml:"channel,omitempty"`
 }
 
 // DefaultNotificationConfig returns the default notification configuration
@@ -194,6 +197,7 @@ func (nm *NotificationManager) CheckForUpdateAsync(ctx context.Context) <-chan *
 		defer cancel()
 
 		checker := NewChecker(nm.currentVersion)
+		checker.Channel = nm.config.Channel
 		info, err := checker.CheckForUpdate(checkCtx)
 
 		if err != nil {
@@ -11,12 +11,18 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"github.com/glide-cli/glide/v3/pkg/artifacts"
+	"github.com/glide-cli/glide/v3/pkg/ratelimit"
 )
 
 // Updater handles self-update functionality
 type Updater struct {
 	checker    *Checker
 	httpClient *http.Client
+	// cache keeps downloaded releases, so updating another installation
+	// to the same release does not download it again
+	cache *artifacts.Cache
 }
 
 // NewUpdater creates a new updater
@@ -24,8 +30,10 @@ func NewUpdater(currentVersion string) *Updater {
 	return &Updater{
 		checker: NewChecker(currentVersion),
 		httpClient: &http.Client{
-			Timeout: 0, // No timeout for downloads
+			Timeout:   0, // No timeout for downloads
+			Transport: ratelimit.Default.Transport(nil),
 		},
+		cache: artifacts.Default(),
 	}
 }
 
@@ -54,7 +62,9 @@ func (u *Updater) SelfUpdate(ctx context.Context) error {
 	}
 
 	// Download new binary
-	tempFile, err := u.downloadBinary(ctx, info.DownloadURL)
+	tempFile, err := u.cache.Download(info.DownloadURL, func() (string, error) {
+		return u.downloadBinary(ctx, info.DownloadURL)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to download update: %w", err)
 	}
@@ -76,48 +86,27 @@ func (u *Updater) SelfUpdate(ctx context.Context) error {
 	return nil
 }
 
-// downloadBinary downloads the new binary to a temporary file
+// downloadBinary downloads the new binary to a temporary file. An
+// interrupted download is kept in the artifact cache and resumed by the
+// next call; see artifacts.Cache.Fetch.
 func (u *Updater) downloadBinary(ctx context.Context, url string) (string, error) {
 	// Skip if URL is a GitHub release page (not a direct download)
 	if strings.Contains(url, "github.com") && strings.Contains(url, "/releases/") && !strings.Contains(url, "/download/") {
 		return "", fmt.Errorf("direct download not available for this platform")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	tempFile, err := u.cache.Fetch(ctx, u.httpClient, url)
 	if err != nil {
 		return "", err
 	}
 
-	resp, err := u.httpClient.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("download failed with status %d", resp.StatusCode)
-	}
-
-	// Create temporary file
-	tempFile, err := os.CreateTemp("", "glide-update-*")
-	if err != nil {
-		return "", err
-	}
-	defer tempFile.Close()
-
-	// Copy download to temp file
-	if _, err := io.Copy(tempFile, resp.Body); err != nil {
-		os.Remove(tempFile.Name())
-		return "", err
-	}
-
 	// Make executable
-	if err := os.Chmod(tempFile.Name(), 0755); err != nil {
-		os.Remove(tempFile.Name())
+	if err := os.Chmod(tempFile, 0755); err != nil {
+		os.Remove(tempFile)
 		return "", err
 	}
 
-	return tempFile.Name(), nil
+	return tempFile, nil
 }
 
 // verifyChecksum downloads and verifies the SHA256 checksum
@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/glide-cli/glide/v3/pkg/artifacts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -72,6 +73,7 @@ func TestDownloadBinary_Success(t *testing.T) {
 		w.Write(testContent)
 	}))
 	defer server.Close()
+	t.Setenv(artifacts.DirEnv, t.TempDir())
 
 	updater := NewUpdater("v1.0.0")
 	ctx := context.Background()
@@ -114,6 +116,7 @@ func TestDownloadBinary_HTTPError(t *testing.T) {
 		w.WriteHeader(http.StatusNotFound)
 	}))
 	defer server.Close()
+	t.Setenv(artifacts.DirEnv, t.TempDir())
 
 	updater := NewUpdater("v1.0.0")
 	ctx := context.Background()
@@ -7,6 +7,7 @@ import (
 
 	"github.com/glide-cli/glide/v3/internal/config"
 	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/glide-cli/glide/v3/pkg/performance"
 )
 
 // BenchmarkConfigLoad benchmarks configuration loading
@@ -130,6 +131,8 @@ commands:
 	for i := 0; i < b.N; i++ {
 		_, _ = config.LoadAndMergeConfigs(configs)
 	}
+
+	performance.CheckBenchmark(b, "config_merge_multiple")
 }
 
 // BenchmarkConfigMergingLarge benchmarks merging large configs
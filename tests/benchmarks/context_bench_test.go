@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/glide-cli/glide/v3/internal/context"
+	"github.com/glide-cli/glide/v3/pkg/performance"
 )
 
 // BenchmarkContextDetection benchmarks basic context detection
@@ -34,6 +35,8 @@ func BenchmarkContextDetection(b *testing.B) {
 		}
 		_, _ = detector.Detect()
 	}
+
+	performance.CheckBenchmark(b, "context_detection")
 }
 
 // BenchmarkContextDetectionFast benchmarks fast context detection
@@ -0,0 +1,21 @@
+package benchmarks_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/pkg/performance"
+)
+
+// TestMain writes the budget checks to GLIDE_BENCH_REPORT for CI
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if err := performance.WriteBenchmarkReport(); err != nil {
+		fmt.Fprintf(os.Stderr, "writing benchmark report: %v\n", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
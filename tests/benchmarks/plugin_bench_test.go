@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/glide-cli/glide/v3/pkg/performance"
 	"github.com/glide-cli/glide/v3/pkg/plugin/sdk"
 )
 
@@ -67,6 +68,8 @@ func BenchmarkPluginDiscovery(b *testing.B) {
 		})
 		_ = manager.DiscoverPlugins()
 	}
+
+	performance.CheckBenchmark(b, "plugin_discovery")
 }
 
 // BenchmarkPluginDiscoveryEmpty benchmarks lazy discovery in empty directory
@@ -0,0 +1,72 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/glide-cli/glide/v3/internal/chaos"
+	"github.com/glide-cli/glide/v3/pkg/branding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInjectedFailures runs glide with failures injected through
+// GLIDE_CHAOS and checks that it reports them
+func TestInjectedFailures(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	glideBinary := buildGlideBinary(t)
+
+	run := func(dir, faults string, args ...string) ([]byte, error) {
+		cmd := exec.Command(glideBinary, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "HOME="+dir, chaos.Env+"="+faults, "GLIDE_NO_UPDATE_CHECK=1")
+		return cmd.CombinedOutput()
+	}
+
+	t.Run("unknown_fault", func(t *testing.T) {
+		out, err := run(t.TempDir(), "disk-full", "version")
+		require.Error(t, err)
+		assert.Contains(t, string(out), `unknown fault "disk-full"`)
+	})
+
+	t.Run("config_corrupt", func(t *testing.T) {
+		home := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(home, branding.ConfigFileName), []byte("defaults: {}\n"), 0644))
+
+		_, err := run(home, "", "version")
+		require.NoError(t, err, "the config is valid without the fault")
+
+		out, err := run(home, "config-corrupt", "version")
+		require.Error(t, err)
+		assert.Contains(t, string(out), "failed to parse config file")
+	})
+
+	t.Run("docker_down", func(t *testing.T) {
+		home := t.TempDir()
+		reportPath := filepath.Join(home, "report.json")
+
+		_, err := run(home, "docker-down", "--report-file", reportPath, "version")
+		require.NoError(t, err, "commands without Docker still work")
+
+		data, err := os.ReadFile(reportPath)
+		require.NoError(t, err)
+		var report struct {
+			ExitCode int `json:"exit_code"`
+		}
+		require.NoError(t, json.Unmarshal(data, &report))
+		assert.Equal(t, 0, report.ExitCode)
+
+		project := filepath.Join(home, "project")
+		require.NoError(t, os.MkdirAll(filepath.Join(project, ".git"), 0755))
+		out, err := run(project, "docker-down", "context")
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "Docker Running: false")
+		assert.Contains(t, string(out), "Injecting failures for testing")
+	})
+}
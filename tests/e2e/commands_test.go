@@ -11,6 +11,13 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// TestMain trusts every fixture project so their .glide.yml commands run
+// without a trust prompt
+func TestMain(m *testing.M) {
+	os.Setenv("GLIDE_TRUST_ALL", "1")
+	os.Exit(m.Run())
+}
+
 // TestCommandExecution tests E2E execution of glide commands
 func TestCommandExecution(t *testing.T) {
 	// Build glide binary for testing